@@ -0,0 +1,166 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfocardinalityprocessor"
+	"github.com/telemetryflow/telemetryflow-collector/internal/version"
+)
+
+// newAnalyzeCommand returns the "analyze" command group.
+func newAnalyzeCommand() *cobra.Command {
+	analyzeCmd := &cobra.Command{
+		Use:   "analyze",
+		Short: "Analyze the telemetry passing through a running collector",
+	}
+	analyzeCmd.AddCommand(newAnalyzeCardinalityCommand())
+	return analyzeCmd
+}
+
+// newAnalyzeCardinalityCommand returns "analyze cardinality", which runs a
+// sampling session of a tfocardinality processor and prints its report.
+func newAnalyzeCardinalityCommand() *cobra.Command {
+	var (
+		endpoint string
+		duration time.Duration
+		top      int
+		asJSON   bool
+	)
+	cmd := &cobra.Command{
+		Use:   "cardinality",
+		Short: "Report the metrics cardinality of live traffic",
+		Long: fmt.Sprintf(`Report the metrics cardinality of live traffic.
+
+Starts a sampling session on the admin API of a tfocardinality processor in
+a running collector, waits for it to end and prints the top metric names by
+estimated unique series and the top label keys by estimated unique values.
+Interrupting the command ends the session early and prints the report so
+far. Estimates have a standard error of about 2%%.
+
+Usage Examples:
+  %s analyze cardinality --duration 5m
+  %s analyze cardinality --endpoint collector-01:55695 --top 50 --json`,
+			version.ProductShortName,
+			version.ProductShortName,
+		),
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if top <= 0 {
+				return errors.New("--top must be positive")
+			}
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			report, err := analyzeCardinality(ctx, endpoint, duration, top, cmd.ErrOrStderr())
+			if err != nil {
+				return err
+			}
+			if asJSON {
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				return enc.Encode(report)
+			}
+			report.Print(cmd.OutOrStdout())
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&endpoint, "endpoint", tfocardinalityprocessor.DefaultEndpoint, "Admin API of the tfocardinality processor")
+	cmd.Flags().DurationVar(&duration, "duration", 5*time.Minute, "Duration of the sampling session")
+	cmd.Flags().IntVar(&top, "top", 20, "Number of metric names and label keys to report")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Print the report as JSON")
+	return cmd
+}
+
+// analyzeCardinality runs a sampling session of duration on the admin API
+// at endpoint and returns its report. Cancelling ctx ends the session early.
+func analyzeCardinality(ctx context.Context, endpoint string, duration time.Duration, top int, progress io.Writer) (*tfocardinalityprocessor.Report, error) {
+	url := strings.TrimSuffix(endpoint, "/")
+	if !strings.Contains(url, "://") {
+		url = "http://" + url
+	}
+	url += "/cardinality"
+	client := &http.Client{Timeout: 30 * time.Second}
+
+	body, _ := json.Marshal(map[string]string{"duration": duration.String()})
+	var started tfocardinalityprocessor.Report
+	if err := adminRequest(context.Background(), client, http.MethodPost, url, bytes.NewReader(body), &started); err != nil {
+		return nil, err
+	}
+	_, _ = fmt.Fprintf(progress, "Sampling metrics until %s (Ctrl-C to stop early)...\n", started.EndsAt.Local().Format(time.TimeOnly))
+
+	// The session ends on its own at EndsAt; the extra second makes sure
+	// the report fetched afterwards is the completed one.
+	timer := time.NewTimer(time.Until(started.EndsAt) + time.Second)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+	case <-ctx.Done():
+		var report tfocardinalityprocessor.Report
+		if err := adminRequest(context.Background(), client, http.MethodDelete, url, nil, &report); err != nil {
+			return nil, err
+		}
+	}
+
+	var report tfocardinalityprocessor.Report
+	if err := adminRequest(context.Background(), client, http.MethodGet, url+"?top="+strconv.Itoa(top), nil, &report); err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// adminRequest sends a request to the admin API and decodes the JSON
+// response into v.
+func adminRequest(ctx context.Context, client *http.Client, method, url string, body io.Reader, v any) error {
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Error string `json:"error"`
+		}
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		if json.Unmarshal(msg, &apiErr) == nil && apiErr.Error != "" {
+			msg = []byte(apiErr.Error)
+		}
+		return errors.New(method + " " + url + ": " + resp.Status + ": " + strings.TrimSpace(string(msg)))
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
@@ -0,0 +1,100 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/telemetryflow/telemetryflow-collector/internal/version"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/authcheck"
+)
+
+// newAuthCommand returns the "auth" command group.
+func newAuthCommand() *cobra.Command {
+	authCmd := &cobra.Command{
+		Use:   "auth",
+		Short: "Manage TelemetryFlow API credentials",
+	}
+	authCmd.AddCommand(newAuthCheckCommand())
+	return authCmd
+}
+
+// newAuthCheckCommand returns "auth check", which validates the credentials
+// of a tfoauth extension without starting the collector.
+func newAuthCheckCommand() *cobra.Command {
+	var (
+		configs    []string
+		profile    string
+		extension  string
+		testExport bool
+		timeout    time.Duration
+	)
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Validate the API credentials of a tfoauth extension",
+		Long: fmt.Sprintf(`Validate the API credentials of a tfoauth extension.
+
+Loads the configuration, calls the extension's validation_endpoint and prints
+pass/fail with the latency and the authenticated principal. With
+--test-export, an empty export request is also sent through every tfo
+exporter that uses the extension. Exits with status 1 when a check fails.
+
+Usage Examples:
+  %s auth check --config configs/tfo-collector.yaml
+  %s auth check -c config.yaml --extension tfoauth/prod --test-export
+  %s auth check -c config.yaml --profile prod`,
+			version.ProductShortName,
+			version.ProductShortName,
+			version.ProductShortName,
+		),
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			opts := authcheck.Options{
+				ConfigURIs: configs,
+				Profile:    profile,
+				TestExport: testExport,
+				Timeout:    timeout,
+			}
+			if extension != "" {
+				if err := opts.Extension.UnmarshalText([]byte(extension)); err != nil {
+					return fmt.Errorf("invalid --extension: %w", err)
+				}
+			}
+
+			report, err := authcheck.Run(context.Background(), opts)
+			if err != nil {
+				return err
+			}
+			report.Print(cmd.OutOrStdout())
+			if !report.Passed() {
+				os.Exit(1)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringSliceVarP(&configs, "config", "c", []string{}, "Locations to the config file(s)")
+	cmd.Flags().StringVarP(&profile, "profile", "p", "", "Config profile merged over the base configuration (default: $TELEMETRYFLOW_PROFILE)")
+	cmd.Flags().StringVar(&extension, "extension", "", "tfoauth extension to check (default: the only one configured)")
+	cmd.Flags().BoolVar(&testExport, "test-export", false, "Also send an empty export request through tfo exporters using the extension")
+	cmd.Flags().DurationVar(&timeout, "timeout", authcheck.DefaultTimeout, "Timeout of each request")
+	return cmd
+}
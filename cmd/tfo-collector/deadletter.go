@@ -0,0 +1,193 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"slices"
+	"syscall"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/telemetryflow/telemetryflow-collector/internal/version"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/deadletter"
+)
+
+// newDeadLetterCommand returns the "deadletter" command group.
+func newDeadLetterCommand() *cobra.Command {
+	deadLetterCmd := &cobra.Command{
+		Use:   "deadletter",
+		Short: "Inspect and replay batches in a dead letter spool",
+	}
+	deadLetterCmd.AddCommand(newDeadLetterListCommand())
+	deadLetterCmd.AddCommand(newDeadLetterReplayCommand())
+	return deadLetterCmd
+}
+
+// newDeadLetterListCommand returns "deadletter list", which prints the
+// batches of a spool directory.
+func newDeadLetterListCommand() *cobra.Command {
+	var (
+		dir    string
+		asJSON bool
+	)
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List the batches in a dead letter spool",
+		Long: fmt.Sprintf(`List the batches in a dead letter spool.
+
+Prints the batches that exporters with a dead_letter directory gave up on
+after exhausting their retries, oldest first: when each was written, its
+signal, the exporter, the number of records and the last error.
+
+Usage Examples:
+  %s deadletter list --dir /var/lib/tfo-collector/dead-letter
+  %s deadletter list --dir /var/lib/tfo-collector/dead-letter --json`,
+			version.ProductShortName,
+			version.ProductShortName,
+		),
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			entries, err := deadletter.List(dir)
+			if err != nil {
+				return err
+			}
+			if asJSON {
+				enc := json.NewEncoder(cmd.OutOrStdout())
+				enc.SetIndent("", "  ")
+				return enc.Encode(entries)
+			}
+			printDeadLetters(cmd.OutOrStdout(), entries)
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&dir, "dir", "", "Dead letter directory of the exporters")
+	cmd.Flags().BoolVar(&asJSON, "json", false, "Print the batches as JSON")
+	_ = cmd.MarkFlagRequired("dir")
+	return cmd
+}
+
+// printDeadLetters prints entries as a table.
+func printDeadLetters(w io.Writer, entries []deadletter.Entry) {
+	if len(entries) == 0 {
+		_, _ = fmt.Fprintln(w, "No batches in the dead letter spool")
+		return
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "TIME\tSIGNAL\tEXPORTER\tRECORDS\tERROR")
+	var records int
+	for _, e := range entries {
+		records += e.Records
+		_, _ = fmt.Fprintf(tw, "%s\t%s\t%s\t%d\t%s\n",
+			e.Time.Local().Format(time.DateTime), e.Signal, e.Exporter, e.Records, e.Error)
+	}
+	_ = tw.Flush()
+	_, _ = fmt.Fprintf(w, "\n%d batches, %d records\n", len(entries), records)
+}
+
+// newDeadLetterReplayCommand returns "deadletter replay", which sends the
+// batches of a spool directory to an OTLP/HTTP receiver.
+func newDeadLetterReplayCommand() *cobra.Command {
+	var (
+		dir      string
+		endpoint string
+		signals  []string
+		keep     bool
+		timeout  time.Duration
+	)
+	cmd := &cobra.Command{
+		Use:   "replay",
+		Short: "Send the batches in a dead letter spool to an OTLP/HTTP receiver",
+		Long: fmt.Sprintf(`Send the batches in a dead letter spool to an OTLP/HTTP receiver.
+
+Sends each batch, oldest first, as an OTLP/HTTP protobuf request to the
+/v1/traces, /v1/metrics or /v1/logs path of the endpoint, e.g. the tfootlp
+receiver of a collector whose backend is reachable again, and removes it
+from the spool once accepted. Replaying stops at the first batch the
+receiver rejects, so that batches keep their order; run the command again
+once the cause is fixed. A batch in flight when the command is interrupted
+stays in the spool.
+
+Usage Examples:
+  %s deadletter replay --dir /var/lib/tfo-collector/dead-letter
+  %s deadletter replay --dir /var/lib/tfo-collector/dead-letter --endpoint collector-01:4318 --signal logs --keep`,
+			version.ProductShortName,
+			version.ProductShortName,
+		),
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+			defer stop()
+			client := &http.Client{Timeout: timeout}
+			return replayDeadLetters(ctx, client, dir, endpoint, signals, keep, cmd.OutOrStdout())
+		},
+	}
+	cmd.Flags().StringVar(&dir, "dir", "", "Dead letter directory of the exporters")
+	cmd.Flags().StringVar(&endpoint, "endpoint", "localhost:4318", "OTLP/HTTP receiver to send the batches to")
+	cmd.Flags().StringSliceVar(&signals, "signal", nil, "Only replay batches of these signals (traces, metrics, logs)")
+	cmd.Flags().BoolVar(&keep, "keep", false, "Keep replayed batches in the spool")
+	cmd.Flags().DurationVar(&timeout, "timeout", 30*time.Second, "Timeout of each request")
+	_ = cmd.MarkFlagRequired("dir")
+	return cmd
+}
+
+// replayDeadLetters sends the batches in dir of the given signals, or of
+// all signals when empty, to endpoint and removes them unless keep is set.
+func replayDeadLetters(ctx context.Context, client *http.Client, dir, endpoint string, signals []string, keep bool, out io.Writer) error {
+	for _, s := range signals {
+		if s != "traces" && s != "metrics" && s != "logs" {
+			return fmt.Errorf("--signal: unknown signal %q", s)
+		}
+	}
+	entries, err := deadletter.List(dir)
+	if err != nil {
+		return err
+	}
+	var batches, records int
+	for _, e := range entries {
+		if len(signals) > 0 && !slices.Contains(signals, e.Signal) {
+			continue
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		if _, err := deadletter.Replay(ctx, client, endpoint, e.Path); err != nil {
+			_, _ = fmt.Fprintf(out, "Replayed %d batches, %d records\n", batches, records)
+			return fmt.Errorf("failed to replay %s: %w", e.Path, err)
+		}
+		if !keep {
+			if err := os.Remove(e.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+		}
+		batches++
+		records += e.Records
+	}
+	_, _ = fmt.Fprintf(out, "Replayed %d batches, %d records\n", batches, records)
+	return ctx.Err()
+}
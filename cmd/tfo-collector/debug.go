@@ -0,0 +1,180 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/telemetryflow/telemetryflow-collector/internal/version"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/profileconf"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/registry"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/supportbundle"
+)
+
+// newDebugCommand returns the "debug" command group.
+func newDebugCommand() *cobra.Command {
+	debugCmd := &cobra.Command{
+		Use:   "debug",
+		Short: "Troubleshoot the collector",
+	}
+	debugCmd.AddCommand(newDebugBundleCommand())
+	return debugCmd
+}
+
+// newDebugBundleCommand returns "debug bundle", which writes a support
+// bundle for a configuration or downloads one from a running collector.
+func newDebugBundleCommand() *cobra.Command {
+	var (
+		configs  []string
+		profile  string
+		logFiles []string
+		endpoint string
+		output   string
+		timeout  time.Duration
+	)
+	cmd := &cobra.Command{
+		Use:   "bundle",
+		Short: "Gather configuration, logs, metrics and profiles into a support bundle",
+		Long: fmt.Sprintf(`Gather configuration, logs, metrics and profiles into a support bundle.
+
+Writes a gzip-compressed tar archive to attach to support tickets. With
+--config, the bundle holds the effective configuration with secrets
+redacted, the version and build information, the end of the configured log
+files, the file listings of queue, storage and capture directories, and,
+when the collector is running, a scrape of its self-metrics and the
+goroutine and heap profiles of its pprof extension. With --endpoint, the
+bundle is downloaded from the admin API of a tfosupport extension, which
+profiles the collector without a pprof extension. Items that cannot be
+collected are listed in errors.txt in the bundle.
+
+Usage Examples:
+  %s debug bundle --config configs/tfo-collector.yaml
+  %s debug bundle -c config.yaml --log /var/log/tfo-collector.log -o bundle.tar.gz
+  %s debug bundle --endpoint localhost:55694`,
+			version.ProductShortName,
+			version.ProductShortName,
+			version.ProductShortName,
+		),
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			ctx := context.Background()
+			if output == "" {
+				output = fmt.Sprintf("%s-support-%s.tar.gz", version.ProductShortName, time.Now().UTC().Format("20060102T150405Z"))
+			}
+			f, err := os.Create(output)
+			if err != nil {
+				return err
+			}
+			if endpoint != "" {
+				err = downloadBundle(ctx, endpoint, timeout, f)
+			} else {
+				err = writeBundle(ctx, configs, profile, logFiles, timeout, f)
+			}
+			if closeErr := f.Close(); err == nil {
+				err = closeErr
+			}
+			if err != nil {
+				_ = os.Remove(output)
+				return err
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Support bundle written to %s\n", output)
+			return nil
+		},
+	}
+	cmd.Flags().StringSliceVarP(&configs, "config", "c", []string{}, "Locations to the config file(s)")
+	cmd.Flags().StringVarP(&profile, "profile", "p", "", "Config profile merged over the base configuration (default: $TELEMETRYFLOW_PROFILE)")
+	cmd.Flags().StringSliceVar(&logFiles, "log", []string{}, "Log files to bundle in addition to those of service::telemetry::logs")
+	cmd.Flags().StringVar(&endpoint, "endpoint", "", "Download the bundle from the admin API of a tfosupport extension")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Bundle file (default: "+version.ProductShortName+"-support-<time>.tar.gz)")
+	cmd.Flags().DurationVar(&timeout, "timeout", time.Minute, "Timeout of each request")
+	cmd.MarkFlagsMutuallyExclusive("config", "endpoint")
+	cmd.MarkFlagsMutuallyExclusive("log", "endpoint")
+	cmd.MarkFlagsOneRequired("config", "endpoint")
+	return cmd
+}
+
+// writeBundle writes the support bundle of a configuration to w.
+func writeBundle(ctx context.Context, uris []string, profile string, logFiles []string, timeout time.Duration, w io.Writer) error {
+	conf, err := loadBundleConfig(ctx, uris, profile)
+	if err != nil {
+		return err
+	}
+	opts := supportbundle.FromConfig(conf.ToStringMap())
+	for _, f := range logFiles {
+		if !slices.Contains(opts.LogFiles, f) {
+			opts.LogFiles = append(opts.LogFiles, f)
+		}
+	}
+	opts.BuildInfo = version.BuildInfo()
+	opts.Client = &http.Client{Timeout: timeout}
+	return supportbundle.Write(ctx, w, opts)
+}
+
+// loadBundleConfig resolves the configuration the way the collector does,
+// with the profile converter only, so that sections such as crash_guard
+// are kept.
+func loadBundleConfig(ctx context.Context, uris []string, profile string) (*confmap.Conf, error) {
+	set := registry.Builder{
+		ConfigURIs:         uris,
+		ConverterFactories: []confmap.ConverterFactory{profileconf.NewConverterFactory(profile)},
+	}.Settings()
+	resolver, err := confmap.NewResolver(set.ConfigProviderSettings.ResolverSettings)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resolver.Shutdown(ctx) }()
+	conf, err := resolver.Resolve(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	return conf, nil
+}
+
+// downloadBundle copies the bundle served by the tfosupport extension at
+// endpoint to w.
+func downloadBundle(ctx context.Context, endpoint string, timeout time.Duration, w io.Writer) error {
+	url := strings.TrimSuffix(endpoint, "/")
+	if !strings.Contains(url, "://") {
+		url = "http://" + url
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url+"/bundle", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := (&http.Client{Timeout: timeout}).Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return errors.New("GET " + req.URL.String() + ": " + resp.Status + ": " + strings.TrimSpace(string(body)))
+	}
+	_, err = io.Copy(w, resp.Body)
+	return err
+}
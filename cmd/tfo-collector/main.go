@@ -25,18 +25,16 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
-	"go.opentelemetry.io/collector/component"
-	"go.opentelemetry.io/collector/confmap"
-	"go.opentelemetry.io/collector/confmap/provider/envprovider"
-	"go.opentelemetry.io/collector/confmap/provider/fileprovider"
-	"go.opentelemetry.io/collector/confmap/provider/yamlprovider"
 	"go.opentelemetry.io/collector/otelcol"
-	"go.opentelemetry.io/collector/service/telemetry/otelconftelemetry"
 
+	"github.com/telemetryflow/telemetryflow-collector/components/extension/tfoopampextension"
 	"github.com/telemetryflow/telemetryflow-collector/internal/version"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/preflight"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/registry"
 )
 
 func main() {
@@ -57,6 +55,9 @@ Usage Examples:
   %s --config configs/tfo-collector.yaml
   %s -c configs/tfo-collector.yaml
 
+  # Start with the "prod" profile of the config merged over its base
+  %s --config config.yaml --profile prod
+
 TFO Custom Components:
   Receivers:
     tfootlp   - OTLP receiver with v1 and v2 endpoint support
@@ -68,6 +69,14 @@ TFO Custom Components:
     tfoauth     - TFO API key management
     tfoidentity - Collector identity and resource enrichment
 
+Commands:
+  analyze cardinality - Report metrics cardinality of live traffic (%s analyze cardinality --duration 5m)
+  auth check          - Validate TFO API credentials (%s auth check -c config.yaml)
+  deadletter replay   - Re-send batches the exporters gave up on (%s deadletter replay --dir DIR)
+  debug bundle        - Write a support bundle (%s debug bundle -c config.yaml)
+  preflight           - Check ports, disk space and file limits (%s preflight -c config.yaml)
+  tls bootstrap       - Create a local CA and mTLS certificates (%s tls bootstrap --client edge-01)
+
 Environment Variables:
   TELEMETRYFLOW_API_KEY_ID      - TFO API Key ID (tfk_xxx)
   TELEMETRYFLOW_API_KEY_SECRET  - TFO API Key Secret (tfs_xxx)
@@ -75,6 +84,7 @@ Environment Variables:
   TELEMETRYFLOW_COLLECTOR_ID    - Unique collector identifier
   TELEMETRYFLOW_COLLECTOR_NAME  - Human-readable collector name
   TELEMETRYFLOW_ENVIRONMENT     - Deployment environment
+  TELEMETRYFLOW_PROFILE         - Config profile when --profile is not set
 
 For more information, visit: %s`,
 			version.ProductName,
@@ -83,15 +93,31 @@ For more information, visit: %s`,
 			version.ProductShortName,
 			version.ProductShortName,
 			version.ProductShortName,
+			version.ProductShortName,
+			version.ProductShortName,
+			version.ProductShortName,
+			version.ProductShortName,
+			version.ProductShortName,
+			version.ProductShortName,
+			version.ProductShortName,
 			version.SupportURL,
 		),
 		Run: runCollector,
 	}
+	rootCmd.AddCommand(newAnalyzeCommand())
+	rootCmd.AddCommand(newAuthCommand())
+	rootCmd.AddCommand(newDeadLetterCommand())
+	rootCmd.AddCommand(newDebugCommand())
+	rootCmd.AddCommand(newPreflightCommand())
+	rootCmd.AddCommand(newTLSCommand())
 
 	// Add flags with short aliases using Viper
 	rootCmd.Flags().StringSliceP("config", "c", []string{}, "Locations to the config file(s)")
 	rootCmd.Flags().StringSliceP("set", "s", []string{}, "Set arbitrary component config property")
 	rootCmd.Flags().StringSliceP("feature-gates", "f", []string{}, "Comma-delimited list of feature gate identifiers")
+	rootCmd.Flags().StringP("profile", "p", "", "Config profile merged over the base configuration (default: $TELEMETRYFLOW_PROFILE)")
+	rootCmd.Flags().Bool("skip-preflight", false, "Start without checking ports, disk space and file limits")
+	rootCmd.Flags().Int("expected-connections", preflight.DefaultExpectedConnections, "Concurrent client connections the open file limit must cover")
 
 	// Bind flags to Viper
 	if err := viper.BindPFlags(rootCmd.Flags()); err != nil {
@@ -124,47 +150,36 @@ func runCollector(cmd *cobra.Command, args []string) {
 	// Show banner when starting the collector
 	fmt.Print(version.Banner())
 
-	info := component.BuildInfo{
-		Command:     version.ProductShortName,
-		Description: version.ProductDescription,
-		Version:     version.Version,
-	}
-
-	// Factories function that returns all component factories including telemetry
-	factoriesFunc := func() (otelcol.Factories, error) {
-		factories, err := components()
-		if err != nil {
-			return otelcol.Factories{}, err
-		}
-		factories.Telemetry = otelconftelemetry.NewFactory()
-		return factories, nil
-	}
-
-	set := otelcol.CollectorSettings{
-		BuildInfo: info,
-		Factories: factoriesFunc,
-		ConfigProviderSettings: otelcol.ConfigProviderSettings{
-			ResolverSettings: confmap.ResolverSettings{
-				ProviderFactories: []confmap.ProviderFactory{
-					fileprovider.NewFactory(),
-					yamlprovider.NewFactory(),
-					envprovider.NewFactory(),
-				},
-			},
-		},
-	}
-
 	// Get config files from Viper
 	configFiles := viper.GetStringSlice("config")
 	if len(configFiles) == 0 {
 		log.Fatal("at least one config file must be provided")
 	}
 
+	// Settings for the built-in distribution; --config is passed through
+	// os.Args below so otelcol keeps handling its own flags. Configurations
+	// pushed through the tfoopamp extension are validated against the same
+	// factories before they are applied.
+	reg := registry.Default().Clone()
+	reg.ReplaceExtensions(tfoopampextension.NewFactoryWithValidator(
+		remoteConfigValidator(configFiles, viper.GetString("profile"))))
+	set := registry.Builder{Registry: reg, Profile: viper.GetString("profile")}.Settings()
+
+	// Catch busy ports, full disks and low file limits before binding.
+	if !viper.GetBool("skip-preflight") {
+		runPreflight(configFiles, viper.GetString("profile"), viper.GetInt("expected-connections"))
+	}
+
 	// Create OTEL collector command with config
 	otelCmd := otelcol.NewCommand(set)
 	// Pass config files to OTEL collector
 	os.Args = append([]string{os.Args[0]}, "--config")
 	os.Args = append(os.Args, configFiles...)
+	// Feature gates unlock upstream behaviour such as Remote Write 2.0 in
+	// the prometheusremotewrite exporter.
+	if gates := viper.GetStringSlice("feature-gates"); len(gates) > 0 {
+		os.Args = append(os.Args, "--feature-gates", strings.Join(gates, ","))
+	}
 
 	if err := otelCmd.Execute(); err != nil {
 		log.Fatal(err)
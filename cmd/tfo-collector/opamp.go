@@ -0,0 +1,71 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/extension/tfoopampextension"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/registry"
+)
+
+// remoteConfigValidator validates configurations pushed through the
+// tfoopamp extension: the configuration files are loaded with the candidate
+// in place of the file it replaces and checked like the validate command
+// does. The converters run as on a dry run, so that a rejected
+// configuration does not change the Go runtime settings or take over
+// listeners, and the crash guard section is applied to a guard of its own.
+func remoteConfigValidator(configFiles []string, profile string) tfoopampextension.ConfigValidator {
+	return func(ctx context.Context, path, candidate string) error {
+		uris := make([]string, len(configFiles))
+		replaced := false
+		for i, uri := range configFiles {
+			uris[i] = uri
+			if samePath(uri, path) {
+				uris[i] = candidate
+				replaced = true
+			}
+		}
+		if !replaced {
+			return fmt.Errorf("%s is not one of the collector configuration files", path)
+		}
+		return registry.Builder{
+			ConfigURIs: uris,
+			Profile:    profile,
+			DryRun:     true,
+		}.Validate(ctx)
+	}
+}
+
+// samePath reports whether the configuration location uri, e.g.
+// "file:config.yaml" or "config.yaml", is the file at path.
+func samePath(uri, path string) bool {
+	uri = strings.TrimPrefix(uri, "file:")
+	if strings.Contains(uri, ":") && filepath.VolumeName(uri) == "" {
+		return false
+	}
+	a, err := filepath.Abs(uri)
+	if err != nil {
+		return false
+	}
+	b, err := filepath.Abs(path)
+	if err != nil {
+		return false
+	}
+	return a == b
+}
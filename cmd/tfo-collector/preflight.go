@@ -0,0 +1,88 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/telemetryflow/telemetryflow-collector/internal/version"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/preflight"
+)
+
+// newPreflightCommand returns "preflight", which checks the environment of
+// a configuration without starting the collector.
+func newPreflightCommand() *cobra.Command {
+	var opts preflight.Options
+	cmd := &cobra.Command{
+		Use:   "preflight",
+		Short: "Check ports, disk space and file limits for a configuration",
+		Long: fmt.Sprintf(`Check ports, disk space and file limits for a configuration.
+
+Loads the configuration and checks that the listen endpoints of the
+receivers, extensions and prometheus exporters in use are free, that the
+directories of tforetention exporters have room to reach max_size, and that
+the open file limit covers the expected connections. The collector runs the
+same checks before starting unless --skip-preflight is set. Exits with
+status 1 when a check fails.
+
+Usage Examples:
+  %s preflight --config configs/tfo-collector.yaml
+  %s preflight -c config.yaml --expected-connections 5000`,
+			version.ProductShortName,
+			version.ProductShortName,
+		),
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			report, err := preflight.Run(context.Background(), opts)
+			if err != nil {
+				return err
+			}
+			report.Print(cmd.OutOrStdout())
+			if !report.Passed() {
+				os.Exit(1)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringSliceVarP(&opts.ConfigURIs, "config", "c", []string{}, "Locations to the config file(s)")
+	cmd.Flags().StringVarP(&opts.Profile, "profile", "p", "", "Config profile merged over the base configuration (default: $TELEMETRYFLOW_PROFILE)")
+	cmd.Flags().IntVar(&opts.ExpectedConnections, "expected-connections", preflight.DefaultExpectedConnections, "Concurrent client connections the open file limit must cover")
+	return cmd
+}
+
+// runPreflight runs the preflight checks before the collector starts and
+// exits when one fails. A configuration that does not load is left to the
+// collector, which reports the error in detail.
+func runPreflight(configFiles []string, profile string, expectedConnections int) {
+	report, err := preflight.Run(context.Background(), preflight.Options{
+		ConfigURIs:          configFiles,
+		Profile:             profile,
+		ExpectedConnections: expectedConnections,
+	})
+	if err != nil {
+		return
+	}
+	if !report.Passed() {
+		report.Print(os.Stderr)
+		fmt.Fprintln(os.Stderr, "Fix the failed checks or start with --skip-preflight.")
+		os.Exit(1)
+	}
+}
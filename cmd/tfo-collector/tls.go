@@ -0,0 +1,82 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/telemetryflow/telemetryflow-collector/internal/version"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/tlsbootstrap"
+)
+
+// newTLSCommand returns the "tls" command group.
+func newTLSCommand() *cobra.Command {
+	tlsCmd := &cobra.Command{
+		Use:   "tls",
+		Short: "Manage TLS certificates",
+	}
+	tlsCmd.AddCommand(newTLSBootstrapCommand())
+	return tlsCmd
+}
+
+// newTLSBootstrapCommand returns "tls bootstrap", which issues a local CA
+// with server and client certificates for mutual TLS.
+func newTLSBootstrapCommand() *cobra.Command {
+	var opts tlsbootstrap.Options
+	cmd := &cobra.Command{
+		Use:   "bootstrap",
+		Short: "Create a local CA with server and client certificates for mTLS",
+		Long: fmt.Sprintf(`Create a local CA with server and client certificates for mTLS.
+
+Writes ca.crt and ca.key, a server certificate (server.crt, server.key) for
+the --host names and addresses, and for each --client a bundle in
+clients/<name>/ (client.crt, client.key, ca.crt) to copy to the sender. An
+existing CA is reused, so running the command again with another --client
+adds a sender; existing certificates are kept unless --force is set. Prints
+the receiver and exporter TLS settings using the files.
+
+Meant for labs and for bootstrapping mTLS-only sites; use your PKI where
+one exists.
+
+Usage Examples:
+  %s tls bootstrap --host collector.lab.local --client edge-01 --client edge-02
+  %s tls bootstrap --dir ./certs --host 10.0.0.5 --validity 2160h
+  %s tls bootstrap --client edge-03`,
+			version.ProductShortName,
+			version.ProductShortName,
+			version.ProductShortName,
+		),
+		Args:          cobra.NoArgs,
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			report, err := tlsbootstrap.Run(opts)
+			if err != nil {
+				return err
+			}
+			report.Print(cmd.OutOrStdout())
+			return nil
+		},
+	}
+	cmd.Flags().StringVar(&opts.Dir, "dir", tlsbootstrap.DefaultDir, "Directory receiving the certificates and keys")
+	cmd.Flags().StringSliceVar(&opts.Hosts, "host", nil, "DNS name or IP address of the server certificate (default: this host name, localhost, 127.0.0.1, ::1)")
+	cmd.Flags().StringSliceVar(&opts.Clients, "client", nil, "Sender to issue a client certificate for")
+	cmd.Flags().DurationVar(&opts.Validity, "validity", tlsbootstrap.DefaultValidity, "Validity of new server and client certificates")
+	cmd.Flags().DurationVar(&opts.CAValidity, "ca-validity", tlsbootstrap.DefaultCAValidity, "Validity of a new CA")
+	cmd.Flags().BoolVar(&opts.Force, "force", false, "Reissue existing server and client certificates (the CA is kept)")
+	return cmd
+}
@@ -16,7 +16,11 @@ package tfoauthextension
 
 import (
 	"errors"
+	"fmt"
+	"maps"
+	"slices"
 	"strings"
+	"time"
 
 	"go.opentelemetry.io/collector/config/configopaque"
 )
@@ -29,23 +33,121 @@ type Config struct {
 	// APIKeySecret is the TelemetryFlow API Key Secret (format: tfs_xxx).
 	APIKeySecret configopaque.String `mapstructure:"api_key_secret"`
 
+	// SecondaryAPIKeyID is the API Key ID of an optional secondary key
+	// (format: tfk_xxx). When the TFO Platform rejects the primary key with
+	// HTTP 401, exporters switch to the secondary key, so a key is rotated
+	// by deploying the new key as secondary before revoking the old one.
+	SecondaryAPIKeyID configopaque.String `mapstructure:"secondary_api_key_id"`
+
+	// SecondaryAPIKeySecret is the API Key Secret of the secondary key
+	// (format: tfs_xxx).
+	SecondaryAPIKeySecret configopaque.String `mapstructure:"secondary_api_key_secret"`
+
 	// ValidationEndpoint is the optional endpoint for validating API keys.
-	// If set, the extension will validate credentials on startup.
+	// If set, the extension validates its credentials on startup and
+	// validates the credentials of requests to receivers referencing it.
 	ValidationEndpoint string `mapstructure:"validation_endpoint"`
 
 	// ValidateOnStart enables API key validation during extension startup.
 	// Default: false
 	ValidateOnStart bool `mapstructure:"validate_on_start"`
+
+	// ProfileAttribute is the resource attribute whose value selects a
+	// credential profile on export. Telemetry without the attribute, or
+	// with a value no profile claims, is exported under the default key.
+	ProfileAttribute string `mapstructure:"profile_attribute"`
+
+	// Profiles are additional API keys by profile name.
+	Profiles map[string]Profile `mapstructure:"profiles"`
+
+	// Cache configures the cache of the credentials validated for
+	// receivers.
+	Cache CacheConfig `mapstructure:"cache"`
+}
+
+// CacheConfig configures the cache of validated credentials.
+type CacheConfig struct {
+	// TTL is how long an accepted credential is trusted without asking
+	// the validation endpoint again. Zero validates every request.
+	// Default: 5m
+	TTL time.Duration `mapstructure:"ttl"`
+
+	// NegativeTTL is how long a rejected credential is refused without
+	// asking the validation endpoint again. Zero does not cache rejections.
+	// Default: 1m
+	NegativeTTL time.Duration `mapstructure:"negative_ttl"`
+
+	// RevalidateInterval is how often accepted credentials used since
+	// their last validation are validated again in the background, so a
+	// revoked key stops working within the interval and requests with an
+	// active key do not wait for the endpoint. Zero disables background
+	// revalidation.
+	// Default: 1m
+	RevalidateInterval time.Duration `mapstructure:"revalidate_interval"`
+
+	// MaxEntries bounds the cached credentials; the least recently used
+	// one is dropped first. Zero disables the cache.
+	// Default: 10000
+	MaxEntries int `mapstructure:"max_entries"`
+}
+
+// Validate checks the cache configuration for errors.
+func (cfg *CacheConfig) Validate() error {
+	if cfg.TTL < 0 {
+		return errors.New("cache.ttl must not be negative")
+	}
+	if cfg.NegativeTTL < 0 {
+		return errors.New("cache.negative_ttl must not be negative")
+	}
+	if cfg.RevalidateInterval < 0 {
+		return errors.New("cache.revalidate_interval must not be negative")
+	}
+	if cfg.MaxEntries < 0 {
+		return errors.New("cache.max_entries must not be negative")
+	}
+	return nil
+}
+
+// defaultProfile names the top-level API key in stats; profiles cannot use
+// it.
+const defaultProfile = "default"
+
+// Profile is an API key selected by values of the profile attribute.
+type Profile struct {
+	// APIKeyID is the API Key ID of the profile (format: tfk_xxx).
+	APIKeyID configopaque.String `mapstructure:"api_key_id"`
+
+	// APIKeySecret is the API Key Secret of the profile (format: tfs_xxx).
+	APIKeySecret configopaque.String `mapstructure:"api_key_secret"`
+
+	// Values of the profile attribute that select the profile.
+	// Default: the profile name
+	Values []string `mapstructure:"values"`
+}
+
+// values returns the attribute values that select the profile name.
+func (p Profile) values(name string) []string {
+	if len(p.Values) == 0 {
+		return []string{name}
+	}
+	return p.Values
 }
 
 // Validate checks the configuration for errors.
 // If API keys are not set (empty), the extension will start in passthrough mode
 // where it doesn't inject authentication headers.
 func (cfg *Config) Validate() error {
+	if err := cfg.Cache.Validate(); err != nil {
+		return err
+	}
+
 	// If both API key ID and secret are empty, allow passthrough mode
 	// This enables the collector to start without TFO authentication configured
 	if cfg.APIKeyID == "" && cfg.APIKeySecret == "" {
-		return nil
+		if cfg.SecondaryAPIKeyID != "" || cfg.SecondaryAPIKeySecret != "" {
+			return errors.New("secondary_api_key_id requires api_key_id")
+		}
+		return cfg.validateProfiles()
 	}
 
 	// If one is set, both must be set
@@ -67,9 +169,61 @@ func (cfg *Config) Validate() error {
 		return errors.New("api_key_secret must start with 'tfs_' prefix")
 	}
 
+	if err := cfg.validateSecondary(); err != nil {
+		return err
+	}
+
 	if cfg.ValidateOnStart && cfg.ValidationEndpoint == "" {
 		return errors.New("validation_endpoint is required when validate_on_start is true")
 	}
 
+	return cfg.validateProfiles()
+}
+
+// validateSecondary checks the secondary API key, if any.
+func (cfg *Config) validateSecondary() error {
+	if cfg.SecondaryAPIKeyID == "" && cfg.SecondaryAPIKeySecret == "" {
+		return nil
+	}
+	if !strings.HasPrefix(string(cfg.SecondaryAPIKeyID), "tfk_") {
+		return errors.New("secondary_api_key_id must start with 'tfk_' prefix")
+	}
+	if !strings.HasPrefix(string(cfg.SecondaryAPIKeySecret), "tfs_") {
+		return errors.New("secondary_api_key_secret must start with 'tfs_' prefix")
+	}
+	if cfg.SecondaryAPIKeyID == cfg.APIKeyID {
+		return errors.New("secondary_api_key_id must differ from api_key_id")
+	}
+	return nil
+}
+
+// validateProfiles checks the credential profiles and that every attribute
+// value selects a single profile.
+func (cfg *Config) validateProfiles() error {
+	if len(cfg.Profiles) == 0 {
+		return nil
+	}
+	if cfg.ProfileAttribute == "" {
+		return errors.New("profile_attribute is required when profiles are set")
+	}
+	owners := make(map[string]string)
+	for _, name := range slices.Sorted(maps.Keys(cfg.Profiles)) {
+		p := cfg.Profiles[name]
+		if name == defaultProfile {
+			return fmt.Errorf("profiles: %q is reserved for the top-level API key", defaultProfile)
+		}
+		if !strings.HasPrefix(string(p.APIKeyID), "tfk_") {
+			return fmt.Errorf("profiles.%s.api_key_id must start with 'tfk_' prefix", name)
+		}
+		if !strings.HasPrefix(string(p.APIKeySecret), "tfs_") {
+			return fmt.Errorf("profiles.%s.api_key_secret must start with 'tfs_' prefix", name)
+		}
+		for _, v := range p.values(name) {
+			if other, ok := owners[v]; ok {
+				return fmt.Errorf("profiles: value %q selects both %q and %q", v, other, name)
+			}
+			owners[v] = name
+		}
+	}
 	return nil
 }
@@ -0,0 +1,265 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoauthextension
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// credentialKey identifies a credential without holding its secret.
+type credentialKey [sha256.Size]byte
+
+func keyOf(keyID, keySecret string) credentialKey {
+	h := sha256.New()
+	h.Write([]byte(keyID))
+	h.Write([]byte{0})
+	h.Write([]byte(keySecret))
+	var k credentialKey
+	h.Sum(k[:0])
+	return k
+}
+
+// credential is the cached validation of an API key. The fields after
+// ready are guarded by credentialCache.mu.
+type credential struct {
+	// The key is kept for the background revalidation.
+	keyID, keySecret string
+
+	// ready is closed once the first validation finished.
+	ready chan struct{}
+
+	valid   bool
+	err     error
+	checked time.Time
+	used    time.Time
+}
+
+// done reports whether the first validation of c finished.
+func (c *credential) done() bool {
+	select {
+	case <-c.ready:
+		return true
+	default:
+		return false
+	}
+}
+
+// credentialCache validates API keys with validate and caches the
+// outcome. Concurrent requests with the same key share one validation.
+type credentialCache struct {
+	cfg      CacheConfig
+	validate func(ctx context.Context, keyID, keySecret string) error
+	logger   *zap.Logger
+
+	mu      sync.Mutex
+	entries map[credentialKey]*credential
+
+	// ctx is cancelled on shutdown to stop the background revalidation.
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// newCredentialCache creates a cache validating keys with validate.
+func newCredentialCache(cfg CacheConfig, validate func(ctx context.Context, keyID, keySecret string) error, logger *zap.Logger) *credentialCache {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &credentialCache{
+		cfg:      cfg,
+		validate: validate,
+		logger:   logger,
+		entries:  make(map[credentialKey]*credential),
+		ctx:      ctx,
+		cancel:   cancel,
+	}
+}
+
+// check reports whether the API key is valid. An error means it could not
+// be validated.
+func (c *credentialCache) check(ctx context.Context, keyID, keySecret string) (bool, error) {
+	k := keyOf(keyID, keySecret)
+
+	c.mu.Lock()
+	e, ok := c.entries[k]
+	if !ok || (e.done() && !c.fresh(e, time.Now())) {
+		e = &credential{keyID: keyID, keySecret: keySecret, ready: make(chan struct{})}
+		c.insert(k, e)
+		// The validation outlives a cancelled request, as other requests
+		// with the same key may wait for it.
+		go c.resolve(k, e)
+	}
+	c.mu.Unlock()
+
+	select {
+	case <-e.ready:
+	case <-ctx.Done():
+		return false, ctx.Err()
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e.used = time.Now()
+	return e.valid, e.err
+}
+
+// resolve runs the first validation of e and drops it from the cache when
+// the outcome is not cached.
+func (c *credentialCache) resolve(k credentialKey, e *credential) {
+	err := c.validate(c.ctx, e.keyID, e.keySecret)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e.checked = time.Now()
+	switch {
+	case err == nil:
+		e.valid = true
+	case errors.Is(err, ErrInvalidCredentials):
+	default:
+		e.err = err
+	}
+	if e.err != nil || !c.cacheable(e) {
+		if c.entries[k] == e {
+			delete(c.entries, k)
+		}
+	}
+	close(e.ready)
+}
+
+// cacheable reports whether the outcome of e is kept. Callers hold mu.
+func (c *credentialCache) cacheable(e *credential) bool {
+	if c.cfg.MaxEntries == 0 {
+		return false
+	}
+	if e.valid {
+		return c.cfg.TTL > 0
+	}
+	return c.cfg.NegativeTTL > 0
+}
+
+// fresh reports whether the finished validation e still applies at now.
+// Callers hold mu.
+func (c *credentialCache) fresh(e *credential, now time.Time) bool {
+	ttl := c.cfg.NegativeTTL
+	if e.valid {
+		ttl = c.cfg.TTL
+	}
+	return now.Sub(e.checked) < ttl
+}
+
+// insert adds e, dropping stale entries and then the least recently used
+// ones while the cache is full. Callers hold mu.
+func (c *credentialCache) insert(k credentialKey, e *credential) {
+	if _, ok := c.entries[k]; !ok && c.cfg.MaxEntries > 0 && len(c.entries) >= c.cfg.MaxEntries {
+		now := time.Now()
+		for key, old := range c.entries {
+			if old.done() && !c.fresh(old, now) {
+				delete(c.entries, key)
+			}
+		}
+		for len(c.entries) >= c.cfg.MaxEntries {
+			var lru credentialKey
+			var oldest *credential
+			for key, old := range c.entries {
+				if old.done() && (oldest == nil || old.used.Before(oldest.used)) {
+					lru, oldest = key, old
+				}
+			}
+			if oldest == nil {
+				// Only validations in flight; they leave on completion.
+				break
+			}
+			delete(c.entries, lru)
+		}
+	}
+	c.entries[k] = e
+}
+
+// start starts the background revalidation.
+func (c *credentialCache) start() {
+	if c.cfg.RevalidateInterval <= 0 || c.cfg.TTL == 0 || c.cfg.MaxEntries == 0 || c.done != nil {
+		return
+	}
+	c.done = make(chan struct{})
+	go c.run()
+}
+
+// shutdown stops the background revalidation.
+func (c *credentialCache) shutdown() {
+	c.cancel()
+	if c.done != nil {
+		<-c.done
+	}
+}
+
+func (c *credentialCache) run() {
+	defer close(c.done)
+	ticker := time.NewTicker(c.cfg.RevalidateInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-c.ctx.Done():
+			return
+		case <-ticker.C:
+			c.revalidate()
+		}
+	}
+}
+
+// revalidate validates the accepted keys used since their last validation
+// again and drops the stale entries. A key failing to revalidate for
+// another reason than a rejection stays accepted until its TTL runs out.
+func (c *credentialCache) revalidate() {
+	now := time.Now()
+	var due []*credential
+	c.mu.Lock()
+	for k, e := range c.entries {
+		if !e.done() {
+			continue
+		}
+		if !c.fresh(e, now) {
+			delete(c.entries, k)
+			continue
+		}
+		if e.valid && e.used.After(e.checked) {
+			due = append(due, e)
+		}
+	}
+	c.mu.Unlock()
+
+	for _, e := range due {
+		err := c.validate(c.ctx, e.keyID, e.keySecret)
+		if c.ctx.Err() != nil {
+			return
+		}
+		c.mu.Lock()
+		switch {
+		case err == nil:
+			e.checked = time.Now()
+		case errors.Is(err, ErrInvalidCredentials):
+			e.valid, e.checked = false, time.Now()
+			c.logger.Warn("API key rejected on revalidation; refusing it",
+				zap.String("api_key_id", MaskAPIKey(e.keyID)))
+		default:
+			c.logger.Warn("Failed to revalidate API key",
+				zap.String("api_key_id", MaskAPIKey(e.keyID)), zap.Error(err))
+		}
+		c.mu.Unlock()
+	}
+}
@@ -19,6 +19,20 @@
 //   - Centralized API key storage for TFO authentication
 //   - API key validation (optional)
 //   - Credential provider interface for tfoexporter
+//   - Credential profiles selected per resource by the value of a resource
+//     attribute, so that each team's telemetry is exported under its own
+//     key; other telemetry uses the top-level key
+//   - API key rotation: with a secondary key, exporters switch to it when
+//     the TFO Platform rejects the primary key with HTTP 401, so a key is
+//     rotated by deploying the new key as secondary and then revoking the
+//     old one, without a coordinated restart (the switch is logged and
+//     counted in tfo_auth_key_rotations)
+//   - Credential validator interface for tfootlpreceiver v2 auth: request
+//     keys are checked against the validation endpoint and the outcome is
+//     cached (cache.ttl, with rejections for cache.negative_ttl); keys in
+//     use are revalidated in the background every
+//     cache.revalidate_interval, so a revoked key stops working within
+//     that interval
 //
 // Configuration example:
 //
@@ -26,5 +40,18 @@
 //	  tfoauth:
 //	    api_key_id: "${env:TELEMETRYFLOW_API_KEY_ID}"
 //	    api_key_secret: "${env:TELEMETRYFLOW_API_KEY_SECRET}"
+//	    secondary_api_key_id: "${env:TELEMETRYFLOW_SECONDARY_API_KEY_ID}"
+//	    secondary_api_key_secret: "${env:TELEMETRYFLOW_SECONDARY_API_KEY_SECRET}"
 //	    validation_endpoint: "https://api.telemetryflow.id/v1/auth/validate"
+//	    cache:
+//	      ttl: 5m
+//	      negative_ttl: 1m
+//	      revalidate_interval: 1m
+//	      max_entries: 10000
+//	    profile_attribute: team
+//	    profiles:
+//	      payments:
+//	        api_key_id: "${env:TFO_PAYMENTS_KEY_ID}"
+//	        api_key_secret: "${env:TFO_PAYMENTS_KEY_SECRET}"
+//	        values: [payments, billing]
 package tfoauthextension // import "github.com/telemetryflow/telemetryflow-collector/components/extension/tfoauthextension"
@@ -16,13 +16,20 @@ package tfoauthextension
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
+	"slices"
+	"sync"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
 	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
 )
 
@@ -32,87 +39,214 @@ type tfoAuthExtension struct {
 	settings *extension.Settings
 	logger   *zap.Logger
 	client   *http.Client
+
+	// profiles maps values of the profile attribute to profile names.
+	profiles map[string]string
+
+	// credentials caches the validations of request credentials.
+	credentials *credentialCache
+
+	// keyMu guards rotated, which is set once the secondary API key
+	// replaced the primary one.
+	keyMu   sync.Mutex
+	rotated bool
+
+	// Rotation counter (nil without a meter provider)
+	rotations metric.Int64Counter
 }
 
 // newTFOAuthExtension creates a new TFO auth extension.
 func newTFOAuthExtension(cfg *Config, set *extension.Settings) (*tfoAuthExtension, error) {
-	return &tfoAuthExtension{
+	profiles := make(map[string]string)
+	for name, p := range cfg.Profiles {
+		for _, v := range p.values(name) {
+			profiles[v] = name
+		}
+	}
+	e := &tfoAuthExtension{
 		cfg:      cfg,
 		settings: set,
 		logger:   set.Logger,
 		client: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-	}, nil
+		profiles: profiles,
+	}
+	e.credentials = newCredentialCache(cfg.Cache, e.validateKey, set.Logger)
+	return e, nil
 }
 
 // Start implements component.Component.
 func (e *tfoAuthExtension) Start(ctx context.Context, host component.Host) error {
 	e.logger.Info("TFO auth extension started",
-		zap.String("api_key_id", maskAPIKey(string(e.cfg.APIKeyID))),
+		zap.String("api_key_id", MaskAPIKey(string(e.cfg.APIKeyID))),
+		zap.Bool("has_secondary_key", e.cfg.SecondaryAPIKeyID != ""),
 		zap.Bool("validate_on_start", e.cfg.ValidateOnStart),
+		zap.Int("profiles", len(e.cfg.Profiles)),
 	)
 
+	if err := e.startRotation(); err != nil {
+		return err
+	}
+
 	if e.cfg.ValidateOnStart && e.cfg.ValidationEndpoint != "" {
 		if err := e.validateCredentials(ctx); err != nil {
 			return fmt.Errorf("API key validation failed: %w", err)
 		}
+		for _, name := range slices.Sorted(maps.Keys(e.cfg.Profiles)) {
+			if err := e.validateProfile(ctx, name); err != nil {
+				return fmt.Errorf("API key validation of profile %q failed: %w", name, err)
+			}
+		}
 		e.logger.Info("API key validated successfully")
 	}
 
+	if e.cfg.ValidationEndpoint != "" {
+		e.credentials.start()
+	}
 	return nil
 }
 
 // Shutdown implements component.Component.
 func (e *tfoAuthExtension) Shutdown(ctx context.Context) error {
+	e.credentials.shutdown()
 	e.logger.Info("TFO auth extension stopped")
 	return nil
 }
 
-// GetAPIKeyID returns the API Key ID.
+// GetAPIKeyID returns the API Key ID, of the secondary key once rotated.
 // Implements the AuthProvider interface for tfoexporter.
 func (e *tfoAuthExtension) GetAPIKeyID() string {
-	return string(e.cfg.APIKeyID)
+	keyID, _ := e.activeKey()
+	return keyID
 }
 
-// GetAPIKeySecret returns the API Key Secret.
+// GetAPIKeySecret returns the API Key Secret, of the secondary key once
+// rotated.
 // Implements the AuthProvider interface for tfoexporter.
 func (e *tfoAuthExtension) GetAPIKeySecret() string {
-	return string(e.cfg.APIKeySecret)
+	_, keySecret := e.activeKey()
+	return keySecret
+}
+
+// GetProfileAttribute returns the resource attribute that selects a
+// credential profile, or empty when no profiles are configured.
+// Implements the ProfileProvider interface for tfoexporter.
+func (e *tfoAuthExtension) GetProfileAttribute() string {
+	if len(e.profiles) == 0 {
+		return ""
+	}
+	return e.cfg.ProfileAttribute
+}
+
+// GetProfileCredentials returns the profile selected by a value of the
+// profile attribute and its API key, or ok false when no profile claims
+// the value.
+// Implements the ProfileProvider interface for tfoexporter.
+func (e *tfoAuthExtension) GetProfileCredentials(value string) (profile, keyID, keySecret string, ok bool) {
+	profile, ok = e.profiles[value]
+	if !ok {
+		return "", "", "", false
+	}
+	p := e.cfg.Profiles[profile]
+	return profile, string(p.APIKeyID), string(p.APIKeySecret), true
+}
+
+// ValidateCredential reports whether the validation endpoint accepts the
+// API key keyID with secret keySecret. Outcomes are cached per the cache
+// settings. An error means the key could not be validated, e.g. because
+// the endpoint is unreachable.
+// Implements the CredentialValidator interface for tfootlpreceiver.
+func (e *tfoAuthExtension) ValidateCredential(ctx context.Context, keyID, keySecret string) (bool, error) {
+	if e.cfg.ValidationEndpoint == "" {
+		return false, errors.New("validation_endpoint is not configured")
+	}
+	return e.credentials.check(ctx, keyID, keySecret)
+}
+
+// validateKey validates an API key of a request against the validation
+// endpoint.
+func (e *tfoAuthExtension) validateKey(ctx context.Context, keyID, keySecret string) error {
+	_, err := ValidateCredentials(ctx, e.client, &Config{
+		APIKeyID:           configopaque.String(keyID),
+		APIKeySecret:       configopaque.String(keySecret),
+		ValidationEndpoint: e.cfg.ValidationEndpoint,
+	})
+	return err
 }
 
 // validateCredentials validates the API key against the validation endpoint.
+// A rejected primary key is rotated when the secondary key validates.
 func (e *tfoAuthExtension) validateCredentials(ctx context.Context) error {
-	req, err := http.NewRequestWithContext(ctx, http.MethodGet, e.cfg.ValidationEndpoint, nil)
+	_, err := ValidateCredentials(ctx, e.client, e.cfg)
+	if !errors.Is(err, ErrInvalidCredentials) || e.cfg.SecondaryAPIKeyID == "" {
+		return err
+	}
+	if err := e.validateKey(ctx, string(e.cfg.SecondaryAPIKeyID), string(e.cfg.SecondaryAPIKeySecret)); err != nil {
+		return fmt.Errorf("primary and secondary API keys: %w", err)
+	}
+	e.RotateAPIKey(ctx, string(e.cfg.APIKeyID))
+	return nil
+}
+
+// validateProfile validates the API key of a profile against the
+// validation endpoint.
+func (e *tfoAuthExtension) validateProfile(ctx context.Context, name string) error {
+	p := e.cfg.Profiles[name]
+	_, err := ValidateCredentials(ctx, e.client, &Config{
+		APIKeyID:           p.APIKeyID,
+		APIKeySecret:       p.APIKeySecret,
+		ValidationEndpoint: e.cfg.ValidationEndpoint,
+	})
+	return err
+}
+
+// maxValidationResponse bounds how much of the validation response is read.
+const maxValidationResponse = 64 << 10
+
+// ErrInvalidCredentials is returned by ValidateCredentials when the
+// validation endpoint rejects the API key.
+var ErrInvalidCredentials = errors.New("invalid API credentials")
+
+// ValidateCredentials calls cfg.ValidationEndpoint with the configured API
+// key and returns the authenticated principal. The principal is the
+// "principal" field of a JSON response body, or empty when the endpoint
+// does not report one.
+func ValidateCredentials(ctx context.Context, client *http.Client, cfg *Config) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, cfg.ValidationEndpoint, nil)
 	if err != nil {
-		return fmt.Errorf("failed to create validation request: %w", err)
+		return "", fmt.Errorf("failed to create validation request: %w", err)
 	}
 
-	req.Header.Set("X-TelemetryFlow-Key-ID", string(e.cfg.APIKeyID))
-	req.Header.Set("X-TelemetryFlow-Key-Secret", string(e.cfg.APIKeySecret))
+	req.Header.Set("X-TelemetryFlow-Key-ID", string(cfg.APIKeyID))
+	req.Header.Set("X-TelemetryFlow-Key-Secret", string(cfg.APIKeySecret))
 
-	resp, err := e.client.Do(req)
+	resp, err := client.Do(req)
 	if err != nil {
-		return fmt.Errorf("validation request failed: %w", err)
+		return "", fmt.Errorf("validation request failed: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
-	// Read and discard body
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxValidationResponse))
 	_, _ = io.Copy(io.Discard, resp.Body)
 
 	if resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden {
-		return fmt.Errorf("invalid API credentials")
+		return "", ErrInvalidCredentials
 	}
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("unexpected validation response: %d", resp.StatusCode)
+		return "", fmt.Errorf("unexpected validation response: %d", resp.StatusCode)
 	}
 
-	return nil
+	var identity struct {
+		Principal string `json:"principal"`
+	}
+	_ = json.Unmarshal(body, &identity)
+	return identity.Principal, nil
 }
 
-// maskAPIKey masks an API key for logging (shows first 8 chars only).
-func maskAPIKey(key string) string {
+// MaskAPIKey masks an API key for logging (shows first 8 chars only).
+func MaskAPIKey(key string) string {
 	if len(key) <= 8 {
 		return "****"
 	}
@@ -16,6 +16,7 @@ package tfoauthextension
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/extension"
@@ -24,6 +25,12 @@ import (
 const (
 	// TypeStr is the type string identifier for the TFO auth extension.
 	TypeStr = "tfoauth"
+
+	// Defaults
+	defaultCacheTTL           = 5 * time.Minute
+	defaultNegativeTTL        = time.Minute
+	defaultRevalidateInterval = time.Minute
+	defaultMaxEntries         = 10000
 )
 
 // NewFactory creates a new factory for the TFO auth extension.
@@ -40,6 +47,12 @@ func NewFactory() extension.Factory {
 func createDefaultConfig() component.Config {
 	return &Config{
 		ValidateOnStart: false,
+		Cache: CacheConfig{
+			TTL:                defaultCacheTTL,
+			NegativeTTL:        defaultNegativeTTL,
+			RevalidateInterval: defaultRevalidateInterval,
+			MaxEntries:         defaultMaxEntries,
+		},
 	}
 }
 
@@ -3,9 +3,11 @@ module github.com/telemetryflow/telemetryflow-collector/components/extension/tfo
 go 1.26
 
 require (
+	github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics v0.0.0
 	go.opentelemetry.io/collector/component v1.52.0
 	go.opentelemetry.io/collector/config/configopaque v1.52.0
 	go.opentelemetry.io/collector/extension v1.52.0
+	go.opentelemetry.io/otel/metric v1.41.0
 	go.uber.org/zap v1.27.1
 )
 
@@ -28,9 +30,11 @@ require (
 	go.opentelemetry.io/collector/featuregate v1.52.0 // indirect
 	go.opentelemetry.io/collector/internal/componentalias v0.146.1 // indirect
 	go.opentelemetry.io/collector/pdata v1.52.0 // indirect
+	go.opentelemetry.io/collector/pipeline v1.52.0 // indirect
 	go.opentelemetry.io/otel v1.41.0 // indirect
-	go.opentelemetry.io/otel/metric v1.41.0 // indirect
 	go.opentelemetry.io/otel/trace v1.41.0 // indirect
 	go.uber.org/multierr v1.11.0 // indirect
 	go.yaml.in/yaml/v3 v3.0.4 // indirect
 )
+
+replace github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics => ../../../pkg/selfmetrics
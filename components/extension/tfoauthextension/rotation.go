@@ -0,0 +1,79 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoauthextension
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+const scopeName = "github.com/telemetryflow/telemetryflow-collector/components/extension/tfoauthextension"
+
+// startRotation creates the rotation counter.
+func (e *tfoAuthExtension) startRotation() error {
+	if e.settings.MeterProvider == nil {
+		return nil
+	}
+	counter, err := e.settings.MeterProvider.Meter(scopeName).Int64Counter(selfmetrics.AuthKeyRotations,
+		metric.WithDescription("Number of times the secondary API key replaced the rejected primary key."),
+		metric.WithUnit("{rotation}"))
+	if err != nil {
+		return err
+	}
+	e.rotations = counter
+	return nil
+}
+
+// activeKey returns the API key exporters send with: the secondary key
+// once rotated, the primary key otherwise.
+func (e *tfoAuthExtension) activeKey() (keyID, keySecret string) {
+	e.keyMu.Lock()
+	defer e.keyMu.Unlock()
+	if e.rotated {
+		return string(e.cfg.SecondaryAPIKeyID), string(e.cfg.SecondaryAPIKeySecret)
+	}
+	return string(e.cfg.APIKeyID), string(e.cfg.APIKeySecret)
+}
+
+// RotateAPIKey switches to the secondary API key after the TFO Platform
+// rejected the key rejectedKeyID, and returns the key to send with. ok is
+// false when there is no other key to try: no secondary key is configured
+// or the secondary key itself was rejected. The switch is shared by every
+// exporter using the extension and lasts until the collector restarts.
+// Implements the KeyRotator interface for tfoexporter.
+func (e *tfoAuthExtension) RotateAPIKey(ctx context.Context, rejectedKeyID string) (keyID, keySecret string, ok bool) {
+	secondaryID := string(e.cfg.SecondaryAPIKeyID)
+	if secondaryID == "" || rejectedKeyID == secondaryID {
+		return "", "", false
+	}
+
+	e.keyMu.Lock()
+	defer e.keyMu.Unlock()
+	if !e.rotated {
+		e.rotated = true
+		e.logger.Warn("Primary API key rejected; switched to the secondary API key",
+			zap.String("api_key_id", MaskAPIKey(string(e.cfg.APIKeyID))),
+			zap.String("secondary_api_key_id", MaskAPIKey(secondaryID)),
+		)
+		if e.rotations != nil {
+			e.rotations.Add(ctx, 1, selfmetrics.Extension(e.settings.ID).Option())
+		}
+	}
+	return secondaryID, string(e.cfg.SecondaryAPIKeySecret), true
+}
@@ -0,0 +1,81 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoclockextension
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"time"
+)
+
+// Config defines the configuration for the TFO clock extension.
+type Config struct {
+	// NTPServers lists NTP servers to query, as host or host:port.
+	// Port 123 is used when omitted.
+	NTPServers []string `mapstructure:"ntp_servers"`
+
+	// HTTPEndpoints lists http(s) URLs whose Date response header is used as
+	// a reference clock. Resolution is one second.
+	HTTPEndpoints []string `mapstructure:"http_endpoints"`
+
+	// Interval is how often the sources are queried.
+	// Default: 5m
+	Interval time.Duration `mapstructure:"interval"`
+
+	// Timeout bounds a single source query.
+	// Default: 5s
+	Timeout time.Duration `mapstructure:"timeout"`
+
+	// MaxDrift is the absolute drift above which a warning is logged.
+	// Zero disables the warning.
+	// Default: 1s
+	MaxDrift time.Duration `mapstructure:"max_drift"`
+}
+
+// Validate checks the configuration for errors.
+func (cfg *Config) Validate() error {
+	if len(cfg.NTPServers) == 0 && len(cfg.HTTPEndpoints) == 0 {
+		return errors.New("at least one of ntp_servers or http_endpoints is required")
+	}
+	for _, server := range cfg.NTPServers {
+		if server == "" {
+			return errors.New("ntp_servers must not contain empty entries")
+		}
+		if _, _, err := net.SplitHostPort(ntpAddr(server)); err != nil {
+			return fmt.Errorf("invalid ntp server %q: %w", server, err)
+		}
+	}
+	for _, endpoint := range cfg.HTTPEndpoints {
+		u, err := url.Parse(endpoint)
+		if err != nil {
+			return fmt.Errorf("invalid http endpoint %q: %w", endpoint, err)
+		}
+		if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("invalid http endpoint %q: must be an http(s) URL with a host", endpoint)
+		}
+	}
+	if cfg.Interval <= 0 {
+		return errors.New("interval must be positive")
+	}
+	if cfg.Timeout <= 0 {
+		return errors.New("timeout must be positive")
+	}
+	if cfg.MaxDrift < 0 {
+		return errors.New("max_drift must not be negative")
+	}
+	return nil
+}
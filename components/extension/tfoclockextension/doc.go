@@ -0,0 +1,40 @@
+// Package tfoclockextension provides the TelemetryFlow clock drift detection extension.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// The tfoclockextension provides:
+//   - Periodic clock offset measurement against NTP servers (SNTP)
+//   - Offset measurement from HTTP Date headers (e.g. the TFO Platform backend)
+//   - tfo_clock_drift_seconds gauge per source plus the combined drift
+//   - Clock drift provider interface for tfoexporter batch annotation
+//
+// Drift is the local clock minus the reference clock: a positive value means
+// the local clock is ahead. When several sources respond, the median offset
+// is used.
+//
+// Configuration example:
+//
+//	extensions:
+//	  tfoclock:
+//	    ntp_servers:
+//	      - pool.ntp.org
+//	      - time.google.com:123
+//	    http_endpoints:
+//	      - https://api.telemetryflow.id
+//	    interval: 5m
+//	    timeout: 5s
+//	    max_drift: 1s
+package tfoclockextension // import "github.com/telemetryflow/telemetryflow-collector/components/extension/tfoclockextension"
@@ -0,0 +1,208 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoclockextension
+
+import (
+	"context"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+const scopeName = "github.com/telemetryflow/telemetryflow-collector/components/extension/tfoclockextension"
+
+// sourceCombined is the source attribute value of the combined drift.
+const sourceCombined = "combined"
+
+// tfoClockExtension measures local clock drift against reference sources.
+type tfoClockExtension struct {
+	cfg      *Config
+	settings *extension.Settings
+	logger   *zap.Logger
+	client   *http.Client
+
+	// Latest measurements, keyed by source.
+	mu      sync.RWMutex
+	drifts  map[string]time.Duration
+	drift   time.Duration
+	hasData bool
+
+	registration metric.Registration
+	stopCh       chan struct{}
+	wg           sync.WaitGroup
+}
+
+// newTFOClockExtension creates a new TFO clock extension.
+func newTFOClockExtension(cfg *Config, set *extension.Settings) (*tfoClockExtension, error) {
+	return &tfoClockExtension{
+		cfg:      cfg,
+		settings: set,
+		logger:   set.Logger,
+		client:   &http.Client{Timeout: cfg.Timeout},
+		drifts:   make(map[string]time.Duration),
+	}, nil
+}
+
+// Start implements component.Component.
+func (e *tfoClockExtension) Start(ctx context.Context, host component.Host) error {
+	if e.settings.MeterProvider != nil {
+		meter := e.settings.MeterProvider.Meter(scopeName)
+		gauge, err := meter.Float64ObservableGauge(selfmetrics.ClockDriftSeconds,
+			metric.WithDescription("Local clock minus reference clock; positive means the local clock is ahead."),
+			metric.WithUnit("s"))
+		if err != nil {
+			return err
+		}
+		labels := selfmetrics.Extension(e.settings.ID)
+		e.registration, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+			e.mu.RLock()
+			defer e.mu.RUnlock()
+			for source, drift := range e.drifts {
+				o.ObserveFloat64(gauge, drift.Seconds(), labels.Option(attribute.String("source", source)))
+			}
+			if e.hasData {
+				o.ObserveFloat64(gauge, e.drift.Seconds(), labels.Option(attribute.String("source", sourceCombined)))
+			}
+			return nil
+		}, gauge)
+		if err != nil {
+			return err
+		}
+	}
+
+	e.stopCh = make(chan struct{})
+	e.wg.Add(1)
+	go e.run()
+
+	e.logger.Info("TFO clock extension started",
+		zap.Strings("ntp_servers", e.cfg.NTPServers),
+		zap.Strings("http_endpoints", e.cfg.HTTPEndpoints),
+		zap.Duration("interval", e.cfg.Interval),
+	)
+
+	return nil
+}
+
+// Shutdown implements component.Component.
+func (e *tfoClockExtension) Shutdown(ctx context.Context) error {
+	if e.stopCh != nil {
+		close(e.stopCh)
+		e.wg.Wait()
+		e.stopCh = nil
+	}
+	if e.registration != nil {
+		if err := e.registration.Unregister(); err != nil {
+			return err
+		}
+		e.registration = nil
+	}
+	e.logger.Info("TFO clock extension stopped")
+	return nil
+}
+
+// GetClockDrift returns the latest combined drift (local minus reference)
+// and whether any source has been measured successfully.
+// Implements the ClockDriftProvider interface for tfoexporter.
+func (e *tfoClockExtension) GetClockDrift() (time.Duration, bool) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.drift, e.hasData
+}
+
+// run measures drift immediately and then every Interval until shutdown.
+func (e *tfoClockExtension) run() {
+	defer e.wg.Done()
+	ticker := time.NewTicker(e.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		e.measure()
+		select {
+		case <-e.stopCh:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// measure queries every source once and updates the recorded drifts.
+func (e *tfoClockExtension) measure() {
+	drifts := make(map[string]time.Duration)
+	query := func(source string, fn func(ctx context.Context) (time.Duration, error)) {
+		ctx, cancel := context.WithTimeout(context.Background(), e.cfg.Timeout)
+		defer cancel()
+		drift, err := fn(ctx)
+		if err != nil {
+			e.logger.Debug("Clock source query failed", zap.String("source", source), zap.Error(err))
+			return
+		}
+		drifts[source] = drift
+	}
+
+	for _, server := range e.cfg.NTPServers {
+		query("ntp:"+server, func(ctx context.Context) (time.Duration, error) {
+			return queryNTP(ctx, server)
+		})
+	}
+	for _, endpoint := range e.cfg.HTTPEndpoints {
+		query(endpoint, func(ctx context.Context) (time.Duration, error) {
+			return queryHTTPDate(ctx, e.client, endpoint)
+		})
+	}
+
+	if len(drifts) == 0 {
+		e.logger.Warn("No clock source could be queried; keeping previous drift")
+		return
+	}
+
+	combined := median(drifts)
+
+	e.mu.Lock()
+	e.drifts = drifts
+	e.drift = combined
+	e.hasData = true
+	e.mu.Unlock()
+
+	if e.cfg.MaxDrift > 0 && combined.Abs() > e.cfg.MaxDrift {
+		e.logger.Warn("Local clock drift exceeds max_drift",
+			zap.Duration("drift", combined),
+			zap.Duration("max_drift", e.cfg.MaxDrift),
+		)
+	} else {
+		e.logger.Debug("Measured clock drift", zap.Duration("drift", combined), zap.Int("sources", len(drifts)))
+	}
+}
+
+// median returns the median of the drift values.
+func median(drifts map[string]time.Duration) time.Duration {
+	values := make([]time.Duration, 0, len(drifts))
+	for _, d := range drifts {
+		values = append(values, d)
+	}
+	slices.Sort(values)
+	mid := len(values) / 2
+	if len(values)%2 == 0 {
+		return (values[mid-1] + values[mid]) / 2
+	}
+	return values[mid]
+}
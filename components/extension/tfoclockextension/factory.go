@@ -0,0 +1,65 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoclockextension
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+)
+
+const (
+	// TypeStr is the type string identifier for the TFO clock extension.
+	TypeStr = "tfoclock"
+
+	// DefaultInterval is the default source query interval.
+	DefaultInterval = 5 * time.Minute
+
+	// DefaultTimeout is the default per-source query timeout.
+	DefaultTimeout = 5 * time.Second
+
+	// DefaultMaxDrift is the default drift warning threshold.
+	DefaultMaxDrift = time.Second
+)
+
+// NewFactory creates a new factory for the TFO clock extension.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(
+		component.MustNewType(TypeStr),
+		createDefaultConfig,
+		createExtension,
+		component.StabilityLevelAlpha,
+	)
+}
+
+// createDefaultConfig creates the default configuration for the extension.
+func createDefaultConfig() component.Config {
+	return &Config{
+		Interval: DefaultInterval,
+		Timeout:  DefaultTimeout,
+		MaxDrift: DefaultMaxDrift,
+	}
+}
+
+// createExtension creates the TFO clock extension.
+func createExtension(
+	ctx context.Context,
+	set extension.Settings,
+	cfg component.Config,
+) (extension.Extension, error) {
+	return newTFOClockExtension(cfg.(*Config), &set)
+}
@@ -0,0 +1,131 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoclockextension
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+const (
+	ntpDefaultPort = "123"
+	ntpPacketSize  = 48
+
+	// ntpEpochOffset is the number of seconds between the NTP epoch (1900)
+	// and the Unix epoch (1970).
+	ntpEpochOffset = 2208988800
+)
+
+// ntpAddr appends the default NTP port when server has none.
+func ntpAddr(server string) string {
+	if _, _, err := net.SplitHostPort(server); err == nil {
+		return server
+	}
+	return net.JoinHostPort(server, ntpDefaultPort)
+}
+
+// queryNTP performs a single SNTP (RFC 4330) exchange and returns the local
+// clock offset relative to the server (local minus server).
+func queryNTP(ctx context.Context, server string) (time.Duration, error) {
+	var d net.Dialer
+	conn, err := d.DialContext(ctx, "udp", ntpAddr(server))
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = conn.Close() }()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	req := make([]byte, ntpPacketSize)
+	req[0] = 0x23 // LI=0, VN=4, Mode=3 (client)
+	t0 := time.Now()
+	binary.BigEndian.PutUint64(req[40:], toNTPTime(t0))
+	if _, err := conn.Write(req); err != nil {
+		return 0, err
+	}
+
+	resp := make([]byte, ntpPacketSize)
+	n, err := conn.Read(resp)
+	if err != nil {
+		return 0, err
+	}
+	t3 := time.Now()
+	if n < ntpPacketSize {
+		return 0, errors.New("short NTP response")
+	}
+	if mode := resp[0] & 0x07; mode != 4 {
+		return 0, fmt.Errorf("unexpected NTP mode %d", mode)
+	}
+	if stratum := resp[1]; stratum == 0 || stratum > 15 {
+		return 0, fmt.Errorf("unsynchronized NTP server (stratum %d)", stratum)
+	}
+	if binary.BigEndian.Uint64(resp[24:]) != binary.BigEndian.Uint64(req[40:]) {
+		return 0, errors.New("NTP response does not match request")
+	}
+
+	t1 := fromNTPTime(binary.BigEndian.Uint64(resp[32:]))
+	t2 := fromNTPTime(binary.BigEndian.Uint64(resp[40:]))
+
+	// Server offset is ((t1 - t0) + (t2 - t3)) / 2; drift is its negation.
+	return -(t1.Sub(t0) + t2.Sub(t3)) / 2, nil
+}
+
+// queryHTTPDate issues a HEAD request and derives the local clock offset
+// from the response Date header, assuming the header was generated halfway
+// through the round trip.
+func queryHTTPDate(ctx context.Context, client *http.Client, endpoint string) (time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, endpoint, nil)
+	if err != nil {
+		return 0, err
+	}
+	sent := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	_ = resp.Body.Close()
+	received := time.Now()
+
+	header := resp.Header.Get("Date")
+	if header == "" {
+		return 0, errors.New("response has no Date header")
+	}
+	serverTime, err := http.ParseTime(header)
+	if err != nil {
+		return 0, fmt.Errorf("invalid Date header: %w", err)
+	}
+	// The Date header is truncated to the second; use the middle of that second.
+	serverTime = serverTime.Add(500 * time.Millisecond)
+	midpoint := sent.Add(received.Sub(sent) / 2)
+	return midpoint.Sub(serverTime), nil
+}
+
+func toNTPTime(t time.Time) uint64 {
+	secs := uint64(t.Unix() + ntpEpochOffset)
+	frac := (uint64(t.Nanosecond()) << 32) / uint64(time.Second)
+	return secs<<32 | frac
+}
+
+func fromNTPTime(v uint64) time.Time {
+	secs := int64(v>>32) - ntpEpochOffset
+	nanos := int64((v & 0xffffffff) * uint64(time.Second) >> 32)
+	return time.Unix(secs, nanos)
+}
@@ -0,0 +1,119 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoencryptionextension
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
+)
+
+// Supported inner formats.
+const (
+	FormatProto = "proto"
+	FormatJSON  = "json"
+)
+
+// masterKeySize is the size of an AES-256 master key in bytes.
+const masterKeySize = 32
+
+// Config defines the configuration for the TFO encryption extension.
+type Config struct {
+	// Keys lists the master keys. All keys can decrypt; only ActiveKey
+	// encrypts.
+	Keys []KeyConfig `mapstructure:"keys"`
+
+	// ActiveKey is the ID of the key used to wrap new data keys.
+	ActiveKey string `mapstructure:"active_key"`
+
+	// Format is the inner serialization used when Encoding is not set:
+	// "proto" or "json".
+	// Default: proto
+	Format string `mapstructure:"format"`
+
+	// Encoding optionally references another encoding extension that
+	// serializes batches before encryption. Takes precedence over Format.
+	Encoding component.ID `mapstructure:"encoding"`
+}
+
+// KeyConfig defines a master key.
+type KeyConfig struct {
+	// ID identifies the key. It is recorded in every envelope.
+	ID string `mapstructure:"id"`
+
+	// Key is the base64 encoded 32 byte key.
+	Key configopaque.String `mapstructure:"key"`
+
+	// KeyFile is a file holding the base64 encoded key. Used when Key is empty.
+	KeyFile string `mapstructure:"key_file"`
+}
+
+// Validate checks the configuration for errors.
+func (cfg *Config) Validate() error {
+	if len(cfg.Keys) == 0 {
+		return errors.New("at least one key is required")
+	}
+	seen := make(map[string]struct{}, len(cfg.Keys))
+	for i, key := range cfg.Keys {
+		if key.ID == "" {
+			return fmt.Errorf("keys[%d]: id is required", i)
+		}
+		if len(key.ID) > maxKeyIDLen {
+			return fmt.Errorf("keys[%d]: id must be at most %d bytes", i, maxKeyIDLen)
+		}
+		if _, dup := seen[key.ID]; dup {
+			return fmt.Errorf("keys[%d]: duplicate id %q", i, key.ID)
+		}
+		seen[key.ID] = struct{}{}
+		if (key.Key == "") == (key.KeyFile == "") {
+			return fmt.Errorf("keys[%d]: exactly one of key or key_file is required", i)
+		}
+	}
+	if cfg.ActiveKey == "" {
+		return errors.New("active_key is required")
+	}
+	if _, ok := seen[cfg.ActiveKey]; !ok {
+		return fmt.Errorf("active_key %q is not among keys", cfg.ActiveKey)
+	}
+	if cfg.Encoding.String() == "" && cfg.Format != FormatProto && cfg.Format != FormatJSON {
+		return fmt.Errorf("format must be %q or %q, got %q", FormatProto, FormatJSON, cfg.Format)
+	}
+	return nil
+}
+
+// load decodes the master key material.
+func (k *KeyConfig) load() ([]byte, error) {
+	encoded := string(k.Key)
+	if encoded == "" {
+		data, err := os.ReadFile(k.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read key file: %w", err)
+		}
+		encoded = string(data)
+	}
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("key is not valid base64: %w", err)
+	}
+	if len(key) != masterKeySize {
+		return nil, fmt.Errorf("key must be %d bytes, got %d", masterKeySize, len(key))
+	}
+	return key, nil
+}
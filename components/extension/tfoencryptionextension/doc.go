@@ -0,0 +1,53 @@
+// Package tfoencryptionextension provides client-side envelope encryption for archival exporters.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// The tfoencryptionextension is an encoding extension: the contrib file and
+// S3 exporters reference it through their `encoding` setting and every batch
+// they write is encrypted before it leaves the collector, independent of the
+// storage layer's own at-rest encryption.
+//
+// Each batch is serialized by the inner encoding (OTLP proto or JSON, or
+// another encoding extension), then sealed with AES-256-GCM under a fresh
+// random data key. The data key is wrapped with the active master key and
+// stored in the envelope header together with the master key ID, so every
+// object records which key is needed to read it. Retired master keys stay
+// configured for decryption (e.g. by the S3 receiver) after a rotation.
+//
+// Configuration example:
+//
+//	extensions:
+//	  tfoencryption:
+//	    active_key: archive-2026
+//	    keys:
+//	      - id: archive-2026
+//	        key_file: /etc/tfo/keys/archive-2026.key
+//	      - id: archive-2025
+//	        key: ${env:TFO_ARCHIVE_KEY_2025}
+//	    format: proto
+//
+//	exporters:
+//	  file/archive:
+//	    path: /var/lib/tfo/archive/telemetry.bin
+//	    encoding: tfoencryption
+//	  awss3/archive:
+//	    s3uploader:
+//	      region: eu-west-1
+//	      s3_bucket: tfo-archive
+//	    encoding: tfoencryption
+//
+// Master keys are 32 random bytes, base64 encoded (e.g. `openssl rand -base64 32`).
+package tfoencryptionextension // import "github.com/telemetryflow/telemetryflow-collector/components/extension/tfoencryptionextension"
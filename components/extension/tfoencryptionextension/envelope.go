@@ -0,0 +1,163 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoencryptionextension
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Envelope layout (all integers big endian):
+//
+//	magic      [4]byte  "TFE1"
+//	keyIDLen   uint8
+//	keyID      [keyIDLen]byte
+//	wrappedLen uint16
+//	wrapped    [wrappedLen]byte  nonce || AES-256-GCM(master, dataKey, aad=keyID)
+//	nonce      [12]byte
+//	ciphertext []byte            AES-256-GCM(dataKey, payload, aad=header)
+//
+// The header is every byte preceding the ciphertext, so tampering with the
+// key ID or wrapped key fails authentication.
+var envelopeMagic = []byte("TFE1")
+
+const (
+	maxKeyIDLen = 255
+	dataKeySize = 32
+)
+
+var errNotEnvelope = errors.New("data is not a TFO encryption envelope")
+
+// keyring holds the master keys.
+type keyring struct {
+	active string
+	keys   map[string]cipher.AEAD
+}
+
+func newAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// seal encrypts payload under a fresh data key wrapped by the active key.
+func (k *keyring) seal(payload []byte) ([]byte, error) {
+	master := k.keys[k.active]
+
+	dataKey := make([]byte, dataKeySize)
+	if _, err := rand.Read(dataKey); err != nil {
+		return nil, err
+	}
+	wrapNonce := make([]byte, master.NonceSize())
+	if _, err := rand.Read(wrapNonce); err != nil {
+		return nil, err
+	}
+	wrapped := master.Seal(wrapNonce, wrapNonce, dataKey, []byte(k.active))
+
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	buf.Grow(len(envelopeMagic) + 1 + len(k.active) + 2 + len(wrapped) + len(nonce) + len(payload) + aead.Overhead())
+	buf.Write(envelopeMagic)
+	buf.WriteByte(byte(len(k.active)))
+	buf.WriteString(k.active)
+	_ = binary.Write(&buf, binary.BigEndian, uint16(len(wrapped)))
+	buf.Write(wrapped)
+	buf.Write(nonce)
+
+	header := buf.Bytes()
+	return aead.Seal(header, nonce, payload, header), nil
+}
+
+// open decrypts an envelope produced by seal with any configured key.
+func (k *keyring) open(envelope []byte) ([]byte, error) {
+	keyID, wrapped, rest, err := parseHeader(envelope)
+	if err != nil {
+		return nil, err
+	}
+	master, ok := k.keys[keyID]
+	if !ok {
+		return nil, fmt.Errorf("unknown key id %q", keyID)
+	}
+
+	nonceSize := master.NonceSize()
+	if len(wrapped) < nonceSize {
+		return nil, errNotEnvelope
+	}
+	dataKey, err := master.Open(nil, wrapped[:nonceSize], wrapped[nonceSize:], []byte(keyID))
+	if err != nil {
+		return nil, fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+
+	aead, err := newAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(rest) < aead.NonceSize() {
+		return nil, errNotEnvelope
+	}
+	headerLen := len(envelope) - len(rest) + aead.NonceSize()
+	payload, err := aead.Open(nil, rest[:aead.NonceSize()], rest[aead.NonceSize():], envelope[:headerLen])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt payload: %w", err)
+	}
+	return payload, nil
+}
+
+// parseHeader splits an envelope into its key ID, wrapped data key and the
+// remaining nonce and ciphertext.
+func parseHeader(envelope []byte) (keyID string, wrapped, rest []byte, err error) {
+	if !bytes.HasPrefix(envelope, envelopeMagic) {
+		return "", nil, nil, errNotEnvelope
+	}
+	b := envelope[len(envelopeMagic):]
+	if len(b) < 1 {
+		return "", nil, nil, errNotEnvelope
+	}
+	idLen := int(b[0])
+	b = b[1:]
+	if len(b) < idLen+2 {
+		return "", nil, nil, errNotEnvelope
+	}
+	keyID = string(b[:idLen])
+	b = b[idLen:]
+	wrappedLen := int(binary.BigEndian.Uint16(b))
+	b = b[2:]
+	if len(b) < wrappedLen {
+		return "", nil, nil, errNotEnvelope
+	}
+	return keyID, b[:wrappedLen], b[wrappedLen:], nil
+}
+
+// KeyID returns the ID of the master key an envelope was sealed with,
+// allowing archives to be matched to keys without decrypting them.
+func KeyID(envelope []byte) (string, error) {
+	keyID, _, _, err := parseHeader(envelope)
+	return keyID, err
+}
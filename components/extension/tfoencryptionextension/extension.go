@@ -0,0 +1,185 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoencryptionextension
+
+import (
+	"context"
+	"crypto/cipher"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+// tfoEncryptionExtension is an encoding extension that encrypts batches.
+type tfoEncryptionExtension struct {
+	cfg      *Config
+	settings *extension.Settings
+	logger   *zap.Logger
+
+	keys *keyring
+
+	// Inner encoding
+	tracesMarshaler    ptrace.Marshaler
+	tracesUnmarshaler  ptrace.Unmarshaler
+	metricsMarshaler   pmetric.Marshaler
+	metricsUnmarshaler pmetric.Unmarshaler
+	logsMarshaler      plog.Marshaler
+	logsUnmarshaler    plog.Unmarshaler
+}
+
+// newTFOEncryptionExtension creates a new TFO encryption extension.
+func newTFOEncryptionExtension(cfg *Config, set *extension.Settings) (*tfoEncryptionExtension, error) {
+	return &tfoEncryptionExtension{
+		cfg:      cfg,
+		settings: set,
+		logger:   set.Logger,
+	}, nil
+}
+
+// Start implements component.Component.
+func (e *tfoEncryptionExtension) Start(ctx context.Context, host component.Host) error {
+	keys := &keyring{active: e.cfg.ActiveKey, keys: make(map[string]cipher.AEAD, len(e.cfg.Keys))}
+	for _, keyCfg := range e.cfg.Keys {
+		key, err := keyCfg.load()
+		if err != nil {
+			return fmt.Errorf("key %q: %w", keyCfg.ID, err)
+		}
+		aead, err := newAEAD(key)
+		if err != nil {
+			return fmt.Errorf("key %q: %w", keyCfg.ID, err)
+		}
+		keys.keys[keyCfg.ID] = aead
+	}
+	e.keys = keys
+
+	if e.cfg.Encoding.String() != "" {
+		ext, ok := host.GetExtensions()[e.cfg.Encoding]
+		if !ok {
+			return fmt.Errorf("encoding extension %q not found", e.cfg.Encoding)
+		}
+		// The inner extension may support only some signals; missing ones
+		// fail at marshal time.
+		e.tracesMarshaler, _ = ext.(ptrace.Marshaler)
+		e.tracesUnmarshaler, _ = ext.(ptrace.Unmarshaler)
+		e.metricsMarshaler, _ = ext.(pmetric.Marshaler)
+		e.metricsUnmarshaler, _ = ext.(pmetric.Unmarshaler)
+		e.logsMarshaler, _ = ext.(plog.Marshaler)
+		e.logsUnmarshaler, _ = ext.(plog.Unmarshaler)
+	} else if e.cfg.Format == FormatJSON {
+		e.tracesMarshaler, e.tracesUnmarshaler = &ptrace.JSONMarshaler{}, &ptrace.JSONUnmarshaler{}
+		e.metricsMarshaler, e.metricsUnmarshaler = &pmetric.JSONMarshaler{}, &pmetric.JSONUnmarshaler{}
+		e.logsMarshaler, e.logsUnmarshaler = &plog.JSONMarshaler{}, &plog.JSONUnmarshaler{}
+	} else {
+		e.tracesMarshaler, e.tracesUnmarshaler = &ptrace.ProtoMarshaler{}, &ptrace.ProtoUnmarshaler{}
+		e.metricsMarshaler, e.metricsUnmarshaler = &pmetric.ProtoMarshaler{}, &pmetric.ProtoUnmarshaler{}
+		e.logsMarshaler, e.logsUnmarshaler = &plog.ProtoMarshaler{}, &plog.ProtoUnmarshaler{}
+	}
+
+	e.logger.Info("TFO encryption extension started",
+		zap.String("active_key", e.cfg.ActiveKey),
+		zap.Int("keys", len(e.cfg.Keys)),
+		zap.String("format", e.cfg.Format),
+		zap.String("encoding", e.cfg.Encoding.String()),
+	)
+
+	return nil
+}
+
+// Shutdown implements component.Component.
+func (e *tfoEncryptionExtension) Shutdown(ctx context.Context) error {
+	e.logger.Info("TFO encryption extension stopped")
+	return nil
+}
+
+// MarshalTraces implements ptrace.Marshaler.
+func (e *tfoEncryptionExtension) MarshalTraces(td ptrace.Traces) ([]byte, error) {
+	if e.tracesMarshaler == nil {
+		return nil, errUnsupported("traces")
+	}
+	payload, err := e.tracesMarshaler.MarshalTraces(td)
+	if err != nil {
+		return nil, err
+	}
+	return e.keys.seal(payload)
+}
+
+// UnmarshalTraces implements ptrace.Unmarshaler.
+func (e *tfoEncryptionExtension) UnmarshalTraces(buf []byte) (ptrace.Traces, error) {
+	if e.tracesUnmarshaler == nil {
+		return ptrace.Traces{}, errUnsupported("traces")
+	}
+	payload, err := e.keys.open(buf)
+	if err != nil {
+		return ptrace.Traces{}, err
+	}
+	return e.tracesUnmarshaler.UnmarshalTraces(payload)
+}
+
+// MarshalMetrics implements pmetric.Marshaler.
+func (e *tfoEncryptionExtension) MarshalMetrics(md pmetric.Metrics) ([]byte, error) {
+	if e.metricsMarshaler == nil {
+		return nil, errUnsupported("metrics")
+	}
+	payload, err := e.metricsMarshaler.MarshalMetrics(md)
+	if err != nil {
+		return nil, err
+	}
+	return e.keys.seal(payload)
+}
+
+// UnmarshalMetrics implements pmetric.Unmarshaler.
+func (e *tfoEncryptionExtension) UnmarshalMetrics(buf []byte) (pmetric.Metrics, error) {
+	if e.metricsUnmarshaler == nil {
+		return pmetric.Metrics{}, errUnsupported("metrics")
+	}
+	payload, err := e.keys.open(buf)
+	if err != nil {
+		return pmetric.Metrics{}, err
+	}
+	return e.metricsUnmarshaler.UnmarshalMetrics(payload)
+}
+
+// MarshalLogs implements plog.Marshaler.
+func (e *tfoEncryptionExtension) MarshalLogs(ld plog.Logs) ([]byte, error) {
+	if e.logsMarshaler == nil {
+		return nil, errUnsupported("logs")
+	}
+	payload, err := e.logsMarshaler.MarshalLogs(ld)
+	if err != nil {
+		return nil, err
+	}
+	return e.keys.seal(payload)
+}
+
+// UnmarshalLogs implements plog.Unmarshaler.
+func (e *tfoEncryptionExtension) UnmarshalLogs(buf []byte) (plog.Logs, error) {
+	if e.logsUnmarshaler == nil {
+		return plog.Logs{}, errUnsupported("logs")
+	}
+	payload, err := e.keys.open(buf)
+	if err != nil {
+		return plog.Logs{}, err
+	}
+	return e.logsUnmarshaler.UnmarshalLogs(payload)
+}
+
+func errUnsupported(signal string) error {
+	return fmt.Errorf("inner encoding does not support %s", signal)
+}
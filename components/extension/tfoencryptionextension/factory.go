@@ -0,0 +1,53 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoencryptionextension
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+)
+
+const (
+	// TypeStr is the type string identifier for the TFO encryption extension.
+	TypeStr = "tfoencryption"
+)
+
+// NewFactory creates a new factory for the TFO encryption extension.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(
+		component.MustNewType(TypeStr),
+		createDefaultConfig,
+		createExtension,
+		component.StabilityLevelAlpha,
+	)
+}
+
+// createDefaultConfig creates the default configuration for the extension.
+func createDefaultConfig() component.Config {
+	return &Config{
+		Format: FormatProto,
+	}
+}
+
+// createExtension creates the TFO encryption extension.
+func createExtension(
+	ctx context.Context,
+	set extension.Settings,
+	cfg component.Config,
+) (extension.Extension, error) {
+	return newTFOEncryptionExtension(cfg.(*Config), &set)
+}
@@ -14,12 +14,25 @@
 // limitations under the License.
 package tfoidentityextension
 
+import (
+	"fmt"
+)
+
 // Config defines the configuration for the TFO identity extension.
 type Config struct {
 	// ID is the unique collector identifier.
-	// If empty, a UUID will be auto-generated.
+	// If empty, an ID will be auto-generated using IDScheme.
 	ID string `mapstructure:"id"`
 
+	// IDScheme selects the generator used when ID is empty: uuid4, uuid7
+	// or ulid. uuid7 and ulid produce time-sortable IDs.
+	// Default: uuid4
+	IDScheme IDScheme `mapstructure:"id_scheme"`
+
+	// IDNamespace is an optional prefix (e.g. a site or fleet name) joined
+	// to generated IDs with a dash. It is not applied to a configured ID.
+	IDNamespace string `mapstructure:"id_namespace"`
+
 	// Hostname is the collector hostname.
 	// If empty, it will be auto-detected.
 	Hostname string `mapstructure:"hostname"`
@@ -41,5 +54,14 @@ type Config struct {
 // Validate checks the configuration for errors.
 func (cfg *Config) Validate() error {
 	// No required fields - all can be auto-generated or optional
+	if err := cfg.IDScheme.validate(); err != nil {
+		return err
+	}
+	if cfg.IDNamespace != "" && !namespacePattern.MatchString(cfg.IDNamespace) {
+		return fmt.Errorf("id_namespace %q must be 1-32 lowercase letters, digits or '-' and start with a letter or digit", cfg.IDNamespace)
+	}
+	if cfg.ID != "" {
+		return validateID(cfg.ID)
+	}
 	return nil
 }
@@ -17,6 +17,7 @@
 //
 // The tfoidentityextension provides:
 //   - Collector identification (ID, hostname, name)
+//   - Sortable ID generation (uuid4, uuid7, ulid) with optional namespaces
 //   - Custom tags for labeling and filtering
 //   - Resource enrichment for telemetry data
 //   - Identity provider interface for tfoexporter
@@ -26,6 +27,9 @@
 //	extensions:
 //	  tfoidentity:
 //	    id: "${env:TELEMETRYFLOW_COLLECTOR_ID}"
+//	    # Used only when id is empty: uuid4 (default), uuid7 or ulid
+//	    id_scheme: uuid7
+//	    id_namespace: jkt-dc1
 //	    name: "Production Edge Collector"
 //	    tags:
 //	      environment: production
@@ -18,7 +18,6 @@ import (
 	"context"
 	"os"
 
-	"github.com/google/uuid"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/extension"
 	"go.uber.org/zap"
@@ -49,8 +48,15 @@ func (e *tfoIdentityExtension) Start(ctx context.Context, host component.Host) e
 	// Resolve collector ID
 	e.collectorID = e.cfg.ID
 	if e.collectorID == "" {
-		e.collectorID = uuid.New().String()
-		e.logger.Info("Generated collector ID", zap.String("id", e.collectorID))
+		id, err := generateID(e.cfg.IDScheme, e.cfg.IDNamespace)
+		if err != nil {
+			return err
+		}
+		e.collectorID = id
+		e.logger.Info("Generated collector ID",
+			zap.String("id", e.collectorID),
+			zap.String("id_scheme", string(e.cfg.IDScheme)),
+		)
 	}
 
 	// Resolve hostname
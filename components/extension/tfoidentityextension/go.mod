@@ -4,6 +4,7 @@ go 1.26
 
 require (
 	github.com/google/uuid v1.6.0
+	github.com/oklog/ulid/v2 v2.1.1
 	go.opentelemetry.io/collector/component v1.52.0
 	go.opentelemetry.io/collector/extension v1.52.0
 	go.uber.org/zap v1.27.1
@@ -0,0 +1,93 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoidentityextension
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/google/uuid"
+	"github.com/oklog/ulid/v2"
+)
+
+// IDScheme selects how the collector ID is generated when none is configured.
+type IDScheme string
+
+const (
+	// IDSchemeUUID4 generates random RFC 9562 version 4 UUIDs.
+	IDSchemeUUID4 IDScheme = "uuid4"
+	// IDSchemeUUID7 generates time-ordered RFC 9562 version 7 UUIDs.
+	IDSchemeUUID7 IDScheme = "uuid7"
+	// IDSchemeULID generates lexicographically sortable ULIDs.
+	IDSchemeULID IDScheme = "ulid"
+)
+
+// maxIDLength bounds operator-provided and generated IDs so they fit in
+// headers, resource attributes and fleet database keys.
+const maxIDLength = 128
+
+var (
+	// idPattern is the character set accepted for collector IDs.
+	idPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._:-]*$`)
+	// namespacePattern is the character set accepted for ID namespaces.
+	namespacePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]{0,31}$`)
+)
+
+// validate reports whether s is a known ID scheme.
+func (s IDScheme) validate() error {
+	switch s {
+	case "", IDSchemeUUID4, IDSchemeUUID7, IDSchemeULID:
+		return nil
+	default:
+		return fmt.Errorf("id_scheme must be one of %q, %q or %q, got %q",
+			IDSchemeUUID4, IDSchemeUUID7, IDSchemeULID, s)
+	}
+}
+
+// validateID checks an operator-provided collector ID.
+func validateID(id string) error {
+	if len(id) > maxIDLength {
+		return fmt.Errorf("id must be at most %d characters, got %d", maxIDLength, len(id))
+	}
+	if !idPattern.MatchString(id) {
+		return fmt.Errorf("id %q must start with a letter or digit and contain only letters, digits, '.', '_', ':' or '-'", id)
+	}
+	return nil
+}
+
+// generateID creates a new collector ID using scheme, prefixed with
+// "<namespace>-" when namespace is set.
+func generateID(scheme IDScheme, namespace string) (string, error) {
+	var id string
+	switch scheme {
+	case "", IDSchemeUUID4:
+		id = uuid.NewString()
+	case IDSchemeUUID7:
+		v7, err := uuid.NewV7()
+		if err != nil {
+			return "", fmt.Errorf("failed to generate uuid7: %w", err)
+		}
+		id = v7.String()
+	case IDSchemeULID:
+		id = ulid.Make().String()
+	default:
+		return "", scheme.validate()
+	}
+
+	if namespace != "" {
+		id = namespace + "-" + id
+	}
+	return id, nil
+}
@@ -0,0 +1,82 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfomaintenanceextension
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+)
+
+// maintenancePath is the admin API resource for the maintenance state.
+const maintenancePath = "/maintenance"
+
+// enterRequest is the body of POST /maintenance.
+type enterRequest struct {
+	Mode   Mode   `json:"mode"`
+	Reason string `json:"reason"`
+}
+
+// startAdmin starts the admin API.
+func (e *tfoMaintenanceExtension) startAdmin(ctx context.Context) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc(maintenancePath, e.handleAdmin)
+
+	server, err := e.cfg.StartAdmin(ctx, e.host, "Maintenance admin API", mux, e.logger)
+	if err != nil {
+		return fmt.Errorf("maintenance admin API: %w", err)
+	}
+	e.server = server
+	return nil
+}
+
+// handleAdmin serves the maintenance admin API:
+//
+//	POST   /maintenance  enter maintenance: {"mode": "pause", "reason": "backend migration"}
+//	GET    /maintenance  current state
+//	DELETE /maintenance  leave maintenance
+//
+// The mode of POST defaults to the configured mode. POST during an active
+// maintenance changes its mode and reason.
+func (e *tfoMaintenanceExtension) handleAdmin(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodPost:
+		body := enterRequest{Mode: e.cfg.Mode}
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil && !errors.Is(err, io.EOF) {
+			serverconf.WriteJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid request body: " + err.Error()})
+			return
+		}
+		if err := body.Mode.validate(); err != nil {
+			serverconf.WriteJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+			return
+		}
+		serverconf.WriteJSON(w, http.StatusOK, e.enter(body.Mode, body.Reason))
+	case http.MethodGet:
+		serverconf.WriteJSON(w, http.StatusOK, e.Status())
+	case http.MethodDelete:
+		if !e.resume().Active {
+			serverconf.WriteJSON(w, http.StatusNotFound, map[string]string{"error": "not in maintenance mode"})
+			return
+		}
+		serverconf.WriteJSON(w, http.StatusOK, e.Status())
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
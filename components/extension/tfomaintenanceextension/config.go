@@ -0,0 +1,75 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfomaintenanceextension
+
+import (
+	"fmt"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+)
+
+// Mode selects how the collector behaves in maintenance mode.
+type Mode string
+
+const (
+	// ModePause keeps receivers accepting telemetry while exports are held
+	// back. Held batches wait in the exporters' bounded sending queues.
+	ModePause Mode = "pause"
+
+	// ModeReject makes receivers refuse telemetry with a retryable error
+	// (HTTP 503, gRPC Unavailable) while exports continue to drain.
+	ModeReject Mode = "reject"
+)
+
+// validate checks that m is a known mode.
+func (m Mode) validate() error {
+	switch m {
+	case ModePause, ModeReject:
+		return nil
+	}
+	return fmt.Errorf("mode must be %q or %q, got %q", ModePause, ModeReject, m)
+}
+
+// Config defines the configuration for the TFO maintenance extension.
+type Config struct {
+	// Active starts the collector in maintenance mode, e.g. for the
+	// duration of a backend migration. The admin API ends it.
+	// Default: false
+	Active bool `mapstructure:"active"`
+
+	// Mode is the maintenance behaviour: "pause" or "reject". The admin
+	// API may choose another mode when entering maintenance.
+	// Default: pause
+	Mode Mode `mapstructure:"mode"`
+
+	// Reason describes the maintenance when Active is set. It is reported
+	// by the admin API and in the health status.
+	Reason string `mapstructure:"reason"`
+
+	// AdminConfig defines the admin API listener: endpoint, tls, auth, acl
+	// and the other shared server settings. An empty endpoint disables the
+	// API; maintenance is then controlled by Active alone. An endpoint off
+	// localhost requires auth or mTLS.
+	// Default endpoint: localhost:55692
+	serverconf.AdminConfig `mapstructure:",squash"`
+}
+
+// Validate checks the configuration for errors.
+func (cfg *Config) Validate() error {
+	if err := cfg.Mode.validate(); err != nil {
+		return err
+	}
+	return cfg.AdminConfig.Validate()
+}
@@ -0,0 +1,63 @@
+// Package tfomaintenanceextension provides the TelemetryFlow maintenance mode extension.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// The tfomaintenanceextension provides:
+//   - A maintenance mode for backend migrations, entered at startup with
+//     active: true or at runtime through the admin API
+//   - Pause mode: receivers keep accepting telemetry while tfoexporter
+//     holds exports back; held batches fill the bounded sending queues
+//   - Reject mode: tfootlpreceiver answers HTTP 503 with Retry-After, or
+//     gRPC Unavailable, while exports continue to drain
+//   - tfo_maintenance_active gauge per mode, and a recoverable error on the
+//     health endpoint while maintenance is active
+//
+// Exporters and receivers opt in by referencing the extension in their
+// maintenance setting. In pause mode each held batch waits for the end of
+// the maintenance or for the exporter timeout, whichever comes first, and
+// is then retried until retry_on_failure.max_elapsed_time: size the
+// sending queue and the retry window for the expected maintenance. Batches
+// still held at shutdown are dropped.
+//
+// The admin API serves the /maintenance resource:
+//
+//	POST   /maintenance  enter maintenance: {"mode": "pause", "reason": "backend migration"}
+//	GET    /maintenance  current state: {"active": true, "mode": "pause", "reason": "...", "since": "..."}
+//	DELETE /maintenance  leave maintenance
+//
+// The admin listener takes the shared admin server settings of
+// pkg/serverconf (tls, auth, acl, network, response_headers and the
+// timeouts). An endpoint whose host is not a loopback address is refused
+// unless clients authenticate through auth or a client certificate verified
+// against tls.client_ca_file.
+//
+// Configuration example:
+//
+//	extensions:
+//	  tfomaintenance:
+//	    active: false
+//	    mode: pause
+//	    reason: ""
+//	    endpoint: localhost:55692
+//
+//	receivers:
+//	  tfootlp:
+//	    maintenance: tfomaintenance
+//
+//	exporters:
+//	  tfo:
+//	    maintenance: tfomaintenance
+package tfomaintenanceextension // import "github.com/telemetryflow/telemetryflow-collector/components/extension/tfomaintenanceextension"
@@ -0,0 +1,238 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfomaintenanceextension
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+)
+
+const scopeName = "github.com/telemetryflow/telemetryflow-collector/components/extension/tfomaintenanceextension"
+
+// Status describes the maintenance state.
+type Status struct {
+	Active bool      `json:"active"`
+	Mode   Mode      `json:"mode,omitempty"`
+	Reason string    `json:"reason,omitempty"`
+	Since  time.Time `json:"since,omitzero"`
+}
+
+// tfoMaintenanceExtension holds the maintenance state shared by receivers
+// and exporters.
+type tfoMaintenanceExtension struct {
+	cfg      *Config
+	settings *extension.Settings
+	logger   *zap.Logger
+	host     component.Host
+
+	mu     sync.RWMutex
+	status Status
+	// resumed is closed when a pause ends; nil unless paused.
+	resumed chan struct{}
+
+	registration metric.Registration
+	server       *serverconf.AdminServer
+}
+
+// newTFOMaintenanceExtension creates a new TFO maintenance extension.
+func newTFOMaintenanceExtension(cfg *Config, set *extension.Settings) (*tfoMaintenanceExtension, error) {
+	return &tfoMaintenanceExtension{
+		cfg:      cfg,
+		settings: set,
+		logger:   set.Logger,
+	}, nil
+}
+
+// Start implements component.Component.
+func (e *tfoMaintenanceExtension) Start(ctx context.Context, host component.Host) error {
+	e.host = host
+
+	if e.settings.MeterProvider != nil {
+		meter := e.settings.MeterProvider.Meter(scopeName)
+		gauge, err := meter.Int64ObservableGauge(selfmetrics.MaintenanceActive,
+			metric.WithDescription("1 while the collector is in maintenance mode with the given mode, 0 otherwise."))
+		if err != nil {
+			return err
+		}
+		labels := selfmetrics.Extension(e.settings.ID)
+		e.registration, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+			status := e.Status()
+			for _, mode := range []Mode{ModePause, ModeReject} {
+				var v int64
+				if status.Active && status.Mode == mode {
+					v = 1
+				}
+				o.ObserveInt64(gauge, v, labels.Option(attribute.String("mode", string(mode))))
+			}
+			return nil
+		}, gauge)
+		if err != nil {
+			return err
+		}
+	}
+
+	if e.cfg.Active {
+		e.enter(e.cfg.Mode, e.cfg.Reason)
+	}
+
+	if e.cfg.NetAddr.Endpoint != "" {
+		if err := e.startAdmin(ctx); err != nil {
+			return err
+		}
+	}
+
+	e.logger.Info("TFO maintenance extension started",
+		zap.Bool("active", e.cfg.Active),
+		zap.String("mode", string(e.cfg.Mode)),
+		zap.String("endpoint", e.cfg.NetAddr.Endpoint),
+	)
+
+	return nil
+}
+
+// Shutdown implements component.Component.
+func (e *tfoMaintenanceExtension) Shutdown(ctx context.Context) error {
+	err := e.server.Shutdown(ctx)
+	e.server = nil
+	if e.registration != nil {
+		err = errors.Join(err, e.registration.Unregister())
+		e.registration = nil
+	}
+
+	// Release exporters still waiting for the pause to end.
+	e.mu.Lock()
+	if e.resumed != nil {
+		close(e.resumed)
+		e.resumed = nil
+	}
+	e.mu.Unlock()
+
+	e.logger.Info("TFO maintenance extension stopped")
+	return err
+}
+
+// Status returns the current maintenance state.
+func (e *tfoMaintenanceExtension) Status() Status {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.status
+}
+
+// Rejecting reports whether receivers should refuse telemetry.
+// Implements the MaintenanceProvider interface for tfootlpreceiver.
+func (e *tfoMaintenanceExtension) Rejecting() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.status.Active && e.status.Mode == ModeReject
+}
+
+// Wait blocks while exports are paused, until maintenance ends or ctx is
+// done, and returns ctx.Err() in the latter case.
+// Implements the MaintenanceProvider interface for tfoexporter.
+func (e *tfoMaintenanceExtension) Wait(ctx context.Context) error {
+	e.mu.RLock()
+	resumed := e.resumed
+	e.mu.RUnlock()
+	if resumed == nil {
+		return nil
+	}
+	select {
+	case <-resumed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// enter puts the collector in maintenance mode, or changes the mode and
+// reason of an active maintenance, and returns the new state.
+func (e *tfoMaintenanceExtension) enter(mode Mode, reason string) Status {
+	e.mu.Lock()
+	if !e.status.Active {
+		e.status.Since = time.Now()
+	}
+	previous := e.status.Mode
+	e.status.Active = true
+	e.status.Mode = mode
+	e.status.Reason = reason
+	switch {
+	case mode == ModePause && e.resumed == nil:
+		e.resumed = make(chan struct{})
+	case mode != ModePause && e.resumed != nil:
+		close(e.resumed)
+		e.resumed = nil
+	}
+	status := e.status
+	e.mu.Unlock()
+
+	e.logger.Warn("Entered maintenance mode",
+		zap.String("mode", string(mode)),
+		zap.String("previous_mode", string(previous)),
+		zap.String("reason", reason),
+	)
+	e.reportStatus(componentstatus.NewRecoverableErrorEvent(maintenanceError(status)))
+	return status
+}
+
+// resume ends maintenance mode and returns the previous state, which is
+// inactive if there was no maintenance to end.
+func (e *tfoMaintenanceExtension) resume() Status {
+	e.mu.Lock()
+	previous := e.status
+	e.status = Status{}
+	if e.resumed != nil {
+		close(e.resumed)
+		e.resumed = nil
+	}
+	e.mu.Unlock()
+
+	if previous.Active {
+		e.logger.Info("Left maintenance mode",
+			zap.String("mode", string(previous.Mode)),
+			zap.Duration("duration", time.Since(previous.Since)),
+		)
+		e.reportStatus(componentstatus.NewEvent(componentstatus.StatusOK))
+	}
+	return previous
+}
+
+// reportStatus reports ev on the health endpoint once the extension has a
+// host.
+func (e *tfoMaintenanceExtension) reportStatus(ev *componentstatus.Event) {
+	if e.host != nil {
+		componentstatus.ReportStatus(e.host, ev)
+	}
+}
+
+// maintenanceError describes an active maintenance in the health status.
+func maintenanceError(s Status) error {
+	if s.Reason == "" {
+		return fmt.Errorf("maintenance mode (%s)", s.Mode)
+	}
+	return fmt.Errorf("maintenance mode (%s): %s", s.Mode, s.Reason)
+}
@@ -0,0 +1,91 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoopampextension
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+// Config defines the configuration for the TFO OpAMP extension.
+type Config struct {
+	// Endpoint is the OpAMP server URL. ws:// and wss:// connect over a
+	// WebSocket, http:// and https:// poll the server over plain HTTP.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Headers are sent with every request to the server, e.g. for
+	// authentication.
+	Headers map[string]configopaque.String `mapstructure:"headers"`
+
+	// TLS configures the connection to a wss:// or https:// endpoint.
+	TLS configtls.ClientConfig `mapstructure:"tls"`
+
+	// CollectorIdentity references the tfoidentity extension whose collector
+	// ID, name and tags identify the collector to the server. The hostname
+	// is used when empty.
+	CollectorIdentity component.ID `mapstructure:"collector_identity"`
+
+	// RemoteConfig configures configurations pushed by the server.
+	RemoteConfig RemoteConfigConfig `mapstructure:"remote_config"`
+}
+
+// RemoteConfigConfig defines how configurations pushed by the server are
+// applied.
+type RemoteConfigConfig struct {
+	// Accept lets the server replace the collector configuration. Pushed
+	// configurations are validated before they are written and applied
+	// through the configuration reload path.
+	// Default: false
+	Accept bool `mapstructure:"accept"`
+
+	// Path is the configuration file replaced by pushed configurations. It
+	// must be one of the files the collector was started with. The previous
+	// content is kept in Path with a ".bak" suffix and the last applied
+	// configuration is recorded in Path with a ".opamp" suffix.
+	Path string `mapstructure:"path"`
+}
+
+// Validate checks the configuration for errors.
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("endpoint is required")
+	}
+	u, err := url.Parse(cfg.Endpoint)
+	if err != nil {
+		return fmt.Errorf("invalid endpoint %q: %w", cfg.Endpoint, err)
+	}
+	switch u.Scheme {
+	case "ws", "wss", "http", "https":
+	default:
+		return fmt.Errorf("invalid endpoint %q: scheme must be ws, wss, http or https", cfg.Endpoint)
+	}
+	if u.Host == "" {
+		return fmt.Errorf("invalid endpoint %q: missing host", cfg.Endpoint)
+	}
+	return cfg.RemoteConfig.Validate()
+}
+
+// Validate checks the remote configuration settings for errors.
+func (cfg *RemoteConfigConfig) Validate() error {
+	if cfg.Accept && cfg.Path == "" {
+		return errors.New("remote_config: path is required when accept is true")
+	}
+	return nil
+}
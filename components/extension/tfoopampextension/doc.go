@@ -0,0 +1,50 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// The tfoopampextension provides:
+//   - A connection to an OpAMP server over WebSocket (ws, wss) or HTTP
+//     polling (http, https) for fleet-wide management
+//   - Collector identity reported in the agent description, taken from the
+//     tfoidentity extension: collector ID, name, hostname and tags
+//   - Collector health reported from the component statuses, unhealthy
+//     while a component reports an error
+//   - Remote configuration: with remote_config.accept, configurations
+//     pushed by the server are validated, written to remote_config.path
+//     and applied through the configuration reload path
+//
+// A pushed configuration holds a single file. It is validated against the
+// collector's component factories, the previous file is kept with a ".bak"
+// suffix, and the collector reloads on SIGHUP; an invalid configuration is
+// reported as FAILED and leaves the running configuration untouched. The
+// status of the last pushed configuration is kept with a ".opamp" suffix
+// so that it survives the reload.
+//
+// Configuration example:
+//
+//	extensions:
+//	  tfoidentity:
+//	    name: "edge-collector-01"
+//	  tfoopamp:
+//	    endpoint: wss://opamp.telemetryflow.id/v1/opamp
+//	    headers:
+//	      Authorization: "Bearer ${env:TELEMETRYFLOW_OPAMP_TOKEN}"
+//	    collector_identity: tfoidentity
+//	    remote_config:
+//	      accept: true
+//	      path: /etc/tfo-collector/tfo-collector.yaml
+//
+//	service:
+//	  extensions: [tfoidentity, tfoopamp]
+package tfoopampextension // import "github.com/telemetryflow/telemetryflow-collector/components/extension/tfoopampextension"
@@ -0,0 +1,317 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoopampextension
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/open-telemetry/opamp-go/client"
+	"github.com/open-telemetry/opamp-go/client/types"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/extension"
+	"go.uber.org/zap"
+)
+
+// IdentityProvider is an interface for extensions that provide collector
+// identity.
+type IdentityProvider interface {
+	GetCollectorID() string
+	GetHostname() string
+	GetName() string
+	GetTags() map[string]string
+}
+
+// tfoOpAMPExtension connects the collector to an OpAMP server.
+type tfoOpAMPExtension struct {
+	cfg      *Config
+	settings *extension.Settings
+	logger   *zap.Logger
+	validate ConfigValidator
+
+	client    client.OpAMPClient
+	startTime time.Time
+
+	mu sync.Mutex
+	// statuses holds the last status event of each component, keyed by
+	// kind and ID, e.g. "receiver:tfootlp".
+	statuses map[string]*componentstatus.Event
+	// state is the last pushed configuration and its status.
+	state remoteConfigState
+}
+
+// newTFOOpAMPExtension creates a new TFO OpAMP extension.
+func newTFOOpAMPExtension(cfg *Config, set *extension.Settings, validate ConfigValidator) (*tfoOpAMPExtension, error) {
+	return &tfoOpAMPExtension{
+		cfg:      cfg,
+		settings: set,
+		logger:   set.Logger,
+		validate: validate,
+		statuses: make(map[string]*componentstatus.Event),
+	}, nil
+}
+
+// Dependencies starts the identity extension first.
+// Implements extensioncapabilities.Dependent.
+func (e *tfoOpAMPExtension) Dependencies() []component.ID {
+	if e.cfg.CollectorIdentity.String() == "" {
+		return nil
+	}
+	return []component.ID{e.cfg.CollectorIdentity}
+}
+
+// Start implements component.Component.
+func (e *tfoOpAMPExtension) Start(ctx context.Context, host component.Host) error {
+	identity, err := resolveIdentity(e.cfg.CollectorIdentity, host)
+	if err != nil {
+		return err
+	}
+	description, instanceID := e.agentDescription(identity)
+
+	settings := types.StartSettings{
+		OpAMPServerURL: e.cfg.Endpoint,
+		Header:         make(http.Header, len(e.cfg.Headers)),
+		InstanceUid:    types.InstanceUid(uuid.NewSHA1(uuid.NameSpaceOID, []byte(instanceID))),
+		Callbacks: types.Callbacks{
+			OnConnect: func(context.Context) {
+				e.logger.Info("Connected to OpAMP server", zap.String("endpoint", e.cfg.Endpoint))
+			},
+			OnConnectFailed: func(_ context.Context, err error) {
+				e.logger.Warn("Failed to connect to OpAMP server", zap.String("endpoint", e.cfg.Endpoint), zap.Error(err))
+			},
+			OnError: func(_ context.Context, resp *protobufs.ServerErrorResponse) {
+				e.logger.Warn("OpAMP server returned an error", zap.String("message", resp.GetErrorMessage()))
+			},
+			OnMessage: e.onMessage,
+		},
+		Capabilities: protobufs.AgentCapabilities_AgentCapabilities_ReportsStatus |
+			protobufs.AgentCapabilities_AgentCapabilities_ReportsHealth |
+			protobufs.AgentCapabilities_AgentCapabilities_ReportsHeartbeat,
+	}
+	for name, value := range e.cfg.Headers {
+		settings.Header.Set(name, string(value))
+	}
+	if u, _ := url.Parse(e.cfg.Endpoint); u.Scheme == "wss" || u.Scheme == "https" {
+		tlsConfig, err := e.cfg.TLS.LoadTLSConfig(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to load TLS config: %w", err)
+		}
+		settings.TLSConfig = tlsConfig
+	}
+	if e.cfg.RemoteConfig.Accept {
+		state, err := readState(statePath(e.cfg.RemoteConfig.Path))
+		if err != nil {
+			return fmt.Errorf("failed to read remote config state: %w", err)
+		}
+		e.state = state
+		settings.RemoteConfigStatus = state.status()
+		settings.Capabilities |= protobufs.AgentCapabilities_AgentCapabilities_AcceptsRemoteConfig |
+			protobufs.AgentCapabilities_AgentCapabilities_ReportsRemoteConfig |
+			protobufs.AgentCapabilities_AgentCapabilities_ReportsEffectiveConfig
+		settings.Callbacks.GetEffectiveConfig = e.effectiveConfig
+	}
+
+	opampLogger := &clientLogger{logger: e.logger.Sugar()}
+	var c client.OpAMPClient
+	if strings.HasPrefix(e.cfg.Endpoint, "ws") {
+		c = client.NewWebSocket(opampLogger)
+	} else {
+		c = client.NewHTTP(opampLogger)
+	}
+	if err := c.SetAgentDescription(description); err != nil {
+		return err
+	}
+
+	e.mu.Lock()
+	e.startTime = time.Now()
+	if err := c.SetHealth(e.healthLocked()); err != nil {
+		e.mu.Unlock()
+		return err
+	}
+	e.mu.Unlock()
+
+	if err := c.Start(ctx, settings); err != nil {
+		return fmt.Errorf("failed to start OpAMP client: %w", err)
+	}
+	e.mu.Lock()
+	e.client = c
+	e.mu.Unlock()
+
+	e.logger.Info("TFO OpAMP extension started",
+		zap.String("endpoint", e.cfg.Endpoint),
+		zap.String("instance_id", instanceID),
+		zap.Bool("accept_remote_config", e.cfg.RemoteConfig.Accept),
+	)
+
+	return nil
+}
+
+// Shutdown implements component.Component.
+func (e *tfoOpAMPExtension) Shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	c := e.client
+	e.client = nil
+	e.mu.Unlock()
+	if c == nil {
+		return nil
+	}
+	err := c.Stop(ctx)
+	e.logger.Info("TFO OpAMP extension stopped")
+	return err
+}
+
+// ComponentStatusChanged reports the health of the collector to the
+// server. Implements componentstatus.Watcher.
+func (e *tfoOpAMPExtension) ComponentStatusChanged(source *componentstatus.InstanceID, event *componentstatus.Event) {
+	key := strings.ToLower(source.Kind().String()) + ":" + source.ComponentID().String()
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.statuses[key] = event
+	if e.client == nil {
+		return
+	}
+	if err := e.client.SetHealth(e.healthLocked()); err != nil {
+		e.logger.Warn("Failed to report health to OpAMP server", zap.Error(err))
+	}
+}
+
+// healthLocked returns the collector health: unhealthy while a component
+// reports an error, with the per-component statuses attached. e.mu must be
+// held.
+func (e *tfoOpAMPExtension) healthLocked() *protobufs.ComponentHealth {
+	health := &protobufs.ComponentHealth{
+		Healthy:            true,
+		StartTimeUnixNano:  uint64(e.startTime.UnixNano()),
+		Status:             componentstatus.StatusOK.String(),
+		StatusTimeUnixNano: uint64(time.Now().UnixNano()),
+		ComponentHealthMap: make(map[string]*protobufs.ComponentHealth, len(e.statuses)),
+	}
+
+	keys := make([]string, 0, len(e.statuses))
+	for key := range e.statuses {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		event := e.statuses[key]
+		component := &protobufs.ComponentHealth{
+			Healthy:            !componentstatus.StatusIsError(event.Status()),
+			Status:             event.Status().String(),
+			StatusTimeUnixNano: uint64(event.Timestamp().UnixNano()),
+		}
+		if err := event.Err(); err != nil {
+			component.LastError = err.Error()
+		}
+		if !component.Healthy && health.Healthy {
+			health.Healthy = false
+			health.Status = component.Status
+			health.LastError = key + ": " + component.LastError
+		}
+		health.ComponentHealthMap[key] = component
+	}
+	return health
+}
+
+// resolveIdentity returns the tfoidentity extension referenced by id, or
+// nil when id is empty.
+func resolveIdentity(id component.ID, host component.Host) (IdentityProvider, error) {
+	if id.String() == "" {
+		return nil, nil
+	}
+	ext := host.GetExtensions()[id]
+	if ext == nil {
+		return nil, fmt.Errorf("tfoidentity extension %q not found", id)
+	}
+	provider, ok := ext.(IdentityProvider)
+	if !ok {
+		return nil, fmt.Errorf("extension %q does not provide a collector identity", id)
+	}
+	return provider, nil
+}
+
+// agentDescription describes the collector to the server and returns the
+// ID identifying its instance.
+func (e *tfoOpAMPExtension) agentDescription(identity IdentityProvider) (*protobufs.AgentDescription, string) {
+	hostname, _ := os.Hostname()
+	instanceID, name := hostname, ""
+	var tags map[string]string
+	if identity != nil {
+		instanceID = identity.GetCollectorID()
+		hostname = identity.GetHostname()
+		name = identity.GetName()
+		tags = identity.GetTags()
+	}
+
+	info := e.settings.BuildInfo
+	description := &protobufs.AgentDescription{
+		IdentifyingAttributes: []*protobufs.KeyValue{
+			stringKeyValue("service.name", info.Command),
+			stringKeyValue("service.version", info.Version),
+			stringKeyValue("service.instance.id", instanceID),
+		},
+		NonIdentifyingAttributes: []*protobufs.KeyValue{
+			stringKeyValue("host.name", hostname),
+			stringKeyValue("os.type", runtime.GOOS),
+			stringKeyValue("host.arch", runtime.GOARCH),
+		},
+	}
+	if name != "" {
+		description.NonIdentifyingAttributes = append(description.NonIdentifyingAttributes,
+			stringKeyValue("telemetryflow.collector.name", name))
+	}
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		description.NonIdentifyingAttributes = append(description.NonIdentifyingAttributes,
+			stringKeyValue("telemetryflow.collector.tag."+key, tags[key]))
+	}
+	return description, instanceID
+}
+
+// stringKeyValue returns a string attribute.
+func stringKeyValue(key, value string) *protobufs.KeyValue {
+	return &protobufs.KeyValue{
+		Key:   key,
+		Value: &protobufs.AnyValue{Value: &protobufs.AnyValue_StringValue{StringValue: value}},
+	}
+}
+
+// clientLogger adapts a zap logger to the OpAMP client.
+type clientLogger struct {
+	logger *zap.SugaredLogger
+}
+
+func (l *clientLogger) Debugf(_ context.Context, format string, v ...any) {
+	l.logger.Debugf(format, v...)
+}
+
+func (l *clientLogger) Errorf(_ context.Context, format string, v ...any) {
+	l.logger.Errorf(format, v...)
+}
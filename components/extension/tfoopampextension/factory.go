@@ -0,0 +1,57 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoopampextension
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+)
+
+const (
+	// TypeStr is the type string identifier for the TFO OpAMP extension.
+	TypeStr = "tfoopamp"
+)
+
+// ConfigValidator checks a pushed configuration before it is applied.
+// candidate is a file holding the pushed configuration, written next to
+// path, the configuration file it is about to replace.
+type ConfigValidator func(ctx context.Context, path, candidate string) error
+
+// NewFactory creates a new factory for the TFO OpAMP extension. Pushed
+// configurations are only checked to be YAML with a service section; use
+// NewFactoryWithValidator to validate them against the component factories.
+func NewFactory() extension.Factory {
+	return NewFactoryWithValidator(nil)
+}
+
+// NewFactoryWithValidator creates a new factory for the TFO OpAMP extension
+// that checks pushed configurations with validate before applying them.
+func NewFactoryWithValidator(validate ConfigValidator) extension.Factory {
+	return extension.NewFactory(
+		component.MustNewType(TypeStr),
+		createDefaultConfig,
+		func(ctx context.Context, set extension.Settings, cfg component.Config) (extension.Extension, error) {
+			return newTFOOpAMPExtension(cfg.(*Config), &set, validate)
+		},
+		component.StabilityLevelAlpha,
+	)
+}
+
+// createDefaultConfig creates the default configuration for the extension.
+func createDefaultConfig() component.Config {
+	return &Config{}
+}
@@ -0,0 +1,251 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoopampextension
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"syscall"
+
+	"github.com/open-telemetry/opamp-go/client/types"
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"go.opentelemetry.io/collector/confmap"
+	"go.uber.org/zap"
+)
+
+// remoteConfigState is the last configuration pushed by the server and
+// the outcome of applying it. It is persisted next to the configuration
+// file so that a reloaded or restarted collector reports it to the server
+// instead of applying the same configuration again.
+type remoteConfigState struct {
+	Hash         []byte                         `json:"hash,omitempty"`
+	Status       protobufs.RemoteConfigStatuses `json:"status,omitempty"`
+	ErrorMessage string                         `json:"error_message,omitempty"`
+}
+
+// status returns the state as reported to the server.
+func (s remoteConfigState) status() *protobufs.RemoteConfigStatus {
+	return &protobufs.RemoteConfigStatus{
+		LastRemoteConfigHash: s.Hash,
+		Status:               s.Status,
+		ErrorMessage:         s.ErrorMessage,
+	}
+}
+
+// statePath returns the state file of the configuration file at path.
+func statePath(path string) string {
+	return path + ".opamp"
+}
+
+// readState reads the state file. A missing file holds no state.
+func readState(path string) (remoteConfigState, error) {
+	var state remoteConfigState
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return state, err
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return state, fmt.Errorf("%s: %w", path, err)
+	}
+	return state, nil
+}
+
+// onMessage applies the configuration pushed by the server.
+func (e *tfoOpAMPExtension) onMessage(ctx context.Context, msg *types.MessageData) {
+	if msg.RemoteConfig == nil || !e.cfg.RemoteConfig.Accept {
+		return
+	}
+	remote := msg.RemoteConfig
+
+	e.mu.Lock()
+	applied := bytes.Equal(remote.GetConfigHash(), e.state.Hash)
+	e.mu.Unlock()
+	if applied {
+		return
+	}
+
+	e.setRemoteConfigStatus(remote.GetConfigHash(), protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLYING, nil)
+	if err := e.applyRemoteConfig(ctx, remote); err != nil {
+		e.logger.Error("Failed to apply remote configuration", zap.Error(err))
+		e.setRemoteConfigStatus(remote.GetConfigHash(), protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED, err)
+		return
+	}
+	e.logger.Info("Applied remote configuration, reloading",
+		zap.String("path", e.cfg.RemoteConfig.Path),
+		zap.String("hash", fmt.Sprintf("%x", remote.GetConfigHash())),
+	)
+}
+
+// applyRemoteConfig validates the pushed configuration, replaces the
+// configuration file with it and reloads the collector. The previous
+// configuration file is restored when the reload cannot be requested.
+func (e *tfoOpAMPExtension) applyRemoteConfig(ctx context.Context, remote *protobufs.AgentRemoteConfig) error {
+	files := remote.GetConfig().GetConfigMap()
+	if len(files) != 1 {
+		return fmt.Errorf("expected one configuration file, got %d", len(files))
+	}
+	var body []byte
+	for _, file := range files {
+		body = file.GetBody()
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		return errors.New("configuration is empty")
+	}
+
+	path := e.cfg.RemoteConfig.Path
+	previous, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	mode := os.FileMode(0o600)
+	if info, err := os.Stat(path); err == nil {
+		mode = info.Mode().Perm()
+	}
+
+	// The candidate keeps the extension of path so that it is decoded the
+	// same way.
+	candidate := filepath.Join(filepath.Dir(path), ".opamp-"+filepath.Base(path))
+	if err := writeFile(candidate, body, mode); err != nil {
+		return err
+	}
+	defer os.Remove(candidate)
+	if err := e.validateConfig(ctx, path, candidate, body); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+
+	if err := writeFile(path+".bak", previous, mode); err != nil {
+		return fmt.Errorf("failed to back up %s: %w", path, err)
+	}
+	if err := os.Rename(candidate, path); err != nil {
+		return err
+	}
+
+	// The reload replaces this extension: record the outcome first.
+	e.setRemoteConfigStatus(remote.GetConfigHash(), protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED, nil)
+	if err := reload(); err != nil {
+		if restoreErr := writeFile(path, previous, mode); restoreErr != nil {
+			err = errors.Join(err, restoreErr)
+		}
+		return fmt.Errorf("failed to reload: %w", err)
+	}
+	return nil
+}
+
+// validateConfig checks the candidate configuration with the injected
+// validator, or checks that it is YAML with a service section.
+func (e *tfoOpAMPExtension) validateConfig(ctx context.Context, path, candidate string, body []byte) error {
+	if e.validate != nil {
+		return e.validate(ctx, path, candidate)
+	}
+	conf, err := confmap.NewRetrievedFromYAML(body)
+	if err != nil {
+		return err
+	}
+	raw, err := conf.AsRaw()
+	if err != nil {
+		return err
+	}
+	if m, ok := raw.(map[string]any); !ok || m["service"] == nil {
+		return errors.New("missing service section")
+	}
+	return nil
+}
+
+// setRemoteConfigStatus records and reports the status of the pushed
+// configuration with the given hash.
+func (e *tfoOpAMPExtension) setRemoteConfigStatus(hash []byte, status protobufs.RemoteConfigStatuses, err error) {
+	state := remoteConfigState{Hash: hash, Status: status}
+	if err != nil {
+		state.ErrorMessage = err.Error()
+	}
+
+	e.mu.Lock()
+	e.state = state
+	c := e.client
+	e.mu.Unlock()
+
+	if status != protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLYING {
+		if data, err := json.Marshal(state); err != nil {
+			e.logger.Warn("Failed to encode remote config state", zap.Error(err))
+		} else if err := writeFile(statePath(e.cfg.RemoteConfig.Path), data, 0o600); err != nil {
+			e.logger.Warn("Failed to persist remote config state", zap.Error(err))
+		}
+	}
+	if c != nil {
+		if err := c.SetRemoteConfigStatus(state.status()); err != nil {
+			e.logger.Warn("Failed to report remote config status", zap.Error(err))
+		}
+	}
+}
+
+// effectiveConfig returns the content of the configuration file replaced
+// by pushed configurations.
+func (e *tfoOpAMPExtension) effectiveConfig(context.Context) (*protobufs.EffectiveConfig, error) {
+	body, err := os.ReadFile(e.cfg.RemoteConfig.Path)
+	if err != nil {
+		return nil, err
+	}
+	return &protobufs.EffectiveConfig{
+		ConfigMap: &protobufs.AgentConfigMap{
+			ConfigMap: map[string]*protobufs.AgentConfigFile{
+				"": {Body: body, ContentType: "text/yaml"},
+			},
+		},
+	}, nil
+}
+
+// reload asks the collector to reload its configuration files, which it
+// does on SIGHUP.
+func reload() error {
+	p, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		return err
+	}
+	return p.Signal(syscall.SIGHUP)
+}
+
+// writeFile replaces the file at path through a temporary file in the same
+// directory, so that a crash leaves either the old or the new file.
+func writeFile(path string, data []byte, mode os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(mode); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
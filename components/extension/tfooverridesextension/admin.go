@@ -0,0 +1,95 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfooverridesextension
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+)
+
+const (
+	// overridesPath is the admin API resource for the runtime overrides.
+	overridesPath = "/overrides"
+
+	// maxBodySize bounds the request bodies of the admin API.
+	maxBodySize = 1 << 20
+)
+
+// startAdmin starts the admin API.
+func (e *tfoOverridesExtension) startAdmin(ctx context.Context, host component.Host) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc(overridesPath, e.handleAdmin)
+
+	server, err := e.cfg.StartAdmin(ctx, host, "Overrides admin API", mux, e.logger)
+	if err != nil {
+		return fmt.Errorf("overrides admin API: %w", err)
+	}
+	e.server = server
+	return nil
+}
+
+// handleAdmin serves the overrides admin API:
+//
+//	GET    /overrides  current overrides, with secrets and header values redacted
+//	PATCH  /overrides  merge a JSON merge patch (RFC 7386) into the overrides
+//	PUT    /overrides  replace all overrides
+//	DELETE /overrides  remove all overrides
+//
+// A change is validated as a whole and applied atomically; an invalid
+// change leaves the overrides untouched.
+func (e *tfoOverridesExtension) handleAdmin(w http.ResponseWriter, req *http.Request) {
+	var change func(Overrides) (Overrides, error)
+	switch req.Method {
+	case http.MethodGet:
+		serverconf.WriteJSON(w, http.StatusOK, e.Overrides().redact())
+		return
+	case http.MethodPatch, http.MethodPut:
+		body, err := io.ReadAll(http.MaxBytesReader(w, req.Body, maxBodySize))
+		if err != nil {
+			serverconf.WriteJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid request body: " + err.Error()})
+			return
+		}
+		if req.Method == http.MethodPatch {
+			change = func(o Overrides) (Overrides, error) { return o.patch(body) }
+		} else {
+			change = func(Overrides) (Overrides, error) { return decodeOverrides(body) }
+		}
+	case http.MethodDelete:
+		change = func(Overrides) (Overrides, error) { return Overrides{}, nil }
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	o, err := e.update(change)
+	var perr *persistError
+	switch {
+	case errors.As(err, &perr):
+		e.logger.Error("Failed to update runtime overrides", zap.Error(err))
+		serverconf.WriteJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	case err != nil:
+		serverconf.WriteJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid overrides: " + err.Error()})
+	default:
+		serverconf.WriteJSON(w, http.StatusOK, o.redact())
+	}
+}
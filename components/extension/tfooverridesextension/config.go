@@ -0,0 +1,45 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfooverridesextension
+
+import (
+	"errors"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+)
+
+// Config defines the configuration for the TFO overrides extension.
+type Config struct {
+	// AdminConfig defines the admin API listener: endpoint, tls, auth, acl
+	// and the other shared server settings. The API changes exporter
+	// credentials and limits, so an endpoint off localhost requires auth or
+	// mTLS.
+	// Default endpoint: localhost:55693
+	serverconf.AdminConfig `mapstructure:",squash"`
+
+	// File persists the overrides as JSON. It is read at start and
+	// rewritten on every change, so that overrides survive restarts and
+	// configuration reloads. Empty keeps them in memory only.
+	// Default: ""
+	File string `mapstructure:"file"`
+}
+
+// Validate checks the configuration for errors.
+func (cfg *Config) Validate() error {
+	if cfg.NetAddr.Endpoint == "" {
+		return errors.New("endpoint must not be empty")
+	}
+	return cfg.AdminConfig.Validate()
+}
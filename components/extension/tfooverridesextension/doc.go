@@ -0,0 +1,77 @@
+// Package tfooverridesextension provides the TelemetryFlow runtime overrides extension.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// The tfooverridesextension provides:
+//   - Runtime changes of a small set of parameters without a configuration
+//     reload: tfo exporter headers and API keys, tfootlp receiver rate
+//     limits and sampling percentages, tfomirror connector percentages and
+//     the log level
+//   - An admin API that validates each change as a whole and applies it
+//     atomically; listeners, pipelines and other settings are untouched
+//   - An overrides file rewritten on every change and applied again at
+//     start, so that overrides survive restarts and configuration reloads
+//
+// Components opt in by referencing the extension in their overrides
+// setting, and read the overrides on every request or batch. Overrides of
+// components that do not reference the extension have no effect. Removing
+// an override restores the configured value. The log level applies to
+// collectors built with the TFO registry, whose logger reads it.
+//
+// The admin API serves the /overrides resource:
+//
+//	GET    /overrides  current overrides, with secrets and header values redacted
+//	PATCH  /overrides  merge a JSON merge patch (RFC 7386) into the overrides
+//	PUT    /overrides  replace all overrides
+//	DELETE /overrides  remove all overrides
+//
+// For example, to rotate the API key of the tfo exporter and lower the
+// rate limit of the tfootlp receiver:
+//
+//	curl -X PATCH localhost:55693/overrides -d '{
+//	  "exporters": {"tfo": {"api_key_id": "tfk_new", "api_key_secret": "tfs_new"}},
+//	  "receivers": {"tfootlp": {"rate_limit": {"records_per_second": 5000}}}
+//	}'
+//
+// and to remove the rate limit override again:
+//
+//	curl -X PATCH localhost:55693/overrides -d '{"receivers": {"tfootlp": null}}'
+//
+// The API can replace exporter credentials, so its listener takes the shared
+// admin server settings of pkg/serverconf (tls, auth, acl, network,
+// response_headers and the timeouts). An endpoint whose host is not a
+// loopback address is refused unless clients authenticate through auth or a
+// client certificate verified against tls.client_ca_file.
+//
+// Configuration example:
+//
+//	extensions:
+//	  tfooverrides:
+//	    endpoint: localhost:55693
+//	    file: /var/lib/tfo-collector/overrides.json
+//
+//	receivers:
+//	  tfootlp:
+//	    overrides: tfooverrides
+//
+//	exporters:
+//	  tfo:
+//	    overrides: tfooverrides
+//
+//	connectors:
+//	  tfomirror:
+//	    overrides: tfooverrides
+package tfooverridesextension // import "github.com/telemetryflow/telemetryflow-collector/components/extension/tfooverridesextension"
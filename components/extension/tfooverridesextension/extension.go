@@ -0,0 +1,284 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfooverridesextension
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/loglevel"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+)
+
+// state is an immutable set of overrides indexed by component ID.
+type state struct {
+	overrides  Overrides
+	exporters  map[component.ID]ExporterOverrides
+	receivers  map[component.ID]ReceiverOverrides
+	connectors map[component.ID]ConnectorOverrides
+}
+
+// newState validates o and indexes it by component ID.
+func newState(o Overrides) (*state, error) {
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+	return &state{
+		overrides:  o,
+		exporters:  index(o.Exporters),
+		receivers:  index(o.Receivers),
+		connectors: index(o.Connectors),
+	}, nil
+}
+
+// index keys validated entries by component ID.
+func index[V any](entries map[string]V) map[component.ID]V {
+	m := make(map[component.ID]V, len(entries))
+	for key, v := range entries {
+		id, _ := parseID(key)
+		m[id] = v
+	}
+	return m
+}
+
+// tfoOverridesExtension holds the runtime overrides read by exporters,
+// receivers and connectors on every use.
+type tfoOverridesExtension struct {
+	cfg      *Config
+	settings *extension.Settings
+	logger   *zap.Logger
+
+	// mu serializes updates; readers only load state.
+	mu    sync.Mutex
+	state atomic.Pointer[state]
+
+	server *serverconf.AdminServer
+}
+
+// newTFOOverridesExtension creates a new TFO overrides extension.
+func newTFOOverridesExtension(cfg *Config, set *extension.Settings) (*tfoOverridesExtension, error) {
+	e := &tfoOverridesExtension{
+		cfg:      cfg,
+		settings: set,
+		logger:   set.Logger,
+	}
+	e.state.Store(&state{})
+	return e, nil
+}
+
+// Start implements component.Component.
+func (e *tfoOverridesExtension) Start(ctx context.Context, host component.Host) error {
+	if e.cfg.File != "" {
+		o, err := readFile(e.cfg.File)
+		if err != nil {
+			return fmt.Errorf("overrides file %s: %w", e.cfg.File, err)
+		}
+		s, err := newState(o)
+		if err != nil {
+			return fmt.Errorf("overrides file %s: %w", e.cfg.File, err)
+		}
+		e.apply(s)
+	}
+
+	if err := e.startAdmin(ctx, host); err != nil {
+		return err
+	}
+
+	e.logger.Info("TFO overrides extension started",
+		zap.String("endpoint", e.cfg.NetAddr.Endpoint),
+		zap.String("file", e.cfg.File),
+	)
+	return nil
+}
+
+// Shutdown implements component.Component. The configured log level is
+// restored; a restarted extension applies the overrides file again.
+func (e *tfoOverridesExtension) Shutdown(ctx context.Context) error {
+	err := e.server.Shutdown(ctx)
+	e.server = nil
+	if e.state.Load().overrides.LogLevel != "" {
+		loglevel.Reset()
+	}
+	e.logger.Info("TFO overrides extension stopped")
+	return err
+}
+
+// Overrides returns the current overrides.
+func (e *tfoOverridesExtension) Overrides() Overrides {
+	return e.state.Load().overrides
+}
+
+// ExporterHeaders returns the headers set on the requests of exporter id.
+// The map must not be modified.
+// Implements the OverridesProvider interface for tfoexporter.
+func (e *tfoOverridesExtension) ExporterHeaders(id component.ID) map[string]string {
+	return e.state.Load().exporters[id].Headers
+}
+
+// ExporterCredentials returns the API key replacing the configured one of
+// exporter id. Empty values keep the configured ones.
+// Implements the OverridesProvider interface for tfoexporter.
+func (e *tfoOverridesExtension) ExporterCredentials(id component.ID) (keyID, keySecret string) {
+	o := e.state.Load().exporters[id]
+	return o.APIKeyID, o.APIKeySecret
+}
+
+// ReceiverRateLimit returns the rate limit of receiver id, or ok false
+// when the configured one applies.
+// Implements the OverridesProvider interface for tfootlpreceiver.
+func (e *tfoOverridesExtension) ReceiverRateLimit(id component.ID) (recordsPerSecond float64, burst int, ok bool) {
+	rl := e.state.Load().receivers[id].RateLimit
+	if rl == nil {
+		return 0, 0, false
+	}
+	return rl.RecordsPerSecond, rl.Burst, true
+}
+
+// ReceiverSampling returns the percentage of signal kept by receiver id,
+// or ok false when the configured one applies.
+// Implements the OverridesProvider interface for tfootlpreceiver.
+func (e *tfoOverridesExtension) ReceiverSampling(id component.ID, signal pipeline.Signal) (percentage float64, ok bool) {
+	s := e.state.Load().receivers[id].Sampling
+	if s == nil {
+		return 0, false
+	}
+	p := s.Traces
+	if signal == pipeline.SignalLogs {
+		p = s.Logs
+	}
+	if p == nil {
+		return 0, false
+	}
+	return *p, true
+}
+
+// ConnectorPercentage returns the share of batches mirrored by connector
+// id, or ok false when the configured one applies.
+// Implements the OverridesProvider interface for tfomirrorconnector.
+func (e *tfoOverridesExtension) ConnectorPercentage(id component.ID) (percentage float64, ok bool) {
+	p := e.state.Load().connectors[id].Percentage
+	if p == nil {
+		return 0, false
+	}
+	return *p, true
+}
+
+// update validates the overrides returned by change, persists them and
+// swaps them in. Nothing changes when any step fails.
+func (e *tfoOverridesExtension) update(change func(Overrides) (Overrides, error)) (Overrides, error) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	o, err := change(e.state.Load().overrides)
+	if err != nil {
+		return Overrides{}, err
+	}
+	s, err := newState(o)
+	if err != nil {
+		return Overrides{}, err
+	}
+	if e.cfg.File != "" {
+		if err := writeFile(e.cfg.File, o); err != nil {
+			return Overrides{}, &persistError{err: err}
+		}
+	}
+	e.apply(s)
+	return o, nil
+}
+
+// apply swaps s in and sets the log level.
+func (e *tfoOverridesExtension) apply(s *state) {
+	previous := e.state.Swap(s)
+	if s.overrides.LogLevel != "" {
+		// Validated by newState.
+		level, _ := zapcore.ParseLevel(s.overrides.LogLevel)
+		loglevel.Set(level)
+	} else if previous.overrides.LogLevel != "" {
+		loglevel.Reset()
+	}
+
+	e.logger.Info("Runtime overrides applied",
+		zap.String("log_level", s.overrides.LogLevel),
+		zap.Strings("exporters", slices.Sorted(maps.Keys(s.overrides.Exporters))),
+		zap.Strings("receivers", slices.Sorted(maps.Keys(s.overrides.Receivers))),
+		zap.Strings("connectors", slices.Sorted(maps.Keys(s.overrides.Connectors))),
+	)
+}
+
+// persistError is an update that failed to write the overrides file.
+type persistError struct {
+	err error
+}
+
+func (e *persistError) Error() string {
+	return "failed to persist overrides: " + e.err.Error()
+}
+
+func (e *persistError) Unwrap() error {
+	return e.err
+}
+
+// readFile reads the overrides file. A missing file holds no overrides.
+func readFile(path string) (Overrides, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Overrides{}, nil
+	}
+	if err != nil {
+		return Overrides{}, err
+	}
+	return decodeOverrides(data)
+}
+
+// writeFile replaces the overrides file through a temporary file in the
+// same directory, so that a crash leaves either the old or the new file.
+// The file holds credentials and is only readable by the owner.
+func writeFile(path string, o Overrides) error {
+	data, err := json.MarshalIndent(o, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
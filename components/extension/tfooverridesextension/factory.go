@@ -0,0 +1,58 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfooverridesextension
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+)
+
+const (
+	// TypeStr is the type string identifier for the TFO overrides extension.
+	TypeStr = "tfooverrides"
+
+	// DefaultEndpoint is the default listen address of the admin API.
+	DefaultEndpoint = "localhost:55693"
+)
+
+// NewFactory creates a new factory for the TFO overrides extension.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(
+		component.MustNewType(TypeStr),
+		createDefaultConfig,
+		createExtension,
+		component.StabilityLevelAlpha,
+	)
+}
+
+// createDefaultConfig creates the default configuration for the extension.
+func createDefaultConfig() component.Config {
+	return &Config{
+		AdminConfig: serverconf.NewDefaultAdminConfig(DefaultEndpoint),
+	}
+}
+
+// createExtension creates the TFO overrides extension.
+func createExtension(
+	ctx context.Context,
+	set extension.Settings,
+	cfg component.Config,
+) (extension.Extension, error) {
+	return newTFOOverridesExtension(cfg.(*Config), &set)
+}
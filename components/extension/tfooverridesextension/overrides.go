@@ -0,0 +1,274 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfooverridesextension
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap/zapcore"
+)
+
+// redacted replaces secrets and header values in the admin API responses.
+const redacted = "[REDACTED]"
+
+// Overrides are the runtime parameters replacing those of the collector
+// configuration. Components are keyed by their ID, e.g. "tfo/backup".
+type Overrides struct {
+	// LogLevel replaces service::telemetry::logs::level.
+	LogLevel string `json:"log_level,omitempty"`
+
+	// Exporters overrides tfo exporters.
+	Exporters map[string]ExporterOverrides `json:"exporters,omitempty"`
+
+	// Receivers overrides tfootlp receivers.
+	Receivers map[string]ReceiverOverrides `json:"receivers,omitempty"`
+
+	// Connectors overrides tfomirror connectors.
+	Connectors map[string]ConnectorOverrides `json:"connectors,omitempty"`
+}
+
+// ExporterOverrides are the overridden settings of an exporter.
+type ExporterOverrides struct {
+	// Headers are set on every request, replacing configured headers of
+	// the same name.
+	Headers map[string]string `json:"headers,omitempty"`
+
+	// APIKeyID and APIKeySecret replace the API key of the exporter's auth
+	// settings or tfoauth extension. Credential profiles are not affected.
+	APIKeyID     string `json:"api_key_id,omitempty"`
+	APIKeySecret string `json:"api_key_secret,omitempty"`
+}
+
+// ReceiverOverrides are the overridden settings of a receiver.
+type ReceiverOverrides struct {
+	// RateLimit replaces the rate and burst of rate_limit.
+	RateLimit *RateLimitOverrides `json:"rate_limit,omitempty"`
+
+	// Sampling replaces the percentages of sampling.
+	Sampling *SamplingOverrides `json:"sampling,omitempty"`
+}
+
+// RateLimitOverrides are the overridden rate limit settings.
+type RateLimitOverrides struct {
+	RecordsPerSecond float64 `json:"records_per_second"`
+
+	// Burst zero uses RecordsPerSecond.
+	Burst int `json:"burst,omitempty"`
+}
+
+// SamplingOverrides are the overridden sampling percentages. A nil
+// percentage keeps the configured one.
+type SamplingOverrides struct {
+	Traces *float64 `json:"traces,omitempty"`
+	Logs   *float64 `json:"logs,omitempty"`
+}
+
+// ConnectorOverrides are the overridden settings of a connector.
+type ConnectorOverrides struct {
+	// Percentage replaces the share of mirrored batches.
+	Percentage *float64 `json:"percentage,omitempty"`
+}
+
+// decodeOverrides decodes JSON overrides, rejecting unknown fields so that
+// settings outside the supported subset are not silently ignored.
+func decodeOverrides(data []byte) (Overrides, error) {
+	var o Overrides
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(&o); err != nil {
+		return Overrides{}, err
+	}
+	return o, nil
+}
+
+// Validate checks the overrides for errors.
+func (o *Overrides) Validate() error {
+	var errs []error
+	if o.LogLevel != "" {
+		if _, err := zapcore.ParseLevel(o.LogLevel); err != nil {
+			errs = append(errs, fmt.Errorf("log_level: %w", err))
+		}
+	}
+	errs = append(errs, validateEntries("exporters", o.Exporters)...)
+	errs = append(errs, validateEntries("receivers", o.Receivers)...)
+	errs = append(errs, validateEntries("connectors", o.Connectors)...)
+	return errors.Join(errs...)
+}
+
+// validateEntries checks the component IDs and overrides of a section.
+func validateEntries[V interface{ validate() error }](section string, entries map[string]V) []error {
+	var errs []error
+	for _, key := range slices.Sorted(maps.Keys(entries)) {
+		if _, err := parseID(key); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", section, err))
+			continue
+		}
+		if err := entries[key].validate(); err != nil {
+			errs = append(errs, fmt.Errorf("%s::%s: %w", section, key, err))
+		}
+	}
+	return errs
+}
+
+func (o ExporterOverrides) validate() error {
+	for _, name := range slices.Sorted(maps.Keys(o.Headers)) {
+		if !validHeaderName(name) {
+			return fmt.Errorf("invalid header name %q", name)
+		}
+		value := o.Headers[name]
+		if strings.ContainsAny(value, "\r\n") {
+			return fmt.Errorf("header %q: value must not contain line breaks", name)
+		}
+		if value == redacted {
+			return fmt.Errorf("header %q: value is the %s placeholder; send the actual value", name, redacted)
+		}
+	}
+	if o.APIKeyID == redacted || o.APIKeySecret == redacted {
+		return fmt.Errorf("api key is the %s placeholder; send the actual value", redacted)
+	}
+	return nil
+}
+
+func (o ReceiverOverrides) validate() error {
+	if o.RateLimit != nil {
+		if o.RateLimit.RecordsPerSecond <= 0 {
+			return errors.New("rate_limit.records_per_second must be positive")
+		}
+		if o.RateLimit.Burst < 0 {
+			return errors.New("rate_limit.burst must not be negative")
+		}
+	}
+	if o.Sampling != nil {
+		if p := o.Sampling.Traces; p != nil && (*p < 0 || *p > 100) {
+			return errors.New("sampling.traces must be between 0 and 100")
+		}
+		if p := o.Sampling.Logs; p != nil && (*p < 0 || *p > 100) {
+			return errors.New("sampling.logs must be between 0 and 100")
+		}
+	}
+	return nil
+}
+
+func (o ConnectorOverrides) validate() error {
+	if o.Percentage != nil && (*o.Percentage < 0 || *o.Percentage > 100) {
+		return errors.New("percentage must be between 0 and 100")
+	}
+	return nil
+}
+
+// redact returns o with credentials and header values replaced by the
+// redacted placeholder.
+func (o Overrides) redact() Overrides {
+	if len(o.Exporters) == 0 {
+		return o
+	}
+	exporters := make(map[string]ExporterOverrides, len(o.Exporters))
+	for key, e := range o.Exporters {
+		if len(e.Headers) > 0 {
+			headers := make(map[string]string, len(e.Headers))
+			for name := range e.Headers {
+				headers[name] = redacted
+			}
+			e.Headers = headers
+		}
+		if e.APIKeyID != "" {
+			e.APIKeyID = redacted
+		}
+		if e.APIKeySecret != "" {
+			e.APIKeySecret = redacted
+		}
+		exporters[key] = e
+	}
+	o.Exporters = exporters
+	return o
+}
+
+// mergePatch applies a JSON merge patch (RFC 7386) to target: members of
+// patch replace those of target, objects are merged recursively and null
+// removes a member.
+func mergePatch(target, patch any) any {
+	p, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+	t, ok := target.(map[string]any)
+	if !ok {
+		t = make(map[string]any)
+	}
+	for key, value := range p {
+		if value == nil {
+			delete(t, key)
+			continue
+		}
+		t[key] = mergePatch(t[key], value)
+	}
+	return t
+}
+
+// patch returns o with the JSON merge patch data applied.
+func (o Overrides) patch(data []byte) (Overrides, error) {
+	var p any
+	if err := json.Unmarshal(data, &p); err != nil {
+		return Overrides{}, err
+	}
+	if _, ok := p.(map[string]any); !ok {
+		return Overrides{}, errors.New("merge patch must be a JSON object")
+	}
+	current, err := json.Marshal(o)
+	if err != nil {
+		return Overrides{}, err
+	}
+	var target any
+	if err := json.Unmarshal(current, &target); err != nil {
+		return Overrides{}, err
+	}
+	merged, err := json.Marshal(mergePatch(target, p))
+	if err != nil {
+		return Overrides{}, err
+	}
+	return decodeOverrides(merged)
+}
+
+// parseID parses a component ID key.
+func parseID(key string) (component.ID, error) {
+	var id component.ID
+	if err := id.UnmarshalText([]byte(key)); err != nil {
+		return component.ID{}, fmt.Errorf("invalid component ID %q: %w", key, err)
+	}
+	return id, nil
+}
+
+// validHeaderName reports whether name is an HTTP header field name.
+func validHeaderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, c := range []byte(name) {
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9':
+		case strings.IndexByte("!#$%&'*+-.^_`|~", c) >= 0:
+		default:
+			return false
+		}
+	}
+	return true
+}
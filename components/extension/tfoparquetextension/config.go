@@ -0,0 +1,53 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoparquetextension
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Supported compression codecs.
+const (
+	CompressionZstd   = "zstd"
+	CompressionSnappy = "snappy"
+	CompressionGzip   = "gzip"
+	CompressionNone   = "none"
+)
+
+// Config defines the configuration for the TFO Parquet extension.
+type Config struct {
+	// Compression is the column chunk codec: zstd, snappy, gzip or none.
+	// Default: zstd
+	Compression string `mapstructure:"compression"`
+
+	// RowGroupSize is the maximum number of rows per row group.
+	// Default: 100000
+	RowGroupSize int64 `mapstructure:"row_group_size"`
+}
+
+// Validate checks the configuration for errors.
+func (cfg *Config) Validate() error {
+	switch cfg.Compression {
+	case CompressionZstd, CompressionSnappy, CompressionGzip, CompressionNone:
+	default:
+		return fmt.Errorf("compression must be one of %s, %s, %s, %s; got %q",
+			CompressionZstd, CompressionSnappy, CompressionGzip, CompressionNone, cfg.Compression)
+	}
+	if cfg.RowGroupSize <= 0 {
+		return errors.New("row_group_size must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+// Package tfoparquetextension provides a Parquet encoding for archival exporters.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// The tfoparquetextension is an encoding extension: the contrib file and S3
+// exporters reference it through their `encoding` setting and write every
+// batch as a self-contained Parquet file that Athena, Trino or Spark can
+// query directly. Each signal has a flat schema:
+//   - traces: one row per span
+//   - logs: one row per log record
+//   - metrics: one row per data point
+//
+// Attributes are stored as MAP<STRING,STRING> columns and timestamps as
+// TIMESTAMP(MICROS); service_name is promoted to its own column for
+// partition-friendly filtering.
+//
+// Configuration example:
+//
+//	extensions:
+//	  tfoparquet:
+//	    compression: zstd
+//	    row_group_size: 100000
+//
+//	exporters:
+//	  awss3/lake:
+//	    s3uploader:
+//	      region: eu-west-1
+//	      s3_bucket: tfo-lake
+//	    encoding: tfoparquet
+//
+// The Parquet encoding can be combined with tfoencryption by referencing it
+// as the encryption extension's inner encoding.
+package tfoparquetextension // import "github.com/telemetryflow/telemetryflow-collector/components/extension/tfoparquetextension"
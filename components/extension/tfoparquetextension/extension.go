@@ -0,0 +1,113 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoparquetextension
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/parquet-go/parquet-go/compress"
+	"github.com/parquet-go/parquet-go/compress/gzip"
+	"github.com/parquet-go/parquet-go/compress/snappy"
+	"github.com/parquet-go/parquet-go/compress/uncompressed"
+	"github.com/parquet-go/parquet-go/compress/zstd"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+)
+
+// tfoParquetExtension is an encoding extension that writes Parquet files.
+type tfoParquetExtension struct {
+	cfg      *Config
+	settings *extension.Settings
+	logger   *zap.Logger
+
+	options []parquet.WriterOption
+}
+
+// newTFOParquetExtension creates a new TFO Parquet extension.
+func newTFOParquetExtension(cfg *Config, set *extension.Settings) (*tfoParquetExtension, error) {
+	return &tfoParquetExtension{
+		cfg:      cfg,
+		settings: set,
+		logger:   set.Logger,
+		options: []parquet.WriterOption{
+			parquet.Compression(codec(cfg.Compression)),
+			parquet.MaxRowsPerRowGroup(cfg.RowGroupSize),
+			parquet.CreatedBy("tfo-collector", "", ""),
+		},
+	}, nil
+}
+
+// Start implements component.Component.
+func (e *tfoParquetExtension) Start(ctx context.Context, host component.Host) error {
+	e.logger.Info("TFO Parquet extension started",
+		zap.String("compression", e.cfg.Compression),
+		zap.Int64("row_group_size", e.cfg.RowGroupSize),
+	)
+	return nil
+}
+
+// Shutdown implements component.Component.
+func (e *tfoParquetExtension) Shutdown(ctx context.Context) error {
+	e.logger.Info("TFO Parquet extension stopped")
+	return nil
+}
+
+// MarshalTraces implements ptrace.Marshaler.
+func (e *tfoParquetExtension) MarshalTraces(td ptrace.Traces) ([]byte, error) {
+	return writeRows(spanRows(td), e.options)
+}
+
+// MarshalMetrics implements pmetric.Marshaler.
+func (e *tfoParquetExtension) MarshalMetrics(md pmetric.Metrics) ([]byte, error) {
+	return writeRows(metricRows(md), e.options)
+}
+
+// MarshalLogs implements plog.Marshaler.
+func (e *tfoParquetExtension) MarshalLogs(ld plog.Logs) ([]byte, error) {
+	return writeRows(logRows(ld), e.options)
+}
+
+// writeRows encodes rows as a complete Parquet file.
+func writeRows[T any](rows []T, options []parquet.WriterOption) ([]byte, error) {
+	var buf bytes.Buffer
+	w := parquet.NewGenericWriter[T](&buf, options...)
+	if _, err := w.Write(rows); err != nil {
+		return nil, fmt.Errorf("failed to write parquet rows: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("failed to close parquet writer: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func codec(name string) compress.Codec {
+	switch name {
+	case CompressionSnappy:
+		return &snappy.Codec{}
+	case CompressionGzip:
+		return &gzip.Codec{}
+	case CompressionNone:
+		return &uncompressed.Codec{}
+	default:
+		return &zstd.Codec{}
+	}
+}
@@ -0,0 +1,292 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoparquetextension
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// serviceNameKey is the resource attribute promoted to the service_name column.
+const serviceNameKey = "service.name"
+
+// SpanRow is the Parquet schema for traces: one row per span.
+type SpanRow struct {
+	ServiceName        string            `parquet:"service_name,dict"`
+	TraceID            string            `parquet:"trace_id"`
+	SpanID             string            `parquet:"span_id"`
+	ParentSpanID       string            `parquet:"parent_span_id"`
+	TraceState         string            `parquet:"trace_state"`
+	Name               string            `parquet:"name,dict"`
+	Kind               string            `parquet:"kind,dict"`
+	StartTime          time.Time         `parquet:"start_time,timestamp(microsecond)"`
+	EndTime            time.Time         `parquet:"end_time,timestamp(microsecond)"`
+	DurationNanos      int64             `parquet:"duration_ns"`
+	StatusCode         string            `parquet:"status_code,dict"`
+	StatusMessage      string            `parquet:"status_message"`
+	Attributes         map[string]string `parquet:"attributes"`
+	ResourceAttributes map[string]string `parquet:"resource_attributes"`
+	ScopeName          string            `parquet:"scope_name,dict"`
+	ScopeVersion       string            `parquet:"scope_version,dict"`
+	EventsCount        int32             `parquet:"events_count"`
+	LinksCount         int32             `parquet:"links_count"`
+}
+
+// LogRow is the Parquet schema for logs: one row per log record.
+type LogRow struct {
+	ServiceName        string            `parquet:"service_name,dict"`
+	Timestamp          time.Time         `parquet:"timestamp,timestamp(microsecond)"`
+	ObservedTimestamp  time.Time         `parquet:"observed_timestamp,timestamp(microsecond)"`
+	SeverityNumber     int32             `parquet:"severity_number"`
+	SeverityText       string            `parquet:"severity_text,dict"`
+	Body               string            `parquet:"body"`
+	TraceID            string            `parquet:"trace_id"`
+	SpanID             string            `parquet:"span_id"`
+	Attributes         map[string]string `parquet:"attributes"`
+	ResourceAttributes map[string]string `parquet:"resource_attributes"`
+	ScopeName          string            `parquet:"scope_name,dict"`
+	ScopeVersion       string            `parquet:"scope_version,dict"`
+}
+
+// MetricRow is the Parquet schema for metrics: one row per data point.
+// Value columns that do not apply to the metric type are null.
+type MetricRow struct {
+	ServiceName        string            `parquet:"service_name,dict"`
+	MetricName         string            `parquet:"metric_name,dict"`
+	Description        string            `parquet:"description,dict"`
+	Unit               string            `parquet:"unit,dict"`
+	Type               string            `parquet:"type,dict"`
+	Timestamp          time.Time         `parquet:"timestamp,timestamp(microsecond)"`
+	StartTimestamp     time.Time         `parquet:"start_timestamp,timestamp(microsecond)"`
+	ValueDouble        *float64          `parquet:"value_double,optional"`
+	ValueInt           *int64            `parquet:"value_int,optional"`
+	Count              *int64            `parquet:"count,optional"`
+	Sum                *float64          `parquet:"sum,optional"`
+	Min                *float64          `parquet:"min,optional"`
+	Max                *float64          `parquet:"max,optional"`
+	IsMonotonic        bool              `parquet:"is_monotonic"`
+	Temporality        string            `parquet:"temporality,dict"`
+	Attributes         map[string]string `parquet:"attributes"`
+	ResourceAttributes map[string]string `parquet:"resource_attributes"`
+	ScopeName          string            `parquet:"scope_name,dict"`
+	ScopeVersion       string            `parquet:"scope_version,dict"`
+}
+
+// spanRows flattens td into one row per span.
+func spanRows(td ptrace.Traces) []SpanRow {
+	rows := make([]SpanRow, 0, td.SpanCount())
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		resAttrs := attributeMap(rs.Resource().Attributes())
+		service := resAttrs[serviceNameKey]
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			ss := rs.ScopeSpans().At(j)
+			for k := 0; k < ss.Spans().Len(); k++ {
+				span := ss.Spans().At(k)
+				rows = append(rows, SpanRow{
+					ServiceName:        service,
+					TraceID:            traceID(span.TraceID()),
+					SpanID:             spanID(span.SpanID()),
+					ParentSpanID:       spanID(span.ParentSpanID()),
+					TraceState:         span.TraceState().AsRaw(),
+					Name:               span.Name(),
+					Kind:               span.Kind().String(),
+					StartTime:          span.StartTimestamp().AsTime(),
+					EndTime:            span.EndTimestamp().AsTime(),
+					DurationNanos:      int64(span.EndTimestamp()) - int64(span.StartTimestamp()),
+					StatusCode:         span.Status().Code().String(),
+					StatusMessage:      span.Status().Message(),
+					Attributes:         attributeMap(span.Attributes()),
+					ResourceAttributes: resAttrs,
+					ScopeName:          ss.Scope().Name(),
+					ScopeVersion:       ss.Scope().Version(),
+					EventsCount:        int32(span.Events().Len()),
+					LinksCount:         int32(span.Links().Len()),
+				})
+			}
+		}
+	}
+	return rows
+}
+
+// logRows flattens ld into one row per log record.
+func logRows(ld plog.Logs) []LogRow {
+	rows := make([]LogRow, 0, ld.LogRecordCount())
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		rl := ld.ResourceLogs().At(i)
+		resAttrs := attributeMap(rl.Resource().Attributes())
+		service := resAttrs[serviceNameKey]
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			sl := rl.ScopeLogs().At(j)
+			for k := 0; k < sl.LogRecords().Len(); k++ {
+				lr := sl.LogRecords().At(k)
+				rows = append(rows, LogRow{
+					ServiceName:        service,
+					Timestamp:          lr.Timestamp().AsTime(),
+					ObservedTimestamp:  lr.ObservedTimestamp().AsTime(),
+					SeverityNumber:     int32(lr.SeverityNumber()),
+					SeverityText:       lr.SeverityText(),
+					Body:               lr.Body().AsString(),
+					TraceID:            traceID(lr.TraceID()),
+					SpanID:             spanID(lr.SpanID()),
+					Attributes:         attributeMap(lr.Attributes()),
+					ResourceAttributes: resAttrs,
+					ScopeName:          sl.Scope().Name(),
+					ScopeVersion:       sl.Scope().Version(),
+				})
+			}
+		}
+	}
+	return rows
+}
+
+// metricRows flattens md into one row per data point.
+func metricRows(md pmetric.Metrics) []MetricRow {
+	rows := make([]MetricRow, 0, md.DataPointCount())
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		resAttrs := attributeMap(rm.Resource().Attributes())
+		service := resAttrs[serviceNameKey]
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			sm := rm.ScopeMetrics().At(j)
+			for k := 0; k < sm.Metrics().Len(); k++ {
+				m := sm.Metrics().At(k)
+				base := MetricRow{
+					ServiceName:        service,
+					MetricName:         m.Name(),
+					Description:        m.Description(),
+					Unit:               m.Unit(),
+					Type:               m.Type().String(),
+					ResourceAttributes: resAttrs,
+					ScopeName:          sm.Scope().Name(),
+					ScopeVersion:       sm.Scope().Version(),
+				}
+				rows = appendDataPoints(rows, base, m)
+			}
+		}
+	}
+	return rows
+}
+
+func appendDataPoints(rows []MetricRow, base MetricRow, m pmetric.Metric) []MetricRow {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		rows = appendNumberPoints(rows, base, m.Gauge().DataPoints())
+	case pmetric.MetricTypeSum:
+		base.IsMonotonic = m.Sum().IsMonotonic()
+		base.Temporality = m.Sum().AggregationTemporality().String()
+		rows = appendNumberPoints(rows, base, m.Sum().DataPoints())
+	case pmetric.MetricTypeHistogram:
+		base.Temporality = m.Histogram().AggregationTemporality().String()
+		dps := m.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			row := withPoint(base, dp.Timestamp(), dp.StartTimestamp(), dp.Attributes())
+			row.Count = ptr(int64(dp.Count()))
+			if dp.HasSum() {
+				row.Sum = ptr(dp.Sum())
+			}
+			if dp.HasMin() {
+				row.Min = ptr(dp.Min())
+			}
+			if dp.HasMax() {
+				row.Max = ptr(dp.Max())
+			}
+			rows = append(rows, row)
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		base.Temporality = m.ExponentialHistogram().AggregationTemporality().String()
+		dps := m.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			row := withPoint(base, dp.Timestamp(), dp.StartTimestamp(), dp.Attributes())
+			row.Count = ptr(int64(dp.Count()))
+			if dp.HasSum() {
+				row.Sum = ptr(dp.Sum())
+			}
+			if dp.HasMin() {
+				row.Min = ptr(dp.Min())
+			}
+			if dp.HasMax() {
+				row.Max = ptr(dp.Max())
+			}
+			rows = append(rows, row)
+		}
+	case pmetric.MetricTypeSummary:
+		dps := m.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			row := withPoint(base, dp.Timestamp(), dp.StartTimestamp(), dp.Attributes())
+			row.Count = ptr(int64(dp.Count()))
+			row.Sum = ptr(dp.Sum())
+			rows = append(rows, row)
+		}
+	}
+	return rows
+}
+
+func appendNumberPoints(rows []MetricRow, base MetricRow, dps pmetric.NumberDataPointSlice) []MetricRow {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		row := withPoint(base, dp.Timestamp(), dp.StartTimestamp(), dp.Attributes())
+		switch dp.ValueType() {
+		case pmetric.NumberDataPointValueTypeDouble:
+			row.ValueDouble = ptr(dp.DoubleValue())
+		case pmetric.NumberDataPointValueTypeInt:
+			row.ValueInt = ptr(dp.IntValue())
+		}
+		rows = append(rows, row)
+	}
+	return rows
+}
+
+func withPoint(base MetricRow, ts, start pcommon.Timestamp, attrs pcommon.Map) MetricRow {
+	base.Timestamp = ts.AsTime()
+	base.StartTimestamp = start.AsTime()
+	base.Attributes = attributeMap(attrs)
+	return base
+}
+
+// attributeMap converts attributes to strings; complex values are JSON encoded.
+func attributeMap(attrs pcommon.Map) map[string]string {
+	out := make(map[string]string, attrs.Len())
+	attrs.Range(func(k string, v pcommon.Value) bool {
+		out[k] = v.AsString()
+		return true
+	})
+	return out
+}
+
+func traceID(id pcommon.TraceID) string {
+	if id.IsEmpty() {
+		return ""
+	}
+	return id.String()
+}
+
+func spanID(id pcommon.SpanID) string {
+	if id.IsEmpty() {
+		return ""
+	}
+	return id.String()
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
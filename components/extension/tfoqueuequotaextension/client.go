@@ -0,0 +1,344 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoqueuequotaextension
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/xpdata/request"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.uber.org/zap"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// metadataKey is the key under which the persistent queue stores its
+// read and write indexes.
+const metadataKey = "qmv0"
+
+// Field numbers of the persistent queue metadata message.
+const (
+	metadataReadIndex       = 3
+	metadataWriteIndex      = 4
+	metadataDispatchedItems = 5
+)
+
+// maxRecoveredBatches bounds the batches recovered from one queue at start.
+const maxRecoveredBatches = 1 << 20
+
+// errBatchMissing fails the read of a batch that was evicted or refused,
+// so that the queue skips it instead of exporting an empty batch.
+var errBatchMissing = errors.New("queued batch was evicted or refused by the tenant queue quota")
+
+// quotaClient is the storage client of one persistent queue.
+type quotaClient struct {
+	ext    *tfoQueueQuotaExtension
+	inner  storage.Client
+	signal pipeline.Signal
+	owner  component.ID
+
+	// batches maps item keys to their batch. Guarded by ext.mu.
+	batches map[string]*queuedBatch
+}
+
+var _ storage.Client = (*quotaClient)(nil)
+
+// Get implements storage.Client.
+func (c *quotaClient) Get(ctx context.Context, key string) ([]byte, error) {
+	op := storage.GetOperation(key)
+	if err := c.Batch(ctx, op); err != nil {
+		return nil, err
+	}
+	return op.Value, nil
+}
+
+// Set implements storage.Client.
+func (c *quotaClient) Set(ctx context.Context, key string, value []byte) error {
+	return c.Batch(ctx, storage.SetOperation(key, value))
+}
+
+// Delete implements storage.Client.
+func (c *quotaClient) Delete(ctx context.Context, key string) error {
+	return c.Batch(ctx, storage.DeleteOperation(key))
+}
+
+// Batch implements storage.Client. Writes of batches are charged to their
+// tenant before they reach the inner storage; batches refused by the
+// quota are left out of the operations and fail the call once the others
+// are applied.
+func (c *quotaClient) Batch(ctx context.Context, ops ...*storage.Operation) error {
+	var (
+		applied  = make([]*storage.Operation, 0, len(ops))
+		added    []*queuedBatch
+		evicted  []*queuedBatch
+		refused  error
+		itemGets []*storage.Operation
+	)
+
+	// Decoding the tenant of a batch takes a while; do it before locking,
+	// which holds up the queues of every exporter.
+	tenants := make([]string, len(ops))
+	for i, op := range ops {
+		if op.Type == storage.Set && isItemKey(op.Key) {
+			tenants[i] = c.tenantOf(op.Value)
+		}
+	}
+
+	c.ext.mu.Lock()
+	for i, op := range ops {
+		if !isItemKey(op.Key) {
+			applied = append(applied, op)
+			continue
+		}
+		switch op.Type {
+		case storage.Set:
+			if old, ok := c.batches[op.Key]; ok {
+				c.ext.remove(old)
+			}
+			b := &queuedBatch{client: c, key: op.Key, tenant: tenants[i], size: int64(len(op.Value))}
+			ev, err := c.ext.admit(b)
+			if err != nil {
+				refused = errors.Join(refused, err)
+				c.ext.recordDropped(ctx, b.tenant, reasonRejected)
+				continue
+			}
+			added = append(added, b)
+			evicted = append(evicted, ev...)
+		case storage.Delete:
+			if b, ok := c.batches[op.Key]; ok {
+				c.ext.remove(b)
+			}
+		case storage.Get:
+			if b, ok := c.batches[op.Key]; ok {
+				b.dispatched = true
+			}
+			itemGets = append(itemGets, op)
+		}
+		applied = append(applied, op)
+	}
+	c.ext.mu.Unlock()
+
+	if len(applied) > 0 {
+		if err := c.inner.Batch(ctx, applied...); err != nil {
+			// Nothing was written; give back what the writes took and keep
+			// the batches chosen for eviction.
+			c.ext.mu.Lock()
+			for _, b := range added {
+				if c.batches[b.key] == b {
+					c.ext.remove(b)
+				}
+			}
+			for _, b := range evicted {
+				c.ext.add(b)
+			}
+			c.ext.mu.Unlock()
+			return err
+		}
+	}
+
+	for _, b := range evicted {
+		if err := b.client.inner.Delete(ctx, b.key); err != nil {
+			c.ext.logger.Warn("Failed to evict a queued batch",
+				zap.String("tenant", b.tenant), zap.String("exporter", b.client.owner.String()), zap.Error(err))
+			continue
+		}
+		c.ext.recordDropped(ctx, b.tenant, reasonEvicted)
+	}
+
+	// The queue reads one batch at a time and exports whatever it gets,
+	// even nothing. Reads of several batches at start skip missing ones.
+	if len(itemGets) == 1 && itemGets[0].Value == nil {
+		return errors.Join(refused, errBatchMissing)
+	}
+	return refused
+}
+
+// Close implements storage.Client. The batches of the queue stay on disk
+// but are no longer charged; they are recovered when the queue reopens.
+func (c *quotaClient) Close(ctx context.Context) error {
+	c.ext.mu.Lock()
+	for _, b := range c.batches {
+		c.ext.remove(b)
+	}
+	c.ext.mu.Unlock()
+	return c.inner.Close(ctx)
+}
+
+// load charges the batches already queued on disk to their tenants.
+func (c *quotaClient) load(ctx context.Context) error {
+	buf, err := c.inner.Get(ctx, metadataKey)
+	if err != nil || buf == nil {
+		return err
+	}
+	indexes, err := queuedIndexes(buf)
+	if err != nil {
+		return err
+	}
+	if len(indexes) == 0 {
+		return nil
+	}
+	ops := make([]*storage.Operation, len(indexes))
+	for i, index := range indexes {
+		ops[i] = storage.GetOperation(strconv.FormatUint(index, 10))
+	}
+	if err := c.inner.Batch(ctx, ops...); err != nil {
+		return err
+	}
+
+	batches := make([]*queuedBatch, 0, len(ops))
+	for _, op := range ops {
+		if op.Value != nil {
+			batches = append(batches, &queuedBatch{client: c, key: op.Key, tenant: c.tenantOf(op.Value), size: int64(len(op.Value))})
+		}
+	}
+	c.ext.mu.Lock()
+	defer c.ext.mu.Unlock()
+	for _, b := range batches {
+		c.ext.add(b)
+	}
+	return nil
+}
+
+// queuedIndexes returns the item indexes a persistent queue metadata
+// record holds: the batches being exported and those not yet read.
+func queuedIndexes(buf []byte) ([]uint64, error) {
+	var readIndex, writeIndex uint64
+	var indexes []uint64
+	for len(buf) > 0 {
+		num, typ, n := protowire.ConsumeTag(buf)
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		buf = buf[n:]
+		switch {
+		case num == metadataReadIndex && typ == protowire.Fixed64Type:
+			readIndex, n = protowire.ConsumeFixed64(buf)
+		case num == metadataWriteIndex && typ == protowire.Fixed64Type:
+			writeIndex, n = protowire.ConsumeFixed64(buf)
+		case num == metadataDispatchedItems && typ == protowire.Fixed64Type:
+			var index uint64
+			index, n = protowire.ConsumeFixed64(buf)
+			indexes = append(indexes, index)
+		case num == metadataDispatchedItems && typ == protowire.BytesType:
+			var packed []byte
+			packed, n = protowire.ConsumeBytes(buf)
+			for len(packed) > 0 {
+				index, m := protowire.ConsumeFixed64(packed)
+				if m < 0 {
+					return nil, protowire.ParseError(m)
+				}
+				indexes = append(indexes, index)
+				packed = packed[m:]
+			}
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, buf)
+		}
+		if n < 0 {
+			return nil, protowire.ParseError(n)
+		}
+		buf = buf[n:]
+	}
+	if writeIndex < readIndex || writeIndex-readIndex > maxRecoveredBatches {
+		return nil, fmt.Errorf("invalid queue indexes %d..%d", readIndex, writeIndex)
+	}
+	for index := readIndex; index < writeIndex; index++ {
+		indexes = append(indexes, index)
+	}
+	return indexes, nil
+}
+
+// isItemKey reports whether a key holds a queued batch; the persistent
+// queue stores batches under their decimal index.
+func isItemKey(key string) bool {
+	_, err := strconv.ParseUint(key, 10, 64)
+	return err == nil
+}
+
+// tenantOf returns the tenant of an encoded batch: the tenant attribute of
+// its first resource carrying it.
+func (c *quotaClient) tenantOf(buf []byte) string {
+	attribute := c.ext.cfg.TenantAttribute
+	var resources []pcommon.Resource
+	switch c.signal {
+	case pipeline.SignalTraces:
+		td, err := unmarshalTraces(buf)
+		if err != nil {
+			return unknownTenant
+		}
+		for i := 0; i < td.ResourceSpans().Len(); i++ {
+			resources = append(resources, td.ResourceSpans().At(i).Resource())
+		}
+	case pipeline.SignalMetrics:
+		md, err := unmarshalMetrics(buf)
+		if err != nil {
+			return unknownTenant
+		}
+		for i := 0; i < md.ResourceMetrics().Len(); i++ {
+			resources = append(resources, md.ResourceMetrics().At(i).Resource())
+		}
+	case pipeline.SignalLogs:
+		ld, err := unmarshalLogs(buf)
+		if err != nil {
+			return unknownTenant
+		}
+		for i := 0; i < ld.ResourceLogs().Len(); i++ {
+			resources = append(resources, ld.ResourceLogs().At(i).Resource())
+		}
+	}
+	for _, resource := range resources {
+		if v, ok := resource.Attributes().Get(attribute); ok && v.AsString() != "" {
+			return v.AsString()
+		}
+	}
+	return unknownTenant
+}
+
+// unmarshalTraces decodes a queued traces batch, written with or without
+// its request context.
+func unmarshalTraces(buf []byte) (ptrace.Traces, error) {
+	_, td, err := request.UnmarshalTraces(buf)
+	if errors.Is(err, request.ErrInvalidFormat) {
+		return (&ptrace.ProtoUnmarshaler{}).UnmarshalTraces(buf)
+	}
+	return td, err
+}
+
+// unmarshalMetrics decodes a queued metrics batch, written with or without
+// its request context.
+func unmarshalMetrics(buf []byte) (pmetric.Metrics, error) {
+	_, md, err := request.UnmarshalMetrics(buf)
+	if errors.Is(err, request.ErrInvalidFormat) {
+		return (&pmetric.ProtoUnmarshaler{}).UnmarshalMetrics(buf)
+	}
+	return md, err
+}
+
+// unmarshalLogs decodes a queued logs batch, written with or without its
+// request context.
+func unmarshalLogs(buf []byte) (plog.Logs, error) {
+	_, ld, err := request.UnmarshalLogs(buf)
+	if errors.Is(err, request.ErrInvalidFormat) {
+		return (&plog.ProtoUnmarshaler{}).UnmarshalLogs(buf)
+	}
+	return ld, err
+}
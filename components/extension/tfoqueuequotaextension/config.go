@@ -0,0 +1,105 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoqueuequotaextension
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/bytesize"
+)
+
+// Eviction policies applied when a tenant exceeds its quota.
+const (
+	// EvictionOldest removes the oldest queued batches of the tenant.
+	EvictionOldest = "oldest"
+	// EvictionReject refuses the new batch.
+	EvictionReject = "reject"
+)
+
+// Config defines the configuration for the TFO queue quota extension.
+type Config struct {
+	// Storage is the storage extension holding the queues, e.g.
+	// file_storage.
+	Storage component.ID `mapstructure:"storage"`
+
+	// TenantAttribute is the resource attribute naming the tenant of a
+	// batch.
+	// Default: tfo.provenance.origin.tenant
+	TenantAttribute string `mapstructure:"tenant_attribute"`
+
+	// DefaultQuota bounds the queued bytes of every tenant not listed in
+	// Quotas, e.g. "256MiB". Zero leaves them unbounded.
+	// Default: 0
+	DefaultQuota bytesize.Size `mapstructure:"default_quota"`
+
+	// Quotas bounds the queued bytes of individual tenants. Zero leaves a
+	// tenant unbounded. Only these tenants are named on the metrics of the
+	// extension.
+	Quotas map[string]bytesize.Size `mapstructure:"quotas"`
+
+	// OtherQuota bounds the queued bytes of all tenants not listed in
+	// Quotas together, e.g. "1GiB". Tenants are named by the senders, so
+	// without it a sender naming a new tenant per batch is bounded by
+	// nothing but the disk. Zero leaves them unbounded.
+	// Default: 0
+	OtherQuota bytesize.Size `mapstructure:"other_quota"`
+
+	// Eviction is the policy for a tenant over its quota: "oldest" or
+	// "reject".
+	// Default: oldest
+	Eviction string `mapstructure:"eviction"`
+}
+
+// Validate checks the configuration for errors.
+func (cfg *Config) Validate() error {
+	if cfg.Storage.String() == "" {
+		return errors.New("storage is required")
+	}
+	if cfg.TenantAttribute == "" {
+		return errors.New("tenant_attribute must not be empty")
+	}
+	if cfg.DefaultQuota < 0 {
+		return errors.New("default_quota must not be negative")
+	}
+	if cfg.OtherQuota < 0 {
+		return errors.New("other_quota must not be negative")
+	}
+	for tenant, quota := range cfg.Quotas {
+		if quota < 0 {
+			return fmt.Errorf("quotas[%s] must not be negative", tenant)
+		}
+	}
+	if cfg.Eviction != EvictionOldest && cfg.Eviction != EvictionReject {
+		return fmt.Errorf("eviction must be %q or %q, got %q", EvictionOldest, EvictionReject, cfg.Eviction)
+	}
+	return nil
+}
+
+// listed reports whether a tenant has an entry in Quotas.
+func (cfg *Config) listed(tenant string) bool {
+	_, ok := cfg.Quotas[tenant]
+	return ok
+}
+
+// quota returns the quota of a tenant in bytes; zero is unbounded.
+func (cfg *Config) quota(tenant string) int64 {
+	if quota, ok := cfg.Quotas[tenant]; ok {
+		return quota.Bytes()
+	}
+	return cfg.DefaultQuota.Bytes()
+}
@@ -0,0 +1,64 @@
+// Package tfoqueuequotaextension provides the TelemetryFlow per-tenant queue quota extension.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// The tfoqueuequotaextension provides:
+//   - Per-tenant disk quotas for persistent sending queues, enforced across
+//     all exporters and signals whose queues use the extension
+//   - Tenants keyed by a resource attribute, tfo.provenance.origin.tenant
+//     by default, which tfootlpreceiver sets from its tenant_header
+//   - An eviction policy per extension: oldest removes the oldest queued
+//     batches of the tenant over quota to make room, reject refuses the
+//     new batch so that the receiver pushes back on that tenant only
+//   - An other_quota shared by the tenants not listed in quotas, so that a
+//     sender naming a new tenant per batch cannot fill the disk
+//   - tfo_queue_tenant_bytes and tfo_queue_tenant_batches gauges, and the
+//     tfo_queue_tenant_dropped counter of evicted and rejected batches. The
+//     tenant label names the tenants listed in quotas and "unknown"; the
+//     others, named by senders, are summed as "other"
+//
+// The extension is a storage extension that wraps another one, usually
+// file_storage, and is referenced from sending_queue::storage in place of
+// it. Batches are charged to the tenant of their first resource carrying
+// the attribute; batches without it are charged to the "unknown" tenant.
+// Batches a sender is exporting are never evicted. Usage is recovered
+// from the queues on disk at start.
+//
+// Evicted batches are skipped when the queue reaches them, but they keep
+// counting against the queue_size of the exporter until its queue next
+// drains. Rejected batches fail the export with a retryable error.
+//
+// Configuration example:
+//
+//	extensions:
+//	  file_storage/queue:
+//	    directory: /var/lib/tfo-collector/queue
+//	  tfoqueuequota:
+//	    storage: file_storage/queue
+//	    default_quota: 256MiB
+//	    other_quota: 2GiB
+//	    quotas:
+//	      acme: 1GiB
+//	    eviction: oldest
+//
+//	exporters:
+//	  tfo:
+//	    sending_queue:
+//	      storage: tfoqueuequota
+//
+//	service:
+//	  extensions: [file_storage/queue, tfoqueuequota]
+package tfoqueuequotaextension // import "github.com/telemetryflow/telemetryflow-collector/components/extension/tfoqueuequotaextension"
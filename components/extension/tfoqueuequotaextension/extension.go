@@ -0,0 +1,381 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoqueuequotaextension
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/xextension/storage"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/bytesize"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+const scopeName = "github.com/telemetryflow/telemetryflow-collector/components/extension/tfoqueuequotaextension"
+
+// unknownTenant is charged for batches without the tenant attribute.
+const unknownTenant = "unknown"
+
+// otherTenants labels the metrics of the tenants not listed in quotas,
+// which senders name and so are unbounded.
+const otherTenants = "other"
+
+// Reasons a batch is dropped, recorded on tfo_queue_tenant_dropped.
+const (
+	reasonEvicted  = "evicted"
+	reasonRejected = "rejected"
+)
+
+// TenantUsage describes the queued batches of a tenant.
+type TenantUsage struct {
+	Tenant  string
+	Bytes   int64
+	Batches int
+}
+
+// queuedBatch is a batch held by a queue on disk.
+type queuedBatch struct {
+	client *quotaClient
+	key    string
+	tenant string
+	size   int64
+	// dispatched is set once the queue has read the batch for export.
+	dispatched bool
+}
+
+// tenantQueue holds the queued batches of a tenant, oldest first.
+type tenantQueue struct {
+	bytes   int64
+	batches []*queuedBatch
+}
+
+// tfoQueueQuotaExtension is a storage extension that enforces per-tenant
+// quotas on the queues of another storage extension.
+type tfoQueueQuotaExtension struct {
+	cfg      *Config
+	settings *extension.Settings
+	logger   *zap.Logger
+
+	inner storage.Extension
+
+	mu      sync.Mutex
+	tenants map[string]*tenantQueue
+	// others holds the batches of the tenants not listed in quotas, for
+	// other_quota.
+	others tenantQueue
+
+	labels       selfmetrics.Labels
+	dropped      metric.Int64Counter
+	registration metric.Registration
+}
+
+var _ storage.Extension = (*tfoQueueQuotaExtension)(nil)
+
+// newTFOQueueQuotaExtension creates a new TFO queue quota extension.
+func newTFOQueueQuotaExtension(cfg *Config, set *extension.Settings) (*tfoQueueQuotaExtension, error) {
+	return &tfoQueueQuotaExtension{
+		cfg:      cfg,
+		settings: set,
+		logger:   set.Logger,
+		tenants:  make(map[string]*tenantQueue),
+		labels:   selfmetrics.Extension(set.ID),
+	}, nil
+}
+
+// Start implements component.Component.
+func (e *tfoQueueQuotaExtension) Start(ctx context.Context, host component.Host) error {
+	ext, ok := host.GetExtensions()[e.cfg.Storage]
+	if !ok {
+		return fmt.Errorf("storage extension %q not found", e.cfg.Storage)
+	}
+	inner, ok := ext.(storage.Extension)
+	if !ok {
+		return fmt.Errorf("extension %q is not a storage extension", e.cfg.Storage)
+	}
+	e.inner = inner
+
+	if e.settings.MeterProvider != nil {
+		meter := e.settings.MeterProvider.Meter(scopeName)
+		var err error
+		e.dropped, err = meter.Int64Counter(selfmetrics.QueueTenantDropped,
+			metric.WithDescription("Queued batches dropped to keep a tenant within its quota, by reason."))
+		if err != nil {
+			return err
+		}
+		queuedBytes, err := meter.Int64ObservableGauge(selfmetrics.QueueTenantBytes,
+			metric.WithDescription("Bytes of the batches queued on disk for a tenant."),
+			metric.WithUnit("By"))
+		if err != nil {
+			return err
+		}
+		queuedBatches, err := meter.Int64ObservableGauge(selfmetrics.QueueTenantBatches,
+			metric.WithDescription("Batches queued on disk for a tenant."))
+		if err != nil {
+			return err
+		}
+		e.registration, err = meter.RegisterCallback(func(_ context.Context, o metric.Observer) error {
+			for _, usage := range e.labelUsage() {
+				opt := e.labels.Option(attribute.String("tenant", usage.Tenant))
+				o.ObserveInt64(queuedBytes, usage.Bytes, opt)
+				o.ObserveInt64(queuedBatches, int64(usage.Batches), opt)
+			}
+			return nil
+		}, queuedBytes, queuedBatches)
+		if err != nil {
+			return err
+		}
+	}
+
+	e.logger.Info("TFO queue quota extension started",
+		zap.String("storage", e.cfg.Storage.String()),
+		zap.String("tenant_attribute", e.cfg.TenantAttribute),
+		zap.Stringer("default_quota", e.cfg.DefaultQuota),
+		zap.Stringer("other_quota", e.cfg.OtherQuota),
+		zap.Int("quotas", len(e.cfg.Quotas)),
+		zap.String("eviction", e.cfg.Eviction),
+	)
+
+	return nil
+}
+
+// Shutdown implements component.Component.
+func (e *tfoQueueQuotaExtension) Shutdown(ctx context.Context) error {
+	var err error
+	if e.registration != nil {
+		err = e.registration.Unregister()
+		e.registration = nil
+	}
+	e.logger.Info("TFO queue quota extension stopped")
+	return err
+}
+
+// GetClient implements storage.Extension. Queues of exporters are tracked;
+// other clients are passed through unchanged.
+func (e *tfoQueueQuotaExtension) GetClient(ctx context.Context, kind component.Kind, id component.ID, name string) (storage.Client, error) {
+	if e.inner == nil {
+		return nil, errors.New("extension not started")
+	}
+	inner, err := e.inner.GetClient(ctx, kind, id, name)
+	if err != nil {
+		return nil, err
+	}
+	signal, ok := queueSignal(kind, name)
+	if !ok {
+		return inner, nil
+	}
+	c := &quotaClient{
+		ext:     e,
+		inner:   inner,
+		signal:  signal,
+		owner:   id,
+		batches: make(map[string]*queuedBatch),
+	}
+	if err := c.load(ctx); err != nil {
+		e.logger.Warn("Failed to recover tenant usage of a queue; its batches are not charged",
+			zap.String("exporter", id.String()), zap.String("signal", name), zap.Error(err))
+	}
+	return c, nil
+}
+
+// queueSignal reports whether a client is the persistent queue of an
+// exporter, which names its client after the signal.
+func queueSignal(kind component.Kind, name string) (pipeline.Signal, bool) {
+	if kind != component.KindExporter {
+		return pipeline.Signal{}, false
+	}
+	for _, signal := range []pipeline.Signal{pipeline.SignalTraces, pipeline.SignalMetrics, pipeline.SignalLogs} {
+		if name == signal.String() {
+			return signal, true
+		}
+	}
+	return pipeline.Signal{}, false
+}
+
+// Usage returns the queued bytes and batches of every tenant with queued
+// batches, sorted by tenant.
+func (e *tfoQueueQuotaExtension) Usage() []TenantUsage {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	usage := make([]TenantUsage, 0, len(e.tenants))
+	for tenant, t := range e.tenants {
+		usage = append(usage, TenantUsage{Tenant: tenant, Bytes: t.bytes, Batches: len(t.batches)})
+	}
+	sort.Slice(usage, func(i, j int) bool { return usage[i].Tenant < usage[j].Tenant })
+	return usage
+}
+
+// labelUsage returns Usage summed by the tenant label of the metrics. The
+// tenants listed in quotas are reported even when nothing is queued.
+func (e *tfoQueueQuotaExtension) labelUsage() []TenantUsage {
+	byLabel := make(map[string]TenantUsage, len(e.cfg.Quotas)+2)
+	for tenant := range e.cfg.Quotas {
+		byLabel[tenant] = TenantUsage{Tenant: tenant}
+	}
+	for _, u := range e.Usage() {
+		label := e.tenantLabel(u.Tenant)
+		sum := byLabel[label]
+		sum.Tenant = label
+		sum.Bytes += u.Bytes
+		sum.Batches += u.Batches
+		byLabel[label] = sum
+	}
+	usage := make([]TenantUsage, 0, len(byLabel))
+	for _, u := range byLabel {
+		usage = append(usage, u)
+	}
+	return usage
+}
+
+// tenantLabel returns the value of the tenant label of the metrics for a
+// tenant: its name when listed in quotas or unknown, "other" otherwise.
+func (e *tfoQueueQuotaExtension) tenantLabel(tenant string) string {
+	if tenant == unknownTenant || e.cfg.listed(tenant) {
+		return tenant
+	}
+	return otherTenants
+}
+
+// admit charges a new batch to its tenant. Over the quota of the tenant,
+// or over other_quota for a tenant not listed in quotas, it returns the
+// batches to evict to make room, or an error when the batch is refused.
+// The caller holds e.mu.
+func (e *tfoQueueQuotaExtension) admit(b *queuedBatch) ([]*queuedBatch, error) {
+	var t *tenantQueue
+	if t = e.tenants[b.tenant]; t == nil {
+		t = &tenantQueue{}
+	}
+	evicted, err := e.makeRoom(b, t, e.cfg.quota(b.tenant), nil,
+		fmt.Sprintf("tenant %q is over its queue quota", b.tenant))
+	if err != nil {
+		return nil, err
+	}
+	if !e.cfg.listed(b.tenant) {
+		more, err := e.makeRoom(b, &e.others, e.cfg.OtherQuota.Bytes(), evicted,
+			"the tenants not listed in quotas are over their shared queue quota")
+		if err != nil {
+			return nil, err
+		}
+		evicted = append(evicted, more...)
+	}
+	for _, old := range evicted {
+		e.remove(old)
+	}
+	e.add(b)
+	return evicted, nil
+}
+
+// makeRoom returns the oldest batches of q, other than those already
+// chosen, to evict so that b fits quota; zero is unbounded. Batches being
+// exported are never chosen. It returns an error naming the problem when
+// b is to be refused. The caller holds e.mu.
+func (e *tfoQueueQuotaExtension) makeRoom(b *queuedBatch, q *tenantQueue, quota int64, chosen []*queuedBatch, problem string) ([]*queuedBatch, error) {
+	need := q.bytes + b.size - quota
+	for _, old := range chosen {
+		need -= old.size
+	}
+	if quota <= 0 || need <= 0 {
+		return nil, nil
+	}
+	if e.cfg.Eviction == EvictionReject || b.size > quota {
+		return nil, overQuota(problem, quota)
+	}
+	var evicted []*queuedBatch
+	for _, old := range q.batches {
+		if need <= 0 {
+			break
+		}
+		if old.dispatched || slices.Contains(chosen, old) {
+			continue
+		}
+		evicted = append(evicted, old)
+		need -= old.size
+	}
+	if need > 0 {
+		// The rest of the batches are being exported.
+		return nil, overQuota(problem, quota)
+	}
+	return evicted, nil
+}
+
+// overQuota returns the error refusing a batch.
+func overQuota(problem string, quota int64) error {
+	return fmt.Errorf("%s of %s: %w", problem, bytesize.Size(quota), storage.ErrStorageFull)
+}
+
+// add charges a batch to its tenant. The caller holds e.mu.
+func (e *tfoQueueQuotaExtension) add(b *queuedBatch) {
+	t, ok := e.tenants[b.tenant]
+	if !ok {
+		t = &tenantQueue{}
+		e.tenants[b.tenant] = t
+	}
+	t.push(b)
+	if !e.cfg.listed(b.tenant) {
+		e.others.push(b)
+	}
+	b.client.batches[b.key] = b
+}
+
+// remove releases a batch from its tenant, forgetting the tenant once
+// nothing of it is queued. The caller holds e.mu.
+func (e *tfoQueueQuotaExtension) remove(b *queuedBatch) {
+	delete(b.client.batches, b.key)
+	t, ok := e.tenants[b.tenant]
+	if !ok {
+		return
+	}
+	t.drop(b)
+	if len(t.batches) == 0 {
+		delete(e.tenants, b.tenant)
+	}
+	if !e.cfg.listed(b.tenant) {
+		e.others.drop(b)
+	}
+}
+
+// push appends a batch to q.
+func (q *tenantQueue) push(b *queuedBatch) {
+	q.bytes += b.size
+	q.batches = append(q.batches, b)
+}
+
+// drop removes a batch from q.
+func (q *tenantQueue) drop(b *queuedBatch) {
+	for i, queued := range q.batches {
+		if queued == b {
+			q.batches = append(q.batches[:i], q.batches[i+1:]...)
+			q.bytes -= b.size
+			return
+		}
+	}
+}
+
+// recordDropped counts a batch evicted or rejected for a tenant.
+func (e *tfoQueueQuotaExtension) recordDropped(ctx context.Context, tenant, reason string) {
+	if e.dropped != nil {
+		e.dropped.Add(ctx, 1, e.labels.Option(attribute.String("tenant", e.tenantLabel(tenant)), attribute.String("reason", reason)))
+	}
+}
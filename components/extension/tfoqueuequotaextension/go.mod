@@ -0,0 +1,43 @@
+module github.com/telemetryflow/telemetryflow-collector/components/extension/tfoqueuequotaextension
+
+go 1.26
+
+require (
+	github.com/telemetryflow/telemetryflow-collector/pkg/bytesize v0.0.0
+	github.com/telemetryflow/telemetryflow-collector/pkg/provenance v0.0.0
+	github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics v0.0.0
+	go.opentelemetry.io/collector/component v1.52.0
+	go.opentelemetry.io/collector/extension v1.52.0
+	go.opentelemetry.io/collector/extension/xextension v0.146.1
+	go.opentelemetry.io/collector/pdata v1.52.0
+	go.opentelemetry.io/collector/pdata/xpdata v0.146.1
+	go.opentelemetry.io/collector/pipeline v1.52.0
+	go.opentelemetry.io/otel v1.40.0
+	go.opentelemetry.io/otel/metric v1.40.0
+	go.uber.org/zap v1.27.1
+	google.golang.org/protobuf v1.36.11
+)
+
+require (
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/hashicorp/go-version v1.8.0 // indirect
+	github.com/json-iterator/go v1.1.12 // indirect
+	github.com/modern-go/concurrent v0.0.0-20180306012644-bacd9c7ef1dd // indirect
+	github.com/modern-go/reflect2 v1.0.3-0.20250322232337-35a7c28c31ee // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/stretchr/testify v1.11.1 // indirect
+	go.opentelemetry.io/collector/client v1.52.0 // indirect
+	go.opentelemetry.io/collector/featuregate v1.52.0 // indirect
+	go.opentelemetry.io/collector/internal/componentalias v0.146.1 // indirect
+	go.opentelemetry.io/collector/pdata/pprofile v0.146.1 // indirect
+	go.opentelemetry.io/otel/trace v1.40.0 // indirect
+	go.uber.org/multierr v1.11.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/telemetryflow/telemetryflow-collector/pkg/bytesize => ../../../pkg/bytesize
+
+replace github.com/telemetryflow/telemetryflow-collector/pkg/provenance => ../../../pkg/provenance
+
+replace github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics => ../../../pkg/selfmetrics
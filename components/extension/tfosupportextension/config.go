@@ -0,0 +1,95 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfosupportextension
+
+import (
+	"errors"
+	"time"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+)
+
+// Config defines the configuration for the TFO support extension.
+type Config struct {
+	// AdminConfig defines the admin API listener: endpoint, tls, auth, acl
+	// and the other shared server settings. The bundle holds the
+	// configuration and the logs, so an endpoint off localhost requires
+	// auth or mTLS.
+	// Default endpoint: localhost:55694
+	serverconf.AdminConfig `mapstructure:",squash"`
+
+	// LogFiles are log files bundled in addition to those of
+	// service::telemetry::logs::output_paths.
+	LogFiles []string `mapstructure:"log_files"`
+
+	// Preview bounds the exporter payload previews started through the
+	// admin API.
+	Preview PreviewConfig `mapstructure:"preview"`
+
+	// History keeps the increases of the key collector counters, scraped
+	// from the self-metrics, for the /stats/history resource.
+	History HistoryConfig `mapstructure:"history"`
+}
+
+// PreviewConfig bounds the exporter payload previews.
+type PreviewConfig struct {
+	// MaxCount is the largest number of payloads a preview may record.
+	// Default: 20
+	MaxCount int `mapstructure:"max_count"`
+
+	// MaxDuration is the longest a preview may stay active.
+	// Default: 10m
+	MaxDuration time.Duration `mapstructure:"max_duration"`
+}
+
+// HistoryConfig defines the history of the key collector counters.
+type HistoryConfig struct {
+	// Enabled scrapes the self-metrics of the collector every interval.
+	// Default: true
+	Enabled bool `mapstructure:"enabled"`
+
+	// Interval is the time between two points of the history.
+	// Default: 1m
+	Interval time.Duration `mapstructure:"interval"`
+
+	// Size is the number of points kept, the oldest dropped first.
+	// Default: 60
+	Size int `mapstructure:"size"`
+}
+
+// Validate checks the configuration for errors.
+func (cfg *Config) Validate() error {
+	if cfg.NetAddr.Endpoint == "" {
+		return errors.New("endpoint is required")
+	}
+	if err := cfg.AdminConfig.Validate(); err != nil {
+		return err
+	}
+	if cfg.Preview.MaxCount <= 0 {
+		return errors.New("preview.max_count must be positive")
+	}
+	if cfg.Preview.MaxDuration <= 0 {
+		return errors.New("preview.max_duration must be positive")
+	}
+	if cfg.History.Enabled {
+		if cfg.History.Interval <= 0 {
+			return errors.New("history.interval must be positive")
+		}
+		if cfg.History.Size <= 0 {
+			return errors.New("history.size must be positive")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+// Package tfosupportextension provides the TelemetryFlow support bundle extension.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// The tfosupportextension provides:
+//   - An admin API serving a support bundle of the running collector as a
+//     gzip-compressed tar archive, to attach to support tickets
+//   - The effective configuration with secrets redacted, version and build
+//     information, the end of the log files, a scrape of the self-metrics,
+//     goroutine and heap profiles of the process, and the file listings of
+//     queue, storage and capture directories
+//   - Previews of the next payloads a tfo exporter sends, after all
+//     processors ran and before compression, as OTLP JSON
+//   - A history of the records received, sent and dropped per signal over
+//     the last hour, to spot a recent drop spike without external
+//     monitoring
+//
+// The configuration, log files, metrics endpoint and state directories are
+// taken from the effective configuration as described by
+// supportbundle.FromConfig; log_files adds files the configuration does not
+// name, e.g. where a service manager redirects stderr. Items that cannot be
+// collected are listed in errors.txt in the bundle. The profiles are
+// those of the process serving the request, so a pprof extension is not
+// needed. The "tfo-collector debug bundle --endpoint" command downloads the
+// bundle from the admin API.
+//
+// A preview records the next payloads of one exporter, optionally of one
+// signal, and stops once it recorded its count or its duration elapsed;
+// its payloads stay readable until the next preview of the exporter
+// starts. preview bounds the count and duration a request may ask for.
+// Previews hold the exported data as is, so they are subject to the same
+// care as the bundle.
+//
+// The history scrapes the self-metrics of the collector every interval,
+// sums the otelcol_receiver_accepted, otelcol_exporter_sent and dropped
+// (receiver refused and failed, exporter send and enqueue failed) counters
+// per signal and keeps the increases of the last size intervals in memory,
+// with the exporter queue size at the end of each. A failed scrape adds no
+// point; the next one spans the gap. The history starts empty on every
+// restart.
+//
+// The admin API serves the /bundle, /preview and /stats resources:
+//
+//	GET    /bundle              support bundle (application/gzip)
+//	GET    /stats               counter totals of the last scrape
+//	GET    /stats/history       counter increases per interval, oldest first
+//	GET    /preview             exporters that support previews
+//	POST   /preview/{exporter}  start a preview: {"signal": "logs", "count": 5, "duration": "2m"}
+//	GET    /preview/{exporter}  current or last preview with its payloads
+//	DELETE /preview/{exporter}  stop the active preview
+//
+// The admin listener takes the shared admin server settings of
+// pkg/serverconf (tls, auth, acl, network, response_headers and the
+// timeouts). An endpoint whose host is not a loopback address is refused
+// unless clients authenticate through auth or a client certificate verified
+// against tls.client_ca_file.
+//
+// Configuration example:
+//
+//	extensions:
+//	  tfosupport:
+//	    endpoint: localhost:55694
+//	    log_files: [/var/log/tfo-collector/collector.log]
+//	    preview:
+//	      max_count: 20
+//	      max_duration: 10m
+//	    history:
+//	      enabled: true
+//	      interval: 1m
+//	      size: 60
+//
+//	service:
+//	  extensions: [tfosupport]
+package tfosupportextension // import "github.com/telemetryflow/telemetryflow-collector/components/extension/tfosupportextension"
@@ -0,0 +1,178 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfosupportextension
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"runtime"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/extensioncapabilities"
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/supportbundle"
+)
+
+// bundlePath is the admin API resource for the support bundle.
+const bundlePath = "/bundle"
+
+var _ extensioncapabilities.ConfigWatcher = (*tfoSupportExtension)(nil)
+
+// tfoSupportExtension serves support bundles of the running collector.
+type tfoSupportExtension struct {
+	cfg      *Config
+	settings *extension.Settings
+	logger   *zap.Logger
+
+	// config is the effective configuration, set by NotifyConfig.
+	config atomic.Pointer[map[string]any]
+
+	// history records the key counters (nil when disabled); kick
+	// triggers its first scrape once the configuration is known.
+	history *statsHistory
+	kick    chan struct{}
+	cancel  context.CancelFunc
+
+	server *serverconf.AdminServer
+	wg     sync.WaitGroup
+}
+
+// newTFOSupportExtension creates a new TFO support extension.
+func newTFOSupportExtension(cfg *Config, set *extension.Settings) *tfoSupportExtension {
+	return &tfoSupportExtension{
+		cfg:      cfg,
+		settings: set,
+		logger:   set.Logger,
+	}
+}
+
+// Start implements component.Component.
+func (e *tfoSupportExtension) Start(ctx context.Context, host component.Host) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc(bundlePath, e.handleBundle)
+	mux.HandleFunc(previewPath, e.handlePreviews)
+	mux.HandleFunc(previewPath+"/", e.handlePreview)
+	if e.cfg.History.Enabled {
+		e.history = newStatsHistory(e.cfg.History, e.metricsURL, e.logger)
+		e.kick = make(chan struct{}, 1)
+		mux.HandleFunc(statsPath, e.history.handleStats)
+		mux.HandleFunc(statsHistoryPath, e.history.handleHistory)
+	}
+
+	server, err := e.cfg.StartAdmin(ctx, host, "Support admin API", mux, e.logger)
+	if err != nil {
+		return fmt.Errorf("support admin API: %w", err)
+	}
+	e.server = server
+
+	if e.history != nil {
+		var historyCtx context.Context
+		historyCtx, e.cancel = context.WithCancel(context.Background())
+		e.wg.Go(func() {
+			e.history.run(historyCtx, e.kick)
+		})
+	}
+
+	e.logger.Info("TFO support extension started", zap.String("endpoint", e.cfg.NetAddr.Endpoint))
+	return nil
+}
+
+// Shutdown implements component.Component.
+func (e *tfoSupportExtension) Shutdown(ctx context.Context) error {
+	if e.cancel != nil {
+		e.cancel()
+	}
+	err := e.server.Shutdown(ctx)
+	e.server = nil
+	e.wg.Wait()
+	e.logger.Info("TFO support extension stopped")
+	return err
+}
+
+// NotifyConfig implements extensioncapabilities.ConfigWatcher. It keeps
+// the effective configuration for the bundles.
+func (e *tfoSupportExtension) NotifyConfig(_ context.Context, conf *confmap.Conf) error {
+	cfg := conf.ToStringMap()
+	e.config.Store(&cfg)
+	if e.kick != nil {
+		select {
+		case e.kick <- struct{}{}:
+		default:
+		}
+	}
+	return nil
+}
+
+// metricsURL returns the self-metrics URL of the effective configuration,
+// or "" while it is unknown or the collector serves none.
+func (e *tfoSupportExtension) metricsURL() string {
+	cfg := e.config.Load()
+	if cfg == nil {
+		return ""
+	}
+	return supportbundle.FromConfig(*cfg).MetricsURL
+}
+
+// bundleOptions returns the options of a bundle of the running collector.
+func (e *tfoSupportExtension) bundleOptions() supportbundle.Options {
+	var opts supportbundle.Options
+	if cfg := e.config.Load(); cfg != nil {
+		opts = supportbundle.FromConfig(*cfg)
+	}
+	for _, f := range e.cfg.LogFiles {
+		if !slices.Contains(opts.LogFiles, f) {
+			opts.LogFiles = append(opts.LogFiles, f)
+		}
+	}
+	opts.PprofURL = ""
+	opts.ProfileProcess = true
+	info := e.settings.BuildInfo
+	opts.BuildInfo = map[string]string{
+		"command":     info.Command,
+		"description": info.Description,
+		"version":     info.Version,
+		"go_version":  runtime.Version(),
+		"os":          runtime.GOOS,
+		"arch":        runtime.GOARCH,
+	}
+	return opts
+}
+
+// handleBundle serves the support bundle:
+//
+//	GET /bundle  support bundle (application/gzip)
+func (e *tfoSupportExtension) handleBundle(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	name := fmt.Sprintf("%s-support-%s.tar.gz", e.settings.BuildInfo.Command, time.Now().UTC().Format("20060102T150405Z"))
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", name))
+	if err := supportbundle.Write(req.Context(), w, e.bundleOptions()); err != nil {
+		e.logger.Warn("Failed to write support bundle", zap.Error(err))
+		return
+	}
+	e.logger.Info("Support bundle served", zap.String("remote_addr", req.RemoteAddr))
+}
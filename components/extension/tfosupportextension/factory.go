@@ -0,0 +1,84 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfosupportextension
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+)
+
+const (
+	// TypeStr is the type string identifier for the TFO support extension.
+	TypeStr = "tfosupport"
+
+	// DefaultEndpoint is the default listen address of the admin API.
+	DefaultEndpoint = "localhost:55694"
+
+	// DefaultPreviewMaxCount is the default largest number of payloads a
+	// preview may record.
+	DefaultPreviewMaxCount = 20
+
+	// DefaultPreviewMaxDuration is the default longest a preview may stay
+	// active.
+	DefaultPreviewMaxDuration = 10 * time.Minute
+
+	// DefaultHistoryInterval is the default time between two points of the
+	// counter history.
+	DefaultHistoryInterval = time.Minute
+
+	// DefaultHistorySize is the default number of points of the counter
+	// history: an hour at the default interval.
+	DefaultHistorySize = 60
+)
+
+// NewFactory creates a new factory for the TFO support extension.
+func NewFactory() extension.Factory {
+	return extension.NewFactory(
+		component.MustNewType(TypeStr),
+		createDefaultConfig,
+		createExtension,
+		component.StabilityLevelAlpha,
+	)
+}
+
+// createDefaultConfig creates the default configuration for the extension.
+func createDefaultConfig() component.Config {
+	return &Config{
+		AdminConfig: serverconf.NewDefaultAdminConfig(DefaultEndpoint),
+		Preview: PreviewConfig{
+			MaxCount:    DefaultPreviewMaxCount,
+			MaxDuration: DefaultPreviewMaxDuration,
+		},
+		History: HistoryConfig{
+			Enabled:  true,
+			Interval: DefaultHistoryInterval,
+			Size:     DefaultHistorySize,
+		},
+	}
+}
+
+// createExtension creates the TFO support extension.
+func createExtension(
+	_ context.Context,
+	set extension.Settings,
+	cfg component.Config,
+) (extension.Extension, error) {
+	return newTFOSupportExtension(cfg.(*Config), &set), nil
+}
@@ -0,0 +1,143 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfosupportextension
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/payloadpreview"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+)
+
+// previewPath is the admin API resource for the exporter payload previews.
+const previewPath = "/preview"
+
+// previewRequest is the body of a POST /preview/{exporter} request.
+type previewRequest struct {
+	// Signal restricts the preview to traces, metrics or logs.
+	Signal   string `json:"signal"`
+	Count    int    `json:"count"`
+	Duration string `json:"duration"`
+}
+
+// defaultPreviewCount is the number of payloads recorded when a request
+// does not set one.
+const defaultPreviewCount = 5
+
+// handlePreviews lists the exporters that support previews:
+//
+//	GET /preview  {"exporters": ["tfo", "tfo/backup"]}
+func (e *tfoSupportExtension) handlePreviews(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	serverconf.WriteJSON(w, http.StatusOK, map[string][]string{"exporters": payloadpreview.Exporters()})
+}
+
+// handlePreview serves the payload preview of an exporter:
+//
+//	POST   /preview/{exporter}  start a preview: {"signal": "logs", "count": 5, "duration": "2m"}
+//	GET    /preview/{exporter}  current or last preview with its payloads
+//	DELETE /preview/{exporter}  stop the active preview
+func (e *tfoSupportExtension) handlePreview(w http.ResponseWriter, req *http.Request) {
+	exporter := strings.TrimPrefix(req.URL.Path, previewPath+"/")
+	p, ok := payloadpreview.Lookup(exporter)
+	if !ok {
+		serverconf.WriteJSON(w, http.StatusNotFound, map[string]string{"error": fmt.Sprintf("unknown exporter %q", exporter)})
+		return
+	}
+
+	switch req.Method {
+	case http.MethodPost:
+		e.handlePreviewStart(w, req, p)
+	case http.MethodGet:
+		status, ok := p.Status()
+		if !ok {
+			serverconf.WriteJSON(w, http.StatusNotFound, map[string]string{"error": "no preview"})
+			return
+		}
+		serverconf.WriteJSON(w, http.StatusOK, status)
+	case http.MethodDelete:
+		status, ok := p.Stop()
+		if !ok {
+			serverconf.WriteJSON(w, http.StatusNotFound, map[string]string{"error": "no preview"})
+			return
+		}
+		serverconf.WriteJSON(w, http.StatusOK, status)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handlePreviewStart validates a preview request and starts the preview.
+func (e *tfoSupportExtension) handlePreviewStart(w http.ResponseWriter, req *http.Request, p *payloadpreview.Preview) {
+	var body previewRequest
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		serverconf.WriteJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid request body: " + err.Error()})
+		return
+	}
+
+	switch body.Signal {
+	case "", "traces", "metrics", "logs":
+	default:
+		serverconf.WriteJSON(w, http.StatusBadRequest, map[string]string{"error": "signal must be one of traces, metrics, logs"})
+		return
+	}
+
+	maxCount := e.cfg.Preview.MaxCount
+	count := body.Count
+	if count == 0 {
+		count = min(defaultPreviewCount, maxCount)
+	}
+	if count < 0 || count > maxCount {
+		serverconf.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": fmt.Sprintf("count must be between 1 and %d", maxCount),
+		})
+		return
+	}
+
+	duration := e.cfg.Preview.MaxDuration
+	if body.Duration != "" {
+		d, err := time.ParseDuration(body.Duration)
+		if err != nil || d <= 0 || d > e.cfg.Preview.MaxDuration {
+			serverconf.WriteJSON(w, http.StatusBadRequest, map[string]string{
+				"error": fmt.Sprintf("duration must be a positive duration of at most %s", e.cfg.Preview.MaxDuration),
+			})
+			return
+		}
+		duration = d
+	}
+
+	status, err := p.Start(body.Signal, count, duration)
+	if errors.Is(err, payloadpreview.ErrActive) {
+		serverconf.WriteJSON(w, http.StatusConflict, map[string]string{"error": "a preview of " + status.Exporter + " is already active"})
+		return
+	}
+	e.logger.Warn("Payload preview started",
+		zap.String("exporter", status.Exporter),
+		zap.String("signal", body.Signal),
+		zap.Int("count", count),
+		zap.Duration("duration", duration),
+	)
+	serverconf.WriteJSON(w, http.StatusCreated, status)
+}
@@ -0,0 +1,326 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfosupportextension
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+)
+
+// Admin API resources for the key collector counters.
+const (
+	statsPath        = "/stats"
+	statsHistoryPath = "/stats/history"
+)
+
+// Prefixes of the collector metrics counting records, followed by the
+// record kind, e.g. otelcol_receiver_accepted_spans.
+var (
+	receivedPrefixes = []string{"otelcol_receiver_accepted_"}
+	sentPrefixes     = []string{"otelcol_exporter_sent_"}
+	droppedPrefixes  = []string{
+		"otelcol_receiver_refused_",
+		"otelcol_receiver_failed_",
+		"otelcol_exporter_send_failed_",
+		"otelcol_exporter_enqueue_failed_",
+	}
+)
+
+// queueSizeName is the gauge of the batches in the exporter sending queues.
+const queueSizeName = "otelcol_exporter_queue_size"
+
+// signalCounts are record counts by signal.
+type signalCounts struct {
+	Traces  float64 `json:"traces"`
+	Metrics float64 `json:"metrics"`
+	Logs    float64 `json:"logs"`
+}
+
+// add adds v to the count of the signal of the record kind, e.g. spans.
+func (c *signalCounts) add(kind string, v float64) {
+	switch kind {
+	case "spans":
+		c.Traces += v
+	case "metric_points":
+		c.Metrics += v
+	case "log_records":
+		c.Logs += v
+	}
+}
+
+// increase returns the increase from prev to c. A count lower than before
+// means the counter was reset, so its whole value is new.
+func (c signalCounts) increase(prev signalCounts) signalCounts {
+	return signalCounts{
+		Traces:  increase(prev.Traces, c.Traces),
+		Metrics: increase(prev.Metrics, c.Metrics),
+		Logs:    increase(prev.Logs, c.Logs),
+	}
+}
+
+func increase(prev, cur float64) float64 {
+	if cur < prev {
+		return cur
+	}
+	return cur - prev
+}
+
+// statsCounters are the key collector counters. Dropped sums the records
+// receivers refused or failed and those exporters failed to send or
+// enqueue.
+type statsCounters struct {
+	Received signalCounts `json:"received"`
+	Sent     signalCounts `json:"sent"`
+	Dropped  signalCounts `json:"dropped"`
+	// QueueSize is a gauge: the batches in the sending queues.
+	QueueSize float64 `json:"queue_size"`
+}
+
+// statsSnapshot are the counter totals of one scrape.
+type statsSnapshot struct {
+	Time time.Time `json:"time"`
+	statsCounters
+}
+
+// statsPoint are the counter increases between two scrapes; QueueSize is
+// the value at End.
+type statsPoint struct {
+	Start time.Time `json:"start"`
+	End   time.Time `json:"end"`
+	statsCounters
+}
+
+// statsHistory scrapes the collector self-metrics every interval and keeps
+// the counter increases of the last points.
+type statsHistory struct {
+	cfg    HistoryConfig
+	url    func() string
+	client *http.Client
+	logger *zap.Logger
+
+	mu     sync.Mutex
+	last   *statsSnapshot
+	points []statsPoint
+	err    error
+}
+
+// newStatsHistory creates a history of the self-metrics served at the URL
+// returned by url, "" while unknown.
+func newStatsHistory(cfg HistoryConfig, url func() string, logger *zap.Logger) *statsHistory {
+	return &statsHistory{
+		cfg:    cfg,
+		url:    url,
+		client: &http.Client{Timeout: 10 * time.Second},
+		logger: logger,
+	}
+}
+
+// run records a point every interval until ctx is done. kick records the
+// first scrape early, once the metrics URL is known.
+func (h *statsHistory) run(ctx context.Context, kick <-chan struct{}) {
+	ticker := time.NewTicker(h.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-kick:
+			h.mu.Lock()
+			started := h.last != nil
+			h.mu.Unlock()
+			if !started {
+				h.record(ctx)
+			}
+		case <-ticker.C:
+			h.record(ctx)
+		}
+	}
+}
+
+// record scrapes the self-metrics and appends the increase since the last
+// successful scrape. A failed scrape adds no point; the next point spans
+// the gap.
+func (h *statsHistory) record(ctx context.Context) {
+	url := h.url()
+	if url == "" {
+		h.setError(errors.New("the collector serves no Prometheus self-metrics"))
+		return
+	}
+	counters, err := h.scrape(ctx, url)
+	if err != nil {
+		h.logger.Debug("Failed to scrape self-metrics", zap.String("url", url), zap.Error(err))
+		h.setError(err)
+		return
+	}
+
+	now := time.Now().UTC()
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.err = nil
+	if prev := h.last; prev != nil {
+		h.points = append(h.points, statsPoint{
+			Start: prev.Time,
+			End:   now,
+			statsCounters: statsCounters{
+				Received:  counters.Received.increase(prev.Received),
+				Sent:      counters.Sent.increase(prev.Sent),
+				Dropped:   counters.Dropped.increase(prev.Dropped),
+				QueueSize: counters.QueueSize,
+			},
+		})
+		if n := len(h.points) - h.cfg.Size; n > 0 {
+			h.points = append(h.points[:0], h.points[n:]...)
+		}
+	}
+	h.last = &statsSnapshot{Time: now, statsCounters: counters}
+}
+
+func (h *statsHistory) setError(err error) {
+	h.mu.Lock()
+	h.err = err
+	h.mu.Unlock()
+}
+
+// scrape reads the key counters from the self-metrics at url.
+func (h *statsHistory) scrape(ctx context.Context, url string) (statsCounters, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return statsCounters{}, err
+	}
+	req.Header.Set("Accept", string(expfmt.NewFormat(expfmt.TypeTextPlain)))
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return statsCounters{}, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return statsCounters{}, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	counters, err := parseCounters(resp.Body, expfmt.ResponseFormat(resp.Header))
+	if err != nil {
+		return statsCounters{}, fmt.Errorf("parse metrics: %w", err)
+	}
+	return counters, nil
+}
+
+// parseCounters sums the metric families of a Prometheus exposition in
+// format into the key counters. Unknown metrics are ignored.
+func parseCounters(r io.Reader, format expfmt.Format) (statsCounters, error) {
+	var c statsCounters
+	dec := expfmt.NewDecoder(r, format)
+	for {
+		var f dto.MetricFamily
+		if err := dec.Decode(&f); err != nil {
+			if errors.Is(err, io.EOF) {
+				return c, nil
+			}
+			return statsCounters{}, err
+		}
+		name := strings.TrimSuffix(f.GetName(), "_total")
+		if name == queueSizeName {
+			c.QueueSize += sum(&f)
+		} else if kind, ok := cutPrefix(name, receivedPrefixes); ok {
+			c.Received.add(kind, sum(&f))
+		} else if kind, ok := cutPrefix(name, sentPrefixes); ok {
+			c.Sent.add(kind, sum(&f))
+		} else if kind, ok := cutPrefix(name, droppedPrefixes); ok {
+			c.Dropped.add(kind, sum(&f))
+		}
+	}
+}
+
+// cutPrefix returns name without the first of prefixes it starts with.
+func cutPrefix(name string, prefixes []string) (string, bool) {
+	for _, prefix := range prefixes {
+		if rest, ok := strings.CutPrefix(name, prefix); ok {
+			return rest, true
+		}
+	}
+	return "", false
+}
+
+// sum returns the sum of the counter, gauge or untyped samples of f.
+func sum(f *dto.MetricFamily) float64 {
+	var total float64
+	for _, m := range f.GetMetric() {
+		switch {
+		case m.GetCounter() != nil:
+			total += m.GetCounter().GetValue()
+		case m.GetGauge() != nil:
+			total += m.GetGauge().GetValue()
+		default:
+			total += m.GetUntyped().GetValue()
+		}
+	}
+	return total
+}
+
+// handleStats serves the totals of the last scrape:
+//
+//	GET /stats  {"time": ..., "received": {"traces": 120, ...}, "sent": ..., "dropped": ..., "queue_size": 0}
+func (h *statsHistory) handleStats(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	h.mu.Lock()
+	last, err := h.last, h.err
+	h.mu.Unlock()
+	if last == nil {
+		msg := "no scrape of the self-metrics yet"
+		if err != nil {
+			msg += ": " + err.Error()
+		}
+		serverconf.WriteJSON(w, http.StatusServiceUnavailable, map[string]string{"error": msg})
+		return
+	}
+	serverconf.WriteJSON(w, http.StatusOK, last)
+}
+
+// handleHistory serves the points, oldest first:
+//
+//	GET /stats/history  {"interval": "1m0s", "points": [{"start": ..., "end": ..., "received": ..., ...}]}
+func (h *statsHistory) handleHistory(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	h.mu.Lock()
+	resp := struct {
+		Interval string       `json:"interval"`
+		Points   []statsPoint `json:"points"`
+		Error    string       `json:"error,omitempty"`
+	}{
+		Interval: h.cfg.Interval.String(),
+		Points:   append([]statsPoint{}, h.points...),
+	}
+	if h.err != nil {
+		resp.Error = h.err.Error()
+	}
+	h.mu.Unlock()
+	serverconf.WriteJSON(w, http.StatusOK, resp)
+}
@@ -0,0 +1,116 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoalertconnector
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// Severity is the severity of the alert records emitted for a rule.
+type Severity string
+
+// Supported severities.
+const (
+	SeverityInfo  Severity = "info"
+	SeverityWarn  Severity = "warn"
+	SeverityError Severity = "error"
+	SeverityFatal Severity = "fatal"
+)
+
+// number returns the log severity number for s.
+func (s Severity) number() plog.SeverityNumber {
+	switch s {
+	case SeverityInfo:
+		return plog.SeverityNumberInfo
+	case SeverityError:
+		return plog.SeverityNumberError
+	case SeverityFatal:
+		return plog.SeverityNumberFatal
+	default:
+		return plog.SeverityNumberWarn
+	}
+}
+
+// Config defines the configuration for the TFO alert connector.
+type Config struct {
+	// EvaluationInterval is how often rules are evaluated.
+	// Default: 15s
+	EvaluationInterval time.Duration `mapstructure:"evaluation_interval"`
+
+	// Rules are the alert rules to evaluate.
+	Rules []RuleConfig `mapstructure:"rules"`
+}
+
+// RuleConfig defines a single alert rule.
+type RuleConfig struct {
+	// Name identifies the rule in emitted alerts. Must be unique.
+	Name string `mapstructure:"name"`
+
+	// Expr is the threshold expression, see the package documentation.
+	Expr string `mapstructure:"expr"`
+
+	// Attributes restricts the rule to data points whose resource or data
+	// point attributes have these values.
+	Attributes map[string]string `mapstructure:"attributes"`
+
+	// For is how long the expression must hold before the rule fires.
+	// Default: 0 (fire on the first evaluation that holds)
+	For time.Duration `mapstructure:"for"`
+
+	// Severity is one of info, warn, error or fatal.
+	// Default: warn
+	Severity Severity `mapstructure:"severity"`
+
+	// Description is copied to the alert.description attribute.
+	Description string `mapstructure:"description"`
+}
+
+// Validate checks the configuration for errors.
+func (cfg *Config) Validate() error {
+	if cfg.EvaluationInterval <= 0 {
+		return errors.New("evaluation_interval must be positive")
+	}
+	if len(cfg.Rules) == 0 {
+		return errors.New("at least one rule is required")
+	}
+
+	seen := make(map[string]struct{}, len(cfg.Rules))
+	for i, rule := range cfg.Rules {
+		if rule.Name == "" {
+			return fmt.Errorf("rules[%d]: name is required", i)
+		}
+		if _, dup := seen[rule.Name]; dup {
+			return fmt.Errorf("rules[%d]: duplicate rule name %q", i, rule.Name)
+		}
+		seen[rule.Name] = struct{}{}
+
+		if _, err := parseExpr(rule.Expr); err != nil {
+			return fmt.Errorf("rule %q: %w", rule.Name, err)
+		}
+		if rule.For < 0 {
+			return fmt.Errorf("rule %q: for must not be negative", rule.Name)
+		}
+		switch rule.Severity {
+		case "", SeverityInfo, SeverityWarn, SeverityError, SeverityFatal:
+		default:
+			return fmt.Errorf("rule %q: severity must be info, warn, error or fatal, got %q", rule.Name, rule.Severity)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,370 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoalertconnector
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+const scopeName = "github.com/telemetryflow/telemetryflow-collector/components/tfoalertconnector"
+
+// EventName is the event name of emitted alert log records.
+const EventName = "tfo.alert"
+
+// Alert states reported in the alert.state attribute.
+const (
+	stateFiring   = "firing"
+	stateResolved = "resolved"
+)
+
+// rule is a configured rule and the series it tracks.
+type rule struct {
+	cfg        RuleConfig
+	expr       expr
+	staleAfter time.Duration
+
+	series map[string]*series
+
+	// lastSeen, absence and absentAttrs are used by absent rules only.
+	lastSeen    time.Time
+	absence     alertState
+	absentAttrs pcommon.Map
+}
+
+// transition is a rule changing state for one series.
+type transition struct {
+	rule  *rule
+	state string
+	value float64
+	attrs pcommon.Map
+}
+
+// alertConnector evaluates rules over metrics and emits alert log records.
+type alertConnector struct {
+	cfg    *Config
+	logger *zap.Logger
+	next   consumer.Logs
+
+	mu       sync.Mutex
+	rules    []*rule
+	byMetric map[string][]*rule
+
+	labels      selfmetrics.Labels
+	transitions metric.Int64Counter
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// newAlertConnector creates the connector for cfg. Transitions are counted
+// under labels.
+func newAlertConnector(cfg *Config, set component.TelemetrySettings, labels selfmetrics.Labels, next consumer.Logs) (*alertConnector, error) {
+	c := &alertConnector{
+		cfg:      cfg,
+		logger:   set.Logger,
+		next:     next,
+		byMetric: make(map[string][]*rule),
+		labels:   labels,
+	}
+
+	for _, rc := range cfg.Rules {
+		e, err := parseExpr(rc.Expr)
+		if err != nil {
+			return nil, fmt.Errorf("rule %q: %w", rc.Name, err)
+		}
+		r := &rule{
+			cfg:        rc,
+			expr:       e,
+			staleAfter: max(e.window, defaultStaleness),
+			series:     make(map[string]*series),
+		}
+		if e.agg == aggAbsent {
+			// An absent alert has no series; report the attributes it filters on.
+			r.absentAttrs = pcommon.NewMap()
+			for k, v := range rc.Attributes {
+				r.absentAttrs.PutStr(k, v)
+			}
+		}
+		c.rules = append(c.rules, r)
+		c.byMetric[e.metric] = append(c.byMetric[e.metric], r)
+	}
+
+	if set.MeterProvider != nil {
+		var err error
+		c.transitions, err = set.MeterProvider.Meter(scopeName).Int64Counter(selfmetrics.AlertTransitions,
+			metric.WithDescription("Number of alert state transitions emitted by the alert connector."),
+			metric.WithUnit("{transition}"))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+// Start begins periodic rule evaluation.
+func (c *alertConnector) Start(_ context.Context, _ component.Host) error {
+	now := time.Now()
+	c.mu.Lock()
+	for _, r := range c.rules {
+		r.lastSeen = now
+	}
+	c.mu.Unlock()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	c.cancel = cancel
+	c.done = make(chan struct{})
+	go c.run(ctx)
+	return nil
+}
+
+// Shutdown stops rule evaluation.
+func (c *alertConnector) Shutdown(ctx context.Context) error {
+	if c.cancel == nil {
+		return nil
+	}
+	c.cancel()
+	select {
+	case <-c.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Capabilities returns the consumer capabilities.
+func (c *alertConnector) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// run evaluates the rules every evaluation interval until ctx is done.
+func (c *alertConnector) run(ctx context.Context) {
+	defer close(c.done)
+
+	ticker := time.NewTicker(c.cfg.EvaluationInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			c.evaluate(ctx, now)
+		}
+	}
+}
+
+// ConsumeMetrics records the gauge and sum data points referenced by rules.
+func (c *alertConnector) ConsumeMetrics(_ context.Context, md pmetric.Metrics) error {
+	now := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		sms := rm.ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				m := ms.At(k)
+				rules := c.byMetric[m.Name()]
+				if len(rules) == 0 {
+					continue
+				}
+
+				var dps pmetric.NumberDataPointSlice
+				delta := false
+				switch m.Type() {
+				case pmetric.MetricTypeGauge:
+					dps = m.Gauge().DataPoints()
+				case pmetric.MetricTypeSum:
+					dps = m.Sum().DataPoints()
+					delta = m.Sum().AggregationTemporality() == pmetric.AggregationTemporalityDelta
+				default:
+					continue
+				}
+
+				for l := 0; l < dps.Len(); l++ {
+					c.record(rules, rm.Resource().Attributes(), dps.At(l), delta, now)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// record adds a data point to every rule it matches. Callers hold mu.
+func (c *alertConnector) record(rules []*rule, resAttrs pcommon.Map, dp pmetric.NumberDataPoint, delta bool, now time.Time) {
+	var value float64
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeInt:
+		value = float64(dp.IntValue())
+	case pmetric.NumberDataPointValueTypeDouble:
+		value = dp.DoubleValue()
+	default:
+		return
+	}
+	ts := dp.Timestamp().AsTime()
+	if dp.Timestamp() == 0 {
+		ts = now
+	}
+
+	var attrs pcommon.Map
+	var key string
+	for _, r := range rules {
+		if !matches(r.cfg.Attributes, resAttrs, dp.Attributes()) {
+			continue
+		}
+		r.lastSeen = now
+		if r.expr.agg == aggAbsent {
+			continue
+		}
+
+		if key == "" {
+			attrs = pcommon.NewMap()
+			resAttrs.CopyTo(attrs)
+			for k, v := range dp.Attributes().All() {
+				v.CopyTo(attrs.PutEmpty(k))
+			}
+			key = seriesKey(attrs)
+		}
+
+		s, ok := r.series[key]
+		if !ok {
+			if len(r.series) >= maxSeriesPerRule {
+				c.logger.Debug("Series limit reached, ignoring data point", zap.String("rule", r.cfg.Name))
+				continue
+			}
+			s = &series{attrs: attrs, delta: delta}
+			r.series[key] = s
+		}
+		s.lastSeen = now
+		s.add(sample{ts: ts, value: value}, r.expr.window)
+	}
+}
+
+// matches reports whether every wanted attribute is present with the given
+// value on the data point or, failing that, the resource.
+func matches(want map[string]string, resAttrs, dpAttrs pcommon.Map) bool {
+	for k, v := range want {
+		got, ok := dpAttrs.Get(k)
+		if !ok {
+			got, ok = resAttrs.Get(k)
+		}
+		if !ok || got.AsString() != v {
+			return false
+		}
+	}
+	return true
+}
+
+// evaluate evaluates every rule at now and emits the resulting transitions.
+func (c *alertConnector) evaluate(ctx context.Context, now time.Time) {
+	var transitions []transition
+
+	c.mu.Lock()
+	for _, r := range c.rules {
+		if r.expr.agg == aggAbsent {
+			since := now.Sub(r.lastSeen)
+			if state := r.absence.step(since >= r.expr.window, since.Seconds(), now, r.cfg.For); state != "" {
+				transitions = append(transitions, transition{rule: r, state: state, value: since.Seconds(), attrs: r.absentAttrs})
+			}
+			continue
+		}
+
+		for key, s := range r.series {
+			if now.Sub(s.lastSeen) > r.staleAfter {
+				delete(r.series, key)
+				if s.alert.firing {
+					transitions = append(transitions, transition{rule: r, state: stateResolved, value: s.alert.value, attrs: s.attrs})
+				}
+				continue
+			}
+			v, ok := s.eval(r.expr)
+			if state := s.alert.step(ok && r.expr.compare(v), v, now, r.cfg.For); state != "" {
+				transitions = append(transitions, transition{rule: r, state: state, value: v, attrs: s.attrs})
+			}
+		}
+	}
+	c.mu.Unlock()
+
+	if len(transitions) == 0 {
+		return
+	}
+	if err := c.next.ConsumeLogs(ctx, c.buildLogs(transitions, now)); err != nil {
+		c.logger.Warn("Failed to emit alert events", zap.Error(err))
+	}
+}
+
+// buildLogs converts transitions into alert log records.
+func (c *alertConnector) buildLogs(transitions []transition, now time.Time) plog.Logs {
+	ld := plog.NewLogs()
+	sl := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
+	sl.Scope().SetName(scopeName)
+
+	ts := pcommon.NewTimestampFromTime(now)
+	for _, t := range transitions {
+		r := t.rule
+		severity := r.cfg.Severity
+		if severity == "" {
+			severity = SeverityWarn
+		}
+
+		lr := sl.LogRecords().AppendEmpty()
+		lr.SetTimestamp(ts)
+		lr.SetObservedTimestamp(ts)
+		lr.SetEventName(EventName)
+		if t.state == stateFiring {
+			lr.SetSeverityNumber(severity.number())
+			lr.Body().SetStr(fmt.Sprintf("%s firing: %s", r.cfg.Name, r.cfg.Expr))
+		} else {
+			lr.SetSeverityNumber(plog.SeverityNumberInfo)
+			lr.Body().SetStr(fmt.Sprintf("%s resolved: %s", r.cfg.Name, r.cfg.Expr))
+		}
+		lr.SetSeverityText(lr.SeverityNumber().String())
+
+		attrs := lr.Attributes()
+		t.attrs.CopyTo(attrs)
+		attrs.PutStr("alert.name", r.cfg.Name)
+		attrs.PutStr("alert.state", t.state)
+		attrs.PutStr("alert.expr", r.cfg.Expr)
+		attrs.PutStr("alert.severity", string(severity))
+		attrs.PutDouble("alert.value", t.value)
+		if r.cfg.Description != "" {
+			attrs.PutStr("alert.description", r.cfg.Description)
+		}
+
+		if c.transitions != nil {
+			c.transitions.Add(context.Background(), 1, c.labels.Option(
+				attribute.String("rule", r.cfg.Name),
+				attribute.String("state", t.state)))
+		}
+	}
+	return ld
+}
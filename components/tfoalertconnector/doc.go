@@ -0,0 +1,69 @@
+// Package tfoalertconnector evaluates alert rules over in-flight metrics and
+// emits alert events as log records.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// The connector sits at the end of a metrics pipeline and at the start of a
+// logs pipeline. Each rule is a threshold expression over a metric; when a
+// rule starts or stops holding, the connector emits a log record with event
+// name tfo.alert into the logs pipeline. This allows alerting at the edge
+// while the backend is unreachable.
+//
+// Expressions have the form:
+//
+//	<metric> <op> <number>                  latest value
+//	<fn>(<metric>[<window>]) <op> <number>  fn is last, avg, min, max, sum, count or rate
+//	absent(<metric>[<window>])              no data point seen for window
+//
+// where op is one of >, >=, <, <=, == or !=. Only gauge and sum data points
+// are evaluated. rate is the per-second increase of a sum over the window;
+// counter resets are handled and delta sums are summed over the window.
+//
+// Rules are evaluated per series, i.e. per distinct set of resource and
+// data point attributes, except absent which covers all matching series.
+// A rule whose expression holds for the "for" duration fires; it resolves
+// when the expression stops holding or its series goes stale.
+//
+// Alert records carry the attributes alert.name, alert.state (firing or
+// resolved), alert.expr, alert.severity, alert.value and alert.description
+// plus the attributes of the series.
+//
+// Configuration example:
+//
+//	connectors:
+//	  tfoalert:
+//	    evaluation_interval: 15s
+//	    rules:
+//	      - name: high_error_rate
+//	        expr: rate(http.server.errors[1m]) > 5
+//	        attributes:
+//	          service.name: checkout
+//	        for: 1m
+//	        severity: error
+//	        description: Checkout errors above 5/s
+//	      - name: heartbeat_missing
+//	        expr: absent(tfo.agent.heartbeat[2m])
+//	        severity: fatal
+//
+//	service:
+//	  pipelines:
+//	    metrics:
+//	      receivers: [otlp]
+//	      exporters: [otlp, tfoalert]
+//	    logs/alerts:
+//	      receivers: [tfoalert]
+//	      exporters: [file]
+package tfoalertconnector // import "github.com/telemetryflow/telemetryflow-collector/components/tfoalertconnector"
@@ -0,0 +1,120 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoalertconnector
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// aggregation is the function applied to the samples of a series.
+type aggregation string
+
+const (
+	aggLast   aggregation = "last"
+	aggAvg    aggregation = "avg"
+	aggMin    aggregation = "min"
+	aggMax    aggregation = "max"
+	aggSum    aggregation = "sum"
+	aggCount  aggregation = "count"
+	aggRate   aggregation = "rate"
+	aggAbsent aggregation = "absent"
+)
+
+// exprPattern matches "fn(metric[window]) op number", "metric op number"
+// and "absent(metric[window])".
+var exprPattern = regexp.MustCompile(
+	`^\s*(?:([a-z][a-z0-9_]*)\s*\(\s*([A-Za-z_][A-Za-z0-9_./-]*)\s*(?:\[\s*([0-9a-z.]+)\s*\])?\s*\)|([A-Za-z_][A-Za-z0-9_./-]*))` +
+		`\s*(?:(>=|<=|==|!=|>|<)\s*(\S+))?\s*$`)
+
+// expr is a parsed rule expression.
+type expr struct {
+	agg       aggregation
+	metric    string
+	window    time.Duration
+	op        string
+	threshold float64
+}
+
+// parseExpr parses a rule expression.
+func parseExpr(s string) (expr, error) {
+	if strings.TrimSpace(s) == "" {
+		return expr{}, errors.New("expr is required")
+	}
+	m := exprPattern.FindStringSubmatch(s)
+	if m == nil {
+		return expr{}, fmt.Errorf("invalid expr %q", s)
+	}
+
+	e := expr{agg: aggregation(m[1]), metric: m[2], op: m[5]}
+	if m[4] != "" {
+		e.agg, e.metric = aggLast, m[4]
+	}
+
+	switch e.agg {
+	case aggLast, aggAvg, aggMin, aggMax, aggSum, aggCount, aggRate, aggAbsent:
+	default:
+		return expr{}, fmt.Errorf("invalid expr %q: unknown function %q", s, e.agg)
+	}
+
+	if m[3] != "" {
+		w, err := time.ParseDuration(m[3])
+		if err != nil || w <= 0 {
+			return expr{}, fmt.Errorf("invalid expr %q: invalid window %q", s, m[3])
+		}
+		e.window = w
+	} else if e.agg != aggLast {
+		return expr{}, fmt.Errorf("invalid expr %q: %s requires a window", s, e.agg)
+	}
+
+	if e.agg == aggAbsent {
+		if e.op != "" {
+			return expr{}, fmt.Errorf("invalid expr %q: absent takes no comparison", s)
+		}
+		return e, nil
+	}
+	if e.op == "" {
+		return expr{}, fmt.Errorf("invalid expr %q: missing comparison", s)
+	}
+	t, err := strconv.ParseFloat(m[6], 64)
+	if err != nil {
+		return expr{}, fmt.Errorf("invalid expr %q: invalid threshold %q", s, m[6])
+	}
+	e.threshold = t
+	return e, nil
+}
+
+// compare reports whether v satisfies the expression's comparison.
+func (e expr) compare(v float64) bool {
+	switch e.op {
+	case ">":
+		return v > e.threshold
+	case ">=":
+		return v >= e.threshold
+	case "<":
+		return v < e.threshold
+	case "<=":
+		return v <= e.threshold
+	case "==":
+		return v == e.threshold
+	case "!=":
+		return v != e.threshold
+	}
+	return false
+}
@@ -0,0 +1,61 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoalertconnector
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pipeline"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+const (
+	// TypeStr is the type string identifier for the TFO alert connector.
+	TypeStr = "tfoalert"
+
+	// Defaults
+	defaultEvaluationInterval = 15 * time.Second
+)
+
+// NewFactory creates a new factory for the TFO alert connector.
+func NewFactory() connector.Factory {
+	return connector.NewFactory(
+		component.MustNewType(TypeStr),
+		createDefaultConfig,
+		connector.WithMetricsToLogs(createMetricsToLogs, component.StabilityLevelAlpha),
+	)
+}
+
+// createDefaultConfig creates the default configuration for the connector.
+func createDefaultConfig() component.Config {
+	return &Config{
+		EvaluationInterval: defaultEvaluationInterval,
+	}
+}
+
+// createMetricsToLogs creates the metrics to logs connector.
+func createMetricsToLogs(
+	_ context.Context,
+	set connector.Settings,
+	cfg component.Config,
+	next consumer.Logs,
+) (connector.Metrics, error) {
+	return newAlertConnector(cfg.(*Config), set.TelemetrySettings, selfmetrics.Connector(set.ID, pipeline.SignalMetrics), next)
+}
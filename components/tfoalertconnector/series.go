@@ -0,0 +1,188 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoalertconnector
+
+import (
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+const (
+	// maxSamplesPerSeries bounds the samples kept for one series; the oldest
+	// samples are dropped first.
+	maxSamplesPerSeries = 1024
+
+	// maxSeriesPerRule bounds the series tracked for one rule; data points
+	// for further series are ignored until existing ones go stale.
+	maxSeriesPerRule = 10000
+
+	// defaultStaleness is how long a series is kept without new data points
+	// when the rule window is shorter.
+	defaultStaleness = 5 * time.Minute
+)
+
+// sample is a single data point value.
+type sample struct {
+	ts    time.Time
+	value float64
+}
+
+// series holds the recent samples of one metric stream and its alert state.
+type series struct {
+	attrs    pcommon.Map
+	delta    bool
+	samples  []sample
+	lastSeen time.Time
+	alert    alertState
+}
+
+// alertState tracks whether a rule is pending or firing.
+type alertState struct {
+	pendingSince time.Time
+	firing       bool
+	value        float64
+}
+
+// step advances the state with the latest evaluation result and returns
+// the state transitioned to, if any.
+func (a *alertState) step(holds bool, value float64, now time.Time, forDur time.Duration) string {
+	a.value = value
+	if !holds {
+		a.pendingSince = time.Time{}
+		if a.firing {
+			a.firing = false
+			return stateResolved
+		}
+		return ""
+	}
+	if a.firing {
+		return ""
+	}
+	if a.pendingSince.IsZero() {
+		a.pendingSince = now
+	}
+	if now.Sub(a.pendingSince) >= forDur {
+		a.firing = true
+		return stateFiring
+	}
+	return ""
+}
+
+// add appends a sample and drops samples that fall outside window of the
+// newest one. A zero window keeps only the newest sample.
+func (s *series) add(smp sample, window time.Duration) {
+	if n := len(s.samples); n > 0 && smp.ts.Before(s.samples[n-1].ts) {
+		// Out of order: the window is anchored at the newest sample.
+		return
+	}
+	s.samples = append(s.samples, smp)
+
+	start := max(0, len(s.samples)-maxSamplesPerSeries)
+	if window == 0 {
+		start = len(s.samples) - 1
+	} else {
+		cutoff := smp.ts.Add(-window)
+		for start < len(s.samples) && s.samples[start].ts.Before(cutoff) {
+			start++
+		}
+	}
+	if start > 0 {
+		s.samples = slices.Delete(s.samples, 0, start)
+	}
+}
+
+// eval applies the aggregation of e to the samples and reports whether a
+// value could be computed.
+func (s *series) eval(e expr) (float64, bool) {
+	n := len(s.samples)
+	if n == 0 {
+		return 0, false
+	}
+
+	switch e.agg {
+	case aggLast:
+		return s.samples[n-1].value, true
+	case aggCount:
+		return float64(n), true
+	case aggSum, aggAvg:
+		var sum float64
+		for _, smp := range s.samples {
+			sum += smp.value
+		}
+		if e.agg == aggAvg {
+			return sum / float64(n), true
+		}
+		return sum, true
+	case aggMin, aggMax:
+		v := s.samples[0].value
+		for _, smp := range s.samples[1:] {
+			if e.agg == aggMin {
+				v = math.Min(v, smp.value)
+			} else {
+				v = math.Max(v, smp.value)
+			}
+		}
+		return v, true
+	case aggRate:
+		return s.rate(e.window)
+	}
+	return 0, false
+}
+
+// rate returns the per-second increase over the samples. Delta samples are
+// summed over window; cumulative samples use the increase between the first
+// and last sample, treating any decrease as a counter reset.
+func (s *series) rate(window time.Duration) (float64, bool) {
+	if s.delta {
+		var sum float64
+		for _, smp := range s.samples {
+			sum += smp.value
+		}
+		return sum / window.Seconds(), true
+	}
+
+	n := len(s.samples)
+	if n < 2 {
+		return 0, false
+	}
+	elapsed := s.samples[n-1].ts.Sub(s.samples[0].ts).Seconds()
+	if elapsed <= 0 {
+		return 0, false
+	}
+	var increase float64
+	for i := 1; i < n; i++ {
+		prev, cur := s.samples[i-1].value, s.samples[i].value
+		if cur >= prev {
+			increase += cur - prev
+		} else {
+			increase += cur
+		}
+	}
+	return increase / elapsed, true
+}
+
+// seriesKey returns a stable identity for an attribute set.
+func seriesKey(attrs pcommon.Map) string {
+	parts := make([]string, 0, attrs.Len())
+	for k, v := range attrs.All() {
+		parts = append(parts, k+"="+v.AsString())
+	}
+	slices.Sort(parts)
+	return strings.Join(parts, "\x00")
+}
@@ -0,0 +1,84 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoarchiveconnector
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+// Config defines the configuration for the TFO archive connector.
+type Config struct {
+	// Sampled are the pipelines running the samplers. They receive every
+	// batch and their outcome is returned to the sending pipeline.
+	Sampled []pipeline.ID `mapstructure:"sampled"`
+
+	// Archive are the pipelines receiving the traces the samplers dropped.
+	Archive []pipeline.ID `mapstructure:"archive"`
+
+	// Percentage is the share of sampled-out traces archived, from 0 to
+	// 100. Traces are selected by trace ID.
+	// Default: 100
+	Percentage float64 `mapstructure:"percentage"`
+
+	// DecisionWait is how long a trace is held after its first span before
+	// it is archived unless the samplers kept it. It must exceed the
+	// decision_wait of tail_sampling.
+	// Default: 40s
+	DecisionWait time.Duration `mapstructure:"decision_wait"`
+
+	// MaxTraces bounds the traces held. Spans of new traces arriving while
+	// the bound is reached are not archived.
+	// Default: 50000
+	MaxTraces int `mapstructure:"max_traces"`
+
+	// Retention is a hint for how long the storage should keep archived
+	// traces, set as the tfo.archive.retention resource attribute, e.g.
+	// "72h0m0s". Zero sets no hint.
+	// Default: 0
+	Retention time.Duration `mapstructure:"retention"`
+}
+
+// Validate checks the configuration for errors.
+func (cfg *Config) Validate() error {
+	if len(cfg.Sampled) == 0 {
+		return errors.New("sampled must list at least one pipeline")
+	}
+	if len(cfg.Archive) == 0 {
+		return errors.New("archive must list at least one pipeline")
+	}
+	for _, id := range cfg.Archive {
+		if slices.Contains(cfg.Sampled, id) {
+			return fmt.Errorf("pipeline %q is listed as both sampled and archive", id)
+		}
+	}
+	if cfg.Percentage < 0 || cfg.Percentage > 100 {
+		return errors.New("percentage must be between 0 and 100")
+	}
+	if cfg.DecisionWait <= 0 {
+		return errors.New("decision_wait must be positive")
+	}
+	if cfg.MaxTraces <= 0 {
+		return errors.New("max_traces must be positive")
+	}
+	if cfg.Retention < 0 {
+		return errors.New("retention must not be negative")
+	}
+	return nil
+}
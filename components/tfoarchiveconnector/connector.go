@@ -0,0 +1,272 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoarchiveconnector
+
+import (
+	"context"
+	"encoding/binary"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/sampled"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+const scopeName = "github.com/telemetryflow/telemetryflow-collector/components/tfoarchiveconnector"
+
+// retentionKey is the resource attribute carrying the retention hint.
+const retentionKey = "tfo.archive.retention"
+
+// Outcomes recorded on the trace counter.
+const (
+	outcomeKept     = "kept"
+	outcomeArchived = "archived"
+	outcomeFailed   = "failed"
+	outcomeDropped  = "dropped"
+)
+
+// maxSweepInterval bounds the time between two checks for held traces
+// whose decision wait is over.
+const maxSweepInterval = time.Second
+
+// heldTrace is a copy of the spans of a trace waiting for the samplers.
+type heldTrace struct {
+	td       ptrace.Traces
+	deadline time.Time
+}
+
+// archiver passes every batch to the sampled pipelines and archives the
+// held traces that the samplers did not keep.
+type archiver struct {
+	cfg    *Config
+	logger *zap.Logger
+
+	sampled   consumer.Traces
+	archive   consumer.Traces
+	decisions *sampled.Decisions
+
+	// mu guards the held traces; order lists them by deadline.
+	mu    sync.Mutex
+	held  map[pcommon.TraceID]*heldTrace
+	order []pcommon.TraceID
+
+	traces metric.Int64Counter
+	labels selfmetrics.Labels
+
+	cancel context.CancelFunc
+	done   sync.WaitGroup
+}
+
+func newArchiver(
+	cfg *Config,
+	id component.ID,
+	set component.TelemetrySettings,
+	labels selfmetrics.Labels,
+	sampledConsumer, archive consumer.Traces,
+) (*archiver, error) {
+	a := &archiver{
+		cfg:       cfg,
+		logger:    set.Logger,
+		sampled:   sampledConsumer,
+		archive:   archive,
+		decisions: sampled.For(id.String()),
+		held:      make(map[pcommon.TraceID]*heldTrace),
+		labels:    labels,
+	}
+	if set.MeterProvider != nil {
+		var err error
+		a.traces, err = set.MeterProvider.Meter(scopeName).Int64Counter(selfmetrics.ArchiveTraces,
+			metric.WithDescription("Number of traces held by the archive connector, by outcome."),
+			metric.WithUnit("{trace}"))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return a, nil
+}
+
+// Start starts the worker archiving the traces whose decision wait is over.
+func (a *archiver) Start(context.Context, component.Host) error {
+	// Kept traces must be remembered until the held copies are checked.
+	a.decisions.SetRetention(2 * a.cfg.DecisionWait)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	a.cancel = cancel
+	a.done.Add(1)
+	go a.run(ctx)
+	return nil
+}
+
+// Shutdown stops the worker and archives the traces still held that the
+// samplers have not kept.
+func (a *archiver) Shutdown(ctx context.Context) error {
+	if a.cancel != nil {
+		a.cancel()
+		a.done.Wait()
+	}
+	a.sweep(ctx, time.Time{})
+	return nil
+}
+
+// Capabilities implements the consumer interfaces.
+func (a *archiver) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// ConsumeTraces holds a copy of the selected traces and passes td to the
+// sampled pipelines. Only their outcome is returned.
+func (a *archiver) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	a.hold(ctx, td, time.Now())
+	return a.sampled.ConsumeTraces(ctx, td)
+}
+
+// run archives the traces whose decision wait is over until ctx is done.
+func (a *archiver) run(ctx context.Context) {
+	defer a.done.Done()
+	interval := min(a.cfg.DecisionWait/4, maxSweepInterval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			a.sweep(ctx, now)
+		}
+	}
+}
+
+// hold copies the spans of the selected traces in td to their held
+// traces, starting a hold for traces not held yet.
+func (a *archiver) hold(ctx context.Context, td ptrace.Traces, now time.Time) {
+	dropped := 0
+	a.mu.Lock()
+	defer func() {
+		a.mu.Unlock()
+		a.count(ctx, outcomeDropped, dropped)
+	}()
+
+	refused := make(map[pcommon.TraceID]bool)
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		sss := rs.ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			ss := sss.At(j)
+			// The scope of each trace in the held copy, for this scope.
+			dest := make(map[pcommon.TraceID]ptrace.ScopeSpans)
+			spans := ss.Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				id := span.TraceID()
+				if refused[id] || !a.selected(id) {
+					continue
+				}
+				out, ok := dest[id]
+				if !ok {
+					t := a.held[id]
+					if t == nil {
+						if len(a.held) >= a.cfg.MaxTraces {
+							refused[id] = true
+							dropped++
+							continue
+						}
+						t = &heldTrace{td: ptrace.NewTraces(), deadline: now.Add(a.cfg.DecisionWait)}
+						a.held[id] = t
+						a.order = append(a.order, id)
+					}
+					hrs := t.td.ResourceSpans().AppendEmpty()
+					rs.Resource().CopyTo(hrs.Resource())
+					hrs.SetSchemaUrl(rs.SchemaUrl())
+					out = hrs.ScopeSpans().AppendEmpty()
+					ss.Scope().CopyTo(out.Scope())
+					out.SetSchemaUrl(ss.SchemaUrl())
+					dest[id] = out
+				}
+				span.CopyTo(out.Spans().AppendEmpty())
+			}
+		}
+	}
+}
+
+// selected reports whether the trace is within the archived percentage.
+// Trace IDs are random, so their low bits are uniformly distributed.
+func (a *archiver) selected(id pcommon.TraceID) bool {
+	if a.cfg.Percentage >= 100 {
+		return true
+	}
+	v := binary.BigEndian.Uint64(id[8:]) >> 11
+	return float64(v)/(1<<53)*100 < a.cfg.Percentage
+}
+
+// sweep archives the held traces whose deadline is not after now, or all
+// of them when now is zero, unless the samplers kept them.
+func (a *archiver) sweep(ctx context.Context, now time.Time) {
+	out := ptrace.NewTraces()
+	kept, archived := 0, 0
+
+	a.mu.Lock()
+	for len(a.order) > 0 {
+		id := a.order[0]
+		t := a.held[id]
+		if !now.IsZero() && t.deadline.After(now) {
+			break
+		}
+		a.order = a.order[1:]
+		delete(a.held, id)
+		if a.decisions.Kept(id) {
+			kept++
+			continue
+		}
+		archived++
+		t.td.ResourceSpans().MoveAndAppendTo(out.ResourceSpans())
+	}
+	a.mu.Unlock()
+
+	a.count(ctx, outcomeKept, kept)
+	if archived == 0 {
+		return
+	}
+	if a.cfg.Retention > 0 {
+		rss := out.ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			rss.At(i).Resource().Attributes().PutStr(retentionKey, a.cfg.Retention.String())
+		}
+	}
+	// The archive pipelines get their own context: the hold outlives the
+	// requests the spans arrived with.
+	if err := a.archive.ConsumeTraces(context.Background(), out); err != nil {
+		a.logger.Warn("Failed to archive sampled-out traces", zap.Int("traces", archived), zap.Error(err))
+		a.count(ctx, outcomeFailed, archived)
+		return
+	}
+	a.count(ctx, outcomeArchived, archived)
+}
+
+// count records n traces with the given outcome.
+func (a *archiver) count(ctx context.Context, outcome string, n int) {
+	if a.traces == nil || n == 0 {
+		return
+	}
+	a.traces.Add(ctx, int64(n), a.labels.Option(attribute.String("outcome", outcome)))
+}
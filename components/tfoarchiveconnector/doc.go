@@ -0,0 +1,69 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// The connector keeps the raw data of sampled-out traces for post-incident
+// forensics. It sits at the end of a pipeline, in front of the samplers,
+// and passes every batch to the sampled pipelines, which run the samplers
+// and export to the primary backend. A copy of each trace is held for
+// decision_wait; a tfosampled processor placed after the samplers records
+// the traces they kept, and once the wait is over the held traces that
+// were not kept are passed to the archive pipelines, e.g. ones exporting
+// to a file exporter or object storage.
+//
+// Only a percentage of the sampled-out traces is archived. Traces are
+// selected by trace ID, so all spans of a trace are archived together, and
+// with retention set the archived resources carry the tfo.archive.retention
+// attribute as a hint for the storage, e.g. to pick a bucket prefix with a
+// matching lifecycle rule. The archive pipelines only get copies: their
+// outcome never affects the sampled pipelines.
+//
+// decision_wait must exceed the decision_wait of tail_sampling, otherwise
+// traces are archived before the samplers had a chance to keep them. Spans
+// arriving after the hold of their trace ended start a new hold. Traces
+// still held at shutdown are archived unless they are known to be kept.
+// Held traces are counted by tfo_archive_traces per outcome.
+//
+// Configuration example:
+//
+//	connectors:
+//	  tfoarchive:
+//	    sampled: [traces/sampled]
+//	    archive: [traces/archive]
+//	    percentage: 25
+//	    decision_wait: 40s
+//	    max_traces: 50000
+//	    retention: 72h
+//
+//	processors:
+//	  tail_sampling:
+//	    decision_wait: 30s
+//	  tfosampled:
+//	    archive: tfoarchive
+//
+//	service:
+//	  pipelines:
+//	    traces:
+//	      receivers: [tfootlp]
+//	      processors: [memory_limiter]
+//	      exporters: [tfoarchive]
+//	    traces/sampled:
+//	      receivers: [tfoarchive]
+//	      processors: [tail_sampling, tfosampled, batch]
+//	      exporters: [tfo]
+//	    traces/archive:
+//	      receivers: [tfoarchive]
+//	      processors: [batch]
+//	      exporters: [file/archive]
+package tfoarchiveconnector // import "github.com/telemetryflow/telemetryflow-collector/components/tfoarchiveconnector"
@@ -0,0 +1,80 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoarchiveconnector
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pipeline"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+const (
+	// TypeStr is the type string identifier for the TFO archive connector.
+	TypeStr = "tfoarchive"
+
+	// Defaults
+	defaultPercentage   = 100
+	defaultDecisionWait = 40 * time.Second
+	defaultMaxTraces    = 50000
+)
+
+// NewFactory creates a new factory for the TFO archive connector.
+func NewFactory() connector.Factory {
+	return connector.NewFactory(
+		component.MustNewType(TypeStr),
+		createDefaultConfig,
+		connector.WithTracesToTraces(createTracesToTraces, component.StabilityLevelAlpha),
+	)
+}
+
+// createDefaultConfig creates the default configuration for the connector.
+func createDefaultConfig() component.Config {
+	return &Config{
+		Percentage:   defaultPercentage,
+		DecisionWait: defaultDecisionWait,
+		MaxTraces:    defaultMaxTraces,
+	}
+}
+
+// createTracesToTraces creates the traces archive connector.
+func createTracesToTraces(
+	_ context.Context,
+	set connector.Settings,
+	cfg component.Config,
+	next consumer.Traces,
+) (connector.Traces, error) {
+	router, ok := next.(connector.TracesRouterAndConsumer)
+	if !ok {
+		return nil, fmt.Errorf("%s connector requires a traces router", TypeStr)
+	}
+	oCfg := cfg.(*Config)
+	sampled, err := router.Consumer(oCfg.Sampled...)
+	if err != nil {
+		return nil, fmt.Errorf("sampled: %w", err)
+	}
+	archive, err := router.Consumer(oCfg.Archive...)
+	if err != nil {
+		return nil, fmt.Errorf("archive: %w", err)
+	}
+	return newArchiver(oCfg, set.ID, set.TelemetrySettings, selfmetrics.Connector(set.ID, pipeline.SignalTraces),
+		sampled, archive)
+}
@@ -0,0 +1,294 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfocaptureexporter
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var (
+	capturesMu sync.Mutex
+	captures   = make(map[component.ID]*Capture)
+)
+
+// Get returns the Capture of the exporter id, creating it on first use.
+// Collectors running in one process share the Capture of an ID.
+func Get(id component.ID) *Capture {
+	capturesMu.Lock()
+	defer capturesMu.Unlock()
+
+	if c, ok := captures[id]; ok {
+		return c
+	}
+	c := New(defaultMaxItems)
+	captures[id] = c
+	return c
+}
+
+// Capture holds copies of the telemetry it consumed. The pdata returned by
+// its query helpers is shared with the Capture and must not be modified.
+type Capture struct {
+	mu       sync.Mutex
+	maxItems int
+	traces   batches[ptrace.Traces]
+	metrics  batches[pmetric.Metrics]
+	logs     batches[plog.Logs]
+
+	// changed is closed and replaced whenever the content changes.
+	changed chan struct{}
+}
+
+// New creates a Capture keeping at most maxItems spans, data points or log
+// records per signal.
+func New(maxItems int) *Capture {
+	return &Capture{maxItems: maxItems, changed: make(chan struct{})}
+}
+
+// batches is the bounded history of one signal.
+type batches[T any] struct {
+	data    []T
+	items   []int
+	total   int
+	dropped int
+}
+
+// add appends data holding n items and evicts the oldest batches while the
+// total exceeds maxItems. The newest batch is always kept.
+func (b *batches[T]) add(data T, n, maxItems int) {
+	b.data = append(b.data, data)
+	b.items = append(b.items, n)
+	b.total += n
+	for b.total > maxItems && len(b.data) > 1 {
+		var zero T
+		b.data[0] = zero
+		b.total -= b.items[0]
+		b.dropped += b.items[0]
+		b.data, b.items = b.data[1:], b.items[1:]
+	}
+}
+
+func (c *Capture) setMaxItems(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.maxItems = n
+}
+
+// notifyLocked wakes up Wait callers. c.mu must be held.
+func (c *Capture) notifyLocked() {
+	close(c.changed)
+	c.changed = make(chan struct{})
+}
+
+// Capabilities implements consumer.Traces, consumer.Metrics and
+// consumer.Logs.
+func (c *Capture) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// ConsumeTraces records a copy of td.
+func (c *Capture) ConsumeTraces(_ context.Context, td ptrace.Traces) error {
+	cp := ptrace.NewTraces()
+	td.CopyTo(cp)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.traces.add(cp, cp.SpanCount(), c.maxItems)
+	c.notifyLocked()
+	return nil
+}
+
+// ConsumeMetrics records a copy of md.
+func (c *Capture) ConsumeMetrics(_ context.Context, md pmetric.Metrics) error {
+	cp := pmetric.NewMetrics()
+	md.CopyTo(cp)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.metrics.add(cp, cp.DataPointCount(), c.maxItems)
+	c.notifyLocked()
+	return nil
+}
+
+// ConsumeLogs records a copy of ld.
+func (c *Capture) ConsumeLogs(_ context.Context, ld plog.Logs) error {
+	cp := plog.NewLogs()
+	ld.CopyTo(cp)
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.logs.add(cp, cp.LogRecordCount(), c.maxItems)
+	c.notifyLocked()
+	return nil
+}
+
+// Reset discards everything recorded, including the dropped counts.
+func (c *Capture) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.traces = batches[ptrace.Traces]{}
+	c.metrics = batches[pmetric.Metrics]{}
+	c.logs = batches[plog.Logs]{}
+	c.notifyLocked()
+}
+
+// Wait blocks until cond reports true or ctx is done. cond is evaluated
+// once immediately and again after every change to the Capture.
+func (c *Capture) Wait(ctx context.Context, cond func(*Capture) bool) error {
+	for {
+		c.mu.Lock()
+		changed := c.changed
+		c.mu.Unlock()
+
+		if cond(c) {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-changed:
+		}
+	}
+}
+
+// Traces returns the recorded trace batches, oldest first.
+func (c *Capture) Traces() []ptrace.Traces {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return slices.Clone(c.traces.data)
+}
+
+// Metrics returns the recorded metric batches, oldest first.
+func (c *Capture) Metrics() []pmetric.Metrics {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return slices.Clone(c.metrics.data)
+}
+
+// Logs returns the recorded log batches, oldest first.
+func (c *Capture) Logs() []plog.Logs {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return slices.Clone(c.logs.data)
+}
+
+// SpanCount returns the number of recorded spans.
+func (c *Capture) SpanCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.traces.total
+}
+
+// DataPointCount returns the number of recorded metric data points.
+func (c *Capture) DataPointCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.metrics.total
+}
+
+// LogRecordCount returns the number of recorded log records.
+func (c *Capture) LogRecordCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.logs.total
+}
+
+// Dropped returns the number of spans, data points and log records evicted
+// because max_items was exceeded.
+func (c *Capture) Dropped() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.traces.dropped + c.metrics.dropped + c.logs.dropped
+}
+
+// Spans returns every recorded span, oldest first.
+func (c *Capture) Spans() []ptrace.Span {
+	return c.findSpans(func(ptrace.Span) bool { return true })
+}
+
+// SpansByName returns the recorded spans named name, oldest first.
+func (c *Capture) SpansByName(name string) []ptrace.Span {
+	return c.findSpans(func(s ptrace.Span) bool { return s.Name() == name })
+}
+
+func (c *Capture) findSpans(match func(ptrace.Span) bool) []ptrace.Span {
+	var out []ptrace.Span
+	for _, td := range c.Traces() {
+		for _, rs := range td.ResourceSpans().All() {
+			for _, ss := range rs.ScopeSpans().All() {
+				for _, span := range ss.Spans().All() {
+					if match(span) {
+						out = append(out, span)
+					}
+				}
+			}
+		}
+	}
+	return out
+}
+
+// MetricNames returns the distinct names of the recorded metrics in order
+// of first appearance.
+func (c *Capture) MetricNames() []string {
+	var names []string
+	seen := make(map[string]struct{})
+	for _, m := range c.metricsMatching(func(pmetric.Metric) bool { return true }) {
+		if _, ok := seen[m.Name()]; !ok {
+			seen[m.Name()] = struct{}{}
+			names = append(names, m.Name())
+		}
+	}
+	return names
+}
+
+// MetricsByName returns the recorded metrics named name, oldest first.
+func (c *Capture) MetricsByName(name string) []pmetric.Metric {
+	return c.metricsMatching(func(m pmetric.Metric) bool { return m.Name() == name })
+}
+
+func (c *Capture) metricsMatching(match func(pmetric.Metric) bool) []pmetric.Metric {
+	var out []pmetric.Metric
+	for _, md := range c.Metrics() {
+		for _, rm := range md.ResourceMetrics().All() {
+			for _, sm := range rm.ScopeMetrics().All() {
+				for _, m := range sm.Metrics().All() {
+					if match(m) {
+						out = append(out, m)
+					}
+				}
+			}
+		}
+	}
+	return out
+}
+
+// LogRecords returns every recorded log record, oldest first.
+func (c *Capture) LogRecords() []plog.LogRecord {
+	var out []plog.LogRecord
+	for _, ld := range c.Logs() {
+		for _, rl := range ld.ResourceLogs().All() {
+			for _, sl := range rl.ScopeLogs().All() {
+				for _, lr := range sl.LogRecords().All() {
+					out = append(out, lr)
+				}
+			}
+		}
+	}
+	return out
+}
@@ -0,0 +1,33 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfocaptureexporter
+
+import "errors"
+
+// Config defines the configuration for the TFO capture exporter.
+type Config struct {
+	// MaxItems bounds the spans, data points or log records kept per
+	// signal. The oldest batches are evicted when it is exceeded.
+	// Default: 100000
+	MaxItems int `mapstructure:"max_items"`
+}
+
+// Validate checks the configuration for errors.
+func (cfg *Config) Validate() error {
+	if cfg.MaxItems <= 0 {
+		return errors.New("max_items must be positive")
+	}
+	return nil
+}
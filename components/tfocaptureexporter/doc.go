@@ -0,0 +1,48 @@
+// Package tfocaptureexporter records exported telemetry in memory for tests.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Every batch exported to a tfocapture exporter is copied into the Capture
+// of its component ID, where tests inspect it with the query helpers (Spans,
+// SpansByName, MetricNames, LogRecords, ...) or block on it with Wait. A
+// Capture is safe for concurrent use and also implements the consumer
+// interfaces, so it can stand in for a pipeline's next consumer in unit
+// tests.
+//
+// Each signal keeps at most max_items spans, data points or log records;
+// the oldest batches are evicted first and counted by Dropped. The newest
+// batch is always kept, even if it alone exceeds the cap.
+//
+// The exporter is not part of the distribution. pkg/registry adds it to
+// the default component set when built with the tfotest tag, and plugin
+// authors can register it in their own RegistrySet.
+//
+// Configuration example:
+//
+//	exporters:
+//	  tfocapture:
+//	    max_items: 10000
+//
+// Test example:
+//
+//	capture := tfocaptureexporter.Get(component.MustNewID("tfocapture"))
+//	capture.Reset()
+//	// ... start a collector exporting to tfocapture and send data ...
+//	err := capture.Wait(ctx, func(c *tfocaptureexporter.Capture) bool {
+//		return c.SpanCount() >= 3
+//	})
+//	spans := capture.SpansByName("checkout")
+package tfocaptureexporter // import "github.com/telemetryflow/telemetryflow-collector/components/tfocaptureexporter"
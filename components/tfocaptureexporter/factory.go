@@ -0,0 +1,101 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfocaptureexporter
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+const (
+	// TypeStr is the type string identifier for the TFO capture exporter.
+	TypeStr = "tfocapture"
+
+	// Defaults
+	defaultMaxItems = 100000
+)
+
+// NewFactory creates a new factory for the TFO capture exporter.
+func NewFactory() exporter.Factory {
+	return exporter.NewFactory(
+		component.MustNewType(TypeStr),
+		createDefaultConfig,
+		exporter.WithTraces(createTracesExporter, component.StabilityLevelDevelopment),
+		exporter.WithMetrics(createMetricsExporter, component.StabilityLevelDevelopment),
+		exporter.WithLogs(createLogsExporter, component.StabilityLevelDevelopment),
+	)
+}
+
+// createDefaultConfig creates the default configuration for the exporter.
+func createDefaultConfig() component.Config {
+	return &Config{
+		MaxItems: defaultMaxItems,
+	}
+}
+
+// createTracesExporter creates a traces exporter.
+func createTracesExporter(
+	ctx context.Context,
+	set exporter.Settings,
+	cfg component.Config,
+) (exporter.Traces, error) {
+	c := Get(set.ID)
+	c.setMaxItems(cfg.(*Config).MaxItems)
+	return exporterhelper.NewTraces(
+		ctx,
+		set,
+		cfg,
+		c.ConsumeTraces,
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+	)
+}
+
+// createMetricsExporter creates a metrics exporter.
+func createMetricsExporter(
+	ctx context.Context,
+	set exporter.Settings,
+	cfg component.Config,
+) (exporter.Metrics, error) {
+	c := Get(set.ID)
+	c.setMaxItems(cfg.(*Config).MaxItems)
+	return exporterhelper.NewMetrics(
+		ctx,
+		set,
+		cfg,
+		c.ConsumeMetrics,
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+	)
+}
+
+// createLogsExporter creates a logs exporter.
+func createLogsExporter(
+	ctx context.Context,
+	set exporter.Settings,
+	cfg component.Config,
+) (exporter.Logs, error) {
+	c := Get(set.ID)
+	c.setMaxItems(cfg.(*Config).MaxItems)
+	return exporterhelper.NewLogs(
+		ctx,
+		set,
+		cfg,
+		c.ConsumeLogs,
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+	)
+}
@@ -0,0 +1,106 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfocardinalityprocessor
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+)
+
+const (
+	// cardinalityPath is the admin API resource for sampling sessions.
+	cardinalityPath = "/cardinality"
+
+	// defaultSessionDuration is the session duration when a request omits
+	// duration.
+	defaultSessionDuration = 5 * time.Minute
+
+	// defaultTop is the number of entries reported when a request omits top.
+	defaultTop = 20
+)
+
+// sessionRequest is the body of POST /cardinality.
+type sessionRequest struct {
+	Duration string `json:"duration"`
+}
+
+// handleAdmin serves the cardinality admin API:
+//
+//	POST   /cardinality           start a session: {"duration": "5m"}
+//	GET    /cardinality?top=20    report of the current or last session
+//	DELETE /cardinality           end the session early
+//
+// POST during an active session replaces it.
+func (a *analyzer) handleAdmin(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodPost:
+		var body sessionRequest
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil && !errors.Is(err, io.EOF) {
+			serverconf.WriteJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid request body: " + err.Error()})
+			return
+		}
+		d := defaultSessionDuration
+		if body.Duration != "" {
+			var err error
+			if d, err = time.ParseDuration(body.Duration); err != nil {
+				serverconf.WriteJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid duration: " + err.Error()})
+				return
+			}
+		}
+		if d <= 0 || d > a.cfg.MaxDuration {
+			serverconf.WriteJSON(w, http.StatusBadRequest, map[string]string{
+				"error": fmt.Sprintf("duration must be positive and at most max_duration %s", a.cfg.MaxDuration),
+			})
+			return
+		}
+		a.logger.Info("Cardinality sampling started", zap.Duration("duration", d))
+		serverconf.WriteJSON(w, http.StatusOK, a.begin(d))
+	case http.MethodGet:
+		top := defaultTop
+		if s := req.URL.Query().Get("top"); s != "" {
+			n, err := strconv.Atoi(s)
+			if err != nil || n <= 0 {
+				serverconf.WriteJSON(w, http.StatusBadRequest, map[string]string{"error": "top must be a positive integer"})
+				return
+			}
+			top = n
+		}
+		report, ok := a.report(top)
+		if !ok {
+			serverconf.WriteJSON(w, http.StatusNotFound, map[string]string{"error": "no sampling session"})
+			return
+		}
+		serverconf.WriteJSON(w, http.StatusOK, report)
+	case http.MethodDelete:
+		report, ok := a.end()
+		if !ok {
+			serverconf.WriteJSON(w, http.StatusNotFound, map[string]string{"error": "no active sampling session"})
+			return
+		}
+		a.logger.Info("Cardinality sampling ended early")
+		serverconf.WriteJSON(w, http.StatusOK, report)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,436 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfocardinalityprocessor
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cespare/xxhash/v2"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+)
+
+// Report is the outcome of a sampling session. Series and value counts
+// are estimates.
+type Report struct {
+	Active    bool      `json:"active"`
+	StartedAt time.Time `json:"started_at"`
+	EndsAt    time.Time `json:"ends_at"`
+
+	// DataPoints is the number of data points seen.
+	DataPoints int64 `json:"data_points"`
+
+	// Series is the number of unique series across all metrics.
+	Series uint64 `json:"series"`
+
+	// MetricNames is the number of metric names tracked.
+	MetricNames int `json:"metric_names"`
+
+	// UntrackedDataPoints counts the data points of metric names beyond
+	// max_metrics, which are part of Series only.
+	UntrackedDataPoints int64 `json:"untracked_data_points,omitempty"`
+
+	// Metrics are the top metric names by series.
+	Metrics []MetricReport `json:"metrics"`
+
+	// LabelKeys are the top data point attribute keys by unique values.
+	LabelKeys []LabelKeyReport `json:"label_keys"`
+}
+
+// MetricReport describes the series of a metric name.
+type MetricReport struct {
+	Name       string   `json:"name"`
+	Type       string   `json:"type"`
+	Series     uint64   `json:"series"`
+	DataPoints int64    `json:"data_points"`
+	LabelKeys  []string `json:"label_keys"`
+}
+
+// LabelKeyReport describes the values of a data point attribute key.
+type LabelKeyReport struct {
+	Key string `json:"key"`
+
+	// Values is the number of unique values across all metrics.
+	Values uint64 `json:"values"`
+
+	// Metrics is the number of tracked metric names using the key.
+	Metrics int `json:"metrics"`
+}
+
+// Print writes a human-readable summary of the report to w.
+func (r *Report) Print(w io.Writer) {
+	state := "completed"
+	if r.Active {
+		state = "active"
+	}
+	_, _ = fmt.Fprintf(w, "Cardinality report (%s, %s to %s)\n", state,
+		r.StartedAt.Format(time.RFC3339), r.EndsAt.Format(time.RFC3339))
+	_, _ = fmt.Fprintf(w, "Data points: %d  Series: ~%d  Metric names: %d\n", r.DataPoints, r.Series, r.MetricNames)
+	if r.UntrackedDataPoints > 0 {
+		_, _ = fmt.Fprintf(w, "Untracked data points beyond max_metrics: %d\n", r.UntrackedDataPoints)
+	}
+
+	_, _ = fmt.Fprintln(w, "\nTop metrics by series")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "  METRIC\tTYPE\tSERIES\tDATA POINTS\tLABEL KEYS")
+	for _, m := range r.Metrics {
+		_, _ = fmt.Fprintf(tw, "  %s\t%s\t~%d\t%d\t%s\n", m.Name, m.Type, m.Series, m.DataPoints, strings.Join(m.LabelKeys, ","))
+	}
+	_ = tw.Flush()
+
+	_, _ = fmt.Fprintln(w, "\nTop label keys by values")
+	tw = tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(tw, "  LABEL KEY\tVALUES\tMETRICS")
+	for _, k := range r.LabelKeys {
+		_, _ = fmt.Fprintf(tw, "  %s\t~%d\t%d\n", k.Key, k.Values, k.Metrics)
+	}
+	_ = tw.Flush()
+}
+
+// analyzers are the analyzers of the processors by configuration; the
+// instances of a processor in several pipelines share one.
+var (
+	analyzersMu sync.Mutex
+	analyzers   = make(map[*Config]*analyzer)
+)
+
+// analyzer runs the sampling sessions of a processor.
+type analyzer struct {
+	cfg    *Config
+	logger *zap.Logger
+
+	// refs counts the started instances; guarded by analyzersMu.
+	refs   int
+	server *serverconf.AdminServer
+
+	// sampling is set while a session may be active, so that batches
+	// outside sessions skip the lock.
+	sampling atomic.Bool
+
+	mu      sync.Mutex
+	session *session
+}
+
+// sharedAnalyzer returns the analyzer of the processor configured by cfg.
+func sharedAnalyzer(cfg *Config, logger *zap.Logger) *analyzer {
+	analyzersMu.Lock()
+	defer analyzersMu.Unlock()
+	if a, ok := analyzers[cfg]; ok {
+		return a
+	}
+	a := &analyzer{cfg: cfg, logger: logger}
+	analyzers[cfg] = a
+	return a
+}
+
+// start starts the admin API with the first instance.
+func (a *analyzer) start(ctx context.Context, host component.Host) error {
+	analyzersMu.Lock()
+	defer analyzersMu.Unlock()
+	a.refs++
+	if a.refs > 1 {
+		return nil
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(cardinalityPath, a.handleAdmin)
+	server, err := a.cfg.StartAdmin(ctx, host, "Cardinality admin API", mux, a.logger)
+	if err != nil {
+		a.refs--
+		return fmt.Errorf("cardinality admin API: %w", err)
+	}
+	a.server = server
+	return nil
+}
+
+// shutdown stops the admin API with the last instance.
+func (a *analyzer) shutdown(ctx context.Context) error {
+	analyzersMu.Lock()
+	defer analyzersMu.Unlock()
+	if a.refs == 0 {
+		return nil
+	}
+	a.refs--
+	if a.refs > 0 {
+		return nil
+	}
+	delete(analyzers, a.cfg)
+	err := a.server.Shutdown(ctx)
+	a.server = nil
+	return err
+}
+
+// processMetrics records md in the active session and passes it on.
+func (a *analyzer) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	if !a.sampling.Load() {
+		return md, nil
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.session == nil || !a.session.activeAt(time.Now()) {
+		a.sampling.Store(false)
+		return md, nil
+	}
+	a.session.observe(md, a.cfg.MaxMetrics)
+	return md, nil
+}
+
+// begin starts a session of duration d, replacing any previous one.
+func (a *analyzer) begin(d time.Duration) Report {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	now := time.Now()
+	a.session = &session{
+		startedAt: now,
+		endsAt:    now.Add(d),
+		metrics:   make(map[string]*metricStats),
+		keys:      make(map[string]*keyStats),
+	}
+	a.sampling.Store(true)
+	return a.session.report(now, 0)
+}
+
+// end ends the active session early. It reports false when none is active.
+func (a *analyzer) end() (Report, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	now := time.Now()
+	if a.session == nil || !a.session.activeAt(now) {
+		return Report{}, false
+	}
+	a.session.endsAt = now
+	a.sampling.Store(false)
+	return a.session.report(now, 0), true
+}
+
+// report returns the report of the current or last session with the top
+// entries, or false when no session was started.
+func (a *analyzer) report(top int) (Report, bool) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if a.session == nil {
+		return Report{}, false
+	}
+	return a.session.report(time.Now(), top), true
+}
+
+// session accumulates the cardinality of the metrics seen between
+// startedAt and endsAt.
+type session struct {
+	startedAt time.Time
+	endsAt    time.Time
+
+	dataPoints int64
+	untracked  int64
+	series     sketch
+	metrics    map[string]*metricStats
+	keys       map[string]*keyStats
+
+	// buf holds the series key being hashed.
+	buf []byte
+}
+
+// metricStats accumulates the cardinality of a metric name.
+type metricStats struct {
+	typ        pmetric.MetricType
+	dataPoints int64
+	series     sketch
+	keys       map[string]struct{}
+}
+
+// keyStats accumulates the values of a data point attribute key.
+type keyStats struct {
+	values sketch
+}
+
+// activeAt reports whether the session samples at t.
+func (s *session) activeAt(t time.Time) bool {
+	return t.Before(s.endsAt)
+}
+
+// observe records the data points of md. Series are identified by the
+// resource attributes, the scope name, the metric name and the data point
+// attributes, appended to buf level by level.
+func (s *session) observe(md pmetric.Metrics, maxMetrics int) {
+	rms := md.ResourceMetrics()
+	for i := range rms.Len() {
+		rm := rms.At(i)
+		s.buf = appendAttributes(s.buf[:0], rm.Resource().Attributes())
+		resourceLen := len(s.buf)
+		sms := rm.ScopeMetrics()
+		for j := range sms.Len() {
+			sm := sms.At(j)
+			s.buf = append(append(s.buf[:resourceLen], sm.Scope().Name()...), 0)
+			scopeLen := len(s.buf)
+			ms := sm.Metrics()
+			for k := range ms.Len() {
+				s.buf = s.buf[:scopeLen]
+				s.observeMetric(ms.At(k), maxMetrics)
+			}
+		}
+	}
+}
+
+// observeMetric records the data points of m; buf holds its resource and
+// scope.
+func (s *session) observeMetric(m pmetric.Metric, maxMetrics int) {
+	name := m.Name()
+	stats := s.metrics[name]
+	if stats == nil && len(s.metrics) < maxMetrics {
+		stats = &metricStats{typ: m.Type(), keys: make(map[string]struct{})}
+		s.metrics[name] = stats
+	}
+
+	s.buf = append(append(s.buf, name...), 0)
+	metricLen := len(s.buf)
+	forEachAttributes(m, func(attrs pcommon.Map) {
+		s.buf = appendAttributes(s.buf[:metricLen], attrs)
+		series := xxhash.Sum64(s.buf)
+		s.dataPoints++
+		s.series.add(series)
+		if stats == nil {
+			s.untracked++
+			return
+		}
+		stats.dataPoints++
+		stats.series.add(series)
+		attrs.Range(func(k string, v pcommon.Value) bool {
+			stats.keys[k] = struct{}{}
+			ks := s.keys[k]
+			if ks == nil && len(s.keys) < maxMetrics {
+				ks = &keyStats{}
+				s.keys[k] = ks
+			}
+			if ks != nil {
+				ks.values.add(xxhash.Sum64String(v.AsString()))
+			}
+			return true
+		})
+	})
+}
+
+// report summarizes the session at now, with the top entries of each
+// list; top zero returns empty lists.
+func (s *session) report(now time.Time, top int) Report {
+	r := Report{
+		Active:              s.activeAt(now),
+		StartedAt:           s.startedAt,
+		EndsAt:              s.endsAt,
+		DataPoints:          s.dataPoints,
+		Series:              bounded(s.series.estimate(), s.dataPoints),
+		MetricNames:         len(s.metrics),
+		UntrackedDataPoints: s.untracked,
+		Metrics:             []MetricReport{},
+		LabelKeys:           []LabelKeyReport{},
+	}
+	if top <= 0 {
+		return r
+	}
+
+	keyMetrics := make(map[string]int)
+	for name, m := range s.metrics {
+		for k := range m.keys {
+			keyMetrics[k]++
+		}
+		r.Metrics = append(r.Metrics, MetricReport{
+			Name:       name,
+			Type:       m.typ.String(),
+			Series:     bounded(m.series.estimate(), m.dataPoints),
+			DataPoints: m.dataPoints,
+			LabelKeys:  slices.Sorted(maps.Keys(m.keys)),
+		})
+	}
+	slices.SortFunc(r.Metrics, func(a, b MetricReport) int {
+		return cmp.Or(cmp.Compare(b.Series, a.Series), cmp.Compare(a.Name, b.Name))
+	})
+	r.Metrics = r.Metrics[:min(top, len(r.Metrics))]
+
+	for k, ks := range s.keys {
+		r.LabelKeys = append(r.LabelKeys, LabelKeyReport{Key: k, Values: ks.values.estimate(), Metrics: keyMetrics[k]})
+	}
+	slices.SortFunc(r.LabelKeys, func(a, b LabelKeyReport) int {
+		return cmp.Or(cmp.Compare(b.Values, a.Values), cmp.Compare(a.Key, b.Key))
+	})
+	r.LabelKeys = r.LabelKeys[:min(top, len(r.LabelKeys))]
+	return r
+}
+
+// bounded caps a series estimate at the number of data points seen, which
+// keeps small counts exact.
+func bounded(estimate uint64, dataPoints int64) uint64 {
+	return min(estimate, uint64(dataPoints))
+}
+
+// appendAttributes appends the attributes to b in key order.
+func appendAttributes(b []byte, attrs pcommon.Map) []byte {
+	keys := make([]string, 0, attrs.Len())
+	attrs.Range(func(k string, _ pcommon.Value) bool {
+		keys = append(keys, k)
+		return true
+	})
+	slices.Sort(keys)
+	for _, k := range keys {
+		v, _ := attrs.Get(k)
+		b = append(append(b, k...), 0)
+		b = append(append(b, v.AsString()...), 0)
+	}
+	return b
+}
+
+// forEachAttributes calls fn with the attributes of every data point of m.
+func forEachAttributes(m pmetric.Metric, fn func(pcommon.Map)) {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := m.Gauge().DataPoints()
+		for i := range dps.Len() {
+			fn(dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeSum:
+		dps := m.Sum().DataPoints()
+		for i := range dps.Len() {
+			fn(dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		for i := range dps.Len() {
+			fn(dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		for i := range dps.Len() {
+			fn(dps.At(i).Attributes())
+		}
+	case pmetric.MetricTypeSummary:
+		dps := m.Summary().DataPoints()
+		for i := range dps.Len() {
+			fn(dps.At(i).Attributes())
+		}
+	}
+}
@@ -0,0 +1,58 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfocardinalityprocessor
+
+import (
+	"errors"
+	"time"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+)
+
+// Config defines the configuration for the TFO cardinality processor.
+type Config struct {
+	// AdminConfig defines the admin API listener: endpoint, tls, auth, acl
+	// and the other shared server settings. An endpoint off localhost
+	// requires auth or mTLS.
+	// Default endpoint: localhost:55695
+	serverconf.AdminConfig `mapstructure:",squash"`
+
+	// MaxDuration bounds the duration of a sampling session.
+	// Default: 1h
+	MaxDuration time.Duration `mapstructure:"max_duration"`
+
+	// MaxMetrics bounds the metric names, and separately the label keys,
+	// tracked by a session, each of which takes about 2 KiB. Data points
+	// of further names are counted but not broken down.
+	// Default: 10000
+	MaxMetrics int `mapstructure:"max_metrics"`
+}
+
+// Validate checks the configuration for errors.
+func (cfg *Config) Validate() error {
+	if cfg.NetAddr.Endpoint == "" {
+		return errors.New("endpoint is required")
+	}
+	if err := cfg.AdminConfig.Validate(); err != nil {
+		return err
+	}
+	if cfg.MaxDuration <= 0 {
+		return errors.New("max_duration must be positive")
+	}
+	if cfg.MaxMetrics <= 0 {
+		return errors.New("max_metrics must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,58 @@
+// Package tfocardinalityprocessor reports the cardinality of the metrics
+// passing a pipeline.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// The processor passes metrics on unchanged. While a sampling session armed
+// through its admin API is active, it estimates for each metric name the
+// number of unique series (resource attributes, scope and data point
+// attributes) and for each data point attribute key the number of unique
+// values, with HyperLogLog sketches of about 2% standard error. The report
+// lists the top metric names and label keys, to decide filter and rollup
+// rules before backend series limits are hit. Outside sessions the
+// processor only checks whether one is active.
+//
+// The admin API serves the /cardinality resource:
+//
+//	POST   /cardinality           start a session: {"duration": "5m"}
+//	GET    /cardinality?top=20    report of the current or last session
+//	DELETE /cardinality           end the session early
+//
+// The "tfo-collector analyze cardinality" command runs a session and prints
+// the report. Instances of the processor in several pipelines share one
+// session and admin API.
+//
+// The admin listener takes the shared admin server settings of
+// pkg/serverconf (tls, auth, acl, network, response_headers and the
+// timeouts). An endpoint whose host is not a loopback address is refused
+// unless clients authenticate through auth or a client certificate verified
+// against tls.client_ca_file.
+//
+// Configuration example:
+//
+//	processors:
+//	  tfocardinality:
+//	    endpoint: localhost:55695
+//	    max_duration: 1h
+//	    max_metrics: 10000
+//
+//	service:
+//	  pipelines:
+//	    metrics:
+//	      receivers: [otlp]
+//	      processors: [memory_limiter, tfocardinality, batch]
+//	      exporters: [tfo]
+package tfocardinalityprocessor // import "github.com/telemetryflow/telemetryflow-collector/components/tfocardinalityprocessor"
@@ -0,0 +1,72 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfocardinalityprocessor
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+)
+
+const (
+	// TypeStr is the type string identifier for the TFO cardinality processor.
+	TypeStr = "tfocardinality"
+
+	// DefaultEndpoint is the default listen address of the admin API.
+	DefaultEndpoint = "localhost:55695"
+
+	// Defaults
+	defaultMaxDuration = time.Hour
+	defaultMaxMetrics  = 10000
+)
+
+// NewFactory creates a new factory for the TFO cardinality processor.
+func NewFactory() processor.Factory {
+	return processor.NewFactory(
+		component.MustNewType(TypeStr),
+		createDefaultConfig,
+		processor.WithMetrics(createMetricsProcessor, component.StabilityLevelAlpha),
+	)
+}
+
+// createDefaultConfig creates the default configuration for the processor.
+func createDefaultConfig() component.Config {
+	return &Config{
+		AdminConfig: serverconf.NewDefaultAdminConfig(DefaultEndpoint),
+		MaxDuration: defaultMaxDuration,
+		MaxMetrics:  defaultMaxMetrics,
+	}
+}
+
+// createMetricsProcessor creates the metrics processor. The instances of
+// the processor in several pipelines share an analyzer.
+func createMetricsProcessor(
+	ctx context.Context,
+	set processor.Settings,
+	cfg component.Config,
+	next consumer.Metrics,
+) (processor.Metrics, error) {
+	a := sharedAnalyzer(cfg.(*Config), set.Logger)
+	return processorhelper.NewMetrics(ctx, set, cfg, next, a.processMetrics,
+		processorhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+		processorhelper.WithStart(a.start),
+		processorhelper.WithShutdown(a.shutdown))
+}
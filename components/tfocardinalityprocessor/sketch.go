@@ -0,0 +1,62 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfocardinalityprocessor
+
+import (
+	"math"
+	"math/bits"
+)
+
+const (
+	// sketchPrecision is the number of hash bits selecting a register. 2^11
+	// registers give a standard error of 1.04/sqrt(2048), about 2.3%.
+	sketchPrecision = 11
+
+	sketchRegisters = 1 << sketchPrecision
+)
+
+// sketch is a HyperLogLog estimator of the number of distinct hashes.
+type sketch struct {
+	registers [sketchRegisters]uint8
+}
+
+// add records the 64-bit hash of a value.
+func (s *sketch) add(hash uint64) {
+	idx := hash >> (64 - sketchPrecision)
+	rest := hash<<sketchPrecision | 1<<(sketchPrecision-1)
+	rank := uint8(bits.LeadingZeros64(rest)) + 1
+	if rank > s.registers[idx] {
+		s.registers[idx] = rank
+	}
+}
+
+// estimate returns the estimated number of distinct hashes added, using
+// linear counting for small cardinalities.
+func (s *sketch) estimate() uint64 {
+	const m = float64(sketchRegisters)
+	var sum float64
+	zeros := 0
+	for _, r := range s.registers {
+		sum += math.Ldexp(1, -int(r))
+		if r == 0 {
+			zeros++
+		}
+	}
+	e := 0.7213 / (1 + 1.079/m) * m * m / sum
+	if e <= 2.5*m && zeros > 0 {
+		e = m * math.Log(m/float64(zeros))
+	}
+	return uint64(math.Round(e))
+}
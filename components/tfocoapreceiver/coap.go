@@ -0,0 +1,157 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfocoapreceiver
+
+import (
+	"encoding/binary"
+	"errors"
+	"strings"
+)
+
+// CoAP message types (RFC 7252, section 3).
+const (
+	coapConfirmable    = 0
+	coapNonConfirmable = 1
+	coapAcknowledgment = 2
+	coapReset          = 3
+)
+
+// CoAP codes, class.detail packed as class<<5 | detail.
+const (
+	coapEmpty              = 0x00
+	coapPost               = 0x02
+	coapPut                = 0x03
+	coapChanged            = 0x44 // 2.04
+	coapBadRequest         = 0x80 // 4.00
+	coapForbidden          = 0x83 // 4.03
+	coapNotFound           = 0x84 // 4.04
+	coapMethodNotAllowed   = 0x85 // 4.05
+	coapServiceUnavailable = 0xa3 // 5.03
+)
+
+// coapOptionURIPath is the number of the Uri-Path option.
+const coapOptionURIPath = 11
+
+// coapPayloadMarker separates the options from the payload.
+const coapPayloadMarker = 0xff
+
+// errNotCoAP is returned for datagrams that are not CoAP version 1
+// messages. They are ignored without a response.
+var errNotCoAP = errors.New("not a CoAP message")
+
+// coapMessage is a parsed CoAP message. Options other than Uri-Path are
+// skipped.
+type coapMessage struct {
+	typ     uint8
+	code    uint8
+	id      uint16
+	token   []byte
+	path    string
+	payload []byte
+}
+
+// parseCoAP parses a CoAP message. The token and payload alias b.
+func parseCoAP(b []byte) (coapMessage, error) {
+	if len(b) < 4 || b[0]>>6 != 1 {
+		return coapMessage{}, errNotCoAP
+	}
+	m := coapMessage{
+		typ:  (b[0] >> 4) & 0x3,
+		code: b[1],
+		id:   binary.BigEndian.Uint16(b[2:4]),
+	}
+	tkl := int(b[0] & 0xf)
+	if tkl > 8 || len(b) < 4+tkl {
+		return coapMessage{}, errNotCoAP
+	}
+	m.token = b[4 : 4+tkl]
+	b = b[4+tkl:]
+
+	var (
+		number   int
+		segments []string
+	)
+	for len(b) > 0 {
+		if b[0] == coapPayloadMarker {
+			if len(b) == 1 {
+				return m, errors.New("payload marker without payload")
+			}
+			m.payload = b[1:]
+			break
+		}
+		delta, length := int(b[0]>>4), int(b[0]&0xf)
+		b = b[1:]
+		var err error
+		if delta, b, err = coapOptionValue(delta, b); err != nil {
+			return m, err
+		}
+		if length, b, err = coapOptionValue(length, b); err != nil {
+			return m, err
+		}
+		if len(b) < length {
+			return m, errors.New("truncated option")
+		}
+		number += delta
+		if number == coapOptionURIPath {
+			segments = append(segments, string(b[:length]))
+		}
+		b = b[length:]
+	}
+	m.path = strings.Join(segments, "/")
+	return m, nil
+}
+
+// coapOptionValue reads the extended option delta or length encoded by
+// the nibble v.
+func coapOptionValue(v int, b []byte) (int, []byte, error) {
+	switch v {
+	case 13:
+		if len(b) < 1 {
+			return 0, nil, errors.New("truncated option")
+		}
+		return int(b[0]) + 13, b[1:], nil
+	case 14:
+		if len(b) < 2 {
+			return 0, nil, errors.New("truncated option")
+		}
+		return int(binary.BigEndian.Uint16(b)) + 269, b[2:], nil
+	case 15:
+		return 0, nil, errors.New("reserved option nibble")
+	}
+	return v, b, nil
+}
+
+// coapResponse returns the piggybacked acknowledgment of m with code and,
+// for errors, a diagnostic payload.
+func coapResponse(m coapMessage, code uint8, diagnostic string) []byte {
+	b := make([]byte, 4, 4+len(m.token)+1+len(diagnostic))
+	b[0] = 1<<6 | coapAcknowledgment<<4 | uint8(len(m.token))
+	b[1] = code
+	binary.BigEndian.PutUint16(b[2:], m.id)
+	b = append(b, m.token...)
+	if diagnostic != "" {
+		b = append(b, coapPayloadMarker)
+		b = append(b, diagnostic...)
+	}
+	return b
+}
+
+// coapResetFor returns the reset answering m, used for CoAP pings.
+func coapResetFor(m coapMessage) []byte {
+	b := make([]byte, 4)
+	b[0] = 1<<6 | coapReset<<4
+	binary.BigEndian.PutUint16(b[2:], m.id)
+	return b
+}
@@ -0,0 +1,284 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfocoapreceiver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Protocols accepted in protocol.
+const (
+	// ProtocolCoAP accepts CoAP POST and PUT requests and selects the
+	// template by the request path.
+	ProtocolCoAP = "coap"
+	// ProtocolUDP accepts bare datagrams whose first byte is the template
+	// ID.
+	ProtocolUDP = "udp"
+)
+
+// Payload encodings of a template.
+const (
+	EncodingJSON   = "json"
+	EncodingBinary = "binary"
+)
+
+// Byte orders of binary templates.
+const (
+	ByteOrderBig    = "big"
+	ByteOrderLittle = "little"
+)
+
+// Metric kinds.
+const (
+	KindGauge = "gauge"
+	KindSum   = "sum"
+)
+
+// Handling of devices missing from devices.
+const (
+	UnknownDevicesAccept = "accept"
+	UnknownDevicesDrop   = "drop"
+)
+
+// maxReplayWindow is the largest replay window, the width of the bitmap of
+// received sequence numbers.
+const maxReplayWindow = 64
+
+// Config defines the configuration for the TFO CoAP receiver.
+type Config struct {
+	// Endpoint is the UDP address to listen on.
+	// Default: 0.0.0.0:5683
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Protocol is coap or udp.
+	// Default: coap
+	Protocol string `mapstructure:"protocol"`
+
+	// Templates map payloads to metrics.
+	Templates []TemplateConfig `mapstructure:"templates"`
+
+	// Devices maps device IDs to the resource attributes of their metrics,
+	// on top of device.id.
+	Devices map[string]map[string]string `mapstructure:"devices"`
+
+	// UnknownDevices is accept or drop: what to do with payloads from
+	// devices missing from devices.
+	// Default: accept
+	UnknownDevices string `mapstructure:"unknown_devices"`
+
+	// ReplayWindow is how many sequence numbers behind the highest one seen
+	// are still accepted, once each, for templates with a sequence field.
+	// 0 disables replay protection. At most 64.
+	// Default: 64
+	ReplayWindow int `mapstructure:"replay_window"`
+
+	// ReplayStateTTL is how long the sequence numbers of a silent device
+	// are remembered. A device that restarts its counter is accepted again
+	// once its state expired.
+	// Default: 24h
+	ReplayStateTTL time.Duration `mapstructure:"replay_state_ttl"`
+}
+
+// TemplateConfig defines the schema of a payload.
+type TemplateConfig struct {
+	// Name identifies the template. With protocol coap it is the request
+	// path, e.g. env for POST coap://collector/env.
+	Name string `mapstructure:"name"`
+
+	// ID identifies the template with protocol udp: the first byte of a
+	// datagram, 1 to 255.
+	ID uint8 `mapstructure:"id"`
+
+	// Encoding is json or binary.
+	// Default: json
+	Encoding string `mapstructure:"encoding"`
+
+	// ByteOrder is big or little. Only used by binary.
+	// Default: big
+	ByteOrder string `mapstructure:"byte_order"`
+
+	// DeviceID locates the device ID, set as device.id resource attribute.
+	DeviceID FieldConfig `mapstructure:"device_id"`
+
+	// Sequence locates the message counter checked against the replay
+	// window. Templates without a sequence are not replay protected.
+	Sequence *FieldConfig `mapstructure:"sequence"`
+
+	// Metrics are the values of the payload.
+	Metrics []MetricConfig `mapstructure:"metrics"`
+}
+
+// FieldConfig locates a value in a payload. A binary payload is the device
+// ID, then the sequence, then the metrics, each of its type and without
+// padding.
+type FieldConfig struct {
+	// Field is the key of the value in a json payload. Nested keys are
+	// joined by dots.
+	Field string `mapstructure:"field"`
+
+	// Type is the type of the value in a binary payload: u8, i8, u16,
+	// i16, u32, i32, u64, i64, f32 or f64. A device ID is unsigned or
+	// eui64, eight bytes written as hex. A sequence is unsigned; in a json
+	// payload its type sets where the counter wraps around.
+	// Default: u64 for the sequence of a json payload
+	Type string `mapstructure:"type"`
+}
+
+// MetricConfig defines a metric read from a payload.
+type MetricConfig struct {
+	FieldConfig `mapstructure:",squash"`
+
+	// Name is the metric name.
+	Name string `mapstructure:"name"`
+
+	// Unit is the metric unit, e.g. Cel or %.
+	Unit string `mapstructure:"unit"`
+
+	// Kind is gauge or sum. A sum is a monotonic cumulative counter.
+	// Default: gauge
+	Kind string `mapstructure:"kind"`
+
+	// Scale and Offset convert the raw value: value*scale + offset. A
+	// scaled value is a double. A scale of 0 is read as 1.
+	// Default: scale 1, offset 0
+	Scale  float64 `mapstructure:"scale"`
+	Offset float64 `mapstructure:"offset"`
+}
+
+// Validate checks the configuration for errors.
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("endpoint is required")
+	}
+	switch cfg.Protocol {
+	case ProtocolCoAP, ProtocolUDP:
+	default:
+		return fmt.Errorf("protocol must be %q or %q", ProtocolCoAP, ProtocolUDP)
+	}
+	switch cfg.UnknownDevices {
+	case "", UnknownDevicesAccept, UnknownDevicesDrop:
+	default:
+		return fmt.Errorf("unknown_devices must be %q or %q", UnknownDevicesAccept, UnknownDevicesDrop)
+	}
+	if cfg.ReplayWindow < 0 || cfg.ReplayWindow > maxReplayWindow {
+		return fmt.Errorf("replay_window must be between 0 and %d", maxReplayWindow)
+	}
+	if cfg.ReplayWindow > 0 && cfg.ReplayStateTTL <= 0 {
+		return errors.New("replay_state_ttl must be positive")
+	}
+	for id := range cfg.Devices {
+		if id == "" {
+			return errors.New("devices: device ID must not be empty")
+		}
+	}
+	if len(cfg.Templates) == 0 {
+		return errors.New("at least one template is required")
+	}
+
+	names := make(map[string]struct{}, len(cfg.Templates))
+	ids := make(map[uint8]struct{}, len(cfg.Templates))
+	for i := range cfg.Templates {
+		tmpl := &cfg.Templates[i]
+		if tmpl.Name == "" {
+			return fmt.Errorf("templates[%d]: name is required", i)
+		}
+		if _, dup := names[tmpl.Name]; dup {
+			return fmt.Errorf("templates[%d]: duplicate name %q", i, tmpl.Name)
+		}
+		names[tmpl.Name] = struct{}{}
+		if cfg.Protocol == ProtocolUDP {
+			if tmpl.ID == 0 {
+				return fmt.Errorf("template %q: id is required with protocol %q", tmpl.Name, ProtocolUDP)
+			}
+			if _, dup := ids[tmpl.ID]; dup {
+				return fmt.Errorf("template %q: duplicate id %d", tmpl.Name, tmpl.ID)
+			}
+			ids[tmpl.ID] = struct{}{}
+		}
+		if err := tmpl.validate(); err != nil {
+			return fmt.Errorf("template %q: %w", tmpl.Name, err)
+		}
+	}
+	return nil
+}
+
+// validate checks a template for errors.
+func (t *TemplateConfig) validate() error {
+	if strings.HasPrefix(t.Name, "/") || strings.HasSuffix(t.Name, "/") || strings.Contains(t.Name, "//") {
+		return errors.New("name must be a path without leading, trailing or empty segments")
+	}
+	switch t.ByteOrder {
+	case "", ByteOrderBig, ByteOrderLittle:
+	default:
+		return fmt.Errorf("byte_order must be %q or %q", ByteOrderBig, ByteOrderLittle)
+	}
+
+	var check func(what string, f FieldConfig, types map[string]int) error
+	switch t.Encoding {
+	case "", EncodingJSON:
+		check = func(what string, f FieldConfig, _ map[string]int) error {
+			if f.Field == "" {
+				return fmt.Errorf("%s: field is required", what)
+			}
+			return nil
+		}
+	case EncodingBinary:
+		check = func(what string, f FieldConfig, types map[string]int) error {
+			if _, ok := types[f.Type]; !ok {
+				return fmt.Errorf("%s: unsupported type %q", what, f.Type)
+			}
+			return nil
+		}
+	default:
+		return fmt.Errorf("encoding must be %q or %q", EncodingJSON, EncodingBinary)
+	}
+
+	if err := check("device_id", t.DeviceID, deviceIDTypes); err != nil {
+		return err
+	}
+	if t.Sequence != nil {
+		if err := check("sequence", *t.Sequence, sequenceTypes); err != nil {
+			return err
+		}
+		if _, ok := sequenceTypes[t.Sequence.Type]; t.Sequence.Type != "" && !ok {
+			return fmt.Errorf("sequence: unsupported type %q", t.Sequence.Type)
+		}
+	}
+	if len(t.Metrics) == 0 {
+		return errors.New("at least one metric is required")
+	}
+	names := make(map[string]struct{}, len(t.Metrics))
+	for i, m := range t.Metrics {
+		if m.Name == "" {
+			return fmt.Errorf("metrics[%d]: name is required", i)
+		}
+		if _, dup := names[m.Name]; dup {
+			return fmt.Errorf("metrics[%d]: duplicate name %q", i, m.Name)
+		}
+		names[m.Name] = struct{}{}
+		if err := check("metric "+m.Name, m.FieldConfig, valueTypes); err != nil {
+			return err
+		}
+		switch m.Kind {
+		case "", KindGauge, KindSum:
+		default:
+			return fmt.Errorf("metric %s: kind must be %q or %q", m.Name, KindGauge, KindSum)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,91 @@
+// Package tfocoapreceiver receives the compact payloads of battery-powered
+// sensors over CoAP or bare UDP datagrams and maps them to metrics with
+// per-device schema templates.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// The receiver listens on one UDP socket. With protocol coap it accepts
+// CoAP POST and PUT requests (RFC 7252) and picks the template named by
+// the request path. Confirmable requests are acknowledged with 2.04
+// Changed, or with an error code and a diagnostic payload:
+//
+//	4.00 Bad Request          malformed payload or unsupported method
+//	4.03 Forbidden            unknown device or replayed payload
+//	4.04 Not Found            no template for the path
+//	5.03 Service Unavailable  the pipeline refused the metrics
+//
+// Responses are kept for the CoAP exchange lifetime to answer
+// retransmissions without processing them twice. With protocol udp a
+// datagram is one byte of template ID followed by the payload, and
+// nothing is sent back.
+//
+// A template decodes a json or binary payload. A json payload is an
+// object whose fields are located by key. A binary payload is the device
+// ID, the sequence number if the template has one and the metric values,
+// packed in this order without padding. For example, with the env template
+// below the 11 bytes
+//
+//	00 00 04 d2  00 2a  00 e1  02 65  01
+//	device 1234  seq 42 temp   hum    door
+//
+// become the gauges env.temperature 22.5, env.humidity 61.3 and
+// env.door_open 1 of device.id 1234.
+//
+// Every metric gets the resource attribute device.id and the attributes
+// listed for the device under devices. unknown_devices: drop refuses
+// devices not listed there.
+//
+// Replay protection applies to templates with a sequence. The receiver
+// remembers per device and template the highest sequence number accepted
+// and which of the replay_window numbers below it were accepted, and
+// drops a payload whose number was seen or is older. Counters may wrap
+// around. A payload refused by the pipeline is not remembered, so the
+// device may resend it.
+//
+// Configuration example:
+//
+//	receivers:
+//	  tfocoap:
+//	    endpoint: 0.0.0.0:5683
+//	    protocol: coap
+//	    unknown_devices: drop
+//	    replay_window: 64
+//	    devices:
+//	      "1234":
+//	        site: plant-a
+//	        room: boiler
+//	    templates:
+//	      - name: env
+//	        encoding: binary
+//	        device_id: {type: u32}
+//	        sequence: {type: u16}
+//	        metrics:
+//	          - {name: env.temperature, type: i16, scale: 0.1, unit: Cel}
+//	          - {name: env.humidity, type: u16, scale: 0.1, unit: "%"}
+//	          - {name: env.door_open, type: u8}
+//	      - name: meter
+//	        device_id: {field: dev}
+//	        sequence: {field: n, type: u16}
+//	        metrics:
+//	          - {name: meter.energy, field: kwh, kind: sum, unit: kWh}
+//	          - {name: meter.voltage, field: v.rms, unit: V}
+//
+//	service:
+//	  pipelines:
+//	    metrics/sensors:
+//	      receivers: [tfocoap]
+//	      exporters: [tfo]
+package tfocoapreceiver // import "github.com/telemetryflow/telemetryflow-collector/components/tfocoapreceiver"
@@ -0,0 +1,64 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfocoapreceiver
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+const (
+	// TypeStr is the type string identifier for the TFO CoAP receiver.
+	TypeStr = "tfocoap"
+
+	// Defaults
+	defaultEndpoint       = "0.0.0.0:5683"
+	defaultReplayWindow   = 64
+	defaultReplayStateTTL = 24 * time.Hour
+)
+
+// NewFactory creates a new factory for the TFO CoAP receiver.
+func NewFactory() receiver.Factory {
+	return receiver.NewFactory(
+		component.MustNewType(TypeStr),
+		createDefaultConfig,
+		receiver.WithMetrics(createMetricsReceiver, component.StabilityLevelAlpha),
+	)
+}
+
+// createDefaultConfig creates the default configuration for the receiver.
+func createDefaultConfig() component.Config {
+	return &Config{
+		Endpoint:       defaultEndpoint,
+		Protocol:       ProtocolCoAP,
+		UnknownDevices: UnknownDevicesAccept,
+		ReplayWindow:   defaultReplayWindow,
+		ReplayStateTTL: defaultReplayStateTTL,
+	}
+}
+
+// createMetricsReceiver creates a metrics receiver.
+func createMetricsReceiver(
+	_ context.Context,
+	set receiver.Settings,
+	cfg component.Config,
+	next consumer.Metrics,
+) (receiver.Metrics, error) {
+	return newCoAPReceiver(cfg.(*Config), set, next), nil
+}
@@ -0,0 +1,32 @@
+module github.com/telemetryflow/telemetryflow-collector/components/tfocoapreceiver
+
+go 1.26
+
+require (
+	github.com/telemetryflow/telemetryflow-collector/pkg/errlog v0.0.0
+	github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics v0.0.0
+	go.opentelemetry.io/collector/component v1.58.0
+	go.opentelemetry.io/collector/consumer v1.58.0
+	go.opentelemetry.io/collector/pdata v1.58.0
+	go.opentelemetry.io/collector/receiver v1.58.0
+	go.opentelemetry.io/otel v1.43.0
+	go.opentelemetry.io/otel/metric v1.43.0
+	go.uber.org/zap v1.28.0
+)
+
+require (
+	github.com/cespare/xxhash/v2 v2.3.0 // indirect
+	github.com/hashicorp/go-version v1.9.0 // indirect
+	github.com/json-iterator/go v1.1.12 // indirect
+	github.com/modern-go/concurrent v0.0.0-20180306012644-bacd9c7ef1dd // indirect
+	github.com/modern-go/reflect2 v1.0.3-0.20250322232337-35a7c28c31ee // indirect
+	go.opentelemetry.io/collector/featuregate v1.58.0 // indirect
+	go.opentelemetry.io/collector/internal/componentalias v0.152.0 // indirect
+	go.opentelemetry.io/collector/pipeline v1.58.0 // indirect
+	go.opentelemetry.io/otel/trace v1.43.0 // indirect
+	go.uber.org/multierr v1.11.0 // indirect
+)
+
+replace github.com/telemetryflow/telemetryflow-collector/pkg/errlog => ../../pkg/errlog
+
+replace github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics => ../../pkg/selfmetrics
@@ -0,0 +1,340 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfocoapreceiver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/errlog"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+const scopeName = "github.com/telemetryflow/telemetryflow-collector/components/tfocoapreceiver"
+
+// Outcomes of a message, recorded on the message counter.
+const (
+	outcomeAccepted        = "accepted"
+	outcomeMalformed       = "malformed"
+	outcomeUnknownTemplate = "unknown_template"
+	outcomeUnknownDevice   = "unknown_device"
+	outcomeReplayed        = "replayed"
+	outcomeRefused         = "refused"
+)
+
+// failureMessages are the log messages of the failed outcomes.
+var failureMessages = map[string]string{
+	outcomeMalformed:       "Dropped malformed payload",
+	outcomeUnknownTemplate: "Dropped payload for an unknown template",
+	outcomeUnknownDevice:   "Dropped payload from an unknown device",
+	outcomeReplayed:        "Dropped replayed payload",
+	outcomeRefused:         "Failed to consume metrics",
+}
+
+// coapCodes are the response codes of the outcomes.
+var coapCodes = map[string]uint8{
+	outcomeAccepted:        coapChanged,
+	outcomeMalformed:       coapBadRequest,
+	outcomeUnknownTemplate: coapNotFound,
+	outcomeUnknownDevice:   coapForbidden,
+	outcomeReplayed:        coapForbidden,
+	outcomeRefused:         coapServiceUnavailable,
+}
+
+// maxDatagramSize is the largest UDP payload.
+const maxDatagramSize = 65535
+
+// exchangeLifetime is how long the response to a confirmable CoAP message
+// is kept to answer its retransmissions (RFC 7252, section 4.8.2).
+const exchangeLifetime = 247 * time.Second
+
+// maxExchanges bounds the cached responses. Beyond it responses are not
+// cached and retransmissions are processed again.
+const maxExchanges = 1 << 16
+
+// exchangeKey identifies a confirmable CoAP message.
+type exchangeKey struct {
+	remote string
+	id     uint16
+}
+
+// exchange is the cached response to a confirmable CoAP message.
+type exchange struct {
+	response []byte
+	expires  time.Time
+}
+
+// coapReceiver reads CoAP messages or bare datagrams from a UDP socket.
+type coapReceiver struct {
+	cfg      *Config
+	set      receiver.Settings
+	next     consumer.Metrics
+	failures *errlog.Aggregator
+
+	byName map[string]*TemplateConfig
+	byID   map[uint8]*TemplateConfig
+
+	messages metric.Int64Counter
+	labels   selfmetrics.Labels
+
+	// The fields below are only used by the read loop.
+	replay    *replayGuard
+	exchanges map[exchangeKey]exchange
+	lastSweep time.Time
+	start     pcommon.Timestamp
+
+	conn net.PacketConn
+	wg   sync.WaitGroup
+}
+
+// newCoAPReceiver creates the receiver.
+func newCoAPReceiver(cfg *Config, set receiver.Settings, next consumer.Metrics) *coapReceiver {
+	r := &coapReceiver{
+		cfg:       cfg,
+		set:       set,
+		next:      next,
+		failures:  errlog.New(set.Logger, errlog.DefaultInterval),
+		byName:    make(map[string]*TemplateConfig, len(cfg.Templates)),
+		byID:      make(map[uint8]*TemplateConfig, len(cfg.Templates)),
+		labels:    selfmetrics.Receiver(set.ID),
+		replay:    newReplayGuard(cfg.ReplayWindow, cfg.ReplayStateTTL),
+		exchanges: make(map[exchangeKey]exchange),
+	}
+	for i := range cfg.Templates {
+		tmpl := &cfg.Templates[i]
+		r.byName[tmpl.Name] = tmpl
+		if tmpl.ID != 0 {
+			r.byID[tmpl.ID] = tmpl
+		}
+	}
+	return r
+}
+
+// Start implements component.Component.
+func (r *coapReceiver) Start(_ context.Context, _ component.Host) error {
+	if r.set.MeterProvider != nil {
+		var err error
+		r.messages, err = r.set.MeterProvider.Meter(scopeName).Int64Counter(selfmetrics.ReceiverDatagrams,
+			metric.WithDescription("CoAP messages or UDP datagrams received, by outcome."),
+			metric.WithUnit("{message}"))
+		if err != nil {
+			return err
+		}
+	}
+
+	conn, err := net.ListenPacket("udp", r.cfg.Endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to listen on %s: %w", r.cfg.Endpoint, err)
+	}
+	r.conn = conn
+	r.start = pcommon.NewTimestampFromTime(time.Now())
+	r.set.Logger.Info("Listening for datagrams",
+		zap.String("endpoint", conn.LocalAddr().String()),
+		zap.String("protocol", r.cfg.Protocol))
+
+	r.wg.Add(1)
+	go r.readLoop()
+	return nil
+}
+
+// Shutdown implements component.Component.
+func (r *coapReceiver) Shutdown(context.Context) error {
+	if r.conn == nil {
+		return nil
+	}
+	err := r.conn.Close()
+	r.wg.Wait()
+	return err
+}
+
+// readLoop handles datagrams one at a time until the socket is closed.
+func (r *coapReceiver) readLoop() {
+	defer r.wg.Done()
+	buf := make([]byte, maxDatagramSize)
+	for {
+		n, addr, err := r.conn.ReadFrom(buf)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			r.set.Logger.Debug("Failed to read datagram", zap.Error(err))
+			continue
+		}
+		if r.cfg.Protocol == ProtocolUDP {
+			r.handleDatagram(buf[:n], addr)
+		} else {
+			r.handleCoAP(buf[:n], addr)
+		}
+	}
+}
+
+// handleDatagram handles a bare datagram: a template ID, then the payload.
+func (r *coapReceiver) handleDatagram(b []byte, addr net.Addr) {
+	if len(b) == 0 {
+		r.finish(outcomeMalformed, errors.New("empty datagram"), addr, "")
+		return
+	}
+	tmpl, ok := r.byID[b[0]]
+	if !ok {
+		r.finish(outcomeUnknownTemplate, fmt.Errorf("template id %d", b[0]), addr, "")
+		return
+	}
+	outcome, err := r.process(tmpl, b[1:])
+	r.finish(outcome, err, addr, tmpl.Name)
+}
+
+// handleCoAP handles a CoAP request, answering confirmable ones.
+// Retransmissions of a confirmable request get the cached response.
+func (r *coapReceiver) handleCoAP(b []byte, addr net.Addr) {
+	m, err := parseCoAP(b)
+	if errors.Is(err, errNotCoAP) {
+		r.finish(outcomeMalformed, err, addr, "")
+		return
+	}
+	if m.typ == coapAcknowledgment || m.typ == coapReset {
+		return
+	}
+	if m.code == coapEmpty {
+		// CoAP ping.
+		if m.typ == coapConfirmable {
+			r.reply(coapResetFor(m), addr)
+		}
+		return
+	}
+
+	now := time.Now()
+	key := exchangeKey{remote: addr.String(), id: m.id}
+	if m.typ == coapConfirmable {
+		r.sweepExchanges(now)
+		if ex, ok := r.exchanges[key]; ok {
+			r.reply(ex.response, addr)
+			return
+		}
+	}
+
+	var (
+		outcome string
+		name    string
+		code    uint8
+	)
+	switch tmpl, ok := r.byName[m.path]; {
+	case err != nil:
+		outcome = outcomeMalformed
+	case m.code != coapPost && m.code != coapPut:
+		outcome, code, err = outcomeMalformed, coapMethodNotAllowed, errors.New("method not allowed")
+	case !ok:
+		outcome, err = outcomeUnknownTemplate, fmt.Errorf("path %q", m.path)
+	default:
+		name = tmpl.Name
+		outcome, err = r.process(tmpl, m.payload)
+	}
+	r.finish(outcome, err, addr, name)
+
+	if m.typ != coapConfirmable {
+		return
+	}
+	if code == 0 {
+		code = coapCodes[outcome]
+	}
+	diagnostic := ""
+	if err != nil {
+		diagnostic = err.Error()
+	}
+	resp := coapResponse(m, code, diagnostic)
+	if len(r.exchanges) < maxExchanges {
+		r.exchanges[key] = exchange{response: resp, expires: now.Add(exchangeLifetime)}
+	}
+	r.reply(resp, addr)
+}
+
+// process decodes a payload with tmpl and passes the metrics on.
+func (r *coapReceiver) process(tmpl *TemplateConfig, payload []byte) (string, error) {
+	rd, err := tmpl.decode(payload)
+	if err != nil {
+		return outcomeMalformed, err
+	}
+	attrs, known := r.cfg.Devices[rd.deviceID]
+	if !known && r.cfg.UnknownDevices == UnknownDevicesDrop {
+		return outcomeUnknownDevice, fmt.Errorf("device %q", rd.deviceID)
+	}
+
+	now := time.Now()
+	key := replayKey{device: rd.deviceID, template: tmpl.Name}
+	guarded := r.replay != nil && rd.hasSeq
+	if guarded && !r.replay.accepts(key, rd.seq, rd.seqBits, now) {
+		return outcomeReplayed, fmt.Errorf("device %q: sequence %d", rd.deviceID, rd.seq)
+	}
+
+	md := pmetric.NewMetrics()
+	tmpl.appendMetrics(md, rd, attrs, r.start, pcommon.NewTimestampFromTime(now))
+	if err := r.next.ConsumeMetrics(context.Background(), md); err != nil {
+		return outcomeRefused, err
+	}
+	// Only accepted payloads count as seen, so that the device may resend
+	// a refused one.
+	if guarded {
+		r.replay.mark(key, rd.seq, rd.seqBits, now)
+	}
+	return outcomeAccepted, nil
+}
+
+// finish logs and counts the outcome of a message.
+func (r *coapReceiver) finish(outcome string, err error, addr net.Addr, template string) {
+	if outcome == outcomeAccepted {
+		for _, msg := range failureMessages {
+			r.failures.Success(msg)
+		}
+	} else {
+		r.failures.Error(failureMessages[outcome], err,
+			zap.String("remote", addr.String()),
+			zap.String("template", template))
+	}
+	if r.messages != nil {
+		r.messages.Add(context.Background(), 1, r.labels.Option(attribute.String("outcome", outcome)))
+	}
+}
+
+// reply sends a CoAP response.
+func (r *coapReceiver) reply(b []byte, addr net.Addr) {
+	if _, err := r.conn.WriteTo(b, addr); err != nil {
+		r.set.Logger.Debug("Failed to send CoAP response", zap.Error(err), zap.String("remote", addr.String()))
+	}
+}
+
+// sweepExchanges forgets expired responses, at most once per second.
+func (r *coapReceiver) sweepExchanges(now time.Time) {
+	if now.Sub(r.lastSweep) < time.Second {
+		return
+	}
+	r.lastSweep = now
+	for key, ex := range r.exchanges {
+		if now.After(ex.expires) {
+			delete(r.exchanges, key)
+		}
+	}
+}
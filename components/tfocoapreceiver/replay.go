@@ -0,0 +1,132 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfocoapreceiver
+
+import "time"
+
+// replayKey identifies a message counter: each device counts the messages
+// of each template.
+type replayKey struct {
+	device   string
+	template string
+}
+
+// replayState holds the highest sequence number seen and which of the
+// window below it were seen too, bit n standing for highest-n.
+type replayState struct {
+	highest uint64
+	seen    uint64
+	last    time.Time
+}
+
+// replayGuard rejects sequence numbers seen before or older than the
+// window. Counters may wrap around: sequence numbers are compared in
+// serial number arithmetic (RFC 1982), so a number is newer when it is
+// less than half the counter range ahead. It is not safe for concurrent
+// use.
+type replayGuard struct {
+	window    uint64
+	ttl       time.Duration
+	states    map[replayKey]*replayState
+	lastSweep time.Time
+}
+
+// newReplayGuard returns a guard with window, or nil when window is 0.
+func newReplayGuard(window int, ttl time.Duration) *replayGuard {
+	if window <= 0 {
+		return nil
+	}
+	return &replayGuard{
+		window: uint64(window),
+		ttl:    ttl,
+		states: make(map[replayKey]*replayState),
+	}
+}
+
+// accepts reports whether seq, a counter of bits bits, is new for key.
+func (g *replayGuard) accepts(key replayKey, seq uint64, bits uint, now time.Time) bool {
+	st := g.state(key, now)
+	if st == nil {
+		return true
+	}
+	ahead, behind := distance(st.highest, seq, bits)
+	switch {
+	case ahead > 0:
+		return true
+	case behind >= g.window:
+		return false
+	default:
+		return st.seen&(1<<behind) == 0
+	}
+}
+
+// mark records seq as seen for key. It must follow accepts(key, seq).
+func (g *replayGuard) mark(key replayKey, seq uint64, bits uint, now time.Time) {
+	g.sweep(now)
+	st := g.state(key, now)
+	if st == nil {
+		g.states[key] = &replayState{highest: seq, seen: 1, last: now}
+		return
+	}
+	st.last = now
+	ahead, behind := distance(st.highest, seq, bits)
+	if ahead > 0 {
+		if ahead >= 64 {
+			st.seen = 0
+		} else {
+			st.seen <<= ahead
+		}
+		st.seen |= 1
+		st.highest = seq
+		return
+	}
+	st.seen |= 1 << behind
+}
+
+// state returns the live state of key, or nil.
+func (g *replayGuard) state(key replayKey, now time.Time) *replayState {
+	st, ok := g.states[key]
+	if !ok || now.Sub(st.last) > g.ttl {
+		return nil
+	}
+	return st
+}
+
+// sweep forgets expired states, at most once per TTL.
+func (g *replayGuard) sweep(now time.Time) {
+	if now.Sub(g.lastSweep) < g.ttl {
+		return
+	}
+	g.lastSweep = now
+	for key, st := range g.states {
+		if now.Sub(st.last) > g.ttl {
+			delete(g.states, key)
+		}
+	}
+}
+
+// distance returns how far seq is ahead of or behind highest, one of them
+// being 0, for a counter of bits bits.
+func distance(highest, seq uint64, bits uint) (ahead, behind uint64) {
+	mask := ^uint64(0)
+	if bits < 64 {
+		mask = 1<<bits - 1
+	}
+	delta := (seq - highest) & mask
+	if delta != 0 && delta <= mask>>1 {
+		return delta, 0
+	}
+	return 0, (highest - seq) & mask
+}
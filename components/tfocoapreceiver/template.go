@@ -0,0 +1,313 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfocoapreceiver
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// AttrDeviceID is the resource attribute holding the device ID.
+const AttrDeviceID = "device.id"
+
+// Sizes in bytes of the binary types.
+var (
+	valueTypes = map[string]int{
+		"u8": 1, "i8": 1, "u16": 2, "i16": 2, "u32": 4, "i32": 4,
+		"u64": 8, "i64": 8, "f32": 4, "f64": 8,
+	}
+	deviceIDTypes = map[string]int{"u8": 1, "u16": 2, "u32": 4, "u64": 8, "eui64": 8}
+	sequenceTypes = map[string]int{"u8": 1, "u16": 2, "u32": 4, "u64": 8}
+)
+
+// number is a decoded metric value.
+type number struct {
+	i     int64
+	f     float64
+	isInt bool
+}
+
+// float returns n as a float64.
+func (n number) float() float64 {
+	if n.isInt {
+		return float64(n.i)
+	}
+	return n.f
+}
+
+// reading is a decoded payload.
+type reading struct {
+	deviceID string
+
+	hasSeq  bool
+	seq     uint64
+	seqBits uint
+
+	// values holds the values of the template metrics; present is false
+	// for metrics missing from a json payload.
+	values  []number
+	present []bool
+}
+
+// decode decodes a payload with template t.
+func (t *TemplateConfig) decode(payload []byte) (reading, error) {
+	if t.Encoding == EncodingBinary {
+		return t.decodeBinary(payload)
+	}
+	return t.decodeJSON(payload)
+}
+
+// decodeBinary decodes the device ID, the sequence and the metrics in
+// order.
+func (t *TemplateConfig) decodeBinary(payload []byte) (reading, error) {
+	var order binary.ByteOrder = binary.BigEndian
+	if t.ByteOrder == ByteOrderLittle {
+		order = binary.LittleEndian
+	}
+	next := func(what, typ string) ([]byte, error) {
+		size := valueTypes[typ]
+		if typ == "eui64" {
+			size = 8
+		}
+		if len(payload) < size {
+			return nil, fmt.Errorf("%s: payload too short", what)
+		}
+		b := payload[:size]
+		payload = payload[size:]
+		return b, nil
+	}
+
+	r := reading{
+		values:  make([]number, len(t.Metrics)),
+		present: make([]bool, len(t.Metrics)),
+	}
+	b, err := next("device_id", t.DeviceID.Type)
+	if err != nil {
+		return reading{}, err
+	}
+	if t.DeviceID.Type == "eui64" {
+		r.deviceID = hex.EncodeToString(b)
+	} else {
+		r.deviceID = strconv.FormatUint(readUint(order, b), 10)
+	}
+
+	if t.Sequence != nil {
+		if b, err = next("sequence", t.Sequence.Type); err != nil {
+			return reading{}, err
+		}
+		r.hasSeq = true
+		r.seq = readUint(order, b)
+		r.seqBits = uint(8 * len(b))
+	}
+
+	for i, m := range t.Metrics {
+		if b, err = next("metric "+m.Name, m.Type); err != nil {
+			return reading{}, err
+		}
+		r.values[i] = readNumber(order, m.Type, b)
+		r.present[i] = true
+	}
+	if len(payload) > 0 {
+		return reading{}, fmt.Errorf("%d trailing bytes", len(payload))
+	}
+	return r, nil
+}
+
+// readUint reads an unsigned integer of len(b) bytes.
+func readUint(order binary.ByteOrder, b []byte) uint64 {
+	switch len(b) {
+	case 1:
+		return uint64(b[0])
+	case 2:
+		return uint64(order.Uint16(b))
+	case 4:
+		return uint64(order.Uint32(b))
+	default:
+		return order.Uint64(b)
+	}
+}
+
+// readNumber reads a value of binary type typ.
+func readNumber(order binary.ByteOrder, typ string, b []byte) number {
+	switch typ {
+	case "i8":
+		return number{i: int64(int8(b[0])), isInt: true}
+	case "i16":
+		return number{i: int64(int16(order.Uint16(b))), isInt: true}
+	case "i32":
+		return number{i: int64(int32(order.Uint32(b))), isInt: true}
+	case "i64":
+		return number{i: int64(order.Uint64(b)), isInt: true}
+	case "f32":
+		return number{f: float64(math.Float32frombits(order.Uint32(b)))}
+	case "f64":
+		return number{f: math.Float64frombits(order.Uint64(b))}
+	}
+	u := readUint(order, b)
+	if u > math.MaxInt64 {
+		return number{f: float64(u)}
+	}
+	return number{i: int64(u), isInt: true}
+}
+
+// decodeJSON decodes a JSON object. Metrics missing from the object are
+// skipped, but at least one must be present.
+func (t *TemplateConfig) decodeJSON(payload []byte) (reading, error) {
+	dec := json.NewDecoder(bytes.NewReader(payload))
+	dec.UseNumber()
+	var obj map[string]any
+	if err := dec.Decode(&obj); err != nil {
+		return reading{}, err
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return reading{}, errors.New("trailing data after JSON object")
+	}
+
+	r := reading{
+		values:  make([]number, len(t.Metrics)),
+		present: make([]bool, len(t.Metrics)),
+	}
+	switch id := lookup(obj, t.DeviceID.Field).(type) {
+	case string:
+		r.deviceID = id
+	case json.Number:
+		r.deviceID = id.String()
+	case nil:
+		return reading{}, fmt.Errorf("device_id: field %q is missing", t.DeviceID.Field)
+	default:
+		return reading{}, fmt.Errorf("device_id: field %q is not a string or number", t.DeviceID.Field)
+	}
+	if r.deviceID == "" {
+		return reading{}, errors.New("device_id: empty")
+	}
+
+	if t.Sequence != nil {
+		v, ok := lookup(obj, t.Sequence.Field).(json.Number)
+		if !ok {
+			return reading{}, fmt.Errorf("sequence: field %q is missing or not a number", t.Sequence.Field)
+		}
+		seq, err := strconv.ParseUint(v.String(), 10, 64)
+		if err != nil {
+			return reading{}, fmt.Errorf("sequence: %w", err)
+		}
+		r.seqBits = 64
+		if size, ok := sequenceTypes[t.Sequence.Type]; ok {
+			r.seqBits = uint(8 * size)
+		}
+		if r.seqBits < 64 && seq >= 1<<r.seqBits {
+			return reading{}, fmt.Errorf("sequence: %d overflows %s", seq, t.Sequence.Type)
+		}
+		r.hasSeq = true
+		r.seq = seq
+	}
+
+	found := false
+	for i, m := range t.Metrics {
+		switch v := lookup(obj, m.Field).(type) {
+		case nil:
+			continue
+		case bool:
+			r.values[i] = number{isInt: true}
+			if v {
+				r.values[i].i = 1
+			}
+		case json.Number:
+			if n, err := v.Int64(); err == nil {
+				r.values[i] = number{i: n, isInt: true}
+			} else if f, err := v.Float64(); err == nil {
+				r.values[i] = number{f: f}
+			} else {
+				return reading{}, fmt.Errorf("metric %s: %w", m.Name, err)
+			}
+		default:
+			return reading{}, fmt.Errorf("metric %s: field %q is not a number or boolean", m.Name, m.Field)
+		}
+		r.present[i] = true
+		found = true
+	}
+	if !found {
+		return reading{}, errors.New("no metric field present")
+	}
+	return r, nil
+}
+
+// lookup returns the value at the dotted path in obj, or nil.
+func lookup(obj map[string]any, path string) any {
+	var v any = obj
+	for key := range strings.SplitSeq(path, ".") {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = m[key]
+	}
+	return v
+}
+
+// appendMetrics appends the metrics of r to md, with the resource
+// attributes of the device.
+func (t *TemplateConfig) appendMetrics(md pmetric.Metrics, r reading, attrs map[string]string, start, now pcommon.Timestamp) {
+	rm := md.ResourceMetrics().AppendEmpty()
+	res := rm.Resource().Attributes()
+	for k, v := range attrs {
+		res.PutStr(k, v)
+	}
+	res.PutStr(AttrDeviceID, r.deviceID)
+
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName(scopeName)
+	for i, mc := range t.Metrics {
+		if !r.present[i] {
+			continue
+		}
+		m := sm.Metrics().AppendEmpty()
+		m.SetName(mc.Name)
+		m.SetUnit(mc.Unit)
+
+		var dp pmetric.NumberDataPoint
+		if mc.Kind == KindSum {
+			sum := m.SetEmptySum()
+			sum.SetIsMonotonic(true)
+			sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+			dp = sum.DataPoints().AppendEmpty()
+			dp.SetStartTimestamp(start)
+		} else {
+			dp = m.SetEmptyGauge().DataPoints().AppendEmpty()
+		}
+		dp.SetTimestamp(now)
+
+		v := r.values[i]
+		scale := mc.Scale
+		if scale == 0 {
+			scale = 1
+		}
+		if v.isInt && scale == 1 && mc.Offset == 0 {
+			dp.SetIntValue(v.i)
+		} else {
+			dp.SetDoubleValue(v.float()*scale + mc.Offset)
+		}
+	}
+}
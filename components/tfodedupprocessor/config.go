@@ -0,0 +1,68 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfodedupprocessor
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Mode selects how duplicate spans are recognised.
+type Mode string
+
+const (
+	// ModeIdentity treats spans with the same trace and span ID as duplicates.
+	ModeIdentity Mode = "identity"
+
+	// ModeFingerprint treats spans with the same trace ID, parent span ID,
+	// name and kind and start/end times within Epsilon as duplicates.
+	ModeFingerprint Mode = "fingerprint"
+)
+
+// Config defines the configuration for the TFO dedup processor.
+type Config struct {
+	// Mode selects identity or fingerprint matching.
+	// Default: identity
+	Mode Mode `mapstructure:"mode"`
+
+	// CacheSize is the number of span identities or fingerprints kept in the
+	// LRU cache. Duplicates arriving after their original was evicted are
+	// not detected.
+	// Default: 100000
+	CacheSize int `mapstructure:"cache_size"`
+
+	// Epsilon is the largest start and end time difference at which two
+	// spans with the same fingerprint are considered duplicates. Only used
+	// in fingerprint mode.
+	// Default: 1ms
+	Epsilon time.Duration `mapstructure:"epsilon"`
+}
+
+// Validate checks the configuration for errors.
+func (cfg *Config) Validate() error {
+	switch cfg.Mode {
+	case ModeIdentity, ModeFingerprint:
+	default:
+		return fmt.Errorf("mode must be %q or %q, got %q", ModeIdentity, ModeFingerprint, cfg.Mode)
+	}
+	if cfg.CacheSize <= 0 {
+		return errors.New("cache_size must be positive")
+	}
+	if cfg.Epsilon < 0 {
+		return errors.New("epsilon must not be negative")
+	}
+	return nil
+}
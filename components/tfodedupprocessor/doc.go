@@ -0,0 +1,38 @@
+// Package tfodedupprocessor removes duplicate spans produced by services that
+// are instrumented twice.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Services running both auto- and manual instrumentation can emit the same
+// operation twice. The processor keeps an LRU cache of recently seen spans
+// and drops repeats in one of two modes:
+//   - identity: spans with the same trace ID and span ID
+//   - fingerprint: spans with the same trace ID, parent span ID, name and
+//     kind whose start and end times are within epsilon of a seen span
+//
+// In fingerprint mode the first span seen is kept; children of a dropped
+// duplicate keep referencing its span ID.
+//
+// Removed spans are counted by tfo_dedup_spans_removed.
+//
+// Configuration example:
+//
+//	processors:
+//	  tfodedup:
+//	    mode: fingerprint
+//	    cache_size: 100000
+//	    epsilon: 1ms
+package tfodedupprocessor // import "github.com/telemetryflow/telemetryflow-collector/components/tfodedupprocessor"
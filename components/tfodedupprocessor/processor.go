@@ -0,0 +1,180 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfodedupprocessor
+
+import (
+	"context"
+	"sync"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/requestid"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+const scopeName = "github.com/telemetryflow/telemetryflow-collector/components/tfodedupprocessor"
+
+// maxTimingsPerFingerprint bounds the distinct start/end pairs remembered
+// for one fingerprint, e.g. a span name repeated in a loop under one parent.
+const maxTimingsPerFingerprint = 16
+
+// identityKey identifies a span by trace and span ID.
+type identityKey struct {
+	traceID pcommon.TraceID
+	spanID  pcommon.SpanID
+}
+
+// fingerprintKey identifies a span by its position and name in the trace.
+type fingerprintKey struct {
+	traceID pcommon.TraceID
+	parent  pcommon.SpanID
+	name    string
+	kind    ptrace.SpanKind
+}
+
+// timing holds the start and end time of a seen span.
+type timing struct {
+	start, end pcommon.Timestamp
+}
+
+// dedupProcessor drops spans already seen by the processor.
+type dedupProcessor struct {
+	cfg    *Config
+	logger *zap.Logger
+
+	// mu serialises cache lookups and inserts so that concurrent batches
+	// carrying the same span cannot both be kept.
+	mu           sync.Mutex
+	identities   *lru.Cache[identityKey, struct{}]
+	fingerprints *lru.Cache[fingerprintKey, []timing]
+
+	removed   metric.Int64Counter
+	modeAttrs metric.MeasurementOption
+}
+
+// newDedupProcessor creates the processor state for cfg. Removed spans are
+// counted under labels.
+func newDedupProcessor(cfg *Config, set component.TelemetrySettings, labels selfmetrics.Labels) (*dedupProcessor, error) {
+	p := &dedupProcessor{
+		cfg:       cfg,
+		logger:    set.Logger,
+		modeAttrs: labels.Option(attribute.String("mode", string(cfg.Mode))),
+	}
+
+	var err error
+	switch cfg.Mode {
+	case ModeFingerprint:
+		p.fingerprints, err = lru.New[fingerprintKey, []timing](cfg.CacheSize)
+	default:
+		p.identities, err = lru.New[identityKey, struct{}](cfg.CacheSize)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if set.MeterProvider != nil {
+		p.removed, err = set.MeterProvider.Meter(scopeName).Int64Counter(selfmetrics.DedupSpansRemoved,
+			metric.WithDescription("Number of duplicate spans removed by the dedup processor."),
+			metric.WithUnit("{span}"))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// processTraces removes duplicate spans from td.
+func (p *dedupProcessor) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	removed := 0
+
+	p.mu.Lock()
+	td.ResourceSpans().RemoveIf(func(rs ptrace.ResourceSpans) bool {
+		rs.ScopeSpans().RemoveIf(func(ss ptrace.ScopeSpans) bool {
+			ss.Spans().RemoveIf(func(span ptrace.Span) bool {
+				if p.isDuplicate(span) {
+					removed++
+					return true
+				}
+				return false
+			})
+			return ss.Spans().Len() == 0
+		})
+		return rs.ScopeSpans().Len() == 0
+	})
+	p.mu.Unlock()
+
+	if removed == 0 {
+		return td, nil
+	}
+
+	if p.removed != nil {
+		p.removed.Add(ctx, int64(removed), p.modeAttrs)
+	}
+	p.logger.Debug("Removed duplicate spans", zap.Int("count", removed), zap.String("mode", string(p.cfg.Mode)), requestid.Field(ctx))
+
+	if td.ResourceSpans().Len() == 0 {
+		return td, processorhelper.ErrSkipProcessingData
+	}
+	return td, nil
+}
+
+// isDuplicate reports whether span was seen before and records it otherwise.
+// Callers hold mu.
+func (p *dedupProcessor) isDuplicate(span ptrace.Span) bool {
+	if p.cfg.Mode != ModeFingerprint {
+		key := identityKey{traceID: span.TraceID(), spanID: span.SpanID()}
+		found, _ := p.identities.ContainsOrAdd(key, struct{}{})
+		return found
+	}
+
+	key := fingerprintKey{
+		traceID: span.TraceID(),
+		parent:  span.ParentSpanID(),
+		name:    span.Name(),
+		kind:    span.Kind(),
+	}
+	t := timing{start: span.StartTimestamp(), end: span.EndTimestamp()}
+
+	seen, _ := p.fingerprints.Get(key)
+	for _, s := range seen {
+		if p.within(s.start, t.start) && p.within(s.end, t.end) {
+			return true
+		}
+	}
+
+	// Copy on append: the cached slice must not alias a previous value.
+	updated := make([]timing, 0, min(len(seen)+1, maxTimingsPerFingerprint))
+	if len(seen) >= maxTimingsPerFingerprint {
+		seen = seen[len(seen)-maxTimingsPerFingerprint+1:]
+	}
+	updated = append(append(updated, seen...), t)
+	p.fingerprints.Add(key, updated)
+	return false
+}
+
+// within reports whether a and b differ by at most the configured epsilon.
+func (p *dedupProcessor) within(a, b pcommon.Timestamp) bool {
+	if a > b {
+		a, b = b, a
+	}
+	return uint64(b-a) <= uint64(p.cfg.Epsilon)
+}
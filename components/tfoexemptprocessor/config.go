@@ -0,0 +1,79 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoexemptprocessor
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Config defines the configuration for the TFO exempt processor.
+type Config struct {
+	// Rules select the spans whose traces are never sampled out. A trace is
+	// exempt when any of its spans matches any rule.
+	Rules []Rule `mapstructure:"rules"`
+
+	// TraceWindow is how long a trace stays exempt after its last span was
+	// seen, so spans of the trace in later batches are marked too.
+	// Default: 5m
+	TraceWindow time.Duration `mapstructure:"trace_window"`
+
+	// MaxTraces bounds the exempt trace IDs remembered; the least recently
+	// seen trace is forgotten first.
+	// Default: 100000
+	MaxTraces int `mapstructure:"max_traces"`
+}
+
+// Rule is a never-sample rule. All of its conditions must match a span.
+type Rule struct {
+	// Name identifies the rule in logs.
+	Name string `mapstructure:"name"`
+
+	// Attributes must all be present with the given values on the span or
+	// its resource. The value "*" matches any value.
+	Attributes map[string]string `mapstructure:"attributes"`
+
+	// Services are service.name values of the resource, any of which
+	// matches.
+	Services []string `mapstructure:"services"`
+}
+
+// Validate checks the configuration for errors.
+func (cfg *Config) Validate() error {
+	if len(cfg.Rules) == 0 {
+		return errors.New("at least one rule is required")
+	}
+	names := make(map[string]bool, len(cfg.Rules))
+	for i, r := range cfg.Rules {
+		if r.Name == "" {
+			return fmt.Errorf("rules[%d]: name is required", i)
+		}
+		if names[r.Name] {
+			return fmt.Errorf("rules[%d]: duplicate name %q", i, r.Name)
+		}
+		names[r.Name] = true
+		if len(r.Attributes) == 0 && len(r.Services) == 0 {
+			return fmt.Errorf("rule %q: attributes or services is required", r.Name)
+		}
+	}
+	if cfg.TraceWindow <= 0 {
+		return errors.New("trace_window must be positive")
+	}
+	if cfg.MaxTraces <= 0 {
+		return errors.New("max_traces must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+// Package tfoexemptprocessor exempts traces from sampling, e.g. debug
+// requests, canary services and synthetic monitors.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// The processor runs before the samplers of a pipeline and evaluates a list
+// of never-sample rules on every span. A rule matches a span when all of
+// its conditions hold:
+//   - attributes: every listed attribute has the given value on the span
+//     or, failing that, its resource; "*" matches any value
+//   - services: the service.name of the resource is one of the listed names
+//
+// Every span of a trace with a matching span gets sampling.priority=1. The
+// trace stays exempt for trace_window after its last span was seen, so
+// spans of the trace arriving in later batches are marked too; spans that
+// passed the processor before the first match are not.
+//
+// The samplers honour the mark:
+//   - probabilistic_sampler keeps spans with a positive sampling.priority
+//   - tail_sampling keeps them given a numeric_attribute policy on
+//     sampling.priority with min_value 1; it must not be combined with a
+//     drop policy matching the same traces
+//
+// Marked spans are counted by tfo_exempt_spans_marked.
+//
+// Configuration example:
+//
+//	processors:
+//	  tfoexempt:
+//	    rules:
+//	      - name: debug
+//	        attributes:
+//	          debug: "true"
+//	      - name: canary
+//	        services: [checkout-canary, payments-canary]
+//	      - name: synthetic
+//	        attributes:
+//	          user_agent.synthetic.type: "*"
+//	    trace_window: 5m
+//	  tail_sampling:
+//	    policies:
+//	      - name: tfo-exempt
+//	        type: numeric_attribute
+//	        numeric_attribute: {key: sampling.priority, min_value: 1}
+//	      - name: errors
+//	        type: status_code
+//	        status_code: {status_codes: [ERROR]}
+//
+//	service:
+//	  pipelines:
+//	    traces:
+//	      processors: [memory_limiter, tfoexempt, tail_sampling, batch]
+package tfoexemptprocessor // import "github.com/telemetryflow/telemetryflow-collector/components/tfoexemptprocessor"
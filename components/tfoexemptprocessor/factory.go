@@ -0,0 +1,69 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoexemptprocessor
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processorhelper"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+const (
+	// TypeStr is the type string identifier for the TFO exempt processor.
+	TypeStr = "tfoexempt"
+
+	// Defaults
+	defaultTraceWindow = 5 * time.Minute
+	defaultMaxTraces   = 100000
+)
+
+// NewFactory creates a new factory for the TFO exempt processor.
+func NewFactory() processor.Factory {
+	return processor.NewFactory(
+		component.MustNewType(TypeStr),
+		createDefaultConfig,
+		processor.WithTraces(createTracesProcessor, component.StabilityLevelAlpha),
+	)
+}
+
+// createDefaultConfig creates the default configuration for the processor.
+func createDefaultConfig() component.Config {
+	return &Config{
+		TraceWindow: defaultTraceWindow,
+		MaxTraces:   defaultMaxTraces,
+	}
+}
+
+// createTracesProcessor creates the traces processor.
+func createTracesProcessor(
+	ctx context.Context,
+	set processor.Settings,
+	cfg component.Config,
+	next consumer.Traces,
+) (processor.Traces, error) {
+	p, err := newExemptProcessor(cfg.(*Config), set.TelemetrySettings, selfmetrics.Processor(set.ID, pipeline.SignalTraces))
+	if err != nil {
+		return nil, err
+	}
+	return processorhelper.NewTraces(ctx, set, cfg, next, p.processTraces,
+		processorhelper.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+}
@@ -0,0 +1,190 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoexemptprocessor
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	lru "github.com/hashicorp/golang-lru/v2"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/requestid"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+const scopeName = "github.com/telemetryflow/telemetryflow-collector/components/tfoexemptprocessor"
+
+const (
+	// priorityKey is the span attribute the samplers read.
+	priorityKey = "sampling.priority"
+
+	// serviceNameKey is the resource attribute matched by Rule.Services.
+	serviceNameKey = "service.name"
+
+	// anyValue matches every value of an attribute.
+	anyValue = "*"
+)
+
+// exemptProcessor marks the spans of exempt traces with sampling.priority.
+type exemptProcessor struct {
+	cfg    *Config
+	logger *zap.Logger
+
+	// mu serialises lookups and inserts of exempt traces, holding the time
+	// each was last seen.
+	mu     sync.Mutex
+	traces *lru.Cache[pcommon.TraceID, time.Time]
+
+	marked     metric.Int64Counter
+	ruleAttrs  metric.MeasurementOption
+	traceAttrs metric.MeasurementOption
+}
+
+// newExemptProcessor creates the processor state for cfg. Marked spans are
+// counted under labels.
+func newExemptProcessor(cfg *Config, set component.TelemetrySettings, labels selfmetrics.Labels) (*exemptProcessor, error) {
+	traces, err := lru.New[pcommon.TraceID, time.Time](cfg.MaxTraces)
+	if err != nil {
+		return nil, err
+	}
+	p := &exemptProcessor{
+		cfg:        cfg,
+		logger:     set.Logger,
+		traces:     traces,
+		ruleAttrs:  labels.Option(attribute.String("reason", "rule")),
+		traceAttrs: labels.Option(attribute.String("reason", "trace")),
+	}
+
+	if set.MeterProvider != nil {
+		p.marked, err = set.MeterProvider.Meter(scopeName).Int64Counter(selfmetrics.ExemptSpansMarked,
+			metric.WithDescription("Number of spans exempted from sampling by the exempt processor."),
+			metric.WithUnit("{span}"))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// processTraces marks the spans of exempt traces in td.
+func (p *exemptProcessor) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	now := time.Now()
+	var byRule, marked int
+	var rules []string
+
+	p.mu.Lock()
+	// The first pass records the traces matched by a rule, so the second
+	// also marks their spans that come earlier in the batch.
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		rs := rss.At(i)
+		resource := rs.Resource().Attributes()
+		forEachSpan(rs, func(span ptrace.Span) {
+			if name := p.match(resource, span); name != "" {
+				p.traces.Add(span.TraceID(), now)
+				byRule++
+				if !slices.Contains(rules, name) {
+					rules = append(rules, name)
+				}
+			}
+		})
+	}
+	for i := 0; i < rss.Len(); i++ {
+		forEachSpan(rss.At(i), func(span ptrace.Span) {
+			seen, ok := p.traces.Get(span.TraceID())
+			if !ok {
+				return
+			}
+			if now.Sub(seen) > p.cfg.TraceWindow {
+				p.traces.Remove(span.TraceID())
+				return
+			}
+			p.traces.Add(span.TraceID(), now)
+			span.Attributes().PutInt(priorityKey, 1)
+			marked++
+		})
+	}
+	p.mu.Unlock()
+
+	if marked == 0 {
+		return td, nil
+	}
+	byTrace := marked - byRule
+	if p.marked != nil {
+		if byRule > 0 {
+			p.marked.Add(ctx, int64(byRule), p.ruleAttrs)
+		}
+		if byTrace > 0 {
+			p.marked.Add(ctx, int64(byTrace), p.traceAttrs)
+		}
+	}
+	p.logger.Debug("Exempted spans from sampling",
+		zap.Int("matched", byRule),
+		zap.Int("trace_members", byTrace),
+		zap.Strings("rules", rules),
+		requestid.Field(ctx),
+	)
+	return td, nil
+}
+
+// match returns the name of the first rule matching span, or "".
+func (p *exemptProcessor) match(resource pcommon.Map, span ptrace.Span) string {
+	for _, r := range p.cfg.Rules {
+		if matches(r, resource, span.Attributes()) {
+			return r.Name
+		}
+	}
+	return ""
+}
+
+// matches reports whether every condition of r holds for a span with
+// attributes attrs in resource.
+func matches(r Rule, resource, attrs pcommon.Map) bool {
+	if len(r.Services) > 0 {
+		service, ok := resource.Get(serviceNameKey)
+		if !ok || !slices.Contains(r.Services, service.AsString()) {
+			return false
+		}
+	}
+	for k, want := range r.Attributes {
+		got, ok := attrs.Get(k)
+		if !ok {
+			got, ok = resource.Get(k)
+		}
+		if !ok || (want != anyValue && got.AsString() != want) {
+			return false
+		}
+	}
+	return true
+}
+
+// forEachSpan calls fn for every span of rs.
+func forEachSpan(rs ptrace.ResourceSpans, fn func(ptrace.Span)) {
+	sss := rs.ScopeSpans()
+	for i := 0; i < sss.Len(); i++ {
+		spans := sss.At(i).Spans()
+		for j := 0; j < spans.Len(); j++ {
+			fn(spans.At(j))
+		}
+	}
+}
@@ -0,0 +1,45 @@
+// Package tfoexperimentexporter ends the pipelines of an A/B processor experiment.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// The exporter discards the telemetry it receives after adding its record
+// count and encoded size to the experiment observation carried by the
+// context (see pkg/experiment). The tfomirror connector, with experiment
+// enabled, creates the observation before passing a batch to a pipeline
+// and turns it into comparative metrics once the pipeline returns.
+//
+// Put it among the exporters of both the primary and the shadow pipeline.
+// It can sit next to real exporters, e.g. tfo on the primary pipeline or
+// debug on the shadow pipeline, as long as the pipelines consume
+// synchronously: a batch processor or a sending queue between the
+// connector and this exporter loses the observation, which is logged once.
+//
+// Configuration example:
+//
+//	exporters:
+//	  tfoexperiment:
+//
+//	service:
+//	  pipelines:
+//	    traces/control:
+//	      receivers: [tfomirror]
+//	      processors: [probabilistic_sampler/current]
+//	      exporters: [tfo, tfoexperiment]
+//	    traces/candidate:
+//	      receivers: [tfomirror]
+//	      processors: [probabilistic_sampler/new]
+//	      exporters: [tfoexperiment]
+package tfoexperimentexporter // import "github.com/telemetryflow/telemetryflow-collector/components/tfoexperimentexporter"
@@ -0,0 +1,72 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoexperimentexporter
+
+import (
+	"context"
+	"sync"
+
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/experiment"
+)
+
+// experimentExporter adds every batch it receives to the experiment
+// observation of the context and discards it.
+type experimentExporter struct {
+	logger *zap.Logger
+
+	// unobserved warns once about batches arriving without an observation.
+	unobserved sync.Once
+}
+
+func newExperimentExporter(set exporter.Settings) *experimentExporter {
+	return &experimentExporter{logger: set.Logger}
+}
+
+func (e *experimentExporter) consumeTraces(ctx context.Context, td ptrace.Traces) error {
+	var sizer ptrace.ProtoMarshaler
+	e.observe(ctx, td.SpanCount(), sizer.TracesSize(td))
+	return nil
+}
+
+func (e *experimentExporter) consumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	var sizer pmetric.ProtoMarshaler
+	e.observe(ctx, md.DataPointCount(), sizer.MetricsSize(md))
+	return nil
+}
+
+func (e *experimentExporter) consumeLogs(ctx context.Context, ld plog.Logs) error {
+	var sizer plog.ProtoMarshaler
+	e.observe(ctx, ld.LogRecordCount(), sizer.LogsSize(ld))
+	return nil
+}
+
+func (e *experimentExporter) observe(ctx context.Context, records, bytes int) {
+	obs := experiment.FromContext(ctx)
+	if obs == nil {
+		e.unobserved.Do(func() {
+			e.logger.Warn("Received a batch outside an experiment; " +
+				"the pipeline must be fed by a tfomirror connector with experiment enabled " +
+				"and must not contain buffering processors such as batch")
+		})
+		return
+	}
+	obs.Add(records, bytes)
+}
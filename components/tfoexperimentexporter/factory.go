@@ -0,0 +1,95 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoexperimentexporter
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+const (
+	// TypeStr is the type string identifier for the TFO experiment exporter.
+	TypeStr = "tfoexperiment"
+)
+
+// NewFactory creates a new factory for the TFO experiment exporter.
+func NewFactory() exporter.Factory {
+	return exporter.NewFactory(
+		component.MustNewType(TypeStr),
+		createDefaultConfig,
+		exporter.WithTraces(createTracesExporter, component.StabilityLevelAlpha),
+		exporter.WithMetrics(createMetricsExporter, component.StabilityLevelAlpha),
+		exporter.WithLogs(createLogsExporter, component.StabilityLevelAlpha),
+	)
+}
+
+// createDefaultConfig creates the default configuration for the exporter.
+func createDefaultConfig() component.Config {
+	return &Config{}
+}
+
+// createTracesExporter creates a traces exporter. It has no sending queue
+// or retries, so the experiment observation is recorded before the
+// pipeline returns.
+func createTracesExporter(
+	ctx context.Context,
+	set exporter.Settings,
+	cfg component.Config,
+) (exporter.Traces, error) {
+	e := newExperimentExporter(set)
+	return exporterhelper.NewTraces(
+		ctx,
+		set,
+		cfg,
+		e.consumeTraces,
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+	)
+}
+
+// createMetricsExporter creates a metrics exporter.
+func createMetricsExporter(
+	ctx context.Context,
+	set exporter.Settings,
+	cfg component.Config,
+) (exporter.Metrics, error) {
+	e := newExperimentExporter(set)
+	return exporterhelper.NewMetrics(
+		ctx,
+		set,
+		cfg,
+		e.consumeMetrics,
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+	)
+}
+
+// createLogsExporter creates a logs exporter.
+func createLogsExporter(
+	ctx context.Context,
+	set exporter.Settings,
+	cfg component.Config,
+) (exporter.Logs, error) {
+	e := newExperimentExporter(set)
+	return exporterhelper.NewLogs(
+		ctx,
+		set,
+		cfg,
+		e.consumeLogs,
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+	)
+}
@@ -0,0 +1,268 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoexporter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// DefaultCapabilitiesEndpoint is the path of the backend capabilities
+	// document.
+	DefaultCapabilitiesEndpoint = "/v2/capabilities"
+
+	// DefaultCapabilitiesRefreshInterval is how often the capabilities are
+	// fetched again after startup.
+	DefaultCapabilitiesRefreshInterval = 5 * time.Minute
+
+	// DefaultCapabilitiesTimeout bounds a capabilities request.
+	DefaultCapabilitiesTimeout = 10 * time.Second
+
+	// maxCapabilitiesSize bounds the capabilities document read.
+	maxCapabilitiesSize = 1 << 20
+)
+
+// CapabilitiesConfig configures backend capability discovery.
+type CapabilitiesConfig struct {
+	// Enabled fetches the backend limits at startup and adjusts
+	// max_request_size and encoding to stay within them.
+	// Default: false
+	Enabled bool `mapstructure:"enabled"`
+
+	// Endpoint is the path of the capabilities document.
+	// Default: /v2/capabilities
+	Endpoint string `mapstructure:"endpoint"`
+
+	// RefreshInterval is how often the capabilities are fetched again.
+	// Zero fetches them only at startup.
+	// Default: 5m
+	RefreshInterval time.Duration `mapstructure:"refresh_interval"`
+
+	// Timeout bounds each request.
+	// Default: 10s
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// Validate checks the capability discovery configuration for errors.
+func (cfg *CapabilitiesConfig) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.Endpoint == "" {
+		return errors.New("capabilities.endpoint is required")
+	}
+	if cfg.RefreshInterval < 0 {
+		return errors.New("capabilities.refresh_interval must not be negative")
+	}
+	if cfg.Timeout <= 0 {
+		return errors.New("capabilities.timeout must be positive")
+	}
+	return nil
+}
+
+// backendCapabilities is the capabilities document published by the TFO
+// Platform. Empty fields mean the backend does not restrict the parameter.
+type backendCapabilities struct {
+	// MaxPayloadBytes is the largest request body the backend accepts.
+	MaxPayloadBytes int `json:"max_payload_bytes"`
+
+	// Encodings are the accepted payload encodings, e.g. "proto", "json".
+	Encodings []string `json:"encodings"`
+
+	// Signals are the accepted signals: "traces", "metrics", "logs".
+	Signals []string `json:"signals"`
+}
+
+func (c *backendCapabilities) equal(o *backendCapabilities) bool {
+	return o != nil && c.MaxPayloadBytes == o.MaxPayloadBytes &&
+		slices.Equal(c.Encodings, o.Encodings) && slices.Equal(c.Signals, o.Signals)
+}
+
+// exportParams are the export parameters in effect: the configured values
+// narrowed to the backend capabilities.
+type exportParams struct {
+	maxRequestSize int
+	encoding       EncodingType
+}
+
+// params returns the export parameters in effect.
+func (e *tfoExporter) params() exportParams {
+	if p := e.effective.Load(); p != nil {
+		return *p
+	}
+	return exportParams{maxRequestSize: int(e.cfg.MaxRequestSize), encoding: e.cfg.Encoding}
+}
+
+// discovery fetches the backend capabilities and applies them.
+type discovery struct {
+	e      *tfoExporter
+	cancel context.CancelFunc
+	done   sync.WaitGroup
+
+	// last is the most recently applied document; only the refresh
+	// goroutine and start touch it.
+	last *backendCapabilities
+}
+
+// startDiscovery fetches the capabilities once and starts the periodic
+// refresh. A failed fetch keeps the configured parameters.
+func (e *tfoExporter) startDiscovery(ctx context.Context) {
+	d := &discovery{e: e}
+	if err := d.refresh(ctx); err != nil {
+		e.logger.Warn("Backend capability discovery failed; using configured export parameters",
+			zap.Error(err))
+	}
+	e.discovery = d
+
+	interval := e.cfg.Capabilities.RefreshInterval
+	if interval <= 0 {
+		return
+	}
+	runCtx, cancel := context.WithCancel(context.Background())
+	d.cancel = cancel
+	d.done.Add(1)
+	go func() {
+		defer d.done.Done()
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-runCtx.Done():
+				return
+			case <-ticker.C:
+				if err := d.refresh(runCtx); err != nil && runCtx.Err() == nil {
+					e.logger.Debug("Backend capability refresh failed", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
+
+// shutdown stops the periodic refresh.
+func (d *discovery) shutdown() {
+	if d == nil || d.cancel == nil {
+		return
+	}
+	d.cancel()
+	d.done.Wait()
+}
+
+// refresh fetches the capabilities and applies them when they changed.
+func (d *discovery) refresh(ctx context.Context) error {
+	caps, err := d.e.fetchCapabilities(ctx)
+	if err != nil {
+		return err
+	}
+	if caps.equal(d.last) {
+		return nil
+	}
+	d.last = caps
+	d.e.applyCapabilities(caps)
+	return nil
+}
+
+// fetchCapabilities requests the capabilities document.
+func (e *tfoExporter) fetchCapabilities(ctx context.Context) (*backendCapabilities, error) {
+	ctx, cancel := context.WithTimeout(ctx, e.cfg.Capabilities.Timeout)
+	defer cancel()
+
+	endpoint := e.cfg.URL(e.cfg.Capabilities.Endpoint)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, http.NoBody)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	e.setAuthHeaders(req)
+
+	e.clientMu.RLock()
+	client := e.client
+	e.clientMu.RUnlock()
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query %s: %w", endpoint, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil, fmt.Errorf("failed to query %s: unexpected status code: %d", endpoint, resp.StatusCode)
+	}
+
+	var caps backendCapabilities
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxCapabilitiesSize)).Decode(&caps); err != nil {
+		return nil, fmt.Errorf("failed to decode capabilities from %s: %w", endpoint, err)
+	}
+	if caps.MaxPayloadBytes < 0 {
+		return nil, fmt.Errorf("invalid capabilities from %s: negative max_payload_bytes", endpoint)
+	}
+	return &caps, nil
+}
+
+// applyCapabilities narrows the configured export parameters to caps and
+// logs every configured value the backend does not allow.
+func (e *tfoExporter) applyCapabilities(caps *backendCapabilities) {
+	p := exportParams{maxRequestSize: int(e.cfg.MaxRequestSize), encoding: e.cfg.Encoding}
+	if p.encoding == "" {
+		p.encoding = EncodingProto
+	}
+
+	if limit := caps.MaxPayloadBytes; limit > 0 && (p.maxRequestSize == 0 || p.maxRequestSize > limit) {
+		e.logger.Warn("Configured max_request_size exceeds the backend limit; using the backend limit",
+			zap.Int("max_request_size", p.maxRequestSize),
+			zap.Int("backend_max_payload_bytes", limit),
+		)
+		p.maxRequestSize = limit
+	}
+
+	if len(caps.Encodings) > 0 && !slices.Contains(caps.Encodings, string(p.encoding)) {
+		fallback := p.encoding
+		for _, enc := range []EncodingType{EncodingProto, EncodingJSON} {
+			if slices.Contains(caps.Encodings, string(enc)) {
+				fallback = enc
+				break
+			}
+		}
+		e.logger.Warn("Configured encoding is not supported by the backend",
+			zap.String("encoding", string(p.encoding)),
+			zap.Strings("backend_encodings", caps.Encodings),
+			zap.String("using", string(fallback)),
+		)
+		p.encoding = fallback
+	}
+
+	if len(caps.Signals) > 0 && !slices.Contains(caps.Signals, e.signal) {
+		e.logger.Warn("Backend does not advertise support for the exported signal",
+			zap.String("signal", e.signal),
+			zap.Strings("backend_signals", caps.Signals),
+		)
+	}
+
+	e.effective.Store(&p)
+	e.logger.Info("Backend capabilities applied",
+		zap.Int("max_request_size", p.maxRequestSize),
+		zap.String("encoding", string(p.encoding)),
+	)
+}
@@ -0,0 +1,331 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoexporter
+
+import (
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/bytesize"
+)
+
+const (
+	// DefaultDictionaryEndpoint is the default path trained zstd
+	// dictionaries are published to.
+	DefaultDictionaryEndpoint = "/v2/dictionaries"
+
+	// DefaultDictionarySize is the default size of a trained dictionary.
+	DefaultDictionarySize = 64 * bytesize.KiB
+
+	// DefaultDictionarySamples is the default number of payloads a
+	// dictionary is trained on.
+	DefaultDictionarySamples = 128
+
+	// maxDictionarySize bounds the dictionary size; larger dictionaries
+	// cost backend memory without helping small payloads.
+	maxDictionarySize = bytesize.MiB
+
+	// contentTypeDictionary is the Content-Type of a published dictionary.
+	contentTypeDictionary = "application/zstd-dictionary"
+)
+
+// CompressionType is the payload compression of one signal.
+type CompressionType string
+
+const (
+	// CompressionNone sends payloads uncompressed, even when the
+	// top-level compression is set.
+	CompressionNone CompressionType = "none"
+
+	// CompressionGzip compresses payloads with gzip.
+	CompressionGzip CompressionType = "gzip"
+
+	// CompressionZstd compresses payloads with zstd.
+	CompressionZstd CompressionType = "zstd"
+)
+
+// UnmarshalText rejects unknown compression types at configuration load.
+func (t *CompressionType) UnmarshalText(text []byte) error {
+	v := CompressionType(text)
+	if err := v.validate(); err != nil {
+		return err
+	}
+	*t = v
+	return nil
+}
+
+func (t CompressionType) validate() error {
+	switch t {
+	case "", CompressionNone, CompressionGzip, CompressionZstd:
+		return nil
+	}
+	return fmt.Errorf("invalid compression type %q: must be %q, %q or %q", string(t), CompressionNone, CompressionGzip, CompressionZstd)
+}
+
+// CompressionConfig selects the compression of each signal. A signal
+// without a type uses the top-level compression and compression_params.
+type CompressionConfig struct {
+	Traces  SignalCompressionConfig `mapstructure:"traces"`
+	Metrics SignalCompressionConfig `mapstructure:"metrics"`
+	Logs    SignalCompressionConfig `mapstructure:"logs"`
+}
+
+// SignalCompressionConfig configures the compression of one signal.
+type SignalCompressionConfig struct {
+	// Type is "none", "gzip" or "zstd". Empty uses the top-level
+	// compression.
+	Type CompressionType `mapstructure:"type"`
+
+	// Level is the compression level: 1-9 for gzip, 1-22 for zstd. Zero
+	// uses the default level.
+	// Default: 0
+	Level int `mapstructure:"level"`
+
+	// WindowSize is the zstd window, a power of two between 1KiB and
+	// 512MiB. Smaller windows save backend memory; windows larger than the
+	// payloads gain nothing. Zero uses the zstd default.
+	// Default: 0
+	WindowSize bytesize.Size `mapstructure:"window_size"`
+
+	// Dictionary trains a zstd dictionary on recent payloads.
+	Dictionary DictionaryConfig `mapstructure:"dictionary"`
+}
+
+// DictionaryConfig configures zstd dictionary training. Small payloads
+// compress poorly on their own because every request starts with an empty
+// window; a dictionary built from recent payloads primes it. Each trained
+// dictionary is published to the backend, which must keep it to decode
+// later requests, before payloads are compressed with it.
+type DictionaryConfig struct {
+	// Enabled trains and uses a dictionary. Requires type zstd.
+	// Default: false
+	Enabled bool `mapstructure:"enabled"`
+
+	// Endpoint is the path dictionaries are published to.
+	// Default: /v2/dictionaries
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Size is the size of a trained dictionary, at most 1MiB.
+	// Default: 64KiB
+	Size bytesize.Size `mapstructure:"size"`
+
+	// Samples is the number of recent payloads a dictionary is trained on.
+	// Default: 128
+	Samples int `mapstructure:"samples"`
+
+	// RetrainInterval replaces the dictionary with one trained on newer
+	// payloads this often. Zero keeps the first dictionary.
+	// Default: 0
+	RetrainInterval time.Duration `mapstructure:"retrain_interval"`
+}
+
+// newDefaultCompressionConfig returns the compression defaults: every
+// signal uses the top-level compression.
+func newDefaultCompressionConfig() CompressionConfig {
+	signal := SignalCompressionConfig{
+		Dictionary: DictionaryConfig{
+			Endpoint: DefaultDictionaryEndpoint,
+			Size:     DefaultDictionarySize,
+			Samples:  DefaultDictionarySamples,
+		},
+	}
+	return CompressionConfig{Traces: signal, Metrics: signal, Logs: signal}
+}
+
+// Validate checks the compression configuration for errors.
+func (cfg *CompressionConfig) Validate() error {
+	for signal, sc := range map[string]*SignalCompressionConfig{
+		signalTraces:  &cfg.Traces,
+		signalMetrics: &cfg.Metrics,
+		signalLogs:    &cfg.Logs,
+	} {
+		if err := sc.Validate(); err != nil {
+			return fmt.Errorf("signal_compression.%s: %w", signal, err)
+		}
+	}
+	return nil
+}
+
+// signal returns the settings of signal.
+func (cfg *CompressionConfig) signal(signal string) SignalCompressionConfig {
+	switch signal {
+	case signalMetrics:
+		return cfg.Metrics
+	case signalLogs:
+		return cfg.Logs
+	}
+	return cfg.Traces
+}
+
+// Validate checks the compression of one signal for errors.
+func (cfg *SignalCompressionConfig) Validate() error {
+	if err := cfg.Type.validate(); err != nil {
+		return err
+	}
+	switch cfg.Type {
+	case CompressionGzip:
+		if cfg.Level < 0 || cfg.Level > gzip.BestCompression {
+			return fmt.Errorf("level must be between 1 and %d for gzip", gzip.BestCompression)
+		}
+	case CompressionZstd:
+		if cfg.Level < 0 || cfg.Level > 22 {
+			return errors.New("level must be between 1 and 22 for zstd")
+		}
+	default:
+		if cfg.Level != 0 {
+			return errors.New("level requires type gzip or zstd")
+		}
+	}
+	if cfg.WindowSize != 0 {
+		if cfg.Type != CompressionZstd {
+			return errors.New("window_size requires type zstd")
+		}
+		w := cfg.WindowSize
+		if w < zstd.MinWindowSize || w > zstd.MaxWindowSize || w&(w-1) != 0 {
+			return fmt.Errorf("window_size must be a power of two between %s and %s",
+				bytesize.Size(zstd.MinWindowSize), bytesize.Size(zstd.MaxWindowSize))
+		}
+	}
+	return cfg.Dictionary.validate(cfg.Type)
+}
+
+func (cfg *DictionaryConfig) validate(typ CompressionType) error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if typ != CompressionZstd {
+		return errors.New("dictionary requires type zstd")
+	}
+	if cfg.Endpoint == "" {
+		return errors.New("dictionary.endpoint is required")
+	}
+	if cfg.Size < bytesize.KiB || cfg.Size > maxDictionarySize {
+		return fmt.Errorf("dictionary.size must be between 1KiB and %s", maxDictionarySize)
+	}
+	if cfg.Samples <= 0 {
+		return errors.New("dictionary.samples must be positive")
+	}
+	if cfg.RetrainInterval < 0 {
+		return errors.New("dictionary.retrain_interval must not be negative")
+	}
+	return nil
+}
+
+// compressor compresses the payloads of one signal. A nil *compressor
+// leaves compression to the HTTP client.
+type compressor struct {
+	typ CompressionType
+
+	gzipLevel int
+	gzipPool  sync.Pool
+
+	// zstdOpts are the encoder options without a dictionary; zstd holds
+	// the encoder in use, replaced when a dictionary is activated.
+	zstdOpts []zstd.EOption
+	zstd     atomic.Pointer[zstd.Encoder]
+}
+
+// newCompressor returns the compressor of cfg, or nil when cfg uses the
+// top-level compression.
+func newCompressor(cfg SignalCompressionConfig) (*compressor, error) {
+	c := &compressor{typ: cfg.Type}
+	switch cfg.Type {
+	case "":
+		return nil, nil
+	case CompressionGzip:
+		c.gzipLevel = gzip.DefaultCompression
+		if cfg.Level != 0 {
+			c.gzipLevel = cfg.Level
+		}
+	case CompressionZstd:
+		if cfg.Level != 0 {
+			c.zstdOpts = append(c.zstdOpts, zstd.WithEncoderLevel(zstd.EncoderLevelFromZstd(cfg.Level)))
+		}
+		if cfg.WindowSize != 0 {
+			c.zstdOpts = append(c.zstdOpts, zstd.WithWindowSize(int(cfg.WindowSize)))
+		}
+		enc, err := zstd.NewWriter(nil, c.zstdOpts...)
+		if err != nil {
+			return nil, err
+		}
+		c.zstd.Store(enc)
+	}
+	return c, nil
+}
+
+// useDictionary switches the zstd encoder to dict.
+func (c *compressor) useDictionary(dict []byte) error {
+	opts := append([]zstd.EOption{zstd.WithEncoderDict(dict)}, c.zstdOpts...)
+	enc, err := zstd.NewWriter(nil, opts...)
+	if err != nil {
+		return err
+	}
+	if old := c.zstd.Swap(enc); old != nil {
+		_ = old.Close()
+	}
+	return nil
+}
+
+// compress returns data compressed and its Content-Encoding. An empty
+// encoding leaves compression to the HTTP client.
+func (c *compressor) compress(data []byte) ([]byte, string, error) {
+	if c == nil {
+		return data, "", nil
+	}
+	switch c.typ {
+	case CompressionGzip:
+		var buf bytes.Buffer
+		w, _ := c.gzipPool.Get().(*gzip.Writer)
+		if w == nil {
+			var err error
+			if w, err = gzip.NewWriterLevel(&buf, c.gzipLevel); err != nil {
+				return nil, "", err
+			}
+		} else {
+			w.Reset(&buf)
+		}
+		defer c.gzipPool.Put(w)
+		if _, err := w.Write(data); err != nil {
+			return nil, "", err
+		}
+		if err := w.Close(); err != nil {
+			return nil, "", err
+		}
+		return buf.Bytes(), string(CompressionGzip), nil
+	case CompressionZstd:
+		enc := c.zstd.Load()
+		return enc.EncodeAll(data, make([]byte, 0, len(data)/2)), string(CompressionZstd), nil
+	}
+	// Identity stops the HTTP client from applying the top-level
+	// compression.
+	return data, "identity", nil
+}
+
+// close releases the zstd encoder.
+func (c *compressor) close() {
+	if c == nil {
+		return
+	}
+	if enc := c.zstd.Load(); enc != nil {
+		_ = enc.Close()
+	}
+}
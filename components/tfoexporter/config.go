@@ -18,29 +18,96 @@ import (
 	"errors"
 
 	"go.opentelemetry.io/collector/component"
-	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configoptional"
 	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/adaptive"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/attrfilter"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/bytesize"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/clientconf"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/deadletter"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/errorbudget"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/residency"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/retrybudget"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/watchdog"
 )
 
 // Config defines the configuration for the TFO exporter.
 type Config struct {
-	confighttp.ClientConfig `mapstructure:",squash"`
+	// ClientConfig holds the shared outbound client settings (TLS, proxy,
+	// compression, timeouts, auth extension reference, headers).
+	clientconf.ClientConfig `mapstructure:",squash"`
 
 	// UseV2API enables the TFO Platform v2 API endpoints.
 	// When true, exports to /v2/traces, /v2/metrics, /v2/logs instead of v1.
 	// Default: true
 	UseV2API bool `mapstructure:"use_v2_api"`
 
+	// Encoding is the wire format of exported payloads: "proto" or "json".
+	// Default: proto
+	Encoding EncodingType `mapstructure:"encoding"`
+
+	// MaxRequestSize is the largest encoded request body, e.g. "8MiB" or a
+	// number of bytes. Larger batches are split before sending. Zero
+	// disables the limit.
+	// Default: 8MiB
+	MaxRequestSize bytesize.Size `mapstructure:"max_request_size"`
+
+	// SignalCompression overrides the compression of individual signals
+	// and tunes zstd levels, windows and trained dictionaries.
+	SignalCompression CompressionConfig `mapstructure:"signal_compression"`
+
 	// Auth configures authentication for the TFO Platform.
 	Auth *AuthConfig `mapstructure:"auth"`
 
 	// CollectorIdentity is a reference to a tfoidentity extension for collector metadata.
 	CollectorIdentity component.ID `mapstructure:"collector_identity"`
 
+	// AllowAnonymous lets the exporter start when the tfoauth extension of
+	// auth or the collector_identity extension is missing or is not of the
+	// expected type. Telemetry is then exported without the API key or
+	// collector ID headers. By default startup fails.
+	// Default: false
+	AllowAnonymous bool `mapstructure:"allow_anonymous"`
+
+	// ClockDrift is a reference to a tfoclock extension. When set, every
+	// batch is annotated with the measured local clock drift.
+	ClockDrift component.ID `mapstructure:"clock_drift"`
+
+	// Maintenance is a reference to a tfomaintenance extension. While it
+	// pauses exports, batches are held until maintenance ends, however long
+	// it lasts, or until shutdown; the sending queue buffers the telemetry
+	// received meanwhile.
+	Maintenance component.ID `mapstructure:"maintenance"`
+
+	// Overrides is a reference to a tfooverrides extension. Its headers
+	// and API key for this exporter apply to every request, replacing the
+	// configured ones, and can be changed without a configuration reload.
+	Overrides component.ID `mapstructure:"overrides"`
+
 	// RetryConfig configures retry on failure.
 	RetryConfig configretry.BackOffConfig `mapstructure:"retry_on_failure"`
 
+	// QueueConfig configures the sending queue. Its num_consumers is the
+	// number of export workers.
+	QueueConfig configoptional.Optional[exporterhelper.QueueBatchConfig] `mapstructure:"sending_queue"`
+
+	// Concurrency adjusts how many export workers may send at once to the
+	// backend's observed latency and throttling, bounded by
+	// sending_queue.num_consumers.
+	Concurrency adaptive.Config `mapstructure:"adaptive_concurrency"`
+
+	// RetryBudget bounds retries across all exporters sending to the same
+	// host to a fraction of their recent successful requests.
+	RetryBudget retrybudget.Config `mapstructure:"retry_budget"`
+
+	// ErrorBudget takes the exporter out of the pipeline fan-out once sends
+	// have failed for longer than the budget, and puts it back when a
+	// background probe succeeds.
+	ErrorBudget errorbudget.Config `mapstructure:"error_budget"`
+
 	// TracesEndpoint overrides the default traces endpoint path.
 	TracesEndpoint string `mapstructure:"traces_endpoint"`
 
@@ -49,6 +116,49 @@ type Config struct {
 
 	// LogsEndpoint overrides the default logs endpoint path.
 	LogsEndpoint string `mapstructure:"logs_endpoint"`
+
+	// Residency restricts which residency regions may be exported to the
+	// endpoint.
+	Residency residency.Config `mapstructure:"residency"`
+
+	// Attributes removes resource and record attributes before batches are
+	// encoded. Other exporters of the pipeline keep every attribute.
+	// Removing data point attributes can merge distinct metric series.
+	Attributes attrfilter.Config `mapstructure:"attributes"`
+
+	// Datasets routes log records into datasets of the TFO v2 API by
+	// severity and attributes. It applies to logs only.
+	Datasets DatasetsConfig `mapstructure:"datasets"`
+
+	// Watchdog aborts in-flight sends and rebuilds the HTTP client when
+	// exports stop making progress.
+	Watchdog watchdog.Config `mapstructure:"watchdog"`
+
+	// Warmup verifies connectivity and credentials before the exporter
+	// reports itself started.
+	Warmup WarmupConfig `mapstructure:"warmup"`
+
+	// Capabilities discovers the backend limits and keeps max_request_size
+	// and encoding within them.
+	Capabilities CapabilitiesConfig `mapstructure:"capabilities"`
+
+	// DryRun encodes, splits and compresses batches as usual and records
+	// the requests, records and bytes that would have been sent, but never
+	// contacts the backend. The warm-up check and capability discovery are
+	// skipped. Use it to size capacity and estimate egress before pointing
+	// the exporter at a production backend.
+	// Default: false
+	DryRun bool `mapstructure:"dry_run"`
+
+	// ShutdownSpill writes the batches the sending queue still holds on
+	// shutdown to a local file instead of dropping them when the backend
+	// is unreachable.
+	ShutdownSpill ShutdownSpillConfig `mapstructure:"shutdown_spill"`
+
+	// DeadLetter writes the batches whose retries were exhausted to a local
+	// spool directory instead of dropping them, so they can be replayed
+	// with "tfo-collector deadletter replay".
+	DeadLetter deadletter.Config `mapstructure:"dead_letter"`
 }
 
 // AuthConfig defines authentication configuration.
@@ -64,12 +174,91 @@ type AuthConfig struct {
 	Extension component.ID `mapstructure:"extension"`
 }
 
+// mutatesData reports whether the exporter modifies the batches it is
+// handed, so the pipeline must pass it a copy.
+func (cfg *Config) mutatesData() bool {
+	return cfg.Residency.Blocks() || cfg.Attributes.Enabled()
+}
+
 // Validate checks the configuration for errors.
 func (cfg *Config) Validate() error {
 	if cfg.Endpoint == "" {
 		return errors.New("endpoint is required")
 	}
 
+	if err := cfg.ClientConfig.Validate(); err != nil {
+		return err
+	}
+
+	if cfg.Encoding != "" {
+		if err := cfg.Encoding.validate(); err != nil {
+			return err
+		}
+	}
+	if cfg.MaxRequestSize < 0 {
+		return errors.New("max_request_size must not be negative")
+	}
+
+	if err := cfg.SignalCompression.Validate(); err != nil {
+		return err
+	}
+
+	if err := cfg.Residency.Validate(); err != nil {
+		return err
+	}
+
+	if err := cfg.Attributes.Validate(); err != nil {
+		return err
+	}
+
+	if err := cfg.Datasets.Validate(); err != nil {
+		return err
+	}
+	if cfg.Datasets.Enabled() && !cfg.UseV2API {
+		return errors.New("datasets requires use_v2_api")
+	}
+
+	if err := cfg.Watchdog.Validate(); err != nil {
+		return err
+	}
+
+	if err := cfg.Concurrency.Validate(); err != nil {
+		return err
+	}
+	if queue := cfg.QueueConfig.Get(); cfg.Concurrency.Enabled && queue != nil &&
+		cfg.Concurrency.MaxConcurrency > queue.NumConsumers {
+		return errors.New("adaptive_concurrency.max_concurrency must not exceed sending_queue.num_consumers")
+	}
+
+	if err := cfg.RetryBudget.Validate(); err != nil {
+		return err
+	}
+
+	if err := cfg.ErrorBudget.Validate(); err != nil {
+		return err
+	}
+
+	if err := cfg.Warmup.Validate(); err != nil {
+		return err
+	}
+
+	if err := cfg.Capabilities.Validate(); err != nil {
+		return err
+	}
+
+	if err := cfg.ShutdownSpill.Validate(); err != nil {
+		return err
+	}
+	if queue := cfg.QueueConfig.Get(); cfg.ShutdownSpill.Enabled() && queue != nil && queue.StorageID != nil {
+		return errors.New("shutdown_spill requires an in-memory sending_queue; a persistent queue keeps its batches on shutdown")
+	}
+	if err := cfg.DeadLetter.Validate(); err != nil {
+		return err
+	}
+	if cfg.DeadLetter.Enabled() && !cfg.QueueConfig.HasValue() {
+		return errors.New("dead_letter requires a sending_queue; without one failed batches are returned to the pipeline")
+	}
+
 	// Validate auth configuration
 	if cfg.Auth != nil {
 		hasDirectAuth := cfg.Auth.APIKeyID != "" && cfg.Auth.APIKeySecret != ""
@@ -0,0 +1,322 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tfoexporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// headerDataset targets a dataset of the TFO v2 API.
+const headerDataset = "X-TelemetryFlow-Dataset"
+
+// datasetName is the form of dataset names accepted by the platform.
+var datasetName = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// LogSeverity is a severity range of the OpenTelemetry log data model.
+type LogSeverity string
+
+const (
+	LogSeverityTrace LogSeverity = "trace"
+	LogSeverityDebug LogSeverity = "debug"
+	LogSeverityInfo  LogSeverity = "info"
+	LogSeverityWarn  LogSeverity = "warn"
+	LogSeverityError LogSeverity = "error"
+	LogSeverityFatal LogSeverity = "fatal"
+)
+
+// logSeverities lists the ranges in ascending order; range i covers the
+// severity numbers 4i+1 to 4i+4.
+var logSeverities = []LogSeverity{
+	LogSeverityTrace, LogSeverityDebug, LogSeverityInfo,
+	LogSeverityWarn, LogSeverityError, LogSeverityFatal,
+}
+
+// UnmarshalText rejects unknown severities at configuration load.
+func (s *LogSeverity) UnmarshalText(text []byte) error {
+	v := LogSeverity(strings.ToLower(string(text)))
+	if v.index() < 0 {
+		return fmt.Errorf("invalid severity %q: must be one of trace, debug, info, warn, error or fatal", string(text))
+	}
+	*s = v
+	return nil
+}
+
+func (s LogSeverity) index() int {
+	for i, v := range logSeverities {
+		if v == s {
+			return i
+		}
+	}
+	return -1
+}
+
+// DatasetRoute sends the log records it matches to a dataset. A record
+// matches when its severity lies within the range and every attribute has
+// the given value, looked up on the record and then on its resource.
+type DatasetRoute struct {
+	// Dataset is the name of the target dataset.
+	Dataset string `mapstructure:"dataset"`
+
+	// MinSeverity is the lowest severity matched, e.g. "warn". Empty
+	// leaves the range open.
+	MinSeverity LogSeverity `mapstructure:"min_severity"`
+
+	// MaxSeverity is the highest severity matched. Empty leaves the range
+	// open.
+	MaxSeverity LogSeverity `mapstructure:"max_severity"`
+
+	// Attributes must all be present with the given values.
+	Attributes map[string]string `mapstructure:"attributes"`
+}
+
+// DatasetsConfig routes log records into datasets of the TFO v2 API.
+// Records are matched against the routes in order and the first match
+// wins; batches are split so that each request targets one dataset.
+type DatasetsConfig struct {
+	// Default is the dataset of records no route matches. Empty leaves them
+	// in the platform's default dataset.
+	Default string `mapstructure:"default"`
+
+	// Routes are evaluated in order.
+	Routes []DatasetRoute `mapstructure:"routes"`
+}
+
+// Enabled reports whether any record is routed to a dataset.
+func (cfg *DatasetsConfig) Enabled() bool {
+	return cfg.Default != "" || len(cfg.Routes) > 0
+}
+
+// Validate checks the dataset configuration for errors.
+func (cfg *DatasetsConfig) Validate() error {
+	if cfg.Default != "" && !datasetName.MatchString(cfg.Default) {
+		return fmt.Errorf("datasets.default: invalid dataset name %q", cfg.Default)
+	}
+	for i, r := range cfg.Routes {
+		if err := r.validate(); err != nil {
+			return fmt.Errorf("datasets.routes[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
+
+func (r *DatasetRoute) validate() error {
+	if !datasetName.MatchString(r.Dataset) {
+		return fmt.Errorf("invalid dataset name %q", r.Dataset)
+	}
+	for _, s := range []LogSeverity{r.MinSeverity, r.MaxSeverity} {
+		if s != "" && s.index() < 0 {
+			return fmt.Errorf("invalid severity %q", s)
+		}
+	}
+	if r.MinSeverity != "" && r.MaxSeverity != "" && r.MinSeverity.index() > r.MaxSeverity.index() {
+		return fmt.Errorf("min_severity %q is above max_severity %q", r.MinSeverity, r.MaxSeverity)
+	}
+	if r.MinSeverity == "" && r.MaxSeverity == "" && len(r.Attributes) == 0 {
+		return errors.New("route matches every record; use datasets.default instead")
+	}
+	return nil
+}
+
+// datasetRoute is a DatasetRoute with its severity range resolved.
+type datasetRoute struct {
+	dataset    string
+	min, max   plog.SeverityNumber
+	attributes map[string]string
+}
+
+// datasetRouter assigns log records to datasets. A nil *datasetRouter
+// leaves every record in the platform's default dataset.
+type datasetRouter struct {
+	routes []datasetRoute
+	def    string
+}
+
+// newDatasetRouter returns the router of cfg, or nil when no record is
+// routed.
+func newDatasetRouter(cfg DatasetsConfig) *datasetRouter {
+	if !cfg.Enabled() {
+		return nil
+	}
+	r := &datasetRouter{def: cfg.Default}
+	for _, route := range cfg.Routes {
+		dr := datasetRoute{
+			dataset:    route.Dataset,
+			min:        plog.SeverityNumberUnspecified,
+			max:        plog.SeverityNumberFatal4,
+			attributes: route.Attributes,
+		}
+		if route.MinSeverity != "" {
+			dr.min = plog.SeverityNumber(4*route.MinSeverity.index() + 1)
+		}
+		if route.MaxSeverity != "" {
+			dr.max = plog.SeverityNumber(4*route.MaxSeverity.index() + 4)
+		}
+		r.routes = append(r.routes, dr)
+	}
+	return r
+}
+
+// dataset returns the dataset of a record of a resource.
+func (r *datasetRouter) dataset(resource pcommon.Map, lr plog.LogRecord) string {
+	severity := recordSeverity(lr)
+	for i := range r.routes {
+		if r.routes[i].matches(resource, lr, severity) {
+			return r.routes[i].dataset
+		}
+	}
+	return r.def
+}
+
+func (dr *datasetRoute) matches(resource pcommon.Map, lr plog.LogRecord, severity plog.SeverityNumber) bool {
+	if severity < dr.min || severity > dr.max {
+		return false
+	}
+	for k, want := range dr.attributes {
+		v, ok := lr.Attributes().Get(k)
+		if !ok {
+			v, ok = resource.Get(k)
+		}
+		if !ok || v.AsString() != want {
+			return false
+		}
+	}
+	return true
+}
+
+// recordSeverity returns the severity number of lr, derived from the
+// severity text when the number is unset.
+func recordSeverity(lr plog.LogRecord) plog.SeverityNumber {
+	if n := lr.SeverityNumber(); n != plog.SeverityNumberUnspecified {
+		return n
+	}
+	text := strings.ToLower(lr.SeverityText())
+	switch {
+	case text == "":
+		return plog.SeverityNumberUnspecified
+	case strings.HasPrefix(text, "trace"):
+		return plog.SeverityNumberTrace
+	case strings.HasPrefix(text, "debug"):
+		return plog.SeverityNumberDebug
+	case strings.HasPrefix(text, "info"), text == "notice":
+		return plog.SeverityNumberInfo
+	case strings.HasPrefix(text, "warn"):
+		return plog.SeverityNumberWarn
+	case strings.HasPrefix(text, "err"):
+		return plog.SeverityNumberError
+	case strings.HasPrefix(text, "fatal"), text == "critical", text == "crit",
+		text == "alert", text == "emergency", text == "emerg", text == "panic":
+		return plog.SeverityNumberFatal
+	}
+	return plog.SeverityNumberUnspecified
+}
+
+// datasetBatch holds the records of a batch that target one dataset.
+type datasetBatch struct {
+	dataset string
+	logs    plog.Logs
+}
+
+// split groups the records of ld by dataset, in order of first appearance.
+// A batch whose records all target the same dataset is returned as is.
+func (r *datasetRouter) split(ld plog.Logs) []datasetBatch {
+	if r == nil {
+		return []datasetBatch{{logs: ld}}
+	}
+
+	var names []string
+	uniform := true
+	for _, rl := range ld.ResourceLogs().All() {
+		for _, sl := range rl.ScopeLogs().All() {
+			for _, lr := range sl.LogRecords().All() {
+				name := r.dataset(rl.Resource().Attributes(), lr)
+				uniform = uniform && (len(names) == 0 || name == names[0])
+				names = append(names, name)
+			}
+		}
+	}
+	if uniform {
+		name := r.def
+		if len(names) > 0 {
+			name = names[0]
+		}
+		return []datasetBatch{{dataset: name, logs: ld}}
+	}
+
+	var batches []datasetBatch
+	index := make(map[string]int)
+	batch := func(name string) plog.Logs {
+		b, ok := index[name]
+		if !ok {
+			b = len(batches)
+			index[name] = b
+			batches = append(batches, datasetBatch{dataset: name, logs: plog.NewLogs()})
+		}
+		return batches[b].logs
+	}
+
+	i := 0
+	for _, rl := range ld.ResourceLogs().All() {
+		// Copies of the resource and the current scope, per dataset.
+		resources := make(map[string]plog.ResourceLogs)
+		for _, sl := range rl.ScopeLogs().All() {
+			scopes := make(map[string]plog.ScopeLogs)
+			for _, lr := range sl.LogRecords().All() {
+				name := names[i]
+				i++
+				scope, ok := scopes[name]
+				if !ok {
+					resource, ok := resources[name]
+					if !ok {
+						resource = batch(name).ResourceLogs().AppendEmpty()
+						rl.Resource().CopyTo(resource.Resource())
+						resource.SetSchemaUrl(rl.SchemaUrl())
+						resources[name] = resource
+					}
+					scope = resource.ScopeLogs().AppendEmpty()
+					sl.Scope().CopyTo(scope.Scope())
+					scope.SetSchemaUrl(sl.SchemaUrl())
+					scopes[name] = scope
+				}
+				lr.CopyTo(scope.LogRecords().AppendEmpty())
+			}
+		}
+	}
+	return batches
+}
+
+type datasetKey struct{}
+
+// withDataset returns ctx targeting dataset; empty leaves the platform's
+// default dataset.
+func withDataset(ctx context.Context, dataset string) context.Context {
+	if dataset == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, datasetKey{}, dataset)
+}
+
+// datasetFromContext returns the dataset set by withDataset.
+func datasetFromContext(ctx context.Context) string {
+	dataset, _ := ctx.Value(datasetKey{}).(string)
+	return dataset
+}
@@ -0,0 +1,233 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoexporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/deadletter"
+)
+
+// errRetryBudgetExhausted fails a send the retry budget cannot pay a retry
+// for. Unlike other permanent errors it says nothing about the batch, which
+// is written to the dead letter spool.
+var errRetryBudgetExhausted = errors.New("retry budget exhausted")
+
+// deadLetter writes the batches whose retries were exhausted to the spool.
+// The exporter helper drops such batches in the sending queue, behind the
+// retries, so with a spool the retries run in an inner exporter helper
+// without a queue, whose failures the queue of the outer one hands to
+// deadLetter first.
+type deadLetter struct {
+	// spool is set by start.
+	spool *deadletter.Spool
+
+	// stopping is set once the shutdown started. Batches failing from then
+	// on were interrupted rather than given up on: a persistent queue keeps
+	// them and shutdown_spill covers an in-memory one.
+	stopping atomic.Bool
+}
+
+// written returns the error of a batch written to the spool.
+func (d *deadLetter) written(err error) error {
+	return fmt.Errorf("batch written to the dead letter spool: %w", err)
+}
+
+// gaveUp reports whether err, returned by the inner exporter helper for a
+// batch, means its retries were exhausted.
+func (d *deadLetter) gaveUp(ctx context.Context, err error) bool {
+	if err == nil || d.stopping.Load() || ctx.Err() != nil {
+		return false
+	}
+	return !consumererror.IsPermanent(err) || errors.Is(err, errRetryBudgetExhausted)
+}
+
+// traces writes td, or the part of it the error carries, to the spool when
+// the retries were exhausted.
+func (d *deadLetter) traces(ctx context.Context, td ptrace.Traces, err error) error {
+	if !d.gaveUp(ctx, err) {
+		return err
+	}
+	var partial consumererror.Traces
+	if errors.As(err, &partial) {
+		td = partial.Data()
+	}
+	if werr := d.spool.Traces(ctx, td, err); werr != nil {
+		return errors.Join(err, werr)
+	}
+	return d.written(err)
+}
+
+// metrics writes md, or the part of it the error carries, to the spool when
+// the retries were exhausted.
+func (d *deadLetter) metrics(ctx context.Context, md pmetric.Metrics, err error) error {
+	if !d.gaveUp(ctx, err) {
+		return err
+	}
+	var partial consumererror.Metrics
+	if errors.As(err, &partial) {
+		md = partial.Data()
+	}
+	if werr := d.spool.Metrics(ctx, md, err); werr != nil {
+		return errors.Join(err, werr)
+	}
+	return d.written(err)
+}
+
+// logs writes ld, or the part of it the error carries, to the spool when the
+// retries were exhausted.
+func (d *deadLetter) logs(ctx context.Context, ld plog.Logs, err error) error {
+	if !d.gaveUp(ctx, err) {
+		return err
+	}
+	var partial consumererror.Logs
+	if errors.As(err, &partial) {
+		ld = partial.Data()
+	}
+	if werr := d.spool.Logs(ctx, ld, err); werr != nil {
+		return errors.Join(err, werr)
+	}
+	return d.written(err)
+}
+
+// retryStopper stops the retries of the inner exporter helper before the
+// outer one drains its queue, as the exporter helper does on its own.
+type retryStopper struct {
+	component.Component
+	inner component.Component
+	d     *deadLetter
+}
+
+// Shutdown implements component.Component.
+func (s retryStopper) Shutdown(ctx context.Context) error {
+	s.d.stopping.Store(true)
+	return errors.Join(s.inner.Shutdown(ctx), s.Component.Shutdown(ctx))
+}
+
+type deadLetterTraces struct {
+	retryStopper
+	consumer.Traces
+}
+
+type deadLetterMetrics struct {
+	retryStopper
+	consumer.Metrics
+}
+
+type deadLetterLogs struct {
+	retryStopper
+	consumer.Logs
+}
+
+// innerSettings returns set for the inner exporter helper, whose telemetry
+// would duplicate that of the outer one.
+func innerSettings(set exporter.Settings) exporter.Settings {
+	set.MeterProvider = metricnoop.NewMeterProvider()
+	set.TracerProvider = tracenoop.NewTracerProvider()
+	return set
+}
+
+// innerOptions returns the options of the inner exporter helper.
+func (e *tfoExporter) innerOptions() []exporterhelper.Option {
+	return []exporterhelper.Option{
+		exporterhelper.WithStart(e.start),
+		exporterhelper.WithRetry(e.cfg.RetryConfig),
+	}
+}
+
+// outerOptions returns the options of the outer exporter helper, which
+// starts inner.
+func (e *tfoExporter) outerOptions(inner component.Component) []exporterhelper.Option {
+	return []exporterhelper.Option{
+		exporterhelper.WithStart(inner.Start),
+		exporterhelper.WithShutdown(e.shutdown),
+		exporterhelper.WithQueue(e.cfg.QueueConfig),
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: e.cfg.mutatesData()}),
+	}
+}
+
+// newTraces creates the exporter helper of a traces exporter.
+func (e *tfoExporter) newTraces(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Traces, error) {
+	if !e.cfg.DeadLetter.Enabled() {
+		return exporterhelper.NewTraces(ctx, set, cfg, e.pushTraces, e.options()...)
+	}
+	inner, err := exporterhelper.NewTraces(ctx, innerSettings(set), cfg, e.pushTraces, e.innerOptions()...)
+	if err != nil {
+		return nil, err
+	}
+	d := &deadLetter{}
+	e.deadLetter = d
+	outer, err := exporterhelper.NewTraces(ctx, set, cfg, func(ctx context.Context, td ptrace.Traces) error {
+		return d.traces(ctx, td, inner.ConsumeTraces(ctx, td))
+	}, e.outerOptions(inner)...)
+	if err != nil {
+		return nil, err
+	}
+	return deadLetterTraces{retryStopper{outer, inner, d}, outer}, nil
+}
+
+// newMetrics creates the exporter helper of a metrics exporter.
+func (e *tfoExporter) newMetrics(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Metrics, error) {
+	if !e.cfg.DeadLetter.Enabled() {
+		return exporterhelper.NewMetrics(ctx, set, cfg, e.pushMetrics, e.options()...)
+	}
+	inner, err := exporterhelper.NewMetrics(ctx, innerSettings(set), cfg, e.pushMetrics, e.innerOptions()...)
+	if err != nil {
+		return nil, err
+	}
+	d := &deadLetter{}
+	e.deadLetter = d
+	outer, err := exporterhelper.NewMetrics(ctx, set, cfg, func(ctx context.Context, md pmetric.Metrics) error {
+		return d.metrics(ctx, md, inner.ConsumeMetrics(ctx, md))
+	}, e.outerOptions(inner)...)
+	if err != nil {
+		return nil, err
+	}
+	return deadLetterMetrics{retryStopper{outer, inner, d}, outer}, nil
+}
+
+// newLogs creates the exporter helper of a logs exporter.
+func (e *tfoExporter) newLogs(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Logs, error) {
+	if !e.cfg.DeadLetter.Enabled() {
+		return exporterhelper.NewLogs(ctx, set, cfg, e.pushLogs, e.options()...)
+	}
+	inner, err := exporterhelper.NewLogs(ctx, innerSettings(set), cfg, e.pushLogs, e.innerOptions()...)
+	if err != nil {
+		return nil, err
+	}
+	d := &deadLetter{}
+	e.deadLetter = d
+	outer, err := exporterhelper.NewLogs(ctx, set, cfg, func(ctx context.Context, ld plog.Logs) error {
+		return d.logs(ctx, ld, inner.ConsumeLogs(ctx, ld))
+	}, e.outerOptions(inner)...)
+	if err != nil {
+		return nil, err
+	}
+	return deadLetterLogs{retryStopper{outer, inner, d}, outer}, nil
+}
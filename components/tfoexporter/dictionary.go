@@ -0,0 +1,201 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoexporter
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"go.uber.org/zap"
+)
+
+const (
+	// dictionaryRetryDelay is how long a trainer waits before collecting
+	// new samples after a dictionary could not be built or published.
+	dictionaryRetryDelay = time.Minute
+
+	// minDictionaryID is the smallest dictionary ID outside the range
+	// reserved by the zstd format.
+	minDictionaryID = 1 << 15
+)
+
+// allBytes holds every byte value once. It is trained on alongside the
+// samples so the literal table of a dictionary can encode any byte, and so
+// payloads that the history matches completely still leave literals to
+// build the table from.
+var allBytes = func() []byte {
+	b := make([]byte, 256)
+	for i := range b {
+		b[i] = byte(i)
+	}
+	return b
+}()
+
+// dictionaryTrainer collects recent payloads, builds a zstd dictionary
+// from them, publishes it to the backend and then switches the compressor
+// to it.
+type dictionaryTrainer struct {
+	e   *tfoExporter
+	c   *compressor
+	cfg DictionaryConfig
+
+	// collecting is set while samples are wanted; ready is signalled once
+	// cfg.Samples were collected.
+	collecting atomic.Bool
+	mu         sync.Mutex
+	samples    [][]byte
+	ready      chan struct{}
+
+	cancel context.CancelFunc
+	done   sync.WaitGroup
+}
+
+// startDictionaryTrainer starts training dictionaries for c.
+func (e *tfoExporter) startDictionaryTrainer(c *compressor, cfg DictionaryConfig) {
+	t := &dictionaryTrainer{
+		e:     e,
+		c:     c,
+		cfg:   cfg,
+		ready: make(chan struct{}, 1),
+	}
+	t.collecting.Store(true)
+	e.dictionary = t
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	t.cancel = cancel
+	t.done.Add(1)
+	go func() {
+		defer t.done.Done()
+		t.run(runCtx)
+	}()
+}
+
+// record keeps the start of data as a training sample while samples are
+// wanted.
+func (t *dictionaryTrainer) record(data []byte) {
+	if t == nil || !t.collecting.Load() || len(data) == 0 {
+		return
+	}
+	// The start of a payload is what a dictionary helps most with, and
+	// capping samples bounds the memory they hold.
+	sample := append([]byte(nil), data[:min(len(data), int(t.cfg.Size))]...)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.collecting.Load() {
+		return
+	}
+	t.samples = append(t.samples, sample)
+	if len(t.samples) >= t.cfg.Samples {
+		t.collecting.Store(false)
+		t.ready <- struct{}{}
+	}
+}
+
+// run trains a dictionary whenever enough samples were collected. Without
+// a retrain interval it stops after the first published dictionary.
+func (t *dictionaryTrainer) run(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.ready:
+		}
+
+		t.mu.Lock()
+		samples := t.samples
+		t.samples = nil
+		t.mu.Unlock()
+
+		delay := t.cfg.RetrainInterval
+		if err := t.train(ctx, samples); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			t.e.logger.Warn("Zstd dictionary training failed; retrying with new samples",
+				zap.Duration("retry_in", dictionaryRetryDelay),
+				zap.Error(err))
+			delay = dictionaryRetryDelay
+		}
+		if delay <= 0 {
+			return
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		t.collecting.Store(true)
+	}
+}
+
+// train builds a dictionary from samples, publishes it and switches the
+// compressor to it.
+func (t *dictionaryTrainer) train(ctx context.Context, samples [][]byte) error {
+	// The history is the most recent payload content, newest last, where
+	// the encoder finds matches at the shortest offsets.
+	size, first, total := int(t.cfg.Size), len(samples), 0
+	for first > 0 && total < size {
+		first--
+		total += len(samples[first])
+	}
+	history := bytes.Join(samples[first:], nil)
+	history = history[max(len(history)-size, 0):]
+
+	id := minDictionaryID + rand.Uint32N(1<<31-minDictionaryID)
+	dict, err := zstd.BuildDict(zstd.BuildDictOptions{
+		ID:       id,
+		Contents: append(samples, allBytes),
+		History:  history,
+		Offsets:  [3]int{1, 4, 8},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build dictionary: %w", err)
+	}
+
+	endpoint := t.e.cfg.URL(t.cfg.Endpoint)
+	if _, err := t.e.post(ctx, endpoint, dict, contentTypeDictionary, ""); err != nil {
+		return fmt.Errorf("failed to publish dictionary to %s: %w", endpoint, err)
+	}
+	if err := t.c.useDictionary(dict); err != nil {
+		return fmt.Errorf("failed to load dictionary: %w", err)
+	}
+
+	t.e.logger.Info("Zstd dictionary published",
+		zap.String("signal", t.e.signal),
+		zap.Uint32("dictionary_id", id),
+		zap.Int("size", len(dict)),
+		zap.Int("samples", len(samples)),
+	)
+	return nil
+}
+
+// shutdown stops training.
+func (t *dictionaryTrainer) shutdown() {
+	if t == nil {
+		return
+	}
+	t.cancel()
+	t.done.Wait()
+}
@@ -20,7 +20,61 @@
 //   - Automatic injection of TFO authentication headers
 //   - Support for both self-hosted and cloud SaaS endpoints
 //   - v2 API endpoint support
-//   - Integration with tfoauth and tfoidentity extensions
+//   - Integration with tfoauth, tfoidentity, tfoclock and tfomaintenance
+//     extensions; startup fails when a referenced tfoauth or tfoidentity
+//     extension is missing unless allow_anonymous is set
+//   - Exports held while a tfomaintenance extension pauses them, with the
+//     bounded sending queue buffering received telemetry
+//   - Headers and API key overridden at runtime through a tfooverrides
+//     extension, without a configuration reload
+//   - Per-resource API key selection from the credential profiles of the
+//     tfoauth extension, splitting batches by profile and counting the
+//     records exported per profile
+//   - Requests rejected with HTTP 401 are sent again with the secondary
+//     API key of the tfoauth extension, which then replaces the primary
+//     key for every exporter using the extension
+//   - Data residency policy blocking records tagged for other regions
+//   - Resource and record attribute allowlists and denylists applied on a
+//     copy of each batch, leaving other exporters untouched
+//   - Routing of log records into TFO v2 datasets by severity range and
+//     attribute values, splitting batches so that each request carries
+//     one X-TelemetryFlow-Dataset header
+//   - Adaptive (AIMD) export concurrency driven by backend latency and
+//     throttling
+//   - Retry budget shared with other exporters to the same host, bounding
+//     retry traffic during a backend brownout
+//   - Error budget that drops the exporter's batches once sends have
+//     failed for longer than the budget, so the rest of the pipeline
+//     fan-out keeps flowing, and re-enables it when a background probe
+//     succeeds
+//   - Protobuf or OTLP/JSON encoding, splitting batches that exceed
+//     max_request_size once encoded
+//   - Per-signal compression overriding the top-level compression, with
+//     gzip and zstd levels, the zstd window and an optional zstd dictionary
+//     trained on recent payloads and published to the backend before use
+//   - Optional warm-up check of connectivity and credentials at startup,
+//     failing, degrading or ignoring per policy
+//   - Optional discovery of the backend limits from /v2/capabilities at
+//     startup and periodically, lowering max_request_size and switching
+//     encoding when the configured values exceed them
+//   - Dry-run mode that encodes, splits and compresses batches and counts
+//     the requests, records and bytes that would have been sent, without
+//     contacting the backend
+//   - Optional spill of the sending queue on shutdown: after drain_timeout,
+//     or when a batch fails while the queue drains, the remaining batches
+//     are appended to a local file as OTLP JSON lines instead of being
+//     dropped (tfo_exporter_shutdown_spilled counts spilled records).
+//     Batches waiting in a retry back-off when the shutdown starts are
+//     still dropped by the exporter helper
+//   - Optional dead letter spool: batches whose retries were exhausted, or
+//     that the retry budget refused to retry, are written with the
+//     exporter, the last error and the time to dead_letter::directory as
+//     OTLP protobuf instead of being dropped, keeping the directory within
+//     max_size by removing the oldest batches. "tfo-collector deadletter
+//     replay" sends them to an OTLP/HTTP receiver. Needs a sending_queue
+//   - Payload previews started through the admin API of the tfosupport
+//     extension, recording the next requests as OTLP JSON once all
+//     processors ran and before compression, including in dry-run mode
 //
 // Configuration example:
 //
@@ -28,9 +82,64 @@
 //	  tfo:
 //	    endpoint: "https://api.telemetryflow.id"
 //	    use_v2_api: true
+//	    encoding: json
+//	    max_request_size: 4MiB
+//	    compression: gzip
+//	    signal_compression:
+//	      metrics:
+//	        type: zstd
+//	        level: 19
+//	        window_size: 256KiB
+//	        dictionary:
+//	          enabled: true
+//	          retrain_interval: 24h
+//	    datasets:
+//	      default: application
+//	      routes:
+//	        - dataset: compliance
+//	          attributes:
+//	            log.type: audit
+//	        - dataset: errors
+//	          min_severity: error
 //	    auth:
 //	      extension: tfoauth
 //	    collector_identity: tfoidentity
+//	    clock_drift: tfoclock
+//	    maintenance: tfomaintenance
+//	    overrides: tfooverrides
 //	    retry_on_failure:
 //	      enabled: true
+//	    sending_queue:
+//	      enabled: true
+//	      num_consumers: 16
+//	    adaptive_concurrency:
+//	      enabled: true
+//	      min_concurrency: 2
+//	      max_concurrency: 16
+//	      latency_threshold: 2s
+//	    retry_budget:
+//	      enabled: true
+//	      ratio: 0.1
+//	    error_budget:
+//	      enabled: true
+//	      budget: 5m
+//	      probe_interval: 30s
+//	    warmup:
+//	      enabled: true
+//	      policy: fail
+//	      timeout: 10s
+//	    capabilities:
+//	      enabled: true
+//	      refresh_interval: 5m
+//	    shutdown_spill:
+//	      path: /var/lib/tfo-collector/spill/tfo.jsonl
+//	      drain_timeout: 5s
+//	    dead_letter:
+//	      directory: /var/lib/tfo-collector/dead-letter
+//	      max_size: 1GiB
+//	    attributes:
+//	      resource:
+//	        include: [service.*, host.name]
+//	      record:
+//	        exclude: [http.request.header.*]
 package tfoexporter // import "github.com/telemetryflow/telemetryflow-collector/components/tfoexporter"
@@ -0,0 +1,88 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tfoexporter
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+// dryRunStats counts what a dry-run exporter would have sent. A nil
+// *dryRunStats records nothing.
+type dryRunStats struct {
+	requests metric.Int64Counter
+	records  metric.Int64Counter
+	bytes    metric.Int64Counter
+
+	attrs      metric.MeasurementOption
+	encoded    metric.MeasurementOption
+	compressed metric.MeasurementOption
+}
+
+// newDryRunStats creates the dry-run counters on set.MeterProvider.
+func newDryRunStats(set component.TelemetrySettings, labels selfmetrics.Labels) (*dryRunStats, error) {
+	s := &dryRunStats{
+		attrs:      labels.Option(),
+		encoded:    labels.Option(attribute.String("stage", "encoded")),
+		compressed: labels.Option(attribute.String("stage", "compressed")),
+	}
+	if set.MeterProvider == nil {
+		return s, nil
+	}
+	meter := set.MeterProvider.Meter(scopeName)
+	var err error
+	if s.requests, err = meter.Int64Counter(selfmetrics.ExporterDryRunRequests,
+		metric.WithDescription("Number of requests built but not sent in dry-run mode."),
+		metric.WithUnit("{request}")); err != nil {
+		return nil, fmt.Errorf("failed to create dry-run stats: %w", err)
+	}
+	if s.records, err = meter.Int64Counter(selfmetrics.ExporterDryRunRecords,
+		metric.WithDescription("Number of records that would have been exported in dry-run mode."),
+		metric.WithUnit("{record}")); err != nil {
+		return nil, fmt.Errorf("failed to create dry-run stats: %w", err)
+	}
+	if s.bytes, err = meter.Int64Counter(selfmetrics.ExporterDryRunBytes,
+		metric.WithDescription("Request body bytes that would have been sent in dry-run mode."),
+		metric.WithUnit("By")); err != nil {
+		return nil, fmt.Errorf("failed to create dry-run stats: %w", err)
+	}
+	return s, nil
+}
+
+// recordRequest counts a request whose body was encoded bytes long before
+// and compressed bytes long after compression.
+func (s *dryRunStats) recordRequest(ctx context.Context, encoded, compressed int) {
+	if s == nil || s.requests == nil {
+		return
+	}
+	s.requests.Add(ctx, 1, s.attrs)
+	s.bytes.Add(ctx, int64(encoded), s.encoded)
+	s.bytes.Add(ctx, int64(compressed), s.compressed)
+}
+
+// recordRecords counts n records of an exported batch.
+func (s *dryRunStats) recordRecords(ctx context.Context, n int) {
+	if s == nil || s.records == nil || n == 0 {
+		return
+	}
+	s.records.Add(ctx, int64(n), s.attrs)
+}
@@ -0,0 +1,73 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoexporter
+
+import (
+	"fmt"
+)
+
+// EncodingType is the wire format of exported OTLP payloads.
+type EncodingType string
+
+const (
+	// EncodingProto sends binary protobuf (application/x-protobuf).
+	EncodingProto EncodingType = "proto"
+
+	// EncodingJSON sends the OTLP/JSON mapping (application/json), with
+	// trace and span IDs as hex strings. JSON payloads are several times
+	// larger than protobuf, so pair it with max_request_size when the
+	// gateway limits request bodies.
+	EncodingJSON EncodingType = "json"
+)
+
+// UnmarshalText rejects unknown encodings at configuration load.
+func (e *EncodingType) UnmarshalText(text []byte) error {
+	v := EncodingType(text)
+	if err := v.validate(); err != nil {
+		return err
+	}
+	*e = v
+	return nil
+}
+
+func (e EncodingType) validate() error {
+	switch e {
+	case EncodingProto, EncodingJSON:
+		return nil
+	}
+	return fmt.Errorf("invalid encoding %q: must be %q or %q", string(e), EncodingProto, EncodingJSON)
+}
+
+// ContentType returns the HTTP Content-Type of the encoding.
+func (e EncodingType) ContentType() string {
+	if e == EncodingJSON {
+		return "application/json"
+	}
+	return "application/x-protobuf"
+}
+
+// otlpRequest is implemented by the pdata OTLP export requests.
+type otlpRequest interface {
+	MarshalProto() ([]byte, error)
+	MarshalJSON() ([]byte, error)
+}
+
+// marshal encodes req in the encoding.
+func (e EncodingType) marshal(req otlpRequest) ([]byte, error) {
+	if e == EncodingJSON {
+		return req.MarshalJSON()
+	}
+	return req.MarshalProto()
+}
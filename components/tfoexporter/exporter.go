@@ -16,21 +16,42 @@ package tfoexporter
 
 import (
 	"bytes"
+	"cmp"
 	"context"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
+	"strconv"
+	"sync"
 	"sync/atomic"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/consumer/consumererror"
 	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
 	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/pipeline"
 	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/adaptive"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/attrfilter"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/deadletter"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/errorbudget"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/payloadpreview"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/requestid"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/residency"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/retrybudget"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/watchdog"
 )
 
 const (
@@ -38,6 +59,17 @@ const (
 	headerKeyID       = "X-TelemetryFlow-Key-ID"
 	headerKeySecret   = "X-TelemetryFlow-Key-Secret"
 	headerCollectorID = "X-TelemetryFlow-Collector-ID"
+
+	// headerClockDrift carries the local clock drift (local minus reference)
+	// in milliseconds.
+	headerClockDrift = "X-TelemetryFlow-Clock-Drift-Ms"
+)
+
+// Signals an exporter instance can be created for.
+const (
+	signalTraces  = "traces"
+	signalMetrics = "metrics"
+	signalLogs    = "logs"
 )
 
 // tfoExporter is the TFO Platform exporter with auto-auth injection.
@@ -45,12 +77,95 @@ type tfoExporter struct {
 	cfg      *Config
 	settings *exporter.Settings
 	logger   *zap.Logger
+
+	// signal is the signal the instance exports; host is set by start.
+	signal string
+	host   component.Host
+
+	// client and abortCtx are replaced by the watchdog on restart.
+	clientMu sync.RWMutex
 	client   *http.Client
+	abortCtx context.Context
+	abort    context.CancelFunc
+
+	// stopping is canceled when the shutdown starts, before the sending
+	// queue is flushed, and ends maintenance waits.
+	stopping context.Context
+	stop     context.CancelFunc
+
+	// Watchdog
+	watchdog  *watchdog.Watchdog
+	heartbeat *watchdog.Heartbeat
+
+	// Adaptive concurrency limiter (nil when disabled)
+	limiter *adaptive.Limiter
+
+	// Retry budget shared with other exporters to the same host (nil when
+	// disabled)
+	budget *retrybudget.Budget
+
+	// Error budget that takes the exporter out of the fan-out (nil when
+	// disabled)
+	errorBudget *errorbudget.Guard
+
+	// Auth credentials (resolved from config or extension; replaced when
+	// the extension rotates to its secondary key)
+	apiKey      atomic.Pointer[credentials]
+	collectorID string
+
+	// Key rotation (resolved from extension)
+	rotator KeyRotator
+
+	// Credential profiles selected per resource (nil when the auth
+	// extension has none)
+	profiles *profileSelector
 
-	// Auth credentials (resolved from config or extension)
-	apiKeyID     string
-	apiKeySecret string
-	collectorID  string
+	// Clock drift source (resolved from extension)
+	clockDrift ClockDriftProvider
+
+	// Maintenance mode (resolved from extension)
+	maintenance MaintenanceProvider
+
+	// Runtime overrides of headers and credentials (resolved from
+	// extension)
+	overrides OverridesProvider
+
+	// Residency policy (nil when disabled)
+	residency *residency.Policy
+
+	// Attribute filter (nil when disabled)
+	attributes *attrfilter.Filter
+
+	// Dataset routing of log records (nil when disabled or not exporting
+	// logs)
+	datasets *datasetRouter
+
+	// Signal compression (nil when the top-level compression applies) and
+	// its dictionary trainer (nil when disabled)
+	compressor *compressor
+	dictionary *dictionaryTrainer
+
+	// degraded is set while a failed warm-up check is reported on the
+	// health endpoint.
+	degraded atomic.Bool
+
+	// effective holds the export parameters narrowed to the backend
+	// capabilities (nil until discovered); discovery refreshes them.
+	effective atomic.Pointer[exportParams]
+	discovery *discovery
+
+	// Stats of the requests built in dry-run mode (nil when disabled)
+	dryRun *dryRunStats
+
+	// Spill target of the batches left on shutdown (nil when disabled)
+	spill *shutdownSpill
+
+	// Spool of the batches whose retries were exhausted (nil when
+	// disabled)
+	deadLetter *deadLetter
+
+	// Payload preview shared with the admin API under the exporter ID
+	preview *payloadpreview.Preview
 
 	// Metrics
 	tracesExported  atomic.Int64
@@ -69,69 +184,275 @@ func newTFOExporter(cfg *Config, set *exporter.Settings) (*tfoExporter, error) {
 	if set.Logger == nil {
 		return nil, fmt.Errorf("tfoexporter settings.Logger cannot be nil")
 	}
+	stopping, stop := context.WithCancel(context.Background())
 	return &tfoExporter{
 		cfg:      cfg,
 		settings: set,
 		logger:   set.Logger,
+		stopping: stopping,
+		stop:     stop,
+		preview:  payloadpreview.For(set.ID.String()),
 	}, nil
 }
 
 // start initializes the exporter.
 func (e *tfoExporter) start(ctx context.Context, host component.Host) error {
-	// Create HTTP client using the new API
-	httpClient, err := e.cfg.ClientConfig.ToClient(ctx, host.GetExtensions(), e.settings.TelemetrySettings)
+	e.host = host
+
+	// Overrides decide how the client applies configured headers
+	if err := e.resolveOverrides(host); err != nil {
+		return err
+	}
+
+	// Create HTTP client from the shared client settings
+	httpClient, err := e.newClient(ctx, host)
 	if err != nil {
 		return fmt.Errorf("failed to create HTTP client: %w", err)
 	}
+	e.clientMu.Lock()
 	e.client = httpClient
+	e.abortCtx, e.abort = context.WithCancel(context.Background())
+	e.clientMu.Unlock()
 
-	// Resolve authentication credentials
-	if e.cfg.Auth != nil {
-		if e.cfg.Auth.Extension.String() != "" {
-			// Get credentials from tfoauth extension
-			ext, ok := host.GetExtensions()[e.cfg.Auth.Extension]
-			if !ok {
-				return fmt.Errorf("tfoauth extension %q not found", e.cfg.Auth.Extension)
-			}
+	// Resolve authentication credentials and collector identity
+	if err := e.resolveAuth(host); err != nil {
+		return err
+	}
+	if err := e.resolveIdentity(host); err != nil {
+		return err
+	}
 
-			// Try to get credentials from extension
-			if authProvider, ok := ext.(AuthProvider); ok {
-				e.apiKeyID = authProvider.GetAPIKeyID()
-				e.apiKeySecret = authProvider.GetAPIKeySecret()
-			}
-		} else {
-			// Use direct credentials from config
-			e.apiKeyID = string(e.cfg.Auth.APIKeyID)
-			e.apiKeySecret = string(e.cfg.Auth.APIKeySecret)
+	if e.signal == signalLogs {
+		e.datasets = newDatasetRouter(e.cfg.Datasets)
+	}
+
+	sc := e.cfg.SignalCompression.signal(e.signal)
+	compressor, err := newCompressor(sc)
+	if err != nil {
+		return fmt.Errorf("failed to create %s compressor: %w", e.signal, err)
+	}
+	e.compressor = compressor
+	if sc.Dictionary.Enabled {
+		e.startDictionaryTrainer(compressor, sc.Dictionary)
+	}
+
+	if e.cfg.Watchdog.Enabled {
+		if err := e.startWatchdog(ctx, host); err != nil {
+			return err
+		}
+	}
+
+	if e.cfg.Concurrency.Enabled {
+		limiter, err := adaptive.New(e.cfg.Concurrency, e.settings.TelemetrySettings, e.labels())
+		if err != nil {
+			return fmt.Errorf("failed to create adaptive concurrency limiter: %w", err)
+		}
+		e.limiter = limiter
+	}
+
+	if e.cfg.RetryBudget.Enabled {
+		budget, err := retrybudget.New(e.cfg.RetryBudget, e.settings.TelemetrySettings, e.cfg.Host(), e.labels())
+		if err != nil {
+			return fmt.Errorf("failed to create retry budget: %w", err)
+		}
+		e.budget = budget
+	}
+
+	if e.cfg.ErrorBudget.Enabled {
+		if err := e.startErrorBudget(ctx, host); err != nil {
+			return err
+		}
+	}
+
+	// Build residency policy
+	if e.cfg.Residency.Enabled {
+		policy, err := residency.NewPolicy(e.cfg.Residency, e.settings.TelemetrySettings, e.labels())
+		if err != nil {
+			return fmt.Errorf("failed to create residency policy: %w", err)
+		}
+		e.residency = policy
+	}
+
+	// Build attribute filter
+	if e.cfg.Attributes.Enabled() {
+		filter, err := attrfilter.NewFilter(e.cfg.Attributes, e.settings.TelemetrySettings, e.labels())
+		if err != nil {
+			return fmt.Errorf("failed to create attribute filter: %w", err)
 		}
+		e.attributes = filter
 	}
 
-	// Resolve collector identity
-	if e.cfg.CollectorIdentity.String() != "" {
-		ext, ok := host.GetExtensions()[e.cfg.CollectorIdentity]
+	// Resolve clock drift source
+	if e.cfg.ClockDrift.String() != "" {
+		ext, ok := host.GetExtensions()[e.cfg.ClockDrift]
 		if !ok {
-			e.logger.Warn("tfoidentity extension not found, collector ID will not be set",
-				zap.String("extension", e.cfg.CollectorIdentity.String()),
-			)
-		} else {
-			if identityProvider, ok := ext.(IdentityProvider); ok {
-				e.collectorID = identityProvider.GetCollectorID()
-			}
+			return fmt.Errorf("tfoclock extension %q not found", e.cfg.ClockDrift)
+		}
+		provider, ok := ext.(ClockDriftProvider)
+		if !ok {
+			return fmt.Errorf("extension %q does not provide clock drift", e.cfg.ClockDrift)
+		}
+		e.clockDrift = provider
+	}
+
+	// Resolve maintenance mode
+	if e.cfg.Maintenance.String() != "" {
+		ext, ok := host.GetExtensions()[e.cfg.Maintenance]
+		if !ok {
+			return fmt.Errorf("tfomaintenance extension %q not found", e.cfg.Maintenance)
+		}
+		provider, ok := ext.(MaintenanceProvider)
+		if !ok {
+			return fmt.Errorf("extension %q does not provide maintenance mode", e.cfg.Maintenance)
+		}
+		e.maintenance = provider
+	}
+
+	if e.cfg.DryRun {
+		stats, err := newDryRunStats(e.settings.TelemetrySettings, e.labels())
+		if err != nil {
+			return err
+		}
+		e.dryRun = stats
+		e.logger.Warn("TFO exporter in dry-run mode; telemetry is not sent to the endpoint")
+	}
+
+	if e.cfg.ShutdownSpill.Enabled() {
+		spill, err := newShutdownSpill(e.cfg.ShutdownSpill, e.settings.TelemetrySettings, e.labels())
+		if err != nil {
+			return err
+		}
+		e.spill = spill
+	}
+
+	if e.deadLetter != nil {
+		spool, err := deadletter.New(e.cfg.DeadLetter, e.settings.TelemetrySettings, e.settings.ID, e.pipelineSignal())
+		if err != nil {
+			return err
+		}
+		e.deadLetter.spool = spool
+	}
+
+	// A dry run never contacts the backend
+	if e.cfg.Capabilities.Enabled && !e.cfg.DryRun {
+		e.startDiscovery(ctx)
+	}
+
+	if e.cfg.Warmup.Enabled && !e.cfg.DryRun {
+		if err := e.warmup(ctx, host); err != nil {
+			return err
 		}
 	}
 
 	e.logger.Info("TFO exporter started",
 		zap.String("endpoint", e.cfg.Endpoint),
 		zap.Bool("use_v2_api", e.cfg.UseV2API),
-		zap.Bool("has_auth", e.apiKeyID != ""),
+		zap.Bool("has_auth", e.apiKeyIDOrEmpty() != ""),
 		zap.Bool("has_collector_id", e.collectorID != ""),
+		zap.Bool("dry_run", e.cfg.DryRun),
 	)
 
 	return nil
 }
 
+// labels returns the labels of the exporter's internal metrics.
+func (e *tfoExporter) labels() selfmetrics.Labels {
+	return selfmetrics.Exporter(e.settings.ID, e.pipelineSignal())
+}
+
+// pipelineSignal returns the signal the instance exports.
+func (e *tfoExporter) pipelineSignal() pipeline.Signal {
+	switch e.signal {
+	case signalMetrics:
+		return pipeline.SignalMetrics
+	case signalLogs:
+		return pipeline.SignalLogs
+	}
+	return pipeline.SignalTraces
+}
+
+// startWatchdog registers the exporter's send heartbeat and starts the
+// watchdog. Incidents are surfaced on the health endpoint via component status.
+func (e *tfoExporter) startWatchdog(ctx context.Context, host component.Host) error {
+	wd, err := watchdog.New(e.cfg.Watchdog, e.settings.TelemetrySettings, e.labels(), watchdog.Hooks{
+		OnWedged: func(incident watchdog.Incident) {
+			componentstatus.ReportStatus(host, componentstatus.NewRecoverableErrorEvent(
+				fmt.Errorf("exporter wedged: %d sends pending for %s", incident.Pending, incident.StalledFor)))
+		},
+		OnRecovered: func(string) {
+			componentstatus.ReportStatus(host, componentstatus.NewEvent(componentstatus.StatusOK))
+		},
+	})
+	if err != nil {
+		return err
+	}
+	e.heartbeat = wd.Register("exporter/"+e.settings.ID.String(), func(ctx context.Context) error {
+		return e.restartClient(ctx, host)
+	})
+	e.watchdog = wd
+	wd.Start(ctx)
+	return nil
+}
+
+// startErrorBudget creates and starts the exporter's error budget, probing
+// the backend with the connectivity check. Transitions are surfaced on the
+// health endpoint via component status.
+func (e *tfoExporter) startErrorBudget(ctx context.Context, host component.Host) error {
+	guard, err := errorbudget.New(e.cfg.ErrorBudget, e.settings.TelemetrySettings, e.labels(), e.checkConnectivity, errorbudget.Hooks{
+		OnDisabled: func(t errorbudget.Transition) {
+			componentstatus.ReportStatus(host, componentstatus.NewRecoverableErrorEvent(
+				fmt.Errorf("exporter removed from the fan-out after failing for %s: %w", t.Duration, t.Err)))
+		},
+		OnEnabled: func(errorbudget.Transition) {
+			componentstatus.ReportStatus(host, componentstatus.NewEvent(componentstatus.StatusOK))
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create error budget: %w", err)
+	}
+	e.errorBudget = guard
+	guard.Start(ctx)
+	return nil
+}
+
+// restartClient aborts in-flight sends and replaces the HTTP client so that
+// retries go out on fresh connections.
+func (e *tfoExporter) restartClient(ctx context.Context, host component.Host) error {
+	httpClient, err := e.newClient(ctx, host)
+	if err != nil {
+		return fmt.Errorf("failed to create HTTP client: %w", err)
+	}
+
+	e.clientMu.Lock()
+	old, abort := e.client, e.abort
+	e.client = httpClient
+	e.abortCtx, e.abort = context.WithCancel(context.Background())
+	e.clientMu.Unlock()
+
+	abort()
+	old.CloseIdleConnections()
+
+	e.logger.Warn("TFO exporter HTTP client restarted by watchdog")
+	return nil
+}
+
 // shutdown stops the exporter.
 func (e *tfoExporter) shutdown(ctx context.Context) error {
+	e.stop()
+	if e.watchdog != nil {
+		e.watchdog.Shutdown(ctx)
+	}
+	e.errorBudget.Shutdown(ctx)
+	e.discovery.shutdown()
+	e.dictionary.shutdown()
+	e.compressor.close()
+	e.spill.close()
+	e.clientMu.Lock()
+	if e.abort != nil {
+		e.abort()
+	}
+	e.clientMu.Unlock()
+
 	e.logger.Info("TFO exporter stopped",
 		zap.Int64("traces_exported", e.tracesExported.Load()),
 		zap.Int64("metrics_exported", e.metricsExported.Load()),
@@ -142,21 +463,62 @@ func (e *tfoExporter) shutdown(ctx context.Context) error {
 
 // pushTraces exports traces to the TFO Platform.
 func (e *tfoExporter) pushTraces(ctx context.Context, td ptrace.Traces) error {
-	req := ptraceotlp.NewExportRequestFromTraces(td)
-	data, err := req.MarshalProto()
+	ctx = withBatchID(ctx)
+	ctx, err := e.waitMaintenance(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to marshal traces: %w", err)
-	}
-
-	endpoint := e.cfg.Endpoint + e.cfg.GetTracesEndpoint()
-	if err := e.sendData(ctx, endpoint, data, "application/x-protobuf"); err != nil {
+		if e.spill.isDraining() {
+			return e.spill.traces(ctx, td)
+		}
 		return err
 	}
+	if !e.errorBudget.Enabled() {
+		e.errorBudget.Drop(ctx, td.SpanCount())
+		return nil
+	}
+	if e.residency.ApplyTraces(ctx, td) > 0 && td.SpanCount() == 0 {
+		return nil
+	}
+	e.attributes.ApplyTraces(ctx, td)
+	if e.spill.expired() {
+		return e.spill.traces(ctx, td)
+	}
 
-	e.tracesExported.Add(int64(td.SpanCount()))
+	endpoint := e.cfg.URL(e.cfg.GetTracesEndpoint())
+	p := e.params()
+	encode := func(td ptrace.Traces) ([]byte, error) {
+		return e.marshal(p.encoding, ptraceotlp.NewExportRequestFromTraces(td), signalTraces)
+	}
+	rss := td.ResourceSpans()
+	batches := splitByProfile(e.profiles, td, rss.Len(),
+		func(i int) pcommon.Resource { return rss.At(i).Resource() }, tracesResources(td))
+	unsent, err := sendProfiles(ctx, e, batches, ptrace.Traces.SpanCount, func(ctx context.Context, td ptrace.Traces) ([]ptrace.Traces, error) {
+		return sendSplit(ctx, e, p, endpoint, td, ptrace.Traces.SpanCount, splitTraces, encode)
+	})
+
+	sent := td.SpanCount()
+	for _, u := range unsent {
+		sent -= u.SpanCount()
+	}
+	e.tracesExported.Add(int64(sent))
+	e.dryRun.recordRecords(ctx, sent)
+	if err != nil {
+		if e.spill.isDraining() && !consumererror.IsPermanent(err) {
+			return e.spill.traces(ctx, unsent...)
+		}
+		if sent == 0 {
+			return err
+		}
+		// Part of the batch went out; retry only the rest.
+		rest := ptrace.NewTraces()
+		for _, u := range unsent {
+			u.ResourceSpans().MoveAndAppendTo(rest.ResourceSpans())
+		}
+		return consumererror.NewTraces(err, rest)
+	}
 	e.logger.Debug("Exported traces",
-		zap.Int("span_count", td.SpanCount()),
+		zap.Int("span_count", sent),
 		zap.String("endpoint", endpoint),
+		requestid.Field(ctx),
 	)
 
 	return nil
@@ -164,21 +526,62 @@ func (e *tfoExporter) pushTraces(ctx context.Context, td ptrace.Traces) error {
 
 // pushMetrics exports metrics to the TFO Platform.
 func (e *tfoExporter) pushMetrics(ctx context.Context, md pmetric.Metrics) error {
-	req := pmetricotlp.NewExportRequestFromMetrics(md)
-	data, err := req.MarshalProto()
+	ctx = withBatchID(ctx)
+	ctx, err := e.waitMaintenance(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to marshal metrics: %w", err)
-	}
-
-	endpoint := e.cfg.Endpoint + e.cfg.GetMetricsEndpoint()
-	if err := e.sendData(ctx, endpoint, data, "application/x-protobuf"); err != nil {
+		if e.spill.isDraining() {
+			return e.spill.metrics(ctx, md)
+		}
 		return err
 	}
+	if !e.errorBudget.Enabled() {
+		e.errorBudget.Drop(ctx, md.DataPointCount())
+		return nil
+	}
+	if e.residency.ApplyMetrics(ctx, md) > 0 && md.DataPointCount() == 0 {
+		return nil
+	}
+	e.attributes.ApplyMetrics(ctx, md)
+	if e.spill.expired() {
+		return e.spill.metrics(ctx, md)
+	}
 
-	e.metricsExported.Add(int64(md.DataPointCount()))
+	endpoint := e.cfg.URL(e.cfg.GetMetricsEndpoint())
+	p := e.params()
+	encode := func(md pmetric.Metrics) ([]byte, error) {
+		return e.marshal(p.encoding, pmetricotlp.NewExportRequestFromMetrics(md), signalMetrics)
+	}
+	rms := md.ResourceMetrics()
+	batches := splitByProfile(e.profiles, md, rms.Len(),
+		func(i int) pcommon.Resource { return rms.At(i).Resource() }, metricsResources(md))
+	unsent, err := sendProfiles(ctx, e, batches, pmetric.Metrics.DataPointCount, func(ctx context.Context, md pmetric.Metrics) ([]pmetric.Metrics, error) {
+		return sendSplit(ctx, e, p, endpoint, md, pmetric.Metrics.MetricCount, splitMetrics, encode)
+	})
+
+	sent := md.DataPointCount()
+	for _, u := range unsent {
+		sent -= u.DataPointCount()
+	}
+	e.metricsExported.Add(int64(sent))
+	e.dryRun.recordRecords(ctx, sent)
+	if err != nil {
+		if e.spill.isDraining() && !consumererror.IsPermanent(err) {
+			return e.spill.metrics(ctx, unsent...)
+		}
+		if sent == 0 {
+			return err
+		}
+		// Part of the batch went out; retry only the rest.
+		rest := pmetric.NewMetrics()
+		for _, u := range unsent {
+			u.ResourceMetrics().MoveAndAppendTo(rest.ResourceMetrics())
+		}
+		return consumererror.NewMetrics(err, rest)
+	}
 	e.logger.Debug("Exported metrics",
-		zap.Int("data_point_count", md.DataPointCount()),
+		zap.Int("data_point_count", sent),
 		zap.String("endpoint", endpoint),
+		requestid.Field(ctx),
 	)
 
 	return nil
@@ -186,49 +589,284 @@ func (e *tfoExporter) pushMetrics(ctx context.Context, md pmetric.Metrics) error
 
 // pushLogs exports logs to the TFO Platform.
 func (e *tfoExporter) pushLogs(ctx context.Context, ld plog.Logs) error {
-	req := plogotlp.NewExportRequestFromLogs(ld)
-	data, err := req.MarshalProto()
+	ctx = withBatchID(ctx)
+	ctx, err := e.waitMaintenance(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to marshal logs: %w", err)
+		if e.spill.isDraining() {
+			return e.spill.logs(ctx, ld)
+		}
+		return err
+	}
+	if !e.errorBudget.Enabled() {
+		e.errorBudget.Drop(ctx, ld.LogRecordCount())
+		return nil
+	}
+	if e.residency.ApplyLogs(ctx, ld) > 0 && ld.LogRecordCount() == 0 {
+		return nil
+	}
+	e.attributes.ApplyLogs(ctx, ld)
+	if e.spill.expired() {
+		return e.spill.logs(ctx, ld)
 	}
 
-	endpoint := e.cfg.Endpoint + e.cfg.GetLogsEndpoint()
-	if err := e.sendData(ctx, endpoint, data, "application/x-protobuf"); err != nil {
-		return err
+	endpoint := e.cfg.URL(e.cfg.GetLogsEndpoint())
+	p := e.params()
+	encode := func(ld plog.Logs) ([]byte, error) {
+		return e.marshal(p.encoding, plogotlp.NewExportRequestFromLogs(ld), signalLogs)
 	}
+	rls := ld.ResourceLogs()
+	batches := splitByProfile(e.profiles, ld, rls.Len(),
+		func(i int) pcommon.Resource { return rls.At(i).Resource() }, logsResources(ld))
+	unsent, err := sendProfiles(ctx, e, batches, plog.Logs.LogRecordCount, func(ctx context.Context, ld plog.Logs) ([]plog.Logs, error) {
+		var (
+			unsent []plog.Logs
+			err    error
+		)
+		// Each request targets a single dataset; after a failure the
+		// batches of the remaining datasets are left for the retry.
+		for _, b := range e.datasets.split(ld) {
+			if err != nil {
+				unsent = append(unsent, b.logs)
+				continue
+			}
+			unsent, err = sendSplit(withDataset(ctx, b.dataset), e, p, endpoint, b.logs, plog.Logs.LogRecordCount, splitLogs, encode)
+		}
+		return unsent, err
+	})
 
-	e.logsExported.Add(int64(ld.LogRecordCount()))
+	sent := ld.LogRecordCount()
+	for _, u := range unsent {
+		sent -= u.LogRecordCount()
+	}
+	e.logsExported.Add(int64(sent))
+	e.dryRun.recordRecords(ctx, sent)
+	if err != nil {
+		if e.spill.isDraining() && !consumererror.IsPermanent(err) {
+			return e.spill.logs(ctx, unsent...)
+		}
+		if sent == 0 {
+			return err
+		}
+		// Part of the batch went out; retry only the rest.
+		rest := plog.NewLogs()
+		for _, u := range unsent {
+			u.ResourceLogs().MoveAndAppendTo(rest.ResourceLogs())
+		}
+		return consumererror.NewLogs(err, rest)
+	}
 	e.logger.Debug("Exported logs",
-		zap.Int("log_record_count", ld.LogRecordCount()),
+		zap.Int("log_record_count", sent),
 		zap.String("endpoint", endpoint),
+		requestid.Field(ctx),
 	)
 
 	return nil
 }
 
-// sendData sends data to the TFO Platform with authentication headers.
+// withBatchID returns ctx with a generated request ID unless it already
+// carries the ID of the ingest request, which is lost when a processor or
+// the sending queue merges requests.
+func withBatchID(ctx context.Context) context.Context {
+	if requestid.FromContext(ctx) != "" {
+		return ctx
+	}
+	return requestid.NewContext(ctx, requestid.New())
+}
+
+// marshal encodes req in enc.
+func (e *tfoExporter) marshal(enc EncodingType, req otlpRequest, signal string) ([]byte, error) {
+	data, err := enc.marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s: %w", signal, err)
+	}
+	return data, nil
+}
+
+// sendSplit encodes data and sends it to endpoint, halving every batch whose
+// payload exceeds the maximum request size of p until it fits or cannot be
+// split further. On failure it returns the batches that were not sent.
+func sendSplit[T any](
+	ctx context.Context,
+	e *tfoExporter,
+	p exportParams,
+	endpoint string,
+	data T,
+	count func(T) int,
+	split func(T) (T, T),
+	encode func(T) ([]byte, error),
+) ([]T, error) {
+	payload, err := encode(data)
+	if err != nil {
+		return []T{data}, err
+	}
+	if limit := p.maxRequestSize; limit > 0 && len(payload) > limit && count(data) > 1 {
+		first, second := split(data)
+		if unsent, err := sendSplit(ctx, e, p, endpoint, first, count, split, encode); err != nil {
+			return append(unsent, second), err
+		}
+		return sendSplit(ctx, e, p, endpoint, second, count, split, encode)
+	}
+	if e.preview.Wants(e.signal) {
+		e.recordPreview(endpoint, payload, p.encoding)
+	}
+	if err := e.sendData(ctx, endpoint, payload, p.encoding.ContentType()); err != nil {
+		return []T{data}, err
+	}
+	return nil, nil
+}
+
+// sendData compresses data with the signal compression and sends it to the
+// TFO Platform once the concurrency limiter admits it, and reports the
+// outcome back to the limiter, the retry budget and the error budget. A
+// failure that the retry budget cannot pay a retry for is permanent.
 func (e *tfoExporter) sendData(ctx context.Context, endpoint string, data []byte, contentType string) error {
+	e.dictionary.record(data)
+	body, contentEncoding, err := e.compressor.compress(data)
+	if err != nil {
+		return consumererror.NewPermanent(fmt.Errorf("failed to compress payload: %w", err))
+	}
+	e.dryRun.recordRequest(ctx, len(data), len(body))
+
+	token, err := e.limiter.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+
+	status, err := e.post(ctx, endpoint, body, contentType, contentEncoding)
+	e.errorBudget.Record(err)
+	switch {
+	case err == nil:
+		token.Success()
+	case isCongestion(status, err):
+		token.Dropped()
+	default:
+		token.Ignore()
+	}
+
+	if err == nil {
+		e.budget.Deposit()
+		e.recovered()
+		return nil
+	}
+	if ce := e.logger.Check(zap.DebugLevel, "Export request failed"); ce != nil {
+		ce.Write(
+			zap.String("endpoint", endpoint),
+			zap.Int("status", status),
+			zap.Error(err),
+			requestid.Field(ctx),
+		)
+	}
+	if e.cfg.RetryConfig.Enabled && !e.budget.Withdraw() {
+		return consumererror.NewPermanent(fmt.Errorf("%w: %w", errRetryBudgetExhausted, err))
+	}
+	return err
+}
+
+// isCongestion reports whether a failed send indicates an overloaded
+// backend: throttling, gateway errors or a timeout.
+func isCongestion(status int, err error) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	var netErr net.Error
+	return errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout())
+}
+
+// waitMaintenance holds a batch while exports are paused for maintenance
+// and returns the context to send it with. The batch stays in the sending
+// queue for as long as the pause lasts: the wait ignores the deadline of
+// ctx, and a batch held past it is sent without it, bounded by the request
+// timeout of the HTTP client. The wait ends on shutdown or a watchdog
+// restart; the batch is then written to the spill file while draining, or
+// left to the retry sender.
+func (e *tfoExporter) waitMaintenance(ctx context.Context) (context.Context, error) {
+	if e.maintenance == nil {
+		return ctx, nil
+	}
+	e.clientMu.RLock()
+	abortCtx := e.abortCtx
+	e.clientMu.RUnlock()
+
+	waitCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancel()
+	defer context.AfterFunc(e.stopping, cancel)()
+	if abortCtx != nil {
+		defer context.AfterFunc(abortCtx, cancel)()
+	}
+	if err := e.maintenance.Wait(waitCtx); err != nil {
+		return ctx, fmt.Errorf("exports paused for maintenance: %w", err)
+	}
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return context.WithoutCancel(ctx), nil
+	}
+	return ctx, nil
+}
+
+// post sends data to the TFO Platform with authentication headers and
+// returns the response status code, or zero if no response was received.
+// A non-empty contentEncoding marks data as already compressed, which
+// stops the HTTP client from applying the top-level compression. In
+// dry-run mode nothing is sent and the request succeeds. A request
+// rejected with HTTP 401 is sent again once the auth extension rotated to
+// its secondary API key.
+func (e *tfoExporter) post(ctx context.Context, endpoint string, data []byte, contentType, contentEncoding string) (int, error) {
+	sent := e.apiKey.Load()
+	status, err := e.postOnce(ctx, endpoint, data, contentType, contentEncoding)
+	if status == http.StatusUnauthorized && e.rotate(ctx, sent) {
+		return e.postOnce(ctx, endpoint, data, contentType, contentEncoding)
+	}
+	return status, err
+}
+
+// postOnce sends one request for post.
+func (e *tfoExporter) postOnce(ctx context.Context, endpoint string, data []byte, contentType, contentEncoding string) (int, error) {
+	if e.cfg.DryRun {
+		return http.StatusOK, nil
+	}
+
+	e.heartbeat.Begin()
+	defer e.heartbeat.End()
+
+	e.clientMu.RLock()
+	client, abortCtx := e.client, e.abortCtx
+	e.clientMu.RUnlock()
+
+	// Tie the request to the current client generation so a watchdog
+	// restart aborts it.
+	if abortCtx != nil {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithCancel(ctx)
+		defer cancel()
+		defer context.AfterFunc(abortCtx, cancel)()
+	}
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(data))
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return 0, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Content-Type", contentType)
-
-	// Inject TFO authentication headers
-	if e.apiKeyID != "" {
-		req.Header.Set(headerKeyID, e.apiKeyID)
+	if contentEncoding != "" {
+		req.Header.Set("Content-Encoding", contentEncoding)
 	}
-	if e.apiKeySecret != "" {
-		req.Header.Set(headerKeySecret, e.apiKeySecret)
+	e.setAuthHeaders(req)
+	if id := requestid.FromContext(ctx); id != "" {
+		req.Header.Set(requestid.Header, id)
 	}
-	if e.collectorID != "" {
-		req.Header.Set(headerCollectorID, e.collectorID)
+	if dataset := datasetFromContext(ctx); dataset != "" {
+		req.Header.Set(headerDataset, dataset)
+	}
+	if e.clockDrift != nil {
+		if drift, ok := e.clockDrift.GetClockDrift(); ok {
+			req.Header.Set(headerClockDrift, strconv.FormatInt(drift.Milliseconds(), 10))
+		}
 	}
 
-	resp, err := e.client.Do(req)
+	resp, err := client.Do(req)
 	if err != nil {
-		return fmt.Errorf("failed to send request: %w", err)
+		return 0, fmt.Errorf("failed to send request: %w", err)
 	}
 	defer func() { _ = resp.Body.Close() }()
 
@@ -236,10 +874,66 @@ func (e *tfoExporter) sendData(ctx context.Context, endpoint string, data []byte
 	_, _ = io.Copy(io.Discard, resp.Body)
 
 	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
-		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+		return resp.StatusCode, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
 	}
 
-	return nil
+	return resp.StatusCode, nil
+}
+
+// newClient builds the HTTP client from the shared client settings. With
+// an overrides extension the configured headers are left to
+// setAuthHeaders, so that overridden headers can replace them.
+func (e *tfoExporter) newClient(ctx context.Context, host component.Host) (*http.Client, error) {
+	if e.overrides == nil {
+		return e.cfg.NewClient(ctx, host, e.settings.TelemetrySettings)
+	}
+	cfg := e.cfg.ClientConfig
+	cfg.Headers = nil
+	return cfg.NewClient(ctx, host, e.settings.TelemetrySettings)
+}
+
+// setAuthHeaders injects the TFO authentication and collector identity
+// headers, using the credential profile of the request context if any,
+// and the configured and overridden headers when an overrides extension
+// is set.
+func (e *tfoExporter) setAuthHeaders(req *http.Request) {
+	var keyID, keySecret string
+	if key := e.apiKey.Load(); key != nil {
+		keyID, keySecret = key.keyID, key.keySecret
+	}
+	if e.overrides != nil {
+		id, secret := e.overrides.ExporterCredentials(e.settings.ID)
+		keyID, keySecret = cmp.Or(id, keyID), cmp.Or(secret, keySecret)
+	}
+	if creds := credentialsFromContext(req.Context()); creds != nil {
+		keyID, keySecret = creds.keyID, creds.keySecret
+	}
+	if keyID != "" {
+		req.Header.Set(headerKeyID, keyID)
+	}
+	if keySecret != "" {
+		req.Header.Set(headerKeySecret, keySecret)
+	}
+	if e.collectorID != "" {
+		req.Header.Set(headerCollectorID, e.collectorID)
+	}
+	if e.overrides != nil {
+		e.setHeaders(req)
+	}
+}
+
+// setHeaders sets the configured headers followed by the overridden ones,
+// as the client would for the configured headers alone.
+func (e *tfoExporter) setHeaders(req *http.Request) {
+	for name, value := range e.cfg.Headers.Iter {
+		req.Header.Set(name, string(value))
+	}
+	for name, value := range e.overrides.ExporterHeaders(e.settings.ID) {
+		req.Header.Set(name, value)
+	}
+	if host := req.Header.Get("Host"); host != "" {
+		req.Host = host
+	}
 }
 
 // AuthProvider is an interface for extensions that provide TFO authentication.
@@ -248,7 +942,40 @@ type AuthProvider interface {
 	GetAPIKeySecret() string
 }
 
+// KeyRotator is an interface for auth extensions that switch to a
+// secondary API key when the TFO Platform rejects the primary one.
+type KeyRotator interface {
+	// RotateAPIKey returns the key to send with after rejectedKeyID was
+	// rejected, or ok false when there is no other key to try.
+	RotateAPIKey(ctx context.Context, rejectedKeyID string) (keyID, keySecret string, ok bool)
+}
+
 // IdentityProvider is an interface for extensions that provide collector identity.
 type IdentityProvider interface {
 	GetCollectorID() string
 }
+
+// ClockDriftProvider is an interface for extensions that measure local clock drift.
+type ClockDriftProvider interface {
+	GetClockDrift() (time.Duration, bool)
+}
+
+// OverridesProvider is an interface for extensions that override exporter
+// settings at runtime.
+type OverridesProvider interface {
+	// ExporterHeaders returns the headers set on the requests of exporter
+	// id. The map must not be modified.
+	ExporterHeaders(id component.ID) map[string]string
+
+	// ExporterCredentials returns the API key replacing the configured one
+	// of exporter id. Empty values keep the configured ones.
+	ExporterCredentials(id component.ID) (keyID, keySecret string)
+}
+
+// MaintenanceProvider is an interface for extensions that pause exports
+// for maintenance.
+type MaintenanceProvider interface {
+	// Wait blocks while exports are paused, until maintenance ends or ctx
+	// is done, and returns ctx.Err() in the latter case.
+	Wait(ctx context.Context) error
+}
@@ -0,0 +1,139 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoexporter
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+)
+
+// resolveAuth sets the API key credentials from the tfoauth extension or
+// from the auth settings.
+func (e *tfoExporter) resolveAuth(host component.Host) error {
+	if e.cfg.Auth == nil {
+		return nil
+	}
+	id := e.cfg.Auth.Extension
+	if id.String() == "" {
+		e.apiKey.Store(&credentials{keyID: string(e.cfg.Auth.APIKeyID), keySecret: string(e.cfg.Auth.APIKeySecret)})
+		return nil
+	}
+
+	ext := host.GetExtensions()[id]
+	if ext == nil {
+		return e.anonymous(fmt.Errorf("tfoauth extension %q not found", id))
+	}
+	provider, ok := ext.(AuthProvider)
+	if !ok {
+		return e.anonymous(fmt.Errorf("extension %q does not provide TFO credentials", id))
+	}
+	// A tfoauth extension without API key runs in passthrough mode on
+	// purpose, so empty credentials are not an error.
+	e.apiKey.Store(&credentials{keyID: provider.GetAPIKeyID(), keySecret: provider.GetAPIKeySecret()})
+	if rotator, ok := ext.(KeyRotator); ok {
+		e.rotator = rotator
+	}
+
+	if profiles, ok := ext.(ProfileProvider); ok {
+		selector, err := newProfileSelector(profiles, e.settings.TelemetrySettings, e.labels())
+		if err != nil {
+			return err
+		}
+		e.profiles = selector
+	}
+	return nil
+}
+
+// apiKeyIDOrEmpty returns the API Key ID of the top-level credentials.
+func (e *tfoExporter) apiKeyIDOrEmpty() string {
+	if key := e.apiKey.Load(); key != nil {
+		return key.keyID
+	}
+	return ""
+}
+
+// rotate asks the auth extension for another API key after the TFO
+// Platform rejected the top-level key sent, and reports whether the
+// request should be sent again with it. Requests under a credential
+// profile or overridden credentials are not rotated.
+func (e *tfoExporter) rotate(ctx context.Context, sent *credentials) bool {
+	if e.rotator == nil || sent == nil || credentialsFromContext(ctx) != nil {
+		return false
+	}
+	if e.overrides != nil {
+		if id, _ := e.overrides.ExporterCredentials(e.settings.ID); id != "" {
+			return false
+		}
+	}
+	keyID, keySecret, ok := e.rotator.RotateAPIKey(ctx, sent.keyID)
+	if !ok || keyID == sent.keyID {
+		return false
+	}
+	e.apiKey.CompareAndSwap(sent, &credentials{keyID: keyID, keySecret: keySecret})
+	return true
+}
+
+// resolveIdentity sets the collector ID from the tfoidentity extension.
+func (e *tfoExporter) resolveIdentity(host component.Host) error {
+	id := e.cfg.CollectorIdentity
+	if id.String() == "" {
+		return nil
+	}
+
+	ext := host.GetExtensions()[id]
+	if ext == nil {
+		return e.anonymous(fmt.Errorf("tfoidentity extension %q not found", id))
+	}
+	provider, ok := ext.(IdentityProvider)
+	if !ok {
+		return e.anonymous(fmt.Errorf("extension %q does not provide a collector identity", id))
+	}
+	e.collectorID = provider.GetCollectorID()
+	return nil
+}
+
+// resolveOverrides sets the runtime overrides from the tfooverrides
+// extension.
+func (e *tfoExporter) resolveOverrides(host component.Host) error {
+	id := e.cfg.Overrides
+	if id.String() == "" {
+		return nil
+	}
+
+	ext := host.GetExtensions()[id]
+	if ext == nil {
+		return fmt.Errorf("tfooverrides extension %q not found", id)
+	}
+	provider, ok := ext.(OverridesProvider)
+	if !ok {
+		return fmt.Errorf("extension %q does not provide runtime overrides", id)
+	}
+	e.overrides = provider
+	return nil
+}
+
+// anonymous returns err unless allow_anonymous is set, in which case it
+// logs err and lets the exporter run without the missing credentials or
+// identity.
+func (e *tfoExporter) anonymous(err error) error {
+	if !e.cfg.AllowAnonymous {
+		return fmt.Errorf("%w (set allow_anonymous: true to export without it)", err)
+	}
+	e.logger.Warn("Exporting anonymously", zap.Error(err))
+	return nil
+}
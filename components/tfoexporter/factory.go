@@ -19,10 +19,20 @@ import (
 	"time"
 
 	"go.opentelemetry.io/collector/component"
-	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configoptional"
 	"go.opentelemetry.io/collector/config/configretry"
+	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/exporter"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/adaptive"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/bytesize"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/clientconf"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/deadletter"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/errorbudget"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/residency"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/retrybudget"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/watchdog"
 )
 
 const (
@@ -31,6 +41,9 @@ const (
 
 	// DefaultEndpoint is the default TFO Platform endpoint.
 	DefaultEndpoint = "https://api.telemetryflow.id"
+
+	// DefaultMaxRequestSize is the default limit of an encoded request body.
+	DefaultMaxRequestSize = 8 * bytesize.MiB
 )
 
 // NewFactory creates a new factory for the TFO exporter.
@@ -47,11 +60,11 @@ func NewFactory() exporter.Factory {
 // createDefaultConfig creates the default configuration for the exporter.
 func createDefaultConfig() component.Config {
 	return &Config{
-		ClientConfig: confighttp.ClientConfig{
-			Endpoint: DefaultEndpoint,
-			Timeout:  30 * time.Second,
-		},
-		UseV2API: true,
+		ClientConfig:      clientconf.NewDefaultClientConfig(DefaultEndpoint),
+		UseV2API:          true,
+		Encoding:          EncodingProto,
+		MaxRequestSize:    DefaultMaxRequestSize,
+		SignalCompression: newDefaultCompressionConfig(),
 		RetryConfig: configretry.BackOffConfig{
 			Enabled:             true,
 			InitialInterval:     5 * time.Second,
@@ -60,6 +73,25 @@ func createDefaultConfig() component.Config {
 			RandomizationFactor: 0.5,
 			Multiplier:          1.5,
 		},
+		QueueConfig: configoptional.Default(exporterhelper.NewDefaultQueueConfig()),
+		Concurrency: adaptive.NewDefaultConfig(),
+		RetryBudget: retrybudget.NewDefaultConfig(),
+		ErrorBudget: errorbudget.NewDefaultConfig(),
+		Residency:   residency.NewDefaultConfig(),
+		Watchdog:    watchdog.NewDefaultConfig(),
+		Warmup: WarmupConfig{
+			Policy:  WarmupPolicyDegrade,
+			Timeout: DefaultWarmupTimeout,
+		},
+		Capabilities: CapabilitiesConfig{
+			Endpoint:        DefaultCapabilitiesEndpoint,
+			RefreshInterval: DefaultCapabilitiesRefreshInterval,
+			Timeout:         DefaultCapabilitiesTimeout,
+		},
+		ShutdownSpill: ShutdownSpillConfig{
+			DrainTimeout: DefaultSpillDrainTimeout,
+		},
+		DeadLetter: deadletter.NewDefaultConfig(),
 	}
 }
 
@@ -74,16 +106,9 @@ func createTracesExporter(
 	if err != nil {
 		return nil, err
 	}
+	exp.signal = signalTraces
 
-	return exporterhelper.NewTraces(
-		ctx,
-		set,
-		cfg,
-		exp.pushTraces,
-		exporterhelper.WithStart(exp.start),
-		exporterhelper.WithShutdown(exp.shutdown),
-		exporterhelper.WithRetry(exp.cfg.RetryConfig),
-	)
+	return exp.notifyTraces(exp.newTraces(ctx, set, cfg))
 }
 
 // createMetricsExporter creates a metrics exporter.
@@ -97,16 +122,9 @@ func createMetricsExporter(
 	if err != nil {
 		return nil, err
 	}
+	exp.signal = signalMetrics
 
-	return exporterhelper.NewMetrics(
-		ctx,
-		set,
-		cfg,
-		exp.pushMetrics,
-		exporterhelper.WithStart(exp.start),
-		exporterhelper.WithShutdown(exp.shutdown),
-		exporterhelper.WithRetry(exp.cfg.RetryConfig),
-	)
+	return exp.notifyMetrics(exp.newMetrics(ctx, set, cfg))
 }
 
 // createLogsExporter creates a logs exporter.
@@ -120,16 +138,20 @@ func createLogsExporter(
 	if err != nil {
 		return nil, err
 	}
+	exp.signal = signalLogs
 
-	return exporterhelper.NewLogs(
-		ctx,
-		set,
-		cfg,
-		exp.pushLogs,
-		exporterhelper.WithStart(exp.start),
-		exporterhelper.WithShutdown(exp.shutdown),
-		exporterhelper.WithRetry(exp.cfg.RetryConfig),
-	)
+	return exp.notifyLogs(exp.newLogs(ctx, set, cfg))
+}
+
+// options returns the options of the exporter helper.
+func (e *tfoExporter) options() []exporterhelper.Option {
+	return []exporterhelper.Option{
+		exporterhelper.WithStart(e.start),
+		exporterhelper.WithShutdown(e.shutdown),
+		exporterhelper.WithRetry(e.cfg.RetryConfig),
+		exporterhelper.WithQueue(e.cfg.QueueConfig),
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: e.cfg.mutatesData()}),
+	}
 }
 
 // resolveConfig performs the component.Config → *Config type assertion using
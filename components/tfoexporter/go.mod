@@ -3,14 +3,34 @@ module github.com/telemetryflow/telemetryflow-collector/components/tfoexporter
 go 1.26
 
 require (
+	github.com/klauspost/compress v1.18.4
 	github.com/stretchr/testify v1.11.1
+	github.com/telemetryflow/telemetryflow-collector/pkg/adaptive v0.0.0
+	github.com/telemetryflow/telemetryflow-collector/pkg/attrfilter v0.0.0
+	github.com/telemetryflow/telemetryflow-collector/pkg/bytesize v0.0.0
+	github.com/telemetryflow/telemetryflow-collector/pkg/clientconf v0.0.0
+	github.com/telemetryflow/telemetryflow-collector/pkg/deadletter v0.0.0
+	github.com/telemetryflow/telemetryflow-collector/pkg/errorbudget v0.0.0
+	github.com/telemetryflow/telemetryflow-collector/pkg/payloadpreview v0.0.0
+	github.com/telemetryflow/telemetryflow-collector/pkg/requestid v0.0.0
+	github.com/telemetryflow/telemetryflow-collector/pkg/residency v0.0.0
+	github.com/telemetryflow/telemetryflow-collector/pkg/retrybudget v0.0.0
+	github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics v0.0.0
+	github.com/telemetryflow/telemetryflow-collector/pkg/watchdog v0.0.0
 	go.opentelemetry.io/collector/component v1.52.0
-	go.opentelemetry.io/collector/config/confighttp v0.146.1
+	go.opentelemetry.io/collector/component/componentstatus v0.146.1
 	go.opentelemetry.io/collector/config/configopaque v1.52.0
+	go.opentelemetry.io/collector/config/configoptional v1.52.0
 	go.opentelemetry.io/collector/config/configretry v1.52.0
+	go.opentelemetry.io/collector/consumer v1.52.0
+	go.opentelemetry.io/collector/consumer/consumererror v0.146.1
 	go.opentelemetry.io/collector/exporter v1.52.0
 	go.opentelemetry.io/collector/exporter/exporterhelper v0.146.1
 	go.opentelemetry.io/collector/pdata v1.52.0
+	go.opentelemetry.io/collector/pipeline v1.52.0
+	go.opentelemetry.io/otel v1.41.0
+	go.opentelemetry.io/otel/metric v1.41.0
+	go.opentelemetry.io/otel/trace v1.41.0
 	go.uber.org/zap v1.27.1
 )
 
@@ -29,7 +49,6 @@ require (
 	github.com/google/go-tpm v0.9.8 // indirect
 	github.com/hashicorp/go-version v1.8.0 // indirect
 	github.com/json-iterator/go v1.1.12 // indirect
-	github.com/klauspost/compress v1.18.4 // indirect
 	github.com/knadh/koanf/maps v0.1.2 // indirect
 	github.com/knadh/koanf/providers/confmap v1.0.0 // indirect
 	github.com/knadh/koanf/v2 v2.3.2 // indirect
@@ -44,14 +63,12 @@ require (
 	go.opentelemetry.io/collector/client v1.52.0 // indirect
 	go.opentelemetry.io/collector/config/configauth v1.52.0 // indirect
 	go.opentelemetry.io/collector/config/configcompression v1.52.0 // indirect
+	go.opentelemetry.io/collector/config/confighttp v0.146.1 // indirect
 	go.opentelemetry.io/collector/config/configmiddleware v1.52.0 // indirect
 	go.opentelemetry.io/collector/config/confignet v1.52.0 // indirect
-	go.opentelemetry.io/collector/config/configoptional v1.52.0 // indirect
 	go.opentelemetry.io/collector/config/configtls v1.52.0 // indirect
 	go.opentelemetry.io/collector/confmap v1.52.0 // indirect
 	go.opentelemetry.io/collector/confmap/xconfmap v0.146.1 // indirect
-	go.opentelemetry.io/collector/consumer v1.52.0 // indirect
-	go.opentelemetry.io/collector/consumer/consumererror v0.146.1 // indirect
 	go.opentelemetry.io/collector/extension v1.52.0 // indirect
 	go.opentelemetry.io/collector/extension/extensionauth v1.52.0 // indirect
 	go.opentelemetry.io/collector/extension/extensionmiddleware v0.146.1 // indirect
@@ -60,12 +77,8 @@ require (
 	go.opentelemetry.io/collector/internal/componentalias v0.146.1 // indirect
 	go.opentelemetry.io/collector/pdata/pprofile v0.146.1 // indirect
 	go.opentelemetry.io/collector/pdata/xpdata v0.146.1 // indirect
-	go.opentelemetry.io/collector/pipeline v1.52.0 // indirect
 	go.opentelemetry.io/collector/pipeline/xpipeline v0.146.1 // indirect
 	go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp v0.63.0 // indirect
-	go.opentelemetry.io/otel v1.41.0 // indirect
-	go.opentelemetry.io/otel/metric v1.41.0 // indirect
-	go.opentelemetry.io/otel/trace v1.41.0 // indirect
 	go.uber.org/multierr v1.11.0 // indirect
 	go.yaml.in/yaml/v3 v3.0.4 // indirect
 	golang.org/x/crypto v0.51.0 // indirect
@@ -77,3 +90,27 @@ require (
 	google.golang.org/protobuf v1.36.11 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
+
+replace github.com/telemetryflow/telemetryflow-collector/pkg/clientconf => ../../pkg/clientconf
+
+replace github.com/telemetryflow/telemetryflow-collector/pkg/watchdog => ../../pkg/watchdog
+
+replace github.com/telemetryflow/telemetryflow-collector/pkg/adaptive => ../../pkg/adaptive
+
+replace github.com/telemetryflow/telemetryflow-collector/pkg/residency => ../../pkg/residency
+
+replace github.com/telemetryflow/telemetryflow-collector/pkg/requestid => ../../pkg/requestid
+
+replace github.com/telemetryflow/telemetryflow-collector/pkg/retrybudget => ../../pkg/retrybudget
+
+replace github.com/telemetryflow/telemetryflow-collector/pkg/errorbudget => ../../pkg/errorbudget
+
+replace github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics => ../../pkg/selfmetrics
+
+replace github.com/telemetryflow/telemetryflow-collector/pkg/bytesize => ../../pkg/bytesize
+
+replace github.com/telemetryflow/telemetryflow-collector/pkg/attrfilter => ../../pkg/attrfilter
+
+replace github.com/telemetryflow/telemetryflow-collector/pkg/payloadpreview => ../../pkg/payloadpreview
+
+replace github.com/telemetryflow/telemetryflow-collector/pkg/deadletter => ../../pkg/deadletter
@@ -0,0 +1,61 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tfoexporter
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.uber.org/zap"
+)
+
+// recordPreview records payload, encoded in enc and about to be sent to
+// endpoint, in the payload preview of the exporter.
+func (e *tfoExporter) recordPreview(endpoint string, payload []byte, enc EncodingType) {
+	body := payload
+	if enc != EncodingJSON {
+		var err error
+		if body, err = otlpJSON(e.signal, payload); err != nil {
+			e.logger.Warn("Failed to convert payload for preview", zap.Error(err))
+			return
+		}
+	}
+	e.preview.Record(e.signal, endpoint, len(payload), body)
+}
+
+// otlpJSON converts an OTLP protobuf export request of signal to OTLP JSON.
+func otlpJSON(signal string, payload []byte) ([]byte, error) {
+	var req interface {
+		UnmarshalProto([]byte) error
+		MarshalJSON() ([]byte, error)
+	}
+	switch signal {
+	case signalTraces:
+		req = ptraceotlp.NewExportRequest()
+	case signalMetrics:
+		req = pmetricotlp.NewExportRequest()
+	case signalLogs:
+		req = plogotlp.NewExportRequest()
+	default:
+		return nil, fmt.Errorf("unknown signal %q", signal)
+	}
+	if err := req.UnmarshalProto(payload); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s: %w", signal, err)
+	}
+	return req.MarshalJSON()
+}
@@ -0,0 +1,245 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tfoexporter
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+const scopeName = "github.com/telemetryflow/telemetryflow-collector/components/tfoexporter"
+
+// defaultProfile labels the stats of telemetry sent under the top-level
+// API key.
+const defaultProfile = "default"
+
+// ProfileProvider is an interface for auth extensions that hold credential
+// profiles selected per resource.
+type ProfileProvider interface {
+	// GetProfileAttribute returns the resource attribute whose value
+	// selects a profile, or empty when there are no profiles.
+	GetProfileAttribute() string
+
+	// GetProfileCredentials returns the profile selected by a value of the
+	// attribute and its API key, or ok false when no profile claims it.
+	GetProfileCredentials(value string) (profile, keyID, keySecret string, ok bool)
+}
+
+// credentials is the API key of a profile.
+type credentials struct {
+	profile   string
+	keyID     string
+	keySecret string
+}
+
+// profileSelector picks the credential profile of each resource of a batch.
+// A nil *profileSelector sends everything under the top-level key.
+type profileSelector struct {
+	provider  ProfileProvider
+	attribute string
+
+	records metric.Int64Counter
+	labels  selfmetrics.Labels
+}
+
+// newProfileSelector returns the selector of provider, or nil when it has
+// no profiles. Records sent and failed per profile are counted on
+// set.MeterProvider.
+func newProfileSelector(provider ProfileProvider, set component.TelemetrySettings, labels selfmetrics.Labels) (*profileSelector, error) {
+	attr := provider.GetProfileAttribute()
+	if attr == "" {
+		return nil, nil
+	}
+	s := &profileSelector{provider: provider, attribute: attr, labels: labels}
+	if set.MeterProvider != nil {
+		var err error
+		s.records, err = set.MeterProvider.Meter(scopeName).Int64Counter(selfmetrics.ExporterProfileRecords,
+			metric.WithDescription("Number of records exported per credential profile."),
+			metric.WithUnit("{record}"))
+		if err != nil {
+			return nil, fmt.Errorf("failed to create profile stats: %w", err)
+		}
+	}
+	return s, nil
+}
+
+// credentialsOf returns the credentials of a resource, or nil for the
+// top-level key.
+func (s *profileSelector) credentialsOf(res pcommon.Resource) *credentials {
+	v, ok := res.Attributes().Get(s.attribute)
+	if !ok {
+		return nil
+	}
+	profile, keyID, keySecret, ok := s.provider.GetProfileCredentials(v.AsString())
+	if !ok {
+		return nil
+	}
+	return &credentials{profile: profile, keyID: keyID, keySecret: keySecret}
+}
+
+// record counts the records of a batch sent and failed under creds.
+func (s *profileSelector) record(ctx context.Context, creds *credentials, sent, failed int) {
+	if s == nil || s.records == nil {
+		return
+	}
+	profile := defaultProfile
+	if creds != nil {
+		profile = creds.profile
+	}
+	if sent > 0 {
+		s.records.Add(ctx, int64(sent), s.labels.Option(
+			attribute.String("profile", profile), attribute.String("outcome", "sent")))
+	}
+	if failed > 0 {
+		s.records.Add(ctx, int64(failed), s.labels.Option(
+			attribute.String("profile", profile), attribute.String("outcome", "failed")))
+	}
+}
+
+// profileBatch holds the resources of a batch sent under one profile.
+type profileBatch[T any] struct {
+	creds *credentials
+	data  T
+}
+
+// splitByProfile groups the n resources of data by profile, in order of
+// first appearance. subset copies the resources at the given positions
+// into a new batch. A batch whose resources share a profile is returned as
+// is.
+func splitByProfile[T any](s *profileSelector, data T, n int, resource func(int) pcommon.Resource, subset func([]int) T) []profileBatch[T] {
+	if s == nil {
+		return []profileBatch[T]{{data: data}}
+	}
+
+	var (
+		batches []profileBatch[T]
+		members [][]int
+		index   = make(map[string]int)
+	)
+	for i := range n {
+		creds := s.credentialsOf(resource(i))
+		key := ""
+		if creds != nil {
+			key = creds.profile
+		}
+		b, ok := index[key]
+		if !ok {
+			b = len(batches)
+			index[key] = b
+			batches = append(batches, profileBatch[T]{creds: creds})
+			members = append(members, nil)
+		}
+		members[b] = append(members[b], i)
+	}
+	if len(batches) <= 1 {
+		var creds *credentials
+		if len(batches) == 1 {
+			creds = batches[0].creds
+		}
+		return []profileBatch[T]{{creds: creds, data: data}}
+	}
+	for b := range batches {
+		batches[b].data = subset(members[b])
+	}
+	return batches
+}
+
+// sendProfiles sends the batches in order under their credentials and
+// counts the outcome per profile. After a failure the remaining batches
+// are returned unsent with the failed records.
+func sendProfiles[T any](
+	ctx context.Context,
+	e *tfoExporter,
+	batches []profileBatch[T],
+	count func(T) int,
+	send func(context.Context, T) ([]T, error),
+) ([]T, error) {
+	for i, b := range batches {
+		unsent, err := send(withCredentials(ctx, b.creds), b.data)
+		failed := 0
+		for _, u := range unsent {
+			failed += count(u)
+		}
+		e.profiles.record(ctx, b.creds, count(b.data)-failed, failed)
+		if err != nil {
+			for _, rest := range batches[i+1:] {
+				unsent = append(unsent, rest.data)
+			}
+			return unsent, err
+		}
+	}
+	return nil, nil
+}
+
+// tracesResources copies the resources of td at the given positions.
+func tracesResources(td ptrace.Traces) func([]int) ptrace.Traces {
+	return func(positions []int) ptrace.Traces {
+		out := ptrace.NewTraces()
+		for _, i := range positions {
+			td.ResourceSpans().At(i).CopyTo(out.ResourceSpans().AppendEmpty())
+		}
+		return out
+	}
+}
+
+// metricsResources copies the resources of md at the given positions.
+func metricsResources(md pmetric.Metrics) func([]int) pmetric.Metrics {
+	return func(positions []int) pmetric.Metrics {
+		out := pmetric.NewMetrics()
+		for _, i := range positions {
+			md.ResourceMetrics().At(i).CopyTo(out.ResourceMetrics().AppendEmpty())
+		}
+		return out
+	}
+}
+
+// logsResources copies the resources of ld at the given positions.
+func logsResources(ld plog.Logs) func([]int) plog.Logs {
+	return func(positions []int) plog.Logs {
+		out := plog.NewLogs()
+		for _, i := range positions {
+			ld.ResourceLogs().At(i).CopyTo(out.ResourceLogs().AppendEmpty())
+		}
+		return out
+	}
+}
+
+type credentialsKey struct{}
+
+// withCredentials returns ctx sending under creds; nil keeps the top-level
+// key.
+func withCredentials(ctx context.Context, creds *credentials) context.Context {
+	if creds == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, credentialsKey{}, creds)
+}
+
+// credentialsFromContext returns the credentials set by withCredentials.
+func credentialsFromContext(ctx context.Context) *credentials {
+	creds, _ := ctx.Value(credentialsKey{}).(*credentials)
+	return creds
+}
@@ -0,0 +1,264 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoexporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+// DefaultSpillDrainTimeout is how long batches are still sent to the
+// backend after the shutdown started.
+const DefaultSpillDrainTimeout = 5 * time.Second
+
+// ShutdownSpillConfig configures the last-resort target of the batches the
+// sending queue still holds when the collector shuts down.
+type ShutdownSpillConfig struct {
+	// Path is the file the batches are appended to, one OTLP JSON request
+	// per line as written by the file exporter in format json. Empty
+	// disables the spill and the batches are dropped as before.
+	Path string `mapstructure:"path"`
+
+	// DrainTimeout bounds how long the queue is still sent to the backend
+	// after the shutdown started. Batches that fail meanwhile and all
+	// batches after it are written to Path. Zero writes the whole queue to
+	// Path without contacting the backend.
+	// Default: 5s
+	DrainTimeout time.Duration `mapstructure:"drain_timeout"`
+}
+
+// Enabled reports whether a spill target is configured.
+func (cfg *ShutdownSpillConfig) Enabled() bool {
+	return cfg.Path != ""
+}
+
+// Validate checks the spill configuration for errors.
+func (cfg *ShutdownSpillConfig) Validate() error {
+	if !cfg.Enabled() {
+		return nil
+	}
+	if cfg.DrainTimeout < 0 {
+		return errors.New("shutdown_spill.drain_timeout must not be negative")
+	}
+	return nil
+}
+
+// shutdownSpill writes the batches left while the sending queue drains to
+// the spill file. A nil *shutdownSpill never drains.
+type shutdownSpill struct {
+	cfg    ShutdownSpillConfig
+	logger *zap.Logger
+
+	records metric.Int64Counter
+	attrs   metric.MeasurementOption
+
+	draining atomic.Bool
+	deadline atomic.Int64
+
+	// The file is opened on the first spilled batch.
+	mu      sync.Mutex
+	file    *os.File
+	batches int
+	count   int
+}
+
+// newShutdownSpill creates the spill and its counter on set.MeterProvider.
+func newShutdownSpill(cfg ShutdownSpillConfig, set component.TelemetrySettings, labels selfmetrics.Labels) (*shutdownSpill, error) {
+	s := &shutdownSpill{
+		cfg:    cfg,
+		logger: set.Logger,
+		attrs:  labels.Option(),
+	}
+	if set.MeterProvider == nil {
+		return s, nil
+	}
+	var err error
+	if s.records, err = set.MeterProvider.Meter(scopeName).Int64Counter(selfmetrics.ExporterShutdownSpilled,
+		metric.WithDescription("Number of queued records written to the spill file on shutdown."),
+		metric.WithUnit("{record}")); err != nil {
+		return nil, fmt.Errorf("failed to create shutdown spill: %w", err)
+	}
+	return s, nil
+}
+
+// begin marks the start of the shutdown and the drain deadline.
+func (s *shutdownSpill) begin() {
+	if s == nil || s.draining.Load() {
+		return
+	}
+	s.deadline.Store(time.Now().Add(s.cfg.DrainTimeout).UnixNano())
+	s.draining.Store(true)
+}
+
+// isDraining reports whether the shutdown started.
+func (s *shutdownSpill) isDraining() bool {
+	return s != nil && s.draining.Load()
+}
+
+// expired reports whether the drain deadline passed, so batches go to the
+// spill file without contacting the backend.
+func (s *shutdownSpill) expired() bool {
+	return s.isDraining() && time.Now().UnixNano() >= s.deadline.Load()
+}
+
+// traces writes batches to the spill file.
+func (s *shutdownSpill) traces(ctx context.Context, batches ...ptrace.Traces) error {
+	return spillAll(ctx, s, batches, (&ptrace.JSONMarshaler{}).MarshalTraces, ptrace.Traces.SpanCount)
+}
+
+// metrics writes batches to the spill file.
+func (s *shutdownSpill) metrics(ctx context.Context, batches ...pmetric.Metrics) error {
+	return spillAll(ctx, s, batches, (&pmetric.JSONMarshaler{}).MarshalMetrics, pmetric.Metrics.DataPointCount)
+}
+
+// logs writes batches to the spill file.
+func (s *shutdownSpill) logs(ctx context.Context, batches ...plog.Logs) error {
+	return spillAll(ctx, s, batches, (&plog.JSONMarshaler{}).MarshalLogs, plog.Logs.LogRecordCount)
+}
+
+func spillAll[T any](ctx context.Context, s *shutdownSpill, batches []T, marshal func(T) ([]byte, error), count func(T) int) error {
+	for _, b := range batches {
+		line, err := marshal(b)
+		if err != nil {
+			return consumererror.NewPermanent(fmt.Errorf("failed to encode spilled batch: %w", err))
+		}
+		if err := s.write(ctx, line, count(b)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// write appends line and a newline to the spill file in one write, so the
+// lines of the signals sharing the file do not interleave.
+func (s *shutdownSpill) write(ctx context.Context, line []byte, n int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		if err := os.MkdirAll(filepath.Dir(s.cfg.Path), 0o750); err != nil {
+			return fmt.Errorf("failed to create spill directory: %w", err)
+		}
+		f, err := os.OpenFile(s.cfg.Path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+		if err != nil {
+			return fmt.Errorf("failed to open spill file: %w", err)
+		}
+		s.file = f
+	}
+	if _, err := s.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write spill file: %w", err)
+	}
+	s.batches++
+	s.count += n
+	if s.records != nil {
+		s.records.Add(ctx, int64(n), s.attrs)
+	}
+	return nil
+}
+
+// close closes the spill file and logs what was written to it.
+func (s *shutdownSpill) close() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return
+	}
+	if err := s.file.Close(); err != nil {
+		s.logger.Error("Failed to close spill file", zap.String("path", s.cfg.Path), zap.Error(err))
+	}
+	s.file = nil
+	s.logger.Warn("Wrote queued telemetry to the spill file on shutdown",
+		zap.String("path", s.cfg.Path),
+		zap.Int("batches", s.batches),
+		zap.Int("records", s.count),
+	)
+}
+
+// drainNotifier ends maintenance waits and starts the drain deadline before
+// the exporter helper stops the retry sender and flushes the sending queue,
+// which happens before the exporter's own shutdown function is called.
+type drainNotifier struct {
+	component.Component
+	exp *tfoExporter
+}
+
+// Shutdown implements component.Component.
+func (n drainNotifier) Shutdown(ctx context.Context) error {
+	n.exp.spill.begin()
+	n.exp.stop()
+	return n.Component.Shutdown(ctx)
+}
+
+type drainingTraces struct {
+	drainNotifier
+	consumer.Traces
+}
+
+type drainingMetrics struct {
+	drainNotifier
+	consumer.Metrics
+}
+
+type drainingLogs struct {
+	drainNotifier
+	consumer.Logs
+}
+
+// notifyTraces wraps exp so its shutdown ends maintenance waits and starts
+// the drain.
+func (e *tfoExporter) notifyTraces(exp exporter.Traces, err error) (exporter.Traces, error) {
+	if err != nil {
+		return exp, err
+	}
+	return drainingTraces{drainNotifier{exp, e}, exp}, nil
+}
+
+// notifyMetrics wraps exp so its shutdown ends maintenance waits and starts
+// the drain.
+func (e *tfoExporter) notifyMetrics(exp exporter.Metrics, err error) (exporter.Metrics, error) {
+	if err != nil {
+		return exp, err
+	}
+	return drainingMetrics{drainNotifier{exp, e}, exp}, nil
+}
+
+// notifyLogs wraps exp so its shutdown ends maintenance waits and starts
+// the drain.
+func (e *tfoExporter) notifyLogs(exp exporter.Logs, err error) (exporter.Logs, error) {
+	if err != nil {
+		return exp, err
+	}
+	return drainingLogs{drainNotifier{exp, e}, exp}, nil
+}
@@ -0,0 +1,101 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoexporter
+
+import (
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// splitTraces halves td by span count. The input is left untouched since
+// it may be shared with other consumers.
+func splitTraces(td ptrace.Traces) (ptrace.Traces, ptrace.Traces) {
+	half := td.SpanCount() / 2
+	return filterTraces(td, func(i int) bool { return i < half }),
+		filterTraces(td, func(i int) bool { return i >= half })
+}
+
+// filterTraces copies the spans of td whose position satisfies keep,
+// dropping scopes and resources left empty.
+func filterTraces(td ptrace.Traces, keep func(int) bool) ptrace.Traces {
+	out := ptrace.NewTraces()
+	td.CopyTo(out)
+	i := 0
+	out.ResourceSpans().RemoveIf(func(rs ptrace.ResourceSpans) bool {
+		rs.ScopeSpans().RemoveIf(func(ss ptrace.ScopeSpans) bool {
+			ss.Spans().RemoveIf(func(ptrace.Span) bool {
+				i++
+				return !keep(i - 1)
+			})
+			return ss.Spans().Len() == 0
+		})
+		return rs.ScopeSpans().Len() == 0
+	})
+	return out
+}
+
+// splitMetrics halves md by metric count. A metric is never split, so its
+// data points always travel together.
+func splitMetrics(md pmetric.Metrics) (pmetric.Metrics, pmetric.Metrics) {
+	half := md.MetricCount() / 2
+	return filterMetrics(md, func(i int) bool { return i < half }),
+		filterMetrics(md, func(i int) bool { return i >= half })
+}
+
+// filterMetrics copies the metrics of md whose position satisfies keep,
+// dropping scopes and resources left empty.
+func filterMetrics(md pmetric.Metrics, keep func(int) bool) pmetric.Metrics {
+	out := pmetric.NewMetrics()
+	md.CopyTo(out)
+	i := 0
+	out.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			sm.Metrics().RemoveIf(func(pmetric.Metric) bool {
+				i++
+				return !keep(i - 1)
+			})
+			return sm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+	return out
+}
+
+// splitLogs halves ld by log record count.
+func splitLogs(ld plog.Logs) (plog.Logs, plog.Logs) {
+	half := ld.LogRecordCount() / 2
+	return filterLogs(ld, func(i int) bool { return i < half }),
+		filterLogs(ld, func(i int) bool { return i >= half })
+}
+
+// filterLogs copies the log records of ld whose position satisfies keep,
+// dropping scopes and resources left empty.
+func filterLogs(ld plog.Logs, keep func(int) bool) plog.Logs {
+	out := plog.NewLogs()
+	ld.CopyTo(out)
+	i := 0
+	out.ResourceLogs().RemoveIf(func(rl plog.ResourceLogs) bool {
+		rl.ScopeLogs().RemoveIf(func(sl plog.ScopeLogs) bool {
+			sl.LogRecords().RemoveIf(func(plog.LogRecord) bool {
+				i++
+				return !keep(i - 1)
+			})
+			return sl.LogRecords().Len() == 0
+		})
+		return rl.ScopeLogs().Len() == 0
+	})
+	return out
+}
@@ -0,0 +1,157 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoexporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.uber.org/zap"
+)
+
+// WarmupPolicy decides what a failed warm-up check does to startup.
+type WarmupPolicy string
+
+const (
+	// WarmupPolicyFail fails the exporter's Start, so the collector does not
+	// start and a bad deployment is rolled back.
+	WarmupPolicyFail WarmupPolicy = "fail"
+
+	// WarmupPolicyDegrade starts the exporter but reports it as a
+	// recoverable error on the health endpoint until an export succeeds.
+	WarmupPolicyDegrade WarmupPolicy = "degrade"
+
+	// WarmupPolicyIgnore only logs the failure.
+	WarmupPolicyIgnore WarmupPolicy = "ignore"
+)
+
+// DefaultWarmupTimeout bounds the warm-up check.
+const DefaultWarmupTimeout = 10 * time.Second
+
+// WarmupConfig configures the connectivity check run before the exporter
+// reports itself started.
+type WarmupConfig struct {
+	// Enabled sends an empty export request to the signal's endpoint during
+	// Start. It resolves the endpoint, completes the TLS handshake and has
+	// the platform validate the credentials without exporting any data.
+	// Default: false
+	Enabled bool `mapstructure:"enabled"`
+
+	// Policy is applied when the check fails: "fail", "degrade" or "ignore".
+	// Default: degrade
+	Policy WarmupPolicy `mapstructure:"policy"`
+
+	// Timeout bounds the check.
+	// Default: 10s
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// Validate checks the warm-up configuration for errors.
+func (cfg *WarmupConfig) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	switch cfg.Policy {
+	case WarmupPolicyFail, WarmupPolicyDegrade, WarmupPolicyIgnore:
+	default:
+		return fmt.Errorf("warmup.policy %q must be %q, %q or %q",
+			cfg.Policy, WarmupPolicyFail, WarmupPolicyDegrade, WarmupPolicyIgnore)
+	}
+	if cfg.Timeout <= 0 {
+		return errors.New("warmup.timeout must be positive")
+	}
+	return nil
+}
+
+// warmup runs the connectivity check and applies the configured policy.
+func (e *tfoExporter) warmup(ctx context.Context, host component.Host) error {
+	start := time.Now()
+	checkCtx, cancel := context.WithTimeout(ctx, e.cfg.Warmup.Timeout)
+	err := e.checkConnectivity(checkCtx)
+	cancel()
+	if err == nil {
+		e.logger.Info("TFO exporter warm-up check passed",
+			zap.String("endpoint", e.cfg.Endpoint),
+			zap.Duration("latency", time.Since(start)),
+		)
+		return nil
+	}
+
+	switch e.cfg.Warmup.Policy {
+	case WarmupPolicyFail:
+		return err
+	case WarmupPolicyDegrade:
+		e.logger.Error("TFO exporter warm-up check failed; starting degraded", zap.Error(err))
+		e.degraded.Store(true)
+		componentstatus.ReportStatus(host, componentstatus.NewRecoverableErrorEvent(err))
+	default:
+		e.logger.Warn("TFO exporter warm-up check failed", zap.Error(err))
+	}
+	return nil
+}
+
+// checkConnectivity sends an empty export request for the exporter's signal.
+// It backs the warm-up check and the error budget probe.
+func (e *tfoExporter) checkConnectivity(ctx context.Context) error {
+	var (
+		path string
+		req  otlpRequest
+		p    = e.params()
+	)
+	switch e.signal {
+	case signalMetrics:
+		path, req = e.cfg.GetMetricsEndpoint(), pmetricotlp.NewExportRequest()
+	case signalLogs:
+		path, req = e.cfg.GetLogsEndpoint(), plogotlp.NewExportRequest()
+	default:
+		path, req = e.cfg.GetTracesEndpoint(), ptraceotlp.NewExportRequest()
+	}
+	payload, err := e.marshal(p.encoding, req, "warm-up request")
+	if err != nil {
+		return err
+	}
+	payload, contentEncoding, err := e.compressor.compress(payload)
+	if err != nil {
+		return err
+	}
+
+	endpoint := e.cfg.URL(path)
+	status, err := e.post(ctx, endpoint, payload, p.encoding.ContentType(), contentEncoding)
+	switch {
+	case err == nil:
+		return nil
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return fmt.Errorf("connectivity check against %s: credentials rejected (status %d)", endpoint, status)
+	default:
+		return fmt.Errorf("connectivity check against %s: %w", endpoint, err)
+	}
+}
+
+// recovered clears the degraded state left by a failed warm-up check once
+// an export succeeds.
+func (e *tfoExporter) recovered() {
+	if e.degraded.CompareAndSwap(true, false) {
+		e.logger.Info("TFO exporter recovered from failed warm-up check")
+		componentstatus.ReportStatus(e.host, componentstatus.NewEvent(componentstatus.StatusOK))
+	}
+}
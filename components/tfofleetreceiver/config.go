@@ -0,0 +1,76 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfofleetreceiver
+
+import (
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/scraper/scraperhelper"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/clientconf"
+)
+
+// Config defines the configuration for the TFO fleet receiver.
+type Config struct {
+	// ControllerConfig holds collection_interval, initial_delay and
+	// timeout.
+	// Default: collection_interval 30s
+	scraperhelper.ControllerConfig `mapstructure:",squash"`
+
+	// Children are the collectors whose internal telemetry is scraped.
+	Children []ChildConfig `mapstructure:"children"`
+}
+
+// ChildConfig defines a single child collector.
+type ChildConfig struct {
+	// Site is the site the child belongs to. Children of the same site are
+	// merged into one set of fleet metrics.
+	Site string `mapstructure:"site"`
+
+	// ClientConfig holds the URL of the child's Prometheus internal
+	// telemetry endpoint, e.g. http://edge-1:8888/metrics, and the TLS,
+	// header and timeout settings used to reach it.
+	clientconf.ClientConfig `mapstructure:",squash"`
+}
+
+// Validate checks the configuration for errors.
+func (cfg *Config) Validate() error {
+	if cfg.CollectionInterval <= 0 {
+		return errors.New("collection_interval must be positive")
+	}
+	if len(cfg.Children) == 0 {
+		return errors.New("at least one child is required")
+	}
+
+	seen := make(map[string]struct{}, len(cfg.Children))
+	for i, child := range cfg.Children {
+		if child.Site == "" {
+			return fmt.Errorf("children[%d]: site is required", i)
+		}
+		if child.Endpoint == "" {
+			return fmt.Errorf("children[%d]: endpoint is required", i)
+		}
+		if _, dup := seen[child.Endpoint]; dup {
+			return fmt.Errorf("children[%d]: duplicate endpoint %q", i, child.Endpoint)
+		}
+		seen[child.Endpoint] = struct{}{}
+
+		if err := child.ClientConfig.Validate(); err != nil {
+			return fmt.Errorf("children[%d]: %w", i, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+// Package tfofleetreceiver scrapes the internal telemetry of child
+// collectors and emits merged fleet metrics per site.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// A regional collector runs the receiver with the child collectors of its
+// sites. Every collection interval it reads the Prometheus endpoint each
+// child exposes for its internal telemetry (service::telemetry::metrics,
+// port 8888 by default), merges the children of each site and emits:
+//
+//	tfo.fleet.received    sum    records accepted by receivers, per site and signal
+//	tfo.fleet.dropped     sum    records refused by receivers, lost by exporters
+//	                             or rejected by provenance, per site and signal
+//	tfo.fleet.queue.size  gauge  exporter queue size, per site
+//	tfo.fleet.collectors  gauge  children per site and state (up or down)
+//
+// Sending these to a Prometheus exporter lets one scrape of the regional
+// collector cover the whole site hierarchy.
+//
+// Counters are merged from the increase of each child between scrapes, so
+// the fleet totals stay monotonic when a child restarts or is unreachable.
+// The totals start when the receiver starts. An unreachable child is
+// counted as down and its last totals are kept.
+//
+// Configuration example:
+//
+//	receivers:
+//	  tfofleet:
+//	    collection_interval: 30s
+//	    children:
+//	      - site: jakarta
+//	        endpoint: http://jkt-edge-1:8888/metrics
+//	      - site: jakarta
+//	        endpoint: http://jkt-edge-2:8888/metrics
+//	      - site: surabaya
+//	        endpoint: https://sby-edge-1:8888/metrics
+//	        tls:
+//	          ca_file: /etc/tfo/ca.pem
+//
+//	exporters:
+//	  prometheus:
+//	    endpoint: 0.0.0.0:9464
+//
+//	service:
+//	  pipelines:
+//	    metrics/fleet:
+//	      receivers: [tfofleet]
+//	      exporters: [prometheus]
+package tfofleetreceiver // import "github.com/telemetryflow/telemetryflow-collector/components/tfofleetreceiver"
@@ -0,0 +1,73 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfofleetreceiver
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/scraper"
+	"go.opentelemetry.io/collector/scraper/scraperhelper"
+)
+
+const (
+	// TypeStr is the type string identifier for the TFO fleet receiver.
+	TypeStr = "tfofleet"
+
+	// Defaults
+	defaultCollectionInterval = 30 * time.Second
+)
+
+// NewFactory creates a new factory for the TFO fleet receiver.
+func NewFactory() receiver.Factory {
+	return receiver.NewFactory(
+		component.MustNewType(TypeStr),
+		createDefaultConfig,
+		receiver.WithMetrics(createMetricsReceiver, component.StabilityLevelAlpha),
+	)
+}
+
+// createDefaultConfig creates the default configuration for the receiver.
+func createDefaultConfig() component.Config {
+	controllerCfg := scraperhelper.NewDefaultControllerConfig()
+	controllerCfg.CollectionInterval = defaultCollectionInterval
+	return &Config{
+		ControllerConfig: controllerCfg,
+	}
+}
+
+// createMetricsReceiver creates a metrics receiver.
+func createMetricsReceiver(
+	_ context.Context,
+	set receiver.Settings,
+	cfg component.Config,
+	next consumer.Metrics,
+) (receiver.Metrics, error) {
+	oCfg := cfg.(*Config)
+	s := newFleetScraper(oCfg, set.TelemetrySettings)
+	sc, err := scraper.NewMetrics(s.scrape, scraper.WithStart(s.startScraper))
+	if err != nil {
+		return nil, err
+	}
+	return scraperhelper.NewMetricsController(
+		&oCfg.ControllerConfig,
+		set,
+		next,
+		scraperhelper.AddMetricsScraper(component.MustNewType(TypeStr), sc),
+	)
+}
@@ -0,0 +1,265 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfofleetreceiver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/prometheus/common/expfmt"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/errlog"
+)
+
+const scopeName = "github.com/telemetryflow/telemetryflow-collector/components/tfofleetreceiver"
+
+// Names of the emitted fleet metrics.
+const (
+	MetricReceived   = "tfo.fleet.received"
+	MetricDropped    = "tfo.fleet.dropped"
+	MetricQueueSize  = "tfo.fleet.queue.size"
+	MetricCollectors = "tfo.fleet.collectors"
+)
+
+// Data point attributes of the emitted fleet metrics.
+const (
+	AttrSite   = "site"
+	AttrSignal = "signal"
+	AttrState  = "state"
+)
+
+// failedScrape is the log message of a failed child scrape.
+const failedScrape = "Failed to scrape child collector"
+
+// acceptHeader asks children for the Prometheus text format.
+var acceptHeader = string(expfmt.NewFormat(expfmt.TypeTextPlain))
+
+// child is a configured child collector and the totals last read from it.
+type child struct {
+	cfg      ChildConfig
+	client   *http.Client
+	failures *errlog.Aggregator
+
+	// last is nil until the first successful scrape.
+	last *stats
+}
+
+// site holds the merged counters of the children of one site.
+type site struct {
+	name     string
+	received [len(signals)]float64
+	dropped  [len(signals)]float64
+}
+
+// fleetScraper scrapes the children and merges their stats per site.
+type fleetScraper struct {
+	cfg *Config
+	set component.TelemetrySettings
+
+	children []*child
+	sites    []*site
+	bySite   map[string]*site
+	started  pcommon.Timestamp
+}
+
+// newFleetScraper creates the scraper for cfg.
+func newFleetScraper(cfg *Config, set component.TelemetrySettings) *fleetScraper {
+	s := &fleetScraper{
+		cfg:    cfg,
+		set:    set,
+		bySite: make(map[string]*site),
+	}
+	for _, childCfg := range cfg.Children {
+		if _, ok := s.bySite[childCfg.Site]; !ok {
+			st := &site{name: childCfg.Site}
+			s.sites = append(s.sites, st)
+			s.bySite[childCfg.Site] = st
+		}
+	}
+	return s
+}
+
+// startScraper builds the HTTP clients of the children.
+func (s *fleetScraper) startScraper(ctx context.Context, host component.Host) error {
+	s.children = make([]*child, 0, len(s.cfg.Children))
+	for _, childCfg := range s.cfg.Children {
+		client, err := childCfg.NewClient(ctx, host, s.set)
+		if err != nil {
+			return fmt.Errorf("child %q: %w", childCfg.Endpoint, err)
+		}
+		logger := s.set.Logger.With(zap.String("site", childCfg.Site), zap.String("endpoint", childCfg.Endpoint))
+		s.children = append(s.children, &child{
+			cfg:      childCfg,
+			client:   client,
+			failures: errlog.New(logger, errlog.DefaultInterval),
+		})
+	}
+	s.started = pcommon.NewTimestampFromTime(time.Now())
+	return nil
+}
+
+// scrape reads every child and returns the merged fleet metrics. Children
+// that cannot be read are logged and reported as down; their counters keep
+// the totals last read.
+func (s *fleetScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
+	results := make([]*stats, len(s.children))
+	var wg sync.WaitGroup
+	for i, c := range s.children {
+		wg.Go(func() {
+			st, err := c.fetch(ctx)
+			if err != nil {
+				c.failures.Error(failedScrape, err)
+				return
+			}
+			c.failures.Success(failedScrape)
+			results[i] = &st
+		})
+	}
+	wg.Wait()
+
+	queueSize := make(map[string]float64, len(s.sites))
+	up := make(map[string]int, len(s.sites))
+	down := make(map[string]int, len(s.sites))
+	for i, c := range s.children {
+		st := results[i]
+		if st == nil {
+			down[c.cfg.Site]++
+			continue
+		}
+		up[c.cfg.Site]++
+		queueSize[c.cfg.Site] += st.queueSize
+		s.bySite[c.cfg.Site].merge(c.last, st)
+		c.last = st
+	}
+
+	now := pcommon.NewTimestampFromTime(time.Now())
+	md := pmetric.NewMetrics()
+	sm := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName(scopeName)
+
+	received := newSum(sm, MetricReceived, "Records received by the collectors of a site.")
+	dropped := newSum(sm, MetricDropped, "Records refused by receivers or lost by exporters of a site.")
+	queue := newGauge(sm, MetricQueueSize, "Size of the exporter queues of a site.", "1")
+	collectors := newGauge(sm, MetricCollectors, "Child collectors of a site by scrape state.", "{collector}")
+	for _, st := range s.sites {
+		for i, signal := range signals {
+			dp := received.AppendEmpty()
+			s.setTimes(dp, now)
+			dp.SetIntValue(int64(st.received[i]))
+			dp.Attributes().PutStr(AttrSite, st.name)
+			dp.Attributes().PutStr(AttrSignal, signal)
+
+			dp = dropped.AppendEmpty()
+			s.setTimes(dp, now)
+			dp.SetIntValue(int64(st.dropped[i]))
+			dp.Attributes().PutStr(AttrSite, st.name)
+			dp.Attributes().PutStr(AttrSignal, signal)
+		}
+
+		dp := queue.AppendEmpty()
+		dp.SetTimestamp(now)
+		dp.SetIntValue(int64(queueSize[st.name]))
+		dp.Attributes().PutStr(AttrSite, st.name)
+
+		for _, state := range []struct {
+			name string
+			n    int
+		}{{"up", up[st.name]}, {"down", down[st.name]}} {
+			dp = collectors.AppendEmpty()
+			dp.SetTimestamp(now)
+			dp.SetIntValue(int64(state.n))
+			dp.Attributes().PutStr(AttrSite, st.name)
+			dp.Attributes().PutStr(AttrState, state.name)
+		}
+	}
+	return md, nil
+}
+
+// setTimes sets the start and end time of a cumulative data point.
+func (s *fleetScraper) setTimes(dp pmetric.NumberDataPoint, now pcommon.Timestamp) {
+	dp.SetStartTimestamp(s.started)
+	dp.SetTimestamp(now)
+}
+
+// merge adds the increase from prev to cur to the site totals. A counter
+// lower than before means the child restarted, so its whole value is new.
+// prev is nil on the first scrape of a child.
+func (st *site) merge(prev, cur *stats) {
+	if prev == nil {
+		prev = &stats{}
+	}
+	for i := range signals {
+		st.received[i] += increase(prev.received[i], cur.received[i])
+		st.dropped[i] += increase(prev.dropped[i], cur.dropped[i])
+	}
+}
+
+func increase(prev, cur float64) float64 {
+	if cur < prev {
+		return cur
+	}
+	return cur - prev
+}
+
+// fetch reads the stats of the child.
+func (c *child) fetch(ctx context.Context) (stats, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.cfg.Endpoint, http.NoBody)
+	if err != nil {
+		return stats{}, err
+	}
+	req.Header.Set("Accept", acceptHeader)
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return stats{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return stats{}, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+	st, err := parseStats(resp.Body, expfmt.ResponseFormat(resp.Header))
+	if err != nil {
+		return stats{}, fmt.Errorf("parse metrics: %w", err)
+	}
+	return st, nil
+}
+
+// newSum adds a cumulative monotonic sum of records to sm.
+func newSum(sm pmetric.ScopeMetrics, name, description string) pmetric.NumberDataPointSlice {
+	m := sm.Metrics().AppendEmpty()
+	m.SetName(name)
+	m.SetDescription(description)
+	m.SetUnit("{record}")
+	sum := m.SetEmptySum()
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	sum.SetIsMonotonic(true)
+	return sum.DataPoints()
+}
+
+// newGauge adds a gauge to sm.
+func newGauge(sm pmetric.ScopeMetrics, name, description, unit string) pmetric.NumberDataPointSlice {
+	m := sm.Metrics().AppendEmpty()
+	m.SetName(name)
+	m.SetDescription(description)
+	m.SetUnit(unit)
+	return m.SetEmptyGauge().DataPoints()
+}
@@ -0,0 +1,158 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfofleetreceiver
+
+import (
+	"errors"
+	"io"
+	"strings"
+
+	dto "github.com/prometheus/client_model/go"
+	"github.com/prometheus/common/expfmt"
+)
+
+// Signals, in the order their data points are emitted.
+var signals = [...]string{"traces", "metrics", "logs"}
+
+// kindIndex maps the record kind suffix of collector metric names to an
+// index into signals.
+var kindIndex = map[string]int{
+	"spans":         0,
+	"metric_points": 1,
+	"log_records":   2,
+}
+
+// signalIndex maps the signal label of TFO metrics to an index into signals.
+var signalIndex = map[string]int{
+	"traces":  0,
+	"metrics": 1,
+	"logs":    2,
+}
+
+// Prefixes of the collector metrics counting received and dropped records.
+// The rest of the name is the record kind, e.g. spans.
+var (
+	receivedPrefixes = []string{
+		"otelcol_receiver_accepted_",
+	}
+	droppedPrefixes = []string{
+		"otelcol_receiver_refused_",
+		"otelcol_exporter_send_failed_",
+		"otelcol_exporter_enqueue_failed_",
+	}
+)
+
+// Names of the metrics read besides the prefixed collector counters.
+const (
+	queueSizeName          = "otelcol_exporter_queue_size"
+	requestRecordsName     = "tfo_receiver_request_records"
+	provenanceRejectedName = "tfo_receiver_provenance_rejected"
+)
+
+// stats are the totals read from one scrape of a child collector.
+type stats struct {
+	received  [len(signals)]float64
+	dropped   [len(signals)]float64
+	queueSize float64
+}
+
+// parseStats reads the metric families of a Prometheus exposition in format
+// and sums them into stats. Unknown metrics are ignored.
+func parseStats(r io.Reader, format expfmt.Format) (stats, error) {
+	var st stats
+	dec := expfmt.NewDecoder(r, format)
+	for {
+		var f dto.MetricFamily
+		if err := dec.Decode(&f); err != nil {
+			if errors.Is(err, io.EOF) {
+				return st, nil
+			}
+			return stats{}, err
+		}
+		st.add(&f)
+	}
+}
+
+// add sums the samples of f into st.
+func (st *stats) add(f *dto.MetricFamily) {
+	name := strings.TrimSuffix(f.GetName(), "_total")
+	switch name {
+	case queueSizeName:
+		for _, m := range f.GetMetric() {
+			st.queueSize += value(m)
+		}
+		return
+	case requestRecordsName:
+		// The TFO OTLP receiver records the records of each request in a
+		// histogram; its sum is the number of records received.
+		for _, m := range f.GetMetric() {
+			if i, ok := signalIndex[label(m, "signal")]; ok {
+				st.received[i] += m.GetHistogram().GetSampleSum()
+			}
+		}
+		return
+	case provenanceRejectedName:
+		for _, m := range f.GetMetric() {
+			if i, ok := signalIndex[label(m, "signal")]; ok {
+				st.dropped[i] += value(m)
+			}
+		}
+		return
+	}
+
+	if i, ok := match(name, receivedPrefixes); ok {
+		for _, m := range f.GetMetric() {
+			st.received[i] += value(m)
+		}
+	} else if i, ok := match(name, droppedPrefixes); ok {
+		for _, m := range f.GetMetric() {
+			st.dropped[i] += value(m)
+		}
+	}
+}
+
+// match reports the signal index of name if it is one of prefixes followed
+// by a record kind.
+func match(name string, prefixes []string) (int, bool) {
+	for _, prefix := range prefixes {
+		if kind, ok := strings.CutPrefix(name, prefix); ok {
+			i, ok := kindIndex[kind]
+			return i, ok
+		}
+	}
+	return 0, false
+}
+
+// value returns the value of a counter, gauge or untyped sample.
+func value(m *dto.Metric) float64 {
+	switch {
+	case m.GetCounter() != nil:
+		return m.GetCounter().GetValue()
+	case m.GetGauge() != nil:
+		return m.GetGauge().GetValue()
+	default:
+		return m.GetUntyped().GetValue()
+	}
+}
+
+// label returns the value of the label name of m, or "".
+func label(m *dto.Metric, name string) string {
+	for _, l := range m.GetLabel() {
+		if l.GetName() == name {
+			return l.GetValue()
+		}
+	}
+	return ""
+}
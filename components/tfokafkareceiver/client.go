@@ -0,0 +1,127 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfokafkareceiver
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/twmb/franz-go/pkg/kerr"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"github.com/twmb/franz-go/pkg/kmsg"
+	"github.com/twmb/franz-go/pkg/sasl"
+	"github.com/twmb/franz-go/pkg/sasl/plain"
+	"github.com/twmb/franz-go/pkg/sasl/scram"
+)
+
+// clientOptions returns the options of the Kafka client of the receiver.
+// The client both consumes the topics of the signal and produces to the
+// dead letter topic.
+func (r *kafkaReceiver) clientOptions(ctx context.Context) ([]kgo.Opt, error) {
+	offset := kgo.NewOffset().AtEnd()
+	if r.cfg.InitialOffset == OffsetEarliest {
+		offset = kgo.NewOffset().AtStart()
+	}
+	opts := []kgo.Opt{
+		kgo.SeedBrokers(r.cfg.Brokers...),
+		kgo.ClientID(r.cfg.ClientID),
+		kgo.ConsumerGroup(r.cfg.GroupID),
+		kgo.ConsumeTopics(r.topics.Topics...),
+		kgo.ConsumeResetOffset(offset),
+		kgo.SessionTimeout(r.cfg.SessionTimeout),
+
+		// Only the offsets of messages that are done with are committed,
+		// and partitions are not revoked in the middle of a batch.
+		kgo.AutoCommitMarks(),
+		kgo.AutoCommitCallback(r.onAutoCommit),
+		kgo.BlockRebalanceOnPoll(),
+		kgo.OnPartitionsRevoked(r.onRevoked),
+		// Lost partitions may already belong to another member, whose
+		// commits must not be rewound.
+		kgo.OnPartitionsLost(func(context.Context, *kgo.Client, map[string][]int32) {}),
+	}
+	// With the sync strategy, run commits after every batch, and the
+	// background commits of the client find nothing left to commit.
+	if r.cfg.Commit.Strategy == CommitPeriodic {
+		opts = append(opts, kgo.AutoCommitInterval(r.cfg.Commit.Interval))
+	}
+
+	if r.cfg.TLS != nil {
+		tlsCfg, err := r.cfg.TLS.LoadTLSConfig(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load TLS config: %w", err)
+		}
+		opts = append(opts, kgo.DialTLSConfig(tlsCfg))
+	}
+	if r.cfg.SASL != nil {
+		opts = append(opts, kgo.SASL(r.cfg.SASL.mechanism()))
+	}
+	return opts, nil
+}
+
+// onRevoked commits the offsets of the messages processed on partitions
+// moving to another member, which resumes after them.
+func (r *kafkaReceiver) onRevoked(ctx context.Context, client *kgo.Client, _ map[string][]int32) {
+	r.commit(ctx, client)
+}
+
+// onAutoCommit reports the outcome of a periodic commit.
+func (r *kafkaReceiver) onAutoCommit(_ *kgo.Client, _ *kmsg.OffsetCommitRequest, resp *kmsg.OffsetCommitResponse, err error) {
+	if err == nil {
+		err = commitError(resp)
+	}
+	r.commitDone(err)
+}
+
+// commit synchronously commits the offsets of the processed messages.
+func (r *kafkaReceiver) commit(ctx context.Context, client *kgo.Client) {
+	r.commitDone(client.CommitMarkedOffsets(ctx))
+}
+
+// commitDone reports the outcome of a commit.
+func (r *kafkaReceiver) commitDone(err error) {
+	if err != nil {
+		r.failures.Error(commitFailed, err)
+		return
+	}
+	r.failures.Success(commitFailed)
+}
+
+// commitError returns the first partition error of a commit response.
+func commitError(resp *kmsg.OffsetCommitResponse) error {
+	if resp == nil {
+		return nil
+	}
+	for _, topic := range resp.Topics {
+		for _, partition := range topic.Partitions {
+			if err := kerr.ErrorForCode(partition.ErrorCode); err != nil {
+				return fmt.Errorf("%s[%d]: %w", topic.Topic, partition.Partition, err)
+			}
+		}
+	}
+	return nil
+}
+
+// mechanism returns the SASL mechanism.
+func (s *SASLConfig) mechanism() sasl.Mechanism {
+	switch s.Mechanism {
+	case MechanismSCRAMSHA256:
+		return scram.Auth{User: s.Username, Pass: string(s.Password)}.AsSha256Mechanism()
+	case MechanismSCRAMSHA512:
+		return scram.Auth{User: s.Username, Pass: string(s.Password)}.AsSha512Mechanism()
+	default:
+		return plain.Auth{User: s.Username, Pass: string(s.Password)}.AsMechanism()
+	}
+}
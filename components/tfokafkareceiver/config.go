@@ -0,0 +1,265 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfokafkareceiver
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+// Payload encodings a topic can carry.
+const (
+	// EncodingOTLPProto is an OTLP export request encoded as protobuf, as
+	// written by the kafka exporter by default.
+	EncodingOTLPProto = "otlp_proto"
+	// EncodingOTLPJSON is an OTLP export request encoded as JSON.
+	EncodingOTLPJSON = "otlp_json"
+)
+
+// Offsets a consumer group without committed offsets starts from.
+const (
+	OffsetLatest   = "latest"
+	OffsetEarliest = "earliest"
+)
+
+// Offset commit strategies.
+const (
+	// CommitPeriodic commits the offsets of processed messages in the
+	// background every commit.interval.
+	CommitPeriodic = "periodic"
+	// CommitSync commits the offsets of a batch of fetched messages
+	// before the next batch is fetched.
+	CommitSync = "sync"
+)
+
+// SASL mechanisms.
+const (
+	MechanismPlain       = "PLAIN"
+	MechanismSCRAMSHA256 = "SCRAM-SHA-256"
+	MechanismSCRAMSHA512 = "SCRAM-SHA-512"
+)
+
+// Config defines the configuration for the TFO Kafka receiver.
+type Config struct {
+	// Brokers are the addresses of the Kafka brokers used to discover the
+	// cluster.
+	// Default: [localhost:9092]
+	Brokers []string `mapstructure:"brokers"`
+
+	// ClientID identifies the receiver to the brokers.
+	// Default: tfo-collector
+	ClientID string `mapstructure:"client_id"`
+
+	// GroupID is the consumer group the receiver joins. Collectors sharing
+	// a group split the partitions of the topics between them.
+	// Default: tfo-collector
+	GroupID string `mapstructure:"group_id"`
+
+	// TLS enables TLS to the brokers. Plaintext is used when unset.
+	TLS *configtls.ClientConfig `mapstructure:"tls"`
+
+	// SASL authenticates the receiver to the brokers.
+	SASL *SASLConfig `mapstructure:"sasl"`
+
+	// Traces, Metrics and Logs are the topics consumed by the traces,
+	// metrics and logs pipelines.
+	Traces  SignalConfig `mapstructure:"traces"`
+	Metrics SignalConfig `mapstructure:"metrics"`
+	Logs    SignalConfig `mapstructure:"logs"`
+
+	// InitialOffset is where the group starts on partitions it has no
+	// committed offset for: latest or earliest.
+	// Default: latest
+	InitialOffset string `mapstructure:"initial_offset"`
+
+	// Commit configures when consumed offsets are committed.
+	Commit CommitConfig `mapstructure:"commit"`
+
+	// DeadLetter configures the topic poison messages are moved to.
+	DeadLetter DeadLetterConfig `mapstructure:"dead_letter"`
+
+	// SessionTimeout is how long the group waits for a heartbeat before
+	// it reassigns the partitions of the receiver.
+	// Default: 45s
+	SessionTimeout time.Duration `mapstructure:"session_timeout"`
+}
+
+// SignalConfig defines the topics of a signal.
+type SignalConfig struct {
+	// Topics are the topics consumed.
+	Topics []string `mapstructure:"topics"`
+
+	// Encoding is the payload encoding: otlp_proto or otlp_json.
+	// Default: otlp_proto
+	Encoding string `mapstructure:"encoding"`
+}
+
+// SASLConfig defines SASL authentication.
+type SASLConfig struct {
+	// Mechanism is PLAIN, SCRAM-SHA-256 or SCRAM-SHA-512.
+	Mechanism string              `mapstructure:"mechanism"`
+	Username  string              `mapstructure:"username"`
+	Password  configopaque.String `mapstructure:"password"`
+}
+
+// CommitConfig defines the offset commit strategy. Whatever the strategy,
+// only the offsets of messages accepted by the pipeline or moved to the
+// dead letter topic are committed, and pending offsets are committed when
+// partitions are revoked and on shutdown.
+type CommitConfig struct {
+	// Strategy is periodic or sync. sync bounds the messages consumed
+	// twice after a crash to one batch, at the cost of a commit round
+	// trip per batch.
+	// Default: periodic
+	Strategy string `mapstructure:"strategy"`
+
+	// Interval is the period of the periodic strategy.
+	// Default: 5s
+	Interval time.Duration `mapstructure:"interval"`
+}
+
+// DeadLetterConfig defines the dead letter topic.
+type DeadLetterConfig struct {
+	// Topic receives the messages that can never be consumed: payloads
+	// that do not decode, and payloads the pipeline refuses permanently.
+	// They are dropped when unset.
+	Topic string `mapstructure:"topic"`
+}
+
+// Validate checks the configuration for errors.
+func (cfg *Config) Validate() error {
+	if len(cfg.Brokers) == 0 {
+		return errors.New("at least one broker is required")
+	}
+	if slices.Contains(cfg.Brokers, "") {
+		return errors.New("brokers must not be empty")
+	}
+	if cfg.ClientID == "" {
+		return errors.New("client_id is required")
+	}
+	if cfg.GroupID == "" {
+		return errors.New("group_id is required")
+	}
+	if cfg.TLS != nil {
+		if err := cfg.TLS.Validate(); err != nil {
+			return fmt.Errorf("tls: %w", err)
+		}
+	}
+	if cfg.SASL != nil {
+		if err := cfg.SASL.validate(); err != nil {
+			return fmt.Errorf("sasl: %w", err)
+		}
+	}
+
+	seen := make(map[string]string)
+	for _, signal := range cfg.signals() {
+		if err := signal.cfg.validate(); err != nil {
+			return fmt.Errorf("%s: %w", signal.name, err)
+		}
+		for _, topic := range signal.cfg.Topics {
+			if other, dup := seen[topic]; dup {
+				return fmt.Errorf("%s: topic %q is also consumed by %s", signal.name, topic, other)
+			}
+			seen[topic] = signal.name
+		}
+	}
+
+	switch cfg.InitialOffset {
+	case OffsetLatest, OffsetEarliest:
+	default:
+		return fmt.Errorf("initial_offset must be %q or %q", OffsetLatest, OffsetEarliest)
+	}
+	switch cfg.Commit.Strategy {
+	case CommitPeriodic:
+		if cfg.Commit.Interval <= 0 {
+			return errors.New("commit: interval must be positive")
+		}
+	case CommitSync:
+	default:
+		return fmt.Errorf("commit: strategy must be %q or %q", CommitPeriodic, CommitSync)
+	}
+	if signal, consumed := seen[cfg.DeadLetter.Topic]; consumed {
+		return fmt.Errorf("dead_letter: topic %q is consumed by %s", cfg.DeadLetter.Topic, signal)
+	}
+	if cfg.SessionTimeout <= 0 {
+		return errors.New("session_timeout must be positive")
+	}
+	return nil
+}
+
+// namedSignal is the configuration of a signal and its name.
+type namedSignal struct {
+	name string
+	cfg  *SignalConfig
+}
+
+// signals returns the configuration of every signal, in a stable order.
+func (cfg *Config) signals() []namedSignal {
+	return []namedSignal{
+		{"traces", &cfg.Traces},
+		{"metrics", &cfg.Metrics},
+		{"logs", &cfg.Logs},
+	}
+}
+
+// validate checks the topics of a signal for errors.
+func (s *SignalConfig) validate() error {
+	if len(s.Topics) == 0 {
+		return errors.New("at least one topic is required")
+	}
+	for i, topic := range s.Topics {
+		if topic == "" {
+			return fmt.Errorf("topics[%d]: topic is required", i)
+		}
+		if slices.Contains(s.Topics[:i], topic) {
+			return fmt.Errorf("topics[%d]: duplicate topic %q", i, topic)
+		}
+	}
+	switch s.Encoding {
+	case "", EncodingOTLPProto, EncodingOTLPJSON:
+	default:
+		return fmt.Errorf("encoding must be %q or %q", EncodingOTLPProto, EncodingOTLPJSON)
+	}
+	return nil
+}
+
+// encoding returns the payload encoding.
+func (s *SignalConfig) encoding() string {
+	if s.Encoding == "" {
+		return EncodingOTLPProto
+	}
+	return s.Encoding
+}
+
+// validate checks the SASL configuration for errors.
+func (s *SASLConfig) validate() error {
+	switch s.Mechanism {
+	case MechanismPlain, MechanismSCRAMSHA256, MechanismSCRAMSHA512:
+	default:
+		return fmt.Errorf("mechanism must be %q, %q or %q", MechanismPlain, MechanismSCRAMSHA256, MechanismSCRAMSHA512)
+	}
+	if s.Username == "" {
+		return errors.New("username is required")
+	}
+	if s.Password == "" {
+		return errors.New("password is required")
+	}
+	return nil
+}
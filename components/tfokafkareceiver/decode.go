@@ -0,0 +1,78 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfokafkareceiver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// errMalformed marks payloads that can never be decoded. They are moved
+// to the dead letter topic rather than retried.
+var errMalformed = errors.New("malformed payload")
+
+// consumeFunc decodes a payload and passes it to the pipeline.
+type consumeFunc func(ctx context.Context, payload []byte) error
+
+// tracesConsumer returns the consumeFunc of a traces receiver.
+func tracesConsumer(s *SignalConfig, next consumer.Traces) consumeFunc {
+	var u ptrace.Unmarshaler = &ptrace.ProtoUnmarshaler{}
+	if s.encoding() == EncodingOTLPJSON {
+		u = &ptrace.JSONUnmarshaler{}
+	}
+	return func(ctx context.Context, payload []byte) error {
+		td, err := u.UnmarshalTraces(payload)
+		if err != nil {
+			return fmt.Errorf("%w: %w", errMalformed, err)
+		}
+		return next.ConsumeTraces(ctx, td)
+	}
+}
+
+// metricsConsumer returns the consumeFunc of a metrics receiver.
+func metricsConsumer(s *SignalConfig, next consumer.Metrics) consumeFunc {
+	var u pmetric.Unmarshaler = &pmetric.ProtoUnmarshaler{}
+	if s.encoding() == EncodingOTLPJSON {
+		u = &pmetric.JSONUnmarshaler{}
+	}
+	return func(ctx context.Context, payload []byte) error {
+		md, err := u.UnmarshalMetrics(payload)
+		if err != nil {
+			return fmt.Errorf("%w: %w", errMalformed, err)
+		}
+		return next.ConsumeMetrics(ctx, md)
+	}
+}
+
+// logsConsumer returns the consumeFunc of a logs receiver.
+func logsConsumer(s *SignalConfig, next consumer.Logs) consumeFunc {
+	var u plog.Unmarshaler = &plog.ProtoUnmarshaler{}
+	if s.encoding() == EncodingOTLPJSON {
+		u = &plog.JSONUnmarshaler{}
+	}
+	return func(ctx context.Context, payload []byte) error {
+		ld, err := u.UnmarshalLogs(payload)
+		if err != nil {
+			return fmt.Errorf("%w: %w", errMalformed, err)
+		}
+		return next.ConsumeLogs(ctx, ld)
+	}
+}
@@ -0,0 +1,83 @@
+// Package tfokafkareceiver consumes OTLP payloads from Kafka topics and
+// feeds them into the traces, metrics and logs pipelines.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// The receiver reads the topics written by the kafka exporter, so
+// telemetry buffered in Kafka can be replayed through the pipelines of
+// the collector. Each signal has its own topics and encoding:
+//
+//	otlp_proto  an OTLP export request encoded as protobuf
+//	otlp_json   an OTLP export request encoded as JSON
+//
+// The traces, metrics and logs instances of the receiver join the same
+// consumer group, each subscribed to the topics of its signal. Collectors
+// sharing group_id split the partitions between them, and the partitions
+// of a collector that stops are reassigned to the others.
+//
+// Offsets are committed at least once: a message is committed once the
+// pipeline accepted it, or once it was moved to the dead letter topic.
+// With the periodic strategy, offsets are committed in the background
+// every commit.interval; with sync, they are committed after every batch.
+// Offsets are also committed when partitions are revoked and on shutdown,
+// so messages are only consumed twice after a crash.
+//
+// Messages are consumed in offset order within a partition and
+// concurrently across partitions. A message refused by the pipeline is
+// retried with backoff, holding back its partition, and rebalances of the
+// group wait for the batch being consumed. A poison message, whose
+// payload does not decode or which the pipeline refuses permanently, is
+// produced to dead_letter.topic with its key, value and headers, plus the
+// headers:
+//
+//	tfo.dead_letter.topic      the topic it was consumed from
+//	tfo.dead_letter.partition  its partition
+//	tfo.dead_letter.offset     its offset
+//	tfo.dead_letter.error      why it could not be consumed
+//
+// Without a dead letter topic, poison messages are dropped.
+//
+// Configuration example:
+//
+//	receivers:
+//	  tfokafka:
+//	    brokers: [kafka-0:9093, kafka-1:9093]
+//	    group_id: tfo-replay
+//	    initial_offset: earliest
+//	    tls:
+//	      ca_file: /etc/tfo/kafka-ca.pem
+//	    sasl:
+//	      mechanism: SCRAM-SHA-512
+//	      username: collector
+//	      password: ${env:KAFKA_PASSWORD}
+//	    traces:
+//	      topics: [otlp_spans]
+//	    metrics:
+//	      topics: [otlp_metrics]
+//	    logs:
+//	      topics: [otlp_logs, otlp_logs_json]
+//	      encoding: otlp_json
+//	    commit:
+//	      strategy: sync
+//	    dead_letter:
+//	      topic: otlp_dead_letter
+//
+//	service:
+//	  pipelines:
+//	    traces/replay:
+//	      receivers: [tfokafka]
+//	      exporters: [tfo]
+package tfokafkareceiver // import "github.com/telemetryflow/telemetryflow-collector/components/tfokafkareceiver"
@@ -0,0 +1,101 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfokafkareceiver
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+const (
+	// TypeStr is the type string identifier for the TFO Kafka receiver.
+	TypeStr = "tfokafka"
+
+	// Defaults
+	defaultBroker         = "localhost:9092"
+	defaultClientID       = "tfo-collector"
+	defaultGroupID        = "tfo-collector"
+	defaultCommitInterval = 5 * time.Second
+	defaultSessionTimeout = 45 * time.Second
+
+	// Default topics, matching those written by the kafka exporter.
+	defaultTracesTopic  = "otlp_spans"
+	defaultMetricsTopic = "otlp_metrics"
+	defaultLogsTopic    = "otlp_logs"
+)
+
+// NewFactory creates a new factory for the TFO Kafka receiver.
+func NewFactory() receiver.Factory {
+	return receiver.NewFactory(
+		component.MustNewType(TypeStr),
+		createDefaultConfig,
+		receiver.WithTraces(createTracesReceiver, component.StabilityLevelAlpha),
+		receiver.WithMetrics(createMetricsReceiver, component.StabilityLevelAlpha),
+		receiver.WithLogs(createLogsReceiver, component.StabilityLevelAlpha),
+	)
+}
+
+// createDefaultConfig creates the default configuration for the receiver.
+func createDefaultConfig() component.Config {
+	return &Config{
+		Brokers:        []string{defaultBroker},
+		ClientID:       defaultClientID,
+		GroupID:        defaultGroupID,
+		Traces:         SignalConfig{Topics: []string{defaultTracesTopic}},
+		Metrics:        SignalConfig{Topics: []string{defaultMetricsTopic}},
+		Logs:           SignalConfig{Topics: []string{defaultLogsTopic}},
+		InitialOffset:  OffsetLatest,
+		Commit:         CommitConfig{Strategy: CommitPeriodic, Interval: defaultCommitInterval},
+		SessionTimeout: defaultSessionTimeout,
+	}
+}
+
+// createTracesReceiver creates a traces receiver.
+func createTracesReceiver(
+	_ context.Context,
+	set receiver.Settings,
+	cfg component.Config,
+	next consumer.Traces,
+) (receiver.Traces, error) {
+	c := cfg.(*Config)
+	return newKafkaReceiver(c, set, pipeline.SignalTraces, &c.Traces, tracesConsumer(&c.Traces, next)), nil
+}
+
+// createMetricsReceiver creates a metrics receiver.
+func createMetricsReceiver(
+	_ context.Context,
+	set receiver.Settings,
+	cfg component.Config,
+	next consumer.Metrics,
+) (receiver.Metrics, error) {
+	c := cfg.(*Config)
+	return newKafkaReceiver(c, set, pipeline.SignalMetrics, &c.Metrics, metricsConsumer(&c.Metrics, next)), nil
+}
+
+// createLogsReceiver creates a logs receiver.
+func createLogsReceiver(
+	_ context.Context,
+	set receiver.Settings,
+	cfg component.Config,
+	next consumer.Logs,
+) (receiver.Logs, error) {
+	c := cfg.(*Config)
+	return newKafkaReceiver(c, set, pipeline.SignalLogs, &c.Logs, logsConsumer(&c.Logs, next)), nil
+}
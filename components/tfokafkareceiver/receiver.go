@@ -0,0 +1,298 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfokafkareceiver
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/twmb/franz-go/pkg/kgo"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/errlog"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+const scopeName = "github.com/telemetryflow/telemetryflow-collector/components/tfokafkareceiver"
+
+// Headers added to the messages moved to the dead letter topic, next to
+// the headers of the original message.
+const (
+	// HeaderDeadLetterTopic is the topic the message was consumed from.
+	HeaderDeadLetterTopic = "tfo.dead_letter.topic"
+	// HeaderDeadLetterPartition is the partition the message was
+	// consumed from, in decimal.
+	HeaderDeadLetterPartition = "tfo.dead_letter.partition"
+	// HeaderDeadLetterOffset is the offset of the message, in decimal.
+	HeaderDeadLetterOffset = "tfo.dead_letter.offset"
+	// HeaderDeadLetterError is why the message could not be consumed.
+	HeaderDeadLetterError = "tfo.dead_letter.error"
+)
+
+// Log messages of the failures aggregated by the receiver.
+const (
+	fetchFailed      = "Failed to fetch Kafka messages"
+	commitFailed     = "Failed to commit Kafka offsets"
+	consumeFailed    = "Failed to consume Kafka message, retrying"
+	deadLetterFailed = "Failed to produce Kafka message to the dead letter topic, retrying"
+	messageRejected  = "Moved Kafka message to the dead letter topic"
+	messageDropped   = "Dropped Kafka message"
+)
+
+// Outcomes recorded on the message counter.
+const (
+	outcomeAccepted     = "accepted"
+	outcomeDeadLettered = "dead_lettered"
+	outcomeDropped      = "dropped"
+)
+
+// Backoff of the retries of a message refused by the pipeline, and of
+// the dead letter topic.
+const (
+	retryInitialInterval = 100 * time.Millisecond
+	retryMaxInterval     = 5 * time.Second
+)
+
+// kafkaReceiver consumes the topics of one signal.
+type kafkaReceiver struct {
+	cfg      *Config
+	set      receiver.Settings
+	topics   *SignalConfig
+	consume  consumeFunc
+	failures *errlog.Aggregator
+
+	labels   selfmetrics.Labels
+	messages metric.Int64Counter
+
+	client *kgo.Client
+
+	// ctx is cancelled on shutdown to stop polling and abandon retries.
+	// Messages not done with are not committed and are consumed again by
+	// the next owner of their partition.
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// newKafkaReceiver creates the receiver of signal.
+func newKafkaReceiver(cfg *Config, set receiver.Settings, signal pipeline.Signal, topics *SignalConfig, consume consumeFunc) *kafkaReceiver {
+	logger := set.Logger.With(zap.String("group_id", cfg.GroupID), zap.String("signal", signal.String()))
+	return &kafkaReceiver{
+		cfg:      cfg,
+		set:      set,
+		topics:   topics,
+		consume:  consume,
+		failures: errlog.New(logger, errlog.DefaultInterval),
+		labels:   selfmetrics.Receiver(set.ID).WithSignal(signal),
+	}
+}
+
+// Start implements component.Component. It does not wait for the brokers:
+// the client connects and joins the group in the background.
+func (r *kafkaReceiver) Start(ctx context.Context, _ component.Host) error {
+	if r.set.MeterProvider != nil {
+		var err error
+		r.messages, err = r.set.MeterProvider.Meter(scopeName).Int64Counter(selfmetrics.ReceiverKafkaMessages,
+			metric.WithDescription("Kafka messages consumed, by outcome."),
+			metric.WithUnit("{message}"))
+		if err != nil {
+			return err
+		}
+	}
+
+	opts, err := r.clientOptions(ctx)
+	if err != nil {
+		return err
+	}
+	if r.client, err = kgo.NewClient(opts...); err != nil {
+		return err
+	}
+	r.ctx, r.cancel = context.WithCancel(context.Background())
+	r.done = make(chan struct{})
+	go r.run()
+	return nil
+}
+
+// Shutdown implements component.Component. It waits for the messages
+// being consumed, commits the offsets of those done with and leaves the
+// group.
+func (r *kafkaReceiver) Shutdown(ctx context.Context) error {
+	if r.cancel == nil {
+		return nil
+	}
+	r.cancel()
+	select {
+	case <-r.done:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	r.commit(ctx, r.client)
+	r.client.CloseAllowingRebalance()
+	return nil
+}
+
+// run polls batches of messages until shutdown. Rebalances are held off
+// while a batch is consumed, so that no partition changes owner with
+// messages in flight.
+func (r *kafkaReceiver) run() {
+	defer close(r.done)
+	for {
+		fetches := r.client.PollFetches(r.ctx)
+		if r.ctx.Err() != nil || fetches.IsClientClosed() {
+			return
+		}
+		r.fetched(fetches.Errors())
+		r.process(fetches)
+		if r.ctx.Err() != nil {
+			return
+		}
+		if r.cfg.Commit.Strategy == CommitSync {
+			r.commit(r.ctx, r.client)
+		}
+		r.client.AllowRebalance()
+	}
+}
+
+// fetched reports the errors of a poll.
+func (r *kafkaReceiver) fetched(errs []kgo.FetchError) {
+	for _, fe := range errs {
+		r.failures.Error(fetchFailed, fe.Err, zap.String("topic", fe.Topic), zap.Int32("partition", fe.Partition))
+	}
+	if len(errs) == 0 {
+		r.failures.Success(fetchFailed)
+	}
+}
+
+// process consumes a batch of messages. Partitions are consumed
+// concurrently, each in offset order, and a message is marked for commit
+// once it is done with.
+func (r *kafkaReceiver) process(fetches kgo.Fetches) {
+	var wg sync.WaitGroup
+	fetches.EachPartition(func(p kgo.FetchTopicPartition) {
+		if len(p.Records) == 0 {
+			return
+		}
+		wg.Go(func() {
+			for _, rec := range p.Records {
+				if !r.handle(rec) {
+					return
+				}
+				r.client.MarkCommitRecords(rec)
+			}
+		})
+	})
+	wg.Wait()
+}
+
+// handle consumes a message, retrying while the pipeline refuses it. It
+// reports whether the message is done with, which is false only when the
+// receiver shuts down first.
+func (r *kafkaReceiver) handle(rec *kgo.Record) bool {
+	backoff := retryInitialInterval
+	for {
+		err := r.consume(r.ctx, rec.Value)
+		switch {
+		case err == nil:
+			r.failures.Success(consumeFailed)
+			r.record(outcomeAccepted)
+			return true
+		case errors.Is(err, errMalformed) || consumererror.IsPermanent(err):
+			return r.deadLetter(rec, err)
+		}
+		r.failures.Error(consumeFailed, err, recordFields(rec)...)
+		if !r.wait(&backoff) {
+			return false
+		}
+	}
+}
+
+// deadLetter moves a message that can never be consumed to the dead
+// letter topic, or drops it when there is none. It reports whether the
+// message is done with.
+func (r *kafkaReceiver) deadLetter(rec *kgo.Record, cause error) bool {
+	topic := r.cfg.DeadLetter.Topic
+	if topic == "" {
+		r.failures.Error(messageDropped, cause, recordFields(rec)...)
+		r.record(outcomeDropped)
+		return true
+	}
+
+	dead := &kgo.Record{
+		Topic:     topic,
+		Key:       rec.Key,
+		Value:     rec.Value,
+		Timestamp: rec.Timestamp,
+		Headers: append(slices.Clone(rec.Headers),
+			kgo.RecordHeader{Key: HeaderDeadLetterTopic, Value: []byte(rec.Topic)},
+			kgo.RecordHeader{Key: HeaderDeadLetterPartition, Value: strconv.AppendInt(nil, int64(rec.Partition), 10)},
+			kgo.RecordHeader{Key: HeaderDeadLetterOffset, Value: strconv.AppendInt(nil, rec.Offset, 10)},
+			kgo.RecordHeader{Key: HeaderDeadLetterError, Value: []byte(cause.Error())},
+		),
+	}
+	backoff := retryInitialInterval
+	for {
+		err := r.client.ProduceSync(r.ctx, dead).FirstErr()
+		if err == nil {
+			r.failures.Success(deadLetterFailed)
+			r.failures.Error(messageRejected, cause, append(recordFields(rec), zap.String("dead_letter_topic", topic))...)
+			r.record(outcomeDeadLettered)
+			return true
+		}
+		r.failures.Error(deadLetterFailed, err, append(recordFields(rec), zap.String("dead_letter_topic", topic))...)
+		if !r.wait(&backoff) {
+			return false
+		}
+	}
+}
+
+// wait sleeps for backoff and doubles it. It reports false when the
+// receiver shuts down first.
+func (r *kafkaReceiver) wait(backoff *time.Duration) bool {
+	timer := time.NewTimer(*backoff)
+	defer timer.Stop()
+	select {
+	case <-r.ctx.Done():
+		return false
+	case <-timer.C:
+	}
+	*backoff = min(2**backoff, retryMaxInterval)
+	return true
+}
+
+// record counts a message with outcome.
+func (r *kafkaReceiver) record(outcome string) {
+	if r.messages != nil {
+		r.messages.Add(context.Background(), 1, r.labels.Option(attribute.String("outcome", outcome)))
+	}
+}
+
+// recordFields returns the log fields locating a message.
+func recordFields(rec *kgo.Record) []zap.Field {
+	return []zap.Field{
+		zap.String("topic", rec.Topic),
+		zap.Int32("partition", rec.Partition),
+		zap.Int64("offset", rec.Offset),
+	}
+}
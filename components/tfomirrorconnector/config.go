@@ -0,0 +1,78 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfomirrorconnector
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+// Config defines the configuration for the TFO mirror connector.
+type Config struct {
+	// Primary are the pipelines receiving every batch. Their outcome is
+	// returned to the sending pipeline.
+	Primary []pipeline.ID `mapstructure:"primary"`
+
+	// Shadow are the pipelines receiving a copy of the mirrored batches.
+	// Their outcome is only counted.
+	Shadow []pipeline.ID `mapstructure:"shadow"`
+
+	// Percentage is the share of batches copied to the shadow pipelines,
+	// from 0 to 100.
+	// Default: 10
+	Percentage float64 `mapstructure:"percentage"`
+
+	// QueueSize bounds the copies waiting for the shadow pipelines. Copies
+	// arriving while the queue is full are dropped.
+	// Default: 100
+	QueueSize int `mapstructure:"queue_size"`
+
+	// Experiment compares what the primary and shadow pipelines do with
+	// the mirrored batches, e.g. two processor chains. Both must end in a
+	// tfoexperiment exporter and consume synchronously.
+	// Default: false
+	Experiment bool `mapstructure:"experiment"`
+
+	// Overrides is a reference to a tfooverrides extension. Its
+	// percentage for this connector replaces the configured one without a
+	// configuration reload.
+	Overrides component.ID `mapstructure:"overrides"`
+}
+
+// Validate checks the configuration for errors.
+func (cfg *Config) Validate() error {
+	if len(cfg.Primary) == 0 {
+		return errors.New("primary must list at least one pipeline")
+	}
+	if len(cfg.Shadow) == 0 {
+		return errors.New("shadow must list at least one pipeline")
+	}
+	for _, id := range cfg.Shadow {
+		if slices.Contains(cfg.Primary, id) {
+			return fmt.Errorf("pipeline %q is listed as both primary and shadow", id)
+		}
+	}
+	if cfg.Percentage < 0 || cfg.Percentage > 100 {
+		return errors.New("percentage must be between 0 and 100")
+	}
+	if cfg.QueueSize <= 0 {
+		return errors.New("queue_size must be positive")
+	}
+	return nil
+}
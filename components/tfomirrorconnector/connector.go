@@ -0,0 +1,283 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfomirrorconnector
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+const scopeName = "github.com/telemetryflow/telemetryflow-collector/components/tfomirrorconnector"
+
+// Paths and outcomes recorded on the batch counter.
+const (
+	pathPrimary = "primary"
+	pathShadow  = "shadow"
+
+	outcomeSuccess = "success"
+	outcomeFailure = "failure"
+	outcomeDropped = "dropped"
+)
+
+// mirror passes every batch to the primary consumer and a sampled copy to
+// the shadow consumer, which runs on its own goroutine so that it can
+// neither slow down nor fail the primary path.
+type mirror[T any] struct {
+	cfg    *Config
+	id     component.ID
+	logger *zap.Logger
+
+	// overrides replaces the percentage; nil without an overrides
+	// extension.
+	overrides OverridesProvider
+
+	primary func(context.Context, T) error
+	shadow  func(context.Context, T) error
+	clone   func(T) T
+	measure func(T) size
+
+	queue chan batch[T]
+
+	labels     selfmetrics.Labels
+	batches    metric.Int64Counter
+	experiment *experimentStats
+
+	cancel context.CancelFunc
+	done   sync.WaitGroup
+}
+
+func newMirror[T any](
+	cfg *Config,
+	id component.ID,
+	set component.TelemetrySettings,
+	labels selfmetrics.Labels,
+	primary, shadow func(context.Context, T) error,
+	clone func(T) T,
+	measure func(T) size,
+) (*mirror[T], error) {
+	m := &mirror[T]{
+		cfg:     cfg,
+		id:      id,
+		logger:  set.Logger,
+		primary: primary,
+		shadow:  shadow,
+		clone:   clone,
+		measure: measure,
+		queue:   make(chan batch[T], cfg.QueueSize),
+		labels:  labels,
+	}
+	if set.MeterProvider != nil {
+		var err error
+		meter := set.MeterProvider.Meter(scopeName)
+		m.batches, err = meter.Int64Counter(selfmetrics.MirrorBatches,
+			metric.WithDescription("Number of batches passed to the primary and shadow pipelines of the mirror connector."),
+			metric.WithUnit("{batch}"))
+		if err != nil {
+			return nil, err
+		}
+		if cfg.Experiment {
+			m.experiment, err = newExperimentStats(meter, labels)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	return m, nil
+}
+
+// Start resolves the overrides extension and starts the shadow worker.
+func (m *mirror[T]) Start(_ context.Context, host component.Host) error {
+	if id := m.cfg.Overrides; id.String() != "" {
+		ext, ok := host.GetExtensions()[id]
+		if !ok {
+			return fmt.Errorf("tfooverrides extension %q not found", id)
+		}
+		provider, ok := ext.(OverridesProvider)
+		if !ok {
+			return fmt.Errorf("extension %q does not provide runtime overrides", id)
+		}
+		m.overrides = provider
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancel = cancel
+	m.done.Add(1)
+	go m.run(ctx)
+	return nil
+}
+
+// Shutdown stops the shadow worker. Queued copies are discarded.
+func (m *mirror[T]) Shutdown(context.Context) error {
+	if m.cancel != nil {
+		m.cancel()
+		m.done.Wait()
+	}
+	return nil
+}
+
+// OverridesProvider is an interface for extensions that override
+// connector settings at runtime.
+type OverridesProvider interface {
+	// ConnectorPercentage returns the share of batches mirrored by
+	// connector id, or ok false when the configured one applies.
+	ConnectorPercentage(id component.ID) (percentage float64, ok bool)
+}
+
+// Capabilities implements the consumer interfaces.
+func (m *mirror[T]) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+// batch is a batch on its way to the primary or shadow pipelines.
+type batch[T any] struct {
+	data T
+
+	// observed reports whether the batch is part of an experiment, in
+	// which case in is its size before entering the pipelines.
+	observed bool
+	in       size
+}
+
+// consume passes data to the primary pipelines and queues a copy for the
+// shadow pipelines when the batch is sampled. Only the primary outcome is
+// returned.
+func (m *mirror[T]) consume(ctx context.Context, data T) error {
+	var (
+		b        = batch[T]{data: data}
+		cp       batch[T]
+		mirrored = m.sampled()
+	)
+	if mirrored {
+		// The primary pipelines may still hold the batch when the shadow
+		// worker reads it, so the shadow gets its own copy.
+		cp = batch[T]{data: m.clone(data)}
+		if m.experiment != nil {
+			// Only mirrored batches are compared, so that both paths
+			// see the same sample.
+			in := m.measure(data)
+			b.observed, b.in = true, in
+			cp.observed, cp.in = true, in
+		}
+	}
+
+	err := m.forward(ctx, pathPrimary, m.primary, b)
+	m.record(ctx, pathPrimary, err)
+
+	if mirrored {
+		select {
+		case m.queue <- cp:
+		default:
+			m.count(ctx, pathShadow, outcomeDropped)
+		}
+	}
+	return err
+}
+
+// forward passes b to next, through the experiment when b is observed.
+func (m *mirror[T]) forward(ctx context.Context, path string, next func(context.Context, T) error, b batch[T]) error {
+	if !b.observed {
+		return next(ctx, b.data)
+	}
+	return m.experiment.observe(ctx, path, b.in, func(ctx context.Context) error {
+		return next(ctx, b.data)
+	})
+}
+
+// sampled reports whether a batch is mirrored, drawing against the
+// overridden or configured percentage.
+func (m *mirror[T]) sampled() bool {
+	percentage := m.cfg.Percentage
+	if m.overrides != nil {
+		if p, ok := m.overrides.ConnectorPercentage(m.id); ok {
+			percentage = p
+		}
+	}
+	switch {
+	case percentage >= 100:
+		return true
+	case percentage <= 0:
+		return false
+	}
+	return rand.Float64()*100 < percentage
+}
+
+// run feeds the queued copies to the shadow pipelines until ctx is done.
+func (m *mirror[T]) run(ctx context.Context) {
+	defer m.done.Done()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case b := <-m.queue:
+			err := m.forward(ctx, pathShadow, m.shadow, b)
+			m.record(ctx, pathShadow, err)
+			if err != nil && ctx.Err() == nil {
+				m.logger.Debug("Shadow pipeline rejected mirrored batch", zap.Error(err))
+			}
+		}
+	}
+}
+
+func (m *mirror[T]) record(ctx context.Context, path string, err error) {
+	if err != nil {
+		m.count(ctx, path, outcomeFailure)
+		return
+	}
+	m.count(ctx, path, outcomeSuccess)
+}
+
+func (m *mirror[T]) count(ctx context.Context, path, outcome string) {
+	if m.batches == nil {
+		return
+	}
+	m.batches.Add(context.WithoutCancel(ctx), 1, m.labels.Option(
+		attribute.String("path", path),
+		attribute.String("outcome", outcome),
+	))
+}
+
+type tracesMirror struct{ *mirror[ptrace.Traces] }
+
+// ConsumeTraces implements consumer.Traces.
+func (m *tracesMirror) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	return m.consume(ctx, td)
+}
+
+type metricsMirror struct{ *mirror[pmetric.Metrics] }
+
+// ConsumeMetrics implements consumer.Metrics.
+func (m *metricsMirror) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	return m.consume(ctx, md)
+}
+
+type logsMirror struct{ *mirror[plog.Logs] }
+
+// ConsumeLogs implements consumer.Logs.
+func (m *logsMirror) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	return m.consume(ctx, ld)
+}
@@ -0,0 +1,77 @@
+// Package tfomirrorconnector mirrors a share of the telemetry of a pipeline to
+// shadow pipelines.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// The connector de-risks backend migrations. It sits at the end of a
+// pipeline and passes every batch to the primary pipelines, as a forward
+// connector would, and a copy of a configurable percentage of batches to
+// the shadow pipelines, e.g. ones exporting to a new backend version.
+//
+// The shadow path never affects the primary path:
+//   - copies are handed to the shadow pipelines by a background worker
+//     through a bounded queue; copies arriving while it is full are dropped
+//   - only the outcome of the primary pipelines is returned to the sending
+//     pipeline
+//
+// Both paths are compared through the tfo_mirror_batches internal metric,
+// which counts batches per path (primary or shadow) and outcome (success,
+// failure or dropped). The shadow outcome is only meaningful when the
+// shadow exporters report errors synchronously, i.e. run without a
+// sending queue.
+//
+// Sampling is per batch; batches are not split. Mirrored batches do not
+// carry the request context, such as client metadata or the request ID, of
+// the original request. The percentage can be changed at runtime through
+// a tfooverrides extension referenced by overrides.
+//
+// # Experiments
+//
+// With experiment enabled, the connector runs an A/B comparison of two
+// processor chains, one on the primary and one on the shadow pipelines.
+// For every mirrored batch it records, per path, the records the pipelines
+// removed (tfo_experiment_records_dropped), the change in encoded size
+// (tfo_experiment_size_delta) and the time they took to consume the batch
+// (tfo_experiment_latency_seconds). The output is measured by tfoexperiment
+// exporters, which must end both pipelines; the shadow pipelines typically
+// have no other exporter, so their output is discarded, or a debug one.
+// Both pipelines must consume synchronously: a batch processor or a
+// sending queue before the tfoexperiment exporter hides the output, which
+// is then counted as dropped. Batches failing a path are not compared.
+//
+// Configuration example:
+//
+//	connectors:
+//	  tfomirror:
+//	    primary: [traces/current]
+//	    shadow: [traces/next]
+//	    percentage: 5
+//	    queue_size: 100
+//	    overrides: tfooverrides
+//
+//	service:
+//	  pipelines:
+//	    traces:
+//	      receivers: [tfootlp]
+//	      processors: [memory_limiter, batch]
+//	      exporters: [tfomirror]
+//	    traces/current:
+//	      receivers: [tfomirror]
+//	      exporters: [tfo]
+//	    traces/next:
+//	      receivers: [tfomirror]
+//	      exporters: [tfo/next]
+package tfomirrorconnector // import "github.com/telemetryflow/telemetryflow-collector/components/tfomirrorconnector"
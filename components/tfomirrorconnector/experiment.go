@@ -0,0 +1,114 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfomirrorconnector
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/experiment"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+// latencyBuckets are the bucket boundaries, in seconds, of the experiment
+// latency histogram.
+var latencyBuckets = []float64{0.0001, 0.0005, 0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5}
+
+// size is the record count and encoded size of a batch.
+type size struct {
+	records int
+	bytes   int
+}
+
+// experimentStats compares what the primary and shadow pipelines of an
+// experiment did with the same batch.
+type experimentStats struct {
+	recordsDropped metric.Int64UpDownCounter
+	sizeDelta      metric.Int64UpDownCounter
+	latency        metric.Float64Histogram
+
+	// options holds the precomputed attributes of each path.
+	options map[string]metric.MeasurementOption
+}
+
+func newExperimentStats(meter metric.Meter, labels selfmetrics.Labels) (*experimentStats, error) {
+	s := &experimentStats{options: make(map[string]metric.MeasurementOption)}
+	var err error
+	s.recordsDropped, err = meter.Int64UpDownCounter(selfmetrics.ExperimentRecordsDropped,
+		metric.WithDescription("Records removed by the primary and shadow pipelines of an A/B experiment."),
+		metric.WithUnit("{record}"))
+	if err != nil {
+		return nil, err
+	}
+	s.sizeDelta, err = meter.Int64UpDownCounter(selfmetrics.ExperimentSizeDelta,
+		metric.WithDescription("Encoded size leaving minus entering the primary and shadow pipelines of an A/B experiment."),
+		metric.WithUnit("By"))
+	if err != nil {
+		return nil, err
+	}
+	s.latency, err = meter.Float64Histogram(selfmetrics.ExperimentLatencySeconds,
+		metric.WithDescription("Time the primary and shadow pipelines of an A/B experiment took to consume a batch."),
+		metric.WithUnit("s"),
+		metric.WithExplicitBucketBoundaries(latencyBuckets...))
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range []string{pathPrimary, pathShadow} {
+		s.options[path] = labels.Option(attribute.String("path", path))
+	}
+	return s, nil
+}
+
+// observe runs consume with an experiment observation in its context and
+// records how the output observed by the tfoexperiment exporters compares
+// with in. Nothing is recorded when consume fails, as the observation may
+// then be partial.
+func (s *experimentStats) observe(ctx context.Context, path string, in size, consume func(context.Context) error) error {
+	obs := &experiment.Observation{}
+	start := time.Now()
+	err := consume(experiment.NewContext(ctx, obs))
+	elapsed := time.Since(start)
+	if err != nil {
+		return err
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	opt := s.options[path]
+	s.recordsDropped.Add(ctx, int64(in.records)-obs.Records(), opt)
+	s.sizeDelta.Add(ctx, obs.Bytes()-int64(in.bytes), opt)
+	s.latency.Record(ctx, elapsed.Seconds(), opt)
+	return nil
+}
+
+func measureTraces(td ptrace.Traces) size {
+	var sizer ptrace.ProtoMarshaler
+	return size{records: td.SpanCount(), bytes: sizer.TracesSize(td)}
+}
+
+func measureMetrics(md pmetric.Metrics) size {
+	var sizer pmetric.ProtoMarshaler
+	return size{records: md.DataPointCount(), bytes: sizer.MetricsSize(md)}
+}
+
+func measureLogs(ld plog.Logs) size {
+	var sizer plog.ProtoMarshaler
+	return size{records: ld.LogRecordCount(), bytes: sizer.LogsSize(ld)}
+}
@@ -0,0 +1,160 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfomirrorconnector
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+const (
+	// TypeStr is the type string identifier for the TFO mirror connector.
+	TypeStr = "tfomirror"
+
+	// Defaults
+	defaultPercentage = 10
+	defaultQueueSize  = 100
+)
+
+// NewFactory creates a new factory for the TFO mirror connector.
+func NewFactory() connector.Factory {
+	return connector.NewFactory(
+		component.MustNewType(TypeStr),
+		createDefaultConfig,
+		connector.WithTracesToTraces(createTracesToTraces, component.StabilityLevelAlpha),
+		connector.WithMetricsToMetrics(createMetricsToMetrics, component.StabilityLevelAlpha),
+		connector.WithLogsToLogs(createLogsToLogs, component.StabilityLevelAlpha),
+	)
+}
+
+// createDefaultConfig creates the default configuration for the connector.
+func createDefaultConfig() component.Config {
+	return &Config{
+		Percentage: defaultPercentage,
+		QueueSize:  defaultQueueSize,
+	}
+}
+
+// createTracesToTraces creates the traces mirror connector.
+func createTracesToTraces(
+	_ context.Context,
+	set connector.Settings,
+	cfg component.Config,
+	next consumer.Traces,
+) (connector.Traces, error) {
+	router, ok := next.(connector.TracesRouterAndConsumer)
+	if !ok {
+		return nil, fmt.Errorf("%s connector requires a traces router", TypeStr)
+	}
+	oCfg := cfg.(*Config)
+	primary, err := router.Consumer(oCfg.Primary...)
+	if err != nil {
+		return nil, fmt.Errorf("primary: %w", err)
+	}
+	shadow, err := router.Consumer(oCfg.Shadow...)
+	if err != nil {
+		return nil, fmt.Errorf("shadow: %w", err)
+	}
+	m, err := newMirror(oCfg, set.ID, set.TelemetrySettings, selfmetrics.Connector(set.ID, pipeline.SignalTraces),
+		primary.ConsumeTraces, shadow.ConsumeTraces, cloneTraces, measureTraces)
+	if err != nil {
+		return nil, err
+	}
+	return &tracesMirror{m}, nil
+}
+
+// createMetricsToMetrics creates the metrics mirror connector.
+func createMetricsToMetrics(
+	_ context.Context,
+	set connector.Settings,
+	cfg component.Config,
+	next consumer.Metrics,
+) (connector.Metrics, error) {
+	router, ok := next.(connector.MetricsRouterAndConsumer)
+	if !ok {
+		return nil, fmt.Errorf("%s connector requires a metrics router", TypeStr)
+	}
+	oCfg := cfg.(*Config)
+	primary, err := router.Consumer(oCfg.Primary...)
+	if err != nil {
+		return nil, fmt.Errorf("primary: %w", err)
+	}
+	shadow, err := router.Consumer(oCfg.Shadow...)
+	if err != nil {
+		return nil, fmt.Errorf("shadow: %w", err)
+	}
+	m, err := newMirror(oCfg, set.ID, set.TelemetrySettings, selfmetrics.Connector(set.ID, pipeline.SignalMetrics),
+		primary.ConsumeMetrics, shadow.ConsumeMetrics, cloneMetrics, measureMetrics)
+	if err != nil {
+		return nil, err
+	}
+	return &metricsMirror{m}, nil
+}
+
+// createLogsToLogs creates the logs mirror connector.
+func createLogsToLogs(
+	_ context.Context,
+	set connector.Settings,
+	cfg component.Config,
+	next consumer.Logs,
+) (connector.Logs, error) {
+	router, ok := next.(connector.LogsRouterAndConsumer)
+	if !ok {
+		return nil, fmt.Errorf("%s connector requires a logs router", TypeStr)
+	}
+	oCfg := cfg.(*Config)
+	primary, err := router.Consumer(oCfg.Primary...)
+	if err != nil {
+		return nil, fmt.Errorf("primary: %w", err)
+	}
+	shadow, err := router.Consumer(oCfg.Shadow...)
+	if err != nil {
+		return nil, fmt.Errorf("shadow: %w", err)
+	}
+	m, err := newMirror(oCfg, set.ID, set.TelemetrySettings, selfmetrics.Connector(set.ID, pipeline.SignalLogs),
+		primary.ConsumeLogs, shadow.ConsumeLogs, cloneLogs, measureLogs)
+	if err != nil {
+		return nil, err
+	}
+	return &logsMirror{m}, nil
+}
+
+func cloneTraces(td ptrace.Traces) ptrace.Traces {
+	cp := ptrace.NewTraces()
+	td.CopyTo(cp)
+	return cp
+}
+
+func cloneMetrics(md pmetric.Metrics) pmetric.Metrics {
+	cp := pmetric.NewMetrics()
+	md.CopyTo(cp)
+	return cp
+}
+
+func cloneLogs(ld plog.Logs) plog.Logs {
+	cp := plog.NewLogs()
+	ld.CopyTo(cp)
+	return cp
+}
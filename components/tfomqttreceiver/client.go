@@ -0,0 +1,189 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfomqttreceiver
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/eclipse/paho.golang/autopaho"
+	"github.com/eclipse/paho.golang/paho"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+)
+
+// Tuning of the MQTT 3.1.1 client.
+const (
+	// v311RetryInterval is the delay between attempts of the first
+	// connection, capped by max_reconnect_interval. Later reconnections
+	// back off exponentially.
+	v311RetryInterval = 10 * time.Second
+	// v311Quiesce is how long the client waits for pending work when
+	// disconnecting.
+	v311Quiesce = 250 * time.Millisecond
+	// v311ProtocolVersion is the protocol level of MQTT 3.1.1.
+	v311ProtocolVersion = 4
+)
+
+// errConnectionDown is recorded when an MQTT 5 connection drops.
+var errConnectionDown = errors.New("connection down")
+
+// v311Client speaks MQTT 3.1.1 through paho.mqtt.golang.
+type v311Client struct {
+	client mqtt.Client
+}
+
+// newV311Client creates the MQTT 3.1.1 client of r.
+func newV311Client(r *mqttReceiver, tlsCfg *tls.Config) *v311Client {
+	handler := func(_ mqtt.Client, msg mqtt.Message) {
+		r.deliver(msg.Topic(), msg.Payload(), msg.Ack)
+	}
+	opts := mqtt.NewClientOptions().
+		AddBroker(r.cfg.Broker).
+		SetClientID(r.clientID).
+		SetUsername(r.cfg.Username).
+		SetPassword(string(r.cfg.Password)).
+		SetTLSConfig(tlsCfg).
+		SetProtocolVersion(v311ProtocolVersion).
+		SetCleanSession(r.cfg.CleanSession).
+		SetResumeSubs(true).
+		SetKeepAlive(r.cfg.KeepAlive).
+		SetConnectTimeout(r.cfg.ConnectTimeout).
+		SetAutoReconnect(true).
+		SetMaxReconnectInterval(r.cfg.MaxReconnectInterval).
+		SetConnectRetry(true).
+		SetConnectRetryInterval(min(v311RetryInterval, r.cfg.MaxReconnectInterval)).
+		SetOrderMatters(false).
+		SetAutoAckDisabled(true).
+		SetDefaultPublishHandler(handler).
+		SetOnConnectHandler(func(c mqtt.Client) {
+			r.onConnect()
+			// Called in its own goroutine, so waiting is fine.
+			token := c.SubscribeMultiple(r.filters(), handler)
+			token.Wait()
+			r.onSubscribe(token.Error())
+		}).
+		SetConnectionLostHandler(func(_ mqtt.Client, err error) {
+			r.failures.Error(connectionLost, err)
+		})
+	return &v311Client{client: mqtt.NewClient(opts)}
+}
+
+// connect implements mqttClient.
+func (c *v311Client) connect() error {
+	// With connect retry the token completes once connected, or with an
+	// error when the client is disconnected first.
+	c.client.Connect()
+	return nil
+}
+
+// disconnect implements mqttClient.
+func (c *v311Client) disconnect(context.Context) error {
+	c.client.Disconnect(uint(v311Quiesce / time.Millisecond))
+	return nil
+}
+
+// v5Client speaks MQTT 5 through autopaho.
+type v5Client struct {
+	cfg autopaho.ClientConfig
+	cm  *autopaho.ConnectionManager
+}
+
+// newV5Client creates the MQTT 5 client of r.
+func newV5Client(r *mqttReceiver, tlsCfg *tls.Config) *v5Client {
+	// The broker URL was checked by Config.Validate.
+	broker, _ := url.Parse(r.cfg.Broker)
+	cfg := autopaho.ClientConfig{
+		ServerUrls:                    []*url.URL{broker},
+		TlsCfg:                        tlsCfg,
+		KeepAlive:                     uint16(r.cfg.KeepAlive / time.Second),
+		CleanStartOnInitialConnection: r.cfg.CleanSession,
+		SessionExpiryInterval:         uint32(r.cfg.SessionExpiry / time.Second),
+		ReconnectBackoff:              r.reconnectBackoff,
+		ConnectTimeout:                r.cfg.ConnectTimeout,
+		ConnectUsername:               r.cfg.Username,
+		ConnectPassword:               []byte(r.cfg.Password),
+		OnConnectionUp: func(cm *autopaho.ConnectionManager, _ *paho.Connack) {
+			r.onConnect()
+			// Must not block.
+			go func() {
+				r.onSubscribe(v5Subscribe(r.ctx, cm, r.filters()))
+			}()
+		},
+		OnConnectionDown: func() bool {
+			r.failures.Error(connectionLost, errConnectionDown)
+			return true
+		},
+		OnConnectError: func(err error) {
+			r.failures.Error(connectFailed, err)
+		},
+		ClientConfig: paho.ClientConfig{
+			ClientID:                   r.clientID,
+			EnableManualAcknowledgment: true,
+			OnPublishReceived: []func(paho.PublishReceived) (bool, error){
+				func(pr paho.PublishReceived) (bool, error) {
+					r.deliver(pr.Packet.Topic, pr.Packet.Payload, func() {
+						if err := pr.Client.Ack(pr.Packet); err != nil {
+							r.failures.Error(ackFailed, err)
+						}
+					})
+					return true, nil
+				},
+			},
+		},
+	}
+	return &v5Client{cfg: cfg}
+}
+
+// v5Subscribe subscribes to filters and checks the reason codes.
+func v5Subscribe(ctx context.Context, cm *autopaho.ConnectionManager, filters map[string]byte) error {
+	sub := &paho.Subscribe{Subscriptions: make([]paho.SubscribeOptions, 0, len(filters))}
+	for topic, qos := range filters {
+		sub.Subscriptions = append(sub.Subscriptions, paho.SubscribeOptions{Topic: topic, QoS: qos})
+	}
+	suback, err := cm.Subscribe(ctx, sub)
+	if err != nil {
+		return err
+	}
+	for i, reason := range suback.Reasons {
+		if reason >= 0x80 && i < len(sub.Subscriptions) {
+			return fmt.Errorf("topic %q refused with reason code 0x%02x", sub.Subscriptions[i].Topic, reason)
+		}
+	}
+	return nil
+}
+
+// connect implements mqttClient.
+func (c *v5Client) connect() error {
+	// The connection manager stops when disconnect is called, not with the
+	// context of Start.
+	cm, err := autopaho.NewConnection(context.Background(), c.cfg)
+	if err != nil {
+		return err
+	}
+	c.cm = cm
+	return nil
+}
+
+// disconnect implements mqttClient.
+func (c *v5Client) disconnect(ctx context.Context) error {
+	if c.cm == nil {
+		return nil
+	}
+	return c.cm.Disconnect(ctx)
+}
@@ -0,0 +1,247 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfomqttreceiver
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configtls"
+)
+
+// Protocol versions accepted in protocol_version.
+const (
+	ProtocolV311 = "3.1.1"
+	ProtocolV5   = "5"
+)
+
+// Signals a topic can carry.
+const (
+	SignalMetrics = "metrics"
+	SignalLogs    = "logs"
+)
+
+// Payload formats a topic can carry.
+const (
+	// FormatOTLP is an OTLP export request encoded as protobuf.
+	FormatOTLP = "otlp"
+	// FormatOTLPJSON is an OTLP export request encoded as JSON.
+	FormatOTLPJSON = "otlp_json"
+	// FormatJSON is a plain JSON object as sent by most devices.
+	FormatJSON = "json"
+)
+
+// Config defines the configuration for the TFO MQTT receiver.
+type Config struct {
+	// Broker is the URL of the MQTT broker, e.g. tcp://broker:1883,
+	// ssl://broker:8883, ws://broker:8080/mqtt or wss://broker:443/mqtt.
+	Broker string `mapstructure:"broker"`
+
+	// ProtocolVersion is the MQTT version spoken to the broker: "3.1.1"
+	// or "5".
+	// Default: 3.1.1
+	ProtocolVersion string `mapstructure:"protocol_version"`
+
+	// ClientID identifies the receiver to the broker. The metrics and logs
+	// instances connect as <client_id>-metrics and <client_id>-logs, so
+	// the broker keeps one session per signal. It must be stable across
+	// restarts for the broker to resume the session.
+	ClientID string `mapstructure:"client_id"`
+
+	// Username and Password authenticate the receiver to the broker.
+	Username string              `mapstructure:"username"`
+	Password configopaque.String `mapstructure:"password"`
+
+	// TLS configures the TLS connection for ssl://, tls://, mqtts:// and
+	// wss:// brokers. Setting cert_file and key_file authenticates the
+	// receiver with a client certificate.
+	TLS configtls.ClientConfig `mapstructure:"tls"`
+
+	// Topics are the topic filters subscribed to.
+	Topics []TopicConfig `mapstructure:"topics"`
+
+	// CleanSession discards the broker session on connect. When false the
+	// broker keeps the subscriptions and queues QoS 1 and 2 messages while
+	// the receiver is disconnected.
+	// Default: false
+	CleanSession bool `mapstructure:"clean_session"`
+
+	// SessionExpiry is how long an MQTT 5 broker keeps the session after
+	// the connection drops. Ignored with MQTT 3.1.1, where a persistent
+	// session lasts until the broker discards it.
+	// Default: 1h
+	SessionExpiry time.Duration `mapstructure:"session_expiry"`
+
+	// KeepAlive is the interval of the MQTT keep alive.
+	// Default: 30s
+	KeepAlive time.Duration `mapstructure:"keep_alive"`
+
+	// ConnectTimeout bounds each connection attempt.
+	// Default: 10s
+	ConnectTimeout time.Duration `mapstructure:"connect_timeout"`
+
+	// MaxReconnectInterval caps the backoff between reconnection attempts.
+	// Default: 1m
+	MaxReconnectInterval time.Duration `mapstructure:"max_reconnect_interval"`
+}
+
+// TopicConfig defines a subscribed topic filter.
+type TopicConfig struct {
+	// Topic is the topic filter, which may use the + and # wildcards.
+	Topic string `mapstructure:"topic"`
+
+	// QoS is the subscription QoS: 0, 1 or 2. With QoS 1 and 2 a message
+	// is acknowledged only after the pipeline accepted it, so the broker
+	// redelivers messages lost in a crash or refused by the pipeline.
+	// Default: 1
+	QoS *byte `mapstructure:"qos"`
+
+	// Signal is the signal the payloads are converted to: metrics or logs.
+	Signal string `mapstructure:"signal"`
+
+	// Format is the payload format: otlp, otlp_json or json.
+	// Default: json
+	Format string `mapstructure:"format"`
+
+	// TimestampField names the JSON field holding the time of the
+	// reading, in Unix seconds, milliseconds or nanoseconds, or RFC 3339.
+	// Nested fields are named by their path joined by dots. For metrics
+	// the field is not converted to a data point or attribute. The arrival
+	// time is used when unset or missing. Only used by json.
+	TimestampField string `mapstructure:"timestamp_field"`
+}
+
+// Validate checks the configuration for errors.
+func (cfg *Config) Validate() error {
+	if cfg.Broker == "" {
+		return errors.New("broker is required")
+	}
+	u, err := url.Parse(cfg.Broker)
+	if err != nil {
+		return fmt.Errorf("broker: %w", err)
+	}
+	switch u.Scheme {
+	case "tcp", "mqtt", "ssl", "tls", "mqtts", "ws", "wss":
+	default:
+		return fmt.Errorf("broker: unsupported scheme %q", u.Scheme)
+	}
+	if u.Host == "" {
+		return errors.New("broker: host is required")
+	}
+	switch cfg.ProtocolVersion {
+	case ProtocolV311, ProtocolV5:
+	default:
+		return fmt.Errorf("protocol_version must be %q or %q", ProtocolV311, ProtocolV5)
+	}
+	if cfg.ClientID == "" {
+		return errors.New("client_id is required")
+	}
+	if cfg.Password != "" && cfg.Username == "" {
+		return errors.New("password requires username")
+	}
+	if err := cfg.TLS.Validate(); err != nil {
+		return fmt.Errorf("tls: %w", err)
+	}
+	if cfg.SessionExpiry < 0 || cfg.SessionExpiry > time.Duration(^uint32(0))*time.Second {
+		return errors.New("session_expiry must be between 0 and 136y")
+	}
+	if cfg.KeepAlive < time.Second || cfg.KeepAlive > time.Duration(^uint16(0))*time.Second {
+		return errors.New("keep_alive must be between 1s and 18h12m15s")
+	}
+	if cfg.ConnectTimeout <= 0 {
+		return errors.New("connect_timeout must be positive")
+	}
+	if cfg.MaxReconnectInterval <= 0 {
+		return errors.New("max_reconnect_interval must be positive")
+	}
+	if len(cfg.Topics) == 0 {
+		return errors.New("at least one topic is required")
+	}
+
+	seen := make(map[string]struct{}, len(cfg.Topics))
+	for i, topic := range cfg.Topics {
+		if err := topic.validate(); err != nil {
+			return fmt.Errorf("topics[%d]: %w", i, err)
+		}
+		if _, dup := seen[topic.Topic]; dup {
+			return fmt.Errorf("topics[%d]: duplicate topic %q", i, topic.Topic)
+		}
+		seen[topic.Topic] = struct{}{}
+	}
+	return nil
+}
+
+// validate checks a topic for errors.
+func (t *TopicConfig) validate() error {
+	if t.Topic == "" {
+		return errors.New("topic is required")
+	}
+	if err := validateFilter(t.Topic); err != nil {
+		return err
+	}
+	if t.QoS != nil && *t.QoS > 2 {
+		return errors.New("qos must be 0, 1 or 2")
+	}
+	switch t.Signal {
+	case SignalMetrics, SignalLogs:
+	default:
+		return fmt.Errorf("signal must be %q or %q", SignalMetrics, SignalLogs)
+	}
+	switch t.Format {
+	case "", FormatJSON:
+	case FormatOTLP, FormatOTLPJSON:
+		if t.TimestampField != "" {
+			return fmt.Errorf("timestamp_field requires format %q", FormatJSON)
+		}
+	default:
+		return fmt.Errorf("format must be %q, %q or %q", FormatOTLP, FormatOTLPJSON, FormatJSON)
+	}
+	return nil
+}
+
+// qos returns the subscription QoS.
+func (t *TopicConfig) qos() byte {
+	if t.QoS == nil {
+		return defaultQoS
+	}
+	return *t.QoS
+}
+
+// format returns the payload format.
+func (t *TopicConfig) format() string {
+	if t.Format == "" {
+		return FormatJSON
+	}
+	return t.Format
+}
+
+// validateFilter checks the wildcards of an MQTT topic filter: + must fill
+// a whole level and # must be the whole last level.
+func validateFilter(filter string) error {
+	levels := strings.Split(filter, "/")
+	for i, level := range levels {
+		switch {
+		case level == "#" && i != len(levels)-1:
+			return fmt.Errorf("topic %q: # must be the last level", filter)
+		case level != "+" && level != "#" && strings.ContainsAny(level, "+#"):
+			return fmt.Errorf("topic %q: wildcards must fill a whole level", filter)
+		}
+	}
+	return nil
+}
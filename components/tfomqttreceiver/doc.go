@@ -0,0 +1,89 @@
+// Package tfomqttreceiver subscribes to MQTT topics and converts the
+// payloads published by IoT devices to metrics and logs.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// The receiver speaks MQTT 3.1.1 or 5 to one broker. Each topic filter
+// is mapped to a signal and a payload format:
+//
+//	otlp       an OTLP export request encoded as protobuf
+//	otlp_json  an OTLP export request encoded as JSON
+//	json       a JSON object, or an array of objects, as sent by devices
+//
+// A json payload on a metrics topic becomes one gauge per numeric or
+// boolean field, named after the field, with nested objects joined by
+// dots. String fields become attributes of the data points, together with
+// mqtt.topic, the topic the payload was published to:
+//
+//	topic   sensors/boiler-1/telemetry
+//	payload {"site": "plant-a", "temp": 71.5, "pump": {"rpm": 1450, "on": true}}
+//
+//	pump.on   1     {mqtt.topic=sensors/boiler-1/telemetry, site=plant-a}
+//	pump.rpm  1450  {mqtt.topic=sensors/boiler-1/telemetry, site=plant-a}
+//	temp      71.5  {mqtt.topic=sensors/boiler-1/telemetry, site=plant-a}
+//
+// A json payload on a logs topic becomes one log record per value, with
+// the value as body and mqtt.topic as attribute. timestamp_field takes
+// the time of a reading from the payload instead of the arrival time.
+//
+// QoS 1 and 2 messages are acknowledged once the pipeline accepted them,
+// or when they are dropped because the payload is malformed or the
+// pipeline refused them permanently. Other refusals are retried with
+// backoff; messages still being retried at shutdown stay unacknowledged
+// and are redelivered by the broker. Messages are consumed concurrently,
+// so they may reach the pipeline in a different order than published.
+//
+// The receiver reconnects with exponential backoff when the broker is
+// unreachable and resubscribes after every connection. Unless
+// clean_session is set, the broker keeps the session of the receiver
+// while it is away and delivers the QoS 1 and 2 messages queued in the
+// meantime. The metrics and logs instances of the receiver connect with
+// the client IDs <client_id>-metrics and <client_id>-logs, so each has a
+// session of its own.
+//
+// Configuration example:
+//
+//	receivers:
+//	  tfomqtt:
+//	    broker: ssl://mqtt.example.com:8883
+//	    protocol_version: "5"
+//	    client_id: tfo-plant-a
+//	    username: collector
+//	    password: ${env:MQTT_PASSWORD}
+//	    tls:
+//	      ca_file: /etc/tfo/mqtt-ca.pem
+//	      # Client certificate authentication:
+//	      # cert_file: /etc/tfo/mqtt-client.pem
+//	      # key_file: /etc/tfo/mqtt-client-key.pem
+//	    topics:
+//	      - topic: sensors/+/telemetry
+//	        signal: metrics
+//	        timestamp_field: ts
+//	      - topic: sensors/+/events
+//	        signal: logs
+//	      - topic: gateways/+/otlp/metrics
+//	        signal: metrics
+//	        format: otlp
+//
+//	service:
+//	  pipelines:
+//	    metrics/iot:
+//	      receivers: [tfomqtt]
+//	      exporters: [tfo]
+//	    logs/iot:
+//	      receivers: [tfomqtt]
+//	      exporters: [tfo]
+package tfomqttreceiver // import "github.com/telemetryflow/telemetryflow-collector/components/tfomqttreceiver"
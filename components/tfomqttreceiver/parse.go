@@ -0,0 +1,288 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfomqttreceiver
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// AttrTopic is the attribute holding the topic a JSON reading was
+// published to.
+const AttrTopic = "mqtt.topic"
+
+// errMalformed marks payloads that can never be converted. They are
+// acknowledged and dropped rather than redelivered.
+var errMalformed = errors.New("malformed payload")
+
+// parseMetrics converts a payload received on topic to metrics.
+func parseMetrics(t *TopicConfig, topic string, payload []byte, now time.Time) (pmetric.Metrics, error) {
+	var (
+		md  pmetric.Metrics
+		err error
+	)
+	switch t.format() {
+	case FormatOTLP:
+		md, err = (&pmetric.ProtoUnmarshaler{}).UnmarshalMetrics(payload)
+	case FormatOTLPJSON:
+		md, err = (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(payload)
+	default:
+		md, err = jsonMetrics(t, topic, payload, now)
+	}
+	if err != nil {
+		return pmetric.Metrics{}, fmt.Errorf("%w: %w", errMalformed, err)
+	}
+	return md, nil
+}
+
+// parseLogs converts a payload received on topic to logs.
+func parseLogs(t *TopicConfig, topic string, payload []byte, now time.Time) (plog.Logs, error) {
+	var (
+		ld  plog.Logs
+		err error
+	)
+	switch t.format() {
+	case FormatOTLP:
+		ld, err = (&plog.ProtoUnmarshaler{}).UnmarshalLogs(payload)
+	case FormatOTLPJSON:
+		ld, err = (&plog.JSONUnmarshaler{}).UnmarshalLogs(payload)
+	default:
+		ld, err = jsonLogs(t, topic, payload, now)
+	}
+	if err != nil {
+		return plog.Logs{}, fmt.Errorf("%w: %w", errMalformed, err)
+	}
+	return ld, nil
+}
+
+// jsonMetrics converts a JSON object, or an array of objects, to gauges.
+// Every numeric or boolean field becomes a gauge named after the field,
+// with nested objects joined by dots. String fields become attributes of
+// the data points of their object. Arrays and nulls are ignored.
+func jsonMetrics(t *TopicConfig, topic string, payload []byte, now time.Time) (pmetric.Metrics, error) {
+	readings, err := decodeReadings(payload)
+	if err != nil {
+		return pmetric.Metrics{}, err
+	}
+
+	md := pmetric.NewMetrics()
+	sm := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName(scopeName)
+	gauges := make(map[string]pmetric.NumberDataPointSlice)
+
+	for i, reading := range readings {
+		obj, ok := reading.(map[string]any)
+		if !ok {
+			return pmetric.Metrics{}, fmt.Errorf("reading %d is not a JSON object", i)
+		}
+		fields := make(map[string]any)
+		flatten("", obj, fields)
+
+		ts := now
+		if t.TimestampField != "" {
+			if v, ok := fields[t.TimestampField]; ok {
+				if ts, err = parseTimestamp(v); err != nil {
+					return pmetric.Metrics{}, fmt.Errorf("reading %d: %s: %w", i, t.TimestampField, err)
+				}
+				delete(fields, t.TimestampField)
+			}
+		}
+
+		attrs := pcommon.NewMap()
+		attrs.PutStr(AttrTopic, topic)
+		names := make([]string, 0, len(fields))
+		for name, v := range fields {
+			if s, ok := v.(string); ok {
+				attrs.PutStr(name, s)
+				continue
+			}
+			names = append(names, name)
+		}
+		slices.Sort(names)
+
+		for _, name := range names {
+			dps, ok := gauges[name]
+			if !ok {
+				m := sm.Metrics().AppendEmpty()
+				m.SetName(name)
+				dps = m.SetEmptyGauge().DataPoints()
+				gauges[name] = dps
+			}
+			dp := dps.AppendEmpty()
+			dp.SetTimestamp(pcommon.NewTimestampFromTime(ts))
+			attrs.CopyTo(dp.Attributes())
+			switch v := fields[name].(type) {
+			case bool:
+				if v {
+					dp.SetIntValue(1)
+				} else {
+					dp.SetIntValue(0)
+				}
+			case json.Number:
+				if n, err := v.Int64(); err == nil {
+					dp.SetIntValue(n)
+				} else if f, err := v.Float64(); err == nil {
+					dp.SetDoubleValue(f)
+				} else {
+					return pmetric.Metrics{}, fmt.Errorf("reading %d: %s: %w", i, name, err)
+				}
+			}
+		}
+	}
+	return md, nil
+}
+
+// jsonLogs converts a JSON value, or an array of values, to log records
+// whose body is the value.
+func jsonLogs(t *TopicConfig, topic string, payload []byte, now time.Time) (plog.Logs, error) {
+	readings, err := decodeReadings(payload)
+	if err != nil {
+		return plog.Logs{}, err
+	}
+
+	ld := plog.NewLogs()
+	sl := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty()
+	sl.Scope().SetName(scopeName)
+	observed := pcommon.NewTimestampFromTime(now)
+
+	for i, reading := range readings {
+		lr := sl.LogRecords().AppendEmpty()
+		lr.SetObservedTimestamp(observed)
+		lr.SetTimestamp(observed)
+		lr.Attributes().PutStr(AttrTopic, topic)
+
+		if obj, ok := reading.(map[string]any); ok && t.TimestampField != "" {
+			fields := make(map[string]any)
+			flatten("", obj, fields)
+			if v, ok := fields[t.TimestampField]; ok {
+				ts, err := parseTimestamp(v)
+				if err != nil {
+					return plog.Logs{}, fmt.Errorf("reading %d: %s: %w", i, t.TimestampField, err)
+				}
+				lr.SetTimestamp(pcommon.NewTimestampFromTime(ts))
+			}
+		}
+		if err := lr.Body().FromRaw(normalize(reading)); err != nil {
+			return plog.Logs{}, fmt.Errorf("reading %d: %w", i, err)
+		}
+	}
+	return ld, nil
+}
+
+// decodeReadings decodes a JSON payload. A top-level array holds several
+// readings; any other value is a single reading.
+func decodeReadings(payload []byte) ([]any, error) {
+	dec := json.NewDecoder(bytes.NewReader(payload))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("trailing data after JSON value")
+	}
+	if readings, ok := v.([]any); ok {
+		return readings, nil
+	}
+	return []any{v}, nil
+}
+
+// flatten copies the scalar fields of obj to fields, naming nested fields
+// after their path joined by dots.
+func flatten(prefix string, obj map[string]any, fields map[string]any) {
+	for k, v := range obj {
+		name := prefix + k
+		switch v := v.(type) {
+		case map[string]any:
+			flatten(name+".", v, fields)
+		case string, bool, json.Number:
+			fields[name] = v
+		}
+	}
+}
+
+// normalize converts the numbers of a decoded JSON value to int64 or
+// float64, as accepted by pcommon.Value.FromRaw.
+func normalize(v any) any {
+	switch v := v.(type) {
+	case json.Number:
+		if n, err := v.Int64(); err == nil {
+			return n
+		}
+		f, _ := v.Float64()
+		return f
+	case map[string]any:
+		for k, e := range v {
+			v[k] = normalize(e)
+		}
+	case []any:
+		for i, e := range v {
+			v[i] = normalize(e)
+		}
+	}
+	return v
+}
+
+// parseTimestamp reads a timestamp field: a Unix time in seconds,
+// milliseconds, microseconds or nanoseconds, told apart by magnitude, or
+// an RFC 3339 string.
+func parseTimestamp(v any) (time.Time, error) {
+	switch v := v.(type) {
+	case string:
+		if ts, err := time.Parse(time.RFC3339Nano, v); err == nil {
+			return ts, nil
+		}
+		if _, err := strconv.ParseFloat(v, 64); err != nil {
+			return time.Time{}, fmt.Errorf("%q is neither RFC 3339 nor a number", v)
+		}
+		return parseTimestamp(json.Number(v))
+	case json.Number:
+		f, err := v.Float64()
+		if err != nil || f < 0 || math.IsInf(f, 0) {
+			return time.Time{}, fmt.Errorf("invalid Unix time %s", v)
+		}
+		if n, err := v.Int64(); err == nil && n >= 1e17 {
+			return time.Unix(0, n), nil
+		}
+		var ns float64
+		switch {
+		case f < 1e11:
+			ns = f * 1e9
+		case f < 1e14:
+			ns = f * 1e6
+		case f < 1e17:
+			ns = f * 1e3
+		default:
+			ns = f
+		}
+		if ns >= math.MaxInt64 {
+			return time.Time{}, fmt.Errorf("Unix time %s out of range", v)
+		}
+		return time.Unix(0, int64(ns)), nil
+	default:
+		return time.Time{}, fmt.Errorf("unsupported type %T", v)
+	}
+}
@@ -0,0 +1,347 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfomqttreceiver
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/errlog"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+const scopeName = "github.com/telemetryflow/telemetryflow-collector/components/tfomqttreceiver"
+
+// Log messages of the failures aggregated by the receiver.
+const (
+	connectFailed    = "Failed to connect to MQTT broker"
+	connectionLost   = "Lost connection to MQTT broker"
+	subscribeFailed  = "Failed to subscribe to MQTT topics"
+	consumeFailed    = "Failed to consume MQTT message, retrying"
+	messageDropped   = "Dropped MQTT message"
+	messageUnmatched = "Received MQTT message on an unsubscribed topic"
+	ackFailed        = "Failed to acknowledge MQTT message"
+)
+
+// Outcomes recorded on the message counter.
+const (
+	outcomeAccepted  = "accepted"
+	outcomeDropped   = "dropped"
+	outcomeUnmatched = "unmatched"
+)
+
+// Backoff of the retries of a message refused by the pipeline.
+const (
+	retryInitialInterval = 100 * time.Millisecond
+	retryMaxInterval     = 5 * time.Second
+)
+
+// consumeFunc converts a payload received on topic and passes it to the
+// pipeline.
+type consumeFunc func(ctx context.Context, t *TopicConfig, topic string, payload []byte) error
+
+// metricsConsumer returns the consumeFunc of a metrics receiver.
+func metricsConsumer(next consumer.Metrics) consumeFunc {
+	return func(ctx context.Context, t *TopicConfig, topic string, payload []byte) error {
+		md, err := parseMetrics(t, topic, payload, time.Now())
+		if err != nil {
+			return err
+		}
+		return next.ConsumeMetrics(ctx, md)
+	}
+}
+
+// logsConsumer returns the consumeFunc of a logs receiver.
+func logsConsumer(next consumer.Logs) consumeFunc {
+	return func(ctx context.Context, t *TopicConfig, topic string, payload []byte) error {
+		ld, err := parseLogs(t, topic, payload, time.Now())
+		if err != nil {
+			return err
+		}
+		return next.ConsumeLogs(ctx, ld)
+	}
+}
+
+// mqttClient is a connection to the broker, speaking MQTT 3.1.1 or 5.
+type mqttClient interface {
+	// connect starts connecting in the background. The client reconnects
+	// and resubscribes until disconnect is called.
+	connect() error
+	// disconnect closes the connection.
+	disconnect(ctx context.Context) error
+}
+
+// mqttReceiver subscribes to the topics of one signal.
+type mqttReceiver struct {
+	cfg      *Config
+	set      receiver.Settings
+	signal   string
+	clientID string
+	topics   []*TopicConfig
+	consume  consumeFunc
+	failures *errlog.Aggregator
+
+	labels   selfmetrics.Labels
+	messages metric.Int64Counter
+
+	client mqttClient
+
+	// ctx is cancelled on shutdown to abandon the retries of refused
+	// messages, which stay unacknowledged and are redelivered by the broker.
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu       sync.Mutex
+	closed   bool
+	inflight sync.WaitGroup
+}
+
+// newMQTTReceiver creates the receiver of signal.
+func newMQTTReceiver(cfg *Config, set receiver.Settings, signal string, consume consumeFunc) (*mqttReceiver, error) {
+	var topics []*TopicConfig
+	for i := range cfg.Topics {
+		if cfg.Topics[i].Signal == signal {
+			topics = append(topics, &cfg.Topics[i])
+		}
+	}
+	if len(topics) == 0 {
+		return nil, fmt.Errorf("no topic has signal %q", signal)
+	}
+
+	labels := selfmetrics.Receiver(set.ID).WithSignal(pipeline.SignalMetrics)
+	if signal == SignalLogs {
+		labels = selfmetrics.Receiver(set.ID).WithSignal(pipeline.SignalLogs)
+	}
+	logger := set.Logger.With(zap.String("broker", cfg.Broker), zap.String("signal", signal))
+	return &mqttReceiver{
+		cfg:      cfg,
+		set:      set,
+		signal:   signal,
+		clientID: cfg.ClientID + "-" + signal,
+		topics:   topics,
+		consume:  consume,
+		failures: errlog.New(logger, errlog.DefaultInterval),
+		labels:   labels,
+	}, nil
+}
+
+// Start implements component.Component. It does not wait for the broker:
+// the connection is retried in the background.
+func (r *mqttReceiver) Start(ctx context.Context, _ component.Host) error {
+	if r.set.MeterProvider != nil {
+		var err error
+		r.messages, err = r.set.MeterProvider.Meter(scopeName).Int64Counter(selfmetrics.ReceiverMQTTMessages,
+			metric.WithDescription("MQTT messages received, by outcome."),
+			metric.WithUnit("{message}"))
+		if err != nil {
+			return err
+		}
+	}
+
+	var tlsCfg *tls.Config
+	if secureBroker(r.cfg.Broker) {
+		var err error
+		if tlsCfg, err = r.cfg.TLS.LoadTLSConfig(ctx); err != nil {
+			return fmt.Errorf("failed to load TLS config: %w", err)
+		}
+	}
+
+	r.ctx, r.cancel = context.WithCancel(context.Background())
+	if r.cfg.ProtocolVersion == ProtocolV5 {
+		r.client = newV5Client(r, tlsCfg)
+	} else {
+		r.client = newV311Client(r, tlsCfg)
+	}
+	return r.client.connect()
+}
+
+// Shutdown implements component.Component. Messages still being retried
+// are left unacknowledged. The connection is closed once no message is in
+// flight, so that no acknowledgement races the disconnect.
+func (r *mqttReceiver) Shutdown(ctx context.Context) error {
+	if r.cancel == nil {
+		return nil
+	}
+	r.mu.Lock()
+	r.closed = true
+	r.mu.Unlock()
+	r.cancel()
+	r.inflight.Wait()
+	return r.client.disconnect(ctx)
+}
+
+// filters returns the subscribed topic filters and their QoS.
+func (r *mqttReceiver) filters() map[string]byte {
+	filters := make(map[string]byte, len(r.topics))
+	for _, t := range r.topics {
+		filters[t.Topic] = t.qos()
+	}
+	return filters
+}
+
+// onConnect is called by the clients once connected.
+func (r *mqttReceiver) onConnect() {
+	r.failures.Success(connectFailed)
+	r.failures.Success(connectionLost)
+	r.set.Logger.Info("Connected to MQTT broker",
+		zap.String("broker", r.cfg.Broker),
+		zap.String("client_id", r.clientID))
+}
+
+// onSubscribe is called by the clients with the outcome of subscribing.
+func (r *mqttReceiver) onSubscribe(err error) {
+	if err != nil {
+		r.failures.Error(subscribeFailed, err)
+		return
+	}
+	r.failures.Success(subscribeFailed)
+}
+
+// deliver consumes a message in the background and calls ack once the
+// message is accepted or dropped. Clients must not block while routing
+// messages, and the broker bounds the number of unacknowledged QoS 1 and 2
+// messages in flight.
+func (r *mqttReceiver) deliver(topic string, payload []byte, ack func()) {
+	r.mu.Lock()
+	if r.closed {
+		r.mu.Unlock()
+		return
+	}
+	r.inflight.Add(1)
+	r.mu.Unlock()
+	go func() {
+		defer r.inflight.Done()
+		if r.handle(topic, payload) {
+			ack()
+		}
+	}()
+}
+
+// handle consumes a message, retrying while the pipeline refuses it. It
+// reports whether the message should be acknowledged, which is false only
+// when the receiver shuts down before the pipeline accepted it.
+func (r *mqttReceiver) handle(topic string, payload []byte) bool {
+	t := r.match(topic)
+	if t == nil {
+		r.failures.Error(messageUnmatched, errors.New("no topic filter matches"), zap.String("topic", topic))
+		r.record(outcomeUnmatched)
+		return true
+	}
+
+	backoff := retryInitialInterval
+	for {
+		err := r.consume(r.ctx, t, topic, payload)
+		switch {
+		case err == nil:
+			r.failures.Success(consumeFailed)
+			r.failures.Success(messageDropped)
+			r.record(outcomeAccepted)
+			return true
+		case errors.Is(err, errMalformed) || consumererror.IsPermanent(err):
+			r.failures.Error(messageDropped, err, zap.String("topic", topic))
+			r.record(outcomeDropped)
+			return true
+		}
+		r.failures.Error(consumeFailed, err, zap.String("topic", topic))
+
+		timer := time.NewTimer(backoff)
+		select {
+		case <-r.ctx.Done():
+			timer.Stop()
+			return false
+		case <-timer.C:
+		}
+		backoff = min(2*backoff, retryMaxInterval)
+	}
+}
+
+// match returns the first subscribed topic whose filter matches topic.
+func (r *mqttReceiver) match(topic string) *TopicConfig {
+	for _, t := range r.topics {
+		if matchFilter(t.Topic, topic) {
+			return t
+		}
+	}
+	return nil
+}
+
+// record counts a message with outcome.
+func (r *mqttReceiver) record(outcome string) {
+	if r.messages != nil {
+		r.messages.Add(context.Background(), 1, r.labels.Option(attribute.String("outcome", outcome)))
+	}
+}
+
+// reconnectBackoff returns the delay before reconnection attempt n,
+// doubling from one second up to max_reconnect_interval.
+func (r *mqttReceiver) reconnectBackoff(attempt int) time.Duration {
+	if attempt <= 0 {
+		return 0
+	}
+	backoff := time.Second
+	for i := 1; i < attempt && backoff < r.cfg.MaxReconnectInterval; i++ {
+		backoff *= 2
+	}
+	return min(backoff, r.cfg.MaxReconnectInterval)
+}
+
+// matchFilter reports whether topic matches the MQTT topic filter. Topics
+// starting with $ are only matched by filters naming them explicitly.
+func matchFilter(filter, topic string) bool {
+	if strings.HasPrefix(topic, "$") && !strings.HasPrefix(filter, "$") {
+		return false
+	}
+	fl := strings.Split(filter, "/")
+	tl := strings.Split(topic, "/")
+	for i, level := range fl {
+		switch {
+		case level == "#":
+			return true
+		case i >= len(tl):
+			return false
+		case level != "+" && level != tl[i]:
+			return false
+		}
+	}
+	return len(fl) == len(tl)
+}
+
+// secureBroker reports whether the broker URL asks for TLS.
+func secureBroker(broker string) bool {
+	u, err := url.Parse(broker)
+	if err != nil {
+		return false
+	}
+	switch u.Scheme {
+	case "ssl", "tls", "mqtts", "wss":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,134 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+// Reasons v2 requests are rejected, the reason label of
+// tfo_receiver_auth_failures.
+const (
+	authMissingKey          = "missing_key"
+	authInvalidKeyFormat    = "invalid_key_format"
+	authKeyNotAllowed       = "key_not_allowed"
+	authMissingSecret       = "missing_secret"
+	authInvalidSecretFormat = "invalid_secret_format"
+	authInvalidCredentials  = "invalid_credentials"
+	authValidationFailed    = "validation_failed"
+)
+
+// Key prefixes of key IDs that are not masked to a sender, keeping the
+// key_prefix label bounded whatever the headers hold.
+const (
+	keyPrefixNone    = "none"
+	keyPrefixInvalid = "invalid"
+	keyPrefixUnknown = "unknown"
+)
+
+// maxValidatedKeys bounds the key IDs remembered as validated.
+const maxValidatedKeys = 1024
+
+// authStats counts rejected v2 requests by reason and masked key ID. Only
+// key IDs listed in valid_api_key_ids or once validated are masked to a
+// prefix; other key IDs come from unauthenticated headers and are counted
+// as "unknown". A nil *authStats counts nothing.
+type authStats struct {
+	failures metric.Int64Counter
+	labels   selfmetrics.Labels
+	allowed  map[string]bool
+
+	mu        sync.Mutex
+	validated map[string]bool
+}
+
+func newAuthStats(meter metric.Meter, labels selfmetrics.Labels, allowed []string) (*authStats, error) {
+	failures, err := meter.Int64Counter(selfmetrics.ReceiverAuthFailures,
+		metric.WithDescription("Requests to the v2 endpoints rejected by authentication."),
+		metric.WithUnit("{request}"))
+	if err != nil {
+		return nil, err
+	}
+	s := &authStats{
+		failures:  failures,
+		labels:    labels,
+		allowed:   make(map[string]bool, len(allowed)),
+		validated: make(map[string]bool),
+	}
+	for _, keyID := range allowed {
+		s.allowed[keyID] = true
+	}
+	return s, nil
+}
+
+// reject counts a request sent with keyID rejected for reason.
+func (s *authStats) reject(ctx context.Context, reason, keyID string) {
+	if s == nil {
+		return
+	}
+	s.failures.Add(ctx, 1, s.labels.Option(
+		attribute.String("reason", reason),
+		attribute.String("key_prefix", keyPrefix(keyID, s.known(keyID))),
+	))
+}
+
+// accept remembers keyID as validated, up to maxValidatedKeys key IDs.
+func (s *authStats) accept(keyID string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if len(s.validated) < maxValidatedKeys {
+		s.validated[keyID] = true
+	}
+}
+
+// known reports whether keyID is allowed by the configuration or was
+// validated.
+func (s *authStats) known(keyID string) bool {
+	if s.allowed[keyID] {
+		return true
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.validated[keyID]
+}
+
+// keyPrefix masks keyID to its first 8 characters, like the tfoauth
+// extension logs API keys, so a spike of failures can be traced to a
+// sender without exposing the key. Key IDs that are not known are not
+// masked but counted as "unknown".
+func keyPrefix(keyID string, known bool) string {
+	switch {
+	case keyID == "":
+		return keyPrefixNone
+	case !strings.HasPrefix(keyID, "tfk_"):
+		return keyPrefixInvalid
+	case !known:
+		return keyPrefixUnknown
+	case len(keyID) <= 8:
+		return "****"
+	default:
+		return keyID[:8] + "****"
+	}
+}
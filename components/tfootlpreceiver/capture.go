@@ -0,0 +1,360 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+)
+
+const (
+	// capturePath is the admin API resource for capture sessions.
+	capturePath = "/capture"
+
+	// defaultCaptureCount is the session size when a request omits count.
+	defaultCaptureCount = 10
+
+	// redacted replaces secret header and query values in capture metadata.
+	redacted = "[REDACTED]"
+)
+
+// alwaysRedactedHeaders are redacted regardless of configuration.
+var alwaysRedactedHeaders = []string{
+	"Authorization",
+	"Proxy-Authorization",
+	"Cookie",
+	"Set-Cookie",
+	headerKeyID,
+	headerKeySecret,
+}
+
+// redactedNameParts mark header and query parameter names as secret.
+var redactedNameParts = []string{"secret", "token", "password", "key"}
+
+// captureRequest is the body of POST /capture.
+type captureRequest struct {
+	Endpoint string `json:"endpoint"`
+	Count    int    `json:"count"`
+	Duration string `json:"duration"`
+}
+
+// captureStatus describes a capture session in admin API responses.
+type captureStatus struct {
+	ID        string    `json:"id"`
+	Endpoint  string    `json:"endpoint"`
+	Limit     int       `json:"limit"`
+	Captured  int       `json:"captured"`
+	Active    bool      `json:"active"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Directory string    `json:"directory"`
+}
+
+// captureMetadata is written next to each captured payload.
+type captureMetadata struct {
+	Time            time.Time           `json:"time"`
+	Method          string              `json:"method"`
+	Path            string              `json:"path"`
+	Query           string              `json:"query,omitempty"`
+	RemoteAddr      string              `json:"remote_addr"`
+	ContentType     string              `json:"content_type"`
+	ContentEncoding string              `json:"content_encoding,omitempty"`
+	Size            int                 `json:"size"`
+	Headers         map[string][]string `json:"headers"`
+}
+
+// payloadCapture dumps the raw bodies of the next N requests to one endpoint
+// while a session armed through the admin API is active.
+type payloadCapture struct {
+	cfg       PayloadCaptureConfig
+	endpoints []string
+	logger    *zap.Logger
+	redact    map[string]bool
+
+	mu      sync.Mutex
+	session *captureStatus
+	nextID  int
+
+	server *serverconf.AdminServer
+}
+
+// newPayloadCapture creates a payload capture for the given HTTP endpoints.
+func newPayloadCapture(cfg PayloadCaptureConfig, endpoints []string, logger *zap.Logger) *payloadCapture {
+	redact := make(map[string]bool)
+	for _, h := range append(slices.Clone(alwaysRedactedHeaders), cfg.RedactHeaders...) {
+		redact[http.CanonicalHeaderKey(h)] = true
+	}
+	return &payloadCapture{
+		cfg:       cfg,
+		endpoints: endpoints,
+		logger:    logger,
+		redact:    redact,
+	}
+}
+
+// start starts the admin API.
+func (c *payloadCapture) start(ctx context.Context, host component.Host) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc(capturePath, c.handleAdmin)
+
+	server, err := c.cfg.StartAdmin(ctx, host, "Payload capture admin API", mux, c.logger)
+	if err != nil {
+		return fmt.Errorf("payload capture admin API: %w", err)
+	}
+	c.server = server
+	return nil
+}
+
+// shutdown stops the admin API and ends any active session.
+func (c *payloadCapture) shutdown(ctx context.Context) error {
+	if c == nil || c.server == nil {
+		return nil
+	}
+	err := c.server.Shutdown(ctx)
+
+	c.mu.Lock()
+	if c.session != nil {
+		c.session.Active = false
+	}
+	c.mu.Unlock()
+	return err
+}
+
+// record captures body if an active session targets the request path.
+func (c *payloadCapture) record(req *http.Request, body []byte) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	s := c.session
+	if s == nil || !s.Active || s.Endpoint != req.URL.Path {
+		c.mu.Unlock()
+		return
+	}
+	if time.Now().After(s.ExpiresAt) {
+		s.Active = false
+		c.mu.Unlock()
+		return
+	}
+	s.Captured++
+	seq := s.Captured
+	if s.Captured >= s.Limit {
+		s.Active = false
+	}
+	dir := s.Directory
+	c.mu.Unlock()
+
+	if err := c.write(dir, seq, req, body); err != nil {
+		c.logger.Warn("Failed to write captured payload", zap.String("directory", dir), zap.Error(err))
+	}
+}
+
+// write stores the payload and its redacted metadata.
+func (c *payloadCapture) write(dir string, seq int, req *http.Request, body []byte) error {
+	ext := "pb"
+	if req.Header.Get("Content-Type") == "application/json" {
+		ext = "json"
+	}
+	base := filepath.Join(dir, fmt.Sprintf("%04d", seq))
+
+	if err := os.WriteFile(base+"."+ext, body, 0o600); err != nil {
+		return err
+	}
+
+	meta, err := json.MarshalIndent(captureMetadata{
+		Time:            time.Now().UTC(),
+		Method:          req.Method,
+		Path:            req.URL.Path,
+		Query:           c.redactQuery(req.URL.Query()),
+		RemoteAddr:      req.RemoteAddr,
+		ContentType:     req.Header.Get("Content-Type"),
+		ContentEncoding: req.Header.Get("Content-Encoding"),
+		Size:            len(body),
+		Headers:         c.redactHeaders(req.Header),
+	}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(base+".meta.json", meta, 0o600)
+}
+
+// isSecret reports whether a header or query parameter name holds a secret.
+func (c *payloadCapture) isSecret(name string) bool {
+	if c.redact[http.CanonicalHeaderKey(name)] {
+		return true
+	}
+	lower := strings.ToLower(name)
+	for _, part := range redactedNameParts {
+		if strings.Contains(lower, part) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactHeaders returns a copy of h with secret values replaced.
+func (c *payloadCapture) redactHeaders(h http.Header) map[string][]string {
+	out := make(map[string][]string, len(h))
+	for name, values := range h {
+		if c.isSecret(name) {
+			out[name] = []string{redacted}
+			continue
+		}
+		out[name] = slices.Clone(values)
+	}
+	return out
+}
+
+// redactQuery returns the encoded query with secret values replaced.
+func (c *payloadCapture) redactQuery(q url.Values) string {
+	for name := range q {
+		if c.isSecret(name) {
+			q[name] = []string{redacted}
+		}
+	}
+	return q.Encode()
+}
+
+// handleAdmin serves the capture session resource:
+//
+//	POST   /capture  start a session: {"endpoint": "/v1/traces", "count": 5, "duration": "2m"}
+//	GET    /capture  current or last session
+//	DELETE /capture  stop the active session
+func (c *payloadCapture) handleAdmin(w http.ResponseWriter, req *http.Request) {
+	switch req.Method {
+	case http.MethodPost:
+		c.handleStart(w, req)
+	case http.MethodGet:
+		c.mu.Lock()
+		status := c.snapshot()
+		c.mu.Unlock()
+		if status == nil {
+			serverconf.WriteJSON(w, http.StatusNotFound, map[string]string{"error": "no capture session"})
+			return
+		}
+		serverconf.WriteJSON(w, http.StatusOK, status)
+	case http.MethodDelete:
+		c.mu.Lock()
+		if c.session != nil {
+			c.session.Active = false
+		}
+		status := c.snapshot()
+		c.mu.Unlock()
+		if status == nil {
+			serverconf.WriteJSON(w, http.StatusNotFound, map[string]string{"error": "no capture session"})
+			return
+		}
+		serverconf.WriteJSON(w, http.StatusOK, status)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// handleStart validates a session request and arms the capture.
+func (c *payloadCapture) handleStart(w http.ResponseWriter, req *http.Request) {
+	var body captureRequest
+	if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+		serverconf.WriteJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid request body: " + err.Error()})
+		return
+	}
+
+	if !slices.Contains(c.endpoints, body.Endpoint) {
+		serverconf.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": fmt.Sprintf("endpoint must be one of %s", strings.Join(c.endpoints, ", ")),
+		})
+		return
+	}
+
+	count := body.Count
+	if count == 0 {
+		count = min(defaultCaptureCount, c.cfg.MaxCount)
+	}
+	if count < 0 || count > c.cfg.MaxCount {
+		serverconf.WriteJSON(w, http.StatusBadRequest, map[string]string{
+			"error": fmt.Sprintf("count must be between 1 and %d", c.cfg.MaxCount),
+		})
+		return
+	}
+
+	duration := c.cfg.MaxDuration
+	if body.Duration != "" {
+		d, err := time.ParseDuration(body.Duration)
+		if err != nil || d <= 0 || d > c.cfg.MaxDuration {
+			serverconf.WriteJSON(w, http.StatusBadRequest, map[string]string{
+				"error": fmt.Sprintf("duration must be a positive duration of at most %s", c.cfg.MaxDuration),
+			})
+			return
+		}
+		duration = d
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if s := c.session; s != nil && s.Active && time.Now().Before(s.ExpiresAt) {
+		serverconf.WriteJSON(w, http.StatusConflict, map[string]string{"error": "a capture session is already active: " + s.ID})
+		return
+	}
+
+	c.nextID++
+	id := fmt.Sprintf("%s-%d", time.Now().UTC().Format("20060102T150405Z"), c.nextID)
+	dir := filepath.Join(c.cfg.Directory, id)
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		serverconf.WriteJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return
+	}
+
+	c.session = &captureStatus{
+		ID:        id,
+		Endpoint:  body.Endpoint,
+		Limit:     count,
+		Active:    true,
+		ExpiresAt: time.Now().Add(duration).UTC(),
+		Directory: dir,
+	}
+	c.logger.Warn("Payload capture session started",
+		zap.String("id", id),
+		zap.String("endpoint", body.Endpoint),
+		zap.Int("count", count),
+		zap.Duration("duration", duration),
+		zap.String("directory", dir),
+	)
+	serverconf.WriteJSON(w, http.StatusCreated, c.snapshot())
+}
+
+// snapshot returns a copy of the session with expiry applied. Callers hold mu.
+func (c *payloadCapture) snapshot() *captureStatus {
+	if c.session == nil {
+		return nil
+	}
+	if c.session.Active && time.Now().After(c.session.ExpiresAt) {
+		c.session.Active = false
+	}
+	status := *c.session
+	return &status
+}
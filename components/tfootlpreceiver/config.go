@@ -15,10 +15,21 @@
 package tfootlpreceiver
 
 import (
+	"cmp"
 	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
 
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configgrpc"
 	"go.opentelemetry.io/collector/config/confighttp"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/bytesize"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/watchdog"
 )
 
 // Config defines the configuration for the TFO OTLP receiver.
@@ -38,6 +49,268 @@ type Config struct {
 	// V2Auth configures authentication for v2 endpoints.
 	// Only applies when EnableV2Endpoints is true.
 	V2Auth V2AuthConfig `mapstructure:"v2_auth"`
+
+	// Watchdog restarts the servers when consumers stop making progress.
+	Watchdog watchdog.Config `mapstructure:"watchdog"`
+
+	// PayloadCapture configures the admin API that dumps raw HTTP request
+	// bodies to disk for debugging SDK encoding issues.
+	PayloadCapture PayloadCaptureConfig `mapstructure:"payload_capture"`
+
+	// DrainTimeout is how long Shutdown waits for in-flight requests to
+	// finish before closing their connections. Requests still running at
+	// the deadline are counted in tfo_receiver_drain_dropped_requests.
+	// Default: 10s
+	DrainTimeout time.Duration `mapstructure:"drain_timeout"`
+
+	// Provenance stamps the multi-hop metadata envelope on received
+	// telemetry and drops telemetry caught in forwarding loops.
+	Provenance ProvenanceConfig `mapstructure:"provenance"`
+
+	// RateLimit bounds the rate of received spans, data points and log
+	// records across both protocols.
+	RateLimit RateLimitConfig `mapstructure:"rate_limit"`
+
+	// Sampling keeps a percentage of the received traces and log records.
+	Sampling SamplingConfig `mapstructure:"sampling"`
+
+	// Maintenance is a reference to a tfomaintenance extension. While it
+	// is in reject mode, requests are refused with HTTP 503 or gRPC
+	// Unavailable so that senders retry later.
+	Maintenance component.ID `mapstructure:"maintenance"`
+
+	// Overrides is a reference to a tfooverrides extension. Its rate limit
+	// for this receiver replaces the rate and burst of rate_limit without
+	// a configuration reload, and applies even when rate_limit is
+	// disabled.
+	Overrides component.ID `mapstructure:"overrides"`
+}
+
+// RateLimitConfig defines the receive rate limit. Records are admitted from
+// a token bucket; requests over the limit are rejected with HTTP 429 or gRPC
+// RESOURCE_EXHAUSTED so that senders retry them later.
+type RateLimitConfig struct {
+	// Enabled turns on the rate limit.
+	// Default: false
+	Enabled bool `mapstructure:"enabled"`
+
+	// RecordsPerSecond is the sustained rate of admitted records.
+	RecordsPerSecond float64 `mapstructure:"records_per_second"`
+
+	// Burst is the largest number of records admitted at once. A request,
+	// or in per_trace mode the spans of one trace in a request, larger than
+	// the burst is always rejected. Zero uses records_per_second.
+	// Default: 0
+	Burst int `mapstructure:"burst"`
+
+	// PerTrace admits or rejects spans per trace instead of per request, so
+	// a trace split across many requests is not cut into pieces. An
+	// admitted trace stays admitted until none of its spans has been
+	// received for trace_window; a rejected trace is decided again
+	// trace_window after its rejection. Requests with some traces rejected
+	// succeed partially, reporting the rejected spans.
+	// Default: false
+	PerTrace bool `mapstructure:"per_trace"`
+
+	// TraceWindow is how long an admitted trace is kept after its last
+	// span, and a rejected trace after its rejection.
+	// Default: 30s
+	TraceWindow time.Duration `mapstructure:"trace_window"`
+
+	// MaxTraces is the number of trace decisions kept, rounded up to a
+	// power of two. Decisions are stored by trace ID hash; a trace whose
+	// entry is reused by another trace is decided again.
+	// Default: 65536
+	MaxTraces int `mapstructure:"max_traces"`
+
+	// PerKey bounds the records each API key may send, so that one
+	// misbehaving sender cannot use up the capacity of the receiver. It
+	// applies before, and independently of, the receiver-wide limit.
+	PerKey KeyRateLimitConfig `mapstructure:"per_key"`
+}
+
+// KeyRateLimitConfig defines a token bucket per API key on the v2 and
+// websocket endpoints, keyed on the X-TelemetryFlow-Key-ID header. Requests
+// without the header share one bucket. Requests over the limit of their key
+// are rejected with HTTP 429 and a Retry-After header.
+type KeyRateLimitConfig struct {
+	// Enabled turns on the per-key rate limit.
+	// Default: false
+	Enabled bool `mapstructure:"enabled"`
+
+	// RecordsPerSecond is the sustained rate of admitted records of each
+	// key.
+	RecordsPerSecond float64 `mapstructure:"records_per_second"`
+
+	// Burst is the largest number of records of a key admitted at once. A
+	// request larger than the burst is always rejected. Zero uses
+	// records_per_second.
+	// Default: 0
+	Burst int `mapstructure:"burst"`
+
+	// Keys overrides the rate and burst of individual API key IDs.
+	Keys map[string]KeyLimit `mapstructure:"keys"`
+
+	// MaxKeys is the number of key buckets kept. The bucket of the least
+	// recently seen key is dropped beyond it and starts full when the key
+	// returns.
+	// Default: 10000
+	MaxKeys int `mapstructure:"max_keys"`
+}
+
+// KeyLimit is the rate limit of one API key.
+type KeyLimit struct {
+	// RecordsPerSecond is the sustained rate of admitted records.
+	RecordsPerSecond float64 `mapstructure:"records_per_second"`
+
+	// Burst is the largest number of records admitted at once. Zero uses
+	// records_per_second.
+	// Default: 0
+	Burst int `mapstructure:"burst"`
+}
+
+// Validate checks the rate limit configuration for errors.
+func (cfg *RateLimitConfig) Validate() error {
+	if err := cfg.PerKey.Validate(); err != nil {
+		return err
+	}
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.RecordsPerSecond <= 0 {
+		return errors.New("rate_limit.records_per_second must be positive")
+	}
+	if cfg.Burst < 0 {
+		return errors.New("rate_limit.burst must not be negative")
+	}
+	if !cfg.PerTrace {
+		return nil
+	}
+	if cfg.TraceWindow <= 0 {
+		return errors.New("rate_limit.trace_window must be positive")
+	}
+	if cfg.MaxTraces <= 0 {
+		return errors.New("rate_limit.max_traces must be positive")
+	}
+	return nil
+}
+
+// Validate checks the per-key rate limit configuration for errors.
+func (cfg *KeyRateLimitConfig) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.RecordsPerSecond <= 0 {
+		return errors.New("rate_limit.per_key.records_per_second must be positive")
+	}
+	if cfg.Burst < 0 {
+		return errors.New("rate_limit.per_key.burst must not be negative")
+	}
+	if cfg.MaxKeys <= 0 {
+		return errors.New("rate_limit.per_key.max_keys must be positive")
+	}
+	for key, limit := range cfg.Keys {
+		if limit.RecordsPerSecond <= 0 {
+			return fmt.Errorf("rate_limit.per_key.keys[%s].records_per_second must be positive", key)
+		}
+		if limit.Burst < 0 {
+			return fmt.Errorf("rate_limit.per_key.keys[%s].burst must not be negative", key)
+		}
+	}
+	return nil
+}
+
+// ProvenanceConfig defines the provenance envelope settings.
+type ProvenanceConfig struct {
+	// Enabled stamps the envelope on every resource received.
+	// Default: false
+	Enabled bool `mapstructure:"enabled"`
+
+	// CollectorIdentity is a reference to the tfoidentity extension
+	// providing the ID of this collector. Required when enabled.
+	CollectorIdentity component.ID `mapstructure:"collector_identity"`
+
+	// TenantHeader is the request header, or gRPC metadata key, naming the
+	// tenant of telemetry entering the hierarchy at this collector. Empty
+	// records no tenant.
+	// Default: X-TelemetryFlow-Tenant
+	TenantHeader string `mapstructure:"tenant_header"`
+
+	// MaxHops is the largest number of collectors telemetry may pass
+	// through, this one included. Telemetry arriving with that many hops
+	// is dropped.
+	// Default: 8
+	MaxHops int `mapstructure:"max_hops"`
+}
+
+// Validate checks the provenance configuration for errors.
+func (cfg *ProvenanceConfig) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.CollectorIdentity.String() == "" {
+		return errors.New("provenance.collector_identity must be set when provenance is enabled")
+	}
+	if cfg.MaxHops <= 0 {
+		return errors.New("provenance.max_hops must be positive")
+	}
+	return nil
+}
+
+// PayloadCaptureConfig defines the opt-in payload capture settings.
+//
+// Enabling it only starts the admin API; nothing is written to disk until a
+// capture session is requested through that API.
+type PayloadCaptureConfig struct {
+	// Enabled starts the payload capture admin API.
+	// Default: false
+	Enabled bool `mapstructure:"enabled"`
+
+	// AdminConfig defines the admin API listener: endpoint, tls, auth, acl
+	// and the other shared server settings. Captures hold raw request
+	// bodies, so an endpoint off localhost requires auth or mTLS.
+	// Default endpoint: localhost:55690
+	serverconf.AdminConfig `mapstructure:",squash"`
+
+	// Directory receives one subdirectory per capture session.
+	Directory string `mapstructure:"directory"`
+
+	// MaxCount is the largest number of payloads a session may capture.
+	// Default: 100
+	MaxCount int `mapstructure:"max_count"`
+
+	// MaxDuration is the longest a session may stay active.
+	// Default: 10m
+	MaxDuration time.Duration `mapstructure:"max_duration"`
+
+	// RedactHeaders lists additional request headers whose values are
+	// replaced in the captured metadata. Authorization, cookies, the
+	// TelemetryFlow API key headers and any header whose name contains
+	// "secret", "token", "password" or "key" are always redacted.
+	RedactHeaders []string `mapstructure:"redact_headers"`
+}
+
+// Validate checks the payload capture configuration for errors.
+func (cfg *PayloadCaptureConfig) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.NetAddr.Endpoint == "" {
+		return errors.New("payload_capture.endpoint must be set when payload capture is enabled")
+	}
+	if err := cfg.AdminConfig.Validate(); err != nil {
+		return fmt.Errorf("payload_capture: %w", err)
+	}
+	if cfg.Directory == "" {
+		return errors.New("payload_capture.directory must be set when payload capture is enabled")
+	}
+	if cfg.MaxCount <= 0 {
+		return errors.New("payload_capture.max_count must be positive")
+	}
+	if cfg.MaxDuration <= 0 {
+		return errors.New("payload_capture.max_duration must be positive")
+	}
+	return nil
 }
 
 // V2AuthConfig defines authentication settings for v2 endpoints.
@@ -53,6 +326,12 @@ type V2AuthConfig struct {
 	// ValidateSecret when true, also validates the API Key Secret.
 	// Default: false (only validates API Key ID presence)
 	ValidateSecret bool `mapstructure:"validate_secret"`
+
+	// Validator is a reference to an extension validating API keys, such
+	// as tfoauth with a validation_endpoint. Requests need a secret and are
+	// refused when the extension rejects the key, so revoked keys stop
+	// working. Empty only checks the headers.
+	Validator component.ID `mapstructure:"validator"`
 }
 
 // ProtocolsConfig defines the protocol configurations.
@@ -67,12 +346,79 @@ type ProtocolsConfig struct {
 // GRPCConfig defines the gRPC protocol configuration.
 type GRPCConfig struct {
 	configgrpc.ServerConfig `mapstructure:",squash"`
+
+	// Config holds the shared listener hardening settings (ACLs, PROXY
+	// protocol, ACME, client certificates, TLS file reloading).
+	serverconf.Config `mapstructure:",squash"`
+
+	// MaxRecvMsgSize bounds the size of a received message, e.g. "16MiB"
+	// or a number of bytes, after decompression as well. The upstream
+	// max_recv_msg_size_mib, deprecated, overrides it in MiB when
+	// positive.
+	// Default: 4MiB
+	MaxRecvMsgSize bytesize.Size `mapstructure:"max_recv_msg_size"`
+
+	// Compression restricts the per-message compression accepted from
+	// clients.
+	Compression GRPCCompressionConfig `mapstructure:"compression"`
+}
+
+// maxRecvMsgSize returns the receive limit in bytes, honouring the
+// deprecated max_recv_msg_size_mib. Zero applies the default.
+func (cfg *GRPCConfig) maxRecvMsgSize() int {
+	if mib := cfg.MaxRecvMsgSizeMiB; mib > 0 && mib <= math.MaxInt>>20 {
+		return mib << 20
+	}
+	if size := cfg.MaxRecvMsgSize; size > 0 && size.Bytes() <= math.MaxInt {
+		return int(size.Bytes())
+	}
+	return defaultGRPCMaxRecvMsgSize
+}
+
+// GRPCCompressionConfig defines the per-message compression accepted by
+// the gRPC server. Messages compressed with gzip, zstd or snappy are
+// decompressed; uncompressed messages are always accepted.
+type GRPCCompressionConfig struct {
+	// Accepted lists the codecs clients may compress messages with (gzip,
+	// zstd, snappy). Messages compressed with another codec are rejected
+	// with UNIMPLEMENTED. Empty accepts every codec.
+	// Default: []
+	Accepted []string `mapstructure:"accepted"`
+
+	// MaxDecompressedMsgSize bounds the size of a message after
+	// decompression, e.g. "8MiB" or a number of bytes, so a small
+	// compressed message cannot inflate into a huge one; decompression
+	// stops at the bound and the request fails with RESOURCE_EXHAUSTED.
+	// The lower of this and max_recv_msg_size applies to every message.
+	// Zero applies max_recv_msg_size alone.
+	// Default: 0
+	MaxDecompressedMsgSize bytesize.Size `mapstructure:"max_decompressed_msg_size"`
+}
+
+// Validate checks the compression configuration for errors.
+func (cfg *GRPCCompressionConfig) Validate() error {
+	for _, codec := range cfg.Accepted {
+		if !slices.Contains(grpcCompressors, codec) {
+			return fmt.Errorf("compression.accepted: unknown codec %q (expected one of %s)", codec, strings.Join(grpcCompressors, ", "))
+		}
+	}
+	if cfg.MaxDecompressedMsgSize < 0 {
+		return errors.New("compression.max_decompressed_msg_size must not be negative")
+	}
+	return nil
 }
 
 // HTTPConfig defines the HTTP protocol configuration with TFO-specific settings.
 type HTTPConfig struct {
 	confighttp.ServerConfig `mapstructure:",squash"`
 
+	// Config holds the shared listener hardening settings (ACLs, PROXY
+	// protocol, ACME, client certificates, TLS file reloading).
+	serverconf.Config `mapstructure:",squash"`
+
+	// HeadersConfig holds the Server, HSTS and request ID header settings.
+	serverconf.HeadersConfig `mapstructure:",squash"`
+
 	// TracesURLPath overrides the default traces path. Default: /v1/traces
 	TracesURLPath string `mapstructure:"traces_url_path"`
 
@@ -81,18 +427,186 @@ type HTTPConfig struct {
 
 	// LogsURLPath overrides the default logs path. Default: /v1/logs
 	LogsURLPath string `mapstructure:"logs_url_path"`
+
+	// CORSAllowCredentials lets browsers send credentialed cross-origin
+	// requests from the origins in cors.allowed_origins. It cannot be
+	// combined with the "*" origin. Default: false
+	CORSAllowCredentials bool `mapstructure:"cors_allow_credentials"`
+
+	// WebSocket configures the websocket ingest endpoint.
+	WebSocket WebSocketConfig `mapstructure:"websocket"`
+
+	// Compression restricts the Content-Encoding accepted on export
+	// requests and bounds their decoded size.
+	Compression HTTPCompressionConfig `mapstructure:"compression"`
+}
+
+// HTTPCompressionConfig defines the Content-Encoding accepted on HTTP
+// export requests. Bodies encoded with gzip, zstd or deflate are decoded;
+// unencoded bodies are always accepted.
+type HTTPCompressionConfig struct {
+	// Accepted lists the encodings clients may send bodies with (gzip,
+	// zstd, deflate). Bodies with another encoding are rejected with HTTP
+	// 415. Empty accepts every encoding.
+	// Default: []
+	Accepted []string `mapstructure:"accepted"`
+
+	// MaxDecompressedSize bounds the size of a body after decoding, e.g.
+	// "16MiB" or a number of bytes, so a small compressed body cannot
+	// inflate into a huge one; decoding stops at the bound and the request
+	// is rejected with HTTP 413. Zero applies 64MiB.
+	// Default: 0
+	MaxDecompressedSize bytesize.Size `mapstructure:"max_decompressed_size"`
+}
+
+// Validate checks the compression configuration for errors.
+func (cfg *HTTPCompressionConfig) Validate() error {
+	for _, encoding := range cfg.Accepted {
+		if !slices.Contains(httpEncodings, encoding) {
+			return fmt.Errorf("compression.accepted: unknown encoding %q (expected one of %s)", encoding, strings.Join(httpEncodings, ", "))
+		}
+	}
+	if cfg.MaxDecompressedSize < 0 {
+		return errors.New("compression.max_decompressed_size must not be negative")
+	}
+	return nil
+}
+
+// WebSocketConfig defines the websocket ingest endpoint, served on the HTTP
+// port, for devices that keep one long-lived connection instead of sending
+// a request per export. Each message carries an OTLP export request and is
+// acknowledged once consumed; see the package documentation for the
+// message format.
+type WebSocketConfig struct {
+	// Enabled serves the websocket endpoint.
+	// Default: false
+	Enabled bool `mapstructure:"enabled"`
+
+	// Path is the URL path of the endpoint.
+	// Default: /v1/websocket
+	Path string `mapstructure:"path"`
+
+	// MaxMessageSize is the largest message accepted, e.g. "4MiB" or a
+	// number of bytes. It bounds compressed messages once decompressed. A
+	// larger message closes the connection.
+	// Default: 4MiB
+	MaxMessageSize bytesize.Size `mapstructure:"max_message_size"`
+
+	// PingInterval is how often the collector pings idle connections. A
+	// connection is closed when nothing, not even a pong, arrives for two
+	// intervals.
+	// Default: 30s
+	PingInterval time.Duration `mapstructure:"ping_interval"`
+}
+
+// exportPaths returns the HTTP export paths of every signal and version.
+func (cfg *HTTPConfig) exportPaths() []string {
+	return []string{
+		cmp.Or(cfg.TracesURLPath, defaultTracesURLPath),
+		cmp.Or(cfg.MetricsURLPath, defaultMetricsURLPath),
+		cmp.Or(cfg.LogsURLPath, defaultLogsURLPath),
+		"/v2/traces", "/v2/metrics", "/v2/logs",
+	}
+}
+
+// Validate checks the websocket configuration for errors.
+func (cfg *WebSocketConfig) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if !strings.HasPrefix(cfg.Path, "/") {
+		return errors.New("websocket.path must start with /")
+	}
+	if cfg.MaxMessageSize <= 0 {
+		return errors.New("websocket.max_message_size must be positive")
+	}
+	if cfg.PingInterval <= 0 {
+		return errors.New("websocket.ping_interval must be positive")
+	}
+	return nil
 }
 
 // Validate checks the configuration for errors.
 func (cfg *Config) Validate() error {
+	if err := cfg.Watchdog.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.PayloadCapture.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.Provenance.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.RateLimit.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.Sampling.Validate(); err != nil {
+		return err
+	}
+	if cfg.DrainTimeout < 0 {
+		return errors.New("drain_timeout must not be negative")
+	}
+
 	if cfg.Protocols.GRPC == nil && cfg.Protocols.HTTP == nil {
 		// At least one protocol must be enabled - but we'll use defaults
 		return nil
 	}
 
+	if cfg.Protocols.GRPC != nil {
+		if err := cfg.Protocols.GRPC.Config.Validate(); err != nil {
+			return fmt.Errorf("protocols.grpc: %w", err)
+		}
+		if err := serverconf.ValidateGRPCServer(&cfg.Protocols.GRPC.ServerConfig); err != nil {
+			return fmt.Errorf("protocols.grpc: %w", err)
+		}
+		if err := cfg.Protocols.GRPC.ValidateTLS(cfg.Protocols.GRPC.TLS.Get()); err != nil {
+			return fmt.Errorf("protocols.grpc: %w", err)
+		}
+		if cfg.Protocols.GRPC.MaxRecvMsgSize < 0 || cfg.Protocols.GRPC.MaxRecvMsgSizeMiB < 0 {
+			return errors.New("protocols.grpc: max_recv_msg_size and max_recv_msg_size_mib must not be negative")
+		}
+		if err := cfg.Protocols.GRPC.Compression.Validate(); err != nil {
+			return fmt.Errorf("protocols.grpc: %w", err)
+		}
+		endpoint := cmp.Or(cfg.Protocols.GRPC.NetAddr.Endpoint, DefaultGRPCEndpoint)
+		if err := cfg.Protocols.GRPC.ValidateEndpoint(endpoint); err != nil {
+			return fmt.Errorf("protocols.grpc: %w", err)
+		}
+	}
+	if cfg.Protocols.HTTP != nil {
+		if err := cfg.Protocols.HTTP.Config.Validate(); err != nil {
+			return fmt.Errorf("protocols.http: %w", err)
+		}
+		if err := serverconf.ValidateHTTPServer(&cfg.Protocols.HTTP.ServerConfig); err != nil {
+			return fmt.Errorf("protocols.http: %w", err)
+		}
+		if err := cfg.Protocols.HTTP.ValidateTLS(cfg.Protocols.HTTP.TLS.Get()); err != nil {
+			return fmt.Errorf("protocols.http: %w", err)
+		}
+		endpoint := cmp.Or(cfg.Protocols.HTTP.NetAddr.Endpoint, DefaultHTTPEndpoint)
+		if err := cfg.Protocols.HTTP.ValidateEndpoint(endpoint); err != nil {
+			return fmt.Errorf("protocols.http: %w", err)
+		}
+		if err := cfg.Protocols.HTTP.HeadersConfig.Validate(); err != nil {
+			return fmt.Errorf("protocols.http: %w", err)
+		}
+		if err := serverconf.ValidateCORS(cfg.Protocols.HTTP.CORS.Get(), cfg.Protocols.HTTP.CORSAllowCredentials); err != nil {
+			return fmt.Errorf("protocols.http: %w", err)
+		}
+		if err := cfg.Protocols.HTTP.WebSocket.Validate(); err != nil {
+			return fmt.Errorf("protocols.http: %w", err)
+		}
+		if err := cfg.Protocols.HTTP.Compression.Validate(); err != nil {
+			return fmt.Errorf("protocols.http: %w", err)
+		}
+		if ws := cfg.Protocols.HTTP.WebSocket; ws.Enabled && slices.Contains(cfg.Protocols.HTTP.exportPaths(), ws.Path) {
+			return fmt.Errorf("protocols.http: websocket.path %q is already an export path", ws.Path)
+		}
+	}
+
 	// Validate V2Auth if v2 endpoints are enabled
 	if cfg.EnableV2Endpoints && cfg.V2Auth.Required {
-		if cfg.V2Auth.ValidateSecret && len(cfg.V2Auth.ValidAPIKeyIDs) > 0 {
+		if cfg.V2Auth.ValidateSecret && len(cfg.V2Auth.ValidAPIKeyIDs) > 0 && cfg.V2Auth.Validator.String() == "" {
 			// When validating secrets with specific key IDs, we need a way to store secrets
 			// For now, this is a configuration error - use extension-based auth instead
 			return errors.New("validate_secret with valid_api_key_ids requires tfoauth extension")
@@ -20,7 +20,94 @@
 //   - v1 endpoints: /v1/traces, /v1/metrics, /v1/logs (OTEL standard)
 //   - v2 endpoints: /v2/traces, /v2/metrics, /v2/logs (TFO Platform)
 //   - Both endpoints served on the same port (4318)
-//   - Full gRPC support on port 4317
+//   - Full gRPC support on port 4317, accepting gzip, zstd and snappy
+//     compressed requests up to max_recv_msg_size (default 4MiB, applied
+//     to the decompressed message; the deprecated max_recv_msg_size_mib
+//     takes precedence when set)
+//   - Optional watchdog restarting the servers when consumers stop making progress
+//   - Optional payload capture dumping raw HTTP request bodies for debugging
+//   - CORS, including preflight (OPTIONS) responses, for browser senders
+//   - Configurable Server, HSTS and custom response headers
+//   - A request ID taken from X-Request-ID (x-request-id metadata on gRPC)
+//     or generated, echoed in the response and passed to the pipeline in
+//     the context, so receiver, processor and exporter logs can be matched
+//   - Connection draining on shutdown and reload, bounded by drain_timeout
+//   - Repeated consumer failures logged once, then summarized every minute
+//     until the pipeline recovers (each failure is logged at debug level)
+//   - Histograms of request size, decompressed size and records per request
+//     for each signal, protocol (grpc, http) and endpoint (v1, v2):
+//     tfo_receiver_request_size, tfo_receiver_request_decompressed_size and
+//     tfo_receiver_request_records
+//   - Optional provenance envelope for multi-hop topologies, recording the
+//     origin collector, tenant and receive time and every collector passed
+//     through in resource attributes (see pkg/provenance)
+//   - TLS from the tls settings, or certificates issued and renewed through
+//     ACME with the tls certificate as fallback
+//   - Requests checked by the server auth extension of each protocol
+//     (auth): rejected HTTP requests get 401 and RPCs UNAUTHENTICATED
+//   - gRPC per-message compression with gzip, zstd or snappy, optionally
+//     restricted to some codecs (compression.accepted; others get
+//     UNIMPLEMENTED) and bounded after decompression
+//     (compression.max_decompressed_msg_size, RESOURCE_EXHAUSTED)
+//   - HTTP request bodies bounded by max_request_body_size (default 20
+//     MiB, on the wire): larger bodies get HTTP 413 with a google.rpc.Status
+//     body, without being read when Content-Length announces them
+//   - HTTP request bodies decoded by Content-Encoding (gzip, zstd or
+//     deflate), optionally restricted to some encodings
+//     (compression.accepted; others get HTTP 415) and bounded after
+//     decoding against zip bombs (compression.max_decompressed_size,
+//     64 MiB by default; larger bodies get HTTP 413)
+//   - OTLP export responses as the specification prescribes: successful
+//     HTTP responses in the encoding of the request, reporting rejected
+//     records as a partial success; failures with a google.rpc.Status body
+//     and the HTTP status mapped from the gRPC code (429 for
+//     RESOURCE_EXHAUSTED, 503 for UNAVAILABLE); RetryInfo on retryable
+//     gRPC errors, sent as Retry-After over HTTP. Consumer errors are
+//     retryable UNAVAILABLE unless permanent (INTERNAL); a status returned
+//     by a consumer, e.g. the memory limiter, is passed through
+//   - Mutual TLS on both protocols (client_auth_type with tls.client_ca_file
+//     and tls.min_version), with the certificate, key and client CA files
+//     reloaded when they change on disk (tls_reload)
+//   - IPv4-only, IPv6-only or dual-stack listeners (network), logged with
+//     the bound address and, for HTTP, the local URL
+//   - Optional rate limit on received records, rejecting requests over it
+//     with HTTP 429 or gRPC RESOURCE_EXHAUSTED; in per_trace mode spans are
+//     admitted or rejected by trace ID, so a trace split across requests
+//     is kept whole (tfo_receiver_rate_limited counts rejected records)
+//   - Optional rate limit per API key on the v2 endpoints (per_key), keyed
+//     by X-TelemetryFlow-Key-ID with overrides for single keys, so one
+//     noisy key cannot exhaust the shared limit; rejected requests get
+//     HTTP 429 with Retry-After (tfo_receiver_key_rate_limited counts
+//     rejected records)
+//   - Optional maintenance gate: while the referenced tfomaintenance
+//     extension is in reject mode, requests are refused with HTTP 503 and
+//     Retry-After or gRPC UNAVAILABLE (tfo_receiver_maintenance_rejected
+//     counts rejected records)
+//   - v2 API keys validated through an extension (v2_auth.validator), e.g.
+//     tfoauth with a validation_endpoint, so revoked keys are refused with
+//     HTTP 401; when the key cannot be validated requests get HTTP 503
+//   - Rejected v2 requests counted in tfo_receiver_auth_failures by reason
+//     (missing_key, invalid_key_format, key_not_allowed, missing_secret,
+//     invalid_secret_format, invalid_credentials, validation_failed) and
+//     key_prefix, the key ID masked to its first 8 characters for key IDs
+//     listed in valid_api_key_ids or once validated ("none" without a key
+//     ID, "invalid" for one not starting with tfk_, "unknown" for any
+//     other, keeping the label bounded)
+//   - Optional head sampling of traces and log records by percentage;
+//     traces are kept or dropped whole by trace ID, log records follow
+//     the decision for their trace. Sampled-out records are accepted and
+//     not reported as rejected
+//   - Rate limit and sampling percentages overridden at runtime through a
+//     tfooverrides extension, without a configuration reload or touching
+//     the listeners
+//   - Optional websocket ingest endpoint on the HTTP port for devices on
+//     flaky networks that keep one long-lived connection, with an ack per
+//     message
+//   - One instance per receiver configuration, shared by its signals, to
+//     which several consumers of the same signal can be registered: each
+//     gets every request, a mutating consumer on its own copy, and a
+//     request fails only if every consumer failed (the failures of single
+//     consumers are logged instead)
 //
 // Configuration example:
 //
@@ -28,8 +115,169 @@
 //	  tfootlp:
 //	    protocols:
 //	      grpc:
-//	        endpoint: "0.0.0.0:4317"
+//	        endpoint: "[::]:4317"
+//	        network: dual
+//	        max_recv_msg_size: 16MiB
+//	        compression:
+//	          accepted: [gzip, zstd]
+//	          max_decompressed_msg_size: 8MiB
 //	      http:
 //	        endpoint: "0.0.0.0:4318"
+//	        max_request_body_size: 10485760
+//	        compression:
+//	          accepted: [gzip, zstd]
+//	          max_decompressed_size: 16MiB
+//	        cors:
+//	          allowed_origins: ["https://*.example.com"]
+//	          allowed_headers: ["X-TelemetryFlow-Key-ID"]
+//	          max_age: 7200
 //	    enable_v2_endpoints: true
+//	    v2_auth:
+//	      required: true
+//	      validator: tfoauth
+//	    drain_timeout: 10s
+//	    rate_limit:
+//	      enabled: true
+//	      records_per_second: 50000
+//	      burst: 100000
+//	      per_trace: true
+//	      trace_window: 30s
+//	      per_key:
+//	        enabled: true
+//	        records_per_second: 5000
+//	        burst: 10000
+//	        keys:
+//	          tfk_bulk_importer:
+//	            records_per_second: 20000
+//	            burst: 40000
+//	    sampling:
+//	      enabled: true
+//	      traces: 25
+//	      logs: 100
+//	    maintenance: tfomaintenance
+//	    overrides: tfooverrides
+//
+// On a reload the old servers stop accepting and finish in-flight requests
+// for up to drain_timeout; requests cut off at the deadline are counted in
+// tfo_receiver_drain_dropped_requests. The listening sockets are handed over
+// to the new servers, so a reload that keeps the endpoints (e.g. only TLS or
+// auth settings changed) refuses no connections. When an endpoint changes,
+// the old socket is closed after a short grace period, or as soon as the new
+// endpoint needs its port.
+//
+// Payload capture is armed through its admin API, e.g.
+//
+//	curl -X POST localhost:55690/capture \
+//	  -d '{"endpoint": "/v1/traces", "count": 5, "duration": "2m"}'
+//
+// which writes the next five /v1/traces bodies verbatim, each with a
+// .meta.json file whose secret headers and query values are redacted:
+//
+//	receivers:
+//	  tfootlp:
+//	    payload_capture:
+//	      enabled: true
+//	      endpoint: "localhost:55690"
+//	      directory: /var/lib/tfo-collector/capture
+//	      max_count: 100
+//	      max_duration: 10m
+//
+// The capture admin API takes the shared admin server settings of
+// pkg/serverconf (tls, auth, acl, network, response_headers and the
+// timeouts). An endpoint whose host is not a loopback address is refused
+// unless clients authenticate through auth or a client certificate verified
+// against tls.client_ca_file.
+//
+// With provenance enabled, edge collectors and regional aggregators each
+// stamp their collector ID, taken from a tfoidentity extension, on the
+// resources they receive. The first collector also records the tenant
+// named by tenant_header (request header or gRPC metadata) and the receive
+// time. Resources that already passed through this collector, or through
+// max_hops collectors, are dropped while the rest of the request is
+// accepted, so misrouted senders do not retry them; the drops are logged
+// and counted in tfo_receiver_provenance_rejected:
+//
+//	receivers:
+//	  tfootlp:
+//	    provenance:
+//	      enabled: true
+//	      collector_identity: tfoidentity
+//	      tenant_header: X-TelemetryFlow-Tenant
+//	      max_hops: 8
+//
+// With acme enabled on a protocol, its certificate is obtained from an ACME
+// CA (Let's Encrypt by default, or an internal CA through directory_url) on
+// start and renewed before expiry. TLS-ALPN-01 challenges are answered on
+// the protocol's own listener, so the CA must reach it on port 443; set
+// http_challenge_endpoint to answer HTTP-01 challenges on port 80 instead.
+// Until a certificate is issued, and for clients that connect by address
+// or to other names, the tls certificate is served if configured:
+//
+//	receivers:
+//	  tfootlp:
+//	    protocols:
+//	      http:
+//	        endpoint: "0.0.0.0:443"
+//	        tls:
+//	          cert_file: /etc/tfo/tls/fallback.crt
+//	          key_file: /etc/tfo/tls/fallback.key
+//	        acme:
+//	          enabled: true
+//	          domains: ["otlp.edge.example.com"]
+//	          email: ops@example.com
+//	          cache_dir: /var/lib/tfo-collector/acme
+//	          http_challenge_endpoint: ":80"
+//
+// For mutual TLS, client_auth_type selects how client certificates are
+// checked against tls.client_ca_file; require_and_verify rejects clients
+// without a certificate signed by it. With tls_reload enabled, rotated
+// files are picked up by the next handshakes without a restart:
+//
+//	receivers:
+//	  tfootlp:
+//	    protocols:
+//	      grpc:
+//	        endpoint: "0.0.0.0:4317"
+//	        tls:
+//	          cert_file: /etc/tfo/tls/tls.crt
+//	          key_file: /etc/tfo/tls/tls.key
+//	          client_ca_file: /etc/tfo/tls/ca.crt
+//	          min_version: "1.3"
+//	        client_auth_type: require_and_verify
+//	        tls_reload:
+//	          enabled: true
+//	          interval: 10s
+//
+// The websocket endpoint accepts the otlp.proto and otlp.msgpack
+// subprotocols, chosen by the client in Sec-WebSocket-Protocol; the upgrade
+// request is authenticated like the v2 endpoints. Each binary message is an
+// envelope with a client-chosen id, the signal ("traces", "metrics" or
+// "logs") and an OTLP protobuf export request as payload, encoded as
+//
+//	message WebSocketMessage { uint64 id = 1; string signal = 2; bytes payload = 3; }
+//
+// or as the MessagePack map {"id": uint, "signal": str, "payload": bin}.
+// Messages pass the same maintenance, rate limit and provenance checks as
+// HTTP requests, are consumed in order and acknowledged in the same
+// encoding with an HTTP status code:
+//
+//	message WebSocketAck { uint64 id = 1; uint32 code = 2; string message = 3; int64 rejected = 4; }
+//
+// or {"id": uint, "code": uint, "message": str, "rejected": uint}, where
+// message is set on errors and rejected counts the spans rejected from a
+// partially admitted request. Clients may send several messages before the
+// first ack and should resend unacknowledged ones after reconnecting. A
+// message that cannot be decoded closes the connection, as does silence
+// for two ping intervals; on shutdown messages being consumed are
+// acknowledged before the connection is closed (code 1001):
+//
+//	receivers:
+//	  tfootlp:
+//	    protocols:
+//	      http:
+//	        websocket:
+//	          enabled: true
+//	          path: /v1/websocket
+//	          max_message_size: 4MiB
+//	          ping_interval: 30s
 package tfootlpreceiver // import "github.com/telemetryflow/telemetryflow-collector/components/tfootlpreceiver"
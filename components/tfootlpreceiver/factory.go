@@ -16,6 +16,7 @@ package tfootlpreceiver
 
 import (
 	"context"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/config/configgrpc"
@@ -23,6 +24,10 @@ import (
 	"go.opentelemetry.io/collector/config/confignet"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/bytesize"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/watchdog"
 )
 
 const (
@@ -35,10 +40,34 @@ const (
 	// DefaultHTTPEndpoint is the default HTTP endpoint.
 	DefaultHTTPEndpoint = "0.0.0.0:4318"
 
+	// DefaultCaptureEndpoint is the default payload capture admin endpoint.
+	DefaultCaptureEndpoint = "localhost:55690"
+
+	// Default payload capture session limits
+	defaultCaptureMaxCount    = 100
+	defaultCaptureMaxDuration = 10 * time.Minute
+
+	// defaultDrainTimeout bounds how long Shutdown waits for in-flight requests.
+	defaultDrainTimeout = 10 * time.Second
+
+	// Default provenance envelope settings
+	defaultTenantHeader = "X-TelemetryFlow-Tenant"
+	defaultMaxHops      = 8
+
+	// Default per-trace and per-key rate limit settings
+	defaultTraceWindow = 30 * time.Second
+	defaultMaxTraces   = 65536
+	defaultMaxKeys     = 10000
+
 	// Default URL paths for OTLP v1 (standard)
 	defaultTracesURLPath  = "/v1/traces"
 	defaultMetricsURLPath = "/v1/metrics"
 	defaultLogsURLPath    = "/v1/logs"
+
+	// Default websocket ingest settings
+	defaultWebSocketPath           = "/v1/websocket"
+	defaultWebSocketMaxMessageSize = 4 * bytesize.MiB
+	defaultWebSocketPingInterval   = 30 * time.Second
 )
 
 // NewFactory creates a new factory for the TFO OTLP receiver.
@@ -67,6 +96,7 @@ func createDefaultConfig() component.Config {
 						Transport: confignet.TransportTypeTCP,
 					},
 				},
+				MaxRecvMsgSize: defaultGRPCMaxRecvMsgSize,
 			},
 			HTTP: func() *HTTPConfig {
 				httpServerCfg := confighttp.NewDefaultServerConfig()
@@ -77,6 +107,11 @@ func createDefaultConfig() component.Config {
 					TracesURLPath:  defaultTracesURLPath,
 					MetricsURLPath: defaultMetricsURLPath,
 					LogsURLPath:    defaultLogsURLPath,
+					WebSocket: WebSocketConfig{
+						Path:           defaultWebSocketPath,
+						MaxMessageSize: defaultWebSocketMaxMessageSize,
+						PingInterval:   defaultWebSocketPingInterval,
+					},
 				}
 			}(),
 		},
@@ -85,6 +120,28 @@ func createDefaultConfig() component.Config {
 			Required:       true,  // v2 endpoints require TFO auth by default
 			ValidateSecret: false, // Only validate API Key ID presence by default
 		},
+		Watchdog: watchdog.NewDefaultConfig(),
+		PayloadCapture: PayloadCaptureConfig{
+			AdminConfig: serverconf.NewDefaultAdminConfig(DefaultCaptureEndpoint),
+			MaxCount:    defaultCaptureMaxCount,
+			MaxDuration: defaultCaptureMaxDuration,
+		},
+		DrainTimeout: defaultDrainTimeout,
+		Provenance: ProvenanceConfig{
+			TenantHeader: defaultTenantHeader,
+			MaxHops:      defaultMaxHops,
+		},
+		RateLimit: RateLimitConfig{
+			TraceWindow: defaultTraceWindow,
+			MaxTraces:   defaultMaxTraces,
+			PerKey: KeyRateLimitConfig{
+				MaxKeys: defaultMaxKeys,
+			},
+		},
+		Sampling: SamplingConfig{
+			Traces: 100,
+			Logs:   100,
+		},
 	}
 }
 
@@ -0,0 +1,165 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver
+
+import (
+	"context"
+	"errors"
+
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/requestid"
+)
+
+// Log messages of consumers failing while others accepted the same data,
+// aggregated by the receiver failures.
+const (
+	failedFanOutTraces  = "Consumer failed to consume traces accepted by other consumers"
+	failedFanOutMetrics = "Consumer failed to consume metrics accepted by other consumers"
+	failedFanOutLogs    = "Consumer failed to consume logs accepted by other consumers"
+)
+
+// fanOut passes the data of one signal to several consumers. A consumer
+// failing does not keep the data from the others, and the request only
+// fails if every consumer failed; the failures of single consumers are
+// logged instead, since the client retrying would duplicate the data for
+// the consumers that accepted it.
+//
+// Consumers that do not mutate the data share it; those that do get their
+// own copy, since the others may still hold on to the data.
+type fanOut[T any] struct {
+	r       *tfoOTLPReceiver
+	msg     string
+	consume []func(context.Context, T) error
+	mutates []bool
+	clone   func(T) T
+}
+
+// baseConsumer is the part of the consumer interfaces common to all
+// signals.
+type baseConsumer interface {
+	Capabilities() consumer.Capabilities
+}
+
+func newFanOut[C baseConsumer, T any](r *tfoOTLPReceiver, msg string, consumers []C,
+	consume func(C) func(context.Context, T) error, clone func(T) T,
+) *fanOut[T] {
+	f := &fanOut[T]{r: r, msg: msg, clone: clone}
+	for _, c := range consumers {
+		f.consume = append(f.consume, consume(c))
+		f.mutates = append(f.mutates, c.Capabilities().MutatesData)
+	}
+	return f
+}
+
+// Capabilities implements the consumer interfaces. Consumers that mutate
+// the data get their own copy.
+func (f *fanOut[T]) Capabilities() consumer.Capabilities {
+	return consumer.Capabilities{MutatesData: false}
+}
+
+func (f *fanOut[T]) fanOut(ctx context.Context, data T) error {
+	var errs []error
+	failed := make([]int, 0, len(f.consume))
+	for i, consume := range f.consume {
+		d := data
+		if f.mutates[i] {
+			d = f.clone(data)
+		}
+		if err := consume(ctx, d); err != nil {
+			errs = append(errs, err)
+			failed = append(failed, i)
+		}
+	}
+
+	switch {
+	case len(errs) == len(f.consume):
+		return errors.Join(errs...)
+	case len(errs) > 0:
+		for i, err := range errs {
+			f.r.failures.Error(f.msg, err, zap.Int("consumer", failed[i]), requestid.Field(ctx))
+		}
+	default:
+		f.r.failures.Success(f.msg)
+	}
+	return nil
+}
+
+type tracesFanOut struct{ *fanOut[ptrace.Traces] }
+
+// newTracesFanOut returns the consumer passing traces to consumers.
+func newTracesFanOut(r *tfoOTLPReceiver, consumers []consumer.Traces) consumer.Traces {
+	if len(consumers) == 1 {
+		return consumers[0]
+	}
+	return tracesFanOut{newFanOut(r, failedFanOutTraces, consumers,
+		func(c consumer.Traces) func(context.Context, ptrace.Traces) error { return c.ConsumeTraces },
+		func(td ptrace.Traces) ptrace.Traces {
+			clone := ptrace.NewTraces()
+			td.CopyTo(clone)
+			return clone
+		})}
+}
+
+// ConsumeTraces implements consumer.Traces.
+func (f tracesFanOut) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	return f.fanOut.fanOut(ctx, td)
+}
+
+type metricsFanOut struct{ *fanOut[pmetric.Metrics] }
+
+// newMetricsFanOut returns the consumer passing metrics to consumers.
+func newMetricsFanOut(r *tfoOTLPReceiver, consumers []consumer.Metrics) consumer.Metrics {
+	if len(consumers) == 1 {
+		return consumers[0]
+	}
+	return metricsFanOut{newFanOut(r, failedFanOutMetrics, consumers,
+		func(c consumer.Metrics) func(context.Context, pmetric.Metrics) error { return c.ConsumeMetrics },
+		func(md pmetric.Metrics) pmetric.Metrics {
+			clone := pmetric.NewMetrics()
+			md.CopyTo(clone)
+			return clone
+		})}
+}
+
+// ConsumeMetrics implements consumer.Metrics.
+func (f metricsFanOut) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	return f.fanOut.fanOut(ctx, md)
+}
+
+type logsFanOut struct{ *fanOut[plog.Logs] }
+
+// newLogsFanOut returns the consumer passing logs to consumers.
+func newLogsFanOut(r *tfoOTLPReceiver, consumers []consumer.Logs) consumer.Logs {
+	if len(consumers) == 1 {
+		return consumers[0]
+	}
+	return logsFanOut{newFanOut(r, failedFanOutLogs, consumers,
+		func(c consumer.Logs) func(context.Context, plog.Logs) error { return c.ConsumeLogs },
+		func(ld plog.Logs) plog.Logs {
+			clone := plog.NewLogs()
+			ld.CopyTo(clone)
+			return clone
+		})}
+}
+
+// ConsumeLogs implements consumer.Logs.
+func (f logsFanOut) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	return f.fanOut.fanOut(ctx, ld)
+}
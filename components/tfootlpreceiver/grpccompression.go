@@ -0,0 +1,67 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver
+
+import (
+	"context"
+	"slices"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// grpcCompressors are the per-message compression codecs registered for
+// the gRPC server.
+var grpcCompressors = []string{"gzip", "zstd", "snappy"}
+
+// defaultGRPCMaxRecvMsgSize bounds received gRPC messages unless
+// max_recv_msg_size is set.
+const defaultGRPCMaxRecvMsgSize = 4 << 20
+
+// recvCompressor is implemented by the server transport stream and
+// reports the codec of the received messages.
+type recvCompressor interface {
+	RecvCompress() string
+}
+
+// compressionOptions returns the server options enforcing cfg: an
+// interceptor rejecting messages compressed with codecs outside accepted,
+// and the receive limit lowered to the decompressed message bound.
+func compressionOptions(cfg *GRPCConfig) []grpc.ServerOption {
+	var opts []grpc.ServerOption
+	if accepted := cfg.Compression.Accepted; len(accepted) > 0 {
+		// gRPC hides grpc-encoding from tap handles and decompresses before
+		// interceptors run, so a rejected message has been decompressed
+		// within the receive limit.
+		opts = append(opts, grpc.ChainUnaryInterceptor(func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+			stream, ok := grpc.ServerTransportStreamFromContext(ctx).(recvCompressor)
+			if !ok {
+				return handler(ctx, req)
+			}
+			if codec := stream.RecvCompress(); codec != "" && codec != "identity" && !slices.Contains(accepted, codec) {
+				return nil, status.Errorf(codes.Unimplemented, "grpc: compression %q is not accepted", codec)
+			}
+			return handler(ctx, req)
+		}))
+	}
+	if size := cfg.Compression.MaxDecompressedMsgSize; size > 0 {
+		// gRPC checks its receive limit on the wire and again while
+		// decompressing, stopping at the limit, so the lower of the two
+		// bounds both.
+		opts = append(opts, grpc.MaxRecvMsgSize(int(min(int64(cfg.maxRecvMsgSize()), size.Bytes()))))
+	}
+	return opts
+}
@@ -0,0 +1,146 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/bytesize"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/requestid"
+)
+
+// httpEncodings are the Content-Encodings decoded on HTTP export requests.
+var httpEncodings = []string{"gzip", "zstd", "deflate"}
+
+// defaultHTTPMaxDecompressedSize bounds decoded HTTP bodies unless
+// max_decompressed_size is set.
+const defaultHTTPMaxDecompressedSize = 64 * bytesize.MiB
+
+var (
+	// errUnsupportedEncoding rejects a body whose Content-Encoding is
+	// unknown or not accepted.
+	errUnsupportedEncoding = errors.New("unsupported Content-Encoding")
+
+	// errDecompressedTooLarge rejects a body that decodes past the bound.
+	errDecompressedTooLarge = errors.New("decompressed body too large")
+)
+
+// zstdDecoders keeps zstd decoders for reuse; creating one allocates its
+// window buffers.
+var zstdDecoders = sync.Pool{New: func() any {
+	dec, err := zstd.NewReader(nil, zstd.WithDecoderConcurrency(1))
+	if err != nil {
+		return nil
+	}
+	return dec
+}}
+
+// decodeBody decodes buf by the Content-Encoding of req. An unencoded body
+// is returned as is; otherwise buf is released and the decoded body is
+// returned in another pooled buffer. On error buf has been released.
+func (r *tfoOTLPReceiver) decodeBody(req *http.Request, buf *bytes.Buffer) (*bytes.Buffer, error) {
+	encoding := strings.ToLower(strings.TrimSpace(req.Header.Get("Content-Encoding")))
+	if encoding == "" || encoding == "identity" {
+		return buf, nil
+	}
+	defer releaseBody(buf)
+
+	cfg := r.cfg.Protocols.HTTP.Compression
+	if !slices.Contains(httpEncodings, encoding) || (len(cfg.Accepted) > 0 && !slices.Contains(cfg.Accepted, encoding)) {
+		return nil, fmt.Errorf("%w %q", errUnsupportedEncoding, encoding)
+	}
+	limit := defaultHTTPMaxDecompressedSize.Bytes()
+	if cfg.MaxDecompressedSize > 0 {
+		limit = cfg.MaxDecompressedSize.Bytes()
+	}
+
+	var dec io.Reader
+	switch encoding {
+	case "gzip":
+		zr, err := gzip.NewReader(buf)
+		if err != nil {
+			return nil, err
+		}
+		dec = zr
+	case "deflate":
+		// HTTP deflate is the zlib format (RFC 9110).
+		zr, err := zlib.NewReader(buf)
+		if err != nil {
+			return nil, err
+		}
+		defer func() { _ = zr.Close() }()
+		dec = zr
+	case "zstd":
+		zd, ok := zstdDecoders.Get().(*zstd.Decoder)
+		if !ok {
+			return nil, errors.New("zstd decoder unavailable")
+		}
+		if err := zd.Reset(buf); err != nil {
+			return nil, err
+		}
+		defer func() {
+			_ = zd.Reset(nil)
+			zstdDecoders.Put(zd)
+		}()
+		dec = zd
+	}
+
+	out := bodyPool.Get().(*bytes.Buffer)
+	out.Reset()
+	// One byte past the limit tells a body at the bound from one over it.
+	if _, err := out.ReadFrom(io.LimitReader(dec, limit+1)); err != nil {
+		releaseBody(out)
+		return nil, err
+	}
+	if int64(out.Len()) > limit {
+		releaseBody(out)
+		return nil, errDecompressedTooLarge
+	}
+	return out, nil
+}
+
+// writeDecodeError logs err from decodeBody and responds with its status:
+// 415 for an unsupported encoding, 413 for a body over the bound and 400
+// for a body that cannot be decoded.
+func (r *tfoOTLPReceiver) writeDecodeError(w http.ResponseWriter, req *http.Request, err error) {
+	r.logger.Warn("Failed to decode request body",
+		zap.Error(err),
+		zap.String("content_encoding", req.Header.Get("Content-Encoding")),
+		zap.String("path", req.URL.Path),
+		requestid.Field(req.Context()),
+	)
+	switch {
+	case errors.Is(err, errUnsupportedEncoding):
+		writeStatus(w, req, http.StatusUnsupportedMediaType, status.New(codes.InvalidArgument, "Unsupported Content-Encoding"))
+	case errors.Is(err, errDecompressedTooLarge):
+		writeStatus(w, req, http.StatusRequestEntityTooLarge, status.New(codes.ResourceExhausted, "decompressed body exceeds max_decompressed_size"))
+	default:
+		writeError(w, req, status.New(codes.InvalidArgument, "Failed to decode body"))
+	}
+}
@@ -25,7 +25,7 @@ import (
 
 // TestNewTFOOTLPReceiver_NilArgs exercises the defensive nil-check branches
 // in newTFOOTLPReceiver that cannot be reached via the public factory API.
-// Each nil-check runs BEFORE the singleton lookup so it is safe to invoke
+// Each nil-check runs BEFORE the shared instance lookup so it is safe to invoke
 // directly without corrupting process state.
 func TestNewTFOOTLPReceiver_NilArgs(t *testing.T) {
 	goodSettings := func() *receiver.Settings {
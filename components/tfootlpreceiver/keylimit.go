@@ -0,0 +1,155 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver
+
+import (
+	"container/list"
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/codes"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/errlog"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/requestid"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+// keyRateLimited is the log message of telemetry rejected over the limit
+// of its API key, aggregated by the receiver failures.
+const keyRateLimited = "Rejected telemetry over the API key rate limit"
+
+// errKeyRateLimited is logged for rejected telemetry.
+var errKeyRateLimited = errors.New("API key rate limit exceeded")
+
+// keyLimiter admits received records at the configured rate of their API
+// key. A nil *keyLimiter admits everything.
+type keyLimiter struct {
+	cfg      KeyRateLimitConfig
+	failures *errlog.Aggregator
+
+	// buckets indexes the elements of lru, which holds a *keyBucket per
+	// key with the most recently seen key first.
+	mu      sync.Mutex
+	buckets map[string]*list.Element
+	lru     *list.List
+
+	// rejected is nil without a meter provider.
+	rejected metric.Int64Counter
+	options  map[pipeline.Signal]metric.MeasurementOption
+}
+
+// keyBucket is the token bucket of one API key.
+type keyBucket struct {
+	key    string
+	bucket *rate.Limiter
+}
+
+// newKeyLimiter creates the per-key rate limiter of cfg.
+func newKeyLimiter(cfg KeyRateLimitConfig, set component.TelemetrySettings, labels selfmetrics.Labels, failures *errlog.Aggregator) (*keyLimiter, error) {
+	l := &keyLimiter{
+		cfg:      cfg,
+		failures: failures,
+		buckets:  make(map[string]*list.Element),
+		lru:      list.New(),
+	}
+	if set.MeterProvider != nil {
+		var err error
+		l.rejected, err = set.MeterProvider.Meter(scopeName).Int64Counter(selfmetrics.ReceiverKeyRateLimited,
+			metric.WithDescription("Records rejected because they exceeded the rate limit of their API key."),
+			metric.WithUnit("{record}"))
+		if err != nil {
+			return nil, err
+		}
+		l.options = make(map[pipeline.Signal]metric.MeasurementOption)
+		for _, signal := range []pipeline.Signal{pipeline.SignalTraces, pipeline.SignalMetrics, pipeline.SignalLogs} {
+			l.options[signal] = labels.WithSignal(signal).Option()
+		}
+	}
+	return l, nil
+}
+
+// bucketOf returns the bucket of key, creating it full if the key was not
+// seen recently.
+func (l *keyLimiter) bucketOf(key string) *rate.Limiter {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if e, ok := l.buckets[key]; ok {
+		l.lru.MoveToFront(e)
+		return e.Value.(*keyBucket).bucket
+	}
+
+	recordsPerSecond, burst := l.cfg.RecordsPerSecond, l.cfg.Burst
+	if limit, ok := l.cfg.Keys[key]; ok {
+		recordsPerSecond, burst = limit.RecordsPerSecond, limit.Burst
+	}
+	b := &keyBucket{key: key, bucket: rate.NewLimiter(limitOf(recordsPerSecond, burst))}
+	l.buckets[key] = l.lru.PushFront(b)
+	for l.lru.Len() > l.cfg.MaxKeys {
+		oldest := l.lru.Back()
+		l.lru.Remove(oldest)
+		delete(l.buckets, oldest.Value.(*keyBucket).key)
+	}
+	return b.bucket
+}
+
+// allow reports whether a request of n records of signal sent with the API
+// key is admitted. A rejected request may be retried after the returned
+// delay; a request larger than the burst of its key never fits and is
+// told to retry after one second.
+func (l *keyLimiter) allow(ctx context.Context, key string, signal pipeline.Signal, n int) (time.Duration, bool) {
+	if l == nil || n == 0 {
+		return 0, true
+	}
+	now := time.Now()
+	reservation := l.bucketOf(key).ReserveN(now, n)
+	retryAfter := time.Second
+	if reservation.OK() {
+		retryAfter = reservation.DelayFrom(now)
+		if retryAfter == 0 {
+			l.failures.Success(keyRateLimited)
+			return 0, true
+		}
+		reservation.CancelAt(now)
+	}
+
+	l.failures.Error(keyRateLimited, errKeyRateLimited,
+		zap.String("key_id", key),
+		zap.String("signal", signal.String()),
+		zap.Int("records", n),
+		requestid.Field(ctx),
+	)
+	if l.rejected != nil {
+		l.rejected.Add(context.WithoutCancel(ctx), int64(n), l.options[signal])
+	}
+	return retryAfter, false
+}
+
+// allowKey applies the per-key rate limit to an HTTP request of n records
+// of signal and answers it when it is rejected.
+func (r *tfoOTLPReceiver) allowKey(w http.ResponseWriter, req *http.Request, signal pipeline.Signal, n int) bool {
+	retryAfter, ok := r.keyLimiter.allow(req.Context(), req.Header.Get(headerKeyID), signal, n)
+	if !ok {
+		writeError(w, req, retryStatus(codes.ResourceExhausted, errKeyRateLimited.Error(), retryAfter))
+	}
+	return ok
+}
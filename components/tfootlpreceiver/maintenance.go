@@ -0,0 +1,115 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/codes"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/errlog"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/requestid"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+// maintenanceRejected is the log message of telemetry rejected during
+// maintenance, aggregated by the receiver failures.
+const maintenanceRejected = "Rejected telemetry during maintenance"
+
+// maintenanceRetryDelay is the retry delay suggested to senders of
+// telemetry rejected during maintenance.
+const maintenanceRetryDelay = 30 * time.Second
+
+// errMaintenance is returned to senders of telemetry rejected during
+// maintenance.
+var errMaintenance = errors.New("collector in maintenance mode")
+
+// MaintenanceProvider is an interface for extensions that put the
+// collector in maintenance mode.
+type MaintenanceProvider interface {
+	// Rejecting reports whether receivers should refuse telemetry.
+	Rejecting() bool
+}
+
+// maintenanceGate refuses received telemetry while the maintenance
+// extension is in reject mode. A nil *maintenanceGate admits everything.
+type maintenanceGate struct {
+	provider MaintenanceProvider
+	failures *errlog.Aggregator
+
+	// rejected is nil without a meter provider.
+	rejected metric.Int64Counter
+	options  map[pipeline.Signal]metric.MeasurementOption
+}
+
+// newMaintenanceGate resolves the maintenance extension id.
+func newMaintenanceGate(id component.ID, host component.Host, set component.TelemetrySettings, labels selfmetrics.Labels, failures *errlog.Aggregator) (*maintenanceGate, error) {
+	ext, ok := host.GetExtensions()[id]
+	if !ok {
+		return nil, fmt.Errorf("maintenance: tfomaintenance extension %q not found", id)
+	}
+	provider, ok := ext.(MaintenanceProvider)
+	if !ok {
+		return nil, fmt.Errorf("maintenance: extension %q does not provide maintenance mode", id)
+	}
+
+	g := &maintenanceGate{provider: provider, failures: failures}
+	if set.MeterProvider != nil {
+		var err error
+		g.rejected, err = set.MeterProvider.Meter(scopeName).Int64Counter(selfmetrics.ReceiverMaintenanceRejected,
+			metric.WithDescription("Records rejected because the collector is in maintenance mode."),
+			metric.WithUnit("{record}"))
+		if err != nil {
+			return nil, err
+		}
+		g.options = make(map[pipeline.Signal]metric.MeasurementOption)
+		for _, signal := range []pipeline.Signal{pipeline.SignalTraces, pipeline.SignalMetrics, pipeline.SignalLogs} {
+			g.options[signal] = labels.WithSignal(signal).Option()
+		}
+	}
+	return g, nil
+}
+
+// allow reports whether a request of n records of signal is admitted, and
+// counts and logs it otherwise.
+func (g *maintenanceGate) allow(ctx context.Context, signal pipeline.Signal, n int) bool {
+	if g == nil {
+		return true
+	}
+	if !g.provider.Rejecting() {
+		g.failures.Success(maintenanceRejected)
+		return true
+	}
+	g.failures.Error(maintenanceRejected, errMaintenance,
+		zap.String("signal", signal.String()),
+		zap.Int("records", n),
+		requestid.Field(ctx),
+	)
+	if g.rejected != nil {
+		g.rejected.Add(context.WithoutCancel(ctx), int64(n), g.options[signal])
+	}
+	return false
+}
+
+// maintenanceStatus is returned to senders during maintenance, as a gRPC
+// error or an HTTP 503. Unavailable is retryable by OTLP exporters.
+var maintenanceStatus = retryStatus(codes.Unavailable, errMaintenance.Error(), maintenanceRetryDelay)
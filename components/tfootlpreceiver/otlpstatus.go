@@ -0,0 +1,153 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+// consumeRetryDelay is the delay suggested to senders of a request the
+// consumers failed with a retryable error, e.g. refused by the memory
+// limiter or a full exporter queue.
+const consumeRetryDelay = 5 * time.Second
+
+// retryStatus returns a status of code carrying RetryInfo, which OTLP
+// exporters honor as the delay before retrying. RESOURCE_EXHAUSTED is only
+// retried with RetryInfo.
+func retryStatus(code codes.Code, msg string, delay time.Duration) *status.Status {
+	st := status.New(code, msg)
+	if detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(delay)}); err == nil {
+		return detailed
+	}
+	return st
+}
+
+// consumeStatus returns the status of a request its consumers failed with
+// err, described by msg. A status returned by a consumer is passed
+// through; permanent errors are not retryable (INTERNAL), and every other
+// error is treated as backpressure (UNAVAILABLE with RetryInfo).
+func consumeStatus(err error, msg string) *status.Status {
+	if st, ok := status.FromError(err); ok {
+		return st
+	}
+	if consumererror.IsPermanent(err) {
+		return status.New(codes.Internal, msg)
+	}
+	return retryStatus(codes.Unavailable, msg, consumeRetryDelay)
+}
+
+// httpStatusCode maps st to the HTTP status of the OTLP/HTTP
+// specification: the retryable codes to 503, or 429 for
+// RESOURCE_EXHAUSTED, and the others to 4xx or 500.
+func httpStatusCode(st *status.Status) int {
+	switch st.Code() {
+	case codes.Canceled, codes.DeadlineExceeded, codes.Aborted, codes.OutOfRange, codes.Unavailable, codes.DataLoss:
+		return http.StatusServiceUnavailable
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.InvalidArgument:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.Unimplemented:
+		return http.StatusNotFound
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// retryDelay returns the RetryInfo delay of st.
+func retryDelay(st *status.Status) (time.Duration, bool) {
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok && info.GetRetryDelay() != nil {
+			return info.GetRetryDelay().AsDuration(), true
+		}
+	}
+	return 0, false
+}
+
+// writeError answers an HTTP request failed with st: the status code
+// mapped from st, Retry-After from its RetryInfo rounded up to whole
+// seconds, and st as the body.
+func writeError(w http.ResponseWriter, req *http.Request, st *status.Status) {
+	if delay, ok := retryDelay(st); ok {
+		seconds := max(int(math.Ceil(delay.Seconds())), 1)
+		w.Header().Set("Retry-After", strconv.Itoa(seconds))
+	}
+	writeStatus(w, req, httpStatusCode(st), st)
+}
+
+// writeStatus responds with httpCode and, as OTLP/HTTP prescribes for
+// failed requests, a google.rpc.Status body in the encoding of the
+// request.
+func writeStatus(w http.ResponseWriter, req *http.Request, httpCode int, st *status.Status) {
+	contentType := "application/x-protobuf"
+	body, err := proto.Marshal(st.Proto())
+	if isJSON(req) {
+		contentType = "application/json"
+		body, err = protojson.Marshal(st.Proto())
+	}
+	if err != nil {
+		http.Error(w, st.Message(), httpCode)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(httpCode)
+	_, _ = w.Write(body)
+}
+
+// exportResponse is an OTLP export response of any signal.
+type exportResponse interface {
+	MarshalProto() ([]byte, error)
+	MarshalJSON() ([]byte, error)
+}
+
+// writeResponse answers a successful HTTP export with resp, which reports
+// a partial success when records were rejected, in the encoding of the
+// request.
+func writeResponse(w http.ResponseWriter, req *http.Request, resp exportResponse) {
+	contentType := "application/x-protobuf"
+	marshal := resp.MarshalProto
+	if isJSON(req) {
+		contentType = "application/json"
+		marshal = resp.MarshalJSON
+	}
+	body, err := marshal()
+	if err != nil {
+		writeStatus(w, req, http.StatusInternalServerError, status.New(codes.Internal, "Failed to encode response"))
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
+
+// isJSON reports whether req carries a JSON-encoded export request.
+func isJSON(req *http.Request) bool {
+	return req.Header.Get("Content-Type") == "application/json"
+}
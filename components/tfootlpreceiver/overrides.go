@@ -0,0 +1,47 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+// OverridesProvider is an interface for extensions that override receiver
+// settings at runtime.
+type OverridesProvider interface {
+	// ReceiverRateLimit returns the rate limit of receiver id, or ok false
+	// when the configured one applies.
+	ReceiverRateLimit(id component.ID) (recordsPerSecond float64, burst int, ok bool)
+
+	// ReceiverSampling returns the percentage of signal kept by receiver
+	// id, or ok false when the configured one applies.
+	ReceiverSampling(id component.ID, signal pipeline.Signal) (percentage float64, ok bool)
+}
+
+// resolveOverrides resolves the overrides extension id.
+func resolveOverrides(id component.ID, host component.Host) (OverridesProvider, error) {
+	ext, ok := host.GetExtensions()[id]
+	if !ok {
+		return nil, fmt.Errorf("overrides: tfooverrides extension %q not found", id)
+	}
+	provider, ok := ext.(OverridesProvider)
+	if !ok {
+		return nil, fmt.Errorf("overrides: extension %q does not provide runtime overrides", id)
+	}
+	return provider, nil
+}
@@ -0,0 +1,176 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/errlog"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/provenance"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/requestid"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+// Log messages of rejected telemetry, aggregated by the receiver failures.
+const (
+	droppedLoop     = "Dropped telemetry caught in a forwarding loop"
+	droppedHopLimit = "Dropped telemetry over the hop limit"
+)
+
+// IdentityProvider is an interface for extensions that provide collector
+// identity.
+type IdentityProvider interface {
+	GetCollectorID() string
+}
+
+// stamper stamps the provenance envelope on received telemetry. A nil
+// *stamper stamps nothing.
+type stamper struct {
+	cfg         ProvenanceConfig
+	collectorID string
+	failures    *errlog.Aggregator
+
+	// rejected is nil without a meter provider.
+	rejected metric.Int64Counter
+	options  map[rejectKey]metric.MeasurementOption
+}
+
+type rejectKey struct {
+	signal pipeline.Signal
+	reason string
+}
+
+// newStamper resolves the collector ID from the collector_identity
+// extension.
+func newStamper(cfg ProvenanceConfig, host component.Host, set component.TelemetrySettings, labels selfmetrics.Labels, failures *errlog.Aggregator) (*stamper, error) {
+	ext, ok := host.GetExtensions()[cfg.CollectorIdentity]
+	if !ok {
+		return nil, fmt.Errorf("provenance: tfoidentity extension %q not found", cfg.CollectorIdentity)
+	}
+	provider, ok := ext.(IdentityProvider)
+	if !ok {
+		return nil, fmt.Errorf("provenance: extension %q does not provide a collector identity", cfg.CollectorIdentity)
+	}
+	id := provider.GetCollectorID()
+	if id == "" {
+		return nil, fmt.Errorf("provenance: extension %q provides an empty collector ID", cfg.CollectorIdentity)
+	}
+
+	s := &stamper{cfg: cfg, collectorID: id, failures: failures}
+	if set.MeterProvider != nil {
+		var err error
+		s.rejected, err = set.MeterProvider.Meter(scopeName).Int64Counter(selfmetrics.ReceiverProvenanceRejected,
+			metric.WithDescription("Records dropped because they looped back to this collector or exceeded the hop limit."),
+			metric.WithUnit("{record}"))
+		if err != nil {
+			return nil, err
+		}
+		s.options = make(map[rejectKey]metric.MeasurementOption)
+		for _, signal := range []pipeline.Signal{pipeline.SignalTraces, pipeline.SignalMetrics, pipeline.SignalLogs} {
+			for _, reason := range []string{"loop", "hop_limit"} {
+				s.options[rejectKey{signal, reason}] = labels.WithSignal(signal).Option(attribute.String("reason", reason))
+			}
+		}
+	}
+	return s, nil
+}
+
+// hop returns the hop of this collector for telemetry sent for tenant.
+func (s *stamper) hop(tenant string) provenance.Hop {
+	return provenance.Hop{
+		CollectorID: s.collectorID,
+		Tenant:      tenant,
+		ReceivedAt:  time.Now(),
+		MaxHops:     s.cfg.MaxHops,
+	}
+}
+
+// httpTenant returns the tenant named by the request headers.
+func (s *stamper) httpTenant(req *http.Request) string {
+	if s == nil || s.cfg.TenantHeader == "" {
+		return ""
+	}
+	return req.Header.Get(s.cfg.TenantHeader)
+}
+
+// grpcTenant returns the tenant named by the request metadata.
+func (s *stamper) grpcTenant(ctx context.Context) string {
+	if s == nil || s.cfg.TenantHeader == "" {
+		return ""
+	}
+	md, _ := metadata.FromIncomingContext(ctx)
+	if v := md.Get(s.cfg.TenantHeader); len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+func (s *stamper) stampTraces(ctx context.Context, td ptrace.Traces, tenant string) {
+	if s == nil {
+		return
+	}
+	s.record(ctx, pipeline.SignalTraces, provenance.StampTraces(td, s.hop(tenant)))
+}
+
+func (s *stamper) stampMetrics(ctx context.Context, md pmetric.Metrics, tenant string) {
+	if s == nil {
+		return
+	}
+	s.record(ctx, pipeline.SignalMetrics, provenance.StampMetrics(md, s.hop(tenant)))
+}
+
+func (s *stamper) stampLogs(ctx context.Context, ld plog.Logs, tenant string) {
+	if s == nil {
+		return
+	}
+	s.record(ctx, pipeline.SignalLogs, provenance.StampLogs(ld, s.hop(tenant)))
+}
+
+// record counts and logs the records rejected from one request. The
+// request itself succeeds, so that senders do not retry telemetry that
+// would be rejected again.
+func (s *stamper) record(ctx context.Context, signal pipeline.Signal, r provenance.Rejected) {
+	s.reject(ctx, signal, "loop", droppedLoop, provenance.ErrLoop, r.Loop)
+	s.reject(ctx, signal, "hop_limit", droppedHopLimit, provenance.ErrHopLimit, r.HopLimit)
+}
+
+func (s *stamper) reject(ctx context.Context, signal pipeline.Signal, reason, msg string, err error, n int) {
+	if n == 0 {
+		s.failures.Success(msg)
+		return
+	}
+	s.failures.Error(msg, err,
+		zap.String("signal", signal.String()),
+		zap.Int("records", n),
+		zap.String("collector_id", s.collectorID),
+		requestid.Field(ctx),
+	)
+	if s.rejected != nil {
+		s.rejected.Add(context.WithoutCancel(ctx), int64(n), s.options[rejectKey{signal, reason}])
+	}
+}
@@ -0,0 +1,276 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"math/bits"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc/codes"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/errlog"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/requestid"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+// rateLimited is the log message of rejected telemetry, aggregated by the
+// receiver failures.
+const rateLimited = "Rejected telemetry over the rate limit"
+
+// errRateLimited is returned to senders of rejected telemetry.
+var errRateLimited = errors.New("rate limit exceeded")
+
+// limiter admits received records at the configured rate. A nil *limiter
+// admits everything.
+type limiter struct {
+	cfg      RateLimitConfig
+	bucket   atomic.Pointer[rate.Limiter]
+	failures *errlog.Aggregator
+
+	// overrides replaces the rate and burst of the receiver id; nil
+	// without an overrides extension.
+	overrides OverridesProvider
+	id        component.ID
+
+	// traces remembers the decision for each trace ID in per_trace mode. It
+	// is a fixed-size table indexed by a hash of the trace ID: a trace
+	// whose slot is taken over by another trace is decided again.
+	mu     sync.Mutex
+	traces []traceDecision
+	mask   uint64
+
+	// rejected is nil without a meter provider.
+	rejected metric.Int64Counter
+	options  map[pipeline.Signal]metric.MeasurementOption
+}
+
+// traceDecision is a remembered admission decision for one trace.
+type traceDecision struct {
+	hash     uint64
+	expires  int64
+	admitted bool
+}
+
+// newLimiter creates the rate limiter of cfg.
+func newLimiter(cfg RateLimitConfig, set component.TelemetrySettings, labels selfmetrics.Labels, failures *errlog.Aggregator) (*limiter, error) {
+	limit, burst := configuredLimit(cfg)
+	l := &limiter{
+		cfg:      cfg,
+		failures: failures,
+	}
+	l.bucket.Store(rate.NewLimiter(limit, burst))
+	if cfg.PerTrace {
+		size := uint64(1) << bits.Len64(uint64(cfg.MaxTraces-1))
+		l.traces = make([]traceDecision, size)
+		l.mask = size - 1
+	}
+	if set.MeterProvider != nil {
+		var err error
+		l.rejected, err = set.MeterProvider.Meter(scopeName).Int64Counter(selfmetrics.ReceiverRateLimited,
+			metric.WithDescription("Records rejected because they exceeded the receiver rate limit."),
+			metric.WithUnit("{record}"))
+		if err != nil {
+			return nil, err
+		}
+		l.options = make(map[pipeline.Signal]metric.MeasurementOption)
+		for _, signal := range []pipeline.Signal{pipeline.SignalTraces, pipeline.SignalMetrics, pipeline.SignalLogs} {
+			l.options[signal] = labels.WithSignal(signal).Option()
+		}
+	}
+	return l, nil
+}
+
+// configuredLimit returns the rate and burst of cfg. A disabled rate limit
+// admits everything.
+func configuredLimit(cfg RateLimitConfig) (rate.Limit, int) {
+	if !cfg.Enabled {
+		return rate.Inf, 0
+	}
+	return limitOf(cfg.RecordsPerSecond, cfg.Burst)
+}
+
+// limitOf returns the bucket rate and burst of a records per second rate
+// and a burst, where a zero burst uses the rate.
+func limitOf(recordsPerSecond float64, burst int) (rate.Limit, int) {
+	if burst == 0 {
+		burst = max(int(recordsPerSecond), 1)
+	}
+	return rate.Limit(recordsPerSecond), burst
+}
+
+// applyOverrides returns the bucket of the overridden rate and burst, or of
+// the configured ones once the override is removed. A changed rate or burst
+// starts a new, full bucket: the unlimited bucket of a disabled rate limit
+// holds no tokens to carry over.
+func (l *limiter) applyOverrides() *rate.Limiter {
+	bucket := l.bucket.Load()
+	if l.overrides == nil {
+		return bucket
+	}
+	limit, burst := configuredLimit(l.cfg)
+	if recordsPerSecond, b, ok := l.overrides.ReceiverRateLimit(l.id); ok {
+		limit, burst = limitOf(recordsPerSecond, b)
+	}
+	if bucket.Limit() == limit && bucket.Burst() == burst {
+		return bucket
+	}
+	next := rate.NewLimiter(limit, burst)
+	if !l.bucket.CompareAndSwap(bucket, next) {
+		return l.bucket.Load()
+	}
+	return next
+}
+
+// allow reports whether a request of n records of signal is admitted.
+func (l *limiter) allow(ctx context.Context, signal pipeline.Signal, n int) bool {
+	if l == nil || n == 0 {
+		return true
+	}
+	if l.applyOverrides().AllowN(time.Now(), n) {
+		l.failures.Success(rateLimited)
+		return true
+	}
+	l.reject(ctx, signal, n)
+	return false
+}
+
+// limitTraces admits the spans of td and returns the number rejected. In
+// per_trace mode, spans of rejected traces are removed from td and the
+// other spans are kept; otherwise the request is admitted or rejected as a
+// whole and td is left unchanged.
+func (l *limiter) limitTraces(ctx context.Context, td ptrace.Traces) int {
+	if l == nil {
+		return 0
+	}
+	spans := td.SpanCount()
+	if !l.cfg.PerTrace {
+		if l.allow(ctx, pipeline.SignalTraces, spans) {
+			return 0
+		}
+		return spans
+	}
+
+	counts := make(map[pcommon.TraceID]int)
+	for _, rs := range td.ResourceSpans().All() {
+		for _, ss := range rs.ScopeSpans().All() {
+			for _, span := range ss.Spans().All() {
+				counts[span.TraceID()]++
+			}
+		}
+	}
+	admitted := l.decide(counts)
+
+	rejected := 0
+	td.ResourceSpans().RemoveIf(func(rs ptrace.ResourceSpans) bool {
+		rs.ScopeSpans().RemoveIf(func(ss ptrace.ScopeSpans) bool {
+			ss.Spans().RemoveIf(func(span ptrace.Span) bool {
+				if admitted[span.TraceID()] {
+					return false
+				}
+				rejected++
+				return true
+			})
+			return ss.Spans().Len() == 0
+		})
+		return rs.ScopeSpans().Len() == 0
+	})
+	if rejected == 0 {
+		l.failures.Success(rateLimited)
+	} else {
+		l.reject(ctx, pipeline.SignalTraces, rejected)
+	}
+	return rejected
+}
+
+// decide returns whether each trace of counts, the number of spans per
+// trace ID in a request, is admitted. An admitted trace keeps its decision
+// while its spans keep arriving within trace_window, so all of them are
+// admitted together; they are charged to the bucket even when it is empty,
+// which delays the admission of new traces instead. Spans of one trace in
+// a request larger than the burst can never be charged and are rejected.
+// A rejected trace is decided again trace_window after its rejection, so
+// senders retrying its spans do not keep it rejected.
+func (l *limiter) decide(counts map[pcommon.TraceID]int) map[pcommon.TraceID]bool {
+	bucket := l.applyOverrides()
+	now := time.Now()
+	expires := now.Add(l.cfg.TraceWindow).UnixNano()
+	admitted := make(map[pcommon.TraceID]bool, len(counts))
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for id, n := range counts {
+		h := traceHash(id)
+		d := &l.traces[h&l.mask]
+		switch {
+		case d.hash != h || d.expires <= now.UnixNano():
+			*d = traceDecision{hash: h, expires: expires, admitted: bucket.AllowN(now, n)}
+		case !d.admitted:
+		case bucket.ReserveN(now, n).OK():
+			d.expires = expires
+		default:
+			admitted[id] = false
+			continue
+		}
+		admitted[id] = d.admitted
+	}
+	return admitted
+}
+
+// traceHash folds a trace ID into a table key. Trace IDs are random, so
+// their halves already spread evenly.
+func traceHash(id pcommon.TraceID) uint64 {
+	return binary.LittleEndian.Uint64(id[:8]) ^ binary.LittleEndian.Uint64(id[8:])
+}
+
+// reject counts and logs n records of signal rejected from one request.
+func (l *limiter) reject(ctx context.Context, signal pipeline.Signal, n int) {
+	l.failures.Error(rateLimited, errRateLimited,
+		zap.String("signal", signal.String()),
+		zap.Int("records", n),
+		requestid.Field(ctx),
+	)
+	if l.rejected != nil {
+		l.rejected.Add(context.WithoutCancel(ctx), int64(n), l.options[signal])
+	}
+}
+
+// rateLimitedStatus is returned to senders of a request rejected as a
+// whole, as a gRPC error or an HTTP 429. RetryInfo asks the sender to
+// retry once the bucket has refilled.
+var rateLimitedStatus = retryStatus(codes.ResourceExhausted, errRateLimited.Error(), time.Second)
+
+// tracesResponse returns the export response of a traces request with
+// rejected spans, reported as a partial success.
+func tracesResponse(rejected int) ptraceotlp.ExportResponse {
+	resp := ptraceotlp.NewExportResponse()
+	if rejected > 0 {
+		resp.PartialSuccess().SetRejectedSpans(int64(rejected))
+		resp.PartialSuccess().SetErrorMessage(errRateLimited.Error())
+	}
+	return resp
+}
@@ -15,24 +15,54 @@
 package tfootlpreceiver
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
-	"io"
-	"net"
 	"net/http"
 	"sync"
 	"sync/atomic"
-	"time"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
 	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/extensionauth"
 	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
 	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
 	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/pipeline"
 	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 	"go.uber.org/zap"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	// Register the decompressors of compressed OTLP gRPC requests.
+	_ "github.com/mostynb/go-grpc-compression/nonclobbering/snappy"
+	_ "github.com/mostynb/go-grpc-compression/nonclobbering/zstd"
+	_ "google.golang.org/grpc/encoding/gzip"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/errlog"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/requestid"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/watchdog"
+)
+
+const scopeName = "github.com/telemetryflow/telemetryflow-collector/components/tfootlpreceiver"
+
+// grpcRequestIDKey is the gRPC metadata key carrying the request ID.
+const grpcRequestIDKey = "x-request-id"
+
+// Log messages of failed consumer calls, aggregated by r.failures.
+const (
+	failedConsumeTraces  = "Failed to consume traces"
+	failedConsumeMetrics = "Failed to consume metrics"
+	failedConsumeLogs    = "Failed to consume logs"
 )
 
 // tfoOTLPReceiver is the TFO-enhanced OTLP receiver with v1/v2 endpoint support.
@@ -41,7 +71,14 @@ type tfoOTLPReceiver struct {
 	settings *receiver.Settings
 	logger   *zap.Logger
 
-	// Consumers
+	// failures rate-limits the logs of repeated consumer failures.
+	failures *errlog.Aggregator
+
+	// Consumers registered per signal, and the consumer passed the data of
+	// each signal: the registered consumer, or a fan-out to all of them.
+	traces          []consumer.Traces
+	metrics         []consumer.Metrics
+	logs            []consumer.Logs
 	tracesConsumer  consumer.Traces
 	metricsConsumer consumer.Metrics
 	logsConsumer    consumer.Logs
@@ -50,26 +87,85 @@ type tfoOTLPReceiver struct {
 	grpcServer *grpc.Server
 	httpServer *http.Server
 
+	// Websocket ingest endpoint (nil unless enabled)
+	websockets *wsServer
+
+	// Server certificates (nil for plaintext)
+	grpcTLS *serverconf.TLS
+	httpTLS *serverconf.TLS
+
 	// State
 	mu      sync.RWMutex
 	started bool
 
-	// Metrics
-	tracesReceived  atomic.Int64
-	metricsReceived atomic.Int64
-	logsReceived    atomic.Int64
+	// Watchdog. The heartbeat is set once the watchdog starts; the consume
+	// scope is replaced on every restart, see consume.
+	watchdog  *watchdog.Watchdog
+	heartbeat atomic.Pointer[watchdog.Heartbeat]
+	scope     atomic.Pointer[consumeScope]
+
+	// Payload capture (nil unless enabled)
+	capture *payloadCapture
+
+	// Metrics, each on its own cache line so concurrent requests for
+	// different signals do not contend.
+	tracesReceived  paddedCounter
+	metricsReceived paddedCounter
+	logsReceived    paddedCounter
+
+	// In-flight requests per protocol, for connection draining on shutdown
+	grpcInflight atomic.Int64
+	httpInflight atomic.Int64
+	drainDropped metric.Int64Counter
+
+	// Request size and record count histograms (nil without a meter provider)
+	requests *requestStats
+
+	// v2 authentication failures (nil without a meter provider)
+	auth *authStats
+
+	// Provenance envelope (nil unless enabled)
+	provenance *stamper
+
+	// Rate limit (nil unless enabled)
+	limiter *limiter
+
+	// Sampling (nil unless configured or overridden)
+	sampler *sampler
+
+	// Per-key rate limit (nil unless enabled)
+	keyLimiter *keyLimiter
+
+	// Maintenance gate (nil unless configured)
+	maintenance *maintenanceGate
+
+	// v2 credential validator (nil unless configured)
+	validator CredentialValidator
+
+	// Authenticators of the protocols' auth settings (nil unless configured)
+	grpcAuth extensionauth.Server
+	httpAuth extensionauth.Server
 
 	// Shared instance management
 	shutdownWG sync.WaitGroup
 }
 
+// paddedCounter is an atomic counter padded to a 64-byte cache line.
+type paddedCounter struct {
+	atomic.Int64
+	_ [56]byte
+}
+
 var (
-	// receiverInstance is a shared receiver instance for all signal types.
-	receiverInstance     *tfoOTLPReceiver
-	receiverInstanceLock sync.Mutex
+	// receivers holds the receiver of each configuration, shared by all
+	// signals: the service passes the same *Config for every signal of a
+	// receiver ID, and distinct receiver IDs never share one.
+	receivers     = make(map[*Config]*tfoOTLPReceiver)
+	receiversLock sync.Mutex
 )
 
-// newTFOOTLPReceiver creates a new TFO OTLP receiver or returns an existing shared instance.
+// newTFOOTLPReceiver creates a new TFO OTLP receiver or returns the instance
+// already created for cfg.
 func newTFOOTLPReceiver(cfg *Config, set *receiver.Settings) (*tfoOTLPReceiver, error) {
 	if cfg == nil {
 		return nil, fmt.Errorf("tfootlpreceiver config cannot be nil")
@@ -80,55 +176,60 @@ func newTFOOTLPReceiver(cfg *Config, set *receiver.Settings) (*tfoOTLPReceiver,
 	if set.Logger == nil {
 		return nil, fmt.Errorf("tfootlpreceiver settings.Logger cannot be nil")
 	}
-	receiverInstanceLock.Lock()
-	defer receiverInstanceLock.Unlock()
+	receiversLock.Lock()
+	defer receiversLock.Unlock()
 
 	// If an instance exists and is already started, return it
-	if receiverInstance != nil {
-		receiverInstance.mu.RLock()
-		started := receiverInstance.started
-		receiverInstance.mu.RUnlock()
-
-		if started {
-			return receiverInstance, nil
+	if existing := receivers[cfg]; existing != nil {
+		existing.mu.Lock()
+		defer existing.mu.Unlock()
+		if existing.started {
+			return existing, nil
 		}
 
-		// If not started yet, allow config update (useful for tests)
-		receiverInstance.cfg = cfg
-		receiverInstance.settings = set
-		receiverInstance.logger = set.Logger
-		return receiverInstance, nil
+		// If not started yet, take the latest settings
+		existing.settings = set
+		existing.logger = set.Logger
+		existing.failures = errlog.New(set.Logger, errlog.DefaultInterval)
+		return existing, nil
 	}
 
 	r := &tfoOTLPReceiver{
 		cfg:      cfg,
 		settings: set,
 		logger:   set.Logger,
+		failures: errlog.New(set.Logger, errlog.DefaultInterval),
 	}
 
-	receiverInstance = r
+	receivers[cfg] = r
 	return r, nil
 }
 
-// registerTracesConsumer registers a traces consumer.
+// registerTracesConsumer adds a traces consumer. Traces are passed to every
+// consumer registered before the receiver starts.
 func (r *tfoOTLPReceiver) registerTracesConsumer(tc consumer.Traces) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	r.tracesConsumer = tc
+	r.traces = append(r.traces, tc)
+	r.tracesConsumer = newTracesFanOut(r, r.traces)
 }
 
-// registerMetricsConsumer registers a metrics consumer.
+// registerMetricsConsumer adds a metrics consumer. Metrics are passed to
+// every consumer registered before the receiver starts.
 func (r *tfoOTLPReceiver) registerMetricsConsumer(mc consumer.Metrics) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	r.metricsConsumer = mc
+	r.metrics = append(r.metrics, mc)
+	r.metricsConsumer = newMetricsFanOut(r, r.metrics)
 }
 
-// registerLogsConsumer registers a logs consumer.
+// registerLogsConsumer adds a logs consumer. Logs are passed to every
+// consumer registered before the receiver starts.
 func (r *tfoOTLPReceiver) registerLogsConsumer(lc consumer.Logs) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	r.logsConsumer = lc
+	r.logs = append(r.logs, lc)
+	r.logsConsumer = newLogsFanOut(r, r.logs)
 }
 
 // Start implements component.Component.
@@ -141,6 +242,105 @@ func (r *tfoOTLPReceiver) Start(ctx context.Context, host component.Host) error
 	r.started = true
 	r.mu.Unlock()
 
+	if r.drainDropped == nil && r.settings.MeterProvider != nil {
+		var err error
+		r.drainDropped, err = r.settings.MeterProvider.Meter(scopeName).Int64Counter(selfmetrics.ReceiverDrainDroppedRequests,
+			metric.WithDescription("In-flight requests cut off because they outlived the receiver's drain timeout."),
+			metric.WithUnit("{request}"))
+		if err != nil {
+			return err
+		}
+	}
+	if r.requests == nil && r.settings.MeterProvider != nil {
+		var err error
+		r.requests, err = newRequestStats(r.settings.MeterProvider.Meter(scopeName), selfmetrics.Receiver(r.settings.ID))
+		if err != nil {
+			return err
+		}
+	}
+	if r.auth == nil && r.settings.MeterProvider != nil {
+		var err error
+		r.auth, err = newAuthStats(r.settings.MeterProvider.Meter(scopeName), selfmetrics.Receiver(r.settings.ID), r.cfg.V2Auth.ValidAPIKeyIDs)
+		if err != nil {
+			return err
+		}
+	}
+
+	if r.provenance == nil && r.cfg.Provenance.Enabled {
+		var err error
+		r.provenance, err = newStamper(r.cfg.Provenance, host, r.settings.TelemetrySettings,
+			selfmetrics.Receiver(r.settings.ID), r.failures)
+		if err != nil {
+			return err
+		}
+	}
+
+	// An overridden rate limit needs a limiter even when rate_limit is
+	// disabled.
+	if r.limiter == nil && (r.cfg.RateLimit.Enabled || r.cfg.Overrides.String() != "") {
+		var err error
+		r.limiter, err = newLimiter(r.cfg.RateLimit, r.settings.TelemetrySettings,
+			selfmetrics.Receiver(r.settings.ID), r.failures)
+		if err != nil {
+			return err
+		}
+	}
+	if r.keyLimiter == nil && r.cfg.RateLimit.PerKey.Enabled {
+		var err error
+		r.keyLimiter, err = newKeyLimiter(r.cfg.RateLimit.PerKey, r.settings.TelemetrySettings,
+			selfmetrics.Receiver(r.settings.ID), r.failures)
+		if err != nil {
+			return err
+		}
+	}
+	// Likewise an overridden sampling percentage needs a sampler.
+	if r.sampler == nil && (r.cfg.Sampling.Enabled || r.cfg.Overrides.String() != "") {
+		r.sampler = newSampler(r.cfg.Sampling)
+	}
+	if r.cfg.Overrides.String() != "" {
+		provider, err := resolveOverrides(r.cfg.Overrides, host)
+		if err != nil {
+			return err
+		}
+		r.limiter.overrides, r.limiter.id = provider, r.settings.ID
+		r.sampler.overrides, r.sampler.id = provider, r.settings.ID
+	}
+	if r.cfg.V2Auth.Validator.String() != "" {
+		validator, err := resolveValidator(r.cfg.V2Auth.Validator, host)
+		if err != nil {
+			return err
+		}
+		r.validator = validator
+	}
+	if err := r.resolveAuth(ctx, host); err != nil {
+		return err
+	}
+
+	if r.maintenance == nil && r.cfg.Maintenance.String() != "" {
+		var err error
+		r.maintenance, err = newMaintenanceGate(r.cfg.Maintenance, host, r.settings.TelemetrySettings,
+			selfmetrics.Receiver(r.settings.ID), r.failures)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Payload capture must exist before the HTTP handlers can run.
+	if r.cfg.PayloadCapture.Enabled {
+		if r.cfg.Protocols.HTTP == nil {
+			r.logger.Warn("Payload capture requires the HTTP protocol; admin API not started")
+		} else {
+			r.capture = newPayloadCapture(r.cfg.PayloadCapture, r.httpEndpoints(), r.logger)
+			if err := r.capture.start(ctx, host); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := r.startTLS(ctx); err != nil {
+		return err
+	}
+
 	// Start gRPC server if configured
 	if r.cfg.Protocols.GRPC != nil {
 		if err := r.startGRPC(ctx); err != nil {
@@ -155,6 +355,12 @@ func (r *tfoOTLPReceiver) Start(ctx context.Context, host component.Host) error
 		}
 	}
 
+	if r.cfg.Watchdog.Enabled {
+		if err := r.startWatchdog(ctx, host); err != nil {
+			return err
+		}
+	}
+
 	r.logger.Info("TFO OTLP receiver started",
 		zap.Bool("grpc_enabled", r.cfg.Protocols.GRPC != nil),
 		zap.Bool("http_enabled", r.cfg.Protocols.HTTP != nil),
@@ -172,7 +378,20 @@ func (r *tfoOTLPReceiver) startGRPC(ctx context.Context) error {
 	}
 
 	opts := []grpc.ServerOption{
-		grpc.MaxRecvMsgSize(4 * 1024 * 1024), // 4 MiB default
+		grpc.MaxRecvMsgSize(r.cfg.Protocols.GRPC.maxRecvMsgSize()),
+		grpc.ChainUnaryInterceptor(r.trackGRPC, requestIDGRPC),
+		grpc.StatsHandler(grpcStatsHandler{r: r}),
+	}
+	if r.grpcAuth != nil {
+		opts = append(opts,
+			grpc.ChainUnaryInterceptor(serverconf.NewAuthUnaryInterceptor(r.grpcAuth)),
+			grpc.ChainStreamInterceptor(serverconf.NewAuthStreamInterceptor(r.grpcAuth)),
+		)
+	}
+	opts = append(opts, serverconf.GRPCServerOptions(&r.cfg.Protocols.GRPC.ServerConfig)...)
+	opts = append(opts, compressionOptions(r.cfg.Protocols.GRPC)...)
+	if tlsCfg := r.grpcTLS.Config(); tlsCfg != nil {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(tlsCfg)))
 	}
 
 	r.grpcServer = grpc.NewServer(opts...)
@@ -182,16 +401,21 @@ func (r *tfoOTLPReceiver) startGRPC(ctx context.Context) error {
 	pmetricotlp.RegisterGRPCServer(r.grpcServer, &metricsServer{r: r})
 	plogotlp.RegisterGRPCServer(r.grpcServer, &logsServer{r: r})
 
-	lis, err := net.Listen("tcp", endpoint)
+	lis, err := r.cfg.Protocols.GRPC.Listen(endpoint)
 	if err != nil {
 		return err
 	}
+	wrapped, err := r.cfg.Protocols.GRPC.WrapListener(lis)
+	if err != nil {
+		_ = lis.Close()
+		return err
+	}
 
 	r.shutdownWG.Add(1)
 	go func() {
 		defer r.shutdownWG.Done()
-		r.logger.Info("TFO OTLP gRPC server listening", zap.String("endpoint", endpoint))
-		if err := r.grpcServer.Serve(lis); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		r.logger.Info("TFO OTLP gRPC server listening", zap.String("endpoint", lis.Addr().String()))
+		if err := r.grpcServer.Serve(wrapped); err != nil && !errors.Is(err, grpc.ErrServerStopped) {
 			r.logger.Error("gRPC server error", zap.Error(err))
 		}
 	}()
@@ -209,18 +433,7 @@ func (r *tfoOTLPReceiver) startHTTP(ctx context.Context) error {
 	mux := http.NewServeMux()
 
 	// v1 endpoints (OTEL standard)
-	tracesPath := r.cfg.Protocols.HTTP.TracesURLPath
-	if tracesPath == "" {
-		tracesPath = defaultTracesURLPath
-	}
-	metricsPath := r.cfg.Protocols.HTTP.MetricsURLPath
-	if metricsPath == "" {
-		metricsPath = defaultMetricsURLPath
-	}
-	logsPath := r.cfg.Protocols.HTTP.LogsURLPath
-	if logsPath == "" {
-		logsPath = defaultLogsURLPath
-	}
+	tracesPath, metricsPath, logsPath := r.v1Paths()
 
 	mux.HandleFunc(tracesPath, r.handleTraces)
 	mux.HandleFunc(metricsPath, r.handleMetrics)
@@ -245,19 +458,48 @@ func (r *tfoOTLPReceiver) startHTTP(ctx context.Context) error {
 		)
 	}
 
-	r.httpServer = &http.Server{
-		Addr:           endpoint,
-		Handler:        mux,
-		ReadTimeout:    30 * time.Second,
-		WriteTimeout:   30 * time.Second,
-		MaxHeaderBytes: 1 << 20,
+	r.websockets = nil
+	if ws := r.cfg.Protocols.HTTP.WebSocket; ws.Enabled {
+		r.websockets = newWSServer(r, ws)
+		mux.Handle(ws.Path, r.websockets)
+		r.logger.Info("TFO OTLP websocket endpoint registered", zap.String("path", ws.Path))
+	}
+
+	var handler http.Handler = mux
+	if r.httpAuth != nil {
+		handler = serverconf.NewAuthHandler(r.httpAuth, r.cfg.Protocols.HTTP.Auth.Get().RequestParameters, handler)
+	}
+	if cors := r.cfg.Protocols.HTTP.CORS.Get(); cors != nil && len(cors.AllowedOrigins) > 0 {
+		handler = serverconf.NewCORSHandler(cors, r.cfg.Protocols.HTTP.CORSAllowCredentials, handler)
+	}
+	handler = serverconf.NewHeadersHandler(&r.cfg.Protocols.HTTP.HeadersConfig, r.cfg.Protocols.HTTP.ResponseHeaders, handler)
+
+	r.httpServer = serverconf.NewHTTPServer(&r.cfg.Protocols.HTTP.ServerConfig, r.trackHTTP(handler))
+	r.httpServer.Addr = endpoint
+
+	lis, err := r.cfg.Protocols.HTTP.Listen(endpoint)
+	if err != nil {
+		return err
+	}
+	wrapped, err := r.cfg.Protocols.HTTP.WrapListener(lis)
+	if err != nil {
+		_ = lis.Close()
+		return err
+	}
+	wrapped = r.httpTLS.WrapListener(wrapped)
+	scheme := "http"
+	if r.httpTLS.Config() != nil {
+		scheme = "https"
 	}
 
 	r.shutdownWG.Add(1)
 	go func() {
 		defer r.shutdownWG.Done()
-		r.logger.Info("TFO OTLP HTTP server listening", zap.String("endpoint", endpoint))
-		if err := r.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		r.logger.Info("TFO OTLP HTTP server listening",
+			zap.String("endpoint", lis.Addr().String()),
+			zap.String("url", r.cfg.Protocols.HTTP.ListenURL(scheme, lis.Addr())),
+		)
+		if err := r.httpServer.Serve(wrapped); err != nil && !errors.Is(err, http.ErrServerClosed) {
 			r.logger.Error("HTTP server error", zap.Error(err))
 		}
 	}()
@@ -265,34 +507,211 @@ func (r *tfoOTLPReceiver) startHTTP(ctx context.Context) error {
 	return nil
 }
 
+// resolveAuth looks up the auth extensions configured for the protocols in
+// host.
+func (r *tfoOTLPReceiver) resolveAuth(ctx context.Context, host component.Host) error {
+	if grpcCfg := r.cfg.Protocols.GRPC; grpcCfg != nil && grpcCfg.Auth.HasValue() {
+		auth, err := grpcCfg.Auth.Get().GetServerAuthenticator(ctx, host.GetExtensions())
+		if err != nil {
+			return fmt.Errorf("protocols.grpc: auth: %w", err)
+		}
+		r.grpcAuth = auth
+	}
+	if httpCfg := r.cfg.Protocols.HTTP; httpCfg != nil && httpCfg.Auth.HasValue() {
+		auth, err := httpCfg.Auth.Get().GetServerAuthenticator(ctx, host.GetExtensions())
+		if err != nil {
+			return fmt.Errorf("protocols.http: auth: %w", err)
+		}
+		r.httpAuth = auth
+	}
+	return nil
+}
+
+// startTLS loads the static certificates and starts ACME for the configured
+// protocols. It runs once per Start; watchdog restarts reuse the result.
+func (r *tfoOTLPReceiver) startTLS(ctx context.Context) error {
+	if grpcCfg := r.cfg.Protocols.GRPC; grpcCfg != nil && r.grpcTLS == nil {
+		t, err := grpcCfg.NewTLS(ctx, grpcCfg.TLS.Get(), r.logger.With(zap.String("protocol", "grpc")))
+		if err != nil {
+			return fmt.Errorf("protocols.grpc: %w", err)
+		}
+		r.grpcTLS = t
+	}
+	if httpCfg := r.cfg.Protocols.HTTP; httpCfg != nil && r.httpTLS == nil {
+		t, err := httpCfg.NewTLS(ctx, httpCfg.TLS.Get(), r.logger.With(zap.String("protocol", "http")))
+		if err != nil {
+			return fmt.Errorf("protocols.http: %w", err)
+		}
+		r.httpTLS = t
+	}
+	return nil
+}
+
+// v1Paths returns the configured v1 HTTP paths, falling back to the defaults.
+func (r *tfoOTLPReceiver) v1Paths() (traces, metrics, logs string) {
+	traces = r.cfg.Protocols.HTTP.TracesURLPath
+	if traces == "" {
+		traces = defaultTracesURLPath
+	}
+	metrics = r.cfg.Protocols.HTTP.MetricsURLPath
+	if metrics == "" {
+		metrics = defaultMetricsURLPath
+	}
+	logs = r.cfg.Protocols.HTTP.LogsURLPath
+	if logs == "" {
+		logs = defaultLogsURLPath
+	}
+	return traces, metrics, logs
+}
+
+// httpEndpoints returns every HTTP path served by the receiver.
+func (r *tfoOTLPReceiver) httpEndpoints() []string {
+	traces, metrics, logs := r.v1Paths()
+	endpoints := []string{traces, metrics, logs}
+	if r.cfg.EnableV2Endpoints {
+		endpoints = append(endpoints, "/v2/traces", "/v2/metrics", "/v2/logs")
+	}
+	return endpoints
+}
+
+// startWatchdog registers the receiver's consumer heartbeat and starts the
+// watchdog. Incidents are surfaced on the health endpoint via component status.
+func (r *tfoOTLPReceiver) startWatchdog(ctx context.Context, host component.Host) error {
+	wd, err := watchdog.New(r.cfg.Watchdog, r.settings.TelemetrySettings, selfmetrics.Receiver(r.settings.ID), watchdog.Hooks{
+		OnWedged: func(incident watchdog.Incident) {
+			componentstatus.ReportStatus(host, componentstatus.NewRecoverableErrorEvent(
+				fmt.Errorf("receiver wedged: %d requests pending for %s", incident.Pending, incident.StalledFor)))
+		},
+		OnRecovered: func(string) {
+			componentstatus.ReportStatus(host, componentstatus.NewEvent(componentstatus.StatusOK))
+		},
+	})
+	if err != nil {
+		return err
+	}
+	r.scope.Store(newConsumeScope())
+	r.heartbeat.Store(wd.Register("receiver/"+r.settings.ID.String(), r.restartServers))
+	r.watchdog = wd
+	wd.Start(ctx)
+	return nil
+}
+
+// restartServers drains the gRPC and HTTP servers like Shutdown does and
+// starts them again on the same endpoints. The drain is bounded by the drain
+// timeout rather than by ctx, which the watchdog cancels after the stall
+// timeout. Consumers still running when the drain timeout expires have their
+// context canceled, so that one stuck downstream does not keep the restart
+// from completing.
+func (r *tfoOTLPReceiver) restartServers(ctx context.Context) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if !r.started {
+		return nil
+	}
+
+	old := r.scope.Swap(newConsumeScope())
+	drainCtx := context.WithoutCancel(ctx)
+	abortCtx, cancel := context.WithTimeout(drainCtx, r.cfg.DrainTimeout)
+	defer cancel()
+	stop := context.AfterFunc(abortCtx, func() { old.cancel(errWatchdogRestart) })
+	r.drainServers(drainCtx)
+	r.shutdownWG.Wait()
+	stop()
+	old.cancel(errWatchdogRestart)
+
+	if r.cfg.Protocols.GRPC != nil {
+		if err := r.startGRPC(drainCtx); err != nil {
+			return err
+		}
+	}
+	if r.cfg.Protocols.HTTP != nil {
+		if err := r.startHTTP(drainCtx); err != nil {
+			return err
+		}
+	}
+
+	r.logger.Warn("TFO OTLP receiver servers restarted by watchdog")
+	return nil
+}
+
+// errWatchdogRestart is the cause given to consumers abandoned by a watchdog
+// restart.
+var errWatchdogRestart = errors.New("receiver restarted by the watchdog")
+
+// consumeScope bounds the consumer calls started between two watchdog
+// restarts.
+type consumeScope struct {
+	ctx    context.Context
+	cancel context.CancelCauseFunc
+}
+
+func newConsumeScope() *consumeScope {
+	ctx, cancel := context.WithCancelCause(context.Background())
+	return &consumeScope{ctx: ctx, cancel: cancel}
+}
+
+// consume passes data to a consumer through call. With the watchdog enabled
+// the call is tracked by the heartbeat, and its context is also canceled
+// when a restart gives up on it.
+func (r *tfoOTLPReceiver) consume(ctx context.Context, call func(context.Context) error) error {
+	heartbeat, scope := r.heartbeat.Load(), r.scope.Load()
+	if heartbeat == nil || scope == nil {
+		return call(ctx)
+	}
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+	stop := context.AfterFunc(scope.ctx, func() { cancel(context.Cause(scope.ctx)) })
+	defer stop()
+
+	heartbeat.Begin()
+	defer heartbeat.End()
+	return call(ctx)
+}
+
+// release removes r from the shared instances, so that the next create
+// call for its configuration starts afresh.
+func (r *tfoOTLPReceiver) release() {
+	receiversLock.Lock()
+	defer receiversLock.Unlock()
+	if receivers[r.cfg] == r {
+		delete(receivers, r.cfg)
+	}
+}
+
 // Shutdown implements component.Component.
 func (r *tfoOTLPReceiver) Shutdown(ctx context.Context) error {
 	r.mu.Lock()
 	if !r.started {
 		r.mu.Unlock()
+		r.release()
 		return nil
 	}
 	r.started = false
+	wd := r.watchdog
+	r.watchdog = nil
+	capture := r.capture
 	r.mu.Unlock()
 
-	if r.grpcServer != nil {
-		r.grpcServer.GracefulStop()
+	// Stop the watchdog first so it cannot restart servers being shut down.
+	if wd != nil {
+		wd.Shutdown(ctx)
 	}
 
-	if r.httpServer != nil {
-		shutdownCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
-		defer cancel()
-		if err := r.httpServer.Shutdown(shutdownCtx); err != nil {
-			r.logger.Error("HTTP server shutdown error", zap.Error(err))
-		}
+	if err := capture.shutdown(ctx); err != nil {
+		r.logger.Error("Payload capture admin API shutdown error", zap.Error(err))
 	}
 
+	r.drainServers(ctx)
 	r.shutdownWG.Wait()
 
-	// Clear shared instance
-	receiverInstanceLock.Lock()
-	receiverInstance = nil
-	receiverInstanceLock.Unlock()
+	for _, t := range []*serverconf.TLS{r.grpcTLS, r.httpTLS} {
+		if err := t.Shutdown(ctx); err != nil {
+			r.logger.Error("ACME challenge server shutdown error", zap.Error(err))
+		}
+	}
+	r.grpcTLS, r.httpTLS = nil, nil
+
+	r.release()
 
 	r.logger.Info("TFO OTLP receiver stopped",
 		zap.Int64("traces_received", r.tracesReceived.Load()),
@@ -303,6 +722,102 @@ func (r *tfoOTLPReceiver) Shutdown(ctx context.Context) error {
 	return nil
 }
 
+// drainServers stops both servers from accepting and waits up to the drain
+// timeout for in-flight requests to complete, then force-closes whatever is
+// left. The listening sockets are released through serverconf.Listen, so a
+// receiver started on the same endpoints right after (a reload that only
+// changed TLS or auth settings) takes them over without refusing connections.
+func (r *tfoOTLPReceiver) drainServers(ctx context.Context) {
+	drainCtx, cancel := context.WithTimeout(ctx, r.cfg.DrainTimeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	if r.grpcServer != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			stopped := make(chan struct{})
+			go func() {
+				r.grpcServer.GracefulStop()
+				close(stopped)
+			}()
+			select {
+			case <-stopped:
+			case <-drainCtx.Done():
+				r.recordDropped(ctx, "grpc", r.grpcInflight.Load())
+				r.grpcServer.Stop()
+				<-stopped
+			}
+		}()
+	}
+	if r.httpServer != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := r.httpServer.Shutdown(drainCtx); err != nil {
+				r.recordDropped(ctx, "http", r.httpInflight.Load())
+				_ = r.httpServer.Close()
+			}
+		}()
+	}
+	if r.websockets != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.recordDropped(ctx, "websocket", r.websockets.drain(drainCtx))
+		}()
+	}
+	wg.Wait()
+}
+
+// recordDropped reports requests abandoned when the drain timeout expired.
+func (r *tfoOTLPReceiver) recordDropped(ctx context.Context, protocol string, n int64) {
+	if n <= 0 {
+		return
+	}
+	r.logger.Warn("Drain timeout expired with requests in flight",
+		zap.String("protocol", protocol),
+		zap.Int64("dropped", n),
+		zap.Duration("drain_timeout", r.cfg.DrainTimeout),
+	)
+	if r.drainDropped != nil {
+		r.drainDropped.Add(ctx, n, selfmetrics.Receiver(r.settings.ID).Option(attribute.String("protocol", protocol)))
+	}
+}
+
+// trackGRPC counts in-flight unary RPCs for drainServers.
+func (r *tfoOTLPReceiver) trackGRPC(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	r.grpcInflight.Add(1)
+	defer r.grpcInflight.Add(-1)
+	return handler(ctx, req)
+}
+
+// requestIDGRPC stores the request ID of an RPC in its context and returns
+// it in the response header. The ID is taken from the x-request-id metadata
+// if valid and generated otherwise, like the HTTP X-Request-ID header.
+func requestIDGRPC(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+	var id string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(grpcRequestIDKey); len(ids) > 0 {
+			id = ids[0]
+		}
+	}
+	if !requestid.Valid(id) {
+		id = requestid.New()
+	}
+	_ = grpc.SetHeader(ctx, metadata.Pairs(grpcRequestIDKey, id))
+	return handler(requestid.NewContext(ctx, id), req)
+}
+
+// trackHTTP counts in-flight HTTP requests for drainServers.
+func (r *tfoOTLPReceiver) trackHTTP(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		r.httpInflight.Add(1)
+		defer r.httpInflight.Add(-1)
+		next.ServeHTTP(w, req)
+	})
+}
+
 // =============================================================================
 // gRPC Service Implementations
 // =============================================================================
@@ -316,20 +831,41 @@ func (s *traceServer) Export(ctx context.Context, req ptraceotlp.ExportRequest)
 	td := req.Traces()
 	spanCount := td.SpanCount()
 	s.r.tracesReceived.Add(int64(spanCount))
+	s.r.requests.recordRecords(ctx, grpcKey(pipeline.SignalTraces), spanCount)
 
-	s.r.logger.Debug("Received traces via gRPC",
-		zap.Int("span_count", spanCount),
-		zap.Int("resource_spans", td.ResourceSpans().Len()),
-	)
+	if ce := s.r.logger.Check(zap.DebugLevel, "Received traces via gRPC"); ce != nil {
+		ce.Write(
+			zap.Int("span_count", spanCount),
+			zap.Int("resource_spans", td.ResourceSpans().Len()),
+			requestid.Field(ctx),
+		)
+	}
+
+	if !s.r.maintenance.allow(ctx, pipeline.SignalTraces, spanCount) {
+		return ptraceotlp.NewExportResponse(), maintenanceStatus.Err()
+	}
+
+	rejected := s.r.limiter.limitTraces(ctx, td)
+	if rejected > 0 && rejected == spanCount {
+		return ptraceotlp.NewExportResponse(), rateLimitedStatus.Err()
+	}
+
+	if s.r.sampler.sampleTraces(td) > 0 && td.ResourceSpans().Len() == 0 {
+		return tracesResponse(rejected), nil
+	}
+
+	s.r.provenance.stampTraces(ctx, td, s.r.provenance.grpcTenant(ctx))
 
 	if s.r.tracesConsumer != nil {
-		if err := s.r.tracesConsumer.ConsumeTraces(ctx, td); err != nil {
-			s.r.logger.Error("Failed to consume traces", zap.Error(err))
-			return ptraceotlp.NewExportResponse(), err
+		err := s.r.consume(ctx, func(ctx context.Context) error { return s.r.tracesConsumer.ConsumeTraces(ctx, td) })
+		if err != nil {
+			s.r.failures.Error(failedConsumeTraces, err, requestid.Field(ctx))
+			return ptraceotlp.NewExportResponse(), consumeStatus(err, "Failed to process traces").Err()
 		}
+		s.r.failures.Success(failedConsumeTraces)
 	}
 
-	return ptraceotlp.NewExportResponse(), nil
+	return tracesResponse(rejected), nil
 }
 
 type metricsServer struct {
@@ -341,17 +877,33 @@ func (s *metricsServer) Export(ctx context.Context, req pmetricotlp.ExportReques
 	md := req.Metrics()
 	dataPointCount := md.DataPointCount()
 	s.r.metricsReceived.Add(int64(dataPointCount))
+	s.r.requests.recordRecords(ctx, grpcKey(pipeline.SignalMetrics), dataPointCount)
 
-	s.r.logger.Debug("Received metrics via gRPC",
-		zap.Int("data_point_count", dataPointCount),
-		zap.Int("resource_metrics", md.ResourceMetrics().Len()),
-	)
+	if ce := s.r.logger.Check(zap.DebugLevel, "Received metrics via gRPC"); ce != nil {
+		ce.Write(
+			zap.Int("data_point_count", dataPointCount),
+			zap.Int("resource_metrics", md.ResourceMetrics().Len()),
+			requestid.Field(ctx),
+		)
+	}
+
+	if !s.r.maintenance.allow(ctx, pipeline.SignalMetrics, dataPointCount) {
+		return pmetricotlp.NewExportResponse(), maintenanceStatus.Err()
+	}
+
+	if !s.r.limiter.allow(ctx, pipeline.SignalMetrics, dataPointCount) {
+		return pmetricotlp.NewExportResponse(), rateLimitedStatus.Err()
+	}
+
+	s.r.provenance.stampMetrics(ctx, md, s.r.provenance.grpcTenant(ctx))
 
 	if s.r.metricsConsumer != nil {
-		if err := s.r.metricsConsumer.ConsumeMetrics(ctx, md); err != nil {
-			s.r.logger.Error("Failed to consume metrics", zap.Error(err))
-			return pmetricotlp.NewExportResponse(), err
+		err := s.r.consume(ctx, func(ctx context.Context) error { return s.r.metricsConsumer.ConsumeMetrics(ctx, md) })
+		if err != nil {
+			s.r.failures.Error(failedConsumeMetrics, err, requestid.Field(ctx))
+			return pmetricotlp.NewExportResponse(), consumeStatus(err, "Failed to process metrics").Err()
 		}
+		s.r.failures.Success(failedConsumeMetrics)
 	}
 
 	return pmetricotlp.NewExportResponse(), nil
@@ -366,17 +918,37 @@ func (s *logsServer) Export(ctx context.Context, req plogotlp.ExportRequest) (pl
 	ld := req.Logs()
 	logRecordCount := ld.LogRecordCount()
 	s.r.logsReceived.Add(int64(logRecordCount))
+	s.r.requests.recordRecords(ctx, grpcKey(pipeline.SignalLogs), logRecordCount)
 
-	s.r.logger.Debug("Received logs via gRPC",
-		zap.Int("log_record_count", logRecordCount),
-		zap.Int("resource_logs", ld.ResourceLogs().Len()),
-	)
+	if ce := s.r.logger.Check(zap.DebugLevel, "Received logs via gRPC"); ce != nil {
+		ce.Write(
+			zap.Int("log_record_count", logRecordCount),
+			zap.Int("resource_logs", ld.ResourceLogs().Len()),
+			requestid.Field(ctx),
+		)
+	}
+
+	if !s.r.maintenance.allow(ctx, pipeline.SignalLogs, logRecordCount) {
+		return plogotlp.NewExportResponse(), maintenanceStatus.Err()
+	}
+
+	if !s.r.limiter.allow(ctx, pipeline.SignalLogs, logRecordCount) {
+		return plogotlp.NewExportResponse(), rateLimitedStatus.Err()
+	}
+
+	if s.r.sampler.sampleLogs(ld) > 0 && ld.ResourceLogs().Len() == 0 {
+		return plogotlp.NewExportResponse(), nil
+	}
+
+	s.r.provenance.stampLogs(ctx, ld, s.r.provenance.grpcTenant(ctx))
 
 	if s.r.logsConsumer != nil {
-		if err := s.r.logsConsumer.ConsumeLogs(ctx, ld); err != nil {
-			s.r.logger.Error("Failed to consume logs", zap.Error(err))
-			return plogotlp.NewExportResponse(), err
+		err := s.r.consume(ctx, func(ctx context.Context) error { return s.r.logsConsumer.ConsumeLogs(ctx, ld) })
+		if err != nil {
+			s.r.failures.Error(failedConsumeLogs, err, requestid.Field(ctx))
+			return plogotlp.NewExportResponse(), consumeStatus(err, "Failed to process logs").Err()
 		}
+		s.r.failures.Success(failedConsumeLogs)
 	}
 
 	return plogotlp.NewExportResponse(), nil
@@ -393,6 +965,78 @@ const (
 	headerCollectorID = "X-TelemetryFlow-Collector-ID"
 )
 
+// maxPooledBodySize bounds the request buffers kept for reuse so one large
+// payload does not pin its memory.
+const maxPooledBodySize = 4 << 20
+
+var bodyPool = sync.Pool{New: func() any { return new(bytes.Buffer) }}
+
+// defaultMaxRequestBodySize bounds HTTP request bodies unless
+// max_request_body_size is set, as in confighttp.
+const defaultMaxRequestBodySize = 20 << 20
+
+// errBodyTooLarge rejects a request body over max_request_body_size.
+var errBodyTooLarge = errors.New("request body too large")
+
+// readBody reads and closes the request body using a pooled buffer,
+// failing with errBodyTooLarge once it exceeds limit bytes; a body
+// announced larger by Content-Length is not read at all. The decoded pdata
+// copies everything it keeps, so the buffer is returned with releaseBody
+// once the payload has been unmarshaled and captured.
+func readBody(w http.ResponseWriter, req *http.Request, limit int64) (*bytes.Buffer, error) {
+	defer func() { _ = req.Body.Close() }()
+	if req.ContentLength > limit {
+		return nil, errBodyTooLarge
+	}
+	buf := bodyPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	if req.ContentLength > 0 && req.ContentLength <= maxPooledBodySize {
+		buf.Grow(int(req.ContentLength))
+	}
+	if _, err := buf.ReadFrom(http.MaxBytesReader(w, req.Body, limit)); err != nil {
+		releaseBody(buf)
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			return nil, errBodyTooLarge
+		}
+		return nil, err
+	}
+	return buf, nil
+}
+
+// maxRequestBodySize returns the bound of HTTP request bodies.
+func (r *tfoOTLPReceiver) maxRequestBodySize() int64 {
+	if limit := r.cfg.Protocols.HTTP.MaxRequestBodySize; limit > 0 {
+		return limit
+	}
+	return defaultMaxRequestBodySize
+}
+
+// writeReadError logs err from readBody and responds with 413 for a body
+// over the bound or 400 for a body that could not be read.
+func (r *tfoOTLPReceiver) writeReadError(w http.ResponseWriter, req *http.Request, err error) {
+	if errors.Is(err, errBodyTooLarge) {
+		r.logger.Warn("Request body too large",
+			zap.Int64("content_length", req.ContentLength),
+			zap.Int64("max_request_body_size", r.maxRequestBodySize()),
+			zap.String("path", req.URL.Path),
+			requestid.Field(req.Context()),
+		)
+		writeStatus(w, req, http.StatusRequestEntityTooLarge, status.Newf(codes.ResourceExhausted,
+			"request body exceeds max_request_body_size of %d bytes", r.maxRequestBodySize()))
+		return
+	}
+	r.logger.Error("Failed to read request body", zap.Error(err), requestid.Field(req.Context()))
+	writeError(w, req, status.New(codes.InvalidArgument, "Failed to read body"))
+}
+
+func releaseBody(buf *bytes.Buffer) {
+	if buf.Cap() > maxPooledBodySize {
+		return
+	}
+	bodyPool.Put(buf)
+}
+
 // isV2Endpoint checks if the request path is a v2 endpoint.
 func isV2Endpoint(path string) bool {
 	return path == "/v2/traces" || path == "/v2/metrics" || path == "/v2/logs"
@@ -414,7 +1058,9 @@ func (r *tfoOTLPReceiver) validateV2Auth(w http.ResponseWriter, req *http.Reques
 		r.logger.Warn("v2 endpoint access denied: missing API Key ID",
 			zap.String("path", req.URL.Path),
 			zap.String("remote_addr", req.RemoteAddr),
+			requestid.Field(req.Context()),
 		)
+		r.auth.reject(req.Context(), authMissingKey, keyID)
 		http.Error(w, `{"error": "missing TelemetryFlow API Key ID"}`, http.StatusUnauthorized)
 		return false
 	}
@@ -424,7 +1070,9 @@ func (r *tfoOTLPReceiver) validateV2Auth(w http.ResponseWriter, req *http.Reques
 		r.logger.Warn("v2 endpoint access denied: invalid API Key ID format",
 			zap.String("path", req.URL.Path),
 			zap.String("remote_addr", req.RemoteAddr),
+			requestid.Field(req.Context()),
 		)
+		r.auth.reject(req.Context(), authInvalidKeyFormat, keyID)
 		http.Error(w, `{"error": "invalid TelemetryFlow API Key ID format (expected tfk_xxx)"}`, http.StatusUnauthorized)
 		return false
 	}
@@ -443,19 +1091,23 @@ func (r *tfoOTLPReceiver) validateV2Auth(w http.ResponseWriter, req *http.Reques
 				zap.String("path", req.URL.Path),
 				zap.String("key_id", keyID),
 				zap.String("remote_addr", req.RemoteAddr),
+				requestid.Field(req.Context()),
 			)
+			r.auth.reject(req.Context(), authKeyNotAllowed, keyID)
 			http.Error(w, `{"error": "API Key ID not authorized"}`, http.StatusForbidden)
 			return false
 		}
 	}
 
 	// Validate secret if required
-	if r.cfg.V2Auth.ValidateSecret {
+	if r.cfg.V2Auth.ValidateSecret || r.validator != nil {
 		if keySecret == "" {
 			r.logger.Warn("v2 endpoint access denied: missing API Key Secret",
 				zap.String("path", req.URL.Path),
 				zap.String("remote_addr", req.RemoteAddr),
+				requestid.Field(req.Context()),
 			)
+			r.auth.reject(req.Context(), authMissingSecret, keyID)
 			http.Error(w, `{"error": "missing TelemetryFlow API Key Secret"}`, http.StatusUnauthorized)
 			return false
 		}
@@ -465,17 +1117,51 @@ func (r *tfoOTLPReceiver) validateV2Auth(w http.ResponseWriter, req *http.Reques
 			r.logger.Warn("v2 endpoint access denied: invalid API Key Secret format",
 				zap.String("path", req.URL.Path),
 				zap.String("remote_addr", req.RemoteAddr),
+				requestid.Field(req.Context()),
 			)
+			r.auth.reject(req.Context(), authInvalidSecretFormat, keyID)
 			http.Error(w, `{"error": "invalid TelemetryFlow API Key Secret format (expected tfs_xxx)"}`, http.StatusUnauthorized)
 			return false
 		}
 	}
 
-	r.logger.Debug("v2 endpoint auth validated",
-		zap.String("path", req.URL.Path),
-		zap.String("key_id", keyID),
-		zap.String("collector_id", req.Header.Get(headerCollectorID)),
-	)
+	// Validate the credentials with the validator extension if configured
+	if r.validator != nil {
+		valid, err := r.validator.ValidateCredential(req.Context(), keyID, keySecret)
+		if err != nil {
+			r.logger.Warn("v2 endpoint access denied: API key validation failed",
+				zap.String("path", req.URL.Path),
+				zap.String("key_id", keyID),
+				zap.String("remote_addr", req.RemoteAddr),
+				zap.Error(err),
+				requestid.Field(req.Context()),
+			)
+			r.auth.reject(req.Context(), authValidationFailed, keyID)
+			http.Error(w, `{"error": "API key validation unavailable"}`, http.StatusServiceUnavailable)
+			return false
+		}
+		if !valid {
+			r.logger.Warn("v2 endpoint access denied: invalid API credentials",
+				zap.String("path", req.URL.Path),
+				zap.String("key_id", keyID),
+				zap.String("remote_addr", req.RemoteAddr),
+				requestid.Field(req.Context()),
+			)
+			r.auth.reject(req.Context(), authInvalidCredentials, keyID)
+			http.Error(w, `{"error": "invalid TelemetryFlow API credentials"}`, http.StatusUnauthorized)
+			return false
+		}
+		r.auth.accept(keyID)
+	}
+
+	if ce := r.logger.Check(zap.DebugLevel, "v2 endpoint auth validated"); ce != nil {
+		ce.Write(
+			zap.String("path", req.URL.Path),
+			zap.String("key_id", keyID),
+			zap.String("collector_id", req.Header.Get(headerCollectorID)),
+			requestid.Field(req.Context()),
+		)
+	}
 
 	return true
 }
@@ -487,17 +1173,25 @@ func (r *tfoOTLPReceiver) handleTraces(w http.ResponseWriter, req *http.Request)
 	}
 
 	// Validate v2 authentication if this is a v2 endpoint
-	if isV2Endpoint(req.URL.Path) && !r.validateV2Auth(w, req) {
+	isV2 := isV2Endpoint(req.URL.Path)
+	if isV2 && !r.validateV2Auth(w, req) {
 		return
 	}
 
-	body, err := io.ReadAll(req.Body)
+	buf, err := readBody(w, req, r.maxRequestBodySize())
 	if err != nil {
-		r.logger.Error("Failed to read request body", zap.Error(err))
-		http.Error(w, "Failed to read body", http.StatusBadRequest)
+		r.writeReadError(w, req, err)
 		return
 	}
-	defer func() { _ = req.Body.Close() }()
+	r.capture.record(req, buf.Bytes())
+	size := buf.Len()
+	if buf, err = r.decodeBody(req, buf); err != nil {
+		r.writeDecodeError(w, req, err)
+		return
+	}
+	defer releaseBody(buf)
+	body := buf.Bytes()
+	r.requests.recordSize(req.Context(), httpKey(pipeline.SignalTraces, isV2), size, len(body))
 
 	contentType := req.Header.Get("Content-Type")
 	exportReq := ptraceotlp.NewExportRequest()
@@ -510,33 +1204,58 @@ func (r *tfoOTLPReceiver) handleTraces(w http.ResponseWriter, req *http.Request)
 	}
 
 	if unmarshalErr != nil {
-		r.logger.Error("Failed to unmarshal traces", zap.Error(unmarshalErr), zap.String("content_type", contentType))
-		http.Error(w, "Failed to unmarshal traces", http.StatusBadRequest)
+		r.logger.Error("Failed to unmarshal traces", zap.Error(unmarshalErr), zap.String("content_type", contentType), requestid.Field(req.Context()))
+		writeError(w, req, status.New(codes.InvalidArgument, "Failed to unmarshal traces"))
 		return
 	}
 
 	td := exportReq.Traces()
 	spanCount := td.SpanCount()
 	r.tracesReceived.Add(int64(spanCount))
+	r.requests.recordRecords(req.Context(), httpKey(pipeline.SignalTraces, isV2), spanCount)
 
-	isV2 := isV2Endpoint(req.URL.Path)
-	r.logger.Debug("Received traces via HTTP",
-		zap.Int("span_count", spanCount),
-		zap.String("path", req.URL.Path),
-		zap.Bool("v2_endpoint", isV2),
-	)
+	if ce := r.logger.Check(zap.DebugLevel, "Received traces via HTTP"); ce != nil {
+		ce.Write(
+			zap.Int("span_count", spanCount),
+			zap.String("path", req.URL.Path),
+			zap.Bool("v2_endpoint", isV2),
+			requestid.Field(req.Context()),
+		)
+	}
+
+	if !r.maintenance.allow(req.Context(), pipeline.SignalTraces, spanCount) {
+		writeError(w, req, maintenanceStatus)
+		return
+	}
+
+	if isV2 && !r.allowKey(w, req, pipeline.SignalTraces, spanCount) {
+		return
+	}
+
+	rejected := r.limiter.limitTraces(req.Context(), td)
+	if rejected > 0 && rejected == spanCount {
+		writeError(w, req, rateLimitedStatus)
+		return
+	}
+
+	if r.sampler.sampleTraces(td) > 0 && td.ResourceSpans().Len() == 0 {
+		writeResponse(w, req, tracesResponse(rejected))
+		return
+	}
+
+	r.provenance.stampTraces(req.Context(), td, r.provenance.httpTenant(req))
 
 	if r.tracesConsumer != nil {
-		if err := r.tracesConsumer.ConsumeTraces(req.Context(), td); err != nil {
-			r.logger.Error("Failed to consume traces", zap.Error(err))
-			http.Error(w, "Failed to process traces", http.StatusInternalServerError)
+		err := r.consume(req.Context(), func(ctx context.Context) error { return r.tracesConsumer.ConsumeTraces(ctx, td) })
+		if err != nil {
+			r.failures.Error(failedConsumeTraces, err, requestid.Field(req.Context()))
+			writeError(w, req, consumeStatus(err, "Failed to process traces"))
 			return
 		}
+		r.failures.Success(failedConsumeTraces)
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	_, _ = w.Write([]byte(`{}`))
+	writeResponse(w, req, tracesResponse(rejected))
 }
 
 func (r *tfoOTLPReceiver) handleMetrics(w http.ResponseWriter, req *http.Request) {
@@ -546,17 +1265,25 @@ func (r *tfoOTLPReceiver) handleMetrics(w http.ResponseWriter, req *http.Request
 	}
 
 	// Validate v2 authentication if this is a v2 endpoint
-	if isV2Endpoint(req.URL.Path) && !r.validateV2Auth(w, req) {
+	isV2 := isV2Endpoint(req.URL.Path)
+	if isV2 && !r.validateV2Auth(w, req) {
 		return
 	}
 
-	body, err := io.ReadAll(req.Body)
+	buf, err := readBody(w, req, r.maxRequestBodySize())
 	if err != nil {
-		r.logger.Error("Failed to read request body", zap.Error(err))
-		http.Error(w, "Failed to read body", http.StatusBadRequest)
+		r.writeReadError(w, req, err)
 		return
 	}
-	defer func() { _ = req.Body.Close() }()
+	r.capture.record(req, buf.Bytes())
+	size := buf.Len()
+	if buf, err = r.decodeBody(req, buf); err != nil {
+		r.writeDecodeError(w, req, err)
+		return
+	}
+	defer releaseBody(buf)
+	body := buf.Bytes()
+	r.requests.recordSize(req.Context(), httpKey(pipeline.SignalMetrics, isV2), size, len(body))
 
 	contentType := req.Header.Get("Content-Type")
 	exportReq := pmetricotlp.NewExportRequest()
@@ -569,33 +1296,52 @@ func (r *tfoOTLPReceiver) handleMetrics(w http.ResponseWriter, req *http.Request
 	}
 
 	if unmarshalErr != nil {
-		r.logger.Error("Failed to unmarshal metrics", zap.Error(unmarshalErr), zap.String("content_type", contentType))
-		http.Error(w, "Failed to unmarshal metrics", http.StatusBadRequest)
+		r.logger.Error("Failed to unmarshal metrics", zap.Error(unmarshalErr), zap.String("content_type", contentType), requestid.Field(req.Context()))
+		writeError(w, req, status.New(codes.InvalidArgument, "Failed to unmarshal metrics"))
 		return
 	}
 
 	md := exportReq.Metrics()
 	dataPointCount := md.DataPointCount()
 	r.metricsReceived.Add(int64(dataPointCount))
+	r.requests.recordRecords(req.Context(), httpKey(pipeline.SignalMetrics, isV2), dataPointCount)
 
-	isV2 := isV2Endpoint(req.URL.Path)
-	r.logger.Debug("Received metrics via HTTP",
-		zap.Int("data_point_count", dataPointCount),
-		zap.String("path", req.URL.Path),
-		zap.Bool("v2_endpoint", isV2),
-	)
+	if ce := r.logger.Check(zap.DebugLevel, "Received metrics via HTTP"); ce != nil {
+		ce.Write(
+			zap.Int("data_point_count", dataPointCount),
+			zap.String("path", req.URL.Path),
+			zap.Bool("v2_endpoint", isV2),
+			requestid.Field(req.Context()),
+		)
+	}
+
+	if !r.maintenance.allow(req.Context(), pipeline.SignalMetrics, dataPointCount) {
+		writeError(w, req, maintenanceStatus)
+		return
+	}
+
+	if isV2 && !r.allowKey(w, req, pipeline.SignalMetrics, dataPointCount) {
+		return
+	}
+
+	if !r.limiter.allow(req.Context(), pipeline.SignalMetrics, dataPointCount) {
+		writeError(w, req, rateLimitedStatus)
+		return
+	}
+
+	r.provenance.stampMetrics(req.Context(), md, r.provenance.httpTenant(req))
 
 	if r.metricsConsumer != nil {
-		if err := r.metricsConsumer.ConsumeMetrics(req.Context(), md); err != nil {
-			r.logger.Error("Failed to consume metrics", zap.Error(err))
-			http.Error(w, "Failed to process metrics", http.StatusInternalServerError)
+		err := r.consume(req.Context(), func(ctx context.Context) error { return r.metricsConsumer.ConsumeMetrics(ctx, md) })
+		if err != nil {
+			r.failures.Error(failedConsumeMetrics, err, requestid.Field(req.Context()))
+			writeError(w, req, consumeStatus(err, "Failed to process metrics"))
 			return
 		}
+		r.failures.Success(failedConsumeMetrics)
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	_, _ = w.Write([]byte(`{}`))
+	writeResponse(w, req, pmetricotlp.NewExportResponse())
 }
 
 func (r *tfoOTLPReceiver) handleLogs(w http.ResponseWriter, req *http.Request) {
@@ -605,17 +1351,25 @@ func (r *tfoOTLPReceiver) handleLogs(w http.ResponseWriter, req *http.Request) {
 	}
 
 	// Validate v2 authentication if this is a v2 endpoint
-	if isV2Endpoint(req.URL.Path) && !r.validateV2Auth(w, req) {
+	isV2 := isV2Endpoint(req.URL.Path)
+	if isV2 && !r.validateV2Auth(w, req) {
 		return
 	}
 
-	body, err := io.ReadAll(req.Body)
+	buf, err := readBody(w, req, r.maxRequestBodySize())
 	if err != nil {
-		r.logger.Error("Failed to read request body", zap.Error(err))
-		http.Error(w, "Failed to read body", http.StatusBadRequest)
+		r.writeReadError(w, req, err)
 		return
 	}
-	defer func() { _ = req.Body.Close() }()
+	r.capture.record(req, buf.Bytes())
+	size := buf.Len()
+	if buf, err = r.decodeBody(req, buf); err != nil {
+		r.writeDecodeError(w, req, err)
+		return
+	}
+	defer releaseBody(buf)
+	body := buf.Bytes()
+	r.requests.recordSize(req.Context(), httpKey(pipeline.SignalLogs, isV2), size, len(body))
 
 	contentType := req.Header.Get("Content-Type")
 	exportReq := plogotlp.NewExportRequest()
@@ -628,31 +1382,55 @@ func (r *tfoOTLPReceiver) handleLogs(w http.ResponseWriter, req *http.Request) {
 	}
 
 	if unmarshalErr != nil {
-		r.logger.Error("Failed to unmarshal logs", zap.Error(unmarshalErr), zap.String("content_type", contentType))
-		http.Error(w, "Failed to unmarshal logs", http.StatusBadRequest)
+		r.logger.Error("Failed to unmarshal logs", zap.Error(unmarshalErr), zap.String("content_type", contentType), requestid.Field(req.Context()))
+		writeError(w, req, status.New(codes.InvalidArgument, "Failed to unmarshal logs"))
 		return
 	}
 
 	ld := exportReq.Logs()
 	logRecordCount := ld.LogRecordCount()
 	r.logsReceived.Add(int64(logRecordCount))
+	r.requests.recordRecords(req.Context(), httpKey(pipeline.SignalLogs, isV2), logRecordCount)
 
-	isV2 := isV2Endpoint(req.URL.Path)
-	r.logger.Debug("Received logs via HTTP",
-		zap.Int("log_record_count", logRecordCount),
-		zap.String("path", req.URL.Path),
-		zap.Bool("v2_endpoint", isV2),
-	)
+	if ce := r.logger.Check(zap.DebugLevel, "Received logs via HTTP"); ce != nil {
+		ce.Write(
+			zap.Int("log_record_count", logRecordCount),
+			zap.String("path", req.URL.Path),
+			zap.Bool("v2_endpoint", isV2),
+			requestid.Field(req.Context()),
+		)
+	}
+
+	if !r.maintenance.allow(req.Context(), pipeline.SignalLogs, logRecordCount) {
+		writeError(w, req, maintenanceStatus)
+		return
+	}
+
+	if isV2 && !r.allowKey(w, req, pipeline.SignalLogs, logRecordCount) {
+		return
+	}
+
+	if !r.limiter.allow(req.Context(), pipeline.SignalLogs, logRecordCount) {
+		writeError(w, req, rateLimitedStatus)
+		return
+	}
+
+	if r.sampler.sampleLogs(ld) > 0 && ld.ResourceLogs().Len() == 0 {
+		writeResponse(w, req, plogotlp.NewExportResponse())
+		return
+	}
+
+	r.provenance.stampLogs(req.Context(), ld, r.provenance.httpTenant(req))
 
 	if r.logsConsumer != nil {
-		if err := r.logsConsumer.ConsumeLogs(req.Context(), ld); err != nil {
-			r.logger.Error("Failed to consume logs", zap.Error(err))
-			http.Error(w, "Failed to process logs", http.StatusInternalServerError)
+		err := r.consume(req.Context(), func(ctx context.Context) error { return r.logsConsumer.ConsumeLogs(ctx, ld) })
+		if err != nil {
+			r.failures.Error(failedConsumeLogs, err, requestid.Field(req.Context()))
+			writeError(w, req, consumeStatus(err, "Failed to process logs"))
 			return
 		}
+		r.failures.Success(failedConsumeLogs)
 	}
 
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	_, _ = w.Write([]byte(`{}`))
+	writeResponse(w, req, plogotlp.NewExportResponse())
 }
@@ -0,0 +1,97 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// newBenchReceiver returns a receiver wired to a nop consumer with an
+// info-level logger, as in a production deployment.
+func newBenchReceiver() *tfoOTLPReceiver {
+	core := zapcore.NewCore(
+		zapcore.NewJSONEncoder(zap.NewProductionEncoderConfig()),
+		zapcore.AddSync(io.Discard),
+		zapcore.InfoLevel,
+	)
+	return &tfoOTLPReceiver{
+		cfg:             createDefaultConfig().(*Config),
+		logger:          zap.New(core),
+		metricsConsumer: consumertest.NewNop(),
+	}
+}
+
+func benchMetricsRequest(b *testing.B) pmetricotlp.ExportRequest {
+	b.Helper()
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "bench")
+	m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("bench.gauge")
+	dps := m.SetEmptyGauge().DataPoints()
+	for i := range 100 {
+		dp := dps.AppendEmpty()
+		dp.SetIntValue(int64(i))
+		dp.Attributes().PutInt("i", int64(i))
+	}
+	return pmetricotlp.NewExportRequestFromMetrics(md)
+}
+
+func BenchmarkHandleMetrics_HTTP(b *testing.B) {
+	r := newBenchReceiver()
+	payload, err := benchMetricsRequest(b).MarshalProto()
+	if err != nil {
+		b.Fatal(err)
+	}
+	body := bytes.NewReader(payload)
+	req := httptest.NewRequest(http.MethodPost, "/v1/metrics", body)
+	req.Header.Set("Content-Type", "application/x-protobuf")
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for b.Loop() {
+		body.Reset(payload)
+		w := httptest.NewRecorder()
+		r.handleMetrics(w, req)
+		if w.Code != http.StatusOK {
+			b.Fatalf("status = %d", w.Code)
+		}
+	}
+}
+
+func BenchmarkExportMetrics_GRPC(b *testing.B) {
+	s := &metricsServer{r: newBenchReceiver()}
+	req := benchMetricsRequest(b)
+	ctx := context.Background()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for b.Loop() {
+		if _, err := s.Export(ctx, req); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
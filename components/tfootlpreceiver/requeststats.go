@@ -0,0 +1,173 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"google.golang.org/grpc/stats"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+// Histogram buckets. Size buckets grow by 4x from 256 B to 16 MiB. Record
+// buckets start at 1 so that clients sending one span per request stand out.
+var (
+	sizeBuckets    = []float64{256, 1 << 10, 4 << 10, 16 << 10, 64 << 10, 256 << 10, 1 << 20, 4 << 20, 16 << 20}
+	recordsBuckets = []float64{1, 2, 5, 10, 20, 50, 100, 200, 500, 1000, 2000, 5000, 10000}
+)
+
+// requestKey identifies the endpoint an export request arrived on.
+type requestKey struct {
+	signal   pipeline.Signal
+	protocol string // grpc, http or websocket
+	endpoint string // v1 or v2
+}
+
+// requestStats records the payload size, decompressed size and record count
+// of every export request. A nil *requestStats records nothing.
+type requestStats struct {
+	size             metric.Int64Histogram
+	decompressedSize metric.Int64Histogram
+	records          metric.Int64Histogram
+
+	// options holds the precomputed attributes of each endpoint so the
+	// request path does not allocate.
+	options map[requestKey]metric.MeasurementOption
+}
+
+func newRequestStats(meter metric.Meter, labels selfmetrics.Labels) (*requestStats, error) {
+	s := &requestStats{options: make(map[requestKey]metric.MeasurementOption)}
+	var err error
+	s.size, err = meter.Int64Histogram(selfmetrics.ReceiverRequestSize,
+		metric.WithDescription("Size of export request payloads as received, before decompression."),
+		metric.WithUnit("By"),
+		metric.WithExplicitBucketBoundaries(sizeBuckets...))
+	if err != nil {
+		return nil, err
+	}
+	s.decompressedSize, err = meter.Int64Histogram(selfmetrics.ReceiverRequestDecompressedSize,
+		metric.WithDescription("Size of export request payloads after decompression."),
+		metric.WithUnit("By"),
+		metric.WithExplicitBucketBoundaries(sizeBuckets...))
+	if err != nil {
+		return nil, err
+	}
+	s.records, err = meter.Int64Histogram(selfmetrics.ReceiverRequestRecords,
+		metric.WithDescription("Spans, data points or log records per export request."),
+		metric.WithUnit("{record}"),
+		metric.WithExplicitBucketBoundaries(recordsBuckets...))
+	if err != nil {
+		return nil, err
+	}
+
+	for _, signal := range []pipeline.Signal{pipeline.SignalTraces, pipeline.SignalMetrics, pipeline.SignalLogs} {
+		for _, protocol := range []string{"grpc", "http", "websocket"} {
+			for _, endpoint := range []string{"v1", "v2"} {
+				s.options[requestKey{signal, protocol, endpoint}] = labels.WithSignal(signal).Option(
+					attribute.String("protocol", protocol),
+					attribute.String("endpoint", endpoint),
+				)
+			}
+		}
+	}
+	return s, nil
+}
+
+// recordSize records the payload size of a request before and after
+// decompression.
+func (s *requestStats) recordSize(ctx context.Context, key requestKey, size, decompressed int) {
+	if s == nil {
+		return
+	}
+	opt := s.options[key]
+	s.size.Record(ctx, int64(size), opt)
+	s.decompressedSize.Record(ctx, int64(decompressed), opt)
+}
+
+// recordRecords records the number of records in a decoded request.
+func (s *requestStats) recordRecords(ctx context.Context, key requestKey, n int) {
+	if s == nil {
+		return
+	}
+	s.records.Record(ctx, int64(n), s.options[key])
+}
+
+// httpKey returns the requestKey of an HTTP request for signal.
+func httpKey(signal pipeline.Signal, isV2 bool) requestKey {
+	if isV2 {
+		return requestKey{signal, "http", "v2"}
+	}
+	return requestKey{signal, "http", "v1"}
+}
+
+// grpcKey returns the requestKey of a gRPC export to signal. gRPC only
+// serves the standard OTLP services, which are v1.
+func grpcKey(signal pipeline.Signal) requestKey {
+	return requestKey{signal, "grpc", "v1"}
+}
+
+// wsKey returns the requestKey of a websocket message for signal.
+func wsKey(signal pipeline.Signal) requestKey {
+	return requestKey{signal, "websocket", "v1"}
+}
+
+// grpcSignals maps the OTLP export methods to their signal.
+var grpcSignals = map[string]pipeline.Signal{
+	"/opentelemetry.proto.collector.trace.v1.TraceService/Export":     pipeline.SignalTraces,
+	"/opentelemetry.proto.collector.metrics.v1.MetricsService/Export": pipeline.SignalMetrics,
+	"/opentelemetry.proto.collector.logs.v1.LogsService/Export":       pipeline.SignalLogs,
+}
+
+type grpcSignalKey struct{}
+
+// grpcStatsHandler records payload sizes of incoming RPCs. gRPC reports
+// the compressed and decompressed length of each message, which the OTLP
+// services do not see.
+type grpcStatsHandler struct {
+	r *tfoOTLPReceiver
+}
+
+// TagRPC implements stats.Handler.
+func (h grpcStatsHandler) TagRPC(ctx context.Context, info *stats.RPCTagInfo) context.Context {
+	if signal, ok := grpcSignals[info.FullMethodName]; ok {
+		return context.WithValue(ctx, grpcSignalKey{}, signal)
+	}
+	return ctx
+}
+
+// HandleRPC implements stats.Handler.
+func (h grpcStatsHandler) HandleRPC(ctx context.Context, s stats.RPCStats) {
+	in, ok := s.(*stats.InPayload)
+	if !ok || in.Client {
+		return
+	}
+	signal, ok := ctx.Value(grpcSignalKey{}).(pipeline.Signal)
+	if !ok {
+		return
+	}
+	h.r.requests.recordSize(ctx, grpcKey(signal), in.CompressedLength, in.Length)
+}
+
+// TagConn implements stats.Handler.
+func (grpcStatsHandler) TagConn(ctx context.Context, _ *stats.ConnTagInfo) context.Context {
+	return ctx
+}
+
+// HandleConn implements stats.Handler.
+func (grpcStatsHandler) HandleConn(context.Context, stats.ConnStats) {}
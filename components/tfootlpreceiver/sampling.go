@@ -0,0 +1,161 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver
+
+import (
+	"errors"
+	"math/rand/v2"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+// SamplingConfig keeps a percentage of the received traces and log records.
+// Traces are kept or dropped whole by trace ID, so every collector that
+// samples at the same percentage keeps the same traces. Metrics are not
+// sampled.
+type SamplingConfig struct {
+	// Enabled turns on sampling.
+	// Default: false
+	Enabled bool `mapstructure:"enabled"`
+
+	// Traces is the percentage of traces kept.
+	// Default: 100
+	Traces float64 `mapstructure:"traces"`
+
+	// Logs is the percentage of log records without a trace ID kept, at
+	// random. Records with a trace ID follow the decision for their trace,
+	// so they are kept at the traces percentage.
+	// Default: 100
+	Logs float64 `mapstructure:"logs"`
+}
+
+// Validate checks the sampling configuration for errors.
+func (cfg *SamplingConfig) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.Traces < 0 || cfg.Traces > 100 {
+		return errors.New("sampling.traces must be between 0 and 100")
+	}
+	if cfg.Logs < 0 || cfg.Logs > 100 {
+		return errors.New("sampling.logs must be between 0 and 100")
+	}
+	return nil
+}
+
+// sampler drops the traces and log records that are sampled out. A nil
+// *sampler keeps everything.
+type sampler struct {
+	cfg SamplingConfig
+
+	// overrides replaces the configured percentages at runtime when set.
+	overrides OverridesProvider
+	id        component.ID
+}
+
+func newSampler(cfg SamplingConfig) *sampler {
+	return &sampler{cfg: cfg}
+}
+
+// percentage returns the percentage of signal currently kept. Disabled
+// sampling keeps everything unless overridden.
+func (s *sampler) percentage(signal pipeline.Signal) float64 {
+	p := 100.0
+	switch {
+	case !s.cfg.Enabled:
+	case signal == pipeline.SignalLogs:
+		p = s.cfg.Logs
+	default:
+		p = s.cfg.Traces
+	}
+	if s.overrides != nil {
+		if o, ok := s.overrides.ReceiverSampling(s.id, signal); ok {
+			p = o
+		}
+	}
+	return p
+}
+
+// sampleTraces removes the spans of the traces sampled out from td and
+// returns how many spans it removed.
+func (s *sampler) sampleTraces(td ptrace.Traces) int {
+	if s == nil {
+		return 0
+	}
+	p := s.percentage(pipeline.SignalTraces)
+	if p >= 100 {
+		return 0
+	}
+	dropped := 0
+	td.ResourceSpans().RemoveIf(func(rs ptrace.ResourceSpans) bool {
+		rs.ScopeSpans().RemoveIf(func(ss ptrace.ScopeSpans) bool {
+			ss.Spans().RemoveIf(func(span ptrace.Span) bool {
+				if keepSample(span.TraceID(), p) {
+					return false
+				}
+				dropped++
+				return true
+			})
+			return ss.Spans().Len() == 0
+		})
+		return rs.ScopeSpans().Len() == 0
+	})
+	return dropped
+}
+
+// sampleLogs removes the log records sampled out from ld and returns how
+// many it removed. Records with a trace ID are sampled at the traces
+// percentage, so they stay with their trace.
+func (s *sampler) sampleLogs(ld plog.Logs) int {
+	if s == nil {
+		return 0
+	}
+	logs, traces := s.percentage(pipeline.SignalLogs), s.percentage(pipeline.SignalTraces)
+	if logs >= 100 && traces >= 100 {
+		return 0
+	}
+	dropped := 0
+	ld.ResourceLogs().RemoveIf(func(rl plog.ResourceLogs) bool {
+		rl.ScopeLogs().RemoveIf(func(sl plog.ScopeLogs) bool {
+			sl.LogRecords().RemoveIf(func(lr plog.LogRecord) bool {
+				p := logs
+				if !lr.TraceID().IsEmpty() {
+					p = traces
+				}
+				if keepSample(lr.TraceID(), p) {
+					return false
+				}
+				dropped++
+				return true
+			})
+			return sl.LogRecords().Len() == 0
+		})
+		return rl.ScopeLogs().Len() == 0
+	})
+	return dropped
+}
+
+// keepSample reports whether a record of trace id is kept at percentage p.
+// Records without a trace ID are kept at random.
+func keepSample(id pcommon.TraceID, p float64) bool {
+	if id.IsEmpty() {
+		return rand.Float64()*100 < p
+	}
+	return float64(traceHash(id)%10000) < p*100
+}
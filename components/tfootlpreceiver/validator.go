@@ -0,0 +1,43 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// CredentialValidator is an interface for extensions that validate v2 API
+// keys, such as tfoauth.
+type CredentialValidator interface {
+	// ValidateCredential reports whether the API key keyID with secret
+	// keySecret is valid. An error means it could not be validated.
+	ValidateCredential(ctx context.Context, keyID, keySecret string) (bool, error)
+}
+
+// resolveValidator resolves the credential validator extension id.
+func resolveValidator(id component.ID, host component.Host) (CredentialValidator, error) {
+	ext, ok := host.GetExtensions()[id]
+	if !ok {
+		return nil, fmt.Errorf("v2_auth: validator extension %q not found", id)
+	}
+	validator, ok := ext.(CredentialValidator)
+	if !ok {
+		return nil, fmt.Errorf("v2_auth: extension %q does not validate credentials", id)
+	}
+	return validator, nil
+}
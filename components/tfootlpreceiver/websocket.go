@@ -0,0 +1,477 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/requestid"
+)
+
+// wsCloseTimeout bounds writing the close message of a connection.
+const wsCloseTimeout = time.Second
+
+// errMessageTooBig is returned by readMessage for a message that exceeds
+// max_message_size once decompressed.
+var errMessageTooBig = errors.New("message exceeds max_message_size")
+
+// maxCloseReason is the longest close reason that fits a control frame.
+const maxCloseReason = 123
+
+// wsSignals maps the signal of a message to its pipeline signal.
+var wsSignals = map[string]pipeline.Signal{
+	pipeline.SignalTraces.String():  pipeline.SignalTraces,
+	pipeline.SignalMetrics.String(): pipeline.SignalMetrics,
+	pipeline.SignalLogs.String():    pipeline.SignalLogs,
+}
+
+// wsServer serves the websocket ingest endpoint. It tracks its connections
+// because http.Server.Shutdown does not wait for hijacked ones.
+type wsServer struct {
+	r        *tfoOTLPReceiver
+	cfg      WebSocketConfig
+	upgrader websocket.Upgrader
+
+	mu      sync.Mutex
+	conns   map[*websocket.Conn]struct{}
+	closing bool
+	wg      sync.WaitGroup
+
+	// inflight counts messages being consumed, for drain.
+	inflight atomic.Int64
+}
+
+func newWSServer(r *tfoOTLPReceiver, cfg WebSocketConfig) *wsServer {
+	return &wsServer{
+		r:   r,
+		cfg: cfg,
+		upgrader: websocket.Upgrader{
+			Subprotocols:      []string{wsProtoSubprotocol, wsMsgpackSubprotocol},
+			EnableCompression: true,
+		},
+		conns: make(map[*websocket.Conn]struct{}),
+	}
+}
+
+// ServeHTTP upgrades the request and consumes its messages until the
+// connection closes.
+func (s *wsServer) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if !s.r.validateV2Auth(w, req) {
+		return
+	}
+	if !websocket.IsWebSocketUpgrade(req) {
+		http.Error(w, "Websocket upgrade required", http.StatusUpgradeRequired)
+		return
+	}
+	if !s.supported(websocket.Subprotocols(req)) {
+		http.Error(w, fmt.Sprintf("Unsupported websocket subprotocol, expected %s or %s",
+			wsProtoSubprotocol, wsMsgpackSubprotocol), http.StatusBadRequest)
+		return
+	}
+	conn, err := s.upgrader.Upgrade(w, req, nil)
+	if err != nil {
+		// Upgrade has replied with the error.
+		return
+	}
+	if !s.add(conn) {
+		closeWS(conn, websocket.CloseGoingAway, "collector shutting down")
+		_ = conn.Close()
+		return
+	}
+	defer s.remove(conn)
+
+	if ce := s.r.logger.Check(zap.DebugLevel, "Websocket connection opened"); ce != nil {
+		ce.Write(
+			zap.String("remote_addr", req.RemoteAddr),
+			zap.String("subprotocol", conn.Subprotocol()),
+			requestid.Field(req.Context()),
+		)
+	}
+	s.serve(req, conn, wsCodecs[conn.Subprotocol()])
+}
+
+// supported reports whether any of the subprotocols offered by a client is
+// served.
+func (s *wsServer) supported(offered []string) bool {
+	for _, p := range offered {
+		if _, ok := wsCodecs[p]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// serve reads the messages of conn in order and answers each with an ack.
+// Clients may send several messages before the first ack arrives.
+func (s *wsServer) serve(req *http.Request, conn *websocket.Conn, codec wsCodec) {
+	conn.SetReadLimit(s.cfg.MaxMessageSize.Bytes())
+	s.extendDeadline(conn)
+	conn.SetPongHandler(func(string) error {
+		s.extendDeadline(conn)
+		return nil
+	})
+	stop := make(chan struct{})
+	defer close(stop)
+	go s.ping(conn, stop)
+
+	tenant, keyID := s.r.provenance.httpTenant(req), req.Header.Get(headerKeyID)
+	var ack []byte
+	for {
+		typ, data, err := s.readMessage(conn)
+		if err != nil {
+			if s.isClosing() {
+				closeWS(conn, websocket.CloseGoingAway, "collector shutting down")
+			} else if errors.Is(err, websocket.ErrReadLimit) || errors.Is(err, errMessageTooBig) {
+				closeWS(conn, websocket.CloseMessageTooBig, "message exceeds max_message_size")
+				discardWS(conn)
+			}
+			if ce := s.r.logger.Check(zap.DebugLevel, "Websocket connection closed"); ce != nil {
+				ce.Write(zap.String("remote_addr", req.RemoteAddr), zap.Error(err), requestid.Field(req.Context()))
+			}
+			return
+		}
+		s.extendDeadline(conn)
+		if typ != websocket.BinaryMessage {
+			closeWS(conn, websocket.CloseUnsupportedData, "binary messages only")
+			return
+		}
+		msg, err := codec.decode(data)
+		if err != nil {
+			s.r.logger.Error("Failed to decode websocket message", zap.Error(err),
+				zap.String("subprotocol", conn.Subprotocol()), requestid.Field(req.Context()))
+			closeWS(conn, websocket.CloseInvalidFramePayloadData, "invalid message: "+err.Error())
+			return
+		}
+
+		ctx := requestid.NewContext(req.Context(), requestid.New())
+		s.inflight.Add(1)
+		result := s.r.consumeWebSocket(ctx, msg, tenant, keyID, len(data))
+		s.inflight.Add(-1)
+
+		ack = codec.appendAck(ack[:0], result)
+		_ = conn.SetWriteDeadline(time.Now().Add(s.cfg.PingInterval))
+		if err := conn.WriteMessage(websocket.BinaryMessage, ack); err != nil {
+			return
+		}
+	}
+}
+
+// readMessage reads the next message of conn. The read limit of conn bounds
+// the bytes on the wire; the message is bounded again once decompressed, as
+// a small permessage-deflate frame can inflate to any size.
+func (s *wsServer) readMessage(conn *websocket.Conn) (int, []byte, error) {
+	typ, r, err := conn.NextReader()
+	if err != nil {
+		return typ, nil, err
+	}
+	limit := s.cfg.MaxMessageSize.Bytes()
+	data, err := io.ReadAll(io.LimitReader(r, limit+1))
+	if err != nil {
+		return typ, nil, err
+	}
+	if int64(len(data)) > limit {
+		return typ, nil, errMessageTooBig
+	}
+	return typ, data, nil
+}
+
+// ping pings conn every ping interval until stop is closed.
+func (s *wsServer) ping(conn *websocket.Conn, stop <-chan struct{}) {
+	ticker := time.NewTicker(s.cfg.PingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(s.cfg.PingInterval)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// extendDeadline gives the peer two ping intervals to send its next message
+// or pong, unless the server is closing.
+func (s *wsServer) extendDeadline(conn *websocket.Conn) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.closing {
+		_ = conn.SetReadDeadline(time.Now().Add(2 * s.cfg.PingInterval))
+	}
+}
+
+func (s *wsServer) add(conn *websocket.Conn) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closing {
+		return false
+	}
+	s.conns[conn] = struct{}{}
+	s.wg.Add(1)
+	return true
+}
+
+func (s *wsServer) remove(conn *websocket.Conn) {
+	s.mu.Lock()
+	delete(s.conns, conn)
+	s.mu.Unlock()
+	_ = conn.Close()
+	s.wg.Done()
+}
+
+func (s *wsServer) isClosing() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.closing
+}
+
+// drain stops reading new messages and waits until ctx is done for the
+// messages being consumed to be acknowledged, then closes the connections
+// left. It returns the number of messages still being consumed at that
+// point. A nil *wsServer has nothing to drain.
+func (s *wsServer) drain(ctx context.Context) int64 {
+	if s == nil {
+		return 0
+	}
+	s.mu.Lock()
+	s.closing = true
+	now := time.Now()
+	for conn := range s.conns {
+		// Reads fail once the deadline has passed; the connection is closed
+		// after its current message is acknowledged.
+		_ = conn.SetReadDeadline(now)
+	}
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		return 0
+	case <-ctx.Done():
+	}
+	dropped := s.inflight.Load()
+	s.close()
+	<-done
+	return dropped
+}
+
+// close closes every connection without waiting for messages being
+// consumed. A nil *wsServer has nothing to close.
+func (s *wsServer) close() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	s.closing = true
+	for conn := range s.conns {
+		_ = conn.Close()
+	}
+	s.mu.Unlock()
+}
+
+// closeWS sends a close message with code and reason to conn.
+func closeWS(conn *websocket.Conn, code int, reason string) {
+	if len(reason) > maxCloseReason {
+		reason = reason[:maxCloseReason]
+	}
+	_ = conn.WriteControl(websocket.CloseMessage, websocket.FormatCloseMessage(code, reason),
+		time.Now().Add(wsCloseTimeout))
+}
+
+// discardWS reads and discards what the peer is still sending, until it
+// closes the connection or wsCloseTimeout expires. Closing a socket with
+// unread input resets it, and the peer would lose the close message.
+func discardWS(conn *websocket.Conn) {
+	nc := conn.NetConn()
+	_ = nc.SetReadDeadline(time.Now().Add(wsCloseTimeout))
+	_, _ = io.Copy(io.Discard, nc)
+}
+
+// consumeWebSocket passes the export request of msg, sent with the API key
+// keyID, to the consumer of its signal, with the same checks as the HTTP
+// handlers, and returns the ack.
+func (r *tfoOTLPReceiver) consumeWebSocket(ctx context.Context, msg wsMessage, tenant, keyID string, size int) wsAck {
+	ack := wsAck{id: msg.id, code: http.StatusOK}
+	signal, ok := wsSignals[msg.signal]
+	if !ok {
+		return ack.fail(http.StatusBadRequest, fmt.Sprintf("Unknown signal %q", msg.signal))
+	}
+	r.requests.recordSize(ctx, wsKey(signal), size, size)
+
+	switch signal {
+	case pipeline.SignalTraces:
+		return r.consumeWebSocketTraces(ctx, ack, msg.payload, tenant, keyID)
+	case pipeline.SignalMetrics:
+		return r.consumeWebSocketMetrics(ctx, ack, msg.payload, tenant, keyID)
+	default:
+		return r.consumeWebSocketLogs(ctx, ack, msg.payload, tenant, keyID)
+	}
+}
+
+func (r *tfoOTLPReceiver) consumeWebSocketTraces(ctx context.Context, ack wsAck, payload []byte, tenant, keyID string) wsAck {
+	exportReq := ptraceotlp.NewExportRequest()
+	if err := exportReq.UnmarshalProto(payload); err != nil {
+		r.logger.Error("Failed to unmarshal traces", zap.Error(err), zap.String("protocol", "websocket"), requestid.Field(ctx))
+		return ack.fail(http.StatusBadRequest, "Failed to unmarshal traces")
+	}
+
+	td := exportReq.Traces()
+	spanCount := td.SpanCount()
+	r.tracesReceived.Add(int64(spanCount))
+	r.requests.recordRecords(ctx, wsKey(pipeline.SignalTraces), spanCount)
+
+	if ce := r.logger.Check(zap.DebugLevel, "Received traces via websocket"); ce != nil {
+		ce.Write(zap.Int("span_count", spanCount), zap.Uint64("message_id", ack.id), requestid.Field(ctx))
+	}
+
+	if !r.maintenance.allow(ctx, pipeline.SignalTraces, spanCount) {
+		return ack.fail(http.StatusServiceUnavailable, "Collector in maintenance mode")
+	}
+
+	if _, ok := r.keyLimiter.allow(ctx, keyID, pipeline.SignalTraces, spanCount); !ok {
+		return ack.fail(http.StatusTooManyRequests, "API key rate limit exceeded")
+	}
+
+	rejected := r.limiter.limitTraces(ctx, td)
+	if rejected > 0 && rejected == spanCount {
+		return ack.fail(http.StatusTooManyRequests, "Rate limit exceeded")
+	}
+
+	if r.sampler.sampleTraces(td) > 0 && td.ResourceSpans().Len() == 0 {
+		ack.rejected = int64(rejected)
+		return ack
+	}
+
+	r.provenance.stampTraces(ctx, td, tenant)
+
+	if r.tracesConsumer != nil {
+		err := r.consume(ctx, func(ctx context.Context) error { return r.tracesConsumer.ConsumeTraces(ctx, td) })
+		if err != nil {
+			r.failures.Error(failedConsumeTraces, err, requestid.Field(ctx))
+			return ack.fail(http.StatusInternalServerError, "Failed to process traces")
+		}
+		r.failures.Success(failedConsumeTraces)
+	}
+
+	ack.rejected = int64(rejected)
+	return ack
+}
+
+func (r *tfoOTLPReceiver) consumeWebSocketMetrics(ctx context.Context, ack wsAck, payload []byte, tenant, keyID string) wsAck {
+	exportReq := pmetricotlp.NewExportRequest()
+	if err := exportReq.UnmarshalProto(payload); err != nil {
+		r.logger.Error("Failed to unmarshal metrics", zap.Error(err), zap.String("protocol", "websocket"), requestid.Field(ctx))
+		return ack.fail(http.StatusBadRequest, "Failed to unmarshal metrics")
+	}
+
+	md := exportReq.Metrics()
+	dataPointCount := md.DataPointCount()
+	r.metricsReceived.Add(int64(dataPointCount))
+	r.requests.recordRecords(ctx, wsKey(pipeline.SignalMetrics), dataPointCount)
+
+	if ce := r.logger.Check(zap.DebugLevel, "Received metrics via websocket"); ce != nil {
+		ce.Write(zap.Int("data_point_count", dataPointCount), zap.Uint64("message_id", ack.id), requestid.Field(ctx))
+	}
+
+	if !r.maintenance.allow(ctx, pipeline.SignalMetrics, dataPointCount) {
+		return ack.fail(http.StatusServiceUnavailable, "Collector in maintenance mode")
+	}
+
+	if _, ok := r.keyLimiter.allow(ctx, keyID, pipeline.SignalMetrics, dataPointCount); !ok {
+		return ack.fail(http.StatusTooManyRequests, "API key rate limit exceeded")
+	}
+
+	if !r.limiter.allow(ctx, pipeline.SignalMetrics, dataPointCount) {
+		return ack.fail(http.StatusTooManyRequests, "Rate limit exceeded")
+	}
+
+	r.provenance.stampMetrics(ctx, md, tenant)
+
+	if r.metricsConsumer != nil {
+		err := r.consume(ctx, func(ctx context.Context) error { return r.metricsConsumer.ConsumeMetrics(ctx, md) })
+		if err != nil {
+			r.failures.Error(failedConsumeMetrics, err, requestid.Field(ctx))
+			return ack.fail(http.StatusInternalServerError, "Failed to process metrics")
+		}
+		r.failures.Success(failedConsumeMetrics)
+	}
+
+	return ack
+}
+
+func (r *tfoOTLPReceiver) consumeWebSocketLogs(ctx context.Context, ack wsAck, payload []byte, tenant, keyID string) wsAck {
+	exportReq := plogotlp.NewExportRequest()
+	if err := exportReq.UnmarshalProto(payload); err != nil {
+		r.logger.Error("Failed to unmarshal logs", zap.Error(err), zap.String("protocol", "websocket"), requestid.Field(ctx))
+		return ack.fail(http.StatusBadRequest, "Failed to unmarshal logs")
+	}
+
+	ld := exportReq.Logs()
+	logRecordCount := ld.LogRecordCount()
+	r.logsReceived.Add(int64(logRecordCount))
+	r.requests.recordRecords(ctx, wsKey(pipeline.SignalLogs), logRecordCount)
+
+	if ce := r.logger.Check(zap.DebugLevel, "Received logs via websocket"); ce != nil {
+		ce.Write(zap.Int("log_record_count", logRecordCount), zap.Uint64("message_id", ack.id), requestid.Field(ctx))
+	}
+
+	if !r.maintenance.allow(ctx, pipeline.SignalLogs, logRecordCount) {
+		return ack.fail(http.StatusServiceUnavailable, "Collector in maintenance mode")
+	}
+
+	if _, ok := r.keyLimiter.allow(ctx, keyID, pipeline.SignalLogs, logRecordCount); !ok {
+		return ack.fail(http.StatusTooManyRequests, "API key rate limit exceeded")
+	}
+
+	if !r.limiter.allow(ctx, pipeline.SignalLogs, logRecordCount) {
+		return ack.fail(http.StatusTooManyRequests, "Rate limit exceeded")
+	}
+
+	if r.sampler.sampleLogs(ld) > 0 && ld.ResourceLogs().Len() == 0 {
+		return ack
+	}
+
+	r.provenance.stampLogs(ctx, ld, tenant)
+
+	if r.logsConsumer != nil {
+		err := r.consume(ctx, func(ctx context.Context) error { return r.logsConsumer.ConsumeLogs(ctx, ld) })
+		if err != nil {
+			r.failures.Error(failedConsumeLogs, err, requestid.Field(ctx))
+			return ack.fail(http.StatusInternalServerError, "Failed to process logs")
+		}
+		r.failures.Success(failedConsumeLogs)
+	}
+
+	return ack
+}
@@ -0,0 +1,426 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// Websocket subprotocols, selecting the encoding of messages and acks.
+const (
+	wsProtoSubprotocol   = "otlp.proto"
+	wsMsgpackSubprotocol = "otlp.msgpack"
+)
+
+// wsMessage is an export request received on the websocket endpoint.
+type wsMessage struct {
+	id      uint64
+	signal  string
+	payload []byte // OTLP protobuf export request
+}
+
+// wsAck acknowledges a wsMessage. Codes are HTTP status codes.
+type wsAck struct {
+	id       uint64
+	code     int
+	message  string
+	rejected int64 // spans rejected from a partially admitted request
+}
+
+// fail returns a with the error code and message.
+func (a wsAck) fail(code int, message string) wsAck {
+	a.code, a.message = code, message
+	return a
+}
+
+// wsCodec decodes messages and encodes acks in one subprotocol.
+type wsCodec struct {
+	decode    func(data []byte) (wsMessage, error)
+	appendAck func(b []byte, ack wsAck) []byte
+}
+
+var wsCodecs = map[string]wsCodec{
+	wsProtoSubprotocol:   {decode: decodeProtoMessage, appendAck: appendProtoAck},
+	wsMsgpackSubprotocol: {decode: decodeMsgpackMessage, appendAck: appendMsgpackAck},
+}
+
+// Field numbers of the protobuf message and ack:
+//
+//	message WebSocketMessage { uint64 id = 1; string signal = 2; bytes payload = 3; }
+//	message WebSocketAck { uint64 id = 1; uint32 code = 2; string message = 3; int64 rejected = 4; }
+const (
+	wsFieldID       protowire.Number = 1
+	wsFieldSignal   protowire.Number = 2
+	wsFieldPayload  protowire.Number = 3
+	wsFieldCode     protowire.Number = 2
+	wsFieldMessage  protowire.Number = 3
+	wsFieldRejected protowire.Number = 4
+)
+
+// decodeProtoMessage decodes a protobuf WebSocketMessage. Unknown fields are
+// skipped.
+func decodeProtoMessage(b []byte) (wsMessage, error) {
+	var m wsMessage
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return m, protowire.ParseError(n)
+		}
+		b = b[n:]
+		switch {
+		case num == wsFieldID && typ == protowire.VarintType:
+			m.id, n = protowire.ConsumeVarint(b)
+		case num == wsFieldSignal && typ == protowire.BytesType:
+			var v []byte
+			v, n = protowire.ConsumeBytes(b)
+			m.signal = string(v)
+		case num == wsFieldPayload && typ == protowire.BytesType:
+			m.payload, n = protowire.ConsumeBytes(b)
+		default:
+			n = protowire.ConsumeFieldValue(num, typ, b)
+		}
+		if n < 0 {
+			return m, protowire.ParseError(n)
+		}
+		b = b[n:]
+	}
+	return m, nil
+}
+
+// appendProtoAck appends ack encoded as a protobuf WebSocketAck to b.
+func appendProtoAck(b []byte, ack wsAck) []byte {
+	b = protowire.AppendTag(b, wsFieldID, protowire.VarintType)
+	b = protowire.AppendVarint(b, ack.id)
+	b = protowire.AppendTag(b, wsFieldCode, protowire.VarintType)
+	b = protowire.AppendVarint(b, uint64(ack.code))
+	if ack.message != "" {
+		b = protowire.AppendTag(b, wsFieldMessage, protowire.BytesType)
+		b = protowire.AppendString(b, ack.message)
+	}
+	if ack.rejected > 0 {
+		b = protowire.AppendTag(b, wsFieldRejected, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(ack.rejected))
+	}
+	return b
+}
+
+// decodeMsgpackMessage decodes a MessagePack map with the keys id, signal
+// and payload. Other keys are skipped.
+func decodeMsgpackMessage(b []byte) (wsMessage, error) {
+	var m wsMessage
+	r := msgpackReader{b: b}
+	n, err := r.mapLen()
+	if err != nil {
+		return m, err
+	}
+	for range n {
+		key, err := r.str()
+		if err != nil {
+			return m, err
+		}
+		switch key {
+		case "id":
+			m.id, err = r.uint()
+		case "signal":
+			m.signal, err = r.str()
+		case "payload":
+			m.payload, err = r.bin()
+		default:
+			err = r.skip(0)
+		}
+		if err != nil {
+			return m, fmt.Errorf("%s: %w", key, err)
+		}
+	}
+	if len(r.b) > 0 {
+		return m, errors.New("msgpack: trailing data")
+	}
+	return m, nil
+}
+
+// appendMsgpackAck appends ack encoded as a MessagePack map with the keys
+// id, code and, when set, message and rejected to b.
+func appendMsgpackAck(b []byte, ack wsAck) []byte {
+	n := 2
+	if ack.message != "" {
+		n++
+	}
+	if ack.rejected > 0 {
+		n++
+	}
+	b = append(b, 0x80|byte(n))
+	b = appendMsgpackStr(b, "id")
+	b = appendMsgpackUint(b, ack.id)
+	b = appendMsgpackStr(b, "code")
+	b = appendMsgpackUint(b, uint64(ack.code))
+	if ack.message != "" {
+		b = appendMsgpackStr(b, "message")
+		b = appendMsgpackStr(b, ack.message)
+	}
+	if ack.rejected > 0 {
+		b = appendMsgpackStr(b, "rejected")
+		b = appendMsgpackUint(b, uint64(ack.rejected))
+	}
+	return b
+}
+
+func appendMsgpackUint(b []byte, v uint64) []byte {
+	switch {
+	case v < 0x80:
+		return append(b, byte(v))
+	case v <= 0xff:
+		return append(b, 0xcc, byte(v))
+	case v <= 0xffff:
+		return binary.BigEndian.AppendUint16(append(b, 0xcd), uint16(v))
+	case v <= 0xffffffff:
+		return binary.BigEndian.AppendUint32(append(b, 0xce), uint32(v))
+	default:
+		return binary.BigEndian.AppendUint64(append(b, 0xcf), v)
+	}
+}
+
+func appendMsgpackStr(b []byte, s string) []byte {
+	switch n := len(s); {
+	case n < 32:
+		b = append(b, 0xa0|byte(n))
+	case n <= 0xff:
+		b = append(b, 0xd9, byte(n))
+	case n <= 0xffff:
+		b = binary.BigEndian.AppendUint16(append(b, 0xda), uint16(n))
+	default:
+		b = binary.BigEndian.AppendUint32(append(b, 0xdb), uint32(n))
+	}
+	return append(b, s...)
+}
+
+// msgpackMaxDepth bounds the nesting of skipped values.
+const msgpackMaxDepth = 32
+
+var errMsgpackTruncated = errors.New("msgpack: truncated")
+
+// msgpackReader decodes the MessagePack values of a websocket message.
+type msgpackReader struct {
+	b []byte
+}
+
+func (r *msgpackReader) next(n int) ([]byte, error) {
+	if n < 0 || n > len(r.b) {
+		return nil, errMsgpackTruncated
+	}
+	v := r.b[:n]
+	r.b = r.b[n:]
+	return v, nil
+}
+
+func (r *msgpackReader) byte() (byte, error) {
+	v, err := r.next(1)
+	if err != nil {
+		return 0, err
+	}
+	return v[0], nil
+}
+
+// length reads a big-endian length of size bytes.
+func (r *msgpackReader) length(size int) (int, error) {
+	v, err := r.next(size)
+	if err != nil {
+		return 0, err
+	}
+	var n uint64
+	for _, c := range v {
+		n = n<<8 | uint64(c)
+	}
+	if n > uint64(len(r.b)) {
+		return 0, errMsgpackTruncated
+	}
+	return int(n), nil
+}
+
+func (r *msgpackReader) mapLen() (int, error) {
+	c, err := r.byte()
+	if err != nil {
+		return 0, err
+	}
+	switch {
+	case c&0xf0 == 0x80:
+		return int(c & 0x0f), nil
+	case c == 0xde:
+		return r.length(2)
+	case c == 0xdf:
+		return r.length(4)
+	}
+	return 0, fmt.Errorf("msgpack: expected a map, got type 0x%02x", c)
+}
+
+func (r *msgpackReader) str() (string, error) {
+	c, err := r.byte()
+	if err != nil {
+		return "", err
+	}
+	n, err := r.strLen(c)
+	if err != nil {
+		return "", err
+	}
+	v, err := r.next(n)
+	return string(v), err
+}
+
+// strLen returns the length of a str of type c, or an error for other types.
+func (r *msgpackReader) strLen(c byte) (int, error) {
+	switch {
+	case c&0xe0 == 0xa0:
+		return int(c & 0x1f), nil
+	case c == 0xd9:
+		return r.length(1)
+	case c == 0xda:
+		return r.length(2)
+	case c == 0xdb:
+		return r.length(4)
+	}
+	return 0, fmt.Errorf("msgpack: expected a string, got type 0x%02x", c)
+}
+
+// bin reads a bin value. A str is accepted too, as encoders predating the
+// bin type write byte slices as str.
+func (r *msgpackReader) bin() ([]byte, error) {
+	c, err := r.byte()
+	if err != nil {
+		return nil, err
+	}
+	var n int
+	switch c {
+	case 0xc4:
+		n, err = r.length(1)
+	case 0xc5:
+		n, err = r.length(2)
+	case 0xc6:
+		n, err = r.length(4)
+	default:
+		n, err = r.strLen(c)
+		if err != nil {
+			return nil, fmt.Errorf("msgpack: expected binary data, got type 0x%02x", c)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return r.next(n)
+}
+
+// uint reads a non-negative integer.
+func (r *msgpackReader) uint() (uint64, error) {
+	c, err := r.byte()
+	if err != nil {
+		return 0, err
+	}
+	var size int
+	switch {
+	case c < 0x80:
+		return uint64(c), nil
+	case c >= 0xcc && c <= 0xcf:
+		size = 1 << (c - 0xcc)
+	case c >= 0xd0 && c <= 0xd3:
+		size = 1 << (c - 0xd0)
+	default:
+		return 0, fmt.Errorf("msgpack: expected an integer, got type 0x%02x", c)
+	}
+	v, err := r.next(size)
+	if err != nil {
+		return 0, err
+	}
+	var n uint64
+	for _, b := range v {
+		n = n<<8 | uint64(b)
+	}
+	if c >= 0xd0 && v[0]&0x80 != 0 {
+		return 0, errors.New("msgpack: negative integer")
+	}
+	return n, nil
+}
+
+// skip skips one value of any type.
+func (r *msgpackReader) skip(depth int) error {
+	if depth > msgpackMaxDepth {
+		return errors.New("msgpack: nested too deeply")
+	}
+	c, err := r.byte()
+	if err != nil {
+		return err
+	}
+	var size, elems int
+	switch {
+	case c < 0x80, c >= 0xe0, c == 0xc0, c == 0xc2, c == 0xc3:
+		return nil
+	case c&0xf0 == 0x80:
+		elems = 2 * int(c&0x0f)
+	case c&0xf0 == 0x90:
+		elems = int(c & 0x0f)
+	case c&0xe0 == 0xa0:
+		size = int(c & 0x1f)
+	case c == 0xc4, c == 0xd9:
+		size, err = r.length(1)
+	case c == 0xc5, c == 0xda:
+		size, err = r.length(2)
+	case c == 0xc6, c == 0xdb:
+		size, err = r.length(4)
+	case c == 0xc7:
+		size, err = r.length(1)
+		size++
+	case c == 0xc8:
+		size, err = r.length(2)
+		size++
+	case c == 0xc9:
+		size, err = r.length(4)
+		size++
+	case c == 0xca:
+		size = 4
+	case c == 0xcb:
+		size = 8
+	case c >= 0xcc && c <= 0xcf:
+		size = 1 << (c - 0xcc)
+	case c >= 0xd0 && c <= 0xd3:
+		size = 1 << (c - 0xd0)
+	case c >= 0xd4 && c <= 0xd8:
+		size = 1<<(c-0xd4) + 1
+	case c == 0xdc:
+		elems, err = r.length(2)
+	case c == 0xdd:
+		elems, err = r.length(4)
+	case c == 0xde:
+		elems, err = r.length(2)
+		elems *= 2
+	case c == 0xdf:
+		elems, err = r.length(4)
+		elems *= 2
+	default:
+		return fmt.Errorf("msgpack: invalid type 0x%02x", c)
+	}
+	if err != nil {
+		return err
+	}
+	if _, err := r.next(size); err != nil {
+		return err
+	}
+	for range elems {
+		if err := r.skip(depth + 1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
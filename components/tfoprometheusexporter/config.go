@@ -0,0 +1,94 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoprometheusexporter
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusexporter"
+)
+
+// Aggregations written for gauges and non-monotonic sums.
+const (
+	AggregationAvg = "avg"
+	AggregationMin = "min"
+	AggregationMax = "max"
+)
+
+// Config defines the configuration for the Prometheus exporter. It is the
+// upstream configuration with an additional downsampling section.
+type Config struct {
+	prometheusexporter.Config `mapstructure:",squash"`
+
+	// Downsampling configures the downsampled endpoint.
+	Downsampling DownsamplingConfig `mapstructure:"downsampling"`
+}
+
+// DownsamplingConfig defines the downsampled endpoint settings.
+type DownsamplingConfig struct {
+	// Enabled serves downsampled series on Endpoint in addition to the
+	// full series on the exporter endpoint.
+	// Default: false
+	Enabled bool `mapstructure:"enabled"`
+
+	// Endpoint is the listen address of the downsampled series. The other
+	// HTTP server settings are those of the exporter endpoint.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Interval is the window the series are aggregated over. Windows are
+	// aligned to the wall clock.
+	// Default: 5m
+	Interval time.Duration `mapstructure:"interval"`
+
+	// Aggregations lists the series written per gauge, each named after
+	// the gauge with the aggregation as suffix: avg, min or max.
+	// Default: [avg, max]
+	Aggregations []string `mapstructure:"aggregations"`
+}
+
+// Validate checks the downsampling configuration for errors. The upstream
+// settings are validated by the embedded configuration.
+func (cfg *Config) Validate() error {
+	ds := &cfg.Downsampling
+	if !ds.Enabled {
+		return nil
+	}
+	if ds.Endpoint == "" {
+		return errors.New("downsampling: endpoint is required")
+	}
+	if ds.Endpoint == cfg.NetAddr.Endpoint {
+		return fmt.Errorf("downsampling: endpoint %q is also the exporter endpoint", ds.Endpoint)
+	}
+	if ds.Interval <= 0 {
+		return errors.New("downsampling: interval must be positive")
+	}
+	if len(ds.Aggregations) == 0 {
+		return errors.New("downsampling: at least one aggregation is required")
+	}
+	for i, agg := range ds.Aggregations {
+		switch agg {
+		case AggregationAvg, AggregationMin, AggregationMax:
+		default:
+			return fmt.Errorf("downsampling: aggregations[%d]: unsupported aggregation %q", i, agg)
+		}
+		if slices.Index(ds.Aggregations, agg) != i {
+			return fmt.Errorf("downsampling: aggregations[%d]: duplicate aggregation %q", i, agg)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,53 @@
+// Package tfoprometheusexporter provides the Prometheus exporter with an
+// optional downsampled endpoint.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// The factory has the type of the upstream Prometheus exporter, prometheus,
+// and replaces it in the distribution: every upstream setting is accepted
+// unchanged and, without downsampling, the exporter is the upstream one.
+//
+// Constrained local Prometheus servers cannot always afford to scrape the
+// full series while the full fidelity goes to the TFO backend. With
+// downsampling enabled, the exporter additionally serves a cheap summary
+// on a second endpoint, refreshed once per interval:
+//   - gauges and non-monotonic sums get one gauge per aggregation, named
+//     after the metric with the aggregation as suffix, e.g. queue_size_avg
+//     and queue_size_max
+//   - monotonic cumulative sums keep their name and the last value of the
+//     window, so rate() still works at the lower resolution
+//   - monotonic delta sums keep their name and the total of the window
+//   - histograms, exponential histograms and summaries are left out
+//
+// Unit and type suffixes are added after the aggregation suffix according
+// to the translation settings, e.g. cpu_load_max_ratio. The downsampled
+// endpoint shares the HTTP server settings, namespace, labels and
+// translation settings of the exporter endpoint. Windows are aligned to the
+// wall clock and the window in progress is lost on shutdown. The
+// tfo_exporter_downsampled_series internal metric records the series
+// written for the last window.
+//
+// Configuration example:
+//
+//	exporters:
+//	  prometheus:
+//	    endpoint: 0.0.0.0:8889
+//	    downsampling:
+//	      enabled: true
+//	      endpoint: 0.0.0.0:8890
+//	      interval: 5m
+//	      aggregations: [avg, max]
+package tfoprometheusexporter // import "github.com/telemetryflow/telemetryflow-collector/components/tfoprometheusexporter"
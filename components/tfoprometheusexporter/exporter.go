@@ -0,0 +1,147 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoprometheusexporter
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+const scopeName = "github.com/telemetryflow/telemetryflow-collector/components/tfoprometheusexporter"
+
+// downsamplingExporter serves the full series through the embedded upstream
+// exporter and writes the downsampled series to summary once per interval.
+type downsamplingExporter struct {
+	exporter.Metrics
+
+	summary      exporter.Metrics
+	interval     time.Duration
+	aggregations []string
+	set          exporter.Settings
+
+	labels selfmetrics.Labels
+	series metric.Int64Gauge
+
+	mu     sync.Mutex
+	window *window
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func newDownsamplingExporter(cfg *Config, set exporter.Settings, full, summary exporter.Metrics) *downsamplingExporter {
+	return &downsamplingExporter{
+		Metrics:      full,
+		summary:      summary,
+		interval:     cfg.Downsampling.Interval,
+		aggregations: cfg.Downsampling.Aggregations,
+		set:          set,
+		labels:       selfmetrics.Exporter(set.ID, pipeline.SignalMetrics),
+		window:       newWindow(pcommon.NewTimestampFromTime(time.Now())),
+	}
+}
+
+// Start implements component.Component.
+func (e *downsamplingExporter) Start(ctx context.Context, host component.Host) error {
+	if e.set.MeterProvider != nil {
+		var err error
+		e.series, err = e.set.MeterProvider.Meter(scopeName).Int64Gauge(selfmetrics.ExporterDownsampledSeries,
+			metric.WithDescription("Series written for the last downsampling window."),
+			metric.WithUnit("{series}"))
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := e.Metrics.Start(ctx, host); err != nil {
+		return err
+	}
+	if err := e.summary.Start(ctx, host); err != nil {
+		return errors.Join(err, e.Metrics.Shutdown(ctx))
+	}
+	e.stop = make(chan struct{})
+	e.done = make(chan struct{})
+	go e.run()
+	return nil
+}
+
+// Shutdown implements component.Component. The window in progress is
+// discarded.
+func (e *downsamplingExporter) Shutdown(ctx context.Context) error {
+	if e.stop != nil {
+		close(e.stop)
+		<-e.done
+		e.stop = nil
+	}
+	return errors.Join(e.Metrics.Shutdown(ctx), e.summary.Shutdown(ctx))
+}
+
+// ConsumeMetrics adds md to the current window before passing it to the
+// upstream exporter, which may modify it.
+func (e *downsamplingExporter) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	e.mu.Lock()
+	e.window.add(md)
+	e.mu.Unlock()
+	return e.Metrics.ConsumeMetrics(ctx, md)
+}
+
+// run flushes the window at every interval boundary until stopped.
+func (e *downsamplingExporter) run() {
+	defer close(e.done)
+	for {
+		end := time.Now().Truncate(e.interval).Add(e.interval)
+		timer := time.NewTimer(time.Until(end))
+		select {
+		case <-e.stop:
+			timer.Stop()
+			return
+		case <-timer.C:
+			e.flush(end)
+		}
+	}
+}
+
+// flush writes the downsampled series of the window ending at end and
+// starts the next window.
+func (e *downsamplingExporter) flush(end time.Time) {
+	ts := pcommon.NewTimestampFromTime(end)
+	e.mu.Lock()
+	w := e.window
+	e.window = newWindow(ts)
+	e.mu.Unlock()
+
+	md, series := w.metrics(ts, e.aggregations)
+	if e.series != nil {
+		e.series.Record(context.Background(), int64(series), e.labels.Option())
+	}
+	if series == 0 {
+		return
+	}
+	if err := e.summary.ConsumeMetrics(context.Background(), md); err != nil {
+		e.set.Logger.Warn("Failed to write downsampled series", zap.Int("series", series), zap.Error(err))
+	}
+}
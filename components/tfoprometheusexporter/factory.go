@@ -0,0 +1,97 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoprometheusexporter
+
+import (
+	"context"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusexporter"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter"
+	"go.uber.org/zap"
+)
+
+// Defaults
+const (
+	defaultInterval = 5 * time.Minute
+)
+
+// upstream is the factory of the upstream Prometheus exporter.
+var upstream = prometheusexporter.NewFactory()
+
+// NewFactory creates a new factory for the Prometheus exporter. It has the
+// type of the upstream exporter, prometheus, and replaces it in the
+// distribution.
+func NewFactory() exporter.Factory {
+	return exporter.NewFactory(
+		upstream.Type(),
+		createDefaultConfig,
+		exporter.WithMetrics(createMetricsExporter, upstream.MetricsStability()),
+	)
+}
+
+// createDefaultConfig creates the default configuration for the exporter.
+func createDefaultConfig() component.Config {
+	return &Config{
+		Config: *upstream.CreateDefaultConfig().(*prometheusexporter.Config),
+		Downsampling: DownsamplingConfig{
+			Interval:     defaultInterval,
+			Aggregations: []string{AggregationAvg, AggregationMax},
+		},
+	}
+}
+
+// createMetricsExporter creates a metrics exporter. Without downsampling it
+// is the upstream exporter.
+func createMetricsExporter(
+	ctx context.Context,
+	set exporter.Settings,
+	cfg component.Config,
+) (exporter.Metrics, error) {
+	c := cfg.(*Config)
+	full, err := upstream.CreateMetrics(ctx, set, &c.Config)
+	if err != nil || !c.Downsampling.Enabled {
+		return full, err
+	}
+
+	summary, err := upstream.CreateMetrics(ctx, downsampledSettings(set), downsampledConfig(c))
+	if err != nil {
+		return nil, err
+	}
+	return newDownsamplingExporter(c, set, full, summary), nil
+}
+
+// downsampledSettings returns the settings of the exporter serving the
+// downsampled series.
+func downsampledSettings(set exporter.Settings) exporter.Settings {
+	name := "downsampled"
+	if set.ID.Name() != "" {
+		name = set.ID.Name() + "_" + name
+	}
+	set.ID = component.NewIDWithName(set.ID.Type(), name)
+	set.Logger = set.Logger.With(zap.String("endpoint", "downsampled"))
+	return set
+}
+
+// downsampledConfig returns the configuration of the exporter serving the
+// downsampled series. Series are written once per interval, so they are
+// kept for two intervals at least.
+func downsampledConfig(cfg *Config) *prometheusexporter.Config {
+	c := cfg.Config
+	c.NetAddr.Endpoint = cfg.Downsampling.Endpoint
+	c.MetricExpiration = max(c.MetricExpiration, 2*cfg.Downsampling.Interval)
+	return &c
+}
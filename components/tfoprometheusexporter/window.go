@@ -0,0 +1,295 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoprometheusexporter
+
+import (
+	"maps"
+	"math"
+	"slices"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// seriesKind is how the points of a metric are downsampled.
+type seriesKind int
+
+const (
+	// kindGauge covers gauges and non-monotonic sums. A series is written
+	// per aggregation.
+	kindGauge seriesKind = iota
+	// kindCumulative covers monotonic cumulative sums. The last value of
+	// the window is written.
+	kindCumulative
+	// kindDelta covers monotonic delta sums. The total of the window is
+	// written.
+	kindDelta
+)
+
+// window accumulates the data points received during one interval. It is
+// not safe for concurrent use.
+type window struct {
+	start     pcommon.Timestamp
+	resources map[string]*resourceWindow
+}
+
+type resourceWindow struct {
+	resource pcommon.Resource
+	scopes   map[string]*scopeWindow
+}
+
+type scopeWindow struct {
+	scope   pcommon.InstrumentationScope
+	metrics map[string]*metricWindow
+}
+
+type metricWindow struct {
+	name        string
+	description string
+	unit        string
+	kind        seriesKind
+	points      map[string]*pointWindow
+}
+
+type pointWindow struct {
+	attributes pcommon.Map
+
+	count int
+	sum   float64
+	min   float64
+	max   float64
+
+	// last, lastTime and start track the latest point of a cumulative sum.
+	last     float64
+	lastTime pcommon.Timestamp
+	start    pcommon.Timestamp
+}
+
+func newWindow(start pcommon.Timestamp) *window {
+	return &window{start: start, resources: make(map[string]*resourceWindow)}
+}
+
+// add accumulates the gauges and sums of md. Histograms, exponential
+// histograms and summaries are not downsampled.
+func (w *window) add(md pmetric.Metrics) {
+	for _, rm := range md.ResourceMetrics().All() {
+		rw := w.resource(rm.Resource())
+		for _, sm := range rm.ScopeMetrics().All() {
+			sw := rw.scope(sm.Scope())
+			for _, m := range sm.Metrics().All() {
+				switch m.Type() {
+				case pmetric.MetricTypeGauge:
+					sw.metric(m, kindGauge).add(m.Gauge().DataPoints())
+				case pmetric.MetricTypeSum:
+					if kind, ok := sumKind(m.Sum()); ok {
+						sw.metric(m, kind).add(m.Sum().DataPoints())
+					}
+				}
+			}
+		}
+	}
+}
+
+// sumKind returns how the points of sum are downsampled, or false if its
+// temporality is unspecified.
+func sumKind(sum pmetric.Sum) (seriesKind, bool) {
+	switch {
+	case !sum.IsMonotonic():
+		return kindGauge, true
+	case sum.AggregationTemporality() == pmetric.AggregationTemporalityCumulative:
+		return kindCumulative, true
+	case sum.AggregationTemporality() == pmetric.AggregationTemporalityDelta:
+		return kindDelta, true
+	default:
+		return 0, false
+	}
+}
+
+func (w *window) resource(res pcommon.Resource) *resourceWindow {
+	key := attributesKey(res.Attributes())
+	rw, ok := w.resources[key]
+	if !ok {
+		rw = &resourceWindow{resource: pcommon.NewResource(), scopes: make(map[string]*scopeWindow)}
+		res.CopyTo(rw.resource)
+		w.resources[key] = rw
+	}
+	return rw
+}
+
+func (rw *resourceWindow) scope(scope pcommon.InstrumentationScope) *scopeWindow {
+	key := scope.Name() + "\x00" + scope.Version()
+	sw, ok := rw.scopes[key]
+	if !ok {
+		sw = &scopeWindow{scope: pcommon.NewInstrumentationScope(), metrics: make(map[string]*metricWindow)}
+		scope.CopyTo(sw.scope)
+		rw.scopes[key] = sw
+	}
+	return sw
+}
+
+// metric returns the window of m. Points of a metric whose kind changed
+// within the window are ignored.
+func (sw *scopeWindow) metric(m pmetric.Metric, kind seriesKind) *metricWindow {
+	mw, ok := sw.metrics[m.Name()]
+	if !ok {
+		mw = &metricWindow{
+			name:        m.Name(),
+			description: m.Description(),
+			unit:        m.Unit(),
+			kind:        kind,
+			points:      make(map[string]*pointWindow),
+		}
+		sw.metrics[m.Name()] = mw
+	}
+	if mw.kind != kind {
+		return nil
+	}
+	return mw
+}
+
+func (mw *metricWindow) add(points pmetric.NumberDataPointSlice) {
+	if mw == nil {
+		return
+	}
+	for _, dp := range points.All() {
+		if dp.Flags().NoRecordedValue() {
+			continue
+		}
+		var v float64
+		switch dp.ValueType() {
+		case pmetric.NumberDataPointValueTypeInt:
+			v = float64(dp.IntValue())
+		case pmetric.NumberDataPointValueTypeDouble:
+			v = dp.DoubleValue()
+		default:
+			continue
+		}
+		if math.IsNaN(v) {
+			continue
+		}
+
+		key := attributesKey(dp.Attributes())
+		pw, ok := mw.points[key]
+		if !ok {
+			pw = &pointWindow{attributes: pcommon.NewMap(), min: v, max: v}
+			dp.Attributes().CopyTo(pw.attributes)
+			mw.points[key] = pw
+		}
+		pw.count++
+		pw.sum += v
+		pw.min = min(pw.min, v)
+		pw.max = max(pw.max, v)
+		if dp.Timestamp() >= pw.lastTime {
+			pw.last = v
+			pw.lastTime = dp.Timestamp()
+			pw.start = dp.StartTimestamp()
+		}
+	}
+}
+
+// metrics returns the downsampled series of the window ending at end and
+// their number. Gauges get one metric per aggregation, named after the
+// gauge with the aggregation as suffix.
+func (w *window) metrics(end pcommon.Timestamp, aggregations []string) (pmetric.Metrics, int) {
+	md := pmetric.NewMetrics()
+	series := 0
+	for _, rw := range w.resources {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rw.resource.CopyTo(rm.Resource())
+		for _, sw := range rw.scopes {
+			sm := rm.ScopeMetrics().AppendEmpty()
+			sw.scope.CopyTo(sm.Scope())
+			for _, name := range slices.Sorted(maps.Keys(sw.metrics)) {
+				mw := sw.metrics[name]
+				switch mw.kind {
+				case kindGauge:
+					for _, agg := range aggregations {
+						dps := mw.appendTo(sm.Metrics(), "_"+agg).SetEmptyGauge().DataPoints()
+						for _, pw := range mw.points {
+							dp := pw.appendTo(dps, w.start, end)
+							dp.SetDoubleValue(pw.aggregate(agg))
+						}
+					}
+					series += len(mw.points) * len(aggregations)
+				case kindCumulative:
+					sum := mw.appendTo(sm.Metrics(), "").SetEmptySum()
+					sum.SetIsMonotonic(true)
+					sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+					for _, pw := range mw.points {
+						dp := pw.appendTo(sum.DataPoints(), pw.start, pw.lastTime)
+						dp.SetDoubleValue(pw.last)
+					}
+					series += len(mw.points)
+				case kindDelta:
+					sum := mw.appendTo(sm.Metrics(), "").SetEmptySum()
+					sum.SetIsMonotonic(true)
+					sum.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+					for _, pw := range mw.points {
+						dp := pw.appendTo(sum.DataPoints(), w.start, end)
+						dp.SetDoubleValue(pw.sum)
+					}
+					series += len(mw.points)
+				}
+			}
+		}
+	}
+	return md, series
+}
+
+func (mw *metricWindow) appendTo(metrics pmetric.MetricSlice, suffix string) pmetric.Metric {
+	m := metrics.AppendEmpty()
+	m.SetName(mw.name + suffix)
+	m.SetDescription(mw.description)
+	m.SetUnit(mw.unit)
+	return m
+}
+
+func (pw *pointWindow) appendTo(points pmetric.NumberDataPointSlice, start, ts pcommon.Timestamp) pmetric.NumberDataPoint {
+	dp := points.AppendEmpty()
+	pw.attributes.CopyTo(dp.Attributes())
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	return dp
+}
+
+func (pw *pointWindow) aggregate(agg string) float64 {
+	switch agg {
+	case AggregationMin:
+		return pw.min
+	case AggregationMax:
+		return pw.max
+	default:
+		return pw.sum / float64(pw.count)
+	}
+}
+
+// attributesKey returns a key identifying the attribute set m.
+func attributesKey(m pcommon.Map) string {
+	keys := make([]string, 0, m.Len())
+	for k := range m.All() {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	var b strings.Builder
+	for _, k := range keys {
+		v, _ := m.Get(k)
+		b.WriteString(k)
+		b.WriteByte('=')
+		b.WriteString(v.AsString())
+		b.WriteByte(0)
+	}
+	return b.String()
+}
@@ -0,0 +1,134 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tforetentionexporter
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/bytesize"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+)
+
+// Config defines the configuration for the TFO retention exporter.
+type Config struct {
+	// Directory holds the ring buffer segment files.
+	Directory string `mapstructure:"directory"`
+
+	// MaxSize bounds the total size of the segment files, e.g. "512MiB" or
+	// a number of bytes. The oldest segment is removed when the bound is
+	// exceeded.
+	// Default: 512MiB
+	MaxSize bytesize.Size `mapstructure:"max_size"`
+
+	// SegmentSize is the size at which a new segment file is started.
+	// Default: 16MiB
+	SegmentSize bytesize.Size `mapstructure:"segment_size"`
+
+	// MaxSizeMiB overrides MaxSize in MiB when positive.
+	//
+	// Deprecated: use MaxSize.
+	MaxSizeMiB int64 `mapstructure:"max_size_mib"`
+
+	// SegmentSizeMiB overrides SegmentSize in MiB when positive.
+	//
+	// Deprecated: use SegmentSize.
+	SegmentSizeMiB int64 `mapstructure:"segment_size_mib"`
+
+	// MaxAge removes segments last written longer ago than this. Zero
+	// disables age-based removal.
+	// Default: 0
+	MaxAge time.Duration `mapstructure:"max_age"`
+
+	// Query configures the local query API.
+	Query QueryConfig `mapstructure:"query"`
+}
+
+// QueryConfig defines the local query API settings.
+type QueryConfig struct {
+	// Enabled starts the query API.
+	// Default: false
+	Enabled bool `mapstructure:"enabled"`
+
+	// AdminConfig defines the query API listener: endpoint, tls, auth, acl
+	// and the other shared server settings. The API exposes retained
+	// telemetry, so an endpoint off localhost requires auth or mTLS.
+	// Default endpoint: localhost:55691
+	serverconf.AdminConfig `mapstructure:",squash"`
+
+	// MaxItems bounds the spans, data points or log records returned by
+	// one query.
+	// Default: 1000
+	MaxItems int `mapstructure:"max_items"`
+}
+
+// Validate checks the configuration for errors.
+func (cfg *Config) Validate() error {
+	if cfg.Directory == "" {
+		return errors.New("directory is required")
+	}
+	if cfg.MaxSizeMiB < 0 || cfg.SegmentSizeMiB < 0 {
+		return errors.New("max_size_mib and segment_size_mib must not be negative")
+	}
+	if cfg.MaxSizeBytes() <= 0 {
+		return errors.New("max_size must be positive")
+	}
+	if cfg.segmentSize() <= 0 {
+		return errors.New("segment_size must be positive")
+	}
+	if cfg.segmentSize() > cfg.MaxSizeBytes() {
+		return errors.New("segment_size must not exceed max_size")
+	}
+	if cfg.MaxAge < 0 {
+		return errors.New("max_age must not be negative")
+	}
+	return cfg.Query.Validate()
+}
+
+// MaxSizeBytes returns the ring size in bytes, honouring the deprecated
+// max_size_mib.
+func (cfg *Config) MaxSizeBytes() int64 {
+	if cfg.MaxSizeMiB > 0 {
+		return cfg.MaxSizeMiB * int64(bytesize.MiB)
+	}
+	return cfg.MaxSize.Bytes()
+}
+
+// segmentSize returns the segment size in bytes, honouring the deprecated
+// segment_size_mib.
+func (cfg *Config) segmentSize() int64 {
+	if cfg.SegmentSizeMiB > 0 {
+		return cfg.SegmentSizeMiB * int64(bytesize.MiB)
+	}
+	return cfg.SegmentSize.Bytes()
+}
+
+// Validate checks the query configuration for errors.
+func (cfg *QueryConfig) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.NetAddr.Endpoint == "" {
+		return errors.New("query: endpoint is required")
+	}
+	if cfg.MaxItems <= 0 {
+		return errors.New("query: max_items must be positive")
+	}
+	if err := cfg.AdminConfig.Validate(); err != nil {
+		return fmt.Errorf("query: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+// Package tforetentionexporter keeps recent telemetry in a bounded on-disk
+// ring buffer and serves it through a local query API.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Sites that lose WAN connectivity for hours still need local visibility.
+// Add the exporter next to the regular exporters of a pipeline: batches are
+// appended to segment files under directory and the oldest segments are
+// removed once max_size or max_age is exceeded. Export to the backend is
+// unaffected and continues through the persistent queue. Sizes take a unit,
+// e.g. "512MiB" or "1GB"; the older max_size_mib and segment_size_mib
+// settings are still honoured and take precedence when set.
+//
+// With query enabled, the exporter serves on its endpoint:
+//
+//	GET /status   segment count, size and time range of the retained data
+//	GET /query    retained telemetry as OTLP JSON
+//
+// /query takes the parameters:
+//
+//	signal  traces, metrics or logs (required)
+//	start   RFC 3339 time or a duration before now, e.g. 15m
+//	end     RFC 3339 time or a duration before now (default: now)
+//	limit   maximum spans, data points or log records (default: max_items)
+//
+// Any other parameter filters on an attribute of the resource or of the
+// span, data point or log record, e.g. service.name=checkout. Spans are
+// matched by start time, log records by timestamp and data points by
+// timestamp. A truncated result carries the X-Tfo-Truncated header.
+//
+// The query listener takes the shared admin server settings of
+// pkg/serverconf (tls, auth, acl, network, response_headers and the
+// timeouts). An endpoint whose host is not a loopback address is refused
+// unless clients authenticate through auth or a client certificate verified
+// against tls.client_ca_file.
+//
+// Configuration example:
+//
+//	exporters:
+//	  tforetention:
+//	    directory: /var/lib/tfo-collector/retention
+//	    max_size: 512MiB
+//	    segment_size: 16MiB
+//	    max_age: 24h
+//	    query:
+//	      enabled: true
+//	      endpoint: localhost:55691
+//
+// Query example:
+//
+//	curl 'localhost:55691/query?signal=logs&start=30m&service.name=checkout'
+package tforetentionexporter // import "github.com/telemetryflow/telemetryflow-collector/components/tforetentionexporter"
@@ -0,0 +1,282 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tforetentionexporter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/bytesize"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+)
+
+// retentionExporter appends batches to the ring and serves the query API.
+// One instance is shared by the traces, metrics and logs exporters of a
+// component ID.
+type retentionExporter struct {
+	id     component.ID
+	cfg    *Config
+	logger *zap.Logger
+
+	// refs counts started signal exporters; the ring is opened by the first
+	// and closed by the last.
+	mu     sync.Mutex
+	refs   int
+	ring   *ring
+	server *serverconf.AdminServer
+}
+
+var (
+	exportersMu sync.Mutex
+	exporters   = make(map[component.ID]*retentionExporter)
+)
+
+// getOrCreateExporter returns the exporter shared by all signals of id.
+func getOrCreateExporter(id component.ID, cfg *Config, logger *zap.Logger) *retentionExporter {
+	exportersMu.Lock()
+	defer exportersMu.Unlock()
+
+	if e, ok := exporters[id]; ok {
+		return e
+	}
+	e := &retentionExporter{id: id, cfg: cfg, logger: logger}
+	exporters[id] = e
+	return e
+}
+
+// start opens the ring and starts the query API on first use.
+func (e *retentionExporter) start(ctx context.Context, host component.Host) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	e.refs++
+	if e.refs > 1 {
+		return nil
+	}
+
+	r, err := openRing(e.cfg.Directory, e.cfg.MaxSizeBytes(), e.cfg.segmentSize(), e.cfg.MaxAge)
+	if err != nil {
+		e.refs--
+		return err
+	}
+	e.ring = r
+
+	if e.cfg.Query.Enabled {
+		if err := e.startQuery(ctx, host); err != nil {
+			_ = r.close()
+			e.ring = nil
+			e.refs--
+			return err
+		}
+	}
+	e.logger.Info("Local retention enabled",
+		zap.String("directory", e.cfg.Directory),
+		zap.Stringer("max_size", bytesize.Size(e.cfg.MaxSizeBytes())),
+		zap.Duration("max_age", e.cfg.MaxAge),
+	)
+	return nil
+}
+
+// startQuery starts the query API.
+func (e *retentionExporter) startQuery(ctx context.Context, host component.Host) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc(queryPath, e.handleQuery)
+	mux.HandleFunc(statusPath, e.handleStatus)
+
+	server, err := e.cfg.Query.StartAdmin(ctx, host, "Retention query API", mux, e.logger)
+	if err != nil {
+		return fmt.Errorf("retention query API: %w", err)
+	}
+	e.server = server
+	return nil
+}
+
+// shutdown stops the query API and closes the ring after the last signal
+// exporter is shut down. The instance is then released so that a reloaded
+// configuration creates a new one.
+func (e *retentionExporter) shutdown(ctx context.Context) error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	if e.refs == 0 {
+		return nil
+	}
+	e.refs--
+	if e.refs > 0 {
+		return nil
+	}
+
+	errs := []error{e.server.Shutdown(ctx), e.ring.close()}
+	e.server = nil
+	e.ring = nil
+
+	exportersMu.Lock()
+	if exporters[e.id] == e {
+		delete(exporters, e.id)
+	}
+	exportersMu.Unlock()
+	return errors.Join(errs...)
+}
+
+// write appends a batch to the ring. Batches that can never fit are
+// rejected as permanent errors.
+func (e *retentionExporter) write(sig signal, minTs, maxTs int64, payload []byte) error {
+	e.mu.Lock()
+	r := e.ring
+	e.mu.Unlock()
+	if r == nil {
+		return errors.New("retention exporter is not started")
+	}
+
+	err := r.append(sig, minTs, maxTs, payload)
+	if errors.Is(err, errRecordTooLarge) {
+		return consumererror.NewPermanent(err)
+	}
+	return err
+}
+
+// timeRange tracks the smallest and largest timestamp of a batch.
+type timeRange struct {
+	lo, hi int64
+	empty  bool
+}
+
+// newTimeRange returns an empty time range.
+func newTimeRange() timeRange {
+	return timeRange{empty: true}
+}
+
+// add widens the range to cover ts.
+func (t *timeRange) add(ts int64) {
+	if t.empty || ts < t.lo {
+		t.lo = ts
+	}
+	if t.empty || ts > t.hi {
+		t.hi = ts
+	}
+	t.empty = false
+}
+
+// pushTraces appends td to the ring.
+func (e *retentionExporter) pushTraces(_ context.Context, td ptrace.Traces) error {
+	tr := newTimeRange()
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		sss := rss.At(i).ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			spans := sss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				tr.add(int64(spans.At(k).StartTimestamp()))
+			}
+		}
+	}
+	if tr.empty {
+		return nil
+	}
+
+	payload, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(td)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	return e.write(signalTraces, tr.lo, tr.hi, payload)
+}
+
+// pushLogs appends ld to the ring.
+func (e *retentionExporter) pushLogs(_ context.Context, ld plog.Logs) error {
+	tr := newTimeRange()
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		sls := rls.At(i).ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			lrs := sls.At(j).LogRecords()
+			for k := 0; k < lrs.Len(); k++ {
+				ts := lrs.At(k).Timestamp()
+				if ts == 0 {
+					ts = lrs.At(k).ObservedTimestamp()
+				}
+				tr.add(int64(ts))
+			}
+		}
+	}
+	if tr.empty {
+		return nil
+	}
+
+	payload, err := (&plog.ProtoMarshaler{}).MarshalLogs(ld)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	return e.write(signalLogs, tr.lo, tr.hi, payload)
+}
+
+// pushMetrics appends md to the ring.
+func (e *retentionExporter) pushMetrics(_ context.Context, md pmetric.Metrics) error {
+	tr := newTimeRange()
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				metricTimestamps(ms.At(k), tr.add)
+			}
+		}
+	}
+	if tr.empty {
+		return nil
+	}
+
+	payload, err := (&pmetric.ProtoMarshaler{}).MarshalMetrics(md)
+	if err != nil {
+		return consumererror.NewPermanent(err)
+	}
+	return e.write(signalMetrics, tr.lo, tr.hi, payload)
+}
+
+// metricTimestamps calls fn with the timestamp of every data point of m.
+func metricTimestamps(m pmetric.Metric, fn func(int64)) {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		for _, dp := range m.Gauge().DataPoints().All() {
+			fn(int64(dp.Timestamp()))
+		}
+	case pmetric.MetricTypeSum:
+		for _, dp := range m.Sum().DataPoints().All() {
+			fn(int64(dp.Timestamp()))
+		}
+	case pmetric.MetricTypeHistogram:
+		for _, dp := range m.Histogram().DataPoints().All() {
+			fn(int64(dp.Timestamp()))
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		for _, dp := range m.ExponentialHistogram().DataPoints().All() {
+			fn(int64(dp.Timestamp()))
+		}
+	case pmetric.MetricTypeSummary:
+		for _, dp := range m.Summary().DataPoints().All() {
+			fn(int64(dp.Timestamp()))
+		}
+	}
+}
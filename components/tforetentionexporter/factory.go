@@ -0,0 +1,117 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tforetentionexporter
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/bytesize"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+)
+
+const (
+	// TypeStr is the type string identifier for the TFO retention exporter.
+	TypeStr = "tforetention"
+
+	// DefaultQueryEndpoint is the default query API endpoint.
+	DefaultQueryEndpoint = "localhost:55691"
+
+	// Defaults
+	defaultMaxSize       = 512 * bytesize.MiB
+	defaultSegmentSize   = 16 * bytesize.MiB
+	defaultQueryMaxItems = 1000
+)
+
+// NewFactory creates a new factory for the TFO retention exporter.
+func NewFactory() exporter.Factory {
+	return exporter.NewFactory(
+		component.MustNewType(TypeStr),
+		createDefaultConfig,
+		exporter.WithTraces(createTracesExporter, component.StabilityLevelAlpha),
+		exporter.WithMetrics(createMetricsExporter, component.StabilityLevelAlpha),
+		exporter.WithLogs(createLogsExporter, component.StabilityLevelAlpha),
+	)
+}
+
+// createDefaultConfig creates the default configuration for the exporter.
+func createDefaultConfig() component.Config {
+	return &Config{
+		MaxSize:     defaultMaxSize,
+		SegmentSize: defaultSegmentSize,
+		Query: QueryConfig{
+			AdminConfig: serverconf.NewDefaultAdminConfig(DefaultQueryEndpoint),
+			MaxItems:    defaultQueryMaxItems,
+		},
+	}
+}
+
+// createTracesExporter creates a traces exporter.
+func createTracesExporter(
+	ctx context.Context,
+	set exporter.Settings,
+	cfg component.Config,
+) (exporter.Traces, error) {
+	exp := getOrCreateExporter(set.ID, cfg.(*Config), set.Logger)
+	return exporterhelper.NewTraces(
+		ctx,
+		set,
+		cfg,
+		exp.pushTraces,
+		exporterhelper.WithStart(exp.start),
+		exporterhelper.WithShutdown(exp.shutdown),
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+	)
+}
+
+// createMetricsExporter creates a metrics exporter.
+func createMetricsExporter(
+	ctx context.Context,
+	set exporter.Settings,
+	cfg component.Config,
+) (exporter.Metrics, error) {
+	exp := getOrCreateExporter(set.ID, cfg.(*Config), set.Logger)
+	return exporterhelper.NewMetrics(
+		ctx,
+		set,
+		cfg,
+		exp.pushMetrics,
+		exporterhelper.WithStart(exp.start),
+		exporterhelper.WithShutdown(exp.shutdown),
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+	)
+}
+
+// createLogsExporter creates a logs exporter.
+func createLogsExporter(
+	ctx context.Context,
+	set exporter.Settings,
+	cfg component.Config,
+) (exporter.Logs, error) {
+	exp := getOrCreateExporter(set.ID, cfg.(*Config), set.Logger)
+	return exporterhelper.NewLogs(
+		ctx,
+		set,
+		cfg,
+		exp.pushLogs,
+		exporterhelper.WithStart(exp.start),
+		exporterhelper.WithShutdown(exp.shutdown),
+		exporterhelper.WithCapabilities(consumer.Capabilities{MutatesData: false}),
+	)
+}
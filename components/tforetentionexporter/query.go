@@ -0,0 +1,304 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tforetentionexporter
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+)
+
+const (
+	queryPath  = "/query"
+	statusPath = "/status"
+
+	// truncatedHeader is set on query responses that hit the item limit.
+	truncatedHeader = "X-Tfo-Truncated"
+)
+
+// reservedParams are query parameters that are not attribute filters.
+var reservedParams = map[string]bool{"signal": true, "start": true, "end": true, "limit": true}
+
+// query is a parsed /query request.
+type query struct {
+	signal  signal
+	start   int64
+	end     int64
+	limit   int
+	filters map[string]string
+
+	matched   int
+	truncated bool
+}
+
+// parseQuery parses the /query parameters.
+func parseQuery(values url.Values, maxItems int, now time.Time) (*query, error) {
+	q := &query{start: 0, end: math.MaxInt64, limit: maxItems, filters: make(map[string]string)}
+
+	switch values.Get("signal") {
+	case "traces":
+		q.signal = signalTraces
+	case "metrics":
+		q.signal = signalMetrics
+	case "logs":
+		q.signal = signalLogs
+	default:
+		return nil, fmt.Errorf("signal must be traces, metrics or logs, got %q", values.Get("signal"))
+	}
+
+	var err error
+	if v := values.Get("start"); v != "" {
+		if q.start, err = parseTime(v, now); err != nil {
+			return nil, fmt.Errorf("start: %w", err)
+		}
+	}
+	if v := values.Get("end"); v != "" {
+		if q.end, err = parseTime(v, now); err != nil {
+			return nil, fmt.Errorf("end: %w", err)
+		}
+	}
+	if q.start > q.end {
+		return nil, errors.New("start must not be after end")
+	}
+	if v := values.Get("limit"); v != "" {
+		limit, err := strconv.Atoi(v)
+		if err != nil || limit <= 0 {
+			return nil, fmt.Errorf("limit must be a positive integer, got %q", v)
+		}
+		q.limit = min(limit, maxItems)
+	}
+
+	for key, vals := range values {
+		if !reservedParams[key] && len(vals) > 0 {
+			q.filters[key] = vals[0]
+		}
+	}
+	return q, nil
+}
+
+// parseTime parses an RFC 3339 time or a duration before now into Unix
+// nanoseconds.
+func parseTime(v string, now time.Time) (int64, error) {
+	if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+		return t.UnixNano(), nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("expected RFC 3339 time or duration, got %q", v)
+	}
+	return now.Add(-d).UnixNano(), nil
+}
+
+// keep reports whether an item with timestamp ts and attributes attrs under
+// a resource with attributes res is part of the result, counting it against
+// the limit.
+func (q *query) keep(ts pcommon.Timestamp, res, attrs pcommon.Map) bool {
+	if int64(ts) < q.start || int64(ts) > q.end {
+		return false
+	}
+	for k, want := range q.filters {
+		v, ok := attrs.Get(k)
+		if !ok {
+			v, ok = res.Get(k)
+		}
+		if !ok || v.AsString() != want {
+			return false
+		}
+	}
+	if q.matched >= q.limit {
+		q.truncated = true
+		return false
+	}
+	q.matched++
+	return true
+}
+
+// filterTraces removes the spans of td not matched by q.
+func (q *query) filterTraces(td ptrace.Traces) {
+	td.ResourceSpans().RemoveIf(func(rs ptrace.ResourceSpans) bool {
+		res := rs.Resource().Attributes()
+		rs.ScopeSpans().RemoveIf(func(ss ptrace.ScopeSpans) bool {
+			ss.Spans().RemoveIf(func(span ptrace.Span) bool {
+				return !q.keep(span.StartTimestamp(), res, span.Attributes())
+			})
+			return ss.Spans().Len() == 0
+		})
+		return rs.ScopeSpans().Len() == 0
+	})
+}
+
+// filterLogs removes the log records of ld not matched by q.
+func (q *query) filterLogs(ld plog.Logs) {
+	ld.ResourceLogs().RemoveIf(func(rl plog.ResourceLogs) bool {
+		res := rl.Resource().Attributes()
+		rl.ScopeLogs().RemoveIf(func(sl plog.ScopeLogs) bool {
+			sl.LogRecords().RemoveIf(func(lr plog.LogRecord) bool {
+				ts := lr.Timestamp()
+				if ts == 0 {
+					ts = lr.ObservedTimestamp()
+				}
+				return !q.keep(ts, res, lr.Attributes())
+			})
+			return sl.LogRecords().Len() == 0
+		})
+		return rl.ScopeLogs().Len() == 0
+	})
+}
+
+// dataPoint is the part of a metric data point used for filtering.
+type dataPoint interface {
+	Timestamp() pcommon.Timestamp
+	Attributes() pcommon.Map
+}
+
+// filterDataPoints removes the data points not matched by q through
+// removeIf, the RemoveIf method of a data point slice, and returns the
+// number left.
+func filterDataPoints[T dataPoint](q *query, res pcommon.Map, removeIf func(func(T) bool), length func() int) int {
+	removeIf(func(dp T) bool {
+		return !q.keep(dp.Timestamp(), res, dp.Attributes())
+	})
+	return length()
+}
+
+// filterMetrics removes the data points of md not matched by q.
+func (q *query) filterMetrics(md pmetric.Metrics) {
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		res := rm.Resource().Attributes()
+		rm.ScopeMetrics().RemoveIf(func(sm pmetric.ScopeMetrics) bool {
+			sm.Metrics().RemoveIf(func(m pmetric.Metric) bool {
+				switch m.Type() {
+				case pmetric.MetricTypeGauge:
+					dps := m.Gauge().DataPoints()
+					return filterDataPoints(q, res, dps.RemoveIf, dps.Len) == 0
+				case pmetric.MetricTypeSum:
+					dps := m.Sum().DataPoints()
+					return filterDataPoints(q, res, dps.RemoveIf, dps.Len) == 0
+				case pmetric.MetricTypeHistogram:
+					dps := m.Histogram().DataPoints()
+					return filterDataPoints(q, res, dps.RemoveIf, dps.Len) == 0
+				case pmetric.MetricTypeExponentialHistogram:
+					dps := m.ExponentialHistogram().DataPoints()
+					return filterDataPoints(q, res, dps.RemoveIf, dps.Len) == 0
+				case pmetric.MetricTypeSummary:
+					dps := m.Summary().DataPoints()
+					return filterDataPoints(q, res, dps.RemoveIf, dps.Len) == 0
+				}
+				return true
+			})
+			return sm.Metrics().Len() == 0
+		})
+		return rm.ScopeMetrics().Len() == 0
+	})
+}
+
+// run scans the ring and returns the matching telemetry as OTLP JSON.
+func (q *query) run(r *ring) ([]byte, error) {
+	var (
+		traces  = ptrace.NewTraces()
+		metrics = pmetric.NewMetrics()
+		logs    = plog.NewLogs()
+		decErr  error
+	)
+
+	err := r.scan(q.signal, q.start, q.end, func(payload []byte) bool {
+		switch q.signal {
+		case signalTraces:
+			td, err := (&ptrace.ProtoUnmarshaler{}).UnmarshalTraces(payload)
+			if err != nil {
+				decErr = err
+				return false
+			}
+			q.filterTraces(td)
+			td.ResourceSpans().MoveAndAppendTo(traces.ResourceSpans())
+		case signalMetrics:
+			md, err := (&pmetric.ProtoUnmarshaler{}).UnmarshalMetrics(payload)
+			if err != nil {
+				decErr = err
+				return false
+			}
+			q.filterMetrics(md)
+			md.ResourceMetrics().MoveAndAppendTo(metrics.ResourceMetrics())
+		case signalLogs:
+			ld, err := (&plog.ProtoUnmarshaler{}).UnmarshalLogs(payload)
+			if err != nil {
+				decErr = err
+				return false
+			}
+			q.filterLogs(ld)
+			ld.ResourceLogs().MoveAndAppendTo(logs.ResourceLogs())
+		}
+		return !q.truncated
+	})
+	if err == nil {
+		err = decErr
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	switch q.signal {
+	case signalTraces:
+		return (&ptrace.JSONMarshaler{}).MarshalTraces(traces)
+	case signalMetrics:
+		return (&pmetric.JSONMarshaler{}).MarshalMetrics(metrics)
+	default:
+		return (&plog.JSONMarshaler{}).MarshalLogs(logs)
+	}
+}
+
+// handleQuery serves GET /query.
+func (e *retentionExporter) handleQuery(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		serverconf.WriteJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+		return
+	}
+	q, err := parseQuery(req.URL.Query(), e.cfg.Query.MaxItems, time.Now())
+	if err != nil {
+		serverconf.WriteJSON(w, http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return
+	}
+
+	body, err := q.run(e.ring)
+	if err != nil {
+		serverconf.WriteJSON(w, http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	if q.truncated {
+		w.Header().Set(truncatedHeader, "true")
+	}
+	_, _ = w.Write(body)
+}
+
+// handleStatus serves GET /status.
+func (e *retentionExporter) handleStatus(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodGet {
+		serverconf.WriteJSON(w, http.StatusMethodNotAllowed, map[string]string{"error": "method not allowed"})
+		return
+	}
+	serverconf.WriteJSON(w, http.StatusOK, e.ring.stats())
+}
@@ -0,0 +1,374 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tforetentionexporter
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// signal identifies the telemetry type of a record.
+type signal byte
+
+const (
+	signalTraces signal = iota + 1
+	signalMetrics
+	signalLogs
+)
+
+// String returns the query parameter value of s.
+func (s signal) String() string {
+	switch s {
+	case signalTraces:
+		return "traces"
+	case signalMetrics:
+		return "metrics"
+	case signalLogs:
+		return "logs"
+	}
+	return "unknown"
+}
+
+const (
+	segmentExt = ".seg"
+
+	// headerSize is the record header: signal (1), min and max timestamp in
+	// Unix nanoseconds (8 + 8), payload length (4) and payload CRC-32 (4),
+	// little endian.
+	headerSize = 25
+)
+
+var errRecordTooLarge = errors.New("batch exceeds max_size")
+
+// segment is one ring buffer file.
+type segment struct {
+	seq       uint64
+	path      string
+	size      int64
+	minTs     int64
+	maxTs     int64
+	lastWrite time.Time
+}
+
+// overlaps reports whether the segment may hold records in [start, end].
+func (s segment) overlaps(start, end int64) bool {
+	return s.size > 0 && s.maxTs >= start && s.minTs <= end
+}
+
+// ring is a bounded sequence of append-only segment files. Records are never
+// rewritten; space is reclaimed by removing whole segments, oldest first.
+type ring struct {
+	dir          string
+	maxBytes     int64
+	segmentBytes int64
+	maxAge       time.Duration
+
+	mu       sync.Mutex
+	segments []*segment
+	active   *os.File
+	total    int64
+}
+
+// openRing opens the ring in dir, recovering existing segments. Writing
+// always continues in a new segment so that a torn record at the end of
+// the previous one is never appended to.
+func openRing(dir string, maxBytes, segmentBytes int64, maxAge time.Duration) (*ring, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create retention directory: %w", err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read retention directory: %w", err)
+	}
+
+	r := &ring{dir: dir, maxBytes: maxBytes, segmentBytes: segmentBytes, maxAge: maxAge}
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, segmentExt) {
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, segmentExt), 10, 64)
+		if err != nil {
+			continue
+		}
+		seg, err := recoverSegment(filepath.Join(dir, name), seq)
+		if err != nil {
+			return nil, err
+		}
+		r.segments = append(r.segments, seg)
+		r.total += seg.size
+	}
+	slices.SortFunc(r.segments, func(a, b *segment) int {
+		switch {
+		case a.seq < b.seq:
+			return -1
+		case a.seq > b.seq:
+			return 1
+		}
+		return 0
+	})
+
+	if err := r.rotate(); err != nil {
+		return nil, err
+	}
+	r.enforce(time.Now())
+	return r, nil
+}
+
+// recoverSegment scans an existing segment for its valid size and time range.
+func recoverSegment(path string, seq uint64) (*segment, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open segment: %w", err)
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return nil, fmt.Errorf("stat segment: %w", err)
+	}
+	seg := &segment{seq: seq, path: path, lastWrite: info.ModTime()}
+	readRecords(f, info.Size(), func(h recordHeader, _ []byte) bool {
+		seg.include(h.minTs, h.maxTs)
+		seg.size += headerSize + int64(h.length)
+		return true
+	})
+	return seg, nil
+}
+
+// include widens the segment time range to cover [minTs, maxTs].
+func (s *segment) include(minTs, maxTs int64) {
+	if s.size == 0 || minTs < s.minTs {
+		s.minTs = minTs
+	}
+	if s.size == 0 || maxTs > s.maxTs {
+		s.maxTs = maxTs
+	}
+}
+
+// rotate closes the active segment and starts a new one. Callers hold mu
+// or have exclusive access.
+func (r *ring) rotate() error {
+	if r.active != nil {
+		if err := r.active.Close(); err != nil {
+			return fmt.Errorf("close segment: %w", err)
+		}
+		r.active = nil
+	}
+
+	var seq uint64 = 1
+	if n := len(r.segments); n > 0 {
+		seq = r.segments[n-1].seq + 1
+	}
+	path := filepath.Join(r.dir, fmt.Sprintf("%020d%s", seq, segmentExt))
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("create segment: %w", err)
+	}
+	r.active = f
+	r.segments = append(r.segments, &segment{seq: seq, path: path, lastWrite: time.Now()})
+	return nil
+}
+
+// append writes one record. The record is written with a single write so
+// that concurrent readers never observe a partial record within size.
+func (r *ring) append(sig signal, minTs, maxTs int64, payload []byte) error {
+	recLen := int64(headerSize + len(payload))
+	if recLen > r.maxBytes {
+		return errRecordTooLarge
+	}
+
+	buf := make([]byte, recLen)
+	buf[0] = byte(sig)
+	binary.LittleEndian.PutUint64(buf[1:], uint64(minTs))
+	binary.LittleEndian.PutUint64(buf[9:], uint64(maxTs))
+	binary.LittleEndian.PutUint32(buf[17:], uint32(len(payload)))
+	binary.LittleEndian.PutUint32(buf[21:], crc32.ChecksumIEEE(payload))
+	copy(buf[headerSize:], payload)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.active == nil {
+		return errors.New("retention ring is closed")
+	}
+	seg := r.segments[len(r.segments)-1]
+	if seg.size > 0 && seg.size+recLen > r.segmentBytes {
+		if err := r.rotate(); err != nil {
+			return err
+		}
+		seg = r.segments[len(r.segments)-1]
+	}
+
+	if _, err := r.active.Write(buf); err != nil {
+		return fmt.Errorf("write segment: %w", err)
+	}
+	seg.include(minTs, maxTs)
+	seg.size += recLen
+	seg.lastWrite = time.Now()
+	r.total += recLen
+
+	r.enforce(seg.lastWrite)
+	return nil
+}
+
+// enforce removes the oldest segments while the ring exceeds its size or
+// age bound. The active segment is never removed. Callers hold mu or have
+// exclusive access.
+func (r *ring) enforce(now time.Time) {
+	for len(r.segments) > 1 {
+		oldest := r.segments[0]
+		expired := r.maxAge > 0 && now.Sub(oldest.lastWrite) > r.maxAge
+		if r.total <= r.maxBytes && !expired {
+			return
+		}
+		if err := os.Remove(oldest.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return
+		}
+		r.total -= oldest.size
+		r.segments = r.segments[1:]
+	}
+}
+
+// close closes the active segment.
+func (r *ring) close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.active == nil {
+		return nil
+	}
+	err := r.active.Close()
+	r.active = nil
+	return err
+}
+
+// ringStats summarises the retained data.
+type ringStats struct {
+	Segments int       `json:"segments"`
+	Bytes    int64     `json:"bytes"`
+	MaxBytes int64     `json:"max_bytes"`
+	Oldest   time.Time `json:"oldest,omitzero"`
+	Newest   time.Time `json:"newest,omitzero"`
+}
+
+// stats returns a summary of the retained data.
+func (r *ring) stats() ringStats {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	st := ringStats{Segments: len(r.segments), Bytes: r.total, MaxBytes: r.maxBytes}
+	for _, seg := range r.segments {
+		if seg.size == 0 {
+			continue
+		}
+		if oldest := time.Unix(0, seg.minTs); st.Oldest.IsZero() || oldest.Before(st.Oldest) {
+			st.Oldest = oldest.UTC()
+		}
+		if newest := time.Unix(0, seg.maxTs); newest.After(st.Newest) {
+			st.Newest = newest.UTC()
+		}
+	}
+	return st
+}
+
+// scan calls fn with the payload of every record of sig that may hold data
+// in [start, end], oldest first, until fn returns false.
+func (r *ring) scan(sig signal, start, end int64, fn func(payload []byte) bool) error {
+	r.mu.Lock()
+	segments := make([]segment, 0, len(r.segments))
+	for _, seg := range r.segments {
+		if seg.overlaps(start, end) {
+			segments = append(segments, *seg)
+		}
+	}
+	r.mu.Unlock()
+
+	for _, seg := range segments {
+		f, err := os.Open(seg.path)
+		if errors.Is(err, fs.ErrNotExist) {
+			// Removed by enforce since the snapshot was taken.
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("open segment: %w", err)
+		}
+
+		more := true
+		readRecords(f, seg.size, func(h recordHeader, payload []byte) bool {
+			if h.signal != sig || h.maxTs < start || h.minTs > end {
+				return true
+			}
+			more = fn(payload)
+			return more
+		})
+		_ = f.Close()
+		if !more {
+			return nil
+		}
+	}
+	return nil
+}
+
+// recordHeader is a decoded record header.
+type recordHeader struct {
+	signal signal
+	minTs  int64
+	maxTs  int64
+	length uint32
+	crc    uint32
+}
+
+// readRecords reads records from rd up to size bytes and calls fn for each
+// until fn returns false. Reading stops at a truncated or corrupt record,
+// which is where an interrupted write left off.
+func readRecords(rd io.Reader, size int64, fn func(recordHeader, []byte) bool) {
+	br := bufio.NewReaderSize(io.LimitReader(rd, size), 64*1024)
+	var hdr [headerSize]byte
+	for {
+		if _, err := io.ReadFull(br, hdr[:]); err != nil {
+			return
+		}
+		h := recordHeader{
+			signal: signal(hdr[0]),
+			minTs:  int64(binary.LittleEndian.Uint64(hdr[1:])),
+			maxTs:  int64(binary.LittleEndian.Uint64(hdr[9:])),
+			length: binary.LittleEndian.Uint32(hdr[17:]),
+			crc:    binary.LittleEndian.Uint32(hdr[21:]),
+		}
+		if h.signal < signalTraces || h.signal > signalLogs || int64(h.length) > size {
+			return
+		}
+
+		payload := make([]byte, h.length)
+		if _, err := io.ReadFull(br, payload); err != nil {
+			return
+		}
+		if crc32.ChecksumIEEE(payload) != h.crc || !fn(h, payload) {
+			return
+		}
+	}
+}
@@ -0,0 +1,33 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// The processor runs after the samplers of a traces pipeline fed by a
+// tfoarchive connector. It records the trace ID of every span that passed
+// the samplers, so that the connector archives only the traces that were
+// sampled out. Spans are passed on unchanged.
+//
+// Configuration example:
+//
+//	processors:
+//	  tfosampled:
+//	    archive: tfoarchive
+//
+//	service:
+//	  pipelines:
+//	    traces/sampled:
+//	      receivers: [tfoarchive]
+//	      processors: [tail_sampling, tfosampled, batch]
+//	      exporters: [tfo]
+package tfosampledprocessor // import "github.com/telemetryflow/telemetryflow-collector/components/tfosampledprocessor"
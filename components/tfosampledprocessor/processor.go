@@ -0,0 +1,56 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfosampledprocessor
+
+import (
+	"context"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/sampled"
+)
+
+// sampledProcessor records the traces kept by the samplers before it.
+type sampledProcessor struct {
+	decisions *sampled.Decisions
+}
+
+// newSampledProcessor creates the processor state for cfg.
+func newSampledProcessor(cfg *Config) *sampledProcessor {
+	return &sampledProcessor{decisions: sampled.For(cfg.Archive.String())}
+}
+
+// processTraces records the trace of every span as kept.
+func (p *sampledProcessor) processTraces(_ context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	now := time.Now()
+	var last pcommon.TraceID
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		sss := rss.At(i).ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			spans := sss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				// tail_sampling releases the spans of a trace together.
+				if id := spans.At(k).TraceID(); id != last {
+					p.decisions.Keep(id, now)
+					last = id
+				}
+			}
+		}
+	}
+	return td, nil
+}
@@ -0,0 +1,78 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfospannameprocessor
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// Config defines the configuration for the TFO span name processor.
+type Config struct {
+	// Rules rename the spans whose name matches a pattern. The first
+	// matching rule applies.
+	Rules []Rule `mapstructure:"rules"`
+
+	// CollapseIDs replaces the identifier segments of the path in span
+	// names no rule matched with Placeholder.
+	// Default: true
+	CollapseIDs bool `mapstructure:"collapse_ids"`
+
+	// Placeholder replaces collapsed path segments.
+	// Default: {id}
+	Placeholder string `mapstructure:"placeholder"`
+
+	// OriginalNameAttribute is the span attribute that keeps the name of
+	// renamed spans. Empty keeps no copy.
+	OriginalNameAttribute string `mapstructure:"original_name_attribute"`
+}
+
+// Rule renames the spans whose name matches Pattern.
+type Rule struct {
+	// Pattern is a regular expression matched against the whole span name.
+	Pattern string `mapstructure:"pattern"`
+
+	// Template is the new span name. $1 or ${name} expand the submatches
+	// of Pattern.
+	Template string `mapstructure:"template"`
+}
+
+// Validate checks the configuration for errors.
+func (cfg *Config) Validate() error {
+	if len(cfg.Rules) == 0 && !cfg.CollapseIDs {
+		return errors.New("rules are required when collapse_ids is disabled")
+	}
+	for i, r := range cfg.Rules {
+		if r.Pattern == "" {
+			return fmt.Errorf("rules[%d]: pattern is required", i)
+		}
+		if _, err := compilePattern(r.Pattern); err != nil {
+			return fmt.Errorf("rules[%d]: invalid pattern: %w", i, err)
+		}
+		if r.Template == "" {
+			return fmt.Errorf("rules[%d]: template is required", i)
+		}
+	}
+	if cfg.CollapseIDs && cfg.Placeholder == "" {
+		return errors.New("placeholder is required when collapse_ids is enabled")
+	}
+	return nil
+}
+
+// compilePattern compiles pattern anchored to the whole span name.
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile(`^(?:` + pattern + `)$`)
+}
@@ -0,0 +1,53 @@
+// Package tfospannameprocessor rewrites span names containing identifiers,
+// such as "GET /users/123", into low-cardinality templates.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// HTTP instrumentation without access to the route template names spans
+// after the request path, so every user, order or session gets its own span
+// name. The processor renames spans in two steps:
+//   - rules: the first rule whose pattern matches the whole span name
+//     replaces it with its template, in which $1 or ${name} expand the
+//     submatches of the pattern
+//   - collapse_ids: when no rule matched, path segments that look like
+//     identifiers are replaced with the placeholder and the query string is
+//     removed; a segment is an identifier when it is all digits, a UUID, or
+//     at least 16 letters and digits including a digit (hex IDs, ULIDs)
+//
+// Only the path after the first "/" is collapsed, so names without a path,
+// such as "SELECT orders", are left alone. Connectors receiving the spans
+// from the pipeline, such as span_metrics, see the new names, so the
+// span.name dimension of the derived metrics stays low-cardinality too.
+//
+// Renamed spans are counted by tfo_spanname_spans_renamed.
+//
+// Configuration example:
+//
+//	processors:
+//	  tfospanname:
+//	    rules:
+//	      - pattern: '(GET|POST) /api/v1/tenants/[^/]+/(.*)'
+//	        template: '$1 /api/v1/tenants/{tenant}/$2'
+//	    collapse_ids: true
+//	    placeholder: "{id}"
+//	    original_name_attribute: span.original_name
+//
+//	service:
+//	  pipelines:
+//	    traces:
+//	      processors: [memory_limiter, tfospanname, batch]
+//	      exporters: [tfo, span_metrics]
+package tfospannameprocessor // import "github.com/telemetryflow/telemetryflow-collector/components/tfospannameprocessor"
@@ -0,0 +1,217 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfospannameprocessor
+
+import (
+	"context"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/requestid"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+const scopeName = "github.com/telemetryflow/telemetryflow-collector/components/tfospannameprocessor"
+
+// minTokenLen is the length from which a segment of letters and digits is
+// taken for an identifier.
+const minTokenLen = 16
+
+// spanNameProcessor renames spans into low-cardinality templates.
+type spanNameProcessor struct {
+	cfg      *Config
+	logger   *zap.Logger
+	patterns []*regexp.Regexp
+
+	renamed       metric.Int64Counter
+	ruleAttrs     metric.MeasurementOption
+	collapseAttrs metric.MeasurementOption
+}
+
+// newSpanNameProcessor creates the processor state for cfg. Renamed spans
+// are counted under labels.
+func newSpanNameProcessor(cfg *Config, set component.TelemetrySettings, labels selfmetrics.Labels) (*spanNameProcessor, error) {
+	p := &spanNameProcessor{
+		cfg:           cfg,
+		logger:        set.Logger,
+		ruleAttrs:     labels.Option(attribute.String("reason", "rule")),
+		collapseAttrs: labels.Option(attribute.String("reason", "collapse")),
+	}
+	for _, r := range cfg.Rules {
+		re, err := compilePattern(r.Pattern)
+		if err != nil {
+			return nil, err
+		}
+		p.patterns = append(p.patterns, re)
+	}
+
+	if set.MeterProvider != nil {
+		var err error
+		p.renamed, err = set.MeterProvider.Meter(scopeName).Int64Counter(selfmetrics.SpanNameSpansRenamed,
+			metric.WithDescription("Number of spans renamed by the span name processor."),
+			metric.WithUnit("{span}"))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// processTraces renames the spans of td.
+func (p *spanNameProcessor) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	var byRule, byCollapse int
+	rss := td.ResourceSpans()
+	for i := 0; i < rss.Len(); i++ {
+		sss := rss.At(i).ScopeSpans()
+		for j := 0; j < sss.Len(); j++ {
+			spans := sss.At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				name, byRuleMatch := p.rename(span.Name())
+				if name == span.Name() {
+					continue
+				}
+				if p.cfg.OriginalNameAttribute != "" {
+					span.Attributes().PutStr(p.cfg.OriginalNameAttribute, span.Name())
+				}
+				span.SetName(name)
+				if byRuleMatch {
+					byRule++
+				} else {
+					byCollapse++
+				}
+			}
+		}
+	}
+
+	if byRule+byCollapse == 0 {
+		return td, nil
+	}
+	if p.renamed != nil {
+		if byRule > 0 {
+			p.renamed.Add(ctx, int64(byRule), p.ruleAttrs)
+		}
+		if byCollapse > 0 {
+			p.renamed.Add(ctx, int64(byCollapse), p.collapseAttrs)
+		}
+	}
+	p.logger.Debug("Renamed spans",
+		zap.Int("by_rule", byRule),
+		zap.Int("by_collapse", byCollapse),
+		requestid.Field(ctx),
+	)
+	return td, nil
+}
+
+// rename returns the new name of a span named name and whether a rule
+// matched it.
+func (p *spanNameProcessor) rename(name string) (string, bool) {
+	for i, re := range p.patterns {
+		if m := re.FindStringSubmatchIndex(name); m != nil {
+			return string(re.ExpandString(nil, p.cfg.Rules[i].Template, name, m)), true
+		}
+	}
+	if p.cfg.CollapseIDs {
+		return collapseIDs(name, p.cfg.Placeholder), false
+	}
+	return name, false
+}
+
+// collapseIDs replaces the identifier segments of the path in name with
+// placeholder and removes the query string and fragment. The path starts
+// at the first "/"; names without one are returned unchanged.
+func collapseIDs(name, placeholder string) string {
+	start := strings.IndexByte(name, '/')
+	if start < 0 {
+		return name
+	}
+	end := len(name)
+	if i := strings.IndexAny(name[start:], "?#"); i >= 0 {
+		end = start + i
+	}
+
+	var b strings.Builder
+	last := 0 // end of the part of name already written to b
+	for seg := start + 1; seg <= end; {
+		n := strings.IndexByte(name[seg:end], '/')
+		if n < 0 {
+			n = end - seg
+		}
+		if isID(name[seg : seg+n]) {
+			if last == 0 {
+				b.Grow(end)
+			}
+			b.WriteString(name[last:seg])
+			b.WriteString(placeholder)
+			last = seg + n
+		}
+		seg += n + 1
+	}
+	if last == 0 {
+		return name[:end]
+	}
+	b.WriteString(name[last:end])
+	return b.String()
+}
+
+// isID reports whether a path segment looks like an identifier: all digits,
+// a UUID, or at least minTokenLen letters and digits including a digit.
+func isID(seg string) bool {
+	if seg == "" {
+		return false
+	}
+	if isUUID(seg) {
+		return true
+	}
+	digits, letters := 0, 0
+	for i := 0; i < len(seg); i++ {
+		switch c := seg[i]; {
+		case c >= '0' && c <= '9':
+			digits++
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z':
+			letters++
+		default:
+			return false
+		}
+	}
+	return letters == 0 || (digits > 0 && len(seg) >= minTokenLen)
+}
+
+// isUUID reports whether s is a UUID in its 36-character text form.
+func isUUID(s string) bool {
+	if len(s) != 36 {
+		return false
+	}
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch i {
+		case 8, 13, 18, 23:
+			if c != '-' {
+				return false
+			}
+		default:
+			if !(c >= '0' && c <= '9' || c >= 'a' && c <= 'f' || c >= 'A' && c <= 'F') {
+				return false
+			}
+		}
+	}
+	return true
+}
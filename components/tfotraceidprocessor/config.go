@@ -0,0 +1,55 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfotraceidprocessor
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Config defines the configuration for the TFO trace ID processor.
+type Config struct {
+	// Attributes are the correlation attributes, in order of preference.
+	// Each is looked up on the log record, then on its resource.
+	// Default: [request_id]
+	Attributes []string `mapstructure:"attributes"`
+
+	// SpanAttribute is the log record attribute that splits the records of
+	// a trace into spans. Empty puts all records of a trace in one span.
+	SpanAttribute string `mapstructure:"span_attribute"`
+
+	// Override replaces the trace context of records that already have
+	// one.
+	// Default: false
+	Override bool `mapstructure:"override"`
+
+	// MarkerAttribute is the boolean log record attribute set on records
+	// given synthesized IDs. Empty sets no attribute.
+	// Default: tfo.trace.synthetic
+	MarkerAttribute string `mapstructure:"marker_attribute"`
+}
+
+// Validate checks the configuration for errors.
+func (cfg *Config) Validate() error {
+	if len(cfg.Attributes) == 0 {
+		return errors.New("at least one correlation attribute is required")
+	}
+	for i, a := range cfg.Attributes {
+		if a == "" {
+			return fmt.Errorf("attributes[%d]: empty attribute name", i)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+// Package tfotraceidprocessor gives log records without trace context
+// deterministic trace and span IDs derived from a correlation attribute.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Services that log a request ID but are not traced produce log streams the
+// backend cannot group. For each log record without a trace ID, the
+// processor takes the first of the correlation attributes present on the
+// record or, failing that, its resource, and derives:
+//   - the trace ID from the first 16 bytes of SHA-256(value)
+//   - the span ID from the first 8 bytes of SHA-256(value + "\x00" + span
+//     value) when span_attribute is set and present, otherwise from bytes
+//     16 to 24 of SHA-256(value), so all records of a request share a span
+//
+// The IDs depend on the value only, so every collector, and any other tool
+// using the same derivation, assigns the same IDs to a request, and the
+// records of a request ID logged by several services land in one trace.
+// Records that already carry a trace ID keep it unless override is set.
+// The marker attribute flags records with synthesized IDs, so the backend
+// can tell them from traced records.
+//
+// Records given IDs are counted by tfo_traceid_logs_assigned.
+//
+// Configuration example:
+//
+//	processors:
+//	  tfotraceid:
+//	    attributes: [request_id, http.request.header.x-request-id]
+//	    span_attribute: step
+//	    override: false
+//	    marker_attribute: tfo.trace.synthetic
+//
+//	service:
+//	  pipelines:
+//	    logs:
+//	      processors: [memory_limiter, tfotraceid, batch]
+package tfotraceidprocessor // import "github.com/telemetryflow/telemetryflow-collector/components/tfotraceidprocessor"
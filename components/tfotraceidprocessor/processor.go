@@ -0,0 +1,147 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfotraceidprocessor
+
+import (
+	"context"
+	"crypto/sha256"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/requestid"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+const scopeName = "github.com/telemetryflow/telemetryflow-collector/components/tfotraceidprocessor"
+
+// traceIDProcessor assigns derived trace and span IDs to log records.
+type traceIDProcessor struct {
+	cfg    *Config
+	logger *zap.Logger
+
+	assigned metric.Int64Counter
+	attrs    metric.MeasurementOption
+}
+
+// newTraceIDProcessor creates the processor state for cfg. Records given
+// IDs are counted under labels.
+func newTraceIDProcessor(cfg *Config, set component.TelemetrySettings, labels selfmetrics.Labels) (*traceIDProcessor, error) {
+	p := &traceIDProcessor{
+		cfg:    cfg,
+		logger: set.Logger,
+		attrs:  labels.Option(),
+	}
+	if set.MeterProvider != nil {
+		var err error
+		p.assigned, err = set.MeterProvider.Meter(scopeName).Int64Counter(selfmetrics.TraceIDLogsAssigned,
+			metric.WithDescription("Number of log records given trace IDs by the trace ID processor."),
+			metric.WithUnit("{record}"))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// processLogs assigns IDs to the records of ld without trace context.
+func (p *traceIDProcessor) processLogs(ctx context.Context, ld plog.Logs) (plog.Logs, error) {
+	var assigned int
+	rls := ld.ResourceLogs()
+	for i := 0; i < rls.Len(); i++ {
+		rl := rls.At(i)
+		resource := rl.Resource().Attributes()
+		sls := rl.ScopeLogs()
+		for j := 0; j < sls.Len(); j++ {
+			records := sls.At(j).LogRecords()
+			for k := 0; k < records.Len(); k++ {
+				if p.assign(resource, records.At(k)) {
+					assigned++
+				}
+			}
+		}
+	}
+
+	if assigned == 0 {
+		return ld, nil
+	}
+	if p.assigned != nil {
+		p.assigned.Add(ctx, int64(assigned), p.attrs)
+	}
+	p.logger.Debug("Assigned trace IDs to log records", zap.Int("records", assigned), requestid.Field(ctx))
+	return ld, nil
+}
+
+// assign gives record the IDs derived from its correlation value. It
+// reports false when the record keeps its trace context or has no
+// correlation value.
+func (p *traceIDProcessor) assign(resource pcommon.Map, record plog.LogRecord) bool {
+	if !p.cfg.Override && !record.TraceID().IsEmpty() {
+		return false
+	}
+	value := p.correlationValue(resource, record.Attributes())
+	if value == "" {
+		return false
+	}
+	var spanValue string
+	if p.cfg.SpanAttribute != "" {
+		if v, ok := record.Attributes().Get(p.cfg.SpanAttribute); ok {
+			spanValue = v.AsString()
+		}
+	}
+	traceID, spanID := DeriveIDs(value, spanValue)
+	record.SetTraceID(traceID)
+	record.SetSpanID(spanID)
+	if p.cfg.MarkerAttribute != "" {
+		record.Attributes().PutBool(p.cfg.MarkerAttribute, true)
+	}
+	return true
+}
+
+// correlationValue returns the value of the first correlation attribute
+// present on the record or its resource, or "".
+func (p *traceIDProcessor) correlationValue(resource, attrs pcommon.Map) string {
+	for _, key := range p.cfg.Attributes {
+		v, ok := attrs.Get(key)
+		if !ok {
+			v, ok = resource.Get(key)
+		}
+		if ok {
+			if s := v.AsString(); s != "" {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+// DeriveIDs returns the trace and span IDs of the correlation value and, if
+// not empty, the span value, as described in the package documentation.
+func DeriveIDs(value, spanValue string) (pcommon.TraceID, pcommon.SpanID) {
+	sum := sha256.Sum256([]byte(value))
+	var traceID pcommon.TraceID
+	var spanID pcommon.SpanID
+	copy(traceID[:], sum[:16])
+	if spanValue == "" {
+		copy(spanID[:], sum[16:24])
+	} else {
+		spanSum := sha256.Sum256([]byte(value + "\x00" + spanValue))
+		copy(spanID[:], spanSum[:8])
+	}
+	return traceID, spanID
+}
@@ -1,6 +1,6 @@
 module github.com/telemetryflow/telemetryflow-collector
 
-go 1.26
+go 1.26.0
 
 toolchain go1.26.3
 
@@ -32,6 +32,7 @@ require (
 	github.com/open-telemetry/opentelemetry-collector-contrib/extension/bearertokenauthextension v0.152.0 // Bearer token auth
 	github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckextension v0.152.0 // Health check endpoint
 	github.com/open-telemetry/opentelemetry-collector-contrib/extension/pprofextension v0.152.0 // pprof profiling
+	github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage v0.152.0 // File storage for checkpoints and queues
 
 	// -------------------------------------------------------------------------
 	// OpenTelemetry Collector Contrib - Processors
@@ -40,6 +41,7 @@ require (
 	github.com/open-telemetry/opentelemetry-collector-contrib/processor/attributesprocessor v0.152.0 // Modify attributes
 	github.com/open-telemetry/opentelemetry-collector-contrib/processor/filterprocessor v0.152.0 // Filter telemetry
 	github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sattributesprocessor v0.152.0 // Kubernetes metadata enrichment
+	github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor v0.152.0 // Mask sensitive data (PII)
 	github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor v0.152.0 // Auto-detect resources
 	github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourceprocessor v0.152.0 // Modify resources
 	github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor v0.152.0 // Tail-based sampling
@@ -58,6 +60,8 @@ require (
 	// -------------------------------------------------------------------------
 	// CLI & Utilities
 	// -------------------------------------------------------------------------
+	github.com/pelletier/go-toml/v2 v2.2.4 // TOML config files
+	github.com/prometheus/prometheus v0.311.4-0.20260507094802-91c184a899b8 // Prometheus service discovery (docker_sd)
 	github.com/spf13/cobra v1.10.2 // CLI framework
 	github.com/spf13/viper v1.21.0 // Configuration management
 
@@ -70,9 +74,57 @@ require (
 	// TFO Custom Components
 	// -------------------------------------------------------------------------
 	github.com/telemetryflow/telemetryflow-collector/components/extension/tfoauthextension v0.0.0-20260514091132-0f3b5ec5588b // TFO auth extension
+	github.com/telemetryflow/telemetryflow-collector/components/extension/tfoclockextension v0.0.0 // TFO clock extension
+	github.com/telemetryflow/telemetryflow-collector/components/extension/tfoencryptionextension v0.0.0 // TFO encryption extension
 	github.com/telemetryflow/telemetryflow-collector/components/extension/tfoidentityextension v0.0.0-20260514091132-0f3b5ec5588b // TFO identity extension
+	github.com/telemetryflow/telemetryflow-collector/components/extension/tfomaintenanceextension v0.0.0 // TFO maintenance extension
+	github.com/telemetryflow/telemetryflow-collector/components/extension/tfoopampextension v0.0.0 // TFO OpAMP remote management extension
+	github.com/telemetryflow/telemetryflow-collector/components/extension/tfooverridesextension v0.0.0 // TFO runtime overrides extension
+	github.com/telemetryflow/telemetryflow-collector/components/extension/tfoparquetextension v0.0.0 // TFO Parquet extension
+	github.com/telemetryflow/telemetryflow-collector/components/extension/tfoqueuequotaextension v0.0.0 // TFO per-tenant queue quota extension
+	github.com/telemetryflow/telemetryflow-collector/components/extension/tfosupportextension v0.0.0 // TFO support bundle extension
+	github.com/telemetryflow/telemetryflow-collector/components/tfoalertconnector v0.0.0 // TFO alert connector
+	github.com/telemetryflow/telemetryflow-collector/components/tfoarchiveconnector v0.0.0 // TFO archive connector
+	github.com/telemetryflow/telemetryflow-collector/components/tfocaptureexporter v0.0.0 // Test capture exporter
+	github.com/telemetryflow/telemetryflow-collector/components/tfocardinalityprocessor v0.0.0 // TFO cardinality processor
+	github.com/telemetryflow/telemetryflow-collector/components/tfocoapreceiver v0.0.0 // TFO CoAP receiver
+	github.com/telemetryflow/telemetryflow-collector/components/tfodedupprocessor v0.0.0 // TFO dedup processor
+	github.com/telemetryflow/telemetryflow-collector/components/tfoexemptprocessor v0.0.0 // TFO exempt processor
+	github.com/telemetryflow/telemetryflow-collector/components/tfoexperimentexporter v0.0.0 // TFO experiment exporter
 	github.com/telemetryflow/telemetryflow-collector/components/tfoexporter v0.0.0-20260514091132-0f3b5ec5588b // TFO exporter
+	github.com/telemetryflow/telemetryflow-collector/components/tfofleetreceiver v0.0.0 // TFO fleet receiver
+	github.com/telemetryflow/telemetryflow-collector/components/tfokafkareceiver v0.0.0 // TFO Kafka receiver
+	github.com/telemetryflow/telemetryflow-collector/components/tfomirrorconnector v0.0.0 // TFO mirror connector
+	github.com/telemetryflow/telemetryflow-collector/components/tfomqttreceiver v0.0.0 // TFO MQTT receiver
 	github.com/telemetryflow/telemetryflow-collector/components/tfootlpreceiver v0.0.0-20260514091132-0f3b5ec5588b // TFO OTLP receiver
+	github.com/telemetryflow/telemetryflow-collector/components/tfoprometheusexporter v0.0.0 // TFO Prometheus exporter
+	github.com/telemetryflow/telemetryflow-collector/components/tforetentionexporter v0.0.0 // TFO retention exporter
+	github.com/telemetryflow/telemetryflow-collector/components/tfosampledprocessor v0.0.0 // TFO sampled processor
+	github.com/telemetryflow/telemetryflow-collector/components/tfospannameprocessor v0.0.0 // TFO span name processor
+	github.com/telemetryflow/telemetryflow-collector/components/tfotraceidprocessor v0.0.0 // TFO trace ID processor
+
+	// -------------------------------------------------------------------------
+	// TFO Shared Packages
+	// -------------------------------------------------------------------------
+	github.com/telemetryflow/telemetryflow-collector/pkg/adaptive v0.0.0 // Adaptive send concurrency
+	github.com/telemetryflow/telemetryflow-collector/pkg/attrfilter v0.0.0 // Exporter attribute filtering
+	github.com/telemetryflow/telemetryflow-collector/pkg/bytesize v0.0.0 // Human-friendly byte sizes
+	github.com/telemetryflow/telemetryflow-collector/pkg/clientconf v0.0.0 // Shared exporter client settings
+	github.com/telemetryflow/telemetryflow-collector/pkg/deadletter v0.0.0 // Dead letter spool of failed exports
+	github.com/telemetryflow/telemetryflow-collector/pkg/errlog v0.0.0 // Aggregated error logging
+	github.com/telemetryflow/telemetryflow-collector/pkg/errorbudget v0.0.0 // Shared exporter error budget
+	github.com/telemetryflow/telemetryflow-collector/pkg/experiment v0.0.0 // A/B experiment observations
+	github.com/telemetryflow/telemetryflow-collector/pkg/loglevel v0.0.0 // Runtime log level override
+	github.com/telemetryflow/telemetryflow-collector/pkg/payloadpreview v0.0.0 // Exporter payload previews
+	github.com/telemetryflow/telemetryflow-collector/pkg/provenance v0.0.0 // Multi-hop provenance envelope
+	github.com/telemetryflow/telemetryflow-collector/pkg/requestid v0.0.0 // Request ID propagation
+	github.com/telemetryflow/telemetryflow-collector/pkg/residency v0.0.0 // Data residency policy
+	github.com/telemetryflow/telemetryflow-collector/pkg/retrybudget v0.0.0 // Shared retry budget
+	github.com/telemetryflow/telemetryflow-collector/pkg/sampled v0.0.0 // Shared sampling decisions
+	github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics v0.0.0 // Internal metrics registry
+	github.com/telemetryflow/telemetryflow-collector/pkg/serverconf v0.0.0 // Shared listener hardening settings
+	github.com/telemetryflow/telemetryflow-collector/pkg/supportbundle v0.0.0 // Support bundle archives
+	github.com/telemetryflow/telemetryflow-collector/pkg/watchdog v0.0.0 // Component watchdog
 
 	// -------------------------------------------------------------------------
 	// OpenTelemetry Collector Core
@@ -83,7 +135,6 @@ require (
 	go.opentelemetry.io/collector/config/configopaque v1.58.0 // Opaque config
 	go.opentelemetry.io/collector/confmap v1.58.0 // Configuration mapping
 	go.opentelemetry.io/collector/confmap/provider/envprovider v1.58.0 // Env config provider
-	go.opentelemetry.io/collector/confmap/provider/fileprovider v1.58.0 // File config provider
 	go.opentelemetry.io/collector/confmap/provider/yamlprovider v1.58.0 // YAML config provider
 	go.opentelemetry.io/collector/connector v0.152.1 // Connector interfaces
 	go.opentelemetry.io/collector/connector/forwardconnector v0.152.1 // Forward connector
@@ -240,7 +291,7 @@ require (
 	github.com/googleapis/gax-go/v2 v2.22.0 // indirect
 	github.com/gophercloud/gophercloud/v2 v2.11.1 // indirect
 	github.com/gorilla/mux v1.8.1 // indirect
-	github.com/gorilla/websocket v1.5.4-0.20250319132907-e064f32e3674 // indirect
+	github.com/gorilla/websocket v1.5.4-0.20250319132907-e064f32e3674
 	github.com/grafana/regexp v0.0.0-20250905093917-f7b3be9d1853 // indirect
 	github.com/grpc-ecosystem/grpc-gateway/v2 v2.28.0 // indirect
 	github.com/hashicorp/consul/api v1.32.1 // indirect
@@ -267,7 +318,7 @@ require (
 	github.com/jpillora/backoff v1.0.0 // indirect
 	github.com/json-iterator/go v1.1.12 // indirect
 	github.com/julienschmidt/httprouter v1.3.0 // indirect
-	github.com/klauspost/compress v1.18.6 // indirect
+	github.com/klauspost/compress v1.20.0
 	github.com/klauspost/cpuid/v2 v2.3.0 // indirect
 	github.com/knadh/koanf/maps v0.1.2 // indirect
 	github.com/knadh/koanf/providers/confmap v1.0.0 // indirect
@@ -334,8 +385,7 @@ require (
 	github.com/pb33f/jsonpath v0.8.2 // indirect
 	github.com/pb33f/libopenapi v0.34.4 // indirect
 	github.com/pb33f/ordered-map/v2 v2.3.1 // indirect
-	github.com/pelletier/go-toml/v2 v2.2.4 // indirect
-	github.com/pierrec/lz4/v4 v4.1.26 // indirect
+	github.com/pierrec/lz4/v4 v4.1.30 // indirect
 	github.com/pkg/browser v0.0.0-20240102092130-5ac0b6a4141c // indirect
 	github.com/planetscale/vtprotobuf v0.6.1-0.20240319094008-0393e58bdf10 // indirect
 	github.com/pmezard/go-difflib v1.0.1-0.20181226105442-5d4384ee4fb2 // indirect
@@ -344,12 +394,11 @@ require (
 	github.com/prometheus/client_golang v1.23.2 // indirect
 	github.com/prometheus/client_golang/exp v0.0.0-20260325093428-d8591d0db856 // indirect
 	github.com/prometheus/client_model v0.6.2 // indirect
-	github.com/prometheus/common v0.67.5 // indirect
+	github.com/prometheus/common v0.67.5
 	github.com/prometheus/common/assets v0.2.0 // indirect
 	github.com/prometheus/exporter-toolkit v0.16.0 // indirect
 	github.com/prometheus/otlptranslator v1.0.0 // indirect
 	github.com/prometheus/procfs v0.20.1 // indirect
-	github.com/prometheus/prometheus v0.311.4-0.20260507094802-91c184a899b8 // indirect
 	github.com/prometheus/sigv4 v0.4.1 // indirect
 	github.com/puzpuzpuz/xsync/v4 v4.5.0 // indirect
 	github.com/rs/cors v1.11.1 // indirect
@@ -383,28 +432,28 @@ require (
 	go.opentelemetry.io/auto/sdk v1.2.1 // indirect
 	go.opentelemetry.io/collector v0.152.1 // indirect
 	go.opentelemetry.io/collector/client v1.58.0 // indirect
-	go.opentelemetry.io/collector/component/componentstatus v0.152.1 // indirect
-	go.opentelemetry.io/collector/config/configauth v1.58.0 // indirect
-	go.opentelemetry.io/collector/config/configcompression v1.58.0 // indirect
-	go.opentelemetry.io/collector/config/configgrpc v0.152.1 // indirect
-	go.opentelemetry.io/collector/config/confighttp v0.152.1 // indirect
+	go.opentelemetry.io/collector/component/componentstatus v0.152.1
+	go.opentelemetry.io/collector/config/configauth v1.58.0
+	go.opentelemetry.io/collector/config/configcompression v1.58.0
+	go.opentelemetry.io/collector/config/configgrpc v0.152.1
+	go.opentelemetry.io/collector/config/confighttp v0.152.1
 	go.opentelemetry.io/collector/config/configmiddleware v1.58.0 // indirect
-	go.opentelemetry.io/collector/config/confignet v1.58.0 // indirect
-	go.opentelemetry.io/collector/config/configoptional v1.58.0 // indirect
+	go.opentelemetry.io/collector/config/confignet v1.58.0
+	go.opentelemetry.io/collector/config/configoptional v1.58.0
 	go.opentelemetry.io/collector/config/configretry v1.58.0 // indirect
 	go.opentelemetry.io/collector/config/configtelemetry v0.152.1 // indirect
-	go.opentelemetry.io/collector/config/configtls v1.58.0 // indirect
-	go.opentelemetry.io/collector/confmap/xconfmap v0.152.1 // indirect
-	go.opentelemetry.io/collector/connector/connectortest v0.152.1 // indirect
+	go.opentelemetry.io/collector/config/configtls v1.58.0
+	go.opentelemetry.io/collector/confmap/xconfmap v0.152.1
+	go.opentelemetry.io/collector/connector/connectortest v0.152.1
 	go.opentelemetry.io/collector/connector/xconnector v0.152.1 // indirect
-	go.opentelemetry.io/collector/consumer/consumererror v0.152.1 // indirect
+	go.opentelemetry.io/collector/consumer/consumererror v0.152.1
 	go.opentelemetry.io/collector/consumer/consumererror/xconsumererror v0.152.1 // indirect
-	go.opentelemetry.io/collector/consumer/xconsumer v0.152.1 // indirect
-	go.opentelemetry.io/collector/exporter/exporterhelper v0.152.1 // indirect
+	go.opentelemetry.io/collector/consumer/xconsumer v0.152.1
+	go.opentelemetry.io/collector/exporter/exporterhelper v0.152.1
 	go.opentelemetry.io/collector/exporter/exporterhelper/xexporterhelper v0.152.1 // indirect
-	go.opentelemetry.io/collector/exporter/xexporter v0.152.1 // indirect
-	go.opentelemetry.io/collector/extension/extensionauth v1.58.0 // indirect
-	go.opentelemetry.io/collector/extension/extensioncapabilities v0.152.1 // indirect
+	go.opentelemetry.io/collector/exporter/xexporter v0.152.1
+	go.opentelemetry.io/collector/extension/extensionauth v1.58.0
+	go.opentelemetry.io/collector/extension/extensioncapabilities v0.152.1
 	go.opentelemetry.io/collector/extension/extensionmiddleware v0.152.1 // indirect
 	go.opentelemetry.io/collector/extension/xextension v0.152.1 // indirect
 	go.opentelemetry.io/collector/featuregate v1.58.0 // indirect
@@ -414,15 +463,15 @@ require (
 	go.opentelemetry.io/collector/internal/memorylimiter v0.152.1 // indirect
 	go.opentelemetry.io/collector/internal/sharedcomponent v0.152.1 // indirect
 	go.opentelemetry.io/collector/internal/telemetry v0.152.1 // indirect
-	go.opentelemetry.io/collector/pdata/pprofile v0.152.1 // indirect
+	go.opentelemetry.io/collector/pdata/pprofile v0.152.1
 	go.opentelemetry.io/collector/pdata/testdata v0.152.1 // indirect
 	go.opentelemetry.io/collector/pdata/xpdata v0.152.1 // indirect
-	go.opentelemetry.io/collector/pipeline v1.58.0 // indirect
-	go.opentelemetry.io/collector/pipeline/xpipeline v0.152.1 // indirect
+	go.opentelemetry.io/collector/pipeline v1.58.0
+	go.opentelemetry.io/collector/pipeline/xpipeline v0.152.1
 	go.opentelemetry.io/collector/processor/processorhelper v0.152.1 // indirect
 	go.opentelemetry.io/collector/processor/processorhelper/xprocessorhelper v0.152.1 // indirect
-	go.opentelemetry.io/collector/processor/processortest v0.152.1 // indirect
-	go.opentelemetry.io/collector/processor/xprocessor v0.152.1 // indirect
+	go.opentelemetry.io/collector/processor/processortest v0.152.1
+	go.opentelemetry.io/collector/processor/xprocessor v0.152.1
 	go.opentelemetry.io/collector/receiver/receiverhelper v0.152.1 // indirect
 	go.opentelemetry.io/collector/receiver/xreceiver v0.152.1 // indirect
 	go.opentelemetry.io/collector/scraper v0.152.0 // indirect
@@ -435,7 +484,7 @@ require (
 	go.opentelemetry.io/contrib/otelconf v0.23.0 // indirect
 	go.opentelemetry.io/contrib/propagators/b3 v1.43.0 // indirect
 	go.opentelemetry.io/contrib/zpages v0.68.0 // indirect
-	go.opentelemetry.io/otel v1.43.0 // indirect
+	go.opentelemetry.io/otel v1.43.0
 	go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploggrpc v0.19.0 // indirect
 	go.opentelemetry.io/otel/exporters/otlp/otlplog/otlploghttp v0.19.0 // indirect
 	go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc v1.43.0 // indirect
@@ -448,16 +497,16 @@ require (
 	go.opentelemetry.io/otel/exporters/stdout/stdoutmetric v1.43.0 // indirect
 	go.opentelemetry.io/otel/exporters/stdout/stdouttrace v1.43.0 // indirect
 	go.opentelemetry.io/otel/log v0.19.0 // indirect
-	go.opentelemetry.io/otel/metric v1.43.0 // indirect
+	go.opentelemetry.io/otel/metric v1.43.0
 	go.opentelemetry.io/otel/sdk v1.43.0 // indirect
 	go.opentelemetry.io/otel/sdk/log v0.19.0 // indirect
-	go.opentelemetry.io/otel/sdk/metric v1.43.0 // indirect
-	go.opentelemetry.io/otel/trace v1.43.0 // indirect
+	go.opentelemetry.io/otel/sdk/metric v1.43.0
+	go.opentelemetry.io/otel/trace v1.43.0
 	go.opentelemetry.io/proto/otlp v1.10.0 // indirect
 	go.uber.org/atomic v1.11.0 // indirect
 	go.uber.org/goleak v1.3.0 // indirect
 	go.uber.org/multierr v1.11.0 // indirect
-	go.uber.org/zap v1.28.0 // indirect
+	go.uber.org/zap v1.28.0
 	go.uber.org/zap/exp v0.3.0 // indirect
 	go.yaml.in/yaml/v2 v2.4.4 // indirect
 	go.yaml.in/yaml/v3 v3.0.4 // indirect
@@ -477,14 +526,14 @@ require (
 	google.golang.org/api v0.280.0 // indirect
 	google.golang.org/genproto v0.0.0-20260319201613-d00831a3d3e7 // indirect
 	google.golang.org/genproto/googleapis/api v0.0.0-20260406210006-6f92a3bedf2d // indirect
-	google.golang.org/genproto/googleapis/rpc v0.0.0-20260511170946-3700d4141b60 // indirect
-	google.golang.org/grpc v1.81.1 // indirect
-	google.golang.org/protobuf v1.36.11 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20260511170946-3700d4141b60
+	google.golang.org/grpc v1.81.1
+	google.golang.org/protobuf v1.36.11
 	gopkg.in/evanphx/json-patch.v4 v4.13.0 // indirect
 	gopkg.in/inf.v0 v0.9.1 // indirect
 	gopkg.in/ini.v1 v1.67.1 // indirect
 	gopkg.in/yaml.v2 v2.4.0 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
+	gopkg.in/yaml.v3 v3.0.1
 	k8s.io/api v0.35.4 // indirect
 	k8s.io/apimachinery v0.35.4 // indirect
 	k8s.io/client-go v0.35.4 // indirect
@@ -497,6 +546,35 @@ require (
 	sigs.k8s.io/yaml v1.6.0 // indirect
 )
 
+require (
+	github.com/mochi-mqtt/server/v2 v2.7.9
+	github.com/open-telemetry/opamp-go v0.23.0
+	github.com/parquet-go/parquet-go v0.32.0
+	github.com/twmb/franz-go v1.22.1
+	github.com/twmb/franz-go/pkg/kfake v0.0.0-20260918054303-01f206a7e32c
+)
+
+require (
+	github.com/DataDog/datadog-agent/pkg/obfuscate v0.77.0-devel.0.20260213154712-e02b9359151a // indirect
+	github.com/DataDog/datadog-go/v5 v5.8.3 // indirect
+	github.com/DataDog/go-sqllexer v0.1.12 // indirect
+	github.com/andybalholm/brotli v1.1.1 // indirect
+	github.com/dustin/go-humanize v1.0.1 // indirect
+	github.com/eclipse/paho.golang v0.23.0 // indirect
+	github.com/eclipse/paho.mqtt.golang v1.5.1 // indirect
+	github.com/grafana/clusterurl v0.2.1 // indirect
+	github.com/michel-laterman/proxy-connect-dialer-go v0.1.0 // indirect
+	github.com/mostynb/go-grpc-compression v1.2.3 // indirect
+	github.com/outcaste-io/ristretto v0.2.3 // indirect
+	github.com/parquet-go/bitpack v1.0.0 // indirect
+	github.com/parquet-go/jsonlite v1.0.0 // indirect
+	github.com/pkg/errors v0.9.1 // indirect
+	github.com/rs/xid v1.4.0 // indirect
+	github.com/twmb/franz-go/pkg/kmsg v1.14.0 // indirect
+	github.com/twpayne/go-geom v1.6.1 // indirect
+	go.etcd.io/bbolt v1.4.3 // indirect
+)
+
 // =============================================================================
 // Replace Directives
 // =============================================================================
@@ -505,7 +583,55 @@ replace (
 	// Local TFO Components
 	// -------------------------------------------------------------------------
 	github.com/telemetryflow/telemetryflow-collector/components/extension/tfoauthextension => ./components/extension/tfoauthextension
+	github.com/telemetryflow/telemetryflow-collector/components/extension/tfoclockextension => ./components/extension/tfoclockextension
+	github.com/telemetryflow/telemetryflow-collector/components/extension/tfoencryptionextension => ./components/extension/tfoencryptionextension
 	github.com/telemetryflow/telemetryflow-collector/components/extension/tfoidentityextension => ./components/extension/tfoidentityextension
+	github.com/telemetryflow/telemetryflow-collector/components/extension/tfomaintenanceextension => ./components/extension/tfomaintenanceextension
+	github.com/telemetryflow/telemetryflow-collector/components/extension/tfoopampextension => ./components/extension/tfoopampextension
+	github.com/telemetryflow/telemetryflow-collector/components/extension/tfooverridesextension => ./components/extension/tfooverridesextension
+	github.com/telemetryflow/telemetryflow-collector/components/extension/tfoparquetextension => ./components/extension/tfoparquetextension
+	github.com/telemetryflow/telemetryflow-collector/components/extension/tfoqueuequotaextension => ./components/extension/tfoqueuequotaextension
+	github.com/telemetryflow/telemetryflow-collector/components/extension/tfosupportextension => ./components/extension/tfosupportextension
+	github.com/telemetryflow/telemetryflow-collector/components/tfoalertconnector => ./components/tfoalertconnector
+	github.com/telemetryflow/telemetryflow-collector/components/tfoarchiveconnector => ./components/tfoarchiveconnector
+	github.com/telemetryflow/telemetryflow-collector/components/tfocaptureexporter => ./components/tfocaptureexporter
+	github.com/telemetryflow/telemetryflow-collector/components/tfocardinalityprocessor => ./components/tfocardinalityprocessor
+	github.com/telemetryflow/telemetryflow-collector/components/tfocoapreceiver => ./components/tfocoapreceiver
+	github.com/telemetryflow/telemetryflow-collector/components/tfodedupprocessor => ./components/tfodedupprocessor
+	github.com/telemetryflow/telemetryflow-collector/components/tfoexemptprocessor => ./components/tfoexemptprocessor
+	github.com/telemetryflow/telemetryflow-collector/components/tfoexperimentexporter => ./components/tfoexperimentexporter
 	github.com/telemetryflow/telemetryflow-collector/components/tfoexporter => ./components/tfoexporter
+	github.com/telemetryflow/telemetryflow-collector/components/tfofleetreceiver => ./components/tfofleetreceiver
+	github.com/telemetryflow/telemetryflow-collector/components/tfokafkareceiver => ./components/tfokafkareceiver
+	github.com/telemetryflow/telemetryflow-collector/components/tfomirrorconnector => ./components/tfomirrorconnector
+	github.com/telemetryflow/telemetryflow-collector/components/tfomqttreceiver => ./components/tfomqttreceiver
 	github.com/telemetryflow/telemetryflow-collector/components/tfootlpreceiver => ./components/tfootlpreceiver
+	github.com/telemetryflow/telemetryflow-collector/components/tfoprometheusexporter => ./components/tfoprometheusexporter
+	github.com/telemetryflow/telemetryflow-collector/components/tforetentionexporter => ./components/tforetentionexporter
+	github.com/telemetryflow/telemetryflow-collector/components/tfosampledprocessor => ./components/tfosampledprocessor
+	github.com/telemetryflow/telemetryflow-collector/components/tfospannameprocessor => ./components/tfospannameprocessor
+	github.com/telemetryflow/telemetryflow-collector/components/tfotraceidprocessor => ./components/tfotraceidprocessor
+
+	// -------------------------------------------------------------------------
+	// Local TFO Shared Packages
+	// -------------------------------------------------------------------------
+	github.com/telemetryflow/telemetryflow-collector/pkg/adaptive => ./pkg/adaptive
+	github.com/telemetryflow/telemetryflow-collector/pkg/attrfilter => ./pkg/attrfilter
+	github.com/telemetryflow/telemetryflow-collector/pkg/bytesize => ./pkg/bytesize
+	github.com/telemetryflow/telemetryflow-collector/pkg/clientconf => ./pkg/clientconf
+	github.com/telemetryflow/telemetryflow-collector/pkg/deadletter => ./pkg/deadletter
+	github.com/telemetryflow/telemetryflow-collector/pkg/errlog => ./pkg/errlog
+	github.com/telemetryflow/telemetryflow-collector/pkg/errorbudget => ./pkg/errorbudget
+	github.com/telemetryflow/telemetryflow-collector/pkg/experiment => ./pkg/experiment
+	github.com/telemetryflow/telemetryflow-collector/pkg/loglevel => ./pkg/loglevel
+	github.com/telemetryflow/telemetryflow-collector/pkg/payloadpreview => ./pkg/payloadpreview
+	github.com/telemetryflow/telemetryflow-collector/pkg/provenance => ./pkg/provenance
+	github.com/telemetryflow/telemetryflow-collector/pkg/requestid => ./pkg/requestid
+	github.com/telemetryflow/telemetryflow-collector/pkg/residency => ./pkg/residency
+	github.com/telemetryflow/telemetryflow-collector/pkg/retrybudget => ./pkg/retrybudget
+	github.com/telemetryflow/telemetryflow-collector/pkg/sampled => ./pkg/sampled
+	github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics => ./pkg/selfmetrics
+	github.com/telemetryflow/telemetryflow-collector/pkg/serverconf => ./pkg/serverconf
+	github.com/telemetryflow/telemetryflow-collector/pkg/supportbundle => ./pkg/supportbundle
+	github.com/telemetryflow/telemetryflow-collector/pkg/watchdog => ./pkg/watchdog
 )
@@ -0,0 +1,90 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package adaptive
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// DefaultMinConcurrency is the default lower bound of the limit.
+	DefaultMinConcurrency = 1
+
+	// DefaultMaxConcurrency is the default upper bound of the limit.
+	DefaultMaxConcurrency = 10
+
+	// DefaultLatencyThreshold is the default latency above which a send
+	// counts as a congestion signal.
+	DefaultLatencyThreshold = 2 * time.Second
+
+	// DefaultDecreaseFactor is the default multiplicative decrease.
+	DefaultDecreaseFactor = 0.5
+)
+
+// Config defines the adaptive concurrency settings embedded by components.
+type Config struct {
+	// Enabled turns on adaptive concurrency.
+	// Default: false
+	Enabled bool `mapstructure:"enabled"`
+
+	// MinConcurrency is the lower bound and initial value of the limit.
+	// Default: 1
+	MinConcurrency int `mapstructure:"min_concurrency"`
+
+	// MaxConcurrency is the upper bound of the limit.
+	// Default: 10
+	MaxConcurrency int `mapstructure:"max_concurrency"`
+
+	// LatencyThreshold is the send latency above which the limit is
+	// decreased.
+	// Default: 2s
+	LatencyThreshold time.Duration `mapstructure:"latency_threshold"`
+
+	// DecreaseFactor multiplies the limit on a congestion signal.
+	// Default: 0.5
+	DecreaseFactor float64 `mapstructure:"decrease_factor"`
+}
+
+// NewDefaultConfig returns the default adaptive concurrency settings
+// (disabled).
+func NewDefaultConfig() Config {
+	return Config{
+		MinConcurrency:   DefaultMinConcurrency,
+		MaxConcurrency:   DefaultMaxConcurrency,
+		LatencyThreshold: DefaultLatencyThreshold,
+		DecreaseFactor:   DefaultDecreaseFactor,
+	}
+}
+
+// Validate checks the configuration for errors.
+func (cfg *Config) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.MinConcurrency <= 0 {
+		return errors.New("adaptive_concurrency.min_concurrency must be positive")
+	}
+	if cfg.MaxConcurrency < cfg.MinConcurrency {
+		return errors.New("adaptive_concurrency.max_concurrency must not be less than adaptive_concurrency.min_concurrency")
+	}
+	if cfg.LatencyThreshold <= 0 {
+		return errors.New("adaptive_concurrency.latency_threshold must be positive")
+	}
+	if cfg.DecreaseFactor <= 0 || cfg.DecreaseFactor >= 1 {
+		return errors.New("adaptive_concurrency.decrease_factor must be between 0 and 1")
+	}
+	return nil
+}
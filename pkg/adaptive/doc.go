@@ -0,0 +1,42 @@
+// Package adaptive adjusts the concurrency of a component's send path to the
+// latency and error rate observed from its backend.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// A Limiter bounds the number of in-flight sends. The limit follows an AIMD
+// (additive increase, multiplicative decrease) scheme: every send that
+// completes within latency_threshold while the limiter is well utilised
+// grows the limit by one per limit's worth of sends, and a send that is
+// throttled, fails with a congestion error or exceeds latency_threshold
+// multiplies the limit by decrease_factor. Only sends started after the
+// previous decrease can trigger another one, so a burst of slow responses
+// counts once. The limit stays within [min_concurrency, max_concurrency]
+// and is reported as tfo_adaptive_concurrency_limit.
+//
+// Configuration example:
+//
+//	exporters:
+//	  tfo:
+//	    sending_queue:
+//	      enabled: true
+//	      num_consumers: 32
+//	    adaptive_concurrency:
+//	      enabled: true
+//	      min_concurrency: 1
+//	      max_concurrency: 32
+//	      latency_threshold: 2s
+//	      decrease_factor: 0.5
+package adaptive // import "github.com/telemetryflow/telemetryflow-collector/pkg/adaptive"
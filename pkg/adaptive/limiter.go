@@ -0,0 +1,215 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package adaptive
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+const scopeName = "github.com/telemetryflow/telemetryflow-collector/pkg/adaptive"
+
+// Limiter bounds concurrent sends with an AIMD-controlled limit. A nil
+// *Limiter is valid and never blocks, so components can use it
+// unconditionally.
+type Limiter struct {
+	cfg    Config
+	name   string
+	logger *zap.Logger
+
+	mu           sync.Mutex
+	limit        float64
+	inflight     int
+	lastDecrease time.Time
+	waiters      []chan struct{}
+
+	gauge metric.Int64Gauge
+	attrs metric.MeasurementOption
+}
+
+// New creates a limiter for the send path of the labeled component. The
+// limit starts at cfg.MinConcurrency. Limit changes are logged with
+// set.Logger and recorded on set.MeterProvider.
+func New(cfg Config, set component.TelemetrySettings, labels selfmetrics.Labels) (*Limiter, error) {
+	logger := set.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	l := &Limiter{
+		cfg:    cfg,
+		name:   labels.String(),
+		logger: logger,
+		limit:  float64(cfg.MinConcurrency),
+		attrs:  labels.Option(),
+	}
+	if set.MeterProvider != nil {
+		var err error
+		l.gauge, err = set.MeterProvider.Meter(scopeName).Int64Gauge(selfmetrics.AdaptiveConcurrencyLimit,
+			metric.WithDescription("Current concurrency limit of an adaptive send path."),
+			metric.WithUnit("{request}"))
+		if err != nil {
+			return nil, err
+		}
+		l.gauge.Record(context.Background(), int64(l.limit), l.attrs)
+	}
+	return l, nil
+}
+
+// Limit returns the current concurrency limit.
+func (l *Limiter) Limit() int {
+	if l == nil {
+		return 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return int(l.limit)
+}
+
+// Acquire blocks until a send may start or ctx is done. The returned token
+// must be completed with exactly one of Success, Dropped or Ignore.
+func (l *Limiter) Acquire(ctx context.Context) (*Token, error) {
+	if l == nil {
+		return nil, nil
+	}
+
+	l.mu.Lock()
+	for l.inflight >= int(l.limit) {
+		ch := make(chan struct{})
+		l.waiters = append(l.waiters, ch)
+		l.mu.Unlock()
+
+		select {
+		case <-ch:
+		case <-ctx.Done():
+			l.mu.Lock()
+			if i := slices.Index(l.waiters, ch); i >= 0 {
+				l.waiters = slices.Delete(l.waiters, i, i+1)
+			} else {
+				// Woken concurrently: pass the slot on.
+				l.wakeLocked()
+			}
+			l.mu.Unlock()
+			return nil, ctx.Err()
+		}
+		l.mu.Lock()
+	}
+	l.inflight++
+	l.mu.Unlock()
+
+	return &Token{l: l, start: time.Now()}, nil
+}
+
+// wakeLocked wakes as many waiters as there are free slots. Woken waiters
+// re-check the limit. Callers hold mu.
+func (l *Limiter) wakeLocked() {
+	free := int(l.limit) - l.inflight
+	for free > 0 && len(l.waiters) > 0 {
+		close(l.waiters[0])
+		l.waiters = l.waiters[1:]
+		free--
+	}
+}
+
+// release ends a send started at start and adjusts the limit.
+func (l *Limiter) release(start time.Time, outcome outcome) {
+	now := time.Now()
+
+	l.mu.Lock()
+	busy := l.inflight
+	l.inflight--
+
+	before := int(l.limit)
+	if outcome == outcomeSuccess && now.Sub(start) > l.cfg.LatencyThreshold {
+		outcome = outcomeDropped
+	}
+	switch outcome {
+	case outcomeDropped:
+		if start.After(l.lastDecrease) {
+			l.limit = max(float64(l.cfg.MinConcurrency), l.limit*l.cfg.DecreaseFactor)
+			l.lastDecrease = now
+		}
+	case outcomeSuccess:
+		// Grow only while the limit is the bottleneck; an idle send path
+		// says nothing about the backend's capacity.
+		if 2*busy >= before {
+			l.limit = min(float64(l.cfg.MaxConcurrency), l.limit+1/l.limit)
+		}
+	}
+	after := int(l.limit)
+	l.wakeLocked()
+	l.mu.Unlock()
+
+	if after != before {
+		l.logger.Debug("Adaptive concurrency limit changed",
+			zap.String("component", l.name),
+			zap.Int("from", before),
+			zap.Int("to", after),
+		)
+		if l.gauge != nil {
+			l.gauge.Record(context.Background(), int64(after), l.attrs)
+		}
+	}
+}
+
+// outcome classifies a completed send.
+type outcome int
+
+const (
+	outcomeIgnore outcome = iota
+	outcomeSuccess
+	outcomeDropped
+)
+
+// Token is an acquired send slot. A nil *Token is valid and ignores all
+// calls.
+type Token struct {
+	l     *Limiter
+	start time.Time
+	done  bool
+}
+
+// Success records a send that reached the backend. It still counts as a
+// congestion signal when it took longer than the latency threshold.
+func (t *Token) Success() {
+	t.complete(outcomeSuccess)
+}
+
+// Dropped records a send that was throttled or timed out.
+func (t *Token) Dropped() {
+	t.complete(outcomeDropped)
+}
+
+// Ignore releases the slot without adjusting the limit, e.g. for requests
+// rejected as invalid.
+func (t *Token) Ignore() {
+	t.complete(outcomeIgnore)
+}
+
+// complete releases the slot once.
+func (t *Token) complete(o outcome) {
+	if t == nil || t.done {
+		return
+	}
+	t.done = true
+	t.l.release(t.start, o)
+}
@@ -0,0 +1,85 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package attrfilter
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Config defines the attribute filter of an exporter.
+type Config struct {
+	// Resource filters resource attributes.
+	Resource Rules `mapstructure:"resource"`
+
+	// Record filters the attributes of spans, span events and links, data
+	// points and log records.
+	Record Rules `mapstructure:"record"`
+}
+
+// Rules selects the attributes kept at one level. A pattern is an attribute
+// key, or a key prefix followed by "*", e.g. "http.request.header.*".
+type Rules struct {
+	// Include keeps only the attributes matching a pattern. Empty keeps
+	// every attribute.
+	Include []string `mapstructure:"include"`
+
+	// Exclude removes the attributes matching a pattern. It applies after
+	// Include.
+	Exclude []string `mapstructure:"exclude"`
+}
+
+// Validate checks the configuration for errors.
+func (cfg *Config) Validate() error {
+	if err := cfg.Resource.validate("attributes.resource"); err != nil {
+		return err
+	}
+	return cfg.Record.validate("attributes.record")
+}
+
+// Enabled reports whether the filter removes any attributes, i.e. whether
+// exporters applying it mutate the data they are handed.
+func (cfg *Config) Enabled() bool {
+	return cfg.Resource.enabled() || cfg.Record.enabled()
+}
+
+func (r *Rules) enabled() bool {
+	return len(r.Include) > 0 || len(r.Exclude) > 0
+}
+
+func (r *Rules) validate(prefix string) error {
+	for i, p := range r.Include {
+		if err := validatePattern(p); err != nil {
+			return fmt.Errorf("%s.include[%d]: %w", prefix, i, err)
+		}
+	}
+	for i, p := range r.Exclude {
+		if err := validatePattern(p); err != nil {
+			return fmt.Errorf("%s.exclude[%d]: %w", prefix, i, err)
+		}
+	}
+	return nil
+}
+
+func validatePattern(p string) error {
+	if strings.TrimSpace(p) == "" {
+		return errors.New("pattern must not be empty")
+	}
+	if i := strings.IndexByte(p, '*'); i >= 0 && i != len(p)-1 {
+		return fmt.Errorf("pattern %q may only end with *", p)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+// Package attrfilter trims the attributes an exporter sends.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Backends that bill by attribute volume do not need every attribute the
+// pipeline carries. A Filter removes resource attributes and the attributes
+// of spans, span events and links, data points and log records right before
+// an exporter encodes a batch. Include keeps only the listed attributes,
+// Exclude then removes attributes from what is left. Patterns are attribute
+// keys or key prefixes ending in "*".
+//
+// Exporters applying a filter report MutatesData, so the pipeline hands them
+// their own copy and other exporters of the same pipeline still see every
+// attribute. Removed attributes are counted in
+// tfo_exporter_attributes_removed.
+//
+// Example:
+//
+//	exporters:
+//	  tfo:
+//	    attributes:
+//	      resource:
+//	        include: [service.*, host.name, deployment.environment]
+//	      record:
+//	        exclude: [http.request.header.*, http.response.header.*]
+package attrfilter // import "github.com/telemetryflow/telemetryflow-collector/pkg/attrfilter"
@@ -0,0 +1,228 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package attrfilter
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+const scopeName = "github.com/telemetryflow/telemetryflow-collector/pkg/attrfilter"
+
+// Levels of a removed attribute.
+const (
+	levelResource = "resource"
+	levelRecord   = "record"
+)
+
+// Filter applies a Config to outgoing batches. A nil *Filter is valid and
+// keeps everything, so exporters can use it unconditionally.
+type Filter struct {
+	resource matcher
+	record   matcher
+	labels   selfmetrics.Labels
+
+	removed metric.Int64Counter
+}
+
+// NewFilter creates the filter for the exporter identified by labels.
+// Removed attributes are counted on set.MeterProvider.
+func NewFilter(cfg Config, set component.TelemetrySettings, labels selfmetrics.Labels) (*Filter, error) {
+	f := &Filter{
+		resource: newMatcher(cfg.Resource),
+		record:   newMatcher(cfg.Record),
+		labels:   labels,
+	}
+	if set.MeterProvider != nil {
+		var err error
+		f.removed, err = set.MeterProvider.Meter(scopeName).Int64Counter(selfmetrics.ExporterAttributesRemoved,
+			metric.WithDescription("Number of attributes removed by the attribute filter of the exporter."),
+			metric.WithUnit("{attribute}"))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+// ApplyTraces removes the filtered attributes of the resources, spans, span
+// events and span links. It returns the number of removed attributes.
+func (f *Filter) ApplyTraces(ctx context.Context, td ptrace.Traces) int {
+	if f == nil {
+		return 0
+	}
+	var res, rec int
+	for _, rs := range td.ResourceSpans().All() {
+		res += f.resource.apply(rs.Resource().Attributes())
+		for _, ss := range rs.ScopeSpans().All() {
+			for _, s := range ss.Spans().All() {
+				rec += f.record.apply(s.Attributes())
+				for _, ev := range s.Events().All() {
+					rec += f.record.apply(ev.Attributes())
+				}
+				for _, l := range s.Links().All() {
+					rec += f.record.apply(l.Attributes())
+				}
+			}
+		}
+	}
+	return f.count(ctx, pipeline.SignalTraces, res, rec)
+}
+
+// ApplyMetrics removes the filtered attributes of the resources and data
+// points. It returns the number of removed attributes.
+func (f *Filter) ApplyMetrics(ctx context.Context, md pmetric.Metrics) int {
+	if f == nil {
+		return 0
+	}
+	var res, rec int
+	for _, rm := range md.ResourceMetrics().All() {
+		res += f.resource.apply(rm.Resource().Attributes())
+		for _, sm := range rm.ScopeMetrics().All() {
+			for _, m := range sm.Metrics().All() {
+				rec += f.applyDataPoints(m)
+			}
+		}
+	}
+	return f.count(ctx, pipeline.SignalMetrics, res, rec)
+}
+
+// ApplyLogs removes the filtered attributes of the resources and log
+// records. It returns the number of removed attributes.
+func (f *Filter) ApplyLogs(ctx context.Context, ld plog.Logs) int {
+	if f == nil {
+		return 0
+	}
+	var res, rec int
+	for _, rl := range ld.ResourceLogs().All() {
+		res += f.resource.apply(rl.Resource().Attributes())
+		for _, sl := range rl.ScopeLogs().All() {
+			for _, lr := range sl.LogRecords().All() {
+				rec += f.record.apply(lr.Attributes())
+			}
+		}
+	}
+	return f.count(ctx, pipeline.SignalLogs, res, rec)
+}
+
+func (f *Filter) applyDataPoints(m pmetric.Metric) int {
+	n := 0
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		for _, dp := range m.Gauge().DataPoints().All() {
+			n += f.record.apply(dp.Attributes())
+		}
+	case pmetric.MetricTypeSum:
+		for _, dp := range m.Sum().DataPoints().All() {
+			n += f.record.apply(dp.Attributes())
+		}
+	case pmetric.MetricTypeHistogram:
+		for _, dp := range m.Histogram().DataPoints().All() {
+			n += f.record.apply(dp.Attributes())
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		for _, dp := range m.ExponentialHistogram().DataPoints().All() {
+			n += f.record.apply(dp.Attributes())
+		}
+	case pmetric.MetricTypeSummary:
+		for _, dp := range m.Summary().DataPoints().All() {
+			n += f.record.apply(dp.Attributes())
+		}
+	}
+	return n
+}
+
+// count records the attributes removed from one batch and returns their
+// total.
+func (f *Filter) count(ctx context.Context, signal pipeline.Signal, resource, record int) int {
+	if f.removed != nil {
+		labels := f.labels.WithSignal(signal)
+		if resource > 0 {
+			f.removed.Add(ctx, int64(resource), labels.Option(attribute.String("level", levelResource)))
+		}
+		if record > 0 {
+			f.removed.Add(ctx, int64(record), labels.Option(attribute.String("level", levelRecord)))
+		}
+	}
+	return resource + record
+}
+
+// matcher applies the Rules of one level.
+type matcher struct {
+	include patterns
+	exclude patterns
+}
+
+func newMatcher(r Rules) matcher {
+	return matcher{include: newPatterns(r.Include), exclude: newPatterns(r.Exclude)}
+}
+
+// apply removes the attributes of attrs not kept by m and returns their
+// number.
+func (m matcher) apply(attrs pcommon.Map) int {
+	if m.include.empty() && m.exclude.empty() {
+		return 0
+	}
+	before := attrs.Len()
+	attrs.RemoveIf(func(k string, _ pcommon.Value) bool {
+		return (!m.include.empty() && !m.include.match(k)) || m.exclude.match(k)
+	})
+	return before - attrs.Len()
+}
+
+// patterns is a set of exact keys and key prefixes.
+type patterns struct {
+	keys     map[string]struct{}
+	prefixes []string
+}
+
+func newPatterns(in []string) patterns {
+	p := patterns{keys: make(map[string]struct{}, len(in))}
+	for _, s := range in {
+		if prefix, ok := strings.CutSuffix(s, "*"); ok {
+			p.prefixes = append(p.prefixes, prefix)
+		} else {
+			p.keys[s] = struct{}{}
+		}
+	}
+	return p
+}
+
+func (p patterns) empty() bool {
+	return len(p.keys) == 0 && len(p.prefixes) == 0
+}
+
+func (p patterns) match(key string) bool {
+	if _, ok := p.keys[key]; ok {
+		return true
+	}
+	for _, prefix := range p.prefixes {
+		if strings.HasPrefix(key, prefix) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,373 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package authcheck
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	metricnoop "go.opentelemetry.io/otel/metric/noop"
+	tracenoop "go.opentelemetry.io/otel/trace/noop"
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/extension/tfoauthextension"
+	"github.com/telemetryflow/telemetryflow-collector/components/tfoexporter"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/presetconf"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/profileconf"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/registry"
+)
+
+// DefaultTimeout bounds each request of a check.
+const DefaultTimeout = 30 * time.Second
+
+var (
+	authType     = component.MustNewType("tfoauth")
+	exporterType = component.MustNewType(tfoexporter.TypeStr)
+)
+
+// Options configures a credential check.
+type Options struct {
+	// ConfigURIs are the configuration locations, as accepted by the
+	// collector's --config flag.
+	ConfigURIs []string
+
+	// Profile selects the configuration profile, as accepted by the
+	// collector's --profile flag.
+	Profile string
+
+	// Extension selects the tfoauth extension to check. When empty, the
+	// configuration must define exactly one.
+	Extension component.ID
+
+	// TestExport also sends an empty export request through every tfo
+	// exporter that uses the extension.
+	TestExport bool
+
+	// Timeout bounds each request. DefaultTimeout is used when zero.
+	Timeout time.Duration
+}
+
+// Step is the outcome of one request of a check.
+type Step struct {
+	// Name identifies the step, e.g. "validate" or "export tfo".
+	Name string
+
+	// Target is the URL the request was sent to.
+	Target string
+
+	// Latency is the time the request took.
+	Latency time.Duration
+
+	// Principal is the identity the platform authenticated, when reported.
+	Principal string
+
+	// Err is nil when the step passed.
+	Err error
+}
+
+// Report is the outcome of a credential check.
+type Report struct {
+	// Extension is the checked tfoauth extension.
+	Extension component.ID
+
+	// APIKeyID is the masked API key ID of the extension.
+	APIKeyID string
+
+	// Steps lists the requests made, in order.
+	Steps []Step
+}
+
+// Passed reports whether every step passed.
+func (r *Report) Passed() bool {
+	for _, s := range r.Steps {
+		if s.Err != nil {
+			return false
+		}
+	}
+	return len(r.Steps) > 0
+}
+
+// Print writes a human-readable summary of the report to w.
+func (r *Report) Print(w io.Writer) {
+	_, _ = fmt.Fprintf(w, "Credential check for extension %s (API key %s)\n", r.Extension, r.APIKeyID)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, s := range r.Steps {
+		result, detail := "PASS", ""
+		if s.Err != nil {
+			result, detail = "FAIL", s.Err.Error()
+		} else if s.Principal != "" {
+			detail = "principal: " + s.Principal
+		}
+		_, _ = fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\t%s\n", result, s.Name, s.Target, s.Latency.Round(time.Millisecond), detail)
+	}
+	_ = tw.Flush()
+	if r.Passed() {
+		_, _ = fmt.Fprintln(w, "Result: PASS")
+	} else {
+		_, _ = fmt.Fprintln(w, "Result: FAIL")
+	}
+}
+
+// Run performs the credential check described by opts. It returns an error
+// when the check cannot be run, e.g. the configuration does not load or
+// does not define the extension; failed requests are reported in the
+// Report instead.
+func Run(ctx context.Context, opts Options) (*Report, error) {
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+	conf, err := loadConfig(ctx, opts.ConfigURIs, opts.Profile)
+	if err != nil {
+		return nil, err
+	}
+
+	id, authCfg, err := findExtension(conf, opts.Extension)
+	if err != nil {
+		return nil, err
+	}
+	if authCfg.APIKeyID == "" {
+		return nil, fmt.Errorf("extension %s has no API key configured", id)
+	}
+	if authCfg.ValidationEndpoint == "" && !opts.TestExport {
+		return nil, fmt.Errorf("extension %s has no validation_endpoint; set one or use a test export", id)
+	}
+
+	report := &Report{Extension: id, APIKeyID: tfoauthextension.MaskAPIKey(string(authCfg.APIKeyID))}
+	if authCfg.ValidationEndpoint != "" {
+		report.Steps = append(report.Steps, validate(ctx, authCfg, opts.Timeout))
+	}
+	if opts.TestExport {
+		steps, err := testExports(ctx, conf, id, authCfg, opts.Timeout)
+		if err != nil {
+			return nil, err
+		}
+		report.Steps = append(report.Steps, steps...)
+	}
+	return report, nil
+}
+
+// loadConfig resolves the configuration the way the collector does, with the
+// profile and preset converters but without the runtime converter.
+func loadConfig(ctx context.Context, uris []string, profile string) (*confmap.Conf, error) {
+	if len(uris) == 0 {
+		return nil, errors.New("at least one config file must be provided")
+	}
+	set := registry.Builder{
+		ConfigURIs: uris,
+		ConverterFactories: []confmap.ConverterFactory{
+			profileconf.NewConverterFactory(profile),
+			presetconf.NewConverterFactory(),
+		},
+	}.Settings()
+	resolver, err := confmap.NewResolver(set.ConfigProviderSettings.ResolverSettings)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resolver.Shutdown(ctx) }()
+	conf, err := resolver.Resolve(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	return conf, nil
+}
+
+// components returns the IDs of the components of type typ in section, in
+// order.
+func components(conf *confmap.Conf, section string, typ component.Type) ([]component.ID, error) {
+	sub, err := conf.Sub(section)
+	if err != nil {
+		return nil, err
+	}
+	var ids []component.ID
+	for key := range sub.ToStringMap() {
+		var id component.ID
+		if err := id.UnmarshalText([]byte(key)); err != nil {
+			return nil, fmt.Errorf("%s: %w", section, err)
+		}
+		if id.Type() == typ {
+			ids = append(ids, id)
+		}
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i].String() < ids[j].String() })
+	return ids, nil
+}
+
+// unmarshal decodes the configuration of component id in section into cfg.
+func unmarshal(conf *confmap.Conf, section string, id component.ID, cfg any) error {
+	sub, err := conf.Sub(section + confmap.KeyDelimiter + id.String())
+	if err != nil {
+		return err
+	}
+	if err := sub.Unmarshal(cfg); err != nil {
+		return fmt.Errorf("%s %s: %w", section, id, err)
+	}
+	return nil
+}
+
+// findExtension returns the tfoauth extension to check.
+func findExtension(conf *confmap.Conf, want component.ID) (component.ID, *tfoauthextension.Config, error) {
+	ids, err := components(conf, "extensions", authType)
+	if err != nil {
+		return component.ID{}, nil, err
+	}
+
+	var id component.ID
+	switch {
+	case want != component.ID{}:
+		if !slices.Contains(ids, want) {
+			return component.ID{}, nil, fmt.Errorf("extension %s is not defined in the configuration", want)
+		}
+		id = want
+	case len(ids) == 1:
+		id = ids[0]
+	case len(ids) == 0:
+		return component.ID{}, nil, errors.New("the configuration defines no tfoauth extension")
+	default:
+		return component.ID{}, nil, fmt.Errorf("the configuration defines %d tfoauth extensions; choose one", len(ids))
+	}
+
+	cfg := tfoauthextension.NewFactory().CreateDefaultConfig().(*tfoauthextension.Config)
+	if err := unmarshal(conf, "extensions", id, cfg); err != nil {
+		return component.ID{}, nil, err
+	}
+	if err := cfg.Validate(); err != nil {
+		return component.ID{}, nil, fmt.Errorf("extension %s: %w", id, err)
+	}
+	return id, cfg, nil
+}
+
+// validate calls the extension's validation endpoint.
+func validate(ctx context.Context, cfg *tfoauthextension.Config, timeout time.Duration) Step {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	start := time.Now()
+	principal, err := tfoauthextension.ValidateCredentials(ctx, &http.Client{}, cfg)
+	return Step{
+		Name:      "validate",
+		Target:    cfg.ValidationEndpoint,
+		Latency:   time.Since(start),
+		Principal: principal,
+		Err:       err,
+	}
+}
+
+// testExports sends an empty traces export request through every tfo
+// exporter that uses the extension.
+func testExports(ctx context.Context, conf *confmap.Conf, ext component.ID, auth *tfoauthextension.Config, timeout time.Duration) ([]Step, error) {
+	ids, err := components(conf, "exporters", exporterType)
+	if err != nil {
+		return nil, err
+	}
+
+	var steps []Step
+	for _, id := range ids {
+		sub, err := conf.Sub("exporters" + confmap.KeyDelimiter + id.String())
+		if err != nil {
+			return nil, err
+		}
+		raw := sub.ToStringMap()
+		if ref, _ := sub.Get("auth" + confmap.KeyDelimiter + "extension").(string); ref != ext.String() {
+			continue
+		}
+		// The exporter's auth section shares its key with the squashed
+		// confighttp auth settings and does not decode on its own; the
+		// extension reference was read above and the credentials come from
+		// the extension.
+		delete(raw, "auth")
+		cfg := tfoexporter.NewFactory().CreateDefaultConfig().(*tfoexporter.Config)
+		if err := confmap.NewFromStringMap(raw).Unmarshal(cfg); err != nil {
+			return nil, fmt.Errorf("exporters %s: %w", id, err)
+		}
+		steps = append(steps, testExport(ctx, id, cfg, auth, timeout))
+	}
+	if len(steps) == 0 {
+		steps = append(steps, Step{
+			Name: "export",
+			Err:  fmt.Errorf("no %s exporter uses extension %s", exporterType, ext),
+		})
+	}
+	return steps, nil
+}
+
+// testExport sends an empty traces export request through one exporter.
+func testExport(ctx context.Context, id component.ID, cfg *tfoexporter.Config, auth *tfoauthextension.Config, timeout time.Duration) Step {
+	step := Step{Name: "export " + id.String(), Target: cfg.URL(cfg.GetTracesEndpoint())}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	client, err := cfg.NewClient(ctx, nil, component.TelemetrySettings{
+		Logger:         zap.NewNop(),
+		MeterProvider:  metricnoop.NewMeterProvider(),
+		TracerProvider: tracenoop.NewTracerProvider(),
+	})
+	if err != nil {
+		step.Err = fmt.Errorf("failed to create HTTP client: %w", err)
+		return step
+	}
+
+	req := ptraceotlp.NewExportRequest()
+	var body []byte
+	if cfg.Encoding == tfoexporter.EncodingJSON {
+		body, err = req.MarshalJSON()
+	} else {
+		body, err = req.MarshalProto()
+	}
+	if err != nil {
+		step.Err = err
+		return step
+	}
+
+	start := time.Now()
+	step.Err = post(ctx, client, step.Target, body, cfg.Encoding.ContentType(), auth)
+	step.Latency = time.Since(start)
+	return step
+}
+
+// post sends an export request with the extension's credentials.
+func post(ctx context.Context, client *http.Client, target string, body []byte, contentType string, auth *tfoauthextension.Config) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("X-TelemetryFlow-Key-ID", string(auth.APIKeyID))
+	req.Header.Set("X-TelemetryFlow-Key-Secret", string(auth.APIKeySecret))
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("credentials rejected (status %d)", resp.StatusCode)
+	case resp.StatusCode < 200 || resp.StatusCode >= 300:
+		return fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+// Package authcheck verifies TelemetryFlow API credentials outside the
+// running collector.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Run loads a collector configuration, calls the validation_endpoint of a
+// tfoauth extension and, optionally, sends an empty export request through
+// every tfo exporter that uses the extension. Each step reports pass or fail
+// with its latency, and the validation step reports the authenticated
+// principal. It backs the "tfo-collector auth check" command:
+//
+//	tfo-collector auth check --config configs/tfo-collector.yaml --test-export
+package authcheck // import "github.com/telemetryflow/telemetryflow-collector/pkg/authcheck"
@@ -0,0 +1,30 @@
+// Package bytesize parses human-friendly byte sizes in configuration.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Size fields accept a bare integer, which counts bytes as before, or a
+// string with a decimal (KB, MB, GB, TB) or binary (KiB, MiB, GiB, TiB)
+// unit, so "4" can no longer be mistaken for 4 MiB. Durations are written
+// the same way everywhere, as Go duration strings such as "500ms" or "1h30m".
+//
+// Example:
+//
+//	type Config struct {
+//		MaxRequestSize bytesize.Size `mapstructure:"max_request_size"`
+//	}
+//
+//	// max_request_size: 8MiB, "10MB" or 8388608
+package bytesize // import "github.com/telemetryflow/telemetryflow-collector/pkg/bytesize"
@@ -0,0 +1,3 @@
+module github.com/telemetryflow/telemetryflow-collector/pkg/bytesize
+
+go 1.26
@@ -0,0 +1,142 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package bytesize
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Size is a number of bytes. In configuration files it is written as a bare
+// integer, which counts bytes, or as a string with a unit such as "512KiB",
+// "10MB" or "1.5GiB".
+type Size int64
+
+// Common sizes.
+const (
+	Byte Size = 1
+	KB   Size = 1000
+	MB   Size = 1000 * KB
+	GB   Size = 1000 * MB
+	TB   Size = 1000 * GB
+	KiB  Size = 1 << 10
+	MiB  Size = 1 << 20
+	GiB  Size = 1 << 30
+	TiB  Size = 1 << 40
+)
+
+var units = map[string]Size{
+	"":    Byte,
+	"b":   Byte,
+	"kb":  KB,
+	"mb":  MB,
+	"gb":  GB,
+	"tb":  TB,
+	"kib": KiB,
+	"mib": MiB,
+	"gib": GiB,
+	"tib": TiB,
+}
+
+// binaryUnits are the units String uses, largest first.
+var binaryUnits = []struct {
+	suffix string
+	size   Size
+}{
+	{"TiB", TiB},
+	{"GiB", GiB},
+	{"MiB", MiB},
+	{"KiB", KiB},
+}
+
+// Parse parses a size: a non-negative number with an optional unit. The
+// units B, KB, MB, GB and TB are powers of 1000, KiB, MiB, GiB and TiB
+// powers of 1024; they are matched case-insensitively and may be separated
+// from the number by spaces. A number without a unit counts bytes.
+// Fractions are allowed as long as the result is a whole number of bytes.
+func Parse(in string) (Size, error) {
+	s := strings.TrimSpace(in)
+	i := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if i < 0 {
+		i = len(s)
+	}
+	num, unit := s[:i], strings.ToLower(strings.TrimSpace(s[i:]))
+	scale, ok := units[unit]
+	if num == "" || !ok {
+		return 0, fmt.Errorf("invalid size %q", in)
+	}
+
+	if !strings.Contains(num, ".") {
+		n, err := strconv.ParseInt(num, 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid size %q", in)
+		}
+		if n > math.MaxInt64/int64(scale) {
+			return 0, fmt.Errorf("size %q overflows", in)
+		}
+		return Size(n) * scale, nil
+	}
+
+	r, ok := new(big.Rat).SetString(num)
+	if !ok {
+		return 0, fmt.Errorf("invalid size %q", in)
+	}
+	r.Mul(r, new(big.Rat).SetInt64(int64(scale)))
+	if !r.IsInt() {
+		return 0, fmt.Errorf("size %q is not a whole number of bytes", in)
+	}
+	if !r.Num().IsInt64() {
+		return 0, fmt.Errorf("size %q overflows", in)
+	}
+	return Size(r.Num().Int64()), nil
+}
+
+// Bytes returns s as a number of bytes.
+func (s Size) Bytes() int64 {
+	return int64(s)
+}
+
+// String renders s in the largest binary unit that divides it, e.g.
+// "8MiB", or in bytes, e.g. "1000B".
+func (s Size) String() string {
+	for _, u := range binaryUnits {
+		if s >= u.size && s%u.size == 0 {
+			return strconv.FormatInt(int64(s/u.size), 10) + u.suffix
+		}
+	}
+	return strconv.FormatInt(int64(s), 10) + "B"
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s Size) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler, so configuration
+// strings are parsed with Parse. Bare integers in a configuration file are
+// decoded as bytes without it.
+func (s *Size) UnmarshalText(text []byte) error {
+	n, err := Parse(string(text))
+	if err != nil {
+		return err
+	}
+	*s = n
+	return nil
+}
@@ -0,0 +1,112 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package clientconf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+// DefaultTimeout is the default per-request timeout for exporter clients.
+const DefaultTimeout = 30 * time.Second
+
+// ClientConfig defines the outbound HTTP client settings shared by all
+// TelemetryFlow exporters. It is meant to be embedded with
+// `mapstructure:",squash"` so the settings appear at the exporter's top level.
+type ClientConfig struct {
+	confighttp.ClientConfig `mapstructure:",squash"`
+}
+
+// NewDefaultClientConfig returns a ClientConfig for the given endpoint using
+// the upstream connection pooling defaults and DefaultTimeout.
+func NewDefaultClientConfig(endpoint string) ClientConfig {
+	httpCfg := confighttp.NewDefaultClientConfig()
+	httpCfg.Endpoint = endpoint
+	httpCfg.Timeout = DefaultTimeout
+	return ClientConfig{ClientConfig: httpCfg}
+}
+
+// Validate checks the client settings for errors. An empty endpoint is
+// accepted here; exporters decide whether the endpoint is required.
+func (cfg *ClientConfig) Validate() error {
+	if cfg.Endpoint != "" {
+		if err := validateURL(cfg.Endpoint); err != nil {
+			return fmt.Errorf("invalid endpoint: %w", err)
+		}
+	}
+
+	if cfg.ProxyURL != "" {
+		if err := validateURL(cfg.ProxyURL); err != nil {
+			return fmt.Errorf("invalid proxy_url: %w", err)
+		}
+	}
+
+	if cfg.Timeout < 0 {
+		return errors.New("timeout must not be negative")
+	}
+
+	return cfg.ClientConfig.Validate()
+}
+
+// NewClient builds the HTTP client, resolving auth and middleware extensions
+// from the host. A nil host is allowed when no extension is referenced.
+func (cfg *ClientConfig) NewClient(ctx context.Context, host component.Host, set component.TelemetrySettings) (*http.Client, error) {
+	var extensions map[component.ID]component.Component
+	if host != nil {
+		extensions = host.GetExtensions()
+	}
+	return cfg.ToClient(ctx, extensions, set)
+}
+
+// URL joins the configured endpoint with the given path, tolerating a
+// trailing slash on the endpoint.
+func (cfg *ClientConfig) URL(path string) string {
+	if path == "" {
+		return cfg.Endpoint
+	}
+	return strings.TrimRight(cfg.Endpoint, "/") + "/" + strings.TrimLeft(path, "/")
+}
+
+// Host returns the host[:port] of the endpoint, or the raw endpoint when it
+// does not parse as a URL. Exporters use it to key per-host shared state.
+func (cfg *ClientConfig) Host() string {
+	if u, err := url.Parse(cfg.Endpoint); err == nil && u.Host != "" {
+		return u.Host
+	}
+	return cfg.Endpoint
+}
+
+// validateURL ensures the value is an absolute http(s) URL with a host.
+func validateURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return err
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("unsupported scheme %q (expected http or https)", u.Scheme)
+	}
+	if u.Host == "" {
+		return errors.New("missing host")
+	}
+	return nil
+}
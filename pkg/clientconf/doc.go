@@ -0,0 +1,40 @@
+// Package clientconf provides the shared outbound client settings consumed by
+// all TelemetryFlow exporters.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Exporters embed ClientConfig (squashed) so every exporter instance gets the
+// same independently configurable feature matrix without re-implementing it:
+//   - TLS / mTLS (tls)
+//   - HTTP proxy (proxy_url)
+//   - Compression (compression, compression_params)
+//   - Timeouts and connection pooling (timeout, idle_conn_timeout, ...)
+//   - Client auth extension reference (auth.authenticator)
+//   - Static request headers (headers)
+//
+// Configuration example:
+//
+//	exporters:
+//	  tfo:
+//	    endpoint: "https://api.telemetryflow.id"
+//	    timeout: 10s
+//	    compression: zstd
+//	    proxy_url: "http://proxy.internal:3128"
+//	    tls:
+//	      ca_file: /etc/tfo/ca.pem
+//	    headers:
+//	      X-Tenant: edge-01
+package clientconf // import "github.com/telemetryflow/telemetryflow-collector/pkg/clientconf"
@@ -0,0 +1,165 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package collector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/otelcol"
+	"go.opentelemetry.io/collector/pipeline"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/pipelineconf"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/registry"
+)
+
+// startPollInterval is how often Start checks whether the service is up.
+const startPollInterval = 10 * time.Millisecond
+
+// Collector is an embedded TFO Collector instance.
+type Collector struct {
+	col     *otelcol.Collector
+	sources map[string]*Source
+	done    chan error
+}
+
+// New creates an embedded collector from opts. The configuration is built in
+// memory; no config files or command line flags are read. Signal handling is
+// left to the embedding program.
+func New(opts Options) (*Collector, error) {
+	reg := opts.Registry
+	if reg == nil {
+		reg = registry.Default()
+	} else {
+		reg = reg.Clone()
+	}
+
+	sources := make(map[string]*Source, len(opts.Sources)+len(opts.Pipelines))
+	for _, name := range opts.Sources {
+		if _, ok := sources[name]; ok {
+			return nil, fmt.Errorf("duplicate source %q", name)
+		}
+		if strings.HasPrefix(name, pipelineSourcePrefix) {
+			return nil, fmt.Errorf("source name %q is reserved: names starting with %q feed a single pipeline", name, pipelineSourcePrefix)
+		}
+		sources[name] = &Source{}
+	}
+	for id := range opts.Pipelines {
+		if name, ok := pipelineSourceName(id); ok {
+			sources[name] = &Source{}
+		}
+	}
+	for name := range opts.Sinks {
+		if name == "" {
+			return nil, errors.New("sink name must not be empty")
+		}
+	}
+	if err := reg.RegisterReceivers(newSourceFactory(sources)); err != nil {
+		return nil, err
+	}
+	if err := reg.RegisterExporters(newSinkFactory(opts.Sinks)); err != nil {
+		return nil, err
+	}
+
+	set := registry.Builder{
+		Registry:          reg,
+		BuildInfo:         opts.BuildInfo,
+		ConfigURIs:        []string{embeddedURI},
+		ProviderFactories: []confmap.ProviderFactory{newEmbeddedProviderFactory(opts.rawConfig())},
+		// The host process owns its Go runtime settings, so only the
+		// pipeline checks run.
+		ConverterFactories: []confmap.ConverterFactory{pipelineconf.NewConverterFactory()},
+	}.Settings()
+	set.DisableGracefulShutdown = true
+	set.SkipSettingGRPCLogger = true
+
+	col, err := otelcol.NewCollector(set)
+	if err != nil {
+		return nil, err
+	}
+	return &Collector{col: col, sources: sources}, nil
+}
+
+// Start builds the pipelines and starts every component. It returns once
+// the collector is running, or with the error that prevented it from
+// starting. Cancelling ctx aborts a start in progress.
+func (c *Collector) Start(ctx context.Context) error {
+	if c.done != nil {
+		return errors.New("collector already started")
+	}
+	c.done = make(chan error, 1)
+	go func() { c.done <- c.col.Run(context.Background()) }()
+
+	ticker := time.NewTicker(startPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case err := <-c.done:
+			if err == nil {
+				err = errors.New("collector stopped during start")
+			}
+			c.done <- err
+			return err
+		case <-ctx.Done():
+			go c.col.Shutdown()
+			return ctx.Err()
+		case <-ticker.C:
+			if c.col.GetState() == otelcol.StateRunning {
+				return nil
+			}
+		}
+	}
+}
+
+// Shutdown stops all components, waiting until they are stopped or ctx is
+// done. It is safe to call on a collector that was never started.
+func (c *Collector) Shutdown(ctx context.Context) error {
+	if c.done == nil {
+		return nil
+	}
+	// otelcol's Shutdown blocks until Run returns; wait on done instead so
+	// that ctx bounds the wait.
+	go c.col.Shutdown()
+	select {
+	case err := <-c.done:
+		c.done <- err
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Source returns the in-process source name listed in Options.Sources, or nil
+// if there is no such source.
+func (c *Collector) Source(name string) *Source {
+	return c.sources[name]
+}
+
+// Pipeline returns a source feeding only the traces, metrics or logs
+// pipeline id, or nil if Options.Pipelines has no such pipeline. Data pushed
+// through it passes the processors and exporters of that pipeline like data
+// of its receivers, without being serialized; the pipeline owns the data
+// once the call returns.
+func (c *Collector) Pipeline(id pipeline.ID) *Source {
+	name, ok := pipelineSourceName(id)
+	if !ok {
+		return nil
+	}
+	return c.sources[name]
+}
@@ -0,0 +1,61 @@
+// Package collector runs the TFO Collector embedded in another Go program.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// The collector is configured programmatically through Options, without the
+// CLI or configuration files. Pipelines can exchange data with the embedding
+// program through in-process components of type "inprocess": sources push
+// data into pipelines and sinks receive the data a pipeline exports.
+//
+// Pipelines follow the service::pipelines semantics of a configuration file:
+// a signal may have several independent pipelines, e.g. logs/default and
+// logs/security, each with its own processors and exporters. A source listed
+// in more than one of them feeds every one with its own copy of the data.
+// Collector.Pipeline pushes into a single named pipeline instead, which then
+// needs no receivers of its own.
+//
+// Example:
+//
+//	col, err := collector.New(collector.Options{
+//		Processors: map[component.ID]collector.ComponentConfig{
+//			component.MustNewID("batch"): {"timeout": "1s"},
+//		},
+//		Exporters: map[component.ID]collector.ComponentConfig{
+//			component.MustNewID("tfo"): {"endpoint": "https://api.telemetryflow.id"},
+//		},
+//		Pipelines: map[pipeline.ID]collector.Pipeline{
+//			pipeline.NewID(pipeline.SignalTraces): {
+//				Receivers:  []component.ID{collector.SourceID("app")},
+//				Processors: []component.ID{component.MustNewID("batch")},
+//				Exporters:  []component.ID{component.MustNewID("tfo"), collector.SinkID("audit")},
+//			},
+//		},
+//		Sources: []string{"app"},
+//		Sinks:   map[string]collector.Sink{"audit": {Traces: auditConsumer}},
+//	})
+//	if err != nil {
+//		return err
+//	}
+//	if err := col.Start(ctx); err != nil {
+//		return err
+//	}
+//	defer col.Shutdown(context.Background())
+//
+//	err = col.Source("app").ConsumeTraces(ctx, traces)
+//
+//	// Only the traces pipeline, without going through a named source.
+//	err = col.Pipeline(pipeline.NewID(pipeline.SignalTraces)).ConsumeTraces(ctx, traces)
+package collector // import "github.com/telemetryflow/telemetryflow-collector/pkg/collector"
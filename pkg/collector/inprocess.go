@@ -0,0 +1,195 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package collector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver"
+)
+
+// ErrSourceNotConnected is returned when data is pushed into a source whose
+// signal is not used by any running pipeline.
+var ErrSourceNotConnected = errors.New("source is not connected to a running pipeline")
+
+// inProcessConfig is the (empty) configuration of in-process components.
+type inProcessConfig struct{}
+
+// inProcessComponent implements component.Component with no-op lifecycle.
+type inProcessComponent struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+// Source pushes data from the embedding program into the pipelines that list
+// the corresponding in-process receiver.
+type Source struct {
+	mu      sync.RWMutex
+	traces  consumer.Traces
+	metrics consumer.Metrics
+	logs    consumer.Logs
+}
+
+// ConsumeTraces sends td into the traces pipelines of the source.
+func (s *Source) ConsumeTraces(ctx context.Context, td ptrace.Traces) error {
+	s.mu.RLock()
+	next := s.traces
+	s.mu.RUnlock()
+	if next == nil {
+		return ErrSourceNotConnected
+	}
+	return next.ConsumeTraces(ctx, td)
+}
+
+// ConsumeMetrics sends md into the metrics pipelines of the source.
+func (s *Source) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) error {
+	s.mu.RLock()
+	next := s.metrics
+	s.mu.RUnlock()
+	if next == nil {
+		return ErrSourceNotConnected
+	}
+	return next.ConsumeMetrics(ctx, md)
+}
+
+// ConsumeLogs sends ld into the logs pipelines of the source.
+func (s *Source) ConsumeLogs(ctx context.Context, ld plog.Logs) error {
+	s.mu.RLock()
+	next := s.logs
+	s.mu.RUnlock()
+	if next == nil {
+		return ErrSourceNotConnected
+	}
+	return next.ConsumeLogs(ctx, ld)
+}
+
+// connect installs next for the receiver's lifetime and returns the receiver.
+func connect[T any](s *Source, slot *T, next T) component.Component {
+	var zero T
+	return &inProcessComponent{
+		StartFunc: func(context.Context, component.Host) error {
+			s.mu.Lock()
+			*slot = next
+			s.mu.Unlock()
+			return nil
+		},
+		ShutdownFunc: func(context.Context) error {
+			s.mu.Lock()
+			*slot = zero
+			s.mu.Unlock()
+			return nil
+		},
+	}
+}
+
+// newSourceFactory creates the receiver factory backing sources.
+func newSourceFactory(sources map[string]*Source) receiver.Factory {
+	lookup := func(id component.ID) (*Source, error) {
+		s, ok := sources[id.Name()]
+		if !ok {
+			return nil, fmt.Errorf("no source named %q in collector options", id.Name())
+		}
+		return s, nil
+	}
+	return receiver.NewFactory(
+		component.MustNewType(InProcessType),
+		func() component.Config { return &inProcessConfig{} },
+		receiver.WithTraces(func(_ context.Context, set receiver.Settings, _ component.Config, next consumer.Traces) (receiver.Traces, error) {
+			s, err := lookup(set.ID)
+			if err != nil {
+				return nil, err
+			}
+			return connect(s, &s.traces, next), nil
+		}, component.StabilityLevelAlpha),
+		receiver.WithMetrics(func(_ context.Context, set receiver.Settings, _ component.Config, next consumer.Metrics) (receiver.Metrics, error) {
+			s, err := lookup(set.ID)
+			if err != nil {
+				return nil, err
+			}
+			return connect(s, &s.metrics, next), nil
+		}, component.StabilityLevelAlpha),
+		receiver.WithLogs(func(_ context.Context, set receiver.Settings, _ component.Config, next consumer.Logs) (receiver.Logs, error) {
+			s, err := lookup(set.ID)
+			if err != nil {
+				return nil, err
+			}
+			return connect(s, &s.logs, next), nil
+		}, component.StabilityLevelAlpha),
+	)
+}
+
+// tracesSink, metricsSink and logsSink forward exported data to a Sink.
+type tracesSink struct {
+	inProcessComponent
+	consumer.Traces
+}
+
+type metricsSink struct {
+	inProcessComponent
+	consumer.Metrics
+}
+
+type logsSink struct {
+	inProcessComponent
+	consumer.Logs
+}
+
+// newSinkFactory creates the exporter factory backing sinks.
+func newSinkFactory(sinks map[string]Sink) exporter.Factory {
+	lookup := func(id component.ID, signal string, has func(Sink) bool) (Sink, error) {
+		s, ok := sinks[id.Name()]
+		if !ok {
+			return Sink{}, fmt.Errorf("no sink named %q in collector options", id.Name())
+		}
+		if !has(s) {
+			return Sink{}, fmt.Errorf("sink %q has no %s consumer", id.Name(), signal)
+		}
+		return s, nil
+	}
+	return exporter.NewFactory(
+		component.MustNewType(InProcessType),
+		func() component.Config { return &inProcessConfig{} },
+		exporter.WithTraces(func(_ context.Context, set exporter.Settings, _ component.Config) (exporter.Traces, error) {
+			s, err := lookup(set.ID, "traces", func(s Sink) bool { return s.Traces != nil })
+			if err != nil {
+				return nil, err
+			}
+			return &tracesSink{Traces: s.Traces}, nil
+		}, component.StabilityLevelAlpha),
+		exporter.WithMetrics(func(_ context.Context, set exporter.Settings, _ component.Config) (exporter.Metrics, error) {
+			s, err := lookup(set.ID, "metrics", func(s Sink) bool { return s.Metrics != nil })
+			if err != nil {
+				return nil, err
+			}
+			return &metricsSink{Metrics: s.Metrics}, nil
+		}, component.StabilityLevelAlpha),
+		exporter.WithLogs(func(_ context.Context, set exporter.Settings, _ component.Config) (exporter.Logs, error) {
+			s, err := lookup(set.ID, "logs", func(s Sink) bool { return s.Logs != nil })
+			if err != nil {
+				return nil, err
+			}
+			return &logsSink{Logs: s.Logs}, nil
+		}, component.StabilityLevelAlpha),
+	)
+}
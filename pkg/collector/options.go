@@ -0,0 +1,180 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package collector
+
+import (
+	"slices"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pipeline"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/registry"
+)
+
+// InProcessType is the component type of the receivers and exporters that
+// connect pipelines to the embedding program. Reference them in pipelines as
+// "inprocess/<name>" using SourceID and SinkID.
+const InProcessType = "inprocess"
+
+// pipelineSourcePrefix starts the names of the in-process receivers that
+// feed a single pipeline; see Collector.Pipeline.
+const pipelineSourcePrefix = "pipeline."
+
+// ComponentConfig is the configuration of a single component, in the same
+// shape as its YAML configuration.
+type ComponentConfig = map[string]any
+
+// Pipeline lists the components of one service pipeline. Receivers may be
+// empty for traces, metrics and logs pipelines that are only fed through
+// Collector.Pipeline.
+type Pipeline struct {
+	Receivers  []component.ID
+	Processors []component.ID
+	Exporters  []component.ID
+}
+
+// Sink receives the data exported by the "inprocess/<name>" exporter. Only
+// the consumers of signals used in pipelines need to be set.
+type Sink struct {
+	Traces  consumer.Traces
+	Metrics consumer.Metrics
+	Logs    consumer.Logs
+}
+
+// Options configures an embedded collector.
+type Options struct {
+	// Registry provides the component factories. registry.Default() is used
+	// when nil. The set is cloned, so it is not modified by New.
+	Registry *registry.RegistrySet
+
+	// BuildInfo identifies the embedding program in the collector's own
+	// telemetry. The TFO Collector build info is used when Command is empty.
+	BuildInfo component.BuildInfo
+
+	// Extensions, Receivers, Processors, Exporters and Connectors hold the
+	// component configurations keyed by component ID. A nil config uses the
+	// factory defaults.
+	Extensions map[component.ID]ComponentConfig
+	Receivers  map[component.ID]ComponentConfig
+	Processors map[component.ID]ComponentConfig
+	Exporters  map[component.ID]ComponentConfig
+	Connectors map[component.ID]ComponentConfig
+
+	// Pipelines defines the service pipelines.
+	Pipelines map[pipeline.ID]Pipeline
+
+	// ServiceExtensions lists the extensions enabled in the service.
+	ServiceExtensions []component.ID
+
+	// Telemetry is the service::telemetry section. When nil, the collector's
+	// own metrics are disabled so that no listener is opened in the
+	// embedding process.
+	Telemetry ComponentConfig
+
+	// Sources names the in-process receivers. Data pushed through
+	// Collector.Source(name) enters every pipeline that lists
+	// SourceID(name) as a receiver.
+	Sources []string
+
+	// Sinks maps in-process exporter names to the consumers receiving the
+	// data of every pipeline that lists SinkID(name) as an exporter.
+	Sinks map[string]Sink
+}
+
+// SourceID returns the component ID of the in-process receiver name.
+func SourceID(name string) component.ID {
+	return component.MustNewIDWithName(InProcessType, name)
+}
+
+// SinkID returns the component ID of the in-process exporter name.
+func SinkID(name string) component.ID {
+	return component.MustNewIDWithName(InProcessType, name)
+}
+
+// pipelineSourceName returns the name of the in-process receiver feeding
+// only pipeline id, and false for signals in-process receivers do not
+// support.
+func pipelineSourceName(id pipeline.ID) (string, bool) {
+	switch id.Signal() {
+	case pipeline.SignalTraces, pipeline.SignalMetrics, pipeline.SignalLogs:
+		return pipelineSourcePrefix + id.String(), true
+	default:
+		return "", false
+	}
+}
+
+// rawConfig renders the options as the collector configuration map.
+func (o Options) rawConfig() map[string]any {
+	components := func(m map[component.ID]ComponentConfig) map[string]any {
+		out := make(map[string]any, len(m))
+		for id, cfg := range m {
+			if cfg == nil {
+				out[id.String()] = nil
+				continue
+			}
+			out[id.String()] = map[string]any(cfg)
+		}
+		return out
+	}
+	ids := func(list []component.ID) []any {
+		out := make([]any, 0, len(list))
+		for _, id := range list {
+			out = append(out, id.String())
+		}
+		return out
+	}
+
+	receivers := components(o.Receivers)
+	for _, name := range o.Sources {
+		receivers[SourceID(name).String()] = nil
+	}
+	exporters := components(o.Exporters)
+	for name := range o.Sinks {
+		exporters[SinkID(name).String()] = nil
+	}
+
+	pipelines := make(map[string]any, len(o.Pipelines))
+	for id, p := range o.Pipelines {
+		pipelineReceivers := p.Receivers
+		if name, ok := pipelineSourceName(id); ok {
+			receivers[SourceID(name).String()] = nil
+			pipelineReceivers = append(slices.Clone(p.Receivers), SourceID(name))
+		}
+		pipelines[id.String()] = map[string]any{
+			"receivers":  ids(pipelineReceivers),
+			"processors": ids(p.Processors),
+			"exporters":  ids(p.Exporters),
+		}
+	}
+
+	telemetry := map[string]any(o.Telemetry)
+	if telemetry == nil {
+		telemetry = map[string]any{"metrics": map[string]any{"level": "none"}}
+	}
+
+	return map[string]any{
+		"extensions": components(o.Extensions),
+		"receivers":  receivers,
+		"processors": components(o.Processors),
+		"exporters":  exporters,
+		"connectors": components(o.Connectors),
+		"service": map[string]any{
+			"extensions": ids(o.ServiceExtensions),
+			"telemetry":  telemetry,
+			"pipelines":  pipelines,
+		},
+	}
+}
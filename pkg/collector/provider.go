@@ -0,0 +1,55 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package collector
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// embeddedScheme is the confmap scheme serving the configuration built from
+// Options; it is only registered with the embedded collector's resolver.
+const embeddedScheme = "tfoembedded"
+
+// embeddedURI is the single configuration URI of an embedded collector.
+const embeddedURI = embeddedScheme + ":options"
+
+// embeddedProvider serves a fixed configuration map.
+type embeddedProvider struct {
+	conf map[string]any
+}
+
+// newEmbeddedProviderFactory returns a provider factory serving conf.
+func newEmbeddedProviderFactory(conf map[string]any) confmap.ProviderFactory {
+	return confmap.NewProviderFactory(func(confmap.ProviderSettings) confmap.Provider {
+		return &embeddedProvider{conf: conf}
+	})
+}
+
+// Retrieve implements confmap.Provider.
+func (p *embeddedProvider) Retrieve(context.Context, string, confmap.WatcherFunc) (*confmap.Retrieved, error) {
+	return confmap.NewRetrieved(p.conf)
+}
+
+// Scheme implements confmap.Provider.
+func (p *embeddedProvider) Scheme() string {
+	return embeddedScheme
+}
+
+// Shutdown implements confmap.Provider.
+func (p *embeddedProvider) Shutdown(context.Context) error {
+	return nil
+}
@@ -0,0 +1,243 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package crashguard
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pprofile"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+// errRestarting refuses data sent to a component restarting after a panic.
+// It is not permanent, so senders retry once the component is back.
+var errRestarting = errors.New("component is restarting after a panic")
+
+// guarded recovers the panics of one processor or exporter of one signal.
+type guarded struct {
+	guard  *Guard
+	kind   component.Kind
+	id     component.ID
+	signal pipeline.Signal
+	logger *zap.Logger
+	comp   component.Component
+
+	// panics is nil without a meter provider.
+	panics metric.Int64Counter
+	option metric.MeasurementOption
+
+	// mu serializes Start, Shutdown and restarts. It is not held while
+	// data is consumed: an exporter aborts its retries only once shut
+	// down, so Shutdown must not wait for the calls in flight.
+	mu      sync.Mutex
+	host    component.Host
+	stopped bool
+
+	// restarting refuses data while the component restarts.
+	restarting atomic.Bool
+}
+
+func newGuarded(g *Guard, set component.TelemetrySettings, kind component.Kind, id component.ID, signal pipeline.Signal, comp component.Component) (*guarded, error) {
+	c := &guarded{guard: g, kind: kind, id: id, signal: signal, logger: set.Logger, comp: comp}
+	if c.logger == nil {
+		c.logger = zap.NewNop()
+	}
+	if set.MeterProvider != nil {
+		var err error
+		c.panics, err = set.MeterProvider.Meter(scopeName).Int64Counter(selfmetrics.ComponentPanics,
+			metric.WithDescription("Panics of processors and exporters recovered by the crash guard."),
+			metric.WithUnit("{panic}"))
+		if err != nil {
+			return nil, err
+		}
+		c.option = selfmetrics.Labels{Kind: kind, ID: id, Signal: signal}.Option()
+	}
+	return c, nil
+}
+
+// Start implements component.Component.
+func (c *guarded) Start(ctx context.Context, host component.Host) (err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.host = host
+	c.stopped = false
+	defer c.contain(&err, false)
+	return c.comp.Start(ctx, host)
+}
+
+// Shutdown implements component.Component. It waits for a restart in
+// progress, and no restart follows it. Calls consuming data are not waited
+// for; shutting the component down makes them return.
+func (c *guarded) Shutdown(ctx context.Context) (err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stopped = true
+	defer c.contain(&err, false)
+	return c.comp.Shutdown(ctx)
+}
+
+// contain turns a panic of the current call into *err and, when restart is
+// set and configured, restarts the component once the call has returned.
+// It must be deferred.
+func (c *guarded) contain(err *error, restart bool) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	cfg := c.guard.Config()
+	if !cfg.Enabled {
+		panic(r)
+	}
+	*err = c.recovered(r, debug.Stack())
+	if restart && cfg.Restart && c.restarting.CompareAndSwap(false, true) {
+		go c.restart()
+	}
+}
+
+// recovered records the panic value and returns the error of the call.
+func (c *guarded) recovered(value any, stack []byte) error {
+	if c.panics != nil {
+		c.panics.Add(context.Background(), 1, c.option)
+	}
+	fields := []zap.Field{
+		zap.String("signal", c.signal.String()),
+		zap.Any("panic", value),
+		zap.ByteString("stack", stack),
+	}
+	path, dumpErr := c.guard.writeDump(c.kind, c.id, c.signal, value, stack)
+	if path != "" {
+		fields = append(fields, zap.String("dump", path))
+	}
+	c.logger.Error("Recovered panic", fields...)
+	if dumpErr != nil {
+		c.logger.Warn("Failed to write stack dump", zap.Error(dumpErr))
+	}
+	return consumererror.NewPermanent(fmt.Errorf("%s %s panicked: %v", strings.ToLower(c.kind.String()), c.id, value))
+}
+
+// restart shuts the component down and starts it again, refusing data
+// until it is back.
+func (c *guarded) restart() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	defer c.restarting.Store(false)
+	if c.stopped || c.host == nil {
+		return
+	}
+	ctx := context.Background()
+	if err := call(func() error { return c.comp.Shutdown(ctx) }); err != nil {
+		c.logger.Warn("Failed to shut down component for restart", zap.Error(err))
+	}
+	if err := call(func() error { return c.comp.Start(ctx, c.host) }); err != nil {
+		c.logger.Error("Failed to restart component after a panic", zap.Error(err))
+		return
+	}
+	c.logger.Info("Restarted component after a panic")
+}
+
+// call runs f, turning a panic into an error.
+func call(f func() error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return f()
+}
+
+type guardedTraces struct {
+	*guarded
+	next consumer.Traces
+}
+
+func (c *guardedTraces) Capabilities() consumer.Capabilities {
+	return c.next.Capabilities()
+}
+
+func (c *guardedTraces) ConsumeTraces(ctx context.Context, td ptrace.Traces) (err error) {
+	if c.restarting.Load() {
+		return errRestarting
+	}
+	defer c.contain(&err, true)
+	return c.next.ConsumeTraces(ctx, td)
+}
+
+type guardedMetrics struct {
+	*guarded
+	next consumer.Metrics
+}
+
+func (c *guardedMetrics) Capabilities() consumer.Capabilities {
+	return c.next.Capabilities()
+}
+
+func (c *guardedMetrics) ConsumeMetrics(ctx context.Context, md pmetric.Metrics) (err error) {
+	if c.restarting.Load() {
+		return errRestarting
+	}
+	defer c.contain(&err, true)
+	return c.next.ConsumeMetrics(ctx, md)
+}
+
+type guardedLogs struct {
+	*guarded
+	next consumer.Logs
+}
+
+func (c *guardedLogs) Capabilities() consumer.Capabilities {
+	return c.next.Capabilities()
+}
+
+func (c *guardedLogs) ConsumeLogs(ctx context.Context, ld plog.Logs) (err error) {
+	if c.restarting.Load() {
+		return errRestarting
+	}
+	defer c.contain(&err, true)
+	return c.next.ConsumeLogs(ctx, ld)
+}
+
+type guardedProfiles struct {
+	*guarded
+	next xconsumer.Profiles
+}
+
+func (c *guardedProfiles) Capabilities() consumer.Capabilities {
+	return c.next.Capabilities()
+}
+
+func (c *guardedProfiles) ConsumeProfiles(ctx context.Context, pd pprofile.Profiles) (err error) {
+	if c.restarting.Load() {
+		return errRestarting
+	}
+	defer c.contain(&err, true)
+	return c.next.ConsumeProfiles(ctx, pd)
+}
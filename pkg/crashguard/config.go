@@ -0,0 +1,54 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package crashguard
+
+import "errors"
+
+// Config defines the crash_guard section of the collector configuration.
+type Config struct {
+	// Enabled recovers panics of processors and exporters. When false a
+	// panic ends the process.
+	// Default: true
+	Enabled bool `mapstructure:"enabled"`
+
+	// Restart restarts a component through Shutdown and Start after it
+	// panicked. Not every component supports being started again.
+	// Default: false
+	Restart bool `mapstructure:"restart"`
+
+	// DumpDir is the directory stack dumps of recovered panics are written
+	// to. Empty writes no dumps.
+	// Default: ""
+	DumpDir string `mapstructure:"dump_dir"`
+
+	// MaxDumps is the number of stack dumps kept in DumpDir; older dumps
+	// are removed.
+	// Default: 20
+	MaxDumps int `mapstructure:"max_dumps"`
+}
+
+// DefaultConfig returns the configuration used without a crash_guard
+// section.
+func DefaultConfig() Config {
+	return Config{Enabled: true, MaxDumps: 20}
+}
+
+// Validate checks the configuration for errors.
+func (cfg *Config) Validate() error {
+	if cfg.MaxDumps < 1 {
+		return errors.New("crash_guard.max_dumps must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package crashguard
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// SectionKey is the top-level configuration key of the crash guard section.
+const SectionKey = "crash_guard"
+
+// NewConverterFactory returns a converter that configures g from the
+// crash_guard section and removes it from the configuration.
+func NewConverterFactory(g *Guard) confmap.ConverterFactory {
+	return confmap.NewConverterFactory(func(confmap.ConverterSettings) confmap.Converter {
+		return &converter{guard: g}
+	})
+}
+
+type converter struct {
+	guard *Guard
+}
+
+func (c *converter) Convert(_ context.Context, conf *confmap.Conf) error {
+	cfg := DefaultConfig()
+	if conf.IsSet(SectionKey) {
+		sub, err := conf.Sub(SectionKey)
+		if err != nil {
+			return err
+		}
+		if err := sub.Unmarshal(&cfg); err != nil {
+			return err
+		}
+		conf.Delete(SectionKey)
+	}
+	if err := cfg.Validate(); err != nil {
+		return err
+	}
+	c.guard.Configure(cfg)
+	return nil
+}
@@ -0,0 +1,46 @@
+// Package crashguard contains panics of processors and exporters.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// A panic in a processor or exporter would take the whole collector down
+// with it. The factories of a Builder are wrapped so that every processor
+// and exporter recovers panics raised while it consumes data, starts or
+// shuts down. A recovered panic:
+//   - fails the call with a permanent error naming the component, so the
+//     batch is dropped instead of retried into the same panic
+//   - is logged with its stack and counted in tfo_component_panics
+//   - is written as a stack dump to dump_dir, keeping the newest max_dumps
+//     files, to be attached to support requests
+//   - restarts the component through Shutdown and Start when restart is
+//     set; data sent meanwhile is refused with a retryable error
+//
+// Panics in goroutines a component starts itself, e.g. the workers of an
+// exporter sending queue, cannot be recovered at the component boundary and
+// still end the process. A connector panicking is recovered by the
+// processor or exporter before it in the pipeline.
+//
+// The top-level "crash_guard" section configures the guard of a Builder. It
+// is applied by a confmap converter, which removes it before the
+// configuration reaches otelcol, and is applied again on every reload.
+//
+// Example:
+//
+//	crash_guard:
+//	  enabled: true                             # default true
+//	  restart: false                            # restart components after a panic
+//	  dump_dir: /var/lib/tfo-collector/crashes  # "" = no stack dumps
+//	  max_dumps: 20
+package crashguard // import "github.com/telemetryflow/telemetryflow-collector/pkg/crashguard"
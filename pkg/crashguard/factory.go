@@ -0,0 +1,185 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package crashguard
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/xexporter"
+	"go.opentelemetry.io/collector/otelcol"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/pipeline/xpipeline"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/xprocessor"
+)
+
+// aliasedFactory is implemented by factories whose component was renamed
+// and that still accept the former type in configurations.
+type aliasedFactory interface {
+	DeprecatedAlias() component.Type
+}
+
+// deprecatedAlias returns the former type of the component of f, if any.
+func deprecatedAlias(f component.Factory) (component.Type, bool) {
+	a, ok := f.(aliasedFactory)
+	if !ok || a.DeprecatedAlias().String() == "" {
+		return component.Type{}, false
+	}
+	return a.DeprecatedAlias(), true
+}
+
+// Wrap returns factories whose processors and exporters are guarded by g.
+// The other factories are returned unchanged.
+func (g *Guard) Wrap(factories otelcol.Factories) otelcol.Factories {
+	processors := make(map[component.Type]processor.Factory, len(factories.Processors))
+	for typ, f := range factories.Processors {
+		processors[typ] = g.WrapProcessor(f)
+	}
+	exporters := make(map[component.Type]exporter.Factory, len(factories.Exporters))
+	for typ, f := range factories.Exporters {
+		exporters[typ] = g.WrapExporter(f)
+	}
+	factories.Processors = processors
+	factories.Exporters = exporters
+	return factories
+}
+
+// WrapProcessor returns a factory creating the processors of f guarded by
+// g, for the same signals, stability levels and deprecated alias.
+func (g *Guard) WrapProcessor(f processor.Factory) processor.Factory {
+	var opts []xprocessor.FactoryOption
+	if sl := f.TracesStability(); sl != component.StabilityLevelUndefined {
+		opts = append(opts, xprocessor.WithTraces(func(ctx context.Context, set processor.Settings, cfg component.Config, next consumer.Traces) (processor.Traces, error) {
+			p, err := f.CreateTraces(ctx, set, cfg, next)
+			if err != nil {
+				return nil, err
+			}
+			c, err := newGuarded(g, set.TelemetrySettings, component.KindProcessor, set.ID, pipeline.SignalTraces, p)
+			if err != nil {
+				return nil, err
+			}
+			return &guardedTraces{guarded: c, next: p}, nil
+		}, sl))
+	}
+	if sl := f.MetricsStability(); sl != component.StabilityLevelUndefined {
+		opts = append(opts, xprocessor.WithMetrics(func(ctx context.Context, set processor.Settings, cfg component.Config, next consumer.Metrics) (processor.Metrics, error) {
+			p, err := f.CreateMetrics(ctx, set, cfg, next)
+			if err != nil {
+				return nil, err
+			}
+			c, err := newGuarded(g, set.TelemetrySettings, component.KindProcessor, set.ID, pipeline.SignalMetrics, p)
+			if err != nil {
+				return nil, err
+			}
+			return &guardedMetrics{guarded: c, next: p}, nil
+		}, sl))
+	}
+	if sl := f.LogsStability(); sl != component.StabilityLevelUndefined {
+		opts = append(opts, xprocessor.WithLogs(func(ctx context.Context, set processor.Settings, cfg component.Config, next consumer.Logs) (processor.Logs, error) {
+			p, err := f.CreateLogs(ctx, set, cfg, next)
+			if err != nil {
+				return nil, err
+			}
+			c, err := newGuarded(g, set.TelemetrySettings, component.KindProcessor, set.ID, pipeline.SignalLogs, p)
+			if err != nil {
+				return nil, err
+			}
+			return &guardedLogs{guarded: c, next: p}, nil
+		}, sl))
+	}
+	if xf, ok := f.(xprocessor.Factory); ok && xf.ProfilesStability() != component.StabilityLevelUndefined {
+		opts = append(opts, xprocessor.WithProfiles(func(ctx context.Context, set processor.Settings, cfg component.Config, next xconsumer.Profiles) (xprocessor.Profiles, error) {
+			p, err := xf.CreateProfiles(ctx, set, cfg, next)
+			if err != nil {
+				return nil, err
+			}
+			c, err := newGuarded(g, set.TelemetrySettings, component.KindProcessor, set.ID, xpipeline.SignalProfiles, p)
+			if err != nil {
+				return nil, err
+			}
+			return &guardedProfiles{guarded: c, next: p}, nil
+		}, xf.ProfilesStability()))
+	}
+	if alias, ok := deprecatedAlias(f); ok {
+		opts = append(opts, xprocessor.WithDeprecatedTypeAlias(alias))
+	}
+	return xprocessor.NewFactory(f.Type(), f.CreateDefaultConfig, opts...)
+}
+
+// WrapExporter returns a factory creating the exporters of f guarded by g,
+// for the same signals, stability levels and deprecated alias.
+func (g *Guard) WrapExporter(f exporter.Factory) exporter.Factory {
+	var opts []xexporter.FactoryOption
+	if sl := f.TracesStability(); sl != component.StabilityLevelUndefined {
+		opts = append(opts, xexporter.WithTraces(func(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Traces, error) {
+			e, err := f.CreateTraces(ctx, set, cfg)
+			if err != nil {
+				return nil, err
+			}
+			c, err := newGuarded(g, set.TelemetrySettings, component.KindExporter, set.ID, pipeline.SignalTraces, e)
+			if err != nil {
+				return nil, err
+			}
+			return &guardedTraces{guarded: c, next: e}, nil
+		}, sl))
+	}
+	if sl := f.MetricsStability(); sl != component.StabilityLevelUndefined {
+		opts = append(opts, xexporter.WithMetrics(func(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Metrics, error) {
+			e, err := f.CreateMetrics(ctx, set, cfg)
+			if err != nil {
+				return nil, err
+			}
+			c, err := newGuarded(g, set.TelemetrySettings, component.KindExporter, set.ID, pipeline.SignalMetrics, e)
+			if err != nil {
+				return nil, err
+			}
+			return &guardedMetrics{guarded: c, next: e}, nil
+		}, sl))
+	}
+	if sl := f.LogsStability(); sl != component.StabilityLevelUndefined {
+		opts = append(opts, xexporter.WithLogs(func(ctx context.Context, set exporter.Settings, cfg component.Config) (exporter.Logs, error) {
+			e, err := f.CreateLogs(ctx, set, cfg)
+			if err != nil {
+				return nil, err
+			}
+			c, err := newGuarded(g, set.TelemetrySettings, component.KindExporter, set.ID, pipeline.SignalLogs, e)
+			if err != nil {
+				return nil, err
+			}
+			return &guardedLogs{guarded: c, next: e}, nil
+		}, sl))
+	}
+	if xf, ok := f.(xexporter.Factory); ok && xf.ProfilesStability() != component.StabilityLevelUndefined {
+		opts = append(opts, xexporter.WithProfiles(func(ctx context.Context, set exporter.Settings, cfg component.Config) (xexporter.Profiles, error) {
+			e, err := xf.CreateProfiles(ctx, set, cfg)
+			if err != nil {
+				return nil, err
+			}
+			c, err := newGuarded(g, set.TelemetrySettings, component.KindExporter, set.ID, xpipeline.SignalProfiles, e)
+			if err != nil {
+				return nil, err
+			}
+			return &guardedProfiles{guarded: c, next: e}, nil
+		}, xf.ProfilesStability()))
+	}
+	if alias, ok := deprecatedAlias(f); ok {
+		opts = append(opts, xexporter.WithDeprecatedTypeAlias(alias))
+	}
+	return xexporter.NewFactory(f.Type(), f.CreateDefaultConfig, opts...)
+}
@@ -0,0 +1,109 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package crashguard
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+const scopeName = "github.com/telemetryflow/telemetryflow-collector/pkg/crashguard"
+
+// dumpSuffix is the file name suffix of stack dumps.
+const dumpSuffix = ".stack"
+
+// Guard holds the crash guard configuration shared by the components it
+// wraps. The configuration may change while they run, e.g. on a reload.
+type Guard struct {
+	cfg atomic.Pointer[Config]
+
+	// dumpMu serializes writing and pruning stack dumps.
+	dumpMu sync.Mutex
+}
+
+// New creates a guard with the default configuration.
+func New() *Guard {
+	g := new(Guard)
+	g.Configure(DefaultConfig())
+	return g
+}
+
+// Configure replaces the configuration of g.
+func (g *Guard) Configure(cfg Config) {
+	g.cfg.Store(&cfg)
+}
+
+// Config returns the current configuration of g.
+func (g *Guard) Config() Config {
+	return *g.cfg.Load()
+}
+
+// writeDump writes the stack dump of a panic of component id to the dump
+// directory, removing the oldest dumps over the limit. It returns the path
+// of the dump, or "" without a dump directory.
+func (g *Guard) writeDump(kind component.Kind, id component.ID, signal pipeline.Signal, value any, stack []byte) (string, error) {
+	cfg := g.Config()
+	if cfg.DumpDir == "" {
+		return "", nil
+	}
+	g.dumpMu.Lock()
+	defer g.dumpMu.Unlock()
+
+	if err := os.MkdirAll(cfg.DumpDir, 0o750); err != nil {
+		return "", err
+	}
+	now := time.Now().UTC()
+	name := fmt.Sprintf("%s-%s-%s%s", now.Format("20060102T150405.000000000Z"),
+		strings.ToLower(kind.String()), strings.ReplaceAll(id.String(), "/", "_"), dumpSuffix)
+	path := filepath.Join(cfg.DumpDir, name)
+	content := fmt.Sprintf("component: %s/%s\nsignal: %s\ntime: %s\npanic: %v\n\n%s",
+		strings.ToLower(kind.String()), id, signal, now.Format(time.RFC3339Nano), value, stack)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		return "", err
+	}
+	return path, prune(cfg.DumpDir, cfg.MaxDumps)
+}
+
+// prune removes the oldest stack dumps in dir beyond keep. Dump names start
+// with their time, so they sort oldest first.
+func prune(dir string, keep int) error {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	var dumps []string
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), dumpSuffix) {
+			dumps = append(dumps, e.Name())
+		}
+	}
+	slices.Sort(dumps)
+	for len(dumps) > keep {
+		if err := os.Remove(filepath.Join(dir, dumps[0])); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		dumps = dumps[1:]
+	}
+	return nil
+}
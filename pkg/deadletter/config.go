@@ -0,0 +1,58 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package deadletter
+
+import (
+	"errors"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/bytesize"
+)
+
+// DefaultMaxSize is the default size limit of a spool directory.
+const DefaultMaxSize = bytesize.GiB
+
+// Config defines the dead letter settings embedded by exporters.
+type Config struct {
+	// Directory holds the batches whose retries were exhausted. Exporters
+	// may share a directory. Empty disables the dead letter and the batches
+	// are dropped as before.
+	Directory string `mapstructure:"directory"`
+
+	// MaxSize bounds the total size of the files in Directory. The oldest
+	// batches are removed to make room for new ones.
+	// Default: 1GiB
+	MaxSize bytesize.Size `mapstructure:"max_size"`
+}
+
+// NewDefaultConfig returns the default dead letter settings (disabled).
+func NewDefaultConfig() Config {
+	return Config{MaxSize: DefaultMaxSize}
+}
+
+// Enabled reports whether a spool directory is configured.
+func (cfg *Config) Enabled() bool {
+	return cfg.Directory != ""
+}
+
+// Validate checks the configuration for errors.
+func (cfg *Config) Validate() error {
+	if !cfg.Enabled() {
+		return nil
+	}
+	if cfg.MaxSize <= 0 {
+		return errors.New("dead_letter.max_size must be positive")
+	}
+	return nil
+}
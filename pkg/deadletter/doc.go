@@ -0,0 +1,44 @@
+// Package deadletter keeps the batches an exporter gave up on for later
+// recovery.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// When an exporter exhausts its retry_on_failure settings, the sending
+// queue drops the batch and only a log line remains. An exporter with a
+// dead letter Spool writes the batch instead to a file in the spool
+// directory: a line of JSON naming the exporter, the signal, the time, the
+// last error and the number of records, followed by the batch as an OTLP
+// export request in protobuf. Each batch is a file of its own, named after
+// its time, signal and exporter, e.g.
+// "20261017T091058.141000000Z-logs-tfo.dlq". The files of the directory are
+// kept within max_size by removing the oldest ones; written and evicted
+// records are counted in tfo_exporter_dead_lettered and
+// tfo_exporter_dead_letter_evicted.
+//
+// List and Read inspect a spool directory, and Replay sends a batch to an
+// OTLP/HTTP receiver, e.g. of the same collector once the backend is
+// reachable again; the "deadletter" command of tfo-collector wraps them.
+//
+// Configuration example:
+//
+//	exporters:
+//	  tfo:
+//	    sending_queue:
+//	      enabled: true
+//	    dead_letter:
+//	      directory: /var/lib/tfo-collector/dead-letter
+//	      max_size: 1GiB
+package deadletter // import "github.com/telemetryflow/telemetryflow-collector/pkg/deadletter"
@@ -0,0 +1,159 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package deadletter
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Suffix is the file name extension of spooled batches. File names start
+// with the time the batch was written, so they sort oldest first.
+const Suffix = ".dlq"
+
+// Entry describes a batch in a spool directory. The file holds the entry as
+// a line of JSON, followed by the batch as an OTLP export request in
+// protobuf.
+type Entry struct {
+	// Path is the file holding the batch.
+	Path string `json:"path,omitempty"`
+
+	// Size is the size of the file in bytes.
+	Size int64 `json:"size,omitempty"`
+
+	// Exporter is the ID of the exporter that gave up on the batch.
+	Exporter string `json:"exporter"`
+
+	// Signal is the signal of the batch: traces, metrics or logs.
+	Signal string `json:"signal"`
+
+	// Time is when the batch was written.
+	Time time.Time `json:"time"`
+
+	// Error is the error of the last attempt to send the batch.
+	Error string `json:"error,omitempty"`
+
+	// Records is the number of spans, data points or log records.
+	Records int `json:"records"`
+}
+
+// List returns the batches in dir, oldest first. A missing directory has
+// no batches.
+func List(dir string) ([]Entry, error) {
+	files, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list dead letter directory: %w", err)
+	}
+	var names []string
+	for _, f := range files {
+		if !f.IsDir() && strings.HasSuffix(f.Name(), Suffix) {
+			names = append(names, f.Name())
+		}
+	}
+	slices.Sort(names)
+	entries := make([]Entry, 0, len(names))
+	for _, name := range names {
+		entry, _, err := readEntry(filepath.Join(dir, name), false)
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// Read returns the entry and the OTLP export request of the batch at path.
+func Read(path string) (Entry, []byte, error) {
+	return readEntry(path, true)
+}
+
+// Replay sends the batch at path to the OTLP/HTTP receiver at endpoint,
+// e.g. "http://localhost:4318", and returns its entry. The batch is left in
+// place; the caller removes it once replayed.
+func Replay(ctx context.Context, client *http.Client, endpoint, path string) (Entry, error) {
+	entry, payload, err := Read(path)
+	if err != nil {
+		return entry, err
+	}
+	url := strings.TrimSuffix(endpoint, "/")
+	if !strings.Contains(url, "://") {
+		url = "http://" + url
+	}
+	url += "/v1/" + entry.Signal
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return entry, err
+	}
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	resp, err := client.Do(req)
+	if err != nil {
+		return entry, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return entry, fmt.Errorf("POST %s: %s: %s", url, resp.Status, strings.TrimSpace(string(msg)))
+	}
+	return entry, nil
+}
+
+// readEntry parses the file at path, and returns its payload when
+// withPayload is set.
+func readEntry(path string, withPayload bool) (Entry, []byte, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return Entry{}, nil, err
+	}
+	defer func() { _ = f.Close() }()
+	info, err := f.Stat()
+	if err != nil {
+		return Entry{}, nil, err
+	}
+	r := bufio.NewReader(f)
+	header, err := r.ReadBytes('\n')
+	if err != nil {
+		return Entry{}, nil, fmt.Errorf("%s: not a dead letter batch: %w", path, err)
+	}
+	var entry Entry
+	if err := json.Unmarshal(header, &entry); err != nil {
+		return Entry{}, nil, fmt.Errorf("%s: not a dead letter batch: %w", path, err)
+	}
+	entry.Path, entry.Size = path, info.Size()
+	if !withPayload {
+		return entry, nil, nil
+	}
+	payload, err := io.ReadAll(r)
+	if err != nil {
+		return Entry{}, nil, fmt.Errorf("failed to read dead letter batch: %w", err)
+	}
+	return entry, payload, nil
+}
@@ -0,0 +1,202 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package deadletter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+const scopeName = "github.com/telemetryflow/telemetryflow-collector/pkg/deadletter"
+
+// dirMu serializes the writes and evictions of all spools of the process,
+// which may share a directory.
+var dirMu sync.Mutex
+
+// Spool writes the batches an exporter gave up on to a spool directory.
+type Spool struct {
+	cfg      Config
+	logger   *zap.Logger
+	exporter string
+	signal   pipeline.Signal
+
+	written metric.Int64Counter
+	evicted metric.Int64Counter
+	attrs   metric.MeasurementOption
+}
+
+// New returns the spool of the exporter id exporting signal. Written and
+// evicted records are recorded on set.MeterProvider under the exporter
+// labels.
+func New(cfg Config, set component.TelemetrySettings, id component.ID, signal pipeline.Signal) (*Spool, error) {
+	logger := set.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	s := &Spool{
+		cfg:      cfg,
+		logger:   logger,
+		exporter: id.String(),
+		signal:   signal,
+		attrs:    selfmetrics.Exporter(id, signal).Option(),
+	}
+	if set.MeterProvider == nil {
+		return s, nil
+	}
+	meter := set.MeterProvider.Meter(scopeName)
+	var err error
+	if s.written, err = meter.Int64Counter(selfmetrics.ExporterDeadLettered,
+		metric.WithDescription("Number of records written to the dead letter spool after their retries were exhausted."),
+		metric.WithUnit("{record}")); err != nil {
+		return nil, fmt.Errorf("failed to create dead letter spool: %w", err)
+	}
+	if s.evicted, err = meter.Int64Counter(selfmetrics.ExporterDeadLetterEvicted,
+		metric.WithDescription("Number of records removed from the dead letter spool to stay within its size limit."),
+		metric.WithUnit("{record}")); err != nil {
+		return nil, fmt.Errorf("failed to create dead letter spool: %w", err)
+	}
+	return s, nil
+}
+
+// Traces writes td, which failed to send with cause, to the spool.
+func (s *Spool) Traces(ctx context.Context, td ptrace.Traces, cause error) error {
+	payload, err := ptraceotlp.NewExportRequestFromTraces(td).MarshalProto()
+	if err != nil {
+		return fmt.Errorf("failed to encode dead letter batch: %w", err)
+	}
+	return s.write(ctx, payload, td.SpanCount(), cause)
+}
+
+// Metrics writes md, which failed to send with cause, to the spool.
+func (s *Spool) Metrics(ctx context.Context, md pmetric.Metrics, cause error) error {
+	payload, err := pmetricotlp.NewExportRequestFromMetrics(md).MarshalProto()
+	if err != nil {
+		return fmt.Errorf("failed to encode dead letter batch: %w", err)
+	}
+	return s.write(ctx, payload, md.DataPointCount(), cause)
+}
+
+// Logs writes ld, which failed to send with cause, to the spool.
+func (s *Spool) Logs(ctx context.Context, ld plog.Logs, cause error) error {
+	payload, err := plogotlp.NewExportRequestFromLogs(ld).MarshalProto()
+	if err != nil {
+		return fmt.Errorf("failed to encode dead letter batch: %w", err)
+	}
+	return s.write(ctx, payload, ld.LogRecordCount(), cause)
+}
+
+// write writes the OTLP request payload of records records as a new file,
+// evicting the oldest files of the directory to make room for it. The file
+// is renamed into place once complete, so List never sees a partial batch.
+func (s *Spool) write(ctx context.Context, payload []byte, records int, cause error) error {
+	now := time.Now().UTC()
+	entry := Entry{
+		Exporter: s.exporter,
+		Signal:   s.signal.String(),
+		Time:     now,
+		Records:  records,
+	}
+	if cause != nil {
+		entry.Error = cause.Error()
+	}
+	header, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode dead letter batch: %w", err)
+	}
+	content := make([]byte, 0, len(header)+1+len(payload))
+	content = append(append(append(content, header...), '\n'), payload...)
+	if limit := s.cfg.MaxSize.Bytes(); int64(len(content)) > limit {
+		return fmt.Errorf("batch of %d bytes exceeds the dead letter max_size of %s", len(content), s.cfg.MaxSize)
+	}
+
+	dirMu.Lock()
+	defer dirMu.Unlock()
+	if err := os.MkdirAll(s.cfg.Directory, 0o750); err != nil {
+		return fmt.Errorf("failed to create dead letter directory: %w", err)
+	}
+	if err := s.evict(ctx, s.cfg.MaxSize.Bytes()-int64(len(content))); err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%s-%s-%s%s", now.Format("20060102T150405.000000000Z"),
+		entry.Signal, strings.ReplaceAll(s.exporter, "/", "_"), Suffix)
+	path := filepath.Join(s.cfg.Directory, name)
+	if err := os.WriteFile(path+".tmp", content, 0o600); err != nil {
+		_ = os.Remove(path + ".tmp")
+		return fmt.Errorf("failed to write dead letter batch: %w", err)
+	}
+	if err := os.Rename(path+".tmp", path); err != nil {
+		_ = os.Remove(path + ".tmp")
+		return fmt.Errorf("failed to write dead letter batch: %w", err)
+	}
+	if s.written != nil {
+		s.written.Add(ctx, int64(records), s.attrs)
+	}
+	s.logger.Warn("Wrote batch to the dead letter spool",
+		zap.String("path", path),
+		zap.Int("records", records),
+		zap.Error(cause),
+	)
+	return nil
+}
+
+// evict removes the oldest batches of the directory until the others take
+// at most budget bytes.
+func (s *Spool) evict(ctx context.Context, budget int64) error {
+	entries, err := List(s.cfg.Directory)
+	if err != nil {
+		return err
+	}
+	var total int64
+	for _, e := range entries {
+		total += e.Size
+	}
+	for _, e := range entries {
+		if total <= budget {
+			break
+		}
+		if err := os.Remove(e.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("failed to evict dead letter batch: %w", err)
+		}
+		total -= e.Size
+		if s.evicted != nil {
+			s.evicted.Add(ctx, int64(e.Records), s.attrs)
+		}
+		s.logger.Warn("Evicted the oldest batch from the dead letter spool",
+			zap.String("path", e.Path),
+			zap.Int("records", e.Records),
+		)
+	}
+	return nil
+}
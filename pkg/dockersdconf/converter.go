@@ -0,0 +1,185 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package dockersdconf
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.uber.org/zap"
+
+	// Register docker_sd_configs with the Prometheus discovery mechanisms
+	// rather than relying on another import to do it.
+	_ "github.com/prometheus/prometheus/discovery/moby"
+)
+
+// DefaultHost is the Docker daemon address of docker_sd_configs entries
+// without a host.
+const DefaultHost = "unix:///var/run/docker.sock"
+
+// receiverType is the component type of the receivers whose scrape
+// configurations are converted.
+const receiverType = "prometheus"
+
+// NewConverterFactory returns a converter that applies the container label
+// convention to the docker_sd_configs jobs of the prometheus receivers.
+func NewConverterFactory() confmap.ConverterFactory {
+	return confmap.NewConverterFactory(func(set confmap.ConverterSettings) confmap.Converter {
+		logger := set.Logger
+		if logger == nil {
+			logger = zap.NewNop()
+		}
+		return &converter{logger: logger}
+	})
+}
+
+type converter struct {
+	logger *zap.Logger
+}
+
+func (c *converter) Convert(_ context.Context, conf *confmap.Conf) error {
+	jobs, err := Apply(conf)
+	if err != nil {
+		return err
+	}
+	for _, job := range jobs {
+		c.logger.Info("Docker label discovery applied",
+			zap.String("receiver", job.Receiver),
+			zap.String("job", job.Name),
+		)
+	}
+	return nil
+}
+
+// Job identifies a scrape job the label convention was applied to.
+type Job struct {
+	Receiver string
+	Name     string
+}
+
+// Apply fills in the host of docker_sd_configs entries without one and
+// adds the label convention relabeling to jobs with docker_sd_configs and
+// no relabel_configs, in every prometheus receiver of conf. It returns the
+// jobs given the convention.
+func Apply(conf *confmap.Conf) ([]Job, error) {
+	if !conf.IsSet("receivers") {
+		return nil, nil
+	}
+	sub, err := conf.Sub("receivers")
+	if err != nil {
+		return nil, fmt.Errorf("receivers: %w", err)
+	}
+	receivers := sub.ToStringMap()
+
+	var jobs []Job
+	changed := false
+	for _, id := range sortedKeys(receivers) {
+		typ, _, _ := strings.Cut(id, "/")
+		if typ != receiverType {
+			continue
+		}
+		rcv, _ := receivers[id].(map[string]any)
+		promConfig, _ := rcv["config"].(map[string]any)
+		scrapeConfigs, _ := promConfig["scrape_configs"].([]any)
+		for i, raw := range scrapeConfigs {
+			job, ok := raw.(map[string]any)
+			if !ok {
+				continue
+			}
+			sds, _ := job["docker_sd_configs"].([]any)
+			if len(sds) == 0 {
+				continue
+			}
+			for j, rawSD := range sds {
+				sd, ok := rawSD.(map[string]any)
+				if rawSD != nil && !ok {
+					return nil, fmt.Errorf("receivers::%s::config::scrape_configs::%d::docker_sd_configs::%d: expected a map, got %T", id, i, j, rawSD)
+				}
+				if sd == nil {
+					sd = map[string]any{}
+					sds[j] = sd
+				}
+				if host, _ := sd["host"].(string); host == "" {
+					sd["host"] = DefaultHost
+					changed = true
+				}
+			}
+			if _, set := job["relabel_configs"]; set {
+				continue
+			}
+			job["relabel_configs"] = Relabeling()
+			changed = true
+			name, _ := job["job_name"].(string)
+			jobs = append(jobs, Job{Receiver: id, Name: name})
+		}
+	}
+	if !changed {
+		return nil, nil
+	}
+
+	// Merging would replace the scrape_configs lists anyway, but deleting
+	// first keeps nothing of the old receivers behind.
+	conf.Delete("receivers")
+	if err := conf.Merge(confmap.NewFromStringMap(map[string]any{"receivers": receivers})); err != nil {
+		return nil, err
+	}
+	return jobs, nil
+}
+
+// Relabeling returns the relabel_configs implementing the label
+// convention, in the form of the prometheus receiver configuration.
+func Relabeling() []any {
+	return []any{
+		// Keep the containers labeled prometheus.scrape=true.
+		map[string]any{
+			"source_labels": []any{"__meta_docker_container_label_prometheus_scrape"},
+			"action":        "keep",
+			"regex":         "true",
+		},
+		// Scrape the prometheus.port of the container address. The targets
+		// of the other exposed ports become identical and are deduplicated.
+		map[string]any{
+			"source_labels": []any{"__address__", "__meta_docker_container_label_prometheus_port"},
+			"regex":         `(.+):\d+;(\d+)`,
+			"replacement":   "$1:$2",
+			"target_label":  "__address__",
+		},
+		map[string]any{
+			"source_labels": []any{"__meta_docker_container_label_prometheus_path"},
+			"regex":         "(.+)",
+			"target_label":  "__metrics_path__",
+		},
+		// Docker names containers with a leading slash.
+		map[string]any{
+			"source_labels": []any{"__meta_docker_container_name"},
+			"regex":         "/?(.+)",
+			"target_label":  "container",
+		},
+	}
+}
+
+// sortedKeys returns the keys of m in order, so jobs are reported
+// deterministically.
+func sortedKeys(m map[string]any) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,51 @@
+// Package dockersdconf discovers Prometheus scrape targets from Docker container labels.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// The prometheus receiver accepts docker_sd_configs in its scrape_configs,
+// as Prometheus does, listing one target per container port found through
+// the Docker API. Like Prometheus, it scrapes every target unless the job
+// relabels them. For single-host deployments without Kubernetes, a job
+// with docker_sd_configs and no relabel_configs of its own instead follows
+// a label convention on the containers:
+//   - prometheus.scrape: "true" opts the container in; other containers
+//     are dropped
+//   - prometheus.port: the port scraped, instead of every exposed port
+//   - prometheus.path: the metrics path, /metrics when unset
+//
+// The container name is added as the container label. A docker_sd_configs
+// entry without a host reads the local Docker socket
+// (unix:///var/run/docker.sock). Setting relabel_configs on the job, even
+// to an empty list, turns the convention off.
+//
+// The convention is applied by a confmap converter that Builder installs
+// by default, after the profile is merged.
+//
+// Example:
+//
+//	receivers:
+//	  prometheus:
+//	    config:
+//	      scrape_configs:
+//	        - job_name: docker
+//	          scrape_interval: 30s
+//	          docker_sd_configs:
+//	            - refresh_interval: 30s
+//
+// with a container started as:
+//
+//	docker run -l prometheus.scrape=true -l prometheus.port=9100 prom/node-exporter
+package dockersdconf // import "github.com/telemetryflow/telemetryflow-collector/pkg/dockersdconf"
@@ -0,0 +1,32 @@
+// Package errlog aggregates repeated error logs of a component.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// During an outage every failed request would log the same error line. An
+// Aggregator logs the first failure of each kind at error level, then one
+// summary per interval with the number of failures, how long they have been
+// going on and the last error, and a recovery line once the operation
+// succeeds again. Every individual failure is still logged at debug level.
+//
+// Example:
+//
+//	failures := errlog.New(logger, errlog.DefaultInterval)
+//	if err := next.ConsumeTraces(ctx, td); err != nil {
+//		failures.Error("Failed to consume traces", err)
+//	} else {
+//		failures.Success("Failed to consume traces")
+//	}
+package errlog // import "github.com/telemetryflow/telemetryflow-collector/pkg/errlog"
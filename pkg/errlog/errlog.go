@@ -0,0 +1,131 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package errlog
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// DefaultInterval is the default time between summaries of repeated
+// failures.
+const DefaultInterval = time.Minute
+
+// Aggregator rate-limits the error logs of one component. Failures are
+// grouped by their log message. A nil Aggregator logs nothing.
+type Aggregator struct {
+	logger   *zap.Logger
+	interval time.Duration
+	now      func() time.Time
+
+	// failing counts the messages currently failing, so Success is a single
+	// atomic load while everything works.
+	failing atomic.Int64
+
+	mu    sync.Mutex
+	state map[string]*failure
+}
+
+// failure tracks a run of consecutive failures of one message.
+type failure struct {
+	first       time.Time
+	lastSummary time.Time
+	total       int
+	suppressed  int
+	lastErr     error
+}
+
+// New returns an Aggregator logging to logger with a summary every interval.
+// A non-positive interval disables aggregation: every failure is logged.
+func New(logger *zap.Logger, interval time.Duration) *Aggregator {
+	return &Aggregator{
+		logger:   logger,
+		interval: interval,
+		now:      time.Now,
+		state:    make(map[string]*failure),
+	}
+}
+
+// Error records a failure. The first failure of msg after a success is
+// logged at error level with fields; later ones are logged at debug level
+// and summarized once per interval.
+func (a *Aggregator) Error(msg string, err error, fields ...zap.Field) {
+	if a == nil {
+		return
+	}
+	if a.interval <= 0 {
+		a.logger.Error(msg, append(fields, zap.Error(err))...)
+		return
+	}
+
+	now := a.now()
+	a.mu.Lock()
+	f, ok := a.state[msg]
+	if !ok {
+		a.state[msg] = &failure{first: now, lastSummary: now, total: 1, lastErr: err}
+		a.failing.Add(1)
+		a.mu.Unlock()
+		a.logger.Error(msg, append(fields, zap.Error(err))...)
+		return
+	}
+	f.total++
+	f.suppressed++
+	f.lastErr = err
+	var summary []zap.Field
+	if now.Sub(f.lastSummary) >= a.interval {
+		summary = []zap.Field{
+			zap.Int("count", f.suppressed),
+			zap.Int("total", f.total),
+			zap.Duration("duration", now.Sub(f.first)),
+			zap.NamedError("last_error", f.lastErr),
+		}
+		f.suppressed = 0
+		f.lastSummary = now
+	}
+	a.mu.Unlock()
+
+	if ce := a.logger.Check(zap.DebugLevel, msg); ce != nil {
+		ce.Write(append(fields, zap.Error(err))...)
+	}
+	if summary != nil {
+		a.logger.Error(msg+" (repeated)", summary...)
+	}
+}
+
+// Success ends a run of failures of msg, logging how many there were.
+func (a *Aggregator) Success(msg string) {
+	if a == nil || a.failing.Load() == 0 {
+		return
+	}
+	now := a.now()
+	a.mu.Lock()
+	f, ok := a.state[msg]
+	if ok {
+		delete(a.state, msg)
+		a.failing.Add(-1)
+	}
+	a.mu.Unlock()
+	if !ok {
+		return
+	}
+	a.logger.Info(msg+" (recovered)",
+		zap.Int("total", f.total),
+		zap.Duration("duration", now.Sub(f.first)),
+		zap.NamedError("last_error", f.lastErr),
+	)
+}
@@ -0,0 +1,7 @@
+module github.com/telemetryflow/telemetryflow-collector/pkg/errlog
+
+go 1.26
+
+require go.uber.org/zap v1.27.1
+
+require go.uber.org/multierr v1.10.0 // indirect
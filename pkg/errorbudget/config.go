@@ -0,0 +1,83 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errorbudget
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// DefaultBudget is how long sends may fail before the exporter is
+	// disabled.
+	DefaultBudget = 5 * time.Minute
+
+	// DefaultProbeInterval is how often a disabled exporter is probed.
+	DefaultProbeInterval = 30 * time.Second
+
+	// DefaultProbeTimeout bounds a single probe.
+	DefaultProbeTimeout = 10 * time.Second
+)
+
+// Config defines the error budget settings embedded by components.
+type Config struct {
+	// Enabled turns on the error budget.
+	// Default: false
+	Enabled bool `mapstructure:"enabled"`
+
+	// Budget is how long sends may keep failing, without any success in
+	// between, before the exporter is taken out of the fan-out.
+	// Default: 5m
+	Budget time.Duration `mapstructure:"budget"`
+
+	// ProbeInterval is how often the backend is probed while the exporter
+	// is disabled.
+	// Default: 30s
+	ProbeInterval time.Duration `mapstructure:"probe_interval"`
+
+	// ProbeTimeout bounds a single probe.
+	// Default: 10s
+	ProbeTimeout time.Duration `mapstructure:"probe_timeout"`
+}
+
+// NewDefaultConfig returns the default error budget settings (disabled).
+func NewDefaultConfig() Config {
+	return Config{
+		Budget:        DefaultBudget,
+		ProbeInterval: DefaultProbeInterval,
+		ProbeTimeout:  DefaultProbeTimeout,
+	}
+}
+
+// Validate checks the configuration for errors.
+func (cfg *Config) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.Budget <= 0 {
+		return errors.New("error_budget.budget must be positive")
+	}
+	if cfg.ProbeInterval <= 0 {
+		return errors.New("error_budget.probe_interval must be positive")
+	}
+	if cfg.ProbeTimeout <= 0 {
+		return errors.New("error_budget.probe_timeout must be positive")
+	}
+	if cfg.ProbeTimeout > cfg.ProbeInterval {
+		return errors.New("error_budget.probe_timeout must not be longer than error_budget.probe_interval")
+	}
+	return nil
+}
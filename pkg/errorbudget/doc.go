@@ -0,0 +1,36 @@
+// Package errorbudget takes a persistently failing exporter out of the fan-out.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// A Guard watches the outcome of every send. Once sends have failed without
+// a single success for longer than the budget, the guard disables the
+// exporter: its sends are dropped at once, so the other exporters of the
+// pipeline keep receiving data without waiting on queue space or retries.
+// While disabled, the guard probes the backend in the background every
+// probe_interval and re-enables the exporter after the first successful
+// probe. Both transitions are logged, counted and passed to the component's
+// hooks so they can be reported on the health endpoint.
+//
+// Configuration example:
+//
+//	exporters:
+//	  tfo:
+//	    error_budget:
+//	      enabled: true
+//	      budget: 5m
+//	      probe_interval: 30s
+//	      probe_timeout: 10s
+package errorbudget // import "github.com/telemetryflow/telemetryflow-collector/pkg/errorbudget"
@@ -0,0 +1,277 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errorbudget
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+const scopeName = "github.com/telemetryflow/telemetryflow-collector/pkg/errorbudget"
+
+// States recorded on the transitions metric.
+const (
+	StateDisabled = "disabled"
+	StateEnabled  = "enabled"
+)
+
+// ProbeFunc checks whether the backend accepts sends again. It is called
+// from the probe goroutine with a context bounded by the probe timeout.
+type ProbeFunc func(ctx context.Context) error
+
+// Transition describes an exporter leaving or rejoining the fan-out.
+type Transition struct {
+	// State is StateDisabled or StateEnabled.
+	State string
+
+	// Duration is how long sends had been failing when the exporter was
+	// disabled, or how long it was disabled when it is enabled again.
+	Duration time.Duration
+
+	// Dropped is the number of records dropped while disabled; zero on
+	// disable.
+	Dropped int64
+
+	// Err is the last send error on disable; nil on enable.
+	Err error
+}
+
+// Hooks are notified of transitions. Both fields are optional.
+type Hooks struct {
+	// OnDisabled is called when the exporter is taken out of the fan-out.
+	OnDisabled func(Transition)
+
+	// OnEnabled is called when the exporter rejoins the fan-out.
+	OnEnabled func(Transition)
+}
+
+// Guard tracks the error budget of one exporter. A nil *Guard is valid and
+// keeps the exporter enabled, so components can use it unconditionally.
+type Guard struct {
+	cfg    Config
+	logger *zap.Logger
+	probe  ProbeFunc
+	hooks  Hooks
+	labels selfmetrics.Labels
+
+	transitions metric.Int64Counter
+	dropped     metric.Int64Counter
+
+	disabled     atomic.Bool
+	droppedTotal atomic.Int64
+
+	mu           sync.Mutex
+	failingSince time.Time
+	disabledAt   time.Time
+
+	probeMu sync.Mutex
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// New creates the guard of the exporter identified by labels. probe is
+// called while the exporter is disabled; transitions are logged with
+// set.Logger and counted on set.MeterProvider.
+func New(cfg Config, set component.TelemetrySettings, labels selfmetrics.Labels, probe ProbeFunc, hooks Hooks) (*Guard, error) {
+	logger := set.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	g := &Guard{
+		cfg:    cfg,
+		logger: logger,
+		probe:  probe,
+		hooks:  hooks,
+		labels: labels,
+	}
+	if set.MeterProvider != nil {
+		meter := set.MeterProvider.Meter(scopeName)
+		var err error
+		g.transitions, err = meter.Int64Counter(selfmetrics.ErrorBudgetTransitions,
+			metric.WithDescription("Number of times an exporter left or rejoined the fan-out."),
+			metric.WithUnit("{transition}"))
+		if err != nil {
+			return nil, err
+		}
+		g.dropped, err = meter.Int64Counter(selfmetrics.ErrorBudgetDropped,
+			metric.WithDescription("Number of records dropped while the exporter was out of the fan-out."),
+			metric.WithUnit("{record}"))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return g, nil
+}
+
+// Start launches the probe loop.
+func (g *Guard) Start(_ context.Context) {
+	if g == nil {
+		return
+	}
+	g.stopCh = make(chan struct{})
+	g.wg.Add(1)
+	go func() {
+		defer g.wg.Done()
+		ticker := time.NewTicker(g.cfg.ProbeInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-g.stopCh:
+				return
+			case <-ticker.C:
+				g.Probe(context.Background())
+			}
+		}
+	}()
+}
+
+// Shutdown stops the probe loop and waits for an in-progress probe to
+// finish.
+func (g *Guard) Shutdown(_ context.Context) {
+	if g == nil || g.stopCh == nil {
+		return
+	}
+	close(g.stopCh)
+	g.wg.Wait()
+	g.stopCh = nil
+}
+
+// Enabled reports whether sends should go out. When it returns false the
+// caller drops the data and reports it with Drop.
+func (g *Guard) Enabled() bool {
+	return g == nil || !g.disabled.Load()
+}
+
+// Drop records n records dropped while the exporter is disabled.
+func (g *Guard) Drop(ctx context.Context, n int) {
+	if g == nil || n <= 0 {
+		return
+	}
+	g.droppedTotal.Add(int64(n))
+	if g.dropped != nil {
+		g.dropped.Add(ctx, int64(n), g.labels.Option())
+	}
+}
+
+// Record reports the outcome of a send. A success clears the failure clock
+// and re-enables a disabled exporter; a failure disables the exporter once
+// sends have been failing for longer than the budget. Cancellations are not
+// failures of the backend and are ignored.
+func (g *Guard) Record(err error) {
+	if g == nil || errors.Is(err, context.Canceled) {
+		return
+	}
+	now := time.Now()
+	g.mu.Lock()
+	if err == nil {
+		g.failingSince = time.Time{}
+		g.mu.Unlock()
+		g.enable(now)
+		return
+	}
+	if g.disabled.Load() {
+		g.mu.Unlock()
+		return
+	}
+	if g.failingSince.IsZero() {
+		g.failingSince = now
+	}
+	failingFor := now.Sub(g.failingSince)
+	if failingFor < g.cfg.Budget {
+		g.mu.Unlock()
+		return
+	}
+	g.disabled.Store(true)
+	g.disabledAt = now
+	g.droppedTotal.Store(0)
+	g.mu.Unlock()
+
+	g.report(Transition{State: StateDisabled, Duration: failingFor, Err: err})
+}
+
+// Probe checks the backend once if the exporter is disabled and re-enables
+// it on success. It is called by the probe loop and may be called directly
+// to force a check.
+func (g *Guard) Probe(ctx context.Context) {
+	if g == nil || !g.disabled.Load() || g.probe == nil {
+		return
+	}
+	g.probeMu.Lock()
+	defer g.probeMu.Unlock()
+
+	probeCtx, cancel := context.WithTimeout(ctx, g.cfg.ProbeTimeout)
+	err := g.probe(probeCtx)
+	cancel()
+	if err != nil {
+		g.logger.Debug("Error budget: probe failed", zap.Error(err))
+		return
+	}
+	g.Record(nil)
+}
+
+// enable puts a disabled exporter back into the fan-out.
+func (g *Guard) enable(now time.Time) {
+	g.mu.Lock()
+	if !g.disabled.Load() {
+		g.mu.Unlock()
+		return
+	}
+	g.disabled.Store(false)
+	g.failingSince = time.Time{}
+	disabledFor := now.Sub(g.disabledAt)
+	g.mu.Unlock()
+
+	g.report(Transition{State: StateEnabled, Duration: disabledFor, Dropped: g.droppedTotal.Load()})
+}
+
+func (g *Guard) report(t Transition) {
+	if g.transitions != nil {
+		g.transitions.Add(context.Background(), 1, g.labels.Option(attribute.String("state", t.State)))
+	}
+
+	if t.State == StateDisabled {
+		g.logger.Warn("Error budget exhausted: exporter removed from the fan-out",
+			zap.String("component", g.labels.String()),
+			zap.Duration("failing_for", t.Duration),
+			zap.Duration("probe_interval", g.cfg.ProbeInterval),
+			zap.Error(t.Err),
+		)
+		if g.hooks.OnDisabled != nil {
+			g.hooks.OnDisabled(t)
+		}
+		return
+	}
+
+	g.logger.Info("Error budget: exporter recovered and rejoined the fan-out",
+		zap.String("component", g.labels.String()),
+		zap.Duration("disabled_for", t.Duration),
+		zap.Int64("dropped", t.Dropped),
+	)
+	if g.hooks.OnEnabled != nil {
+		g.hooks.OnEnabled(t)
+	}
+}
@@ -0,0 +1,37 @@
+// Package experiment carries the observation of an A/B experiment arm through a
+// context.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// The tfomirror connector runs an experiment by passing the same batch to
+// two pipelines, each with its own processor chain. Before handing the
+// batch to a pipeline it stores a new Observation in the context; the
+// tfoexperiment exporter at the end of the pipeline adds what reached it.
+// Once the pipeline returns, the connector compares the observation with
+// the batch it sent to get the records dropped, the size delta and the
+// latency of the processor chain.
+//
+// The observation only travels with the context, so the pipelines of an
+// experiment must consume synchronously: processors that buffer data, such
+// as batch or tail_sampling, and exporters with a sending queue break the
+// link, and the tfoexperiment exporter then sees no observation.
+//
+// Example:
+//
+//	obs := new(experiment.Observation)
+//	err := next.ConsumeTraces(experiment.NewContext(ctx, obs), td)
+//	dropped := int64(td.SpanCount()) - obs.Records()
+package experiment // import "github.com/telemetryflow/telemetryflow-collector/pkg/experiment"
@@ -0,0 +1,57 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package experiment
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+type contextKey struct{}
+
+// Observation accumulates the records and bytes that reached the end of an
+// experiment pipeline. It is safe for concurrent use.
+type Observation struct {
+	records atomic.Int64
+	bytes   atomic.Int64
+}
+
+// Add records a batch of records holding bytes encoded bytes.
+func (o *Observation) Add(records, bytes int) {
+	o.records.Add(int64(records))
+	o.bytes.Add(int64(bytes))
+}
+
+// Records returns the number of records added.
+func (o *Observation) Records() int64 {
+	return o.records.Load()
+}
+
+// Bytes returns the number of bytes added.
+func (o *Observation) Bytes() int64 {
+	return o.bytes.Load()
+}
+
+// NewContext returns a copy of ctx carrying obs.
+func NewContext(ctx context.Context, obs *Observation) context.Context {
+	return context.WithValue(ctx, contextKey{}, obs)
+}
+
+// FromContext returns the observation carried by ctx, or nil if there is
+// none.
+func FromContext(ctx context.Context) *Observation {
+	obs, _ := ctx.Value(contextKey{}).(*Observation)
+	return obs
+}
@@ -0,0 +1,96 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package fileconf
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pelletier/go-toml/v2"
+)
+
+// templatePrefix marks top-level keys holding YAML anchors rather than
+// configuration.
+const templatePrefix = "x-"
+
+func decodeJSON(content []byte) (map[string]any, error) {
+	dec := json.NewDecoder(bytes.NewReader(content))
+	dec.UseNumber()
+
+	var raw map[string]any
+	if err := dec.Decode(&raw); err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); !errors.Is(err, io.EOF) {
+		return nil, errors.New("unexpected data after the top-level object")
+	}
+	return raw, nil
+}
+
+func decodeTOML(content []byte) (map[string]any, error) {
+	var raw map[string]any
+	if err := toml.Unmarshal(content, &raw); err != nil {
+		return nil, err
+	}
+	return raw, nil
+}
+
+// prepare drops the template keys of a decoded file and copies the rest
+// into the types confmap accepts.
+func prepare(raw map[string]any) map[string]any {
+	out := make(map[string]any, len(raw))
+	for k, v := range raw {
+		if strings.HasPrefix(k, templatePrefix) {
+			continue
+		}
+		out[k] = normalize(v)
+	}
+	return out
+}
+
+// normalize returns a deep copy of v, so values reached through several
+// YAML aliases are no longer shared. Integers become int as they do in
+// YAML, other JSON numbers float64, and TOML local dates and times strings.
+func normalize(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, e := range v {
+			out[k] = normalize(e)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, e := range v {
+			out[i] = normalize(e)
+		}
+		return out
+	case int64:
+		return int(v)
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return int(i)
+		}
+		f, _ := v.Float64()
+		return f
+	case toml.LocalDate, toml.LocalTime, toml.LocalDateTime:
+		return fmt.Sprint(v)
+	}
+	return v
+}
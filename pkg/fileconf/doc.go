@@ -0,0 +1,54 @@
+// Package fileconf loads collector configuration files in YAML, JSON or TOML.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// The provider replaces the upstream "file" provider. The format of a file
+// is chosen by its extension: .json files are decoded as JSON, .toml files
+// as TOML and everything else as YAML, so existing configurations keep
+// working. All formats share the collector schema. A configuration file
+// referenced inside a longer string, as in "labels=${file:labels.json}", is
+// embedded in its JSON form.
+//
+// YAML anchors and merge keys are supported. Two things make them usable
+// for templating:
+//   - top-level keys starting with "x-" are removed after decoding, so
+//     shared blocks can be declared next to the regular sections without
+//     otelcol rejecting them as unknown keys
+//   - every alias gets its own copy of the anchored value, so a later
+//     configuration source overriding one use of an anchor does not change
+//     the others
+//
+// Example:
+//
+//	x-tfo-exporter: &tfo-exporter
+//	  timeout: 10s
+//	  retry_on_failure:
+//	    enabled: true
+//
+//	exporters:
+//	  tfo/primary:
+//	    <<: *tfo-exporter
+//	    endpoint: https://primary.example.com
+//	  tfo/secondary:
+//	    <<: *tfo-exporter
+//	    endpoint: https://secondary.example.com
+//
+// The same exporters in TOML:
+//
+//	[exporters."tfo/primary"]
+//	endpoint = "https://primary.example.com"
+//	timeout = "10s"
+package fileconf // import "github.com/telemetryflow/telemetryflow-collector/pkg/fileconf"
@@ -0,0 +1,105 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package fileconf
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"go.opentelemetry.io/collector/confmap"
+)
+
+const schemeName = "file"
+
+// NewProviderFactory returns a provider for the "file" scheme that detects
+// the configuration format from the file extension.
+func NewProviderFactory() confmap.ProviderFactory {
+	return confmap.NewProviderFactory(func(confmap.ProviderSettings) confmap.Provider {
+		return &provider{}
+	})
+}
+
+type provider struct{}
+
+func (p *provider) Retrieve(_ context.Context, uri string, _ confmap.WatcherFunc) (*confmap.Retrieved, error) {
+	if !strings.HasPrefix(uri, schemeName+":") {
+		return nil, fmt.Errorf("%q uri is not supported by %q provider", uri, schemeName)
+	}
+
+	path := filepath.Clean(uri[len(schemeName)+1:])
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the file %v: %w", uri, err)
+	}
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		raw, err := decodeJSON(content)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse the JSON file %v: %w", uri, err)
+		}
+		return retrieved(prepare(raw))
+	case ".toml":
+		raw, err := decodeTOML(content)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse the TOML file %v: %w", uri, err)
+		}
+		return retrieved(prepare(raw))
+	}
+
+	// YAML keeps the upstream behavior, including falling back to a string
+	// for content that is not valid YAML, e.g. a secret embedded with
+	// ${file:token.txt}.
+	ret, err := confmap.NewRetrievedFromYAML(content)
+	if err != nil {
+		return nil, err
+	}
+	raw, err := ret.AsRaw()
+	if err != nil {
+		return nil, err
+	}
+	if m, ok := raw.(map[string]any); ok {
+		return retrieved(prepare(m))
+	}
+	return ret, nil
+}
+
+// retrieved returns m together with a string form, used when the file is
+// referenced inside a longer string such as "prefix ${file:x.json}". The
+// string form is m encoded as JSON, which NewRetrievedFromYAML decodes back
+// to m; only floats with an integral value come back as ints. A document
+// JSON cannot encode, e.g. a TOML nan, has no string form.
+func retrieved(m map[string]any) (*confmap.Retrieved, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(m); err != nil {
+		return confmap.NewRetrieved(m)
+	}
+	return confmap.NewRetrievedFromYAML(bytes.TrimSuffix(buf.Bytes(), []byte("\n")))
+}
+
+func (*provider) Scheme() string {
+	return schemeName
+}
+
+func (*provider) Shutdown(context.Context) error {
+	return nil
+}
@@ -0,0 +1,25 @@
+// Package loglevel overrides the level of the collector logs at runtime.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// The collector builds its logger once from service::telemetry::logs. The
+// registry wraps the logger core with WrapCore, so that Set can raise or
+// lower the level of every component logger without a configuration
+// reload, and Reset restores the configured level:
+//
+//	loglevel.Set(zapcore.DebugLevel)
+//	defer loglevel.Reset()
+package loglevel // import "github.com/telemetryflow/telemetryflow-collector/pkg/loglevel"
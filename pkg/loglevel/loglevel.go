@@ -0,0 +1,91 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package loglevel
+
+import (
+	"sync/atomic"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// override is the level replacing the configured one; nil when unset.
+var override atomic.Pointer[zapcore.Level]
+
+// Set overrides the level of the collector logs.
+func Set(level zapcore.Level) {
+	override.Store(&level)
+}
+
+// Reset restores the configured level.
+func Reset() {
+	override.Store(nil)
+}
+
+// Get returns the overridden level, or ok false when the configured level
+// applies.
+func Get() (level zapcore.Level, ok bool) {
+	if l := override.Load(); l != nil {
+		return *l, true
+	}
+	return 0, false
+}
+
+// WrapCore returns core with the level override applied. It is meant for
+// zap.WrapCore on the core built from the logs configuration, before
+// sampling or other wrappers are added.
+func WrapCore(core zapcore.Core) zapcore.Core {
+	return &levelCore{Core: core}
+}
+
+// levelCore checks entries against the override while it is set, and
+// against the wrapped core otherwise.
+type levelCore struct {
+	zapcore.Core
+}
+
+// Enabled implements zapcore.LevelEnabler.
+func (c *levelCore) Enabled(level zapcore.Level) bool {
+	if l, ok := Get(); ok {
+		return level >= l
+	}
+	return c.Core.Enabled(level)
+}
+
+// Level implements zapcore.LevelOf.
+func (c *levelCore) Level() zapcore.Level {
+	if l, ok := Get(); ok {
+		return l
+	}
+	return zapcore.LevelOf(c.Core)
+}
+
+// With implements zapcore.Core.
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCore{Core: c.Core.With(fields)}
+}
+
+// Check implements zapcore.Core. While the override is set the wrapped
+// core's own level is bypassed, so that a level below the configured one
+// takes effect.
+func (c *levelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	l, ok := Get()
+	if !ok {
+		return c.Core.Check(ent, ce)
+	}
+	if ent.Level >= l {
+		return ce.AddCore(ent, c.Core)
+	}
+	return ce
+}
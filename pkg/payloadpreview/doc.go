@@ -0,0 +1,43 @@
+// Package payloadpreview shares export payload previews between exporters
+// and the admin API that starts them.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// A preview records the next payloads an exporter sends, after all
+// processors ran and before compression, as OTLP JSON, so that backend
+// teams can inspect exactly what the collector sends. The tfo exporter
+// registers a Preview under its component ID and records payloads while
+// one is active; the tfosupport extension starts, reads and stops them
+// through its admin API.
+//
+// Previews are shared process-wide by name, so that the components,
+// created independently by the collector, find each other. A preview
+// stops once it recorded its limit or expired; its payloads stay readable
+// until the next preview starts.
+//
+// Example:
+//
+//	// in the exporter
+//	preview := payloadpreview.For("tfo/backend")
+//	if preview.Wants("logs") {
+//		preview.Record("logs", endpoint, len(payload), otlpJSON)
+//	}
+//
+//	// in the admin API
+//	if p, ok := payloadpreview.Lookup("tfo/backend"); ok {
+//		_, err := p.Start("logs", 5, 2*time.Minute)
+//	}
+package payloadpreview // import "github.com/telemetryflow/telemetryflow-collector/pkg/payloadpreview"
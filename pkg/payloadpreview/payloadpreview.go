@@ -0,0 +1,209 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package payloadpreview
+
+import (
+	"encoding/json"
+	"errors"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrActive is returned by Start while a preview is already active.
+var ErrActive = errors.New("a preview is already active")
+
+var (
+	registryMu sync.Mutex
+	registry   = make(map[string]*Preview)
+)
+
+// For returns the preview shared under exporter, the component ID of an
+// exporter, creating it on first use.
+func For(exporter string) *Preview {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	p, ok := registry[exporter]
+	if !ok {
+		p = &Preview{exporter: exporter}
+		registry[exporter] = p
+	}
+	return p
+}
+
+// Lookup returns the preview shared under exporter, if an exporter
+// registered it.
+func Lookup(exporter string) (*Preview, bool) {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	p, ok := registry[exporter]
+	return p, ok
+}
+
+// Exporters returns the sorted exporters that registered a preview.
+func Exporters() []string {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	names := make([]string, 0, len(registry))
+	for name := range registry {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Payload is an export payload recorded by a preview.
+type Payload struct {
+	Time     time.Time `json:"time"`
+	Signal   string    `json:"signal"`
+	Endpoint string    `json:"endpoint"`
+	// Size is the size of the serialized payload before compression.
+	Size int `json:"size"`
+	// Body is the payload as OTLP JSON.
+	Body json.RawMessage `json:"body"`
+}
+
+// Status describes the current or last preview of an exporter.
+type Status struct {
+	Exporter string `json:"exporter"`
+	// Signal restricts the preview to traces, metrics or logs; empty
+	// records every signal.
+	Signal    string    `json:"signal,omitempty"`
+	Limit     int       `json:"limit"`
+	Captured  int       `json:"captured"`
+	Active    bool      `json:"active"`
+	ExpiresAt time.Time `json:"expires_at"`
+	Payloads  []Payload `json:"payloads"`
+}
+
+// Preview records the next payloads of an exporter once started. A nil
+// Preview records nothing.
+type Preview struct {
+	exporter string
+
+	// armed lets Wants skip the lock while no preview is active.
+	armed atomic.Bool
+
+	mu     sync.Mutex
+	status *Status
+}
+
+// Start starts a preview recording the next count payloads of signal, or
+// of every signal when signal is empty, for at most d. The payloads of a
+// previous preview are discarded.
+func (p *Preview) Start(signal string, count int, d time.Duration) (Status, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.active() {
+		return p.snapshot(), ErrActive
+	}
+	p.status = &Status{
+		Exporter:  p.exporter,
+		Signal:    signal,
+		Limit:     count,
+		Active:    true,
+		ExpiresAt: time.Now().Add(d).UTC(),
+		Payloads:  []Payload{},
+	}
+	p.armed.Store(true)
+	return p.snapshot(), nil
+}
+
+// Wants reports whether the next payload of signal is to be recorded, so
+// that exporters only convert payloads while a preview is active.
+func (p *Preview) Wants(signal string) bool {
+	if p == nil || !p.armed.Load() {
+		return false
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.active() && (p.status.Signal == "" || p.status.Signal == signal)
+}
+
+// Record records a payload of signal sent to endpoint. size is the size
+// of the serialized payload and body its OTLP JSON form. The preview
+// stops once it recorded its limit.
+func (p *Preview) Record(signal, endpoint string, size int, body []byte) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.active() || (p.status.Signal != "" && p.status.Signal != signal) {
+		return
+	}
+	p.status.Payloads = append(p.status.Payloads, Payload{
+		Time:     time.Now().UTC(),
+		Signal:   signal,
+		Endpoint: endpoint,
+		Size:     size,
+		Body:     json.RawMessage(slices.Clone(body)),
+	})
+	p.status.Captured++
+	if p.status.Captured >= p.status.Limit {
+		p.stop()
+	}
+}
+
+// Status returns the current or last preview; ok is false if none was
+// started.
+func (p *Preview) Status() (status Status, ok bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.status == nil {
+		return Status{}, false
+	}
+	p.active()
+	return p.snapshot(), true
+}
+
+// Stop stops the active preview and returns it with the payloads recorded
+// so far; ok is false if none was started.
+func (p *Preview) Stop() (status Status, ok bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.status == nil {
+		return Status{}, false
+	}
+	p.stop()
+	return p.snapshot(), true
+}
+
+// active reports whether the preview is active, stopping it once expired.
+// Callers hold mu.
+func (p *Preview) active() bool {
+	if p.status == nil || !p.status.Active {
+		return false
+	}
+	if time.Now().After(p.status.ExpiresAt) {
+		p.stop()
+		return false
+	}
+	return true
+}
+
+// stop stops the preview. Callers hold mu.
+func (p *Preview) stop() {
+	p.status.Active = false
+	p.armed.Store(false)
+}
+
+// snapshot returns a copy of the status. Callers hold mu.
+func (p *Preview) snapshot() Status {
+	status := *p.status
+	status.Payloads = slices.Clone(status.Payloads)
+	return status
+}
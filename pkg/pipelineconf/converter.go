@@ -0,0 +1,59 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package pipelineconf
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.uber.org/zap"
+)
+
+// NewConverterFactory returns a converter that resolves the service
+// pipelines, failing on unknown references and logging processor order
+// warnings and unused components. It does not modify the configuration.
+func NewConverterFactory() confmap.ConverterFactory {
+	return confmap.NewConverterFactory(func(set confmap.ConverterSettings) confmap.Converter {
+		logger := set.Logger
+		if logger == nil {
+			logger = zap.NewNop()
+		}
+		return &converter{logger: logger}
+	})
+}
+
+type converter struct {
+	logger *zap.Logger
+}
+
+func (c *converter) Convert(_ context.Context, conf *confmap.Conf) error {
+	pipelines, err := Parse(conf)
+	if err != nil {
+		return err
+	}
+	for _, p := range pipelines {
+		for _, w := range CheckOrder(p) {
+			c.logger.Warn("Questionable processor order",
+				zap.String("pipeline", w.Pipeline),
+				zap.String("reason", w.Message),
+			)
+		}
+	}
+	for _, key := range Unused(conf, pipelines) {
+		c.logger.Warn("Component is configured but not used by the service; its settings have no effect",
+			zap.String("component", key))
+	}
+	return nil
+}
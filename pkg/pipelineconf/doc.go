@@ -0,0 +1,49 @@
+// Package pipelineconf resolves and checks the service pipelines of the collector configuration.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// otelcol reads the receivers, processors and exporters of a pipeline as
+// plain strings and only checks them once every component has been built.
+// Parse resolves them into component IDs up front, failing with the
+// pipeline and position of any malformed or unknown reference.
+//
+// CheckOrder reports processor orders that are valid but known to behave
+// badly:
+//   - memory_limiter is not the first processor, so the processors before
+//     it allocate memory the limiter cannot refuse
+//   - batch runs before a processor that drops data (filter, sampling,
+//     tfodedup), so batches shrink after they are formed
+//   - a sampler runs before tfoexempt, so it drops traces before they are
+//     exempted
+//
+// Unused lists the extensions, receivers, processors and exporters that are
+// configured but referenced by no pipeline or service::extensions; otelcol
+// never starts them, e.g. a prometheus receiver whose scrape_configs are
+// then never scraped.
+//
+// The checks are applied by a confmap converter that Builder installs by
+// default: unknown references stop the collector from starting, order
+// problems and unused components are logged as warnings.
+//
+// Example of a pipeline triggering both warnings:
+//
+//	service:
+//	  pipelines:
+//	    traces:
+//	      receivers: [tfootlp]
+//	      processors: [batch, memory_limiter, tail_sampling]
+//	      exporters: [tfo]
+package pipelineconf // import "github.com/telemetryflow/telemetryflow-collector/pkg/pipelineconf"
@@ -0,0 +1,77 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package pipelineconf
+
+import (
+	"fmt"
+	"slices"
+
+	"go.opentelemetry.io/collector/component"
+)
+
+// Processor types the order checks know about.
+const (
+	memoryLimiterType = "memory_limiter"
+	batchType         = "batch"
+	exemptType        = "tfoexempt"
+)
+
+// droppingTypes are processors that drop part of the data they receive.
+var droppingTypes = []string{"filter", "tail_sampling", "probabilistic_sampler", "tfodedup"}
+
+// samplingTypes are processors that honour the exemptions of tfoexempt.
+var samplingTypes = []string{"tail_sampling", "probabilistic_sampler"}
+
+// Warning is a processor order that is valid but known to behave badly.
+type Warning struct {
+	Pipeline string
+	Message  string
+}
+
+// CheckOrder returns the order problems of the processors of p.
+func CheckOrder(p Pipeline) []Warning {
+	var warnings []Warning
+	warn := func(format string, args ...any) {
+		warnings = append(warnings, Warning{Pipeline: p.ID.String(), Message: fmt.Sprintf(format, args...)})
+	}
+
+	for i, id := range p.Processors {
+		if id.Type().String() == memoryLimiterType && i > 0 {
+			warn("%s should be the first processor; %s runs before it", id, p.Processors[0])
+		}
+	}
+
+	if batch := indexOfType(p.Processors, batchType); batch >= 0 {
+		for _, id := range p.Processors[batch+1:] {
+			if slices.Contains(droppingTypes, id.Type().String()) {
+				warn("%s drops data and should run before %s", id, p.Processors[batch])
+			}
+		}
+	}
+
+	if exempt := indexOfType(p.Processors, exemptType); exempt >= 0 {
+		for _, id := range p.Processors[:exempt] {
+			if slices.Contains(samplingTypes, id.Type().String()) {
+				warn("%s samples before %s exempts traces and should run after it", id, p.Processors[exempt])
+			}
+		}
+	}
+	return warnings
+}
+
+// indexOfType returns the index of the first processor of type typ, or -1.
+func indexOfType(ids []component.ID, typ string) int {
+	return slices.IndexFunc(ids, func(id component.ID) bool { return id.Type().String() == typ })
+}
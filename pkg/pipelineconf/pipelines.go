@@ -0,0 +1,124 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package pipelineconf
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/pipeline"
+)
+
+// Pipeline is a service pipeline with its component references resolved.
+type Pipeline struct {
+	ID         pipeline.ID
+	Receivers  []component.ID
+	Processors []component.ID
+	Exporters  []component.ID
+}
+
+// Parse resolves the pipelines in the service::pipelines section of conf,
+// sorted by ID. It fails if a pipeline ID or component reference is
+// malformed, or refers to a component missing from its top-level section.
+// Connectors may be used as receivers and exporters.
+func Parse(conf *confmap.Conf) ([]Pipeline, error) {
+	raw, _ := conf.Get("service::pipelines").(map[string]any)
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	receivers := configured(conf, "receivers", "connectors")
+	processors := configured(conf, "processors")
+	exporters := configured(conf, "exporters", "connectors")
+
+	pipelines := make([]Pipeline, 0, len(raw))
+	for name, settings := range raw {
+		var p Pipeline
+		if err := p.ID.UnmarshalText([]byte(name)); err != nil {
+			return nil, fmt.Errorf("service::pipelines::%s: %w", name, err)
+		}
+		section, _ := settings.(map[string]any)
+		var err error
+		if p.Receivers, err = resolve(name, "receivers", section["receivers"], receivers); err != nil {
+			return nil, err
+		}
+		if p.Processors, err = resolve(name, "processors", section["processors"], processors); err != nil {
+			return nil, err
+		}
+		if p.Exporters, err = resolve(name, "exporters", section["exporters"], exporters); err != nil {
+			return nil, err
+		}
+		pipelines = append(pipelines, p)
+	}
+	slices.SortFunc(pipelines, func(a, b Pipeline) int {
+		return strings.Compare(a.ID.String(), b.ID.String())
+	})
+	return pipelines, nil
+}
+
+// configured returns the component IDs defined in the given top-level
+// sections of conf.
+func configured(conf *confmap.Conf, sections ...string) map[component.ID]bool {
+	ids := make(map[component.ID]bool)
+	for _, section := range sections {
+		components, _ := conf.Get(section).(map[string]any)
+		for name := range components {
+			var id component.ID
+			if err := id.UnmarshalText([]byte(name)); err == nil {
+				ids[id] = true
+			}
+		}
+	}
+	return ids
+}
+
+// resolve parses the references of one list of a pipeline.
+func resolve(pipelineName, kind string, raw any, known map[component.ID]bool) ([]component.ID, error) {
+	if raw == nil {
+		return nil, nil
+	}
+	list, ok := raw.([]any)
+	if !ok {
+		return nil, fmt.Errorf("service::pipelines::%s::%s: must be a list of component IDs", pipelineName, kind)
+	}
+
+	ids := make([]component.ID, 0, len(list))
+	for i, entry := range list {
+		name, ok := entry.(string)
+		if !ok {
+			return nil, fmt.Errorf("service::pipelines::%s::%s[%d]: must be a component ID, got %v", pipelineName, kind, i, entry)
+		}
+		var id component.ID
+		if err := id.UnmarshalText([]byte(name)); err != nil {
+			return nil, fmt.Errorf("service::pipelines::%s::%s[%d]: %w", pipelineName, kind, i, err)
+		}
+		if !known[id] {
+			return nil, fmt.Errorf("service::pipelines::%s::%s[%d]: %q is not configured in the %s section", pipelineName, kind, i, name, section(kind))
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// section names the top-level section a pipeline list refers to.
+func section(kind string) string {
+	if kind == "processors" {
+		return kind
+	}
+	return kind + " or connectors"
+}
@@ -0,0 +1,66 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package pipelineconf
+
+import (
+	"slices"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+)
+
+// Unused returns the keys, e.g. "receivers::prometheus", of the extensions,
+// receivers, processors and exporters configured in conf that no pipeline
+// or service::extensions refers to. otelcol does not build them, so their
+// settings have no effect. Connectors are left out, as otelcol rejects
+// those used on one side only.
+func Unused(conf *confmap.Conf, pipelines []Pipeline) []string {
+	used := map[string]map[component.ID]bool{
+		"extensions": {},
+		"receivers":  {},
+		"processors": {},
+		"exporters":  {},
+	}
+	for _, p := range pipelines {
+		for _, id := range p.Receivers {
+			used["receivers"][id] = true
+		}
+		for _, id := range p.Processors {
+			used["processors"][id] = true
+		}
+		for _, id := range p.Exporters {
+			used["exporters"][id] = true
+		}
+	}
+	extensions, _ := conf.Get("service::extensions").([]any)
+	for _, entry := range extensions {
+		name, _ := entry.(string)
+		var id component.ID
+		if err := id.UnmarshalText([]byte(name)); err == nil {
+			used["extensions"][id] = true
+		}
+	}
+
+	var unused []string
+	for _, section := range []string{"extensions", "receivers", "processors", "exporters"} {
+		for id := range configured(conf, section) {
+			if !used[section][id] {
+				unused = append(unused, section+confmap.KeyDelimiter+id.String())
+			}
+		}
+	}
+	slices.Sort(unused)
+	return unused
+}
@@ -0,0 +1,144 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package preflight
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tforetentionexporter"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/bytesize"
+)
+
+// errUnsupported is returned by platform checks not available on this
+// operating system.
+var errUnsupported = errors.New("not supported on this platform")
+
+// checkDisk checks that the directory of every tforetention exporter has
+// room for its ring buffer to grow to max_size, and that the directories of
+// file_storage extensions, which hold persistent sending queues, are
+// usable.
+func checkDisk(used []usedComponent) []Check {
+	var checks []Check
+	for _, c := range used {
+		switch cfg := c.cfg.(type) {
+		case *tforetentionexporter.Config:
+			if cfg.Directory != "" {
+				checks = append(checks, checkDirectory(c.key(), cfg.Directory, cfg.MaxSizeBytes()))
+			}
+		case *filestorage.Config:
+			checks = append(checks, checkStorageDirectory(c.key()+"::directory", cfg.Directory, cfg.CreateDirectory))
+			if cfg.Compaction != nil && cfg.Compaction.Directory != "" && cfg.Compaction.Directory != cfg.Directory {
+				checks = append(checks, checkStorageDirectory(c.key()+"::compaction::directory", cfg.Compaction.Directory, cfg.CreateDirectory))
+			}
+		}
+	}
+	return checks
+}
+
+// checkStorageDirectory checks that dir, set at key, is a directory or, with
+// create, can be created, and reports the space free on it. file_storage
+// does not bound the size of its files, so no amount is required.
+func checkStorageDirectory(key, dir string, create bool) Check {
+	check := Check{Name: "disk", Target: dir}
+	info, err := os.Stat(dir)
+	switch {
+	case err == nil && !info.IsDir():
+		check.Err = fmt.Errorf("%s is not a directory; change %s", dir, key)
+		return check
+	case errors.Is(err, fs.ErrNotExist) && !create:
+		check.Err = fmt.Errorf("%s does not exist; create it, set create_directory or change %s", dir, key)
+		return check
+	case err != nil && !errors.Is(err, fs.ErrNotExist):
+		check.Err = fmt.Errorf("cannot access %s: %w; fix its permissions or change %s", dir, err, key)
+		return check
+	}
+
+	free, err := freeSpace(existingParent(dir))
+	if err != nil {
+		check.Detail = "not checked: " + err.Error()
+		return check
+	}
+	check.Detail = fmt.Sprintf("%s free", bytesize.Size(free))
+	return check
+}
+
+// checkDirectory checks that dir, created on demand, can hold size bytes
+// on top of the files already in it.
+func checkDirectory(key, dir string, size int64) Check {
+	check := Check{Name: "disk", Target: dir}
+	info, err := os.Stat(dir)
+	switch {
+	case err == nil && !info.IsDir():
+		check.Err = fmt.Errorf("%s is not a directory; change %s::directory", dir, key)
+		return check
+	case err != nil && !errors.Is(err, fs.ErrNotExist):
+		check.Err = fmt.Errorf("cannot access %s: %w; fix its permissions or change %s::directory", dir, err, key)
+		return check
+	}
+
+	needed := max(size-dirSize(dir), 0)
+	free, err := freeSpace(existingParent(dir))
+	if err != nil {
+		check.Detail = "not checked: " + err.Error()
+		return check
+	}
+	if free < needed {
+		check.Err = fmt.Errorf("%s free but the ring buffer needs %s more to reach max_size %s; free disk space or lower %s::max_size",
+			bytesize.Size(free), bytesize.Size(needed), bytesize.Size(size), key)
+		return check
+	}
+	check.Detail = fmt.Sprintf("%s free, %s needed", bytesize.Size(free), bytesize.Size(needed))
+	return check
+}
+
+// dirSize returns the total size of the regular files under dir, or zero
+// when it does not exist.
+func dirSize(dir string) int64 {
+	var total int64
+	_ = filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.Type().IsRegular() {
+			if info, err := d.Info(); err == nil {
+				total += info.Size()
+			}
+		}
+		return nil
+	})
+	return total
+}
+
+// existingParent returns dir or its closest existing ancestor, which holds
+// the file system dir will be created on.
+func existingParent(dir string) string {
+	dir = filepath.Clean(dir)
+	for {
+		if _, err := os.Stat(dir); err == nil {
+			return dir
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return dir
+		}
+		dir = parent
+	}
+}
@@ -0,0 +1,42 @@
+// Package preflight checks ports, disk space and file limits before startup.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Run resolves the configuration the way the collector does and checks,
+// for the receivers, exporters and extensions used by the service:
+//   - that every listen endpoint of the components that listen (the otlp,
+//     tfootlp, jaeger, zipkin and tfocoap receivers, the prometheus and
+//     tfoprometheus exporters and the health_check, pprof and zpages
+//     extensions) is free and may be bound by this process. Endpoints of
+//     other components, e.g. the servers of the redis or postgresql
+//     scrapers, are addresses the collector connects to and are skipped
+//   - that the directory of every tforetention exporter has enough free
+//     space for its ring buffer to grow to max_size
+//   - that the directories of file_storage extensions exist, or are created
+//     with create_directory
+//   - that the open file limit leaves room for the expected client
+//     connections, the listeners and the export workers
+//
+// Each failure carries an actionable message naming the configuration key
+// to change or the limit to raise. The collector runs the checks before
+// starting unless --skip-preflight is set, and they back the
+// "tfo-collector preflight" command:
+//
+//	tfo-collector preflight --config configs/tfo-collector.yaml --expected-connections 5000
+//
+// Endpoints that cannot be bound for other reasons, e.g. an address of
+// another host, are left to the component that uses them.
+package preflight // import "github.com/telemetryflow/telemetryflow-collector/pkg/preflight"
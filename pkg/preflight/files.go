@@ -0,0 +1,79 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package preflight
+
+import "fmt"
+
+// reservedFiles are the descriptors the collector needs besides client
+// connections, listeners and export workers: log and retention files, DNS
+// lookups and its own telemetry.
+const reservedFiles = 64
+
+// checkOpenFiles checks that the open file limit leaves room for the
+// expected client connections, the listeners and one connection per export
+// worker.
+func checkOpenFiles(used []usedComponent, listeners, connections int) Check {
+	check := Check{Name: "open files", Target: "RLIMIT_NOFILE"}
+	workers := exportWorkers(used)
+	needed := connections + listeners + workers + reservedFiles
+
+	limit, err := openFileLimit()
+	if err != nil {
+		check.Detail = "not checked: " + err.Error()
+		return check
+	}
+	if limit < needed {
+		check.Err = fmt.Errorf("the open file limit is %d but %d descriptors are expected (%d connections, %d listeners, %d export workers, %d reserved); "+
+			"raise it with \"ulimit -n %d\" or LimitNOFILE=%d in the systemd unit, or lower --expected-connections",
+			limit, needed, connections, listeners, workers, reservedFiles, needed, needed)
+		return check
+	}
+	check.Detail = fmt.Sprintf("limit %d, %d expected", limit, needed)
+	return check
+}
+
+// exportWorkers returns the number of concurrent sends of the used
+// exporters: the num_consumers of an enabled sending queue, or one.
+func exportWorkers(used []usedComponent) int {
+	workers := 0
+	for _, c := range used {
+		if c.section != "exporters" {
+			continue
+		}
+		n := 1
+		if queue, ok := c.settings["sending_queue"].(map[string]any); ok && queue["enabled"] != false {
+			if consumers := toInt(queue["num_consumers"]); consumers > 0 {
+				n = consumers
+			}
+		}
+		workers += n
+	}
+	return workers
+}
+
+// toInt converts a numeric configuration value to an int, or returns zero.
+func toInt(v any) int {
+	switch n := v.(type) {
+	case int:
+		return n
+	case int64:
+		return int(n)
+	case uint64:
+		return int(n)
+	case float64:
+		return int(n)
+	}
+	return 0
+}
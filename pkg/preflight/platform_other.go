@@ -0,0 +1,27 @@
+//go:build !linux && !darwin
+
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package preflight
+
+// freeSpace is not supported on this platform.
+func freeSpace(string) (int64, error) {
+	return 0, errUnsupported
+}
+
+// openFileLimit is not supported on this platform.
+func openFileLimit() (int, error) {
+	return 0, errUnsupported
+}
@@ -0,0 +1,148 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package preflight
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"net"
+	"os"
+	"slices"
+	"strings"
+	"syscall"
+
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+)
+
+// listeningTypes are the component types, by configuration section, whose
+// "endpoint" settings are addresses the collector listens on. Endpoints of
+// other components, such as the servers scrapers and exporters connect to,
+// are not checked.
+var listeningTypes = map[string][]string{
+	"receivers":  {"otlp", "tfootlp", "jaeger", "zipkin", "tfocoap"},
+	"exporters":  {"prometheus", "tfoprometheus"},
+	"extensions": {"health_check", "pprof", "zpages"},
+}
+
+// listener is a listen endpoint found in the configuration.
+type listener struct {
+	// key is the configuration key of the endpoint, e.g.
+	// "receivers::otlp::protocols::grpc::endpoint".
+	key      string
+	endpoint string
+	udp      bool
+}
+
+// listenEndpoints returns the listen endpoints of the used components of a
+// listening type: every "endpoint" setting holding a host:port, on UDP when
+// a sibling "transport" setting says so. URLs, port zero and settings
+// blocks with "enabled: false" are skipped.
+func listenEndpoints(used []usedComponent) []listener {
+	var listeners []listener
+	for _, c := range used {
+		if !slices.Contains(listeningTypes[c.section], c.id.Type().String()) {
+			continue
+		}
+		listeners = append(listeners, findEndpoints(c.key(), c.settings)...)
+	}
+	return listeners
+}
+
+// findEndpoints walks settings, found at key, for listen endpoints.
+func findEndpoints(key string, settings map[string]any) []listener {
+	if settings["enabled"] == false {
+		return nil
+	}
+	var listeners []listener
+	if endpoint, ok := settings["endpoint"].(string); ok && isListenAddress(endpoint) {
+		transport, _ := settings["transport"].(string)
+		listeners = append(listeners, listener{
+			key:      key + confmap.KeyDelimiter + "endpoint",
+			endpoint: endpoint,
+			udp:      strings.HasPrefix(transport, "udp"),
+		})
+	}
+	for _, name := range slices.Sorted(maps.Keys(settings)) {
+		switch v := settings[name].(type) {
+		case map[string]any:
+			listeners = append(listeners, findEndpoints(key+confmap.KeyDelimiter+name, v)...)
+		case []any:
+			for i, item := range v {
+				if m, ok := item.(map[string]any); ok {
+					listeners = append(listeners, findEndpoints(fmt.Sprintf("%s%s%s[%d]", key, confmap.KeyDelimiter, name, i), m)...)
+				}
+			}
+		}
+	}
+	return listeners
+}
+
+// isListenAddress reports whether endpoint is a host:port with a fixed port.
+func isListenAddress(endpoint string) bool {
+	if strings.Contains(endpoint, "://") {
+		return false
+	}
+	_, port, err := net.SplitHostPort(endpoint)
+	return err == nil && port != "" && port != "0"
+}
+
+// previousProcessHandsOver reports whether listener handoff is enabled and a
+// running collector serves its handoff socket, which then hands its ports
+// over and stops. The socket is not dialed, as connecting starts the
+// handoff.
+func previousProcessHandsOver(conf *confmap.Conf) bool {
+	cfg, err := serverconf.LoadHandoffConfig(conf)
+	if err != nil || !cfg.Enabled {
+		return false
+	}
+	info, err := os.Stat(cfg.SocketPath())
+	return err == nil && info.Mode().Type() == fs.ModeSocket
+}
+
+// checkPort binds and releases the endpoint of l. Only failures the
+// collector would hit for certain, a port in use or not permitted, are
+// reported. With handingOver, a port in use is expected to be freed by the
+// previous process.
+func checkPort(l listener, handingOver bool) Check {
+	check := Check{Name: "port", Target: l.endpoint, Detail: "free"}
+	var err error
+	if l.udp {
+		var conn net.PacketConn
+		if conn, err = net.ListenPacket("udp", l.endpoint); err == nil {
+			_ = conn.Close()
+		}
+	} else {
+		var lis net.Listener
+		if lis, err = net.Listen("tcp", l.endpoint); err == nil {
+			_ = lis.Close()
+		}
+	}
+	switch {
+	case err == nil:
+	case errors.Is(err, syscall.EADDRINUSE) && handingOver:
+		check.Detail = "in use by the previous process, which hands over and stops"
+	case errors.Is(err, syscall.EADDRINUSE):
+		check.Err = fmt.Errorf("%s is already in use; stop the process holding it or change %s", l.endpoint, l.key)
+	case errors.Is(err, syscall.EACCES):
+		check.Err = fmt.Errorf("binding %s is not permitted; use a port above 1023, grant CAP_NET_BIND_SERVICE or change %s", l.endpoint, l.key)
+	default:
+		check.Detail = "not checked: " + err.Error()
+	}
+	return check
+}
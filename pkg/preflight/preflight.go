@@ -0,0 +1,265 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package preflight
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"text/tabwriter"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/otelcol"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/presetconf"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/profileconf"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/registry"
+)
+
+// DefaultExpectedConnections is the number of concurrent client
+// connections the open file limit must leave room for when
+// Options.ExpectedConnections is zero.
+const DefaultExpectedConnections = 1000
+
+// Options configures a preflight check.
+type Options struct {
+	// ConfigURIs are the configuration locations, as accepted by the
+	// collector's --config flag.
+	ConfigURIs []string
+
+	// Profile selects the configuration profile, as accepted by the
+	// collector's --profile flag.
+	Profile string
+
+	// ExpectedConnections is the number of concurrent client connections
+	// the receivers are expected to hold. DefaultExpectedConnections is
+	// used when zero.
+	ExpectedConnections int
+}
+
+// Check is the outcome of one preflight check.
+type Check struct {
+	// Name identifies the check: "port", "disk" or "open files".
+	Name string
+
+	// Target is what was checked, e.g. an endpoint or a directory.
+	Target string
+
+	// Detail describes a passed check.
+	Detail string
+
+	// Err is nil when the check passed. Its message says how to fix the
+	// problem.
+	Err error
+}
+
+// Report is the outcome of a preflight check.
+type Report struct {
+	// Checks lists the checks made, in order.
+	Checks []Check
+}
+
+// Passed reports whether every check passed.
+func (r *Report) Passed() bool {
+	for _, c := range r.Checks {
+		if c.Err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Print writes a human-readable summary of the report to w.
+func (r *Report) Print(w io.Writer) {
+	_, _ = fmt.Fprintln(w, "Preflight checks")
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, c := range r.Checks {
+		result, detail := "PASS", c.Detail
+		if c.Err != nil {
+			result, detail = "FAIL", c.Err.Error()
+		}
+		_, _ = fmt.Fprintf(tw, "  %s\t%s\t%s\t%s\n", result, c.Name, c.Target, detail)
+	}
+	_ = tw.Flush()
+	if r.Passed() {
+		_, _ = fmt.Fprintln(w, "Result: PASS")
+	} else {
+		_, _ = fmt.Fprintln(w, "Result: FAIL")
+	}
+}
+
+// Run performs the preflight checks of the configuration described by
+// opts. It returns an error when the checks cannot be run, e.g. the
+// configuration does not load; failed checks are reported in the Report
+// instead.
+func Run(ctx context.Context, opts Options) (*Report, error) {
+	if opts.ExpectedConnections <= 0 {
+		opts.ExpectedConnections = DefaultExpectedConnections
+	}
+	conf, err := loadConfig(ctx, opts.ConfigURIs, opts.Profile)
+	if err != nil {
+		return nil, err
+	}
+	factories, err := registry.Default().Factories()
+	if err != nil {
+		return nil, err
+	}
+	used, err := usedComponents(conf, factories)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &Report{}
+	listeners := listenEndpoints(used)
+	handingOver := previousProcessHandsOver(conf)
+	for _, l := range listeners {
+		report.Checks = append(report.Checks, checkPort(l, handingOver))
+	}
+	report.Checks = append(report.Checks, checkDisk(used)...)
+	report.Checks = append(report.Checks, checkOpenFiles(used, len(listeners), opts.ExpectedConnections))
+	return report, nil
+}
+
+// loadConfig resolves the configuration the way the collector does, with the
+// profile and preset converters but without the runtime converter.
+func loadConfig(ctx context.Context, uris []string, profile string) (*confmap.Conf, error) {
+	if len(uris) == 0 {
+		return nil, errors.New("at least one config file must be provided")
+	}
+	set := registry.Builder{
+		ConfigURIs: uris,
+		ConverterFactories: []confmap.ConverterFactory{
+			profileconf.NewConverterFactory(profile),
+			presetconf.NewConverterFactory(),
+		},
+	}.Settings()
+	resolver, err := confmap.NewResolver(set.ConfigProviderSettings.ResolverSettings)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resolver.Shutdown(ctx) }()
+	conf, err := resolver.Resolve(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	return conf, nil
+}
+
+// usedComponent is a receiver, exporter or extension used by the service,
+// with its configuration decoded over the factory defaults.
+type usedComponent struct {
+	// section is "receivers", "exporters" or "extensions".
+	section string
+	id      component.ID
+
+	// cfg is the decoded configuration, or nil when the component type is
+	// unknown or its configuration does not decode.
+	cfg component.Config
+
+	// settings is cfg as a map, or the configuration as written when cfg
+	// is nil.
+	settings map[string]any
+}
+
+// key returns the configuration key of the component, e.g.
+// "receivers::otlp".
+func (c usedComponent) key() string {
+	return c.section + confmap.KeyDelimiter + c.id.String()
+}
+
+// serviceConfig is the part of the service section naming the components
+// the collector starts.
+type serviceConfig struct {
+	Extensions []component.ID `mapstructure:"extensions"`
+	Pipelines  map[string]struct {
+		Receivers []component.ID `mapstructure:"receivers"`
+		Exporters []component.ID `mapstructure:"exporters"`
+	} `mapstructure:"pipelines"`
+}
+
+// defaultConfiger creates the default configuration of a component type.
+type defaultConfiger interface {
+	CreateDefaultConfig() component.Config
+}
+
+// usedComponents returns the receivers, exporters and extensions of conf
+// that the service starts: extensions in service order, then receivers and
+// exporters by pipeline name.
+// Connectors, which appear among pipeline receivers and exporters, are
+// skipped.
+func usedComponents(conf *confmap.Conf, factories otelcol.Factories) ([]usedComponent, error) {
+	sub, err := conf.Sub("service")
+	if err != nil {
+		return nil, err
+	}
+	var svc serviceConfig
+	if err := sub.Unmarshal(&svc, confmap.WithIgnoreUnused()); err != nil {
+		return nil, fmt.Errorf("service: %w", err)
+	}
+
+	var used []usedComponent
+	seen := make(map[string]bool)
+	add := func(section string, id component.ID, factory defaultConfiger) {
+		c := usedComponent{section: section, id: id}
+		if seen[c.key()] || !conf.IsSet(c.key()) {
+			return
+		}
+		seen[c.key()] = true
+		c.cfg, c.settings = decode(conf, c.key(), factory)
+		used = append(used, c)
+	}
+	for _, id := range svc.Extensions {
+		add("extensions", id, factories.Extensions[id.Type()])
+	}
+	pipelines := slices.Sorted(maps.Keys(svc.Pipelines))
+	for _, name := range pipelines {
+		for _, id := range svc.Pipelines[name].Receivers {
+			add("receivers", id, factories.Receivers[id.Type()])
+		}
+	}
+	for _, name := range pipelines {
+		for _, id := range svc.Pipelines[name].Exporters {
+			add("exporters", id, factories.Exporters[id.Type()])
+		}
+	}
+	return used, nil
+}
+
+// decode decodes the configuration at key over the defaults of factory and
+// returns it with its map form, so that defaults such as listen endpoints
+// are visible. The configuration as written is returned when it does not
+// decode; the collector reports that error itself.
+func decode(conf *confmap.Conf, key string, factory defaultConfiger) (component.Config, map[string]any) {
+	sub, err := conf.Sub(key)
+	if err != nil {
+		return nil, nil
+	}
+	if factory == nil {
+		return nil, sub.ToStringMap()
+	}
+	cfg := factory.CreateDefaultConfig()
+	if err := sub.Unmarshal(cfg); err != nil {
+		return nil, sub.ToStringMap()
+	}
+	out := confmap.New()
+	if err := out.Marshal(cfg); err != nil {
+		return cfg, sub.ToStringMap()
+	}
+	return cfg, out.ToStringMap()
+}
@@ -0,0 +1,202 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package presetconf
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.uber.org/zap"
+)
+
+// SectionKey is the top-level configuration key selecting the preset.
+const SectionKey = "preset"
+
+// runtimeEnv maps the keys of the runtime section to the environment
+// variables that take precedence over preset values.
+var runtimeEnv = map[string]string{
+	"gomaxprocs": "GOMAXPROCS",
+	"gomemlimit": "GOMEMLIMIT",
+	"gc_percent": "GOGC",
+}
+
+// preset holds the defaults of a device class.
+type preset struct {
+	// runtime holds defaults for the runtime section.
+	runtime map[string]any
+	// components holds defaults for configured components.
+	components []componentDefaults
+	// extensions are enabled when the configuration does not list
+	// service::extensions itself.
+	extensions []string
+}
+
+// componentDefaults are defaults for every configured component of the
+// given kind and types.
+type componentDefaults struct {
+	kind  string
+	types []string
+	// requires limits the defaults to components that set this key.
+	requires string
+	// groups hold settings keyed by "::" separated paths below the
+	// component. A group applies as a whole, and only to components that
+	// set none of its keys; keys with a nil value only guard the group.
+	groups []map[string]any
+}
+
+// NewConverterFactory returns a converter that applies the preset selected
+// by the preset key under the configuration and removes the key.
+func NewConverterFactory() confmap.ConverterFactory {
+	return confmap.NewConverterFactory(func(set confmap.ConverterSettings) confmap.Converter {
+		logger := set.Logger
+		if logger == nil {
+			logger = zap.NewNop()
+		}
+		return &converter{logger: logger}
+	})
+}
+
+type converter struct {
+	logger *zap.Logger
+}
+
+func (c *converter) Convert(_ context.Context, conf *confmap.Conf) error {
+	name, err := Apply(conf, os.LookupEnv)
+	if err != nil {
+		return err
+	}
+	if name != "" {
+		c.logger.Info("Configuration preset applied", zap.String("preset", name))
+	}
+	return nil
+}
+
+// Names returns the names of the built-in presets, sorted.
+func Names() []string {
+	names := make([]string, 0, len(presets))
+	for name := range presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Apply fills the settings conf leaves unset from the selected preset and
+// removes the preset key. Runtime settings whose environment variable is
+// set, according to lookupEnv, are left to it. It returns the name of the
+// preset applied, or "" when none is selected.
+func Apply(conf *confmap.Conf, lookupEnv func(string) (string, bool)) (string, error) {
+	if !conf.IsSet(SectionKey) {
+		return "", nil
+	}
+	raw := conf.Get(SectionKey)
+	conf.Delete(SectionKey)
+	if raw == nil {
+		return "", nil
+	}
+	name, ok := raw.(string)
+	if !ok {
+		return "", fmt.Errorf("%s: expected a preset name, got %T", SectionKey, raw)
+	}
+	if name == "" {
+		return "", nil
+	}
+	p, ok := presets[name]
+	if !ok {
+		return "", fmt.Errorf("preset %q is not defined; available presets: %s", name, strings.Join(Names(), ", "))
+	}
+
+	enabled := map[string]any{}
+	for key, value := range p.runtime {
+		path := "runtime::" + key
+		if _, env := lookupEnv(runtimeEnv[key]); env || conf.IsSet(path) {
+			continue
+		}
+		enabled[path] = value
+	}
+	if !conf.IsSet("service::extensions") && len(p.extensions) > 0 {
+		ids := make([]any, 0, len(p.extensions))
+		for _, id := range p.extensions {
+			ids = append(ids, id)
+			if !conf.IsSet("extensions::" + id) {
+				enabled["extensions::"+id] = map[string]any{}
+			}
+		}
+		enabled["service::extensions"] = ids
+	}
+	if err := conf.Merge(confmap.NewFromStringMap(expand(enabled))); err != nil {
+		return "", err
+	}
+
+	// Components enabled above get their defaults too.
+	defaulted := map[string]any{}
+	for _, defaults := range p.components {
+		components, _ := conf.Get(defaults.kind).(map[string]any)
+		for id := range components {
+			if typ, _, _ := strings.Cut(id, "/"); !slices.Contains(defaults.types, typ) {
+				continue
+			}
+			prefix := defaults.kind + "::" + id + "::"
+			if defaults.requires != "" && !conf.IsSet(prefix+defaults.requires) {
+				continue
+			}
+			for _, group := range defaults.groups {
+				if anySet(conf, prefix, group) {
+					continue
+				}
+				for key, value := range group {
+					if value != nil {
+						defaulted[prefix+key] = value
+					}
+				}
+			}
+		}
+	}
+	return name, conf.Merge(confmap.NewFromStringMap(expand(defaulted)))
+}
+
+// anySet reports whether conf sets any key of group below prefix.
+func anySet(conf *confmap.Conf, prefix string, group map[string]any) bool {
+	for key := range group {
+		if conf.IsSet(prefix + key) {
+			return true
+		}
+	}
+	return false
+}
+
+// expand turns "::" separated keys into nested maps.
+func expand(flat map[string]any) map[string]any {
+	out := map[string]any{}
+	for key, value := range flat {
+		parts := strings.Split(key, "::")
+		m := out
+		for _, part := range parts[:len(parts)-1] {
+			next, ok := m[part].(map[string]any)
+			if !ok {
+				next = map[string]any{}
+				m[part] = next
+			}
+			m = next
+		}
+		m[parts[len(parts)-1]] = value
+	}
+	return out
+}
@@ -0,0 +1,60 @@
+// Package presetconf applies named presets of defaults tuned for a class
+// of deployment.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// The top-level "preset" key names one of the built-in presets:
+//
+//   - edge-small: single-board computers and ARM gateways with a few
+//     hundred MiB of memory; small batches and queues, an aggressive
+//     garbage collector and a GOMEMLIMIT of 55% of the available memory.
+//   - gateway-large: dedicated gateways with several cores and GiBs of
+//     memory; large batches, many queue consumers and a lazier garbage
+//     collector.
+//   - k8s-daemonset: node agents under container limits; moderate batches
+//     and queues, and a health_check endpoint the kubelet can probe.
+//
+// A preset sets the runtime section (GOMEMLIMIT and GOGC), the batch sizes
+// and timeouts of batch processors, the percentage limits of memory_limiter
+// processors, the sending queue sizes and consumers of OTLP exporters, and
+// the service extensions. It only fills in settings the configuration
+// leaves unset: the explicit configuration, and the GOMAXPROCS, GOMEMLIMIT
+// and GOGC environment variables, always win. Related settings are
+// defaulted together, so setting limit_mib on a memory_limiter keeps the
+// preset percentages off it, and a service::extensions list replaces the
+// preset extensions. The preset GOMEMLIMIT matches the soft limit of its
+// memory_limiter, and takes precedence over the value runtimeconf derives
+// from memory_limiter.
+//
+// The key is applied by a confmap converter, which removes it before the
+// configuration reaches otelcol. Builder installs the converter after the
+// profile converter, so a profile may select the preset, and before the
+// runtime converter. Selecting a preset that is not defined fails the
+// configuration.
+//
+// Example:
+//
+//	preset: edge-small
+//
+//	processors:
+//	  batch:
+//	    timeout: 10s # overrides the preset timeout, keeps its batch size
+//	  memory_limiter: {}
+//
+//	exporters:
+//	  otlp:
+//	    endpoint: gateway:4317
+package presetconf // import "github.com/telemetryflow/telemetryflow-collector/pkg/presetconf"
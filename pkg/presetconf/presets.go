@@ -0,0 +1,110 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package presetconf
+
+// queueExporters are the exporter types whose sending queue is enabled by
+// default.
+var queueExporters = []string{"otlp", "otlp_grpc", "otlphttp", "otlp_http"}
+
+// presets are the built-in presets by name.
+var presets = map[string]preset{
+	// Single-board computers and gateways at the edge: a few hundred MiB of
+	// memory and one or two cores, often ARM.
+	"edge-small": {
+		runtime: map[string]any{"gomemlimit": "55%", "gc_percent": 50},
+		components: []componentDefaults{
+			batchDefaults(256, "5s"),
+			memoryLimiterDefaults(70, 15),
+			queueDefaults(queueExporters, "", 2, 200),
+			queueDefaults([]string{"tfo"}, "sending_queue", 2, 200),
+		},
+		extensions: []string{"health_check"},
+	},
+	// Dedicated gateways aggregating many agents: several cores and GiBs of
+	// memory, tuned for throughput.
+	"gateway-large": {
+		runtime: map[string]any{"gomemlimit": "75%", "gc_percent": 200},
+		components: []componentDefaults{
+			batchDefaults(8192, "200ms"),
+			memoryLimiterDefaults(85, 10),
+			queueDefaults(queueExporters, "", 32, 20000),
+			queueDefaults([]string{"tfo"}, "sending_queue", 32, 20000),
+		},
+		extensions: []string{"health_check", "pprof"},
+	},
+	// Node agents run as a Kubernetes DaemonSet under container limits,
+	// probed by the kubelet.
+	"k8s-daemonset": {
+		runtime: map[string]any{"gomemlimit": "60%"},
+		components: []componentDefaults{
+			batchDefaults(1024, "1s"),
+			memoryLimiterDefaults(80, 20),
+			queueDefaults(queueExporters, "", 4, 2000),
+			queueDefaults([]string{"tfo"}, "sending_queue", 4, 2000),
+			{
+				kind:   "extensions",
+				types:  []string{"health_check"},
+				groups: []map[string]any{{"endpoint": "0.0.0.0:13133"}},
+			},
+		},
+		extensions: []string{"health_check"},
+	},
+}
+
+// batchDefaults returns the defaults of the batch processor.
+func batchDefaults(size int, timeout string) componentDefaults {
+	return componentDefaults{
+		kind:  "processors",
+		types: []string{"batch"},
+		groups: []map[string]any{
+			// send_batch_max_size must not be below send_batch_size.
+			{"send_batch_size": size, "send_batch_max_size": nil},
+			{"timeout": timeout},
+		},
+	}
+}
+
+// memoryLimiterDefaults returns the defaults of the memory_limiter
+// processor, with limits as percentages of the cgroup or host memory.
+func memoryLimiterDefaults(limit, spike int) componentDefaults {
+	return componentDefaults{
+		kind:  "processors",
+		types: []string{"memory_limiter"},
+		groups: []map[string]any{
+			{"check_interval": "1s"},
+			{
+				"limit_percentage":       limit,
+				"spike_limit_percentage": spike,
+				"limit_mib":              nil,
+				"spike_limit_mib":        nil,
+			},
+		},
+	}
+}
+
+// queueDefaults returns the sending queue defaults of exporters. When
+// requires is set, only exporters setting that key get them.
+func queueDefaults(types []string, requires string, consumers, size int) componentDefaults {
+	return componentDefaults{
+		kind:     "exporters",
+		types:    types,
+		requires: requires,
+		groups: []map[string]any{
+			{"sending_queue::num_consumers": consumers},
+			// queue_size is counted in the unit of the sizer.
+			{"sending_queue::queue_size": size, "sending_queue::sizer": nil},
+		},
+	}
+}
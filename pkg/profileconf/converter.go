@@ -0,0 +1,115 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package profileconf
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.uber.org/zap"
+)
+
+// SectionKey is the top-level configuration key of the profiles section.
+const SectionKey = "profiles"
+
+// EnvVar selects the profile when none is passed to NewConverterFactory.
+const EnvVar = "TELEMETRYFLOW_PROFILE"
+
+// NewConverterFactory returns a converter that merges the selected profile
+// over the rest of the configuration and removes the profiles section. When
+// profile is empty, the profile named by the TELEMETRYFLOW_PROFILE
+// environment variable is used; when both are empty, the section is only
+// removed.
+func NewConverterFactory(profile string) confmap.ConverterFactory {
+	return confmap.NewConverterFactory(func(set confmap.ConverterSettings) confmap.Converter {
+		logger := set.Logger
+		if logger == nil {
+			logger = zap.NewNop()
+		}
+		source := "flag"
+		if profile == "" {
+			profile = os.Getenv(EnvVar)
+			source = EnvVar
+		}
+		return &converter{logger: logger, profile: profile, source: source}
+	})
+}
+
+type converter struct {
+	logger  *zap.Logger
+	profile string
+	source  string
+}
+
+func (c *converter) Convert(_ context.Context, conf *confmap.Conf) error {
+	if err := Apply(conf, c.profile); err != nil {
+		return err
+	}
+	if c.profile != "" {
+		c.logger.Info("Configuration profile applied",
+			zap.String("profile", c.profile),
+			zap.String("source", c.source),
+		)
+	}
+	return nil
+}
+
+// Apply merges the profile named profile over conf and removes the profiles
+// section. An empty profile only removes the section. It fails when profile
+// is not defined.
+func Apply(conf *confmap.Conf, profile string) error {
+	profiles := map[string]any{}
+	if conf.IsSet(SectionKey) {
+		sub, err := conf.Sub(SectionKey)
+		if err != nil {
+			return fmt.Errorf("%s: %w", SectionKey, err)
+		}
+		profiles = sub.ToStringMap()
+		conf.Delete(SectionKey)
+	}
+	if profile == "" {
+		return nil
+	}
+
+	raw, ok := profiles[profile]
+	if !ok {
+		if len(profiles) == 0 {
+			return fmt.Errorf("profile %q is selected but the configuration defines no profiles", profile)
+		}
+		return fmt.Errorf("profile %q is not defined; available profiles: %s", profile, strings.Join(names(profiles), ", "))
+	}
+	if raw == nil {
+		return nil
+	}
+	overlay, ok := raw.(map[string]any)
+	if !ok {
+		return fmt.Errorf("%s::%s: expected a map, got %T", SectionKey, profile, raw)
+	}
+	return conf.Merge(confmap.NewFromStringMap(overlay))
+}
+
+// names returns the sorted profile names of a profiles section.
+func names(profiles map[string]any) []string {
+	out := make([]string, 0, len(profiles))
+	for name := range profiles {
+		out = append(out, name)
+	}
+	sort.Strings(out)
+	return out
+}
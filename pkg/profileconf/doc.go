@@ -0,0 +1,51 @@
+// Package profileconf selects a per-environment profile of the collector
+// configuration.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// The top-level "profiles" section maps profile names to partial
+// configurations. The selected profile is merged over the rest of the file,
+// the common base: maps are merged key by key and any other value, including
+// lists, replaces the base value. The profile is selected with the
+// --profile flag or, when the flag is not set, the TELEMETRYFLOW_PROFILE
+// environment variable; without either, only the base is used. Selecting a
+// profile that is not defined fails the configuration.
+//
+// The section is applied by a confmap converter, which removes it before the
+// configuration reaches otelcol. Builder installs the converter by default,
+// ahead of the other converters, so they see the merged configuration.
+//
+// Example:
+//
+//	exporters:
+//	  tfo:
+//	    endpoint: https://api.telemetryflow.id
+//	    sending_queue:
+//	      num_consumers: 10
+//
+//	profiles:
+//	  dev:
+//	    exporters:
+//	      tfo:
+//	        endpoint: https://dev.api.telemetryflow.id
+//	        sending_queue:
+//	          num_consumers: 2
+//	  prod:
+//	    exporters:
+//	      tfo:
+//	        sending_queue:
+//	          num_consumers: 20
+package profileconf // import "github.com/telemetryflow/telemetryflow-collector/pkg/profileconf"
@@ -0,0 +1,47 @@
+// Package provenance records where telemetry entered a hierarchy of collectors
+// and the collectors it passed through.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// When edge collectors forward to regional aggregators, which forward to
+// the backend, the backend only sees the last hop. Each collector receiving
+// telemetry stamps a metadata envelope into the resource attributes:
+//
+//   - tfo.provenance.origin.collector_id, .tenant and .received_at are set
+//     by the first collector and kept by the others
+//   - tfo.provenance.hop_count and tfo.provenance.path (the collector IDs,
+//     first hop first) are updated by every collector
+//
+// Resource attributes travel with the telemetry through batching and any
+// OTLP exporter, so the envelope survives hops that merge or split
+// requests.
+//
+// Stamping fails, and the telemetry must be dropped, when the collector
+// already appears in the path (a forwarding loop) or the hop count reached
+// the hop limit.
+//
+// Example:
+//
+//	rejected := provenance.StampTraces(td, provenance.Hop{
+//		CollectorID: collectorID,
+//		Tenant:      req.Header.Get("X-TelemetryFlow-Tenant"),
+//		ReceivedAt:  time.Now(),
+//		MaxHops:     8,
+//	})
+//	if rejected.Loop > 0 {
+//		logger.Warn("Dropped looping spans", zap.Int("spans", rejected.Loop))
+//	}
+package provenance // import "github.com/telemetryflow/telemetryflow-collector/pkg/provenance"
@@ -0,0 +1,200 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package provenance
+
+import (
+	"errors"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// Resource attributes carrying the envelope.
+const (
+	// AttrOriginCollectorID is the ID of the first collector the telemetry
+	// passed through.
+	AttrOriginCollectorID = "tfo.provenance.origin.collector_id"
+
+	// AttrOriginTenant is the tenant the telemetry was sent for at the
+	// first collector.
+	AttrOriginTenant = "tfo.provenance.origin.tenant"
+
+	// AttrOriginReceivedAt is when the first collector received the
+	// telemetry, in RFC 3339 format with nanoseconds.
+	AttrOriginReceivedAt = "tfo.provenance.origin.received_at"
+
+	// AttrHopCount is the number of collectors the telemetry passed
+	// through.
+	AttrHopCount = "tfo.provenance.hop_count"
+
+	// AttrPath lists the IDs of the collectors the telemetry passed
+	// through, first hop first.
+	AttrPath = "tfo.provenance.path"
+)
+
+var (
+	// ErrLoop reports telemetry that already passed through the collector.
+	ErrLoop = errors.New("telemetry already passed through this collector")
+
+	// ErrHopLimit reports telemetry that passed through the maximum number
+	// of collectors.
+	ErrHopLimit = errors.New("telemetry exceeded the hop limit")
+)
+
+// Hop describes the collector stamping the envelope.
+type Hop struct {
+	// CollectorID identifies the collector. It must not be empty.
+	CollectorID string
+
+	// Tenant is recorded as the origin tenant when the telemetry enters
+	// the hierarchy at this collector. Empty means unknown.
+	Tenant string
+
+	// ReceivedAt is recorded as the origin receive time when the telemetry
+	// enters the hierarchy at this collector.
+	ReceivedAt time.Time
+
+	// MaxHops is the largest number of collectors telemetry may pass
+	// through, this one included. Zero means no limit.
+	MaxHops int
+}
+
+// Stamp appends h to the envelope in the resource attributes attrs,
+// starting the envelope if there is none. It returns ErrLoop or ErrHopLimit,
+// leaving attrs unchanged, when the telemetry must not be forwarded.
+func Stamp(attrs pcommon.Map, h Hop) error {
+	path, hasPath := pcommon.Slice{}, false
+	if v, ok := attrs.Get(AttrPath); ok && v.Type() == pcommon.ValueTypeSlice {
+		path, hasPath = v.Slice(), true
+		for _, id := range path.All() {
+			if id.AsString() == h.CollectorID {
+				return ErrLoop
+			}
+		}
+	}
+	var hops int64
+	if v, ok := attrs.Get(AttrHopCount); ok && v.Type() == pcommon.ValueTypeInt {
+		hops = v.Int()
+	}
+	if h.MaxHops > 0 && hops >= int64(h.MaxHops) {
+		return ErrHopLimit
+	}
+
+	if _, ok := attrs.Get(AttrOriginCollectorID); !ok {
+		attrs.PutStr(AttrOriginCollectorID, h.CollectorID)
+		attrs.PutStr(AttrOriginReceivedAt, h.ReceivedAt.UTC().Format(time.RFC3339Nano))
+		if h.Tenant != "" {
+			attrs.PutStr(AttrOriginTenant, h.Tenant)
+		}
+	}
+	attrs.PutInt(AttrHopCount, hops+1)
+	if !hasPath {
+		path = attrs.PutEmptySlice(AttrPath)
+	}
+	path.AppendEmpty().SetStr(h.CollectorID)
+	return nil
+}
+
+// Rejected counts the records removed by StampTraces, StampMetrics and
+// StampLogs.
+type Rejected struct {
+	Loop     int
+	HopLimit int
+}
+
+func (r *Rejected) add(err error, records int) bool {
+	switch {
+	case errors.Is(err, ErrLoop):
+		r.Loop += records
+	case errors.Is(err, ErrHopLimit):
+		r.HopLimit += records
+	default:
+		return false
+	}
+	return true
+}
+
+// StampTraces stamps h on every resource of td and removes the resources
+// that must not be forwarded.
+func StampTraces(td ptrace.Traces, h Hop) Rejected {
+	var r Rejected
+	td.ResourceSpans().RemoveIf(func(rs ptrace.ResourceSpans) bool {
+		err := Stamp(rs.Resource().Attributes(), h)
+		return err != nil && r.add(err, spans(rs))
+	})
+	return r
+}
+
+// StampMetrics stamps h on every resource of md and removes the resources
+// that must not be forwarded.
+func StampMetrics(md pmetric.Metrics, h Hop) Rejected {
+	var r Rejected
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		err := Stamp(rm.Resource().Attributes(), h)
+		return err != nil && r.add(err, dataPoints(rm))
+	})
+	return r
+}
+
+// StampLogs stamps h on every resource of ld and removes the resources that
+// must not be forwarded.
+func StampLogs(ld plog.Logs, h Hop) Rejected {
+	var r Rejected
+	ld.ResourceLogs().RemoveIf(func(rl plog.ResourceLogs) bool {
+		err := Stamp(rl.Resource().Attributes(), h)
+		return err != nil && r.add(err, logRecords(rl))
+	})
+	return r
+}
+
+func spans(rs ptrace.ResourceSpans) int {
+	n := 0
+	for _, ss := range rs.ScopeSpans().All() {
+		n += ss.Spans().Len()
+	}
+	return n
+}
+
+func logRecords(rl plog.ResourceLogs) int {
+	n := 0
+	for _, sl := range rl.ScopeLogs().All() {
+		n += sl.LogRecords().Len()
+	}
+	return n
+}
+
+func dataPoints(rm pmetric.ResourceMetrics) int {
+	n := 0
+	for _, sm := range rm.ScopeMetrics().All() {
+		for _, m := range sm.Metrics().All() {
+			switch m.Type() {
+			case pmetric.MetricTypeGauge:
+				n += m.Gauge().DataPoints().Len()
+			case pmetric.MetricTypeSum:
+				n += m.Sum().DataPoints().Len()
+			case pmetric.MetricTypeHistogram:
+				n += m.Histogram().DataPoints().Len()
+			case pmetric.MetricTypeExponentialHistogram:
+				n += m.ExponentialHistogram().DataPoints().Len()
+			case pmetric.MetricTypeSummary:
+				n += m.Summary().DataPoints().Len()
+			}
+		}
+	}
+	return n
+}
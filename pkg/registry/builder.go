@@ -0,0 +1,204 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package registry
+
+import (
+	"context"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/provider/envprovider"
+	"go.opentelemetry.io/collector/confmap/provider/yamlprovider"
+	"go.opentelemetry.io/collector/otelcol"
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/internal/version"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/crashguard"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/dockersdconf"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/fileconf"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/loglevel"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/pipelineconf"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/presetconf"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/profileconf"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/runtimeconf"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+)
+
+// Builder assembles otelcol settings from an injected RegistrySet. The zero
+// value builds the standard TFO Collector distribution.
+type Builder struct {
+	// Registry provides the component factories. Default() is used when nil.
+	Registry *RegistrySet
+
+	// BuildInfo identifies the binary. The TFO Collector build info is used
+	// when Command is empty.
+	BuildInfo component.BuildInfo
+
+	// ConfigURIs are the configuration locations, e.g. "file:config.yaml"
+	// or "yaml:receivers::otlp::protocols::grpc:". They may be left empty
+	// when the settings are passed to otelcol.NewCommand, which adds its
+	// own --config flag.
+	ConfigURIs []string
+
+	// ProviderFactories resolve ConfigURIs. The file, yaml and env providers
+	// are used when nil; the file provider reads YAML, JSON and TOML files.
+	ProviderFactories []confmap.ProviderFactory
+
+	// Profile selects the entry of the "profiles" section merged over the
+	// configuration. The TELEMETRYFLOW_PROFILE environment variable is used
+	// when empty.
+	Profile string
+
+	// ConverterFactories transform the resolved configuration. When nil,
+	// the profile converter, which merges the selected profile, the preset
+	// converter, which fills in the defaults of the named preset, the runtime
+	// converter, which applies the "runtime" section to the Go runtime of
+	// the process, the listener handoff converter, which takes over the
+	// sockets of a previous process, the crash guard converter, which applies the
+	// "crash_guard" section to CrashGuard, the Docker discovery converter,
+	// which applies the container label convention to docker_sd_configs
+	// scrape jobs, and the pipeline converter, which rejects unknown
+	// component references and warns about bad processor orders, are used;
+	// pass an empty slice to skip them all.
+	ConverterFactories []confmap.ConverterFactory
+
+	// CrashGuard recovers the panics of processors and exporters. A guard
+	// with the default configuration is used when nil.
+	CrashGuard *crashguard.Guard
+
+	// DryRun builds settings for validating a configuration only. The
+	// default runtime and listener handoff converters then check and remove
+	// their sections without changing the Go runtime or taking over the
+	// sockets of another process.
+	DryRun bool
+}
+
+// DefaultBuildInfo returns the build info of the TFO Collector binary.
+func DefaultBuildInfo() component.BuildInfo {
+	return component.BuildInfo{
+		Command:     version.ProductShortName,
+		Description: version.ProductDescription,
+		Version:     version.Version,
+	}
+}
+
+// Settings returns the collector settings described by b.
+func (b Builder) Settings() otelcol.CollectorSettings {
+	reg := b.Registry
+	if reg == nil {
+		reg = Default()
+	}
+
+	info := b.BuildInfo
+	if info.Command == "" {
+		info = DefaultBuildInfo()
+	}
+
+	providers := b.ProviderFactories
+	if providers == nil {
+		providers = []confmap.ProviderFactory{
+			fileconf.NewProviderFactory(),
+			yamlprovider.NewFactory(),
+			envprovider.NewFactory(),
+		}
+	}
+
+	guard := b.CrashGuard
+	if guard == nil {
+		guard = crashguard.New()
+	}
+
+	converters := b.ConverterFactories
+	if converters == nil {
+		runtime, handoff := runtimeconf.NewConverterFactory(), serverconf.NewHandoffConverterFactory()
+		if b.DryRun {
+			runtime = newSectionCheckFactory(runtimeconf.SectionKey, func(conf *confmap.Conf) error {
+				_, err := runtimeconf.LoadConfig(conf)
+				return err
+			})
+			handoff = newSectionCheckFactory(serverconf.HandoffSectionKey, func(conf *confmap.Conf) error {
+				_, err := serverconf.LoadHandoffConfig(conf)
+				return err
+			})
+		}
+		converters = []confmap.ConverterFactory{
+			profileconf.NewConverterFactory(b.Profile),
+			presetconf.NewConverterFactory(),
+			runtime,
+			handoff,
+			crashguard.NewConverterFactory(guard),
+			dockersdconf.NewConverterFactory(),
+			pipelineconf.NewConverterFactory(),
+		}
+	}
+
+	return otelcol.CollectorSettings{
+		BuildInfo: info,
+		Factories: func() (otelcol.Factories, error) {
+			factories, err := reg.Factories()
+			if err != nil {
+				return otelcol.Factories{}, err
+			}
+			return guard.Wrap(factories), nil
+		},
+		// Let the tfooverrides extension change the log level at runtime.
+		LoggingOptions: []zap.Option{zap.WrapCore(loglevel.WrapCore)},
+		ConfigProviderSettings: otelcol.ConfigProviderSettings{
+			ResolverSettings: confmap.ResolverSettings{
+				URIs:               b.ConfigURIs,
+				ProviderFactories:  providers,
+				ConverterFactories: converters,
+			},
+		},
+	}
+}
+
+// newSectionCheckFactory returns a converter that checks the top-level
+// section key with load and removes it, standing in for a converter whose
+// effects on the process must not happen on a dry run.
+func newSectionCheckFactory(key string, load func(*confmap.Conf) error) confmap.ConverterFactory {
+	return confmap.NewConverterFactory(func(confmap.ConverterSettings) confmap.Converter {
+		return sectionCheck{key: key, load: load}
+	})
+}
+
+type sectionCheck struct {
+	key  string
+	load func(*confmap.Conf) error
+}
+
+func (c sectionCheck) Convert(_ context.Context, conf *confmap.Conf) error {
+	if err := c.load(conf); err != nil {
+		return err
+	}
+	conf.Delete(c.key)
+	return nil
+}
+
+// New creates a collector from the settings described by b. The caller runs
+// it with Collector.Run and stops it with Collector.Shutdown.
+func (b Builder) New() (*otelcol.Collector, error) {
+	return otelcol.NewCollector(b.Settings())
+}
+
+// Validate checks the configuration described by b against the component
+// factories without starting a collector, like the validate command.
+func (b Builder) Validate(ctx context.Context) error {
+	col, err := b.New()
+	if err != nil {
+		return err
+	}
+	return col.DryRun(ctx)
+}
@@ -16,16 +16,10 @@
 // This file registers all component factories for the TFO Collector.
 // It includes both OCB-generated community components and TFO custom components.
 
-package main
+package registry
 
 import (
-	"go.opentelemetry.io/collector/component"
-	"go.opentelemetry.io/collector/connector"
-	"go.opentelemetry.io/collector/exporter"
-	"go.opentelemetry.io/collector/extension"
-	"go.opentelemetry.io/collector/otelcol"
-	"go.opentelemetry.io/collector/processor"
-	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/service/telemetry/otelconftelemetry"
 
 	// ==========================================================================
 	// TelemetryFlow Custom Components
@@ -33,13 +27,41 @@ import (
 
 	// TFO Extensions
 	"github.com/telemetryflow/telemetryflow-collector/components/extension/tfoauthextension"
+	"github.com/telemetryflow/telemetryflow-collector/components/extension/tfoclockextension"
+	"github.com/telemetryflow/telemetryflow-collector/components/extension/tfoencryptionextension"
 	"github.com/telemetryflow/telemetryflow-collector/components/extension/tfoidentityextension"
+	"github.com/telemetryflow/telemetryflow-collector/components/extension/tfomaintenanceextension"
+	"github.com/telemetryflow/telemetryflow-collector/components/extension/tfoopampextension"
+	"github.com/telemetryflow/telemetryflow-collector/components/extension/tfooverridesextension"
+	"github.com/telemetryflow/telemetryflow-collector/components/extension/tfoparquetextension"
+	"github.com/telemetryflow/telemetryflow-collector/components/extension/tfoqueuequotaextension"
+	"github.com/telemetryflow/telemetryflow-collector/components/extension/tfosupportextension"
 
 	// TFO Receiver
+	"github.com/telemetryflow/telemetryflow-collector/components/tfocoapreceiver"
+	"github.com/telemetryflow/telemetryflow-collector/components/tfofleetreceiver"
+	"github.com/telemetryflow/telemetryflow-collector/components/tfokafkareceiver"
+	"github.com/telemetryflow/telemetryflow-collector/components/tfomqttreceiver"
 	"github.com/telemetryflow/telemetryflow-collector/components/tfootlpreceiver"
 
+	// TFO Processor
+	"github.com/telemetryflow/telemetryflow-collector/components/tfodedupprocessor"
+	"github.com/telemetryflow/telemetryflow-collector/components/tfoexemptprocessor"
+
 	// TFO Exporter
+	"github.com/telemetryflow/telemetryflow-collector/components/tfocardinalityprocessor"
+	"github.com/telemetryflow/telemetryflow-collector/components/tfoexperimentexporter"
 	"github.com/telemetryflow/telemetryflow-collector/components/tfoexporter"
+	"github.com/telemetryflow/telemetryflow-collector/components/tfoprometheusexporter"
+	"github.com/telemetryflow/telemetryflow-collector/components/tforetentionexporter"
+	"github.com/telemetryflow/telemetryflow-collector/components/tfosampledprocessor"
+	"github.com/telemetryflow/telemetryflow-collector/components/tfospannameprocessor"
+	"github.com/telemetryflow/telemetryflow-collector/components/tfotraceidprocessor"
+
+	// TFO Connector
+	"github.com/telemetryflow/telemetryflow-collector/components/tfoalertconnector"
+	"github.com/telemetryflow/telemetryflow-collector/components/tfoarchiveconnector"
+	"github.com/telemetryflow/telemetryflow-collector/components/tfomirrorconnector"
 
 	// ==========================================================================
 	// OpenTelemetry Collector Core Components
@@ -73,6 +95,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/bearertokenauthextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/healthcheckextension"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/pprofextension"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage"
 
 	// Contrib Receivers
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/filelogreceiver"
@@ -85,6 +108,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/attributesprocessor"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/filterprocessor"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/k8sattributesprocessor"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/redactionprocessor"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourcedetectionprocessor"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/resourceprocessor"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/processor/tailsamplingprocessor"
@@ -92,7 +116,6 @@ import (
 
 	// Contrib Exporters
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter"
-	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusexporter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusremotewriteexporter"
 
 	// Contrib Connectors
@@ -101,16 +124,25 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector"
 )
 
-// components returns all component factories for the TFO Collector.
-func components() (otelcol.Factories, error) {
-	factories := otelcol.Factories{}
+// Default returns a new RegistrySet holding every component factory built
+// into the TFO Collector distribution. Each call returns an independent set,
+// so callers may register or replace factories without affecting others.
+func Default() *RegistrySet {
+	r := NewRegistrySet()
 
-	// Extensions - build factory map manually
-	factories.Extensions = make(map[component.Type]extension.Factory)
-	for _, f := range []extension.Factory{
+	// Extensions
+	mustRegister(r.RegisterExtensions(
 		// TFO Custom Extensions
 		tfoauthextension.NewFactory(),
 		tfoidentityextension.NewFactory(),
+		tfoclockextension.NewFactory(),
+		tfoencryptionextension.NewFactory(),
+		tfoparquetextension.NewFactory(),
+		tfomaintenanceextension.NewFactory(),
+		tfooverridesextension.NewFactory(),
+		tfoopampextension.NewFactory(),
+		tfosupportextension.NewFactory(),
+		tfoqueuequotaextension.NewFactory(),
 
 		// Core Extensions
 		zpagesextension.NewFactory(),
@@ -120,15 +152,17 @@ func components() (otelcol.Factories, error) {
 		pprofextension.NewFactory(),
 		basicauthextension.NewFactory(),
 		bearertokenauthextension.NewFactory(),
-	} {
-		factories.Extensions[f.Type()] = f
-	}
+		filestorage.NewFactory(),
+	))
 
-	// Receivers - build factory map manually
-	factories.Receivers = make(map[component.Type]receiver.Factory)
-	for _, f := range []receiver.Factory{
+	// Receivers
+	mustRegister(r.RegisterReceivers(
 		// TFO Custom Receiver
 		tfootlpreceiver.NewFactory(),
+		tfofleetreceiver.NewFactory(),
+		tfomqttreceiver.NewFactory(),
+		tfocoapreceiver.NewFactory(),
+		tfokafkareceiver.NewFactory(),
 
 		// Core Receivers
 		otlpreceiver.NewFactory(),
@@ -139,13 +173,18 @@ func components() (otelcol.Factories, error) {
 		prometheusreceiver.NewFactory(),
 		hostmetricsreceiver.NewFactory(),
 		filelogreceiver.NewFactory(),
-	} {
-		factories.Receivers[f.Type()] = f
-	}
+	))
+
+	// Processors
+	mustRegister(r.RegisterProcessors(
+		// TFO Custom Processor
+		tfodedupprocessor.NewFactory(),
+		tfoexemptprocessor.NewFactory(),
+		tfosampledprocessor.NewFactory(),
+		tfocardinalityprocessor.NewFactory(),
+		tfospannameprocessor.NewFactory(),
+		tfotraceidprocessor.NewFactory(),
 
-	// Processors - build factory map manually
-	factories.Processors = make(map[component.Type]processor.Factory)
-	for _, f := range []processor.Factory{
 		// Core Processors
 		batchprocessor.NewFactory(),
 		memorylimiterprocessor.NewFactory(),
@@ -157,16 +196,16 @@ func components() (otelcol.Factories, error) {
 		k8sattributesprocessor.NewFactory(),
 		filterprocessor.NewFactory(),
 		transformprocessor.NewFactory(),
+		redactionprocessor.NewFactory(),
 		tailsamplingprocessor.NewFactory(),
-	} {
-		factories.Processors[f.Type()] = f
-	}
+	))
 
-	// Exporters - build factory map manually
-	factories.Exporters = make(map[component.Type]exporter.Factory)
-	for _, f := range []exporter.Factory{
+	// Exporters
+	mustRegister(r.RegisterExporters(
 		// TFO Custom Exporter
 		tfoexporter.NewFactory(),
+		tforetentionexporter.NewFactory(),
+		tfoexperimentexporter.NewFactory(),
 
 		// Core Exporters
 		debugexporter.NewFactory(),
@@ -175,16 +214,20 @@ func components() (otelcol.Factories, error) {
 		otlphttpexporter.NewFactory(),
 
 		// Contrib Exporters
-		prometheusexporter.NewFactory(),
+		// prometheus: the contrib exporter with a downsampled endpoint
+		tfoprometheusexporter.NewFactory(),
 		prometheusremotewriteexporter.NewFactory(),
 		fileexporter.NewFactory(),
-	} {
-		factories.Exporters[f.Type()] = f
-	}
+	))
+	mustRegister(r.RegisterExporters(testExporters()...))
+
+	// Connectors
+	mustRegister(r.RegisterConnectors(
+		// TFO Custom Connector
+		tfoalertconnector.NewFactory(),
+		tfomirrorconnector.NewFactory(),
+		tfoarchiveconnector.NewFactory(),
 
-	// Connectors - build factory map manually
-	factories.Connectors = make(map[component.Type]connector.Factory)
-	for _, f := range []connector.Factory{
 		// Core Connectors
 		forwardconnector.NewFactory(),
 
@@ -192,9 +235,18 @@ func components() (otelcol.Factories, error) {
 		spanmetricsconnector.NewFactory(),
 		servicegraphconnector.NewFactory(),
 		countconnector.NewFactory(),
-	} {
-		factories.Connectors[f.Type()] = f
-	}
+	))
+
+	// Telemetry
+	r.SetTelemetry(otelconftelemetry.NewFactory())
 
-	return factories, nil
+	return r
+}
+
+// mustRegister panics on registration errors in the built-in set, which can
+// only be caused by two built-in factories sharing a component type.
+func mustRegister(err error) {
+	if err != nil {
+		panic(err)
+	}
 }
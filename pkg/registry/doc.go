@@ -0,0 +1,39 @@
+// Package registry holds the component factories of the TFO Collector and
+// builds collector settings from them.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// A RegistrySet is an injectable collection of factories. Default returns a
+// fresh set containing every component built into the distribution; callers
+// embedding the collector can clone it, register their own factories and pass
+// it to a Builder without touching any package-level state, so several
+// collectors with different component sets can run in one process.
+//
+// Example:
+//
+//	reg := registry.Default()
+//	if err := reg.RegisterProcessors(myprocessor.NewFactory()); err != nil {
+//		return err
+//	}
+//	col, err := registry.Builder{
+//		Registry:   reg,
+//		ConfigURIs: []string{"file:/etc/tfo-collector/config.yaml"},
+//	}.New()
+//	if err != nil {
+//		return err
+//	}
+//	return col.Run(ctx)
+package registry // import "github.com/telemetryflow/telemetryflow-collector/pkg/registry"
@@ -0,0 +1,199 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package registry
+
+import (
+	"fmt"
+	"maps"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/otelcol"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/service/telemetry"
+)
+
+// RegistrySet holds the component factories available to one collector
+// instance. Sets are independent values: build one with NewRegistrySet or
+// Default and inject it into a Builder instead of mutating shared state.
+// A RegistrySet is safe for concurrent use.
+type RegistrySet struct {
+	mu         sync.RWMutex
+	extensions map[component.Type]extension.Factory
+	receivers  map[component.Type]receiver.Factory
+	processors map[component.Type]processor.Factory
+	exporters  map[component.Type]exporter.Factory
+	connectors map[component.Type]connector.Factory
+	telemetry  telemetry.Factory
+}
+
+// NewRegistrySet creates an empty RegistrySet.
+func NewRegistrySet() *RegistrySet {
+	return &RegistrySet{
+		extensions: make(map[component.Type]extension.Factory),
+		receivers:  make(map[component.Type]receiver.Factory),
+		processors: make(map[component.Type]processor.Factory),
+		exporters:  make(map[component.Type]exporter.Factory),
+		connectors: make(map[component.Type]connector.Factory),
+	}
+}
+
+// factory is the part of every component factory used for registration.
+type factory interface {
+	Type() component.Type
+}
+
+// aliasedFactory is implemented by factories whose component was renamed
+// and that still accept the former type in configurations.
+type aliasedFactory interface {
+	DeprecatedAlias() component.Type
+}
+
+// types returns the types f is registered under: its type and, like
+// otelcol.MakeFactoryMap, its deprecated alias.
+func types(f factory) []component.Type {
+	if a, ok := f.(aliasedFactory); ok && a.DeprecatedAlias().String() != "" {
+		return []component.Type{f.Type(), a.DeprecatedAlias()}
+	}
+	return []component.Type{f.Type()}
+}
+
+// register adds factories to m, rejecting types that are already present.
+func register[F factory](mu *sync.RWMutex, m map[component.Type]F, kind string, factories []F) error {
+	mu.Lock()
+	defer mu.Unlock()
+	for _, f := range factories {
+		for _, typ := range types(f) {
+			if _, ok := m[typ]; ok {
+				return fmt.Errorf("duplicate %s factory %q", kind, typ)
+			}
+		}
+	}
+	for _, f := range factories {
+		for _, typ := range types(f) {
+			m[typ] = f
+		}
+	}
+	return nil
+}
+
+// replace adds factories to m, overwriting existing types.
+func replace[F factory](mu *sync.RWMutex, m map[component.Type]F, factories []F) {
+	mu.Lock()
+	defer mu.Unlock()
+	for _, f := range factories {
+		for _, typ := range types(f) {
+			m[typ] = f
+		}
+	}
+}
+
+// RegisterExtensions adds extension factories. It fails without registering
+// anything if a type is already present.
+func (r *RegistrySet) RegisterExtensions(factories ...extension.Factory) error {
+	return register(&r.mu, r.extensions, "extension", factories)
+}
+
+// RegisterReceivers adds receiver factories. It fails without registering
+// anything if a type is already present.
+func (r *RegistrySet) RegisterReceivers(factories ...receiver.Factory) error {
+	return register(&r.mu, r.receivers, "receiver", factories)
+}
+
+// RegisterProcessors adds processor factories. It fails without registering
+// anything if a type is already present.
+func (r *RegistrySet) RegisterProcessors(factories ...processor.Factory) error {
+	return register(&r.mu, r.processors, "processor", factories)
+}
+
+// RegisterExporters adds exporter factories. It fails without registering
+// anything if a type is already present.
+func (r *RegistrySet) RegisterExporters(factories ...exporter.Factory) error {
+	return register(&r.mu, r.exporters, "exporter", factories)
+}
+
+// RegisterConnectors adds connector factories. It fails without registering
+// anything if a type is already present.
+func (r *RegistrySet) RegisterConnectors(factories ...connector.Factory) error {
+	return register(&r.mu, r.connectors, "connector", factories)
+}
+
+// ReplaceExtensions adds extension factories, overwriting existing types.
+func (r *RegistrySet) ReplaceExtensions(factories ...extension.Factory) {
+	replace(&r.mu, r.extensions, factories)
+}
+
+// ReplaceReceivers adds receiver factories, overwriting existing types.
+func (r *RegistrySet) ReplaceReceivers(factories ...receiver.Factory) {
+	replace(&r.mu, r.receivers, factories)
+}
+
+// ReplaceProcessors adds processor factories, overwriting existing types.
+func (r *RegistrySet) ReplaceProcessors(factories ...processor.Factory) {
+	replace(&r.mu, r.processors, factories)
+}
+
+// ReplaceExporters adds exporter factories, overwriting existing types.
+func (r *RegistrySet) ReplaceExporters(factories ...exporter.Factory) {
+	replace(&r.mu, r.exporters, factories)
+}
+
+// ReplaceConnectors adds connector factories, overwriting existing types.
+func (r *RegistrySet) ReplaceConnectors(factories ...connector.Factory) {
+	replace(&r.mu, r.connectors, factories)
+}
+
+// SetTelemetry sets the factory creating the service's own telemetry providers.
+func (r *RegistrySet) SetTelemetry(f telemetry.Factory) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.telemetry = f
+}
+
+// Clone returns an independent copy of the set.
+func (r *RegistrySet) Clone() *RegistrySet {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return &RegistrySet{
+		extensions: maps.Clone(r.extensions),
+		receivers:  maps.Clone(r.receivers),
+		processors: maps.Clone(r.processors),
+		exporters:  maps.Clone(r.exporters),
+		connectors: maps.Clone(r.connectors),
+		telemetry:  r.telemetry,
+	}
+}
+
+// Factories returns a snapshot of the set in the form consumed by otelcol.
+// Later changes to the set do not affect the returned value.
+func (r *RegistrySet) Factories() (otelcol.Factories, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	if r.telemetry == nil {
+		return otelcol.Factories{}, fmt.Errorf("registry has no telemetry factory")
+	}
+	return otelcol.Factories{
+		Extensions: maps.Clone(r.extensions),
+		Receivers:  maps.Clone(r.receivers),
+		Processors: maps.Clone(r.processors),
+		Exporters:  maps.Clone(r.exporters),
+		Connectors: maps.Clone(r.connectors),
+		Telemetry:  r.telemetry,
+	}, nil
+}
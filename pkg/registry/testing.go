@@ -0,0 +1,32 @@
+//go:build tfotest
+
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package registry
+
+import (
+	"go.opentelemetry.io/collector/exporter"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfocaptureexporter"
+)
+
+// testExporters returns the exporters added to Default in builds with the
+// tfotest tag, so test suites can configure them like any other component.
+func testExporters() []exporter.Factory {
+	return []exporter.Factory{
+		tfocaptureexporter.NewFactory(),
+	}
+}
@@ -0,0 +1,40 @@
+// Package requestid carries the ID of an ingest request through a context.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Receivers accept the ID a client sends in its request (the X-Request-ID
+// header, or x-request-id metadata over gRPC) or generate one, echo it in
+// the response and store it in the request context. Components further down
+// log it with Field so a single request can be followed across log lines,
+// and exporters forward it to the backend in the X-Request-ID header.
+//
+// The ID travels with the context the pipeline passes from component to
+// component. Processors and sending queues that merge requests into batches
+// do not keep it; exporters then generate an ID for each batch they send, so
+// the batch can still be matched between collector and backend logs.
+//
+// Client supplied IDs are only accepted if Valid; anything else is replaced
+// by a generated ID, so IDs are safe to put in headers and logs.
+//
+// Example:
+//
+//	id := req.Header.Get(requestid.Header)
+//	if !requestid.Valid(id) {
+//		id = requestid.New()
+//	}
+//	ctx := requestid.NewContext(req.Context(), id)
+//	logger.Warn("Rejected request", requestid.Field(ctx))
+package requestid // import "github.com/telemetryflow/telemetryflow-collector/pkg/requestid"
@@ -0,0 +1,97 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package residency
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// DefaultAttribute is the attribute holding a record's residency region.
+const DefaultAttribute = "telemetryflow.residency"
+
+// Mode selects how violations are handled.
+type Mode string
+
+const (
+	// ModeEnforce blocks violating records.
+	ModeEnforce Mode = "enforce"
+
+	// ModeAudit only counts and logs violating records (dry run).
+	ModeAudit Mode = "audit"
+)
+
+// Config defines the residency policy of an exporter.
+type Config struct {
+	// Enabled turns on residency checks.
+	// Default: false
+	Enabled bool `mapstructure:"enabled"`
+
+	// Regions lists the regions the exporter's endpoint is labeled with.
+	// Matching is case-insensitive.
+	Regions []string `mapstructure:"regions"`
+
+	// Attribute is the attribute holding a record's residency region.
+	// Default: telemetryflow.residency
+	Attribute string `mapstructure:"attribute"`
+
+	// Mode is either "enforce" or "audit".
+	// Default: enforce
+	Mode Mode `mapstructure:"mode"`
+
+	// AllowUntagged exports records without a residency attribute.
+	// Default: true
+	AllowUntagged bool `mapstructure:"allow_untagged"`
+}
+
+// NewDefaultConfig returns the default residency settings (disabled).
+func NewDefaultConfig() Config {
+	return Config{
+		Attribute:     DefaultAttribute,
+		Mode:          ModeEnforce,
+		AllowUntagged: true,
+	}
+}
+
+// Validate checks the configuration for errors.
+func (cfg *Config) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if len(cfg.Regions) == 0 {
+		return errors.New("residency.regions must not be empty when residency is enabled")
+	}
+	for _, region := range cfg.Regions {
+		if strings.TrimSpace(region) == "" {
+			return errors.New("residency.regions must not contain empty entries")
+		}
+	}
+	if cfg.Attribute == "" {
+		return errors.New("residency.attribute must not be empty")
+	}
+	switch cfg.Mode {
+	case ModeEnforce, ModeAudit:
+	default:
+		return fmt.Errorf("residency.mode must be %q or %q, got %q", ModeEnforce, ModeAudit, cfg.Mode)
+	}
+	return nil
+}
+
+// Blocks reports whether the policy removes violating records, i.e. whether
+// exporters applying it mutate the data they are handed.
+func (cfg *Config) Blocks() bool {
+	return cfg.Enabled && cfg.Mode == ModeEnforce
+}
@@ -0,0 +1,39 @@
+// Package residency enforces data residency policies on outgoing telemetry.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Records carry their residency region in an attribute (by default
+// telemetryflow.residency). An exporter is labeled with the regions its
+// endpoint may receive; records whose region is not among them are blocked
+// before export and counted in tfo_residency_violations. Record-level
+// attributes take precedence over resource attributes. For metrics the
+// region is read from resource attributes only.
+//
+// In audit mode violations are counted and logged but nothing is blocked,
+// which allows a policy to be rolled out as a dry run.
+//
+// Configuration example:
+//
+//	exporters:
+//	  tfo/eu:
+//	    endpoint: "https://eu.api.telemetryflow.id"
+//	    residency:
+//	      enabled: true
+//	      regions: [eu, eu-west-1]
+//	      attribute: telemetryflow.residency
+//	      mode: enforce
+//	      allow_untagged: true
+package residency // import "github.com/telemetryflow/telemetryflow-collector/pkg/residency"
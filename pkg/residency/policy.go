@@ -0,0 +1,228 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package residency
+
+import (
+	"context"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+const scopeName = "github.com/telemetryflow/telemetryflow-collector/pkg/residency"
+
+// untaggedRegion labels violations of records without a residency attribute.
+const untaggedRegion = "untagged"
+
+// Policy applies a residency Config to outgoing batches. A nil *Policy is
+// valid and allows everything, so exporters can use it unconditionally.
+type Policy struct {
+	cfg     Config
+	labels  selfmetrics.Labels
+	logger  *zap.Logger
+	allowed map[string]struct{}
+
+	violations metric.Int64Counter
+}
+
+// NewPolicy creates the policy for the exporter identified by labels.
+// Violations are logged with set.Logger and counted on set.MeterProvider.
+func NewPolicy(cfg Config, set component.TelemetrySettings, labels selfmetrics.Labels) (*Policy, error) {
+	logger := set.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	p := &Policy{
+		cfg:     cfg,
+		labels:  labels,
+		logger:  logger,
+		allowed: make(map[string]struct{}, len(cfg.Regions)),
+	}
+	for _, region := range cfg.Regions {
+		p.allowed[normalize(region)] = struct{}{}
+	}
+	if set.MeterProvider != nil {
+		var err error
+		p.violations, err = set.MeterProvider.Meter(scopeName).Int64Counter(selfmetrics.ResidencyViolations,
+			metric.WithDescription("Number of records whose residency region is not allowed for the exporter."),
+			metric.WithUnit("{record}"))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return p, nil
+}
+
+// ApplyTraces checks every span and, in enforce mode, removes the violating
+// ones. It returns the number of violating spans.
+func (p *Policy) ApplyTraces(ctx context.Context, td ptrace.Traces) int {
+	if p == nil {
+		return 0
+	}
+	counts := make(map[string]int)
+	block := p.cfg.Mode == ModeEnforce
+	td.ResourceSpans().RemoveIf(func(rs ptrace.ResourceSpans) bool {
+		resRegion := p.region(rs.Resource().Attributes(), "")
+		rs.ScopeSpans().RemoveIf(func(ss ptrace.ScopeSpans) bool {
+			ss.Spans().RemoveIf(func(s ptrace.Span) bool {
+				return p.violates(p.region(s.Attributes(), resRegion), counts) && block
+			})
+			return block && ss.Spans().Len() == 0
+		})
+		return block && rs.ScopeSpans().Len() == 0
+	})
+	return p.record(ctx, pipeline.SignalTraces, counts)
+}
+
+// ApplyLogs checks every log record and, in enforce mode, removes the
+// violating ones. It returns the number of violating log records.
+func (p *Policy) ApplyLogs(ctx context.Context, ld plog.Logs) int {
+	if p == nil {
+		return 0
+	}
+	counts := make(map[string]int)
+	block := p.cfg.Mode == ModeEnforce
+	ld.ResourceLogs().RemoveIf(func(rl plog.ResourceLogs) bool {
+		resRegion := p.region(rl.Resource().Attributes(), "")
+		rl.ScopeLogs().RemoveIf(func(sl plog.ScopeLogs) bool {
+			sl.LogRecords().RemoveIf(func(lr plog.LogRecord) bool {
+				return p.violates(p.region(lr.Attributes(), resRegion), counts) && block
+			})
+			return block && sl.LogRecords().Len() == 0
+		})
+		return block && rl.ScopeLogs().Len() == 0
+	})
+	return p.record(ctx, pipeline.SignalLogs, counts)
+}
+
+// ApplyMetrics checks every resource and, in enforce mode, removes the
+// violating ones. It returns the number of violating data points.
+func (p *Policy) ApplyMetrics(ctx context.Context, md pmetric.Metrics) int {
+	if p == nil {
+		return 0
+	}
+	counts := make(map[string]int)
+	block := p.cfg.Mode == ModeEnforce
+	md.ResourceMetrics().RemoveIf(func(rm pmetric.ResourceMetrics) bool {
+		region := p.region(rm.Resource().Attributes(), "")
+		if p.permits(region) {
+			return false
+		}
+		counts[labelOf(region)] += dataPointCount(rm)
+		return block
+	})
+	return p.record(ctx, pipeline.SignalMetrics, counts)
+}
+
+// region returns the normalized residency region in attrs, or fallback.
+func (p *Policy) region(attrs pcommon.Map, fallback string) string {
+	if v, ok := attrs.Get(p.cfg.Attribute); ok {
+		return normalize(v.AsString())
+	}
+	return fallback
+}
+
+func (p *Policy) permits(region string) bool {
+	if region == "" {
+		return p.cfg.AllowUntagged
+	}
+	_, ok := p.allowed[region]
+	return ok
+}
+
+// violates reports whether region is not permitted, counting it if so.
+func (p *Policy) violates(region string, counts map[string]int) bool {
+	if p.permits(region) {
+		return false
+	}
+	counts[labelOf(region)]++
+	return true
+}
+
+// record emits metrics and a log line for the violations of one batch and
+// returns their total.
+func (p *Policy) record(ctx context.Context, signal pipeline.Signal, counts map[string]int) int {
+	total := 0
+	for region, n := range counts {
+		total += n
+		if p.violations != nil {
+			p.violations.Add(ctx, int64(n), p.labels.WithSignal(signal).Option(
+				attribute.String("region", region),
+				attribute.String("mode", string(p.cfg.Mode)),
+			))
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+
+	msg := "Residency policy blocked records"
+	if p.cfg.Mode == ModeAudit {
+		msg = "Residency policy violation (audit mode, not blocked)"
+	}
+	p.logger.Warn(msg,
+		zap.String("exporter", p.labels.ID.String()),
+		zap.String("signal", signal.String()),
+		zap.Int("records", total),
+		zap.Any("regions", counts),
+		zap.Strings("allowed_regions", p.cfg.Regions),
+	)
+	return total
+}
+
+func normalize(region string) string {
+	return strings.ToLower(strings.TrimSpace(region))
+}
+
+func labelOf(region string) string {
+	if region == "" {
+		return untaggedRegion
+	}
+	return region
+}
+
+// dataPointCount returns the number of data points in rm.
+func dataPointCount(rm pmetric.ResourceMetrics) int {
+	count := 0
+	for i := 0; i < rm.ScopeMetrics().Len(); i++ {
+		metrics := rm.ScopeMetrics().At(i).Metrics()
+		for j := 0; j < metrics.Len(); j++ {
+			m := metrics.At(j)
+			switch m.Type() {
+			case pmetric.MetricTypeGauge:
+				count += m.Gauge().DataPoints().Len()
+			case pmetric.MetricTypeSum:
+				count += m.Sum().DataPoints().Len()
+			case pmetric.MetricTypeHistogram:
+				count += m.Histogram().DataPoints().Len()
+			case pmetric.MetricTypeExponentialHistogram:
+				count += m.ExponentialHistogram().DataPoints().Len()
+			case pmetric.MetricTypeSummary:
+				count += m.Summary().DataPoints().Len()
+			}
+		}
+	}
+	return count
+}
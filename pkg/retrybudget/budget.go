@@ -0,0 +1,164 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package retrybudget
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+const scopeName = "github.com/telemetryflow/telemetryflow-collector/pkg/retrybudget"
+
+// numBuckets is the number of slots the window is divided into.
+const numBuckets = 10
+
+// Budget is one component's handle on the retry budget of a host. A nil
+// *Budget is valid and admits every retry, so components can use it
+// unconditionally.
+type Budget struct {
+	s *shared
+
+	denied metric.Int64Counter
+	attrs  metric.MeasurementOption
+}
+
+// New returns a handle on the budget shared by all senders to host with the
+// same settings, creating the budget on first use. Denied retries are
+// recorded on set.MeterProvider under the component labels.
+func New(cfg Config, set component.TelemetrySettings, host string, labels selfmetrics.Labels) (*Budget, error) {
+	b := &Budget{
+		s:     sharedFor(host, cfg),
+		attrs: labels.Option(attribute.String("server.address", host)),
+	}
+	if set.MeterProvider != nil {
+		var err error
+		b.denied, err = set.MeterProvider.Meter(scopeName).Int64Counter(selfmetrics.RetryBudgetExhausted,
+			metric.WithDescription("Retries skipped because the host's retry budget was exhausted."),
+			metric.WithUnit("{retry}"))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// Deposit records a successful request, earning retry allowance.
+func (b *Budget) Deposit() {
+	if b == nil {
+		return
+	}
+	b.s.deposit(time.Now())
+}
+
+// Withdraw reports whether a retry may be made and, if so, charges it to
+// the budget.
+func (b *Budget) Withdraw() bool {
+	if b == nil {
+		return true
+	}
+	if b.s.withdraw(time.Now()) {
+		return true
+	}
+	if b.denied != nil {
+		b.denied.Add(context.Background(), 1, b.attrs)
+	}
+	return false
+}
+
+type key struct {
+	host string
+	cfg  Config
+}
+
+var (
+	sharedMu sync.Mutex
+	budgets  = make(map[key]*shared)
+)
+
+func sharedFor(host string, cfg Config) *shared {
+	sharedMu.Lock()
+	defer sharedMu.Unlock()
+	k := key{host: host, cfg: cfg}
+	s, ok := budgets[k]
+	if !ok {
+		s = &shared{cfg: cfg, width: cfg.Window / numBuckets}
+		budgets[k] = s
+	}
+	return s
+}
+
+// bucket counts the requests of one slot of the window.
+type bucket struct {
+	slot      int64
+	successes int64
+	retries   int64
+}
+
+// shared is the sliding-window state of one host's budget.
+type shared struct {
+	cfg   Config
+	width time.Duration
+
+	mu      sync.Mutex
+	buckets [numBuckets]bucket
+}
+
+func (s *shared) slot(now time.Time) int64 {
+	return now.UnixNano() / int64(s.width)
+}
+
+// currentLocked returns the bucket of now, recycling it when it holds an
+// expired slot.
+func (s *shared) currentLocked(now time.Time) *bucket {
+	slot := s.slot(now)
+	b := &s.buckets[slot%numBuckets]
+	if b.slot != slot {
+		*b = bucket{slot: slot}
+	}
+	return b
+}
+
+func (s *shared) deposit(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.currentLocked(now).successes++
+}
+
+func (s *shared) withdraw(now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	slot := s.slot(now)
+	var successes, retries int64
+	for i := range s.buckets {
+		if b := &s.buckets[i]; slot-b.slot < numBuckets {
+			successes += b.successes
+			retries += b.retries
+		}
+	}
+	allowed := s.cfg.Ratio*float64(successes) + s.cfg.MinRetriesPerSecond*s.cfg.Window.Seconds()
+	if float64(retries)+1 > allowed {
+		return false
+	}
+	s.currentLocked(now).retries++
+	return true
+}
@@ -0,0 +1,79 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package retrybudget
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// DefaultRatio is the default number of retries allowed per successful
+	// request.
+	DefaultRatio = 0.1
+
+	// DefaultMinRetriesPerSecond is the default retry rate that is always
+	// allowed.
+	DefaultMinRetriesPerSecond = 1.0
+
+	// DefaultWindow is the default period over which requests are counted.
+	DefaultWindow = 10 * time.Second
+)
+
+// Config defines the retry budget settings embedded by components.
+type Config struct {
+	// Enabled turns on the retry budget.
+	// Default: false
+	Enabled bool `mapstructure:"enabled"`
+
+	// Ratio is the number of retries allowed per successful request in the
+	// window, e.g. 0.1 allows retries to add 10% to the request rate.
+	// Default: 0.1
+	Ratio float64 `mapstructure:"ratio"`
+
+	// MinRetriesPerSecond is the retry rate allowed regardless of traffic.
+	// Default: 1
+	MinRetriesPerSecond float64 `mapstructure:"min_retries_per_second"`
+
+	// Window is the period over which successes and retries are counted.
+	// Default: 10s
+	Window time.Duration `mapstructure:"window"`
+}
+
+// NewDefaultConfig returns the default retry budget settings (disabled).
+func NewDefaultConfig() Config {
+	return Config{
+		Ratio:               DefaultRatio,
+		MinRetriesPerSecond: DefaultMinRetriesPerSecond,
+		Window:              DefaultWindow,
+	}
+}
+
+// Validate checks the configuration for errors.
+func (cfg *Config) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.Ratio < 0 {
+		return errors.New("retry_budget.ratio must not be negative")
+	}
+	if cfg.MinRetriesPerSecond < 0 {
+		return errors.New("retry_budget.min_retries_per_second must not be negative")
+	}
+	if cfg.Window < time.Second {
+		return errors.New("retry_budget.window must be at least 1s")
+	}
+	return nil
+}
@@ -0,0 +1,38 @@
+// Package retrybudget bounds the retries of exporters sharing a backend.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// A Budget allows retries in proportion to recent successful requests, so
+// aggregate retry traffic to a host stays bounded during a brownout instead
+// of multiplying with every exporter and signal sending to it. Within the
+// sliding window, a retry is admitted while the number of retries stays
+// below ratio times the number of successes plus min_retries_per_second
+// times the window length; the floor keeps low-traffic exporters able to
+// retry. Exporters targeting the same host with the same settings share one
+// budget. A failed send that finds the budget exhausted is not retried.
+//
+// Configuration example:
+//
+//	exporters:
+//	  tfo:
+//	    retry_on_failure:
+//	      enabled: true
+//	    retry_budget:
+//	      enabled: true
+//	      ratio: 0.1
+//	      min_retries_per_second: 1
+//	      window: 10s
+package retrybudget // import "github.com/telemetryflow/telemetryflow-collector/pkg/retrybudget"
@@ -0,0 +1,130 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtimeconf
+
+import (
+	"bufio"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// DefaultCgroupRoot is the mount point of the cgroup filesystem.
+const DefaultCgroupRoot = "/sys/fs/cgroup"
+
+// cgroup v1 reports "no limit" as a page-rounded MaxInt64.
+const cgroupV1Unlimited = int64(1) << 62
+
+// Limits are the resources available to the process.
+type Limits struct {
+	// CPUQuota is the number of CPUs granted by the cgroup; zero when
+	// unlimited.
+	CPUQuota float64
+
+	// Memory is the cgroup memory limit in bytes; zero when unlimited.
+	Memory int64
+
+	// TotalMemory is the physical memory of the host in bytes; zero when
+	// unknown.
+	TotalMemory int64
+}
+
+// AvailableMemory returns the memory limit the process runs under: the
+// cgroup limit when set, otherwise the host memory.
+func (l Limits) AvailableMemory() int64 {
+	if l.Memory > 0 {
+		return l.Memory
+	}
+	return l.TotalMemory
+}
+
+// DetectLimits reads the cgroup v2 or v1 limits mounted at root and the
+// host memory from /proc/meminfo. Missing or unreadable files count as
+// unlimited.
+func DetectLimits(root string) Limits {
+	l := Limits{TotalMemory: readMemTotal("/proc/meminfo")}
+	if b, err := os.ReadFile(filepath.Join(root, "cpu.max")); err == nil {
+		l.CPUQuota = parseCPUMax(string(b))
+		l.Memory = readLimit(filepath.Join(root, "memory.max"))
+		return l
+	}
+	for _, dir := range []string{"cpu", "cpu,cpuacct"} {
+		quota := readInt(filepath.Join(root, dir, "cpu.cfs_quota_us"))
+		period := readInt(filepath.Join(root, dir, "cpu.cfs_period_us"))
+		if quota > 0 && period > 0 {
+			l.CPUQuota = float64(quota) / float64(period)
+			break
+		}
+	}
+	l.Memory = readLimit(filepath.Join(root, "memory", "memory.limit_in_bytes"))
+	return l
+}
+
+// parseCPUMax parses the cgroup v2 cpu.max format "$MAX $PERIOD".
+func parseCPUMax(s string) float64 {
+	fields := strings.Fields(s)
+	if len(fields) != 2 || fields[0] == "max" {
+		return 0
+	}
+	quota, err1 := strconv.ParseInt(fields[0], 10, 64)
+	period, err2 := strconv.ParseInt(fields[1], 10, 64)
+	if err1 != nil || err2 != nil || quota <= 0 || period <= 0 {
+		return 0
+	}
+	return float64(quota) / float64(period)
+}
+
+func readInt(path string) int64 {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return 0
+	}
+	n, err := strconv.ParseInt(strings.TrimSpace(string(b)), 10, 64)
+	if err != nil {
+		return 0
+	}
+	return n
+}
+
+func readLimit(path string) int64 {
+	n := readInt(path)
+	if n <= 0 || n >= cgroupV1Unlimited {
+		return 0
+	}
+	return n
+}
+
+func readMemTotal(path string) int64 {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0
+	}
+	defer f.Close()
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) < 2 || fields[0] != "MemTotal:" {
+			continue
+		}
+		kb, err := strconv.ParseInt(fields[1], 10, 64)
+		if err != nil || kb > math.MaxInt64>>10 {
+			return 0
+		}
+		return kb << 10
+	}
+	return 0
+}
@@ -0,0 +1,117 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtimeconf
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/bytesize"
+)
+
+// MemoryLimitOff disables the soft memory limit.
+const MemoryLimitOff = "off"
+
+// Config defines the runtime section of the collector configuration.
+type Config struct {
+	// GOMAXPROCS is the number of threads executing Go code. Zero derives
+	// it from the cgroup CPU quota.
+	// Default: 0
+	GOMAXPROCS int `mapstructure:"gomaxprocs"`
+
+	// MemoryLimit is the soft memory limit (GOMEMLIMIT), e.g. "1536MiB" or
+	// "1.5GB", or a percentage of the cgroup or host memory, e.g. "80%".
+	// Empty derives it from the memory_limiter processor; "off" disables the
+	// limit.
+	// Default: ""
+	MemoryLimit string `mapstructure:"gomemlimit"`
+
+	// GCPercent is the GC target percentage (GOGC). Zero keeps the Go
+	// default; a negative value turns the GC off.
+	// Default: 0
+	GCPercent int `mapstructure:"gc_percent"`
+}
+
+// Validate checks the configuration for errors.
+func (cfg *Config) Validate() error {
+	if cfg.GOMAXPROCS < 0 {
+		return errors.New("runtime.gomaxprocs must not be negative")
+	}
+	if pct, ok := strings.CutSuffix(cfg.MemoryLimit, "%"); ok {
+		if _, err := parsePercent(pct); err != nil {
+			return fmt.Errorf("runtime.gomemlimit: %w", err)
+		}
+	} else if cfg.MemoryLimit != "" && cfg.MemoryLimit != MemoryLimitOff {
+		if _, err := bytesize.Parse(cfg.MemoryLimit); err != nil {
+			return fmt.Errorf("runtime.gomemlimit: %w", err)
+		}
+	}
+	return nil
+}
+
+// parsePercent parses the number of a percentage, from 1 to 100.
+func parsePercent(in string) (int64, error) {
+	n, err := strconv.ParseInt(strings.TrimSpace(in), 10, 64)
+	if err != nil || n < 1 || n > 100 {
+		return 0, fmt.Errorf("invalid percentage %q: must be from 1%% to 100%%", in+"%")
+	}
+	return n, nil
+}
+
+var byteUnits = []struct {
+	suffix string
+	scale  int64
+}{
+	{"TiB", 1 << 40},
+	{"GiB", 1 << 30},
+	{"MiB", 1 << 20},
+	{"KiB", 1 << 10},
+	{"B", 1},
+}
+
+// ParseBytes parses a size in the GOMEMLIMIT format: a non-negative integer
+// with an optional B, KiB, MiB, GiB or TiB suffix.
+func ParseBytes(in string) (int64, error) {
+	s := strings.TrimSpace(in)
+	scale := int64(1)
+	for _, u := range byteUnits {
+		if strings.HasSuffix(s, u.suffix) {
+			s = strings.TrimSpace(strings.TrimSuffix(s, u.suffix))
+			scale = u.scale
+			break
+		}
+	}
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || n < 0 {
+		return 0, fmt.Errorf("invalid size %q", in)
+	}
+	if n > math.MaxInt64/scale {
+		return 0, fmt.Errorf("size %q overflows", in)
+	}
+	return n * scale, nil
+}
+
+// FormatBytes renders n in the largest binary unit that divides it.
+func FormatBytes(n int64) string {
+	for _, u := range byteUnits {
+		if n >= u.scale && n%u.scale == 0 {
+			return strconv.FormatInt(n/u.scale, 10) + u.suffix
+		}
+	}
+	return strconv.FormatInt(n, 10) + "B"
+}
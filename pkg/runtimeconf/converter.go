@@ -0,0 +1,113 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtimeconf
+
+import (
+	"context"
+	"math"
+	"os"
+	"strconv"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.uber.org/zap"
+)
+
+// SectionKey is the top-level configuration key of the runtime section.
+const SectionKey = "runtime"
+
+// NewConverterFactory returns a converter that applies the runtime section
+// to the Go runtime and removes it from the configuration.
+func NewConverterFactory() confmap.ConverterFactory {
+	return NewConverterFactoryWithRoot(DefaultCgroupRoot)
+}
+
+// NewConverterFactoryWithRoot is like NewConverterFactory but reads the
+// cgroup limits below root.
+func NewConverterFactoryWithRoot(root string) confmap.ConverterFactory {
+	return confmap.NewConverterFactory(func(set confmap.ConverterSettings) confmap.Converter {
+		logger := set.Logger
+		if logger == nil {
+			logger = zap.NewNop()
+		}
+		return &converter{logger: logger, root: root}
+	})
+}
+
+type converter struct {
+	logger *zap.Logger
+	root   string
+}
+
+// LoadConfig reads and validates the runtime section of conf.
+func LoadConfig(conf *confmap.Conf) (Config, error) {
+	var cfg Config
+	if conf.IsSet(SectionKey) {
+		sub, err := conf.Sub(SectionKey)
+		if err != nil {
+			return cfg, err
+		}
+		if err := sub.Unmarshal(&cfg); err != nil {
+			return cfg, err
+		}
+	}
+	return cfg, cfg.Validate()
+}
+
+func (c *converter) Convert(_ context.Context, conf *confmap.Conf) error {
+	cfg, err := LoadConfig(conf)
+	if err != nil {
+		return err
+	}
+	conf.Delete(SectionKey)
+
+	limits := DetectLimits(c.root)
+	plan, err := Resolve(cfg, conf, limits, os.LookupEnv)
+	if err != nil {
+		return err
+	}
+	plan.Apply()
+
+	c.logger.Info("Go runtime configured",
+		zap.Int("gomaxprocs", plan.GOMAXPROCS),
+		zap.String("gomaxprocs_source", plan.GOMAXPROCSSource),
+		zap.String("gomemlimit", formatLimit(plan.MemoryLimit)),
+		zap.String("gomemlimit_source", plan.MemoryLimitSource),
+		zap.String("gc_percent", formatGCPercent(plan.GCPercent)),
+		zap.String("gc_percent_source", plan.GCPercentSource),
+		zap.Float64("cgroup_cpu_quota", limits.CPUQuota),
+		zap.String("cgroup_memory_limit", formatLimit(limits.Memory)),
+	)
+	return nil
+}
+
+func formatLimit(n int64) string {
+	switch n {
+	case 0:
+		return "none"
+	case math.MaxInt64:
+		return MemoryLimitOff
+	}
+	return FormatBytes(n)
+}
+
+func formatGCPercent(n int) string {
+	switch {
+	case n == 0:
+		return "default"
+	case n < 0:
+		return "off"
+	}
+	return strconv.Itoa(n)
+}
@@ -0,0 +1,34 @@
+// Package runtimeconf tunes the Go runtime of the collector process.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// The top-level "runtime" section of the collector configuration sets
+// GOMAXPROCS, GOMEMLIMIT and the GC percent. Values left unset are derived
+// automatically: GOMAXPROCS from the cgroup CPU quota and GOMEMLIMIT from the
+// soft limit of the memory_limiter processor. Explicit GOMAXPROCS, GOMEMLIMIT
+// and GOGC environment variables always win over derived values. The chosen
+// values and their source are logged at startup.
+//
+// The section is applied by a confmap converter, which removes it before the
+// configuration reaches otelcol. Builder installs the converter by default.
+//
+// Example:
+//
+//	runtime:
+//	  gomaxprocs: 0          # 0 = from cgroup CPU quota
+//	  gomemlimit: ""         # "" = from memory_limiter, "off", e.g. "1536MiB" or "80%"
+//	  gc_percent: 0          # 0 = Go default (GOGC=100), -1 = off
+package runtimeconf // import "github.com/telemetryflow/telemetryflow-collector/pkg/runtimeconf"
@@ -0,0 +1,216 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtimeconf
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"runtime/debug"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/bytesize"
+)
+
+// Sources of a runtime setting.
+const (
+	SourceDefault       = "default"
+	SourceConfig        = "config"
+	SourceEnv           = "env"
+	SourceCgroup        = "cgroup"
+	SourceMemoryLimiter = "memory_limiter"
+)
+
+// memoryLimiterType is the component type of the memory limiter processor.
+const memoryLimiterType = "memory_limiter"
+
+// Plan holds the runtime settings chosen for the process.
+type Plan struct {
+	// GOMAXPROCS is the number of threads executing Go code.
+	GOMAXPROCS       int
+	GOMAXPROCSSource string
+
+	// MemoryLimit is the soft memory limit in bytes; math.MaxInt64 when
+	// off and zero when left to the runtime.
+	MemoryLimit       int64
+	MemoryLimitSource string
+
+	// GCPercent is the GC target percentage; negative when off and zero
+	// when left to the runtime.
+	GCPercent       int
+	GCPercentSource string
+}
+
+// Resolve chooses the runtime settings. Explicit config wins, then the
+// GOMAXPROCS, GOMEMLIMIT and GOGC environment variables, then values
+// derived from limits and the memory_limiter processors in conf.
+func Resolve(cfg Config, conf *confmap.Conf, limits Limits, lookupEnv func(string) (string, bool)) (Plan, error) {
+	var p Plan
+
+	switch _, env := lookupEnv("GOMAXPROCS"); {
+	case cfg.GOMAXPROCS > 0:
+		p.GOMAXPROCS, p.GOMAXPROCSSource = cfg.GOMAXPROCS, SourceConfig
+	case env:
+		p.GOMAXPROCS, p.GOMAXPROCSSource = runtime.GOMAXPROCS(0), SourceEnv
+	case limits.CPUQuota > 0:
+		n := int(math.Ceil(limits.CPUQuota))
+		p.GOMAXPROCS, p.GOMAXPROCSSource = min(max(n, 1), runtime.NumCPU()), SourceCgroup
+	default:
+		p.GOMAXPROCS, p.GOMAXPROCSSource = runtime.GOMAXPROCS(0), SourceDefault
+	}
+
+	envLimit, env := lookupEnv("GOMEMLIMIT")
+	switch {
+	case cfg.MemoryLimit == MemoryLimitOff:
+		p.MemoryLimit, p.MemoryLimitSource = math.MaxInt64, SourceConfig
+	case strings.HasSuffix(cfg.MemoryLimit, "%"):
+		pct, err := parsePercent(strings.TrimSuffix(cfg.MemoryLimit, "%"))
+		if err != nil {
+			return Plan{}, fmt.Errorf("runtime.gomemlimit: %w", err)
+		}
+		// Without a known amount of memory the limit is left to the runtime.
+		p.MemoryLimitSource = SourceDefault
+		if n := limits.AvailableMemory() / 100 * pct; n > 0 {
+			p.MemoryLimit, p.MemoryLimitSource = n, SourceConfig
+		}
+	case cfg.MemoryLimit != "":
+		n, err := bytesize.Parse(cfg.MemoryLimit)
+		if err != nil {
+			return Plan{}, fmt.Errorf("runtime.gomemlimit: %w", err)
+		}
+		p.MemoryLimit, p.MemoryLimitSource = n.Bytes(), SourceConfig
+	case env:
+		p.MemoryLimitSource = SourceEnv
+		if envLimit == MemoryLimitOff {
+			p.MemoryLimit = math.MaxInt64
+		} else if n, err := ParseBytes(envLimit); err == nil {
+			p.MemoryLimit = n
+		}
+	default:
+		n, err := memoryLimiterSoftLimit(conf, limits.AvailableMemory())
+		if err != nil {
+			return Plan{}, err
+		}
+		p.MemoryLimit, p.MemoryLimitSource = n, SourceDefault
+		if n > 0 {
+			p.MemoryLimitSource = SourceMemoryLimiter
+		}
+	}
+
+	envGC, env := lookupEnv("GOGC")
+	switch {
+	case cfg.GCPercent != 0:
+		p.GCPercent, p.GCPercentSource = max(cfg.GCPercent, -1), SourceConfig
+	case env:
+		p.GCPercentSource = SourceEnv
+		if envGC == "off" {
+			p.GCPercent = -1
+		} else if n, err := strconv.Atoi(envGC); err == nil {
+			p.GCPercent = n
+		}
+	default:
+		p.GCPercentSource = SourceDefault
+	}
+	return p, nil
+}
+
+// Apply sets the chosen values on the Go runtime. Values taken from the
+// environment or left to the runtime are not touched; GOMAXPROCS is only
+// set when it differs, so the runtime keeps tracking quota changes.
+func (p Plan) Apply() {
+	if p.GOMAXPROCSSource == SourceConfig || p.GOMAXPROCSSource == SourceCgroup {
+		if p.GOMAXPROCS != runtime.GOMAXPROCS(0) {
+			runtime.GOMAXPROCS(p.GOMAXPROCS)
+		}
+	}
+	if p.MemoryLimitSource == SourceConfig || p.MemoryLimitSource == SourceMemoryLimiter {
+		debug.SetMemoryLimit(p.MemoryLimit)
+	}
+	if p.GCPercentSource == SourceConfig {
+		debug.SetGCPercent(p.GCPercent)
+	}
+}
+
+// memoryLimiterSoftLimit returns the smallest soft limit (limit minus spike
+// limit) of the memory_limiter processors used by a pipeline, or zero when
+// there is none. available resolves percentage based limits.
+func memoryLimiterSoftLimit(conf *confmap.Conf, available int64) (int64, error) {
+	processors, err := conf.Sub("processors")
+	if err != nil {
+		return 0, err
+	}
+	used := pipelineProcessors(conf)
+
+	var soft int64
+	for id, raw := range processors.ToStringMap() {
+		if typ, _, _ := strings.Cut(id, "/"); typ != memoryLimiterType || !used[id] {
+			continue
+		}
+		settings, _ := raw.(map[string]any)
+		var limit, spike int64
+		if mib := toInt64(settings["limit_mib"]); mib > 0 {
+			limit = mib << 20
+			spike = toInt64(settings["spike_limit_mib"]) << 20
+		} else if pct := toInt64(settings["limit_percentage"]); pct > 0 && available > 0 {
+			limit = available / 100 * pct
+			spike = available / 100 * toInt64(settings["spike_limit_percentage"])
+		}
+		if spike == 0 {
+			// The memory_limiter default spike limit is 20% of the limit.
+			spike = limit / 5
+		}
+		if n := limit - spike; n > 0 && (soft == 0 || n < soft) {
+			soft = n
+		}
+	}
+	return soft, nil
+}
+
+// pipelineProcessors returns the IDs of the processors referenced by any
+// pipeline.
+func pipelineProcessors(conf *confmap.Conf) map[string]bool {
+	used := make(map[string]bool)
+	pipelines, _ := conf.Get("service::pipelines").(map[string]any)
+	for _, raw := range pipelines {
+		pipeline, _ := raw.(map[string]any)
+		ids, _ := pipeline["processors"].([]any)
+		for _, id := range ids {
+			if s, ok := id.(string); ok {
+				used[s] = true
+			}
+		}
+	}
+	return used
+}
+
+func toInt64(v any) int64 {
+	switch n := v.(type) {
+	case int:
+		return int64(n)
+	case int64:
+		return n
+	case uint64:
+		return int64(min(n, math.MaxInt64))
+	case float64:
+		return int64(n)
+	case string:
+		i, _ := strconv.ParseInt(strings.TrimSpace(n), 10, 64)
+		return i
+	}
+	return 0
+}
@@ -0,0 +1,36 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// The tfoarchive connector archives the traces that the samplers of a
+// pipeline dropped. It holds a copy of every trace until the samplers have
+// decided; the tfosampled processor, placed after the samplers, records
+// the traces they kept in the Decisions shared under the connector ID, and
+// the connector archives the held traces that were not recorded.
+//
+// Decisions are shared process-wide by name, so that the two components,
+// created independently by the collector, find each other. Kept traces are
+// remembered for the retention set by the connector and at most MaxKept
+// of them are held.
+//
+// Example:
+//
+//	// after the samplers
+//	sampled.For("tfoarchive").Keep(traceID, time.Now())
+//
+//	// once the samplers had time to decide
+//	if !sampled.For("tfoarchive").Kept(traceID) {
+//		archive(trace)
+//	}
+package sampled // import "github.com/telemetryflow/telemetryflow-collector/pkg/sampled"
@@ -0,0 +1,3 @@
+module github.com/telemetryflow/telemetryflow-collector/pkg/sampled
+
+go 1.26
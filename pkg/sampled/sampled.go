@@ -0,0 +1,116 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package sampled
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultRetention is how long kept traces are remembered until a
+	// connector sets its own retention.
+	DefaultRetention = 2 * time.Minute
+
+	// MaxKept bounds the kept traces remembered by one Decisions; the
+	// oldest are forgotten first.
+	MaxKept = 1 << 20
+)
+
+var (
+	registryMu sync.Mutex
+	registry   = make(map[string]*Decisions)
+)
+
+// For returns the decisions shared under name, e.g. the ID of a tfoarchive
+// connector, creating them on first use.
+func For(name string) *Decisions {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	d, ok := registry[name]
+	if !ok {
+		d = &Decisions{
+			kept:      make(map[[16]byte]time.Time),
+			retention: DefaultRetention,
+		}
+		registry[name] = d
+	}
+	return d
+}
+
+// Decisions records the traces kept by samplers. It is safe for concurrent
+// use.
+type Decisions struct {
+	mu        sync.Mutex
+	kept      map[[16]byte]time.Time
+	order     []decision
+	retention time.Duration
+}
+
+// decision is a kept trace in the order it was recorded.
+type decision struct {
+	id [16]byte
+	at time.Time
+}
+
+// SetRetention sets how long kept traces are remembered. It must exceed the
+// time the connector holds traces.
+func (d *Decisions) SetRetention(retention time.Duration) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.retention = retention
+}
+
+// Keep records that the trace with the given ID was kept at now.
+func (d *Decisions) Keep(id [16]byte, now time.Time) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.kept[id] = now
+	d.order = append(d.order, decision{id: id, at: now})
+	d.expire(now)
+}
+
+// Kept reports whether the trace with the given ID was recorded as kept
+// within the retention.
+func (d *Decisions) Kept(id [16]byte) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	_, ok := d.kept[id]
+	return ok
+}
+
+// Len returns the number of kept traces remembered.
+func (d *Decisions) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.kept)
+}
+
+// expire forgets the traces recorded before the retention and the oldest
+// ones over MaxKept. d.mu must be held.
+func (d *Decisions) expire(now time.Time) {
+	cutoff := now.Add(-d.retention)
+	for len(d.order) > 0 {
+		oldest := d.order[0]
+		if !oldest.at.Before(cutoff) && len(d.kept) <= MaxKept {
+			break
+		}
+		d.order = d.order[1:]
+		// A trace kept again later has a newer entry further on.
+		if at, ok := d.kept[oldest.id]; ok && at.Equal(oldest.at) {
+			delete(d.kept, oldest.id)
+		}
+	}
+}
@@ -0,0 +1,62 @@
+// Package selfmetrics is the registry of the collector's internal metrics.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Every metric a TFO component or shared package records about itself is
+// named in names.go and carries the component labels of this package, so
+// traffic can be attributed when several receivers and exporters run side
+// by side.
+//
+// # Naming
+//
+// Metric names are tfo_<area>_<measurement>, in snake case, where area is
+// the component or package recording it (receiver, exporter, dedup,
+// watchdog, ...). The unit is set with metric.WithUnit rather than encoded
+// in the name, except for seconds where Prometheus convention expects a
+// _seconds suffix. Counters carry no _total suffix; the Prometheus exporter
+// adds it.
+//
+// # Labels
+//
+// Every data point carries all five component labels, so one query can
+// aggregate across components:
+//
+//	component.kind  receiver, processor, exporter, connector or extension
+//	component.type  the component type, e.g. tfo
+//	component.name  the component name, e.g. eu for tfo/eu, or empty
+//	pipeline        the pipeline ID, e.g. traces/edge
+//	signal          traces, metrics or logs
+//
+// The collector does not tell a component which pipelines it is wired into,
+// and receivers are shared by all pipelines of their type, so pipeline is
+// All unless a component is given its pipeline explicitly. signal is All
+// for measurements not tied to one signal, such as a receiver's listeners
+// or an extension.
+//
+// # Cardinality
+//
+// Label values must come from configuration or from a small fixed set
+// (protocol, mode, outcome, state), never from the telemetry being
+// processed: no service names, tenant IDs, attribute values or endpoints
+// taken from requests. Values that may come from data are mapped onto a
+// bounded set before they become labels, as residency does for regions.
+//
+// Example:
+//
+//	labels := selfmetrics.Exporter(set.ID, pipeline.SignalTraces)
+//	counter, _ := meter.Int64Counter(selfmetrics.RetryBudgetExhausted)
+//	counter.Add(ctx, 1, labels.Option(attribute.String("server.address", host)))
+package selfmetrics // import "github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
@@ -0,0 +1,117 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package selfmetrics
+
+import (
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Label keys carried by every internal metric.
+const (
+	KindKey     = "component.kind"
+	TypeKey     = "component.type"
+	NameKey     = "component.name"
+	PipelineKey = "pipeline"
+	SignalKey   = "signal"
+)
+
+// All is the pipeline or signal label value of measurements not tied to a
+// single pipeline or signal.
+const All = "_all"
+
+// Labels identifies the component a measurement is recorded for. The zero
+// Pipeline and Signal are reported as All.
+type Labels struct {
+	Kind     component.Kind
+	ID       component.ID
+	Pipeline pipeline.ID
+	Signal   pipeline.Signal
+}
+
+// Receiver returns the labels of the receiver id.
+func Receiver(id component.ID) Labels {
+	return Labels{Kind: component.KindReceiver, ID: id}
+}
+
+// Processor returns the labels of the processor id handling signal.
+func Processor(id component.ID, signal pipeline.Signal) Labels {
+	return Labels{Kind: component.KindProcessor, ID: id, Signal: signal}
+}
+
+// Exporter returns the labels of the exporter id handling signal.
+func Exporter(id component.ID, signal pipeline.Signal) Labels {
+	return Labels{Kind: component.KindExporter, ID: id, Signal: signal}
+}
+
+// Connector returns the labels of the connector id consuming signal.
+func Connector(id component.ID, signal pipeline.Signal) Labels {
+	return Labels{Kind: component.KindConnector, ID: id, Signal: signal}
+}
+
+// Extension returns the labels of the extension id.
+func Extension(id component.ID) Labels {
+	return Labels{Kind: component.KindExtension, ID: id}
+}
+
+// WithSignal returns a copy of l for signal.
+func (l Labels) WithSignal(signal pipeline.Signal) Labels {
+	l.Signal = signal
+	return l
+}
+
+// WithPipeline returns a copy of l for the pipeline id. The signal is taken
+// from the pipeline.
+func (l Labels) WithPipeline(id pipeline.ID) Labels {
+	l.Pipeline = id
+	l.Signal = id.Signal()
+	return l
+}
+
+// String returns the component as kind/type[/name], e.g. exporter/tfo/eu.
+// It is meant for log fields and heartbeat names.
+func (l Labels) String() string {
+	return strings.ToLower(l.Kind.String()) + "/" + l.ID.String()
+}
+
+// Attributes returns the labels as attributes, followed by extra.
+func (l Labels) Attributes(extra ...attribute.KeyValue) []attribute.KeyValue {
+	pipelineID := l.Pipeline.String()
+	if pipelineID == "" {
+		pipelineID = All
+	}
+	signal := l.Signal.String()
+	if signal == "" {
+		signal = All
+	}
+	return append([]attribute.KeyValue{
+		attribute.String(KindKey, strings.ToLower(l.Kind.String())),
+		attribute.String(TypeKey, l.ID.Type().String()),
+		attribute.String(NameKey, l.ID.Name()),
+		attribute.String(PipelineKey, pipelineID),
+		attribute.String(SignalKey, signal),
+	}, extra...)
+}
+
+// Option returns a measurement option recording the labels and extra.
+// Callers recording on a hot path should build the option once and reuse
+// it.
+func (l Labels) Option(extra ...attribute.KeyValue) metric.MeasurementOption {
+	return metric.WithAttributeSet(attribute.NewSet(l.Attributes(extra...)...))
+}
@@ -0,0 +1,200 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package selfmetrics
+
+// Names of the internal metrics. Each entry lists the labels recorded on
+// top of the component labels. Add new metrics here so that names stay
+// unique and follow the scheme in the package documentation.
+const (
+	// ReceiverDrainDroppedRequests counts in-flight requests cut off by the
+	// drain timeout. Extra labels: protocol.
+	ReceiverDrainDroppedRequests = "tfo_receiver_drain_dropped_requests"
+
+	// ReceiverRequestSize is the payload size of export requests as
+	// received. Extra labels: protocol, endpoint.
+	ReceiverRequestSize = "tfo_receiver_request_size"
+
+	// ReceiverRequestDecompressedSize is the payload size of export
+	// requests after decompression. Extra labels: protocol, endpoint.
+	ReceiverRequestDecompressedSize = "tfo_receiver_request_decompressed_size"
+
+	// ReceiverRequestRecords is the number of spans, data points or log
+	// records per export request. Extra labels: protocol, endpoint.
+	ReceiverRequestRecords = "tfo_receiver_request_records"
+
+	// ReceiverProvenanceRejected counts records dropped because they looped
+	// back to the collector or exceeded the hop limit. Extra labels:
+	// reason.
+	ReceiverProvenanceRejected = "tfo_receiver_provenance_rejected"
+
+	// ReceiverRateLimited counts records rejected by the receiver rate
+	// limit.
+	ReceiverRateLimited = "tfo_receiver_rate_limited"
+
+	// ReceiverKeyRateLimited counts records rejected by the rate limit of
+	// their API key.
+	ReceiverKeyRateLimited = "tfo_receiver_key_rate_limited"
+
+	// ReceiverAuthFailures counts requests to the v2 endpoints rejected by
+	// authentication. Extra labels: reason, key_prefix.
+	ReceiverAuthFailures = "tfo_receiver_auth_failures"
+
+	// ReceiverMaintenanceRejected counts records rejected because the
+	// collector is in maintenance mode.
+	ReceiverMaintenanceRejected = "tfo_receiver_maintenance_rejected"
+
+	// ReceiverMQTTMessages counts MQTT messages received. Extra labels:
+	// outcome.
+	ReceiverMQTTMessages = "tfo_receiver_mqtt_messages"
+
+	// ReceiverDatagrams counts CoAP messages or UDP datagrams received.
+	// Extra labels: outcome.
+	ReceiverDatagrams = "tfo_receiver_datagrams"
+
+	// ReceiverKafkaMessages counts Kafka messages consumed. Extra labels:
+	// outcome.
+	ReceiverKafkaMessages = "tfo_receiver_kafka_messages"
+
+	// ComponentPanics counts panics of processors and exporters recovered
+	// by the crash guard.
+	ComponentPanics = "tfo_component_panics"
+
+	// AdaptiveConcurrencyLimit is the current limit of an adaptive send
+	// path.
+	AdaptiveConcurrencyLimit = "tfo_adaptive_concurrency_limit"
+
+	// RetryBudgetExhausted counts retries skipped for lack of budget.
+	// Extra labels: server.address.
+	RetryBudgetExhausted = "tfo_retry_budget_exhausted"
+
+	// ResidencyViolations counts records whose region is not allowed.
+	// Extra labels: region, mode.
+	ResidencyViolations = "tfo_residency_violations"
+
+	// ExporterAttributesRemoved counts attributes removed by the attribute
+	// filter of an exporter. Extra labels: level.
+	ExporterAttributesRemoved = "tfo_exporter_attributes_removed"
+
+	// ExporterProfileRecords counts records exported per credential
+	// profile. Extra labels: profile, outcome.
+	ExporterProfileRecords = "tfo_exporter_profile_records"
+
+	// ExporterDryRunRequests counts requests a dry-run exporter built
+	// without sending them.
+	ExporterDryRunRequests = "tfo_exporter_dry_run_requests"
+
+	// ExporterDryRunRecords counts records a dry-run exporter would have
+	// sent.
+	ExporterDryRunRecords = "tfo_exporter_dry_run_records"
+
+	// ExporterDryRunBytes counts request body bytes a dry-run exporter
+	// would have sent. Extra labels: stage (encoded, compressed).
+	ExporterDryRunBytes = "tfo_exporter_dry_run_bytes"
+
+	// ExporterShutdownSpilled counts queued records written to the spill
+	// file on shutdown instead of being dropped.
+	ExporterShutdownSpilled = "tfo_exporter_shutdown_spilled"
+
+	// ExporterDeadLettered counts records whose retries were exhausted and
+	// that were written to the dead letter spool instead of being dropped.
+	ExporterDeadLettered = "tfo_exporter_dead_lettered"
+
+	// ExporterDeadLetterEvicted counts records removed from the dead letter
+	// spool to keep it within its size limit.
+	ExporterDeadLetterEvicted = "tfo_exporter_dead_letter_evicted"
+
+	// ExporterDownsampledSeries is the number of series written by the
+	// Prometheus exporter for its last downsampling window.
+	ExporterDownsampledSeries = "tfo_exporter_downsampled_series"
+
+	// ErrorBudgetTransitions counts exporters leaving or rejoining the
+	// fan-out after exhausting their error budget. Extra labels: state.
+	ErrorBudgetTransitions = "tfo_error_budget_transitions"
+
+	// ErrorBudgetDropped counts records dropped while an exporter is out
+	// of the fan-out.
+	ErrorBudgetDropped = "tfo_error_budget_dropped"
+
+	// WatchdogIncidents counts wedged loops detected by the watchdog.
+	WatchdogIncidents = "tfo_watchdog_incidents"
+
+	// WatchdogRestarts counts restarts attempted by the watchdog. Extra
+	// labels: outcome.
+	WatchdogRestarts = "tfo_watchdog_restarts"
+
+	// DedupSpansRemoved counts duplicate spans removed. Extra labels: mode.
+	DedupSpansRemoved = "tfo_dedup_spans_removed"
+
+	// ExemptSpansMarked counts spans exempted from sampling. Extra labels:
+	// reason (rule, trace).
+	ExemptSpansMarked = "tfo_exempt_spans_marked"
+
+	// SpanNameSpansRenamed counts spans renamed into templates. Extra
+	// labels: reason (rule, collapse).
+	SpanNameSpansRenamed = "tfo_spanname_spans_renamed"
+
+	// TraceIDLogsAssigned counts log records given synthesized trace IDs.
+	TraceIDLogsAssigned = "tfo_traceid_logs_assigned"
+
+	// AlertTransitions counts alert state transitions. Extra labels: rule,
+	// state.
+	AlertTransitions = "tfo_alert_transitions"
+
+	// MirrorBatches counts batches passed to the primary and shadow
+	// pipelines of a mirror connector. Extra labels: path, outcome.
+	MirrorBatches = "tfo_mirror_batches"
+
+	// ArchiveTraces counts traces held by an archive connector by outcome:
+	// kept by the samplers, archived, failed to archive, or dropped over
+	// max_traces. Extra labels: outcome.
+	ArchiveTraces = "tfo_archive_traces"
+
+	// ExperimentRecordsDropped is the records removed by the pipelines of
+	// an A/B experiment. Extra labels: path.
+	ExperimentRecordsDropped = "tfo_experiment_records_dropped"
+
+	// ExperimentSizeDelta is the encoded size of the telemetry leaving the
+	// pipelines of an A/B experiment minus the size entering them. Extra
+	// labels: path.
+	ExperimentSizeDelta = "tfo_experiment_size_delta"
+
+	// ExperimentLatencySeconds is the time the pipelines of an A/B
+	// experiment took to consume a batch. Extra labels: path.
+	ExperimentLatencySeconds = "tfo_experiment_latency_seconds"
+
+	// ClockDriftSeconds is the local clock minus the reference clock.
+	// Extra labels: source.
+	ClockDriftSeconds = "tfo_clock_drift_seconds"
+
+	// MaintenanceActive is 1 while the collector is in maintenance mode.
+	// Extra labels: mode.
+	MaintenanceActive = "tfo_maintenance_active"
+
+	// QueueTenantBytes is the bytes of the batches queued on disk for a
+	// tenant. Extra labels: tenant.
+	QueueTenantBytes = "tfo_queue_tenant_bytes"
+
+	// QueueTenantBatches is the number of batches queued on disk for a
+	// tenant. Extra labels: tenant.
+	QueueTenantBatches = "tfo_queue_tenant_batches"
+
+	// QueueTenantDropped counts queued batches evicted or refused to keep
+	// a tenant within its queue quota. Extra labels: tenant, reason.
+	QueueTenantDropped = "tfo_queue_tenant_dropped"
+
+	// AuthKeyRotations counts switches of a tfoauth extension to its
+	// secondary API key after the primary key was rejected.
+	AuthKeyRotations = "tfo_auth_key_rotations"
+)
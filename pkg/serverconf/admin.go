@@ -0,0 +1,194 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package serverconf
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.uber.org/zap"
+)
+
+// AdminConfig defines the listener of an admin API, such as the overrides or
+// maintenance APIs. It combines the upstream confighttp server settings
+// (endpoint, tls, auth, max_request_body_size, response_headers, timeouts)
+// with Config and HeadersConfig, and is meant to be embedded with
+// `mapstructure:",squash"`.
+//
+// Admin APIs expose and change collector state, so an endpoint whose host is
+// not a loopback address is refused unless clients authenticate, through the
+// auth extension or a verified client certificate. Server-side tls alone
+// does not count.
+type AdminConfig struct {
+	confighttp.ServerConfig `mapstructure:",squash"`
+	Config                  `mapstructure:",squash"`
+	HeadersConfig           `mapstructure:",squash"`
+}
+
+// NewDefaultAdminConfig returns the default admin settings listening on
+// endpoint.
+func NewDefaultAdminConfig(endpoint string) AdminConfig {
+	cfg := AdminConfig{ServerConfig: confighttp.NewDefaultServerConfig()}
+	cfg.NetAddr.Endpoint = endpoint
+	return cfg
+}
+
+// Validate checks the configuration for errors. An empty endpoint is
+// accepted; components that require one check it themselves.
+func (cfg *AdminConfig) Validate() error {
+	switch {
+	case cfg.CORS.HasValue():
+		return errors.New("cors is not supported")
+	case cfg.IncludeMetadata:
+		return errors.New("include_metadata is not supported")
+	case len(cfg.Middlewares) > 0:
+		return errors.New("middlewares is not supported")
+	case len(cfg.CompressionAlgorithms) > 0:
+		return errors.New("compression_algorithms is not supported")
+	case cfg.MaxRequestBodySize < 0:
+		return errors.New("max_request_body_size must not be negative")
+	}
+	if err := cfg.Config.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.HeadersConfig.Validate(); err != nil {
+		return err
+	}
+	if err := cfg.ValidateTLS(cfg.TLS.Get()); err != nil {
+		return err
+	}
+
+	endpoint := cfg.NetAddr.Endpoint
+	if endpoint == "" {
+		return nil
+	}
+	if err := cfg.ValidateEndpoint(endpoint); err != nil {
+		return err
+	}
+	if !isLoopback(endpoint) && !cfg.Auth.HasValue() && !cfg.requiresClientCert(cfg.TLS.Get()) {
+		return fmt.Errorf("endpoint %q is not a loopback address: configure auth or client_auth_type %q to expose the admin API",
+			endpoint, ClientAuthRequireAndVerify)
+	}
+	return nil
+}
+
+// isLoopback reports whether the host of endpoint only accepts connections
+// from this machine. Empty and wildcard hosts accept any.
+func isLoopback(endpoint string) bool {
+	host, _, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return false
+	}
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	addr, err := netip.ParseAddr(host)
+	return err == nil && addr.Unmap().IsLoopback()
+}
+
+// AdminServer is a running admin API.
+type AdminServer struct {
+	server *http.Server
+	tls    *TLS
+	addr   net.Addr
+	wg     sync.WaitGroup
+}
+
+// StartAdmin serves handler on the admin listener. The configured auth
+// extension is looked up in host and checks every request; requests it
+// rejects are answered with 401. name identifies the API in log messages,
+// e.g. "Overrides admin API".
+func (cfg *AdminConfig) StartAdmin(ctx context.Context, host component.Host, name string, handler http.Handler, logger *zap.Logger) (*AdminServer, error) {
+	if auth := cfg.Auth.Get(); auth != nil {
+		server, err := auth.GetServerAuthenticator(ctx, host.GetExtensions())
+		if err != nil {
+			return nil, fmt.Errorf("auth: %w", err)
+		}
+		handler = NewAuthHandler(server, auth.RequestParameters, handler)
+	}
+	if limit := cfg.MaxRequestBodySize; limit > 0 {
+		next := handler
+		handler = http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			req.Body = http.MaxBytesReader(w, req.Body, limit)
+			next.ServeHTTP(w, req)
+		})
+	}
+	handler = NewHeadersHandler(&cfg.HeadersConfig, cfg.ResponseHeaders, handler)
+
+	t, err := cfg.NewTLS(ctx, cfg.TLS.Get(), logger)
+	if err != nil {
+		return nil, err
+	}
+	lis, err := cfg.Listen(cfg.NetAddr.Endpoint)
+	if err != nil {
+		return nil, errors.Join(err, t.Shutdown(ctx))
+	}
+	wrapped, err := cfg.WrapListener(lis)
+	if err != nil {
+		_ = lis.Close()
+		return nil, errors.Join(err, t.Shutdown(ctx))
+	}
+	scheme := "http"
+	if t.Config() != nil {
+		scheme = "https"
+	}
+
+	s := &AdminServer{
+		server: NewHTTPServer(&cfg.ServerConfig, handler),
+		tls:    t,
+		addr:   lis.Addr(),
+	}
+	logger.Info(name+" listening",
+		zap.String("endpoint", s.addr.String()),
+		zap.String("url", cfg.ListenURL(scheme, s.addr)),
+	)
+	s.wg.Go(func() {
+		if err := s.server.Serve(t.WrapListener(wrapped)); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error(name+" error", zap.Error(err))
+		}
+	})
+	return s, nil
+}
+
+// Addr returns the address the server listens on.
+func (s *AdminServer) Addr() net.Addr {
+	return s.addr
+}
+
+// Shutdown stops the server gracefully. It is a no-op on a nil server.
+func (s *AdminServer) Shutdown(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+	err := s.server.Shutdown(ctx)
+	s.wg.Wait()
+	return errors.Join(err, s.tls.Shutdown(ctx))
+}
+
+// WriteJSON writes v as a JSON response.
+func WriteJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,101 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package serverconf
+
+import (
+	"context"
+	"net/http"
+
+	"go.opentelemetry.io/collector/extension/extensionauth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// NewAuthHandler passes the requests that auth accepts on to next, with the
+// context auth returns, and answers the others with 401. auth sees the
+// request headers plus the query parameters named in requestParams, as with
+// the upstream auth.request_params setting.
+func NewAuthHandler(auth extensionauth.Server, requestParams []string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		sources := map[string][]string(req.Header)
+		if len(requestParams) > 0 {
+			sources = req.Header.Clone()
+			query := req.URL.Query()
+			for _, param := range requestParams {
+				if values, ok := query[param]; ok {
+					sources[param] = values
+				}
+			}
+		}
+		ctx, err := auth.Authenticate(req.Context(), sources)
+		if err != nil {
+			WriteJSON(w, http.StatusUnauthorized, map[string]string{"error": "unauthorized"})
+			return
+		}
+		next.ServeHTTP(w, req.WithContext(ctx))
+	})
+}
+
+// NewAuthUnaryInterceptor checks the metadata of unary RPCs with auth.
+// Rejected RPCs fail with the status auth returns, or Unauthenticated.
+func NewAuthUnaryInterceptor(auth extensionauth.Server) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := authenticateRPC(ctx, auth)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// NewAuthStreamInterceptor checks the metadata of streaming RPCs with auth,
+// like NewAuthUnaryInterceptor.
+func NewAuthStreamInterceptor(auth extensionauth.Server) grpc.StreamServerInterceptor {
+	return func(srv any, stream grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := authenticateRPC(stream.Context(), auth)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &authStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// authenticateRPC passes the incoming metadata of ctx to auth.
+func authenticateRPC(ctx context.Context, auth extensionauth.Server) (context.Context, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "metadata not found")
+	}
+	ctx, err := auth.Authenticate(ctx, md)
+	if err != nil {
+		if st, ok := status.FromError(err); ok {
+			return nil, st.Err()
+		}
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+	return ctx, nil
+}
+
+// authStream carries the context returned by the auth extension.
+type authStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authStream) Context() context.Context {
+	return s.ctx
+}
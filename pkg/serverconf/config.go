@@ -0,0 +1,163 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package serverconf
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/netip"
+	"time"
+)
+
+// DefaultProxyHeaderTimeout bounds how long a connection may take to send its
+// PROXY protocol header before it is closed.
+const DefaultProxyHeaderTimeout = 5 * time.Second
+
+// Config defines the server hardening settings shared by all listener-based
+// components. It is meant to be embedded with `mapstructure:",squash"` next to
+// the upstream confighttp/configgrpc server settings.
+type Config struct {
+	// Network selects the IP families the listener accepts: "tcp4",
+	// "tcp6" or "dual". When unset, a wildcard or empty host accepts both
+	// families and an address host only its own.
+	// Default: ""
+	Network string `mapstructure:"network"`
+
+	// ACL restricts which client addresses may connect.
+	ACL ACLConfig `mapstructure:"acl"`
+
+	// ProxyProtocol enables PROXY protocol (v1 and v2) header parsing so the
+	// real client address is used for ACLs and logging behind load balancers.
+	ProxyProtocol ProxyProtocolConfig `mapstructure:"proxy_protocol"`
+
+	// ACME obtains and renews the server certificate automatically. The
+	// upstream tls settings, if any, remain the fallback certificate.
+	ACME ACMEConfig `mapstructure:"acme"`
+
+	// ClientAuthType selects how client certificates are requested and
+	// verified: "none", "request", "require_any", "verify_if_given" or
+	// "require_and_verify". The verifying types check certificates against
+	// the upstream tls.client_ca_file. Empty keeps the upstream behaviour
+	// of requiring a verified certificate when tls.client_ca_file is set.
+	// Default: ""
+	ClientAuthType ClientAuthType `mapstructure:"client_auth_type"`
+
+	// TLSReload reloads the upstream tls certificate, key and client CA
+	// files when they change on disk.
+	TLSReload TLSReloadConfig `mapstructure:"tls_reload"`
+}
+
+// ACLConfig defines connection-level allow and deny lists.
+type ACLConfig struct {
+	// AllowedCIDRs lists the networks allowed to connect.
+	// If empty, all addresses not denied are allowed.
+	AllowedCIDRs []string `mapstructure:"allowed_cidrs"`
+
+	// DeniedCIDRs lists the networks that are always rejected.
+	// Deny rules take precedence over allow rules.
+	DeniedCIDRs []string `mapstructure:"denied_cidrs"`
+}
+
+// ProxyProtocolConfig defines PROXY protocol settings.
+type ProxyProtocolConfig struct {
+	// Enabled turns on PROXY protocol header parsing.
+	// Default: false
+	Enabled bool `mapstructure:"enabled"`
+
+	// TrustedCIDRs lists the load balancer networks allowed to send PROXY
+	// headers. Connections from other peers are served without a header,
+	// so a spoofed client address cannot bypass the ACLs. Required when
+	// enabled.
+	TrustedCIDRs []string `mapstructure:"trusted_cidrs"`
+
+	// HeaderTimeout bounds how long a peer may take to send the header.
+	// Default: 5s
+	HeaderTimeout time.Duration `mapstructure:"header_timeout"`
+}
+
+// Validate checks the configuration for errors.
+func (cfg *Config) Validate() error {
+	if err := validateNetwork(cfg.Network); err != nil {
+		return err
+	}
+	if _, err := parsePrefixes(cfg.ACL.AllowedCIDRs); err != nil {
+		return fmt.Errorf("acl.allowed_cidrs: %w", err)
+	}
+	if _, err := parsePrefixes(cfg.ACL.DeniedCIDRs); err != nil {
+		return fmt.Errorf("acl.denied_cidrs: %w", err)
+	}
+	if _, err := parsePrefixes(cfg.ProxyProtocol.TrustedCIDRs); err != nil {
+		return fmt.Errorf("proxy_protocol.trusted_cidrs: %w", err)
+	}
+	if cfg.ProxyProtocol.Enabled && len(cfg.ProxyProtocol.TrustedCIDRs) == 0 {
+		return errors.New("proxy_protocol.trusted_cidrs must not be empty when proxy_protocol is enabled")
+	}
+	if cfg.ProxyProtocol.HeaderTimeout < 0 {
+		return errors.New("proxy_protocol.header_timeout must not be negative")
+	}
+	if err := cfg.ClientAuthType.validate(); err != nil {
+		return err
+	}
+	if cfg.TLSReload.Interval < 0 {
+		return errors.New("tls_reload.interval must not be negative")
+	}
+	return cfg.ACME.Validate()
+}
+
+// parsePrefixes parses CIDR strings. Bare IP addresses are accepted and
+// treated as single-host prefixes.
+func parsePrefixes(cidrs []string) ([]netip.Prefix, error) {
+	prefixes := make([]netip.Prefix, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			addr, addrErr := netip.ParseAddr(cidr)
+			if addrErr != nil {
+				return nil, fmt.Errorf("invalid CIDR %q", cidr)
+			}
+			prefix = netip.PrefixFrom(addr, addr.BitLen())
+		}
+		prefixes = append(prefixes, prefix.Masked())
+	}
+	return prefixes, nil
+}
+
+// containsAddr reports whether any prefix contains the address.
+func containsAddr(prefixes []netip.Prefix, addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, prefix := range prefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// addrFromNet extracts the IP address from a net.Addr.
+func addrFromNet(a net.Addr) (netip.Addr, bool) {
+	if a == nil {
+		return netip.Addr{}, false
+	}
+	if tcp, ok := a.(*net.TCPAddr); ok {
+		addr, ok := netip.AddrFromSlice(tcp.IP)
+		return addr.Unmap(), ok
+	}
+	addrPort, err := netip.ParseAddrPort(a.String())
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return addrPort.Addr().Unmap(), true
+}
@@ -0,0 +1,185 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package serverconf
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/config/confighttp"
+)
+
+// safelistedHeaders are the request headers a preflight always allows.
+var safelistedHeaders = []string{"accept", "accept-language", "content-language", "content-type"}
+
+// ValidateCORS checks the CORS settings for errors. A wildcard origin cannot
+// be combined with credentials: browsers reject the pair, and echoing every
+// origin instead would let any site make authenticated requests.
+func ValidateCORS(cfg *confighttp.CORSConfig, allowCredentials bool) error {
+	if cfg == nil {
+		return nil
+	}
+	for _, origin := range cfg.AllowedOrigins {
+		if strings.Count(origin, "*") > 1 {
+			return errors.New("cors.allowed_origins: at most one wildcard per origin is supported")
+		}
+		if allowCredentials && origin == "*" {
+			return errors.New("cors_allow_credentials cannot be combined with the \"*\" allowed origin")
+		}
+	}
+	return nil
+}
+
+// corsHandler answers CORS preflight requests and adds the CORS response
+// headers to cross-origin requests from allowed origins.
+type corsHandler struct {
+	next        http.Handler
+	origins     []string
+	anyOrigin   bool
+	headers     []string
+	anyHeader   bool
+	maxAge      string
+	credentials bool
+}
+
+// NewCORSHandler wraps next with CORS handling following the upstream
+// confighttp CORS settings: allowed_origins may contain one "*" wildcard,
+// allowed_headers adds to the CORS-safelisted headers ("*" allows any) and
+// max_age sets how long browsers cache a preflight. Preflight requests are
+// answered without reaching next; disallowed ones get 403. Only POST is
+// offered, as every OTLP/HTTP endpoint is POST-only.
+func NewCORSHandler(cfg *confighttp.CORSConfig, allowCredentials bool, next http.Handler) http.Handler {
+	h := &corsHandler{next: next, credentials: allowCredentials}
+	for _, origin := range cfg.AllowedOrigins {
+		if origin == "*" {
+			h.anyOrigin = true
+			continue
+		}
+		h.origins = append(h.origins, strings.ToLower(origin))
+	}
+	h.headers = slices.Clone(safelistedHeaders)
+	if len(cfg.AllowedHeaders) == 0 {
+		h.headers = append(h.headers, "x-requested-with")
+	}
+	for _, header := range cfg.AllowedHeaders {
+		if header == "*" {
+			h.anyHeader = true
+			continue
+		}
+		h.headers = append(h.headers, strings.ToLower(header))
+	}
+	if cfg.MaxAge > 0 {
+		h.maxAge = strconv.Itoa(cfg.MaxAge)
+	}
+	return h
+}
+
+func (h *corsHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	origin := req.Header.Get("Origin")
+	preflight := req.Method == http.MethodOptions && req.Header.Get("Access-Control-Request-Method") != ""
+	if origin == "" {
+		h.next.ServeHTTP(w, req)
+		return
+	}
+
+	header := w.Header()
+	header.Add("Vary", "Origin")
+	if preflight {
+		header.Add("Vary", "Access-Control-Request-Method")
+		header.Add("Vary", "Access-Control-Request-Headers")
+	}
+
+	if !h.originAllowed(origin) {
+		if preflight {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		h.next.ServeHTTP(w, req)
+		return
+	}
+
+	if !preflight {
+		h.setOrigin(header, origin)
+		h.next.ServeHTTP(w, req)
+		return
+	}
+
+	requested := req.Header.Get("Access-Control-Request-Headers")
+	if req.Header.Get("Access-Control-Request-Method") != http.MethodPost || !h.headersAllowed(requested) {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+	h.setOrigin(header, origin)
+	header.Set("Access-Control-Allow-Methods", http.MethodPost)
+	if requested != "" {
+		header.Set("Access-Control-Allow-Headers", requested)
+	}
+	if h.maxAge != "" {
+		header.Set("Access-Control-Max-Age", h.maxAge)
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// setOrigin writes the allow-origin headers. A wildcard origin is answered
+// with "*" and never with credentials, even if validation was bypassed.
+func (h *corsHandler) setOrigin(header http.Header, origin string) {
+	if h.anyOrigin {
+		header.Set("Access-Control-Allow-Origin", "*")
+		return
+	}
+	header.Set("Access-Control-Allow-Origin", origin)
+	if h.credentials {
+		header.Set("Access-Control-Allow-Credentials", "true")
+	}
+}
+
+func (h *corsHandler) originAllowed(origin string) bool {
+	if h.anyOrigin {
+		return true
+	}
+	origin = strings.ToLower(origin)
+	for _, pattern := range h.origins {
+		prefix, suffix, wildcard := strings.Cut(pattern, "*")
+		if !wildcard {
+			if origin == pattern {
+				return true
+			}
+			continue
+		}
+		if len(origin) >= len(prefix)+len(suffix) &&
+			strings.HasPrefix(origin, prefix) && strings.HasSuffix(origin, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// headersAllowed reports whether every header of a comma-separated
+// Access-Control-Request-Headers value is allowed.
+func (h *corsHandler) headersAllowed(requested string) bool {
+	if h.anyHeader {
+		return true
+	}
+	for name := range strings.SplitSeq(requested, ",") {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if name != "" && !slices.Contains(h.headers, name) {
+			return false
+		}
+	}
+	return true
+}
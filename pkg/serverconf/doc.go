@@ -0,0 +1,82 @@
+// Package serverconf provides the shared server hardening settings consumed by
+// all listener-based TelemetryFlow components.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Listener-based components combine the upstream confighttp/configgrpc server
+// settings (TLS, CORS, max body size, timeouts, keepalive) with Config, which
+// adds the hardening features upstream does not model:
+//   - Listener network selection (network: tcp4, tcp6 or dual), with
+//     endpoints checked for unbracketed IPv6 literals and hosts of the
+//     wrong family; see ValidateEndpoint and Config.Listen
+//   - Connection ACLs (acl.allowed_cidrs, acl.denied_cidrs)
+//   - PROXY protocol v1/v2 (proxy_protocol), with headers accepted only
+//     from the load balancers in trusted_cidrs
+//   - Certificates issued and renewed through ACME (acme), with the upstream
+//     tls certificate as fallback; see NewTLS
+//   - Client certificate policy (client_auth_type) for mutual TLS against
+//     the upstream tls.client_ca_file
+//   - Reloading of the upstream tls certificate, key and client CA files
+//     when they change on disk (tls_reload)
+//
+// HTTP servers can also embed HeadersConfig, applied by NewHeadersHandler
+// together with the upstream response_headers:
+//   - Server header (server_header; none is sent by default)
+//   - Strict-Transport-Security on TLS requests (hsts_max_age)
+//   - Request IDs echoed from the client or generated (request_id_header)
+//
+// The helpers in this package turn those settings into listeners, HTTP
+// servers, and gRPC server options so every component applies them the same
+// way. NewCORSHandler applies the upstream CORS settings, including preflight
+// (OPTIONS) responses, to servers not built with confighttp.ToServer, and
+// NewAuthHandler, NewAuthUnaryInterceptor and NewAuthStreamInterceptor check
+// requests with the upstream auth extension. The upstream include_metadata
+// and middlewares settings, and the HTTP compression_algorithms, are not
+// applied; ValidateHTTPServer and ValidateGRPCServer reject them.
+//
+// Admin APIs embed AdminConfig instead, which bundles the upstream
+// confighttp settings, Config and HeadersConfig. StartAdmin serves them with
+// the same listener, TLS and headers handling, checks requests with the
+// upstream auth extension and bounds bodies by max_request_body_size;
+// WriteJSON writes their responses. AdminConfig.Validate refuses an endpoint
+// whose host is not a loopback address unless clients authenticate, through
+// auth or a client certificate verified against tls.client_ca_file
+// (client_auth_type require_and_verify, or unset); server-side tls alone
+// does not protect an admin API.
+//
+// Listen keeps released sockets open for the next server on the same
+// address. With the listener_handoff section, removed from the configuration
+// by NewHandoffConverterFactory, the sockets also pass to a new collector
+// process over a Unix socket (ServeHandoff, TakeOverListeners), so upgrades
+// refuse no connections.
+//
+// Configuration example:
+//
+//	receivers:
+//	  tfootlp:
+//	    protocols:
+//	      http:
+//	        endpoint: "[::]:4318"
+//	        network: dual
+//	        acl:
+//	          allowed_cidrs: ["10.0.0.0/8"]
+//	        proxy_protocol:
+//	          enabled: true
+//	          trusted_cidrs: ["10.1.0.0/16"]
+//	        hsts_max_age: 8760h
+//	        response_headers:
+//	          X-Content-Type-Options: nosniff
+package serverconf // import "github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
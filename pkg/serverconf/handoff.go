@@ -0,0 +1,155 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package serverconf
+
+import (
+	"errors"
+	"net"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"time"
+)
+
+// DefaultListenerGrace is how long a released listening socket stays open
+// waiting to be taken over by the next Listen for the same address.
+const DefaultListenerGrace = 30 * time.Second
+
+// pooledSocket is a listening socket shared by successive servers.
+type pooledSocket struct {
+	key   string
+	port  string
+	tcp   *net.TCPListener
+	inUse bool
+	timer *time.Timer
+}
+
+var sockets = struct {
+	sync.Mutex
+	m map[string]*pooledSocket
+}{m: make(map[string]*pooledSocket)}
+
+// Listen announces on a TCP address like net.Listen, but closing the returned
+// listener only stops accepting: the socket stays open for
+// DefaultListenerGrace, and a Listen for the same address within that time
+// takes it over. A server restarted with an unchanged endpoint, e.g. on a
+// configuration reload, therefore refuses no connections; clients that
+// connect in between wait in the accept backlog. When the address changed,
+// the released socket of the old address is closed once the grace expires,
+// or at once if the new bind needs its port.
+func Listen(network, address string) (net.Listener, error) {
+	_, port, err := net.SplitHostPort(address)
+	if err != nil || port == "0" || (network != "tcp" && network != "tcp4" && network != "tcp6") {
+		return net.Listen(network, address)
+	}
+	key := network + "|" + address
+
+	sockets.Lock()
+	defer sockets.Unlock()
+
+	if s, ok := sockets.m[key]; ok && !s.inUse {
+		s.timer.Stop()
+		s.inUse = true
+		_ = s.tcp.SetDeadline(time.Time{})
+		return &handoffListener{s: s}, nil
+	}
+
+	lis, err := net.Listen(network, address)
+	if errors.Is(err, syscall.EADDRINUSE) && closeReleasedLocked(port) {
+		lis, err = net.Listen(network, address)
+	}
+	if err != nil {
+		return nil, err
+	}
+	tcp, ok := lis.(*net.TCPListener)
+	if !ok {
+		return lis, nil
+	}
+	s := &pooledSocket{key: key, port: port, tcp: tcp, inUse: true}
+	sockets.m[key] = s
+	return &handoffListener{s: s}, nil
+}
+
+// closeReleasedLocked closes the released sockets bound to port and reports
+// whether there were any.
+func closeReleasedLocked(port string) bool {
+	closed := false
+	for key, s := range sockets.m {
+		if !s.inUse && s.port == port {
+			s.timer.Stop()
+			_ = s.tcp.Close()
+			delete(sockets.m, key)
+			closed = true
+		}
+	}
+	return closed
+}
+
+func release(s *pooledSocket) {
+	sockets.Lock()
+	defer sockets.Unlock()
+	s.inUse = false
+	s.timer = time.AfterFunc(DefaultListenerGrace, func() {
+		sockets.Lock()
+		defer sockets.Unlock()
+		if !s.inUse && sockets.m[s.key] == s {
+			_ = s.tcp.Close()
+			delete(sockets.m, s.key)
+		}
+	})
+}
+
+// handoffListener is one server's use of a pooled socket.
+type handoffListener struct {
+	s      *pooledSocket
+	closed atomic.Bool
+	once   sync.Once
+}
+
+// Accept implements net.Listener.
+func (l *handoffListener) Accept() (net.Conn, error) {
+	for {
+		c, err := l.s.tcp.Accept()
+		if err == nil {
+			return c, nil
+		}
+		if l.closed.Load() {
+			return nil, net.ErrClosed
+		}
+		// A deadline left by the previous owner's Close; keep accepting.
+		var netErr net.Error
+		if errors.As(err, &netErr) && netErr.Timeout() {
+			continue
+		}
+		return nil, err
+	}
+}
+
+// Close stops accepting and releases the socket to the pool. Connections
+// already accepted are unaffected.
+func (l *handoffListener) Close() error {
+	l.once.Do(func() {
+		l.closed.Store(true)
+		// Wake a blocked Accept without closing the socket.
+		_ = l.s.tcp.SetDeadline(time.Now())
+		release(l.s)
+	})
+	return nil
+}
+
+// Addr implements net.Listener.
+func (l *handoffListener) Addr() net.Addr {
+	return l.s.tcp.Addr()
+}
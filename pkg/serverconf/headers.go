@@ -0,0 +1,134 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package serverconf
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configopaque"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/requestid"
+)
+
+// HeadersConfig defines the headers an HTTP server adds to its responses on
+// top of the upstream response_headers. It is meant to be embedded with
+// `mapstructure:",squash"` next to confighttp.ServerConfig.
+type HeadersConfig struct {
+	// ServerHeader is sent as the Server header of every response. When
+	// empty, responses carry no Server header, even if a handler sets one.
+	ServerHeader string `mapstructure:"server_header"`
+
+	// HSTSMaxAge sends Strict-Transport-Security with this max-age on
+	// responses to requests received over TLS.
+	// Default: 0 (not sent)
+	HSTSMaxAge time.Duration `mapstructure:"hsts_max_age"`
+
+	// RequestIDHeader is the header a client sets its request ID in and
+	// the response echoes it in. Requests without a valid ID get a
+	// generated one.
+	// Default: X-Request-ID
+	RequestIDHeader string `mapstructure:"request_id_header"`
+}
+
+// Validate checks the headers configuration for errors.
+func (cfg *HeadersConfig) Validate() error {
+	if cfg.HSTSMaxAge < 0 {
+		return errors.New("hsts_max_age must not be negative")
+	}
+	return nil
+}
+
+// headersHandler sets the configured response headers and the request ID.
+type headersHandler struct {
+	next            http.Handler
+	server          string
+	hsts            string
+	requestIDHeader string
+	responseHeaders configopaque.MapList
+}
+
+// NewHeadersHandler wraps next so that every response carries
+// responseHeaders (the upstream response_headers), the headers configured in
+// cfg and the request ID. The request ID is taken from the request if it
+// passes requestid.Valid, generated otherwise, and stored in the request
+// context for logging.
+func NewHeadersHandler(cfg *HeadersConfig, responseHeaders configopaque.MapList, next http.Handler) http.Handler {
+	h := &headersHandler{
+		next:            next,
+		server:          cfg.ServerHeader,
+		requestIDHeader: cfg.RequestIDHeader,
+		responseHeaders: responseHeaders,
+	}
+	if h.requestIDHeader == "" {
+		h.requestIDHeader = requestid.Header
+	}
+	if cfg.HSTSMaxAge > 0 {
+		h.hsts = "max-age=" + strconv.FormatInt(int64(cfg.HSTSMaxAge/time.Second), 10)
+	}
+	return h
+}
+
+func (h *headersHandler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	id := req.Header.Get(h.requestIDHeader)
+	if !requestid.Valid(id) {
+		id = requestid.New()
+	}
+
+	header := w.Header()
+	for name, value := range h.responseHeaders.Iter {
+		header.Set(name, string(value))
+	}
+	if h.hsts != "" && req.TLS != nil {
+		header.Set("Strict-Transport-Security", h.hsts)
+	}
+	header.Set(h.requestIDHeader, id)
+
+	h.next.ServeHTTP(&serverHeaderWriter{ResponseWriter: w, server: h.server}, req.WithContext(requestid.NewContext(req.Context(), id)))
+}
+
+// serverHeaderWriter replaces whatever Server header the handler set with
+// the configured one right before the header is written.
+type serverHeaderWriter struct {
+	http.ResponseWriter
+	server      string
+	wroteHeader bool
+}
+
+func (w *serverHeaderWriter) WriteHeader(code int) {
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		if w.server != "" {
+			w.Header().Set("Server", w.server)
+		} else {
+			w.Header().Del("Server")
+		}
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *serverHeaderWriter) Write(b []byte) (int, error) {
+	if !w.wroteHeader {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (w *serverHeaderWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
@@ -0,0 +1,167 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package serverconf
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"net/netip"
+	"sync"
+	"time"
+)
+
+// errConnectionDenied is returned by reads on connections rejected by the ACL.
+var errConnectionDenied = errors.New("connection denied by ACL")
+
+// WrapListener applies the ACL and PROXY protocol settings to lis. The
+// returned listener must be used in place of lis by the server.
+func (cfg *Config) WrapListener(lis net.Listener) (net.Listener, error) {
+	allowed, err := parsePrefixes(cfg.ACL.AllowedCIDRs)
+	if err != nil {
+		return nil, err
+	}
+	denied, err := parsePrefixes(cfg.ACL.DeniedCIDRs)
+	if err != nil {
+		return nil, err
+	}
+	trusted, err := parsePrefixes(cfg.ProxyProtocol.TrustedCIDRs)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(allowed) == 0 && len(denied) == 0 && !cfg.ProxyProtocol.Enabled {
+		return lis, nil
+	}
+
+	headerTimeout := cfg.ProxyProtocol.HeaderTimeout
+	if headerTimeout == 0 {
+		headerTimeout = DefaultProxyHeaderTimeout
+	}
+
+	return &listener{
+		Listener:      lis,
+		allowed:       allowed,
+		denied:        denied,
+		proxyEnabled:  cfg.ProxyProtocol.Enabled,
+		trusted:       trusted,
+		headerTimeout: headerTimeout,
+	}, nil
+}
+
+// listener enforces ACLs and resolves PROXY protocol headers.
+type listener struct {
+	net.Listener
+
+	allowed []netip.Prefix
+	denied  []netip.Prefix
+
+	proxyEnabled  bool
+	trusted       []netip.Prefix
+	headerTimeout time.Duration
+}
+
+// permits reports whether the ACL allows the address.
+func (l *listener) permits(addr netip.Addr) bool {
+	if containsAddr(l.denied, addr) {
+		return false
+	}
+	return len(l.allowed) == 0 || containsAddr(l.allowed, addr)
+}
+
+// Accept implements net.Listener. Without PROXY protocol the ACL is enforced
+// immediately and denied connections are closed without being returned.
+// With PROXY protocol the header is read lazily on the connection's first
+// use so a slow peer cannot stall the accept loop.
+func (l *listener) Accept() (net.Conn, error) {
+	for {
+		c, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+
+		peer, ok := addrFromNet(c.RemoteAddr())
+		if !l.proxyEnabled {
+			if ok && !l.permits(peer) {
+				_ = c.Close()
+				continue
+			}
+			return c, nil
+		}
+
+		expectHeader := ok && containsAddr(l.trusted, peer)
+		return &conn{
+			Conn:         c,
+			listener:     l,
+			reader:       bufio.NewReader(c),
+			expectHeader: expectHeader,
+		}, nil
+	}
+}
+
+// conn is a connection whose client address may come from a PROXY header.
+type conn struct {
+	net.Conn
+
+	listener     *listener
+	reader       *bufio.Reader
+	expectHeader bool
+
+	once    sync.Once
+	remote  net.Addr
+	initErr error
+}
+
+// init reads the PROXY header (if expected) and enforces the ACL against the
+// resolved client address.
+func (c *conn) init() {
+	c.once.Do(func() {
+		c.remote = c.Conn.RemoteAddr()
+
+		if c.expectHeader {
+			_ = c.Conn.SetReadDeadline(time.Now().Add(c.listener.headerTimeout))
+			src, err := readProxyHeader(c.reader)
+			_ = c.Conn.SetReadDeadline(time.Time{})
+			if err != nil {
+				c.initErr = err
+				_ = c.Conn.Close()
+				return
+			}
+			if src != nil {
+				c.remote = src
+			}
+		}
+
+		if addr, ok := addrFromNet(c.remote); ok && !c.listener.permits(addr) {
+			c.initErr = errConnectionDenied
+			_ = c.Conn.Close()
+		}
+	})
+}
+
+// Read implements net.Conn.
+func (c *conn) Read(b []byte) (int, error) {
+	c.init()
+	if c.initErr != nil {
+		return 0, c.initErr
+	}
+	return c.reader.Read(b)
+}
+
+// RemoteAddr implements net.Conn and returns the PROXY-resolved address.
+func (c *conn) RemoteAddr() net.Addr {
+	c.init()
+	return c.remote
+}
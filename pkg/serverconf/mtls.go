@@ -0,0 +1,230 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package serverconf
+
+import (
+	"cmp"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.uber.org/zap"
+)
+
+// DefaultTLSReloadInterval is the least time between two checks of the tls
+// files when tls_reload.interval is not configured.
+const DefaultTLSReloadInterval = 10 * time.Second
+
+// ClientAuthType is the client certificate policy of a TLS listener.
+type ClientAuthType string
+
+const (
+	// ClientAuthNone does not request client certificates.
+	ClientAuthNone ClientAuthType = "none"
+
+	// ClientAuthRequest requests a client certificate without requiring or
+	// verifying it.
+	ClientAuthRequest ClientAuthType = "request"
+
+	// ClientAuthRequireAny requires a client certificate without verifying
+	// it.
+	ClientAuthRequireAny ClientAuthType = "require_any"
+
+	// ClientAuthVerifyIfGiven verifies a client certificate if one is sent.
+	ClientAuthVerifyIfGiven ClientAuthType = "verify_if_given"
+
+	// ClientAuthRequireAndVerify requires a verified client certificate.
+	ClientAuthRequireAndVerify ClientAuthType = "require_and_verify"
+)
+
+// validate checks that t is a known client auth type.
+func (t ClientAuthType) validate() error {
+	switch t {
+	case "", ClientAuthNone, ClientAuthRequest, ClientAuthRequireAny,
+		ClientAuthVerifyIfGiven, ClientAuthRequireAndVerify:
+		return nil
+	}
+	return fmt.Errorf("invalid client_auth_type %q: must be %q, %q, %q, %q or %q", string(t),
+		ClientAuthNone, ClientAuthRequest, ClientAuthRequireAny, ClientAuthVerifyIfGiven, ClientAuthRequireAndVerify)
+}
+
+// verifies reports whether t checks client certificates against the
+// client CA.
+func (t ClientAuthType) verifies() bool {
+	return t == ClientAuthVerifyIfGiven || t == ClientAuthRequireAndVerify
+}
+
+// requiresClientCert reports whether the listener only accepts clients with a
+// certificate verified against the client CA of static, which may be nil.
+func (cfg *Config) requiresClientCert(static *configtls.ServerConfig) bool {
+	if static == nil || static.ClientCAFile == "" {
+		return false
+	}
+	// Without client_auth_type, upstream requires a verified certificate
+	// whenever client_ca_file is set.
+	return cfg.ClientAuthType == "" || cfg.ClientAuthType == ClientAuthRequireAndVerify
+}
+
+// tlsClientAuth returns the crypto/tls policy of t.
+func (t ClientAuthType) tlsClientAuth() tls.ClientAuthType {
+	switch t {
+	case ClientAuthRequest:
+		return tls.RequestClientCert
+	case ClientAuthRequireAny:
+		return tls.RequireAnyClientCert
+	case ClientAuthVerifyIfGiven:
+		return tls.VerifyClientCertIfGiven
+	case ClientAuthRequireAndVerify:
+		return tls.RequireAndVerifyClientCert
+	default:
+		return tls.NoClientCert
+	}
+}
+
+// TLSReloadConfig defines the reloading of the upstream tls files, e.g. for
+// certificates rotated by cert-manager or a sidecar.
+type TLSReloadConfig struct {
+	// Enabled checks the cert_file, key_file and client_ca_file of the
+	// upstream tls settings on handshakes, and reloads them when one has
+	// changed. New connections use the new files; established connections
+	// keep the certificates they were set up with. Files that fail to load,
+	// e.g. a certificate written before its key, are retried on the next
+	// check while the previous ones are served.
+	// Default: false
+	Enabled bool `mapstructure:"enabled"`
+
+	// Interval is the least time between two checks of the files.
+	// Default: 10s
+	Interval time.Duration `mapstructure:"interval"`
+}
+
+// ValidateTLS checks the settings that depend on the upstream static tls
+// settings, which may be nil.
+func (cfg *Config) ValidateTLS(static *configtls.ServerConfig) error {
+	if cfg.ClientAuthType != "" && static == nil {
+		return fmt.Errorf("client_auth_type %q requires tls", cfg.ClientAuthType)
+	}
+	if cfg.ClientAuthType.verifies() && static.ClientCAFile == "" {
+		return fmt.Errorf("client_auth_type %q requires tls.client_ca_file", cfg.ClientAuthType)
+	}
+	if cfg.TLSReload.Enabled && (static == nil || static.CertFile == "" && static.ClientCAFile == "") {
+		return errors.New("tls_reload requires tls.cert_file or tls.client_ca_file")
+	}
+	return nil
+}
+
+// loadStatic loads the static TLS settings and applies the client auth
+// type.
+func (cfg *Config) loadStatic(ctx context.Context, static *configtls.ServerConfig) (*tls.Config, error) {
+	tlsCfg, err := static.LoadTLSConfig(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load TLS config: %w", err)
+	}
+	if cfg.ClientAuthType != "" {
+		tlsCfg.ClientAuth = cfg.ClientAuthType.tlsClientAuth()
+	}
+	return tlsCfg, nil
+}
+
+// fileStamp identifies the content of a file by size and modification
+// time; a missing file has the zero stamp.
+type fileStamp struct {
+	size    int64
+	modTime int64
+}
+
+// stampFiles returns the stamps of files. It follows symlinks, so a
+// Kubernetes secret volume swapping its data directory is noticed.
+func stampFiles(files []string) []fileStamp {
+	stamps := make([]fileStamp, len(files))
+	for i, file := range files {
+		if info, err := os.Stat(file); err == nil {
+			stamps[i] = fileStamp{size: info.Size(), modTime: info.ModTime().UnixNano()}
+		}
+	}
+	return stamps
+}
+
+// tlsReloader serves the server TLS configuration built from the static
+// settings as of their last successful load.
+type tlsReloader struct {
+	cfg      *Config
+	static   *configtls.ServerConfig
+	build    func(*tls.Config) *tls.Config
+	files    []string
+	interval time.Duration
+	logger   *zap.Logger
+
+	mu      sync.Mutex
+	checked time.Time
+	stamps  []fileStamp
+	config  *tls.Config
+}
+
+// newTLSReloader returns a reloader serving config, which build made from
+// the current static files, until they change.
+func (cfg *Config) newTLSReloader(static *configtls.ServerConfig, config *tls.Config, build func(*tls.Config) *tls.Config, logger *zap.Logger) *tlsReloader {
+	var files []string
+	for _, file := range []string{static.CertFile, static.KeyFile, static.ClientCAFile} {
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+	return &tlsReloader{
+		cfg:      cfg,
+		static:   static,
+		build:    build,
+		files:    files,
+		interval: cmp.Or(cfg.TLSReload.Interval, DefaultTLSReloadInterval),
+		logger:   logger,
+		checked:  time.Now(),
+		stamps:   stampFiles(files),
+		config:   config,
+	}
+}
+
+// configForClient is the GetConfigForClient function of the listener. It
+// reloads the files first when they have changed since the last check.
+func (r *tlsReloader) configForClient(*tls.ClientHelloInfo) (*tls.Config, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if now := time.Now(); now.Sub(r.checked) >= r.interval {
+		r.checked = now
+		if stamps := stampFiles(r.files); !slices.Equal(stamps, r.stamps) {
+			r.reload(stamps)
+		}
+	}
+	return r.config, nil
+}
+
+// reload loads the files whose stamps are stamps. On failure the previous
+// configuration is kept and the stamps are not recorded, so the next check
+// tries again.
+func (r *tlsReloader) reload(stamps []fileStamp) {
+	loaded, err := r.cfg.loadStatic(context.Background(), r.static)
+	if err != nil {
+		r.logger.Warn("Failed to reload TLS files, serving the previous ones", zap.Error(err))
+		return
+	}
+	r.stamps = stamps
+	r.config = r.build(loaded)
+	r.logger.Info("TLS files reloaded", zap.Strings("files", r.files))
+}
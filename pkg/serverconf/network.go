@@ -0,0 +1,119 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package serverconf
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+)
+
+// Listener networks selectable with the network setting.
+const (
+	// NetworkTCP4 listens on IPv4 only.
+	NetworkTCP4 = "tcp4"
+
+	// NetworkTCP6 listens on IPv6 only, even on dual-stack hosts.
+	NetworkTCP6 = "tcp6"
+
+	// NetworkDual listens on both IPv4 and IPv6. The endpoint host must be
+	// empty or a wildcard address; "0.0.0.0" and "::" both mean every
+	// address of both families. It is the default for wildcard hosts, set
+	// explicitly to reject endpoints that would bind one family only.
+	NetworkDual = "dual"
+)
+
+// validateNetwork checks the network setting.
+func validateNetwork(network string) error {
+	switch network {
+	case "", NetworkTCP4, NetworkTCP6, NetworkDual:
+		return nil
+	}
+	return fmt.Errorf("invalid network %q: must be %q, %q or %q", network, NetworkTCP4, NetworkTCP6, NetworkDual)
+}
+
+// ValidateEndpoint checks that endpoint is a listen address usable with the
+// network setting. IPv6 literals must be bracketed, e.g. "[::]:4317".
+func (cfg *Config) ValidateEndpoint(endpoint string) error {
+	host, port, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		if strings.Count(endpoint, ":") > 1 && !strings.HasPrefix(endpoint, "[") {
+			return fmt.Errorf("invalid endpoint %q: IPv6 addresses must be enclosed in brackets, e.g. \"[::]:4317\"", endpoint)
+		}
+		return fmt.Errorf("invalid endpoint %q: %w", endpoint, err)
+	}
+	if port == "" {
+		return fmt.Errorf("invalid endpoint %q: missing port", endpoint)
+	}
+
+	addr, err := netip.ParseAddr(host)
+	isIP := err == nil
+	switch cfg.Network {
+	case NetworkTCP4:
+		if isIP && !addr.Unmap().Is4() {
+			return fmt.Errorf("endpoint %q is an IPv6 address; network %q listens on IPv4 only", endpoint, cfg.Network)
+		}
+	case NetworkTCP6:
+		if isIP && addr.Is4() {
+			return fmt.Errorf("endpoint %q is an IPv4 address; network %q listens on IPv6 only", endpoint, cfg.Network)
+		}
+	case NetworkDual:
+		if host != "" && (!isIP || !addr.IsUnspecified()) {
+			return fmt.Errorf("endpoint %q: network %q requires an empty or wildcard host, e.g. \"[::]:4317\"", endpoint, cfg.Network)
+		}
+	}
+	return nil
+}
+
+// listenNetwork returns the network net.Listen is called with. For a
+// wildcard host, "tcp" binds a dual-stack socket where the host supports
+// IPv6, whichever wildcard address is given.
+func (cfg *Config) listenNetwork() string {
+	switch cfg.Network {
+	case NetworkTCP4, NetworkTCP6:
+		return cfg.Network
+	}
+	return "tcp"
+}
+
+// Listen announces on endpoint with the network setting. Like the package
+// level Listen, the socket is handed over to the next Listen for the same
+// address after the listener is closed.
+func (cfg *Config) Listen(endpoint string) (net.Listener, error) {
+	return Listen(cfg.listenNetwork(), endpoint)
+}
+
+// ListenURL renders the URL clients on this host reach a listener bound to
+// addr at, with IPv6 hosts bracketed. A wildcard host is replaced by the
+// loopback address of the listener's family, or by "localhost" when the
+// listener accepts both families.
+func (cfg *Config) ListenURL(scheme string, addr net.Addr) string {
+	host, port, err := net.SplitHostPort(addr.String())
+	if err != nil {
+		return scheme + "://" + addr.String()
+	}
+	if ip, err := netip.ParseAddr(host); err == nil && ip.IsUnspecified() {
+		switch {
+		case ip.Is4():
+			host = "127.0.0.1"
+		case cfg.Network == NetworkTCP6:
+			host = "::1"
+		default:
+			host = "localhost"
+		}
+	}
+	return scheme + "://" + net.JoinHostPort(host, port)
+}
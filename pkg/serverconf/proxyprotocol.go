@@ -0,0 +1,153 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package serverconf
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// proxyV2Signature is the fixed 12-byte PROXY protocol v2 preamble.
+var proxyV2Signature = []byte("\r\n\r\n\x00\r\nQUIT\n")
+
+// errInvalidProxyHeader is returned when a PROXY protocol header is malformed.
+var errInvalidProxyHeader = errors.New("invalid PROXY protocol header")
+
+const (
+	// proxyV1MaxLength is the maximum length of a v1 header including CRLF.
+	proxyV1MaxLength = 107
+
+	proxyV2CmdLocal = 0x0
+	proxyV2CmdProxy = 0x1
+
+	proxyV2FamTCP4 = 0x11
+	proxyV2FamUDP4 = 0x12
+	proxyV2FamTCP6 = 0x21
+	proxyV2FamUDP6 = 0x22
+)
+
+// readProxyHeader consumes a PROXY protocol v1 or v2 header from r and
+// returns the original client address. A nil address means the header
+// carried no usable source (LOCAL command or UNKNOWN family), in which case
+// the socket peer address should be used.
+func readProxyHeader(r *bufio.Reader) (net.Addr, error) {
+	peek, err := r.Peek(len(proxyV2Signature))
+	if err != nil {
+		// v1 headers can be shorter than the v2 signature only if malformed
+		return nil, fmt.Errorf("%w: %v", errInvalidProxyHeader, err)
+	}
+	if bytes.Equal(peek, proxyV2Signature) {
+		return readProxyV2(r)
+	}
+	if bytes.HasPrefix(peek, []byte("PROXY ")) {
+		return readProxyV1(r)
+	}
+	return nil, fmt.Errorf("%w: missing PROXY preamble", errInvalidProxyHeader)
+}
+
+// readProxyV1 parses the human-readable v1 header, e.g.
+// "PROXY TCP4 192.168.0.1 192.168.0.11 56324 443\r\n".
+func readProxyV1(r *bufio.Reader) (net.Addr, error) {
+	var line []byte
+	for len(line) < proxyV1MaxLength {
+		b, err := r.ReadByte()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", errInvalidProxyHeader, err)
+		}
+		line = append(line, b)
+		if b == '\n' {
+			break
+		}
+	}
+	if !bytes.HasSuffix(line, []byte("\r\n")) {
+		return nil, fmt.Errorf("%w: v1 header not terminated by CRLF", errInvalidProxyHeader)
+	}
+
+	fields := strings.Fields(strings.TrimSuffix(string(line), "\r\n"))
+	if len(fields) >= 2 && fields[1] == "UNKNOWN" {
+		return nil, nil
+	}
+	if len(fields) != 6 || (fields[1] != "TCP4" && fields[1] != "TCP6") {
+		return nil, fmt.Errorf("%w: malformed v1 header", errInvalidProxyHeader)
+	}
+
+	addr, err := netip.ParseAddr(fields[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: bad source address", errInvalidProxyHeader)
+	}
+	if (fields[1] == "TCP4") != addr.Is4() {
+		return nil, fmt.Errorf("%w: address family mismatch", errInvalidProxyHeader)
+	}
+	port, err := strconv.ParseUint(fields[4], 10, 16)
+	if err != nil {
+		return nil, fmt.Errorf("%w: bad source port", errInvalidProxyHeader)
+	}
+	return net.TCPAddrFromAddrPort(netip.AddrPortFrom(addr, uint16(port))), nil
+}
+
+// readProxyV2 parses the binary v2 header.
+func readProxyV2(r *bufio.Reader) (net.Addr, error) {
+	header := make([]byte, 16)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidProxyHeader, err)
+	}
+	if header[12]>>4 != 0x2 {
+		return nil, fmt.Errorf("%w: unsupported version", errInvalidProxyHeader)
+	}
+	cmd := header[12] & 0x0F
+	family := header[13]
+	length := binary.BigEndian.Uint16(header[14:16])
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidProxyHeader, err)
+	}
+
+	switch cmd {
+	case proxyV2CmdLocal:
+		return nil, nil
+	case proxyV2CmdProxy:
+	default:
+		return nil, fmt.Errorf("%w: unsupported command", errInvalidProxyHeader)
+	}
+
+	switch family {
+	case proxyV2FamTCP4, proxyV2FamUDP4:
+		if len(payload) < 12 {
+			return nil, fmt.Errorf("%w: short IPv4 address block", errInvalidProxyHeader)
+		}
+		addr := netip.AddrFrom4([4]byte(payload[0:4]))
+		port := binary.BigEndian.Uint16(payload[8:10])
+		return net.TCPAddrFromAddrPort(netip.AddrPortFrom(addr, port)), nil
+	case proxyV2FamTCP6, proxyV2FamUDP6:
+		if len(payload) < 36 {
+			return nil, fmt.Errorf("%w: short IPv6 address block", errInvalidProxyHeader)
+		}
+		addr := netip.AddrFrom16([16]byte(payload[0:16]))
+		port := binary.BigEndian.Uint16(payload[32:34])
+		return net.TCPAddrFromAddrPort(netip.AddrPortFrom(addr, port)), nil
+	default:
+		// UNSPEC or unix sockets carry no usable client address
+		return nil, nil
+	}
+}
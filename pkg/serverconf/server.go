@@ -0,0 +1,134 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package serverconf
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+)
+
+const (
+	// DefaultHTTPReadTimeout is used when read_timeout is not configured.
+	DefaultHTTPReadTimeout = 30 * time.Second
+
+	// DefaultHTTPWriteTimeout is used when write_timeout is not configured.
+	DefaultHTTPWriteTimeout = 30 * time.Second
+
+	// DefaultMaxHeaderBytes is the maximum size of HTTP request headers.
+	DefaultMaxHeaderBytes = 1 << 20
+)
+
+// NewHTTPServer creates an HTTP server for handler applying the timeout and
+// keep-alive settings from the upstream confighttp server configuration.
+func NewHTTPServer(cfg *confighttp.ServerConfig, handler http.Handler) *http.Server {
+	readTimeout := cfg.ReadTimeout
+	if readTimeout == 0 {
+		readTimeout = DefaultHTTPReadTimeout
+	}
+	writeTimeout := cfg.WriteTimeout
+	if writeTimeout == 0 {
+		writeTimeout = DefaultHTTPWriteTimeout
+	}
+
+	srv := &http.Server{
+		Handler:           handler,
+		ReadTimeout:       readTimeout,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		WriteTimeout:      writeTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+		MaxHeaderBytes:    DefaultMaxHeaderBytes,
+	}
+	srv.SetKeepAlivesEnabled(cfg.KeepAlivesEnabled)
+	return srv
+}
+
+// ValidateHTTPServer rejects the upstream confighttp settings that servers
+// built with NewHTTPServer do not apply. TLS is applied separately through
+// NewTLS, auth through NewAuthHandler, and CORS and response headers through
+// NewCORSHandler and NewHeadersHandler.
+func ValidateHTTPServer(cfg *confighttp.ServerConfig) error {
+	switch {
+	case cfg.IncludeMetadata:
+		return errors.New("include_metadata is not supported")
+	case len(cfg.Middlewares) > 0:
+		return errors.New("middlewares is not supported")
+	case len(cfg.CompressionAlgorithms) > 0:
+		return errors.New("compression_algorithms is not supported")
+	}
+	return nil
+}
+
+// ValidateGRPCServer rejects the upstream configgrpc settings that
+// GRPCServerOptions does not apply. TLS is applied separately through NewTLS,
+// and auth through NewAuthUnaryInterceptor and NewAuthStreamInterceptor.
+func ValidateGRPCServer(cfg *configgrpc.ServerConfig) error {
+	switch {
+	case cfg.IncludeMetadata:
+		return errors.New("include_metadata is not supported")
+	case len(cfg.Middlewares) > 0:
+		return errors.New("middlewares is not supported")
+	}
+	return nil
+}
+
+// GRPCServerOptions returns the gRPC server options derived from the upstream
+// configgrpc server configuration (keepalive, message, stream and buffer
+// limits).
+func GRPCServerOptions(cfg *configgrpc.ServerConfig) []grpc.ServerOption {
+	var opts []grpc.ServerOption
+
+	if cfg.MaxRecvMsgSizeMiB > 0 && cfg.MaxRecvMsgSizeMiB <= math.MaxInt>>20 {
+		opts = append(opts, grpc.MaxRecvMsgSize(cfg.MaxRecvMsgSizeMiB<<20))
+	}
+	if cfg.MaxConcurrentStreams > 0 {
+		opts = append(opts, grpc.MaxConcurrentStreams(cfg.MaxConcurrentStreams))
+	}
+	if cfg.ReadBufferSize > 0 {
+		opts = append(opts, grpc.ReadBufferSize(cfg.ReadBufferSize))
+	}
+	if cfg.WriteBufferSize > 0 {
+		opts = append(opts, grpc.WriteBufferSize(cfg.WriteBufferSize))
+	}
+
+	if cfg.Keepalive.HasValue() {
+		ka := cfg.Keepalive.Get()
+		if ka.ServerParameters.HasValue() {
+			sp := ka.ServerParameters.Get()
+			opts = append(opts, grpc.KeepaliveParams(keepalive.ServerParameters{
+				MaxConnectionIdle:     sp.MaxConnectionIdle,
+				MaxConnectionAge:      sp.MaxConnectionAge,
+				MaxConnectionAgeGrace: sp.MaxConnectionAgeGrace,
+				Time:                  sp.Time,
+				Timeout:               sp.Timeout,
+			}))
+		}
+		if ka.EnforcementPolicy.HasValue() {
+			ep := ka.EnforcementPolicy.Get()
+			opts = append(opts, grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+				MinTime:             ep.MinTime,
+				PermitWithoutStream: ep.PermitWithoutStream,
+			}))
+		}
+	}
+
+	return opts
+}
@@ -0,0 +1,318 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package serverconf
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"slices"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.uber.org/zap"
+	"golang.org/x/crypto/acme"
+	"golang.org/x/crypto/acme/autocert"
+)
+
+const (
+	// DefaultACMEDirectory is the Let's Encrypt production directory, used
+	// when acme.directory_url is not configured.
+	DefaultACMEDirectory = autocert.DefaultACMEDirectory
+
+	// DefaultACMERenewBefore is how long before expiry ACME certificates are
+	// renewed when acme.renew_before is not configured.
+	DefaultACMERenewBefore = 30 * 24 * time.Hour
+)
+
+// ACMEConfig defines automatic certificate issuance and renewal through an
+// ACME CA such as Let's Encrypt or an internal step-ca.
+type ACMEConfig struct {
+	// Enabled obtains the server certificate from the ACME CA.
+	// Default: false
+	Enabled bool `mapstructure:"enabled"`
+
+	// Domains are the host names certificates are issued for. Handshakes
+	// for other names are served the static certificate, if any.
+	Domains []string `mapstructure:"domains"`
+
+	// Email is the contact address registered with the ACME account.
+	Email string `mapstructure:"email"`
+
+	// DirectoryURL is the ACME directory of the CA.
+	// Default: https://acme-v02.api.letsencrypt.org/directory
+	DirectoryURL string `mapstructure:"directory_url"`
+
+	// CAFile is a PEM bundle trusted for the connection to an internal ACME
+	// CA, in addition to the system roots.
+	CAFile string `mapstructure:"ca_file"`
+
+	// CacheDir stores the account key and issued certificates so restarts
+	// do not request new ones. Required.
+	CacheDir string `mapstructure:"cache_dir"`
+
+	// HTTPChallengeEndpoint is the address serving HTTP-01 challenges, e.g.
+	// ":80". The CA always connects to port 80 of the domain. TLS-ALPN-01
+	// challenges are answered on the server's own listener, which the CA
+	// reaches on port 443.
+	// Default: "" (TLS-ALPN-01 only)
+	HTTPChallengeEndpoint string `mapstructure:"http_challenge_endpoint"`
+
+	// RenewBefore is how long before expiry certificates are renewed.
+	// Default: 720h
+	RenewBefore time.Duration `mapstructure:"renew_before"`
+}
+
+// Validate checks the ACME configuration for errors.
+func (cfg *ACMEConfig) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if len(cfg.Domains) == 0 {
+		return errors.New("acme.domains must be set when acme is enabled")
+	}
+	for _, domain := range cfg.Domains {
+		if domain == "" || net.ParseIP(domain) != nil {
+			return fmt.Errorf("acme.domains: %q is not a host name", domain)
+		}
+	}
+	if cfg.CacheDir == "" {
+		return errors.New("acme.cache_dir must be set when acme is enabled")
+	}
+	if cfg.HTTPChallengeEndpoint != "" {
+		if _, _, err := net.SplitHostPort(cfg.HTTPChallengeEndpoint); err != nil {
+			return fmt.Errorf("acme.http_challenge_endpoint: %w", err)
+		}
+	}
+	if cfg.RenewBefore < 0 {
+		return errors.New("acme.renew_before must not be negative")
+	}
+	return nil
+}
+
+// TLS provides the certificates of a server listener from ACME, from the
+// static upstream tls settings, or from ACME with the static certificate as
+// fallback while ACME cannot provide one. A nil TLS serves plaintext.
+type TLS struct {
+	config    *tls.Config
+	challenge *http.Server
+	wg        sync.WaitGroup
+}
+
+// NewTLS builds the TLS settings of a listener from the ACME settings in cfg
+// and the upstream static settings, which may be nil. It returns nil when
+// neither is configured. The HTTP-01 challenge server, if configured, is
+// started here and stopped by Shutdown. With tls_reload enabled, the static
+// files are reloaded on handshakes once they change.
+func (cfg *Config) NewTLS(ctx context.Context, static *configtls.ServerConfig, logger *zap.Logger) (*TLS, error) {
+	if err := cfg.ValidateTLS(static); err != nil {
+		return nil, err
+	}
+	var staticCfg *tls.Config
+	if static != nil {
+		var err error
+		if staticCfg, err = cfg.loadStatic(ctx, static); err != nil {
+			return nil, err
+		}
+	}
+	if !cfg.ACME.Enabled {
+		if staticCfg == nil {
+			return nil, nil
+		}
+		t := &TLS{}
+		t.setConfig(cfg, static, staticCfg, func(staticCfg *tls.Config) *tls.Config {
+			staticCfg.NextProtos = []string{"h2", "http/1.1"}
+			return staticCfg
+		}, logger)
+		return t, nil
+	}
+
+	manager, err := cfg.ACME.newManager()
+	if err != nil {
+		return nil, err
+	}
+
+	t := &TLS{}
+	t.setConfig(cfg, static, staticCfg, func(staticCfg *tls.Config) *tls.Config {
+		var config *tls.Config
+		if staticCfg != nil {
+			config = staticCfg.Clone()
+		} else {
+			config = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+		config.NextProtos = []string{"h2", "http/1.1", acme.ALPNProto}
+		config.Certificates = nil
+		config.GetCertificate = acmeCertificate(manager, staticCertificate(staticCfg), logger)
+		return config
+	}, logger)
+
+	if cfg.ACME.HTTPChallengeEndpoint != "" {
+		lis, err := Listen("tcp", cfg.ACME.HTTPChallengeEndpoint)
+		if err != nil {
+			return nil, fmt.Errorf("acme.http_challenge_endpoint: %w", err)
+		}
+		t.challenge = &http.Server{
+			Handler:           manager.HTTPHandler(nil),
+			ReadHeaderTimeout: DefaultHTTPReadTimeout,
+		}
+		t.wg.Go(func() {
+			if err := t.challenge.Serve(lis); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				logger.Error("ACME HTTP-01 challenge server error", zap.Error(err))
+			}
+		})
+	}
+
+	// Request the certificates now rather than on the first handshake, so
+	// clients see the ACME certificate as early as possible.
+	for _, domain := range cfg.ACME.Domains {
+		go func() {
+			if _, err := manager.GetCertificate(prefetchHello(domain)); err != nil {
+				logger.Warn("ACME certificate not obtained yet", zap.String("domain", domain), zap.Error(err))
+			}
+		}()
+	}
+	return t, nil
+}
+
+// setConfig sets the server configuration that build makes from the loaded
+// static settings. With tls_reload enabled, handshakes are served the
+// configuration built from the latest static files instead.
+func (t *TLS) setConfig(cfg *Config, static *configtls.ServerConfig, staticCfg *tls.Config, build func(*tls.Config) *tls.Config, logger *zap.Logger) {
+	config := build(staticCfg)
+	if !cfg.TLSReload.Enabled {
+		t.config = config
+		return
+	}
+	reloader := cfg.newTLSReloader(static, config, build, logger)
+	t.config = config.Clone()
+	t.config.GetConfigForClient = reloader.configForClient
+}
+
+// Config returns the server TLS configuration, or nil for plaintext.
+func (t *TLS) Config() *tls.Config {
+	if t == nil {
+		return nil
+	}
+	return t.config
+}
+
+// WrapListener returns lis serving TLS, or lis itself for plaintext.
+func (t *TLS) WrapListener(lis net.Listener) net.Listener {
+	if t == nil {
+		return lis
+	}
+	return tls.NewListener(lis, t.config)
+}
+
+// Shutdown stops the HTTP-01 challenge server.
+func (t *TLS) Shutdown(ctx context.Context) error {
+	if t == nil || t.challenge == nil {
+		return nil
+	}
+	err := t.challenge.Shutdown(ctx)
+	t.wg.Wait()
+	return err
+}
+
+// newManager creates the autocert manager for cfg.
+func (cfg *ACMEConfig) newManager() (*autocert.Manager, error) {
+	directory := cfg.DirectoryURL
+	if directory == "" {
+		directory = DefaultACMEDirectory
+	}
+	renewBefore := cfg.RenewBefore
+	if renewBefore == 0 {
+		renewBefore = DefaultACMERenewBefore
+	}
+
+	client := &acme.Client{DirectoryURL: directory}
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("acme.ca_file: %w", err)
+		}
+		roots, err := x509.SystemCertPool()
+		if err != nil {
+			roots = x509.NewCertPool()
+		}
+		if !roots.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("acme.ca_file: no certificates found in %q", cfg.CAFile)
+		}
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.TLSClientConfig = &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12}
+		client.HTTPClient = &http.Client{Transport: transport}
+	}
+
+	return &autocert.Manager{
+		Prompt:      autocert.AcceptTOS,
+		Cache:       autocert.DirCache(cfg.CacheDir),
+		HostPolicy:  autocert.HostWhitelist(cfg.Domains...),
+		RenewBefore: renewBefore,
+		Client:      client,
+		Email:       cfg.Email,
+	}, nil
+}
+
+// acmeCertificate returns a GetCertificate function serving the ACME
+// certificate, and the static one when ACME fails and static is not nil.
+// TLS-ALPN-01 challenge handshakes are always answered by ACME.
+func acmeCertificate(manager *autocert.Manager, static func(*tls.ClientHelloInfo) (*tls.Certificate, error), logger *zap.Logger) func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	var warned sync.Map
+	return func(hello *tls.ClientHelloInfo) (*tls.Certificate, error) {
+		cert, err := manager.GetCertificate(hello)
+		if err == nil || static == nil || slices.Contains(hello.SupportedProtos, acme.ALPNProto) {
+			return cert, err
+		}
+		if _, loaded := warned.LoadOrStore(hello.ServerName, struct{}{}); !loaded {
+			logger.Warn("Serving the static certificate, ACME certificate unavailable",
+				zap.String("server_name", hello.ServerName), zap.Error(err))
+		}
+		return static(hello)
+	}
+}
+
+// staticCertificate returns the GetCertificate function of a static
+// configuration, or nil if cfg is nil.
+func staticCertificate(cfg *tls.Config) func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	switch {
+	case cfg == nil:
+		return nil
+	case cfg.GetCertificate != nil:
+		return cfg.GetCertificate
+	case len(cfg.Certificates) > 0:
+		cert := &cfg.Certificates[0]
+		return func(*tls.ClientHelloInfo) (*tls.Certificate, error) { return cert, nil }
+	default:
+		return nil
+	}
+}
+
+// prefetchHello is a ClientHelloInfo for domain from a client supporting
+// ECDSA, so a prefetch obtains the certificate modern clients are served.
+func prefetchHello(domain string) *tls.ClientHelloInfo {
+	return &tls.ClientHelloInfo{
+		ServerName:        domain,
+		SupportedVersions: []uint16{tls.VersionTLS13},
+		SignatureSchemes:  []tls.SignatureScheme{tls.ECDSAWithP256AndSHA256},
+		SupportedCurves:   []tls.CurveID{tls.CurveP256},
+		CipherSuites:      []uint16{tls.TLS_ECDHE_ECDSA_WITH_AES_128_GCM_SHA256},
+	}
+}
@@ -0,0 +1,206 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package serverconf
+
+import (
+	"context"
+	"errors"
+	"net"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/confmap"
+	"go.uber.org/zap"
+)
+
+// HandoffSectionKey is the top-level configuration key of the listener
+// handoff section.
+const HandoffSectionKey = "listener_handoff"
+
+const (
+	// DefaultHandoffDirectory is the default state directory holding the
+	// handoff socket.
+	DefaultHandoffDirectory = "/var/lib/tfo-collector/handoff"
+
+	// DefaultHandoffTimeout is how long a new process waits by default for
+	// the previous one to stop after taking over its sockets.
+	DefaultHandoffTimeout = time.Minute
+
+	// handoffSocketName is the name of the handoff socket in the directory.
+	handoffSocketName = "handoff.sock"
+
+	// maxHandoffSockets bounds the listening sockets handed over at once.
+	maxHandoffSockets = 64
+)
+
+// HandoffConfig defines the listener_handoff section: the listening sockets
+// of Listen are handed from a running collector to the next one started
+// with the same directory, e.g. during a binary upgrade.
+type HandoffConfig struct {
+	// Enabled serves the sockets to the next process and takes them over
+	// from the previous one at startup.
+	// Default: false
+	Enabled bool `mapstructure:"enabled"`
+
+	// Directory is the state directory of the handoff socket, shared by
+	// the old and new process.
+	// Default: /var/lib/tfo-collector/handoff
+	Directory string `mapstructure:"directory"`
+
+	// Timeout is how long the new process waits for the previous one to
+	// stop before starting its components.
+	// Default: 1m
+	Timeout time.Duration `mapstructure:"timeout"`
+}
+
+// DefaultHandoffConfig returns the default listener handoff settings.
+func DefaultHandoffConfig() HandoffConfig {
+	return HandoffConfig{
+		Directory: DefaultHandoffDirectory,
+		Timeout:   DefaultHandoffTimeout,
+	}
+}
+
+// Validate checks the configuration for errors.
+func (cfg *HandoffConfig) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.Directory == "" {
+		return errors.New("listener_handoff.directory must not be empty")
+	}
+	if cfg.Timeout <= 0 {
+		return errors.New("listener_handoff.timeout must be positive")
+	}
+	return nil
+}
+
+// SocketPath returns the path of the handoff socket.
+func (cfg *HandoffConfig) SocketPath() string {
+	return filepath.Join(cfg.Directory, handoffSocketName)
+}
+
+// LoadHandoffConfig reads the listener_handoff section of conf over the
+// defaults.
+func LoadHandoffConfig(conf *confmap.Conf) (HandoffConfig, error) {
+	cfg := DefaultHandoffConfig()
+	if conf.IsSet(HandoffSectionKey) {
+		sub, err := conf.Sub(HandoffSectionKey)
+		if err != nil {
+			return cfg, err
+		}
+		if err := sub.Unmarshal(&cfg); err != nil {
+			return cfg, err
+		}
+	}
+	return cfg, cfg.Validate()
+}
+
+// handoff is the handoff server of the process, started once.
+var handoff struct {
+	sync.Mutex
+	server *HandoffServer
+}
+
+// NewHandoffConverterFactory returns a converter that removes the
+// listener_handoff section from the configuration and, when enabled, takes
+// over the listening sockets of the previous process, waiting for it to
+// stop, then serves the sockets of this process to the next one. A handed
+// over process shuts itself down as on SIGTERM. Later conversions, e.g. on
+// a configuration reload, keep the running server.
+func NewHandoffConverterFactory() confmap.ConverterFactory {
+	return confmap.NewConverterFactory(func(set confmap.ConverterSettings) confmap.Converter {
+		logger := set.Logger
+		if logger == nil {
+			logger = zap.NewNop()
+		}
+		return &handoffConverter{logger: logger}
+	})
+}
+
+type handoffConverter struct {
+	logger *zap.Logger
+}
+
+func (c *handoffConverter) Convert(ctx context.Context, conf *confmap.Conf) error {
+	cfg, err := LoadHandoffConfig(conf)
+	if err != nil {
+		return err
+	}
+	conf.Delete(HandoffSectionKey)
+	if !cfg.Enabled {
+		return nil
+	}
+
+	handoff.Lock()
+	defer handoff.Unlock()
+	if handoff.server != nil {
+		return nil
+	}
+
+	waitCtx, cancel := context.WithTimeout(ctx, cfg.Timeout)
+	defer cancel()
+	n, err := TakeOverListeners(waitCtx, cfg.SocketPath())
+	switch {
+	case err != nil && n == 0:
+		return err
+	case err != nil:
+		c.logger.Warn("Previous process did not stop after handing over its listeners; its other ports may still be in use",
+			zap.Int("sockets", n), zap.Duration("timeout", cfg.Timeout), zap.Error(err))
+	case n > 0:
+		c.logger.Info("Took over listening sockets from the previous process", zap.Int("sockets", n))
+	}
+
+	server, err := ServeHandoff(cfg.SocketPath(), func(n int) {
+		c.logger.Info("Handed listening sockets over to a new process; shutting down", zap.Int("sockets", n))
+		terminateSelf()
+	})
+	if err != nil {
+		return err
+	}
+	handoff.server = server
+	c.logger.Info("Listener handoff enabled", zap.String("socket", cfg.SocketPath()))
+	return nil
+}
+
+// handedSockets returns the listening sockets of the pool by key.
+func handedSockets() map[string]*net.TCPListener {
+	sockets.Lock()
+	defer sockets.Unlock()
+	out := make(map[string]*net.TCPListener, len(sockets.m))
+	for key, s := range sockets.m {
+		out[key] = s.tcp
+	}
+	return out
+}
+
+// adopt adds a listening socket taken over from another process to the
+// pool as released, so the next Listen for key takes it within
+// DefaultListenerGrace. A key already pooled keeps its socket.
+func adopt(key string, tcp *net.TCPListener) {
+	_, port, _ := net.SplitHostPort(key[strings.Index(key, "|")+1:])
+	sockets.Lock()
+	if _, ok := sockets.m[key]; ok {
+		sockets.Unlock()
+		_ = tcp.Close()
+		return
+	}
+	s := &pooledSocket{key: key, port: port, tcp: tcp, inUse: true}
+	sockets.m[key] = s
+	sockets.Unlock()
+	release(s)
+}
@@ -0,0 +1,47 @@
+//go:build !linux && !darwin
+
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package serverconf
+
+import (
+	"context"
+	"errors"
+)
+
+// errHandoffUnsupported is returned where sockets cannot be passed between
+// processes.
+var errHandoffUnsupported = errors.New("listener handoff is not supported on this platform")
+
+// HandoffServer serves the listening sockets of the process to the next
+// one on a handoff socket.
+type HandoffServer struct{}
+
+// ServeHandoff is not supported on this platform.
+func ServeHandoff(string, func(n int)) (*HandoffServer, error) {
+	return nil, errHandoffUnsupported
+}
+
+// Close implements io.Closer.
+func (s *HandoffServer) Close() error {
+	return nil
+}
+
+// TakeOverListeners is not supported on this platform.
+func TakeOverListeners(context.Context, string) (int, error) {
+	return 0, errHandoffUnsupported
+}
+
+func terminateSelf() {}
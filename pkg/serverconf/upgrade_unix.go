@@ -0,0 +1,273 @@
+//go:build linux || darwin
+
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package serverconf
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"syscall"
+)
+
+// HandoffServer serves the listening sockets of the process to the next
+// one on a handoff socket.
+type HandoffServer struct {
+	lis       *net.UnixListener
+	onHandoff func(n int)
+
+	mu     sync.Mutex
+	conns  map[*net.UnixConn]struct{}
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// ServeHandoff serves the listening sockets of Listen at path, replacing a
+// stale socket file. A process connecting receives all of them, then
+// onHandoff is called with their number. The connection is held until the
+// server is closed or the process exits, which tells the new process that
+// this one stopped.
+func ServeHandoff(path string, onHandoff func(n int)) (*HandoffServer, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("listener handoff: %w", err)
+	}
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("listener handoff: %w", err)
+	}
+	lis, err := net.ListenUnix("unix", &net.UnixAddr{Name: path, Net: "unix"})
+	if err != nil {
+		return nil, fmt.Errorf("listener handoff: %w", err)
+	}
+	// The next process replaces the file; closing must not remove its
+	// socket.
+	lis.SetUnlinkOnClose(false)
+	if err := os.Chmod(path, 0o600); err != nil {
+		_ = lis.Close()
+		return nil, fmt.Errorf("listener handoff: %w", err)
+	}
+
+	s := &HandoffServer{lis: lis, onHandoff: onHandoff, conns: make(map[*net.UnixConn]struct{})}
+	s.wg.Add(1)
+	go s.serve()
+	return s, nil
+}
+
+func (s *HandoffServer) serve() {
+	defer s.wg.Done()
+	for {
+		conn, err := s.lis.AcceptUnix()
+		if err != nil {
+			return
+		}
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			_ = conn.Close()
+			return
+		}
+		s.conns[conn] = struct{}{}
+		s.wg.Add(1)
+		s.mu.Unlock()
+		go s.handle(conn)
+	}
+}
+
+// handle sends the listening sockets over conn and holds it open.
+func (s *HandoffServer) handle(conn *net.UnixConn) {
+	defer s.wg.Done()
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+		_ = conn.Close()
+	}()
+
+	n, err := sendSockets(conn)
+	if err != nil {
+		return
+	}
+	if s.onHandoff != nil {
+		s.onHandoff(n)
+	}
+	_, _ = io.Copy(io.Discard, conn)
+}
+
+// sendSockets writes the keys of the pooled sockets as a JSON line, with
+// their descriptors attached, in a single message.
+func sendSockets(conn *net.UnixConn) (int, error) {
+	keys := []string{}
+	var files []*os.File
+	defer func() {
+		for _, f := range files {
+			_ = f.Close()
+		}
+	}()
+	for key, tcp := range handedSockets() {
+		if len(files) == maxHandoffSockets {
+			break
+		}
+		f, err := tcp.File()
+		if err != nil {
+			continue
+		}
+		keys = append(keys, key)
+		files = append(files, f)
+	}
+
+	payload, err := json.Marshal(keys)
+	if err != nil {
+		return 0, err
+	}
+	var oob []byte
+	if len(files) > 0 {
+		fds := make([]int, len(files))
+		for i, f := range files {
+			fds[i] = int(f.Fd())
+		}
+		oob = syscall.UnixRights(fds...)
+	}
+	if _, _, err := conn.WriteMsgUnix(append(payload, '\n'), oob, nil); err != nil {
+		return 0, err
+	}
+	return len(files), nil
+}
+
+// Close stops serving and closes the held connections.
+func (s *HandoffServer) Close() error {
+	s.mu.Lock()
+	s.closed = true
+	err := s.lis.Close()
+	for conn := range s.conns {
+		_ = conn.Close()
+	}
+	s.mu.Unlock()
+	s.wg.Wait()
+	return err
+}
+
+// TakeOverListeners takes over the listening sockets of the process serving
+// the handoff socket at path and waits, until ctx is done, for that process
+// to stop. The sockets join the pool of Listen once it stopped, or when ctx
+// is done, and are used by the next Listen for the same address. It
+// returns the number of sockets taken, 0 if no process serves path, and
+// the error of the wait.
+func TakeOverListeners(ctx context.Context, path string) (int, error) {
+	var d net.Dialer
+	c, err := d.DialContext(ctx, "unix", path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) || errors.Is(err, syscall.ECONNREFUSED) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("listener handoff: %w", err)
+	}
+	conn := c.(*net.UnixConn)
+	defer func() { _ = conn.Close() }()
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = conn.SetDeadline(deadline)
+	}
+
+	listeners, err := receiveSockets(conn)
+	if err != nil {
+		return 0, fmt.Errorf("listener handoff: %w", err)
+	}
+
+	// The previous process closes the connection when it exits.
+	_, waitErr := io.Copy(io.Discard, conn)
+	if waitErr != nil {
+		waitErr = fmt.Errorf("waiting for the previous process to stop: %w", waitErr)
+	}
+	for key, tcp := range listeners {
+		adopt(key, tcp)
+	}
+	return len(listeners), waitErr
+}
+
+// receiveSockets reads the message of sendSockets.
+func receiveSockets(conn *net.UnixConn) (map[string]*net.TCPListener, error) {
+	buf := make([]byte, 64<<10)
+	oob := make([]byte, syscall.CmsgSpace(4*maxHandoffSockets))
+	n, oobn, flags, _, err := conn.ReadMsgUnix(buf, oob)
+	if err != nil {
+		return nil, err
+	}
+	var fds []int
+	msgs, err := syscall.ParseSocketControlMessage(oob[:oobn])
+	if err != nil {
+		return nil, err
+	}
+	for _, msg := range msgs {
+		rights, err := syscall.ParseUnixRights(&msg)
+		if err == nil {
+			fds = append(fds, rights...)
+		}
+	}
+	files := make([]*os.File, len(fds))
+	for i, fd := range fds {
+		files[i] = os.NewFile(uintptr(fd), "handoff")
+	}
+	defer func() {
+		for _, f := range files {
+			_ = f.Close()
+		}
+	}()
+	if flags&syscall.MSG_CTRUNC != 0 {
+		return nil, errors.New("too many sockets")
+	}
+
+	data := buf[:n]
+	for !bytes.HasSuffix(data, []byte("\n")) {
+		m, err := conn.Read(buf)
+		if err != nil {
+			return nil, err
+		}
+		data = append(data, buf[:m]...)
+	}
+	var keys []string
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, err
+	}
+	if len(keys) != len(files) {
+		return nil, fmt.Errorf("received %d sockets for %d listeners", len(files), len(keys))
+	}
+
+	listeners := make(map[string]*net.TCPListener, len(keys))
+	for i, key := range keys {
+		lis, err := net.FileListener(files[i])
+		if err != nil {
+			continue
+		}
+		tcp, ok := lis.(*net.TCPListener)
+		if !ok {
+			_ = lis.Close()
+			continue
+		}
+		listeners[key] = tcp
+	}
+	return listeners, nil
+}
+
+// terminateSelf shuts the collector down as on SIGTERM.
+func terminateSelf() {
+	_ = syscall.Kill(os.Getpid(), syscall.SIGTERM)
+}
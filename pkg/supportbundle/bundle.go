@@ -0,0 +1,291 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package supportbundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime/pprof"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// DefaultMaxLogBytes is how much of the end of each log file a bundle
+	// holds when Options.MaxLogBytes is zero.
+	DefaultMaxLogBytes = 1 << 20
+
+	// DefaultTimeout bounds each HTTP request when Options.Client is nil.
+	DefaultTimeout = 10 * time.Second
+
+	// maxStateEntries bounds the files listed per state directory.
+	maxStateEntries = 10000
+)
+
+// Options describes what a bundle holds.
+type Options struct {
+	// BuildInfo is written to version.json.
+	BuildInfo map[string]string
+
+	// Config is the effective configuration, written to config.yaml after
+	// Redact.
+	Config map[string]any
+
+	// LogFiles are the log files of the collector. The last MaxLogBytes of
+	// each are written under logs/.
+	LogFiles []string
+
+	// MaxLogBytes bounds the bytes taken from each log file.
+	// DefaultMaxLogBytes is used when zero.
+	MaxLogBytes int64
+
+	// StateDirs are the queue, storage and capture directories, or single
+	// files, listed in state.txt.
+	StateDirs []string
+
+	// MetricsURL is the Prometheus self-metrics endpoint scraped into
+	// metrics.txt, e.g. "http://localhost:8888/metrics". Empty skips it.
+	MetricsURL string
+
+	// PprofURL is the base URL of a pprof extension the profiles are
+	// fetched from, e.g. "http://localhost:1777/debug/pprof".
+	PprofURL string
+
+	// ProfileProcess profiles the calling process when PprofURL is empty,
+	// as the tfosupport extension does. Without either no profiles are
+	// written.
+	ProfileProcess bool
+
+	// Client makes the HTTP requests. A client with DefaultTimeout is used
+	// when nil.
+	Client *http.Client
+}
+
+// Write writes the bundle described by opts to w as a gzip-compressed tar
+// archive. Items that cannot be collected, e.g. a missing log file or an
+// unreachable endpoint, are listed in errors.txt rather than failing the
+// bundle; Write only returns the errors of writing to w.
+func Write(ctx context.Context, w io.Writer, opts Options) error {
+	if opts.MaxLogBytes <= 0 {
+		opts.MaxLogBytes = DefaultMaxLogBytes
+	}
+	if opts.Client == nil {
+		opts.Client = &http.Client{Timeout: DefaultTimeout}
+	}
+
+	gz := gzip.NewWriter(w)
+	b := &bundle{tw: tar.NewWriter(gz), now: time.Now(), names: make(map[string]bool)}
+
+	if opts.BuildInfo != nil {
+		data, err := json.MarshalIndent(opts.BuildInfo, "", "  ")
+		b.addOrFail("version.json", data, err)
+	}
+	if opts.Config != nil {
+		data, err := marshalYAML(Redact(opts.Config))
+		b.addOrFail("config.yaml", data, err)
+	}
+	for _, file := range opts.LogFiles {
+		data, err := tail(file, opts.MaxLogBytes)
+		b.addOrFail(path.Join("logs", filepath.Base(file)), data, err)
+	}
+	if opts.MetricsURL != "" {
+		data, err := fetch(ctx, opts.Client, opts.MetricsURL)
+		b.addOrFail("metrics.txt", data, err)
+	}
+	b.addProfiles(ctx, opts)
+	if len(opts.StateDirs) > 0 {
+		b.add("state.txt", listState(opts.StateDirs))
+	}
+	if len(b.failures) > 0 {
+		b.add("errors.txt", []byte(strings.Join(b.failures, "\n")+"\n"))
+	}
+
+	if err := b.tw.Close(); err != nil && b.err == nil {
+		b.err = err
+	}
+	if err := gz.Close(); err != nil && b.err == nil {
+		b.err = err
+	}
+	return b.err
+}
+
+// bundle writes the entries of the archive.
+type bundle struct {
+	tw    *tar.Writer
+	now   time.Time
+	names map[string]bool
+
+	// failures are the items that could not be collected.
+	failures []string
+
+	// err is the first error of writing the archive.
+	err error
+}
+
+// add writes an entry. Names already taken get a numeric suffix, e.g.
+// when two log files share a base name.
+func (b *bundle) add(name string, data []byte) {
+	if b.err != nil {
+		return
+	}
+	unique := name
+	for i := 2; b.names[unique]; i++ {
+		ext := path.Ext(name)
+		unique = fmt.Sprintf("%s-%d%s", strings.TrimSuffix(name, ext), i, ext)
+	}
+	b.names[unique] = true
+
+	hdr := &tar.Header{Name: unique, Mode: 0o600, Size: int64(len(data)), ModTime: b.now}
+	if err := b.tw.WriteHeader(hdr); err != nil {
+		b.err = err
+		return
+	}
+	if _, err := b.tw.Write(data); err != nil {
+		b.err = err
+	}
+}
+
+// addOrFail writes an entry, or records err as a failure to collect it.
+func (b *bundle) addOrFail(name string, data []byte, err error) {
+	if err != nil {
+		b.failures = append(b.failures, fmt.Sprintf("%s: %v", name, err))
+		return
+	}
+	b.add(name, data)
+}
+
+// addProfiles writes a goroutine dump and a heap profile, fetched from
+// opts.PprofURL or taken from this process.
+func (b *bundle) addProfiles(ctx context.Context, opts Options) {
+	const goroutines, heap = "profiles/goroutine.txt", "profiles/heap.pb.gz"
+	switch {
+	case opts.PprofURL != "":
+		base := strings.TrimSuffix(opts.PprofURL, "/")
+		data, err := fetch(ctx, opts.Client, base+"/goroutine?debug=2")
+		b.addOrFail(goroutines, data, err)
+		data, err = fetch(ctx, opts.Client, base+"/heap")
+		b.addOrFail(heap, data, err)
+	case opts.ProfileProcess:
+		var buf bytes.Buffer
+		err := pprof.Lookup("goroutine").WriteTo(&buf, 2)
+		b.addOrFail(goroutines, buf.Bytes(), err)
+		buf = bytes.Buffer{}
+		err = pprof.Lookup("heap").WriteTo(&buf, 0)
+		b.addOrFail(heap, buf.Bytes(), err)
+	}
+}
+
+// marshalYAML encodes v with the two-space indentation of the collector
+// configuration files.
+func marshalYAML(v any) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// tail returns the last limit bytes of file.
+func tail(file string, limit int64) ([]byte, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return nil, err
+	}
+	if info.Size() > limit {
+		if _, err := f.Seek(info.Size()-limit, io.SeekStart); err != nil {
+			return nil, err
+		}
+	}
+	return io.ReadAll(io.LimitReader(f, limit))
+}
+
+// fetch returns the body of a successful GET request to url.
+func fetch(ctx context.Context, client *http.Client, url string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET %s: %s", url, resp.Status)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// listState lists the files of each state directory, one per line with
+// their mode, size and modification time, followed by the totals.
+func listState(dirs []string) []byte {
+	var buf bytes.Buffer
+	for i, dir := range dirs {
+		if i > 0 {
+			buf.WriteByte('\n')
+		}
+		fmt.Fprintf(&buf, "# %s\n", dir)
+		var files, size int64
+		err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+			if err != nil {
+				fmt.Fprintf(&buf, "error\t%s\t%v\n", p, err)
+				return nil
+			}
+			info, err := d.Info()
+			if err != nil {
+				return nil
+			}
+			if !info.IsDir() {
+				files++
+				size += info.Size()
+			}
+			if files <= maxStateEntries {
+				fmt.Fprintf(&buf, "%s\t%d\t%s\t%s\n", info.Mode(), info.Size(),
+					info.ModTime().UTC().Format(time.RFC3339), p)
+			}
+			return nil
+		})
+		if err != nil {
+			fmt.Fprintf(&buf, "error\t%s\t%v\n", dir, err)
+		}
+		if files > maxStateEntries {
+			fmt.Fprintf(&buf, "... %d more files\n", files-maxStateEntries)
+		}
+		fmt.Fprintf(&buf, "# %d files, %d bytes\n", files, size)
+	}
+	return buf.Bytes()
+}
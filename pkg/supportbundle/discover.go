@@ -0,0 +1,166 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package supportbundle
+
+import (
+	"fmt"
+	"maps"
+	"net"
+	"slices"
+	"strings"
+)
+
+const (
+	// defaultMetricsEndpoint is where the collector serves its self-metrics
+	// when service::telemetry::metrics has no readers.
+	defaultMetricsEndpoint = "localhost:8888"
+
+	// defaultPprofEndpoint is the default endpoint of the pprof extension.
+	defaultPprofEndpoint = "localhost:1777"
+)
+
+// FromConfig returns the options of a bundle of the collector running cfg,
+// its effective configuration:
+//   - the log files of service::telemetry::logs, other than stdout and
+//     stderr
+//   - the Prometheus reader of service::telemetry::metrics
+//   - the endpoint of a pprof extension used by the service
+//   - the directories of file_storage extensions, tforetention exporters and
+//     tfootlp payload captures, the shutdown spill files and dead letter
+//     directories of tfo exporters and the crash_guard dump directory
+//
+// BuildInfo, the profiles of the calling process and the client are left
+// to the caller.
+func FromConfig(cfg map[string]any) Options {
+	opts := Options{Config: cfg}
+
+	for _, key := range []string{"output_paths", "error_output_paths"} {
+		for _, p := range stringList(lookup(cfg, "service", "telemetry", "logs", key)) {
+			p = strings.TrimPrefix(p, "file://")
+			if p != "stdout" && p != "stderr" && !slices.Contains(opts.LogFiles, p) {
+				opts.LogFiles = append(opts.LogFiles, p)
+			}
+		}
+	}
+
+	opts.MetricsURL = metricsURL(lookup(cfg, "service", "telemetry", "metrics"))
+
+	for _, id := range stringList(lookup(cfg, "service", "extensions")) {
+		switch componentType(id) {
+		case "pprof":
+			if opts.PprofURL == "" {
+				endpoint, _ := lookup(cfg, "extensions", id, "endpoint").(string)
+				if endpoint == "" {
+					endpoint = defaultPprofEndpoint
+				}
+				opts.PprofURL = "http://" + localEndpoint(endpoint) + "/debug/pprof"
+			}
+		case "file_storage":
+			opts.StateDirs = appendPath(opts.StateDirs, lookup(cfg, "extensions", id, "directory"))
+		}
+	}
+	exporters, _ := lookup(cfg, "exporters").(map[string]any)
+	for _, id := range slices.Sorted(maps.Keys(exporters)) {
+		switch componentType(id) {
+		case "tforetention":
+			opts.StateDirs = appendPath(opts.StateDirs, lookup(exporters, id, "directory"))
+		case "tfo":
+			opts.StateDirs = appendPath(opts.StateDirs, lookup(exporters, id, "shutdown_spill", "path"))
+			opts.StateDirs = appendPath(opts.StateDirs, lookup(exporters, id, "dead_letter", "directory"))
+		}
+	}
+	receivers, _ := lookup(cfg, "receivers").(map[string]any)
+	for _, id := range slices.Sorted(maps.Keys(receivers)) {
+		if componentType(id) == "tfootlp" {
+			opts.StateDirs = appendPath(opts.StateDirs, lookup(receivers, id, "payload_capture", "directory"))
+		}
+	}
+	opts.StateDirs = appendPath(opts.StateDirs, lookup(cfg, "crash_guard", "dump_dir"))
+	return opts
+}
+
+// metricsURL returns the URL of the Prometheus reader of the telemetry
+// metrics settings, or "" when the collector serves none.
+func metricsURL(settings any) string {
+	m, _ := settings.(map[string]any)
+	if level, _ := m["level"].(string); level == "none" {
+		return ""
+	}
+	readers, ok := m["readers"].([]any)
+	if !ok {
+		return "http://" + defaultMetricsEndpoint + "/metrics"
+	}
+	for _, r := range readers {
+		prom, ok := lookup(r, "pull", "exporter", "prometheus").(map[string]any)
+		if !ok {
+			continue
+		}
+		host, _ := prom["host"].(string)
+		return "http://" + localEndpoint(net.JoinHostPort(host, fmt.Sprint(prom["port"]))) + "/metrics"
+	}
+	return ""
+}
+
+// localEndpoint replaces an unspecified host of endpoint, e.g. 0.0.0.0,
+// with localhost.
+func localEndpoint(endpoint string) string {
+	host, port, err := net.SplitHostPort(endpoint)
+	if err != nil {
+		return endpoint
+	}
+	if ip := net.ParseIP(host); host == "" || (ip != nil && ip.IsUnspecified()) {
+		host = "localhost"
+	}
+	return net.JoinHostPort(host, port)
+}
+
+// lookup returns the value at keys under v, or nil.
+func lookup(v any, keys ...string) any {
+	for _, k := range keys {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = m[k]
+	}
+	return v
+}
+
+// strings_ returns the strings of a list value.
+func stringList(v any) []string {
+	list, _ := v.([]any)
+	var out []string
+	for _, e := range list {
+		if s, ok := e.(string); ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// appendPath appends v to paths when it is a new, non-empty string.
+func appendPath(paths []string, v any) []string {
+	if p, _ := v.(string); p != "" && !slices.Contains(paths, p) {
+		return append(paths, p)
+	}
+	return paths
+}
+
+// componentType returns the type of a component ID, e.g. "tfo" for
+// "tfo/backup".
+func componentType(id string) string {
+	typ, _, _ := strings.Cut(id, "/")
+	return typ
+}
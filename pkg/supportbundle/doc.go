@@ -0,0 +1,36 @@
+// Package supportbundle gathers the state of a collector into an archive to
+// attach to support tickets.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Write collects, into one gzip-compressed tar archive:
+//   - version.json: the version and build information
+//   - config.yaml: the effective configuration, with secrets, header values
+//     and URL passwords redacted
+//   - logs/: the end of each log file the collector writes to
+//   - metrics.txt: a scrape of the collector's Prometheus self-metrics
+//   - profiles/: a goroutine dump and a heap profile
+//   - state.txt: the files of the queue, storage and capture directories,
+//     with their size and modification time but not their content
+//   - errors.txt: the items that could not be collected
+//
+// FromConfig derives the log files, endpoints and state directories from
+// the configuration. The archive is written by the "tfo-collector debug
+// bundle" command and served by the tfosupport extension:
+//
+//	tfo-collector debug bundle --config configs/tfo-collector.yaml
+//	curl -o bundle.tar.gz localhost:55694/bundle
+package supportbundle // import "github.com/telemetryflow/telemetryflow-collector/pkg/supportbundle"
@@ -0,0 +1,5 @@
+module github.com/telemetryflow/telemetryflow-collector/pkg/supportbundle
+
+go 1.26
+
+require gopkg.in/yaml.v3 v3.0.1
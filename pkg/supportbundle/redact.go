@@ -0,0 +1,132 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package supportbundle
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Redacted replaces the values of secrets in the bundled configuration.
+const Redacted = "[REDACTED]"
+
+// secretKeys are the substrings of the configuration keys whose values
+// are redacted.
+var secretKeys = []string{
+	"password",
+	"passphrase",
+	"secret",
+	"token",
+	"api_key",
+	"apikey",
+	"authorization",
+	"credential",
+	"private_key",
+}
+
+// Redact returns a copy of cfg with the values of secret keys, e.g.
+// api_key_secret, bearer_token, the data key of an encryption key entry
+// ("key"), an HMAC key ("hmac_key") or an inline private key ("key_pem"),
+// the values of headers maps and the
+// passwords of URLs replaced.
+func Redact(cfg map[string]any) map[string]any {
+	return redactMap(cfg)
+}
+
+// redactMap returns a redacted copy of m.
+func redactMap(m map[string]any) map[string]any {
+	out := make(map[string]any, len(m))
+	for k, v := range m {
+		if strings.EqualFold(k, "headers") || isSecret(k) {
+			out[k] = redactAll(v)
+		} else {
+			out[k] = redactValue(v)
+		}
+	}
+	return out
+}
+
+// redactValue returns a redacted copy of v.
+func redactValue(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		return redactMap(v)
+	case []any:
+		out := make([]any, len(v))
+		for i, e := range v {
+			out[i] = redactValue(e)
+		}
+		return out
+	case string:
+		return redactURL(v)
+	}
+	return v
+}
+
+// redactAll replaces every scalar under v. Empty values are kept, so that
+// the bundle shows which secrets are unset.
+func redactAll(v any) any {
+	switch v := v.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(v))
+		for k, e := range v {
+			out[k] = redactAll(e)
+		}
+		return out
+	case []any:
+		out := make([]any, len(v))
+		for i, e := range v {
+			out[i] = redactAll(e)
+		}
+		return out
+	case nil:
+		return nil
+	case string:
+		if v == "" {
+			return v
+		}
+	}
+	return Redacted
+}
+
+// isSecret reports whether key names a secret: it contains one of
+// secretKeys, is "key" or ends in "_key" or "_pem".
+func isSecret(key string) bool {
+	key = strings.ToLower(key)
+	if key == "key" || strings.HasSuffix(key, "_key") || strings.HasSuffix(key, "_pem") {
+		return true
+	}
+	for _, s := range secretKeys {
+		if strings.Contains(key, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// redactURL masks the password of s when it is a URL with one.
+func redactURL(s string) string {
+	if !strings.Contains(s, "@") {
+		return s
+	}
+	u, err := url.Parse(s)
+	if err != nil || u.User == nil {
+		return s
+	}
+	if _, ok := u.User.Password(); !ok {
+		return s
+	}
+	return u.Redacted()
+}
@@ -0,0 +1,45 @@
+// Package tlsbootstrap issues a local certificate authority and mTLS certificates.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Run is meant for labs and sites starting with mutual TLS, where running
+// a PKI is the larger part of the onboarding. It writes, in the layout the
+// TLS settings of the receivers and exporters expect:
+//   - ca.crt and ca.key: a local CA, reused when already present so that
+//     senders can be added later
+//   - server.crt and server.key: the receiver certificate, valid for the
+//     configured host names and IP addresses
+//   - clients/<name>/client.crt, client.key and ca.crt: one bundle per
+//     sender, copied to the sender's certificate directory
+//
+// Existing server and client certificates are kept unless Options.Force is
+// set. Keys are ECDSA P-256 and written with mode 0600. It backs the
+// "tfo-collector tls bootstrap" command:
+//
+//	tfo-collector tls bootstrap --host collector.lab.local --client edge-01 --client edge-02
+//
+// The receiver then verifies the senders with:
+//
+//	receivers:
+//	  tfootlp:
+//	    protocols:
+//	      grpc:
+//	        tls:
+//	          cert_file: /etc/tfo-collector/certs/server.crt
+//	          key_file: /etc/tfo-collector/certs/server.key
+//	          client_ca_file: /etc/tfo-collector/certs/ca.crt
+//	        client_auth_type: require_and_verify
+package tlsbootstrap // import "github.com/telemetryflow/telemetryflow-collector/pkg/tlsbootstrap"
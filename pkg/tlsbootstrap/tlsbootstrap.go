@@ -0,0 +1,417 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tlsbootstrap
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"regexp"
+	"text/tabwriter"
+	"time"
+
+	"github.com/telemetryflow/telemetryflow-collector/internal/version"
+)
+
+const (
+	// DefaultDir is the certificate directory of the TLS settings in the
+	// configuration examples.
+	DefaultDir = "/etc/tfo-collector/certs"
+
+	// DefaultCAValidity is how long the CA is valid when
+	// Options.CAValidity is zero.
+	DefaultCAValidity = 10 * 365 * 24 * time.Hour
+
+	// DefaultValidity is how long the server and client certificates are
+	// valid when Options.Validity is zero.
+	DefaultValidity = 365 * 24 * time.Hour
+)
+
+// File names within the directory.
+const (
+	CACertFile     = "ca.crt"
+	CAKeyFile      = "ca.key"
+	ServerCertFile = "server.crt"
+	ServerKeyFile  = "server.key"
+	ClientCertFile = "client.crt"
+	ClientKeyFile  = "client.key"
+	ClientsDir     = "clients"
+)
+
+// clientNameRe matches the client names usable as directory names.
+var clientNameRe = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// Options configures a bootstrap.
+type Options struct {
+	// Dir receives the files. DefaultDir is used when empty.
+	Dir string
+
+	// Hosts are the DNS names and IP addresses the server certificate is
+	// valid for. The host name of the machine, localhost, 127.0.0.1 and ::1
+	// are used when empty.
+	Hosts []string
+
+	// Clients names the senders a client certificate is issued for. The
+	// name is the certificate's common name.
+	Clients []string
+
+	// CAValidity is how long a new CA is valid. DefaultCAValidity is used
+	// when zero.
+	CAValidity time.Duration
+
+	// Validity is how long new server and client certificates are valid,
+	// at most until the CA expires. DefaultValidity is used when zero.
+	Validity time.Duration
+
+	// Force reissues existing server and client certificates. The CA is
+	// never replaced; remove ca.crt and ca.key to start over.
+	Force bool
+}
+
+// File is a file of the bootstrap.
+type File struct {
+	// Path is the location of the file.
+	Path string
+
+	// Created is false when an existing file was kept.
+	Created bool
+}
+
+// Report is the outcome of a bootstrap.
+type Report struct {
+	// Dir is the directory the files were written to.
+	Dir string
+
+	// Files lists the files written or kept, in order.
+	Files []File
+
+	// Clients lists the senders with a certificate bundle.
+	Clients []string
+}
+
+// Print writes the files and the TLS settings using them to w.
+func (r *Report) Print(w io.Writer) {
+	_, _ = fmt.Fprintf(w, "TLS bootstrap in %s\n", r.Dir)
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, f := range r.Files {
+		action := "kept"
+		if f.Created {
+			action = "created"
+		}
+		rel, err := filepath.Rel(r.Dir, f.Path)
+		if err != nil {
+			rel = f.Path
+		}
+		_, _ = fmt.Fprintf(tw, "  %s\t%s\n", action, rel)
+	}
+	_ = tw.Flush()
+
+	_, _ = fmt.Fprintf(w, `
+Receiver settings (grpc and http):
+  tls:
+    cert_file: %s
+    key_file: %s
+    client_ca_file: %s
+  client_auth_type: require_and_verify
+`, filepath.Join(r.Dir, ServerCertFile), filepath.Join(r.Dir, ServerKeyFile), filepath.Join(r.Dir, CACertFile))
+	if len(r.Clients) == 0 {
+		return
+	}
+	_, _ = fmt.Fprintf(w, `
+Copy %s/<name>/ to %s on each sender; exporter settings:
+  tls:
+    ca_file: %s
+    cert_file: %s
+    key_file: %s
+`, filepath.Join(r.Dir, ClientsDir), DefaultDir,
+		filepath.Join(DefaultDir, CACertFile), filepath.Join(DefaultDir, ClientCertFile), filepath.Join(DefaultDir, ClientKeyFile))
+}
+
+// Run writes the CA, the server certificate and the client certificates
+// described by opts.
+func Run(opts Options) (*Report, error) {
+	if opts.Dir == "" {
+		opts.Dir = DefaultDir
+	}
+	if len(opts.Hosts) == 0 {
+		opts.Hosts = defaultHosts()
+	}
+	if opts.CAValidity <= 0 {
+		opts.CAValidity = DefaultCAValidity
+	}
+	if opts.Validity <= 0 {
+		opts.Validity = DefaultValidity
+	}
+	seen := make(map[string]bool, len(opts.Clients))
+	for _, name := range opts.Clients {
+		if !clientNameRe.MatchString(name) {
+			return nil, fmt.Errorf("client name %q must start with a letter or digit and contain only letters, digits, '.', '_' and '-'", name)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("client %q is listed twice", name)
+		}
+		seen[name] = true
+	}
+	if err := os.MkdirAll(opts.Dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	report := &Report{Dir: opts.Dir}
+	ca, caCreated, err := loadOrCreateCA(opts.Dir, opts.CAValidity)
+	if err != nil {
+		return nil, err
+	}
+	report.Files = append(report.Files,
+		File{Path: filepath.Join(opts.Dir, CACertFile), Created: caCreated},
+		File{Path: filepath.Join(opts.Dir, CAKeyFile), Created: caCreated},
+	)
+
+	server := &x509.Certificate{
+		Subject:     pkix.Name{CommonName: opts.Hosts[0]},
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	for _, host := range opts.Hosts {
+		if ip := net.ParseIP(host); ip != nil {
+			server.IPAddresses = append(server.IPAddresses, ip)
+		} else {
+			server.DNSNames = append(server.DNSNames, host)
+		}
+	}
+	files, err := issue(ca, server, opts,
+		filepath.Join(opts.Dir, ServerCertFile), filepath.Join(opts.Dir, ServerKeyFile))
+	if err != nil {
+		return nil, err
+	}
+	report.Files = append(report.Files, files...)
+
+	for _, name := range opts.Clients {
+		dir := filepath.Join(opts.Dir, ClientsDir, name)
+		if err := os.MkdirAll(dir, 0o700); err != nil {
+			return nil, err
+		}
+		client := &x509.Certificate{
+			Subject:     pkix.Name{CommonName: name},
+			ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		}
+		files, err := issue(ca, client, opts,
+			filepath.Join(dir, ClientCertFile), filepath.Join(dir, ClientKeyFile))
+		if err != nil {
+			return nil, err
+		}
+		report.Files = append(report.Files, files...)
+
+		// The bundle carries the CA so the sender can verify the receiver.
+		caPath := filepath.Join(dir, CACertFile)
+		created := false
+		if current, err := os.ReadFile(caPath); err != nil || !bytes.Equal(current, ca.certPEM) {
+			if err := writeFile(caPath, ca.certPEM, 0o644); err != nil {
+				return nil, err
+			}
+			created = true
+		}
+		report.Files = append(report.Files, File{Path: caPath, Created: created})
+		report.Clients = append(report.Clients, name)
+	}
+	return report, nil
+}
+
+// authority is the CA signing the certificates.
+type authority struct {
+	cert    *x509.Certificate
+	key     crypto.Signer
+	certPEM []byte
+}
+
+// loadOrCreateCA loads the CA of dir, or creates one when dir has none.
+func loadOrCreateCA(dir string, validity time.Duration) (*authority, bool, error) {
+	certPath, keyPath := filepath.Join(dir, CACertFile), filepath.Join(dir, CAKeyFile)
+	certPEM, certErr := os.ReadFile(certPath)
+	keyPEM, keyErr := os.ReadFile(keyPath)
+	switch {
+	case certErr == nil && keyErr == nil:
+		ca, err := parseCA(certPEM, keyPEM)
+		if err != nil {
+			return nil, false, fmt.Errorf("existing CA in %s: %w", dir, err)
+		}
+		return ca, false, nil
+	case errors.Is(certErr, os.ErrNotExist) && errors.Is(keyErr, os.ErrNotExist):
+	case certErr == nil || keyErr == nil:
+		return nil, false, fmt.Errorf("%s has only one of %s and %s; restore the other or remove it to create a new CA", dir, CACertFile, CAKeyFile)
+	default:
+		return nil, false, errors.Join(certErr, keyErr)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, false, err
+	}
+	serial, err := serialNumber()
+	if err != nil {
+		return nil, false, err
+	}
+	now := time.Now()
+	tmpl := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: version.ProductShortName + " local CA"},
+		NotBefore:             now.Add(-time.Hour),
+		NotAfter:              now.Add(validity),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		MaxPathLenZero:        true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	if err != nil {
+		return nil, false, err
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, false, err
+	}
+	ca := &authority{cert: cert, key: key, certPEM: pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})}
+	if err := writeKey(keyPath, key); err != nil {
+		return nil, false, err
+	}
+	if err := writeFile(certPath, ca.certPEM, 0o644); err != nil {
+		return nil, false, err
+	}
+	return ca, true, nil
+}
+
+// parseCA parses a CA certificate and its private key.
+func parseCA(certPEM, keyPEM []byte) (*authority, error) {
+	block, _ := pem.Decode(certPEM)
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("%s holds no PEM certificate", CACertFile)
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	if !cert.IsCA {
+		return nil, fmt.Errorf("%s is not a CA certificate", CACertFile)
+	}
+	block, _ = pem.Decode(keyPEM)
+	if block == nil {
+		return nil, fmt.Errorf("%s holds no PEM key", CAKeyFile)
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := parsed.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("%s holds an unsupported key", CAKeyFile)
+	}
+	return &authority{cert: cert, key: key, certPEM: certPEM}, nil
+}
+
+// issue signs tmpl with ca and writes the certificate and its new key,
+// unless both files exist and opts.Force is not set.
+func issue(ca *authority, tmpl *x509.Certificate, opts Options, certPath, keyPath string) ([]File, error) {
+	if !opts.Force && exists(certPath) && exists(keyPath) {
+		return []File{{Path: certPath}, {Path: keyPath}}, nil
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	if tmpl.SerialNumber, err = serialNumber(); err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	tmpl.NotBefore = now.Add(-time.Hour)
+	tmpl.NotAfter = now.Add(opts.Validity)
+	if tmpl.NotAfter.After(ca.cert.NotAfter) {
+		tmpl.NotAfter = ca.cert.NotAfter
+	}
+	tmpl.KeyUsage = x509.KeyUsageDigitalSignature
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.cert, key.Public(), ca.key)
+	if err != nil {
+		return nil, err
+	}
+	if err := writeKey(keyPath, key); err != nil {
+		return nil, err
+	}
+	if err := writeFile(certPath, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o644); err != nil {
+		return nil, err
+	}
+	return []File{{Path: certPath, Created: true}, {Path: keyPath, Created: true}}, nil
+}
+
+// defaultHosts returns the server certificate hosts used when none are
+// configured.
+func defaultHosts() []string {
+	hosts := []string{"localhost", "127.0.0.1", "::1"}
+	if name, err := os.Hostname(); err == nil && name != "" && name != "localhost" {
+		hosts = append([]string{name}, hosts...)
+	}
+	return hosts
+}
+
+// serialNumber returns a random 128-bit certificate serial number.
+func serialNumber() (*big.Int, error) {
+	return rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 128))
+}
+
+// writeKey writes key as a PKCS #8 PEM file readable by the owner only.
+func writeKey(path string, key *ecdsa.PrivateKey) error {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return err
+	}
+	return writeFile(path, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0o600)
+}
+
+// writeFile replaces the file at path with data through a temporary file,
+// so a reader never sees a partial certificate or key.
+func writeFile(path string, data []byte, perm os.FileMode) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer func() { _ = os.Remove(tmp.Name()) }()
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(perm); err != nil {
+		_ = tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// exists reports whether a file exists at path.
+func exists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
+}
@@ -0,0 +1,83 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package watchdog
+
+import (
+	"errors"
+	"time"
+)
+
+const (
+	// DefaultCheckInterval is how often heartbeats are inspected.
+	DefaultCheckInterval = 10 * time.Second
+
+	// DefaultStallTimeout is how long a loop may hold pending work without
+	// making progress before it is considered wedged.
+	DefaultStallTimeout = time.Minute
+
+	// DefaultMaxRestarts bounds consecutive restarts of a wedged loop.
+	DefaultMaxRestarts = 3
+)
+
+// Config defines the watchdog settings embedded by components.
+type Config struct {
+	// Enabled turns on heartbeat monitoring.
+	// Default: false
+	Enabled bool `mapstructure:"enabled"`
+
+	// CheckInterval is how often heartbeats are inspected.
+	// Default: 10s
+	CheckInterval time.Duration `mapstructure:"check_interval"`
+
+	// StallTimeout is how long a loop may hold pending work without
+	// completing any of it before it is considered wedged.
+	// Default: 1m
+	StallTimeout time.Duration `mapstructure:"stall_timeout"`
+
+	// MaxRestarts bounds how many times a wedged loop is restarted before the
+	// watchdog gives up and only reports the incident. The budget is reset
+	// once the loop makes progress again. Zero disables restarts.
+	// Default: 3
+	MaxRestarts int `mapstructure:"max_restarts"`
+}
+
+// NewDefaultConfig returns the default watchdog settings (disabled).
+func NewDefaultConfig() Config {
+	return Config{
+		CheckInterval: DefaultCheckInterval,
+		StallTimeout:  DefaultStallTimeout,
+		MaxRestarts:   DefaultMaxRestarts,
+	}
+}
+
+// Validate checks the configuration for errors.
+func (cfg *Config) Validate() error {
+	if !cfg.Enabled {
+		return nil
+	}
+	if cfg.CheckInterval <= 0 {
+		return errors.New("watchdog.check_interval must be positive")
+	}
+	if cfg.StallTimeout <= 0 {
+		return errors.New("watchdog.stall_timeout must be positive")
+	}
+	if cfg.StallTimeout < cfg.CheckInterval {
+		return errors.New("watchdog.stall_timeout must not be shorter than watchdog.check_interval")
+	}
+	if cfg.MaxRestarts < 0 {
+		return errors.New("watchdog.max_restarts must not be negative")
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+// Package watchdog detects wedged component loops and restarts them.
+//
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+//
+// Components register a Heartbeat per loop they own (a receiver's servers,
+// an exporter's send path). Each unit of work is bracketed with Begin/End and
+// every completed unit counts as progress. A loop is considered wedged when it
+// has pending work but has made no progress for stall_timeout; the watchdog
+// then invokes the loop's restart function and reports the incident through
+// the configured hooks so the component can surface it on the health endpoint.
+//
+// Configuration example:
+//
+//	receivers:
+//	  tfootlp:
+//	    watchdog:
+//	      enabled: true
+//	      check_interval: 10s
+//	      stall_timeout: 1m
+//	      max_restarts: 3
+package watchdog // import "github.com/telemetryflow/telemetryflow-collector/pkg/watchdog"
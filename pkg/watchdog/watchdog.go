@@ -0,0 +1,290 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package watchdog
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+const scopeName = "github.com/telemetryflow/telemetryflow-collector/pkg/watchdog"
+
+// RestartFunc restarts a wedged loop. It is called from the watchdog
+// goroutine with a context bounded by the stall timeout.
+type RestartFunc func(ctx context.Context) error
+
+// Incident describes a wedged loop detected by the watchdog.
+type Incident struct {
+	// Component is the name the heartbeat was registered with.
+	Component string
+
+	// StalledFor is how long the loop held pending work without progress.
+	StalledFor time.Duration
+
+	// Pending is the number of work units in flight when the stall was detected.
+	Pending int64
+
+	// Restarted reports whether a restart was attempted.
+	Restarted bool
+
+	// Err is the restart error, if any.
+	Err error
+}
+
+// Hooks are notified of watchdog incidents. Both fields are optional.
+type Hooks struct {
+	// OnWedged is called when a loop is detected as wedged and after every
+	// restart attempt.
+	OnWedged func(Incident)
+
+	// OnRecovered is called when a previously wedged loop makes progress again.
+	OnRecovered func(component string)
+}
+
+// Heartbeat tracks the progress of a single loop. A nil *Heartbeat is valid
+// and ignores all calls, so components can use it unconditionally.
+type Heartbeat struct {
+	name    string
+	restart RestartFunc
+
+	pending      atomic.Int64
+	progress     atomic.Uint64
+	lastProgress atomic.Int64
+
+	// Owned by Watchdog.Check.
+	wedged         bool
+	wedgedProgress uint64
+	restartedAt    time.Time
+	restarts       int
+}
+
+// Begin records the start of a unit of work.
+func (h *Heartbeat) Begin() {
+	if h == nil {
+		return
+	}
+	// Idle time does not count as a stall: the clock starts with the first
+	// pending unit.
+	if h.pending.Add(1) == 1 {
+		h.lastProgress.Store(time.Now().UnixNano())
+	}
+}
+
+// End records the completion of a unit of work started with Begin.
+func (h *Heartbeat) End() {
+	if h == nil {
+		return
+	}
+	h.pending.Add(-1)
+	h.progress.Add(1)
+	h.lastProgress.Store(time.Now().UnixNano())
+}
+
+// Pending returns the number of work units in flight.
+func (h *Heartbeat) Pending() int64 {
+	if h == nil {
+		return 0
+	}
+	return h.pending.Load()
+}
+
+// Watchdog periodically inspects registered heartbeats and restarts loops
+// that hold pending work without making progress.
+type Watchdog struct {
+	cfg    Config
+	logger *zap.Logger
+	hooks  Hooks
+	labels selfmetrics.Labels
+
+	incidents metric.Int64Counter
+	restarts  metric.Int64Counter
+
+	mu    sync.Mutex
+	beats []*Heartbeat
+
+	checkMu sync.Mutex
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+}
+
+// New creates the watchdog of the component identified by labels. Incidents
+// are logged with set.Logger and counted on set.MeterProvider.
+func New(cfg Config, set component.TelemetrySettings, labels selfmetrics.Labels, hooks Hooks) (*Watchdog, error) {
+	logger := set.Logger
+	if logger == nil {
+		logger = zap.NewNop()
+	}
+	w := &Watchdog{
+		cfg:    cfg,
+		logger: logger,
+		hooks:  hooks,
+		labels: labels,
+	}
+	if set.MeterProvider != nil {
+		meter := set.MeterProvider.Meter(scopeName)
+		var err error
+		w.incidents, err = meter.Int64Counter(selfmetrics.WatchdogIncidents,
+			metric.WithDescription("Number of wedged component loops detected by the watchdog."),
+			metric.WithUnit("{incident}"))
+		if err != nil {
+			return nil, err
+		}
+		w.restarts, err = meter.Int64Counter(selfmetrics.WatchdogRestarts,
+			metric.WithDescription("Number of restarts attempted by the watchdog."),
+			metric.WithUnit("{restart}"))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return w, nil
+}
+
+// Register adds a heartbeat for the named loop. restart may be nil, in which
+// case wedged loops are only reported.
+func (w *Watchdog) Register(name string, restart RestartFunc) *Heartbeat {
+	h := &Heartbeat{name: name, restart: restart}
+	h.lastProgress.Store(time.Now().UnixNano())
+	w.mu.Lock()
+	w.beats = append(w.beats, h)
+	w.mu.Unlock()
+	return h
+}
+
+// Start launches the check loop.
+func (w *Watchdog) Start(_ context.Context) {
+	w.stopCh = make(chan struct{})
+	w.wg.Add(1)
+	go func() {
+		defer w.wg.Done()
+		ticker := time.NewTicker(w.cfg.CheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-w.stopCh:
+				return
+			case <-ticker.C:
+				w.Check(context.Background())
+			}
+		}
+	}()
+}
+
+// Shutdown stops the check loop and waits for an in-progress check to finish.
+func (w *Watchdog) Shutdown(_ context.Context) {
+	if w.stopCh == nil {
+		return
+	}
+	close(w.stopCh)
+	w.wg.Wait()
+	w.stopCh = nil
+}
+
+// Check inspects every heartbeat once. It is called by the check loop and
+// may be called directly to force an inspection.
+func (w *Watchdog) Check(ctx context.Context) {
+	w.checkMu.Lock()
+	defer w.checkMu.Unlock()
+
+	w.mu.Lock()
+	beats := append([]*Heartbeat(nil), w.beats...)
+	w.mu.Unlock()
+
+	now := time.Now()
+	for _, h := range beats {
+		w.check(ctx, h, now)
+	}
+}
+
+func (w *Watchdog) check(ctx context.Context, h *Heartbeat, now time.Time) {
+	pending := h.pending.Load()
+	progress := h.progress.Load()
+
+	if h.wedged && (pending == 0 || progress != h.wedgedProgress) {
+		h.wedged = false
+		h.restarts = 0
+		w.logger.Info("Watchdog: component recovered", zap.String("component", h.name))
+		if w.hooks.OnRecovered != nil {
+			w.hooks.OnRecovered(h.name)
+		}
+		return
+	}
+	if pending == 0 {
+		return
+	}
+
+	since := time.Unix(0, h.lastProgress.Load())
+	if h.restartedAt.After(since) {
+		since = h.restartedAt
+	}
+	stalled := now.Sub(since)
+	if stalled < w.cfg.StallTimeout {
+		return
+	}
+
+	incident := Incident{Component: h.name, StalledFor: stalled, Pending: pending}
+	firstDetection := !h.wedged
+	h.wedged = true
+	h.wedgedProgress = progress
+
+	switch {
+	case h.restart != nil && h.restarts < w.cfg.MaxRestarts:
+		h.restarts++
+		h.restartedAt = now
+		restartCtx, cancel := context.WithTimeout(ctx, w.cfg.StallTimeout)
+		incident.Restarted = true
+		incident.Err = h.restart(restartCtx)
+		cancel()
+	case !firstDetection:
+		// Restart budget exhausted and already reported.
+		return
+	}
+
+	w.report(ctx, incident)
+}
+
+func (w *Watchdog) report(ctx context.Context, incident Incident) {
+	if w.incidents != nil {
+		w.incidents.Add(ctx, 1, w.labels.Option())
+	}
+	if incident.Restarted && w.restarts != nil {
+		outcome := "success"
+		if incident.Err != nil {
+			outcome = "failure"
+		}
+		w.restarts.Add(ctx, 1, w.labels.Option(attribute.String("outcome", outcome)))
+	}
+
+	w.logger.Warn("Watchdog: component wedged",
+		zap.String("component", incident.Component),
+		zap.Duration("stalled_for", incident.StalledFor),
+		zap.Int64("pending", incident.Pending),
+		zap.Bool("restarted", incident.Restarted),
+		zap.Error(incident.Err),
+	)
+
+	if w.hooks.OnWedged != nil {
+		w.hooks.OnWedged(incident)
+	}
+}
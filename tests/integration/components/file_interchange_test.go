@@ -0,0 +1,220 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package components_test
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// These tests pin the on-disk archive format produced by the file exporter
+// shipped in this distribution to the format of the stock otelcol file
+// exporter, so archives are interchangeable with the stock distribution's:
+//
+//   - format json: one OTLP/JSON request per line, newline terminated
+//   - format proto: each OTLP/protobuf request prefixed by a 4-byte
+//     big-endian length
+//   - rotation: the active file keeps the configured name and backups are
+//     renamed <name>-<timestamp>[-<reason>]<ext>, so the glob <name>*<ext>
+//     matches the whole archive
+//   - flushing: buffered data reaches disk every flush_interval and on
+//     shutdown
+
+func archiveTraces(spans int, payload string) ptrace.Traces {
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "archive-test")
+	ss := rs.ScopeSpans().AppendEmpty()
+	for i := range spans {
+		span := ss.Spans().AppendEmpty()
+		span.SetName("op")
+		span.SetTraceID(pcommon.TraceID([16]byte{1, byte(i >> 8), byte(i)}))
+		span.SetSpanID(pcommon.SpanID([8]byte{2, byte(i >> 8), byte(i)}))
+		span.Attributes().PutStr("payload", payload)
+	}
+	return td
+}
+
+func startFileExporter(t *testing.T, cfg *fileexporter.Config) func(ptrace.Traces) {
+	t.Helper()
+	factory := fileexporter.NewFactory()
+	require.NoError(t, cfg.Validate())
+
+	exp, err := factory.CreateTraces(context.Background(), exportertest.NewNopSettings(factory.Type()), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { _ = exp.Shutdown(context.Background()) })
+
+	return func(td ptrace.Traces) {
+		require.NoError(t, exp.ConsumeTraces(context.Background(), td))
+	}
+}
+
+func newFileExporterConfig(path, format string) *fileexporter.Config {
+	cfg := fileexporter.NewFactory().CreateDefaultConfig().(*fileexporter.Config)
+	cfg.Path = path
+	cfg.FormatType = format
+	return cfg
+}
+
+// readJSONLines decodes a file of OTLP/JSON requests, one per line.
+func readJSONLines(t *testing.T, path string) int {
+	t.Helper()
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var (
+		spans int
+		u     ptrace.JSONUnmarshaler
+	)
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 1024*1024), 8*1024*1024)
+	for scanner.Scan() {
+		td, err := u.UnmarshalTraces(scanner.Bytes())
+		require.NoError(t, err)
+		spans += td.SpanCount()
+	}
+	require.NoError(t, scanner.Err())
+	return spans
+}
+
+// readLengthPrefixed decodes a file written with format proto.
+func readLengthPrefixed(t *testing.T, path string) int {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var (
+		spans int
+		u     ptrace.ProtoUnmarshaler
+		r     = bytes.NewReader(data)
+	)
+	for {
+		var size uint32
+		if err := binary.Read(r, binary.BigEndian, &size); err == io.EOF {
+			break
+		} else {
+			require.NoError(t, err)
+		}
+		buf := make([]byte, size)
+		_, err := io.ReadFull(r, buf)
+		require.NoError(t, err)
+		td, err := u.UnmarshalTraces(buf)
+		require.NoError(t, err)
+		spans += td.SpanCount()
+	}
+	return spans
+}
+
+func TestFileArchive_JSONLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.json")
+	export := startFileExporter(t, newFileExporterConfig(path, "json"))
+
+	export(archiveTraces(3, "a"))
+	export(archiveTraces(2, "b"))
+
+	require.Eventually(t, func() bool {
+		data, err := os.ReadFile(path)
+		return err == nil && bytes.Count(data, []byte("\n")) == 2
+	}, 5*time.Second, 20*time.Millisecond, "each request is flushed as one line")
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(data, []byte(`{"resourceSpans":[`)), "lines are OTLP/JSON requests")
+	assert.Equal(t, 5, readJSONLines(t, path))
+}
+
+func TestFileArchive_ProtoLengthPrefixed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.pb")
+	cfg := newFileExporterConfig(path, "proto")
+	factory := fileexporter.NewFactory()
+	exp, err := factory.CreateTraces(context.Background(), exportertest.NewNopSettings(factory.Type()), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, exp.ConsumeTraces(context.Background(), archiveTraces(4, "a")))
+	require.NoError(t, exp.ConsumeTraces(context.Background(), archiveTraces(1, "b")))
+	require.NoError(t, exp.Shutdown(context.Background()), "shutdown flushes buffered data")
+
+	assert.Equal(t, 5, readLengthPrefixed(t, path))
+}
+
+func TestFileArchive_RotationNaming(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "traces.json")
+	cfg := newFileExporterConfig(path, "json")
+	cfg.Rotation = &fileexporter.Rotation{MaxMegabytes: 1, MaxBackups: 10}
+	factory := fileexporter.NewFactory()
+	exp, err := factory.CreateTraces(context.Background(), exportertest.NewNopSettings(factory.Type()), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+
+	// Each request is ~400KiB of JSON, so five of them cross the 1MiB limit
+	// at least once.
+	payload := strings.Repeat("x", 4096)
+	const requests, perRequest = 5, 100
+	for range requests {
+		require.NoError(t, exp.ConsumeTraces(context.Background(), archiveTraces(perRequest, payload)))
+	}
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	files, err := filepath.Glob(filepath.Join(dir, "traces*.json"))
+	require.NoError(t, err)
+	sort.Strings(files)
+	require.Greater(t, len(files), 1, "rotation produced backups")
+	assert.Contains(t, files, path, "active file keeps the configured name")
+
+	total := 0
+	for _, f := range files {
+		name := filepath.Base(f)
+		if f != path {
+			assert.Regexp(t, `^traces-\d{4}-\d{2}-\d{2}T\d{2}-\d{2}-\d{2}\.\d{3}(-[a-z]+)?\.json$`, name)
+		}
+		total += readJSONLines(t, f)
+	}
+	assert.Equal(t, requests*perRequest, total, "no data lost across rotated files")
+}
+
+func TestFileArchive_FlushInterval(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "traces.json")
+	cfg := newFileExporterConfig(path, "json")
+	cfg.FlushInterval = 50 * time.Millisecond
+	export := startFileExporter(t, cfg)
+
+	export(archiveTraces(1, "a"))
+
+	require.Eventually(t, func() bool {
+		data, err := os.ReadFile(path)
+		return err == nil && len(data) > 0 && data[len(data)-1] == '\n'
+	}, 5*time.Second, 20*time.Millisecond, "data reaches disk without shutdown")
+	assert.Equal(t, 1, readJSONLines(t, path))
+}
@@ -0,0 +1,177 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package components_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/prometheusreceiver"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+)
+
+// These tests pin that the prometheus receiver scrapes what its
+// scrape_configs describe and feeds the samples to the metrics pipeline:
+// static targets, relabel_configs and metric_relabel_configs, honor_labels
+// and a scrape interval per job.
+
+// exposition is served by the scraped target; env clashes with the target
+// label of the same name.
+const exposition = `# TYPE orders_total counter
+orders_total{env="exposed"} 42
+# TYPE debug_goroutines gauge
+debug_goroutines 7
+`
+
+// scrapeTarget serves exposition and counts the scrapes per path.
+type scrapeTarget struct {
+	mu   sync.Mutex
+	hits map[string]int
+}
+
+func (s *scrapeTarget) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	s.hits[r.URL.Path]++
+	s.mu.Unlock()
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	_, _ = w.Write([]byte(exposition))
+}
+
+func (s *scrapeTarget) scrapes(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.hits[path]
+}
+
+// scrapedPoints returns the number of data points of name by job, with the
+// attributes of the last one seen per job.
+func scrapedPoints(sink *consumertest.MetricsSink, name string) (map[string]int, map[string]pcommon.Map) {
+	counts := make(map[string]int)
+	attrs := make(map[string]pcommon.Map)
+	for _, md := range sink.AllMetrics() {
+		for i := 0; i < md.ResourceMetrics().Len(); i++ {
+			rm := md.ResourceMetrics().At(i)
+			job, _ := rm.Resource().Attributes().Get("service.name")
+			for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+				metrics := rm.ScopeMetrics().At(j).Metrics()
+				for k := 0; k < metrics.Len(); k++ {
+					m := metrics.At(k)
+					if m.Name() != name {
+						continue
+					}
+					var dps pmetric.NumberDataPointSlice
+					switch m.Type() {
+					case pmetric.MetricTypeSum:
+						dps = m.Sum().DataPoints()
+					case pmetric.MetricTypeGauge:
+						dps = m.Gauge().DataPoints()
+					default:
+						continue
+					}
+					for l := 0; l < dps.Len(); l++ {
+						counts[job.Str()]++
+						attrs[job.Str()] = dps.At(l).Attributes()
+					}
+				}
+			}
+		}
+	}
+	return counts, attrs
+}
+
+func TestPrometheusReceiver_ScrapeConfigs(t *testing.T) {
+	target := &scrapeTarget{hits: make(map[string]int)}
+	server := httptest.NewServer(target)
+	defer server.Close()
+	u, err := url.Parse(server.URL)
+	require.NoError(t, err)
+
+	job := func(name, path, interval string, extra map[string]any) map[string]any {
+		cfg := map[string]any{
+			"job_name":        name,
+			"metrics_path":    path,
+			"scrape_interval": interval,
+			"scrape_timeout":  interval,
+			"static_configs": []any{map[string]any{
+				"targets": []any{u.Host},
+				"labels":  map[string]any{"env": "static"},
+			}},
+		}
+		for k, v := range extra {
+			cfg[k] = v
+		}
+		return cfg
+	}
+	factory := prometheusreceiver.NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, confmap.NewFromStringMap(map[string]any{
+		"config": map[string]any{"scrape_configs": []any{
+			job("fast", "/fast", "100ms", map[string]any{"honor_labels": true}),
+			job("slow", "/slow", "500ms", map[string]any{
+				"relabel_configs": []any{map[string]any{
+					"target_label": "team",
+					"replacement":  "payments",
+				}},
+				"metric_relabel_configs": []any{map[string]any{
+					"source_labels": []any{"__name__"},
+					"regex":         "debug_.*",
+					"action":        "drop",
+				}},
+			}),
+		}},
+	}).Unmarshal(cfg))
+
+	sink := new(consumertest.MetricsSink)
+	rcv, err := factory.CreateMetrics(context.Background(), receivertest.NewNopSettings(factory.Type()), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, rcv.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, rcv.Shutdown(context.Background())) }()
+
+	require.Eventually(t, func() bool {
+		counts, _ := scrapedPoints(sink, "orders_total")
+		return counts["fast"] > 0 && counts["slow"] > 0
+	}, 15*time.Second, 50*time.Millisecond)
+	time.Sleep(time.Second)
+	assert.Greater(t, target.scrapes("/fast"), target.scrapes("/slow"), "each job scrapes at its own interval")
+
+	_, attrs := scrapedPoints(sink, "orders_total")
+	env, _ := attrs["fast"].Get("env")
+	assert.Equal(t, "exposed", env.Str(), "honor_labels keeps the exposed label")
+	_, relabeled := attrs["fast"].Get("team")
+	assert.False(t, relabeled, "relabel_configs apply per job")
+
+	env, _ = attrs["slow"].Get("env")
+	assert.Equal(t, "static", env.Str(), "the target label wins without honor_labels")
+	exported, _ := attrs["slow"].Get("exported_env")
+	assert.Equal(t, "exposed", exported.Str())
+	team, _ := attrs["slow"].Get("team")
+	assert.Equal(t, "payments", team.Str())
+
+	debug, _ := scrapedPoints(sink, "debug_goroutines")
+	assert.Positive(t, debug["fast"])
+	assert.Zero(t, debug["slow"], "metric_relabel_configs drop the metric")
+}
@@ -0,0 +1,195 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package components_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusremotewriteexporter"
+	"github.com/prometheus/prometheus/prompb"
+	"github.com/prometheus/prometheus/storage/remote"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// These tests pin the prometheusremotewrite exporter settings used to
+// dual-write metrics to Thanos or another remote-write endpoint next to the
+// tfo exporter: external labels on every series, retries of failed requests
+// from the write-ahead log, and requests split at max_batch_size_bytes.
+
+// remoteWriteServer records the remote-write requests it accepts, failing
+// the first failures attempts with 503.
+type remoteWriteServer struct {
+	mu       sync.Mutex
+	failures int
+	attempts int
+	requests []*prompb.WriteRequest
+}
+
+func (s *remoteWriteServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	req, err := remote.DecodeWriteRequest(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.attempts++
+	if s.attempts <= s.failures {
+		http.Error(w, "store unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	s.requests = append(s.requests, req)
+}
+
+// received returns the accepted requests and the number of attempts.
+func (s *remoteWriteServer) received() ([]*prompb.WriteRequest, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]*prompb.WriteRequest(nil), s.requests...), s.attempts
+}
+
+// series returns the time series of reqs by metric name.
+func series(reqs []*prompb.WriteRequest) map[string]prompb.TimeSeries {
+	out := make(map[string]prompb.TimeSeries)
+	for _, req := range reqs {
+		for _, ts := range req.Timeseries {
+			for _, l := range ts.Labels {
+				if l.Name == "__name__" {
+					out[l.Value] = ts
+				}
+			}
+		}
+	}
+	return out
+}
+
+func labelValue(ts prompb.TimeSeries, name string) string {
+	for _, l := range ts.Labels {
+		if l.Name == name {
+			return l.Value
+		}
+	}
+	return ""
+}
+
+// gauges returns n gauges named queue_depth_<i>.
+func gauges(n int) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "checkout")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	now := pcommon.NewTimestampFromTime(time.Now())
+	for i := range n {
+		m := sm.Metrics().AppendEmpty()
+		m.SetName(fmt.Sprintf("queue_depth_%d", i))
+		dp := m.SetEmptyGauge().DataPoints().AppendEmpty()
+		dp.SetTimestamp(now)
+		dp.SetIntValue(int64(i))
+		dp.Attributes().PutStr("queue", "orders")
+	}
+	return md
+}
+
+func startRemoteWrite(t *testing.T, settings map[string]any) func(pmetric.Metrics) error {
+	t.Helper()
+	factory := prometheusremotewriteexporter.NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	require.NoError(t, confmap.NewFromStringMap(settings).Unmarshal(cfg))
+	require.NoError(t, cfg.(interface{ Validate() error }).Validate())
+
+	exp, err := factory.CreateMetrics(context.Background(), exportertest.NewNopSettings(factory.Type()), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, exp.Shutdown(context.Background())) })
+	return func(md pmetric.Metrics) error { return exp.ConsumeMetrics(context.Background(), md) }
+}
+
+func TestPrometheusRemoteWrite_ExternalLabelsAndWALRetry(t *testing.T) {
+	thanos := &remoteWriteServer{failures: 2}
+	server := httptest.NewServer(thanos)
+	defer server.Close()
+
+	consume := startRemoteWrite(t, map[string]any{
+		"endpoint":        server.URL + "/api/v1/receive",
+		"external_labels": map[string]any{"replica": "tfo-0", "cluster": "prod"},
+		"retry_on_failure": map[string]any{
+			"initial_interval": "10ms",
+			"max_interval":     "50ms",
+		},
+		"wal": map[string]any{
+			"directory": t.TempDir(),
+			// The WAL sends once buffer_size requests were read; with more,
+			// a lone request waits for the next one.
+			"buffer_size": 1,
+		},
+	})
+	// With the WAL, the batch is accepted once persisted and sent from the
+	// log, so the outage does not fail the pipeline.
+	require.NoError(t, consume(gauges(3)))
+
+	require.Eventually(t, func() bool {
+		reqs, _ := thanos.received()
+		return len(series(reqs)) == 3
+	}, 10*time.Second, 20*time.Millisecond)
+
+	reqs, attempts := thanos.received()
+	assert.Equal(t, 3, attempts, "two failed attempts and the retry")
+	for name, ts := range series(reqs) {
+		assert.Equal(t, "tfo-0", labelValue(ts, "replica"), name)
+		assert.Equal(t, "prod", labelValue(ts, "cluster"), name)
+		assert.Equal(t, "orders", labelValue(ts, "queue"), name)
+		require.Len(t, ts.Samples, 1, name)
+	}
+}
+
+func TestPrometheusRemoteWrite_MaxBatchSizeBytes(t *testing.T) {
+	const limit = 1000
+	thanos := &remoteWriteServer{}
+	server := httptest.NewServer(thanos)
+	defer server.Close()
+
+	consume := startRemoteWrite(t, map[string]any{
+		"endpoint":             server.URL + "/api/v1/receive",
+		"max_batch_size_bytes": limit,
+	})
+	require.NoError(t, consume(gauges(100)))
+
+	require.Eventually(t, func() bool {
+		reqs, _ := thanos.received()
+		return len(series(reqs)) == 100
+	}, 10*time.Second, 20*time.Millisecond)
+	reqs, _ := thanos.received()
+	assert.Greater(t, len(reqs), 1, "split into several requests")
+	for _, req := range reqs {
+		size := 0
+		for _, ts := range req.Timeseries {
+			size += ts.Size()
+		}
+		assert.Less(t, size, limit)
+	}
+}
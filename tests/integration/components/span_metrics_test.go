@@ -0,0 +1,173 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package components_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// These tests run the span_metrics connector with the settings of the
+// shipped configuration, which feeds the metrics/span_metrics pipeline, and
+// pin the RED metrics it derives from traces: calls per status code, so
+// errors are the calls with status.code STATUS_CODE_ERROR, and a duration
+// histogram in milliseconds carrying the trace IDs as exemplars.
+
+// loadSpanMetricsConfig returns the span_metrics connector settings of
+// configs/tfo-collector.yaml.
+func loadSpanMetricsConfig(t *testing.T) *spanmetricsconnector.Config {
+	t.Helper()
+	conf, err := confmaptest.LoadConf(filepath.Join("..", "..", "..", "configs", "tfo-collector.yaml"))
+	require.NoError(t, err)
+	sub, err := conf.Sub("connectors::span_metrics")
+	require.NoError(t, err)
+
+	cfg := spanmetricsconnector.NewFactory().CreateDefaultConfig().(*spanmetricsconnector.Config)
+	require.NoError(t, sub.Unmarshal(cfg))
+	require.NoError(t, cfg.Validate())
+	return cfg
+}
+
+func redTraces() ptrace.Traces {
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "checkout")
+	ss := rs.ScopeSpans().AppendEmpty()
+	start := time.Now().Add(-time.Second)
+	for i, d := range []time.Duration{20 * time.Millisecond, 300 * time.Millisecond} {
+		span := ss.Spans().AppendEmpty()
+		span.SetName("GET /cart")
+		span.SetKind(ptrace.SpanKindServer)
+		span.SetTraceID(pcommon.TraceID([16]byte{0xca, 0xfe, byte(i)}))
+		span.SetSpanID(pcommon.SpanID([8]byte{0xbe, 0xef, byte(i)}))
+		span.SetStartTimestamp(pcommon.NewTimestampFromTime(start))
+		span.SetEndTimestamp(pcommon.NewTimestampFromTime(start.Add(d)))
+		span.Attributes().PutStr("http.route", "/cart")
+		if i == 1 {
+			span.Status().SetCode(ptrace.StatusCodeError)
+		}
+	}
+	return td
+}
+
+// metricsNamed returns the metrics of md with the given name.
+func metricsNamed(md pmetric.Metrics, name string) []pmetric.Metric {
+	var out []pmetric.Metric
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		sms := rms.At(i).ScopeMetrics()
+		for j := 0; j < sms.Len(); j++ {
+			ms := sms.At(j).Metrics()
+			for k := 0; k < ms.Len(); k++ {
+				if ms.At(k).Name() == name {
+					out = append(out, ms.At(k))
+				}
+			}
+		}
+	}
+	return out
+}
+
+// lastMetric returns the named metric of the last batch in sink.
+func lastMetric(sink *consumertest.MetricsSink, name string) (pmetric.Metric, bool) {
+	all := sink.AllMetrics()
+	if len(all) == 0 {
+		return pmetric.Metric{}, false
+	}
+	ms := metricsNamed(all[len(all)-1], name)
+	if len(ms) == 0 {
+		return pmetric.Metric{}, false
+	}
+	return ms[0], true
+}
+
+func TestSpanMetrics_ShippedConfigDerivesREDMetrics(t *testing.T) {
+	cfg := loadSpanMetricsConfig(t)
+	assert.Equal(t, "traces", cfg.Namespace)
+	assert.True(t, cfg.Exemplars.Enabled)
+	cfg.MetricsFlushInterval = 10 * time.Millisecond
+
+	sink := new(consumertest.MetricsSink)
+	factory := spanmetricsconnector.NewFactory()
+	conn, err := factory.CreateTracesToMetrics(context.Background(),
+		connectortest.NewNopSettings(factory.Type()), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, conn.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { _ = conn.Shutdown(context.Background()) })
+
+	require.NoError(t, conn.ConsumeTraces(context.Background(), redTraces()))
+
+	// Rate and errors: one call per status code. The first flush of the
+	// cumulative series reports them at zero.
+	calls := make(map[string]int64)
+	require.Eventually(t, func() bool {
+		m, ok := lastMetric(sink, "traces.calls")
+		if !ok {
+			return false
+		}
+		dps := m.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			code, _ := dps.At(i).Attributes().Get("status.code")
+			calls[code.AsString()] = dps.At(i).IntValue()
+		}
+		return calls["STATUS_CODE_UNSET"] > 0 && calls["STATUS_CODE_ERROR"] > 0
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, map[string]int64{"STATUS_CODE_UNSET": 1, "STATUS_CODE_ERROR": 1}, calls)
+
+	// Duration: a histogram over the configured buckets.
+	m, ok := lastMetric(sink, "traces.duration")
+	require.True(t, ok)
+	assert.Equal(t, "ms", m.Unit())
+	dps := m.Histogram().DataPoints()
+	require.Equal(t, 2, dps.Len())
+	var sum float64
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		assert.Len(t, dp.ExplicitBounds().AsRaw(), 12)
+		method, _ := dp.Attributes().Get("http.method")
+		assert.Equal(t, "GET", method.AsString(), "default dimension value")
+		route, _ := dp.Attributes().Get("http.route")
+		assert.Equal(t, "/cart", route.AsString())
+		sum += dp.Sum()
+	}
+	assert.InDelta(t, 320, sum, 0.001)
+
+	// Exemplars: each trace is attached once, to the flush after the span.
+	var exemplars []pcommon.TraceID
+	for _, md := range sink.AllMetrics() {
+		for _, m := range metricsNamed(md, "traces.duration") {
+			hdps := m.Histogram().DataPoints()
+			for i := 0; i < hdps.Len(); i++ {
+				for j := 0; j < hdps.At(i).Exemplars().Len(); j++ {
+					exemplars = append(exemplars, hdps.At(i).Exemplars().At(j).TraceID())
+				}
+			}
+		}
+	}
+	assert.ElementsMatch(t, []pcommon.TraceID{{0xca, 0xfe, 0}, {0xca, 0xfe, 1}}, exemplars)
+}
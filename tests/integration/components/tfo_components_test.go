@@ -23,12 +23,12 @@ import (
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configopaque"
-	"go.opentelemetry.io/collector/consumer/consumertest"
 	"go.opentelemetry.io/collector/extension/extensiontest"
 	"go.opentelemetry.io/collector/receiver/receivertest"
 
 	"github.com/telemetryflow/telemetryflow-collector/components/extension/tfoauthextension"
 	"github.com/telemetryflow/telemetryflow-collector/components/extension/tfoidentityextension"
+	"github.com/telemetryflow/telemetryflow-collector/components/tfocaptureexporter"
 	"github.com/telemetryflow/telemetryflow-collector/components/tfootlpreceiver"
 )
 
@@ -65,8 +65,8 @@ func TestTFOComponentsIntegration(t *testing.T) {
 	receiverCfg.Protocols.HTTP = nil
 
 	receiverSet := receivertest.NewNopSettings(component.MustNewType("tfootlp"))
-	sink := new(consumertest.TracesSink)
-	receiver, err := receiverFactory.CreateTraces(ctx, receiverSet, receiverCfg, sink)
+	capture := tfocaptureexporter.New(1000)
+	receiver, err := receiverFactory.CreateTraces(ctx, receiverSet, receiverCfg, capture)
 	require.NoError(t, err)
 
 	// Start all components
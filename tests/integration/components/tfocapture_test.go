@@ -0,0 +1,77 @@
+//go:build tfotest
+
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package components_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfocaptureexporter"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/collector"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/registry"
+)
+
+// TestTFOCaptureExporterRegistered verifies that tfotest builds add the
+// capture exporter to the default component set.
+func TestTFOCaptureExporterRegistered(t *testing.T) {
+	factories, err := registry.Default().Factories()
+	require.NoError(t, err)
+	assert.Contains(t, factories.Exporters, component.MustNewType("tfocapture"))
+}
+
+// TestTFOCaptureExporterPipeline runs an embedded collector exporting to
+// tfocapture and inspects the captured spans.
+func TestTFOCaptureExporterPipeline(t *testing.T) {
+	id := component.MustNewIDWithName("tfocapture", "integration")
+	capture := tfocaptureexporter.Get(id)
+	capture.Reset()
+
+	col, err := collector.New(collector.Options{
+		Exporters: map[component.ID]collector.ComponentConfig{
+			id: {"max_items": 10},
+		},
+		Pipelines: map[pipeline.ID]collector.Pipeline{
+			pipeline.NewID(pipeline.SignalTraces): {
+				Receivers: []component.ID{collector.SourceID("app")},
+				Exporters: []component.ID{id},
+			},
+		},
+		Sources: []string{"app"},
+	})
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, col.Start(ctx))
+	defer func() { assert.NoError(t, col.Shutdown(context.Background())) }()
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("checkout")
+	require.NoError(t, col.Source("app").ConsumeTraces(ctx, td))
+
+	require.NoError(t, capture.Wait(ctx, func(c *tfocaptureexporter.Capture) bool {
+		return c.SpanCount() == 1
+	}))
+	assert.Len(t, capture.SpansByName("checkout"), 1)
+}
@@ -0,0 +1,147 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoalertconnector_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfoalertconnector"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	rule := func(expr string) tfoalertconnector.RuleConfig {
+		return tfoalertconnector.RuleConfig{Name: "r", Expr: expr}
+	}
+
+	tests := []struct {
+		name    string
+		config  tfoalertconnector.Config
+		wantErr string
+	}{
+		{
+			name: "valid expressions",
+			config: tfoalertconnector.Config{EvaluationInterval: time.Second, Rules: []tfoalertconnector.RuleConfig{
+				{Name: "a", Expr: "system.cpu.utilization > 0.9"},
+				{Name: "b", Expr: "rate(http.server.errors[1m]) >= 5"},
+				{Name: "c", Expr: "avg(queue_size[30s]) < 10", Severity: tfoalertconnector.SeverityError},
+				{Name: "d", Expr: "absent(heartbeat[2m])", For: time.Minute},
+				{Name: "e", Expr: "max( latency [5m] ) != -1.5e3"},
+			}},
+		},
+		{
+			name:    "zero evaluation interval",
+			config:  tfoalertconnector.Config{Rules: []tfoalertconnector.RuleConfig{rule("x > 1")}},
+			wantErr: "evaluation_interval",
+		},
+		{
+			name:    "no rules",
+			config:  tfoalertconnector.Config{EvaluationInterval: time.Second},
+			wantErr: "at least one rule",
+		},
+		{
+			name:    "missing name",
+			config:  tfoalertconnector.Config{EvaluationInterval: time.Second, Rules: []tfoalertconnector.RuleConfig{{Expr: "x > 1"}}},
+			wantErr: "name is required",
+		},
+		{
+			name: "duplicate name",
+			config: tfoalertconnector.Config{EvaluationInterval: time.Second, Rules: []tfoalertconnector.RuleConfig{
+				rule("x > 1"), rule("y > 1"),
+			}},
+			wantErr: "duplicate rule name",
+		},
+		{
+			name:    "missing expr",
+			config:  tfoalertconnector.Config{EvaluationInterval: time.Second, Rules: []tfoalertconnector.RuleConfig{rule("")}},
+			wantErr: "expr is required",
+		},
+		{
+			name:    "unknown function",
+			config:  tfoalertconnector.Config{EvaluationInterval: time.Second, Rules: []tfoalertconnector.RuleConfig{rule("p99(x[1m]) > 1")}},
+			wantErr: "unknown function",
+		},
+		{
+			name:    "rate without window",
+			config:  tfoalertconnector.Config{EvaluationInterval: time.Second, Rules: []tfoalertconnector.RuleConfig{rule("rate(x) > 1")}},
+			wantErr: "requires a window",
+		},
+		{
+			name:    "invalid window",
+			config:  tfoalertconnector.Config{EvaluationInterval: time.Second, Rules: []tfoalertconnector.RuleConfig{rule("avg(x[soon]) > 1")}},
+			wantErr: "invalid window",
+		},
+		{
+			name:    "missing comparison",
+			config:  tfoalertconnector.Config{EvaluationInterval: time.Second, Rules: []tfoalertconnector.RuleConfig{rule("avg(x[1m])")}},
+			wantErr: "missing comparison",
+		},
+		{
+			name:    "absent with comparison",
+			config:  tfoalertconnector.Config{EvaluationInterval: time.Second, Rules: []tfoalertconnector.RuleConfig{rule("absent(x[1m]) > 0")}},
+			wantErr: "absent takes no comparison",
+		},
+		{
+			name:    "invalid threshold",
+			config:  tfoalertconnector.Config{EvaluationInterval: time.Second, Rules: []tfoalertconnector.RuleConfig{rule("x > high")}},
+			wantErr: "invalid threshold",
+		},
+		{
+			name:    "garbage",
+			config:  tfoalertconnector.Config{EvaluationInterval: time.Second, Rules: []tfoalertconnector.RuleConfig{rule("x >> 1")}},
+			wantErr: "invalid expr",
+		},
+		{
+			name: "negative for",
+			config: tfoalertconnector.Config{EvaluationInterval: time.Second, Rules: []tfoalertconnector.RuleConfig{
+				{Name: "r", Expr: "x > 1", For: -time.Second},
+			}},
+			wantErr: "for must not be negative",
+		},
+		{
+			name: "unknown severity",
+			config: tfoalertconnector.Config{EvaluationInterval: time.Second, Rules: []tfoalertconnector.RuleConfig{
+				{Name: "r", Expr: "x > 1", Severity: "critical"},
+			}},
+			wantErr: "severity must be",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := tfoalertconnector.NewFactory()
+	assert.Equal(t, component.MustNewType("tfoalert"), factory.Type())
+
+	cfg := factory.CreateDefaultConfig().(*tfoalertconnector.Config)
+	assert.Equal(t, 15*time.Second, cfg.EvaluationInterval)
+	assert.Empty(t, cfg.Rules)
+	assert.Error(t, cfg.Validate(), "rules must be configured")
+}
@@ -0,0 +1,276 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoalertconnector_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfoalertconnector"
+)
+
+const (
+	waitFor = 2 * time.Second
+	tick    = 5 * time.Millisecond
+)
+
+type harness struct {
+	conn connector.Metrics
+	sink *consumertest.LogsSink
+}
+
+func newHarness(t *testing.T, rules ...tfoalertconnector.RuleConfig) *harness {
+	t.Helper()
+	factory := tfoalertconnector.NewFactory()
+	cfg := factory.CreateDefaultConfig().(*tfoalertconnector.Config)
+	cfg.EvaluationInterval = 10 * time.Millisecond
+	cfg.Rules = rules
+	require.NoError(t, cfg.Validate())
+
+	h := &harness{sink: new(consumertest.LogsSink)}
+	var err error
+	h.conn, err = factory.CreateMetricsToLogs(context.Background(),
+		connectortest.NewNopSettings(factory.Type()), cfg, h.sink)
+	require.NoError(t, err)
+	require.NoError(t, h.conn.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, h.conn.Shutdown(context.Background())) })
+	return h
+}
+
+// point is a single number data point.
+type point struct {
+	host  string
+	at    time.Duration
+	value float64
+}
+
+var base = time.Now()
+
+func gauge(name string, points ...point) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	for _, p := range points {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().PutStr("host.name", p.host)
+		m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+		m.SetName(name)
+		dp := m.SetEmptyGauge().DataPoints().AppendEmpty()
+		dp.SetTimestamp(pcommon.NewTimestampFromTime(base.Add(p.at)))
+		dp.SetDoubleValue(p.value)
+	}
+	return md
+}
+
+func sum(name string, temporality pmetric.AggregationTemporality, points ...point) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName(name)
+	s := m.SetEmptySum()
+	s.SetIsMonotonic(true)
+	s.SetAggregationTemporality(temporality)
+	for _, p := range points {
+		dp := s.DataPoints().AppendEmpty()
+		dp.SetTimestamp(pcommon.NewTimestampFromTime(base.Add(p.at)))
+		dp.SetIntValue(int64(p.value))
+	}
+	return md
+}
+
+// alerts returns all alert records received so far.
+func (h *harness) alerts() []plog.LogRecord {
+	var out []plog.LogRecord
+	for _, ld := range h.sink.AllLogs() {
+		rls := ld.ResourceLogs()
+		for i := 0; i < rls.Len(); i++ {
+			sls := rls.At(i).ScopeLogs()
+			for j := 0; j < sls.Len(); j++ {
+				lrs := sls.At(j).LogRecords()
+				for k := 0; k < lrs.Len(); k++ {
+					out = append(out, lrs.At(k))
+				}
+			}
+		}
+	}
+	return out
+}
+
+func (h *harness) waitForAlerts(t *testing.T, n int) []plog.LogRecord {
+	t.Helper()
+	require.Eventually(t, func() bool { return len(h.alerts()) >= n }, waitFor, tick)
+	return h.alerts()
+}
+
+func attr(lr plog.LogRecord, key string) string {
+	v, ok := lr.Attributes().Get(key)
+	if !ok {
+		return ""
+	}
+	return v.AsString()
+}
+
+func TestConnector_ThresholdFiresAndResolves(t *testing.T) {
+	h := newHarness(t, tfoalertconnector.RuleConfig{
+		Name:        "cpu_high",
+		Expr:        "system.cpu.utilization > 0.9",
+		Severity:    tfoalertconnector.SeverityError,
+		Description: "CPU above 90%",
+	})
+	ctx := context.Background()
+
+	require.NoError(t, h.conn.ConsumeMetrics(ctx, gauge("system.cpu.utilization", point{host: "a", value: 0.95})))
+	firing := h.waitForAlerts(t, 1)[0]
+
+	assert.Equal(t, tfoalertconnector.EventName, firing.EventName())
+	assert.Equal(t, plog.SeverityNumberError, firing.SeverityNumber())
+	assert.Equal(t, "cpu_high firing: system.cpu.utilization > 0.9", firing.Body().Str())
+	assert.Equal(t, "cpu_high", attr(firing, "alert.name"))
+	assert.Equal(t, "firing", attr(firing, "alert.state"))
+	assert.Equal(t, "error", attr(firing, "alert.severity"))
+	assert.Equal(t, "CPU above 90%", attr(firing, "alert.description"))
+	assert.Equal(t, "a", attr(firing, "host.name"))
+	value, _ := firing.Attributes().Get("alert.value")
+	assert.InDelta(t, 0.95, value.Double(), 1e-9)
+
+	require.NoError(t, h.conn.ConsumeMetrics(ctx, gauge("system.cpu.utilization", point{host: "a", at: time.Second, value: 0.5})))
+	resolved := h.waitForAlerts(t, 2)[1]
+	assert.Equal(t, "resolved", attr(resolved, "alert.state"))
+	assert.Equal(t, plog.SeverityNumberInfo, resolved.SeverityNumber())
+
+	assert.Len(t, h.alerts(), 2, "a firing alert is reported once")
+}
+
+func TestConnector_PerSeries(t *testing.T) {
+	h := newHarness(t, tfoalertconnector.RuleConfig{Name: "cpu_high", Expr: "system.cpu.utilization > 0.9"})
+
+	require.NoError(t, h.conn.ConsumeMetrics(context.Background(), gauge("system.cpu.utilization",
+		point{host: "a", value: 0.95},
+		point{host: "b", value: 0.20},
+		point{host: "c", value: 0.99},
+	)))
+
+	alerts := h.waitForAlerts(t, 2)
+	hosts := []string{attr(alerts[0], "host.name"), attr(alerts[1], "host.name")}
+	assert.ElementsMatch(t, []string{"a", "c"}, hosts)
+}
+
+func TestConnector_AttributeFilter(t *testing.T) {
+	h := newHarness(t, tfoalertconnector.RuleConfig{
+		Name:       "cpu_high_b",
+		Expr:       "system.cpu.utilization > 0.9",
+		Attributes: map[string]string{"host.name": "b"},
+	})
+
+	require.NoError(t, h.conn.ConsumeMetrics(context.Background(), gauge("system.cpu.utilization",
+		point{host: "a", value: 0.95},
+		point{host: "b", value: 0.97},
+	)))
+
+	alerts := h.waitForAlerts(t, 1)
+	assert.Equal(t, "b", attr(alerts[0], "host.name"))
+	assert.Never(t, func() bool { return len(h.alerts()) > 1 }, 100*time.Millisecond, tick)
+}
+
+func TestConnector_RateOverWindow(t *testing.T) {
+	h := newHarness(t, tfoalertconnector.RuleConfig{Name: "errors", Expr: "rate(http.server.errors[1m]) > 5"})
+	ctx := context.Background()
+
+	// 40 errors in 10s, then a counter reset followed by 30 more: 7/s.
+	require.NoError(t, h.conn.ConsumeMetrics(ctx, sum("http.server.errors", pmetric.AggregationTemporalityCumulative,
+		point{at: 0, value: 100},
+		point{at: 5 * time.Second, value: 140},
+		point{at: 10 * time.Second, value: 30},
+	)))
+
+	alerts := h.waitForAlerts(t, 1)
+	value, _ := alerts[0].Attributes().Get("alert.value")
+	assert.InDelta(t, 7.0, value.Double(), 1e-9)
+}
+
+func TestConnector_RateSlidesOutOfWindow(t *testing.T) {
+	h := newHarness(t, tfoalertconnector.RuleConfig{Name: "errors", Expr: "rate(http.server.errors[10s]) > 5"})
+	ctx := context.Background()
+
+	require.NoError(t, h.conn.ConsumeMetrics(ctx, sum("http.server.errors", pmetric.AggregationTemporalityCumulative,
+		point{at: 0, value: 0},
+		point{at: 10 * time.Second, value: 100},
+	)))
+	h.waitForAlerts(t, 1)
+
+	// The burst leaves the window; the counter has been flat since.
+	require.NoError(t, h.conn.ConsumeMetrics(ctx, sum("http.server.errors", pmetric.AggregationTemporalityCumulative,
+		point{at: 20 * time.Second, value: 100},
+		point{at: 25 * time.Second, value: 100},
+	)))
+	alerts := h.waitForAlerts(t, 2)
+	assert.Equal(t, "resolved", attr(alerts[1], "alert.state"))
+}
+
+func TestConnector_DeltaRate(t *testing.T) {
+	h := newHarness(t, tfoalertconnector.RuleConfig{Name: "errors", Expr: "rate(http.server.errors[10s]) >= 3"})
+
+	require.NoError(t, h.conn.ConsumeMetrics(context.Background(), sum("http.server.errors", pmetric.AggregationTemporalityDelta,
+		point{at: 0, value: 10},
+		point{at: 5 * time.Second, value: 20},
+	)))
+
+	alerts := h.waitForAlerts(t, 1)
+	value, _ := alerts[0].Attributes().Get("alert.value")
+	assert.InDelta(t, 3.0, value.Double(), 1e-9)
+}
+
+func TestConnector_ForDuration(t *testing.T) {
+	h := newHarness(t, tfoalertconnector.RuleConfig{Name: "cpu_high", Expr: "system.cpu.utilization > 0.9", For: time.Hour})
+
+	require.NoError(t, h.conn.ConsumeMetrics(context.Background(), gauge("system.cpu.utilization", point{host: "a", value: 0.95})))
+	assert.Never(t, func() bool { return len(h.alerts()) > 0 }, 100*time.Millisecond, tick)
+}
+
+func TestConnector_Absent(t *testing.T) {
+	h := newHarness(t, tfoalertconnector.RuleConfig{
+		Name:       "heartbeat_missing",
+		Expr:       "absent(tfo.agent.heartbeat[50ms])",
+		Attributes: map[string]string{"host.name": "a"},
+		Severity:   tfoalertconnector.SeverityFatal,
+	})
+
+	firing := h.waitForAlerts(t, 1)[0]
+	assert.Equal(t, "firing", attr(firing, "alert.state"))
+	assert.Equal(t, plog.SeverityNumberFatal, firing.SeverityNumber())
+	assert.Equal(t, "a", attr(firing, "host.name"))
+
+	// Data for another host does not satisfy the rule.
+	require.NoError(t, h.conn.ConsumeMetrics(context.Background(), gauge("tfo.agent.heartbeat", point{host: "b", value: 1})))
+	require.NoError(t, h.conn.ConsumeMetrics(context.Background(), gauge("tfo.agent.heartbeat", point{host: "a", value: 1})))
+	resolved := h.waitForAlerts(t, 2)[1]
+	assert.Equal(t, "resolved", attr(resolved, "alert.state"))
+}
+
+func TestConnector_IgnoresUnreferencedMetrics(t *testing.T) {
+	h := newHarness(t, tfoalertconnector.RuleConfig{Name: "cpu_high", Expr: "system.cpu.utilization > 0.9"})
+
+	require.NoError(t, h.conn.ConsumeMetrics(context.Background(), gauge("system.memory.utilization", point{host: "a", value: 0.99})))
+	assert.Never(t, func() bool { return len(h.alerts()) > 0 }, 100*time.Millisecond, tick)
+	assert.False(t, h.conn.Capabilities().MutatesData)
+}
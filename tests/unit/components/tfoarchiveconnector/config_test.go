@@ -0,0 +1,87 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoarchiveconnector_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pipeline"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfoarchiveconnector"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	sampled := pipeline.NewIDWithName(pipeline.SignalTraces, "sampled")
+	archive := pipeline.NewIDWithName(pipeline.SignalTraces, "archive")
+
+	tests := []struct {
+		name    string
+		mutate  func(cfg *tfoarchiveconnector.Config)
+		wantErr string
+	}{
+		{name: "defaults", mutate: func(*tfoarchiveconnector.Config) {}},
+		{
+			name:    "no sampled pipeline",
+			mutate:  func(cfg *tfoarchiveconnector.Config) { cfg.Sampled = nil },
+			wantErr: "sampled must list at least one pipeline",
+		},
+		{
+			name:    "no archive pipeline",
+			mutate:  func(cfg *tfoarchiveconnector.Config) { cfg.Archive = nil },
+			wantErr: "archive must list at least one pipeline",
+		},
+		{
+			name:    "pipeline in both lists",
+			mutate:  func(cfg *tfoarchiveconnector.Config) { cfg.Archive = append(cfg.Archive, sampled) },
+			wantErr: "listed as both sampled and archive",
+		},
+		{
+			name:    "percentage over 100",
+			mutate:  func(cfg *tfoarchiveconnector.Config) { cfg.Percentage = 150 },
+			wantErr: "percentage must be between 0 and 100",
+		},
+		{
+			name:    "zero decision wait",
+			mutate:  func(cfg *tfoarchiveconnector.Config) { cfg.DecisionWait = 0 },
+			wantErr: "decision_wait must be positive",
+		},
+		{
+			name:    "zero max traces",
+			mutate:  func(cfg *tfoarchiveconnector.Config) { cfg.MaxTraces = 0 },
+			wantErr: "max_traces must be positive",
+		},
+		{
+			name:    "negative retention",
+			mutate:  func(cfg *tfoarchiveconnector.Config) { cfg.Retention = -time.Hour },
+			wantErr: "retention must not be negative",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tfoarchiveconnector.NewFactory().CreateDefaultConfig().(*tfoarchiveconnector.Config)
+			cfg.Sampled = []pipeline.ID{sampled}
+			cfg.Archive = []pipeline.ID{archive}
+			tt.mutate(cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,225 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoarchiveconnector_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfoarchiveconnector"
+	"github.com/telemetryflow/telemetryflow-collector/components/tfosampledprocessor"
+)
+
+const (
+	waitFor = 2 * time.Second
+	tick    = 5 * time.Millisecond
+)
+
+var (
+	sampledPipeline = pipeline.NewIDWithName(pipeline.SignalTraces, "sampled")
+	archivePipeline = pipeline.NewIDWithName(pipeline.SignalTraces, "archive")
+
+	// harnesses gives each connector its own ID, and so its own decisions.
+	harnesses atomic.Int64
+)
+
+type harness struct {
+	conn    connector.Traces
+	tel     *componenttest.Telemetry
+	kept    *consumertest.TracesSink
+	archive *consumertest.TracesSink
+}
+
+// newHarness creates an archive connector whose sampled pipeline runs a
+// sampler keeping the traces with an odd first trace ID byte, followed by
+// a tfosampled processor.
+func newHarness(t *testing.T, mutate func(cfg *tfoarchiveconnector.Config)) *harness {
+	t.Helper()
+	factory := tfoarchiveconnector.NewFactory()
+	id := component.MustNewIDWithName(factory.Type().String(), fmt.Sprintf("test%d", harnesses.Add(1)))
+	cfg := factory.CreateDefaultConfig().(*tfoarchiveconnector.Config)
+	cfg.Sampled = []pipeline.ID{sampledPipeline}
+	cfg.Archive = []pipeline.ID{archivePipeline}
+	cfg.DecisionWait = 50 * time.Millisecond
+	mutate(cfg)
+	require.NoError(t, cfg.Validate())
+
+	h := &harness{
+		tel:     componenttest.NewTelemetry(),
+		kept:    new(consumertest.TracesSink),
+		archive: new(consumertest.TracesSink),
+	}
+	t.Cleanup(func() { _ = h.tel.Shutdown(context.Background()) })
+
+	pFactory := tfosampledprocessor.NewFactory()
+	pCfg := pFactory.CreateDefaultConfig().(*tfosampledprocessor.Config)
+	pCfg.Archive = id
+	recorder, err := pFactory.CreateTraces(context.Background(), processortest.NewNopSettings(pFactory.Type()), pCfg, h.kept)
+	require.NoError(t, err)
+	sampler, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		td.ResourceSpans().RemoveIf(func(rs ptrace.ResourceSpans) bool {
+			return rs.ScopeSpans().At(0).Spans().At(0).TraceID()[0]%2 == 0
+		})
+		return recorder.ConsumeTraces(ctx, td)
+	})
+	require.NoError(t, err)
+
+	set := connectortest.NewNopSettings(factory.Type())
+	set.ID = id
+	set.TelemetrySettings = h.tel.NewTelemetrySettings()
+	router := connector.NewTracesRouter(map[pipeline.ID]consumer.Traces{sampledPipeline: sampler, archivePipeline: h.archive})
+	h.conn, err = factory.CreateTracesToTraces(context.Background(), set, cfg, router)
+	require.NoError(t, err)
+	require.NoError(t, h.conn.Start(context.Background(), componenttest.NewNopHost()))
+	return h
+}
+
+// traces returns the tfo_archive_traces count for outcome.
+func (h *harness) traces(outcome string) int64 {
+	m, err := h.tel.GetMetric("tfo_archive_traces")
+	if err != nil {
+		return 0
+	}
+	for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+		o, _ := dp.Attributes.Value(attribute.Key("outcome"))
+		if o.AsString() == outcome {
+			return dp.Value
+		}
+	}
+	return 0
+}
+
+// batch returns one resource per trace, holding one span of each trace.
+func batch(traces ...byte) ptrace.Traces {
+	td := ptrace.NewTraces()
+	for _, id := range traces {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr("service.name", "checkout")
+		span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+		span.SetTraceID(pcommon.TraceID([16]byte{id}))
+		span.SetName("span")
+	}
+	return td
+}
+
+// traceIDs returns the first trace ID byte of every span in the sink.
+func traceIDs(sink *consumertest.TracesSink) []byte {
+	var ids []byte
+	for _, td := range sink.AllTraces() {
+		rss := td.ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			sss := rss.At(i).ScopeSpans()
+			for j := 0; j < sss.Len(); j++ {
+				spans := sss.At(j).Spans()
+				for k := 0; k < spans.Len(); k++ {
+					ids = append(ids, spans.At(k).TraceID()[0])
+				}
+			}
+		}
+	}
+	return ids
+}
+
+func TestArchive_ArchivesSampledOutTraces(t *testing.T) {
+	h := newHarness(t, func(cfg *tfoarchiveconnector.Config) { cfg.Retention = 72 * time.Hour })
+	t.Cleanup(func() { require.NoError(t, h.conn.Shutdown(context.Background())) })
+
+	require.NoError(t, h.conn.ConsumeTraces(context.Background(), batch(1, 2)))
+	require.NoError(t, h.conn.ConsumeTraces(context.Background(), batch(1, 2)))
+	assert.Equal(t, []byte{1, 1}, traceIDs(h.kept))
+
+	require.Eventually(t, func() bool { return h.traces("archived") == 1 }, waitFor, tick)
+	assert.Equal(t, []byte{2, 2}, traceIDs(h.archive))
+	assert.Equal(t, int64(1), h.traces("kept"))
+
+	rs := h.archive.AllTraces()[0].ResourceSpans().At(0)
+	service, _ := rs.Resource().Attributes().Get("service.name")
+	assert.Equal(t, "checkout", service.Str())
+	retention, ok := rs.Resource().Attributes().Get("tfo.archive.retention")
+	require.True(t, ok)
+	assert.Equal(t, "72h0m0s", retention.Str())
+}
+
+func TestArchive_ZeroPercentage(t *testing.T) {
+	h := newHarness(t, func(cfg *tfoarchiveconnector.Config) { cfg.Percentage = 0 })
+
+	require.NoError(t, h.conn.ConsumeTraces(context.Background(), batch(1, 2, 4)))
+	require.NoError(t, h.conn.Shutdown(context.Background()))
+
+	assert.Equal(t, []byte{1}, traceIDs(h.kept))
+	assert.Zero(t, h.archive.SpanCount())
+}
+
+func TestArchive_BoundsHeldTraces(t *testing.T) {
+	h := newHarness(t, func(cfg *tfoarchiveconnector.Config) { cfg.MaxTraces = 1 })
+	t.Cleanup(func() { require.NoError(t, h.conn.Shutdown(context.Background())) })
+
+	require.NoError(t, h.conn.ConsumeTraces(context.Background(), batch(2, 4)))
+
+	require.Eventually(t, func() bool { return h.traces("archived") == 1 }, waitFor, tick)
+	assert.Equal(t, []byte{2}, traceIDs(h.archive))
+	assert.Equal(t, int64(1), h.traces("dropped"))
+}
+
+func TestArchive_ShutdownArchivesHeldTraces(t *testing.T) {
+	h := newHarness(t, func(cfg *tfoarchiveconnector.Config) { cfg.DecisionWait = time.Hour })
+
+	require.NoError(t, h.conn.ConsumeTraces(context.Background(), batch(1, 2)))
+	assert.Zero(t, h.archive.SpanCount())
+	require.NoError(t, h.conn.Shutdown(context.Background()))
+
+	assert.Equal(t, []byte{2}, traceIDs(h.archive))
+	assert.Equal(t, int64(1), h.traces("kept"))
+}
+
+func TestArchive_ReturnsSampledOutcome(t *testing.T) {
+	factory := tfoarchiveconnector.NewFactory()
+	cfg := factory.CreateDefaultConfig().(*tfoarchiveconnector.Config)
+	cfg.Sampled = []pipeline.ID{sampledPipeline}
+	cfg.Archive = []pipeline.ID{archivePipeline}
+	archive := new(consumertest.TracesSink)
+	router := connector.NewTracesRouter(map[pipeline.ID]consumer.Traces{
+		sampledPipeline: consumertest.NewErr(errors.New("backend down")),
+		archivePipeline: archive,
+	})
+	set := connectortest.NewNopSettings(factory.Type())
+	set.ID = component.MustNewIDWithName(factory.Type().String(), "failing")
+	conn, err := factory.CreateTracesToTraces(context.Background(), set, cfg, router)
+	require.NoError(t, err)
+	require.NoError(t, conn.Start(context.Background(), componenttest.NewNopHost()))
+
+	assert.EqualError(t, conn.ConsumeTraces(context.Background(), batch(2)), "backend down")
+	require.NoError(t, conn.Shutdown(context.Background()))
+	assert.Equal(t, []byte{2}, traceIDs(archive), "traces the sampled pipelines refused are not kept")
+}
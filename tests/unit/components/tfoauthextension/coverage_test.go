@@ -81,8 +81,8 @@ func TestExtension_PassthroughStart(t *testing.T) {
 }
 
 func TestMaskAPIKey_ShortKey(t *testing.T) {
-	// A short key (<=8 chars) takes the "****" branch inside maskAPIKey.
-	// We exercise maskAPIKey indirectly through Start's info log.
+	// A short key (<=8 chars) takes the "****" branch inside MaskAPIKey.
+	// We exercise MaskAPIKey indirectly through Start's info log.
 	cfg := tfoauthextension.NewFactory().CreateDefaultConfig().(*tfoauthextension.Config)
 	cfg.APIKeyID = configopaque.String("tfk_ab") // 6 chars — short
 	cfg.APIKeySecret = configopaque.String("tfs_ab")
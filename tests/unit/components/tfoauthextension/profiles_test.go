@@ -0,0 +1,148 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tfoauthextension_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/extension/tfoauthextension"
+)
+
+// profileProvider is the interface the tfo exporter expects.
+type profileProvider interface {
+	GetProfileAttribute() string
+	GetProfileCredentials(value string) (profile, keyID, keySecret string, ok bool)
+}
+
+func profilesConfig() *tfoauthextension.Config {
+	return &tfoauthextension.Config{
+		APIKeyID:         "tfk_default",
+		APIKeySecret:     "tfs_default",
+		ProfileAttribute: "team",
+		Profiles: map[string]tfoauthextension.Profile{
+			"payments": {APIKeyID: "tfk_payments", APIKeySecret: "tfs_payments", Values: []string{"payments", "billing"}},
+			"search":   {APIKeyID: "tfk_search", APIKeySecret: "tfs_search"},
+		},
+	}
+}
+
+func TestConfig_ValidateProfiles(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *tfoauthextension.Config)
+		wantErr string
+	}{
+		{name: "valid", mutate: func(*tfoauthextension.Config) {}},
+		{name: "passthrough default key", mutate: func(cfg *tfoauthextension.Config) {
+			cfg.APIKeyID, cfg.APIKeySecret = "", ""
+		}},
+		{name: "missing attribute", mutate: func(cfg *tfoauthextension.Config) {
+			cfg.ProfileAttribute = ""
+		}, wantErr: "profile_attribute is required"},
+		{name: "reserved name", mutate: func(cfg *tfoauthextension.Config) {
+			cfg.Profiles["default"] = tfoauthextension.Profile{APIKeyID: "tfk_x", APIKeySecret: "tfs_x"}
+		}, wantErr: `"default" is reserved`},
+		{name: "bad key id", mutate: func(cfg *tfoauthextension.Config) {
+			cfg.Profiles["search"] = tfoauthextension.Profile{APIKeyID: "key", APIKeySecret: "tfs_x"}
+		}, wantErr: "profiles.search.api_key_id"},
+		{name: "bad key secret in passthrough", mutate: func(cfg *tfoauthextension.Config) {
+			cfg.APIKeyID, cfg.APIKeySecret = "", ""
+			cfg.Profiles["search"] = tfoauthextension.Profile{APIKeyID: "tfk_x", APIKeySecret: "secret"}
+		}, wantErr: "profiles.search.api_key_secret"},
+		{name: "value claimed twice", mutate: func(cfg *tfoauthextension.Config) {
+			cfg.Profiles["search"] = tfoauthextension.Profile{APIKeyID: "tfk_x", APIKeySecret: "tfs_x", Values: []string{"billing"}}
+		}, wantErr: `value "billing" selects both "payments" and "search"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := profilesConfig()
+			tt.mutate(cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestExtension_ProfileCredentials(t *testing.T) {
+	ext, err := tfoauthextension.NewFactory().Create(context.Background(),
+		extensiontest.NewNopSettings(component.MustNewType("tfoauth")), profilesConfig())
+	require.NoError(t, err)
+	provider, ok := ext.(profileProvider)
+	require.True(t, ok)
+
+	assert.Equal(t, "team", provider.GetProfileAttribute())
+
+	profile, keyID, keySecret, ok := provider.GetProfileCredentials("billing")
+	require.True(t, ok)
+	assert.Equal(t, []string{"payments", "tfk_payments", "tfs_payments"}, []string{profile, keyID, keySecret})
+
+	profile, keyID, _, ok = provider.GetProfileCredentials("search")
+	require.True(t, ok, "values default to the profile name")
+	assert.Equal(t, "search", profile)
+	assert.Equal(t, "tfk_search", keyID)
+
+	_, _, _, ok = provider.GetProfileCredentials("checkout")
+	assert.False(t, ok)
+}
+
+func TestExtension_NoProfiles(t *testing.T) {
+	cfg := &tfoauthextension.Config{ProfileAttribute: "team"}
+	ext, err := tfoauthextension.NewFactory().Create(context.Background(),
+		extensiontest.NewNopSettings(component.MustNewType("tfoauth")), cfg)
+	require.NoError(t, err)
+	assert.Empty(t, ext.(profileProvider).GetProfileAttribute(), "an attribute without profiles selects nothing")
+}
+
+func TestExtension_ValidatesProfilesOnStart(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-TelemetryFlow-Key-ID") == "tfk_search" {
+			w.WriteHeader(http.StatusUnauthorized)
+		}
+	}))
+	t.Cleanup(backend.Close)
+
+	cfg := profilesConfig()
+	cfg.ValidateOnStart = true
+	cfg.ValidationEndpoint = backend.URL
+	require.NoError(t, cfg.Validate())
+
+	ext, err := tfoauthextension.NewFactory().Create(context.Background(),
+		extensiontest.NewNopSettings(component.MustNewType("tfoauth")), cfg)
+	require.NoError(t, err)
+	err = ext.Start(context.Background(), componenttest.NewNopHost())
+	assert.ErrorContains(t, err, `profile "search"`)
+
+	cfg.Profiles["search"] = tfoauthextension.Profile{APIKeyID: configopaque.String("tfk_search2"), APIKeySecret: "tfs_search2"}
+	ext, err = tfoauthextension.NewFactory().Create(context.Background(),
+		extensiontest.NewNopSettings(component.MustNewType("tfoauth")), cfg)
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, ext.Shutdown(context.Background()))
+}
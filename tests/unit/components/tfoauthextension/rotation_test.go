@@ -0,0 +1,111 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoauthextension_test
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/extension/tfoauthextension"
+)
+
+// keyRotator matches the rotation surface consumed by tfoexporter.
+type keyRotator interface {
+	RotateAPIKey(ctx context.Context, rejectedKeyID string) (keyID, keySecret string, ok bool)
+}
+
+func rotationConfig() *tfoauthextension.Config {
+	return &tfoauthextension.Config{
+		APIKeyID:              "tfk_primary",
+		APIKeySecret:          "tfs_primary",
+		SecondaryAPIKeyID:     "tfk_secondary",
+		SecondaryAPIKeySecret: "tfs_secondary",
+	}
+}
+
+func TestConfig_ValidateSecondaryKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*tfoauthextension.Config)
+		wantErr string
+	}{
+		{"valid", func(*tfoauthextension.Config) {}, ""},
+		{"no primary", func(c *tfoauthextension.Config) {
+			c.APIKeyID, c.APIKeySecret = "", ""
+		}, "secondary_api_key_id requires api_key_id"},
+		{"id prefix", func(c *tfoauthextension.Config) { c.SecondaryAPIKeyID = "key" }, "secondary_api_key_id must start with 'tfk_' prefix"},
+		{"secret missing", func(c *tfoauthextension.Config) { c.SecondaryAPIKeySecret = "" }, "secondary_api_key_secret must start with 'tfs_' prefix"},
+		{"same as primary", func(c *tfoauthextension.Config) { c.SecondaryAPIKeyID = c.APIKeyID }, "secondary_api_key_id must differ from api_key_id"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := rotationConfig()
+			tt.mutate(cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestExtension_RotateAPIKey(t *testing.T) {
+	ext := newStartedAuthExtension(t, rotationConfig())
+	getter := ext.(authGetter)
+	rotator, ok := ext.(keyRotator)
+	require.True(t, ok, "extension must satisfy keyRotator")
+	assert.Equal(t, "tfk_primary", getter.GetAPIKeyID())
+
+	for range 2 {
+		keyID, keySecret, ok := rotator.RotateAPIKey(context.Background(), "tfk_primary")
+		require.True(t, ok)
+		assert.Equal(t, "tfk_secondary", keyID)
+		assert.Equal(t, "tfs_secondary", keySecret)
+	}
+	assert.Equal(t, "tfk_secondary", getter.GetAPIKeyID())
+	assert.Equal(t, "tfs_secondary", getter.GetAPIKeySecret())
+
+	_, _, ok = rotator.RotateAPIKey(context.Background(), "tfk_secondary")
+	assert.False(t, ok, "a rejected secondary key leaves nothing to rotate to")
+}
+
+func TestExtension_RotateAPIKeyWithoutSecondary(t *testing.T) {
+	cfg := rotationConfig()
+	cfg.SecondaryAPIKeyID, cfg.SecondaryAPIKeySecret = "", ""
+	rotator := newStartedAuthExtension(t, cfg).(keyRotator)
+
+	_, _, ok := rotator.RotateAPIKey(context.Background(), "tfk_primary")
+	assert.False(t, ok)
+}
+
+func TestExtension_ValidateOnStartRotatesRejectedPrimary(t *testing.T) {
+	endpoint := &authEndpoint{revoked: map[string]bool{"tfk_primary": true}}
+	srv := httptest.NewServer(endpoint)
+	t.Cleanup(srv.Close)
+
+	cfg := rotationConfig()
+	cfg.SecondaryAPIKeySecret = "tfs_good"
+	cfg.ValidateOnStart = true
+	cfg.ValidationEndpoint = srv.URL
+
+	getter := newStartedAuthExtension(t, cfg).(authGetter)
+	assert.Equal(t, "tfk_secondary", getter.GetAPIKeyID())
+}
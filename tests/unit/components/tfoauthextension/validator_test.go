@@ -0,0 +1,178 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoauthextension_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config/configopaque"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/extension/tfoauthextension"
+)
+
+// credentialValidator matches the validator surface consumed by tfootlpreceiver.
+type credentialValidator interface {
+	ValidateCredential(ctx context.Context, keyID, keySecret string) (bool, error)
+}
+
+// authEndpoint is a validation endpoint accepting tfs_good secrets until
+// the key is revoked.
+type authEndpoint struct {
+	calls   atomic.Int64
+	mu      sync.Mutex
+	revoked map[string]bool
+}
+
+func (a *authEndpoint) revoke(keyID string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.revoked[keyID] = true
+}
+
+func (a *authEndpoint) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	a.calls.Add(1)
+	a.mu.Lock()
+	revoked := a.revoked[r.Header.Get("X-TelemetryFlow-Key-ID")]
+	a.mu.Unlock()
+	if revoked || r.Header.Get("X-TelemetryFlow-Key-Secret") != "tfs_good" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func newValidator(t *testing.T, endpoint string, cache tfoauthextension.CacheConfig) credentialValidator {
+	t.Helper()
+	cfg := tfoauthextension.NewFactory().CreateDefaultConfig().(*tfoauthextension.Config)
+	cfg.APIKeyID = configopaque.String("tfk_collector")
+	cfg.APIKeySecret = configopaque.String("tfs_collector")
+	cfg.ValidationEndpoint = endpoint
+	cfg.Cache = cache
+	require.NoError(t, cfg.Validate())
+
+	v, ok := newStartedAuthExtension(t, cfg).(credentialValidator)
+	require.True(t, ok, "extension must satisfy credentialValidator")
+	return v
+}
+
+func TestValidateCredential_CachesOutcomes(t *testing.T) {
+	endpoint := &authEndpoint{revoked: map[string]bool{}}
+	srv := httptest.NewServer(endpoint)
+	t.Cleanup(srv.Close)
+
+	v := newValidator(t, srv.URL, tfoauthextension.CacheConfig{
+		TTL: time.Minute, NegativeTTL: time.Minute, MaxEntries: 10,
+	})
+	ctx := context.Background()
+
+	for range 3 {
+		valid, err := v.ValidateCredential(ctx, "tfk_a", "tfs_good")
+		require.NoError(t, err)
+		assert.True(t, valid)
+	}
+	assert.EqualValues(t, 1, endpoint.calls.Load())
+
+	// A wrong secret is a different credential, and its rejection is cached.
+	for range 3 {
+		valid, err := v.ValidateCredential(ctx, "tfk_a", "tfs_bad")
+		require.NoError(t, err)
+		assert.False(t, valid)
+	}
+	assert.EqualValues(t, 2, endpoint.calls.Load())
+}
+
+func TestValidateCredential_ZeroTTLValidatesEveryRequest(t *testing.T) {
+	endpoint := &authEndpoint{revoked: map[string]bool{}}
+	srv := httptest.NewServer(endpoint)
+	t.Cleanup(srv.Close)
+
+	v := newValidator(t, srv.URL, tfoauthextension.CacheConfig{MaxEntries: 10})
+	for range 3 {
+		valid, err := v.ValidateCredential(context.Background(), "tfk_a", "tfs_good")
+		require.NoError(t, err)
+		assert.True(t, valid)
+	}
+	assert.EqualValues(t, 3, endpoint.calls.Load())
+}
+
+func TestValidateCredential_RevokedKeyStopsWorking(t *testing.T) {
+	endpoint := &authEndpoint{revoked: map[string]bool{}}
+	srv := httptest.NewServer(endpoint)
+	t.Cleanup(srv.Close)
+
+	v := newValidator(t, srv.URL, tfoauthextension.CacheConfig{
+		TTL: time.Hour, RevalidateInterval: 10 * time.Millisecond, MaxEntries: 10,
+	})
+	ctx := context.Background()
+
+	valid, err := v.ValidateCredential(ctx, "tfk_a", "tfs_good")
+	require.NoError(t, err)
+	require.True(t, valid)
+
+	endpoint.revoke("tfk_a")
+	// The key is in use, so the background revalidation picks up the
+	// revocation long before the TTL runs out.
+	assert.Eventually(t, func() bool {
+		valid, err := v.ValidateCredential(ctx, "tfk_a", "tfs_good")
+		return err == nil && !valid
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestValidateCredential_EndpointUnavailable(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	t.Cleanup(srv.Close)
+
+	v := newValidator(t, srv.URL, tfoauthextension.CacheConfig{
+		TTL: time.Minute, NegativeTTL: time.Minute, MaxEntries: 10,
+	})
+	valid, err := v.ValidateCredential(context.Background(), "tfk_a", "tfs_good")
+	require.Error(t, err)
+	assert.False(t, valid)
+}
+
+func TestValidateCredential_RequiresEndpoint(t *testing.T) {
+	cfg := tfoauthextension.NewFactory().CreateDefaultConfig().(*tfoauthextension.Config)
+	cfg.APIKeyID = configopaque.String("tfk_collector")
+	cfg.APIKeySecret = configopaque.String("tfs_collector")
+
+	v, ok := newStartedAuthExtension(t, cfg).(credentialValidator)
+	require.True(t, ok)
+	_, err := v.ValidateCredential(context.Background(), "tfk_a", "tfs_good")
+	assert.ErrorContains(t, err, "validation_endpoint")
+}
+
+func TestCacheConfig_Validate(t *testing.T) {
+	for name, cache := range map[string]tfoauthextension.CacheConfig{
+		"ttl":                 {TTL: -time.Second},
+		"negative_ttl":        {NegativeTTL: -time.Second},
+		"revalidate_interval": {RevalidateInterval: -time.Second},
+		"max_entries":         {MaxEntries: -1},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorContains(t, cache.Validate(), "cache."+name)
+		})
+	}
+	assert.NoError(t, (&tfoauthextension.CacheConfig{}).Validate())
+}
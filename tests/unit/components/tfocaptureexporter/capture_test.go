@@ -0,0 +1,208 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfocaptureexporter_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfocaptureexporter"
+)
+
+func traces(names ...string) ptrace.Traces {
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for _, name := range names {
+		spans.AppendEmpty().SetName(name)
+	}
+	return td
+}
+
+func metrics(names ...string) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	for _, name := range names {
+		m := ms.AppendEmpty()
+		m.SetName(name)
+		m.SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(1)
+	}
+	return md
+}
+
+func logs(bodies ...string) plog.Logs {
+	ld := plog.NewLogs()
+	records := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for _, body := range bodies {
+		records.AppendEmpty().Body().SetStr(body)
+	}
+	return ld
+}
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := tfocaptureexporter.NewFactory()
+	assert.Equal(t, component.MustNewType("tfocapture"), factory.Type())
+
+	cfg := factory.CreateDefaultConfig().(*tfocaptureexporter.Config)
+	assert.Equal(t, 100000, cfg.MaxItems)
+	assert.NoError(t, cfg.Validate())
+
+	cfg.MaxItems = 0
+	assert.EqualError(t, cfg.Validate(), "max_items must be positive")
+}
+
+func TestCapture_QueryHelpers(t *testing.T) {
+	ctx := context.Background()
+	c := tfocaptureexporter.New(100)
+
+	require.NoError(t, c.ConsumeTraces(ctx, traces("a", "b")))
+	require.NoError(t, c.ConsumeTraces(ctx, traces("a")))
+	require.NoError(t, c.ConsumeMetrics(ctx, metrics("requests", "latency")))
+	require.NoError(t, c.ConsumeMetrics(ctx, metrics("requests")))
+	require.NoError(t, c.ConsumeLogs(ctx, logs("hello", "world")))
+
+	assert.Len(t, c.Traces(), 2)
+	assert.Equal(t, 3, c.SpanCount())
+	assert.Len(t, c.Spans(), 3)
+	assert.Len(t, c.SpansByName("a"), 2)
+	assert.Empty(t, c.SpansByName("missing"))
+
+	assert.Len(t, c.Metrics(), 2)
+	assert.Equal(t, 3, c.DataPointCount())
+	assert.Equal(t, []string{"requests", "latency"}, c.MetricNames())
+	assert.Len(t, c.MetricsByName("requests"), 2)
+
+	assert.Len(t, c.Logs(), 1)
+	assert.Equal(t, 2, c.LogRecordCount())
+	records := c.LogRecords()
+	require.Len(t, records, 2)
+	assert.Equal(t, "world", records[1].Body().Str())
+
+	c.Reset()
+	assert.Zero(t, c.SpanCount())
+	assert.Zero(t, c.DataPointCount())
+	assert.Zero(t, c.LogRecordCount())
+	assert.Empty(t, c.Traces())
+}
+
+func TestCapture_CopiesInput(t *testing.T) {
+	c := tfocaptureexporter.New(100)
+	td := traces("original")
+	require.NoError(t, c.ConsumeTraces(context.Background(), td))
+
+	td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SetName("changed")
+	assert.Len(t, c.SpansByName("original"), 1)
+}
+
+func TestCapture_MaxItems(t *testing.T) {
+	ctx := context.Background()
+	c := tfocaptureexporter.New(3)
+
+	require.NoError(t, c.ConsumeTraces(ctx, traces("old1", "old2")))
+	require.NoError(t, c.ConsumeTraces(ctx, traces("new1", "new2")))
+	assert.Equal(t, 2, c.SpanCount())
+	assert.Equal(t, 2, c.Dropped())
+	assert.Empty(t, c.SpansByName("old1"))
+	assert.Len(t, c.SpansByName("new1"), 1)
+
+	// The newest batch is kept even if it alone exceeds the cap.
+	require.NoError(t, c.ConsumeLogs(ctx, logs("a", "b", "c", "d")))
+	assert.Equal(t, 4, c.LogRecordCount())
+	assert.Equal(t, 2, c.Dropped())
+}
+
+func TestCapture_Wait(t *testing.T) {
+	c := tfocaptureexporter.New(100)
+	spans := func(n int) func(*tfocaptureexporter.Capture) bool {
+		return func(c *tfocaptureexporter.Capture) bool { return c.SpanCount() >= n }
+	}
+
+	go func() {
+		for range 3 {
+			time.Sleep(5 * time.Millisecond)
+			_ = c.ConsumeTraces(context.Background(), traces("op"))
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, c.Wait(ctx, spans(3)))
+
+	short, cancelShort := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancelShort()
+	assert.ErrorIs(t, c.Wait(short, spans(4)), context.DeadlineExceeded)
+}
+
+func TestCapture_Concurrent(t *testing.T) {
+	c := tfocaptureexporter.New(1000)
+	var wg sync.WaitGroup
+	for range 8 {
+		wg.Go(func() {
+			for range 50 {
+				_ = c.ConsumeTraces(context.Background(), traces("op"))
+				_ = c.Spans()
+			}
+		})
+	}
+	wg.Wait()
+	assert.Equal(t, 400, c.SpanCount())
+}
+
+func TestExporter_RecordsIntoSharedCapture(t *testing.T) {
+	ctx := context.Background()
+	factory := tfocaptureexporter.NewFactory()
+	cfg := factory.CreateDefaultConfig()
+	set := exportertest.NewNopSettings(factory.Type())
+
+	capture := tfocaptureexporter.Get(set.ID)
+	capture.Reset()
+
+	te, err := factory.CreateTraces(ctx, set, cfg)
+	require.NoError(t, err)
+	me, err := factory.CreateMetrics(ctx, set, cfg)
+	require.NoError(t, err)
+	le, err := factory.CreateLogs(ctx, set, cfg)
+	require.NoError(t, err)
+
+	host := componenttest.NewNopHost()
+	for _, exp := range []component.Component{te, me, le} {
+		require.NoError(t, exp.Start(ctx, host))
+	}
+	t.Cleanup(func() {
+		for _, exp := range []component.Component{te, me, le} {
+			assert.NoError(t, exp.Shutdown(ctx))
+		}
+	})
+
+	require.NoError(t, te.ConsumeTraces(ctx, traces("op")))
+	require.NoError(t, me.ConsumeMetrics(ctx, metrics("requests")))
+	require.NoError(t, le.ConsumeLogs(ctx, logs("hello")))
+
+	assert.Same(t, capture, tfocaptureexporter.Get(set.ID))
+	assert.Equal(t, 1, capture.SpanCount())
+	assert.Equal(t, 1, capture.DataPointCount())
+	assert.Equal(t, 1, capture.LogRecordCount())
+	assert.False(t, te.Capabilities().MutatesData)
+}
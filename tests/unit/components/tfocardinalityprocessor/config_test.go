@@ -0,0 +1,73 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfocardinalityprocessor_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfocardinalityprocessor"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *tfocardinalityprocessor.Config)
+		wantErr string
+	}{
+		{name: "defaults", mutate: func(*tfocardinalityprocessor.Config) {}},
+		{
+			name:    "endpoint without port",
+			mutate:  func(cfg *tfocardinalityprocessor.Config) { cfg.NetAddr.Endpoint = "localhost" },
+			wantErr: "invalid endpoint",
+		},
+		{
+			name:    "endpoint off localhost without client authentication",
+			mutate:  func(cfg *tfocardinalityprocessor.Config) { cfg.NetAddr.Endpoint = "0.0.0.0:55695" },
+			wantErr: "not a loopback address",
+		},
+		{
+			name:    "zero max_duration",
+			mutate:  func(cfg *tfocardinalityprocessor.Config) { cfg.MaxDuration = 0 },
+			wantErr: "max_duration must be positive",
+		},
+		{
+			name:    "negative max_metrics",
+			mutate:  func(cfg *tfocardinalityprocessor.Config) { cfg.MaxMetrics = -1 },
+			wantErr: "max_metrics must be positive",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tfocardinalityprocessor.NewFactory().CreateDefaultConfig().(*tfocardinalityprocessor.Config)
+			tt.mutate(cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCreateDefaultConfig(t *testing.T) {
+	cfg := tfocardinalityprocessor.NewFactory().CreateDefaultConfig().(*tfocardinalityprocessor.Config)
+	assert.Equal(t, tfocardinalityprocessor.DefaultEndpoint, cfg.NetAddr.Endpoint)
+	assert.Equal(t, time.Hour, cfg.MaxDuration)
+	assert.Equal(t, 10000, cfg.MaxMetrics)
+}
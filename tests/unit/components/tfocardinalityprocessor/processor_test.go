@@ -0,0 +1,242 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfocardinalityprocessor_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processortest"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfocardinalityprocessor"
+)
+
+func freeEndpoint(t *testing.T) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = l.Close() }()
+	return l.Addr().String()
+}
+
+func newConfig(t *testing.T) *tfocardinalityprocessor.Config {
+	t.Helper()
+	cfg := tfocardinalityprocessor.NewFactory().CreateDefaultConfig().(*tfocardinalityprocessor.Config)
+	cfg.NetAddr.Endpoint = freeEndpoint(t)
+	return cfg
+}
+
+func startProcessor(t *testing.T, cfg *tfocardinalityprocessor.Config, sink *consumertest.MetricsSink) processor.Metrics {
+	t.Helper()
+	factory := tfocardinalityprocessor.NewFactory()
+	p, err := factory.CreateMetrics(context.Background(), processortest.NewNopSettings(factory.Type()), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { _ = p.Shutdown(context.Background()) })
+	return p
+}
+
+// call sends a request to the admin API and decodes the JSON response into
+// v when it is not nil.
+func call(t *testing.T, method, url, body string, v any) int {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	if v != nil {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(v))
+	}
+	return resp.StatusCode
+}
+
+// gauges returns metrics with n data points of the gauge name, each with
+// a distinct value of the key "id" and the constant "region".
+func gauges(name string, n int) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", "checkout")
+	m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName(name)
+	dps := m.SetEmptyGauge().DataPoints()
+	for i := range n {
+		dp := dps.AppendEmpty()
+		dp.Attributes().PutStr("id", fmt.Sprintf("id-%d", i))
+		dp.Attributes().PutStr("region", "eu")
+		dp.SetIntValue(int64(i))
+	}
+	return md
+}
+
+func TestProcessor_EstimatesSeries(t *testing.T) {
+	cfg := newConfig(t)
+	sink := new(consumertest.MetricsSink)
+	p := startProcessor(t, cfg, sink)
+	url := "http://" + cfg.NetAddr.Endpoint + "/cardinality"
+
+	// Outside sessions nothing is recorded.
+	require.NoError(t, p.ConsumeMetrics(context.Background(), gauges("before", 10)))
+	assert.Equal(t, http.StatusNotFound, call(t, http.MethodGet, url, "", nil))
+
+	var started tfocardinalityprocessor.Report
+	require.Equal(t, http.StatusOK, call(t, http.MethodPost, url, `{"duration":"1m"}`, &started))
+	assert.True(t, started.Active)
+	assert.WithinDuration(t, started.StartedAt.Add(time.Minute), started.EndsAt, time.Millisecond)
+
+	// Repeated data points of a series count once.
+	for range 2 {
+		require.NoError(t, p.ConsumeMetrics(context.Background(), gauges("http.requests", 1000)))
+	}
+	require.NoError(t, p.ConsumeMetrics(context.Background(), gauges("queue.size", 3)))
+	assert.Len(t, sink.AllMetrics(), 4, "metrics are passed on")
+
+	var report tfocardinalityprocessor.Report
+	require.Equal(t, http.StatusOK, call(t, http.MethodGet, url+"?top=1", "", &report))
+	assert.True(t, report.Active)
+	assert.Equal(t, int64(2003), report.DataPoints)
+	assert.InDelta(t, 1003, float64(report.Series), 1003*0.06)
+	assert.Equal(t, 2, report.MetricNames)
+	require.Len(t, report.Metrics, 1)
+	assert.Equal(t, "http.requests", report.Metrics[0].Name)
+	assert.Equal(t, "Gauge", report.Metrics[0].Type)
+	assert.Equal(t, int64(2000), report.Metrics[0].DataPoints)
+	assert.InDelta(t, 1000, float64(report.Metrics[0].Series), 1000*0.06)
+	assert.Equal(t, []string{"id", "region"}, report.Metrics[0].LabelKeys)
+	require.Len(t, report.LabelKeys, 1)
+	assert.Equal(t, "id", report.LabelKeys[0].Key)
+	assert.Equal(t, 2, report.LabelKeys[0].Metrics)
+
+	require.Equal(t, http.StatusOK, call(t, http.MethodGet, url, "", &report))
+	require.Len(t, report.Metrics, 2)
+	assert.Equal(t, "queue.size", report.Metrics[1].Name)
+	assert.Equal(t, uint64(3), report.Metrics[1].Series, "small counts are exact")
+	require.Len(t, report.LabelKeys, 2)
+	assert.Equal(t, tfocardinalityprocessor.LabelKeyReport{Key: "region", Values: 1, Metrics: 2}, report.LabelKeys[1])
+
+	// Ending the session stops sampling and keeps the report.
+	require.Equal(t, http.StatusOK, call(t, http.MethodDelete, url, "", &report))
+	assert.False(t, report.Active)
+	require.NoError(t, p.ConsumeMetrics(context.Background(), gauges("after", 10)))
+	require.Equal(t, http.StatusOK, call(t, http.MethodGet, url, "", &report))
+	assert.Equal(t, int64(2003), report.DataPoints)
+	assert.Equal(t, http.StatusNotFound, call(t, http.MethodDelete, url, "", nil))
+}
+
+func TestProcessor_UntrackedBeyondMaxMetrics(t *testing.T) {
+	cfg := newConfig(t)
+	cfg.MaxMetrics = 1
+	p := startProcessor(t, cfg, new(consumertest.MetricsSink))
+	url := "http://" + cfg.NetAddr.Endpoint + "/cardinality"
+
+	require.Equal(t, http.StatusOK, call(t, http.MethodPost, url, "", nil))
+	require.NoError(t, p.ConsumeMetrics(context.Background(), gauges("first", 2)))
+	require.NoError(t, p.ConsumeMetrics(context.Background(), gauges("second", 5)))
+
+	var report tfocardinalityprocessor.Report
+	require.Equal(t, http.StatusOK, call(t, http.MethodGet, url, "", &report))
+	assert.Equal(t, 1, report.MetricNames)
+	assert.Equal(t, int64(7), report.DataPoints)
+	assert.Equal(t, uint64(7), report.Series)
+	assert.Equal(t, int64(5), report.UntrackedDataPoints)
+}
+
+func TestProcessor_PipelinesShareSession(t *testing.T) {
+	cfg := newConfig(t)
+	first := startProcessor(t, cfg, new(consumertest.MetricsSink))
+	second := startProcessor(t, cfg, new(consumertest.MetricsSink))
+	url := "http://" + cfg.NetAddr.Endpoint + "/cardinality"
+
+	require.Equal(t, http.StatusOK, call(t, http.MethodPost, url, "", nil))
+	require.NoError(t, first.ConsumeMetrics(context.Background(), gauges("first", 2)))
+	require.NoError(t, second.ConsumeMetrics(context.Background(), gauges("second", 3)))
+
+	var report tfocardinalityprocessor.Report
+	require.Equal(t, http.StatusOK, call(t, http.MethodGet, url, "", &report))
+	assert.Equal(t, 2, report.MetricNames)
+	assert.Equal(t, int64(5), report.DataPoints)
+
+	// The admin API stays up until the last instance shuts down; a
+	// restarted processor takes the endpoint over with a new analyzer.
+	require.NoError(t, first.Shutdown(context.Background()))
+	assert.Equal(t, http.StatusOK, call(t, http.MethodGet, url, "", nil))
+	require.NoError(t, second.Shutdown(context.Background()))
+	startProcessor(t, cfg, new(consumertest.MetricsSink))
+	assert.Equal(t, http.StatusNotFound, call(t, http.MethodGet, url, "", nil))
+}
+
+func TestProcessor_AdminErrors(t *testing.T) {
+	cfg := newConfig(t)
+	cfg.MaxDuration = time.Hour
+	startProcessor(t, cfg, new(consumertest.MetricsSink))
+	url := "http://" + cfg.NetAddr.Endpoint + "/cardinality"
+
+	tests := []struct {
+		name   string
+		method string
+		url    string
+		body   string
+		want   int
+	}{
+		{name: "invalid body", method: http.MethodPost, url: url, body: "{", want: http.StatusBadRequest},
+		{name: "invalid duration", method: http.MethodPost, url: url, body: `{"duration":"soon"}`, want: http.StatusBadRequest},
+		{name: "beyond max_duration", method: http.MethodPost, url: url, body: `{"duration":"2h"}`, want: http.StatusBadRequest},
+		{name: "negative duration", method: http.MethodPost, url: url, body: `{"duration":"-1m"}`, want: http.StatusBadRequest},
+		{name: "invalid top", method: http.MethodGet, url: url + "?top=0", want: http.StatusBadRequest},
+		{name: "no session", method: http.MethodGet, url: url, want: http.StatusNotFound},
+		{name: "method", method: http.MethodPut, url: url, want: http.StatusMethodNotAllowed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, call(t, tt.method, tt.url, tt.body, nil))
+		})
+	}
+}
+
+func TestReport_Print(t *testing.T) {
+	start := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	report := tfocardinalityprocessor.Report{
+		StartedAt:   start,
+		EndsAt:      start.Add(5 * time.Minute),
+		DataPoints:  1200,
+		Series:      1000,
+		MetricNames: 1,
+		Metrics: []tfocardinalityprocessor.MetricReport{
+			{Name: "http.requests", Type: "Sum", Series: 1000, DataPoints: 1200, LabelKeys: []string{"id", "route"}},
+		},
+		LabelKeys: []tfocardinalityprocessor.LabelKeyReport{{Key: "id", Values: 990, Metrics: 1}},
+	}
+	var buf bytes.Buffer
+	report.Print(&buf)
+	out := buf.String()
+	assert.Contains(t, out, "Cardinality report (completed, 2026-01-02T03:04:05Z to 2026-01-02T03:09:05Z)")
+	assert.Contains(t, out, "Data points: 1200  Series: ~1000  Metric names: 1")
+	assert.Regexp(t, `http\.requests\s+Sum\s+~1000\s+1200\s+id,route`, out)
+	assert.Regexp(t, `id\s+~990\s+1`, out)
+	assert.NotContains(t, out, "Untracked")
+}
@@ -0,0 +1,95 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoclockextension_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/extension/tfoclockextension"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	valid := func() tfoclockextension.Config {
+		return tfoclockextension.Config{
+			NTPServers: []string{"pool.ntp.org"},
+			Interval:   time.Minute,
+			Timeout:    time.Second,
+			MaxDrift:   time.Second,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(cfg *tfoclockextension.Config)
+		wantErr string
+	}{
+		{name: "valid ntp", mutate: func(*tfoclockextension.Config) {}},
+		{
+			name: "valid ntp with port and http endpoint",
+			mutate: func(cfg *tfoclockextension.Config) {
+				cfg.NTPServers = []string{"time.google.com:123", "[::1]:123"}
+				cfg.HTTPEndpoints = []string{"https://api.telemetryflow.id"}
+			},
+		},
+		{
+			name:    "no sources",
+			mutate:  func(cfg *tfoclockextension.Config) { cfg.NTPServers = nil },
+			wantErr: "at least one of ntp_servers or http_endpoints",
+		},
+		{
+			name:    "empty ntp server",
+			mutate:  func(cfg *tfoclockextension.Config) { cfg.NTPServers = []string{""} },
+			wantErr: "empty entries",
+		},
+		{
+			name:    "non-http endpoint",
+			mutate:  func(cfg *tfoclockextension.Config) { cfg.HTTPEndpoints = []string{"ftp://example.com"} },
+			wantErr: "invalid http endpoint",
+		},
+		{
+			name:    "zero interval",
+			mutate:  func(cfg *tfoclockextension.Config) { cfg.Interval = 0 },
+			wantErr: "interval must be positive",
+		},
+		{
+			name:    "zero timeout",
+			mutate:  func(cfg *tfoclockextension.Config) { cfg.Timeout = 0 },
+			wantErr: "timeout must be positive",
+		},
+		{
+			name:    "negative max drift",
+			mutate:  func(cfg *tfoclockextension.Config) { cfg.MaxDrift = -time.Second },
+			wantErr: "max_drift",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid()
+			tt.mutate(&cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
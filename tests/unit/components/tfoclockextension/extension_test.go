@@ -0,0 +1,157 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoclockextension_test
+
+import (
+	"context"
+	"encoding/binary"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/extension/tfoclockextension"
+)
+
+// clockDriftProvider mirrors tfoexporter.ClockDriftProvider.
+type clockDriftProvider interface {
+	GetClockDrift() (time.Duration, bool)
+}
+
+// startFakeNTP serves SNTP responses whose clock is offset from the local
+// clock by serverOffset and returns the server address.
+func startFakeNTP(t *testing.T, serverOffset time.Duration) string {
+	t.Helper()
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	go func() {
+		buf := make([]byte, 48)
+		for {
+			n, addr, err := conn.ReadFrom(buf)
+			if err != nil {
+				return
+			}
+			if n < 48 {
+				continue
+			}
+			now := ntpTime(time.Now().Add(serverOffset))
+			resp := make([]byte, 48)
+			resp[0] = 0x24 // LI=0, VN=4, Mode=4 (server)
+			resp[1] = 2    // stratum
+			copy(resp[24:32], buf[40:48])
+			binary.BigEndian.PutUint64(resp[32:], now)
+			binary.BigEndian.PutUint64(resp[40:], now)
+			_, _ = conn.WriteTo(resp, addr)
+		}
+	}()
+	return conn.LocalAddr().String()
+}
+
+func ntpTime(t time.Time) uint64 {
+	secs := uint64(t.Unix() + 2208988800)
+	frac := (uint64(t.Nanosecond()) << 32) / uint64(time.Second)
+	return secs<<32 | frac
+}
+
+func startExtension(t *testing.T, cfg *tfoclockextension.Config) clockDriftProvider {
+	t.Helper()
+	factory := tfoclockextension.NewFactory()
+	set := extensiontest.NewNopSettings(component.MustNewType("tfoclock"))
+	ext, err := factory.Create(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, ext.Shutdown(context.Background())) })
+
+	provider, ok := ext.(clockDriftProvider)
+	require.True(t, ok, "extension must provide clock drift")
+	return provider
+}
+
+func TestNewFactory(t *testing.T) {
+	factory := tfoclockextension.NewFactory()
+	assert.Equal(t, component.MustNewType("tfoclock"), factory.Type())
+
+	cfg := factory.CreateDefaultConfig().(*tfoclockextension.Config)
+	assert.Equal(t, tfoclockextension.DefaultInterval, cfg.Interval)
+	assert.Equal(t, tfoclockextension.DefaultTimeout, cfg.Timeout)
+	assert.Equal(t, tfoclockextension.DefaultMaxDrift, cfg.MaxDrift)
+	assert.Error(t, cfg.Validate(), "a source must be configured")
+}
+
+func TestExtension_NTPDrift(t *testing.T) {
+	// The server is 3s behind, so the local clock is 3s ahead.
+	server := startFakeNTP(t, -3*time.Second)
+	provider := startExtension(t, &tfoclockextension.Config{
+		NTPServers: []string{server},
+		Interval:   time.Hour,
+		Timeout:    time.Second,
+	})
+
+	var drift time.Duration
+	require.Eventually(t, func() bool {
+		var ok bool
+		drift, ok = provider.GetClockDrift()
+		return ok
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.InDelta(t, 3*time.Second, drift, float64(100*time.Millisecond))
+}
+
+func TestExtension_HTTPDateDrift(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Date", time.Now().Add(10*time.Second).UTC().Format(http.TimeFormat))
+	}))
+	t.Cleanup(backend.Close)
+
+	provider := startExtension(t, &tfoclockextension.Config{
+		HTTPEndpoints: []string{backend.URL},
+		Interval:      time.Hour,
+		Timeout:       time.Second,
+	})
+
+	var drift time.Duration
+	require.Eventually(t, func() bool {
+		var ok bool
+		drift, ok = provider.GetClockDrift()
+		return ok
+	}, 2*time.Second, 10*time.Millisecond)
+	// The Date header has one second resolution.
+	assert.InDelta(t, -10*time.Second, drift, float64(time.Second))
+}
+
+func TestExtension_UnreachableSources(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	url := backend.URL
+	backend.Close()
+
+	provider := startExtension(t, &tfoclockextension.Config{
+		HTTPEndpoints: []string{url},
+		Interval:      10 * time.Millisecond,
+		Timeout:       100 * time.Millisecond,
+		MaxDrift:      time.Second,
+	})
+
+	time.Sleep(50 * time.Millisecond)
+	_, ok := provider.GetClockDrift()
+	assert.False(t, ok)
+}
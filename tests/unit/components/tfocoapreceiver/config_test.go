@@ -0,0 +1,203 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfocoapreceiver_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfocoapreceiver"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	valid := func() *tfocoapreceiver.Config {
+		cfg := tfocoapreceiver.NewFactory().CreateDefaultConfig().(*tfocoapreceiver.Config)
+		cfg.Templates = []tfocoapreceiver.TemplateConfig{
+			{
+				Name:     "env",
+				ID:       1,
+				Encoding: "binary",
+				DeviceID: tfocoapreceiver.FieldConfig{Type: "u32"},
+				Sequence: &tfocoapreceiver.FieldConfig{Type: "u16"},
+				Metrics: []tfocoapreceiver.MetricConfig{
+					{Name: "env.temperature", FieldConfig: tfocoapreceiver.FieldConfig{Type: "i16"}, Scale: 0.1},
+				},
+			},
+			{
+				Name:     "sensors/meter",
+				ID:       2,
+				DeviceID: tfocoapreceiver.FieldConfig{Field: "dev"},
+				Metrics: []tfocoapreceiver.MetricConfig{
+					{Name: "meter.energy", FieldConfig: tfocoapreceiver.FieldConfig{Field: "kwh"}, Kind: "sum"},
+				},
+			},
+		}
+		return cfg
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(*tfocoapreceiver.Config)
+		wantErr string
+	}{
+		{name: "valid", modify: func(*tfocoapreceiver.Config) {}},
+		{
+			name:    "missing endpoint",
+			modify:  func(cfg *tfocoapreceiver.Config) { cfg.Endpoint = "" },
+			wantErr: "endpoint is required",
+		},
+		{
+			name:    "protocol",
+			modify:  func(cfg *tfocoapreceiver.Config) { cfg.Protocol = "tcp" },
+			wantErr: "protocol must be",
+		},
+		{
+			name:    "unknown devices",
+			modify:  func(cfg *tfocoapreceiver.Config) { cfg.UnknownDevices = "log" },
+			wantErr: "unknown_devices must be",
+		},
+		{
+			name:    "replay window too large",
+			modify:  func(cfg *tfocoapreceiver.Config) { cfg.ReplayWindow = 65 },
+			wantErr: "replay_window must be between 0 and 64",
+		},
+		{
+			name:    "replay state ttl",
+			modify:  func(cfg *tfocoapreceiver.Config) { cfg.ReplayStateTTL = 0 },
+			wantErr: "replay_state_ttl must be positive",
+		},
+		{
+			name: "replay disabled without ttl",
+			modify: func(cfg *tfocoapreceiver.Config) {
+				cfg.ReplayWindow = 0
+				cfg.ReplayStateTTL = 0
+			},
+		},
+		{
+			name:    "empty device id",
+			modify:  func(cfg *tfocoapreceiver.Config) { cfg.Devices = map[string]map[string]string{"": nil} },
+			wantErr: "device ID must not be empty",
+		},
+		{
+			name:    "no templates",
+			modify:  func(cfg *tfocoapreceiver.Config) { cfg.Templates = nil },
+			wantErr: "at least one template",
+		},
+		{
+			name:    "duplicate name",
+			modify:  func(cfg *tfocoapreceiver.Config) { cfg.Templates[1].Name = "env" },
+			wantErr: `templates[1]: duplicate name "env"`,
+		},
+		{
+			name:    "path with empty segment",
+			modify:  func(cfg *tfocoapreceiver.Config) { cfg.Templates[1].Name = "sensors//meter" },
+			wantErr: "name must be a path",
+		},
+		{
+			name: "udp requires id",
+			modify: func(cfg *tfocoapreceiver.Config) {
+				cfg.Protocol = "udp"
+				cfg.Templates[1].ID = 0
+			},
+			wantErr: `template "sensors/meter": id is required`,
+		},
+		{
+			name: "udp duplicate id",
+			modify: func(cfg *tfocoapreceiver.Config) {
+				cfg.Protocol = "udp"
+				cfg.Templates[1].ID = 1
+			},
+			wantErr: "duplicate id 1",
+		},
+		{
+			name:    "encoding",
+			modify:  func(cfg *tfocoapreceiver.Config) { cfg.Templates[0].Encoding = "cbor" },
+			wantErr: "encoding must be",
+		},
+		{
+			name:    "byte order",
+			modify:  func(cfg *tfocoapreceiver.Config) { cfg.Templates[0].ByteOrder = "middle" },
+			wantErr: "byte_order must be",
+		},
+		{
+			name:    "signed binary device id",
+			modify:  func(cfg *tfocoapreceiver.Config) { cfg.Templates[0].DeviceID.Type = "i32" },
+			wantErr: `device_id: unsupported type "i32"`,
+		},
+		{
+			name:    "float binary sequence",
+			modify:  func(cfg *tfocoapreceiver.Config) { cfg.Templates[0].Sequence.Type = "f32" },
+			wantErr: `sequence: unsupported type "f32"`,
+		},
+		{
+			name:    "json device id field",
+			modify:  func(cfg *tfocoapreceiver.Config) { cfg.Templates[1].DeviceID.Field = "" },
+			wantErr: "device_id: field is required",
+		},
+		{
+			name: "json sequence type",
+			modify: func(cfg *tfocoapreceiver.Config) {
+				cfg.Templates[1].Sequence = &tfocoapreceiver.FieldConfig{Field: "n", Type: "i16"}
+			},
+			wantErr: `sequence: unsupported type "i16"`,
+		},
+		{
+			name:    "no metrics",
+			modify:  func(cfg *tfocoapreceiver.Config) { cfg.Templates[0].Metrics = nil },
+			wantErr: "at least one metric",
+		},
+		{
+			name:    "binary metric type",
+			modify:  func(cfg *tfocoapreceiver.Config) { cfg.Templates[0].Metrics[0].Type = "i24" },
+			wantErr: `metric env.temperature: unsupported type "i24"`,
+		},
+		{
+			name:    "metric kind",
+			modify:  func(cfg *tfocoapreceiver.Config) { cfg.Templates[1].Metrics[0].Kind = "histogram" },
+			wantErr: "kind must be",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid()
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestFactory_DefaultConfig(t *testing.T) {
+	factory := tfocoapreceiver.NewFactory()
+	assert.Equal(t, component.MustNewType("tfocoap"), factory.Type())
+	assert.Equal(t, component.StabilityLevelAlpha, factory.MetricsStability())
+
+	cfg := factory.CreateDefaultConfig().(*tfocoapreceiver.Config)
+	assert.Equal(t, "0.0.0.0:5683", cfg.Endpoint)
+	assert.Equal(t, "coap", cfg.Protocol)
+	assert.Equal(t, "accept", cfg.UnknownDevices)
+	assert.Equal(t, 64, cfg.ReplayWindow)
+	assert.Equal(t, 24*time.Hour, cfg.ReplayStateTTL)
+}
@@ -0,0 +1,395 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfocoapreceiver_test
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"net"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfocoapreceiver"
+)
+
+// CoAP codes of the responses.
+const (
+	codeChanged            = 0x44
+	codeBadRequest         = 0x80
+	codeForbidden          = 0x83
+	codeNotFound           = 0x84
+	codeMethodNotAllowed   = 0x85
+	codeServiceUnavailable = 0xa3
+)
+
+// coapTimeout bounds every wait for a response or for metrics.
+const coapTimeout = 5 * time.Second
+
+func freeUDPAddr(t *testing.T) string {
+	t.Helper()
+	c, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = c.Close() }()
+	return c.LocalAddr().String()
+}
+
+// envTemplate is a binary template: u32 device ID, u16 sequence, i16
+// temperature in tenths, u16 humidity in tenths and a u8 door flag.
+func envTemplate() tfocoapreceiver.TemplateConfig {
+	return tfocoapreceiver.TemplateConfig{
+		Name:     "env",
+		ID:       1,
+		Encoding: "binary",
+		DeviceID: tfocoapreceiver.FieldConfig{Type: "u32"},
+		Sequence: &tfocoapreceiver.FieldConfig{Type: "u16"},
+		Metrics: []tfocoapreceiver.MetricConfig{
+			{Name: "env.temperature", FieldConfig: tfocoapreceiver.FieldConfig{Type: "i16"}, Scale: 0.1, Unit: "Cel"},
+			{Name: "env.humidity", FieldConfig: tfocoapreceiver.FieldConfig{Type: "u16"}, Scale: 0.1, Unit: "%"},
+			{Name: "env.door_open", FieldConfig: tfocoapreceiver.FieldConfig{Type: "u8"}},
+		},
+	}
+}
+
+func envPayload(device uint32, seq uint16, temp int16) []byte {
+	b := binary.BigEndian.AppendUint32(nil, device)
+	b = binary.BigEndian.AppendUint16(b, seq)
+	b = binary.BigEndian.AppendUint16(b, uint16(temp))
+	b = binary.BigEndian.AppendUint16(b, 613)
+	return append(b, 1)
+}
+
+// meterTemplate is a json template with a u8 sequence.
+func meterTemplate() tfocoapreceiver.TemplateConfig {
+	return tfocoapreceiver.TemplateConfig{
+		Name:     "sensors/meter",
+		ID:       2,
+		DeviceID: tfocoapreceiver.FieldConfig{Field: "dev"},
+		Sequence: &tfocoapreceiver.FieldConfig{Field: "n", Type: "u8"},
+		Metrics: []tfocoapreceiver.MetricConfig{
+			{Name: "meter.energy", FieldConfig: tfocoapreceiver.FieldConfig{Field: "kwh"}, Kind: "sum", Unit: "kWh"},
+			{Name: "meter.voltage", FieldConfig: tfocoapreceiver.FieldConfig{Field: "v.rms"}, Unit: "V"},
+		},
+	}
+}
+
+func coapCfg(t *testing.T, protocol string) *tfocoapreceiver.Config {
+	t.Helper()
+	cfg := tfocoapreceiver.NewFactory().CreateDefaultConfig().(*tfocoapreceiver.Config)
+	cfg.Endpoint = freeUDPAddr(t)
+	cfg.Protocol = protocol
+	cfg.Templates = []tfocoapreceiver.TemplateConfig{envTemplate(), meterTemplate()}
+	cfg.Devices = map[string]map[string]string{"1234": {"site": "plant-a"}}
+	return cfg
+}
+
+func startReceiver(t *testing.T, cfg *tfocoapreceiver.Config, next consumer.Metrics) net.Conn {
+	t.Helper()
+	require.NoError(t, cfg.Validate())
+	set := receivertest.NewNopSettings(component.MustNewType("tfocoap"))
+	r, err := tfocoapreceiver.NewFactory().CreateMetrics(context.Background(), set, cfg, next)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, r.Shutdown(context.Background())) })
+
+	conn, err := net.Dial("udp", cfg.Endpoint)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+// coapRequest encodes a CoAP request with a one-byte token equal to the
+// low byte of id.
+func coapRequest(confirmable bool, code byte, id uint16, path string, payload []byte) []byte {
+	typ := byte(1)
+	if confirmable {
+		typ = 0
+	}
+	b := []byte{1<<6 | typ<<4 | 1, code, byte(id >> 8), byte(id), byte(id)}
+	last := 0
+	for _, segment := range splitPath(path) {
+		delta := 11 - last
+		last = 11
+		b = append(b, byte(delta<<4|len(segment)))
+		b = append(b, segment...)
+	}
+	if len(payload) > 0 {
+		b = append(b, 0xff)
+		b = append(b, payload...)
+	}
+	return b
+}
+
+func splitPath(path string) []string {
+	var segments []string
+	start := 0
+	for i := 0; i <= len(path); i++ {
+		if i == len(path) || path[i] == '/' {
+			segments = append(segments, path[start:i])
+			start = i + 1
+		}
+	}
+	return segments
+}
+
+// post sends a confirmable POST and returns the response code and
+// diagnostic payload, checking the message ID and token.
+func post(t *testing.T, conn net.Conn, id uint16, path string, payload []byte) (byte, string) {
+	t.Helper()
+	_, err := conn.Write(coapRequest(true, 0x02, id, path, payload))
+	require.NoError(t, err)
+	return readResponse(t, conn, id)
+}
+
+func readResponse(t *testing.T, conn net.Conn, id uint16) (byte, string) {
+	t.Helper()
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(coapTimeout)))
+	buf := make([]byte, 1500)
+	n, err := conn.Read(buf)
+	require.NoError(t, err)
+	resp := buf[:n]
+	require.GreaterOrEqual(t, len(resp), 5)
+	assert.Equal(t, byte(1<<6|2<<4|1), resp[0], "piggybacked ACK with a one-byte token")
+	assert.Equal(t, id, binary.BigEndian.Uint16(resp[2:4]))
+	assert.Equal(t, byte(id), resp[4])
+	diagnostic := ""
+	if len(resp) > 6 && resp[5] == 0xff {
+		diagnostic = string(resp[6:])
+	}
+	return resp[1], diagnostic
+}
+
+// point returns the only data point of metric name in sink.
+func point(t *testing.T, sink *consumertest.MetricsSink, name string) (pmetric.Metric, pmetric.NumberDataPoint, map[string]any) {
+	t.Helper()
+	for _, md := range sink.AllMetrics() {
+		rm := md.ResourceMetrics().At(0)
+		ms := rm.ScopeMetrics().At(0).Metrics()
+		for i := 0; i < ms.Len(); i++ {
+			m := ms.At(i)
+			if m.Name() != name {
+				continue
+			}
+			if m.Type() == pmetric.MetricTypeSum {
+				return m, m.Sum().DataPoints().At(0), rm.Resource().Attributes().AsRaw()
+			}
+			return m, m.Gauge().DataPoints().At(0), rm.Resource().Attributes().AsRaw()
+		}
+	}
+	require.Failf(t, "metric not found", "%s", name)
+	return pmetric.Metric{}, pmetric.NumberDataPoint{}, nil
+}
+
+func TestReceiver_BinaryTemplate(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	conn := startReceiver(t, coapCfg(t, "coap"), sink)
+
+	code, diagnostic := post(t, conn, 1, "env", envPayload(1234, 42, 225))
+	require.Equal(t, byte(codeChanged), code, diagnostic)
+	require.Len(t, sink.AllMetrics(), 1)
+
+	m, dp, res := point(t, sink, "env.temperature")
+	assert.Equal(t, "Cel", m.Unit())
+	assert.Equal(t, pmetric.MetricTypeGauge, m.Type())
+	assert.InDelta(t, 22.5, dp.DoubleValue(), 1e-9)
+	assert.Equal(t, map[string]any{"device.id": "1234", "site": "plant-a"}, res)
+	_, dp, _ = point(t, sink, "env.humidity")
+	assert.InDelta(t, 61.3, dp.DoubleValue(), 1e-9)
+	_, dp, _ = point(t, sink, "env.door_open")
+	assert.Equal(t, int64(1), dp.IntValue())
+
+	code, _ = post(t, conn, 2, "env", envPayload(1234, 43, -55))
+	require.Equal(t, byte(codeChanged), code)
+	last := sink.AllMetrics()[1].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+	assert.InDelta(t, -5.5, last.Gauge().DataPoints().At(0).DoubleValue(), 1e-9)
+}
+
+func TestReceiver_JSONTemplate(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	conn := startReceiver(t, coapCfg(t, "coap"), sink)
+
+	code, diagnostic := post(t, conn, 1, "sensors/meter", []byte(`{"dev": "m-7", "n": 1, "kwh": 1520, "v": {"rms": 229.5}}`))
+	require.Equal(t, byte(codeChanged), code, diagnostic)
+
+	m, dp, res := point(t, sink, "meter.energy")
+	assert.Equal(t, map[string]any{"device.id": "m-7"}, res)
+	assert.True(t, m.Sum().IsMonotonic())
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, m.Sum().AggregationTemporality())
+	assert.Equal(t, int64(1520), dp.IntValue())
+	assert.NotZero(t, dp.StartTimestamp())
+	_, dp, _ = point(t, sink, "meter.voltage")
+	assert.InDelta(t, 229.5, dp.DoubleValue(), 0)
+
+	// Missing metrics are skipped.
+	code, _ = post(t, conn, 2, "sensors/meter", []byte(`{"dev": 8, "n": 1, "kwh": 3}`))
+	require.Equal(t, byte(codeChanged), code)
+	last := sink.AllMetrics()[1].ResourceMetrics().At(0)
+	assert.Equal(t, 1, last.ScopeMetrics().At(0).Metrics().Len())
+	id, _ := last.Resource().Attributes().Get("device.id")
+	assert.Equal(t, "8", id.Str())
+}
+
+func TestReceiver_ReplayWindow(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	cfg := coapCfg(t, "coap")
+	cfg.ReplayWindow = 4
+	conn := startReceiver(t, cfg, sink)
+
+	meter := func(id uint16, seq int) byte {
+		t.Helper()
+		code, _ := post(t, conn, id, "sensors/meter", []byte(`{"dev": "m-1", "kwh": 1, "n": `+strconv.Itoa(seq)+`}`))
+		return code
+	}
+
+	assert.Equal(t, byte(codeChanged), meter(1, 250))
+	assert.Equal(t, byte(codeForbidden), meter(2, 250), "same sequence")
+	assert.Equal(t, byte(codeChanged), meter(3, 253))
+	assert.Equal(t, byte(codeChanged), meter(4, 251), "late but inside the window")
+	assert.Equal(t, byte(codeForbidden), meter(5, 251), "late and seen")
+	assert.Equal(t, byte(codeForbidden), meter(6, 249), "older than the window")
+	assert.Equal(t, byte(codeChanged), meter(7, 1), "u8 counter wrapped around")
+	assert.Equal(t, byte(codeForbidden), meter(8, 253), "from before the wraparound")
+
+	// Counters are kept per device.
+	code, _ := post(t, conn, 9, "sensors/meter", []byte(`{"dev": "m-2", "kwh": 1, "n": 250}`))
+	assert.Equal(t, byte(codeChanged), code)
+	assert.Len(t, sink.AllMetrics(), 5)
+}
+
+func TestReceiver_RefusedPayloadMayBeResent(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	var refuse atomic.Bool
+	refuse.Store(true)
+	next, err := consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
+		if refuse.Load() {
+			return errors.New("queue full")
+		}
+		return sink.ConsumeMetrics(ctx, md)
+	})
+	require.NoError(t, err)
+	conn := startReceiver(t, coapCfg(t, "coap"), next)
+
+	code, diagnostic := post(t, conn, 1, "env", envPayload(1234, 7, 200))
+	assert.Equal(t, byte(codeServiceUnavailable), code)
+	assert.Equal(t, "queue full", diagnostic)
+
+	refuse.Store(false)
+	code, _ = post(t, conn, 2, "env", envPayload(1234, 7, 200))
+	assert.Equal(t, byte(codeChanged), code)
+	assert.Len(t, sink.AllMetrics(), 1)
+}
+
+func TestReceiver_RetransmissionIsAnsweredFromCache(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	cfg := coapCfg(t, "coap")
+	cfg.ReplayWindow = 0
+	conn := startReceiver(t, cfg, sink)
+
+	code, _ := post(t, conn, 77, "env", envPayload(1234, 1, 200))
+	assert.Equal(t, byte(codeChanged), code)
+	code, _ = post(t, conn, 77, "env", envPayload(1234, 1, 200))
+	assert.Equal(t, byte(codeChanged), code)
+	assert.Len(t, sink.AllMetrics(), 1, "the retransmission is not processed again")
+
+	code, _ = post(t, conn, 78, "env", envPayload(1234, 1, 200))
+	assert.Equal(t, byte(codeChanged), code)
+	assert.Len(t, sink.AllMetrics(), 2, "without replay protection a new message is processed")
+}
+
+func TestReceiver_Errors(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	cfg := coapCfg(t, "coap")
+	cfg.UnknownDevices = "drop"
+	conn := startReceiver(t, cfg, sink)
+
+	code, diagnostic := post(t, conn, 1, "env", envPayload(1234, 1, 200)[:7])
+	assert.Equal(t, byte(codeBadRequest), code)
+	assert.Contains(t, diagnostic, "payload too short")
+
+	code, diagnostic = post(t, conn, 2, "env", append(envPayload(1234, 2, 200), 0))
+	assert.Equal(t, byte(codeBadRequest), code)
+	assert.Contains(t, diagnostic, "1 trailing bytes")
+
+	code, _ = post(t, conn, 3, "sensors/meter", []byte(`{"dev": "1234", "n": 1, "kwh": "many"}`))
+	assert.Equal(t, byte(codeBadRequest), code)
+
+	code, _ = post(t, conn, 4, "sensors/meter", []byte(`{"dev": "1234", "n": 1}`))
+	assert.Equal(t, byte(codeBadRequest), code)
+
+	code, diagnostic = post(t, conn, 5, "env", envPayload(9999, 1, 200))
+	assert.Equal(t, byte(codeForbidden), code)
+	assert.Contains(t, diagnostic, `device "9999"`)
+
+	code, _ = post(t, conn, 6, "unknown", envPayload(1234, 1, 200))
+	assert.Equal(t, byte(codeNotFound), code)
+
+	_, err := conn.Write(coapRequest(true, 0x01, 7, "env", nil))
+	require.NoError(t, err)
+	code, _ = readResponse(t, conn, 7)
+	assert.Equal(t, byte(codeMethodNotAllowed), code)
+
+	assert.Empty(t, sink.AllMetrics())
+}
+
+func TestReceiver_NonConfirmableAndPing(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	conn := startReceiver(t, coapCfg(t, "coap"), sink)
+
+	_, err := conn.Write(coapRequest(false, 0x02, 1, "env", envPayload(1234, 1, 200)))
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return len(sink.AllMetrics()) == 1 }, coapTimeout, 10*time.Millisecond)
+
+	// An empty confirmable message is a ping, answered with a reset.
+	_, err = conn.Write([]byte{1<<6 | 0<<4, 0, 0, 9})
+	require.NoError(t, err)
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(coapTimeout)))
+	buf := make([]byte, 64)
+	n, err := conn.Read(buf)
+	require.NoError(t, err)
+	assert.Equal(t, []byte{1<<6 | 3<<4, 0, 0, 9}, buf[:n])
+}
+
+func TestReceiver_UDPDatagrams(t *testing.T) {
+	sink := new(consumertest.MetricsSink)
+	conn := startReceiver(t, coapCfg(t, "udp"), sink)
+
+	_, err := conn.Write(append([]byte{9}, envPayload(1234, 1, 200)...))
+	require.NoError(t, err)
+	_, err = conn.Write(append([]byte{1}, envPayload(1234, 1, 200)...))
+	require.NoError(t, err)
+	_, err = conn.Write(append([]byte{1}, envPayload(1234, 1, 200)...))
+	require.NoError(t, err)
+	_, err = conn.Write(append([]byte{2}, `{"dev": "m-1", "n": 1, "kwh": 5}`...))
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool { return len(sink.AllMetrics()) == 2 }, coapTimeout, 10*time.Millisecond)
+	assert.Never(t, func() bool { return len(sink.AllMetrics()) > 2 }, 100*time.Millisecond, 10*time.Millisecond,
+		"unknown template IDs and replays are dropped")
+	_, dp, _ := point(t, sink, "env.temperature")
+	assert.InDelta(t, 20.0, dp.DoubleValue(), 1e-9)
+	_, dp, _ = point(t, sink, "meter.energy")
+	assert.Equal(t, int64(5), dp.IntValue())
+}
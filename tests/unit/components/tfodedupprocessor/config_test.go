@@ -0,0 +1,81 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfodedupprocessor_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfodedupprocessor"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  tfodedupprocessor.Config
+		wantErr string
+	}{
+		{
+			name:   "identity mode",
+			config: tfodedupprocessor.Config{Mode: tfodedupprocessor.ModeIdentity, CacheSize: 10},
+		},
+		{
+			name:   "fingerprint mode",
+			config: tfodedupprocessor.Config{Mode: tfodedupprocessor.ModeFingerprint, CacheSize: 10, Epsilon: time.Millisecond},
+		},
+		{
+			name:    "unknown mode",
+			config:  tfodedupprocessor.Config{Mode: "hash", CacheSize: 10},
+			wantErr: "mode must be",
+		},
+		{
+			name:    "zero cache size",
+			config:  tfodedupprocessor.Config{Mode: tfodedupprocessor.ModeIdentity},
+			wantErr: "cache_size",
+		},
+		{
+			name:    "negative epsilon",
+			config:  tfodedupprocessor.Config{Mode: tfodedupprocessor.ModeFingerprint, CacheSize: 10, Epsilon: -time.Second},
+			wantErr: "epsilon",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := tfodedupprocessor.NewFactory()
+	assert.Equal(t, component.MustNewType("tfodedup"), factory.Type())
+
+	cfg := factory.CreateDefaultConfig().(*tfodedupprocessor.Config)
+	require.NoError(t, cfg.Validate())
+	assert.Equal(t, tfodedupprocessor.ModeIdentity, cfg.Mode)
+	assert.Equal(t, 100000, cfg.CacheSize)
+	assert.Equal(t, time.Millisecond, cfg.Epsilon)
+}
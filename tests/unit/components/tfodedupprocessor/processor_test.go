@@ -0,0 +1,201 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfodedupprocessor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfodedupprocessor"
+)
+
+var (
+	traceID = pcommon.TraceID([16]byte{1, 2, 3})
+	rootID  = pcommon.SpanID([8]byte{9})
+	base    = time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+type spanSpec struct {
+	id     byte
+	name   string
+	parent pcommon.SpanID
+	start  time.Duration
+	end    time.Duration
+}
+
+func makeTraces(specs ...spanSpec) ptrace.Traces {
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for _, s := range specs {
+		span := spans.AppendEmpty()
+		span.SetTraceID(traceID)
+		span.SetSpanID(pcommon.SpanID([8]byte{s.id}))
+		span.SetParentSpanID(s.parent)
+		span.SetName(s.name)
+		span.SetKind(ptrace.SpanKindServer)
+		span.SetStartTimestamp(pcommon.NewTimestampFromTime(base.Add(s.start)))
+		span.SetEndTimestamp(pcommon.NewTimestampFromTime(base.Add(s.end)))
+	}
+	return td
+}
+
+type harness struct {
+	proc processor.Traces
+	sink *consumertest.TracesSink
+	tel  *componenttest.Telemetry
+}
+
+func newHarness(t *testing.T, mode tfodedupprocessor.Mode, cacheSize int) *harness {
+	t.Helper()
+	factory := tfodedupprocessor.NewFactory()
+	cfg := factory.CreateDefaultConfig().(*tfodedupprocessor.Config)
+	cfg.Mode = mode
+	cfg.CacheSize = cacheSize
+	require.NoError(t, cfg.Validate())
+
+	h := &harness{sink: new(consumertest.TracesSink), tel: componenttest.NewTelemetry()}
+	t.Cleanup(func() { _ = h.tel.Shutdown(context.Background()) })
+
+	set := processortest.NewNopSettings(factory.Type())
+	set.TelemetrySettings = h.tel.NewTelemetrySettings()
+	var err error
+	h.proc, err = factory.CreateTraces(context.Background(), set, cfg, h.sink)
+	require.NoError(t, err)
+	require.NoError(t, h.proc.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { _ = h.proc.Shutdown(context.Background()) })
+	return h
+}
+
+func (h *harness) names() []string {
+	var out []string
+	for _, td := range h.sink.AllTraces() {
+		rss := td.ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			sss := rss.At(i).ScopeSpans()
+			for j := 0; j < sss.Len(); j++ {
+				spans := sss.At(j).Spans()
+				for k := 0; k < spans.Len(); k++ {
+					out = append(out, spans.At(k).Name())
+				}
+			}
+		}
+	}
+	return out
+}
+
+func (h *harness) removed(t *testing.T) int64 {
+	t.Helper()
+	m, err := h.tel.GetMetric("tfo_dedup_spans_removed")
+	require.NoError(t, err)
+	sum := m.Data.(metricdata.Sum[int64])
+	var total int64
+	for _, dp := range sum.DataPoints {
+		total += dp.Value
+	}
+	return total
+}
+
+func TestProcessor_IdentityMode(t *testing.T) {
+	h := newHarness(t, tfodedupprocessor.ModeIdentity, 100)
+	ctx := context.Background()
+
+	// Duplicate within one batch.
+	require.NoError(t, h.proc.ConsumeTraces(ctx, makeTraces(
+		spanSpec{id: 1, name: "a"},
+		spanSpec{id: 1, name: "a-dup"},
+		spanSpec{id: 2, name: "b"},
+	)))
+	// Duplicate across batches.
+	require.NoError(t, h.proc.ConsumeTraces(ctx, makeTraces(
+		spanSpec{id: 2, name: "b-dup"},
+		spanSpec{id: 3, name: "c"},
+	)))
+
+	assert.Equal(t, []string{"a", "b", "c"}, h.names())
+	assert.Equal(t, int64(2), h.removed(t))
+}
+
+func TestProcessor_DropsFullyDuplicateBatch(t *testing.T) {
+	h := newHarness(t, tfodedupprocessor.ModeIdentity, 100)
+	ctx := context.Background()
+
+	require.NoError(t, h.proc.ConsumeTraces(ctx, makeTraces(spanSpec{id: 1, name: "a"})))
+	require.NoError(t, h.proc.ConsumeTraces(ctx, makeTraces(spanSpec{id: 1, name: "a"})))
+
+	assert.Len(t, h.sink.AllTraces(), 1, "an empty batch is not forwarded")
+}
+
+func TestProcessor_FingerprintMode(t *testing.T) {
+	h := newHarness(t, tfodedupprocessor.ModeFingerprint, 100)
+	ctx := context.Background()
+
+	require.NoError(t, h.proc.ConsumeTraces(ctx, makeTraces(
+		// auto-instrumented span
+		spanSpec{id: 1, name: "GET /users", parent: rootID, start: 0, end: 10 * time.Millisecond},
+		// manual span for the same operation, different span ID, times within epsilon
+		spanSpec{id: 2, name: "GET /users", parent: rootID, start: 500 * time.Microsecond, end: 10*time.Millisecond + 200*time.Microsecond},
+		// same name and parent but a later call: kept
+		spanSpec{id: 3, name: "GET /users", parent: rootID, start: 20 * time.Millisecond, end: 30 * time.Millisecond},
+		// same times but a different parent: kept
+		spanSpec{id: 4, name: "GET /users", parent: pcommon.SpanID([8]byte{8}), start: 0, end: 10 * time.Millisecond},
+	)))
+
+	require.Len(t, h.names(), 3)
+	assert.Equal(t, int64(1), h.removed(t))
+}
+
+func TestProcessor_FingerprintOutsideEpsilon(t *testing.T) {
+	h := newHarness(t, tfodedupprocessor.ModeFingerprint, 100)
+
+	require.NoError(t, h.proc.ConsumeTraces(context.Background(), makeTraces(
+		spanSpec{id: 1, name: "op", parent: rootID, start: 0, end: 10 * time.Millisecond},
+		spanSpec{id: 2, name: "op", parent: rootID, start: 2 * time.Millisecond, end: 12 * time.Millisecond},
+	)))
+
+	assert.Len(t, h.names(), 2)
+}
+
+func TestProcessor_LRUEviction(t *testing.T) {
+	h := newHarness(t, tfodedupprocessor.ModeIdentity, 2)
+	ctx := context.Background()
+
+	require.NoError(t, h.proc.ConsumeTraces(ctx, makeTraces(
+		spanSpec{id: 1, name: "a"},
+		spanSpec{id: 2, name: "b"},
+		spanSpec{id: 3, name: "c"}, // evicts a
+	)))
+	require.NoError(t, h.proc.ConsumeTraces(ctx, makeTraces(
+		spanSpec{id: 1, name: "a-again"},
+		spanSpec{id: 3, name: "c-dup"},
+	)))
+
+	assert.Equal(t, []string{"a", "b", "c", "a-again"}, h.names())
+}
+
+func TestProcessor_Capabilities(t *testing.T) {
+	h := newHarness(t, tfodedupprocessor.ModeIdentity, 10)
+	assert.True(t, h.proc.Capabilities().MutatesData)
+}
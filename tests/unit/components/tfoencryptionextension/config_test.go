@@ -0,0 +1,130 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoencryptionextension_test
+
+import (
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/extension/tfoencryptionextension"
+)
+
+// testKey returns a base64 encoded 32 byte key filled with b.
+func testKey(b byte) configopaque.String {
+	key := make([]byte, 32)
+	for i := range key {
+		key[i] = b
+	}
+	return configopaque.String(base64.StdEncoding.EncodeToString(key))
+}
+
+func TestConfig_Validate(t *testing.T) {
+	valid := func() tfoencryptionextension.Config {
+		return tfoencryptionextension.Config{
+			Keys:      []tfoencryptionextension.KeyConfig{{ID: "k1", Key: testKey(1)}},
+			ActiveKey: "k1",
+			Format:    tfoencryptionextension.FormatProto,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(cfg *tfoencryptionextension.Config)
+		wantErr string
+	}{
+		{name: "valid", mutate: func(*tfoencryptionextension.Config) {}},
+		{
+			name: "inner encoding ignores format",
+			mutate: func(cfg *tfoencryptionextension.Config) {
+				cfg.Format = ""
+				cfg.Encoding = component.MustNewID("parquet")
+			},
+		},
+		{
+			name:    "no keys",
+			mutate:  func(cfg *tfoencryptionextension.Config) { cfg.Keys = nil },
+			wantErr: "at least one key",
+		},
+		{
+			name:    "missing id",
+			mutate:  func(cfg *tfoencryptionextension.Config) { cfg.Keys[0].ID = "" },
+			wantErr: "id is required",
+		},
+		{
+			name: "duplicate id",
+			mutate: func(cfg *tfoencryptionextension.Config) {
+				cfg.Keys = append(cfg.Keys, tfoencryptionextension.KeyConfig{ID: "k1", Key: testKey(2)})
+			},
+			wantErr: "duplicate id",
+		},
+		{
+			name:    "key and key_file",
+			mutate:  func(cfg *tfoencryptionextension.Config) { cfg.Keys[0].KeyFile = "/tmp/key" },
+			wantErr: "exactly one of key or key_file",
+		},
+		{
+			name:    "missing active key",
+			mutate:  func(cfg *tfoencryptionextension.Config) { cfg.ActiveKey = "" },
+			wantErr: "active_key is required",
+		},
+		{
+			name:    "unknown active key",
+			mutate:  func(cfg *tfoencryptionextension.Config) { cfg.ActiveKey = "k2" },
+			wantErr: "not among keys",
+		},
+		{
+			name:    "unknown format",
+			mutate:  func(cfg *tfoencryptionextension.Config) { cfg.Format = "avro" },
+			wantErr: "format must be",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid()
+			tt.mutate(&cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestKeyFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "archive.key")
+	require.NoError(t, os.WriteFile(path, []byte(string(testKey(7))+"\n"), 0o600))
+
+	ext := startExtension(t, &tfoencryptionextension.Config{
+		Keys:      []tfoencryptionextension.KeyConfig{{ID: "file", KeyFile: path}},
+		ActiveKey: "file",
+		Format:    tfoencryptionextension.FormatProto,
+	}, nil)
+	data, err := ext.MarshalTraces(testTraces())
+	require.NoError(t, err)
+	keyID, err := tfoencryptionextension.KeyID(data)
+	require.NoError(t, err)
+	assert.Equal(t, "file", keyID)
+}
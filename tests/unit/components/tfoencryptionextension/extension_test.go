@@ -0,0 +1,250 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoencryptionextension_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/extension/tfoencryptionextension"
+)
+
+// encodingExtension is the subset of the contrib encoding extension
+// interfaces implemented by tfoencryption.
+type encodingExtension interface {
+	extension.Extension
+	ptrace.Marshaler
+	ptrace.Unmarshaler
+	pmetric.Marshaler
+	pmetric.Unmarshaler
+	plog.Marshaler
+	plog.Unmarshaler
+}
+
+// extensionsHost is a component.Host that returns a populated extensions map.
+type extensionsHost struct {
+	component.Host
+	exts map[component.ID]component.Component
+}
+
+func (h *extensionsHost) GetExtensions() map[component.ID]component.Component {
+	return h.exts
+}
+
+func startExtension(t *testing.T, cfg *tfoencryptionextension.Config, host component.Host) encodingExtension {
+	t.Helper()
+	require.NoError(t, cfg.Validate())
+	if host == nil {
+		host = componenttest.NewNopHost()
+	}
+	factory := tfoencryptionextension.NewFactory()
+	set := extensiontest.NewNopSettings(component.MustNewType("tfoencryption"))
+	ext, err := factory.Create(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(context.Background(), host))
+	t.Cleanup(func() { require.NoError(t, ext.Shutdown(context.Background())) })
+
+	enc, ok := ext.(encodingExtension)
+	require.True(t, ok, "extension must implement the encoding interfaces")
+	return enc
+}
+
+func singleKeyConfig(format string) *tfoencryptionextension.Config {
+	return &tfoencryptionextension.Config{
+		Keys:      []tfoencryptionextension.KeyConfig{{ID: "archive-2026", Key: testKey(1)}},
+		ActiveKey: "archive-2026",
+		Format:    format,
+	}
+}
+
+func testTraces() ptrace.Traces {
+	td := ptrace.NewTraces()
+	span := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("checkout")
+	span.Attributes().PutStr("card.last4", "4242")
+	return td
+}
+
+func TestNewFactory(t *testing.T) {
+	factory := tfoencryptionextension.NewFactory()
+	assert.Equal(t, component.MustNewType("tfoencryption"), factory.Type())
+	cfg := factory.CreateDefaultConfig().(*tfoencryptionextension.Config)
+	assert.Equal(t, tfoencryptionextension.FormatProto, cfg.Format)
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, format := range []string{tfoencryptionextension.FormatProto, tfoencryptionextension.FormatJSON} {
+		t.Run(format, func(t *testing.T) {
+			ext := startExtension(t, singleKeyConfig(format), nil)
+
+			data, err := ext.MarshalTraces(testTraces())
+			require.NoError(t, err)
+			assert.NotContains(t, string(data), "checkout", "payload must be encrypted")
+			td, err := ext.UnmarshalTraces(data)
+			require.NoError(t, err)
+			assert.Equal(t, "checkout", td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+
+			md := pmetric.NewMetrics()
+			md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("requests")
+			data, err = ext.MarshalMetrics(md)
+			require.NoError(t, err)
+			md, err = ext.UnmarshalMetrics(data)
+			require.NoError(t, err)
+			assert.Equal(t, "requests", md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Name())
+
+			ld := plog.NewLogs()
+			ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("hello")
+			data, err = ext.MarshalLogs(ld)
+			require.NoError(t, err)
+			ld, err = ext.UnmarshalLogs(data)
+			require.NoError(t, err)
+			assert.Equal(t, "hello", ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Str())
+		})
+	}
+}
+
+func TestEachBatchUsesFreshDataKey(t *testing.T) {
+	ext := startExtension(t, singleKeyConfig(tfoencryptionextension.FormatProto), nil)
+	a, err := ext.MarshalTraces(testTraces())
+	require.NoError(t, err)
+	b, err := ext.MarshalTraces(testTraces())
+	require.NoError(t, err)
+	assert.NotEqual(t, a, b)
+}
+
+func TestKeyRotation(t *testing.T) {
+	old := startExtension(t, &tfoencryptionextension.Config{
+		Keys:      []tfoencryptionextension.KeyConfig{{ID: "archive-2025", Key: testKey(2)}},
+		ActiveKey: "archive-2025",
+		Format:    tfoencryptionextension.FormatProto,
+	}, nil)
+	archived, err := old.MarshalTraces(testTraces())
+	require.NoError(t, err)
+
+	rotated := startExtension(t, &tfoencryptionextension.Config{
+		Keys: []tfoencryptionextension.KeyConfig{
+			{ID: "archive-2026", Key: testKey(1)},
+			{ID: "archive-2025", Key: testKey(2)},
+		},
+		ActiveKey: "archive-2026",
+		Format:    tfoencryptionextension.FormatProto,
+	}, nil)
+
+	td, err := rotated.UnmarshalTraces(archived)
+	require.NoError(t, err)
+	assert.Equal(t, 1, td.SpanCount())
+
+	fresh, err := rotated.MarshalTraces(testTraces())
+	require.NoError(t, err)
+	keyID, err := tfoencryptionextension.KeyID(fresh)
+	require.NoError(t, err)
+	assert.Equal(t, "archive-2026", keyID)
+
+	_, err = old.UnmarshalTraces(fresh)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown key id")
+}
+
+func TestTamperingIsDetected(t *testing.T) {
+	ext := startExtension(t, singleKeyConfig(tfoencryptionextension.FormatProto), nil)
+	data, err := ext.MarshalTraces(testTraces())
+	require.NoError(t, err)
+
+	tampered := append([]byte(nil), data...)
+	tampered[len(tampered)-1] ^= 0xff
+	_, err = ext.UnmarshalTraces(tampered)
+	require.Error(t, err)
+
+	_, err = ext.UnmarshalTraces([]byte("plain text"))
+	require.Error(t, err)
+	_, err = tfoencryptionextension.KeyID(data[:6])
+	require.Error(t, err)
+}
+
+func TestInvalidKeyMaterial(t *testing.T) {
+	cfg := singleKeyConfig(tfoencryptionextension.FormatProto)
+	cfg.Keys[0].Key = "c2hvcnQ=" // "short"
+	ext, err := tfoencryptionextension.NewFactory().Create(context.Background(),
+		extensiontest.NewNopSettings(component.MustNewType("tfoencryption")), cfg)
+	require.NoError(t, err)
+	err = ext.Start(context.Background(), componenttest.NewNopHost())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must be 32 bytes")
+}
+
+// upperMarshaler is an inner encoding extension supporting traces only.
+type upperMarshaler struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+func (upperMarshaler) MarshalTraces(td ptrace.Traces) ([]byte, error) {
+	return []byte(td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name()), nil
+}
+
+func TestInnerEncodingExtension(t *testing.T) {
+	inner := component.MustNewID("upper")
+	cfg := singleKeyConfig("")
+	cfg.Encoding = inner
+	host := &extensionsHost{Host: componenttest.NewNopHost(), exts: map[component.ID]component.Component{inner: upperMarshaler{}}}
+	ext := startExtension(t, cfg, host)
+
+	data, err := ext.MarshalTraces(testTraces())
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "checkout")
+
+	_, err = ext.UnmarshalTraces(data)
+	require.Error(t, err, "inner encoding cannot unmarshal")
+	_, err = ext.MarshalLogs(plog.NewLogs())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not support logs")
+}
+
+func TestFileExporterWritesEncryptedArchive(t *testing.T) {
+	extID := component.MustNewID("tfoencryption")
+	ext := startExtension(t, singleKeyConfig(tfoencryptionextension.FormatProto), nil)
+	host := &extensionsHost{Host: componenttest.NewNopHost(), exts: map[component.ID]component.Component{extID: ext}}
+
+	path := filepath.Join(t.TempDir(), "archive.bin")
+	factory := fileexporter.NewFactory()
+	cfg := factory.CreateDefaultConfig().(*fileexporter.Config)
+	cfg.Path = path
+	cfg.Encoding = &extID
+
+	exp, err := factory.CreateTraces(context.Background(), exportertest.NewNopSettings(factory.Type()), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), host))
+	require.NoError(t, exp.ConsumeTraces(context.Background(), testTraces()))
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "checkout")
+	assert.Contains(t, string(data), "archive-2026", "key id is recorded in the archive")
+}
@@ -0,0 +1,101 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoexemptprocessor_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfoexemptprocessor"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	debug := tfoexemptprocessor.Rule{Name: "debug", Attributes: map[string]string{"debug": "true"}}
+	tests := []struct {
+		name    string
+		config  tfoexemptprocessor.Config
+		wantErr string
+	}{
+		{
+			name:   "valid",
+			config: tfoexemptprocessor.Config{Rules: []tfoexemptprocessor.Rule{debug}, TraceWindow: time.Minute, MaxTraces: 10},
+		},
+		{
+			name:    "no rules",
+			config:  tfoexemptprocessor.Config{TraceWindow: time.Minute, MaxTraces: 10},
+			wantErr: "at least one rule is required",
+		},
+		{
+			name: "unnamed rule",
+			config: tfoexemptprocessor.Config{
+				Rules:       []tfoexemptprocessor.Rule{{Services: []string{"canary"}}},
+				TraceWindow: time.Minute, MaxTraces: 10,
+			},
+			wantErr: "rules[0]: name is required",
+		},
+		{
+			name: "duplicate name",
+			config: tfoexemptprocessor.Config{
+				Rules:       []tfoexemptprocessor.Rule{debug, debug},
+				TraceWindow: time.Minute, MaxTraces: 10,
+			},
+			wantErr: `rules[1]: duplicate name "debug"`,
+		},
+		{
+			name: "rule without conditions",
+			config: tfoexemptprocessor.Config{
+				Rules:       []tfoexemptprocessor.Rule{{Name: "all"}},
+				TraceWindow: time.Minute, MaxTraces: 10,
+			},
+			wantErr: `rule "all": attributes or services is required`,
+		},
+		{
+			name:    "zero trace window",
+			config:  tfoexemptprocessor.Config{Rules: []tfoexemptprocessor.Rule{debug}, MaxTraces: 10},
+			wantErr: "trace_window must be positive",
+		},
+		{
+			name:    "zero max traces",
+			config:  tfoexemptprocessor.Config{Rules: []tfoexemptprocessor.Rule{debug}, TraceWindow: time.Minute},
+			wantErr: "max_traces must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := tfoexemptprocessor.NewFactory()
+	assert.Equal(t, component.MustNewType("tfoexempt"), factory.Type())
+
+	cfg := factory.CreateDefaultConfig().(*tfoexemptprocessor.Config)
+	assert.Empty(t, cfg.Rules)
+	assert.Equal(t, 5*time.Minute, cfg.TraceWindow)
+	assert.Equal(t, 100000, cfg.MaxTraces)
+}
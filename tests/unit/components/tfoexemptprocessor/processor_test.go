@@ -0,0 +1,171 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoexemptprocessor_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfoexemptprocessor"
+)
+
+var rules = []tfoexemptprocessor.Rule{
+	{Name: "debug", Attributes: map[string]string{"debug": "true"}},
+	{Name: "canary", Services: []string{"checkout-canary"}},
+	{Name: "synthetic", Attributes: map[string]string{"user_agent.synthetic.type": "*"}},
+}
+
+type spanSpec struct {
+	trace   byte
+	name    string
+	service string
+	attrs   map[string]string
+}
+
+// makeTraces returns one resource per span, named after the span's service.
+func makeTraces(specs ...spanSpec) ptrace.Traces {
+	td := ptrace.NewTraces()
+	for i, s := range specs {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr("service.name", s.service)
+		span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+		span.SetTraceID(pcommon.TraceID([16]byte{s.trace}))
+		span.SetSpanID(pcommon.SpanID([8]byte{byte(i + 1)}))
+		span.SetName(s.name)
+		for k, v := range s.attrs {
+			span.Attributes().PutStr(k, v)
+		}
+	}
+	return td
+}
+
+type harness struct {
+	proc processor.Traces
+	sink *consumertest.TracesSink
+	tel  *componenttest.Telemetry
+}
+
+func newHarness(t *testing.T, window time.Duration) *harness {
+	t.Helper()
+	factory := tfoexemptprocessor.NewFactory()
+	cfg := factory.CreateDefaultConfig().(*tfoexemptprocessor.Config)
+	cfg.Rules = rules
+	cfg.TraceWindow = window
+	require.NoError(t, cfg.Validate())
+
+	h := &harness{sink: new(consumertest.TracesSink), tel: componenttest.NewTelemetry()}
+	t.Cleanup(func() { _ = h.tel.Shutdown(context.Background()) })
+
+	set := processortest.NewNopSettings(factory.Type())
+	set.TelemetrySettings = h.tel.NewTelemetrySettings()
+	var err error
+	h.proc, err = factory.CreateTraces(context.Background(), set, cfg, h.sink)
+	require.NoError(t, err)
+	require.NoError(t, h.proc.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { _ = h.proc.Shutdown(context.Background()) })
+	return h
+}
+
+// exempt returns the names of the received spans with sampling.priority=1.
+func (h *harness) exempt() []string {
+	var out []string
+	for _, td := range h.sink.AllTraces() {
+		rss := td.ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			sss := rss.At(i).ScopeSpans()
+			for j := 0; j < sss.Len(); j++ {
+				spans := sss.At(j).Spans()
+				for k := 0; k < spans.Len(); k++ {
+					if v, ok := spans.At(k).Attributes().Get("sampling.priority"); ok && v.Int() == 1 {
+						out = append(out, spans.At(k).Name())
+					}
+				}
+			}
+		}
+	}
+	return out
+}
+
+// marked returns the tfo_exempt_spans_marked counter by reason.
+func (h *harness) marked(t *testing.T) map[string]int64 {
+	t.Helper()
+	m, err := h.tel.GetMetric("tfo_exempt_spans_marked")
+	require.NoError(t, err)
+	out := make(map[string]int64)
+	for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+		reason, _ := dp.Attributes.Value("reason")
+		out[reason.AsString()] += dp.Value
+	}
+	return out
+}
+
+func TestProcessor_MarksMatchingTraces(t *testing.T) {
+	h := newHarness(t, time.Minute)
+	ctx := context.Background()
+
+	require.NoError(t, h.proc.ConsumeTraces(ctx, makeTraces(
+		// The matching span comes after another span of its trace.
+		spanSpec{trace: 1, name: "gateway", service: "gateway"},
+		spanSpec{trace: 1, name: "debugged", service: "orders", attrs: map[string]string{"debug": "true"}},
+		spanSpec{trace: 2, name: "canary", service: "checkout-canary"},
+		spanSpec{trace: 3, name: "probe", service: "web", attrs: map[string]string{"user_agent.synthetic.type": "bot"}},
+		spanSpec{trace: 4, name: "regular", service: "web", attrs: map[string]string{"debug": "false"}},
+	)))
+
+	assert.Equal(t, []string{"gateway", "debugged", "canary", "probe"}, h.exempt())
+	assert.Equal(t, map[string]int64{"rule": 3, "trace": 1}, h.marked(t))
+}
+
+func TestProcessor_LaterSpansOfExemptTrace(t *testing.T) {
+	h := newHarness(t, 100*time.Millisecond)
+	ctx := context.Background()
+
+	require.NoError(t, h.proc.ConsumeTraces(ctx, makeTraces(
+		spanSpec{trace: 1, name: "debugged", service: "orders", attrs: map[string]string{"debug": "true"}},
+	)))
+	require.NoError(t, h.proc.ConsumeTraces(ctx, makeTraces(
+		spanSpec{trace: 1, name: "child", service: "db"},
+		spanSpec{trace: 2, name: "other", service: "db"},
+	)))
+	assert.Equal(t, []string{"debugged", "child"}, h.exempt())
+
+	// The window runs from the last span seen of the trace.
+	time.Sleep(150 * time.Millisecond)
+	require.NoError(t, h.proc.ConsumeTraces(ctx, makeTraces(
+		spanSpec{trace: 1, name: "late", service: "db"},
+	)))
+	assert.Equal(t, []string{"debugged", "child"}, h.exempt())
+}
+
+func TestProcessor_OverridesPriority(t *testing.T) {
+	h := newHarness(t, time.Minute)
+
+	td := makeTraces(spanSpec{trace: 1, name: "debugged", service: "orders", attrs: map[string]string{"debug": "true"}})
+	td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Attributes().PutInt("sampling.priority", 0)
+	require.NoError(t, h.proc.ConsumeTraces(context.Background(), td))
+
+	assert.Equal(t, []string{"debugged"}, h.exempt())
+}
@@ -0,0 +1,100 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoexperimentexporter_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfoexperimentexporter"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/experiment"
+)
+
+func spans(n int) ptrace.Traces {
+	td := ptrace.NewTraces()
+	ss := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty()
+	for range n {
+		ss.Spans().AppendEmpty().SetName("span")
+	}
+	return td
+}
+
+func TestFactory(t *testing.T) {
+	factory := tfoexperimentexporter.NewFactory()
+	assert.Equal(t, "tfoexperiment", factory.Type().String())
+	assert.NoError(t, componenttest.CheckConfigStruct(factory.CreateDefaultConfig()))
+}
+
+func TestExporter_AddsToObservation(t *testing.T) {
+	factory := tfoexperimentexporter.NewFactory()
+	set := exportertest.NewNopSettings(factory.Type())
+	cfg := factory.CreateDefaultConfig()
+	ctx := context.Background()
+
+	traces, err := factory.CreateTraces(ctx, set, cfg)
+	require.NoError(t, err)
+	metrics, err := factory.CreateMetrics(ctx, set, cfg)
+	require.NoError(t, err)
+	logs, err := factory.CreateLogs(ctx, set, cfg)
+	require.NoError(t, err)
+
+	obs := &experiment.Observation{}
+	octx := experiment.NewContext(ctx, obs)
+
+	td := spans(3)
+	require.NoError(t, traces.ConsumeTraces(octx, td))
+
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty()
+	require.NoError(t, metrics.ConsumeMetrics(octx, md))
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("hello")
+	require.NoError(t, logs.ConsumeLogs(octx, ld))
+
+	var (
+		ts ptrace.ProtoMarshaler
+		ms pmetric.ProtoMarshaler
+		ls plog.ProtoMarshaler
+	)
+	assert.Equal(t, int64(5), obs.Records())
+	assert.Equal(t, int64(ts.TracesSize(td)+ms.MetricsSize(md)+ls.LogsSize(ld)), obs.Bytes())
+}
+
+func TestExporter_WarnsOnceWithoutObservation(t *testing.T) {
+	factory := tfoexperimentexporter.NewFactory()
+	set := exportertest.NewNopSettings(factory.Type())
+	core, logs := observer.New(zapcore.WarnLevel)
+	set.Logger = zap.New(core)
+
+	exp, err := factory.CreateTraces(context.Background(), set, factory.CreateDefaultConfig())
+	require.NoError(t, err)
+
+	require.NoError(t, exp.ConsumeTraces(context.Background(), spans(1)))
+	require.NoError(t, exp.ConsumeTraces(context.Background(), spans(1)))
+
+	assert.Equal(t, 1, logs.FilterMessageSnippet("outside an experiment").Len())
+}
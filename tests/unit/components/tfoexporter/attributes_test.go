@@ -0,0 +1,83 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoexporter_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfoexporter"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/attrfilter"
+)
+
+func TestExporter_AttributeFilter(t *testing.T) {
+	backend := newRecordingBackend(http.StatusOK)
+	t.Cleanup(backend.Close)
+
+	factory := tfoexporter.NewFactory()
+	cfg := factory.CreateDefaultConfig().(*tfoexporter.Config)
+	cfg.Endpoint = backend.URL()
+	cfg.Attributes = attrfilter.Config{
+		Resource: attrfilter.Rules{Include: []string{"service.name"}},
+		Record:   attrfilter.Rules{Exclude: []string{"http.request.header.*"}},
+	}
+	disableRetry(cfg)
+	require.NoError(t, cfg.Validate())
+
+	exp, err := factory.CreateTraces(context.Background(), exportertest.NewNopSettings(component.MustNewType("tfo")), cfg)
+	require.NoError(t, err)
+	assert.True(t, exp.Capabilities().MutatesData, "the pipeline must hand the exporter its own copy")
+	require.NoError(t, exp.Start(context.Background(), newExtHost(nil)))
+	t.Cleanup(func() { _ = exp.Shutdown(context.Background()) })
+
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "checkout")
+	rs.Resource().Attributes().PutStr("process.command_line", "/bin/checkout --verbose")
+	span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.Attributes().PutStr("http.route", "/cart")
+	span.Attributes().PutStr("http.request.header.cookie", "secret")
+	require.NoError(t, exp.ConsumeTraces(context.Background(), td))
+
+	backend.wait()
+	req := ptraceotlp.NewExportRequest()
+	require.NoError(t, req.UnmarshalProto(backend.lastBody))
+	sent := req.Traces().ResourceSpans().At(0)
+	assert.Equal(t, map[string]any{"service.name": "checkout"}, sent.Resource().Attributes().AsRaw())
+	assert.Equal(t, map[string]any{"http.route": "/cart"}, sent.ScopeSpans().At(0).Spans().At(0).Attributes().AsRaw())
+}
+
+func TestExporter_NoAttributeFilterKeepsData(t *testing.T) {
+	cfg := tfoexporter.NewFactory().CreateDefaultConfig().(*tfoexporter.Config)
+	assert.False(t, cfg.Attributes.Enabled())
+
+	exp, err := tfoexporter.NewFactory().CreateTraces(context.Background(), exportertest.NewNopSettings(component.MustNewType("tfo")), cfg)
+	require.NoError(t, err)
+	assert.False(t, exp.Capabilities().MutatesData)
+}
+
+func TestConfig_Validate_Attributes(t *testing.T) {
+	cfg := tfoexporter.NewFactory().CreateDefaultConfig().(*tfoexporter.Config)
+	cfg.Attributes.Record.Exclude = []string{"*.secret"}
+	assert.ErrorContains(t, cfg.Validate(), "attributes.record.exclude[0]")
+}
@@ -0,0 +1,200 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoexporter_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfoexporter"
+)
+
+// capsBackend serves a capabilities document in front of a spanBackend.
+type capsBackend struct {
+	*spanBackend
+	srv *httptest.Server
+
+	mu      sync.Mutex
+	doc     string
+	status  int
+	keyIDs  []string
+	fetches int
+}
+
+func newCapsBackend(t *testing.T, doc string) *capsBackend {
+	b := &capsBackend{spanBackend: newSpanBackend(t), doc: doc, status: http.StatusOK}
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /v2/capabilities", func(w http.ResponseWriter, r *http.Request) {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.fetches++
+		b.keyIDs = append(b.keyIDs, r.Header.Get("X-TelemetryFlow-Key-ID"))
+		w.WriteHeader(b.status)
+		_, _ = w.Write([]byte(b.doc))
+	})
+	mux.Handle("/", b.spanBackend.srv.Config.Handler)
+	b.srv = httptest.NewServer(mux)
+	t.Cleanup(b.srv.Close)
+	return b
+}
+
+func (b *capsBackend) setDoc(doc string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.doc = doc
+}
+
+func (b *capsBackend) fetchCount() int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.fetches
+}
+
+func capabilitiesConfig(endpoint string) *tfoexporter.Config {
+	cfg := encodingConfig(endpoint)
+	cfg.Capabilities.Enabled = true
+	cfg.Capabilities.RefreshInterval = 0
+	return cfg
+}
+
+// newObservedTracesExporter starts a traces exporter whose logs are recorded.
+func newObservedTracesExporter(t *testing.T, cfg *tfoexporter.Config) (func(ptrace.Traces) error, *observer.ObservedLogs) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	set := exportertest.NewNopSettings(component.MustNewType("tfo"))
+	set.Logger = zap.New(core)
+	exp, err := tfoexporter.NewFactory().CreateTraces(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), newExtHost(nil)))
+	t.Cleanup(func() { _ = exp.Shutdown(context.Background()) })
+	return func(td ptrace.Traces) error { return exp.ConsumeTraces(context.Background(), td) }, logs
+}
+
+func TestConfig_CapabilitiesDefaults(t *testing.T) {
+	cfg := tfoexporter.NewFactory().CreateDefaultConfig().(*tfoexporter.Config)
+	assert.False(t, cfg.Capabilities.Enabled)
+	assert.Equal(t, tfoexporter.DefaultCapabilitiesEndpoint, cfg.Capabilities.Endpoint)
+	assert.Equal(t, tfoexporter.DefaultCapabilitiesRefreshInterval, cfg.Capabilities.RefreshInterval)
+	assert.Equal(t, tfoexporter.DefaultCapabilitiesTimeout, cfg.Capabilities.Timeout)
+}
+
+func TestConfig_CapabilitiesValidation(t *testing.T) {
+	cfg := capabilitiesConfig("https://example.com")
+	require.NoError(t, cfg.Validate())
+
+	cfg.Capabilities.RefreshInterval = -time.Second
+	assert.ErrorContains(t, cfg.Validate(), "capabilities.refresh_interval")
+
+	cfg.Capabilities.RefreshInterval = 0
+	cfg.Capabilities.Timeout = 0
+	assert.ErrorContains(t, cfg.Validate(), "capabilities.timeout")
+
+	cfg.Capabilities.Timeout = time.Second
+	cfg.Capabilities.Endpoint = ""
+	assert.ErrorContains(t, cfg.Validate(), "capabilities.endpoint")
+
+	cfg.Capabilities.Enabled = false
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestCapabilities_NarrowConfiguredParameters(t *testing.T) {
+	backend := newCapsBackend(t, `{"max_payload_bytes": 1024, "encodings": ["json", "avro"], "signals": ["metrics"]}`)
+	cfg := capabilitiesConfig(backend.srv.URL)
+	cfg.Auth = &tfoexporter.AuthConfig{
+		APIKeyID:     configopaque.String("tfk_caps"),
+		APIKeySecret: configopaque.String("tfs_caps"),
+	}
+	consume, logs := newObservedTracesExporter(t, cfg)
+
+	require.NoError(t, consume(makeSpans(3, 10)))
+
+	assert.Greater(t, len(backend.spans), 1)
+	total := 0
+	for i, n := range backend.spans {
+		total += n
+		assert.LessOrEqual(t, len(backend.bodies[i]), 1024)
+		assert.Equal(t, "application/json", backend.contentTypes[i])
+	}
+	assert.Equal(t, 30, total)
+	assert.Equal(t, []string{"tfk_caps"}, backend.keyIDs)
+
+	assert.Equal(t, 1, logs.FilterMessage("Configured max_request_size exceeds the backend limit; using the backend limit").Len())
+	assert.Equal(t, 1, logs.FilterMessage("Configured encoding is not supported by the backend").Len())
+	assert.Equal(t, 1, logs.FilterMessage("Backend does not advertise support for the exported signal").Len())
+
+	// The configuration itself is left untouched.
+	assert.Equal(t, tfoexporter.EncodingProto, cfg.Encoding)
+	assert.Equal(t, tfoexporter.DefaultMaxRequestSize, cfg.MaxRequestSize)
+}
+
+func TestCapabilities_WithinLimitsKeepsConfiguration(t *testing.T) {
+	backend := newCapsBackend(t, `{"max_payload_bytes": 67108864, "encodings": ["proto", "json"], "signals": ["traces"]}`)
+	consume, logs := newObservedTracesExporter(t, capabilitiesConfig(backend.srv.URL))
+
+	require.NoError(t, consume(makeSpans(1, 5)))
+
+	require.Len(t, backend.spans, 1)
+	assert.Equal(t, "application/x-protobuf", backend.contentTypes[0])
+	assert.Zero(t, logs.FilterLevelExact(zapcore.WarnLevel).Len())
+}
+
+func TestCapabilities_FailedDiscoveryKeepsConfiguration(t *testing.T) {
+	backend := newCapsBackend(t, `not found`)
+	backend.status = http.StatusNotFound
+	cfg := capabilitiesConfig(backend.srv.URL)
+	cfg.MaxRequestSize = 0
+	consume, logs := newObservedTracesExporter(t, cfg)
+
+	require.NoError(t, consume(makeSpans(3, 10)))
+
+	require.Len(t, backend.spans, 1)
+	assert.Equal(t, "application/x-protobuf", backend.contentTypes[0])
+	entries := logs.FilterMessage("Backend capability discovery failed; using configured export parameters").All()
+	require.Len(t, entries, 1)
+	assert.Contains(t, entries[0].ContextMap()["error"], "unexpected status code: 404")
+}
+
+func TestCapabilities_Refresh(t *testing.T) {
+	backend := newCapsBackend(t, `{}`)
+	cfg := capabilitiesConfig(backend.srv.URL)
+	cfg.Capabilities.RefreshInterval = 10 * time.Millisecond
+	consume, _ := newObservedTracesExporter(t, cfg)
+
+	require.NoError(t, consume(makeSpans(3, 10)))
+	require.Len(t, backend.spans, 1)
+
+	backend.setDoc(`{"max_payload_bytes": 512}`)
+	fetches := backend.fetchCount()
+	require.Eventually(t, func() bool { return backend.fetchCount() > fetches+1 }, 5*time.Second, 5*time.Millisecond)
+
+	require.NoError(t, consume(makeSpans(3, 10)))
+	assert.Greater(t, len(backend.spans), 2)
+	for _, body := range backend.bodies[1:] {
+		assert.LessOrEqual(t, len(body), 512)
+	}
+}
@@ -0,0 +1,105 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoexporter_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfoexporter"
+)
+
+// fakeClock is a minimal tfoexporter.ClockDriftProvider extension.
+type fakeClock struct {
+	component.StartFunc
+	component.ShutdownFunc
+	drift time.Duration
+	ok    bool
+}
+
+func (c *fakeClock) GetClockDrift() (time.Duration, bool) { return c.drift, c.ok }
+
+func startClockDriftExporter(t *testing.T, backend *recordingBackend, host component.Host) error {
+	t.Helper()
+	factory := tfoexporter.NewFactory()
+	cfg := factory.CreateDefaultConfig().(*tfoexporter.Config)
+	cfg.Endpoint = backend.URL()
+	cfg.ClockDrift = component.MustNewID("tfoclock")
+	disableRetry(cfg)
+
+	set := exportertest.NewNopSettings(component.MustNewType("tfo"))
+	exp, err := factory.CreateTraces(context.Background(), set, cfg)
+	require.NoError(t, err)
+	if err := exp.Start(context.Background(), host); err != nil {
+		return err
+	}
+	t.Cleanup(func() { _ = exp.Shutdown(context.Background()) })
+
+	require.NoError(t, exp.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	backend.wait()
+	return nil
+}
+
+func TestExporter_ClockDriftHeader(t *testing.T) {
+	backend := newRecordingBackend(http.StatusOK)
+	t.Cleanup(backend.Close)
+
+	host := newExtHost(map[component.ID]component.Component{
+		component.MustNewID("tfoclock"): &fakeClock{drift: -1500 * time.Millisecond, ok: true},
+	})
+	require.NoError(t, startClockDriftExporter(t, backend, host))
+
+	require.NotNil(t, backend.lastReq)
+	assert.Equal(t, "-1500", backend.lastReq.Header.Get("X-TelemetryFlow-Clock-Drift-Ms"))
+}
+
+func TestExporter_ClockDriftHeader_NotMeasured(t *testing.T) {
+	backend := newRecordingBackend(http.StatusOK)
+	t.Cleanup(backend.Close)
+
+	host := newExtHost(map[component.ID]component.Component{
+		component.MustNewID("tfoclock"): &fakeClock{},
+	})
+	require.NoError(t, startClockDriftExporter(t, backend, host))
+
+	require.NotNil(t, backend.lastReq)
+	assert.Empty(t, backend.lastReq.Header.Get("X-TelemetryFlow-Clock-Drift-Ms"))
+}
+
+func TestExporter_ClockDrift_ExtensionErrors(t *testing.T) {
+	backend := newRecordingBackend(http.StatusOK)
+	t.Cleanup(backend.Close)
+
+	err := startClockDriftExporter(t, backend, newExtHost(nil))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "tfoclock extension")
+
+	err = startClockDriftExporter(t, backend, newExtHost(map[component.ID]component.Component{
+		component.MustNewID("tfoclock"): &struct {
+			component.StartFunc
+			component.ShutdownFunc
+		}{},
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not provide clock drift")
+}
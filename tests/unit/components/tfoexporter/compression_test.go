@@ -0,0 +1,306 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoexporter_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configcompression"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfoexporter"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/bytesize"
+)
+
+// compressionRequest is a request received by compressionBackend.
+type compressionRequest struct {
+	path     string
+	encoding string
+	body     []byte
+}
+
+// compressionBackend records every request it receives.
+type compressionBackend struct {
+	srv *httptest.Server
+
+	mu       sync.Mutex
+	requests []compressionRequest
+}
+
+func newCompressionBackend(t *testing.T) *compressionBackend {
+	b := &compressionBackend{}
+	b.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		b.mu.Lock()
+		b.requests = append(b.requests, compressionRequest{
+			path:     r.URL.Path,
+			encoding: r.Header.Get("Content-Encoding"),
+			body:     body,
+		})
+		b.mu.Unlock()
+	}))
+	t.Cleanup(b.srv.Close)
+	return b
+}
+
+// received returns the requests received on path.
+func (b *compressionBackend) received(path string) []compressionRequest {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var out []compressionRequest
+	for _, r := range b.requests {
+		if r.path == path {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+func compressionConfig(endpoint string) *tfoexporter.Config {
+	cfg := tfoexporter.NewFactory().CreateDefaultConfig().(*tfoexporter.Config)
+	cfg.Endpoint = endpoint
+	cfg.Compression = configcompression.TypeGzip
+	disableRetry(cfg)
+	return cfg
+}
+
+func compressionTraces(service string) ptrace.Traces {
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", service)
+	span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("GET /cart")
+	span.Attributes().PutStr("http.route", "/cart")
+	return td
+}
+
+func startCompressionTraces(t *testing.T, cfg *tfoexporter.Config) func(ptrace.Traces) {
+	require.NoError(t, cfg.Validate())
+	exp, err := tfoexporter.NewFactory().CreateTraces(context.Background(),
+		exportertest.NewNopSettings(component.MustNewType("tfo")), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), newExtHost(nil)))
+	t.Cleanup(func() { require.NoError(t, exp.Shutdown(context.Background())) })
+	return func(td ptrace.Traces) {
+		require.NoError(t, exp.ConsumeTraces(context.Background(), td))
+	}
+}
+
+func decodeTraces(t *testing.T, body []byte) ptrace.Traces {
+	req := ptraceotlp.NewExportRequest()
+	require.NoError(t, req.UnmarshalProto(body))
+	return req.Traces()
+}
+
+func TestConfig_SignalCompressionDefaults(t *testing.T) {
+	cfg := tfoexporter.NewFactory().CreateDefaultConfig().(*tfoexporter.Config)
+	for _, sc := range []tfoexporter.SignalCompressionConfig{
+		cfg.SignalCompression.Traces, cfg.SignalCompression.Metrics, cfg.SignalCompression.Logs,
+	} {
+		assert.Empty(t, sc.Type, "signals use the top-level compression by default")
+		assert.False(t, sc.Dictionary.Enabled)
+		assert.Equal(t, tfoexporter.DefaultDictionaryEndpoint, sc.Dictionary.Endpoint)
+		assert.Equal(t, tfoexporter.DefaultDictionarySize, sc.Dictionary.Size)
+		assert.Equal(t, tfoexporter.DefaultDictionarySamples, sc.Dictionary.Samples)
+	}
+}
+
+func TestConfig_Validate_SignalCompression(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*tfoexporter.CompressionConfig)
+		wantErr string
+	}{
+		{"zstd tuned", func(c *tfoexporter.CompressionConfig) {
+			c.Traces = tfoexporter.SignalCompressionConfig{Type: tfoexporter.CompressionZstd, Level: 19, WindowSize: 64 * bytesize.KiB}
+		}, ""},
+		{"unknown type", func(c *tfoexporter.CompressionConfig) {
+			c.Logs.Type = "brotli"
+		}, `signal_compression.logs: invalid compression type "brotli"`},
+		{"gzip level", func(c *tfoexporter.CompressionConfig) {
+			c.Metrics = tfoexporter.SignalCompressionConfig{Type: tfoexporter.CompressionGzip, Level: 10}
+		}, "signal_compression.metrics: level must be between 1 and 9 for gzip"},
+		{"zstd level", func(c *tfoexporter.CompressionConfig) {
+			c.Traces = tfoexporter.SignalCompressionConfig{Type: tfoexporter.CompressionZstd, Level: 23}
+		}, "level must be between 1 and 22 for zstd"},
+		{"level without type", func(c *tfoexporter.CompressionConfig) {
+			c.Traces.Level = 3
+		}, "level requires type gzip or zstd"},
+		{"window not power of two", func(c *tfoexporter.CompressionConfig) {
+			c.Traces = tfoexporter.SignalCompressionConfig{Type: tfoexporter.CompressionZstd, WindowSize: 3 * bytesize.KiB}
+		}, "window_size must be a power of two between 1KiB and 512MiB"},
+		{"window with gzip", func(c *tfoexporter.CompressionConfig) {
+			c.Traces = tfoexporter.SignalCompressionConfig{Type: tfoexporter.CompressionGzip, WindowSize: 64 * bytesize.KiB}
+		}, "window_size requires type zstd"},
+		{"dictionary with gzip", func(c *tfoexporter.CompressionConfig) {
+			c.Logs.Type = tfoexporter.CompressionGzip
+			c.Logs.Dictionary.Enabled = true
+		}, "dictionary requires type zstd"},
+		{"dictionary too large", func(c *tfoexporter.CompressionConfig) {
+			c.Logs.Type = tfoexporter.CompressionZstd
+			c.Logs.Dictionary.Enabled = true
+			c.Logs.Dictionary.Size = 2 * bytesize.MiB
+		}, "dictionary.size must be between 1KiB and 1MiB"},
+		{"dictionary without samples", func(c *tfoexporter.CompressionConfig) {
+			c.Logs.Type = tfoexporter.CompressionZstd
+			c.Logs.Dictionary.Enabled = true
+			c.Logs.Dictionary.Samples = 0
+		}, "dictionary.samples must be positive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := compressionConfig("http://localhost:4318")
+			tt.mutate(&cfg.SignalCompression)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestExporter_SignalCompression_Zstd(t *testing.T) {
+	backend := newCompressionBackend(t)
+	cfg := compressionConfig(backend.srv.URL)
+	cfg.SignalCompression.Traces = tfoexporter.SignalCompressionConfig{
+		Type:       tfoexporter.CompressionZstd,
+		Level:      19,
+		WindowSize: 64 * bytesize.KiB,
+	}
+	startCompressionTraces(t, cfg)(compressionTraces("checkout"))
+
+	sent := backend.received("/v2/traces")
+	require.Len(t, sent, 1)
+	assert.Equal(t, "zstd", sent[0].encoding)
+	dec, err := zstd.NewReader(nil)
+	require.NoError(t, err)
+	defer dec.Close()
+	body, err := dec.DecodeAll(sent[0].body, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, decodeTraces(t, body).SpanCount())
+}
+
+func TestExporter_SignalCompression_OverridesTopLevel(t *testing.T) {
+	backend := newCompressionBackend(t)
+	cfg := compressionConfig(backend.srv.URL)
+	cfg.Compression = configcompression.TypeZstd
+	cfg.SignalCompression.Logs.Type = tfoexporter.CompressionGzip
+	cfg.SignalCompression.Logs.Level = 9
+	require.NoError(t, cfg.Validate())
+
+	exp, err := tfoexporter.NewFactory().CreateLogs(context.Background(),
+		exportertest.NewNopSettings(component.MustNewType("tfo")), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), newExtHost(nil)))
+	t.Cleanup(func() { require.NoError(t, exp.Shutdown(context.Background())) })
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("checkout failed")
+	require.NoError(t, exp.ConsumeLogs(context.Background(), ld))
+
+	sent := backend.received("/v2/logs")
+	require.Len(t, sent, 1)
+	assert.Equal(t, "gzip", sent[0].encoding, "the logs setting replaces the top-level compression")
+	zr, err := gzip.NewReader(bytes.NewReader(sent[0].body))
+	require.NoError(t, err)
+	_, err = io.ReadAll(zr)
+	require.NoError(t, err)
+}
+
+func TestExporter_SignalCompression_None(t *testing.T) {
+	backend := newCompressionBackend(t)
+	cfg := compressionConfig(backend.srv.URL)
+	cfg.SignalCompression.Traces.Type = tfoexporter.CompressionNone
+	startCompressionTraces(t, cfg)(compressionTraces("checkout"))
+
+	sent := backend.received("/v2/traces")
+	require.Len(t, sent, 1)
+	assert.Equal(t, "identity", sent[0].encoding)
+	assert.Equal(t, 1, decodeTraces(t, sent[0].body).SpanCount())
+}
+
+func TestExporter_SignalCompression_DefaultsToTopLevel(t *testing.T) {
+	backend := newCompressionBackend(t)
+	startCompressionTraces(t, compressionConfig(backend.srv.URL))(compressionTraces("checkout"))
+
+	sent := backend.received("/v2/traces")
+	require.Len(t, sent, 1)
+	assert.Equal(t, "gzip", sent[0].encoding)
+}
+
+func TestExporter_SignalCompression_TrainedDictionary(t *testing.T) {
+	backend := newCompressionBackend(t)
+	cfg := compressionConfig(backend.srv.URL)
+	cfg.SignalCompression.Traces.Type = tfoexporter.CompressionZstd
+	cfg.SignalCompression.Traces.Dictionary.Enabled = true
+	cfg.SignalCompression.Traces.Dictionary.Size = bytesize.KiB
+	cfg.SignalCompression.Traces.Dictionary.Samples = 4
+	push := startCompressionTraces(t, cfg)
+
+	for range 4 {
+		push(compressionTraces("checkout"))
+	}
+	require.Eventually(t, func() bool {
+		return len(backend.received(tfoexporter.DefaultDictionaryEndpoint)) == 1
+	}, 5*time.Second, 10*time.Millisecond, "dictionary is published after enough samples")
+	published := backend.received(tfoexporter.DefaultDictionaryEndpoint)[0]
+	assert.Equal(t, "gzip", published.encoding, "the dictionary is sent with the top-level compression")
+	zr, err := gzip.NewReader(bytes.NewReader(published.body))
+	require.NoError(t, err)
+	dict, err := io.ReadAll(zr)
+	require.NoError(t, err)
+
+	// Payloads after publication reference the dictionary and decode only
+	// with it.
+	push(compressionTraces("checkout"))
+	sent := backend.received("/v2/traces")
+	require.Len(t, sent, 5)
+	last := sent[4]
+	assert.Equal(t, "zstd", last.encoding)
+
+	var header zstd.Header
+	require.NoError(t, header.Decode(last.body))
+	assert.NotZero(t, header.DictionaryID)
+
+	plain, err := zstd.NewReader(nil)
+	require.NoError(t, err)
+	defer plain.Close()
+	_, err = plain.DecodeAll(last.body, nil)
+	assert.Error(t, err, "payloads compressed with the dictionary need it to decode")
+
+	withDict, err := zstd.NewReader(nil, zstd.WithDecoderDicts(dict))
+	require.NoError(t, err)
+	defer withDict.Close()
+	body, err := withDict.DecodeAll(last.body, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, decodeTraces(t, body).SpanCount())
+}
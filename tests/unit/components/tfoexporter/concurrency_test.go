@@ -0,0 +1,141 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoexporter_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfoexporter"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/adaptive"
+)
+
+func adaptiveConfig(endpoint string, minC, maxC int) *tfoexporter.Config {
+	cfg := tfoexporter.NewFactory().CreateDefaultConfig().(*tfoexporter.Config)
+	cfg.Endpoint = endpoint
+	cfg.Concurrency = adaptive.NewDefaultConfig()
+	cfg.Concurrency.Enabled = true
+	cfg.Concurrency.MinConcurrency = minC
+	cfg.Concurrency.MaxConcurrency = maxC
+	disableRetry(cfg)
+	return cfg
+}
+
+func TestExporter_AdaptiveConcurrencyBoundsInFlight(t *testing.T) {
+	var inflight, peak atomic.Int32
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		n := inflight.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		inflight.Add(-1)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(backend.Close)
+
+	cfg := adaptiveConfig(backend.URL, 2, 2)
+	require.NoError(t, cfg.Validate())
+
+	exp, err := tfoexporter.NewFactory().CreateTraces(context.Background(),
+		exportertest.NewNopSettings(component.MustNewType("tfo")), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), newExtHost(nil)))
+	t.Cleanup(func() { _ = exp.Shutdown(context.Background()) })
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			assert.NoError(t, exp.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+		})
+	}
+	wg.Wait()
+	assert.Equal(t, int32(2), peak.Load())
+}
+
+func TestExporter_AdaptiveConcurrencyBacksOffOnThrottling(t *testing.T) {
+	var throttle atomic.Bool
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		if throttle.Load() {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(backend.Close)
+
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { _ = tel.Shutdown(context.Background()) })
+
+	cfg := adaptiveConfig(backend.URL, 1, 8)
+	set := exportertest.NewNopSettings(component.MustNewType("tfo"))
+	set.TelemetrySettings = tel.NewTelemetrySettings()
+	exp, err := tfoexporter.NewFactory().CreateTraces(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), newExtHost(nil)))
+	t.Cleanup(func() { _ = exp.Shutdown(context.Background()) })
+
+	limit := func() int64 {
+		m, err := tel.GetMetric("tfo_adaptive_concurrency_limit")
+		require.NoError(t, err)
+		return m.Data.(metricdata.Gauge[int64]).DataPoints[0].Value
+	}
+
+	for range 4 {
+		require.NoError(t, exp.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	}
+	grown := limit()
+	assert.Greater(t, grown, int64(1))
+
+	throttle.Store(true)
+	require.Error(t, exp.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	assert.Less(t, limit(), grown)
+}
+
+func TestConfig_Validate_AdaptiveConcurrency(t *testing.T) {
+	cfg := tfoexporter.NewFactory().CreateDefaultConfig().(*tfoexporter.Config)
+	assert.False(t, cfg.Concurrency.Enabled)
+	assert.False(t, cfg.QueueConfig.HasValue(), "the sending queue is off by default")
+
+	cfg = adaptiveConfig("http://localhost:4318", 1, 32)
+	require.NoError(t, cfg.Validate(), "without a queue the limit applies to pipeline callers")
+
+	cfg.QueueConfig.GetOrInsertDefault().NumConsumers = 10
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must not exceed sending_queue.num_consumers")
+
+	cfg.Concurrency.MaxConcurrency = 10
+	require.NoError(t, cfg.Validate())
+
+	cfg.Concurrency.DecreaseFactor = 2
+	require.Error(t, cfg.Validate())
+}
@@ -364,9 +364,7 @@ func TestExporter_Start_WithIdentityExtension(t *testing.T) {
 	assert.Equal(t, "collector-id-xyz", backend.lastReq.Header.Get("X-TelemetryFlow-Collector-ID"))
 }
 
-func TestExporter_Start_IdentityExtensionMissing_Warns(t *testing.T) {
-	// collector_identity references a non-existent extension — start() should
-	// log a warning but NOT fail (start returns nil).
+func TestExporter_Start_IdentityExtensionNotFound(t *testing.T) {
 	factory := tfoexporter.NewFactory()
 	cfg := factory.CreateDefaultConfig().(*tfoexporter.Config)
 	cfg.Endpoint = "http://127.0.0.1:1"
@@ -378,8 +376,9 @@ func TestExporter_Start_IdentityExtensionMissing_Warns(t *testing.T) {
 	require.NoError(t, err)
 
 	host := newExtHost(nil) // identity extension not registered
-	require.NoError(t, tracesExp.Start(context.Background(), host))
-	require.NoError(t, tracesExp.Shutdown(context.Background()))
+	err = tracesExp.Start(context.Background(), host)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `tfoidentity extension "tfoidentity" not found`)
 }
 
 func TestExporter_GetEndpointPath_DirectOverrides(t *testing.T) {
@@ -0,0 +1,228 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tfoexporter_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfoexporter"
+)
+
+// datasetBackend records the log bodies received per dataset header and
+// fails the requests of the datasets in fail.
+type datasetBackend struct {
+	srv  *httptest.Server
+	fail map[string]bool
+
+	mu       sync.Mutex
+	requests int
+	bodies   map[string][]string
+}
+
+func newDatasetBackend(t *testing.T, fail ...string) *datasetBackend {
+	b := &datasetBackend{fail: make(map[string]bool), bodies: make(map[string][]string)}
+	for _, d := range fail {
+		b.fail[d] = true
+	}
+	b.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		dataset := r.Header.Get("X-TelemetryFlow-Dataset")
+		body, _ := io.ReadAll(r.Body)
+		req := plogotlp.NewExportRequest()
+		if err := req.UnmarshalProto(body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		b.mu.Lock()
+		b.requests++
+		b.mu.Unlock()
+		if b.fail[dataset] {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		for _, rl := range req.Logs().ResourceLogs().All() {
+			for _, sl := range rl.ScopeLogs().All() {
+				for _, lr := range sl.LogRecords().All() {
+					b.bodies[dataset] = append(b.bodies[dataset], lr.Body().Str())
+				}
+			}
+		}
+	}))
+	t.Cleanup(b.srv.Close)
+	return b
+}
+
+func (b *datasetBackend) received() map[string][]string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	out := make(map[string][]string, len(b.bodies))
+	for k, v := range b.bodies {
+		out[k] = append([]string(nil), v...)
+		sort.Strings(out[k])
+	}
+	return out
+}
+
+func datasetConfig(t *testing.T, endpoint string) *tfoexporter.Config {
+	t.Helper()
+	cfg := tfoexporter.NewFactory().CreateDefaultConfig().(*tfoexporter.Config)
+	require.NoError(t, confmap.NewFromStringMap(map[string]any{
+		"endpoint": endpoint,
+		"datasets": map[string]any{
+			"default": "application",
+			"routes": []any{
+				map[string]any{"dataset": "compliance", "attributes": map[string]any{"log.type": "audit"}},
+				map[string]any{"dataset": "errors", "min_severity": "ERROR"},
+			},
+		},
+	}).Unmarshal(cfg))
+	disableRetry(cfg)
+	require.NoError(t, cfg.Validate())
+	return cfg
+}
+
+func startLogsExporter(t *testing.T, cfg *tfoexporter.Config) func(plog.Logs) error {
+	t.Helper()
+	exp, err := tfoexporter.NewFactory().CreateLogs(context.Background(), exportertest.NewNopSettings(component.MustNewType("tfo")), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), newExtHost(nil)))
+	t.Cleanup(func() { _ = exp.Shutdown(context.Background()) })
+	return func(ld plog.Logs) error { return exp.ConsumeLogs(context.Background(), ld) }
+}
+
+// routedLogs returns two resources whose records span every route.
+func routedLogs() plog.Logs {
+	ld := plog.NewLogs()
+	app := ld.ResourceLogs().AppendEmpty()
+	app.Resource().Attributes().PutStr("service.name", "checkout")
+	records := app.ScopeLogs().AppendEmpty().LogRecords()
+	add := func(records plog.LogRecordSlice, body string, severity plog.SeverityNumber, text string) plog.LogRecord {
+		lr := records.AppendEmpty()
+		lr.Body().SetStr(body)
+		lr.SetSeverityNumber(severity)
+		lr.SetSeverityText(text)
+		return lr
+	}
+	add(records, "started", plog.SeverityNumberInfo, "")
+	add(records, "payment failed", plog.SeverityNumberError, "")
+	add(records, "panic", plog.SeverityNumberUnspecified, "CRITICAL")
+	add(records, "user login", plog.SeverityNumberInfo, "").Attributes().PutStr("log.type", "audit")
+
+	audit := ld.ResourceLogs().AppendEmpty()
+	audit.Resource().Attributes().PutStr("log.type", "audit")
+	add(audit.ScopeLogs().AppendEmpty().LogRecords(), "role granted", plog.SeverityNumberWarn, "")
+	return ld
+}
+
+func TestExporter_DatasetRouting(t *testing.T) {
+	backend := newDatasetBackend(t)
+	consume := startLogsExporter(t, datasetConfig(t, backend.srv.URL))
+
+	require.NoError(t, consume(routedLogs()))
+	assert.Equal(t, map[string][]string{
+		"application": {"started"},
+		"errors":      {"panic", "payment failed"},
+		"compliance":  {"role granted", "user login"},
+	}, backend.received())
+	assert.Equal(t, 3, backend.requests)
+}
+
+func TestExporter_DatasetRoutingUniformBatch(t *testing.T) {
+	backend := newDatasetBackend(t)
+	consume := startLogsExporter(t, datasetConfig(t, backend.srv.URL))
+
+	ld := plog.NewLogs()
+	records := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for _, body := range []string{"a", "b"} {
+		lr := records.AppendEmpty()
+		lr.Body().SetStr(body)
+		lr.SetSeverityNumber(plog.SeverityNumberFatal)
+	}
+	require.NoError(t, consume(ld))
+	assert.Equal(t, map[string][]string{"errors": {"a", "b"}}, backend.received())
+	assert.Equal(t, 1, backend.requests)
+}
+
+func TestExporter_DatasetRoutingPartialFailure(t *testing.T) {
+	backend := newDatasetBackend(t, "errors")
+	consume := startLogsExporter(t, datasetConfig(t, backend.srv.URL))
+
+	err := consume(routedLogs())
+	require.Error(t, err)
+	var logsErr consumererror.Logs
+	require.ErrorAs(t, err, &logsErr)
+	assert.Equal(t, map[string][]string{"application": {"started"}}, backend.received())
+	assert.Equal(t, 4, logsErr.Data().LogRecordCount(), "the failed and the remaining datasets are retried")
+}
+
+func TestConfig_Validate_Datasets(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *tfoexporter.Config)
+		wantErr string
+	}{
+		{name: "invalid default", mutate: func(cfg *tfoexporter.Config) {
+			cfg.Datasets.Default = "bad name"
+		}, wantErr: "datasets.default"},
+		{name: "invalid route dataset", mutate: func(cfg *tfoexporter.Config) {
+			cfg.Datasets.Routes = []tfoexporter.DatasetRoute{{Dataset: "", MinSeverity: tfoexporter.LogSeverityWarn}}
+		}, wantErr: "datasets.routes[0]: invalid dataset name"},
+		{name: "inverted range", mutate: func(cfg *tfoexporter.Config) {
+			cfg.Datasets.Routes = []tfoexporter.DatasetRoute{{
+				Dataset: "x", MinSeverity: tfoexporter.LogSeverityError, MaxSeverity: tfoexporter.LogSeverityInfo,
+			}}
+		}, wantErr: "above max_severity"},
+		{name: "catch-all route", mutate: func(cfg *tfoexporter.Config) {
+			cfg.Datasets.Routes = []tfoexporter.DatasetRoute{{Dataset: "x"}}
+		}, wantErr: "use datasets.default"},
+		{name: "v1 API", mutate: func(cfg *tfoexporter.Config) {
+			cfg.UseV2API = false
+			cfg.Datasets.Default = "application"
+		}, wantErr: "datasets requires use_v2_api"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tfoexporter.NewFactory().CreateDefaultConfig().(*tfoexporter.Config)
+			cfg.Endpoint = "https://api.telemetryflow.id"
+			tt.mutate(cfg)
+			assert.ErrorContains(t, cfg.Validate(), tt.wantErr)
+		})
+	}
+}
+
+func TestConfig_DatasetSeverityUnmarshal(t *testing.T) {
+	cfg := tfoexporter.NewFactory().CreateDefaultConfig().(*tfoexporter.Config)
+	err := confmap.NewFromStringMap(map[string]any{
+		"datasets": map[string]any{"routes": []any{map[string]any{"dataset": "x", "min_severity": "severe"}}},
+	}).Unmarshal(cfg)
+	assert.ErrorContains(t, err, `invalid severity "severe"`)
+}
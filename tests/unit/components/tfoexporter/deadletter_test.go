@@ -0,0 +1,159 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoexporter_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfoexporter"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/deadletter"
+)
+
+func TestConfig_Validate_DeadLetter(t *testing.T) {
+	cfg := tfoexporter.NewFactory().CreateDefaultConfig().(*tfoexporter.Config)
+	assert.False(t, cfg.DeadLetter.Enabled())
+	assert.Equal(t, deadletter.DefaultMaxSize, cfg.DeadLetter.MaxSize)
+
+	cfg.DeadLetter.Directory = t.TempDir()
+	assert.ErrorContains(t, cfg.Validate(), "dead_letter requires a sending_queue")
+
+	cfg.QueueConfig.GetOrInsertDefault()
+	require.NoError(t, cfg.Validate())
+
+	cfg.DeadLetter.MaxSize = 0
+	assert.ErrorContains(t, cfg.Validate(), "dead_letter.max_size must be positive")
+}
+
+// deadLetterExporter starts a logs exporter with a single queue consumer
+// that sends to endpoint, retries for maxElapsed and spools to dir.
+func deadLetterExporter(t *testing.T, tel *componenttest.Telemetry, endpoint, dir string, maxElapsed time.Duration) exporter.Logs {
+	t.Helper()
+	factory := tfoexporter.NewFactory()
+	cfg := factory.CreateDefaultConfig().(*tfoexporter.Config)
+	cfg.Endpoint = endpoint
+	cfg.Timeout = 200 * time.Millisecond
+	cfg.QueueConfig.GetOrInsertDefault().NumConsumers = 1
+	cfg.RetryConfig.InitialInterval = 10 * time.Millisecond
+	cfg.RetryConfig.MaxInterval = 20 * time.Millisecond
+	cfg.RetryConfig.MaxElapsedTime = maxElapsed
+	cfg.DeadLetter.Directory = dir
+	require.NoError(t, cfg.Validate())
+
+	set := exportertest.NewNopSettings(component.MustNewType("tfo"))
+	set.ID = component.MustNewID("tfo")
+	set.TelemetrySettings = tel.NewTelemetrySettings()
+	exp, err := factory.CreateLogs(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	return exp
+}
+
+// logRecordsMetric returns the sum of the otelcol exporter metric name.
+func logRecordsMetric(t *testing.T, tel *componenttest.Telemetry, name string) int64 {
+	t.Helper()
+	return dryRunSum(t, tel, name)[""]
+}
+
+func TestExporter_DeadLetter_RetriesExhausted(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(srv.Close)
+
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { _ = tel.Shutdown(context.Background()) })
+
+	dir := filepath.Join(t.TempDir(), "dead-letter")
+	exp := deadLetterExporter(t, tel, srv.URL, dir, 100*time.Millisecond)
+	require.NoError(t, exp.ConsumeLogs(context.Background(), logBatch(3)))
+
+	var entries []deadletter.Entry
+	require.Eventually(t, func() bool {
+		var err error
+		entries, err = deadletter.List(dir)
+		return err == nil && len(entries) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	e := entries[0]
+	assert.Equal(t, "tfo", e.Exporter)
+	assert.Equal(t, "logs", e.Signal)
+	assert.Equal(t, 3, e.Records)
+	assert.Contains(t, e.Error, "no more retries left")
+	assert.Greater(t, hits.Load(), int32(1), "the batch is retried first")
+
+	assert.Equal(t, int64(3), logRecordsMetric(t, tel, "tfo_exporter_dead_lettered"))
+	assert.Equal(t, int64(3), logRecordsMetric(t, tel, "otelcol_exporter_send_failed_log_records"),
+		"failures are counted once")
+}
+
+func TestExporter_DeadLetter_HealthyBackend(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	t.Cleanup(srv.Close)
+
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { _ = tel.Shutdown(context.Background()) })
+
+	dir := t.TempDir()
+	exp := deadLetterExporter(t, tel, srv.URL, dir, time.Minute)
+	for i := 1; i <= 4; i++ {
+		require.NoError(t, exp.ConsumeLogs(context.Background(), logBatch(i)))
+	}
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	entries, err := deadletter.List(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+	assert.Equal(t, int64(10), logRecordsMetric(t, tel, "otelcol_exporter_sent_log_records"), "sends are counted once")
+}
+
+func TestExporter_DeadLetter_ShutdownIsNotGivingUp(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(srv.Close)
+
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { _ = tel.Shutdown(context.Background()) })
+
+	dir := t.TempDir()
+	exp := deadLetterExporter(t, tel, srv.URL, dir, time.Minute)
+	require.NoError(t, exp.ConsumeLogs(context.Background(), logBatch(2)))
+	require.Eventually(t, func() bool { return hits.Load() >= 2 }, 5*time.Second, 10*time.Millisecond)
+
+	// The retry back-off ends with the shutdown; the batch was not given up
+	// on by the exporter, so it is not written to the spool.
+	require.NoError(t, exp.Shutdown(context.Background()))
+	entries, err := deadletter.List(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
@@ -0,0 +1,124 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tfoexporter_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfoexporter"
+)
+
+// dryRunSum returns the sum of the data points of a dry-run counter, keyed
+// by their stage attribute (empty for counters without one).
+func dryRunSum(t *testing.T, tel *componenttest.Telemetry, name string) map[string]int64 {
+	t.Helper()
+	m, err := tel.GetMetric(name)
+	require.NoError(t, err)
+	out := make(map[string]int64)
+	for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+		stage, _ := dp.Attributes.Value("stage")
+		out[stage.AsString()] += dp.Value
+	}
+	return out
+}
+
+func TestExporter_DryRun(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		hits.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(srv.Close)
+
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { _ = tel.Shutdown(context.Background()) })
+
+	factory := tfoexporter.NewFactory()
+	cfg := factory.CreateDefaultConfig().(*tfoexporter.Config)
+	cfg.Endpoint = srv.URL
+	cfg.DryRun = true
+	cfg.MaxRequestSize = 1024
+	cfg.SignalCompression.Traces.Type = tfoexporter.CompressionGzip
+	// A failing warm-up check would abort startup if it were sent.
+	cfg.Warmup.Enabled = true
+	cfg.Warmup.Policy = tfoexporter.WarmupPolicyFail
+	cfg.Capabilities.Enabled = true
+	disableRetry(cfg)
+	require.NoError(t, cfg.Validate())
+
+	set := exportertest.NewNopSettings(component.MustNewType("tfo"))
+	set.TelemetrySettings = tel.NewTelemetrySettings()
+	exp, err := factory.CreateTraces(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { _ = exp.Shutdown(context.Background()) })
+
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for i := range 100 {
+		span := spans.AppendEmpty()
+		span.SetName(fmt.Sprintf("operation-%d", i))
+		span.Attributes().PutStr("http.route", "/api/v1/orders")
+	}
+	require.NoError(t, exp.ConsumeTraces(context.Background(), td))
+
+	assert.Zero(t, hits.Load(), "dry run must not contact the backend")
+
+	requests := dryRunSum(t, tel, "tfo_exporter_dry_run_requests")[""]
+	assert.Greater(t, requests, int64(1), "batch exceeding max_request_size is split")
+	assert.Equal(t, map[string]int64{"": 100}, dryRunSum(t, tel, "tfo_exporter_dry_run_records"))
+
+	bytes := dryRunSum(t, tel, "tfo_exporter_dry_run_bytes")
+	assert.Positive(t, bytes["compressed"])
+	assert.Less(t, bytes["compressed"], bytes["encoded"])
+}
+
+func TestExporter_DryRunDisabledRecordsNothing(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {}))
+	t.Cleanup(srv.Close)
+
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { _ = tel.Shutdown(context.Background()) })
+
+	factory := tfoexporter.NewFactory()
+	cfg := factory.CreateDefaultConfig().(*tfoexporter.Config)
+	cfg.Endpoint = srv.URL
+	disableRetry(cfg)
+
+	set := exportertest.NewNopSettings(component.MustNewType("tfo"))
+	set.TelemetrySettings = tel.NewTelemetrySettings()
+	exp, err := factory.CreateTraces(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { _ = exp.Shutdown(context.Background()) })
+
+	require.NoError(t, exp.ConsumeTraces(context.Background(), teamTraces("checkout")))
+	_, err = tel.GetMetric("tfo_exporter_dry_run_requests")
+	assert.Error(t, err)
+}
@@ -0,0 +1,213 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoexporter_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfoexporter"
+)
+
+// spanBackend decodes every traces request and records its span count.
+type spanBackend struct {
+	srv *httptest.Server
+
+	mu           sync.Mutex
+	contentTypes []string
+	bodies       [][]byte
+	spans        []int
+	failAfter    int // fail requests after this many succeeded; 0 = never
+}
+
+func newSpanBackend(t *testing.T) *spanBackend {
+	b := &spanBackend{}
+	b.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		req := ptraceotlp.NewExportRequest()
+		var err error
+		if r.Header.Get("Content-Type") == "application/json" {
+			err = req.UnmarshalJSON(body)
+		} else {
+			err = req.UnmarshalProto(body)
+		}
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		if b.failAfter > 0 && len(b.spans) >= b.failAfter {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		b.contentTypes = append(b.contentTypes, r.Header.Get("Content-Type"))
+		b.bodies = append(b.bodies, body)
+		b.spans = append(b.spans, req.Traces().SpanCount())
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(b.srv.Close)
+	return b
+}
+
+func encodingConfig(endpoint string) *tfoexporter.Config {
+	cfg := tfoexporter.NewFactory().CreateDefaultConfig().(*tfoexporter.Config)
+	cfg.Endpoint = endpoint
+	cfg.QueueConfig = configoptional.None[exporterhelper.QueueBatchConfig]()
+	disableRetry(cfg)
+	return cfg
+}
+
+func newTracesExporter(t *testing.T, cfg *tfoexporter.Config) func(ptrace.Traces) error {
+	exp, err := tfoexporter.NewFactory().CreateTraces(context.Background(),
+		exportertest.NewNopSettings(component.MustNewType("tfo")), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), newExtHost(nil)))
+	t.Cleanup(func() { _ = exp.Shutdown(context.Background()) })
+	return func(td ptrace.Traces) error { return exp.ConsumeTraces(context.Background(), td) }
+}
+
+func makeSpans(resources, spansPerResource int) ptrace.Traces {
+	td := ptrace.NewTraces()
+	for r := range resources {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutInt("resource", int64(r))
+		ss := rs.ScopeSpans().AppendEmpty()
+		for i := range spansPerResource {
+			s := ss.Spans().AppendEmpty()
+			s.SetName("span")
+			s.SetTraceID(pcommon.TraceID{0x5b, 0x8e, 0xff, 0xf7, 0x98, 0x03, 0x81, 0x03, 0xd2, 0x69, 0xb6, 0x33, 0x81, 0x3f, 0xc6, 0x0c})
+			s.SetSpanID(pcommon.SpanID{0xee, 0xe1, 0x9b, 0x7e, 0xc3, 0xc1, 0xb1, byte(i)})
+		}
+	}
+	return td
+}
+
+func TestConfig_EncodingDefaults(t *testing.T) {
+	cfg := tfoexporter.NewFactory().CreateDefaultConfig().(*tfoexporter.Config)
+	assert.Equal(t, tfoexporter.EncodingProto, cfg.Encoding)
+	assert.Equal(t, tfoexporter.DefaultMaxRequestSize, cfg.MaxRequestSize)
+	assert.Equal(t, "application/x-protobuf", cfg.Encoding.ContentType())
+	assert.Equal(t, "application/json", tfoexporter.EncodingJSON.ContentType())
+}
+
+func TestConfig_EncodingValidation(t *testing.T) {
+	cfg := encodingConfig("https://example.com")
+	require.NoError(t, confmap.NewFromStringMap(map[string]any{"encoding": "json"}).Unmarshal(cfg))
+	assert.Equal(t, tfoexporter.EncodingJSON, cfg.Encoding)
+	require.NoError(t, cfg.Validate())
+
+	err := confmap.NewFromStringMap(map[string]any{"encoding": "xml"}).Unmarshal(cfg)
+	assert.ErrorContains(t, err, "invalid encoding")
+
+	cfg.Encoding = "xml"
+	assert.ErrorContains(t, cfg.Validate(), "invalid encoding")
+
+	cfg.Encoding = tfoexporter.EncodingProto
+	cfg.MaxRequestSize = -1
+	assert.ErrorContains(t, cfg.Validate(), "max_request_size")
+}
+
+func TestExporter_JSONEncoding(t *testing.T) {
+	backend := newSpanBackend(t)
+	cfg := encodingConfig(backend.srv.URL)
+	cfg.Encoding = tfoexporter.EncodingJSON
+	consume := newTracesExporter(t, cfg)
+
+	require.NoError(t, consume(makeSpans(1, 1)))
+
+	require.Len(t, backend.bodies, 1)
+	assert.Equal(t, "application/json", backend.contentTypes[0])
+
+	// OTLP/JSON encodes trace and span IDs as lowercase hex strings.
+	var body struct {
+		ResourceSpans []struct {
+			ScopeSpans []struct {
+				Spans []struct {
+					TraceID string `json:"traceId"`
+					SpanID  string `json:"spanId"`
+				} `json:"spans"`
+			} `json:"scopeSpans"`
+		} `json:"resourceSpans"`
+	}
+	require.NoError(t, json.Unmarshal(backend.bodies[0], &body))
+	span := body.ResourceSpans[0].ScopeSpans[0].Spans[0]
+	assert.Equal(t, "5b8efff798038103d269b633813fc60c", span.TraceID)
+	assert.Equal(t, "eee19b7ec3c1b100", span.SpanID)
+}
+
+func TestExporter_SplitsOversizedRequests(t *testing.T) {
+	backend := newSpanBackend(t)
+	cfg := encodingConfig(backend.srv.URL)
+	cfg.Encoding = tfoexporter.EncodingJSON
+	cfg.MaxRequestSize = 1024
+	consume := newTracesExporter(t, cfg)
+
+	require.NoError(t, consume(makeSpans(3, 10)))
+
+	assert.Greater(t, len(backend.spans), 1)
+	total := 0
+	for i, n := range backend.spans {
+		total += n
+		assert.LessOrEqual(t, len(backend.bodies[i]), 1024)
+	}
+	assert.Equal(t, 30, total)
+}
+
+func TestExporter_SplitRetriesOnlyUnsent(t *testing.T) {
+	backend := newSpanBackend(t)
+	backend.failAfter = 1
+	cfg := encodingConfig(backend.srv.URL)
+	cfg.MaxRequestSize = 512
+	consume := newTracesExporter(t, cfg)
+
+	td := makeSpans(2, 8)
+	err := consume(td)
+	require.Error(t, err)
+
+	var partial consumererror.Traces
+	require.ErrorAs(t, err, &partial)
+	require.Len(t, backend.spans, 1)
+	assert.Equal(t, 16-backend.spans[0], partial.Data().SpanCount())
+	assert.Equal(t, 16, td.SpanCount(), "the input batch must not be modified")
+}
+
+func TestExporter_SingleOversizedItemIsSent(t *testing.T) {
+	backend := newSpanBackend(t)
+	cfg := encodingConfig(backend.srv.URL)
+	cfg.MaxRequestSize = 1
+	consume := newTracesExporter(t, cfg)
+
+	require.NoError(t, consume(makeSpans(1, 1)))
+	assert.Equal(t, []int{1}, backend.spans)
+}
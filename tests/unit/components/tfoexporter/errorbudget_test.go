@@ -0,0 +1,101 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tfoexporter_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfoexporter"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/errorbudget"
+)
+
+func TestConfig_ErrorBudgetValidation(t *testing.T) {
+	cfg := tfoexporter.NewFactory().CreateDefaultConfig().(*tfoexporter.Config)
+	assert.False(t, cfg.ErrorBudget.Enabled)
+	assert.Equal(t, errorbudget.DefaultBudget, cfg.ErrorBudget.Budget)
+
+	cfg.ErrorBudget.Enabled = true
+	cfg.ErrorBudget.ProbeInterval = 0
+	assert.ErrorContains(t, cfg.Validate(), "error_budget.probe_interval")
+}
+
+func TestExporter_ErrorBudgetRemovesAndRestoresExporter(t *testing.T) {
+	var healthy atomic.Bool
+	var requests atomic.Int32
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		if !healthy.Load() {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(backend.Close)
+
+	factory := tfoexporter.NewFactory()
+	cfg := factory.CreateDefaultConfig().(*tfoexporter.Config)
+	cfg.Endpoint = backend.URL
+	cfg.ErrorBudget = errorbudget.Config{
+		Enabled:       true,
+		Budget:        50 * time.Millisecond,
+		ProbeInterval: 20 * time.Millisecond,
+		ProbeTimeout:  20 * time.Millisecond,
+	}
+	disableRetry(cfg)
+	require.NoError(t, cfg.Validate())
+
+	host := &statusHost{Host: componenttest.NewNopHost()}
+	exp, err := factory.CreateTraces(context.Background(), exportertest.NewNopSettings(component.MustNewType("tfo")), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), host))
+	t.Cleanup(func() { _ = exp.Shutdown(context.Background()) })
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("op")
+
+	require.Error(t, exp.ConsumeTraces(context.Background(), td))
+	time.Sleep(cfg.ErrorBudget.Budget)
+	require.Error(t, exp.ConsumeTraces(context.Background(), td), "the send that exhausts the budget still fails")
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError}, host.statuses())
+
+	// Out of the fan-out: batches are dropped without reaching the backend.
+	sent := requests.Load()
+	require.NoError(t, exp.ConsumeTraces(context.Background(), td))
+	assert.LessOrEqual(t, requests.Load()-sent, int32(1), "only probes reach the backend")
+
+	healthy.Store(true)
+	require.Eventually(t, func() bool {
+		return len(host.statuses()) == 2
+	}, 2*time.Second, 10*time.Millisecond)
+	assert.Equal(t, componentstatus.StatusOK, host.statuses()[1])
+
+	sent = requests.Load()
+	require.NoError(t, exp.ConsumeTraces(context.Background(), td))
+	assert.Equal(t, sent+1, requests.Load(), "the exporter sends again after recovery")
+}
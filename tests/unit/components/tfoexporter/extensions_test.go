@@ -0,0 +1,128 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoexporter_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfoexporter"
+)
+
+// plainExtension is an extension providing neither credentials nor identity.
+type plainExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+func TestExporter_Start_UnusableExtensions(t *testing.T) {
+	authID := component.MustNewID("tfoauth")
+	identityID := component.MustNewID("tfoidentity")
+	wrongType := map[component.ID]component.Component{
+		authID:     plainExtension{},
+		identityID: plainExtension{},
+	}
+
+	tests := []struct {
+		name    string
+		exts    map[component.ID]component.Component
+		mutate  func(*tfoexporter.Config)
+		wantErr string
+	}{
+		{
+			name:    "auth extension not found",
+			mutate:  func(c *tfoexporter.Config) { c.Auth = &tfoexporter.AuthConfig{Extension: authID} },
+			wantErr: `tfoauth extension "tfoauth" not found`,
+		},
+		{
+			name:    "auth extension of another type",
+			exts:    wrongType,
+			mutate:  func(c *tfoexporter.Config) { c.Auth = &tfoexporter.AuthConfig{Extension: authID} },
+			wantErr: `extension "tfoauth" does not provide TFO credentials`,
+		},
+		{
+			name:    "identity extension not found",
+			mutate:  func(c *tfoexporter.Config) { c.CollectorIdentity = identityID },
+			wantErr: `tfoidentity extension "tfoidentity" not found`,
+		},
+		{
+			name:    "identity extension of another type",
+			exts:    wrongType,
+			mutate:  func(c *tfoexporter.Config) { c.CollectorIdentity = identityID },
+			wantErr: `extension "tfoidentity" does not provide a collector identity`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name+"/fails by default", func(t *testing.T) {
+			factory := tfoexporter.NewFactory()
+			cfg := factory.CreateDefaultConfig().(*tfoexporter.Config)
+			cfg.Endpoint = "http://127.0.0.1:1"
+			tt.mutate(cfg)
+			disableRetry(cfg)
+
+			exp, err := factory.CreateTraces(context.Background(),
+				exportertest.NewNopSettings(component.MustNewType("tfo")), cfg)
+			require.NoError(t, err)
+
+			err = exp.Start(context.Background(), newExtHost(tt.exts))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+			assert.Contains(t, err.Error(), "allow_anonymous")
+		})
+
+		t.Run(tt.name+"/anonymous", func(t *testing.T) {
+			backend := newRecordingBackend(http.StatusOK)
+			t.Cleanup(backend.Close)
+
+			factory := tfoexporter.NewFactory()
+			cfg := factory.CreateDefaultConfig().(*tfoexporter.Config)
+			cfg.Endpoint = backend.URL()
+			cfg.AllowAnonymous = true
+			tt.mutate(cfg)
+			disableRetry(cfg)
+
+			set := exportertest.NewNopSettings(component.MustNewType("tfo"))
+			core, logs := observer.New(zapcore.WarnLevel)
+			set.Logger = zap.New(core)
+			exp, err := factory.CreateTraces(context.Background(), set, cfg)
+			require.NoError(t, err)
+			require.NoError(t, exp.Start(context.Background(), newExtHost(tt.exts)))
+			t.Cleanup(func() { _ = exp.Shutdown(context.Background()) })
+
+			warnings := logs.FilterMessage("Exporting anonymously").All()
+			require.Len(t, warnings, 1)
+			assert.Contains(t, warnings[0].ContextMap()["error"], tt.wantErr)
+
+			td := ptrace.NewTraces()
+			td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("anonymous")
+			require.NoError(t, exp.ConsumeTraces(context.Background(), td))
+			backend.wait()
+			require.NotNil(t, backend.lastReq)
+			assert.Empty(t, backend.lastReq.Header.Get("X-TelemetryFlow-Key-ID"))
+			assert.Empty(t, backend.lastReq.Header.Get("X-TelemetryFlow-Key-Secret"))
+			assert.Empty(t, backend.lastReq.Header.Get("X-TelemetryFlow-Collector-ID"))
+		})
+	}
+}
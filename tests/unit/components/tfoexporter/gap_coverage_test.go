@@ -169,12 +169,13 @@ func TestExporter_ConfigureDirectAuth_OnlyKeyID(t *testing.T) {
 
 // TestExporter_AuthExtensionNotAuthProvider exercises the branch in start()
 // where the referenced extension exists but does not implement AuthProvider.
+// Without allow_anonymous, start() must fail rather than export without
+// credentials.
 func TestExporter_AuthExtensionNotAuthProvider(t *testing.T) {
 	authID := component.MustNewID("tfoauth")
 
 	// Build a real extension (the identity extension) that does not satisfy
-	// the AuthProvider interface — so the type assertion in start() fails and
-	// apiKeyID/apiKeySecret remain empty.
+	// the AuthProvider interface — so the type assertion in start() fails.
 	idFactory := tfoidentityextension.NewFactory()
 	idCfg := idFactory.CreateDefaultConfig()
 	idSet := extensiontest.NewNopSettings(component.MustNewType("tfoidentity"))
@@ -195,8 +196,7 @@ func TestExporter_AuthExtensionNotAuthProvider(t *testing.T) {
 	tracesExp, err := factory.CreateTraces(context.Background(), set, cfg)
 	require.NoError(t, err)
 
-	// Start should succeed (the extension simply doesn't provide creds),
-	// but apiKeyID/apiKeySecret remain empty.
-	require.NoError(t, tracesExp.Start(context.Background(), host))
-	require.NoError(t, tracesExp.Shutdown(context.Background()))
+	err = tracesExp.Start(context.Background(), host)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `extension "tfoauth" does not provide TFO credentials`)
 }
@@ -0,0 +1,147 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoexporter_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfoexporter"
+)
+
+var maintenanceID = component.MustNewID("tfomaintenance")
+
+// fakeMaintenance is a minimal tfoexporter.MaintenanceProvider extension
+// that pauses exports until resumed is closed.
+type fakeMaintenance struct {
+	component.StartFunc
+	component.ShutdownFunc
+	resumed chan struct{}
+}
+
+func (m *fakeMaintenance) Wait(ctx context.Context) error {
+	select {
+	case <-m.resumed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func startMaintenanceExporter(t *testing.T, backend *recordingBackend, host component.Host) (exporter.Traces, error) {
+	t.Helper()
+	factory := tfoexporter.NewFactory()
+	cfg := factory.CreateDefaultConfig().(*tfoexporter.Config)
+	cfg.Endpoint = backend.URL()
+	cfg.Maintenance = maintenanceID
+	disableRetry(cfg)
+
+	exp, err := factory.CreateTraces(context.Background(), exportertest.NewNopSettings(component.MustNewType("tfo")), cfg)
+	require.NoError(t, err)
+	if err := exp.Start(context.Background(), host); err != nil {
+		return nil, err
+	}
+	t.Cleanup(func() { _ = exp.Shutdown(context.Background()) })
+	return exp, nil
+}
+
+func TestExporter_Maintenance_HoldsExportsUntilResumed(t *testing.T) {
+	backend := newRecordingBackend(http.StatusOK)
+	t.Cleanup(backend.Close)
+	ext := &fakeMaintenance{resumed: make(chan struct{})}
+	exp, err := startMaintenanceExporter(t, backend, newExtHost(map[component.ID]component.Component{maintenanceID: ext}))
+	require.NoError(t, err)
+
+	done := make(chan error, 1)
+	go func() { done <- exp.ConsumeTraces(context.Background(), ptrace.NewTraces()) }()
+
+	select {
+	case <-backend.requested:
+		t.Fatal("export sent while paused")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(ext.resumed)
+	require.NoError(t, <-done)
+	assert.NotNil(t, backend.lastReq)
+}
+
+func TestExporter_Maintenance_HeldBatchOutlivesTimeout(t *testing.T) {
+	backend := newRecordingBackend(http.StatusOK)
+	t.Cleanup(backend.Close)
+	ext := &fakeMaintenance{resumed: make(chan struct{})}
+	exp, err := startMaintenanceExporter(t, backend, newExtHost(map[component.ID]component.Component{maintenanceID: ext}))
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	done := make(chan error, 1)
+	go func() { done <- exp.ConsumeTraces(ctx, ptrace.NewTraces()) }()
+
+	select {
+	case err := <-done:
+		t.Fatalf("held batch released before maintenance ended: %v", err)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	close(ext.resumed)
+	require.NoError(t, <-done, "the batch is sent once maintenance ends")
+	assert.NotNil(t, backend.lastReq)
+}
+
+func TestExporter_Maintenance_ShutdownEndsWait(t *testing.T) {
+	backend := newRecordingBackend(http.StatusOK)
+	t.Cleanup(backend.Close)
+	ext := &fakeMaintenance{resumed: make(chan struct{})}
+	exp, err := startMaintenanceExporter(t, backend, newExtHost(map[component.ID]component.Component{maintenanceID: ext}))
+	require.NoError(t, err)
+
+	done := make(chan error, 1)
+	go func() { done <- exp.ConsumeTraces(context.Background(), ptrace.NewTraces()) }()
+	time.Sleep(50 * time.Millisecond)
+
+	require.NoError(t, exp.Shutdown(context.Background()))
+	select {
+	case err := <-done:
+		assert.ErrorContains(t, err, "exports paused for maintenance")
+	case <-time.After(2 * time.Second):
+		t.Fatal("shutdown did not end the maintenance wait")
+	}
+	assert.Nil(t, backend.lastReq)
+}
+
+func TestExporter_Maintenance_ExtensionErrors(t *testing.T) {
+	backend := newRecordingBackend(http.StatusOK)
+	t.Cleanup(backend.Close)
+
+	_, err := startMaintenanceExporter(t, backend, newExtHost(nil))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `tfomaintenance extension "tfomaintenance" not found`)
+
+	_, err = startMaintenanceExporter(t, backend, newExtHost(map[component.ID]component.Component{
+		maintenanceID: &fakeClock{},
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not provide maintenance mode")
+}
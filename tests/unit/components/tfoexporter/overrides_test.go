@@ -0,0 +1,134 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoexporter_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfoexporter"
+)
+
+var overridesID = component.MustNewID("tfooverrides")
+
+// fakeOverrides is a minimal tfoexporter.OverridesProvider extension whose
+// overrides can be changed while the exporter runs.
+type fakeOverrides struct {
+	component.StartFunc
+	component.ShutdownFunc
+
+	mu                sync.Mutex
+	headers           map[string]string
+	keyID, keySecret  string
+	requestedExporter component.ID
+}
+
+func (o *fakeOverrides) ExporterHeaders(id component.ID) map[string]string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.requestedExporter = id
+	return o.headers
+}
+
+func (o *fakeOverrides) ExporterCredentials(component.ID) (string, string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return o.keyID, o.keySecret
+}
+
+func (o *fakeOverrides) set(headers map[string]string, keyID, keySecret string) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.headers, o.keyID, o.keySecret = headers, keyID, keySecret
+}
+
+func startOverridesExporter(t *testing.T, backend *recordingBackend, host component.Host) (exporter.Traces, error) {
+	t.Helper()
+	factory := tfoexporter.NewFactory()
+	cfg := factory.CreateDefaultConfig().(*tfoexporter.Config)
+	cfg.Endpoint = backend.URL()
+	cfg.Headers = configopaque.MapList{
+		{Name: "X-Tenant", Value: "configured"},
+		{Name: "X-Team", Value: "platform"},
+	}
+	cfg.Auth = &tfoexporter.AuthConfig{APIKeyID: "tfk_configured", APIKeySecret: "tfs_configured"}
+	cfg.Overrides = overridesID
+	disableRetry(cfg)
+
+	set := exportertest.NewNopSettings(component.MustNewType("tfo"))
+	set.ID = component.MustNewIDWithName("tfo", "primary")
+	exp, err := factory.CreateTraces(context.Background(), set, cfg)
+	require.NoError(t, err)
+	if err := exp.Start(context.Background(), host); err != nil {
+		return nil, err
+	}
+	t.Cleanup(func() { _ = exp.Shutdown(context.Background()) })
+	return exp, nil
+}
+
+func TestExporter_Overrides_ReplaceHeadersAndCredentials(t *testing.T) {
+	backend := newRecordingBackend(http.StatusOK)
+	t.Cleanup(backend.Close)
+	ext := &fakeOverrides{}
+	exp, err := startOverridesExporter(t, backend, newExtHost(map[component.ID]component.Component{overridesID: ext}))
+	require.NoError(t, err)
+
+	require.NoError(t, exp.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	require.NotNil(t, backend.lastReq)
+	assert.Equal(t, "configured", backend.lastReq.Header.Get("X-Tenant"))
+	assert.Equal(t, "platform", backend.lastReq.Header.Get("X-Team"))
+	assert.Equal(t, "tfk_configured", backend.lastReq.Header.Get("X-TelemetryFlow-Key-ID"))
+	assert.Equal(t, component.MustNewIDWithName("tfo", "primary"), ext.requestedExporter)
+
+	// Rotate a header and the API key without restarting the exporter.
+	ext.set(map[string]string{"x-tenant": "rotated"}, "tfk_rotated", "tfs_rotated")
+	require.NoError(t, exp.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	assert.Equal(t, "rotated", backend.lastReq.Header.Get("X-Tenant"))
+	assert.Equal(t, []string{"rotated"}, backend.lastReq.Header.Values("X-Tenant"))
+	assert.Equal(t, "platform", backend.lastReq.Header.Get("X-Team"))
+	assert.Equal(t, "tfk_rotated", backend.lastReq.Header.Get("X-TelemetryFlow-Key-ID"))
+	assert.Equal(t, "tfs_rotated", backend.lastReq.Header.Get("X-TelemetryFlow-Key-Secret"))
+
+	// Removing the overrides restores the configured values.
+	ext.set(nil, "", "")
+	require.NoError(t, exp.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+	assert.Equal(t, "configured", backend.lastReq.Header.Get("X-Tenant"))
+	assert.Equal(t, "tfk_configured", backend.lastReq.Header.Get("X-TelemetryFlow-Key-ID"))
+}
+
+func TestExporter_Overrides_ExtensionErrors(t *testing.T) {
+	backend := newRecordingBackend(http.StatusOK)
+	t.Cleanup(backend.Close)
+
+	_, err := startOverridesExporter(t, backend, newExtHost(nil))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `tfooverrides extension "tfooverrides" not found`)
+
+	_, err = startOverridesExporter(t, backend, newExtHost(map[component.ID]component.Component{
+		overridesID: &fakeClock{},
+	}))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "does not provide runtime overrides")
+}
@@ -0,0 +1,75 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoexporter_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfoexporter"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/payloadpreview"
+)
+
+func TestExporter_PayloadPreview(t *testing.T) {
+	for _, enc := range []tfoexporter.EncodingType{tfoexporter.EncodingProto, tfoexporter.EncodingJSON} {
+		t.Run(string(enc), func(t *testing.T) {
+			backend := newSpanBackend(t)
+			cfg := encodingConfig(backend.srv.URL)
+			cfg.Encoding = enc
+
+			set := exportertest.NewNopSettings(component.MustNewType("tfo"))
+			// Previews are process-wide: a new exporter per run starts
+			// without one.
+			set.ID = component.MustNewIDWithName("tfo", fmt.Sprintf("preview-%s-%d", enc, time.Now().UnixNano()))
+			exp, err := tfoexporter.NewFactory().CreateTraces(context.Background(), set, cfg)
+			require.NoError(t, err)
+			require.NoError(t, exp.Start(context.Background(), newExtHost(nil)))
+			t.Cleanup(func() { _ = exp.Shutdown(context.Background()) })
+
+			// Nothing is recorded until a preview starts.
+			require.NoError(t, exp.ConsumeTraces(context.Background(), makeSpans(1, 1)))
+			p, ok := payloadpreview.Lookup(set.ID.String())
+			require.True(t, ok)
+			_, ok = p.Status()
+			assert.False(t, ok)
+
+			_, err = p.Start("traces", 1, time.Minute)
+			require.NoError(t, err)
+			require.NoError(t, exp.ConsumeTraces(context.Background(), makeSpans(1, 3)))
+			require.NoError(t, exp.ConsumeTraces(context.Background(), makeSpans(1, 2)))
+
+			status, ok := p.Status()
+			require.True(t, ok)
+			assert.False(t, status.Active)
+			require.Len(t, status.Payloads, 1)
+			payload := status.Payloads[0]
+			assert.Equal(t, "traces", payload.Signal)
+			assert.Equal(t, backend.srv.URL+"/v2/traces", payload.Endpoint)
+			assert.Equal(t, len(backend.bodies[1]), payload.Size)
+
+			req := ptraceotlp.NewExportRequest()
+			require.NoError(t, req.UnmarshalJSON(payload.Body))
+			assert.Equal(t, 3, req.Traces().SpanCount())
+		})
+	}
+}
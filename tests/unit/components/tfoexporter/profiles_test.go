@@ -0,0 +1,172 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tfoexporter_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/extension/tfoauthextension"
+	"github.com/telemetryflow/telemetryflow-collector/components/tfoexporter"
+)
+
+// keyBackend records the span names received per API key ID and rejects
+// the keys in reject.
+type keyBackend struct {
+	srv    *httptest.Server
+	reject string
+
+	mu    sync.Mutex
+	spans map[string][]string
+}
+
+func newKeyBackend(t *testing.T, reject string) *keyBackend {
+	b := &keyBackend{reject: reject, spans: make(map[string][]string)}
+	b.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		keyID := r.Header.Get("X-TelemetryFlow-Key-ID")
+		if keyID == b.reject {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		req := ptraceotlp.NewExportRequest()
+		require.NoError(t, req.UnmarshalProto(body))
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		for _, rs := range req.Traces().ResourceSpans().All() {
+			for _, ss := range rs.ScopeSpans().All() {
+				for _, span := range ss.Spans().All() {
+					b.spans[keyID] = append(b.spans[keyID], span.Name())
+				}
+			}
+		}
+	}))
+	t.Cleanup(b.srv.Close)
+	return b
+}
+
+func (b *keyBackend) received() map[string][]string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	out := make(map[string][]string, len(b.spans))
+	for k, v := range b.spans {
+		out[k] = append([]string(nil), v...)
+		sort.Strings(out[k])
+	}
+	return out
+}
+
+func startProfileExporter(t *testing.T, endpoint string, tel *componenttest.Telemetry) func(ptrace.Traces) error {
+	t.Helper()
+	authCfg := &tfoauthextension.Config{
+		APIKeyID:         "tfk_default",
+		APIKeySecret:     "tfs_default",
+		ProfileAttribute: "team",
+		Profiles: map[string]tfoauthextension.Profile{
+			"payments": {APIKeyID: "tfk_payments", APIKeySecret: "tfs_payments", Values: []string{"payments", "billing"}},
+		},
+	}
+	require.NoError(t, authCfg.Validate())
+	authExt, err := tfoauthextension.NewFactory().Create(context.Background(),
+		extensiontest.NewNopSettings(component.MustNewType("tfoauth")), authCfg)
+	require.NoError(t, err)
+	authID := component.MustNewID("tfoauth")
+
+	factory := tfoexporter.NewFactory()
+	cfg := factory.CreateDefaultConfig().(*tfoexporter.Config)
+	cfg.Endpoint = endpoint
+	cfg.Auth = &tfoexporter.AuthConfig{Extension: authID}
+	disableRetry(cfg)
+	require.NoError(t, cfg.Validate())
+
+	set := exportertest.NewNopSettings(component.MustNewType("tfo"))
+	set.TelemetrySettings = tel.NewTelemetrySettings()
+	exp, err := factory.CreateTraces(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), newExtHost(map[component.ID]component.Component{authID: authExt})))
+	t.Cleanup(func() { _ = exp.Shutdown(context.Background()) })
+	return func(td ptrace.Traces) error { return exp.ConsumeTraces(context.Background(), td) }
+}
+
+// teamTraces returns one resource per team with a span named after it; an
+// empty team leaves the attribute unset.
+func teamTraces(teams ...string) ptrace.Traces {
+	td := ptrace.NewTraces()
+	for _, team := range teams {
+		rs := td.ResourceSpans().AppendEmpty()
+		name := "untagged"
+		if team != "" {
+			rs.Resource().Attributes().PutStr("team", team)
+			name = team
+		}
+		rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName(name)
+	}
+	return td
+}
+
+func profileRecords(t *testing.T, tel *componenttest.Telemetry) map[string]int64 {
+	t.Helper()
+	m, err := tel.GetMetric("tfo_exporter_profile_records")
+	require.NoError(t, err)
+	out := make(map[string]int64)
+	for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+		profile, _ := dp.Attributes.Value("profile")
+		outcome, _ := dp.Attributes.Value("outcome")
+		out[profile.AsString()+"/"+outcome.AsString()] = dp.Value
+	}
+	return out
+}
+
+func TestExporter_ProfileSelection(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { _ = tel.Shutdown(context.Background()) })
+	backend := newKeyBackend(t, "")
+	consume := startProfileExporter(t, backend.srv.URL, tel)
+
+	require.NoError(t, consume(teamTraces("payments", "", "billing", "checkout")))
+	assert.Equal(t, map[string][]string{
+		"tfk_payments": {"billing", "payments"},
+		"tfk_default":  {"checkout", "untagged"},
+	}, backend.received())
+	assert.Equal(t, map[string]int64{"payments/sent": 2, "default/sent": 2}, profileRecords(t, tel))
+}
+
+func TestExporter_ProfileFailureRetriesRest(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { _ = tel.Shutdown(context.Background()) })
+	backend := newKeyBackend(t, "tfk_payments")
+	consume := startProfileExporter(t, backend.srv.URL, tel)
+
+	err := consume(teamTraces("", "payments"))
+	require.Error(t, err)
+	assert.Equal(t, map[string][]string{"tfk_default": {"untagged"}}, backend.received())
+	assert.Equal(t, map[string]int64{"default/sent": 1, "payments/failed": 1}, profileRecords(t, tel))
+}
@@ -0,0 +1,64 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoexporter_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfoexporter"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/requestid"
+)
+
+func TestExporter_ForwardsRequestID(t *testing.T) {
+	var mu sync.Mutex
+	var ids []string
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		ids = append(ids, r.Header.Get(requestid.Header))
+		mu.Unlock()
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(backend.Close)
+
+	cfg := tfoexporter.NewFactory().CreateDefaultConfig().(*tfoexporter.Config)
+	cfg.Endpoint = backend.URL
+	disableRetry(cfg)
+	exp, err := tfoexporter.NewFactory().CreateTraces(context.Background(),
+		exportertest.NewNopSettings(component.MustNewType("tfo")), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), newExtHost(nil)))
+	t.Cleanup(func() { _ = exp.Shutdown(context.Background()) })
+
+	ctx := requestid.NewContext(context.Background(), "req-4230")
+	require.NoError(t, exp.ConsumeTraces(ctx, ptrace.NewTraces()))
+	require.NoError(t, exp.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.Len(t, ids, 2)
+	assert.Equal(t, "req-4230", ids[0])
+	assert.True(t, requestid.Valid(ids[1]), "batch ID generated without an ingest request ID")
+	assert.NotEqual(t, ids[0], ids[1])
+}
@@ -0,0 +1,121 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoexporter_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfoexporter"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/residency"
+)
+
+func residencyTraces() ptrace.Traces {
+	td := ptrace.NewTraces()
+	for _, region := range []string{"eu", "us"} {
+		rs := td.ResourceSpans().AppendEmpty()
+		rs.Resource().Attributes().PutStr(residency.DefaultAttribute, region)
+		rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("span-" + region)
+	}
+	return td
+}
+
+func residencyConfig(t *testing.T, backend *recordingBackend, mode residency.Mode) *tfoexporter.Config {
+	t.Helper()
+	factory := tfoexporter.NewFactory()
+	cfg := factory.CreateDefaultConfig().(*tfoexporter.Config)
+	cfg.Endpoint = backend.URL()
+	cfg.Residency.Enabled = true
+	cfg.Residency.Regions = []string{"eu"}
+	cfg.Residency.Mode = mode
+	disableRetry(cfg)
+	require.NoError(t, cfg.Validate())
+	return cfg
+}
+
+func TestExporter_Residency(t *testing.T) {
+	tests := []struct {
+		name      string
+		mode      residency.Mode
+		input     func() ptrace.Traces
+		wantSent  bool
+		wantSpans int
+	}{
+		{name: "enforce blocks other regions", mode: residency.ModeEnforce, input: residencyTraces, wantSent: true, wantSpans: 1},
+		{name: "audit exports everything", mode: residency.ModeAudit, input: residencyTraces, wantSent: true, wantSpans: 2},
+		{
+			name: "enforce skips fully blocked batch",
+			mode: residency.ModeEnforce,
+			input: func() ptrace.Traces {
+				td := ptrace.NewTraces()
+				rs := td.ResourceSpans().AppendEmpty()
+				rs.Resource().Attributes().PutStr(residency.DefaultAttribute, "us")
+				rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+				return td
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := newRecordingBackend(http.StatusOK)
+			t.Cleanup(backend.Close)
+			cfg := residencyConfig(t, backend, tt.mode)
+
+			factory := tfoexporter.NewFactory()
+			exp, err := factory.CreateTraces(context.Background(), exportertest.NewNopSettings(component.MustNewType("tfo")), cfg)
+			require.NoError(t, err)
+			assert.Equal(t, cfg.Residency.Blocks(), exp.Capabilities().MutatesData)
+			require.NoError(t, exp.Start(context.Background(), newExtHost(nil)))
+			t.Cleanup(func() { _ = exp.Shutdown(context.Background()) })
+
+			require.NoError(t, exp.ConsumeTraces(context.Background(), tt.input()))
+
+			if !tt.wantSent {
+				select {
+				case <-backend.requested:
+					t.Fatal("fully blocked batch must not be sent")
+				case <-time.After(100 * time.Millisecond):
+				}
+				return
+			}
+			backend.wait()
+			require.NotNil(t, backend.lastReq)
+			req := ptraceotlp.NewExportRequest()
+			require.NoError(t, req.UnmarshalProto(backend.lastBody))
+			assert.Equal(t, tt.wantSpans, req.Traces().SpanCount())
+		})
+	}
+}
+
+func TestConfig_Validate_Residency(t *testing.T) {
+	cfg := tfoexporter.NewFactory().CreateDefaultConfig().(*tfoexporter.Config)
+	assert.False(t, cfg.Residency.Enabled)
+	assert.Equal(t, residency.DefaultAttribute, cfg.Residency.Attribute)
+
+	cfg.Residency.Enabled = true
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "residency.regions")
+}
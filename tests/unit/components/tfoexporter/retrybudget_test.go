@@ -0,0 +1,85 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoexporter_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfoexporter"
+)
+
+func TestConfig_RetryBudgetValidation(t *testing.T) {
+	cfg := tfoexporter.NewFactory().CreateDefaultConfig().(*tfoexporter.Config)
+	assert.False(t, cfg.RetryBudget.Enabled)
+
+	cfg.RetryBudget.Enabled = true
+	cfg.RetryBudget.Ratio = -0.5
+	assert.ErrorContains(t, cfg.Validate(), "retry_budget.ratio")
+}
+
+func TestExporter_RetryBudgetSharedAcrossExporters(t *testing.T) {
+	var requests atomic.Int32
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(backend.Close)
+
+	cfg := tfoexporter.NewFactory().CreateDefaultConfig().(*tfoexporter.Config)
+	cfg.Endpoint = backend.URL
+	cfg.QueueConfig = configoptional.None[exporterhelper.QueueBatchConfig]()
+	cfg.RetryConfig.InitialInterval = time.Millisecond
+	cfg.RetryConfig.MaxInterval = time.Millisecond
+	cfg.RetryConfig.MaxElapsedTime = 5 * time.Second
+	cfg.RetryBudget.Enabled = true
+	cfg.RetryBudget.Ratio = 0
+	cfg.RetryBudget.MinRetriesPerSecond = 0.1 // one retry per 10s window
+	require.NoError(t, cfg.Validate())
+
+	consume := func(name string) error {
+		set := exportertest.NewNopSettings(component.MustNewType("tfo"))
+		set.ID = component.MustNewIDWithName("tfo", name)
+		exp, err := tfoexporter.NewFactory().CreateTraces(context.Background(), set, cfg)
+		require.NoError(t, err)
+		require.NoError(t, exp.Start(context.Background(), newExtHost(nil)))
+		t.Cleanup(func() { _ = exp.Shutdown(context.Background()) })
+		return exp.ConsumeTraces(context.Background(), ptrace.NewTraces())
+	}
+
+	err := consume("primary")
+	require.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.ErrorContains(t, err, "retry budget exhausted")
+	assert.Equal(t, int32(2), requests.Load(), "one retry was paid for")
+
+	err = consume("secondary")
+	require.Error(t, err)
+	assert.True(t, consumererror.IsPermanent(err))
+	assert.Equal(t, int32(3), requests.Load(), "the shared budget is empty, so no retry")
+}
@@ -0,0 +1,104 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tfoexporter_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/extension/tfoauthextension"
+	"github.com/telemetryflow/telemetryflow-collector/components/tfoexporter"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+var rotationAuthID = component.MustNewID("tfoauth")
+
+// startRotatingAuth starts a tfoauth extension with the primary key
+// tfk_primary and, if secondary, the secondary key tfk_secondary.
+func startRotatingAuth(t *testing.T, tel *componenttest.Telemetry, secondary bool) extension.Extension {
+	t.Helper()
+	cfg := &tfoauthextension.Config{APIKeyID: "tfk_primary", APIKeySecret: "tfs_primary"}
+	if secondary {
+		cfg.SecondaryAPIKeyID, cfg.SecondaryAPIKeySecret = "tfk_secondary", "tfs_secondary"
+	}
+	require.NoError(t, cfg.Validate())
+	set := extensiontest.NewNopSettings(component.MustNewType("tfoauth"))
+	set.TelemetrySettings = tel.NewTelemetrySettings()
+	ext, err := tfoauthextension.NewFactory().Create(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { _ = ext.Shutdown(context.Background()) })
+	return ext
+}
+
+// startRotationExporter starts a traces exporter using the auth extension.
+func startRotationExporter(t *testing.T, endpoint string, auth extension.Extension) func(ptrace.Traces) error {
+	t.Helper()
+	factory := tfoexporter.NewFactory()
+	cfg := factory.CreateDefaultConfig().(*tfoexporter.Config)
+	cfg.Endpoint = endpoint
+	cfg.Auth = &tfoexporter.AuthConfig{Extension: rotationAuthID}
+	disableRetry(cfg)
+	require.NoError(t, cfg.Validate())
+
+	exp, err := factory.CreateTraces(context.Background(), exportertest.NewNopSettings(component.MustNewType("tfo")), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), newExtHost(map[component.ID]component.Component{rotationAuthID: auth})))
+	t.Cleanup(func() { _ = exp.Shutdown(context.Background()) })
+	return func(td ptrace.Traces) error { return exp.ConsumeTraces(context.Background(), td) }
+}
+
+func TestExporter_RotatesToSecondaryKey(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { _ = tel.Shutdown(context.Background()) })
+	backend := newKeyBackend(t, "tfk_primary")
+	auth := startRotatingAuth(t, tel, true)
+	first := startRotationExporter(t, backend.srv.URL, auth)
+	second := startRotationExporter(t, backend.srv.URL, auth)
+
+	require.NoError(t, first(teamTraces("payments")), "the rejected request is sent again with the secondary key")
+	require.NoError(t, second(teamTraces("billing")))
+	require.NoError(t, first(teamTraces("checkout")))
+	assert.Equal(t, map[string][]string{"tfk_secondary": {"billing", "checkout", "payments"}}, backend.received())
+
+	m, err := tel.GetMetric(selfmetrics.AuthKeyRotations)
+	require.NoError(t, err)
+	points := m.Data.(metricdata.Sum[int64]).DataPoints
+	require.Len(t, points, 1)
+	assert.EqualValues(t, 1, points[0].Value, "exporters sharing the extension rotate once")
+}
+
+func TestExporter_RejectedKeyWithoutSecondary(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { _ = tel.Shutdown(context.Background()) })
+	backend := newKeyBackend(t, "tfk_primary")
+	consume := startRotationExporter(t, backend.srv.URL, startRotatingAuth(t, tel, false))
+
+	require.Error(t, consume(teamTraces("payments")))
+	assert.Empty(t, backend.received())
+	_, err := tel.GetMetric(selfmetrics.AuthKeyRotations)
+	assert.Error(t, err, "no rotation without a secondary key")
+}
@@ -0,0 +1,158 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoexporter_test
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfoexporter"
+)
+
+func TestConfig_Validate_ShutdownSpill(t *testing.T) {
+	cfg := tfoexporter.NewFactory().CreateDefaultConfig().(*tfoexporter.Config)
+	assert.Equal(t, tfoexporter.DefaultSpillDrainTimeout, cfg.ShutdownSpill.DrainTimeout)
+	assert.False(t, cfg.ShutdownSpill.Enabled())
+
+	cfg.ShutdownSpill.DrainTimeout = -time.Second
+	require.NoError(t, cfg.Validate(), "settings are ignored without a path")
+
+	cfg.ShutdownSpill.Path = filepath.Join(t.TempDir(), "spill.jsonl")
+	assert.ErrorContains(t, cfg.Validate(), "shutdown_spill.drain_timeout must not be negative")
+
+	cfg.ShutdownSpill.DrainTimeout = 0
+	require.NoError(t, cfg.Validate())
+
+	storage := component.MustNewID("file_storage")
+	cfg.QueueConfig.GetOrInsertDefault().StorageID = &storage
+	assert.ErrorContains(t, cfg.Validate(), "shutdown_spill requires an in-memory sending_queue")
+}
+
+// spillExporter starts a logs exporter with a single queue consumer that
+// sends to endpoint and spills to path.
+func spillExporter(t *testing.T, tel *componenttest.Telemetry, endpoint, path string, drainTimeout time.Duration) exporter.Logs {
+	t.Helper()
+	factory := tfoexporter.NewFactory()
+	cfg := factory.CreateDefaultConfig().(*tfoexporter.Config)
+	cfg.Endpoint = endpoint
+	cfg.Timeout = 200 * time.Millisecond
+	cfg.QueueConfig.GetOrInsertDefault().NumConsumers = 1
+	cfg.ShutdownSpill.Path = path
+	cfg.ShutdownSpill.DrainTimeout = drainTimeout
+	disableRetry(cfg)
+	require.NoError(t, cfg.Validate())
+
+	set := exportertest.NewNopSettings(component.MustNewType("tfo"))
+	set.TelemetrySettings = tel.NewTelemetrySettings()
+	exp, err := factory.CreateLogs(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	return exp
+}
+
+func logBatch(n int) plog.Logs {
+	ld := plog.NewLogs()
+	records := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for range n {
+		records.AppendEmpty().Body().SetStr("queued")
+	}
+	return ld
+}
+
+// spilledRecords parses the spill file and returns its log records per line.
+func spilledRecords(t *testing.T, path string) []int {
+	t.Helper()
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+
+	var out []int
+	var u plog.JSONUnmarshaler
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		ld, err := u.UnmarshalLogs(scanner.Bytes())
+		require.NoError(t, err)
+		out = append(out, ld.LogRecordCount())
+	}
+	require.NoError(t, scanner.Err())
+	return out
+}
+
+func TestExporter_ShutdownSpill_UnreachableBackend(t *testing.T) {
+	var hits atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		hits.Add(1)
+		// Hang until the client gives up, as an unreachable backend would.
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { _ = tel.Shutdown(context.Background()) })
+
+	path := filepath.Join(t.TempDir(), "spill", "tfo.jsonl")
+	exp := spillExporter(t, tel, srv.URL, path, 0)
+	for i := 1; i <= 4; i++ {
+		require.NoError(t, exp.ConsumeLogs(context.Background(), logBatch(i)))
+	}
+	// The consumer is stuck on the first batch; the others are queued.
+	require.Eventually(t, func() bool { return hits.Load() == 1 }, 5*time.Second, 10*time.Millisecond)
+
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	assert.Equal(t, int32(1), hits.Load(), "after the drain timeout the backend is not contacted")
+	assert.ElementsMatch(t, []int{1, 2, 3, 4}, spilledRecords(t, path))
+
+	assert.Equal(t, map[string]int64{"": 10}, dryRunSum(t, tel, "tfo_exporter_shutdown_spilled"))
+}
+
+func TestExporter_ShutdownSpill_HealthyBackend(t *testing.T) {
+	var requests atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		requests.Add(1)
+	}))
+	t.Cleanup(srv.Close)
+
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { _ = tel.Shutdown(context.Background()) })
+
+	path := filepath.Join(t.TempDir(), "tfo.jsonl")
+	exp := spillExporter(t, tel, srv.URL, path, time.Minute)
+	for i := 1; i <= 4; i++ {
+		require.NoError(t, exp.ConsumeLogs(context.Background(), logBatch(i)))
+	}
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	assert.Equal(t, int32(4), requests.Load(), "the queue drains to the backend")
+	assert.NoFileExists(t, path)
+}
@@ -0,0 +1,181 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoexporter_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfoexporter"
+)
+
+// statusHost records the component status events reported to it.
+type statusHost struct {
+	component.Host
+	mu     sync.Mutex
+	events []componentstatus.Status
+}
+
+func (h *statusHost) Report(ev *componentstatus.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = append(h.events, ev.Status())
+}
+
+func (h *statusHost) statuses() []componentstatus.Status {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]componentstatus.Status(nil), h.events...)
+}
+
+// authBackend answers 401 until accept is set.
+func authBackend(t *testing.T) (*httptest.Server, *atomic.Bool) {
+	t.Helper()
+	accept := new(atomic.Bool)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.Copy(io.Discard, r.Body)
+		if !accept.Load() {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+	return srv, accept
+}
+
+func warmupConfig(endpoint string, policy tfoexporter.WarmupPolicy) *tfoexporter.Config {
+	cfg := encodingConfig(endpoint)
+	cfg.Auth = &tfoexporter.AuthConfig{
+		APIKeyID:     configopaque.String("tfk_warmup"),
+		APIKeySecret: configopaque.String("tfs_warmup"),
+	}
+	cfg.Warmup.Enabled = true
+	cfg.Warmup.Policy = policy
+	return cfg
+}
+
+func TestConfig_WarmupValidation(t *testing.T) {
+	cfg := warmupConfig("http://localhost:4318", tfoexporter.WarmupPolicyFail)
+	require.NoError(t, cfg.Validate())
+
+	cfg.Warmup.Policy = "retry"
+	assert.ErrorContains(t, cfg.Validate(), "warmup.policy")
+
+	cfg.Warmup.Policy = tfoexporter.WarmupPolicyIgnore
+	cfg.Warmup.Timeout = 0
+	assert.ErrorContains(t, cfg.Validate(), "warmup.timeout")
+
+	cfg.Warmup.Enabled = false
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestWarmup_SendsEmptyRequestForSignal(t *testing.T) {
+	var (
+		mu    sync.Mutex
+		paths []string
+		key   string
+		body  []byte
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		paths = append(paths, r.URL.Path)
+		key = r.Header.Get("X-TelemetryFlow-Key-ID")
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusOK)
+	}))
+	t.Cleanup(srv.Close)
+
+	exp, err := tfoexporter.NewFactory().CreateMetrics(context.Background(),
+		exportertest.NewNopSettings(component.MustNewType("tfo")),
+		warmupConfig(srv.URL, tfoexporter.WarmupPolicyFail))
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { _ = exp.Shutdown(context.Background()) })
+
+	mu.Lock()
+	defer mu.Unlock()
+	assert.Equal(t, []string{"/v2/metrics"}, paths)
+	assert.Equal(t, "tfk_warmup", key)
+	req := pmetricotlp.NewExportRequest()
+	require.NoError(t, req.UnmarshalProto(body))
+	assert.Equal(t, 0, req.Metrics().ResourceMetrics().Len())
+}
+
+func TestWarmup_FailPolicyAbortsStart(t *testing.T) {
+	srv, _ := authBackend(t)
+
+	exp, err := tfoexporter.NewFactory().CreateTraces(context.Background(),
+		exportertest.NewNopSettings(component.MustNewType("tfo")),
+		warmupConfig(srv.URL, tfoexporter.WarmupPolicyFail))
+	require.NoError(t, err)
+	err = exp.Start(context.Background(), componenttest.NewNopHost())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "credentials rejected (status 401)")
+	_ = exp.Shutdown(context.Background())
+}
+
+func TestWarmup_DegradePolicyReportsUntilExportSucceeds(t *testing.T) {
+	srv, accept := authBackend(t)
+
+	exp, err := tfoexporter.NewFactory().CreateTraces(context.Background(),
+		exportertest.NewNopSettings(component.MustNewType("tfo")),
+		warmupConfig(srv.URL, tfoexporter.WarmupPolicyDegrade))
+	require.NoError(t, err)
+	host := &statusHost{Host: componenttest.NewNopHost()}
+	require.NoError(t, exp.Start(context.Background(), host))
+	t.Cleanup(func() { _ = exp.Shutdown(context.Background()) })
+	assert.Equal(t, []componentstatus.Status{componentstatus.StatusRecoverableError}, host.statuses())
+
+	// Still rejected: stays degraded.
+	require.Error(t, exp.ConsumeTraces(context.Background(), makeSpans(1, 1)))
+	assert.Len(t, host.statuses(), 1)
+
+	accept.Store(true)
+	require.NoError(t, exp.ConsumeTraces(context.Background(), makeSpans(1, 1)))
+	require.NoError(t, exp.ConsumeTraces(context.Background(), makeSpans(1, 1)))
+	assert.Equal(t, []componentstatus.Status{
+		componentstatus.StatusRecoverableError,
+		componentstatus.StatusOK,
+	}, host.statuses())
+}
+
+func TestWarmup_IgnorePolicyOnlyLogs(t *testing.T) {
+	srv, _ := authBackend(t)
+
+	exp, err := tfoexporter.NewFactory().CreateLogs(context.Background(),
+		exportertest.NewNopSettings(component.MustNewType("tfo")),
+		warmupConfig(srv.URL, tfoexporter.WarmupPolicyIgnore))
+	require.NoError(t, err)
+	host := &statusHost{Host: componenttest.NewNopHost()}
+	require.NoError(t, exp.Start(context.Background(), host))
+	t.Cleanup(func() { _ = exp.Shutdown(context.Background()) })
+	assert.Empty(t, host.statuses())
+}
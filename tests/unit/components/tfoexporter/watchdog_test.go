@@ -0,0 +1,78 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoexporter_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfoexporter"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/watchdog"
+)
+
+func TestExporter_WatchdogAbortsWedgedSend(t *testing.T) {
+	release := make(chan struct{})
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-release:
+		}
+	}))
+	t.Cleanup(backend.Close)
+	t.Cleanup(func() { close(release) })
+
+	factory := tfoexporter.NewFactory()
+	cfg := factory.CreateDefaultConfig().(*tfoexporter.Config)
+	cfg.Endpoint = backend.URL
+	cfg.Watchdog = watchdog.Config{
+		Enabled:       true,
+		CheckInterval: 10 * time.Millisecond,
+		StallTimeout:  100 * time.Millisecond,
+		MaxRestarts:   1,
+	}
+	disableRetry(cfg)
+	require.NoError(t, cfg.Validate())
+
+	set := exportertest.NewNopSettings(component.MustNewType("tfo"))
+	tracesExp, err := factory.CreateTraces(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NoError(t, tracesExp.Start(context.Background(), newExtHost(nil)))
+	t.Cleanup(func() { _ = tracesExp.Shutdown(context.Background()) })
+
+	start := time.Now()
+	err = tracesExp.ConsumeTraces(context.Background(), ptrace.NewTraces())
+	require.Error(t, err, "the wedged send is aborted by the watchdog restart")
+	assert.Less(t, time.Since(start), cfg.Timeout)
+}
+
+func TestConfig_Validate_Watchdog(t *testing.T) {
+	cfg := tfoexporter.NewFactory().CreateDefaultConfig().(*tfoexporter.Config)
+	assert.False(t, cfg.Watchdog.Enabled)
+
+	cfg.Watchdog.Enabled = true
+	cfg.Watchdog.MaxRestarts = -1
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "watchdog.max_restarts")
+}
@@ -0,0 +1,106 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfofleetreceiver_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfofleetreceiver"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/clientconf"
+)
+
+func child(site, endpoint string) tfofleetreceiver.ChildConfig {
+	return tfofleetreceiver.ChildConfig{Site: site, ClientConfig: clientconf.NewDefaultClientConfig(endpoint)}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	valid := func() *tfofleetreceiver.Config {
+		cfg := tfofleetreceiver.NewFactory().CreateDefaultConfig().(*tfofleetreceiver.Config)
+		cfg.Children = []tfofleetreceiver.ChildConfig{
+			child("jakarta", "http://jkt-edge-1:8888/metrics"),
+			child("jakarta", "http://jkt-edge-2:8888/metrics"),
+		}
+		return cfg
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(*tfofleetreceiver.Config)
+		wantErr string
+	}{
+		{name: "valid", modify: func(*tfofleetreceiver.Config) {}},
+		{
+			name:    "zero collection interval",
+			modify:  func(cfg *tfofleetreceiver.Config) { cfg.CollectionInterval = 0 },
+			wantErr: "collection_interval",
+		},
+		{
+			name:    "no children",
+			modify:  func(cfg *tfofleetreceiver.Config) { cfg.Children = nil },
+			wantErr: "at least one child",
+		},
+		{
+			name:    "missing site",
+			modify:  func(cfg *tfofleetreceiver.Config) { cfg.Children[1].Site = "" },
+			wantErr: "children[1]: site is required",
+		},
+		{
+			name:    "missing endpoint",
+			modify:  func(cfg *tfofleetreceiver.Config) { cfg.Children[0].Endpoint = "" },
+			wantErr: "children[0]: endpoint is required",
+		},
+		{
+			name: "duplicate endpoint",
+			modify: func(cfg *tfofleetreceiver.Config) {
+				cfg.Children[1].Endpoint = cfg.Children[0].Endpoint
+			},
+			wantErr: "duplicate endpoint",
+		},
+		{
+			name:    "invalid endpoint",
+			modify:  func(cfg *tfofleetreceiver.Config) { cfg.Children[0].Endpoint = "jkt-edge-1:8888" },
+			wantErr: "children[0]: invalid endpoint",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid()
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestFactory_DefaultConfig(t *testing.T) {
+	factory := tfofleetreceiver.NewFactory()
+	assert.Equal(t, component.MustNewType("tfofleet"), factory.Type())
+	assert.Equal(t, component.StabilityLevelAlpha, factory.MetricsStability())
+
+	cfg := factory.CreateDefaultConfig().(*tfofleetreceiver.Config)
+	assert.Equal(t, 30*time.Second, cfg.CollectionInterval)
+	assert.Empty(t, cfg.Children)
+}
@@ -0,0 +1,208 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfofleetreceiver_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfofleetreceiver"
+)
+
+// exposition is the internal telemetry page of a child with the given
+// totals, in the naming of current collectors.
+func exposition(spans, failedSpans, logs, queue int) string {
+	return fmt.Sprintf(`# HELP otelcol_receiver_accepted_spans_total Spans accepted.
+# TYPE otelcol_receiver_accepted_spans_total counter
+otelcol_receiver_accepted_spans_total{receiver="otlp",transport="grpc"} %d
+# TYPE otelcol_receiver_accepted_log_records_total counter
+otelcol_receiver_accepted_log_records_total{receiver="otlp",transport="grpc"} %d
+# TYPE otelcol_exporter_send_failed_spans_total counter
+otelcol_exporter_send_failed_spans_total{exporter="otlp"} %d
+# TYPE otelcol_exporter_queue_size gauge
+otelcol_exporter_queue_size{exporter="otlp"} %d
+# TYPE otelcol_process_uptime_seconds_total counter
+otelcol_process_uptime_seconds_total 42
+`, spans, logs, failedSpans, queue)
+}
+
+// tfoExposition is the internal telemetry page of a child running the TFO
+// OTLP receiver, in the naming of older collectors.
+const tfoExposition = `# TYPE otelcol_receiver_accepted_spans counter
+otelcol_receiver_accepted_spans{receiver="otlp"} 50
+# TYPE tfo_receiver_request_records histogram
+tfo_receiver_request_records_bucket{signal="traces",protocol="http",endpoint="v1",le="+Inf"} 2
+tfo_receiver_request_records_sum{signal="traces",protocol="http",endpoint="v1"} 20
+tfo_receiver_request_records_count{signal="traces",protocol="http",endpoint="v1"} 2
+# TYPE tfo_receiver_provenance_rejected_total counter
+tfo_receiver_provenance_rejected_total{signal="logs",reason="loop"} 4
+# TYPE otelcol_exporter_queue_size gauge
+otelcol_exporter_queue_size{exporter="tfo"} 2
+`
+
+// page serves a settable Prometheus text page.
+type page struct {
+	body atomic.Value
+}
+
+func newPage(t *testing.T, body string) (*page, string) {
+	p := &page{}
+	p.body.Store(body)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+		_, _ = w.Write([]byte(p.body.Load().(string)))
+	}))
+	t.Cleanup(srv.Close)
+	return p, srv.URL + "/metrics"
+}
+
+// value returns the value of the data point of the last scrape in sink
+// with metric name and the given attributes, or -1 if there is none.
+func value(sink *consumertest.MetricsSink, name string, attrs map[string]string) int64 {
+	all := sink.AllMetrics()
+	if len(all) == 0 {
+		return -1
+	}
+	rms := all[len(all)-1].ResourceMetrics()
+	for _, rm := range rms.All() {
+		for _, sm := range rm.ScopeMetrics().All() {
+			for _, m := range sm.Metrics().All() {
+				if m.Name() != name {
+					continue
+				}
+				var dps pmetric.NumberDataPointSlice
+				if m.Type() == pmetric.MetricTypeSum {
+					dps = m.Sum().DataPoints()
+				} else {
+					dps = m.Gauge().DataPoints()
+				}
+				for _, dp := range dps.All() {
+					if matches(dp.Attributes(), attrs) {
+						return dp.IntValue()
+					}
+				}
+			}
+		}
+	}
+	return -1
+}
+
+func matches(got pcommon.Map, want map[string]string) bool {
+	if got.Len() != len(want) {
+		return false
+	}
+	for k, v := range want {
+		if a, ok := got.Get(k); !ok || a.Str() != v {
+			return false
+		}
+	}
+	return true
+}
+
+func TestReceiver_MergesChildrenPerSite(t *testing.T) {
+	edge1, edge1URL := newPage(t, exposition(100, 3, 10, 5))
+	_, edge2URL := newPage(t, tfoExposition)
+
+	down := httptest.NewServer(http.NotFoundHandler())
+	downURL := down.URL + "/metrics"
+	down.Close()
+
+	factory := tfofleetreceiver.NewFactory()
+	cfg := factory.CreateDefaultConfig().(*tfofleetreceiver.Config)
+	cfg.CollectionInterval = 10 * time.Millisecond
+	cfg.InitialDelay = 0
+	cfg.Children = []tfofleetreceiver.ChildConfig{
+		child("jakarta", edge1URL),
+		child("jakarta", edge2URL),
+		child("surabaya", downURL),
+	}
+	require.NoError(t, cfg.Validate())
+
+	sink := new(consumertest.MetricsSink)
+	rcv, err := factory.CreateMetrics(context.Background(), receivertest.NewNopSettings(factory.Type()), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, rcv.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, rcv.Shutdown(context.Background())) })
+
+	jakarta := func(signal string) map[string]string {
+		return map[string]string{"site": "jakarta", "signal": signal}
+	}
+	require.Eventually(t, func() bool {
+		return value(sink, tfofleetreceiver.MetricReceived, jakarta("traces")) == 170
+	}, 5*time.Second, 5*time.Millisecond)
+
+	assert.Equal(t, int64(10), value(sink, tfofleetreceiver.MetricReceived, jakarta("logs")))
+	assert.Equal(t, int64(0), value(sink, tfofleetreceiver.MetricReceived, jakarta("metrics")))
+	assert.Equal(t, int64(3), value(sink, tfofleetreceiver.MetricDropped, jakarta("traces")))
+	assert.Equal(t, int64(4), value(sink, tfofleetreceiver.MetricDropped, jakarta("logs")))
+	assert.Equal(t, int64(7), value(sink, tfofleetreceiver.MetricQueueSize, map[string]string{"site": "jakarta"}))
+	assert.Equal(t, int64(2), value(sink, tfofleetreceiver.MetricCollectors, map[string]string{"site": "jakarta", "state": "up"}))
+	assert.Equal(t, int64(0), value(sink, tfofleetreceiver.MetricCollectors, map[string]string{"site": "jakarta", "state": "down"}))
+	assert.Equal(t, int64(1), value(sink, tfofleetreceiver.MetricCollectors, map[string]string{"site": "surabaya", "state": "down"}))
+	assert.Equal(t, int64(0), value(sink, tfofleetreceiver.MetricReceived, map[string]string{"site": "surabaya", "signal": "traces"}))
+
+	// A restarted child reports lower counters; the fleet total keeps
+	// growing by the new counts.
+	edge1.body.Store(exposition(30, 0, 10, 0))
+	require.Eventually(t, func() bool {
+		return value(sink, tfofleetreceiver.MetricReceived, jakarta("traces")) == 200
+	}, 5*time.Second, 5*time.Millisecond)
+	assert.Equal(t, int64(2), value(sink, tfofleetreceiver.MetricQueueSize, map[string]string{"site": "jakarta"}))
+}
+
+func TestReceiver_UnreachableChildKeepsTotals(t *testing.T) {
+	edge, edgeURL := newPage(t, exposition(100, 0, 0, 0))
+
+	factory := tfofleetreceiver.NewFactory()
+	cfg := factory.CreateDefaultConfig().(*tfofleetreceiver.Config)
+	cfg.CollectionInterval = 10 * time.Millisecond
+	cfg.InitialDelay = 0
+	cfg.Children = []tfofleetreceiver.ChildConfig{child("jakarta", edgeURL)}
+
+	sink := new(consumertest.MetricsSink)
+	rcv, err := factory.CreateMetrics(context.Background(), receivertest.NewNopSettings(factory.Type()), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, rcv.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, rcv.Shutdown(context.Background())) })
+
+	traces := map[string]string{"site": "jakarta", "signal": "traces"}
+	require.Eventually(t, func() bool {
+		return value(sink, tfofleetreceiver.MetricReceived, traces) == 100
+	}, 5*time.Second, 5*time.Millisecond)
+
+	edge.body.Store("not a metrics page {")
+	require.Eventually(t, func() bool {
+		return value(sink, tfofleetreceiver.MetricCollectors, map[string]string{"site": "jakarta", "state": "down"}) == 1
+	}, 5*time.Second, 5*time.Millisecond)
+	assert.Equal(t, int64(100), value(sink, tfofleetreceiver.MetricReceived, traces))
+
+	edge.body.Store(exposition(120, 0, 0, 0))
+	require.Eventually(t, func() bool {
+		return value(sink, tfofleetreceiver.MetricReceived, traces) == 120
+	}, 5*time.Second, 5*time.Millisecond)
+}
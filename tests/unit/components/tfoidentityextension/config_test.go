@@ -15,6 +15,7 @@
 package tfoidentityextension_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -68,6 +69,64 @@ func TestConfig_Validate(t *testing.T) {
 	}
 }
 
+func TestConfig_ValidateIDSettings(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  tfoidentityextension.Config
+		wantErr string
+	}{
+		{
+			name:   "uuid7 scheme",
+			config: tfoidentityextension.Config{IDScheme: tfoidentityextension.IDSchemeUUID7},
+		},
+		{
+			name:   "ulid scheme with namespace",
+			config: tfoidentityextension.Config{IDScheme: tfoidentityextension.IDSchemeULID, IDNamespace: "jkt-dc1"},
+		},
+		{
+			name:   "operator id with separators",
+			config: tfoidentityextension.Config{ID: "site-a:edge_01.prod"},
+		},
+		{
+			name:    "unknown scheme",
+			config:  tfoidentityextension.Config{IDScheme: "uuid1"},
+			wantErr: "id_scheme",
+		},
+		{
+			name:    "namespace with uppercase",
+			config:  tfoidentityextension.Config{IDNamespace: "JKT"},
+			wantErr: "id_namespace",
+		},
+		{
+			name:    "namespace too long",
+			config:  tfoidentityextension.Config{IDNamespace: strings.Repeat("a", 33)},
+			wantErr: "id_namespace",
+		},
+		{
+			name:    "operator id with spaces",
+			config:  tfoidentityextension.Config{ID: "my collector"},
+			wantErr: "id",
+		},
+		{
+			name:    "operator id too long",
+			config:  tfoidentityextension.Config{ID: strings.Repeat("a", 129)},
+			wantErr: "at most 128",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.config.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
 func TestConfig_Defaults(t *testing.T) {
 	cfg := tfoidentityextension.Config{}
 
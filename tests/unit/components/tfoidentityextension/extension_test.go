@@ -17,6 +17,7 @@ package tfoidentityextension_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -123,6 +124,78 @@ func TestExtension_AutoGeneratedID(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestExtension_IDSchemes(t *testing.T) {
+	tests := []struct {
+		name      string
+		scheme    tfoidentityextension.IDScheme
+		namespace string
+		pattern   string
+	}{
+		{
+			name:    "default is uuid4",
+			pattern: `^[0-9a-f]{8}-[0-9a-f]{4}-4[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`,
+		},
+		{
+			name:    "uuid7",
+			scheme:  tfoidentityextension.IDSchemeUUID7,
+			pattern: `^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`,
+		},
+		{
+			name:    "ulid",
+			scheme:  tfoidentityextension.IDSchemeULID,
+			pattern: `^[0-9A-HJKMNP-TV-Z]{26}$`,
+		},
+		{
+			name:      "namespaced ulid",
+			scheme:    tfoidentityextension.IDSchemeULID,
+			namespace: "jkt-dc1",
+			pattern:   `^jkt-dc1-[0-9A-HJKMNP-TV-Z]{26}$`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			factory := tfoidentityextension.NewFactory()
+			cfg := factory.CreateDefaultConfig().(*tfoidentityextension.Config)
+			cfg.IDScheme = tt.scheme
+			cfg.IDNamespace = tt.namespace
+			require.NoError(t, cfg.Validate())
+
+			ext, err := factory.Create(context.Background(), extensiontest.NewNopSettings(factory.Type()), cfg)
+			require.NoError(t, err)
+			require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+			defer func() { require.NoError(t, ext.Shutdown(context.Background())) }()
+
+			provider, ok := ext.(interface{ GetCollectorID() string })
+			require.True(t, ok)
+			assert.Regexp(t, tt.pattern, provider.GetCollectorID())
+		})
+	}
+}
+
+func TestExtension_SortableIDs(t *testing.T) {
+	for _, scheme := range []tfoidentityextension.IDScheme{
+		tfoidentityextension.IDSchemeUUID7,
+		tfoidentityextension.IDSchemeULID,
+	} {
+		t.Run(string(scheme), func(t *testing.T) {
+			factory := tfoidentityextension.NewFactory()
+			var ids []string
+			for range 2 {
+				cfg := factory.CreateDefaultConfig().(*tfoidentityextension.Config)
+				cfg.IDScheme = scheme
+				ext, err := factory.Create(context.Background(), extensiontest.NewNopSettings(factory.Type()), cfg)
+				require.NoError(t, err)
+				require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+				ids = append(ids, ext.(interface{ GetCollectorID() string }).GetCollectorID())
+				require.NoError(t, ext.Shutdown(context.Background()))
+				time.Sleep(2 * time.Millisecond)
+			}
+			assert.Less(t, ids[0], ids[1], "later IDs sort after earlier ones")
+		})
+	}
+}
+
 func TestExtension_AutoDetectedHostname(t *testing.T) {
 	factory := tfoidentityextension.NewFactory()
 	cfg := factory.CreateDefaultConfig().(*tfoidentityextension.Config)
@@ -0,0 +1,169 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfokafkareceiver_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtls"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfokafkareceiver"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*tfokafkareceiver.Config)
+		wantErr string
+	}{
+		{name: "default", modify: func(*tfokafkareceiver.Config) {}},
+		{
+			name: "full",
+			modify: func(cfg *tfokafkareceiver.Config) {
+				cfg.TLS = &configtls.ClientConfig{}
+				cfg.SASL = &tfokafkareceiver.SASLConfig{Mechanism: "SCRAM-SHA-512", Username: "tfo", Password: "secret"}
+				cfg.Logs.Encoding = "otlp_json"
+				cfg.Commit.Strategy = "sync"
+				cfg.Commit.Interval = 0
+				cfg.DeadLetter.Topic = "otlp_dead_letter"
+			},
+		},
+		{
+			name:    "no brokers",
+			modify:  func(cfg *tfokafkareceiver.Config) { cfg.Brokers = nil },
+			wantErr: "at least one broker is required",
+		},
+		{
+			name:    "empty broker",
+			modify:  func(cfg *tfokafkareceiver.Config) { cfg.Brokers = []string{"kafka:9092", ""} },
+			wantErr: "brokers must not be empty",
+		},
+		{
+			name:    "missing client id",
+			modify:  func(cfg *tfokafkareceiver.Config) { cfg.ClientID = "" },
+			wantErr: "client_id is required",
+		},
+		{
+			name:    "missing group id",
+			modify:  func(cfg *tfokafkareceiver.Config) { cfg.GroupID = "" },
+			wantErr: "group_id is required",
+		},
+		{
+			name: "sasl mechanism",
+			modify: func(cfg *tfokafkareceiver.Config) {
+				cfg.SASL = &tfokafkareceiver.SASLConfig{Mechanism: "GSSAPI", Username: "tfo", Password: "secret"}
+			},
+			wantErr: "sasl: mechanism",
+		},
+		{
+			name: "sasl without password",
+			modify: func(cfg *tfokafkareceiver.Config) {
+				cfg.SASL = &tfokafkareceiver.SASLConfig{Mechanism: "PLAIN", Username: "tfo"}
+			},
+			wantErr: "sasl: password is required",
+		},
+		{
+			name:    "no topics",
+			modify:  func(cfg *tfokafkareceiver.Config) { cfg.Metrics.Topics = nil },
+			wantErr: "metrics: at least one topic is required",
+		},
+		{
+			name:    "empty topic",
+			modify:  func(cfg *tfokafkareceiver.Config) { cfg.Logs.Topics = []string{"otlp_logs", ""} },
+			wantErr: "logs: topics[1]: topic is required",
+		},
+		{
+			name:    "duplicate topic",
+			modify:  func(cfg *tfokafkareceiver.Config) { cfg.Traces.Topics = []string{"otlp_spans", "otlp_spans"} },
+			wantErr: `traces: topics[1]: duplicate topic "otlp_spans"`,
+		},
+		{
+			name:    "topic shared by signals",
+			modify:  func(cfg *tfokafkareceiver.Config) { cfg.Logs.Topics = []string{"otlp_metrics"} },
+			wantErr: `logs: topic "otlp_metrics" is also consumed by metrics`,
+		},
+		{
+			name:    "encoding",
+			modify:  func(cfg *tfokafkareceiver.Config) { cfg.Traces.Encoding = "jaeger_proto" },
+			wantErr: "traces: encoding",
+		},
+		{
+			name:    "initial offset",
+			modify:  func(cfg *tfokafkareceiver.Config) { cfg.InitialOffset = "oldest" },
+			wantErr: "initial_offset",
+		},
+		{
+			name:    "commit strategy",
+			modify:  func(cfg *tfokafkareceiver.Config) { cfg.Commit.Strategy = "async" },
+			wantErr: "commit: strategy",
+		},
+		{
+			name:    "periodic commit without interval",
+			modify:  func(cfg *tfokafkareceiver.Config) { cfg.Commit.Interval = 0 },
+			wantErr: "commit: interval must be positive",
+		},
+		{
+			name:    "consumed dead letter topic",
+			modify:  func(cfg *tfokafkareceiver.Config) { cfg.DeadLetter.Topic = "otlp_logs" },
+			wantErr: `dead_letter: topic "otlp_logs" is consumed by logs`,
+		},
+		{
+			name:    "session timeout",
+			modify:  func(cfg *tfokafkareceiver.Config) { cfg.SessionTimeout = 0 },
+			wantErr: "session_timeout must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tfokafkareceiver.NewFactory().CreateDefaultConfig().(*tfokafkareceiver.Config)
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestFactory_DefaultConfig(t *testing.T) {
+	factory := tfokafkareceiver.NewFactory()
+	assert.Equal(t, component.MustNewType("tfokafka"), factory.Type())
+	assert.Equal(t, component.StabilityLevelAlpha, factory.TracesStability())
+	assert.Equal(t, component.StabilityLevelAlpha, factory.MetricsStability())
+	assert.Equal(t, component.StabilityLevelAlpha, factory.LogsStability())
+
+	cfg := factory.CreateDefaultConfig().(*tfokafkareceiver.Config)
+	assert.Equal(t, []string{"localhost:9092"}, cfg.Brokers)
+	assert.Equal(t, "tfo-collector", cfg.ClientID)
+	assert.Equal(t, "tfo-collector", cfg.GroupID)
+	assert.Equal(t, []string{"otlp_spans"}, cfg.Traces.Topics)
+	assert.Equal(t, []string{"otlp_metrics"}, cfg.Metrics.Topics)
+	assert.Equal(t, []string{"otlp_logs"}, cfg.Logs.Topics)
+	assert.Equal(t, "latest", cfg.InitialOffset)
+	assert.Equal(t, "periodic", cfg.Commit.Strategy)
+	assert.Equal(t, 5*time.Second, cfg.Commit.Interval)
+	assert.Empty(t, cfg.DeadLetter.Topic)
+	assert.Equal(t, 45*time.Second, cfg.SessionTimeout)
+	assert.Nil(t, cfg.TLS)
+	assert.Nil(t, cfg.SASL)
+}
@@ -0,0 +1,310 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfokafkareceiver_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twmb/franz-go/pkg/kfake"
+	"github.com/twmb/franz-go/pkg/kgo"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfokafkareceiver"
+)
+
+// kafkaTimeout bounds every wait for consumed or produced messages.
+const kafkaTimeout = 10 * time.Second
+
+const deadLetterTopic = "otlp_dead_letter"
+
+// cluster starts an in-memory Kafka cluster with the default topics and
+// returns it with a client producing to it.
+func cluster(t *testing.T) (*kfake.Cluster, *kgo.Client) {
+	t.Helper()
+	c, err := kfake.NewCluster(
+		kfake.NumBrokers(1),
+		kfake.SeedTopics(2, "otlp_spans", "otlp_metrics", "otlp_logs", deadLetterTopic),
+	)
+	require.NoError(t, err)
+	t.Cleanup(c.Close)
+
+	producer, err := kgo.NewClient(kgo.SeedBrokers(c.ListenAddrs()...))
+	require.NoError(t, err)
+	t.Cleanup(producer.Close)
+	return c, producer
+}
+
+func kafkaCfg(c *kfake.Cluster) *tfokafkareceiver.Config {
+	cfg := tfokafkareceiver.NewFactory().CreateDefaultConfig().(*tfokafkareceiver.Config)
+	cfg.Brokers = c.ListenAddrs()
+	cfg.InitialOffset = "earliest"
+	cfg.Commit.Interval = 100 * time.Millisecond
+	return cfg
+}
+
+// start starts r and returns a function shutting it down, which is also
+// called on cleanup.
+func start(t *testing.T, r component.Component) func() {
+	t.Helper()
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	stop := sync.OnceFunc(func() { require.NoError(t, r.Shutdown(context.Background())) })
+	t.Cleanup(stop)
+	return stop
+}
+
+func settings() receiver.Settings {
+	return receivertest.NewNopSettings(component.MustNewType("tfokafka"))
+}
+
+func startTraces(t *testing.T, cfg *tfokafkareceiver.Config, next consumer.Traces) func() {
+	t.Helper()
+	require.NoError(t, cfg.Validate())
+	r, err := tfokafkareceiver.NewFactory().CreateTraces(context.Background(), settings(), cfg, next)
+	require.NoError(t, err)
+	return start(t, r)
+}
+
+func produce(t *testing.T, producer *kgo.Client, recs ...*kgo.Record) {
+	t.Helper()
+	require.NoError(t, producer.ProduceSync(context.Background(), recs...).FirstErr())
+}
+
+func spans(t *testing.T, names ...string) []byte {
+	t.Helper()
+	td := ptrace.NewTraces()
+	ss := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for _, name := range names {
+		ss.AppendEmpty().SetName(name)
+	}
+	b, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(td)
+	require.NoError(t, err)
+	return b
+}
+
+// spanNames returns the names of the spans received by sink, in order.
+func spanNames(sink *consumertest.TracesSink) []string {
+	var names []string
+	for _, td := range sink.AllTraces() {
+		ss := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+		for i := 0; i < ss.Len(); i++ {
+			names = append(names, ss.At(i).Name())
+		}
+	}
+	return names
+}
+
+func waitSpans(t *testing.T, sink *consumertest.TracesSink, want ...string) {
+	t.Helper()
+	require.Eventually(t, func() bool { return len(spanNames(sink)) >= len(want) }, kafkaTimeout, 10*time.Millisecond)
+	assert.Equal(t, want, spanNames(sink))
+}
+
+func TestReceiver_ConsumesSignals(t *testing.T) {
+	c, producer := cluster(t)
+	cfg := kafkaCfg(c)
+	cfg.Logs.Encoding = "otlp_json"
+
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetName("queue.depth")
+	metricsPayload, err := (&pmetric.ProtoMarshaler{}).MarshalMetrics(md)
+	require.NoError(t, err)
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("started")
+	logsPayload, err := (&plog.JSONMarshaler{}).MarshalLogs(ld)
+	require.NoError(t, err)
+
+	produce(t, producer,
+		&kgo.Record{Topic: "otlp_spans", Value: spans(t, "checkout")},
+		&kgo.Record{Topic: "otlp_metrics", Value: metricsPayload},
+		&kgo.Record{Topic: "otlp_logs", Value: logsPayload},
+	)
+
+	factory := tfokafkareceiver.NewFactory()
+	traces := new(consumertest.TracesSink)
+	startTraces(t, cfg, traces)
+	metrics := new(consumertest.MetricsSink)
+	mr, err := factory.CreateMetrics(context.Background(), settings(), cfg, metrics)
+	require.NoError(t, err)
+	start(t, mr)
+	logs := new(consumertest.LogsSink)
+	lr, err := factory.CreateLogs(context.Background(), settings(), cfg, logs)
+	require.NoError(t, err)
+	start(t, lr)
+
+	waitSpans(t, traces, "checkout")
+	require.Eventually(t, func() bool { return metrics.DataPointCount() == 0 && len(metrics.AllMetrics()) == 1 }, kafkaTimeout, 10*time.Millisecond)
+	assert.Equal(t, "queue.depth", metrics.AllMetrics()[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Name())
+	require.Eventually(t, func() bool { return logs.LogRecordCount() == 1 }, kafkaTimeout, 10*time.Millisecond)
+	assert.Equal(t, "started", logs.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Body().Str())
+}
+
+func TestReceiver_DeadLetter(t *testing.T) {
+	c, producer := cluster(t)
+	cfg := kafkaCfg(c)
+	cfg.DeadLetter.Topic = deadLetterTopic
+
+	sink := new(consumertest.TracesSink)
+	next, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		if td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name() == "poison" {
+			return consumererror.NewPermanent(errors.New("span rejected"))
+		}
+		return sink.ConsumeTraces(ctx, td)
+	})
+	require.NoError(t, err)
+
+	// One key keeps the messages on one partition, in order.
+	produce(t, producer,
+		&kgo.Record{Topic: "otlp_spans", Key: []byte("k"), Value: []byte("not otlp"),
+			Headers: []kgo.RecordHeader{{Key: "origin", Value: []byte("edge-1")}}},
+		&kgo.Record{Topic: "otlp_spans", Key: []byte("k"), Value: spans(t, "poison")},
+		&kgo.Record{Topic: "otlp_spans", Key: []byte("k"), Value: spans(t, "checkout")},
+	)
+	startTraces(t, cfg, next)
+	waitSpans(t, sink, "checkout")
+
+	dead := consumeAll(t, c, deadLetterTopic, 2)
+	require.Len(t, dead, 2)
+	headers := func(rec *kgo.Record) map[string]string {
+		h := make(map[string]string)
+		for _, header := range rec.Headers {
+			h[header.Key] = string(header.Value)
+		}
+		return h
+	}
+
+	malformed := headers(dead[0])
+	assert.Equal(t, []byte("k"), dead[0].Key)
+	assert.Equal(t, []byte("not otlp"), dead[0].Value)
+	assert.Equal(t, "edge-1", malformed["origin"])
+	assert.Equal(t, "otlp_spans", malformed[tfokafkareceiver.HeaderDeadLetterTopic])
+	assert.Equal(t, "0", malformed[tfokafkareceiver.HeaderDeadLetterOffset])
+	assert.Contains(t, []string{"0", "1"}, malformed[tfokafkareceiver.HeaderDeadLetterPartition])
+	assert.Contains(t, malformed[tfokafkareceiver.HeaderDeadLetterError], "malformed payload")
+
+	refused := headers(dead[1])
+	assert.Equal(t, "1", refused[tfokafkareceiver.HeaderDeadLetterOffset])
+	assert.Contains(t, refused[tfokafkareceiver.HeaderDeadLetterError], "span rejected")
+}
+
+// consumeAll reads n messages of topic from the start.
+func consumeAll(t *testing.T, c *kfake.Cluster, topic string, n int) []*kgo.Record {
+	t.Helper()
+	client, err := kgo.NewClient(
+		kgo.SeedBrokers(c.ListenAddrs()...),
+		kgo.ConsumeTopics(topic),
+		kgo.ConsumeResetOffset(kgo.NewOffset().AtStart()),
+	)
+	require.NoError(t, err)
+	defer client.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), kafkaTimeout)
+	defer cancel()
+	var recs []*kgo.Record
+	for len(recs) < n && ctx.Err() == nil {
+		recs = append(recs, client.PollFetches(ctx).Records()...)
+	}
+	return recs
+}
+
+func TestReceiver_DropsPoisonWithoutDeadLetter(t *testing.T) {
+	c, producer := cluster(t)
+	sink := new(consumertest.TracesSink)
+	produce(t, producer,
+		&kgo.Record{Topic: "otlp_spans", Key: []byte("k"), Value: []byte("not otlp")},
+		&kgo.Record{Topic: "otlp_spans", Key: []byte("k"), Value: spans(t, "checkout")},
+	)
+	startTraces(t, kafkaCfg(c), sink)
+	waitSpans(t, sink, "checkout")
+}
+
+func TestReceiver_RetriesRefusedMessages(t *testing.T) {
+	c, producer := cluster(t)
+	sink := new(consumertest.TracesSink)
+	var calls atomic.Int32
+	next, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		if calls.Add(1) <= 3 {
+			return errors.New("queue full")
+		}
+		return sink.ConsumeTraces(ctx, td)
+	})
+	require.NoError(t, err)
+
+	produce(t, producer,
+		&kgo.Record{Topic: "otlp_spans", Key: []byte("k"), Value: spans(t, "first")},
+		&kgo.Record{Topic: "otlp_spans", Key: []byte("k"), Value: spans(t, "second")},
+	)
+	startTraces(t, kafkaCfg(c), next)
+	waitSpans(t, sink, "first", "second")
+	assert.Equal(t, int32(5), calls.Load())
+}
+
+func TestReceiver_ResumesFromCommittedOffsets(t *testing.T) {
+	for _, strategy := range []string{"periodic", "sync"} {
+		t.Run(strategy, func(t *testing.T) {
+			c, producer := cluster(t)
+			cfg := kafkaCfg(c)
+			cfg.Commit.Strategy = strategy
+
+			first := new(consumertest.TracesSink)
+			stop := startTraces(t, cfg, first)
+			produce(t, producer, &kgo.Record{Topic: "otlp_spans", Value: spans(t, "before")})
+			waitSpans(t, first, "before")
+			stop()
+
+			produce(t, producer, &kgo.Record{Topic: "otlp_spans", Value: spans(t, "after")})
+			second := new(consumertest.TracesSink)
+			startTraces(t, cfg, second)
+			waitSpans(t, second, "after")
+			assert.Never(t, func() bool { return len(spanNames(second)) > 1 }, 200*time.Millisecond, 10*time.Millisecond)
+		})
+	}
+}
+
+func TestReceiver_RedeliversMessagesPendingAtShutdown(t *testing.T) {
+	c, producer := cluster(t)
+	cfg := kafkaCfg(c)
+	cfg.Commit.Strategy = "sync"
+
+	var refused atomic.Int32
+	next, err := consumer.NewTraces(func(context.Context, ptrace.Traces) error {
+		refused.Add(1)
+		return errors.New("exporter down")
+	})
+	require.NoError(t, err)
+	stop := startTraces(t, cfg, next)
+	produce(t, producer, &kgo.Record{Topic: "otlp_spans", Value: spans(t, "pending")})
+	require.Eventually(t, func() bool { return refused.Load() > 0 }, kafkaTimeout, 10*time.Millisecond)
+	stop()
+
+	sink := new(consumertest.TracesSink)
+	startTraces(t, cfg, sink)
+	waitSpans(t, sink, "pending")
+}
@@ -0,0 +1,68 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfomaintenanceextension_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/extension/tfomaintenanceextension"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *tfomaintenanceextension.Config)
+		wantErr string
+	}{
+		{name: "defaults", mutate: func(*tfomaintenanceextension.Config) {}},
+		{
+			name: "active reject without admin API",
+			mutate: func(cfg *tfomaintenanceextension.Config) {
+				cfg.Active = true
+				cfg.Mode = tfomaintenanceextension.ModeReject
+				cfg.NetAddr.Endpoint = ""
+			},
+		},
+		{
+			name:    "unknown mode",
+			mutate:  func(cfg *tfomaintenanceextension.Config) { cfg.Mode = "drain" },
+			wantErr: `mode must be "pause" or "reject", got "drain"`,
+		},
+		{
+			name:    "endpoint without port",
+			mutate:  func(cfg *tfomaintenanceextension.Config) { cfg.NetAddr.Endpoint = "localhost" },
+			wantErr: "invalid endpoint",
+		},
+		{
+			name:    "endpoint off localhost without client authentication",
+			mutate:  func(cfg *tfomaintenanceextension.Config) { cfg.NetAddr.Endpoint = ":55692" },
+			wantErr: "not a loopback address",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tfomaintenanceextension.NewFactory().CreateDefaultConfig().(*tfomaintenanceextension.Config)
+			tt.mutate(cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,242 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfomaintenanceextension_test
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/extension/tfomaintenanceextension"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+)
+
+// maintenanceProvider mirrors the tfoexporter and tfootlpreceiver
+// MaintenanceProvider interfaces.
+type maintenanceProvider interface {
+	Wait(ctx context.Context) error
+	Rejecting() bool
+}
+
+// statusHost records the component status events reported to it.
+type statusHost struct {
+	component.Host
+	mu     sync.Mutex
+	events []*componentstatus.Event
+}
+
+func (h *statusHost) Report(ev *componentstatus.Event) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.events = append(h.events, ev)
+}
+
+func (h *statusHost) last() *componentstatus.Event {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if len(h.events) == 0 {
+		return nil
+	}
+	return h.events[len(h.events)-1]
+}
+
+func freeEndpoint(t *testing.T) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = l.Close() }()
+	return l.Addr().String()
+}
+
+func startExtension(t *testing.T, cfg *tfomaintenanceextension.Config, host component.Host, set component.TelemetrySettings) (component.Component, maintenanceProvider) {
+	t.Helper()
+	factory := tfomaintenanceextension.NewFactory()
+	extSet := extensiontest.NewNopSettings(component.MustNewType("tfomaintenance"))
+	extSet.TelemetrySettings = set
+	ext, err := factory.Create(context.Background(), extSet, cfg)
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(context.Background(), host))
+	t.Cleanup(func() { _ = ext.Shutdown(context.Background()) })
+
+	provider, ok := ext.(maintenanceProvider)
+	require.True(t, ok, "extension must provide maintenance mode")
+	return ext, provider
+}
+
+func admin(t *testing.T, method, endpoint, body string) (int, tfomaintenanceextension.Status) {
+	t.Helper()
+	req, err := http.NewRequest(method, "http://"+endpoint+"/maintenance", strings.NewReader(body))
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	var status tfomaintenanceextension.Status
+	if resp.StatusCode == http.StatusOK {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&status))
+	}
+	return resp.StatusCode, status
+}
+
+func TestNewFactory(t *testing.T) {
+	factory := tfomaintenanceextension.NewFactory()
+	assert.Equal(t, component.MustNewType("tfomaintenance"), factory.Type())
+
+	cfg := factory.CreateDefaultConfig().(*tfomaintenanceextension.Config)
+	assert.False(t, cfg.Active)
+	assert.Equal(t, tfomaintenanceextension.ModePause, cfg.Mode)
+	assert.Equal(t, tfomaintenanceextension.DefaultEndpoint, cfg.NetAddr.Endpoint)
+}
+
+func TestExtension_InactiveAdmitsEverything(t *testing.T) {
+	cfg := &tfomaintenanceextension.Config{Mode: tfomaintenanceextension.ModePause}
+	_, provider := startExtension(t, cfg, componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+
+	assert.False(t, provider.Rejecting())
+	assert.NoError(t, provider.Wait(context.Background()))
+}
+
+func TestExtension_ActivePauseHoldsUntilResumed(t *testing.T) {
+	endpoint := freeEndpoint(t)
+	cfg := &tfomaintenanceextension.Config{
+		Active:      true,
+		Mode:        tfomaintenanceextension.ModePause,
+		Reason:      "backend migration",
+		AdminConfig: serverconf.NewDefaultAdminConfig(endpoint),
+	}
+	host := &statusHost{Host: componenttest.NewNopHost()}
+	_, provider := startExtension(t, cfg, host, componenttest.NewNopTelemetrySettings())
+
+	assert.False(t, provider.Rejecting(), "pause mode keeps receivers accepting")
+	require.NotNil(t, host.last())
+	assert.Equal(t, componentstatus.StatusRecoverableError, host.last().Status())
+	assert.EqualError(t, host.last().Err(), "maintenance mode (pause): backend migration")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, provider.Wait(ctx), context.DeadlineExceeded)
+
+	code, status := admin(t, http.MethodGet, endpoint, "")
+	require.Equal(t, http.StatusOK, code)
+	assert.True(t, status.Active)
+	assert.Equal(t, tfomaintenanceextension.ModePause, status.Mode)
+	assert.Equal(t, "backend migration", status.Reason)
+	assert.False(t, status.Since.IsZero())
+
+	done := make(chan error, 1)
+	go func() { done <- provider.Wait(context.Background()) }()
+
+	code, status = admin(t, http.MethodDelete, endpoint, "")
+	require.Equal(t, http.StatusOK, code)
+	assert.False(t, status.Active)
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("Wait not released on resume")
+	}
+	assert.Equal(t, componentstatus.StatusOK, host.last().Status())
+
+	code, _ = admin(t, http.MethodDelete, endpoint, "")
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func TestExtension_AdminSwitchesModes(t *testing.T) {
+	endpoint := freeEndpoint(t)
+	cfg := &tfomaintenanceextension.Config{Mode: tfomaintenanceextension.ModePause, AdminConfig: serverconf.NewDefaultAdminConfig(endpoint)}
+	_, provider := startExtension(t, cfg, componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+
+	code, _ := admin(t, http.MethodPost, endpoint, `{"mode": "drain"}`)
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = admin(t, http.MethodPost, endpoint, `{"mode":`)
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	// An empty body enters the configured mode.
+	code, status := admin(t, http.MethodPost, endpoint, "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, tfomaintenanceextension.ModePause, status.Mode)
+	since := status.Since
+
+	done := make(chan error, 1)
+	go func() { done <- provider.Wait(context.Background()) }()
+
+	// Switching to reject releases held exports and keeps the start time.
+	code, status = admin(t, http.MethodPost, endpoint, `{"mode": "reject", "reason": "cutover"}`)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, tfomaintenanceextension.ModeReject, status.Mode)
+	assert.Equal(t, "cutover", status.Reason)
+	assert.Equal(t, since.UnixNano(), status.Since.UnixNano())
+	assert.True(t, provider.Rejecting())
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("Wait not released on switch to reject")
+	}
+
+	req, err := http.NewRequest(http.MethodPut, "http://"+endpoint+"/maintenance", nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+func TestExtension_ShutdownReleasesWaiters(t *testing.T) {
+	cfg := &tfomaintenanceextension.Config{Active: true, Mode: tfomaintenanceextension.ModePause}
+	ext, provider := startExtension(t, cfg, componenttest.NewNopHost(), componenttest.NewNopTelemetrySettings())
+
+	done := make(chan error, 1)
+	go func() { done <- provider.Wait(context.Background()) }()
+	require.NoError(t, ext.Shutdown(context.Background()))
+
+	select {
+	case err := <-done:
+		assert.NoError(t, err)
+	case <-time.After(2 * time.Second):
+		t.Fatal("Wait not released on shutdown")
+	}
+}
+
+func TestExtension_ActiveGauge(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { _ = tel.Shutdown(context.Background()) })
+	cfg := &tfomaintenanceextension.Config{Active: true, Mode: tfomaintenanceextension.ModeReject}
+	startExtension(t, cfg, componenttest.NewNopHost(), tel.NewTelemetrySettings())
+
+	m, err := tel.GetMetric(selfmetrics.MaintenanceActive)
+	require.NoError(t, err)
+	got := map[string]int64{}
+	for _, dp := range m.Data.(metricdata.Gauge[int64]).DataPoints {
+		mode, _ := dp.Attributes.Value(attribute.Key("mode"))
+		got[mode.AsString()] = dp.Value
+	}
+	assert.Equal(t, map[string]int64{"pause": 0, "reject": 1}, got)
+}
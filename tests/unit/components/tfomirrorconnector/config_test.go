@@ -0,0 +1,118 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfomirrorconnector_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/pipeline"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfomirrorconnector"
+)
+
+var (
+	current = pipeline.NewIDWithName(pipeline.SignalTraces, "current")
+	next    = pipeline.NewIDWithName(pipeline.SignalTraces, "next")
+)
+
+func TestConfig_Validate(t *testing.T) {
+	valid := func() tfomirrorconnector.Config {
+		return tfomirrorconnector.Config{
+			Primary:    []pipeline.ID{current},
+			Shadow:     []pipeline.ID{next},
+			Percentage: 10,
+			QueueSize:  10,
+		}
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(*tfomirrorconnector.Config)
+		wantErr string
+	}{
+		{name: "valid", mutate: func(*tfomirrorconnector.Config) {}},
+		{name: "mirror nothing", mutate: func(c *tfomirrorconnector.Config) { c.Percentage = 0 }},
+		{name: "mirror everything", mutate: func(c *tfomirrorconnector.Config) { c.Percentage = 100 }},
+		{
+			name:    "no primary",
+			mutate:  func(c *tfomirrorconnector.Config) { c.Primary = nil },
+			wantErr: "primary must list at least one pipeline",
+		},
+		{
+			name:    "no shadow",
+			mutate:  func(c *tfomirrorconnector.Config) { c.Shadow = nil },
+			wantErr: "shadow must list at least one pipeline",
+		},
+		{
+			name:    "pipeline on both paths",
+			mutate:  func(c *tfomirrorconnector.Config) { c.Shadow = append(c.Shadow, current) },
+			wantErr: `pipeline "traces/current" is listed as both primary and shadow`,
+		},
+		{
+			name:    "negative percentage",
+			mutate:  func(c *tfomirrorconnector.Config) { c.Percentage = -1 },
+			wantErr: "percentage must be between 0 and 100",
+		},
+		{
+			name:    "percentage above 100",
+			mutate:  func(c *tfomirrorconnector.Config) { c.Percentage = 100.5 },
+			wantErr: "percentage must be between 0 and 100",
+		},
+		{
+			name:    "zero queue",
+			mutate:  func(c *tfomirrorconnector.Config) { c.QueueSize = 0 },
+			wantErr: "queue_size must be positive",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid()
+			tt.mutate(&cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := tfomirrorconnector.NewFactory()
+	assert.Equal(t, "tfomirror", factory.Type().String())
+
+	cfg := factory.CreateDefaultConfig().(*tfomirrorconnector.Config)
+	assert.Equal(t, 10.0, cfg.Percentage)
+	assert.Equal(t, 100, cfg.QueueSize)
+	assert.Error(t, cfg.Validate(), "pipelines must be configured")
+}
+
+func TestConfig_Unmarshal(t *testing.T) {
+	cfg := tfomirrorconnector.NewFactory().CreateDefaultConfig().(*tfomirrorconnector.Config)
+	require.NoError(t, confmap.NewFromStringMap(map[string]any{
+		"primary":    []any{"traces/current"},
+		"shadow":     []any{"traces/next"},
+		"percentage": 2.5,
+	}).Unmarshal(cfg))
+
+	assert.Equal(t, []pipeline.ID{current}, cfg.Primary)
+	assert.Equal(t, []pipeline.ID{next}, cfg.Shadow)
+	assert.Equal(t, 2.5, cfg.Percentage)
+	assert.NoError(t, cfg.Validate())
+}
@@ -0,0 +1,269 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfomirrorconnector_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfomirrorconnector"
+)
+
+const (
+	waitFor = 2 * time.Second
+	tick    = 5 * time.Millisecond
+)
+
+type harness struct {
+	conn connector.Traces
+	tel  *componenttest.Telemetry
+}
+
+// newHarness creates a traces mirror connector routing to primary and shadow.
+func newHarness(t *testing.T, percentage float64, queueSize int, primary, shadow consumer.Traces) *harness {
+	t.Helper()
+	factory := tfomirrorconnector.NewFactory()
+	cfg := factory.CreateDefaultConfig().(*tfomirrorconnector.Config)
+	cfg.Primary = []pipeline.ID{current}
+	cfg.Shadow = []pipeline.ID{next}
+	cfg.Percentage = percentage
+	cfg.QueueSize = queueSize
+	require.NoError(t, cfg.Validate())
+
+	h := &harness{tel: componenttest.NewTelemetry()}
+	t.Cleanup(func() { _ = h.tel.Shutdown(context.Background()) })
+
+	set := connectortest.NewNopSettings(factory.Type())
+	set.TelemetrySettings = h.tel.NewTelemetrySettings()
+	router := connector.NewTracesRouter(map[pipeline.ID]consumer.Traces{current: primary, next: shadow})
+
+	var err error
+	h.conn, err = factory.CreateTracesToTraces(context.Background(), set, cfg, router)
+	require.NoError(t, err)
+	require.NoError(t, h.conn.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, h.conn.Shutdown(context.Background())) })
+	return h
+}
+
+// batches returns the tfo_mirror_batches count for path and outcome.
+func (h *harness) batches(t *testing.T, path, outcome string) int64 {
+	t.Helper()
+	m, err := h.tel.GetMetric("tfo_mirror_batches")
+	if err != nil {
+		return 0
+	}
+	for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+		p, _ := dp.Attributes.Value(attribute.Key("path"))
+		o, _ := dp.Attributes.Value(attribute.Key("outcome"))
+		if p.AsString() == path && o.AsString() == outcome {
+			return dp.Value
+		}
+	}
+	return 0
+}
+
+func spans(n int) ptrace.Traces {
+	td := ptrace.NewTraces()
+	ss := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty()
+	for range n {
+		ss.Spans().AppendEmpty().SetName("span")
+	}
+	return td
+}
+
+func TestMirror_CopiesEveryBatchAtFullPercentage(t *testing.T) {
+	primary, shadow := new(consumertest.TracesSink), new(consumertest.TracesSink)
+	h := newHarness(t, 100, 10, primary, shadow)
+
+	for range 3 {
+		require.NoError(t, h.conn.ConsumeTraces(context.Background(), spans(2)))
+	}
+
+	assert.Equal(t, 6, primary.SpanCount())
+	require.Eventually(t, func() bool { return shadow.SpanCount() == 6 }, waitFor, tick)
+	assert.Equal(t, int64(3), h.batches(t, "primary", "success"))
+	assert.Equal(t, int64(3), h.batches(t, "shadow", "success"))
+
+	// The shadow got copies, not the batches passed to the primary path.
+	primary.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SetName("changed")
+	assert.Equal(t, "span", shadow.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+}
+
+func TestMirror_ZeroPercentage(t *testing.T) {
+	primary, shadow := new(consumertest.TracesSink), new(consumertest.TracesSink)
+	h := newHarness(t, 0, 10, primary, shadow)
+
+	for range 10 {
+		require.NoError(t, h.conn.ConsumeTraces(context.Background(), spans(1)))
+	}
+
+	assert.Equal(t, 10, primary.SpanCount())
+	assert.Zero(t, shadow.SpanCount())
+	assert.Zero(t, h.batches(t, "shadow", "success"))
+}
+
+func TestMirror_SamplesPercentageOfBatches(t *testing.T) {
+	primary, shadow := new(consumertest.TracesSink), new(consumertest.TracesSink)
+	h := newHarness(t, 25, 1000, primary, shadow)
+
+	const n = 1000
+	for range n {
+		require.NoError(t, h.conn.ConsumeTraces(context.Background(), spans(1)))
+	}
+
+	assert.Equal(t, n, primary.SpanCount())
+	require.Eventually(t, func() bool {
+		return h.batches(t, "shadow", "success") == int64(shadow.SpanCount()) && shadow.SpanCount() > 0
+	}, waitFor, tick)
+	// 25% of 1000 has a standard deviation of about 14.
+	assert.InDelta(t, n/4, shadow.SpanCount(), 100)
+}
+
+func TestMirror_ShadowFailureDoesNotAffectPrimary(t *testing.T) {
+	primary := new(consumertest.TracesSink)
+	h := newHarness(t, 100, 10, primary, consumertest.NewErr(errors.New("new backend down")))
+
+	require.NoError(t, h.conn.ConsumeTraces(context.Background(), spans(1)))
+
+	assert.Equal(t, 1, primary.SpanCount())
+	require.Eventually(t, func() bool { return h.batches(t, "shadow", "failure") == 1 }, waitFor, tick)
+	assert.Equal(t, int64(1), h.batches(t, "primary", "success"))
+}
+
+func TestMirror_ReturnsPrimaryFailure(t *testing.T) {
+	shadow := new(consumertest.TracesSink)
+	h := newHarness(t, 100, 10, consumertest.NewErr(errors.New("backend down")), shadow)
+
+	err := h.conn.ConsumeTraces(context.Background(), spans(1))
+	assert.ErrorContains(t, err, "backend down")
+	assert.Equal(t, int64(1), h.batches(t, "primary", "failure"))
+	require.Eventually(t, func() bool { return shadow.SpanCount() == 1 }, waitFor, tick)
+}
+
+func TestMirror_SlowShadowDropsCopies(t *testing.T) {
+	release := make(chan struct{})
+	blocking, err := consumer.NewTraces(func(ctx context.Context, _ ptrace.Traces) error {
+		select {
+		case <-release:
+		case <-ctx.Done():
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	defer close(release)
+
+	primary := new(consumertest.TracesSink)
+	h := newHarness(t, 100, 2, primary, blocking)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for range 10 {
+			assert.NoError(t, h.conn.ConsumeTraces(context.Background(), spans(1)))
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(waitFor):
+		t.Fatal("primary path blocked by the shadow pipeline")
+	}
+
+	assert.Equal(t, 10, primary.SpanCount())
+	// One copy is held by the worker and two wait in the queue.
+	assert.GreaterOrEqual(t, h.batches(t, "shadow", "dropped"), int64(7))
+}
+
+func TestMirror_MetricsAndLogs(t *testing.T) {
+	factory := tfomirrorconnector.NewFactory()
+	set := connectortest.NewNopSettings(factory.Type())
+
+	metricsCfg := &tfomirrorconnector.Config{
+		Primary:    []pipeline.ID{pipeline.NewID(pipeline.SignalMetrics)},
+		Shadow:     []pipeline.ID{pipeline.NewIDWithName(pipeline.SignalMetrics, "next")},
+		Percentage: 100,
+		QueueSize:  1,
+	}
+	primaryMetrics, shadowMetrics := new(consumertest.MetricsSink), new(consumertest.MetricsSink)
+	mconn, err := factory.CreateMetricsToMetrics(context.Background(), set, metricsCfg,
+		connector.NewMetricsRouter(map[pipeline.ID]consumer.Metrics{
+			metricsCfg.Primary[0]: primaryMetrics,
+			metricsCfg.Shadow[0]:  shadowMetrics,
+		}))
+	require.NoError(t, err)
+	require.NoError(t, mconn.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, mconn.Shutdown(context.Background())) })
+
+	md := pmetric.NewMetrics()
+	md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty()
+	require.NoError(t, mconn.ConsumeMetrics(context.Background(), md))
+	assert.Equal(t, 1, primaryMetrics.DataPointCount())
+	require.Eventually(t, func() bool { return shadowMetrics.DataPointCount() == 1 }, waitFor, tick)
+
+	logsCfg := &tfomirrorconnector.Config{
+		Primary:    []pipeline.ID{pipeline.NewID(pipeline.SignalLogs)},
+		Shadow:     []pipeline.ID{pipeline.NewIDWithName(pipeline.SignalLogs, "next")},
+		Percentage: 100,
+		QueueSize:  1,
+	}
+	primaryLogs, shadowLogs := new(consumertest.LogsSink), new(consumertest.LogsSink)
+	lconn, err := factory.CreateLogsToLogs(context.Background(), set, logsCfg,
+		connector.NewLogsRouter(map[pipeline.ID]consumer.Logs{
+			logsCfg.Primary[0]: primaryLogs,
+			logsCfg.Shadow[0]:  shadowLogs,
+		}))
+	require.NoError(t, err)
+	require.NoError(t, lconn.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, lconn.Shutdown(context.Background())) })
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	require.NoError(t, lconn.ConsumeLogs(context.Background(), ld))
+	assert.Equal(t, 1, primaryLogs.LogRecordCount())
+	require.Eventually(t, func() bool { return shadowLogs.LogRecordCount() == 1 }, waitFor, tick)
+}
+
+func TestCreate_Errors(t *testing.T) {
+	factory := tfomirrorconnector.NewFactory()
+	set := connectortest.NewNopSettings(factory.Type())
+	cfg := &tfomirrorconnector.Config{
+		Primary:    []pipeline.ID{current},
+		Shadow:     []pipeline.ID{next},
+		Percentage: 10,
+		QueueSize:  1,
+	}
+
+	_, err := factory.CreateTracesToTraces(context.Background(), set, cfg, new(consumertest.TracesSink))
+	assert.ErrorContains(t, err, "requires a traces router")
+
+	router := connector.NewTracesRouter(map[pipeline.ID]consumer.Traces{current: new(consumertest.TracesSink)})
+	_, err = factory.CreateTracesToTraces(context.Background(), set, cfg, router)
+	assert.ErrorContains(t, err, "shadow:")
+}
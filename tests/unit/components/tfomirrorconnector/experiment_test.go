@@ -0,0 +1,147 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfomirrorconnector_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfoexperimentexporter"
+	"github.com/telemetryflow/telemetryflow-collector/components/tfomirrorconnector"
+)
+
+// newExperiment creates a traces mirror connector in experiment mode whose
+// primary pipeline ends in a tfoexperiment exporter and whose shadow
+// pipeline applies process before ending in another one.
+func newExperiment(t *testing.T, percentage float64, process func(ptrace.Traces)) *harness {
+	t.Helper()
+	exporters := tfoexperimentexporter.NewFactory()
+	newExporter := func() consumer.Traces {
+		exp, err := exporters.CreateTraces(context.Background(),
+			exportertest.NewNopSettings(exporters.Type()), exporters.CreateDefaultConfig())
+		require.NoError(t, err)
+		return exp
+	}
+	primary := newExporter()
+	end := newExporter()
+	shadow, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+		process(td)
+		return end.ConsumeTraces(ctx, td)
+	})
+	require.NoError(t, err)
+
+	factory := tfomirrorconnector.NewFactory()
+	cfg := factory.CreateDefaultConfig().(*tfomirrorconnector.Config)
+	cfg.Primary = []pipeline.ID{current}
+	cfg.Shadow = []pipeline.ID{next}
+	cfg.Percentage = percentage
+	cfg.Experiment = true
+	require.NoError(t, cfg.Validate())
+
+	h := &harness{tel: componenttest.NewTelemetry()}
+	t.Cleanup(func() { _ = h.tel.Shutdown(context.Background()) })
+
+	set := connectortest.NewNopSettings(factory.Type())
+	set.TelemetrySettings = h.tel.NewTelemetrySettings()
+	router := connector.NewTracesRouter(map[pipeline.ID]consumer.Traces{current: primary, next: shadow})
+
+	h.conn, err = factory.CreateTracesToTraces(context.Background(), set, cfg, router)
+	require.NoError(t, err)
+	require.NoError(t, h.conn.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, h.conn.Shutdown(context.Background())) })
+	return h
+}
+
+// sum returns the value of the tfo_experiment_* counter name for path.
+func (h *harness) sum(t *testing.T, name, path string) int64 {
+	t.Helper()
+	m, err := h.tel.GetMetric(name)
+	if err != nil {
+		return 0
+	}
+	for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+		if p, _ := dp.Attributes.Value(attribute.Key("path")); p.AsString() == path {
+			return dp.Value
+		}
+	}
+	return 0
+}
+
+// latencies returns the number of tfo_experiment_latency_seconds
+// observations for path.
+func (h *harness) latencies(t *testing.T, path string) uint64 {
+	t.Helper()
+	m, err := h.tel.GetMetric("tfo_experiment_latency_seconds")
+	if err != nil {
+		return 0
+	}
+	for _, dp := range m.Data.(metricdata.Histogram[float64]).DataPoints {
+		if p, _ := dp.Attributes.Value(attribute.Key("path")); p.AsString() == path {
+			return dp.Count
+		}
+	}
+	return 0
+}
+
+// dropOdd removes every other span.
+func dropOdd(td ptrace.Traces) {
+	i := 0
+	td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().RemoveIf(func(ptrace.Span) bool {
+		i++
+		return i%2 == 0
+	})
+}
+
+func TestExperiment_ComparesPaths(t *testing.T) {
+	h := newExperiment(t, 100, dropOdd)
+
+	var sizer ptrace.ProtoMarshaler
+	in := spans(4)
+	out := spans(2)
+	delta := int64(sizer.TracesSize(out) - sizer.TracesSize(in))
+
+	for range 3 {
+		require.NoError(t, h.conn.ConsumeTraces(context.Background(), spans(4)))
+	}
+	require.Eventually(t, func() bool { return h.latencies(t, "shadow") == 3 }, waitFor, tick)
+
+	assert.Zero(t, h.sum(t, "tfo_experiment_records_dropped", "primary"))
+	assert.Zero(t, h.sum(t, "tfo_experiment_size_delta", "primary"))
+	assert.Equal(t, uint64(3), h.latencies(t, "primary"))
+
+	assert.Equal(t, int64(6), h.sum(t, "tfo_experiment_records_dropped", "shadow"))
+	assert.Equal(t, 3*delta, h.sum(t, "tfo_experiment_size_delta", "shadow"))
+}
+
+func TestExperiment_OnlyMirroredBatches(t *testing.T) {
+	h := newExperiment(t, 0, dropOdd)
+
+	require.NoError(t, h.conn.ConsumeTraces(context.Background(), spans(4)))
+
+	assert.Equal(t, int64(1), h.batches(t, "primary", "success"))
+	assert.Zero(t, h.latencies(t, "primary"))
+}
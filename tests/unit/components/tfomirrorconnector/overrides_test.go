@@ -0,0 +1,135 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfomirrorconnector_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pipeline"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfomirrorconnector"
+)
+
+var overridesID = component.MustNewID("tfooverrides")
+
+// fakeOverrides is a minimal tfomirrorconnector.OverridesProvider extension
+// whose percentage can be changed while the connector runs.
+type fakeOverrides struct {
+	component.StartFunc
+	component.ShutdownFunc
+
+	mu         sync.Mutex
+	percentage *float64
+	requested  component.ID
+}
+
+func (o *fakeOverrides) ConnectorPercentage(id component.ID) (float64, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.requested = id
+	if o.percentage == nil {
+		return 0, false
+	}
+	return *o.percentage, true
+}
+
+func (o *fakeOverrides) set(percentage *float64) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.percentage = percentage
+}
+
+// extensionsHost is a component.Host with the given extensions.
+type extensionsHost struct {
+	component.Host
+	exts map[component.ID]component.Component
+}
+
+func (h *extensionsHost) GetExtensions() map[component.ID]component.Component {
+	return h.exts
+}
+
+// startOverridesMirror starts a traces mirror connector at percentage
+// referencing the overrides extension on host.
+func startOverridesMirror(t *testing.T, percentage float64, host component.Host, primary, shadow consumer.Traces) (connector.Traces, error) {
+	t.Helper()
+	factory := tfomirrorconnector.NewFactory()
+	cfg := factory.CreateDefaultConfig().(*tfomirrorconnector.Config)
+	cfg.Primary = []pipeline.ID{current}
+	cfg.Shadow = []pipeline.ID{next}
+	cfg.Percentage = percentage
+	cfg.Overrides = overridesID
+	require.NoError(t, cfg.Validate())
+
+	set := connectortest.NewNopSettings(factory.Type())
+	set.ID = component.MustNewIDWithName("tfomirror", "migration")
+	router := connector.NewTracesRouter(map[pipeline.ID]consumer.Traces{current: primary, next: shadow})
+	conn, err := factory.CreateTracesToTraces(context.Background(), set, cfg, router)
+	require.NoError(t, err)
+	if err := conn.Start(context.Background(), host); err != nil {
+		return nil, err
+	}
+	t.Cleanup(func() { require.NoError(t, conn.Shutdown(context.Background())) })
+	return conn, nil
+}
+
+func TestOverrides_PercentageChangesAtRuntime(t *testing.T) {
+	ext := &fakeOverrides{}
+	host := &extensionsHost{Host: componenttest.NewNopHost(), exts: map[component.ID]component.Component{overridesID: ext}}
+	primary, shadow := new(consumertest.TracesSink), new(consumertest.TracesSink)
+	conn, err := startOverridesMirror(t, 0, host, primary, shadow)
+	require.NoError(t, err)
+
+	require.NoError(t, conn.ConsumeTraces(context.Background(), spans(1)))
+	assert.Equal(t, component.MustNewIDWithName("tfomirror", "migration"), ext.requested)
+
+	full := 100.0
+	ext.set(&full)
+	for range 3 {
+		require.NoError(t, conn.ConsumeTraces(context.Background(), spans(1)))
+	}
+	require.Eventually(t, func() bool { return shadow.SpanCount() == 3 }, waitFor, tick)
+
+	// Removing the override goes back to the configured percentage.
+	ext.set(nil)
+	require.NoError(t, conn.ConsumeTraces(context.Background(), spans(1)))
+	assert.Equal(t, 5, primary.SpanCount())
+	assert.Never(t, func() bool { return shadow.SpanCount() != 3 }, 50*tick, tick)
+}
+
+func TestOverrides_MissingExtension(t *testing.T) {
+	sink := new(consumertest.TracesSink)
+
+	host := &extensionsHost{Host: componenttest.NewNopHost()}
+	_, err := startOverridesMirror(t, 0, host, sink, sink)
+	assert.ErrorContains(t, err, `tfooverrides extension "tfooverrides" not found`)
+
+	host.exts = map[component.ID]component.Component{overridesID: &struct {
+		component.StartFunc
+		component.ShutdownFunc
+	}{}}
+	_, err = startOverridesMirror(t, 0, host, sink, sink)
+	assert.ErrorContains(t, err, `extension "tfooverrides" does not provide runtime overrides`)
+}
@@ -0,0 +1,160 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfomqttreceiver_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfomqttreceiver"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	valid := func() *tfomqttreceiver.Config {
+		cfg := tfomqttreceiver.NewFactory().CreateDefaultConfig().(*tfomqttreceiver.Config)
+		cfg.Broker = "tcp://broker:1883"
+		cfg.ClientID = "tfo"
+		cfg.Topics = []tfomqttreceiver.TopicConfig{
+			{Topic: "sensors/+/telemetry", Signal: "metrics"},
+			{Topic: "sensors/#", Signal: "logs", Format: "otlp_json"},
+		}
+		return cfg
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(*tfomqttreceiver.Config)
+		wantErr string
+	}{
+		{name: "valid", modify: func(*tfomqttreceiver.Config) {}},
+		{
+			name:    "missing broker",
+			modify:  func(cfg *tfomqttreceiver.Config) { cfg.Broker = "" },
+			wantErr: "broker is required",
+		},
+		{
+			name:    "unsupported scheme",
+			modify:  func(cfg *tfomqttreceiver.Config) { cfg.Broker = "http://broker:1883" },
+			wantErr: `unsupported scheme "http"`,
+		},
+		{
+			name:    "missing host",
+			modify:  func(cfg *tfomqttreceiver.Config) { cfg.Broker = "tcp://" },
+			wantErr: "host is required",
+		},
+		{
+			name:    "protocol version",
+			modify:  func(cfg *tfomqttreceiver.Config) { cfg.ProtocolVersion = "3.1" },
+			wantErr: "protocol_version",
+		},
+		{
+			name:    "missing client id",
+			modify:  func(cfg *tfomqttreceiver.Config) { cfg.ClientID = "" },
+			wantErr: "client_id is required",
+		},
+		{
+			name:    "password without username",
+			modify:  func(cfg *tfomqttreceiver.Config) { cfg.Password = "secret" },
+			wantErr: "password requires username",
+		},
+		{
+			name:    "keep alive too short",
+			modify:  func(cfg *tfomqttreceiver.Config) { cfg.KeepAlive = 0 },
+			wantErr: "keep_alive",
+		},
+		{
+			name:    "negative session expiry",
+			modify:  func(cfg *tfomqttreceiver.Config) { cfg.SessionExpiry = -time.Second },
+			wantErr: "session_expiry",
+		},
+		{
+			name:    "no topics",
+			modify:  func(cfg *tfomqttreceiver.Config) { cfg.Topics = nil },
+			wantErr: "at least one topic",
+		},
+		{
+			name:    "duplicate topic",
+			modify:  func(cfg *tfomqttreceiver.Config) { cfg.Topics[1].Topic = cfg.Topics[0].Topic },
+			wantErr: "topics[1]: duplicate topic",
+		},
+		{
+			name:    "misplaced multi-level wildcard",
+			modify:  func(cfg *tfomqttreceiver.Config) { cfg.Topics[0].Topic = "sensors/#/telemetry" },
+			wantErr: "topics[0]: topic \"sensors/#/telemetry\": # must be the last level",
+		},
+		{
+			name:    "partial wildcard",
+			modify:  func(cfg *tfomqttreceiver.Config) { cfg.Topics[0].Topic = "sensors/boiler+" },
+			wantErr: "wildcards must fill a whole level",
+		},
+		{
+			name: "qos",
+			modify: func(cfg *tfomqttreceiver.Config) {
+				qos := byte(3)
+				cfg.Topics[0].QoS = &qos
+			},
+			wantErr: "topics[0]: qos must be 0, 1 or 2",
+		},
+		{
+			name:    "signal",
+			modify:  func(cfg *tfomqttreceiver.Config) { cfg.Topics[0].Signal = "traces" },
+			wantErr: "topics[0]: signal",
+		},
+		{
+			name:    "format",
+			modify:  func(cfg *tfomqttreceiver.Config) { cfg.Topics[0].Format = "csv" },
+			wantErr: "topics[0]: format",
+		},
+		{
+			name:    "timestamp field with otlp",
+			modify:  func(cfg *tfomqttreceiver.Config) { cfg.Topics[1].TimestampField = "ts" },
+			wantErr: "topics[1]: timestamp_field requires format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid()
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestFactory_DefaultConfig(t *testing.T) {
+	factory := tfomqttreceiver.NewFactory()
+	assert.Equal(t, component.MustNewType("tfomqtt"), factory.Type())
+	assert.Equal(t, component.StabilityLevelAlpha, factory.MetricsStability())
+	assert.Equal(t, component.StabilityLevelAlpha, factory.LogsStability())
+
+	cfg := factory.CreateDefaultConfig().(*tfomqttreceiver.Config)
+	assert.Equal(t, "3.1.1", cfg.ProtocolVersion)
+	assert.False(t, cfg.CleanSession)
+	assert.Equal(t, time.Hour, cfg.SessionExpiry)
+	assert.Equal(t, 30*time.Second, cfg.KeepAlive)
+	assert.Equal(t, 10*time.Second, cfg.ConnectTimeout)
+	assert.Equal(t, time.Minute, cfg.MaxReconnectInterval)
+	assert.Empty(t, cfg.Topics)
+}
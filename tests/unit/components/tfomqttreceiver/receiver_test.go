@@ -0,0 +1,503 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfomqttreceiver_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"io"
+	"log/slog"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	mqttserver "github.com/mochi-mqtt/server/v2"
+	"github.com/mochi-mqtt/server/v2/hooks/auth"
+	"github.com/mochi-mqtt/server/v2/listeners"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfomqttreceiver"
+)
+
+// mqttTimeout bounds every wait on the broker or the receiver.
+const mqttTimeout = 10 * time.Second
+
+// versions are the protocol versions every broker test runs with.
+var versions = []string{tfomqttreceiver.ProtocolV311, tfomqttreceiver.ProtocolV5}
+
+func freeAddr(t *testing.T) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = l.Close() }()
+	return l.Addr().String()
+}
+
+// broker is an embedded MQTT broker.
+type broker struct {
+	*mqttserver.Server
+	stop func()
+}
+
+// startBroker starts an MQTT broker on addr. A nil ledger allows every
+// client.
+func startBroker(t *testing.T, addr string, tlsCfg *tls.Config, ledger *auth.Ledger) *broker {
+	t.Helper()
+	srv := mqttserver.New(&mqttserver.Options{
+		InlineClient: true,
+		Logger:       slog.New(slog.NewTextHandler(io.Discard, nil)),
+	})
+	if ledger == nil {
+		require.NoError(t, srv.AddHook(new(auth.AllowHook), nil))
+	} else {
+		require.NoError(t, srv.AddHook(new(auth.Hook), &auth.Options{Ledger: ledger}))
+	}
+	require.NoError(t, srv.AddListener(listeners.NewTCP(listeners.Config{ID: "tcp", Address: addr, TLSConfig: tlsCfg})))
+	require.NoError(t, srv.Serve())
+	b := &broker{Server: srv, stop: sync.OnceFunc(func() { _ = srv.Close() })}
+	t.Cleanup(b.stop)
+	return b
+}
+
+// waitSubscribed waits until clientID is subscribed to a filter matching
+// topic.
+func waitSubscribed(t *testing.T, srv *broker, topic, clientID string) {
+	t.Helper()
+	require.Eventually(t, func() bool {
+		_, ok := srv.Topics.Subscribers(topic).Subscriptions[clientID]
+		return ok
+	}, mqttTimeout, 10*time.Millisecond)
+}
+
+func publish(t *testing.T, srv *broker, topic, payload string) {
+	t.Helper()
+	require.NoError(t, srv.Publish(topic, []byte(payload), false, 1))
+}
+
+func mqttCfg(broker, version string, topics ...tfomqttreceiver.TopicConfig) *tfomqttreceiver.Config {
+	cfg := tfomqttreceiver.NewFactory().CreateDefaultConfig().(*tfomqttreceiver.Config)
+	cfg.Broker = broker
+	cfg.ProtocolVersion = version
+	cfg.ClientID = "tfo-test"
+	cfg.MaxReconnectInterval = 200 * time.Millisecond
+	cfg.Topics = topics
+	return cfg
+}
+
+func metricsTopic(topic, format string) tfomqttreceiver.TopicConfig {
+	return tfomqttreceiver.TopicConfig{Topic: topic, Signal: tfomqttreceiver.SignalMetrics, Format: format}
+}
+
+func startMetrics(t *testing.T, cfg *tfomqttreceiver.Config, next consumer.Metrics) receiver.Metrics {
+	t.Helper()
+	require.NoError(t, cfg.Validate())
+	set := receivertest.NewNopSettings(component.MustNewType("tfomqtt"))
+	r, err := tfomqttreceiver.NewFactory().CreateMetrics(context.Background(), set, cfg, next)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { _ = r.Shutdown(context.Background()) })
+	return r
+}
+
+func startLogs(t *testing.T, cfg *tfomqttreceiver.Config, next consumer.Logs) {
+	t.Helper()
+	require.NoError(t, cfg.Validate())
+	set := receivertest.NewNopSettings(component.MustNewType("tfomqtt"))
+	r, err := tfomqttreceiver.NewFactory().CreateLogs(context.Background(), set, cfg, next)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { _ = r.Shutdown(context.Background()) })
+}
+
+// waitPoints waits until sink holds n data points and returns them by
+// metric name.
+func waitPoints(t *testing.T, sink *consumertest.MetricsSink, n int) map[string][]pmetric.NumberDataPoint {
+	t.Helper()
+	require.Eventually(t, func() bool { return sink.DataPointCount() >= n }, mqttTimeout, 10*time.Millisecond)
+	points := make(map[string][]pmetric.NumberDataPoint)
+	for _, md := range sink.AllMetrics() {
+		rms := md.ResourceMetrics()
+		for i := 0; i < rms.Len(); i++ {
+			sms := rms.At(i).ScopeMetrics()
+			for j := 0; j < sms.Len(); j++ {
+				ms := sms.At(j).Metrics()
+				for k := 0; k < ms.Len(); k++ {
+					m := ms.At(k)
+					dps := m.Gauge().DataPoints()
+					for l := 0; l < dps.Len(); l++ {
+						points[m.Name()] = append(points[m.Name()], dps.At(l))
+					}
+				}
+			}
+		}
+	}
+	return points
+}
+
+func TestReceiver_JSONMetrics(t *testing.T) {
+	for _, version := range versions {
+		t.Run(version, func(t *testing.T) {
+			addr := freeAddr(t)
+			srv := startBroker(t, addr, nil, nil)
+			topic := metricsTopic("sensors/+/telemetry", "")
+			topic.TimestampField = "meta.ts"
+			sink := new(consumertest.MetricsSink)
+			startMetrics(t, mqttCfg("tcp://"+addr, version, topic), sink)
+			waitSubscribed(t, srv, "sensors/boiler-1/telemetry", "tfo-test-metrics")
+
+			publish(t, srv, "sensors/boiler-1/telemetry",
+				`{"site": "plant-a", "temp": 71.5, "pump": {"rpm": 1450, "on": true}, "tags": [1, 2], "meta": {"ts": 1760000000}}`)
+
+			points := waitPoints(t, sink, 3)
+			assert.Len(t, points, 3)
+			require.Len(t, points["temp"], 1)
+			assert.InDelta(t, 71.5, points["temp"][0].DoubleValue(), 0)
+			require.Len(t, points["pump.rpm"], 1)
+			assert.Equal(t, int64(1450), points["pump.rpm"][0].IntValue())
+			require.Len(t, points["pump.on"], 1)
+			assert.Equal(t, int64(1), points["pump.on"][0].IntValue())
+
+			dp := points["temp"][0]
+			assert.Equal(t, time.Unix(1760000000, 0).UTC(), dp.Timestamp().AsTime())
+			assert.Equal(t, map[string]any{
+				tfomqttreceiver.AttrTopic: "sensors/boiler-1/telemetry",
+				"site":                    "plant-a",
+			}, dp.Attributes().AsRaw())
+		})
+	}
+}
+
+func TestReceiver_JSONArrayOfReadings(t *testing.T) {
+	addr := freeAddr(t)
+	srv := startBroker(t, addr, nil, nil)
+	topic := metricsTopic("meters/#", "json")
+	topic.TimestampField = "time"
+	sink := new(consumertest.MetricsSink)
+	startMetrics(t, mqttCfg("tcp://"+addr, tfomqttreceiver.ProtocolV311, topic), sink)
+	waitSubscribed(t, srv, "meters/m1", "tfo-test-metrics")
+
+	publish(t, srv, "meters/m1", `[
+		{"kwh": 12, "time": 1760000000000},
+		{"kwh": 13, "time": "2025-10-09T08:53:21Z"}
+	]`)
+
+	points := waitPoints(t, sink, 2)
+	require.Len(t, points["kwh"], 2)
+	assert.Equal(t, time.UnixMilli(1760000000000).UTC(), points["kwh"][0].Timestamp().AsTime())
+	assert.Equal(t, time.Date(2025, 10, 9, 8, 53, 21, 0, time.UTC), points["kwh"][1].Timestamp().AsTime())
+	assert.Equal(t, int64(13), points["kwh"][1].IntValue())
+}
+
+func TestReceiver_OTLPMetrics(t *testing.T) {
+	for _, version := range versions {
+		t.Run(version, func(t *testing.T) {
+			addr := freeAddr(t)
+			srv := startBroker(t, addr, nil, nil)
+			sink := new(consumertest.MetricsSink)
+			startMetrics(t, mqttCfg("tcp://"+addr, version,
+				metricsTopic("otlp/proto", "otlp"),
+				metricsTopic("otlp/json", "otlp_json"),
+			), sink)
+			waitSubscribed(t, srv, "otlp/json", "tfo-test-metrics")
+
+			md := pmetric.NewMetrics()
+			md.ResourceMetrics().AppendEmpty().Resource().Attributes().PutStr("service.name", "gateway")
+			m := md.ResourceMetrics().At(0).ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+			m.SetName("uplink.rssi")
+			m.SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(-70)
+
+			proto, err := (&pmetric.ProtoMarshaler{}).MarshalMetrics(md)
+			require.NoError(t, err)
+			publish(t, srv, "otlp/proto", string(proto))
+			js, err := (&pmetric.JSONMarshaler{}).MarshalMetrics(md)
+			require.NoError(t, err)
+			publish(t, srv, "otlp/json", string(js))
+
+			points := waitPoints(t, sink, 2)
+			require.Len(t, points["uplink.rssi"], 2)
+			for _, got := range sink.AllMetrics() {
+				name, _ := got.ResourceMetrics().At(0).Resource().Attributes().Get("service.name")
+				assert.Equal(t, "gateway", name.Str())
+			}
+		})
+	}
+}
+
+func TestReceiver_JSONLogs(t *testing.T) {
+	for _, version := range versions {
+		t.Run(version, func(t *testing.T) {
+			addr := freeAddr(t)
+			srv := startBroker(t, addr, nil, nil)
+			sink := new(consumertest.LogsSink)
+			startLogs(t, mqttCfg("tcp://"+addr, version,
+				metricsTopic("sensors/+/telemetry", ""),
+				tfomqttreceiver.TopicConfig{Topic: "sensors/+/events", Signal: tfomqttreceiver.SignalLogs, TimestampField: "ts"},
+			), sink)
+			waitSubscribed(t, srv, "sensors/door-1/events", "tfo-test-logs")
+			_, metricsSubscribed := srv.Topics.Subscribers("sensors/door-1/telemetry").Subscriptions["tfo-test-logs"]
+			assert.False(t, metricsSubscribed, "the logs instance subscribes to logs topics only")
+
+			publish(t, srv, "sensors/door-1/events", `{"event": "opened", "count": 3, "ts": 1760000000.5}`)
+			publish(t, srv, "sensors/door-1/events", `"tamper"`)
+
+			require.Eventually(t, func() bool { return sink.LogRecordCount() == 2 }, mqttTimeout, 10*time.Millisecond)
+			// Messages are consumed concurrently: find the records by body.
+			var event, tamper plog.LogRecord
+			for _, ld := range sink.AllLogs() {
+				lr := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0)
+				if lr.Body().Type() == pcommon.ValueTypeMap {
+					event = lr
+				} else {
+					tamper = lr
+				}
+			}
+			assert.Equal(t, map[string]any{"event": "opened", "count": int64(3), "ts": 1760000000.5}, event.Body().Map().AsRaw())
+			assert.Equal(t, time.Unix(1760000000, 5e8).UTC(), event.Timestamp().AsTime())
+			topic, _ := event.Attributes().Get(tfomqttreceiver.AttrTopic)
+			assert.Equal(t, "sensors/door-1/events", topic.Str())
+
+			assert.Equal(t, "tamper", tamper.Body().Str())
+			assert.Equal(t, tamper.ObservedTimestamp(), tamper.Timestamp())
+		})
+	}
+}
+
+func TestReceiver_RequiresTopicsOfSignal(t *testing.T) {
+	cfg := mqttCfg("tcp://127.0.0.1:1883", tfomqttreceiver.ProtocolV311, metricsTopic("sensors/#", ""))
+	set := receivertest.NewNopSettings(component.MustNewType("tfomqtt"))
+	_, err := tfomqttreceiver.NewFactory().CreateLogs(context.Background(), set, cfg, consumertest.NewNop())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `no topic has signal "logs"`)
+}
+
+func TestReceiver_DropsMalformedPayloads(t *testing.T) {
+	addr := freeAddr(t)
+	srv := startBroker(t, addr, nil, nil)
+	sink := new(consumertest.MetricsSink)
+	next, err := consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
+		if name := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Name(); name == "refused" {
+			return consumererror.NewPermanent(errors.New("schema violation"))
+		}
+		return sink.ConsumeMetrics(ctx, md)
+	})
+	require.NoError(t, err)
+	topic := metricsTopic("sensors/#", "")
+	topic.TimestampField = "ts"
+	startMetrics(t, mqttCfg("tcp://"+addr, tfomqttreceiver.ProtocolV5, topic), next)
+	waitSubscribed(t, srv, "sensors/a", "tfo-test-metrics")
+
+	publish(t, srv, "sensors/a", `{"temp": `)
+	publish(t, srv, "sensors/a", `["not an object"]`)
+	publish(t, srv, "sensors/a", `{"temp": 1, "ts": "yesterday"}`)
+	publish(t, srv, "sensors/a", `{"refused": 1}`)
+	publish(t, srv, "sensors/a", `{"temp": 2}`)
+
+	points := waitPoints(t, sink, 1)
+	require.Len(t, points["temp"], 1)
+	assert.Equal(t, int64(2), points["temp"][0].IntValue())
+	assert.Never(t, func() bool { return sink.DataPointCount() > 1 }, 200*time.Millisecond, 10*time.Millisecond)
+}
+
+func TestReceiver_RetriesRefusedMessages(t *testing.T) {
+	for _, version := range versions {
+		t.Run(version, func(t *testing.T) {
+			addr := freeAddr(t)
+			srv := startBroker(t, addr, nil, nil)
+			sink := new(consumertest.MetricsSink)
+			var calls atomic.Int32
+			next, err := consumer.NewMetrics(func(ctx context.Context, md pmetric.Metrics) error {
+				if calls.Add(1) <= 2 {
+					return errors.New("queue full")
+				}
+				return sink.ConsumeMetrics(ctx, md)
+			})
+			require.NoError(t, err)
+			startMetrics(t, mqttCfg("tcp://"+addr, version, metricsTopic("sensors/#", "")), next)
+			waitSubscribed(t, srv, "sensors/a", "tfo-test-metrics")
+
+			publish(t, srv, "sensors/a", `{"temp": 1}`)
+
+			waitPoints(t, sink, 1)
+			assert.Equal(t, int32(3), calls.Load())
+			assert.Never(t, func() bool { return sink.DataPointCount() > 1 }, 200*time.Millisecond, 10*time.Millisecond)
+		})
+	}
+}
+
+func TestReceiver_ResumesPersistentSession(t *testing.T) {
+	for _, version := range versions {
+		t.Run(version, func(t *testing.T) {
+			addr := freeAddr(t)
+			srv := startBroker(t, addr, nil, nil)
+			cfg := mqttCfg("tcp://"+addr, version, metricsTopic("sensors/#", ""))
+
+			first := startMetrics(t, cfg, consumertest.NewNop())
+			waitSubscribed(t, srv, "sensors/a", "tfo-test-metrics")
+			require.NoError(t, first.Shutdown(context.Background()))
+
+			// Published while the receiver is away: the broker queues it in
+			// the session.
+			publish(t, srv, "sensors/a", `{"temp": 7}`)
+
+			sink := new(consumertest.MetricsSink)
+			startMetrics(t, cfg, sink)
+			points := waitPoints(t, sink, 1)
+			require.Len(t, points["temp"], 1)
+			assert.Equal(t, int64(7), points["temp"][0].IntValue())
+		})
+	}
+}
+
+func TestReceiver_ReconnectsToBroker(t *testing.T) {
+	for _, version := range versions {
+		t.Run(version, func(t *testing.T) {
+			addr := freeAddr(t)
+			sink := new(consumertest.MetricsSink)
+			// The broker is not up yet: Start must not fail or block.
+			startMetrics(t, mqttCfg("tcp://"+addr, version, metricsTopic("sensors/#", "")), sink)
+
+			srv := startBroker(t, addr, nil, nil)
+			waitSubscribed(t, srv, "sensors/a", "tfo-test-metrics")
+			publish(t, srv, "sensors/a", `{"temp": 1}`)
+			waitPoints(t, sink, 1)
+
+			// A new broker has no session: the receiver must subscribe again.
+			srv.stop()
+			srv = startBroker(t, addr, nil, nil)
+			waitSubscribed(t, srv, "sensors/a", "tfo-test-metrics")
+			publish(t, srv, "sensors/a", `{"temp": 2}`)
+			points := waitPoints(t, sink, 2)
+			require.Len(t, points["temp"], 2)
+			assert.Equal(t, int64(2), points["temp"][1].IntValue())
+		})
+	}
+}
+
+func TestReceiver_UsernamePassword(t *testing.T) {
+	for _, version := range versions {
+		t.Run(version, func(t *testing.T) {
+			addr := freeAddr(t)
+			srv := startBroker(t, addr, nil, &auth.Ledger{
+				Auth: auth.AuthRules{{Username: "collector", Password: "secret", Allow: true}},
+			})
+
+			denied := mqttCfg("tcp://"+addr, version, metricsTopic("sensors/#", ""))
+			denied.ClientID = "denied"
+			denied.Username = "collector"
+			denied.Password = "wrong"
+			startMetrics(t, denied, consumertest.NewNop())
+
+			cfg := mqttCfg("tcp://"+addr, version, metricsTopic("sensors/#", ""))
+			cfg.Username = "collector"
+			cfg.Password = "secret"
+			sink := new(consumertest.MetricsSink)
+			startMetrics(t, cfg, sink)
+
+			waitSubscribed(t, srv, "sensors/a", "tfo-test-metrics")
+			_, ok := srv.Topics.Subscribers("sensors/a").Subscriptions["denied-metrics"]
+			assert.False(t, ok)
+			publish(t, srv, "sensors/a", `{"temp": 1}`)
+			waitPoints(t, sink, 1)
+		})
+	}
+}
+
+// selfSigned writes a self-signed certificate for 127.0.0.1 with usage to
+// dir and returns the certificate and key files and the certificate.
+func selfSigned(t *testing.T, dir, name string, usage x509.ExtKeyUsage) (string, string, *x509.Certificate) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		Subject:      pkix.Name{CommonName: name},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:  []x509.ExtKeyUsage{usage},
+		IsCA:         true,
+
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	certFile := filepath.Join(dir, name+".pem")
+	keyFile := filepath.Join(dir, name+"-key.pem")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0o600))
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	return certFile, keyFile, cert
+}
+
+func TestReceiver_TLSClientCertificate(t *testing.T) {
+	for _, version := range versions {
+		t.Run(version, func(t *testing.T) {
+			dir := t.TempDir()
+			serverCertFile, serverKeyFile, _ := selfSigned(t, dir, "broker", x509.ExtKeyUsageServerAuth)
+			clientCertFile, clientKeyFile, clientCert := selfSigned(t, dir, "collector", x509.ExtKeyUsageClientAuth)
+			serverCert, err := tls.LoadX509KeyPair(serverCertFile, serverKeyFile)
+			require.NoError(t, err)
+			clientCAs := x509.NewCertPool()
+			clientCAs.AddCert(clientCert)
+
+			addr := freeAddr(t)
+			srv := startBroker(t, addr, &tls.Config{
+				Certificates: []tls.Certificate{serverCert},
+				ClientCAs:    clientCAs,
+				ClientAuth:   tls.RequireAndVerifyClientCert,
+				MinVersion:   tls.VersionTLS12,
+			}, nil)
+
+			cfg := mqttCfg("ssl://"+addr, version, metricsTopic("sensors/#", ""))
+			cfg.TLS.CAFile = serverCertFile
+			cfg.TLS.CertFile = clientCertFile
+			cfg.TLS.KeyFile = clientKeyFile
+			sink := new(consumertest.MetricsSink)
+			startMetrics(t, cfg, sink)
+
+			waitSubscribed(t, srv, "sensors/a", "tfo-test-metrics")
+			publish(t, srv, "sensors/a", `{"temp": 1}`)
+			waitPoints(t, sink, 1)
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoopampextension_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/extension/tfoopampextension"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *tfoopampextension.Config)
+		wantErr string
+	}{
+		{name: "websocket", mutate: func(*tfoopampextension.Config) {}},
+		{
+			name:   "http polling",
+			mutate: func(cfg *tfoopampextension.Config) { cfg.Endpoint = "https://opamp.example.com/v1/opamp" },
+		},
+		{
+			name: "remote config",
+			mutate: func(cfg *tfoopampextension.Config) {
+				cfg.RemoteConfig.Accept = true
+				cfg.RemoteConfig.Path = "/etc/tfo-collector/config.yaml"
+			},
+		},
+		{
+			name:    "missing endpoint",
+			mutate:  func(cfg *tfoopampextension.Config) { cfg.Endpoint = "" },
+			wantErr: "endpoint is required",
+		},
+		{
+			name:    "unknown scheme",
+			mutate:  func(cfg *tfoopampextension.Config) { cfg.Endpoint = "grpc://opamp.example.com" },
+			wantErr: "scheme must be ws, wss, http or https",
+		},
+		{
+			name:    "missing host",
+			mutate:  func(cfg *tfoopampextension.Config) { cfg.Endpoint = "wss:///v1/opamp" },
+			wantErr: "missing host",
+		},
+		{
+			name:    "accept without path",
+			mutate:  func(cfg *tfoopampextension.Config) { cfg.RemoteConfig.Accept = true },
+			wantErr: "remote_config: path is required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tfoopampextension.NewFactory().CreateDefaultConfig().(*tfoopampextension.Config)
+			cfg.Endpoint = "wss://opamp.example.com/v1/opamp"
+			tt.mutate(cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,380 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoopampextension_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+	"github.com/open-telemetry/opamp-go/server"
+	servertypes "github.com/open-telemetry/opamp-go/server/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componentstatus"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/extension/tfoopampextension"
+)
+
+const validConfig = `receivers:
+  otlp:
+    protocols:
+      grpc:
+exporters:
+  debug:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [debug]
+`
+
+// identityExtension mirrors the tfoidentity extension.
+type identityExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+func (identityExtension) GetCollectorID() string     { return "collector-1" }
+func (identityExtension) GetHostname() string        { return "edge-host" }
+func (identityExtension) GetName() string            { return "edge-collector" }
+func (identityExtension) GetTags() map[string]string { return map[string]string{"region": "jkt"} }
+
+// extensionsHost provides extensions to the extension under test.
+type extensionsHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h *extensionsHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+// opampServer records the messages of the connected agent and pushes
+// remote configuration to it once.
+type opampServer struct {
+	endpoint string
+
+	mu       sync.Mutex
+	messages []*protobufs.AgentToServer
+	push     *protobufs.AgentRemoteConfig
+}
+
+func startServer(t *testing.T, push *protobufs.AgentRemoteConfig) *opampServer {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	endpoint := l.Addr().String()
+	require.NoError(t, l.Close())
+
+	s := &opampServer{endpoint: endpoint, push: push}
+	srv := server.New(nil)
+	require.NoError(t, srv.Start(server.StartSettings{
+		ListenEndpoint: endpoint,
+		Settings: server.Settings{
+			Callbacks: servertypes.Callbacks{
+				OnConnecting: func(*http.Request) servertypes.ConnectionResponse {
+					return servertypes.ConnectionResponse{
+						Accept:              true,
+						ConnectionCallbacks: servertypes.ConnectionCallbacks{OnMessage: s.onMessage},
+					}
+				},
+			},
+		},
+	}))
+	t.Cleanup(func() { _ = srv.Stop(context.Background()) })
+	return s
+}
+
+func (s *opampServer) onMessage(_ context.Context, _ servertypes.Connection, msg *protobufs.AgentToServer) *protobufs.ServerToAgent {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.messages = append(s.messages, msg)
+	resp := &protobufs.ServerToAgent{InstanceUid: msg.GetInstanceUid()}
+	if s.push != nil {
+		resp.RemoteConfig = s.push
+		s.push = nil
+	}
+	return resp
+}
+
+// find returns the last message matching match, or nil.
+func (s *opampServer) find(match func(*protobufs.AgentToServer) bool) *protobufs.AgentToServer {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i := len(s.messages) - 1; i >= 0; i-- {
+		if match(s.messages[i]) {
+			return s.messages[i]
+		}
+	}
+	return nil
+}
+
+// remoteConfigStatus returns the last reported remote config status.
+func (s *opampServer) remoteConfigStatus() protobufs.RemoteConfigStatuses {
+	msg := s.find(func(m *protobufs.AgentToServer) bool { return m.GetRemoteConfigStatus() != nil })
+	if msg == nil {
+		return protobufs.RemoteConfigStatuses_RemoteConfigStatuses_UNSET
+	}
+	return msg.GetRemoteConfigStatus().GetStatus()
+}
+
+func startExtension(t *testing.T, factory extension.Factory, cfg *tfoopampextension.Config, host component.Host) extension.Extension {
+	t.Helper()
+	set := extensiontest.NewNopSettings(component.MustNewType("tfoopamp"))
+	ext, err := factory.Create(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(context.Background(), host))
+	t.Cleanup(func() { _ = ext.Shutdown(context.Background()) })
+	return ext
+}
+
+func remoteConfig(body, hash string) *protobufs.AgentRemoteConfig {
+	return &protobufs.AgentRemoteConfig{
+		Config: &protobufs.AgentConfigMap{
+			ConfigMap: map[string]*protobufs.AgentConfigFile{
+				"": {Body: []byte(body), ContentType: "text/yaml"},
+			},
+		},
+		ConfigHash: []byte(hash),
+	}
+}
+
+// catchReload intercepts the SIGHUP sent to request a reload.
+func catchReload(t *testing.T) <-chan os.Signal {
+	t.Helper()
+	reloads := make(chan os.Signal, 1)
+	signal.Notify(reloads, syscall.SIGHUP)
+	t.Cleanup(func() { signal.Stop(reloads) })
+	return reloads
+}
+
+func writeConfig(t *testing.T, body string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(body), 0o600))
+	return path
+}
+
+func stringAttribute(attrs []*protobufs.KeyValue, key string) string {
+	for _, kv := range attrs {
+		if kv.GetKey() == key {
+			return kv.GetValue().GetStringValue()
+		}
+	}
+	return ""
+}
+
+func TestNewFactory(t *testing.T) {
+	factory := tfoopampextension.NewFactory()
+	assert.Equal(t, component.MustNewType("tfoopamp"), factory.Type())
+
+	cfg := factory.CreateDefaultConfig().(*tfoopampextension.Config)
+	assert.Empty(t, cfg.Endpoint)
+	assert.False(t, cfg.RemoteConfig.Accept)
+}
+
+func TestExtension_ReportsIdentityAndHealth(t *testing.T) {
+	srv := startServer(t, nil)
+	identityID := component.MustNewID("tfoidentity")
+	host := &extensionsHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{identityID: identityExtension{}},
+	}
+	cfg := &tfoopampextension.Config{
+		Endpoint:          "ws://" + srv.endpoint + "/v1/opamp",
+		CollectorIdentity: identityID,
+	}
+	ext := startExtension(t, tfoopampextension.NewFactory(), cfg, host)
+
+	var description *protobufs.AgentDescription
+	require.Eventually(t, func() bool {
+		msg := srv.find(func(m *protobufs.AgentToServer) bool { return m.GetAgentDescription() != nil })
+		if msg != nil {
+			description = msg.GetAgentDescription()
+		}
+		return description != nil
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, "collector-1", stringAttribute(description.GetIdentifyingAttributes(), "service.instance.id"))
+	assert.Equal(t, "edge-host", stringAttribute(description.GetNonIdentifyingAttributes(), "host.name"))
+	assert.Equal(t, "edge-collector", stringAttribute(description.GetNonIdentifyingAttributes(), "telemetryflow.collector.name"))
+	assert.Equal(t, "jkt", stringAttribute(description.GetNonIdentifyingAttributes(), "telemetryflow.collector.tag.region"))
+
+	watcher, ok := ext.(componentstatus.Watcher)
+	require.True(t, ok, "extension must watch component statuses")
+	source := componentstatus.NewInstanceID(component.MustNewID("tfootlp"), component.KindReceiver)
+	watcher.ComponentStatusChanged(source, componentstatus.NewRecoverableErrorEvent(errors.New("port in use")))
+
+	require.Eventually(t, func() bool {
+		msg := srv.find(func(m *protobufs.AgentToServer) bool { return m.GetHealth() != nil })
+		return msg != nil && !msg.GetHealth().GetHealthy()
+	}, 5*time.Second, 10*time.Millisecond)
+	health := srv.find(func(m *protobufs.AgentToServer) bool { return m.GetHealth() != nil }).GetHealth()
+	assert.Equal(t, "receiver:tfootlp: port in use", health.GetLastError())
+	require.Contains(t, health.GetComponentHealthMap(), "receiver:tfootlp")
+	assert.False(t, health.GetComponentHealthMap()["receiver:tfootlp"].GetHealthy())
+}
+
+func TestExtension_MissingIdentity(t *testing.T) {
+	cfg := &tfoopampextension.Config{
+		Endpoint:          "ws://127.0.0.1:1/v1/opamp",
+		CollectorIdentity: component.MustNewID("tfoidentity"),
+	}
+	set := extensiontest.NewNopSettings(component.MustNewType("tfoopamp"))
+	ext, err := tfoopampextension.NewFactory().Create(context.Background(), set, cfg)
+	require.NoError(t, err)
+	err = ext.Start(context.Background(), componenttest.NewNopHost())
+	assert.ErrorContains(t, err, `tfoidentity extension "tfoidentity" not found`)
+}
+
+func TestExtension_AppliesRemoteConfig(t *testing.T) {
+	reloads := catchReload(t)
+	path := writeConfig(t, "# previous\n"+validConfig)
+	pushed := "# pushed\n" + validConfig
+	srv := startServer(t, remoteConfig(pushed, "hash-1"))
+
+	cfg := &tfoopampextension.Config{
+		Endpoint: "ws://" + srv.endpoint + "/v1/opamp",
+		RemoteConfig: tfoopampextension.RemoteConfigConfig{
+			Accept: true,
+			Path:   path,
+		},
+	}
+	startExtension(t, tfoopampextension.NewFactory(), cfg, componenttest.NewNopHost())
+
+	select {
+	case <-reloads:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a configuration reload")
+	}
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, pushed, string(data))
+	backup, err := os.ReadFile(path + ".bak")
+	require.NoError(t, err)
+	assert.Equal(t, "# previous\n"+validConfig, string(backup))
+
+	require.Eventually(t, func() bool {
+		return srv.remoteConfigStatus() == protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.FileExists(t, path+".opamp")
+}
+
+func TestExtension_SkipsAppliedRemoteConfig(t *testing.T) {
+	reloads := catchReload(t)
+	path := writeConfig(t, validConfig)
+	require.NoError(t, os.WriteFile(path+".opamp", []byte(`{"hash":"aGFzaC0x","status":1}`), 0o600))
+	srv := startServer(t, remoteConfig("# pushed\n"+validConfig, "hash-1"))
+
+	cfg := &tfoopampextension.Config{
+		Endpoint: "ws://" + srv.endpoint + "/v1/opamp",
+		RemoteConfig: tfoopampextension.RemoteConfigConfig{
+			Accept: true,
+			Path:   path,
+		},
+	}
+	startExtension(t, tfoopampextension.NewFactory(), cfg, componenttest.NewNopHost())
+
+	// The status restored from the state file is reported on connect.
+	require.Eventually(t, func() bool {
+		return srv.remoteConfigStatus() == protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED
+	}, 5*time.Second, 10*time.Millisecond)
+	select {
+	case <-reloads:
+		t.Fatal("an applied configuration must not be applied again")
+	case <-time.After(200 * time.Millisecond):
+	}
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, validConfig, string(data))
+}
+
+func TestExtension_RejectsInvalidRemoteConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		factory extension.Factory
+		body    string
+		wantErr string
+	}{
+		{
+			name:    "no service section",
+			factory: tfoopampextension.NewFactory(),
+			body:    "receivers:\n  otlp:\n",
+			wantErr: "missing service section",
+		},
+		{
+			name:    "not yaml",
+			factory: tfoopampextension.NewFactory(),
+			body:    "service: [unterminated",
+			wantErr: "invalid configuration",
+		},
+		{
+			name: "rejected by validator",
+			factory: tfoopampextension.NewFactoryWithValidator(func(_ context.Context, _, candidate string) error {
+				if _, err := os.Stat(candidate); err != nil {
+					return err
+				}
+				return errors.New(`unknown exporter type "missing"`)
+			}),
+			body:    validConfig,
+			wantErr: `unknown exporter type "missing"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reloads := catchReload(t)
+			path := writeConfig(t, validConfig)
+			srv := startServer(t, remoteConfig(tt.body, "hash-2"))
+
+			cfg := &tfoopampextension.Config{
+				Endpoint: "http://" + srv.endpoint + "/v1/opamp",
+				RemoteConfig: tfoopampextension.RemoteConfigConfig{
+					Accept: true,
+					Path:   path,
+				},
+			}
+			startExtension(t, tt.factory, cfg, componenttest.NewNopHost())
+
+			require.Eventually(t, func() bool {
+				return srv.remoteConfigStatus() == protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED
+			}, 5*time.Second, 10*time.Millisecond)
+			msg := srv.find(func(m *protobufs.AgentToServer) bool { return m.GetRemoteConfigStatus() != nil })
+			assert.Contains(t, msg.GetRemoteConfigStatus().GetErrorMessage(), tt.wantErr)
+
+			select {
+			case <-reloads:
+				t.Fatal("an invalid configuration must not be applied")
+			default:
+			}
+			data, err := os.ReadFile(path)
+			require.NoError(t, err)
+			assert.Equal(t, validConfig, string(data))
+			assert.NoFileExists(t, path+".bak")
+		})
+	}
+}
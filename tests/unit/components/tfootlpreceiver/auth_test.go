@@ -0,0 +1,144 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/extension/extensionauth"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfootlpreceiver"
+)
+
+var tokenAuthID = component.MustNewID("tokenauth")
+
+// tokenAuth is a server auth extension accepting requests whose "token"
+// header or metadata is "secret".
+type tokenAuth struct {
+	component.StartFunc
+	component.ShutdownFunc
+	extensionauth.ServerAuthenticateFunc
+}
+
+func newTokenAuthHost() component.Host {
+	auth := tokenAuth{ServerAuthenticateFunc: func(ctx context.Context, sources map[string][]string) (context.Context, error) {
+		for key, values := range sources {
+			if http.CanonicalHeaderKey(key) == "Token" && len(values) == 1 && values[0] == "secret" {
+				return ctx, nil
+			}
+		}
+		return ctx, errors.New("missing token")
+	}}
+	return identityHost{
+		Host: componenttest.NewNopHost(),
+		exts: map[component.ID]component.Component{tokenAuthID: auth},
+	}
+}
+
+// startAuthReceiver starts an HTTP and gRPC receiver whose protocols both
+// authenticate through the tokenauth extension.
+func startAuthReceiver(t *testing.T) (*tfootlpreceiver.Config, *consumertest.TracesSink) {
+	t.Helper()
+	cfg := grpcHTTPCfg(t)
+	cfg.Protocols.GRPC.Auth = configoptional.Some(configauth.Config{AuthenticatorID: tokenAuthID})
+	cfg.Protocols.HTTP.Auth = configoptional.Some(confighttp.AuthConfig{Config: configauth.Config{AuthenticatorID: tokenAuthID}})
+	require.NoError(t, cfg.Validate())
+
+	sink := new(consumertest.TracesSink)
+	r, err := tfootlpreceiver.NewFactory().CreateTraces(context.Background(),
+		receivertest.NewNopSettings(component.MustNewType("tfootlp")), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), newTokenAuthHost()))
+	t.Cleanup(func() { _ = r.Shutdown(context.Background()) })
+	time.Sleep(100 * time.Millisecond)
+	return cfg, sink
+}
+
+func TestAuth_HTTP(t *testing.T) {
+	cfg, sink := startAuthReceiver(t)
+	body, err := ptraceotlp.NewExportRequestFromTraces(traceIDs(1, 1)).MarshalProto()
+	require.NoError(t, err)
+
+	post := func(token string) int {
+		req, err := http.NewRequest(http.MethodPost, "http://"+cfg.Protocols.HTTP.NetAddr.Endpoint+"/v1/traces", bytes.NewReader(body))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/x-protobuf")
+		if token != "" {
+			req.Header.Set("Token", token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, post(""))
+	assert.Equal(t, http.StatusUnauthorized, post("wrong"))
+	assert.Equal(t, 0, sink.SpanCount())
+
+	assert.Equal(t, http.StatusOK, post("secret"))
+	assert.Equal(t, 1, sink.SpanCount())
+}
+
+func TestAuth_GRPC(t *testing.T) {
+	cfg, sink := startAuthReceiver(t)
+	cc, err := grpc.NewClient(cfg.Protocols.GRPC.NetAddr.Endpoint,
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+	client := ptraceotlp.NewGRPCClient(cc)
+	req := ptraceotlp.NewExportRequestFromTraces(traceIDs(1, 1))
+
+	_, err = client.Export(context.Background(), req)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	assert.Equal(t, 0, sink.SpanCount())
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "token", "secret")
+	_, err = client.Export(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, 1, sink.SpanCount())
+}
+
+func TestAuth_StartRequiresExtension(t *testing.T) {
+	cfg := grpcHTTPCfg(t)
+	cfg.Protocols.HTTP.Auth = configoptional.Some(confighttp.AuthConfig{Config: configauth.Config{AuthenticatorID: tokenAuthID}})
+
+	r, err := tfootlpreceiver.NewFactory().CreateTraces(context.Background(),
+		receivertest.NewNopSettings(component.MustNewType("tfootlp")), cfg, new(consumertest.TracesSink))
+	require.NoError(t, err)
+	err = r.Start(context.Background(), componenttest.NewNopHost())
+	assert.ErrorContains(t, err, "protocols.http: auth:")
+	_ = r.Shutdown(context.Background())
+}
@@ -0,0 +1,123 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfootlpreceiver"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+func TestV2Auth_FailuresCountedByReasonAndKeyPrefix(t *testing.T) {
+	cfg := httpOnlyCfg(t, true, false, []string{"tfk_edge0001"})
+	cfg.V2Auth.Validator = validatorID
+	require.NoError(t, cfg.Validate())
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { _ = tel.Shutdown(context.Background()) })
+	set := receivertest.NewNopSettings(component.MustNewType("tfootlp"))
+	set.TelemetrySettings = tel.NewTelemetrySettings()
+
+	sink := new(consumertest.LogsSink)
+	r, err := tfootlpreceiver.NewFactory().CreateLogs(context.Background(), set, cfg, sink)
+	require.NoError(t, err)
+	ext := &validator{valid: map[string]string{"tfk_edge0001": "tfs_right"}}
+	require.NoError(t, r.Start(context.Background(), newValidatorHost(ext)))
+	t.Cleanup(func() { _ = r.Shutdown(context.Background()) })
+	time.Sleep(50 * time.Millisecond)
+
+	requests := []struct {
+		keyID, keySecret string
+		want             int
+	}{
+		{"", "", http.StatusUnauthorized},
+		{"edge-0001", "", http.StatusUnauthorized},
+		{"tfk_stranger", "tfs_right", http.StatusForbidden},
+		{"tfk_edge0001", "", http.StatusUnauthorized},
+		{"tfk_edge0001", "right", http.StatusUnauthorized},
+		{"tfk_edge0001", "tfs_wrong", http.StatusUnauthorized},
+		{"tfk_edge0001", "tfs_wrong", http.StatusUnauthorized},
+		{"tfk_edge0001", "tfs_right", http.StatusOK},
+	}
+	for _, req := range requests {
+		assert.Equal(t, req.want, postLogsWithCredentials(t, cfg, req.keyID, req.keySecret).StatusCode)
+	}
+	assert.Equal(t, 1, sink.LogRecordCount())
+
+	m, err := tel.GetMetric(selfmetrics.ReceiverAuthFailures)
+	require.NoError(t, err)
+	got := make(map[[2]string]int64)
+	for _, point := range m.Data.(metricdata.Sum[int64]).DataPoints {
+		reason, _ := point.Attributes.Value(attribute.Key("reason"))
+		prefix, _ := point.Attributes.Value(attribute.Key("key_prefix"))
+		got[[2]string{reason.AsString(), prefix.AsString()}] = point.Value
+	}
+	assert.Equal(t, map[[2]string]int64{
+		{"missing_key", "none"}:                   1,
+		{"invalid_key_format", "invalid"}:         1,
+		{"key_not_allowed", "unknown"}:            1,
+		{"missing_secret", "tfk_edge****"}:        1,
+		{"invalid_secret_format", "tfk_edge****"}: 1,
+		{"invalid_credentials", "tfk_edge****"}:   2,
+	}, got)
+}
+
+func TestV2Auth_KeyPrefixOnlyForValidatedKeys(t *testing.T) {
+	cfg := httpOnlyCfg(t, true, false, nil)
+	cfg.V2Auth.Validator = validatorID
+	require.NoError(t, cfg.Validate())
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { _ = tel.Shutdown(context.Background()) })
+	set := receivertest.NewNopSettings(component.MustNewType("tfootlp"))
+	set.TelemetrySettings = tel.NewTelemetrySettings()
+
+	r, err := tfootlpreceiver.NewFactory().CreateLogs(context.Background(), set, cfg, new(consumertest.LogsSink))
+	require.NoError(t, err)
+	ext := &validator{valid: map[string]string{"tfk_edge0001": "tfs_right"}}
+	require.NoError(t, r.Start(context.Background(), newValidatorHost(ext)))
+	t.Cleanup(func() { _ = r.Shutdown(context.Background()) })
+	time.Sleep(50 * time.Millisecond)
+
+	// Made-up key IDs do not create series of their own.
+	for i := range 5 {
+		postLogsWithCredentials(t, cfg, fmt.Sprintf("tfk_random%02d", i), "tfs_wrong")
+	}
+	// A key once validated is named when it later fails.
+	assert.Equal(t, http.StatusUnauthorized, postLogsWithCredentials(t, cfg, "tfk_edge0001", "tfs_wrong").StatusCode)
+	assert.Equal(t, http.StatusOK, postLogsWithCredentials(t, cfg, "tfk_edge0001", "tfs_right").StatusCode)
+	assert.Equal(t, http.StatusUnauthorized, postLogsWithCredentials(t, cfg, "tfk_edge0001", "tfs_wrong").StatusCode)
+
+	m, err := tel.GetMetric(selfmetrics.ReceiverAuthFailures)
+	require.NoError(t, err)
+	got := make(map[string]int64)
+	for _, point := range m.Data.(metricdata.Sum[int64]).DataPoints {
+		prefix, _ := point.Attributes.Value(attribute.Key("key_prefix"))
+		got[prefix.AsString()] = point.Value
+	}
+	assert.Equal(t, map[string]int64{"unknown": 6, "tfk_edge****": 1}, got)
+}
@@ -0,0 +1,110 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver_test
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// startBodyLimitReceiver starts a logs receiver accepting HTTP bodies of
+// up to limit bytes and returns its logs URL.
+func startBodyLimitReceiver(t *testing.T, limit int64) (string, *consumertest.LogsSink) {
+	t.Helper()
+	cfg := httpOnlyCfg(t, false, false, nil)
+	cfg.Protocols.HTTP.MaxRequestBodySize = limit
+	sink := new(consumertest.LogsSink)
+	startLogsReceiver(t, cfg, sink)
+	return fmt.Sprintf("http://%s/v1/logs", cfg.Protocols.HTTP.NetAddr.Endpoint), sink
+}
+
+// postStream sends body without a Content-Length, so the receiver only
+// learns its size while reading it.
+func postStream(t *testing.T, url string, body []byte) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, url, io.MultiReader(bytes.NewReader(body)))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+	return resp
+}
+
+func TestHTTPBodyLimit_RejectsLargeBodies(t *testing.T) {
+	url, sink := startBodyLimitReceiver(t, 64<<10)
+	small, err := largeLogs(1024).MarshalProto()
+	require.NoError(t, err)
+	large, err := largeLogs(128 << 10).MarshalProto()
+	require.NoError(t, err)
+
+	resp, _ := doPost(t, url, nil, small)
+	defer func() { _ = resp.Body.Close() }()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// Announced by Content-Length.
+	resp, body := doPost(t, url, nil, large)
+	defer func() { _ = resp.Body.Close() }()
+	require.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+	assert.Equal(t, "application/x-protobuf", resp.Header.Get("Content-Type"))
+	var st status.Status
+	require.NoError(t, proto.Unmarshal(body, &st))
+	assert.Equal(t, int32(codes.ResourceExhausted), st.GetCode())
+	assert.Contains(t, st.GetMessage(), "max_request_body_size of 65536 bytes")
+
+	// Streamed without Content-Length.
+	assert.Equal(t, http.StatusRequestEntityTooLarge, postStream(t, url, large).StatusCode)
+	assert.Equal(t, http.StatusOK, postStream(t, url, small).StatusCode)
+
+	assert.Equal(t, 2, sink.LogRecordCount())
+}
+
+func TestHTTPBodyLimit_JSONStatus(t *testing.T) {
+	url, _ := startBodyLimitReceiver(t, 1024)
+	data, err := largeLogs(4096).MarshalJSON()
+	require.NoError(t, err)
+
+	resp, body := doPostWithCT(t, url, nil, data, "application/json")
+	defer func() { _ = resp.Body.Close() }()
+	require.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	var st status.Status
+	require.NoError(t, protojson.Unmarshal(body, &st))
+	assert.Contains(t, st.GetMessage(), "max_request_body_size")
+}
+
+func TestHTTPBodyLimit_AppliesToWireSize(t *testing.T) {
+	// A compressed body within the limit may decode past it; the decoded
+	// size is bounded by compression.max_decompressed_size instead.
+	url, sink := startBodyLimitReceiver(t, 64<<10)
+	data, err := largeLogs(256 << 10).MarshalProto()
+	require.NoError(t, err)
+
+	resp, _ := doPost(t, url, map[string]string{"Content-Encoding": "gzip"}, encode(t, "gzip", data))
+	defer func() { _ = resp.Body.Close() }()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 1, sink.LogRecordCount())
+}
@@ -0,0 +1,224 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfootlpreceiver"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+)
+
+type captureEnv struct {
+	dir     string
+	ingest  string
+	admin   string
+	client  *http.Client
+	payload []byte
+}
+
+func startCaptureReceiver(t *testing.T, maxCount int) *captureEnv {
+	t.Helper()
+	cfg := httpOnlyCfg(t, false, false, nil)
+	env := &captureEnv{
+		dir:    t.TempDir(),
+		ingest: "http://" + cfg.Protocols.HTTP.NetAddr.Endpoint,
+		client: &http.Client{Timeout: 5 * time.Second},
+	}
+	adminAddr := fmt.Sprintf("127.0.0.1:%d", freePort(t))
+	env.admin = "http://" + adminAddr + "/capture"
+	cfg.PayloadCapture = tfootlpreceiver.PayloadCaptureConfig{
+		Enabled:       true,
+		AdminConfig:   serverconf.NewDefaultAdminConfig(adminAddr),
+		Directory:     env.dir,
+		MaxCount:      maxCount,
+		MaxDuration:   time.Minute,
+		RedactHeaders: []string{"X-Tenant-Auth"},
+	}
+	require.NoError(t, cfg.Validate())
+
+	factory := tfootlpreceiver.NewFactory()
+	set := receivertest.NewNopSettings(component.MustNewType("tfootlp"))
+	r, err := factory.CreateTraces(context.Background(), set, cfg, new(consumertest.TracesSink))
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { _ = r.Shutdown(context.Background()) })
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("captured")
+	env.payload, err = ptraceotlp.NewExportRequestFromTraces(td).MarshalProto()
+	require.NoError(t, err)
+	return env
+}
+
+func (e *captureEnv) adminCall(t *testing.T, method, body string) (int, map[string]any) {
+	t.Helper()
+	req, err := http.NewRequest(method, e.admin, strings.NewReader(body))
+	require.NoError(t, err)
+	resp, err := e.client.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	var out map[string]any
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(&out))
+	return resp.StatusCode, out
+}
+
+func (e *captureEnv) send(t *testing.T, path string) {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, e.ingest+path+"?api_key=s3cr3t&tenant=a", bytes.NewReader(e.payload))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("Authorization", "Bearer s3cr3t")
+	req.Header.Set("X-TelemetryFlow-Key-Secret", "tfs_s3cr3t")
+	req.Header.Set("X-Tenant-Auth", "s3cr3t")
+	req.Header.Set("X-Session-Token", "s3cr3t")
+	req.Header.Set("User-Agent", "otel-sdk/1.0")
+	resp, err := e.client.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestPayloadCapture_CapturesNextN(t *testing.T) {
+	env := startCaptureReceiver(t, 10)
+
+	// Nothing is captured before a session is armed.
+	env.send(t, "/v1/traces")
+	entries, err := os.ReadDir(env.dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	code, status := env.adminCall(t, http.MethodPost, `{"endpoint": "/v1/traces", "count": 2, "duration": "30s"}`)
+	require.Equal(t, http.StatusCreated, code)
+	assert.Equal(t, true, status["active"])
+	sessionDir := status["directory"].(string)
+
+	env.send(t, "/v2/traces") // other endpoint: ignored
+	env.send(t, "/v1/traces")
+	env.send(t, "/v1/traces")
+	env.send(t, "/v1/traces") // beyond count: ignored
+
+	files, err := filepath.Glob(filepath.Join(sessionDir, "*"))
+	require.NoError(t, err)
+	for i := range files {
+		files[i] = filepath.Base(files[i])
+	}
+	assert.ElementsMatch(t, []string{"0001.pb", "0001.meta.json", "0002.pb", "0002.meta.json"}, files)
+
+	body, err := os.ReadFile(filepath.Join(sessionDir, "0001.pb"))
+	require.NoError(t, err)
+	assert.Equal(t, env.payload, body, "payload is stored verbatim")
+
+	info, err := os.Stat(filepath.Join(sessionDir, "0001.pb"))
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	code, status = env.adminCall(t, http.MethodGet, "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, false, status["active"])
+	assert.EqualValues(t, 2, status["captured"])
+}
+
+func TestPayloadCapture_RedactsSecrets(t *testing.T) {
+	env := startCaptureReceiver(t, 10)
+
+	code, status := env.adminCall(t, http.MethodPost, `{"endpoint": "/v2/traces", "count": 1}`)
+	require.Equal(t, http.StatusCreated, code)
+	env.send(t, "/v2/traces")
+
+	raw, err := os.ReadFile(filepath.Join(status["directory"].(string), "0001.meta.json"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(raw), "s3cr3t")
+
+	var meta struct {
+		Path    string              `json:"path"`
+		Query   string              `json:"query"`
+		Size    int                 `json:"size"`
+		Headers map[string][]string `json:"headers"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &meta))
+	assert.Equal(t, "/v2/traces", meta.Path)
+	assert.Equal(t, len(env.payload), meta.Size)
+	assert.Contains(t, meta.Query, "tenant=a")
+	for _, h := range []string{"Authorization", "X-Telemetryflow-Key-Secret", "X-Tenant-Auth", "X-Session-Token"} {
+		assert.Equal(t, []string{"[REDACTED]"}, meta.Headers[h], h)
+	}
+	assert.Equal(t, []string{"otel-sdk/1.0"}, meta.Headers["User-Agent"])
+}
+
+func TestPayloadCapture_AdminAPI(t *testing.T) {
+	env := startCaptureReceiver(t, 5)
+
+	code, _ := env.adminCall(t, http.MethodGet, "")
+	assert.Equal(t, http.StatusNotFound, code, "no session yet")
+
+	for _, body := range []string{
+		`{"endpoint": "/v1/unknown"}`,
+		`{"endpoint": "/v1/traces", "count": 6}`,
+		`{"endpoint": "/v1/traces", "duration": "2m"}`,
+		`{"endpoint": "/v1/traces", "duration": "soon"}`,
+		`not json`,
+	} {
+		code, _ := env.adminCall(t, http.MethodPost, body)
+		assert.Equal(t, http.StatusBadRequest, code, body)
+	}
+
+	code, _ = env.adminCall(t, http.MethodPost, `{"endpoint": "/v1/logs"}`)
+	require.Equal(t, http.StatusCreated, code)
+	code, _ = env.adminCall(t, http.MethodPost, `{"endpoint": "/v1/traces"}`)
+	assert.Equal(t, http.StatusConflict, code, "one session at a time")
+
+	code, status := env.adminCall(t, http.MethodDelete, "")
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, false, status["active"])
+	assert.EqualValues(t, 5, status["limit"], "count defaults to min(10, max_count)")
+
+	code, _ = env.adminCall(t, http.MethodPost, `{"endpoint": "/v1/traces"}`)
+	assert.Equal(t, http.StatusCreated, code, "a new session can start after stop")
+}
+
+func TestPayloadCapture_Expires(t *testing.T) {
+	env := startCaptureReceiver(t, 10)
+
+	code, status := env.adminCall(t, http.MethodPost, `{"endpoint": "/v1/traces", "duration": "50ms"}`)
+	require.Equal(t, http.StatusCreated, code)
+	time.Sleep(100 * time.Millisecond)
+	env.send(t, "/v1/traces")
+
+	entries, err := os.ReadDir(status["directory"].(string))
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	_, status = env.adminCall(t, http.MethodGet, "")
+	assert.Equal(t, false, status["active"])
+}
@@ -0,0 +1,92 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/status"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfootlpreceiver"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/bytesize"
+)
+
+// exportGRPC sends a traces request of n spans to endpoint with the given
+// call options.
+func exportGRPC(t *testing.T, endpoint string, n int, opts ...grpc.CallOption) error {
+	t.Helper()
+	cc, err := grpc.NewClient(endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+	_, err = ptraceotlp.NewGRPCClient(cc).Export(context.Background(), ptraceotlp.NewExportRequestFromTraces(spans(n)), opts...)
+	return err
+}
+
+func TestReceiver_GRPCCompression(t *testing.T) {
+	cfg := grpcHTTPCfg(t)
+	sink := new(consumertest.TracesSink)
+	startTracesReceiver(t, cfg, sink)
+
+	// The receiver registers the zstd and snappy compressors the client
+	// uses too.
+	for i, name := range []string{gzip.Name, "zstd", "snappy"} {
+		t.Run(name, func(t *testing.T) {
+			require.NoError(t, exportGRPC(t, cfg.Protocols.GRPC.NetAddr.Endpoint, 2, grpc.UseCompressor(name)))
+			assert.Equal(t, 2*(i+1), sink.SpanCount())
+		})
+	}
+}
+
+func TestReceiver_GRPCMaxRecvMsgSize(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(cfg *tfootlpreceiver.GRPCConfig)
+	}{
+		{"max_recv_msg_size", func(cfg *tfootlpreceiver.GRPCConfig) { cfg.MaxRecvMsgSize = bytesize.MiB }},
+		{"deprecated max_recv_msg_size_mib", func(cfg *tfootlpreceiver.GRPCConfig) {
+			cfg.MaxRecvMsgSize = 4 * bytesize.MiB
+			cfg.MaxRecvMsgSizeMiB = 1
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := grpcHTTPCfg(t)
+			tt.mutate(cfg.Protocols.GRPC)
+			sink := new(consumertest.TracesSink)
+			startTracesReceiver(t, cfg, sink)
+			endpoint := cfg.Protocols.GRPC.NetAddr.Endpoint
+
+			// Over 1 MiB of spans: over the configured limit, under the default 4 MiB.
+			err := exportGRPC(t, endpoint, 200000)
+			assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+			// The limit applies to the decompressed message.
+			err = exportGRPC(t, endpoint, 200000, grpc.UseCompressor(gzip.Name))
+			assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+			require.NoError(t, exportGRPC(t, endpoint, 100))
+			assert.Equal(t, 100, sink.SpanCount())
+		})
+	}
+}
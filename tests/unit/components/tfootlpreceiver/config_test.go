@@ -16,10 +16,16 @@ package tfootlpreceiver_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/confighttp"
+
 	"github.com/telemetryflow/telemetryflow-collector/components/tfootlpreceiver"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/watchdog"
 )
 
 func TestConfig_Validate(t *testing.T) {
@@ -126,6 +132,141 @@ func TestConfig_Validate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "validate_secret with valid_api_key_ids requires tfoauth extension",
 		},
+		{
+			name: "invalid grpc acl cidr",
+			config: tfootlpreceiver.Config{
+				Protocols: tfootlpreceiver.ProtocolsConfig{
+					GRPC: &tfootlpreceiver.GRPCConfig{
+						Config: serverconf.Config{ACL: serverconf.ACLConfig{AllowedCIDRs: []string{"10.0.0.0/99"}}},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "protocols.grpc: acl.allowed_cidrs",
+		},
+		{
+			name: "invalid http proxy protocol trusted cidr",
+			config: tfootlpreceiver.Config{
+				Protocols: tfootlpreceiver.ProtocolsConfig{
+					HTTP: &tfootlpreceiver.HTTPConfig{
+						Config: serverconf.Config{ProxyProtocol: serverconf.ProxyProtocolConfig{
+							Enabled:      true,
+							TrustedCIDRs: []string{"lb"},
+						}},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "protocols.http: proxy_protocol.trusted_cidrs",
+		},
+		{
+			name: "invalid watchdog stall timeout",
+			config: tfootlpreceiver.Config{
+				Watchdog: watchdog.Config{Enabled: true, CheckInterval: time.Second},
+			},
+			wantErr: true,
+			errMsg:  "watchdog.stall_timeout",
+		},
+		{
+			name: "payload capture without directory",
+			config: tfootlpreceiver.Config{
+				PayloadCapture: tfootlpreceiver.PayloadCaptureConfig{
+					Enabled: true, AdminConfig: serverconf.NewDefaultAdminConfig("localhost:0"), MaxCount: 1, MaxDuration: time.Minute,
+				},
+			},
+			wantErr: true,
+			errMsg:  "payload_capture.directory",
+		},
+		{
+			name: "payload capture with non-positive max_count",
+			config: tfootlpreceiver.Config{
+				PayloadCapture: tfootlpreceiver.PayloadCaptureConfig{
+					Enabled: true, AdminConfig: serverconf.NewDefaultAdminConfig("localhost:0"), Directory: "/tmp", MaxDuration: time.Minute,
+				},
+			},
+			wantErr: true,
+			errMsg:  "payload_capture.max_count",
+		},
+		{
+			name: "payload capture off localhost without client authentication",
+			config: tfootlpreceiver.Config{
+				PayloadCapture: tfootlpreceiver.PayloadCaptureConfig{
+					Enabled: true, AdminConfig: serverconf.NewDefaultAdminConfig("0.0.0.0:55690"), Directory: "/tmp", MaxCount: 1, MaxDuration: time.Minute,
+				},
+			},
+			wantErr: true,
+			errMsg:  `payload_capture: endpoint "0.0.0.0:55690" is not a loopback address`,
+		},
+		{
+			name: "websocket path of an export path",
+			config: tfootlpreceiver.Config{
+				Protocols: tfootlpreceiver.ProtocolsConfig{
+					HTTP: &tfootlpreceiver.HTTPConfig{
+						WebSocket: tfootlpreceiver.WebSocketConfig{
+							Enabled: true, Path: "/v2/traces", MaxMessageSize: 1, PingInterval: time.Second,
+						},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  `protocols.http: websocket.path "/v2/traces" is already an export path`,
+		},
+		{
+			name: "websocket with non-positive ping_interval",
+			config: tfootlpreceiver.Config{
+				Protocols: tfootlpreceiver.ProtocolsConfig{
+					HTTP: &tfootlpreceiver.HTTPConfig{
+						WebSocket: tfootlpreceiver.WebSocketConfig{
+							Enabled: true, Path: "/v1/websocket", MaxMessageSize: 1,
+						},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "protocols.http: websocket.ping_interval",
+		},
+		{
+			name: "negative grpc max_recv_msg_size_mib",
+			config: tfootlpreceiver.Config{
+				Protocols: tfootlpreceiver.ProtocolsConfig{
+					GRPC: &tfootlpreceiver.GRPCConfig{
+						ServerConfig: configgrpc.ServerConfig{MaxRecvMsgSizeMiB: -1},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "protocols.grpc: max_recv_msg_size and max_recv_msg_size_mib must not be negative",
+		},
+		{
+			name: "http include_metadata is rejected",
+			config: tfootlpreceiver.Config{
+				Protocols: tfootlpreceiver.ProtocolsConfig{
+					HTTP: &tfootlpreceiver.HTTPConfig{
+						ServerConfig: confighttp.ServerConfig{IncludeMetadata: true},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "protocols.http: include_metadata is not supported",
+		},
+		{
+			name: "grpc include_metadata is rejected",
+			config: tfootlpreceiver.Config{
+				Protocols: tfootlpreceiver.ProtocolsConfig{
+					GRPC: &tfootlpreceiver.GRPCConfig{
+						ServerConfig: configgrpc.ServerConfig{IncludeMetadata: true},
+					},
+				},
+			},
+			wantErr: true,
+			errMsg:  "protocols.grpc: include_metadata is not supported",
+		},
+		{
+			name: "disabled payload capture is not validated",
+			config: tfootlpreceiver.Config{
+				PayloadCapture: tfootlpreceiver.PayloadCaptureConfig{MaxCount: -1},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,98 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfootlpreceiver"
+)
+
+func corsCfg(t *testing.T, origins, headers []string) *tfootlpreceiver.Config {
+	t.Helper()
+	cfg := httpOnlyCfg(t, false, false, nil)
+	cors := confighttp.NewDefaultCORSConfig()
+	cors.AllowedOrigins = origins
+	cors.AllowedHeaders = headers
+	cors.MaxAge = 7200
+	cfg.Protocols.HTTP.CORS = configoptional.Some(cors)
+	return cfg
+}
+
+func TestConfig_CORSCredentialsWithWildcard(t *testing.T) {
+	cfg := corsCfg(t, []string{"*"}, nil)
+	require.NoError(t, cfg.Validate())
+
+	cfg.Protocols.HTTP.CORSAllowCredentials = true
+	assert.ErrorContains(t, cfg.Validate(), "cors_allow_credentials")
+}
+
+func TestReceiver_CORSPreflight(t *testing.T) {
+	cfg := corsCfg(t, []string{"https://*.example.com"}, []string{"X-TelemetryFlow-Key-ID"})
+	require.NoError(t, cfg.Validate())
+
+	sink := new(consumertest.TracesSink)
+	r, err := tfootlpreceiver.NewFactory().CreateTraces(context.Background(),
+		receivertest.NewNopSettings(component.MustNewType("tfootlp")), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { _ = r.Shutdown(context.Background()) })
+
+	base := "http://" + cfg.Protocols.HTTP.NetAddr.Endpoint
+	client := &http.Client{Timeout: 5 * time.Second}
+
+	req, err := http.NewRequest(http.MethodOptions, base+"/v2/traces", nil)
+	require.NoError(t, err)
+	req.Header.Set("Origin", "https://rum.example.com")
+	req.Header.Set("Access-Control-Request-Method", "POST")
+	req.Header.Set("Access-Control-Request-Headers", "content-type,x-telemetryflow-key-id")
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	assert.Equal(t, "https://rum.example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "7200", resp.Header.Get("Access-Control-Max-Age"))
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("rum")
+	body, err := ptraceotlp.NewExportRequestFromTraces(td).MarshalJSON()
+	require.NoError(t, err)
+
+	req, err = http.NewRequest(http.MethodPost, base+"/v1/traces", bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Origin", "https://rum.example.com")
+	req.Header.Set("Content-Type", "application/json")
+	resp, err = client.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "https://rum.example.com", resp.Header.Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, 1, sink.SpanCount())
+}
@@ -0,0 +1,172 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfootlpreceiver"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+// postTraces sends a one-span JSON export request to the receiver.
+func postTraces(cfg *tfootlpreceiver.Config) (*http.Response, error) {
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("drain")
+	body, err := ptraceotlp.NewExportRequestFromTraces(td).MarshalJSON()
+	if err != nil {
+		return nil, err
+	}
+	client := &http.Client{Timeout: 5 * time.Second}
+	return client.Post("http://"+cfg.Protocols.HTTP.NetAddr.Endpoint+"/v1/traces", "application/json", bytes.NewReader(body))
+}
+
+// blockingTraces returns a consumer that signals entered and then waits for
+// release before accepting the data.
+func blockingTraces(t *testing.T) (consumer.Traces, <-chan struct{}, chan<- struct{}) {
+	t.Helper()
+	entered := make(chan struct{}, 1)
+	release := make(chan struct{})
+	tc, err := consumer.NewTraces(func(context.Context, ptrace.Traces) error {
+		entered <- struct{}{}
+		<-release
+		return nil
+	})
+	require.NoError(t, err)
+	return tc, entered, release
+}
+
+func startTraces(t *testing.T, set receiver.Settings, cfg *tfootlpreceiver.Config, tc consumer.Traces) receiver.Traces {
+	t.Helper()
+	r, err := tfootlpreceiver.NewFactory().CreateTraces(context.Background(), set, cfg, tc)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	return r
+}
+
+func TestConfig_DrainTimeout(t *testing.T) {
+	cfg := tfootlpreceiver.NewFactory().CreateDefaultConfig().(*tfootlpreceiver.Config)
+	assert.Equal(t, 10*time.Second, cfg.DrainTimeout)
+
+	cfg.DrainTimeout = -time.Second
+	assert.ErrorContains(t, cfg.Validate(), "drain_timeout")
+}
+
+func TestReceiver_RestartOnSameEndpoint(t *testing.T) {
+	cfg := httpOnlyCfg(t, false, false, nil)
+	set := receivertest.NewNopSettings(component.MustNewType("tfootlp"))
+
+	first := startTraces(t, set, cfg, consumertest.NewNop())
+	require.NoError(t, first.Shutdown(context.Background()))
+
+	sink := new(consumertest.TracesSink)
+	second := startTraces(t, set, cfg, sink)
+	t.Cleanup(func() { _ = second.Shutdown(context.Background()) })
+
+	resp, err := postTraces(cfg)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 1, sink.SpanCount())
+}
+
+func TestReceiver_DrainWaitsForInflightRequests(t *testing.T) {
+	cfg := httpOnlyCfg(t, false, false, nil)
+	cfg.DrainTimeout = 5 * time.Second
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { _ = tel.Shutdown(context.Background()) })
+	set := receivertest.NewNopSettings(component.MustNewType("tfootlp"))
+	set.TelemetrySettings = tel.NewTelemetrySettings()
+
+	tc, entered, release := blockingTraces(t)
+	r := startTraces(t, set, cfg, tc)
+
+	status := make(chan int, 1)
+	go func() {
+		resp, err := postTraces(cfg)
+		if err != nil {
+			status <- 0
+			return
+		}
+		_ = resp.Body.Close()
+		status <- resp.StatusCode
+	}()
+	<-entered
+
+	shutdownDone := make(chan error, 1)
+	go func() { shutdownDone <- r.Shutdown(context.Background()) }()
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+
+	require.NoError(t, <-shutdownDone)
+	assert.Equal(t, http.StatusOK, <-status)
+	_, err := tel.GetMetric("tfo_receiver_drain_dropped_requests")
+	assert.Error(t, err, "no request should be counted as dropped")
+}
+
+func TestReceiver_DrainTimeoutDropsRequests(t *testing.T) {
+	cfg := httpOnlyCfg(t, false, false, nil)
+	cfg.DrainTimeout = 50 * time.Millisecond
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { _ = tel.Shutdown(context.Background()) })
+	set := receivertest.NewNopSettings(component.MustNewType("tfootlp"))
+	set.TelemetrySettings = tel.NewTelemetrySettings()
+
+	tc, entered, release := blockingTraces(t)
+	defer close(release)
+	r := startTraces(t, set, cfg, tc)
+
+	clientErr := make(chan error, 1)
+	go func() {
+		resp, err := postTraces(cfg)
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+		clientErr <- err
+	}()
+	<-entered
+
+	require.NoError(t, r.Shutdown(context.Background()))
+	assert.Error(t, <-clientErr)
+
+	m, err := tel.GetMetric("tfo_receiver_drain_dropped_requests")
+	require.NoError(t, err)
+	sum := m.Data.(metricdata.Sum[int64])
+	require.Len(t, sum.DataPoints, 1)
+	assert.Equal(t, int64(1), sum.DataPoints[0].Value)
+	protocol, _ := sum.DataPoints[0].Attributes.Value(attribute.Key("protocol"))
+	assert.Equal(t, "http", protocol.AsString())
+	kind, _ := sum.DataPoints[0].Attributes.Value(selfmetrics.KindKey)
+	assert.Equal(t, "receiver", kind.AsString())
+	typ, _ := sum.DataPoints[0].Attributes.Value(selfmetrics.TypeKey)
+	assert.Equal(t, "tfootlp", typ.AsString())
+}
@@ -0,0 +1,52 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestReceiver_RepeatedConsumeFailuresLoggedOnce(t *testing.T) {
+	cfg := httpOnlyCfg(t, false, false, nil)
+	core, logs := observer.New(zapcore.InfoLevel)
+	set := receivertest.NewNopSettings(component.MustNewType("tfootlp"))
+	set.Logger = zap.New(core)
+
+	r := startTraces(t, set, cfg, consumertest.NewErr(errors.New("exporter queue is full")))
+	t.Cleanup(func() { _ = r.Shutdown(context.Background()) })
+
+	for i := 0; i < 5; i++ {
+		resp, err := postTraces(cfg)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	}
+
+	failures := logs.FilterMessage("Failed to consume traces").All()
+	require.Len(t, failures, 1)
+	assert.Equal(t, "exporter queue is full", failures[0].ContextMap()["error"])
+}
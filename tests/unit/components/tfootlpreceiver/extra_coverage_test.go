@@ -90,7 +90,7 @@ func (f *failingLogsConsumer) ConsumeLogs(ctx context.Context, ld plog.Logs) err
 
 // --- HTTP consumer-error paths (returns 500) ---
 
-func TestReceiver_V1Traces_ConsumerError_500(t *testing.T) {
+func TestReceiver_V1Traces_ConsumerError_503(t *testing.T) {
 	cfg := httpOnlyCfg(t, true, false, nil)
 	factory := tfootlpreceiver.NewFactory()
 	set := receivertest.NewNopSettings(component.MustNewType("tfootlp"))
@@ -111,11 +111,11 @@ func TestReceiver_V1Traces_ConsumerError_500(t *testing.T) {
 	url := fmt.Sprintf("http://%s/v1/traces", cfg.Protocols.HTTP.NetAddr.Endpoint)
 	resp, body := doPost(t, url, nil, data)
 	defer func() { _ = resp.Body.Close() }()
-	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
 	assert.Contains(t, string(body), "Failed to process traces")
 }
 
-func TestReceiver_V1Metrics_ConsumerError_500(t *testing.T) {
+func TestReceiver_V1Metrics_ConsumerError_503(t *testing.T) {
 	cfg := httpOnlyCfg(t, true, false, nil)
 	factory := tfootlpreceiver.NewFactory()
 	set := receivertest.NewNopSettings(component.MustNewType("tfootlp"))
@@ -135,11 +135,11 @@ func TestReceiver_V1Metrics_ConsumerError_500(t *testing.T) {
 	url := fmt.Sprintf("http://%s/v1/metrics", cfg.Protocols.HTTP.NetAddr.Endpoint)
 	resp, body := doPost(t, url, nil, data)
 	defer func() { _ = resp.Body.Close() }()
-	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
 	assert.Contains(t, string(body), "Failed to process metrics")
 }
 
-func TestReceiver_V1Logs_ConsumerError_500(t *testing.T) {
+func TestReceiver_V1Logs_ConsumerError_503(t *testing.T) {
 	cfg := httpOnlyCfg(t, true, false, nil)
 	factory := tfootlpreceiver.NewFactory()
 	set := receivertest.NewNopSettings(component.MustNewType("tfootlp"))
@@ -157,7 +157,7 @@ func TestReceiver_V1Logs_ConsumerError_500(t *testing.T) {
 	url := fmt.Sprintf("http://%s/v1/logs", cfg.Protocols.HTTP.NetAddr.Endpoint)
 	resp, body := doPost(t, url, nil, data)
 	defer func() { _ = resp.Body.Close() }()
-	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
 	assert.Contains(t, string(body), "Failed to process logs")
 }
 
@@ -30,7 +30,7 @@ import (
 
 // TestReceiver_Factory_NilConfig exercises the nil-config and wrong-type
 // branches that flow through resolveReceiverConfig + newTFOOTLPReceiver. The
-// nil check in newTFOOTLPReceiver runs BEFORE the singleton lookup, so the
+// nil check in newTFOOTLPReceiver runs BEFORE the shared instance lookup, so the
 // test is hermetic regardless of prior receiver state.
 func TestReceiver_Factory_NilConfig(t *testing.T) {
 	factory := tfootlpreceiver.NewFactory()
@@ -0,0 +1,132 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfootlpreceiver"
+)
+
+// startFanOut creates a traces receiver for cfg once per consumer and
+// starts it.
+func startFanOut(t *testing.T, cfg *tfootlpreceiver.Config, consumers ...consumer.Traces) {
+	t.Helper()
+	factory := tfootlpreceiver.NewFactory()
+	set := receivertest.NewNopSettings(component.MustNewType(tfootlpreceiver.TypeStr))
+	var r component.Component
+	for _, tc := range consumers {
+		var err error
+		r, err = factory.CreateTraces(context.Background(), set, cfg, tc)
+		require.NoError(t, err)
+	}
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, r.Shutdown(context.Background())) })
+}
+
+func exportStatus(t *testing.T, cfg *tfootlpreceiver.Config) int {
+	t.Helper()
+	resp, err := postTraces(cfg)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestReceiver_FanOut_AllConsumers(t *testing.T) {
+	cfg := httpOnlyCfg(t, false, false, nil)
+	a, b := new(consumertest.TracesSink), new(consumertest.TracesSink)
+	startFanOut(t, cfg, a, b)
+
+	assert.Equal(t, http.StatusOK, exportStatus(t, cfg))
+	assert.Equal(t, 1, a.SpanCount())
+	assert.Equal(t, 1, b.SpanCount())
+}
+
+func TestReceiver_FanOut_ErrorIsolation(t *testing.T) {
+	cfg := httpOnlyCfg(t, false, false, nil)
+	sink := new(consumertest.TracesSink)
+	startFanOut(t, cfg, consumertest.NewErr(errors.New("pipeline down")), sink)
+
+	assert.Equal(t, http.StatusOK, exportStatus(t, cfg), "accepted by one consumer")
+	assert.Equal(t, 1, sink.SpanCount())
+}
+
+func TestReceiver_FanOut_AllFail(t *testing.T) {
+	cfg := httpOnlyCfg(t, false, false, nil)
+	startFanOut(t, cfg,
+		consumertest.NewErr(errors.New("first down")),
+		consumertest.NewErr(errors.New("second down")))
+
+	assert.Equal(t, http.StatusServiceUnavailable, exportStatus(t, cfg))
+}
+
+func TestReceiver_FanOut_MutatingConsumerGetsCopy(t *testing.T) {
+	cfg := httpOnlyCfg(t, false, false, nil)
+	rename := func(name string) consumer.Traces {
+		tc, err := consumer.NewTraces(func(_ context.Context, td ptrace.Traces) error {
+			td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).SetName(name)
+			return nil
+		}, consumer.WithCapabilities(consumer.Capabilities{MutatesData: true}))
+		require.NoError(t, err)
+		return tc
+	}
+	before, after := new(consumertest.TracesSink), new(consumertest.TracesSink)
+	// The sinks keep the data they were passed: the mutating consumers
+	// must not modify it.
+	startFanOut(t, cfg, rename("first"), before, rename("second"), after)
+
+	assert.Equal(t, http.StatusOK, exportStatus(t, cfg))
+	require.Equal(t, 1, before.SpanCount())
+	require.Equal(t, 1, after.SpanCount())
+	assert.Equal(t, "drain", before.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+	assert.Equal(t, "drain", after.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+}
+
+func TestReceiver_IndependentPerConfig(t *testing.T) {
+	cfgA, cfgB := httpOnlyCfg(t, false, false, nil), httpOnlyCfg(t, false, false, nil)
+	a, b := new(consumertest.TracesSink), new(consumertest.TracesSink)
+	// Created before either starts, as the service does for two receiver
+	// IDs: neither replaces the other's configuration or consumer.
+	factory := tfootlpreceiver.NewFactory()
+	set := receivertest.NewNopSettings(component.MustNewType(tfootlpreceiver.TypeStr))
+	ra, err := factory.CreateTraces(context.Background(), set, cfgA, a)
+	require.NoError(t, err)
+	rb, err := factory.CreateTraces(context.Background(), set, cfgB, b)
+	require.NoError(t, err)
+	for _, r := range []component.Component{ra, rb} {
+		require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+		t.Cleanup(func() { assert.NoError(t, r.Shutdown(context.Background())) })
+	}
+
+	assert.Equal(t, http.StatusOK, exportStatus(t, cfgA))
+	assert.Equal(t, 1, a.SpanCount())
+	assert.Equal(t, 0, b.SpanCount())
+
+	assert.Equal(t, http.StatusOK, exportStatus(t, cfgB))
+	assert.Equal(t, 1, a.SpanCount())
+	assert.Equal(t, 1, b.SpanCount())
+}
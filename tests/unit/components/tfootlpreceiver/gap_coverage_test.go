@@ -311,7 +311,7 @@ func TestReceiver_AllSignals_HTTP(t *testing.T) {
 	rl, err := factory.CreateLogs(context.Background(), set, cfg, logsSink)
 	require.NoError(t, err)
 
-	// Start any one of them — they share the singleton instance.
+	// Start any one of them — they share one instance.
 	require.NoError(t, rt.Start(context.Background(), componenttest.NewNopHost()))
 	t.Cleanup(func() {
 		_ = rl.Shutdown(context.Background())
@@ -0,0 +1,111 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfootlpreceiver"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/bytesize"
+)
+
+// startCompressionReceiver starts a logs receiver with the gRPC
+// compression settings and returns a client for it.
+func startCompressionReceiver(t *testing.T, compression tfootlpreceiver.GRPCCompressionConfig) (plogotlp.GRPCClient, *consumertest.LogsSink) {
+	t.Helper()
+	cfg := grpcHTTPCfg(t)
+	cfg.Protocols.GRPC.Compression = compression
+	require.NoError(t, cfg.Validate())
+
+	sink := new(consumertest.LogsSink)
+	r, err := tfootlpreceiver.NewFactory().CreateLogs(context.Background(),
+		receivertest.NewNopSettings(component.MustNewType("tfootlp")), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { _ = r.Shutdown(context.Background()) })
+	time.Sleep(50 * time.Millisecond)
+
+	conn, err := grpc.NewClient(cfg.Protocols.GRPC.NetAddr.Endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return plogotlp.NewGRPCClient(conn), sink
+}
+
+// largeLogs returns an export request of one highly compressible record
+// with a body of size bytes.
+func largeLogs(size int) plogotlp.ExportRequest {
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr(strings.Repeat("a", size))
+	return plogotlp.NewExportRequestFromLogs(ld)
+}
+
+func TestGRPCCompression_AcceptsRegisteredCodecs(t *testing.T) {
+	client, sink := startCompressionReceiver(t, tfootlpreceiver.GRPCCompressionConfig{})
+
+	for _, codec := range []string{"gzip", "zstd", "snappy"} {
+		_, err := client.Export(context.Background(), largeLogs(1<<10), grpc.UseCompressor(codec))
+		require.NoError(t, err, codec)
+	}
+	assert.Equal(t, 3, sink.LogRecordCount())
+}
+
+func TestGRPCCompression_RejectsCodecsNotAccepted(t *testing.T) {
+	client, sink := startCompressionReceiver(t, tfootlpreceiver.GRPCCompressionConfig{Accepted: []string{"zstd"}})
+
+	_, err := client.Export(context.Background(), largeLogs(1<<10), grpc.UseCompressor("zstd"))
+	require.NoError(t, err)
+	_, err = client.Export(context.Background(), largeLogs(1<<10))
+	require.NoError(t, err, "uncompressed messages are always accepted")
+
+	_, err = client.Export(context.Background(), largeLogs(1<<10), grpc.UseCompressor("gzip"))
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+	assert.Equal(t, 2, sink.LogRecordCount())
+}
+
+func TestGRPCCompression_MaxDecompressedSize(t *testing.T) {
+	client, sink := startCompressionReceiver(t, tfootlpreceiver.GRPCCompressionConfig{MaxDecompressedMsgSize: bytesize.MiB})
+
+	_, err := client.Export(context.Background(), largeLogs(512<<10), grpc.UseCompressor("gzip"))
+	require.NoError(t, err)
+
+	// A few kilobytes on the wire that inflate past the bound.
+	_, err = client.Export(context.Background(), largeLogs(2<<20), grpc.UseCompressor("gzip"))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, 1, sink.LogRecordCount())
+}
+
+func TestGRPCCompressionConfig_Validate(t *testing.T) {
+	cfg := tfootlpreceiver.GRPCCompressionConfig{Accepted: []string{"gzip", "br"}}
+	assert.EqualError(t, cfg.Validate(), `compression.accepted: unknown codec "br" (expected one of gzip, zstd, snappy)`)
+
+	cfg = tfootlpreceiver.GRPCCompressionConfig{MaxDecompressedMsgSize: -1}
+	assert.EqualError(t, cfg.Validate(), "compression.max_decompressed_msg_size must not be negative")
+}
@@ -0,0 +1,75 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfootlpreceiver"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+)
+
+// startHardenedHTTP starts an HTTP-only receiver with the given listener
+// hardening settings and returns its base URL.
+func startHardenedHTTP(t *testing.T, hardening serverconf.Config) string {
+	t.Helper()
+	cfg := httpOnlyCfg(t, false, false, nil)
+	cfg.Protocols.HTTP.Config = hardening
+
+	factory := tfootlpreceiver.NewFactory()
+	set := receivertest.NewNopSettings(component.MustNewType("tfootlp"))
+	r, err := factory.CreateTraces(context.Background(), set, cfg, new(consumertest.TracesSink))
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { _ = r.Shutdown(context.Background()) })
+
+	return "http://" + cfg.Protocols.HTTP.NetAddr.Endpoint
+}
+
+func TestReceiver_HTTP_ACLRejectsDeniedClient(t *testing.T) {
+	base := startHardenedHTTP(t, serverconf.Config{
+		ACL: serverconf.ACLConfig{DeniedCIDRs: []string{"127.0.0.0/8"}},
+	})
+
+	client := &http.Client{Timeout: 2 * time.Second}
+	resp, err := client.Post(base+"/v1/traces", "application/x-protobuf", bytes.NewReader(nil))
+	if resp != nil {
+		_ = resp.Body.Close()
+	}
+	require.Error(t, err, "connection from a denied address must be dropped")
+}
+
+func TestReceiver_HTTP_ACLAllowsPermittedClient(t *testing.T) {
+	base := startHardenedHTTP(t, serverconf.Config{
+		ACL: serverconf.ACLConfig{AllowedCIDRs: []string{"127.0.0.1/32"}},
+	})
+
+	client := &http.Client{Timeout: 2 * time.Second}
+	resp, err := client.Get(base + "/v1/traces")
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
@@ -0,0 +1,63 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestReceiver_ResponseHeaders(t *testing.T) {
+	cfg := httpOnlyCfg(t, false, false, nil)
+	cfg.Protocols.HTTP.ServerHeader = "tfo-collector"
+	cfg.Protocols.HTTP.ResponseHeaders.Set("X-Content-Type-Options", "nosniff")
+	require.NoError(t, cfg.Validate())
+
+	core, logs := observer.New(zapcore.ErrorLevel)
+	set := receivertest.NewNopSettings(component.MustNewType("tfootlp"))
+	set.Logger = zap.New(core)
+	r := startTraces(t, set, cfg, consumertest.NewNop())
+	t.Cleanup(func() { _ = r.Shutdown(context.Background()) })
+
+	client := &http.Client{Timeout: 5 * time.Second}
+	req, err := http.NewRequest(http.MethodPost, "http://"+cfg.Protocols.HTTP.NetAddr.Endpoint+"/v1/traces", bytes.NewReader([]byte("{not json")))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Request-ID", "req-4229")
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, "tfo-collector", resp.Header.Get("Server"))
+	assert.Equal(t, "nosniff", resp.Header.Get("X-Content-Type-Options"))
+	assert.Equal(t, "req-4229", resp.Header.Get("X-Request-ID"))
+
+	entries := logs.FilterMessage("Failed to unmarshal traces").All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "req-4229", entries[0].ContextMap()["request_id"])
+}
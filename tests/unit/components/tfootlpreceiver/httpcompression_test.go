@@ -0,0 +1,174 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/klauspost/compress/zstd"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfootlpreceiver"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/bytesize"
+)
+
+// encode compresses data with the HTTP Content-Encoding.
+func encode(t *testing.T, encoding string, data []byte) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	switch encoding {
+	case "gzip":
+		w := gzip.NewWriter(&buf)
+		_, err := w.Write(data)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+	case "deflate":
+		w := zlib.NewWriter(&buf)
+		_, err := w.Write(data)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+	case "zstd":
+		w, err := zstd.NewWriter(&buf)
+		require.NoError(t, err)
+		_, err = w.Write(data)
+		require.NoError(t, err)
+		require.NoError(t, w.Close())
+	default:
+		t.Fatalf("unknown encoding %q", encoding)
+	}
+	return buf.Bytes()
+}
+
+// startHTTPCompressionReceiver starts a logs receiver with the HTTP
+// compression settings and returns its logs URL.
+func startHTTPCompressionReceiver(t *testing.T, compression tfootlpreceiver.HTTPCompressionConfig) (string, *consumertest.LogsSink) {
+	t.Helper()
+	cfg := httpOnlyCfg(t, false, false, nil)
+	cfg.Protocols.HTTP.Compression = compression
+	require.NoError(t, cfg.Validate())
+	sink := new(consumertest.LogsSink)
+	startLogsReceiver(t, cfg, sink)
+	return fmt.Sprintf("http://%s/v1/logs", cfg.Protocols.HTTP.NetAddr.Endpoint), sink
+}
+
+func TestHTTPCompression_DecodesEncodings(t *testing.T) {
+	url, sink := startHTTPCompressionReceiver(t, tfootlpreceiver.HTTPCompressionConfig{})
+	data, err := largeLogs(1024).MarshalProto()
+	require.NoError(t, err)
+
+	for i, encoding := range []string{"gzip", "zstd", "deflate"} {
+		t.Run(encoding, func(t *testing.T) {
+			resp, _ := doPost(t, url, map[string]string{"Content-Encoding": encoding}, encode(t, encoding, data))
+			defer func() { _ = resp.Body.Close() }()
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, i+1, sink.LogRecordCount())
+		})
+	}
+
+	t.Run("identity", func(t *testing.T) {
+		resp, _ := doPost(t, url, map[string]string{"Content-Encoding": "identity"}, data)
+		defer func() { _ = resp.Body.Close() }()
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	})
+}
+
+func TestHTTPCompression_DecodesJSON(t *testing.T) {
+	url, sink := startHTTPCompressionReceiver(t, tfootlpreceiver.HTTPCompressionConfig{})
+	data, err := largeLogs(16).MarshalJSON()
+	require.NoError(t, err)
+
+	resp, _ := doPostWithCT(t, url, map[string]string{"Content-Encoding": "gzip"}, encode(t, "gzip", data), "application/json")
+	defer func() { _ = resp.Body.Close() }()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 1, sink.LogRecordCount())
+}
+
+func TestHTTPCompression_RejectsEncodingsNotAccepted(t *testing.T) {
+	url, sink := startHTTPCompressionReceiver(t, tfootlpreceiver.HTTPCompressionConfig{Accepted: []string{"gzip"}})
+	data, err := largeLogs(16).MarshalProto()
+	require.NoError(t, err)
+
+	resp, _ := doPost(t, url, map[string]string{"Content-Encoding": "zstd"}, encode(t, "zstd", data))
+	defer func() { _ = resp.Body.Close() }()
+	assert.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+
+	resp, _ = doPost(t, url, map[string]string{"Content-Encoding": "br"}, data)
+	defer func() { _ = resp.Body.Close() }()
+	assert.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+	assert.Zero(t, sink.LogRecordCount())
+}
+
+func TestHTTPCompression_MaxDecompressedSize(t *testing.T) {
+	url, sink := startHTTPCompressionReceiver(t, tfootlpreceiver.HTTPCompressionConfig{MaxDecompressedSize: bytesize.MiB})
+
+	small, err := largeLogs(512 << 10).MarshalProto()
+	require.NoError(t, err)
+	resp, _ := doPost(t, url, map[string]string{"Content-Encoding": "gzip"}, encode(t, "gzip", small))
+	defer func() { _ = resp.Body.Close() }()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// A few KiB on the wire inflating past the bound.
+	bomb, err := largeLogs(4 << 20).MarshalProto()
+	require.NoError(t, err)
+	for _, encoding := range []string{"gzip", "zstd", "deflate"} {
+		body := encode(t, encoding, bomb)
+		require.Less(t, len(body), 1<<20)
+		resp, _ := doPost(t, url, map[string]string{"Content-Encoding": encoding}, body)
+		defer func() { _ = resp.Body.Close() }()
+		assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode, encoding)
+	}
+	assert.Equal(t, 1, sink.LogRecordCount())
+}
+
+func TestHTTPCompression_CorruptBody(t *testing.T) {
+	url, sink := startHTTPCompressionReceiver(t, tfootlpreceiver.HTTPCompressionConfig{})
+	for _, encoding := range []string{"gzip", "zstd", "deflate"} {
+		resp, body := doPost(t, url, map[string]string{"Content-Encoding": encoding}, []byte("not compressed"))
+		defer func() { _ = resp.Body.Close() }()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, encoding)
+		assert.Contains(t, string(body), "Failed to decode body")
+	}
+	assert.Zero(t, sink.LogRecordCount())
+}
+
+func TestHTTPCompressionConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     tfootlpreceiver.HTTPCompressionConfig
+		wantErr string
+	}{
+		{name: "default", cfg: tfootlpreceiver.HTTPCompressionConfig{}},
+		{name: "accepted", cfg: tfootlpreceiver.HTTPCompressionConfig{Accepted: []string{"gzip", "zstd", "deflate"}, MaxDecompressedSize: 16 * bytesize.MiB}},
+		{name: "unknown encoding", cfg: tfootlpreceiver.HTTPCompressionConfig{Accepted: []string{"br"}}, wantErr: `unknown encoding "br"`},
+		{name: "negative size", cfg: tfootlpreceiver.HTTPCompressionConfig{MaxDecompressedSize: -1}, wantErr: "must not be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,157 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver_test
+
+import (
+	"bytes"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfootlpreceiver"
+)
+
+func keyRateLimitCfg(t *testing.T, maxKeys int, keys map[string]tfootlpreceiver.KeyLimit) *tfootlpreceiver.Config {
+	t.Helper()
+	cfg := httpOnlyCfg(t, true, false, nil)
+	cfg.RateLimit.PerKey = tfootlpreceiver.KeyRateLimitConfig{
+		Enabled:          true,
+		RecordsPerSecond: 0.001,
+		Burst:            3,
+		Keys:             keys,
+		MaxKeys:          maxKeys,
+	}
+	require.NoError(t, cfg.Validate())
+	return cfg
+}
+
+// logLines returns an export request body of n log records.
+func logLines(t *testing.T, n int) []byte {
+	t.Helper()
+	ld := plog.NewLogs()
+	records := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for range n {
+		records.AppendEmpty().Body().SetStr("line")
+	}
+	body, err := plogotlp.NewExportRequestFromLogs(ld).MarshalProto()
+	require.NoError(t, err)
+	return body
+}
+
+// postLogsWithKey posts n log records to path with the API key ID keyID.
+func postLogsWithKey(t *testing.T, cfg *tfootlpreceiver.Config, path, keyID string, n int) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, "http://"+cfg.Protocols.HTTP.NetAddr.Endpoint+path, bytes.NewReader(logLines(t, n)))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("X-TelemetryFlow-Key-ID", keyID)
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+	return resp
+}
+
+func TestConfig_Validate_KeyRateLimit(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*tfootlpreceiver.KeyRateLimitConfig)
+		wantErr string
+	}{
+		{"valid", func(*tfootlpreceiver.KeyRateLimitConfig) {}, ""},
+		{"disabled ignores settings", func(c *tfootlpreceiver.KeyRateLimitConfig) {
+			c.Enabled = false
+			c.RecordsPerSecond = 0
+		}, ""},
+		{"rate", func(c *tfootlpreceiver.KeyRateLimitConfig) { c.RecordsPerSecond = 0 }, "rate_limit.per_key.records_per_second must be positive"},
+		{"burst", func(c *tfootlpreceiver.KeyRateLimitConfig) { c.Burst = -1 }, "rate_limit.per_key.burst must not be negative"},
+		{"max keys", func(c *tfootlpreceiver.KeyRateLimitConfig) { c.MaxKeys = 0 }, "rate_limit.per_key.max_keys must be positive"},
+		{"key rate", func(c *tfootlpreceiver.KeyRateLimitConfig) {
+			c.Keys = map[string]tfootlpreceiver.KeyLimit{"tfk_a": {}}
+		}, "rate_limit.per_key.keys[tfk_a].records_per_second must be positive"},
+		{"key burst", func(c *tfootlpreceiver.KeyRateLimitConfig) {
+			c.Keys = map[string]tfootlpreceiver.KeyLimit{"tfk_a": {RecordsPerSecond: 1, Burst: -1}}
+		}, "rate_limit.per_key.keys[tfk_a].burst must not be negative"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tfootlpreceiver.NewFactory().CreateDefaultConfig().(*tfootlpreceiver.Config)
+			assert.Equal(t, 10000, cfg.RateLimit.PerKey.MaxKeys)
+			cfg.RateLimit.PerKey.Enabled = true
+			cfg.RateLimit.PerKey.RecordsPerSecond = 100
+			tt.mutate(&cfg.RateLimit.PerKey)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestReceiver_KeyRateLimit_IsolatesKeys(t *testing.T) {
+	cfg := keyRateLimitCfg(t, 100, nil)
+	sink := new(consumertest.LogsSink)
+	startLogsReceiver(t, cfg, sink)
+
+	assert.Equal(t, http.StatusOK, postLogsWithKey(t, cfg, "/v2/logs", "tfk_noisy", 2).StatusCode)
+
+	resp := postLogsWithKey(t, cfg, "/v2/logs", "tfk_noisy", 2)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	// One record is missing, refilled at 0.001 records per second.
+	assert.Equal(t, "1000", resp.Header.Get("Retry-After"))
+
+	assert.Equal(t, http.StatusOK, postLogsWithKey(t, cfg, "/v2/logs", "tfk_quiet", 3).StatusCode,
+		"other keys keep their own bucket")
+	assert.Equal(t, http.StatusOK, postLogsWithKey(t, cfg, "/v1/logs", "tfk_noisy", 3).StatusCode,
+		"v1 endpoints are not limited per key")
+	assert.Equal(t, 8, sink.LogRecordCount())
+}
+
+func TestReceiver_KeyRateLimit_LargerThanBurst(t *testing.T) {
+	cfg := keyRateLimitCfg(t, 100, map[string]tfootlpreceiver.KeyLimit{
+		"tfk_bulk": {RecordsPerSecond: 0.001, Burst: 10},
+	})
+	sink := new(consumertest.LogsSink)
+	startLogsReceiver(t, cfg, sink)
+
+	resp := postLogsWithKey(t, cfg, "/v2/logs", "tfk_small", 5)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "1", resp.Header.Get("Retry-After"))
+
+	assert.Equal(t, http.StatusOK, postLogsWithKey(t, cfg, "/v2/logs", "tfk_bulk", 5).StatusCode,
+		"the key override raises the burst")
+	assert.Equal(t, 5, sink.LogRecordCount())
+}
+
+func TestReceiver_KeyRateLimit_EvictsLeastRecentKey(t *testing.T) {
+	cfg := keyRateLimitCfg(t, 1, nil)
+	sink := new(consumertest.LogsSink)
+	startLogsReceiver(t, cfg, sink)
+
+	assert.Equal(t, http.StatusOK, postLogsWithKey(t, cfg, "/v2/logs", "tfk_a", 3).StatusCode)
+	assert.Equal(t, http.StatusTooManyRequests, postLogsWithKey(t, cfg, "/v2/logs", "tfk_a", 1).StatusCode)
+
+	// Seeing another key drops the bucket of tfk_a, which starts full again.
+	assert.Equal(t, http.StatusOK, postLogsWithKey(t, cfg, "/v2/logs", "tfk_b", 1).StatusCode)
+	assert.Equal(t, http.StatusOK, postLogsWithKey(t, cfg, "/v2/logs", "tfk_a", 3).StatusCode)
+}
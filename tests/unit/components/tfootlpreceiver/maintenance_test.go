@@ -0,0 +1,135 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver_test
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfootlpreceiver"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+var maintenanceID = component.MustNewID("tfomaintenance")
+
+// maintenance is a tfomaintenance extension stand-in.
+type maintenance struct {
+	component.StartFunc
+	component.ShutdownFunc
+	rejecting atomic.Bool
+}
+
+func (m *maintenance) Rejecting() bool { return m.rejecting.Load() }
+
+func newMaintenanceHost(m *maintenance) component.Host {
+	return identityHost{
+		Host: componenttest.NewNopHost(),
+		exts: map[component.ID]component.Component{maintenanceID: m},
+	}
+}
+
+func TestMaintenance_StartRequiresExtension(t *testing.T) {
+	tests := []struct {
+		name    string
+		host    component.Host
+		wantErr string
+	}{
+		{"missing", componenttest.NewNopHost(), `maintenance: tfomaintenance extension "tfomaintenance" not found`},
+		{"wrong type", identityHost{
+			Host: componenttest.NewNopHost(),
+			exts: map[component.ID]component.Component{maintenanceID: identity{id: "edge-1"}},
+		}, `maintenance: extension "tfomaintenance" does not provide maintenance mode`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := httpOnlyCfg(t, false, false, nil)
+			cfg.Maintenance = maintenanceID
+			r, err := tfootlpreceiver.NewFactory().CreateTraces(context.Background(),
+				receivertest.NewNopSettings(component.MustNewType("tfootlp")), cfg, new(consumertest.TracesSink))
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = r.Shutdown(context.Background()) })
+
+			assert.ErrorContains(t, r.Start(context.Background(), tt.host), tt.wantErr)
+		})
+	}
+}
+
+func TestMaintenance_RejectsDuringMaintenance(t *testing.T) {
+	cfg := grpcHTTPCfg(t)
+	cfg.Maintenance = maintenanceID
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { _ = tel.Shutdown(context.Background()) })
+	set := receivertest.NewNopSettings(component.MustNewType("tfootlp"))
+	set.TelemetrySettings = tel.NewTelemetrySettings()
+
+	ext := &maintenance{}
+	sink := new(consumertest.LogsSink)
+	r, err := tfootlpreceiver.NewFactory().CreateLogs(context.Background(), set, cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), newMaintenanceHost(ext)))
+	t.Cleanup(func() { _ = r.Shutdown(context.Background()) })
+	time.Sleep(80 * time.Millisecond)
+
+	ld := plog.NewLogs()
+	records := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	records.AppendEmpty().Body().SetStr("one")
+	records.AppendEmpty().Body().SetStr("two")
+	req := plogotlp.NewExportRequestFromLogs(ld)
+	body, err := req.MarshalProto()
+	require.NoError(t, err)
+
+	conn, err := grpc.NewClient(cfg.Protocols.GRPC.NetAddr.Endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	client := plogotlp.NewGRPCClient(conn)
+
+	require.Equal(t, http.StatusOK, postTo(t, cfg, "/v1/logs", body).StatusCode)
+
+	ext.rejecting.Store(true)
+	resp := postTo(t, cfg, "/v1/logs", body)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, "30", resp.Header.Get("Retry-After"))
+	_, err = client.Export(context.Background(), req)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 2, sink.LogRecordCount(), "rejected requests are not consumed")
+
+	ext.rejecting.Store(false)
+	_, err = client.Export(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, 4, sink.LogRecordCount())
+
+	m, err := tel.GetMetric(selfmetrics.ReceiverMaintenanceRejected)
+	require.NoError(t, err)
+	points := m.Data.(metricdata.Sum[int64]).DataPoints
+	require.Len(t, points, 1)
+	assert.Equal(t, int64(4), points[0].Value)
+}
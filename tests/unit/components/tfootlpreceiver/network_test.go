@@ -0,0 +1,109 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver_test
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfootlpreceiver"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+)
+
+func TestConfig_Validate_ListenerNetwork(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*tfootlpreceiver.Config)
+		wantErr string
+	}{
+		{"bracketed IPv6 wildcard", func(cfg *tfootlpreceiver.Config) {
+			cfg.Protocols.HTTP.NetAddr.Endpoint = "[::]:4318"
+			cfg.Protocols.HTTP.Network = serverconf.NetworkDual
+		}, ""},
+		{"unbracketed IPv6", func(cfg *tfootlpreceiver.Config) {
+			cfg.Protocols.HTTP.NetAddr.Endpoint = ":::4318"
+		}, "protocols.http: invalid endpoint \":::4318\": IPv6 addresses must be enclosed in brackets"},
+		{"IPv6 only on the default IPv4 endpoint", func(cfg *tfootlpreceiver.Config) {
+			cfg.Protocols.GRPC.NetAddr.Endpoint = ""
+			cfg.Protocols.GRPC.Network = serverconf.NetworkTCP6
+		}, "protocols.grpc: endpoint \"0.0.0.0:4317\" is an IPv4 address"},
+		{"unknown network", func(cfg *tfootlpreceiver.Config) {
+			cfg.Protocols.GRPC.Network = "tcp5"
+		}, "protocols.grpc: invalid network \"tcp5\""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := grpcHTTPCfg(t)
+			tt.mutate(cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestReceiver_HTTP_DualStack(t *testing.T) {
+	if l, err := net.Listen("tcp6", "[::1]:0"); err != nil {
+		t.Skipf("IPv6 is not available: %v", err)
+	} else {
+		_ = l.Close()
+	}
+
+	port := freePort(t)
+	cfg := httpOnlyCfg(t, false, false, nil)
+	cfg.Protocols.HTTP.NetAddr.Endpoint = fmt.Sprintf("[::]:%d", port)
+	cfg.Protocols.HTTP.Network = serverconf.NetworkDual
+	require.NoError(t, cfg.Validate())
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	set := receivertest.NewNopSettings(component.MustNewType("tfootlp"))
+	set.Logger = zap.New(core)
+	r, err := tfootlpreceiver.NewFactory().CreateTraces(context.Background(), set, cfg, new(consumertest.TracesSink))
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { _ = r.Shutdown(context.Background()) })
+
+	client := &http.Client{Timeout: 2 * time.Second}
+	for _, host := range []string{"127.0.0.1", "::1"} {
+		resp, err := client.Get(fmt.Sprintf("http://%s/v1/traces", net.JoinHostPort(host, fmt.Sprint(port))))
+		require.NoError(t, err, host)
+		_ = resp.Body.Close()
+		assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode, host)
+	}
+
+	require.Eventually(t, func() bool {
+		return logs.FilterMessage("TFO OTLP HTTP server listening").Len() == 1
+	}, 2*time.Second, 10*time.Millisecond)
+	fields := logs.FilterMessage("TFO OTLP HTTP server listening").All()[0].ContextMap()
+	assert.Equal(t, fmt.Sprintf("[::]:%d", port), fields["endpoint"])
+	assert.Equal(t, fmt.Sprintf("http://localhost:%d", port), fields["url"])
+}
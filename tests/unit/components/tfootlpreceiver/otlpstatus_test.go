@@ -0,0 +1,185 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	spb "google.golang.org/genproto/googleapis/rpc/status"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfootlpreceiver"
+)
+
+// startFailingTraces starts an HTTP and gRPC receiver whose traces
+// consumer fails with err.
+func startFailingTraces(t *testing.T, err error) *tfootlpreceiver.Config {
+	t.Helper()
+	cfg := grpcHTTPCfg(t)
+	r := startTraces(t, receivertest.NewNopSettings(component.MustNewType("tfootlp")), cfg, consumertest.NewErr(err))
+	t.Cleanup(func() { _ = r.Shutdown(context.Background()) })
+	time.Sleep(100 * time.Millisecond)
+	return cfg
+}
+
+// exportGRPCTraces sends one span over gRPC and returns the status.
+func exportGRPCTraces(t *testing.T, cfg *tfootlpreceiver.Config) *status.Status {
+	t.Helper()
+	cc, err := grpc.NewClient(cfg.Protocols.GRPC.NetAddr.Endpoint,
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+	_, err = ptraceotlp.NewGRPCClient(cc).Export(context.Background(), ptraceotlp.NewExportRequestFromTraces(traceIDs(1, 1)))
+	return status.Convert(err)
+}
+
+// grpcRetryDelay returns the RetryInfo delay of st.
+func grpcRetryDelay(t *testing.T, st *status.Status) time.Duration {
+	t.Helper()
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			return info.GetRetryDelay().AsDuration()
+		}
+	}
+	t.Fatalf("status %v has no RetryInfo", st)
+	return 0
+}
+
+// readStatus decodes the google.rpc.Status body of a failed HTTP export.
+func readStatus(t *testing.T, resp *http.Response) *spb.Status {
+	t.Helper()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	st := new(spb.Status)
+	if resp.Header.Get("Content-Type") == "application/json" {
+		require.NoError(t, protojson.Unmarshal(body, st))
+	} else {
+		require.Equal(t, "application/x-protobuf", resp.Header.Get("Content-Type"))
+		require.NoError(t, proto.Unmarshal(body, st))
+	}
+	return st
+}
+
+func TestReceiver_ExportResponse_EncodedLikeRequest(t *testing.T) {
+	cfg := httpOnlyCfg(t, false, false, nil)
+	startTracesReceiver(t, cfg, new(consumertest.TracesSink))
+
+	resp := postSpans(t, cfg, traceIDs(1, 1))
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/x-protobuf", resp.Header.Get("Content-Type"))
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, ptraceotlp.NewExportResponse().UnmarshalProto(body))
+
+	resp, err = postTraces(cfg)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	body, err = io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	require.NoError(t, ptraceotlp.NewExportResponse().UnmarshalJSON(body))
+}
+
+func TestReceiver_ConsumeError_Backpressure(t *testing.T) {
+	cfg := startFailingTraces(t, errors.New("sending queue is full"))
+
+	resp := postSpans(t, cfg, traceIDs(1, 1))
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Equal(t, "5", resp.Header.Get("Retry-After"))
+	st := readStatus(t, resp)
+	assert.Equal(t, int32(codes.Unavailable), st.GetCode())
+	assert.Equal(t, "Failed to process traces", st.GetMessage())
+
+	grpcStatus := exportGRPCTraces(t, cfg)
+	assert.Equal(t, codes.Unavailable, grpcStatus.Code())
+	assert.Equal(t, 5*time.Second, grpcRetryDelay(t, grpcStatus))
+}
+
+func TestReceiver_ConsumeError_Permanent(t *testing.T) {
+	cfg := startFailingTraces(t, consumererror.NewPermanent(errors.New("malformed span")))
+
+	resp := postSpans(t, cfg, traceIDs(1, 1))
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+	assert.Empty(t, resp.Header.Get("Retry-After"))
+	assert.Equal(t, int32(codes.Internal), readStatus(t, resp).GetCode())
+
+	grpcStatus := exportGRPCTraces(t, cfg)
+	assert.Equal(t, codes.Internal, grpcStatus.Code())
+	assert.Empty(t, grpcStatus.Details())
+}
+
+func TestReceiver_ConsumeError_StatusPassedThrough(t *testing.T) {
+	cfg := startFailingTraces(t, status.Error(codes.ResourceExhausted, "memory limit exceeded"))
+
+	resp := postSpans(t, cfg, traceIDs(1, 1))
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	st := readStatus(t, resp)
+	assert.Equal(t, int32(codes.ResourceExhausted), st.GetCode())
+	assert.Equal(t, "memory limit exceeded", st.GetMessage())
+
+	grpcStatus := exportGRPCTraces(t, cfg)
+	assert.Equal(t, codes.ResourceExhausted, grpcStatus.Code())
+	assert.Equal(t, "memory limit exceeded", grpcStatus.Message())
+}
+
+func TestReceiver_RateLimited_RetryInfo(t *testing.T) {
+	cfg := rateLimitCfg(t, grpcHTTPCfg(t), false)
+	startTracesReceiver(t, cfg, new(consumertest.TracesSink))
+
+	// The burst of three spans is spent by the first request.
+	require.Equal(t, http.StatusOK, postSpans(t, cfg, traceIDs(3, 1)).StatusCode)
+
+	resp := postSpans(t, cfg, traceIDs(1, 2))
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "1", resp.Header.Get("Retry-After"))
+	assert.Equal(t, int32(codes.ResourceExhausted), readStatus(t, resp).GetCode())
+
+	grpcStatus := exportGRPCTraces(t, cfg)
+	assert.Equal(t, codes.ResourceExhausted, grpcStatus.Code())
+	assert.Equal(t, time.Second, grpcRetryDelay(t, grpcStatus))
+}
+
+func TestReceiver_UnmarshalError_JSONStatus(t *testing.T) {
+	cfg := httpOnlyCfg(t, false, false, nil)
+	startTracesReceiver(t, cfg, new(consumertest.TracesSink))
+
+	resp, body := doPostWithCT(t, "http://"+cfg.Protocols.HTTP.NetAddr.Endpoint+"/v1/traces", nil, []byte("{not json"), "application/json")
+	defer func() { _ = resp.Body.Close() }()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	st := new(spb.Status)
+	require.NoError(t, protojson.Unmarshal(body, st))
+	assert.Equal(t, int32(codes.InvalidArgument), st.GetCode())
+	assert.Equal(t, "Failed to unmarshal traces", st.GetMessage())
+}
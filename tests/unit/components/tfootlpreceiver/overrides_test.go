@@ -0,0 +1,181 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver_test
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfootlpreceiver"
+)
+
+var overridesID = component.MustNewID("tfooverrides")
+
+// overrides is a tfooverrides extension stand-in.
+type overrides struct {
+	component.StartFunc
+	component.ShutdownFunc
+
+	mu               sync.Mutex
+	recordsPerSecond float64
+	burst            int
+	receiver         component.ID
+
+	// sampling is the overridden percentage per signal.
+	sampling map[pipeline.Signal]float64
+}
+
+func (o *overrides) ReceiverRateLimit(id component.ID) (float64, int, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.receiver = id
+	return o.recordsPerSecond, o.burst, o.recordsPerSecond > 0
+}
+
+func (o *overrides) ReceiverSampling(id component.ID, signal pipeline.Signal) (float64, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.receiver = id
+	p, ok := o.sampling[signal]
+	return p, ok
+}
+
+func (o *overrides) setSampling(signal pipeline.Signal, percentage float64) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.sampling == nil {
+		o.sampling = make(map[pipeline.Signal]float64)
+	}
+	o.sampling[signal] = percentage
+}
+
+func (o *overrides) set(recordsPerSecond float64, burst int) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.recordsPerSecond, o.burst = recordsPerSecond, burst
+}
+
+func TestOverrides_StartRequiresExtension(t *testing.T) {
+	tests := []struct {
+		name    string
+		host    component.Host
+		wantErr string
+	}{
+		{"missing", componenttest.NewNopHost(), `overrides: tfooverrides extension "tfooverrides" not found`},
+		{"wrong type", identityHost{
+			Host: componenttest.NewNopHost(),
+			exts: map[component.ID]component.Component{overridesID: identity{id: "edge-1"}},
+		}, `overrides: extension "tfooverrides" does not provide runtime overrides`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := httpOnlyCfg(t, false, false, nil)
+			cfg.Overrides = overridesID
+			r, err := tfootlpreceiver.NewFactory().CreateTraces(context.Background(),
+				receivertest.NewNopSettings(component.MustNewType("tfootlp")), cfg, new(consumertest.TracesSink))
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = r.Shutdown(context.Background()) })
+
+			assert.ErrorContains(t, r.Start(context.Background(), tt.host), tt.wantErr)
+		})
+	}
+}
+
+func TestOverrides_RateLimitChangesAtRuntime(t *testing.T) {
+	// The configured rate limit is disabled; only the override applies.
+	cfg := httpOnlyCfg(t, false, false, nil)
+	cfg.Overrides = overridesID
+	set := receivertest.NewNopSettings(component.MustNewType("tfootlp"))
+	set.ID = component.MustNewIDWithName("tfootlp", "edge")
+
+	ext := &overrides{}
+	sink := new(consumertest.LogsSink)
+	r, err := tfootlpreceiver.NewFactory().CreateLogs(context.Background(), set, cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), identityHost{
+		Host: componenttest.NewNopHost(),
+		exts: map[component.ID]component.Component{overridesID: ext},
+	}))
+	t.Cleanup(func() { _ = r.Shutdown(context.Background()) })
+	time.Sleep(80 * time.Millisecond)
+
+	ld := plog.NewLogs()
+	records := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	records.AppendEmpty().Body().SetStr("one")
+	records.AppendEmpty().Body().SetStr("two")
+	body, err := plogotlp.NewExportRequestFromLogs(ld).MarshalProto()
+	require.NoError(t, err)
+
+	for range 3 {
+		require.Equal(t, http.StatusOK, postTo(t, cfg, "/v1/logs", body).StatusCode)
+	}
+
+	ext.set(0.001, 3)
+	assert.Equal(t, http.StatusOK, postTo(t, cfg, "/v1/logs", body).StatusCode)
+	assert.Equal(t, http.StatusTooManyRequests, postTo(t, cfg, "/v1/logs", body).StatusCode)
+	assert.Equal(t, set.ID, ext.receiver)
+
+	// Removing the override restores the configured (disabled) limit.
+	ext.set(0, 0)
+	assert.Equal(t, http.StatusOK, postTo(t, cfg, "/v1/logs", body).StatusCode)
+	assert.Equal(t, 10, sink.LogRecordCount())
+}
+
+func TestOverrides_SamplingChangesAtRuntime(t *testing.T) {
+	// Sampling is not configured; only the override applies.
+	cfg := httpOnlyCfg(t, false, false, nil)
+	cfg.Overrides = overridesID
+	set := receivertest.NewNopSettings(component.MustNewType("tfootlp"))
+	set.ID = component.MustNewIDWithName("tfootlp", "edge")
+
+	ext := &overrides{}
+	sink := new(consumertest.LogsSink)
+	r, err := tfootlpreceiver.NewFactory().CreateLogs(context.Background(), set, cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), identityHost{
+		Host: componenttest.NewNopHost(),
+		exts: map[component.ID]component.Component{overridesID: ext},
+	}))
+	t.Cleanup(func() { _ = r.Shutdown(context.Background()) })
+	time.Sleep(80 * time.Millisecond)
+
+	// Records without a trace ID follow the logs percentage.
+	body := logsOf(t, make([]pcommon.TraceID, 4))
+	require.Equal(t, http.StatusOK, postTo(t, cfg, "/v1/logs", body).StatusCode)
+	assert.Equal(t, 4, sink.LogRecordCount())
+
+	ext.setSampling(pipeline.SignalLogs, 0)
+	assert.Equal(t, http.StatusOK, postTo(t, cfg, "/v1/logs", body).StatusCode)
+	assert.Equal(t, 4, sink.LogRecordCount(), "every record is sampled out")
+	assert.Equal(t, set.ID, ext.receiver)
+
+	ext.setSampling(pipeline.SignalLogs, 100)
+	assert.Equal(t, http.StatusOK, postTo(t, cfg, "/v1/logs", body).StatusCode)
+	assert.Equal(t, 8, sink.LogRecordCount())
+}
@@ -0,0 +1,193 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfootlpreceiver"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/provenance"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+var identityID = component.MustNewID("tfoidentity")
+
+// identity is a tfoidentity extension stand-in.
+type identity struct {
+	component.StartFunc
+	component.ShutdownFunc
+	id string
+}
+
+func (i identity) GetCollectorID() string { return i.id }
+
+// identityHost is a host offering the identity extension.
+type identityHost struct {
+	component.Host
+	exts map[component.ID]component.Component
+}
+
+func (h identityHost) GetExtensions() map[component.ID]component.Component { return h.exts }
+
+func newIdentityHost(id string) component.Host {
+	return identityHost{
+		Host: componenttest.NewNopHost(),
+		exts: map[component.ID]component.Component{identityID: identity{id: id}},
+	}
+}
+
+func provenanceCfg(t *testing.T) *tfootlpreceiver.Config {
+	t.Helper()
+	cfg := grpcHTTPCfg(t)
+	cfg.Provenance = tfootlpreceiver.ProvenanceConfig{
+		Enabled:           true,
+		CollectorIdentity: identityID,
+		TenantHeader:      "X-TelemetryFlow-Tenant",
+		MaxHops:           3,
+	}
+	return cfg
+}
+
+func TestProvenanceConfig_Validate(t *testing.T) {
+	cfg := tfootlpreceiver.NewFactory().CreateDefaultConfig().(*tfootlpreceiver.Config)
+	assert.False(t, cfg.Provenance.Enabled)
+	assert.Equal(t, "X-TelemetryFlow-Tenant", cfg.Provenance.TenantHeader)
+	assert.Equal(t, 8, cfg.Provenance.MaxHops)
+
+	cfg.Provenance.Enabled = true
+	assert.ErrorContains(t, cfg.Validate(), "provenance.collector_identity must be set")
+
+	cfg.Provenance.CollectorIdentity = identityID
+	cfg.Provenance.MaxHops = 0
+	assert.ErrorContains(t, cfg.Validate(), "provenance.max_hops must be positive")
+
+	cfg.Provenance.MaxHops = 2
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestProvenance_StartRequiresIdentity(t *testing.T) {
+	r, err := tfootlpreceiver.NewFactory().CreateTraces(context.Background(),
+		receivertest.NewNopSettings(component.MustNewType("tfootlp")), provenanceCfg(t), new(consumertest.TracesSink))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = r.Shutdown(context.Background()) })
+
+	err = r.Start(context.Background(), componenttest.NewNopHost())
+	assert.ErrorContains(t, err, `provenance: tfoidentity extension "tfoidentity" not found`)
+}
+
+func TestProvenance_StampsAndRejects(t *testing.T) {
+	cfg := provenanceCfg(t)
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { _ = tel.Shutdown(context.Background()) })
+	set := receivertest.NewNopSettings(component.MustNewType("tfootlp"))
+	set.TelemetrySettings = tel.NewTelemetrySettings()
+
+	sink := new(consumertest.TracesSink)
+	r, err := tfootlpreceiver.NewFactory().CreateTraces(context.Background(), set, cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), newIdentityHost("region-1")))
+	t.Cleanup(func() { _ = r.Shutdown(context.Background()) })
+
+	// One new resource from a tenant, one forwarded by an edge collector
+	// and one that already passed through region-1.
+	td := spans(1)
+	forwarded := td.ResourceSpans().AppendEmpty()
+	require.NoError(t, provenance.Stamp(forwarded.Resource().Attributes(),
+		provenance.Hop{CollectorID: "edge-1", Tenant: "acme", ReceivedAt: time.Now()}))
+	forwarded.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	looped := td.ResourceSpans().AppendEmpty()
+	require.NoError(t, provenance.Stamp(looped.Resource().Attributes(),
+		provenance.Hop{CollectorID: "region-1", ReceivedAt: time.Now()}))
+	ss := looped.ScopeSpans().AppendEmpty()
+	ss.Spans().AppendEmpty()
+	ss.Spans().AppendEmpty()
+
+	body, err := ptraceotlp.NewExportRequestFromTraces(td).MarshalProto()
+	require.NoError(t, err)
+	req, err := http.NewRequest(http.MethodPost, "http://"+cfg.Protocols.HTTP.NetAddr.Endpoint+"/v1/traces", bytes.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("X-TelemetryFlow-Tenant", "globex")
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode, "loops are dropped, not retried")
+
+	require.Len(t, sink.AllTraces(), 1)
+	got := sink.AllTraces()[0].ResourceSpans()
+	require.Equal(t, 2, got.Len())
+
+	fresh := got.At(0).Resource().Attributes().AsRaw()
+	assert.Equal(t, "region-1", fresh[provenance.AttrOriginCollectorID])
+	assert.Equal(t, "globex", fresh[provenance.AttrOriginTenant])
+	assert.Equal(t, []any{"region-1"}, fresh[provenance.AttrPath])
+
+	relayed := got.At(1).Resource().Attributes().AsRaw()
+	assert.Equal(t, "edge-1", relayed[provenance.AttrOriginCollectorID])
+	assert.Equal(t, "acme", relayed[provenance.AttrOriginTenant])
+	assert.Equal(t, int64(2), relayed[provenance.AttrHopCount])
+	assert.Equal(t, []any{"edge-1", "region-1"}, relayed[provenance.AttrPath])
+
+	m, err := tel.GetMetric(selfmetrics.ReceiverProvenanceRejected)
+	require.NoError(t, err)
+	points := m.Data.(metricdata.Sum[int64]).DataPoints
+	require.Len(t, points, 1)
+	reason, _ := points[0].Attributes.Value(attribute.Key("reason"))
+	assert.Equal(t, "loop", reason.AsString())
+	assert.Equal(t, int64(2), points[0].Value)
+}
+
+func TestProvenance_GRPCTenantMetadata(t *testing.T) {
+	cfg := provenanceCfg(t)
+	sink := new(consumertest.TracesSink)
+	r, err := tfootlpreceiver.NewFactory().CreateTraces(context.Background(),
+		receivertest.NewNopSettings(component.MustNewType("tfootlp")), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), newIdentityHost("edge-1")))
+	t.Cleanup(func() { _ = r.Shutdown(context.Background()) })
+
+	cc, err := grpc.NewClient(cfg.Protocols.GRPC.NetAddr.Endpoint,
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-telemetryflow-tenant", "acme")
+	_, err = ptraceotlp.NewGRPCClient(cc).Export(ctx, ptraceotlp.NewExportRequestFromTraces(spans(1)))
+	require.NoError(t, err)
+
+	require.Len(t, sink.AllTraces(), 1)
+	attrs := sink.AllTraces()[0].ResourceSpans().At(0).Resource().Attributes().AsRaw()
+	assert.Equal(t, "edge-1", attrs[provenance.AttrOriginCollectorID])
+	assert.Equal(t, "acme", attrs[provenance.AttrOriginTenant])
+	assert.Equal(t, int64(1), attrs[provenance.AttrHopCount])
+}
@@ -0,0 +1,270 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfootlpreceiver"
+)
+
+func rateLimitCfg(t *testing.T, cfg *tfootlpreceiver.Config, perTrace bool) *tfootlpreceiver.Config {
+	t.Helper()
+	cfg.RateLimit = tfootlpreceiver.RateLimitConfig{
+		Enabled:          true,
+		RecordsPerSecond: 0.001,
+		Burst:            3,
+		PerTrace:         perTrace,
+		TraceWindow:      time.Minute,
+		MaxTraces:        1024,
+	}
+	require.NoError(t, cfg.Validate())
+	return cfg
+}
+
+// traceIDs builds traces with n spans for each of the trace IDs.
+func traceIDs(n int, ids ...byte) ptrace.Traces {
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for _, id := range ids {
+		for range n {
+			span := spans.AppendEmpty()
+			span.SetTraceID(pcommon.TraceID{id, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15})
+			span.SetName("op")
+		}
+	}
+	return td
+}
+
+func postTo(t *testing.T, cfg *tfootlpreceiver.Config, path string, body []byte) *http.Response {
+	t.Helper()
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Post("http://"+cfg.Protocols.HTTP.NetAddr.Endpoint+path, "application/x-protobuf", bytes.NewReader(body))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+	return resp
+}
+
+func postSpans(t *testing.T, cfg *tfootlpreceiver.Config, td ptrace.Traces) *http.Response {
+	t.Helper()
+	body, err := ptraceotlp.NewExportRequestFromTraces(td).MarshalProto()
+	require.NoError(t, err)
+	return postTo(t, cfg, "/v1/traces", body)
+}
+
+func TestConfig_Validate_RateLimit(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*tfootlpreceiver.RateLimitConfig)
+		wantErr string
+	}{
+		{"disabled ignores settings", func(c *tfootlpreceiver.RateLimitConfig) {
+			c.Enabled = false
+			c.RecordsPerSecond = 0
+		}, ""},
+		{"rate", func(c *tfootlpreceiver.RateLimitConfig) { c.RecordsPerSecond = 0 }, "rate_limit.records_per_second must be positive"},
+		{"burst", func(c *tfootlpreceiver.RateLimitConfig) { c.Burst = -1 }, "rate_limit.burst must not be negative"},
+		{"trace window", func(c *tfootlpreceiver.RateLimitConfig) { c.TraceWindow = 0 }, "rate_limit.trace_window must be positive"},
+		{"max traces", func(c *tfootlpreceiver.RateLimitConfig) { c.MaxTraces = 0 }, "rate_limit.max_traces must be positive"},
+		{"per request ignores trace settings", func(c *tfootlpreceiver.RateLimitConfig) {
+			c.PerTrace = false
+			c.TraceWindow = 0
+		}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tfootlpreceiver.NewFactory().CreateDefaultConfig().(*tfootlpreceiver.Config)
+			assert.Equal(t, 30*time.Second, cfg.RateLimit.TraceWindow)
+			assert.Equal(t, 65536, cfg.RateLimit.MaxTraces)
+			cfg.RateLimit.Enabled = true
+			cfg.RateLimit.RecordsPerSecond = 100
+			cfg.RateLimit.PerTrace = true
+			tt.mutate(&cfg.RateLimit)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestReceiver_RateLimit_PerRequest(t *testing.T) {
+	cfg := rateLimitCfg(t, httpOnlyCfg(t, false, false, nil), false)
+	sink := new(consumertest.LogsSink)
+	startLogsReceiver(t, cfg, sink)
+
+	logs := func(n int) []byte {
+		ld := plog.NewLogs()
+		records := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+		for range n {
+			records.AppendEmpty().Body().SetStr("line")
+		}
+		body, err := plogotlp.NewExportRequestFromLogs(ld).MarshalProto()
+		require.NoError(t, err)
+		return body
+	}
+
+	assert.Equal(t, http.StatusOK, postTo(t, cfg, "/v1/logs", logs(2)).StatusCode)
+
+	resp := postTo(t, cfg, "/v1/logs", logs(2))
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "1", resp.Header.Get("Retry-After"))
+	assert.Equal(t, 2, sink.LogRecordCount(), "the rejected request is not consumed")
+}
+
+func TestReceiver_RateLimit_PerTraceKeepsTracesWhole(t *testing.T) {
+	cfg := rateLimitCfg(t, httpOnlyCfg(t, false, false, nil), true)
+	sink := new(consumertest.TracesSink)
+	startTracesReceiver(t, cfg, sink)
+
+	// Trace 1 fits the burst and is admitted.
+	require.Equal(t, http.StatusOK, postSpans(t, cfg, traceIDs(2, 1)).StatusCode)
+
+	// Trace 1 continues past the remaining tokens and is still admitted;
+	// the new trace 2 is rejected, so the request succeeds partially.
+	resp := postSpans(t, cfg, traceIDs(2, 1, 2))
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	partial := ptraceotlp.NewExportResponse()
+	require.NoError(t, partial.UnmarshalProto(body))
+	assert.Equal(t, int64(2), partial.PartialSuccess().RejectedSpans())
+	assert.Equal(t, "rate limit exceeded", partial.PartialSuccess().ErrorMessage())
+
+	// Later spans of trace 2 stay rejected; a request with nothing left is
+	// rejected as a whole.
+	assert.Equal(t, http.StatusTooManyRequests, postSpans(t, cfg, traceIDs(1, 2)).StatusCode)
+
+	assert.Equal(t, 4, sink.SpanCount())
+	for _, td := range sink.AllTraces() {
+		spans := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+		for i := range spans.Len() {
+			assert.Equal(t, byte(1), spans.At(i).TraceID()[0], "only spans of the admitted trace are consumed")
+		}
+	}
+}
+
+func TestReceiver_RateLimit_RejectedTraceStaysRejected(t *testing.T) {
+	cfg := httpOnlyCfg(t, false, false, nil)
+	cfg.RateLimit = tfootlpreceiver.RateLimitConfig{
+		Enabled:          true,
+		RecordsPerSecond: 1000,
+		Burst:            2,
+		PerTrace:         true,
+		TraceWindow:      time.Minute,
+		MaxTraces:        1024,
+	}
+	require.NoError(t, cfg.Validate())
+	sink := new(consumertest.TracesSink)
+	startTracesReceiver(t, cfg, sink)
+
+	// The first request of trace 1 exceeds the burst.
+	require.Equal(t, http.StatusTooManyRequests, postSpans(t, cfg, traceIDs(3, 1)).StatusCode)
+	time.Sleep(10 * time.Millisecond)
+
+	// The bucket has refilled, but the rest of trace 1 is not admitted
+	// while other traces are.
+	assert.Equal(t, http.StatusTooManyRequests, postSpans(t, cfg, traceIDs(1, 1)).StatusCode)
+	assert.Equal(t, http.StatusOK, postSpans(t, cfg, traceIDs(1, 3)).StatusCode)
+	assert.Equal(t, 1, sink.SpanCount())
+}
+
+func TestReceiver_RateLimit_RetriesDoNotExtendRejection(t *testing.T) {
+	cfg := httpOnlyCfg(t, false, false, nil)
+	cfg.RateLimit = tfootlpreceiver.RateLimitConfig{
+		Enabled:          true,
+		RecordsPerSecond: 1000,
+		Burst:            2,
+		PerTrace:         true,
+		TraceWindow:      200 * time.Millisecond,
+		MaxTraces:        1024,
+	}
+	require.NoError(t, cfg.Validate())
+	sink := new(consumertest.TracesSink)
+	startTracesReceiver(t, cfg, sink)
+
+	rejectedAt := time.Now()
+	require.Equal(t, http.StatusTooManyRequests, postSpans(t, cfg, traceIDs(3, 1)).StatusCode)
+
+	// A sender retrying the spans of trace 1 more often than trace_window
+	// gets them admitted once trace_window has passed since the rejection.
+	require.Eventually(t, func() bool {
+		return postSpans(t, cfg, traceIDs(2, 1)).StatusCode == http.StatusOK
+	}, 2*time.Second, 20*time.Millisecond)
+	assert.GreaterOrEqual(t, time.Since(rejectedAt), cfg.RateLimit.TraceWindow)
+	assert.Equal(t, 2, sink.SpanCount())
+}
+
+func TestReceiver_RateLimit_AdmittedTraceOverBurst(t *testing.T) {
+	cfg := rateLimitCfg(t, httpOnlyCfg(t, false, false, nil), true)
+	sink := new(consumertest.TracesSink)
+	startTracesReceiver(t, cfg, sink)
+
+	require.Equal(t, http.StatusOK, postSpans(t, cfg, traceIDs(1, 1)).StatusCode)
+
+	// More spans of trace 1 than the burst in one request are rejected,
+	// while the trace stays admitted.
+	assert.Equal(t, http.StatusTooManyRequests, postSpans(t, cfg, traceIDs(4, 1)).StatusCode)
+	assert.Equal(t, http.StatusOK, postSpans(t, cfg, traceIDs(3, 1)).StatusCode)
+	assert.Equal(t, 4, sink.SpanCount())
+}
+
+func TestReceiver_RateLimit_GRPC(t *testing.T) {
+	cfg := rateLimitCfg(t, grpcHTTPCfg(t), false)
+	sink := new(consumertest.MetricsSink)
+	startMetricsReceiver(t, cfg, sink)
+
+	conn, err := grpc.NewClient(cfg.Protocols.GRPC.NetAddr.Endpoint, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	client := pmetricotlp.NewGRPCClient(conn)
+
+	metrics := func(n int) pmetricotlp.ExportRequest {
+		md := pmetric.NewMetrics()
+		dps := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty().SetEmptyGauge().DataPoints()
+		for range n {
+			dps.AppendEmpty().SetIntValue(1)
+		}
+		return pmetricotlp.NewExportRequestFromMetrics(md)
+	}
+
+	_, err = client.Export(context.Background(), metrics(3))
+	require.NoError(t, err)
+	_, err = client.Export(context.Background(), metrics(1))
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.Equal(t, 3, sink.DataPointCount())
+}
@@ -0,0 +1,82 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/requestid"
+)
+
+func TestReceiver_GRPCRequestID(t *testing.T) {
+	cfg := grpcHTTPCfg(t)
+	seen := make(chan string, 2)
+	tc, err := consumer.NewTraces(func(ctx context.Context, _ ptrace.Traces) error {
+		seen <- requestid.FromContext(ctx)
+		return nil
+	})
+	require.NoError(t, err)
+	r := startTraces(t, receivertest.NewNopSettings(component.MustNewType("tfootlp")), cfg, tc)
+	t.Cleanup(func() { _ = r.Shutdown(context.Background()) })
+
+	cc, err := grpc.NewClient(cfg.Protocols.GRPC.NetAddr.Endpoint,
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+	client := ptraceotlp.NewGRPCClient(cc)
+
+	var header metadata.MD
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "x-request-id", "req-grpc")
+	_, err = client.Export(ctx, ptraceotlp.NewExportRequestFromTraces(spans(1)), grpc.Header(&header))
+	require.NoError(t, err)
+	assert.Equal(t, "req-grpc", <-seen)
+	assert.Equal(t, []string{"req-grpc"}, header.Get("x-request-id"))
+
+	_, err = client.Export(context.Background(), ptraceotlp.NewExportRequestFromTraces(spans(1)), grpc.Header(&header))
+	require.NoError(t, err)
+	generated := <-seen
+	assert.True(t, requestid.Valid(generated))
+	assert.Equal(t, []string{generated}, header.Get("x-request-id"))
+}
+
+func TestReceiver_HTTPRequestIDReachesConsumer(t *testing.T) {
+	cfg := httpOnlyCfg(t, false, false, nil)
+	seen := make(chan string, 1)
+	tc, err := consumer.NewTraces(func(ctx context.Context, _ ptrace.Traces) error {
+		seen <- requestid.FromContext(ctx)
+		return errors.New("backend down")
+	})
+	require.NoError(t, err)
+	r := startTraces(t, receivertest.NewNopSettings(component.MustNewType("tfootlp")), cfg, tc)
+	t.Cleanup(func() { _ = r.Shutdown(context.Background()) })
+
+	resp, err := postTraces(cfg)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, <-seen, resp.Header.Get(requestid.Header))
+}
@@ -0,0 +1,125 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+func spans(n int) ptrace.Traces {
+	td := ptrace.NewTraces()
+	ss := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for range n {
+		ss.AppendEmpty().SetName("op")
+	}
+	return td
+}
+
+// histogramPoint returns the data point of the histogram name recorded for
+// protocol and endpoint.
+func histogramPoint(t *testing.T, tel *componenttest.Telemetry, name, protocol, endpoint string) metricdata.HistogramDataPoint[int64] {
+	t.Helper()
+	m, err := tel.GetMetric(name)
+	require.NoError(t, err)
+	for _, dp := range m.Data.(metricdata.Histogram[int64]).DataPoints {
+		p, _ := dp.Attributes.Value(attribute.Key("protocol"))
+		e, _ := dp.Attributes.Value(attribute.Key("endpoint"))
+		if p.AsString() == protocol && e.AsString() == endpoint {
+			return dp
+		}
+	}
+	require.Failf(t, "data point not found", "%s protocol=%s endpoint=%s", name, protocol, endpoint)
+	return metricdata.HistogramDataPoint[int64]{}
+}
+
+func TestReceiver_RequestStats(t *testing.T) {
+	cfg := grpcHTTPCfg(t)
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { _ = tel.Shutdown(context.Background()) })
+	set := receivertest.NewNopSettings(component.MustNewType("tfootlp"))
+	set.TelemetrySettings = tel.NewTelemetrySettings()
+
+	sink := new(consumertest.TracesSink)
+	r := startTraces(t, set, cfg, sink)
+	t.Cleanup(func() { _ = r.Shutdown(context.Background()) })
+
+	post := func(path string, td ptrace.Traces) int {
+		body, err := ptraceotlp.NewExportRequestFromTraces(td).MarshalJSON()
+		require.NoError(t, err)
+		client := &http.Client{Timeout: 5 * time.Second}
+		resp, err := client.Post("http://"+cfg.Protocols.HTTP.NetAddr.Endpoint+path, "application/json", bytes.NewReader(body))
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		return len(body)
+	}
+	v1Size := post("/v1/traces", spans(2))
+	v2Size := post("/v2/traces", spans(1))
+
+	cc, err := grpc.NewClient(cfg.Protocols.GRPC.NetAddr.Endpoint,
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+	grpcReq := ptraceotlp.NewExportRequestFromTraces(spans(3))
+	grpcBody, err := grpcReq.MarshalProto()
+	require.NoError(t, err)
+	_, err = ptraceotlp.NewGRPCClient(cc).Export(context.Background(), grpcReq)
+	require.NoError(t, err)
+
+	tests := []struct {
+		protocol, endpoint string
+		size, records      int64
+	}{
+		{"http", "v1", int64(v1Size), 2},
+		{"http", "v2", int64(v2Size), 1},
+		{"grpc", "v1", int64(len(grpcBody)), 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.protocol+"/"+tt.endpoint, func(t *testing.T) {
+			size := histogramPoint(t, tel, selfmetrics.ReceiverRequestSize, tt.protocol, tt.endpoint)
+			assert.Equal(t, uint64(1), size.Count)
+			assert.Equal(t, tt.size, size.Sum)
+
+			decompressed := histogramPoint(t, tel, selfmetrics.ReceiverRequestDecompressedSize, tt.protocol, tt.endpoint)
+			assert.Equal(t, tt.size, decompressed.Sum, "uncompressed requests")
+
+			records := histogramPoint(t, tel, selfmetrics.ReceiverRequestRecords, tt.protocol, tt.endpoint)
+			assert.Equal(t, uint64(1), records.Count)
+			assert.Equal(t, tt.records, records.Sum)
+			signal, _ := records.Attributes.Value(selfmetrics.SignalKey)
+			assert.Equal(t, "traces", signal.AsString())
+		})
+	}
+	assert.Equal(t, 6, sink.SpanCount())
+}
@@ -0,0 +1,193 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver_test
+
+import (
+	"crypto/rand"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfootlpreceiver"
+)
+
+// randomTraceIDs returns n random trace IDs.
+func randomTraceIDs(n int) []pcommon.TraceID {
+	ids := make([]pcommon.TraceID, n)
+	for i := range ids {
+		_, _ = rand.Read(ids[i][:])
+	}
+	return ids
+}
+
+// spansOf builds traces with spans spans for each of the trace IDs.
+func spansOf(ids []pcommon.TraceID, spans int) ptrace.Traces {
+	td := ptrace.NewTraces()
+	ss := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for _, id := range ids {
+		for range spans {
+			span := ss.AppendEmpty()
+			span.SetTraceID(id)
+			span.SetName("op")
+		}
+	}
+	return td
+}
+
+// logsOf builds one log record for each of the trace IDs.
+func logsOf(t *testing.T, ids []pcommon.TraceID) []byte {
+	t.Helper()
+	ld := plog.NewLogs()
+	records := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for _, id := range ids {
+		lr := records.AppendEmpty()
+		lr.SetTraceID(id)
+		lr.Body().SetStr("line")
+	}
+	body, err := plogotlp.NewExportRequestFromLogs(ld).MarshalProto()
+	require.NoError(t, err)
+	return body
+}
+
+func TestConfig_Validate_Sampling(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     tfootlpreceiver.SamplingConfig
+		wantErr string
+	}{
+		{"disabled ignores settings", tfootlpreceiver.SamplingConfig{Traces: 101}, ""},
+		{"bounds", tfootlpreceiver.SamplingConfig{Enabled: true, Traces: 0, Logs: 100}, ""},
+		{"traces", tfootlpreceiver.SamplingConfig{Enabled: true, Traces: 101, Logs: 100}, "sampling.traces must be between 0 and 100"},
+		{"logs", tfootlpreceiver.SamplingConfig{Enabled: true, Traces: 100, Logs: -1}, "sampling.logs must be between 0 and 100"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tfootlpreceiver.NewFactory().CreateDefaultConfig().(*tfootlpreceiver.Config)
+			assert.Equal(t, tfootlpreceiver.SamplingConfig{Traces: 100, Logs: 100}, cfg.Sampling)
+			cfg.Sampling = tt.cfg
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestReceiver_Sampling_KeepsWholeTraces(t *testing.T) {
+	cfg := httpOnlyCfg(t, false, false, nil)
+	cfg.Sampling = tfootlpreceiver.SamplingConfig{Enabled: true, Traces: 50, Logs: 100}
+	sink := new(consumertest.TracesSink)
+	startTracesReceiver(t, cfg, sink)
+
+	ids := randomTraceIDs(200)
+	require.Equal(t, http.StatusOK, postSpans(t, cfg, spansOf(ids, 3)).StatusCode)
+
+	spans := make(map[pcommon.TraceID]int)
+	for _, td := range sink.AllTraces() {
+		ss := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+		for i := range ss.Len() {
+			spans[ss.At(i).TraceID()]++
+		}
+	}
+	assert.NotEmpty(t, spans)
+	assert.Less(t, len(spans), len(ids))
+	for id, n := range spans {
+		assert.Equal(t, 3, n, "trace %s is kept whole", id)
+	}
+
+	// The same traces are kept again.
+	before := sink.SpanCount()
+	require.Equal(t, http.StatusOK, postSpans(t, cfg, spansOf(ids, 3)).StatusCode)
+	assert.Equal(t, 2*before, sink.SpanCount())
+}
+
+func TestReceiver_Sampling_LogsFollowTraces(t *testing.T) {
+	tests := []struct {
+		name         string
+		traces, logs float64
+	}{
+		{"same percentages", 50, 50},
+		{"fewer logs", 50, 10},
+		{"more logs", 25, 100},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := httpOnlyCfg(t, false, false, nil)
+			cfg.Sampling = tfootlpreceiver.SamplingConfig{Enabled: true, Traces: tt.traces, Logs: tt.logs}
+			traces := new(consumertest.TracesSink)
+			logs := new(consumertest.LogsSink)
+			startTracesReceiver(t, cfg, traces)
+			startLogsReceiver(t, cfg, logs)
+
+			ids := randomTraceIDs(200)
+			require.Equal(t, http.StatusOK, postSpans(t, cfg, spansOf(ids, 1)).StatusCode)
+			require.Equal(t, http.StatusOK, postTo(t, cfg, "/v1/logs", logsOf(t, ids)).StatusCode)
+
+			kept := make(map[pcommon.TraceID]bool)
+			for _, td := range traces.AllTraces() {
+				ss := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+				for i := range ss.Len() {
+					kept[ss.At(i).TraceID()] = true
+				}
+			}
+			assert.NotEmpty(t, kept)
+			assert.Less(t, len(kept), len(ids))
+
+			// The logs of the kept traces are kept, the others dropped.
+			require.Equal(t, len(kept), logs.LogRecordCount())
+			for _, ld := range logs.AllLogs() {
+				records := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+				for i := range records.Len() {
+					assert.True(t, kept[records.At(i).TraceID()])
+				}
+			}
+		})
+	}
+}
+
+func TestReceiver_Sampling_LogsWithoutTraceID(t *testing.T) {
+	cfg := httpOnlyCfg(t, false, false, nil)
+	cfg.Sampling = tfootlpreceiver.SamplingConfig{Enabled: true, Traces: 0, Logs: 100}
+	sink := new(consumertest.LogsSink)
+	startLogsReceiver(t, cfg, sink)
+
+	// Records without a trace ID are sampled at the logs percentage.
+	require.Equal(t, http.StatusOK, postTo(t, cfg, "/v1/logs", logsOf(t, make([]pcommon.TraceID, 5))).StatusCode)
+	assert.Equal(t, 5, sink.LogRecordCount())
+
+	require.Equal(t, http.StatusOK, postTo(t, cfg, "/v1/logs", logsOf(t, randomTraceIDs(5))).StatusCode)
+	assert.Equal(t, 5, sink.LogRecordCount(), "records of sampled-out traces are dropped")
+}
+
+func TestReceiver_Sampling_AllSampledOut(t *testing.T) {
+	cfg := httpOnlyCfg(t, false, false, nil)
+	cfg.Sampling = tfootlpreceiver.SamplingConfig{Enabled: true, Traces: 0, Logs: 0}
+	sink := new(consumertest.LogsSink)
+	startLogsReceiver(t, cfg, sink)
+
+	// Sampled-out records are accepted, not reported as rejected.
+	resp := postTo(t, cfg, "/v1/logs", logsOf(t, append(randomTraceIDs(5), pcommon.TraceID{})))
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Empty(t, sink.AllLogs(), "nothing is passed on")
+}
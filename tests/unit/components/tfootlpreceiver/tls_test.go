@@ -0,0 +1,259 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfootlpreceiver"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+)
+
+// staticTLS writes a self-signed certificate for 127.0.0.1 and returns the
+// tls settings using it and a client config trusting it.
+func staticTLS(t *testing.T) (configtls.ServerConfig, *tls.Config) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "tfootlp-test"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	static := configtls.NewDefaultServerConfig()
+	static.CertFile = filepath.Join(dir, "cert.pem")
+	static.KeyFile = filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(static.CertFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(static.KeyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+	leaf, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	roots := x509.NewCertPool()
+	roots.AddCert(leaf)
+	return static, &tls.Config{RootCAs: roots, MinVersion: tls.VersionTLS12}
+}
+
+func startTLSReceiver(t *testing.T, cfg *tfootlpreceiver.Config, sink *consumertest.TracesSink) {
+	t.Helper()
+	factory := tfootlpreceiver.NewFactory()
+	set := receivertest.NewNopSettings(component.MustNewType("tfootlp"))
+	r, err := factory.CreateTraces(context.Background(), set, cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { _ = r.Shutdown(context.Background()) })
+}
+
+func postTLSTraces(t *testing.T, client *http.Client, url string) *http.Response {
+	t.Helper()
+	body, err := ptraceotlp.NewExportRequestFromTraces(oneSpan()).MarshalProto()
+	require.NoError(t, err)
+	resp, err := client.Post(url, "application/x-protobuf", bytes.NewReader(body))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+	return resp
+}
+
+func oneSpan() ptrace.Traces {
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("tls")
+	return td
+}
+
+func TestReceiver_StaticTLS(t *testing.T) {
+	static, clientTLS := staticTLS(t)
+	cfg := grpcHTTPCfg(t)
+	cfg.Protocols.GRPC.TLS = configoptional.Some(static)
+	cfg.Protocols.HTTP.TLS = configoptional.Some(static)
+	sink := new(consumertest.TracesSink)
+	startTLSReceiver(t, cfg, sink)
+
+	client := &http.Client{Timeout: 5 * time.Second, Transport: &http.Transport{TLSClientConfig: clientTLS}}
+	resp := postTLSTraces(t, client, "https://"+cfg.Protocols.HTTP.NetAddr.Endpoint+"/v1/traces")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.NotNil(t, resp.TLS)
+
+	cc, err := grpc.NewClient(cfg.Protocols.GRPC.NetAddr.Endpoint, grpc.WithTransportCredentials(credentials.NewTLS(clientTLS)))
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+	_, err = ptraceotlp.NewGRPCClient(cc).Export(context.Background(), ptraceotlp.NewExportRequestFromTraces(oneSpan()))
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool { return sink.SpanCount() == 2 }, time.Second, 10*time.Millisecond)
+}
+
+func TestReceiver_ACMEFallsBackToStaticTLS(t *testing.T) {
+	static, clientTLS := staticTLS(t)
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	directory := "http://" + l.Addr().String() + "/directory"
+	require.NoError(t, l.Close())
+
+	cfg := httpOnlyCfg(t, false, false, nil)
+	cfg.Protocols.HTTP.TLS = configoptional.Some(static)
+	cfg.Protocols.HTTP.ACME = serverconf.ACMEConfig{
+		Enabled:      true,
+		Domains:      []string{"collector.example.com"},
+		DirectoryURL: directory,
+		CacheDir:     t.TempDir(),
+	}
+	require.NoError(t, cfg.Validate())
+	sink := new(consumertest.TracesSink)
+	startTLSReceiver(t, cfg, sink)
+
+	client := &http.Client{Timeout: 5 * time.Second, Transport: &http.Transport{TLSClientConfig: clientTLS}}
+	resp := postTLSTraces(t, client, "https://"+cfg.Protocols.HTTP.NetAddr.Endpoint+"/v1/traces")
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 1, sink.SpanCount())
+}
+
+func TestReceiver_TLSInvalidStaticCertFailsStart(t *testing.T) {
+	cfg := httpOnlyCfg(t, false, false, nil)
+	static := configtls.NewDefaultServerConfig()
+	static.CertFile = filepath.Join(t.TempDir(), "missing.pem")
+	static.KeyFile = static.CertFile
+	cfg.Protocols.HTTP.TLS = configoptional.Some(static)
+
+	factory := tfootlpreceiver.NewFactory()
+	set := receivertest.NewNopSettings(component.MustNewType("tfootlp"))
+	r, err := factory.CreateTraces(context.Background(), set, cfg, new(consumertest.TracesSink))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = r.Shutdown(context.Background()) })
+	err = r.Start(context.Background(), componenttest.NewNopHost())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "protocols.http")
+}
+
+// clientCertificate writes a self-signed client certificate, which doubles
+// as the client CA, and returns its file and the certificate.
+func clientCertificate(t *testing.T) (string, tls.Certificate) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(2),
+		Subject:               pkix.Name{CommonName: "edge-agent"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	file := filepath.Join(t.TempDir(), "ca.pem")
+	require.NoError(t, os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	return file, tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+func TestReceiver_MutualTLS(t *testing.T) {
+	static, clientTLS := staticTLS(t)
+	caFile, cert := clientCertificate(t)
+	static.ClientCAFile = caFile
+	static.MinVersion = "1.3"
+
+	cfg := grpcHTTPCfg(t)
+	cfg.Protocols.GRPC.TLS = configoptional.Some(static)
+	cfg.Protocols.GRPC.ClientAuthType = serverconf.ClientAuthRequireAndVerify
+	cfg.Protocols.GRPC.TLSReload = serverconf.TLSReloadConfig{Enabled: true}
+	cfg.Protocols.HTTP.TLS = configoptional.Some(static)
+	cfg.Protocols.HTTP.ClientAuthType = serverconf.ClientAuthRequireAndVerify
+	cfg.Protocols.HTTP.TLSReload = serverconf.TLSReloadConfig{Enabled: true}
+	require.NoError(t, cfg.Validate())
+	sink := new(consumertest.TracesSink)
+	startTLSReceiver(t, cfg, sink)
+
+	mutualTLS := clientTLS.Clone()
+	mutualTLS.Certificates = []tls.Certificate{cert}
+	url := "https://" + cfg.Protocols.HTTP.NetAddr.Endpoint + "/v1/traces"
+
+	// Without a client certificate the handshake fails on both protocols.
+	anonymous := &http.Client{Timeout: 5 * time.Second, Transport: &http.Transport{TLSClientConfig: clientTLS}}
+	body, err := ptraceotlp.NewExportRequestFromTraces(oneSpan()).MarshalProto()
+	require.NoError(t, err)
+	resp, err := anonymous.Post(url, "application/x-protobuf", bytes.NewReader(body))
+	if err == nil {
+		_ = resp.Body.Close()
+	}
+	require.Error(t, err)
+
+	cc, err := grpc.NewClient(cfg.Protocols.GRPC.NetAddr.Endpoint, grpc.WithTransportCredentials(credentials.NewTLS(clientTLS)))
+	require.NoError(t, err)
+	_, err = ptraceotlp.NewGRPCClient(cc).Export(context.Background(), ptraceotlp.NewExportRequestFromTraces(oneSpan()))
+	require.Error(t, err)
+	_ = cc.Close()
+
+	client := &http.Client{Timeout: 5 * time.Second, Transport: &http.Transport{TLSClientConfig: mutualTLS}}
+	resp = postTLSTraces(t, client, url)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, uint16(tls.VersionTLS13), resp.TLS.Version)
+
+	cc, err = grpc.NewClient(cfg.Protocols.GRPC.NetAddr.Endpoint, grpc.WithTransportCredentials(credentials.NewTLS(mutualTLS)))
+	require.NoError(t, err)
+	defer func() { _ = cc.Close() }()
+	_, err = ptraceotlp.NewGRPCClient(cc).Export(context.Background(), ptraceotlp.NewExportRequestFromTraces(oneSpan()))
+	require.NoError(t, err)
+
+	require.Eventually(t, func() bool { return sink.SpanCount() == 2 }, time.Second, 10*time.Millisecond)
+}
+
+func TestReceiver_MutualTLSRequiresClientCA(t *testing.T) {
+	static, _ := staticTLS(t)
+	cfg := grpcHTTPCfg(t)
+	cfg.Protocols.GRPC.TLS = configoptional.Some(static)
+	cfg.Protocols.GRPC.ClientAuthType = serverconf.ClientAuthRequireAndVerify
+
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "protocols.grpc: client_auth_type")
+}
@@ -0,0 +1,139 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfootlpreceiver"
+)
+
+var validatorID = component.MustNewID("tfoauth")
+
+// validator is a tfoauth extension stand-in accepting the keys in valid.
+type validator struct {
+	component.StartFunc
+	component.ShutdownFunc
+	valid map[string]string
+	err   error
+}
+
+func (v *validator) ValidateCredential(_ context.Context, keyID, keySecret string) (bool, error) {
+	if v.err != nil {
+		return false, v.err
+	}
+	return v.valid[keyID] == keySecret, nil
+}
+
+func newValidatorHost(ext component.Component) component.Host {
+	return identityHost{
+		Host: componenttest.NewNopHost(),
+		exts: map[component.ID]component.Component{validatorID: ext},
+	}
+}
+
+// startValidatedLogsReceiver starts a logs receiver validating v2 keys with ext.
+func startValidatedLogsReceiver(t *testing.T, ext component.Component) (*tfootlpreceiver.Config, *consumertest.LogsSink) {
+	t.Helper()
+	cfg := httpOnlyCfg(t, true, false, nil)
+	cfg.V2Auth.Validator = validatorID
+	require.NoError(t, cfg.Validate())
+
+	sink := new(consumertest.LogsSink)
+	r, err := tfootlpreceiver.NewFactory().CreateLogs(context.Background(),
+		receivertest.NewNopSettings(component.MustNewType("tfootlp")), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), newValidatorHost(ext)))
+	t.Cleanup(func() { _ = r.Shutdown(context.Background()) })
+	time.Sleep(50 * time.Millisecond)
+	return cfg, sink
+}
+
+// postLogsWithCredentials posts one log record to /v2/logs with the API key.
+func postLogsWithCredentials(t *testing.T, cfg *tfootlpreceiver.Config, keyID, keySecret string) *http.Response {
+	t.Helper()
+	req, err := http.NewRequest(http.MethodPost, "http://"+cfg.Protocols.HTTP.NetAddr.Endpoint+"/v2/logs", bytes.NewReader(logLines(t, 1)))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/x-protobuf")
+	req.Header.Set("X-TelemetryFlow-Key-ID", keyID)
+	req.Header.Set("X-TelemetryFlow-Key-Secret", keySecret)
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resp.Body.Close() })
+	return resp
+}
+
+func TestV2AuthValidator_StartRequiresExtension(t *testing.T) {
+	tests := []struct {
+		name    string
+		host    component.Host
+		wantErr string
+	}{
+		{"missing", componenttest.NewNopHost(), `v2_auth: validator extension "tfoauth" not found`},
+		{"wrong type", newValidatorHost(identity{id: "edge-1"}), `v2_auth: extension "tfoauth" does not validate credentials`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := httpOnlyCfg(t, true, false, nil)
+			cfg.V2Auth.Validator = validatorID
+			r, err := tfootlpreceiver.NewFactory().CreateLogs(context.Background(),
+				receivertest.NewNopSettings(component.MustNewType("tfootlp")), cfg, new(consumertest.LogsSink))
+			require.NoError(t, err)
+			t.Cleanup(func() { _ = r.Shutdown(context.Background()) })
+
+			assert.ErrorContains(t, r.Start(context.Background(), tt.host), tt.wantErr)
+		})
+	}
+}
+
+func TestV2AuthValidator_AllowsSecretCheckWithKeyList(t *testing.T) {
+	cfg := httpOnlyCfg(t, true, true, []string{"tfk_a"})
+	require.ErrorContains(t, cfg.Validate(), "requires tfoauth extension")
+
+	cfg.V2Auth.Validator = validatorID
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestV2AuthValidator_RefusesRejectedKeys(t *testing.T) {
+	cfg, sink := startValidatedLogsReceiver(t, &validator{valid: map[string]string{"tfk_a": "tfs_a"}})
+
+	assert.Equal(t, http.StatusOK, postLogsWithCredentials(t, cfg, "tfk_a", "tfs_a").StatusCode)
+	assert.Equal(t, http.StatusUnauthorized, postLogsWithCredentials(t, cfg, "tfk_a", "tfs_revoked").StatusCode)
+	assert.Equal(t, http.StatusUnauthorized, postLogsWithCredentials(t, cfg, "tfk_b", "tfs_b").StatusCode)
+	assert.Equal(t, http.StatusUnauthorized, postLogsWithCredentials(t, cfg, "tfk_a", "").StatusCode,
+		"a validator requires the secret")
+	assert.Equal(t, 1, sink.LogRecordCount())
+}
+
+func TestV2AuthValidator_UnavailableValidation(t *testing.T) {
+	cfg, sink := startValidatedLogsReceiver(t, &validator{err: errors.New("validation request failed")})
+
+	resp := postLogsWithCredentials(t, cfg, "tfk_a", "tfs_a")
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Zero(t, sink.LogRecordCount())
+}
@@ -0,0 +1,148 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver_test
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfootlpreceiver"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/watchdog"
+)
+
+func TestReceiver_WatchdogRestartsWedgedServer(t *testing.T) {
+	cfg := httpOnlyCfg(t, false, false, nil)
+	cfg.Watchdog = watchdog.Config{
+		Enabled:       true,
+		CheckInterval: 10 * time.Millisecond,
+		StallTimeout:  100 * time.Millisecond,
+		MaxRestarts:   1,
+	}
+
+	// The first export blocks until the restart gives up on it; later ones
+	// succeed.
+	var calls atomic.Int32
+	abandoned := make(chan error, 1)
+	next, err := consumer.NewTraces(func(ctx context.Context, _ ptrace.Traces) error {
+		if calls.Add(1) == 1 {
+			<-ctx.Done()
+			abandoned <- context.Cause(ctx)
+			return context.Cause(ctx)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	factory := tfootlpreceiver.NewFactory()
+	set := receivertest.NewNopSettings(component.MustNewType("tfootlp"))
+	r, err := factory.CreateTraces(context.Background(), set, cfg, next)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { _ = r.Shutdown(context.Background()) })
+
+	body, err := ptraceotlp.NewExportRequest().MarshalProto()
+	require.NoError(t, err)
+	url := "http://" + cfg.Protocols.HTTP.NetAddr.Endpoint + "/v1/traces"
+	client := &http.Client{Timeout: 5 * time.Second}
+
+	go func() {
+		resp, err := client.Post(url, "application/x-protobuf", bytes.NewReader(body))
+		if err == nil {
+			_ = resp.Body.Close()
+		}
+	}()
+
+	select {
+	case cause := <-abandoned:
+		assert.Error(t, cause, "the stuck consumer is unblocked by the restart")
+	case <-time.After(3 * time.Second):
+		t.Fatal("watchdog did not restart the wedged receiver")
+	}
+
+	require.Eventually(t, func() bool {
+		resp, err := client.Post(url, "application/x-protobuf", bytes.NewReader(body))
+		if err != nil {
+			return false
+		}
+		_ = resp.Body.Close()
+		return resp.StatusCode == http.StatusOK
+	}, 3*time.Second, 20*time.Millisecond, "receiver serves again after restart")
+}
+
+func TestReceiver_WatchdogRestartDrainsInFlightRequests(t *testing.T) {
+	cfg := httpOnlyCfg(t, false, false, nil)
+	cfg.DrainTimeout = 2 * time.Second
+	cfg.Watchdog = watchdog.Config{
+		Enabled:       true,
+		CheckInterval: 10 * time.Millisecond,
+		StallTimeout:  100 * time.Millisecond,
+		MaxRestarts:   1,
+	}
+
+	// The first export is slow enough to trigger a restart but finishes
+	// within the drain timeout.
+	var calls atomic.Int32
+	next, err := consumer.NewTraces(func(ctx context.Context, _ ptrace.Traces) error {
+		if calls.Add(1) == 1 {
+			select {
+			case <-time.After(300 * time.Millisecond):
+			case <-ctx.Done():
+				return context.Cause(ctx)
+			}
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	factory := tfootlpreceiver.NewFactory()
+	set := receivertest.NewNopSettings(component.MustNewType("tfootlp"))
+	r, err := factory.CreateTraces(context.Background(), set, cfg, next)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { _ = r.Shutdown(context.Background()) })
+
+	body, err := ptraceotlp.NewExportRequest().MarshalProto()
+	require.NoError(t, err)
+	url := "http://" + cfg.Protocols.HTTP.NetAddr.Endpoint + "/v1/traces"
+	client := &http.Client{Timeout: 5 * time.Second}
+
+	resp, err := client.Post(url, "application/x-protobuf", bytes.NewReader(body))
+	require.NoError(t, err, "the in-flight request is drained, not dropped")
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	require.Eventually(t, func() bool {
+		resp, err := client.Post(url, "application/x-protobuf", bytes.NewReader(body))
+		if err != nil {
+			return false
+		}
+		_ = resp.Body.Close()
+		return resp.StatusCode == http.StatusOK
+	}, 3*time.Second, 20*time.Millisecond, "receiver serves again after restart")
+	assert.GreaterOrEqual(t, calls.Load(), int32(2))
+}
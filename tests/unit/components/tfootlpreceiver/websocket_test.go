@@ -0,0 +1,246 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfootlpreceiver_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"google.golang.org/protobuf/encoding/protowire"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfootlpreceiver"
+)
+
+// wsTimeout bounds each websocket handshake and read.
+const wsTimeout = 2 * time.Second
+
+// wsCfg returns a receiver configuration serving the websocket endpoint.
+func wsCfg(t *testing.T) *tfootlpreceiver.Config {
+	t.Helper()
+	cfg := grpcHTTPCfg(t)
+	cfg.Protocols.HTTP.WebSocket = tfootlpreceiver.WebSocketConfig{
+		Enabled:        true,
+		Path:           "/v1/websocket",
+		MaxMessageSize: 1 << 20,
+		PingInterval:   time.Minute,
+	}
+	return cfg
+}
+
+// dialWS connects to the websocket endpoint of cfg with subprotocol.
+func dialWS(t *testing.T, cfg *tfootlpreceiver.Config, subprotocol string, header http.Header) (*websocket.Conn, *http.Response, error) {
+	t.Helper()
+	dialer := websocket.Dialer{Subprotocols: []string{subprotocol}, HandshakeTimeout: wsTimeout}
+	conn, resp, err := dialer.Dial("ws://"+cfg.Protocols.HTTP.NetAddr.Endpoint+"/v1/websocket", header)
+	if conn != nil {
+		t.Cleanup(func() { _ = conn.Close() })
+	}
+	return conn, resp, err
+}
+
+func tracesPayload(t *testing.T, n int) []byte {
+	t.Helper()
+	payload, err := ptraceotlp.NewExportRequestFromTraces(spans(n)).MarshalProto()
+	require.NoError(t, err)
+	return payload
+}
+
+// protoMessage encodes a protobuf websocket message.
+func protoMessage(id uint64, signal string, payload []byte) []byte {
+	b := protowire.AppendTag(nil, 1, protowire.VarintType)
+	b = protowire.AppendVarint(b, id)
+	b = protowire.AppendTag(b, 2, protowire.BytesType)
+	b = protowire.AppendString(b, signal)
+	b = protowire.AppendTag(b, 3, protowire.BytesType)
+	return protowire.AppendBytes(b, payload)
+}
+
+type protoAck struct {
+	id, code, rejected uint64
+	message            string
+}
+
+func readProtoAck(t *testing.T, conn *websocket.Conn) protoAck {
+	t.Helper()
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(wsTimeout)))
+	typ, b, err := conn.ReadMessage()
+	require.NoError(t, err)
+	require.Equal(t, websocket.BinaryMessage, typ)
+	var ack protoAck
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		require.GreaterOrEqual(t, n, 0)
+		b = b[n:]
+		if typ == protowire.BytesType {
+			v, n := protowire.ConsumeString(b)
+			require.GreaterOrEqual(t, n, 0)
+			ack.message, b = v, b[n:]
+			continue
+		}
+		v, n := protowire.ConsumeVarint(b)
+		require.GreaterOrEqual(t, n, 0)
+		b = b[n:]
+		switch num {
+		case 1:
+			ack.id = v
+		case 2:
+			ack.code = v
+		case 4:
+			ack.rejected = v
+		}
+	}
+	return ack
+}
+
+func TestWebSocket_ProtoMessagesAreAcked(t *testing.T) {
+	cfg := wsCfg(t)
+	sink := new(consumertest.TracesSink)
+	startTracesReceiver(t, cfg, sink)
+
+	conn, resp, err := dialWS(t, cfg, "otlp.proto", nil)
+	require.NoError(t, err)
+	assert.Equal(t, "otlp.proto", resp.Header.Get("Sec-WebSocket-Protocol"))
+
+	// Messages may be sent before earlier ones are acknowledged.
+	require.NoError(t, conn.WriteMessage(websocket.BinaryMessage, protoMessage(1, "traces", tracesPayload(t, 2))))
+	require.NoError(t, conn.WriteMessage(websocket.BinaryMessage, protoMessage(2, "traces", tracesPayload(t, 3))))
+	require.NoError(t, conn.WriteMessage(websocket.BinaryMessage, protoMessage(3, "traces", []byte("not otlp"))))
+	require.NoError(t, conn.WriteMessage(websocket.BinaryMessage, protoMessage(4, "profiles", nil)))
+
+	assert.Equal(t, protoAck{id: 1, code: 200}, readProtoAck(t, conn))
+	assert.Equal(t, protoAck{id: 2, code: 200}, readProtoAck(t, conn))
+	assert.Equal(t, protoAck{id: 3, code: 400, message: "Failed to unmarshal traces"}, readProtoAck(t, conn))
+	assert.Equal(t, protoAck{id: 4, code: 400, message: `Unknown signal "profiles"`}, readProtoAck(t, conn))
+	assert.Equal(t, 5, sink.SpanCount())
+}
+
+func TestWebSocket_MsgpackMessagesAreAcked(t *testing.T) {
+	cfg := wsCfg(t)
+	sink := new(consumertest.TracesSink)
+	startTracesReceiver(t, cfg, sink)
+
+	conn, _, err := dialWS(t, cfg, "otlp.msgpack", nil)
+	require.NoError(t, err)
+
+	payload := tracesPayload(t, 4)
+	// {"id": 300, "device": "sensor-1", "signal": "traces", "payload": <bin>}
+	msg := []byte{0x84, 0xa2, 'i', 'd', 0xcd, 0x01, 0x2c}
+	msg = append(msg, 0xa6, 'd', 'e', 'v', 'i', 'c', 'e', 0xa8, 's', 'e', 'n', 's', 'o', 'r', '-', '1')
+	msg = append(msg, 0xa6, 's', 'i', 'g', 'n', 'a', 'l', 0xa6, 't', 'r', 'a', 'c', 'e', 's')
+	msg = append(msg, 0xa7, 'p', 'a', 'y', 'l', 'o', 'a', 'd', 0xc4, byte(len(payload)))
+	msg = append(msg, payload...)
+	require.NoError(t, conn.WriteMessage(websocket.BinaryMessage, msg))
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(wsTimeout)))
+	_, ack, err := conn.ReadMessage()
+	require.NoError(t, err)
+	// {"id": 300, "code": 200}
+	assert.Equal(t, []byte{0x82, 0xa2, 'i', 'd', 0xcd, 0x01, 0x2c, 0xa4, 'c', 'o', 'd', 'e', 0xcc, 0xc8}, ack)
+	assert.Equal(t, 4, sink.SpanCount())
+}
+
+func TestWebSocket_InvalidMessagesCloseTheConnection(t *testing.T) {
+	cfg := wsCfg(t)
+	startTracesReceiver(t, cfg, new(consumertest.TracesSink))
+
+	for _, tt := range []struct {
+		name        string
+		subprotocol string
+		typ         int
+		data        []byte
+		code        int
+	}{
+		{"text message", "otlp.proto", websocket.TextMessage, []byte(`{}`), websocket.CloseUnsupportedData},
+		{"truncated protobuf", "otlp.proto", websocket.BinaryMessage, []byte{0x1a, 0x05}, websocket.CloseInvalidFramePayloadData},
+		{"msgpack array", "otlp.msgpack", websocket.BinaryMessage, []byte{0x90}, websocket.CloseInvalidFramePayloadData},
+		{"too large", "otlp.proto", websocket.BinaryMessage, make([]byte, 2<<20), websocket.CloseMessageTooBig},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			conn, _, err := dialWS(t, cfg, tt.subprotocol, nil)
+			require.NoError(t, err)
+			require.NoError(t, conn.WriteMessage(tt.typ, tt.data))
+			require.NoError(t, conn.SetReadDeadline(time.Now().Add(wsTimeout)))
+			_, _, err = conn.ReadMessage()
+			assert.True(t, websocket.IsCloseError(err, tt.code), "got %v", err)
+		})
+	}
+}
+
+func TestWebSocket_CompressedMessagesAreBoundedOnceInflated(t *testing.T) {
+	cfg := wsCfg(t)
+	startTracesReceiver(t, cfg, new(consumertest.TracesSink))
+
+	dialer := websocket.Dialer{Subprotocols: []string{"otlp.proto"}, HandshakeTimeout: wsTimeout, EnableCompression: true}
+	conn, _, err := dialer.Dial("ws://"+cfg.Protocols.HTTP.NetAddr.Endpoint+"/v1/websocket", nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+
+	// 8MiB of zeros deflate to a few KiB, well below the 1MiB limit on the
+	// wire.
+	require.NoError(t, conn.SetCompressionLevel(9))
+	require.NoError(t, conn.WriteMessage(websocket.BinaryMessage, make([]byte, 8<<20)))
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(wsTimeout)))
+	_, _, err = conn.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseMessageTooBig), "got %v", err)
+}
+
+func TestWebSocket_RejectsHandshakes(t *testing.T) {
+	cfg := wsCfg(t)
+	cfg.V2Auth.Required = true
+	startTracesReceiver(t, cfg, new(consumertest.TracesSink))
+
+	_, resp, err := dialWS(t, cfg, "otlp.proto", nil)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	header := http.Header{"X-TelemetryFlow-Key-ID": {"tfk_device"}}
+	_, resp, err = dialWS(t, cfg, "otlp.json", header)
+	require.Error(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	_, _, err = dialWS(t, cfg, "otlp.proto", header)
+	require.NoError(t, err)
+}
+
+func TestWebSocket_ShutdownClosesConnections(t *testing.T) {
+	cfg := wsCfg(t)
+	cfg.DrainTimeout = wsTimeout
+	r := startTracesReceiver(t, cfg, new(consumertest.TracesSink))
+
+	conn, _, err := dialWS(t, cfg, "otlp.proto", nil)
+	require.NoError(t, err)
+	require.NoError(t, conn.WriteMessage(websocket.BinaryMessage, protoMessage(1, "traces", tracesPayload(t, 1))))
+	assert.Equal(t, protoAck{id: 1, code: 200}, readProtoAck(t, conn))
+
+	done := make(chan error, 1)
+	go func() { done <- r.Shutdown(context.Background()) }()
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(wsTimeout)))
+	_, _, err = conn.ReadMessage()
+	assert.True(t, websocket.IsCloseError(err, websocket.CloseGoingAway), "got %v", err)
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(wsTimeout):
+		t.Fatal("shutdown waited for the websocket connection")
+	}
+}
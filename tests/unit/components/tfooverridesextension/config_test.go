@@ -0,0 +1,182 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfooverridesextension_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configoptional"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/extension/tfooverridesextension"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *tfooverridesextension.Config)
+		wantErr string
+	}{
+		{name: "defaults", mutate: func(*tfooverridesextension.Config) {}},
+		{
+			name:   "with file",
+			mutate: func(cfg *tfooverridesextension.Config) { cfg.File = "/var/lib/tfo-collector/overrides.json" },
+		},
+		{
+			name:    "no endpoint",
+			mutate:  func(cfg *tfooverridesextension.Config) { cfg.NetAddr.Endpoint = "" },
+			wantErr: "endpoint must not be empty",
+		},
+		{
+			name:    "endpoint without port",
+			mutate:  func(cfg *tfooverridesextension.Config) { cfg.NetAddr.Endpoint = "localhost" },
+			wantErr: "invalid endpoint",
+		},
+		{
+			name:    "endpoint off localhost without client authentication",
+			mutate:  func(cfg *tfooverridesextension.Config) { cfg.NetAddr.Endpoint = "0.0.0.0:55693" },
+			wantErr: `endpoint "0.0.0.0:55693" is not a loopback address`,
+		},
+		{
+			name: "endpoint off localhost with auth",
+			mutate: func(cfg *tfooverridesextension.Config) {
+				cfg.NetAddr.Endpoint = "0.0.0.0:55693"
+				cfg.Auth = configoptional.Some(confighttp.AuthConfig{
+					Config: configauth.Config{AuthenticatorID: component.MustNewID("basicauth")},
+				})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tfooverridesextension.NewFactory().CreateDefaultConfig().(*tfooverridesextension.Config)
+			tt.mutate(cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestOverrides_Validate(t *testing.T) {
+	percentage := func(p float64) *float64 { return &p }
+	tests := []struct {
+		name      string
+		overrides tfooverridesextension.Overrides
+		wantErr   string
+	}{
+		{name: "empty"},
+		{
+			name: "all sections",
+			overrides: tfooverridesextension.Overrides{
+				LogLevel: "debug",
+				Exporters: map[string]tfooverridesextension.ExporterOverrides{
+					"tfo/backup": {Headers: map[string]string{"X-Tenant": "acme"}, APIKeyID: "tfk_1", APIKeySecret: "tfs_1"},
+				},
+				Receivers: map[string]tfooverridesextension.ReceiverOverrides{
+					"tfootlp": {
+						RateLimit: &tfooverridesextension.RateLimitOverrides{RecordsPerSecond: 100},
+						Sampling:  &tfooverridesextension.SamplingOverrides{Traces: percentage(10)},
+					},
+				},
+				Connectors: map[string]tfooverridesextension.ConnectorOverrides{
+					"tfomirror": {Percentage: percentage(0)},
+				},
+			},
+		},
+		{
+			name:      "unknown log level",
+			overrides: tfooverridesextension.Overrides{LogLevel: "verbose"},
+			wantErr:   "log_level",
+		},
+		{
+			name: "invalid component ID",
+			overrides: tfooverridesextension.Overrides{
+				Exporters: map[string]tfooverridesextension.ExporterOverrides{"tfo/": {}},
+			},
+			wantErr: `exporters: invalid component ID "tfo/"`,
+		},
+		{
+			name: "invalid header name",
+			overrides: tfooverridesextension.Overrides{
+				Exporters: map[string]tfooverridesextension.ExporterOverrides{
+					"tfo": {Headers: map[string]string{"X Tenant": "acme"}},
+				},
+			},
+			wantErr: `exporters::tfo: invalid header name "X Tenant"`,
+		},
+		{
+			name: "header value with line break",
+			overrides: tfooverridesextension.Overrides{
+				Exporters: map[string]tfooverridesextension.ExporterOverrides{
+					"tfo": {Headers: map[string]string{"X-Tenant": "acme\r\nX-Other: 1"}},
+				},
+			},
+			wantErr: "must not contain line breaks",
+		},
+		{
+			name: "redacted secret sent back",
+			overrides: tfooverridesextension.Overrides{
+				Exporters: map[string]tfooverridesextension.ExporterOverrides{
+					"tfo": {APIKeySecret: "[REDACTED]"},
+				},
+			},
+			wantErr: "placeholder",
+		},
+		{
+			name: "zero rate",
+			overrides: tfooverridesextension.Overrides{
+				Receivers: map[string]tfooverridesextension.ReceiverOverrides{
+					"tfootlp": {RateLimit: &tfooverridesextension.RateLimitOverrides{}},
+				},
+			},
+			wantErr: "rate_limit.records_per_second must be positive",
+		},
+		{
+			name: "sampling percentage below 0",
+			overrides: tfooverridesextension.Overrides{
+				Receivers: map[string]tfooverridesextension.ReceiverOverrides{
+					"tfootlp": {Sampling: &tfooverridesextension.SamplingOverrides{Logs: percentage(-1)}},
+				},
+			},
+			wantErr: "receivers::tfootlp: sampling.logs must be between 0 and 100",
+		},
+		{
+			name: "percentage above 100",
+			overrides: tfooverridesextension.Overrides{
+				Connectors: map[string]tfooverridesextension.ConnectorOverrides{
+					"tfomirror": {Percentage: percentage(150)},
+				},
+			},
+			wantErr: "percentage must be between 0 and 100",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.overrides.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,234 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfooverridesextension_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.uber.org/zap/zapcore"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/extension/tfooverridesextension"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/loglevel"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+)
+
+// overridesProvider mirrors the OverridesProvider interfaces of
+// tfoexporter, tfootlpreceiver and tfomirrorconnector.
+type overridesProvider interface {
+	ExporterHeaders(id component.ID) map[string]string
+	ExporterCredentials(id component.ID) (keyID, keySecret string)
+	ReceiverRateLimit(id component.ID) (recordsPerSecond float64, burst int, ok bool)
+	ReceiverSampling(id component.ID, signal pipeline.Signal) (percentage float64, ok bool)
+	ConnectorPercentage(id component.ID) (percentage float64, ok bool)
+}
+
+func freeEndpoint(t *testing.T) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = l.Close() }()
+	return l.Addr().String()
+}
+
+func startExtension(t *testing.T, cfg *tfooverridesextension.Config) (component.Component, overridesProvider) {
+	t.Helper()
+	factory := tfooverridesextension.NewFactory()
+	ext, err := factory.Create(context.Background(), extensiontest.NewNopSettings(component.MustNewType("tfooverrides")), cfg)
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { _ = ext.Shutdown(context.Background()) })
+
+	provider, ok := ext.(overridesProvider)
+	require.True(t, ok, "extension must provide runtime overrides")
+	return ext, provider
+}
+
+func admin(t *testing.T, method, endpoint, body string) (int, string) {
+	t.Helper()
+	req, err := http.NewRequest(method, "http://"+endpoint+"/overrides", strings.NewReader(body))
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	b, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp.StatusCode, string(b)
+}
+
+func TestNewFactory(t *testing.T) {
+	factory := tfooverridesextension.NewFactory()
+	assert.Equal(t, component.MustNewType("tfooverrides"), factory.Type())
+
+	cfg := factory.CreateDefaultConfig().(*tfooverridesextension.Config)
+	assert.Equal(t, tfooverridesextension.DefaultEndpoint, cfg.NetAddr.Endpoint)
+	assert.Empty(t, cfg.File)
+}
+
+func TestExtension_PatchAppliesAndPersists(t *testing.T) {
+	endpoint := freeEndpoint(t)
+	file := filepath.Join(t.TempDir(), "overrides.json")
+	_, provider := startExtension(t, &tfooverridesextension.Config{AdminConfig: serverconf.NewDefaultAdminConfig(endpoint), File: file})
+
+	tfo := component.MustNewID("tfo")
+	otlp := component.MustNewID("tfootlp")
+	mirror := component.MustNewID("tfomirror")
+	assert.Nil(t, provider.ExporterHeaders(tfo))
+	_, _, ok := provider.ReceiverRateLimit(otlp)
+	assert.False(t, ok)
+
+	code, body := admin(t, http.MethodPatch, endpoint, `{
+		"exporters": {"tfo": {"headers": {"X-Tenant": "acme"}, "api_key_id": "tfk_new", "api_key_secret": "tfs_new"}},
+		"receivers": {"tfootlp": {"rate_limit": {"records_per_second": 500, "burst": 1000}, "sampling": {"traces": 10}}},
+		"connectors": {"tfomirror": {"percentage": 0}}
+	}`)
+	require.Equal(t, http.StatusOK, code, body)
+	assert.NotContains(t, body, "tfs_new", "secrets are redacted")
+	assert.NotContains(t, body, "acme", "header values are redacted")
+
+	assert.Equal(t, map[string]string{"X-Tenant": "acme"}, provider.ExporterHeaders(tfo))
+	keyID, keySecret := provider.ExporterCredentials(tfo)
+	assert.Equal(t, "tfk_new", keyID)
+	assert.Equal(t, "tfs_new", keySecret)
+	rps, burst, ok := provider.ReceiverRateLimit(otlp)
+	assert.True(t, ok)
+	assert.Equal(t, 500.0, rps)
+	assert.Equal(t, 1000, burst)
+	p, ok := provider.ReceiverSampling(otlp, pipeline.SignalTraces)
+	assert.True(t, ok)
+	assert.Equal(t, 10.0, p)
+	_, ok = provider.ReceiverSampling(otlp, pipeline.SignalLogs)
+	assert.False(t, ok, "logs keep the configured percentage")
+	p, ok = provider.ConnectorPercentage(mirror)
+	assert.True(t, ok)
+	assert.Equal(t, 0.0, p)
+
+	// A second patch merges into the first and null removes a member.
+	code, body = admin(t, http.MethodPatch, endpoint, `{
+		"exporters": {"tfo": {"headers": {"X-Region": "eu"}}},
+		"receivers": {"tfootlp": null}
+	}`)
+	require.Equal(t, http.StatusOK, code, body)
+	assert.Equal(t, map[string]string{"X-Tenant": "acme", "X-Region": "eu"}, provider.ExporterHeaders(tfo))
+	keyID, _ = provider.ExporterCredentials(tfo)
+	assert.Equal(t, "tfk_new", keyID)
+	_, _, ok = provider.ReceiverRateLimit(otlp)
+	assert.False(t, ok)
+	_, ok = provider.ReceiverSampling(otlp, pipeline.SignalTraces)
+	assert.False(t, ok)
+
+	info, err := os.Stat(file)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	data, err := os.ReadFile(file)
+	require.NoError(t, err)
+	var persisted tfooverridesextension.Overrides
+	require.NoError(t, json.Unmarshal(data, &persisted))
+	assert.Equal(t, "tfs_new", persisted.Exporters["tfo"].APIKeySecret)
+	assert.Empty(t, persisted.Receivers)
+
+	// A restarted extension applies the file again.
+	_, restarted := startExtension(t, &tfooverridesextension.Config{AdminConfig: serverconf.NewDefaultAdminConfig(freeEndpoint(t)), File: file})
+	assert.Equal(t, map[string]string{"X-Tenant": "acme", "X-Region": "eu"}, restarted.ExporterHeaders(tfo))
+}
+
+func TestExtension_InvalidChangeLeavesOverridesUntouched(t *testing.T) {
+	endpoint := freeEndpoint(t)
+	_, provider := startExtension(t, &tfooverridesextension.Config{AdminConfig: serverconf.NewDefaultAdminConfig(endpoint)})
+	otlp := component.MustNewID("tfootlp")
+
+	code, _ := admin(t, http.MethodPut, endpoint, `{"receivers": {"tfootlp": {"rate_limit": {"records_per_second": 10}}}}`)
+	require.Equal(t, http.StatusOK, code)
+
+	tests := []struct {
+		name    string
+		method  string
+		body    string
+		wantErr string
+	}{
+		{name: "listener setting", method: http.MethodPatch, body: `{"receivers": {"tfootlp": {"endpoint": "0.0.0.0:4317"}}}`, wantErr: "unknown field"},
+		{name: "one invalid member", method: http.MethodPatch, body: `{"log_level": "debug", "receivers": {"tfootlp": {"rate_limit": {"records_per_second": -1}}}}`, wantErr: "must be positive"},
+		{name: "not an object", method: http.MethodPatch, body: `[]`, wantErr: "merge patch must be a JSON object"},
+		{name: "malformed", method: http.MethodPut, body: `{"log_level":`, wantErr: "invalid overrides"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, body := admin(t, tt.method, endpoint, tt.body)
+			assert.Equal(t, http.StatusBadRequest, code)
+			assert.Contains(t, body, tt.wantErr)
+
+			rps, _, ok := provider.ReceiverRateLimit(otlp)
+			assert.True(t, ok)
+			assert.Equal(t, 10.0, rps)
+			_, set := loglevel.Get()
+			assert.False(t, set)
+		})
+	}
+
+	code, _ = admin(t, http.MethodPost, endpoint, `{}`)
+	assert.Equal(t, http.StatusMethodNotAllowed, code)
+}
+
+func TestExtension_LogLevel(t *testing.T) {
+	endpoint := freeEndpoint(t)
+	ext, _ := startExtension(t, &tfooverridesextension.Config{AdminConfig: serverconf.NewDefaultAdminConfig(endpoint)})
+
+	code, _ := admin(t, http.MethodPatch, endpoint, `{"log_level": "debug"}`)
+	require.Equal(t, http.StatusOK, code)
+	level, ok := loglevel.Get()
+	assert.True(t, ok)
+	assert.Equal(t, zapcore.DebugLevel, level)
+
+	code, body := admin(t, http.MethodGet, endpoint, "")
+	require.Equal(t, http.StatusOK, code)
+	assert.JSONEq(t, `{"log_level": "debug"}`, body)
+
+	code, _ = admin(t, http.MethodDelete, endpoint, "")
+	require.Equal(t, http.StatusOK, code)
+	_, ok = loglevel.Get()
+	assert.False(t, ok, "removing the override restores the configured level")
+
+	code, _ = admin(t, http.MethodPatch, endpoint, `{"log_level": "warn"}`)
+	require.Equal(t, http.StatusOK, code)
+	require.NoError(t, ext.Shutdown(context.Background()))
+	_, ok = loglevel.Get()
+	assert.False(t, ok, "shutdown restores the configured level")
+}
+
+func TestExtension_InvalidFileFailsStart(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "overrides.json")
+	require.NoError(t, os.WriteFile(file, []byte(`{"log_level": "loud"}`), 0o600))
+
+	factory := tfooverridesextension.NewFactory()
+	cfg := &tfooverridesextension.Config{AdminConfig: serverconf.NewDefaultAdminConfig(freeEndpoint(t)), File: file}
+	ext, err := factory.Create(context.Background(), extensiontest.NewNopSettings(component.MustNewType("tfooverrides")), cfg)
+	require.NoError(t, err)
+	err = ext.Start(context.Background(), componenttest.NewNopHost())
+	assert.ErrorContains(t, err, "overrides file")
+	assert.ErrorContains(t, err, "log_level")
+}
@@ -0,0 +1,59 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoparquetextension_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/extension/tfoparquetextension"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     tfoparquetextension.Config
+		wantErr string
+	}{
+		{name: "zstd", cfg: tfoparquetextension.Config{Compression: "zstd", RowGroupSize: 10}},
+		{name: "snappy", cfg: tfoparquetextension.Config{Compression: "snappy", RowGroupSize: 10}},
+		{name: "gzip", cfg: tfoparquetextension.Config{Compression: "gzip", RowGroupSize: 10}},
+		{name: "none", cfg: tfoparquetextension.Config{Compression: "none", RowGroupSize: 10}},
+		{
+			name:    "unknown compression",
+			cfg:     tfoparquetextension.Config{Compression: "lzo", RowGroupSize: 10},
+			wantErr: "compression must be one of",
+		},
+		{
+			name:    "zero row group size",
+			cfg:     tfoparquetextension.Config{Compression: "zstd"},
+			wantErr: "row_group_size must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
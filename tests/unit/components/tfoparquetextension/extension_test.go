@@ -0,0 +1,216 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoparquetextension_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/extension/tfoparquetextension"
+)
+
+type parquetMarshaler interface {
+	ptrace.Marshaler
+	pmetric.Marshaler
+	plog.Marshaler
+}
+
+var testTime = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+func startExtension(t *testing.T, mutate func(cfg *tfoparquetextension.Config)) parquetMarshaler {
+	t.Helper()
+	factory := tfoparquetextension.NewFactory()
+	cfg := factory.CreateDefaultConfig().(*tfoparquetextension.Config)
+	if mutate != nil {
+		mutate(cfg)
+	}
+	require.NoError(t, cfg.Validate())
+	ext, err := factory.Create(context.Background(), extensiontest.NewNopSettings(component.MustNewType("tfoparquet")), cfg)
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { require.NoError(t, ext.Shutdown(context.Background())) })
+
+	m, ok := ext.(parquetMarshaler)
+	require.True(t, ok, "extension must implement the marshaler interfaces")
+	return m
+}
+
+func readRows[T any](t *testing.T, data []byte) []T {
+	t.Helper()
+	rows, err := parquet.Read[T](bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	return rows
+}
+
+func TestNewFactory(t *testing.T) {
+	factory := tfoparquetextension.NewFactory()
+	assert.Equal(t, component.MustNewType("tfoparquet"), factory.Type())
+	cfg := factory.CreateDefaultConfig().(*tfoparquetextension.Config)
+	assert.Equal(t, tfoparquetextension.CompressionZstd, cfg.Compression)
+	assert.Equal(t, int64(tfoparquetextension.DefaultRowGroupSize), cfg.RowGroupSize)
+}
+
+func TestMarshalTraces(t *testing.T) {
+	ext := startExtension(t, nil)
+
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "checkout")
+	ss := rs.ScopeSpans().AppendEmpty()
+	ss.Scope().SetName("otel-go")
+	span := ss.Spans().AppendEmpty()
+	span.SetName("GET /cart")
+	span.SetKind(ptrace.SpanKindServer)
+	span.SetTraceID(pcommon.TraceID{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	span.SetSpanID(pcommon.SpanID{1, 2, 3, 4, 5, 6, 7, 8})
+	span.SetStartTimestamp(pcommon.NewTimestampFromTime(testTime))
+	span.SetEndTimestamp(pcommon.NewTimestampFromTime(testTime.Add(250 * time.Millisecond)))
+	span.Status().SetCode(ptrace.StatusCodeError)
+	span.Attributes().PutInt("http.status_code", 500)
+	span.Events().AppendEmpty()
+
+	data, err := ext.MarshalTraces(td)
+	require.NoError(t, err)
+
+	rows := readRows[tfoparquetextension.SpanRow](t, data)
+	require.Len(t, rows, 1)
+	row := rows[0]
+	assert.Equal(t, "checkout", row.ServiceName)
+	assert.Equal(t, "GET /cart", row.Name)
+	assert.Equal(t, "Server", row.Kind)
+	assert.Equal(t, "0102030405060708090a0b0c0d0e0f10", row.TraceID)
+	assert.Equal(t, "0102030405060708", row.SpanID)
+	assert.Empty(t, row.ParentSpanID)
+	assert.Equal(t, int64(250*time.Millisecond), row.DurationNanos)
+	assert.True(t, testTime.Equal(row.StartTime))
+	assert.Equal(t, "Error", row.StatusCode)
+	assert.Equal(t, "500", row.Attributes["http.status_code"])
+	assert.Equal(t, "checkout", row.ResourceAttributes["service.name"])
+	assert.Equal(t, "otel-go", row.ScopeName)
+	assert.Equal(t, int32(1), row.EventsCount)
+}
+
+func TestMarshalLogs(t *testing.T) {
+	ext := startExtension(t, func(cfg *tfoparquetextension.Config) { cfg.Compression = tfoparquetextension.CompressionSnappy })
+
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "billing")
+	lr := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(testTime))
+	lr.SetSeverityNumber(plog.SeverityNumberWarn)
+	lr.SetSeverityText("WARN")
+	lr.Body().SetStr("payment retried")
+	lr.Attributes().PutBool("retry", true)
+
+	data, err := ext.MarshalLogs(ld)
+	require.NoError(t, err)
+
+	rows := readRows[tfoparquetextension.LogRow](t, data)
+	require.Len(t, rows, 1)
+	assert.Equal(t, "billing", rows[0].ServiceName)
+	assert.Equal(t, int32(plog.SeverityNumberWarn), rows[0].SeverityNumber)
+	assert.Equal(t, "payment retried", rows[0].Body)
+	assert.Equal(t, "true", rows[0].Attributes["retry"])
+	assert.True(t, testTime.Equal(rows[0].Timestamp))
+}
+
+func TestMarshalMetrics(t *testing.T) {
+	ext := startExtension(t, nil)
+
+	md := pmetric.NewMetrics()
+	sm := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty()
+
+	sum := sm.Metrics().AppendEmpty()
+	sum.SetName("requests")
+	sum.SetEmptySum().SetIsMonotonic(true)
+	sum.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	dp := sum.Sum().DataPoints().AppendEmpty()
+	dp.SetIntValue(42)
+	dp.Attributes().PutStr("route", "/cart")
+
+	gauge := sm.Metrics().AppendEmpty()
+	gauge.SetName("cpu")
+	gauge.SetEmptyGauge().DataPoints().AppendEmpty().SetDoubleValue(0.5)
+
+	hist := sm.Metrics().AppendEmpty()
+	hist.SetName("latency")
+	hdp := hist.SetEmptyHistogram().DataPoints().AppendEmpty()
+	hdp.SetCount(3)
+	hdp.SetSum(1.5)
+	hdp.SetMax(1)
+
+	data, err := ext.MarshalMetrics(md)
+	require.NoError(t, err)
+
+	rows := readRows[tfoparquetextension.MetricRow](t, data)
+	require.Len(t, rows, 3)
+
+	assert.Equal(t, "requests", rows[0].MetricName)
+	assert.Equal(t, "Sum", rows[0].Type)
+	require.NotNil(t, rows[0].ValueInt)
+	assert.Equal(t, int64(42), *rows[0].ValueInt)
+	assert.Nil(t, rows[0].ValueDouble)
+	assert.True(t, rows[0].IsMonotonic)
+	assert.Equal(t, "Cumulative", rows[0].Temporality)
+	assert.Equal(t, "/cart", rows[0].Attributes["route"])
+
+	require.NotNil(t, rows[1].ValueDouble)
+	assert.InDelta(t, 0.5, *rows[1].ValueDouble, 1e-9)
+
+	require.NotNil(t, rows[2].Count)
+	assert.Equal(t, int64(3), *rows[2].Count)
+	require.NotNil(t, rows[2].Sum)
+	assert.InDelta(t, 1.5, *rows[2].Sum, 1e-9)
+	assert.Nil(t, rows[2].Min)
+	require.NotNil(t, rows[2].Max)
+}
+
+func TestRowGroupSize(t *testing.T) {
+	ext := startExtension(t, func(cfg *tfoparquetextension.Config) { cfg.RowGroupSize = 2 })
+
+	ld := plog.NewLogs()
+	records := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for range 5 {
+		records.AppendEmpty().Body().SetStr("line")
+	}
+	data, err := ext.MarshalLogs(ld)
+	require.NoError(t, err)
+
+	file, err := parquet.OpenFile(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), file.NumRows())
+	assert.Len(t, file.RowGroups(), 3)
+}
+
+func TestMarshalEmptyBatch(t *testing.T) {
+	ext := startExtension(t, nil)
+	data, err := ext.MarshalTraces(ptrace.NewTraces())
+	require.NoError(t, err)
+	assert.Empty(t, readRows[tfoparquetextension.SpanRow](t, data))
+}
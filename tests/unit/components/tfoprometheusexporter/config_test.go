@@ -0,0 +1,144 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoprometheusexporter_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/xconfmap"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfoprometheusexporter"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	valid := func() *tfoprometheusexporter.Config {
+		cfg := tfoprometheusexporter.NewFactory().CreateDefaultConfig().(*tfoprometheusexporter.Config)
+		cfg.NetAddr.Endpoint = "0.0.0.0:8889"
+		cfg.Downsampling.Enabled = true
+		cfg.Downsampling.Endpoint = "0.0.0.0:8890"
+		return cfg
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(*tfoprometheusexporter.Config)
+		wantErr string
+	}{
+		{name: "valid", modify: func(*tfoprometheusexporter.Config) {}},
+		{
+			name: "disabled",
+			modify: func(cfg *tfoprometheusexporter.Config) {
+				cfg.Downsampling.Enabled = false
+				cfg.Downsampling.Endpoint = ""
+			},
+		},
+		{
+			name:    "missing endpoint",
+			modify:  func(cfg *tfoprometheusexporter.Config) { cfg.Downsampling.Endpoint = "" },
+			wantErr: "downsampling: endpoint is required",
+		},
+		{
+			name:    "same endpoint",
+			modify:  func(cfg *tfoprometheusexporter.Config) { cfg.Downsampling.Endpoint = cfg.NetAddr.Endpoint },
+			wantErr: `endpoint "0.0.0.0:8889" is also the exporter endpoint`,
+		},
+		{
+			name:    "interval",
+			modify:  func(cfg *tfoprometheusexporter.Config) { cfg.Downsampling.Interval = 0 },
+			wantErr: "downsampling: interval must be positive",
+		},
+		{
+			name:    "no aggregations",
+			modify:  func(cfg *tfoprometheusexporter.Config) { cfg.Downsampling.Aggregations = nil },
+			wantErr: "at least one aggregation",
+		},
+		{
+			name:    "unsupported aggregation",
+			modify:  func(cfg *tfoprometheusexporter.Config) { cfg.Downsampling.Aggregations = []string{"avg", "p99"} },
+			wantErr: `aggregations[1]: unsupported aggregation "p99"`,
+		},
+		{
+			name:    "duplicate aggregation",
+			modify:  func(cfg *tfoprometheusexporter.Config) { cfg.Downsampling.Aggregations = []string{"max", "max"} },
+			wantErr: `aggregations[1]: duplicate aggregation "max"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid()
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestConfig_Unmarshal(t *testing.T) {
+	cfg := tfoprometheusexporter.NewFactory().CreateDefaultConfig().(*tfoprometheusexporter.Config)
+	require.NoError(t, confmap.NewFromStringMap(map[string]any{
+		"endpoint":  "0.0.0.0:8889",
+		"namespace": "edge",
+		"downsampling": map[string]any{
+			"enabled":      true,
+			"endpoint":     "0.0.0.0:8890",
+			"interval":     "1m",
+			"aggregations": []any{"min", "max"},
+		},
+	}).Unmarshal(cfg))
+
+	assert.Equal(t, "0.0.0.0:8889", cfg.NetAddr.Endpoint)
+	assert.Equal(t, "edge", cfg.Namespace)
+	assert.True(t, cfg.Downsampling.Enabled)
+	assert.Equal(t, "0.0.0.0:8890", cfg.Downsampling.Endpoint)
+	assert.Equal(t, time.Minute, cfg.Downsampling.Interval)
+	assert.Equal(t, []string{"min", "max"}, cfg.Downsampling.Aggregations)
+	assert.NoError(t, xconfmap.Validate(cfg))
+}
+
+func TestConfig_ValidatesUpstreamSettings(t *testing.T) {
+	cfg := tfoprometheusexporter.NewFactory().CreateDefaultConfig().(*tfoprometheusexporter.Config)
+	require.NoError(t, confmap.NewFromStringMap(map[string]any{
+		"translation_strategy": "Bogus",
+	}).Unmarshal(cfg))
+
+	err := xconfmap.Validate(cfg)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid translation_strategy")
+}
+
+func TestFactory_DefaultConfig(t *testing.T) {
+	factory := tfoprometheusexporter.NewFactory()
+	assert.Equal(t, component.MustNewType("prometheus"), factory.Type())
+	assert.Equal(t, component.StabilityLevelBeta, factory.MetricsStability())
+
+	cfg := factory.CreateDefaultConfig().(*tfoprometheusexporter.Config)
+	assert.Equal(t, 5*time.Minute, cfg.MetricExpiration)
+	assert.True(t, cfg.AddMetricSuffixes)
+	assert.False(t, cfg.Downsampling.Enabled)
+	assert.Equal(t, 5*time.Minute, cfg.Downsampling.Interval)
+	assert.Equal(t, []string{"avg", "max"}, cfg.Downsampling.Aggregations)
+	assert.NoError(t, xconfmap.Validate(cfg))
+}
@@ -0,0 +1,161 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoprometheusexporter_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfoprometheusexporter"
+)
+
+func freeAddr(t *testing.T) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = l.Close() }()
+	return l.Addr().String()
+}
+
+func scrape(t *testing.T, addr string) string {
+	t.Helper()
+	resp, err := http.Get("http://" + addr + "/metrics")
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+// hasSample reports whether the exposition contains a sample of name with
+// value, whatever its labels.
+func hasSample(exposition, name, value string) bool {
+	return regexp.MustCompile(`(?m)^` + regexp.QuoteMeta(name) + `(\{[^}]*\})? ` + regexp.QuoteMeta(value) + `$`).MatchString(exposition)
+}
+
+func startExporter(t *testing.T, cfg *tfoprometheusexporter.Config) exporter.Metrics {
+	t.Helper()
+	factory := tfoprometheusexporter.NewFactory()
+	exp, err := factory.CreateMetrics(context.Background(), exportertest.NewNopSettings(factory.Type()), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, exp.Shutdown(context.Background())) })
+	return exp
+}
+
+// testMetrics returns a gauge, a counter and a histogram, each with two
+// points of the same series.
+func testMetrics() pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	ms := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+	now := time.Now()
+
+	gauge := ms.AppendEmpty()
+	gauge.SetName("queue_depth")
+	gauge.SetEmptyGauge()
+	for i, v := range []float64{1, 3} {
+		dp := gauge.Gauge().DataPoints().AppendEmpty()
+		dp.Attributes().PutStr("queue", "ingest")
+		dp.SetTimestamp(pcommon.NewTimestampFromTime(now.Add(time.Duration(i) * time.Second)))
+		dp.SetDoubleValue(v)
+	}
+
+	counter := ms.AppendEmpty()
+	counter.SetName("requests")
+	sum := counter.SetEmptySum()
+	sum.SetIsMonotonic(true)
+	sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	for i, v := range []int64{5, 8} {
+		dp := sum.DataPoints().AppendEmpty()
+		dp.SetStartTimestamp(pcommon.NewTimestampFromTime(now.Add(-time.Minute)))
+		dp.SetTimestamp(pcommon.NewTimestampFromTime(now.Add(time.Duration(i) * time.Second)))
+		dp.SetIntValue(v)
+	}
+
+	histogram := ms.AppendEmpty()
+	histogram.SetName("latency")
+	hist := histogram.SetEmptyHistogram()
+	hist.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	dp := hist.DataPoints().AppendEmpty()
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(now))
+	dp.SetCount(1)
+	dp.SetSum(0.5)
+	dp.ExplicitBounds().FromRaw([]float64{1})
+	dp.BucketCounts().FromRaw([]uint64{1, 0})
+	return md
+}
+
+func TestExporter_Downsampling(t *testing.T) {
+	cfg := tfoprometheusexporter.NewFactory().CreateDefaultConfig().(*tfoprometheusexporter.Config)
+	cfg.NetAddr.Endpoint = freeAddr(t)
+	cfg.Downsampling.Enabled = true
+	cfg.Downsampling.Endpoint = freeAddr(t)
+	cfg.Downsampling.Interval = 100 * time.Millisecond
+	cfg.Downsampling.Aggregations = []string{"avg", "min", "max"}
+	exp := startExporter(t, cfg)
+
+	require.NoError(t, exp.ConsumeMetrics(context.Background(), testMetrics()))
+
+	var summary string
+	require.Eventually(t, func() bool {
+		summary = scrape(t, cfg.Downsampling.Endpoint)
+		return hasSample(summary, "queue_depth_avg", "2")
+	}, 5*time.Second, 20*time.Millisecond)
+	assert.True(t, hasSample(summary, "queue_depth_min", "1"), summary)
+	assert.True(t, hasSample(summary, "queue_depth_max", "3"), summary)
+	assert.True(t, hasSample(summary, "requests_total", "8"), summary)
+	assert.Contains(t, summary, `queue="ingest"`)
+	assert.NotContains(t, summary, "latency")
+	assert.NotRegexp(t, `(?m)^queue_depth[{ ]`, summary, "gauges are only written aggregated")
+
+	full := scrape(t, cfg.NetAddr.Endpoint)
+	assert.True(t, hasSample(full, "queue_depth", "3"), full)
+	assert.Contains(t, full, "latency_bucket")
+	assert.NotContains(t, full, "queue_depth_avg")
+}
+
+func TestExporter_DownsamplingDisabled(t *testing.T) {
+	cfg := tfoprometheusexporter.NewFactory().CreateDefaultConfig().(*tfoprometheusexporter.Config)
+	cfg.NetAddr.Endpoint = freeAddr(t)
+	exp := startExporter(t, cfg)
+
+	require.NoError(t, exp.ConsumeMetrics(context.Background(), testMetrics()))
+	assert.True(t, hasSample(scrape(t, cfg.NetAddr.Endpoint), "queue_depth", "3"))
+}
+
+func TestExporter_ShutdownWithoutStart(t *testing.T) {
+	cfg := tfoprometheusexporter.NewFactory().CreateDefaultConfig().(*tfoprometheusexporter.Config)
+	cfg.NetAddr.Endpoint = freeAddr(t)
+	cfg.Downsampling.Enabled = true
+	cfg.Downsampling.Endpoint = freeAddr(t)
+
+	factory := tfoprometheusexporter.NewFactory()
+	exp, err := factory.CreateMetrics(context.Background(), exportertest.NewNopSettings(factory.Type()), cfg)
+	require.NoError(t, err)
+	assert.NoError(t, exp.Shutdown(context.Background()))
+}
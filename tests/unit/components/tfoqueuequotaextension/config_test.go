@@ -0,0 +1,101 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoqueuequotaextension_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/extension/tfoqueuequotaextension"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/bytesize"
+)
+
+func TestNewFactory(t *testing.T) {
+	factory := tfoqueuequotaextension.NewFactory()
+	assert.Equal(t, component.MustNewType("tfoqueuequota"), factory.Type())
+
+	cfg := factory.CreateDefaultConfig().(*tfoqueuequotaextension.Config)
+	assert.Equal(t, "tfo.provenance.origin.tenant", cfg.TenantAttribute)
+	assert.Equal(t, tfoqueuequotaextension.EvictionOldest, cfg.Eviction)
+	assert.Zero(t, cfg.DefaultQuota)
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *tfoqueuequotaextension.Config)
+		wantErr string
+	}{
+		{name: "valid", mutate: func(*tfoqueuequotaextension.Config) {}},
+		{
+			name: "reject with quotas",
+			mutate: func(cfg *tfoqueuequotaextension.Config) {
+				cfg.Eviction = tfoqueuequotaextension.EvictionReject
+				cfg.Quotas = map[string]bytesize.Size{"acme": bytesize.GiB, "free": 0}
+			},
+		},
+		{
+			name:    "no storage",
+			mutate:  func(cfg *tfoqueuequotaextension.Config) { cfg.Storage = component.ID{} },
+			wantErr: "storage is required",
+		},
+		{
+			name:    "empty tenant attribute",
+			mutate:  func(cfg *tfoqueuequotaextension.Config) { cfg.TenantAttribute = "" },
+			wantErr: "tenant_attribute",
+		},
+		{
+			name:    "negative default quota",
+			mutate:  func(cfg *tfoqueuequotaextension.Config) { cfg.DefaultQuota = -1 },
+			wantErr: "default_quota",
+		},
+		{
+			name:    "negative other quota",
+			mutate:  func(cfg *tfoqueuequotaextension.Config) { cfg.OtherQuota = -1 },
+			wantErr: "other_quota must not be negative",
+		},
+		{
+			name: "negative tenant quota",
+			mutate: func(cfg *tfoqueuequotaextension.Config) {
+				cfg.Quotas = map[string]bytesize.Size{"acme": -1}
+			},
+			wantErr: "quotas[acme]",
+		},
+		{
+			name:    "unknown eviction",
+			mutate:  func(cfg *tfoqueuequotaextension.Config) { cfg.Eviction = "newest" },
+			wantErr: "eviction must be",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tfoqueuequotaextension.NewFactory().CreateDefaultConfig().(*tfoqueuequotaextension.Config)
+			cfg.Storage = component.MustNewID("file_storage")
+			cfg.DefaultQuota = 256 * bytesize.MiB
+			tt.mutate(cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,336 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfoqueuequotaextension_test
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/storage/filestorage"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/extension/tfoqueuequotaextension"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/bytesize"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+const tenantAttr = "tfo.provenance.origin.tenant"
+
+var (
+	storageID = component.MustNewID("file_storage")
+	quotaID   = component.MustNewID("tfoqueuequota")
+)
+
+// usageProvider exposes the per-tenant usage of the extension.
+type usageProvider interface {
+	Usage() []tfoqueuequotaextension.TenantUsage
+}
+
+// extensionsHost is a component.Host that returns a populated extensions map.
+type extensionsHost struct {
+	component.Host
+	exts map[component.ID]component.Component
+}
+
+func (h *extensionsHost) GetExtensions() map[component.ID]component.Component {
+	return h.exts
+}
+
+// tenantBatch builds a batch of 100 spans named name for a tenant.
+func tenantBatch(tenant, name string) ptrace.Traces {
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr(tenantAttr, tenant)
+	spans := rs.ScopeSpans().AppendEmpty().Spans()
+	for range 100 {
+		spans.AppendEmpty().SetName(name)
+	}
+	return td
+}
+
+// batchSize is the encoded size of a tenant batch, without the request
+// context the queue may add.
+var batchSize = int64((&ptrace.ProtoMarshaler{}).TracesSize(tenantBatch("acme", "a1")))
+
+// pusher records the batches it exports. Every push waits for release.
+type pusher struct {
+	release chan struct{}
+	started chan struct{}
+
+	mu     sync.Mutex
+	pushed []string
+}
+
+func newPusher() *pusher {
+	return &pusher{release: make(chan struct{}), started: make(chan struct{}, 100)}
+}
+
+func (p *pusher) push(_ context.Context, td ptrace.Traces) error {
+	p.started <- struct{}{}
+	<-p.release
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	name := "<empty>"
+	if td.SpanCount() > 0 {
+		name = td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name()
+	}
+	p.pushed = append(p.pushed, name)
+	return nil
+}
+
+func (p *pusher) names() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.pushed...)
+}
+
+// startQuota starts file_storage in dir and the quota extension on top of
+// it, and returns the host holding both.
+func startQuota(t *testing.T, dir string, cfg *tfoqueuequotaextension.Config, set component.TelemetrySettings) (*extensionsHost, usageProvider) {
+	t.Helper()
+	fsFactory := filestorage.NewFactory()
+	fsCfg := fsFactory.CreateDefaultConfig().(*filestorage.Config)
+	fsCfg.Directory = dir
+	fs, err := fsFactory.Create(context.Background(), extensiontest.NewNopSettings(fsFactory.Type()), fsCfg)
+	require.NoError(t, err)
+	require.NoError(t, fs.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { _ = fs.Shutdown(context.Background()) })
+
+	cfg.Storage = storageID
+	require.NoError(t, cfg.Validate())
+	factory := tfoqueuequotaextension.NewFactory()
+	extSet := extensiontest.NewNopSettings(factory.Type())
+	extSet.TelemetrySettings = set
+	ext, err := factory.Create(context.Background(), extSet, cfg)
+	require.NoError(t, err)
+	host := &extensionsHost{Host: componenttest.NewNopHost(), exts: map[component.ID]component.Component{storageID: fs, quotaID: ext}}
+	require.NoError(t, ext.Start(context.Background(), host))
+	t.Cleanup(func() { _ = ext.Shutdown(context.Background()) })
+
+	provider, ok := ext.(usageProvider)
+	require.True(t, ok, "extension must report usage")
+	return host, provider
+}
+
+// startExporter starts a traces exporter with a single consumer whose
+// persistent queue uses the quota extension.
+func startExporter(t *testing.T, host component.Host, p *pusher) exporter.Traces {
+	t.Helper()
+	qCfg := exporterhelper.NewDefaultQueueConfig()
+	qCfg.NumConsumers = 1
+	id := quotaID
+	qCfg.StorageID = &id
+	set := exportertest.NewNopSettings(component.MustNewType("tfo"))
+	// The queue is stored under the exporter ID; keep it across restarts.
+	set.ID = component.MustNewID("tfo")
+	exp, err := exporterhelper.NewTraces(context.Background(), set, &struct{}{}, p.push,
+		exporterhelper.WithQueue(configoptional.Some(qCfg)))
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), host))
+	return exp
+}
+
+func usageOf(usage []tfoqueuequotaextension.TenantUsage) map[string]int {
+	batches := map[string]int{}
+	for _, u := range usage {
+		batches[u.Tenant] = u.Batches
+	}
+	return batches
+}
+
+func TestExtension_EvictsOldestWithinTenant(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { _ = tel.Shutdown(context.Background()) })
+	cfg := tfoqueuequotaextension.NewFactory().CreateDefaultConfig().(*tfoqueuequotaextension.Config)
+	cfg.DefaultQuota = bytesize.Size(batchSize * 5 / 2)
+	cfg.Quotas = map[string]bytesize.Size{"acme": cfg.DefaultQuota}
+	host, quota := startQuota(t, t.TempDir(), cfg, tel.NewTelemetrySettings())
+
+	p := newPusher()
+	exp := startExporter(t, host, p)
+	ctx := context.Background()
+
+	// a1 is being exported; a2 and a3 wait in the queue.
+	require.NoError(t, exp.ConsumeTraces(ctx, tenantBatch("acme", "a1")))
+	<-p.started
+	require.NoError(t, exp.ConsumeTraces(ctx, tenantBatch("acme", "a2")))
+	// a3 takes acme over its quota: a2, the oldest batch not being
+	// exported, makes room.
+	require.NoError(t, exp.ConsumeTraces(ctx, tenantBatch("acme", "a3")))
+	// beta has a quota of its own.
+	require.NoError(t, exp.ConsumeTraces(ctx, tenantBatch("beta", "b1")))
+	require.NoError(t, exp.ConsumeTraces(ctx, tenantBatch("beta", "b2")))
+
+	assert.Equal(t, map[string]int{"acme": 2, "beta": 2}, usageOf(quota.Usage()))
+
+	m, err := tel.GetMetric(selfmetrics.QueueTenantBytes)
+	require.NoError(t, err)
+	var labels []string
+	for _, dp := range m.Data.(metricdata.Gauge[int64]).DataPoints {
+		assert.Greater(t, dp.Value, 2*batchSize)
+		assert.LessOrEqual(t, dp.Value, batchSize*5/2)
+		tenant, _ := dp.Attributes.Value(attribute.Key("tenant"))
+		labels = append(labels, tenant.AsString())
+	}
+	assert.ElementsMatch(t, []string{"acme", "other"}, labels, "tenants not listed in quotas are not named")
+	m, err = tel.GetMetric(selfmetrics.QueueTenantDropped)
+	require.NoError(t, err)
+	dps := m.Data.(metricdata.Sum[int64]).DataPoints
+	require.Len(t, dps, 1)
+	tenant, _ := dps[0].Attributes.Value(attribute.Key("tenant"))
+	reason, _ := dps[0].Attributes.Value(attribute.Key("reason"))
+	assert.Equal(t, "acme", tenant.AsString())
+	assert.Equal(t, "evicted", reason.AsString())
+	assert.Equal(t, int64(1), dps[0].Value)
+
+	close(p.release)
+	assert.Eventually(t, func() bool { return len(p.names()) == 4 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, exp.Shutdown(ctx))
+	// The evicted batch is skipped, not exported empty.
+	assert.Equal(t, []string{"a1", "a3", "b1", "b2"}, p.names())
+	assert.Empty(t, quota.Usage(), "tenants are forgotten once nothing of them is queued")
+}
+
+func TestExtension_RejectsOverQuota(t *testing.T) {
+	cfg := tfoqueuequotaextension.NewFactory().CreateDefaultConfig().(*tfoqueuequotaextension.Config)
+	cfg.Eviction = tfoqueuequotaextension.EvictionReject
+	cfg.Quotas = map[string]bytesize.Size{"acme": bytesize.Size(batchSize * 5 / 2)}
+	host, quota := startQuota(t, t.TempDir(), cfg, componenttest.NewNopTelemetrySettings())
+
+	p := newPusher()
+	exp := startExporter(t, host, p)
+	ctx := context.Background()
+
+	require.NoError(t, exp.ConsumeTraces(ctx, tenantBatch("acme", "a1")))
+	<-p.started
+	require.NoError(t, exp.ConsumeTraces(ctx, tenantBatch("acme", "a2")))
+	err := exp.ConsumeTraces(ctx, tenantBatch("acme", "a3"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `tenant "acme" is over its queue quota`)
+	// Tenants without a quota entry fall back to the unbounded default.
+	for i := range 3 {
+		require.NoError(t, exp.ConsumeTraces(ctx, tenantBatch("beta", fmt.Sprintf("b%d", i+1))))
+	}
+	assert.Equal(t, map[string]int{"acme": 2, "beta": 3}, usageOf(quota.Usage()))
+
+	close(p.release)
+	assert.Eventually(t, func() bool { return len(p.names()) == 5 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, exp.Shutdown(ctx))
+	assert.Equal(t, []string{"a1", "a2", "b1", "b2", "b3"}, p.names())
+}
+
+func TestExtension_OtherQuotaBoundsUnlistedTenants(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { _ = tel.Shutdown(context.Background()) })
+	cfg := tfoqueuequotaextension.NewFactory().CreateDefaultConfig().(*tfoqueuequotaextension.Config)
+	cfg.DefaultQuota = bytesize.Size(batchSize * 5 / 2)
+	cfg.OtherQuota = bytesize.Size(batchSize * 5 / 2)
+	host, quota := startQuota(t, t.TempDir(), cfg, tel.NewTelemetrySettings())
+
+	p := newPusher()
+	exp := startExporter(t, host, p)
+	ctx := context.Background()
+
+	// Every batch names a new tenant, each within its default_quota.
+	require.NoError(t, exp.ConsumeTraces(ctx, tenantBatch("t1", "1")))
+	<-p.started
+	for i := 2; i <= 5; i++ {
+		require.NoError(t, exp.ConsumeTraces(ctx, tenantBatch(fmt.Sprintf("t%d", i), strconv.Itoa(i))))
+	}
+	// The oldest batches not being exported made room, whatever their
+	// tenant.
+	assert.Equal(t, map[string]int{"t1": 1, "t5": 1}, usageOf(quota.Usage()))
+
+	m, err := tel.GetMetric(selfmetrics.QueueTenantDropped)
+	require.NoError(t, err)
+	dps := m.Data.(metricdata.Sum[int64]).DataPoints
+	require.Len(t, dps, 1)
+	tenant, _ := dps[0].Attributes.Value(attribute.Key("tenant"))
+	assert.Equal(t, "other", tenant.AsString())
+	assert.Equal(t, int64(3), dps[0].Value)
+
+	close(p.release)
+	assert.Eventually(t, func() bool { return len(p.names()) == 2 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, exp.Shutdown(ctx))
+	assert.Equal(t, []string{"1", "5"}, p.names())
+	assert.Empty(t, quota.Usage())
+}
+
+func TestExtension_UnknownTenant(t *testing.T) {
+	cfg := tfoqueuequotaextension.NewFactory().CreateDefaultConfig().(*tfoqueuequotaextension.Config)
+	host, quota := startQuota(t, t.TempDir(), cfg, componenttest.NewNopTelemetrySettings())
+
+	p := newPusher()
+	exp := startExporter(t, host, p)
+	td := tenantBatch("acme", "u1")
+	td.ResourceSpans().At(0).Resource().Attributes().Remove(tenantAttr)
+	require.NoError(t, exp.ConsumeTraces(context.Background(), td))
+	<-p.started
+
+	assert.Equal(t, map[string]int{"unknown": 1}, usageOf(quota.Usage()))
+	close(p.release)
+	require.NoError(t, exp.Shutdown(context.Background()))
+}
+
+func TestExtension_RecoversUsageAtStart(t *testing.T) {
+	dir := t.TempDir()
+	cfg := tfoqueuequotaextension.NewFactory().CreateDefaultConfig().(*tfoqueuequotaextension.Config)
+	host, quota := startQuota(t, dir, cfg, componenttest.NewNopTelemetrySettings())
+
+	p := newPusher()
+	exp := startExporter(t, host, p)
+	ctx := context.Background()
+	require.NoError(t, exp.ConsumeTraces(ctx, tenantBatch("acme", "a1")))
+	<-p.started
+	require.NoError(t, exp.ConsumeTraces(ctx, tenantBatch("acme", "a2")))
+	require.NoError(t, exp.ConsumeTraces(ctx, tenantBatch("beta", "b1")))
+
+	// Stop the queue while a1 is exported; a2 and b1 stay on disk.
+	done := make(chan error, 1)
+	go func() { done <- exp.Shutdown(ctx) }()
+	time.Sleep(100 * time.Millisecond)
+	close(p.release)
+	require.NoError(t, <-done)
+	assert.Empty(t, quota.Usage())
+
+	cfg = tfoqueuequotaextension.NewFactory().CreateDefaultConfig().(*tfoqueuequotaextension.Config)
+	host, quota = startQuota(t, dir, cfg, componenttest.NewNopTelemetrySettings())
+	p = newPusher()
+	exp = startExporter(t, host, p)
+	<-p.started
+
+	usage := quota.Usage()
+	assert.Equal(t, map[string]int{"acme": 1, "beta": 1}, usageOf(usage))
+	for _, u := range usage {
+		assert.Greater(t, u.Bytes, batchSize-1)
+	}
+	close(p.release)
+	require.NoError(t, exp.Shutdown(ctx))
+}
@@ -0,0 +1,147 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tforetentionexporter_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tforetentionexporter"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/bytesize"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	valid := func() tforetentionexporter.Config {
+		return tforetentionexporter.Config{
+			Directory:   "/tmp/retention",
+			MaxSize:     64 * bytesize.MiB,
+			SegmentSize: 8 * bytesize.MiB,
+			Query: tforetentionexporter.QueryConfig{
+				Enabled:     true,
+				AdminConfig: serverconf.NewDefaultAdminConfig("localhost:55691"),
+				MaxItems:    100,
+			},
+		}
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(*tforetentionexporter.Config)
+		wantErr string
+	}{
+		{name: "valid", mutate: func(*tforetentionexporter.Config) {}},
+		{
+			name:    "missing directory",
+			mutate:  func(c *tforetentionexporter.Config) { c.Directory = "" },
+			wantErr: "directory is required",
+		},
+		{
+			name:    "zero max size",
+			mutate:  func(c *tforetentionexporter.Config) { c.MaxSize = 0 },
+			wantErr: "max_size must be positive",
+		},
+		{
+			name:    "zero segment size",
+			mutate:  func(c *tforetentionexporter.Config) { c.SegmentSize = 0 },
+			wantErr: "segment_size must be positive",
+		},
+		{
+			name:    "segment larger than ring",
+			mutate:  func(c *tforetentionexporter.Config) { c.SegmentSize = 128 * bytesize.MiB },
+			wantErr: "segment_size must not exceed max_size",
+		},
+		{
+			name: "deprecated mib sizes",
+			mutate: func(c *tforetentionexporter.Config) {
+				c.MaxSize, c.SegmentSize = 0, 0
+				c.MaxSizeMiB, c.SegmentSizeMiB = 64, 8
+			},
+		},
+		{
+			name:    "deprecated mib size overrides max_size",
+			mutate:  func(c *tforetentionexporter.Config) { c.MaxSizeMiB = 4 },
+			wantErr: "segment_size must not exceed max_size",
+		},
+		{
+			name:    "negative deprecated size",
+			mutate:  func(c *tforetentionexporter.Config) { c.SegmentSizeMiB = -1 },
+			wantErr: "must not be negative",
+		},
+		{
+			name:    "negative max age",
+			mutate:  func(c *tforetentionexporter.Config) { c.MaxAge = -time.Hour },
+			wantErr: "max_age",
+		},
+		{
+			name:    "query without endpoint",
+			mutate:  func(c *tforetentionexporter.Config) { c.Query.NetAddr.Endpoint = "" },
+			wantErr: "query: endpoint",
+		},
+		{
+			name:    "query off localhost without client authentication",
+			mutate:  func(c *tforetentionexporter.Config) { c.Query.NetAddr.Endpoint = "0.0.0.0:55691" },
+			wantErr: "query: endpoint \"0.0.0.0:55691\" is not a loopback address",
+		},
+		{
+			name:    "query without max items",
+			mutate:  func(c *tforetentionexporter.Config) { c.Query.MaxItems = 0 },
+			wantErr: "query: max_items",
+		},
+		{
+			name: "disabled query is not checked",
+			mutate: func(c *tforetentionexporter.Config) {
+				c.Query = tforetentionexporter.QueryConfig{}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid()
+			tt.mutate(&cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestCreateDefaultConfig(t *testing.T) {
+	factory := tforetentionexporter.NewFactory()
+	assert.Equal(t, component.MustNewType("tforetention"), factory.Type())
+
+	cfg := factory.CreateDefaultConfig().(*tforetentionexporter.Config)
+	assert.Equal(t, 512*bytesize.MiB, cfg.MaxSize)
+	assert.Equal(t, 16*bytesize.MiB, cfg.SegmentSize)
+	assert.Zero(t, cfg.MaxSizeMiB)
+	assert.Zero(t, cfg.SegmentSizeMiB)
+	assert.Zero(t, cfg.MaxAge)
+	assert.False(t, cfg.Query.Enabled)
+	assert.Equal(t, tforetentionexporter.DefaultQueryEndpoint, cfg.Query.NetAddr.Endpoint)
+	assert.Equal(t, 1000, cfg.Query.MaxItems)
+
+	assert.Error(t, cfg.Validate(), "directory must be configured")
+	cfg.Directory = t.TempDir()
+	assert.NoError(t, cfg.Validate())
+}
@@ -0,0 +1,287 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tforetentionexporter_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tforetentionexporter"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/bytesize"
+)
+
+var base = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+// freeEndpoint returns a localhost address with an unused port.
+func freeEndpoint(t *testing.T) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := lis.Addr().String()
+	require.NoError(t, lis.Close())
+	return addr
+}
+
+func newConfig(t *testing.T, dir string) *tforetentionexporter.Config {
+	t.Helper()
+	cfg := tforetentionexporter.NewFactory().CreateDefaultConfig().(*tforetentionexporter.Config)
+	cfg.Directory = dir
+	cfg.Query.Enabled = true
+	cfg.Query.NetAddr.Endpoint = freeEndpoint(t)
+	require.NoError(t, cfg.Validate())
+	return cfg
+}
+
+func settings(name string) exporter.Settings {
+	factory := tforetentionexporter.NewFactory()
+	set := exportertest.NewNopSettings(factory.Type())
+	set.ID = component.NewIDWithName(factory.Type(), name)
+	return set
+}
+
+func startLogs(t *testing.T, name string, cfg *tforetentionexporter.Config) exporter.Logs {
+	t.Helper()
+	exp, err := tforetentionexporter.NewFactory().CreateLogs(context.Background(), settings(name), cfg)
+	require.NoError(t, err)
+	require.NoError(t, exp.Start(context.Background(), componenttest.NewNopHost()))
+	return exp
+}
+
+func makeLogs(service string, at time.Duration, bodies ...string) plog.Logs {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", service)
+	lrs := rl.ScopeLogs().AppendEmpty().LogRecords()
+	for i, body := range bodies {
+		lr := lrs.AppendEmpty()
+		lr.SetTimestamp(pcommon.NewTimestampFromTime(base.Add(at + time.Duration(i)*time.Second)))
+		lr.Body().SetStr(body)
+	}
+	return ld
+}
+
+func get(t *testing.T, url string) (*http.Response, []byte) {
+	t.Helper()
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, body
+}
+
+func queryLogs(t *testing.T, cfg *tforetentionexporter.Config, params string) ([]string, *http.Response) {
+	t.Helper()
+	resp, body := get(t, "http://"+cfg.Query.NetAddr.Endpoint+"/query?signal=logs&"+params)
+	require.Equal(t, http.StatusOK, resp.StatusCode, string(body))
+
+	ld, err := (&plog.JSONUnmarshaler{}).UnmarshalLogs(body)
+	require.NoError(t, err)
+	var bodies []string
+	for _, rl := range ld.ResourceLogs().All() {
+		for _, sl := range rl.ScopeLogs().All() {
+			for _, lr := range sl.LogRecords().All() {
+				bodies = append(bodies, lr.Body().Str())
+			}
+		}
+	}
+	return bodies, resp
+}
+
+func TestExporter_QueryLogs(t *testing.T) {
+	cfg := newConfig(t, t.TempDir())
+	exp := startLogs(t, "query", cfg)
+	defer func() { require.NoError(t, exp.Shutdown(context.Background())) }()
+
+	ctx := context.Background()
+	require.NoError(t, exp.ConsumeLogs(ctx, makeLogs("checkout", 0, "c0", "c1", "c2")))
+	require.NoError(t, exp.ConsumeLogs(ctx, makeLogs("billing", time.Minute, "b0", "b1")))
+
+	t.Run("all", func(t *testing.T) {
+		bodies, _ := queryLogs(t, cfg, "")
+		assert.Equal(t, []string{"c0", "c1", "c2", "b0", "b1"}, bodies)
+	})
+
+	t.Run("attribute filter", func(t *testing.T) {
+		bodies, _ := queryLogs(t, cfg, "service.name=billing")
+		assert.Equal(t, []string{"b0", "b1"}, bodies)
+	})
+
+	t.Run("time range", func(t *testing.T) {
+		start := base.Add(time.Second).Format(time.RFC3339)
+		end := base.Add(time.Minute).Format(time.RFC3339)
+		bodies, _ := queryLogs(t, cfg, "start="+start+"&end="+end)
+		assert.Equal(t, []string{"c1", "c2", "b0"}, bodies)
+	})
+
+	t.Run("relative start", func(t *testing.T) {
+		bodies, _ := queryLogs(t, cfg, "start=1h")
+		assert.Empty(t, bodies, "test data is older than an hour")
+	})
+
+	t.Run("limit", func(t *testing.T) {
+		bodies, resp := queryLogs(t, cfg, "limit=2")
+		assert.Equal(t, []string{"c0", "c1"}, bodies)
+		assert.Equal(t, "true", resp.Header.Get("X-Tfo-Truncated"))
+	})
+
+	t.Run("bad request", func(t *testing.T) {
+		for _, params := range []string{"signal=profiles", "signal=logs&start=yesterday", "signal=logs&limit=-1", "signal=logs&start=1m&end=2m"} {
+			resp, _ := get(t, "http://"+cfg.Query.NetAddr.Endpoint+"/query?"+params)
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode, params)
+		}
+	})
+}
+
+func TestExporter_SharedAcrossSignals(t *testing.T) {
+	cfg := newConfig(t, t.TempDir())
+	factory := tforetentionexporter.NewFactory()
+	ctx := context.Background()
+
+	traces, err := factory.CreateTraces(ctx, settings("shared"), cfg)
+	require.NoError(t, err)
+	metrics, err := factory.CreateMetrics(ctx, settings("shared"), cfg)
+	require.NoError(t, err)
+	require.NoError(t, traces.Start(ctx, componenttest.NewNopHost()))
+	require.NoError(t, metrics.Start(ctx, componenttest.NewNopHost()), "second signal reuses the query endpoint")
+
+	td := ptrace.NewTraces()
+	span := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetName("GET /cart")
+	span.SetStartTimestamp(pcommon.NewTimestampFromTime(base))
+	require.NoError(t, traces.ConsumeTraces(ctx, td))
+
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("queue.size")
+	for i, host := range []string{"a", "b"} {
+		dp := m.SetEmptyGauge().DataPoints().AppendEmpty()
+		if i > 0 {
+			dp = m.Gauge().DataPoints().AppendEmpty()
+		}
+		dp.SetTimestamp(pcommon.NewTimestampFromTime(base))
+		dp.Attributes().PutStr("host.name", host)
+		dp.SetIntValue(int64(i))
+	}
+	require.NoError(t, metrics.ConsumeMetrics(ctx, md))
+
+	resp, body := get(t, "http://"+cfg.Query.NetAddr.Endpoint+"/query?signal=traces")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	gotTraces, err := (&ptrace.JSONUnmarshaler{}).UnmarshalTraces(body)
+	require.NoError(t, err)
+	assert.Equal(t, 1, gotTraces.SpanCount())
+
+	resp, body = get(t, "http://"+cfg.Query.NetAddr.Endpoint+"/query?signal=metrics&host.name=b")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	gotMetrics, err := (&pmetric.JSONUnmarshaler{}).UnmarshalMetrics(body)
+	require.NoError(t, err)
+	assert.Equal(t, 1, gotMetrics.DataPointCount())
+
+	require.NoError(t, traces.Shutdown(ctx))
+	resp, _ = get(t, "http://"+cfg.Query.NetAddr.Endpoint+"/status")
+	assert.Equal(t, http.StatusOK, resp.StatusCode, "query API stays up until the last signal shuts down")
+	require.NoError(t, metrics.Shutdown(ctx))
+}
+
+func TestExporter_SizeBound(t *testing.T) {
+	cfg := newConfig(t, t.TempDir())
+	cfg.MaxSizeMiB = 2
+	cfg.SegmentSizeMiB = 1
+	exp := startLogs(t, "size", cfg)
+	defer func() { require.NoError(t, exp.Shutdown(context.Background())) }()
+
+	// Ten batches of ~300 KiB each; only the newest fit in 2 MiB.
+	payload := strings.Repeat("x", 300*1024)
+	for i := range 10 {
+		require.NoError(t, exp.ConsumeLogs(context.Background(),
+			makeLogs("svc", time.Duration(i)*time.Minute, payload)))
+	}
+
+	resp, body := get(t, "http://"+cfg.Query.NetAddr.Endpoint+"/status")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	var status struct {
+		Segments int       `json:"segments"`
+		Bytes    int64     `json:"bytes"`
+		MaxBytes int64     `json:"max_bytes"`
+		Oldest   time.Time `json:"oldest"`
+		Newest   time.Time `json:"newest"`
+	}
+	require.NoError(t, json.Unmarshal(body, &status))
+	assert.LessOrEqual(t, status.Bytes, status.MaxBytes)
+	assert.Equal(t, int64(2<<20), status.MaxBytes)
+	assert.True(t, status.Oldest.After(base), "oldest batches were dropped")
+	assert.Equal(t, base.Add(9*time.Minute), status.Newest)
+
+	bodies, _ := queryLogs(t, cfg, "")
+	assert.Less(t, len(bodies), 10)
+	assert.NotEmpty(t, bodies)
+}
+
+func TestExporter_RejectsOversizedBatch(t *testing.T) {
+	cfg := newConfig(t, t.TempDir())
+	cfg.MaxSize = bytesize.MiB
+	cfg.SegmentSize = bytesize.MiB
+	exp := startLogs(t, "oversized", cfg)
+	defer func() { require.NoError(t, exp.Shutdown(context.Background())) }()
+
+	err := exp.ConsumeLogs(context.Background(), makeLogs("svc", 0, strings.Repeat("x", 2<<20)))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exceeds max_size")
+}
+
+func TestExporter_RecoversAfterRestart(t *testing.T) {
+	dir := t.TempDir()
+	cfg := newConfig(t, dir)
+	exp := startLogs(t, "restart", cfg)
+	require.NoError(t, exp.ConsumeLogs(context.Background(), makeLogs("svc", 0, "before restart")))
+	require.NoError(t, exp.Shutdown(context.Background()))
+
+	// Simulate a write interrupted by a crash.
+	segments, err := filepath.Glob(filepath.Join(dir, "*.seg"))
+	require.NoError(t, err)
+	require.NotEmpty(t, segments)
+	f, err := os.OpenFile(segments[len(segments)-1], os.O_APPEND|os.O_WRONLY, 0o600)
+	require.NoError(t, err)
+	_, err = f.Write([]byte{3, 1, 2, 3})
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	cfg = newConfig(t, dir)
+	exp = startLogs(t, "restart", cfg)
+	defer func() { require.NoError(t, exp.Shutdown(context.Background())) }()
+	require.NoError(t, exp.ConsumeLogs(context.Background(), makeLogs("svc", time.Minute, "after restart")))
+
+	bodies, _ := queryLogs(t, cfg, "")
+	assert.Equal(t, []string{"before restart", "after restart"}, bodies)
+}
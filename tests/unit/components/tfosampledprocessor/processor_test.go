@@ -0,0 +1,62 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfosampledprocessor_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfosampledprocessor"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/sampled"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	cfg := tfosampledprocessor.NewFactory().CreateDefaultConfig().(*tfosampledprocessor.Config)
+	assert.ErrorContains(t, cfg.Validate(), "archive is required")
+
+	cfg.Archive = component.MustNewID("tfoarchive")
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestProcessor_RecordsKeptTraces(t *testing.T) {
+	factory := tfosampledprocessor.NewFactory()
+	cfg := factory.CreateDefaultConfig().(*tfosampledprocessor.Config)
+	cfg.Archive = component.MustNewIDWithName("tfoarchive", "processor")
+
+	sink := new(consumertest.TracesSink)
+	p, err := factory.CreateTraces(context.Background(), processortest.NewNopSettings(factory.Type()), cfg, sink)
+	require.NoError(t, err)
+
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	for _, id := range []byte{1, 1, 2} {
+		spans.AppendEmpty().SetTraceID(pcommon.TraceID([16]byte{id}))
+	}
+	require.NoError(t, p.ConsumeTraces(context.Background(), td))
+
+	assert.Equal(t, 3, sink.SpanCount(), "spans are passed on unchanged")
+	decisions := sampled.For(cfg.Archive.String())
+	assert.True(t, decisions.Kept([16]byte{1}))
+	assert.True(t, decisions.Kept([16]byte{2}))
+	assert.False(t, decisions.Kept([16]byte{3}))
+}
@@ -0,0 +1,83 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfospannameprocessor_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfospannameprocessor"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *tfospannameprocessor.Config)
+		wantErr string
+	}{
+		{name: "defaults", mutate: func(*tfospannameprocessor.Config) {}},
+		{
+			name: "rules only",
+			mutate: func(cfg *tfospannameprocessor.Config) {
+				cfg.CollapseIDs = false
+				cfg.Rules = []tfospannameprocessor.Rule{{Pattern: "GET /users/.*", Template: "GET /users/{id}"}}
+			},
+		},
+		{
+			name:    "nothing to do",
+			mutate:  func(cfg *tfospannameprocessor.Config) { cfg.CollapseIDs = false },
+			wantErr: "rules are required when collapse_ids is disabled",
+		},
+		{
+			name: "missing pattern",
+			mutate: func(cfg *tfospannameprocessor.Config) {
+				cfg.Rules = []tfospannameprocessor.Rule{{Template: "GET /users/{id}"}}
+			},
+			wantErr: "rules[0]: pattern is required",
+		},
+		{
+			name: "invalid pattern",
+			mutate: func(cfg *tfospannameprocessor.Config) {
+				cfg.Rules = []tfospannameprocessor.Rule{{Pattern: "GET /users/(", Template: "GET /users/{id}"}}
+			},
+			wantErr: "rules[0]: invalid pattern",
+		},
+		{
+			name: "missing template",
+			mutate: func(cfg *tfospannameprocessor.Config) {
+				cfg.Rules = []tfospannameprocessor.Rule{{Pattern: "GET /users/.*"}}
+			},
+			wantErr: "rules[0]: template is required",
+		},
+		{
+			name:    "empty placeholder",
+			mutate:  func(cfg *tfospannameprocessor.Config) { cfg.Placeholder = "" },
+			wantErr: "placeholder is required when collapse_ids is enabled",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tfospannameprocessor.NewFactory().CreateDefaultConfig().(*tfospannameprocessor.Config)
+			tt.mutate(cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,183 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfospannameprocessor_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/connector/spanmetricsconnector"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/connector/connectortest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfospannameprocessor"
+)
+
+// makeTraces returns a span for each of names.
+func makeTraces(names ...string) ptrace.Traces {
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "web")
+	spans := rs.ScopeSpans().AppendEmpty().Spans()
+	for _, name := range names {
+		spans.AppendEmpty().SetName(name)
+	}
+	return td
+}
+
+// spanNames returns the names of the spans of td.
+func spanNames(td ptrace.Traces) []string {
+	var out []string
+	spans := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	for i := 0; i < spans.Len(); i++ {
+		out = append(out, spans.At(i).Name())
+	}
+	return out
+}
+
+func TestProcessor_CollapsesIDs(t *testing.T) {
+	factory := tfospannameprocessor.NewFactory()
+	cfg := factory.CreateDefaultConfig().(*tfospannameprocessor.Config)
+	sink := new(consumertest.TracesSink)
+	p, err := factory.CreateTraces(context.Background(), processortest.NewNopSettings(factory.Type()), cfg, sink)
+	require.NoError(t, err)
+
+	require.NoError(t, p.ConsumeTraces(context.Background(), makeTraces(
+		"GET /users/123",
+		"GET /users/123/orders/987654",
+		"DELETE /sessions/3f2b8c1e-9a4d-4e5f-8b6a-1c2d3e4f5a6b",
+		"GET /objects/507f1f77bcf86cd799439011",
+		"GET /tokens/01ARZ3NDEKTSV4RRFFQ69G5FAV",
+		"GET /search?q=123",
+		"GET /api/v2/users/",
+		"GET /docs/getting-started",
+		"GET /callbacks/oauth2",
+		"SELECT 123",
+		"/123",
+	)))
+
+	assert.Equal(t, []string{
+		"GET /users/{id}",
+		"GET /users/{id}/orders/{id}",
+		"DELETE /sessions/{id}",
+		"GET /objects/{id}",
+		"GET /tokens/{id}",
+		"GET /search",
+		"GET /api/v2/users/",
+		"GET /docs/getting-started",
+		"GET /callbacks/oauth2",
+		"SELECT 123",
+		"/{id}",
+	}, spanNames(sink.AllTraces()[0]))
+}
+
+func TestProcessor_Rules(t *testing.T) {
+	factory := tfospannameprocessor.NewFactory()
+	cfg := factory.CreateDefaultConfig().(*tfospannameprocessor.Config)
+	cfg.Rules = []tfospannameprocessor.Rule{
+		{Pattern: `(GET|POST) /tenants/[^/]+/(.*)`, Template: "$1 /tenants/{tenant}/$2"},
+		{Pattern: `GET /files/(?P<kind>[a-z]+)/.+`, Template: "GET /files/${kind}/{path}"},
+		// Patterns match the whole name, so this one never applies.
+		{Pattern: `GET /users`, Template: "never"},
+	}
+	cfg.OriginalNameAttribute = "span.original_name"
+	require.NoError(t, cfg.Validate())
+
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { _ = tel.Shutdown(context.Background()) })
+	set := processortest.NewNopSettings(factory.Type())
+	set.TelemetrySettings = tel.NewTelemetrySettings()
+	sink := new(consumertest.TracesSink)
+	p, err := factory.CreateTraces(context.Background(), set, cfg, sink)
+	require.NoError(t, err)
+
+	require.NoError(t, p.ConsumeTraces(context.Background(), makeTraces(
+		"POST /tenants/acme/users/123",
+		"GET /files/images/a/b.png",
+		"GET /users/42",
+		"GET /health",
+	)))
+
+	td := sink.AllTraces()[0]
+	// Rules take precedence; collapse_ids applies to names no rule matched.
+	assert.Equal(t, []string{
+		"POST /tenants/{tenant}/users/123",
+		"GET /files/images/{path}",
+		"GET /users/{id}",
+		"GET /health",
+	}, spanNames(td))
+
+	spans := td.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+	original, ok := spans.At(0).Attributes().Get("span.original_name")
+	require.True(t, ok)
+	assert.Equal(t, "POST /tenants/acme/users/123", original.Str())
+	_, ok = spans.At(3).Attributes().Get("span.original_name")
+	assert.False(t, ok, "spans that keep their name get no attribute")
+
+	m, err := tel.GetMetric("tfo_spanname_spans_renamed")
+	require.NoError(t, err)
+	renamed := make(map[string]int64)
+	for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+		reason, _ := dp.Attributes.Value("reason")
+		renamed[reason.AsString()] += dp.Value
+	}
+	assert.Equal(t, map[string]int64{"rule": 2, "collapse": 1}, renamed)
+}
+
+func TestProcessor_SpanMetricsDimension(t *testing.T) {
+	metricsSink := new(consumertest.MetricsSink)
+	connFactory := spanmetricsconnector.NewFactory()
+	connCfg := connFactory.CreateDefaultConfig().(*spanmetricsconnector.Config)
+	connCfg.MetricsFlushInterval = 10 * time.Millisecond
+	conn, err := connFactory.CreateTracesToMetrics(context.Background(),
+		connectortest.NewNopSettings(connFactory.Type()), connCfg, metricsSink)
+	require.NoError(t, err)
+	require.NoError(t, conn.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { _ = conn.Shutdown(context.Background()) })
+
+	factory := tfospannameprocessor.NewFactory()
+	p, err := factory.CreateTraces(context.Background(), processortest.NewNopSettings(factory.Type()),
+		factory.CreateDefaultConfig(), conn)
+	require.NoError(t, err)
+	require.NoError(t, p.ConsumeTraces(context.Background(), makeTraces("GET /users/1", "GET /users/2", "GET /users/3")))
+
+	// The calls metric has a single series, for the template.
+	require.Eventually(t, func() bool { return metricsSink.DataPointCount() > 0 }, 5*time.Second, 10*time.Millisecond)
+	var names []string
+	for _, md := range metricsSink.AllMetrics() {
+		ms := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+		for i := 0; i < ms.Len(); i++ {
+			if ms.At(i).Name() != "traces.span.metrics.calls" {
+				continue
+			}
+			dps := ms.At(i).Sum().DataPoints()
+			for j := 0; j < dps.Len(); j++ {
+				name, _ := dps.At(j).Attributes().Get("span.name")
+				if !slices.Contains(names, name.Str()) {
+					names = append(names, name.Str())
+				}
+			}
+		}
+	}
+	assert.Equal(t, []string{"GET /users/{id}"}, names)
+}
@@ -0,0 +1,90 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfosupportextension_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/extension/tfosupportextension"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *tfosupportextension.Config)
+		wantErr string
+	}{
+		{name: "defaults", mutate: func(*tfosupportextension.Config) {}},
+		{
+			name:   "log files",
+			mutate: func(cfg *tfosupportextension.Config) { cfg.LogFiles = []string{"/var/log/tfo.log"} },
+		},
+		{
+			name:    "endpoint without port",
+			mutate:  func(cfg *tfosupportextension.Config) { cfg.NetAddr.Endpoint = "localhost" },
+			wantErr: "invalid endpoint",
+		},
+		{
+			name:    "empty endpoint",
+			mutate:  func(cfg *tfosupportextension.Config) { cfg.NetAddr.Endpoint = "" },
+			wantErr: "endpoint is required",
+		},
+		{
+			name:    "endpoint off localhost without client authentication",
+			mutate:  func(cfg *tfosupportextension.Config) { cfg.NetAddr.Endpoint = "0.0.0.0:55694" },
+			wantErr: "not a loopback address",
+		},
+		{
+			name:    "zero preview count",
+			mutate:  func(cfg *tfosupportextension.Config) { cfg.Preview.MaxCount = 0 },
+			wantErr: "preview.max_count must be positive",
+		},
+		{
+			name:    "zero preview duration",
+			mutate:  func(cfg *tfosupportextension.Config) { cfg.Preview.MaxDuration = 0 },
+			wantErr: "preview.max_duration must be positive",
+		},
+		{
+			name:    "zero history interval",
+			mutate:  func(cfg *tfosupportextension.Config) { cfg.History.Interval = 0 },
+			wantErr: "history.interval must be positive",
+		},
+		{
+			name:    "zero history size",
+			mutate:  func(cfg *tfosupportextension.Config) { cfg.History.Size = 0 },
+			wantErr: "history.size must be positive",
+		},
+		{
+			name: "history disabled",
+			mutate: func(cfg *tfosupportextension.Config) {
+				cfg.History = tfosupportextension.HistoryConfig{}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tfosupportextension.NewFactory().CreateDefaultConfig().(*tfosupportextension.Config)
+			tt.mutate(cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
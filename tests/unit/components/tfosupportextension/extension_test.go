@@ -0,0 +1,167 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfosupportextension_test
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/extension/extensioncapabilities"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/extension/tfosupportextension"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/supportbundle"
+)
+
+func freeEndpoint(t *testing.T) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = l.Close() }()
+	return l.Addr().String()
+}
+
+func startExtension(t *testing.T, cfg *tfosupportextension.Config) component.Component {
+	t.Helper()
+	set := extensiontest.NewNopSettings(component.MustNewType("tfosupport"))
+	set.BuildInfo = component.BuildInfo{Command: "tfo-collector", Version: "1.2.3"}
+	ext, err := tfosupportextension.NewFactory().Create(context.Background(), set, cfg)
+	require.NoError(t, err)
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { _ = ext.Shutdown(context.Background()) })
+	return ext
+}
+
+// getBundle downloads the bundle and returns its entries by name.
+func getBundle(t *testing.T, endpoint string) map[string]string {
+	t.Helper()
+	resp, err := http.Get("http://" + endpoint + "/bundle")
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/gzip", resp.Header.Get("Content-Type"))
+	assert.Regexp(t, `^attachment; filename="tfo-collector-support-\d{8}T\d{6}Z\.tar\.gz"$`, resp.Header.Get("Content-Disposition"))
+
+	gz, err := gzip.NewReader(resp.Body)
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+	entries := make(map[string]string)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return entries
+		}
+		require.NoError(t, err)
+		body, err := io.ReadAll(tr)
+		require.NoError(t, err)
+		entries[hdr.Name] = string(body)
+	}
+}
+
+func TestNewFactory(t *testing.T) {
+	factory := tfosupportextension.NewFactory()
+	assert.Equal(t, component.MustNewType("tfosupport"), factory.Type())
+
+	cfg := factory.CreateDefaultConfig().(*tfosupportextension.Config)
+	assert.Equal(t, tfosupportextension.DefaultEndpoint, cfg.NetAddr.Endpoint)
+	assert.Empty(t, cfg.LogFiles)
+	assert.Equal(t, tfosupportextension.DefaultPreviewMaxCount, cfg.Preview.MaxCount)
+	assert.Equal(t, tfosupportextension.DefaultPreviewMaxDuration, cfg.Preview.MaxDuration)
+	assert.True(t, cfg.History.Enabled)
+	assert.Equal(t, tfosupportextension.DefaultHistoryInterval, cfg.History.Interval)
+	assert.Equal(t, tfosupportextension.DefaultHistorySize, cfg.History.Size)
+}
+
+func TestExtension_ServesBundleOfEffectiveConfig(t *testing.T) {
+	dir := t.TempDir()
+	logFile := filepath.Join(dir, "collector.log")
+	extraLog := filepath.Join(dir, "stderr.log")
+	require.NoError(t, os.WriteFile(logFile, []byte("collector started\n"), 0o600))
+	require.NoError(t, os.WriteFile(extraLog, []byte("panic: boom\n"), 0o600))
+
+	endpoint := freeEndpoint(t)
+	ext := startExtension(t, &tfosupportextension.Config{AdminConfig: serverconf.NewDefaultAdminConfig(endpoint), LogFiles: []string{extraLog}})
+
+	watcher, ok := ext.(extensioncapabilities.ConfigWatcher)
+	require.True(t, ok, "extension must watch the effective configuration")
+	require.NoError(t, watcher.NotifyConfig(context.Background(), confmap.NewFromStringMap(map[string]any{
+		"extensions": map[string]any{
+			"tfoauth": map[string]any{"api_key_secret": "tfs_secret"},
+		},
+		"service": map[string]any{
+			"telemetry": map[string]any{
+				"logs":    map[string]any{"output_paths": []any{"stderr", logFile}},
+				"metrics": map[string]any{"level": "none"},
+			},
+		},
+	})))
+
+	entries := getBundle(t, endpoint)
+	assert.Contains(t, entries["version.json"], `"version": "1.2.3"`)
+	assert.Contains(t, entries["config.yaml"], "api_key_secret: '"+supportbundle.Redacted+"'")
+	assert.NotContains(t, entries["config.yaml"], "tfs_secret")
+	assert.Equal(t, "collector started\n", entries["logs/collector.log"])
+	assert.Equal(t, "panic: boom\n", entries["logs/stderr.log"])
+	assert.Contains(t, entries["profiles/goroutine.txt"], "goroutine")
+	assert.NotEmpty(t, entries["profiles/heap.pb.gz"])
+	assert.NotContains(t, entries, "metrics.txt")
+	assert.NotContains(t, entries, "errors.txt")
+}
+
+func TestExtension_BundleBeforeConfig(t *testing.T) {
+	endpoint := freeEndpoint(t)
+	startExtension(t, &tfosupportextension.Config{AdminConfig: serverconf.NewDefaultAdminConfig(endpoint)})
+
+	entries := getBundle(t, endpoint)
+	assert.Contains(t, entries, "version.json")
+	assert.NotContains(t, entries, "config.yaml")
+	assert.Contains(t, entries, "profiles/goroutine.txt")
+}
+
+func TestExtension_RejectsOtherMethods(t *testing.T) {
+	endpoint := freeEndpoint(t)
+	startExtension(t, &tfosupportextension.Config{AdminConfig: serverconf.NewDefaultAdminConfig(endpoint)})
+
+	resp, err := http.Post("http://"+endpoint+"/bundle", "application/json", nil)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+}
+
+func TestExtension_StartFailsOnBusyEndpoint(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = l.Close() }()
+
+	set := extensiontest.NewNopSettings(component.MustNewType("tfosupport"))
+	ext, err := tfosupportextension.NewFactory().Create(context.Background(), set,
+		&tfosupportextension.Config{AdminConfig: serverconf.NewDefaultAdminConfig(l.Addr().String())})
+	require.NoError(t, err)
+	assert.ErrorContains(t, ext.Start(context.Background(), componenttest.NewNopHost()), "support admin API")
+}
@@ -0,0 +1,117 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfosupportextension_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/extension/tfosupportextension"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/payloadpreview"
+)
+
+// previewRequest sends a request to the preview API and decodes the JSON
+// response into out.
+func previewRequest(t *testing.T, method, url, body string, out any) int {
+	t.Helper()
+	req, err := http.NewRequest(method, url, strings.NewReader(body))
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	if out != nil {
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(out))
+	}
+	return resp.StatusCode
+}
+
+func TestExtension_Preview(t *testing.T) {
+	// Previews are process-wide: a new exporter per run starts without one.
+	exporter := fmt.Sprintf("tfo/support-preview-%d", time.Now().UnixNano())
+	p := payloadpreview.For(exporter)
+	cfg := tfosupportextension.NewFactory().CreateDefaultConfig().(*tfosupportextension.Config)
+	cfg.NetAddr.Endpoint = freeEndpoint(t)
+	startExtension(t, cfg)
+	base := "http://" + cfg.NetAddr.Endpoint + "/preview"
+
+	var list map[string][]string
+	require.Equal(t, http.StatusOK, previewRequest(t, http.MethodGet, base, "", &list))
+	assert.Contains(t, list["exporters"], exporter)
+
+	url := base + "/" + exporter
+	var errBody map[string]string
+	assert.Equal(t, http.StatusNotFound, previewRequest(t, http.MethodGet, url, "", &errBody))
+	assert.Equal(t, "no preview", errBody["error"])
+
+	var status payloadpreview.Status
+	require.Equal(t, http.StatusCreated,
+		previewRequest(t, http.MethodPost, url, `{"signal":"logs","count":2,"duration":"1m"}`, &status))
+	assert.True(t, status.Active)
+	assert.Equal(t, "logs", status.Signal)
+	assert.Equal(t, 2, status.Limit)
+
+	assert.Equal(t, http.StatusConflict, previewRequest(t, http.MethodPost, url, `{}`, &errBody))
+
+	p.Record("logs", "http://backend/v2/logs", 42, []byte(`{"resourceLogs":[]}`))
+	require.Equal(t, http.StatusOK, previewRequest(t, http.MethodGet, url, "", &status))
+	assert.True(t, status.Active)
+	require.Len(t, status.Payloads, 1)
+	assert.Equal(t, 42, status.Payloads[0].Size)
+	assert.JSONEq(t, `{"resourceLogs":[]}`, string(status.Payloads[0].Body))
+
+	require.Equal(t, http.StatusOK, previewRequest(t, http.MethodDelete, url, "", &status))
+	assert.False(t, status.Active)
+	assert.Len(t, status.Payloads, 1)
+}
+
+func TestExtension_PreviewRejectsInvalidRequests(t *testing.T) {
+	payloadpreview.For("tfo/support-invalid")
+	cfg := tfosupportextension.NewFactory().CreateDefaultConfig().(*tfosupportextension.Config)
+	cfg.NetAddr.Endpoint = freeEndpoint(t)
+	startExtension(t, cfg)
+	base := "http://" + cfg.NetAddr.Endpoint + "/preview"
+
+	tests := []struct {
+		name    string
+		method  string
+		path    string
+		body    string
+		code    int
+		wantErr string
+	}{
+		{name: "unknown exporter", method: http.MethodGet, path: "/tfo/missing", code: http.StatusNotFound, wantErr: `unknown exporter "tfo/missing"`},
+		{name: "invalid body", method: http.MethodPost, path: "/tfo/support-invalid", body: `{`, code: http.StatusBadRequest, wantErr: "invalid request body"},
+		{name: "unknown signal", method: http.MethodPost, path: "/tfo/support-invalid", body: `{"signal":"profiles"}`, code: http.StatusBadRequest, wantErr: "signal must be one of traces, metrics, logs"},
+		{name: "count too large", method: http.MethodPost, path: "/tfo/support-invalid", body: `{"count":21}`, code: http.StatusBadRequest, wantErr: "count must be between 1 and 20"},
+		{name: "duration too long", method: http.MethodPost, path: "/tfo/support-invalid", body: `{"duration":"11m"}`, code: http.StatusBadRequest, wantErr: "duration must be a positive duration of at most 10m0s"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var errBody map[string]string
+			assert.Equal(t, tt.code, previewRequest(t, tt.method, base+tt.path, tt.body, &errBody))
+			assert.Contains(t, errBody["error"], tt.wantErr)
+		})
+	}
+
+	assert.Equal(t, http.StatusMethodNotAllowed, previewRequest(t, http.MethodPut, base+"/tfo/support-invalid", "", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, previewRequest(t, http.MethodPost, base, "", nil))
+}
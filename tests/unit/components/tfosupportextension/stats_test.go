@@ -0,0 +1,201 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfosupportextension_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/extension/extensioncapabilities"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/extension/tfosupportextension"
+)
+
+// selfMetrics serves collector self-metrics whose counters grow by step
+// on every scrape.
+func selfMetrics(t *testing.T, step float64) (host string, port int) {
+	t.Helper()
+	var scrapes atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		n := float64(scrapes.Add(1)) * step
+		w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+		_, _ = fmt.Fprintf(w, `# TYPE otelcol_receiver_accepted_spans_total counter
+otelcol_receiver_accepted_spans_total{receiver="otlp",transport="http"} %[1]g
+otelcol_receiver_accepted_spans_total{receiver="tfootlp",transport="grpc"} %[1]g
+# TYPE otelcol_receiver_accepted_log_records_total counter
+otelcol_receiver_accepted_log_records_total{receiver="otlp",transport="http"} %[1]g
+# TYPE otelcol_exporter_sent_spans_total counter
+otelcol_exporter_sent_spans_total{exporter="tfo"} %[1]g
+# TYPE otelcol_exporter_send_failed_log_records_total counter
+otelcol_exporter_send_failed_log_records_total{exporter="tfo"} %[1]g
+# TYPE otelcol_receiver_refused_spans_total counter
+otelcol_receiver_refused_spans_total{receiver="otlp",transport="http"} 1
+# TYPE otelcol_exporter_queue_size gauge
+otelcol_exporter_queue_size{exporter="tfo"} 7
+# TYPE otelcol_process_uptime_seconds_total counter
+otelcol_process_uptime_seconds_total %[1]g
+`, n)
+	}))
+	t.Cleanup(srv.Close)
+	h, p, err := net.SplitHostPort(srv.Listener.Addr().String())
+	require.NoError(t, err)
+	port, err = strconv.Atoi(p)
+	require.NoError(t, err)
+	return h, port
+}
+
+func getJSON(t *testing.T, url string, out any) int {
+	t.Helper()
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	defer func() { _ = resp.Body.Close() }()
+	require.NoError(t, json.NewDecoder(resp.Body).Decode(out))
+	return resp.StatusCode
+}
+
+type signalCounts struct {
+	Traces  float64 `json:"traces"`
+	Metrics float64 `json:"metrics"`
+	Logs    float64 `json:"logs"`
+}
+
+type statsCounters struct {
+	Received  signalCounts `json:"received"`
+	Sent      signalCounts `json:"sent"`
+	Dropped   signalCounts `json:"dropped"`
+	QueueSize float64      `json:"queue_size"`
+}
+
+type statsHistory struct {
+	Interval string `json:"interval"`
+	Points   []struct {
+		Start time.Time `json:"start"`
+		End   time.Time `json:"end"`
+		statsCounters
+	} `json:"points"`
+	Error string `json:"error"`
+}
+
+func TestExtension_StatsHistory(t *testing.T) {
+	host, port := selfMetrics(t, 10)
+	cfg := tfosupportextension.NewFactory().CreateDefaultConfig().(*tfosupportextension.Config)
+	cfg.NetAddr.Endpoint = freeEndpoint(t)
+	cfg.History.Interval = 20 * time.Millisecond
+	cfg.History.Size = 3
+	ext := startExtension(t, cfg)
+	base := "http://" + cfg.NetAddr.Endpoint
+
+	var errBody map[string]string
+	assert.Equal(t, http.StatusServiceUnavailable, getJSON(t, base+"/stats", &errBody))
+	assert.Contains(t, errBody["error"], "no scrape of the self-metrics yet")
+
+	require.NoError(t, ext.(extensioncapabilities.ConfigWatcher).NotifyConfig(context.Background(),
+		confmap.NewFromStringMap(map[string]any{
+			"service": map[string]any{"telemetry": map[string]any{"metrics": map[string]any{
+				"readers": []any{map[string]any{"pull": map[string]any{"exporter": map[string]any{
+					"prometheus": map[string]any{"host": host, "port": port},
+				}}}},
+			}}},
+		})))
+
+	var history statsHistory
+	require.Eventually(t, func() bool {
+		getJSON(t, base+"/stats/history", &history)
+		return len(history.Points) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, "20ms", history.Interval)
+	assert.Empty(t, history.Error)
+	for i, p := range history.Points {
+		assert.True(t, p.End.After(p.Start))
+		if i > 0 {
+			assert.Equal(t, history.Points[i-1].End, p.Start)
+		}
+		// Counters grow by 10 per scrape on each series.
+		assert.Equal(t, signalCounts{Traces: 20, Logs: 10}, p.Received)
+		assert.Equal(t, signalCounts{Traces: 10}, p.Sent)
+		assert.Equal(t, signalCounts{Logs: 10}, p.Dropped, "refused spans did not change")
+		assert.Equal(t, float64(7), p.QueueSize)
+	}
+
+	var totals statsCounters
+	require.Equal(t, http.StatusOK, getJSON(t, base+"/stats", &totals))
+	assert.Equal(t, float64(1), totals.Dropped.Traces)
+	assert.Positive(t, totals.Received.Traces)
+}
+
+func TestExtension_StatsHistoryCounterReset(t *testing.T) {
+	var value atomic.Int64
+	value.Store(100)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = fmt.Fprintf(w, "# TYPE otelcol_receiver_accepted_spans_total counter\notelcol_receiver_accepted_spans_total %d\n", value.Load())
+	}))
+	t.Cleanup(srv.Close)
+	host, p, err := net.SplitHostPort(srv.Listener.Addr().String())
+	require.NoError(t, err)
+
+	cfg := tfosupportextension.NewFactory().CreateDefaultConfig().(*tfosupportextension.Config)
+	cfg.NetAddr.Endpoint = freeEndpoint(t)
+	cfg.History.Interval = 20 * time.Millisecond
+	ext := startExtension(t, cfg)
+	require.NoError(t, ext.(extensioncapabilities.ConfigWatcher).NotifyConfig(context.Background(),
+		confmap.NewFromStringMap(map[string]any{
+			"service": map[string]any{"telemetry": map[string]any{"metrics": map[string]any{
+				"readers": []any{map[string]any{"pull": map[string]any{"exporter": map[string]any{
+					"prometheus": map[string]any{"host": host, "port": p},
+				}}}},
+			}}},
+		})))
+
+	var history statsHistory
+	require.Eventually(t, func() bool {
+		getJSON(t, "http://"+cfg.NetAddr.Endpoint+"/stats/history", &history)
+		return len(history.Points) > 0
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// A lower value means the counter restarted: all of it is new.
+	value.Store(4)
+	require.Eventually(t, func() bool {
+		getJSON(t, "http://"+cfg.NetAddr.Endpoint+"/stats/history", &history)
+		for _, p := range history.Points {
+			if p.Received.Traces == 4 {
+				return true
+			}
+		}
+		return false
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestExtension_StatsHistoryDisabled(t *testing.T) {
+	cfg := tfosupportextension.NewFactory().CreateDefaultConfig().(*tfosupportextension.Config)
+	cfg.NetAddr.Endpoint = freeEndpoint(t)
+	cfg.History.Enabled = false
+	startExtension(t, cfg)
+
+	resp, err := http.Get("http://" + cfg.NetAddr.Endpoint + "/stats/history")
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
@@ -0,0 +1,55 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfotraceidprocessor_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfotraceidprocessor"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *tfotraceidprocessor.Config)
+		wantErr string
+	}{
+		{name: "defaults", mutate: func(*tfotraceidprocessor.Config) {}},
+		{
+			name:    "no attributes",
+			mutate:  func(cfg *tfotraceidprocessor.Config) { cfg.Attributes = nil },
+			wantErr: "at least one correlation attribute is required",
+		},
+		{
+			name:    "empty attribute",
+			mutate:  func(cfg *tfotraceidprocessor.Config) { cfg.Attributes = []string{"request_id", ""} },
+			wantErr: "attributes[1]: empty attribute name",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := tfotraceidprocessor.NewFactory().CreateDefaultConfig().(*tfotraceidprocessor.Config)
+			tt.mutate(cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
@@ -0,0 +1,153 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tfotraceidprocessor_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfotraceidprocessor"
+)
+
+// makeLogs returns a log record for each attribute map, under a resource
+// with the attributes resource.
+func makeLogs(resource map[string]string, records ...map[string]string) plog.Logs {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	for k, v := range resource {
+		rl.Resource().Attributes().PutStr(k, v)
+	}
+	lrs := rl.ScopeLogs().AppendEmpty().LogRecords()
+	for _, attrs := range records {
+		lr := lrs.AppendEmpty()
+		for k, v := range attrs {
+			lr.Attributes().PutStr(k, v)
+		}
+	}
+	return ld
+}
+
+func process(t *testing.T, cfg *tfotraceidprocessor.Config, ld plog.Logs) (plog.LogRecordSlice, *componenttest.Telemetry) {
+	t.Helper()
+	factory := tfotraceidprocessor.NewFactory()
+	require.NoError(t, cfg.Validate())
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { _ = tel.Shutdown(context.Background()) })
+	set := processortest.NewNopSettings(factory.Type())
+	set.TelemetrySettings = tel.NewTelemetrySettings()
+	sink := new(consumertest.LogsSink)
+	p, err := factory.CreateLogs(context.Background(), set, cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, p.ConsumeLogs(context.Background(), ld))
+	return sink.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords(), tel
+}
+
+func TestDeriveIDs(t *testing.T) {
+	sum := sha256.Sum256([]byte("req-1"))
+	traceID, spanID := tfotraceidprocessor.DeriveIDs("req-1", "")
+	assert.Equal(t, pcommon.TraceID(sum[:16]), traceID)
+	assert.Equal(t, pcommon.SpanID(sum[16:24]), spanID)
+
+	spanSum := sha256.Sum256([]byte("req-1\x00checkout"))
+	stepTraceID, stepSpanID := tfotraceidprocessor.DeriveIDs("req-1", "checkout")
+	assert.Equal(t, traceID, stepTraceID, "the span value does not change the trace")
+	assert.Equal(t, pcommon.SpanID(spanSum[:8]), stepSpanID)
+}
+
+func TestProcessor_AssignsIDs(t *testing.T) {
+	cfg := tfotraceidprocessor.NewFactory().CreateDefaultConfig().(*tfotraceidprocessor.Config)
+	cfg.Attributes = []string{"request_id", "correlation_id"}
+
+	records, tel := process(t, cfg, makeLogs(map[string]string{"correlation_id": "from-resource"},
+		map[string]string{"request_id": "req-1"},
+		map[string]string{"request_id": "req-1"},
+		map[string]string{"request_id": "req-2", "correlation_id": "ignored"},
+		map[string]string{},
+		map[string]string{"request_id": ""},
+	))
+
+	traceID, spanID := tfotraceidprocessor.DeriveIDs("req-1", "")
+	for i := range 2 {
+		assert.Equal(t, traceID, records.At(i).TraceID())
+		assert.Equal(t, spanID, records.At(i).SpanID())
+		marker, ok := records.At(i).Attributes().Get("tfo.trace.synthetic")
+		require.True(t, ok)
+		assert.True(t, marker.Bool())
+	}
+	otherID, _ := tfotraceidprocessor.DeriveIDs("req-2", "")
+	assert.Equal(t, otherID, records.At(2).TraceID(), "the first attribute present wins")
+	resourceID, _ := tfotraceidprocessor.DeriveIDs("from-resource", "")
+	assert.Equal(t, resourceID, records.At(3).TraceID(), "attributes fall back to the resource")
+	assert.Equal(t, resourceID, records.At(4).TraceID(), "empty values are skipped")
+
+	m, err := tel.GetMetric("tfo_traceid_logs_assigned")
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), m.Data.(metricdata.Sum[int64]).DataPoints[0].Value)
+}
+
+func TestProcessor_SpanAttribute(t *testing.T) {
+	cfg := tfotraceidprocessor.NewFactory().CreateDefaultConfig().(*tfotraceidprocessor.Config)
+	cfg.SpanAttribute = "step"
+	cfg.MarkerAttribute = ""
+
+	records, _ := process(t, cfg, makeLogs(nil,
+		map[string]string{"request_id": "req-1", "step": "validate"},
+		map[string]string{"request_id": "req-1", "step": "charge"},
+		map[string]string{"request_id": "req-1"},
+	))
+
+	traceID, _ := tfotraceidprocessor.DeriveIDs("req-1", "")
+	for i := range 3 {
+		assert.Equal(t, traceID, records.At(i).TraceID())
+		_, ok := records.At(i).Attributes().Get("tfo.trace.synthetic")
+		assert.False(t, ok)
+	}
+	_, validate := tfotraceidprocessor.DeriveIDs("req-1", "validate")
+	_, charge := tfotraceidprocessor.DeriveIDs("req-1", "charge")
+	_, none := tfotraceidprocessor.DeriveIDs("req-1", "")
+	assert.Equal(t, validate, records.At(0).SpanID())
+	assert.Equal(t, charge, records.At(1).SpanID())
+	assert.Equal(t, none, records.At(2).SpanID())
+	assert.NotEqual(t, validate, charge)
+}
+
+func TestProcessor_ExistingTraceContext(t *testing.T) {
+	existing := pcommon.TraceID([16]byte{1, 2, 3})
+	ld := makeLogs(nil, map[string]string{"request_id": "req-1"})
+	ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).SetTraceID(existing)
+
+	cfg := tfotraceidprocessor.NewFactory().CreateDefaultConfig().(*tfotraceidprocessor.Config)
+	records, tel := process(t, cfg, ld)
+	assert.Equal(t, existing, records.At(0).TraceID())
+	_, err := tel.GetMetric("tfo_traceid_logs_assigned")
+	assert.Error(t, err, "nothing is counted")
+
+	ld = makeLogs(nil, map[string]string{"request_id": "req-1"})
+	ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).SetTraceID(existing)
+	cfg.Override = true
+	records, _ = process(t, cfg, ld)
+	traceID, _ := tfotraceidprocessor.DeriveIDs("req-1", "")
+	assert.Equal(t, traceID, records.At(0).TraceID())
+}
@@ -0,0 +1,263 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package adaptive_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/adaptive"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+var tfoLabels = selfmetrics.Exporter(component.MustNewID("tfo"), pipeline.SignalTraces)
+
+func newLimiter(t *testing.T, minC, maxC int) *adaptive.Limiter {
+	t.Helper()
+	cfg := adaptive.NewDefaultConfig()
+	cfg.Enabled = true
+	cfg.MinConcurrency = minC
+	cfg.MaxConcurrency = maxC
+	require.NoError(t, cfg.Validate())
+	l, err := adaptive.New(cfg, componenttest.NewNopTelemetrySettings(), tfoLabels)
+	require.NoError(t, err)
+	return l
+}
+
+// acquireAll acquires the current limit's worth of tokens.
+func acquireAll(t *testing.T, l *adaptive.Limiter) []*adaptive.Token {
+	t.Helper()
+	tokens := make([]*adaptive.Token, l.Limit())
+	for i := range tokens {
+		tok, err := l.Acquire(context.Background())
+		require.NoError(t, err)
+		tokens[i] = tok
+	}
+	return tokens
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(*adaptive.Config)
+		wantErr string
+	}{
+		{name: "defaults", mutate: func(*adaptive.Config) {}},
+		{
+			name:    "zero min",
+			mutate:  func(c *adaptive.Config) { c.MinConcurrency = 0 },
+			wantErr: "min_concurrency must be positive",
+		},
+		{
+			name:    "max below min",
+			mutate:  func(c *adaptive.Config) { c.MinConcurrency, c.MaxConcurrency = 4, 2 },
+			wantErr: "max_concurrency must not be less",
+		},
+		{
+			name:    "zero latency threshold",
+			mutate:  func(c *adaptive.Config) { c.LatencyThreshold = 0 },
+			wantErr: "latency_threshold",
+		},
+		{
+			name:    "decrease factor of one",
+			mutate:  func(c *adaptive.Config) { c.DecreaseFactor = 1 },
+			wantErr: "decrease_factor",
+		},
+		{
+			name:    "zero decrease factor",
+			mutate:  func(c *adaptive.Config) { c.DecreaseFactor = 0 },
+			wantErr: "decrease_factor",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := adaptive.NewDefaultConfig()
+			cfg.Enabled = true
+			tt.mutate(&cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+
+	disabled := adaptive.Config{}
+	assert.NoError(t, disabled.Validate(), "a disabled config is not checked")
+}
+
+func TestLimiter_Nil(t *testing.T) {
+	var l *adaptive.Limiter
+	tok, err := l.Acquire(context.Background())
+	require.NoError(t, err)
+	assert.Nil(t, tok)
+	tok.Success()
+	tok.Dropped()
+	tok.Ignore()
+	assert.Zero(t, l.Limit())
+}
+
+func TestLimiter_AdditiveIncrease(t *testing.T) {
+	l := newLimiter(t, 1, 4)
+	assert.Equal(t, 1, l.Limit(), "the limit starts at min_concurrency")
+
+	for range 20 {
+		for _, tok := range acquireAll(t, l) {
+			tok.Success()
+		}
+		require.LessOrEqual(t, l.Limit(), 4)
+	}
+	assert.Equal(t, 4, l.Limit())
+}
+
+func TestLimiter_NoIncreaseWhenIdle(t *testing.T) {
+	l := newLimiter(t, 4, 8)
+	for range 20 {
+		tok, err := l.Acquire(context.Background())
+		require.NoError(t, err)
+		tok.Success()
+	}
+	assert.Equal(t, 4, l.Limit(), "one send at a time does not probe a limit of four")
+}
+
+func TestLimiter_MultiplicativeDecrease(t *testing.T) {
+	l := newLimiter(t, 1, 8)
+	for l.Limit() < 8 {
+		for _, tok := range acquireAll(t, l) {
+			tok.Success()
+		}
+	}
+
+	// A burst of throttled sends issued under the same limit counts once.
+	for _, tok := range acquireAll(t, l) {
+		tok.Dropped()
+	}
+	assert.Equal(t, 4, l.Limit())
+
+	tok, err := l.Acquire(context.Background())
+	require.NoError(t, err)
+	tok.Dropped()
+	assert.Equal(t, 2, l.Limit())
+
+	for range 5 {
+		tok, err := l.Acquire(context.Background())
+		require.NoError(t, err)
+		tok.Dropped()
+	}
+	assert.Equal(t, 1, l.Limit(), "the limit does not drop below min_concurrency")
+}
+
+func TestLimiter_SlowSuccessDecreases(t *testing.T) {
+	cfg := adaptive.NewDefaultConfig()
+	cfg.Enabled = true
+	cfg.MinConcurrency = 1
+	cfg.MaxConcurrency = 4
+	cfg.LatencyThreshold = 10 * time.Millisecond
+	l, err := adaptive.New(cfg, componenttest.NewNopTelemetrySettings(), tfoLabels)
+	require.NoError(t, err)
+
+	for l.Limit() < 4 {
+		for _, tok := range acquireAll(t, l) {
+			tok.Success()
+		}
+	}
+
+	tok, err := l.Acquire(context.Background())
+	require.NoError(t, err)
+	time.Sleep(20 * time.Millisecond)
+	tok.Success()
+	assert.Equal(t, 2, l.Limit())
+}
+
+func TestLimiter_IgnoreKeepsLimit(t *testing.T) {
+	l := newLimiter(t, 2, 4)
+	for _, tok := range acquireAll(t, l) {
+		tok.Ignore()
+	}
+	assert.Equal(t, 2, l.Limit())
+}
+
+func TestLimiter_BlocksAtLimit(t *testing.T) {
+	l := newLimiter(t, 1, 1)
+	first, err := l.Acquire(context.Background())
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = l.Acquire(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+
+	acquired := make(chan *adaptive.Token)
+	go func() {
+		tok, err := l.Acquire(context.Background())
+		assert.NoError(t, err)
+		acquired <- tok
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("second send admitted above the limit")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	first.Success()
+	first.Success() // completing twice releases once
+	select {
+	case tok := <-acquired:
+		tok.Success()
+	case <-time.After(time.Second):
+		t.Fatal("waiting send not admitted after release")
+	}
+}
+
+func TestLimiter_Metric(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { _ = tel.Shutdown(context.Background()) })
+
+	cfg := adaptive.NewDefaultConfig()
+	cfg.Enabled = true
+	cfg.MinConcurrency = 1
+	cfg.MaxConcurrency = 2
+	l, err := adaptive.New(cfg, tel.NewTelemetrySettings(), tfoLabels)
+	require.NoError(t, err)
+
+	tok, err := l.Acquire(context.Background())
+	require.NoError(t, err)
+	tok.Success()
+	require.Equal(t, 2, l.Limit())
+
+	m, err := tel.GetMetric("tfo_adaptive_concurrency_limit")
+	require.NoError(t, err)
+	gauge := m.Data.(metricdata.Gauge[int64])
+	require.Len(t, gauge.DataPoints, 1)
+	assert.Equal(t, int64(2), gauge.DataPoints[0].Value)
+	kind, _ := gauge.DataPoints[0].Attributes.Value(selfmetrics.KindKey)
+	assert.Equal(t, "exporter", kind.AsString())
+	typ, _ := gauge.DataPoints[0].Attributes.Value(selfmetrics.TypeKey)
+	assert.Equal(t, "tfo", typ.AsString())
+	signal, _ := gauge.DataPoints[0].Attributes.Value(selfmetrics.SignalKey)
+	assert.Equal(t, "traces", signal.AsString())
+}
@@ -0,0 +1,135 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package attrfilter_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/attrfilter"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+func newFilter(t *testing.T, cfg attrfilter.Config) *attrfilter.Filter {
+	t.Helper()
+	require.NoError(t, cfg.Validate())
+	f, err := attrfilter.NewFilter(cfg, componenttest.NewNopTelemetrySettings(),
+		selfmetrics.Exporter(component.MustNewID("tfo"), pipeline.SignalTraces))
+	require.NoError(t, err)
+	return f
+}
+
+func putAll(m pcommon.Map, keys ...string) {
+	for _, k := range keys {
+		m.PutStr(k, "v")
+	}
+}
+
+func keys(m pcommon.Map) []string {
+	var out []string
+	for k := range m.All() {
+		out = append(out, k)
+	}
+	return out
+}
+
+func TestConfig_Validate(t *testing.T) {
+	cfg := attrfilter.Config{}
+	assert.NoError(t, cfg.Validate())
+	assert.False(t, cfg.Enabled())
+
+	cfg.Record.Exclude = []string{"http.request.header.*", "db.statement"}
+	assert.NoError(t, cfg.Validate())
+	assert.True(t, cfg.Enabled())
+
+	cfg.Resource.Include = []string{"service.name", ""}
+	assert.ErrorContains(t, cfg.Validate(), "attributes.resource.include[1]: pattern must not be empty")
+
+	cfg.Resource.Include = []string{"service.*.name"}
+	assert.ErrorContains(t, cfg.Validate(), "may only end with *")
+}
+
+func TestFilter_Traces(t *testing.T) {
+	f := newFilter(t, attrfilter.Config{
+		Resource: attrfilter.Rules{Include: []string{"service.*", "host.name"}, Exclude: []string{"service.instance.id"}},
+		Record:   attrfilter.Rules{Exclude: []string{"http.request.header.*"}},
+	})
+
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	putAll(rs.Resource().Attributes(), "service.name", "service.instance.id", "host.name", "process.command_line")
+	span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	putAll(span.Attributes(), "http.route", "http.request.header.cookie")
+	putAll(span.Events().AppendEmpty().Attributes(), "http.request.header.authorization", "exception.type")
+	putAll(span.Links().AppendEmpty().Attributes(), "http.request.header.x")
+
+	assert.Equal(t, 5, f.ApplyTraces(context.Background(), td))
+	assert.ElementsMatch(t, []string{"service.name", "host.name"}, keys(rs.Resource().Attributes()))
+	assert.Equal(t, []string{"http.route"}, keys(span.Attributes()))
+	assert.Equal(t, []string{"exception.type"}, keys(span.Events().At(0).Attributes()))
+	assert.Empty(t, keys(span.Links().At(0).Attributes()))
+}
+
+func TestFilter_Metrics(t *testing.T) {
+	f := newFilter(t, attrfilter.Config{Record: attrfilter.Rules{Include: []string{"route"}}})
+
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	putAll(rm.Resource().Attributes(), "service.name")
+	ms := rm.ScopeMetrics().AppendEmpty().Metrics()
+	sum := ms.AppendEmpty().SetEmptySum().DataPoints().AppendEmpty()
+	putAll(sum.Attributes(), "route", "user.id")
+	hist := ms.AppendEmpty().SetEmptyHistogram().DataPoints().AppendEmpty()
+	putAll(hist.Attributes(), "route", "pod")
+
+	assert.Equal(t, 2, f.ApplyMetrics(context.Background(), md))
+	assert.Equal(t, []string{"service.name"}, keys(rm.Resource().Attributes()), "resource rules are independent")
+	assert.Equal(t, []string{"route"}, keys(sum.Attributes()))
+	assert.Equal(t, []string{"route"}, keys(hist.Attributes()))
+}
+
+func TestFilter_Logs(t *testing.T) {
+	f := newFilter(t, attrfilter.Config{Resource: attrfilter.Rules{Exclude: []string{"k8s.*"}}})
+
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	putAll(rl.Resource().Attributes(), "k8s.pod.name", "k8s.node.name", "service.name")
+	lr := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	putAll(lr.Attributes(), "k8s.pod.name")
+
+	assert.Equal(t, 2, f.ApplyLogs(context.Background(), ld))
+	assert.Equal(t, []string{"service.name"}, keys(rl.Resource().Attributes()))
+	assert.Equal(t, []string{"k8s.pod.name"}, keys(lr.Attributes()), "record rules are independent")
+}
+
+func TestFilter_Nil(t *testing.T) {
+	var f *attrfilter.Filter
+	td := ptrace.NewTraces()
+	putAll(td.ResourceSpans().AppendEmpty().Resource().Attributes(), "a")
+	assert.Zero(t, f.ApplyTraces(context.Background(), td))
+	assert.Zero(t, f.ApplyMetrics(context.Background(), pmetric.NewMetrics()))
+	assert.Zero(t, f.ApplyLogs(context.Background(), plog.NewLogs()))
+	assert.Equal(t, 1, td.ResourceSpans().At(0).Resource().Attributes().Len())
+}
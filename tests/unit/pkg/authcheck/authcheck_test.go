@@ -0,0 +1,195 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package authcheck_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/authcheck"
+)
+
+const (
+	goodKeyID     = "tfk_onboarding"
+	goodKeySecret = "tfs_onboarding"
+)
+
+// platform accepts goodKeyID/goodKeySecret on the validation and export
+// endpoints and rejects anything else.
+func platform(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	authorized := func(r *http.Request) bool {
+		return r.Header.Get("X-TelemetryFlow-Key-ID") == goodKeyID &&
+			r.Header.Get("X-TelemetryFlow-Key-Secret") == goodKeySecret
+	}
+	mux.HandleFunc("/v1/auth/validate", func(w http.ResponseWriter, r *http.Request) {
+		if !authorized(r) {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"principal":"acme/onboarding"}`))
+	})
+	mux.HandleFunc("/v2/traces", func(w http.ResponseWriter, r *http.Request) {
+		if !authorized(r) {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func writeConfig(t *testing.T, yaml string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(yaml), 0o600))
+	return path
+}
+
+func platformConfig(t *testing.T, endpoint, secret string) string {
+	return writeConfig(t, fmt.Sprintf(`
+extensions:
+  tfoauth:
+    api_key_id: %[2]s
+    api_key_secret: %[3]s
+    validation_endpoint: %[1]s/v1/auth/validate
+exporters:
+  tfo:
+    endpoint: %[1]s
+    auth:
+      extension: tfoauth
+  tfo/other:
+    endpoint: %[1]s
+`, endpoint, goodKeyID, secret))
+}
+
+func TestRun_Pass(t *testing.T) {
+	srv := platform(t)
+	report, err := authcheck.Run(context.Background(), authcheck.Options{
+		ConfigURIs: []string{platformConfig(t, srv.URL, goodKeySecret)},
+		TestExport: true,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, report.Steps, 2, "only the exporter using the extension is tested")
+	assert.True(t, report.Passed())
+	assert.Equal(t, component.MustNewID("tfoauth"), report.Extension)
+	assert.Equal(t, "tfk_onbo****", report.APIKeyID)
+	assert.Equal(t, "validate", report.Steps[0].Name)
+	assert.Equal(t, "acme/onboarding", report.Steps[0].Principal)
+	assert.Equal(t, "export tfo", report.Steps[1].Name)
+	assert.Equal(t, srv.URL+"/v2/traces", report.Steps[1].Target)
+
+	var out bytes.Buffer
+	report.Print(&out)
+	assert.Contains(t, out.String(), "principal: acme/onboarding")
+	assert.Contains(t, out.String(), "Result: PASS")
+	assert.NotContains(t, out.String(), goodKeySecret)
+}
+
+func TestRun_RejectedCredentials(t *testing.T) {
+	srv := platform(t)
+	report, err := authcheck.Run(context.Background(), authcheck.Options{
+		ConfigURIs: []string{platformConfig(t, srv.URL, "tfs_wrong")},
+		TestExport: true,
+	})
+	require.NoError(t, err)
+
+	assert.False(t, report.Passed())
+	require.Len(t, report.Steps, 2)
+	assert.ErrorContains(t, report.Steps[0].Err, "invalid API credentials")
+	assert.ErrorContains(t, report.Steps[1].Err, "credentials rejected (status 403)")
+
+	var out bytes.Buffer
+	report.Print(&out)
+	assert.Contains(t, out.String(), "FAIL")
+	assert.Contains(t, out.String(), "Result: FAIL")
+}
+
+func TestRun_ValidationOnly(t *testing.T) {
+	srv := platform(t)
+	report, err := authcheck.Run(context.Background(), authcheck.Options{
+		ConfigURIs: []string{platformConfig(t, srv.URL, goodKeySecret)},
+	})
+	require.NoError(t, err)
+	require.Len(t, report.Steps, 1)
+	assert.True(t, report.Passed())
+}
+
+func TestRun_ExtensionSelection(t *testing.T) {
+	path := writeConfig(t, `
+extensions:
+  tfoauth/a:
+    api_key_id: tfk_aaaaaaaa
+    api_key_secret: tfs_aaaaaaaa
+    validation_endpoint: http://127.0.0.1:1/validate
+  tfoauth/b:
+    api_key_id: tfk_bbbbbbbb
+    api_key_secret: tfs_bbbbbbbb
+`)
+
+	_, err := authcheck.Run(context.Background(), authcheck.Options{ConfigURIs: []string{path}})
+	assert.ErrorContains(t, err, "2 tfoauth extensions")
+
+	_, err = authcheck.Run(context.Background(), authcheck.Options{
+		ConfigURIs: []string{path},
+		Extension:  component.MustNewIDWithName("tfoauth", "c"),
+	})
+	assert.ErrorContains(t, err, "tfoauth/c is not defined")
+
+	_, err = authcheck.Run(context.Background(), authcheck.Options{
+		ConfigURIs: []string{path},
+		Extension:  component.MustNewIDWithName("tfoauth", "b"),
+	})
+	assert.ErrorContains(t, err, "no validation_endpoint")
+
+	report, err := authcheck.Run(context.Background(), authcheck.Options{
+		ConfigURIs: []string{path},
+		Extension:  component.MustNewIDWithName("tfoauth", "b"),
+		TestExport: true,
+	})
+	require.NoError(t, err)
+	require.Len(t, report.Steps, 1)
+	assert.ErrorContains(t, report.Steps[0].Err, "no tfo exporter uses extension tfoauth/b")
+}
+
+func TestRun_ConfigErrors(t *testing.T) {
+	_, err := authcheck.Run(context.Background(), authcheck.Options{})
+	assert.ErrorContains(t, err, "at least one config file")
+
+	_, err = authcheck.Run(context.Background(), authcheck.Options{
+		ConfigURIs: []string{writeConfig(t, "receivers: {}\n")},
+	})
+	assert.ErrorContains(t, err, "no tfoauth extension")
+
+	_, err = authcheck.Run(context.Background(), authcheck.Options{
+		ConfigURIs: []string{writeConfig(t, "extensions:\n  tfoauth:\n    api_key_id: bad\n    api_key_secret: tfs_x\n")},
+	})
+	assert.ErrorContains(t, err, "tfk_")
+}
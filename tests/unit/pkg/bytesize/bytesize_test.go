@@ -0,0 +1,91 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package bytesize_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/confmap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/bytesize"
+)
+
+func TestParse(t *testing.T) {
+	tests := map[string]bytesize.Size{
+		"0":        0,
+		"4":        4,
+		"512B":     512,
+		"512KiB":   512 << 10,
+		"10MB":     10_000_000,
+		"10mb":     10_000_000,
+		"1.5GiB":   3 << 29,
+		"0.5KB":    500,
+		" 8 MiB ":  8 << 20,
+		"2TiB":     2 << 40,
+		"1gb":      1_000_000_000,
+		"16777216": 16 << 20,
+	}
+	for in, want := range tests {
+		got, err := bytesize.Parse(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+
+	for _, in := range []string{"", "MiB", "-1MiB", "1.5", "0.1B", "1.2.3KB", "10 MBs", "1PiB", "9999999TiB"} {
+		_, err := bytesize.Parse(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestSize_String(t *testing.T) {
+	assert.Equal(t, "8MiB", bytesize.Size(8<<20).String())
+	assert.Equal(t, "1536MiB", bytesize.Size(1536<<20).String())
+	assert.Equal(t, "1000B", bytesize.KB.String())
+	assert.Equal(t, "0B", bytesize.Size(0).String())
+
+	text, err := bytesize.GiB.MarshalText()
+	require.NoError(t, err)
+	assert.Equal(t, "1GiB", string(text))
+}
+
+type sizeConfig struct {
+	Limit bytesize.Size `mapstructure:"limit"`
+}
+
+func TestSize_Unmarshal(t *testing.T) {
+	tests := map[string]struct {
+		in   any
+		want bytesize.Size
+	}{
+		"bare number": {in: 4, want: 4},
+		"numeric":     {in: "4096", want: 4 << 10},
+		"binary unit": {in: "512KiB", want: 512 << 10},
+		"si unit":     {in: "10MB", want: 10_000_000},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			var cfg sizeConfig
+			conf := confmap.NewFromStringMap(map[string]any{"limit": tt.in})
+			require.NoError(t, conf.Unmarshal(&cfg))
+			assert.Equal(t, tt.want, cfg.Limit)
+		})
+	}
+
+	var cfg sizeConfig
+	conf := confmap.NewFromStringMap(map[string]any{"limit": "lots"})
+	assert.ErrorContains(t, conf.Unmarshal(&cfg), `invalid size "lots"`)
+}
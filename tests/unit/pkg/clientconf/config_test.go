@@ -0,0 +1,112 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package clientconf_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config/configcompression"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/clientconf"
+)
+
+func TestNewDefaultClientConfig(t *testing.T) {
+	cfg := clientconf.NewDefaultClientConfig("https://api.telemetryflow.id")
+
+	assert.Equal(t, "https://api.telemetryflow.id", cfg.Endpoint)
+	assert.Equal(t, clientconf.DefaultTimeout, cfg.Timeout)
+	assert.NoError(t, cfg.Validate())
+}
+
+func TestClientConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *clientconf.ClientConfig)
+		wantErr string
+	}{
+		{
+			name:   "empty endpoint is allowed",
+			mutate: func(cfg *clientconf.ClientConfig) { cfg.Endpoint = "" },
+		},
+		{
+			name:    "unsupported endpoint scheme",
+			mutate:  func(cfg *clientconf.ClientConfig) { cfg.Endpoint = "ftp://api.telemetryflow.id" },
+			wantErr: "invalid endpoint",
+		},
+		{
+			name:    "endpoint without host",
+			mutate:  func(cfg *clientconf.ClientConfig) { cfg.Endpoint = "https://" },
+			wantErr: "missing host",
+		},
+		{
+			name:    "invalid proxy url",
+			mutate:  func(cfg *clientconf.ClientConfig) { cfg.ProxyURL = "proxy.internal:3128" },
+			wantErr: "invalid proxy_url",
+		},
+		{
+			name:    "negative timeout",
+			mutate:  func(cfg *clientconf.ClientConfig) { cfg.Timeout = -time.Second },
+			wantErr: "timeout must not be negative",
+		},
+		{
+			name: "invalid compression level",
+			mutate: func(cfg *clientconf.ClientConfig) {
+				cfg.Compression = configcompression.TypeGzip
+				cfg.CompressionParams.Level = 42
+			},
+			wantErr: "unsupported parameters",
+		},
+		{
+			name: "proxy and compression overrides",
+			mutate: func(cfg *clientconf.ClientConfig) {
+				cfg.ProxyURL = "http://proxy.internal:3128"
+				cfg.Compression = configcompression.TypeZstd
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := clientconf.NewDefaultClientConfig("https://api.telemetryflow.id")
+			tt.mutate(&cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestClientConfig_URL(t *testing.T) {
+	cfg := clientconf.NewDefaultClientConfig("https://api.telemetryflow.id/")
+
+	assert.Equal(t, "https://api.telemetryflow.id/v2/traces", cfg.URL("/v2/traces"))
+	assert.Equal(t, "https://api.telemetryflow.id/v2/logs", cfg.URL("v2/logs"))
+	assert.Equal(t, "https://api.telemetryflow.id/", cfg.URL(""))
+}
+
+func TestClientConfig_Host(t *testing.T) {
+	cfg := clientconf.NewDefaultClientConfig("https://api.telemetryflow.id:8443/otlp")
+	assert.Equal(t, "api.telemetryflow.id:8443", cfg.Host())
+
+	cfg = clientconf.NewDefaultClientConfig("not a url")
+	assert.Equal(t, "not a url", cfg.Host())
+}
@@ -0,0 +1,334 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package collector_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/collector"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/registry"
+)
+
+func testTraces() ptrace.Traces {
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "embedded")
+	rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("op")
+	return td
+}
+
+func testMetrics() pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	m := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("requests")
+	m.SetEmptyGauge().DataPoints().AppendEmpty().SetIntValue(1)
+	return md
+}
+
+func testLogs() plog.Logs {
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty().Body().SetStr("hello")
+	return ld
+}
+
+func startCollector(t *testing.T, opts collector.Options) *collector.Collector {
+	t.Helper()
+	col, err := collector.New(opts)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	require.NoError(t, col.Start(ctx))
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		assert.NoError(t, col.Shutdown(ctx))
+	})
+	return col
+}
+
+func TestCollector_InProcessRoundTrip(t *testing.T) {
+	traces := new(consumertest.TracesSink)
+	metrics := new(consumertest.MetricsSink)
+	logs := new(consumertest.LogsSink)
+	batch := component.MustNewID("batch")
+
+	col := startCollector(t, collector.Options{
+		Processors: map[component.ID]collector.ComponentConfig{
+			batch: {"timeout": "10ms"},
+		},
+		Pipelines: map[pipeline.ID]collector.Pipeline{
+			pipeline.NewID(pipeline.SignalTraces): {
+				Receivers:  []component.ID{collector.SourceID("app")},
+				Processors: []component.ID{batch},
+				Exporters:  []component.ID{collector.SinkID("out")},
+			},
+			pipeline.NewID(pipeline.SignalMetrics): {
+				Receivers: []component.ID{collector.SourceID("app")},
+				Exporters: []component.ID{collector.SinkID("out")},
+			},
+			pipeline.NewID(pipeline.SignalLogs): {
+				Receivers: []component.ID{collector.SourceID("app")},
+				Exporters: []component.ID{collector.SinkID("out")},
+			},
+		},
+		Sources: []string{"app"},
+		Sinks: map[string]collector.Sink{
+			"out": {Traces: traces, Metrics: metrics, Logs: logs},
+		},
+	})
+
+	src := col.Source("app")
+	require.NotNil(t, src)
+	ctx := context.Background()
+	require.NoError(t, src.ConsumeTraces(ctx, testTraces()))
+	require.NoError(t, src.ConsumeMetrics(ctx, testMetrics()))
+	require.NoError(t, src.ConsumeLogs(ctx, testLogs()))
+
+	require.Eventually(t, func() bool { return traces.SpanCount() == 1 }, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 1, metrics.DataPointCount())
+	assert.Equal(t, 1, logs.LogRecordCount())
+	assert.Equal(t, "op", traces.AllTraces()[0].ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name())
+}
+
+func TestCollector_SourceNotConnected(t *testing.T) {
+	col, err := collector.New(collector.Options{
+		Pipelines: map[pipeline.ID]collector.Pipeline{
+			pipeline.NewID(pipeline.SignalTraces): {
+				Receivers: []component.ID{collector.SourceID("app")},
+				Exporters: []component.ID{component.MustNewID("nop")},
+			},
+		},
+		Exporters: map[component.ID]collector.ComponentConfig{component.MustNewID("nop"): nil},
+		Sources:   []string{"app"},
+	})
+	require.NoError(t, err)
+
+	src := col.Source("app")
+	require.NotNil(t, src)
+	assert.ErrorIs(t, src.ConsumeTraces(context.Background(), testTraces()), collector.ErrSourceNotConnected, "not started")
+	assert.Nil(t, col.Source("missing"))
+	assert.NoError(t, col.Shutdown(context.Background()), "shutdown without start")
+}
+
+func TestCollector_StartErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		opts collector.Options
+	}{
+		{
+			name: "unknown component type",
+			opts: collector.Options{
+				Exporters: map[component.ID]collector.ComponentConfig{component.MustNewID("doesnotexist"): nil},
+				Pipelines: map[pipeline.ID]collector.Pipeline{
+					pipeline.NewID(pipeline.SignalTraces): {
+						Receivers: []component.ID{collector.SourceID("app")},
+						Exporters: []component.ID{component.MustNewID("doesnotexist")},
+					},
+				},
+				Sources: []string{"app"},
+			},
+		},
+		{
+			name: "sink without consumer for the pipeline signal",
+			opts: collector.Options{
+				Pipelines: map[pipeline.ID]collector.Pipeline{
+					pipeline.NewID(pipeline.SignalLogs): {
+						Receivers: []component.ID{collector.SourceID("app")},
+						Exporters: []component.ID{collector.SinkID("out")},
+					},
+				},
+				Sources: []string{"app"},
+				Sinks:   map[string]collector.Sink{"out": {Traces: new(consumertest.TracesSink)}},
+			},
+		},
+		{
+			name: "pipeline references an undeclared source",
+			opts: collector.Options{
+				Pipelines: map[pipeline.ID]collector.Pipeline{
+					pipeline.NewID(pipeline.SignalTraces): {
+						Receivers: []component.ID{collector.SourceID("other")},
+						Exporters: []component.ID{collector.SinkID("out")},
+					},
+				},
+				Sources: []string{"app"},
+				Sinks:   map[string]collector.Sink{"out": {Traces: new(consumertest.TracesSink)}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			col, err := collector.New(tt.opts)
+			require.NoError(t, err)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			require.Error(t, col.Start(ctx))
+		})
+	}
+}
+
+func TestNew_DuplicateSource(t *testing.T) {
+	_, err := collector.New(collector.Options{Sources: []string{"app", "app"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `duplicate source "app"`)
+}
+
+func TestNew_DoesNotModifyRegistry(t *testing.T) {
+	reg := registry.Default()
+	_, err := collector.New(collector.Options{Registry: reg})
+	require.NoError(t, err)
+
+	factories, err := reg.Factories()
+	require.NoError(t, err)
+	assert.NotContains(t, factories.Receivers, component.MustNewType(collector.InProcessType))
+	assert.NotContains(t, factories.Exporters, component.MustNewType(collector.InProcessType))
+
+	_, err = collector.New(collector.Options{Registry: reg})
+	require.NoError(t, err, "the same registry can back several collectors")
+}
+
+func TestCollector_MultipleInstances(t *testing.T) {
+	newOpts := func(sink *consumertest.TracesSink) collector.Options {
+		return collector.Options{
+			Pipelines: map[pipeline.ID]collector.Pipeline{
+				pipeline.NewID(pipeline.SignalTraces): {
+					Receivers: []component.ID{collector.SourceID("app")},
+					Exporters: []component.ID{collector.SinkID("out")},
+				},
+			},
+			Sources: []string{"app"},
+			Sinks:   map[string]collector.Sink{"out": {Traces: sink}},
+		}
+	}
+	sinkA, sinkB := new(consumertest.TracesSink), new(consumertest.TracesSink)
+	a := startCollector(t, newOpts(sinkA))
+	startCollector(t, newOpts(sinkB))
+
+	require.NoError(t, a.Source("app").ConsumeTraces(context.Background(), testTraces()))
+	assert.Equal(t, 1, sinkA.SpanCount())
+	assert.Equal(t, 0, sinkB.SpanCount(), "instances do not share in-process components")
+}
+
+func TestCollector_IndependentPipelinesPerSignal(t *testing.T) {
+	general := new(consumertest.LogsSink)
+	security := new(consumertest.LogsSink)
+	tag := component.MustNewIDWithName("attributes", "security")
+
+	col := startCollector(t, collector.Options{
+		Processors: map[component.ID]collector.ComponentConfig{
+			tag: {"actions": []any{
+				map[string]any{"key": "pipeline", "value": "security", "action": "insert"},
+			}},
+		},
+		Pipelines: map[pipeline.ID]collector.Pipeline{
+			pipeline.NewIDWithName(pipeline.SignalLogs, "default"): {
+				Receivers: []component.ID{collector.SourceID("app")},
+				Exporters: []component.ID{collector.SinkID("default")},
+			},
+			pipeline.NewIDWithName(pipeline.SignalLogs, "security"): {
+				Receivers:  []component.ID{collector.SourceID("app")},
+				Processors: []component.ID{tag},
+				Exporters:  []component.ID{collector.SinkID("security")},
+			},
+		},
+		Sources: []string{"app"},
+		Sinks: map[string]collector.Sink{
+			"default":  {Logs: general},
+			"security": {Logs: security},
+		},
+	})
+
+	require.NoError(t, col.Source("app").ConsumeLogs(context.Background(), testLogs()))
+
+	require.Equal(t, 1, general.LogRecordCount())
+	require.Equal(t, 1, security.LogRecordCount())
+	_, tagged := general.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().Get("pipeline")
+	assert.False(t, tagged, "processors of one pipeline must not affect another")
+	v, tagged := security.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().Get("pipeline")
+	require.True(t, tagged)
+	assert.Equal(t, "security", v.Str())
+}
+
+func TestCollector_PushIntoNamedPipeline(t *testing.T) {
+	general := new(consumertest.LogsSink)
+	security := new(consumertest.LogsSink)
+	spans := new(consumertest.TracesSink)
+	tag := component.MustNewIDWithName("attributes", "security")
+	defaultLogs := pipeline.NewIDWithName(pipeline.SignalLogs, "default")
+	securityLogs := pipeline.NewIDWithName(pipeline.SignalLogs, "security")
+
+	col := startCollector(t, collector.Options{
+		Processors: map[component.ID]collector.ComponentConfig{
+			tag: {"actions": []any{
+				map[string]any{"key": "pipeline", "value": "security", "action": "insert"},
+			}},
+		},
+		Pipelines: map[pipeline.ID]collector.Pipeline{
+			defaultLogs: {
+				Receivers: []component.ID{collector.SourceID("app")},
+				Exporters: []component.ID{collector.SinkID("default")},
+			},
+			// Fed only through Collector.Pipeline, so no receivers.
+			securityLogs: {
+				Processors: []component.ID{tag},
+				Exporters:  []component.ID{collector.SinkID("security")},
+			},
+			pipeline.NewID(pipeline.SignalTraces): {
+				Exporters: []component.ID{collector.SinkID("spans")},
+			},
+		},
+		Sources: []string{"app"},
+		Sinks: map[string]collector.Sink{
+			"default":  {Logs: general},
+			"security": {Logs: security},
+			"spans":    {Traces: spans},
+		},
+	})
+
+	ctx := context.Background()
+	require.NoError(t, col.Pipeline(securityLogs).ConsumeLogs(ctx, testLogs()))
+	require.Equal(t, 1, security.LogRecordCount())
+	assert.Zero(t, general.LogRecordCount(), "other pipelines of the signal are not fed")
+	v, tagged := security.AllLogs()[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(0).Attributes().Get("pipeline")
+	require.True(t, tagged, "processors of the pipeline apply")
+	assert.Equal(t, "security", v.Str())
+
+	require.NoError(t, col.Pipeline(defaultLogs).ConsumeLogs(ctx, testLogs()))
+	assert.Equal(t, 1, general.LogRecordCount())
+	assert.Equal(t, 1, security.LogRecordCount())
+
+	require.NoError(t, col.Pipeline(pipeline.NewID(pipeline.SignalTraces)).ConsumeTraces(ctx, testTraces()))
+	assert.Equal(t, 1, spans.SpanCount())
+
+	assert.Nil(t, col.Pipeline(pipeline.NewIDWithName(pipeline.SignalLogs, "missing")))
+}
+
+func TestNew_ReservedSourceName(t *testing.T) {
+	_, err := collector.New(collector.Options{Sources: []string{"pipeline.logs"}})
+	require.EqualError(t, err, `source name "pipeline.logs" is reserved: names starting with "pipeline." feed a single pipeline`)
+}
@@ -0,0 +1,150 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package collector_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pipeline"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/collector"
+)
+
+// fileLogOptions tails *.log files in dir with multiline stitching and a
+// regex parser, and *.json files with a JSON parser, checkpointing both
+// in storage.
+func fileLogOptions(dir, storage string, sink *consumertest.LogsSink) collector.Options {
+	fileStorage := component.MustNewID("file_storage")
+	text := component.MustNewIDWithName("file_log", "text")
+	json := component.MustNewIDWithName("file_log", "json")
+	common := func(include string) collector.ComponentConfig {
+		return collector.ComponentConfig{
+			"include":            []any{filepath.Join(dir, include)},
+			"start_at":           "beginning",
+			"storage":            fileStorage.String(),
+			"poll_interval":      "10ms",
+			"force_flush_period": "50ms",
+		}
+	}
+	textCfg := common("*.log")
+	textCfg["multiline"] = map[string]any{"line_start_pattern": `^\d{4}-\d{2}-\d{2}T`}
+	textCfg["operators"] = []any{map[string]any{
+		"type":      "regex_parser",
+		"regex":     `^(?P<time>\S+) (?P<level>[A-Z]+) (?P<message>(?s:.*))$`,
+		"timestamp": map[string]any{"parse_from": "attributes.time", "layout_type": "gotime", "layout": time.RFC3339},
+		"severity":  map[string]any{"parse_from": "attributes.level"},
+	}}
+	jsonCfg := common("*.json")
+	jsonCfg["operators"] = []any{map[string]any{"type": "json_parser"}}
+
+	return collector.Options{
+		Extensions: map[component.ID]collector.ComponentConfig{
+			fileStorage: {"directory": storage},
+		},
+		ServiceExtensions: []component.ID{fileStorage},
+		Receivers: map[component.ID]collector.ComponentConfig{
+			text: textCfg,
+			json: jsonCfg,
+		},
+		Pipelines: map[pipeline.ID]collector.Pipeline{
+			pipeline.NewID(pipeline.SignalLogs): {
+				Receivers: []component.ID{text, json},
+				Exporters: []component.ID{collector.SinkID("out")},
+			},
+		},
+		Sinks: map[string]collector.Sink{"out": {Logs: sink}},
+	}
+}
+
+// logRecords returns the log records received by sink, keyed by their
+// message attribute.
+func logRecords(sink *consumertest.LogsSink) map[string]plog.LogRecord {
+	records := make(map[string]plog.LogRecord)
+	for _, ld := range sink.AllLogs() {
+		for i := 0; i < ld.ResourceLogs().Len(); i++ {
+			sls := ld.ResourceLogs().At(i).ScopeLogs()
+			for j := 0; j < sls.Len(); j++ {
+				lrs := sls.At(j).LogRecords()
+				for k := 0; k < lrs.Len(); k++ {
+					msg, _ := lrs.At(k).Attributes().Get("message")
+					records[msg.Str()] = lrs.At(k)
+				}
+			}
+		}
+	}
+	return records
+}
+
+func waitLogRecords(t *testing.T, sink *consumertest.LogsSink, n int) map[string]plog.LogRecord {
+	t.Helper()
+	require.Eventually(t, func() bool { return sink.LogRecordCount() >= n }, 10*time.Second, 10*time.Millisecond)
+	assert.Never(t, func() bool { return sink.LogRecordCount() > n }, 200*time.Millisecond, 10*time.Millisecond)
+	return logRecords(sink)
+}
+
+func TestCollector_FileLog(t *testing.T) {
+	dir, storage := t.TempDir(), t.TempDir()
+	logFile := filepath.Join(dir, "app.log")
+	require.NoError(t, os.WriteFile(logFile, []byte(
+		"2026-10-17T08:00:00Z INFO started\n"+
+			"2026-10-17T08:00:01Z ERROR request failed\n"+
+			"panic: boom\n"+
+			"\tat main.go:12\n"+
+			"2026-10-17T08:00:02Z INFO recovered\n"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "audit.json"),
+		[]byte(`{"message": "login", "user": "ops"}`+"\n"), 0o600))
+
+	sink := new(consumertest.LogsSink)
+	col := startCollector(t, fileLogOptions(dir, storage, sink))
+	records := waitLogRecords(t, sink, 4)
+
+	require.Contains(t, records, "request failed\npanic: boom\n\tat main.go:12")
+	failed := records["request failed\npanic: boom\n\tat main.go:12"]
+	assert.Equal(t, plog.SeverityNumberError, failed.SeverityNumber())
+	assert.Equal(t, time.Date(2026, 10, 17, 8, 0, 1, 0, time.UTC), failed.Timestamp().AsTime())
+	assert.Contains(t, records, "started")
+	assert.Contains(t, records, "recovered")
+	require.Contains(t, records, "login")
+	user, _ := records["login"].Attributes().Get("user")
+	assert.Equal(t, "ops", user.Str())
+
+	// A restarted collector resumes from the checkpoints in storage.
+	require.NoError(t, col.Shutdown(t.Context()))
+	f, err := os.OpenFile(logFile, os.O_APPEND|os.O_WRONLY, 0)
+	require.NoError(t, err)
+	_, err = f.WriteString("2026-10-17T08:00:03Z WARN after restart\n")
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	sink = new(consumertest.LogsSink)
+	startCollector(t, fileLogOptions(dir, storage, sink))
+	records = waitLogRecords(t, sink, 1)
+	assert.Contains(t, records, "after restart")
+
+	// A rotated file is not read again, and its successor is read from
+	// the start.
+	require.NoError(t, os.Rename(logFile, logFile+".1"))
+	require.NoError(t, os.WriteFile(logFile, []byte("2026-10-17T08:00:04Z INFO after rotation\n"), 0o600))
+	records = waitLogRecords(t, sink, 2)
+	assert.Contains(t, records, "after rotation")
+}
@@ -0,0 +1,311 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package crashguard_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/consumer/xconsumer"
+	"go.opentelemetry.io/collector/exporter"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/exporter/xexporter"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor"
+	"go.opentelemetry.io/collector/processor/processortest"
+	"go.opentelemetry.io/collector/processor/xprocessor"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/crashguard"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/registry"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+var panickyType = component.MustNewType("panicky")
+
+// panicky is a traces processor panicking on spans named "boom" and
+// counting its starts.
+type panicky struct {
+	component.ShutdownFunc
+	consumer.Traces
+	starts *atomic.Int32
+}
+
+func (p *panicky) Start(context.Context, component.Host) error {
+	p.starts.Add(1)
+	return nil
+}
+
+func newPanickyFactory(starts *atomic.Int32) processor.Factory {
+	return processor.NewFactory(panickyType, func() component.Config { return &struct{}{} },
+		processor.WithTraces(func(_ context.Context, _ processor.Settings, _ component.Config, next consumer.Traces) (processor.Traces, error) {
+			tc, err := consumer.NewTraces(func(ctx context.Context, td ptrace.Traces) error {
+				if td.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0).Name() == "boom" {
+					panic("boom")
+				}
+				return next.ConsumeTraces(ctx, td)
+			})
+			return &panicky{Traces: tc, starts: starts}, err
+		}, component.StabilityLevelDevelopment))
+}
+
+func span(name string) ptrace.Traces {
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName(name)
+	return td
+}
+
+// startPanicky creates and starts a panicky processor guarded by g.
+func startPanicky(t *testing.T, g *crashguard.Guard, set processor.Settings, sink consumer.Traces) (processor.Traces, *atomic.Int32) {
+	t.Helper()
+	starts := new(atomic.Int32)
+	p, err := g.WrapProcessor(newPanickyFactory(starts)).CreateTraces(context.Background(), set, &struct{}{}, sink)
+	require.NoError(t, err)
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { _ = p.Shutdown(context.Background()) })
+	return p, starts
+}
+
+func TestGuard_RecoversPanic(t *testing.T) {
+	dir := t.TempDir()
+	g := crashguard.New()
+	g.Configure(crashguard.Config{Enabled: true, DumpDir: dir, MaxDumps: 2})
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { _ = tel.Shutdown(context.Background()) })
+	set := processortest.NewNopSettings(panickyType)
+	set.TelemetrySettings = tel.NewTelemetrySettings()
+	sink := new(consumertest.TracesSink)
+	p, _ := startPanicky(t, g, set, sink)
+
+	for range 3 {
+		err := p.ConsumeTraces(context.Background(), span("boom"))
+		require.Error(t, err)
+		assert.True(t, consumererror.IsPermanent(err), "the batch must not be retried into the same panic")
+		assert.Contains(t, err.Error(), "processor panicky panicked: boom")
+	}
+	require.NoError(t, p.ConsumeTraces(context.Background(), span("ok")))
+	assert.Equal(t, 1, sink.SpanCount())
+
+	m, err := tel.GetMetric(selfmetrics.ComponentPanics)
+	require.NoError(t, err)
+	points := m.Data.(metricdata.Sum[int64]).DataPoints
+	require.Len(t, points, 1)
+	assert.Equal(t, int64(3), points[0].Value)
+
+	dumps, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, dumps, 2, "older dumps are pruned")
+	content, err := os.ReadFile(filepath.Join(dir, dumps[0].Name()))
+	require.NoError(t, err)
+	assert.True(t, strings.HasSuffix(dumps[0].Name(), "-processor-panicky.stack"), dumps[0].Name())
+	assert.Contains(t, string(content), "component: processor/panicky")
+	assert.Contains(t, string(content), "panic: boom")
+	assert.Contains(t, string(content), "goroutine")
+}
+
+func TestGuard_Disabled(t *testing.T) {
+	g := crashguard.New()
+	g.Configure(crashguard.Config{MaxDumps: 1})
+	p, _ := startPanicky(t, g, processortest.NewNopSettings(panickyType), consumertest.NewNop())
+
+	assert.PanicsWithValue(t, "boom", func() { _ = p.ConsumeTraces(context.Background(), span("boom")) })
+}
+
+func TestGuard_Restart(t *testing.T) {
+	g := crashguard.New()
+	g.Configure(crashguard.Config{Enabled: true, Restart: true, MaxDumps: 1})
+	sink := new(consumertest.TracesSink)
+	p, starts := startPanicky(t, g, processortest.NewNopSettings(panickyType), sink)
+
+	require.Error(t, p.ConsumeTraces(context.Background(), span("boom")))
+	require.Eventually(t, func() bool {
+		return starts.Load() == 2 && p.ConsumeTraces(context.Background(), span("ok")) == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	assert.Equal(t, 1, sink.SpanCount())
+}
+
+func TestGuard_RestartSkippedAfterShutdown(t *testing.T) {
+	g := crashguard.New()
+	g.Configure(crashguard.Config{Enabled: true, Restart: true, MaxDumps: 1})
+	starts := new(atomic.Int32)
+	p, err := g.WrapProcessor(newPanickyFactory(starts)).CreateTraces(context.Background(),
+		processortest.NewNopSettings(panickyType), &struct{}{}, consumertest.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, p.Shutdown(context.Background()))
+
+	require.Error(t, p.ConsumeTraces(context.Background(), span("boom")))
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int32(1), starts.Load())
+}
+
+func TestGuard_ShutdownDoesNotWaitForConsumers(t *testing.T) {
+	// Like an exporter retrying a batch, the processor blocks until it is
+	// shut down.
+	stopped := make(chan struct{})
+	factory := processor.NewFactory(panickyType, func() component.Config { return &struct{}{} },
+		processor.WithTraces(func(context.Context, processor.Settings, component.Config, consumer.Traces) (processor.Traces, error) {
+			tc, err := consumer.NewTraces(func(context.Context, ptrace.Traces) error {
+				<-stopped
+				return errors.New("shut down")
+			})
+			return &panicky{
+				ShutdownFunc: func(context.Context) error { close(stopped); return nil },
+				Traces:       tc,
+				starts:       new(atomic.Int32),
+			}, err
+		}, component.StabilityLevelDevelopment))
+	g := crashguard.New()
+	g.Configure(crashguard.Config{Enabled: true, MaxDumps: 1})
+	p, err := g.WrapProcessor(factory).CreateTraces(context.Background(),
+		processortest.NewNopSettings(panickyType), &struct{}{}, consumertest.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, p.Start(context.Background(), componenttest.NewNopHost()))
+
+	consumed := make(chan error, 1)
+	go func() { consumed <- p.ConsumeTraces(context.Background(), span("ok")) }()
+	time.Sleep(20 * time.Millisecond)
+
+	shutdown := make(chan error, 1)
+	go func() { shutdown <- p.Shutdown(context.Background()) }()
+	select {
+	case err := <-shutdown:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("Shutdown waited for the call in flight")
+	}
+	assert.EqualError(t, <-consumed, "shut down")
+}
+
+func TestWrap_KeepsSignalsAndAlias(t *testing.T) {
+	alias := component.MustNewType("formerly_panicky")
+	pf := xprocessor.NewFactory(panickyType, func() component.Config { return &struct{}{} },
+		xprocessor.WithTraces(processortest.NewNopFactory().CreateTraces, component.StabilityLevelBeta),
+		xprocessor.WithProfiles(func(context.Context, processor.Settings, component.Config, xconsumer.Profiles) (xprocessor.Profiles, error) {
+			return nil, errors.New("unused")
+		}, component.StabilityLevelAlpha),
+		xprocessor.WithDeprecatedTypeAlias(alias))
+	wrapped := crashguard.New().WrapProcessor(pf)
+
+	assert.Equal(t, panickyType, wrapped.Type())
+	assert.Equal(t, component.StabilityLevelBeta, wrapped.TracesStability())
+	assert.Equal(t, component.StabilityLevelUndefined, wrapped.LogsStability())
+	xf, ok := wrapped.(xprocessor.Factory)
+	require.True(t, ok)
+	assert.Equal(t, component.StabilityLevelAlpha, xf.ProfilesStability())
+	assert.Equal(t, alias, wrapped.(interface{ DeprecatedAlias() component.Type }).DeprecatedAlias())
+
+	ef := crashguard.New().WrapExporter(exportertest.NewNopFactory())
+	assert.Equal(t, exportertest.NewNopFactory().Type(), ef.Type())
+	_, ok = ef.(xexporter.Factory)
+	assert.True(t, ok)
+	e, err := ef.CreateLogs(context.Background(), exportertest.NewNopSettings(ef.Type()), ef.CreateDefaultConfig())
+	require.NoError(t, err)
+	var _ exporter.Logs = e
+	require.NoError(t, e.Start(context.Background(), componenttest.NewNopHost()))
+	require.NoError(t, e.Shutdown(context.Background()))
+}
+
+func TestConverter_ConfiguresGuardAndRemovesSection(t *testing.T) {
+	conf := confmap.NewFromStringMap(map[string]any{
+		"crash_guard": map[string]any{
+			"restart":   true,
+			"dump_dir":  "/var/lib/tfo-collector/crashes",
+			"max_dumps": 5,
+		},
+		"receivers": map[string]any{"nop": nil},
+	})
+	g := crashguard.New()
+	c := crashguard.NewConverterFactory(g).Create(confmap.ConverterSettings{Logger: zap.NewNop()})
+	require.NoError(t, c.Convert(context.Background(), conf))
+
+	assert.False(t, conf.IsSet("crash_guard"), "section must not reach otelcol")
+	assert.True(t, conf.IsSet("receivers"))
+	assert.Equal(t, crashguard.Config{
+		Enabled:  true,
+		Restart:  true,
+		DumpDir:  "/var/lib/tfo-collector/crashes",
+		MaxDumps: 5,
+	}, g.Config())
+
+	// Without the section, e.g. after it is removed on reload, the
+	// defaults apply again.
+	require.NoError(t, c.Convert(context.Background(), confmap.New()))
+	assert.Equal(t, crashguard.DefaultConfig(), g.Config())
+}
+
+func TestConverter_RejectsInvalidSection(t *testing.T) {
+	tests := []struct {
+		name    string
+		section map[string]any
+		wantErr string
+	}{
+		{"unknown key", map[string]any{"restarts": true}, "restarts"},
+		{"max dumps", map[string]any{"max_dumps": 0}, "crash_guard.max_dumps must be positive"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			conf := confmap.NewFromStringMap(map[string]any{"crash_guard": tt.section})
+			c := crashguard.NewConverterFactory(crashguard.New()).Create(confmap.ConverterSettings{Logger: zap.NewNop()})
+			err := c.Convert(context.Background(), conf)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestBuilder_AcceptsCrashGuardSection(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+crash_guard:
+  restart: true
+  max_dumps: 5
+receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: localhost:4317
+processors:
+  batch:
+exporters:
+  debug:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [batch]
+      exporters: [debug]
+`), 0o600))
+
+	g := crashguard.New()
+	require.NoError(t, registry.Builder{ConfigURIs: []string{"file:" + path}, CrashGuard: g}.Validate(context.Background()))
+	assert.True(t, g.Config().Restart)
+	assert.Equal(t, 5, g.Config().MaxDumps)
+}
@@ -0,0 +1,230 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package deadletter_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/plog/plogotlp"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pdata/ptrace/ptraceotlp"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/bytesize"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/deadletter"
+)
+
+func logBatch(n int) plog.Logs {
+	ld := plog.NewLogs()
+	records := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for range n {
+		records.AppendEmpty().Body().SetStr("failed")
+	}
+	return ld
+}
+
+func newSpool(t *testing.T, cfg deadletter.Config, set component.TelemetrySettings, signal pipeline.Signal) *deadletter.Spool {
+	t.Helper()
+	require.NoError(t, cfg.Validate())
+	s, err := deadletter.New(cfg, set, component.MustNewIDWithName("tfo", "backend"), signal)
+	require.NoError(t, err)
+	return s
+}
+
+func counter(t *testing.T, tel *componenttest.Telemetry, name string) int64 {
+	t.Helper()
+	m, err := tel.GetMetric(name)
+	require.NoError(t, err)
+	var sum int64
+	for _, dp := range m.Data.(metricdata.Sum[int64]).DataPoints {
+		sum += dp.Value
+	}
+	return sum
+}
+
+func TestConfig_Validate(t *testing.T) {
+	cfg := deadletter.NewDefaultConfig()
+	assert.False(t, cfg.Enabled())
+	assert.Equal(t, deadletter.DefaultMaxSize, cfg.MaxSize)
+
+	cfg.MaxSize = 0
+	require.NoError(t, cfg.Validate(), "settings are ignored without a directory")
+
+	cfg.Directory = t.TempDir()
+	assert.ErrorContains(t, cfg.Validate(), "dead_letter.max_size must be positive")
+
+	cfg.MaxSize = bytesize.MiB
+	require.NoError(t, cfg.Validate())
+}
+
+func TestSpool_WriteAndRead(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "dead-letter")
+	cfg := deadletter.NewDefaultConfig()
+	cfg.Directory = dir
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { _ = tel.Shutdown(context.Background()) })
+	s := newSpool(t, cfg, tel.NewTelemetrySettings(), pipeline.SignalLogs)
+
+	require.NoError(t, s.Logs(context.Background(), logBatch(3), errors.New("no more retries left: unexpected status code: 503")))
+
+	entries, err := deadletter.List(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	e := entries[0]
+	assert.Equal(t, "tfo/backend", e.Exporter)
+	assert.Equal(t, "logs", e.Signal)
+	assert.Equal(t, 3, e.Records)
+	assert.Equal(t, "no more retries left: unexpected status code: 503", e.Error)
+	assert.False(t, e.Time.IsZero())
+	assert.Regexp(t, `^\d{8}T\d{6}\.\d{9}Z-logs-tfo_backend\.dlq$`, filepath.Base(e.Path))
+
+	info, err := os.Stat(e.Path)
+	require.NoError(t, err)
+	assert.Equal(t, info.Size(), e.Size)
+
+	read, payload, err := deadletter.Read(e.Path)
+	require.NoError(t, err)
+	assert.Equal(t, e, read)
+	req := plogotlp.NewExportRequest()
+	require.NoError(t, req.UnmarshalProto(payload))
+	assert.Equal(t, 3, req.Logs().LogRecordCount())
+
+	assert.Equal(t, int64(3), counter(t, tel, "tfo_exporter_dead_lettered"))
+}
+
+func TestSpool_EvictsOldestBatches(t *testing.T) {
+	dir := t.TempDir()
+	cfg := deadletter.NewDefaultConfig()
+	cfg.Directory = dir
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { _ = tel.Shutdown(context.Background()) })
+
+	// Size the spool for two batches of the same size.
+	probeDir := t.TempDir()
+	probe := newSpool(t, deadletter.Config{Directory: probeDir, MaxSize: bytesize.MiB}, componenttest.NewNopTelemetrySettings(), pipeline.SignalLogs)
+	require.NoError(t, probe.Logs(context.Background(), logBatch(10), errors.New("failed")))
+	sized, err := deadletter.List(probeDir)
+	require.NoError(t, err)
+	cfg.MaxSize = bytesize.Size(2*sized[0].Size + sized[0].Size/2)
+
+	s := newSpool(t, cfg, tel.NewTelemetrySettings(), pipeline.SignalLogs)
+	for range 4 {
+		require.NoError(t, s.Logs(context.Background(), logBatch(10), errors.New("failed")))
+	}
+
+	entries, err := deadletter.List(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	var total int64
+	for _, e := range entries {
+		total += e.Size
+	}
+	assert.LessOrEqual(t, total, cfg.MaxSize.Bytes())
+	assert.Equal(t, int64(40), counter(t, tel, "tfo_exporter_dead_lettered"))
+	assert.Equal(t, int64(20), counter(t, tel, "tfo_exporter_dead_letter_evicted"))
+}
+
+func TestSpool_RejectsBatchOverMaxSize(t *testing.T) {
+	dir := t.TempDir()
+	s := newSpool(t, deadletter.Config{Directory: dir, MaxSize: 64}, componenttest.NewNopTelemetrySettings(), pipeline.SignalLogs)
+
+	assert.ErrorContains(t, s.Logs(context.Background(), logBatch(100), errors.New("failed")), "exceeds the dead letter max_size")
+	entries, err := deadletter.List(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
+
+func TestList(t *testing.T) {
+	entries, err := deadletter.List(filepath.Join(t.TempDir(), "missing"))
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("ignored"), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "20260101T000000.000000000Z-logs-tfo.dlq.tmp"), []byte("partial"), 0o600))
+	entries, err = deadletter.List(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "other and partial files are skipped")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "20260101T000000.000000000Z-logs-tfo.dlq"), []byte("garbage"), 0o600))
+	_, err = deadletter.List(dir)
+	assert.ErrorContains(t, err, "not a dead letter batch")
+}
+
+func TestReplay(t *testing.T) {
+	var (
+		gotPath, gotType string
+		gotSpans         int
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath, gotType = r.URL.Path, r.Header.Get("Content-Type")
+		body, _ := io.ReadAll(r.Body)
+		req := ptraceotlp.NewExportRequest()
+		if err := req.UnmarshalProto(body); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		gotSpans = req.Traces().SpanCount()
+	}))
+	t.Cleanup(srv.Close)
+
+	dir := t.TempDir()
+	s := newSpool(t, deadletter.Config{Directory: dir, MaxSize: bytesize.MiB}, componenttest.NewNopTelemetrySettings(), pipeline.SignalTraces)
+	td := ptrace.NewTraces()
+	spans := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans()
+	spans.AppendEmpty().SetName("a")
+	spans.AppendEmpty().SetName("b")
+	require.NoError(t, s.Traces(context.Background(), td, errors.New("failed")))
+	entries, err := deadletter.List(dir)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+
+	e, err := deadletter.Replay(context.Background(), srv.Client(), srv.URL+"/", entries[0].Path)
+	require.NoError(t, err)
+	assert.Equal(t, 2, e.Records)
+	assert.Equal(t, "/v1/traces", gotPath)
+	assert.Equal(t, "application/x-protobuf", gotType)
+	assert.Equal(t, 2, gotSpans)
+	assert.FileExists(t, entries[0].Path, "the caller removes replayed batches")
+}
+
+func TestReplay_Rejected(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "backend unavailable", http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(srv.Close)
+
+	dir := t.TempDir()
+	s := newSpool(t, deadletter.Config{Directory: dir, MaxSize: bytesize.MiB}, componenttest.NewNopTelemetrySettings(), pipeline.SignalLogs)
+	require.NoError(t, s.Logs(context.Background(), logBatch(1), errors.New("failed")))
+	entries, err := deadletter.List(dir)
+	require.NoError(t, err)
+
+	_, err = deadletter.Replay(context.Background(), srv.Client(), srv.URL, entries[0].Path)
+	assert.ErrorContains(t, err, "503 Service Unavailable: backend unavailable")
+}
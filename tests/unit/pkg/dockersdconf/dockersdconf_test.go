@@ -0,0 +1,238 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package dockersdconf_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prometheus/common/model"
+	"github.com/prometheus/prometheus/model/labels"
+	"github.com/prometheus/prometheus/model/relabel"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/confmap"
+	"go.uber.org/zap"
+	"gopkg.in/yaml.v3"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/dockersdconf"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/registry"
+)
+
+// promConf returns a configuration with a prometheus receiver of the given
+// scrape_configs and an otlp receiver.
+func promConf(scrapeConfigs ...any) *confmap.Conf {
+	return confmap.NewFromStringMap(map[string]any{
+		"receivers": map[string]any{
+			"prometheus/docker": map[string]any{
+				"config": map[string]any{"scrape_configs": scrapeConfigs},
+			},
+			"otlp": map[string]any{"protocols": map[string]any{"grpc": nil}},
+		},
+	})
+}
+
+func convert(t *testing.T, conf *confmap.Conf) error {
+	t.Helper()
+	c := dockersdconf.NewConverterFactory().Create(confmap.ConverterSettings{Logger: zap.NewNop()})
+	return c.Convert(context.Background(), conf)
+}
+
+func TestApply_LabelConvention(t *testing.T) {
+	conf := promConf(map[string]any{
+		"job_name":          "docker",
+		"docker_sd_configs": []any{map[string]any{"refresh_interval": "30s"}},
+	})
+	jobs, err := dockersdconf.Apply(conf)
+	require.NoError(t, err)
+	assert.Equal(t, []dockersdconf.Job{{Receiver: "prometheus/docker", Name: "docker"}}, jobs)
+
+	job := conf.Get("receivers::prometheus/docker::config::scrape_configs").([]any)[0].(map[string]any)
+	sd := job["docker_sd_configs"].([]any)[0].(map[string]any)
+	assert.Equal(t, dockersdconf.DefaultHost, sd["host"])
+	assert.Equal(t, "30s", sd["refresh_interval"])
+	assert.Equal(t, dockersdconf.Relabeling(), job["relabel_configs"])
+	assert.True(t, conf.IsSet("receivers::otlp"), "other receivers are kept")
+}
+
+func TestApply_KeepsOwnRelabeling(t *testing.T) {
+	own := []any{map[string]any{"action": "labeldrop", "regex": "tmp"}}
+	conf := promConf(
+		map[string]any{
+			"job_name":          "custom",
+			"docker_sd_configs": []any{map[string]any{"host": "tcp://docker:2375"}},
+			"relabel_configs":   own,
+		},
+		map[string]any{
+			"job_name":          "off",
+			"docker_sd_configs": []any{map[string]any{}},
+			"relabel_configs":   []any{},
+		},
+	)
+	jobs, err := dockersdconf.Apply(conf)
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+
+	scrapeConfigs := conf.Get("receivers::prometheus/docker::config::scrape_configs").([]any)
+	custom := scrapeConfigs[0].(map[string]any)
+	assert.Equal(t, own, custom["relabel_configs"])
+	assert.Equal(t, "tcp://docker:2375", custom["docker_sd_configs"].([]any)[0].(map[string]any)["host"])
+	off := scrapeConfigs[1].(map[string]any)
+	assert.Empty(t, off["relabel_configs"])
+	assert.Equal(t, dockersdconf.DefaultHost, off["docker_sd_configs"].([]any)[0].(map[string]any)["host"], "the host is filled in regardless")
+}
+
+func TestApply_IgnoresOtherJobsAndReceivers(t *testing.T) {
+	static := map[string]any{
+		"job_name":       "node",
+		"static_configs": []any{map[string]any{"targets": []any{"node:9100"}}},
+	}
+	conf := promConf(static)
+	require.NoError(t, conf.Merge(confmap.NewFromStringMap(map[string]any{
+		"receivers": map[string]any{
+			"prometheus_simple": map[string]any{
+				"config": map[string]any{"scrape_configs": []any{map[string]any{"docker_sd_configs": []any{map[string]any{}}}}},
+			},
+		},
+	})))
+	before := conf.ToStringMap()
+
+	jobs, err := dockersdconf.Apply(conf)
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+	assert.Equal(t, before, conf.ToStringMap())
+
+	jobs, err = dockersdconf.Apply(confmap.New())
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+}
+
+func TestApply_RejectsMalformedEntry(t *testing.T) {
+	conf := promConf(map[string]any{
+		"job_name":          "docker",
+		"docker_sd_configs": []any{"unix:///var/run/docker.sock"},
+	})
+	require.ErrorContains(t, convert(t, conf), "docker_sd_configs::0: expected a map")
+}
+
+// process applies cfgs to in, returning the relabeled target and whether
+// it is kept.
+func process(in labels.Labels, cfgs []*relabel.Config) (labels.Labels, bool) {
+	lb := labels.NewBuilder(in)
+	keep := relabel.ProcessBuilder(lb, cfgs...)
+	return lb.Labels(), keep
+}
+
+// relabelConfigs parses the convention as the prometheus receiver does.
+func relabelConfigs(t *testing.T) []*relabel.Config {
+	t.Helper()
+	out, err := yaml.Marshal(dockersdconf.Relabeling())
+	require.NoError(t, err)
+	var cfgs []*relabel.Config
+	require.NoError(t, yaml.Unmarshal(out, &cfgs))
+	for _, cfg := range cfgs {
+		require.NoError(t, cfg.Validate(model.UTF8Validation))
+	}
+	return cfgs
+}
+
+func TestRelabeling(t *testing.T) {
+	cfgs := relabelConfigs(t)
+	target := func(extra ...string) labels.Labels {
+		return labels.FromStrings(append([]string{
+			model.AddressLabel, "172.17.0.2:8080",
+			model.MetricsPathLabel, "/metrics",
+			"__meta_docker_container_name", "/api",
+		}, extra...)...)
+	}
+
+	tests := []struct {
+		name    string
+		in      labels.Labels
+		keep    bool
+		address string
+		path    string
+	}{
+		{name: "not labeled", in: target()},
+		{name: "scrape false", in: target("__meta_docker_container_label_prometheus_scrape", "false")},
+		{
+			name:    "exposed port",
+			in:      target("__meta_docker_container_label_prometheus_scrape", "true"),
+			keep:    true,
+			address: "172.17.0.2:8080",
+			path:    "/metrics",
+		},
+		{
+			name: "port and path",
+			in: target(
+				"__meta_docker_container_label_prometheus_scrape", "true",
+				"__meta_docker_container_label_prometheus_port", "9100",
+				"__meta_docker_container_label_prometheus_path", "/internal/metrics",
+			),
+			keep:    true,
+			address: "172.17.0.2:9100",
+			path:    "/internal/metrics",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, keep := process(tt.in, cfgs)
+			require.Equal(t, tt.keep, keep)
+			if !keep {
+				return
+			}
+			assert.Equal(t, tt.address, out.Get(model.AddressLabel))
+			assert.Equal(t, tt.path, out.Get(model.MetricsPathLabel))
+			assert.Equal(t, "api", out.Get("container"))
+		})
+	}
+
+	// IPv6 container addresses are bracketed.
+	out, keep := process(labels.FromStrings(
+		model.AddressLabel, "[fd00::2]:8080",
+		"__meta_docker_container_label_prometheus_scrape", "true",
+		"__meta_docker_container_label_prometheus_port", "9100",
+	), cfgs)
+	require.True(t, keep)
+	assert.Equal(t, "[fd00::2]:9100", out.Get(model.AddressLabel))
+}
+
+func TestBuilder_ValidatesDockerJob(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(`
+receivers:
+  prometheus:
+    config:
+      scrape_configs:
+        - job_name: docker
+          docker_sd_configs:
+            - refresh_interval: 30s
+exporters:
+  debug:
+service:
+  pipelines:
+    metrics:
+      receivers: [prometheus]
+      exporters: [debug]
+`), 0o600))
+
+	// Without the converter docker_sd_configs needs a host.
+	err := registry.Builder{ConfigURIs: []string{"file:" + path}, ConverterFactories: []confmap.ConverterFactory{}}.Validate(context.Background())
+	require.ErrorContains(t, err, "host missing")
+
+	require.NoError(t, registry.Builder{ConfigURIs: []string{"file:" + path}}.Validate(context.Background()))
+}
@@ -0,0 +1,136 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package errlog_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/errlog"
+)
+
+const msg = "Failed to consume traces"
+
+func newObserved(level zapcore.Level, interval time.Duration) (*errlog.Aggregator, *observer.ObservedLogs) {
+	core, logs := observer.New(level)
+	return errlog.New(zap.New(core), interval), logs
+}
+
+func TestAggregator_LogsFirstFailureOnly(t *testing.T) {
+	agg, logs := newObserved(zapcore.InfoLevel, time.Hour)
+
+	for i := 0; i < 100; i++ {
+		agg.Error(msg, errors.New("queue is full"), zap.String("protocol", "grpc"))
+	}
+
+	require.Equal(t, 1, logs.Len())
+	entry := logs.All()[0]
+	assert.Equal(t, zapcore.ErrorLevel, entry.Level)
+	assert.Equal(t, msg, entry.Message)
+	assert.Equal(t, "grpc", entry.ContextMap()["protocol"])
+	assert.Equal(t, "queue is full", entry.ContextMap()["error"])
+}
+
+func TestAggregator_PeriodicSummary(t *testing.T) {
+	agg, logs := newObserved(zapcore.InfoLevel, 20*time.Millisecond)
+
+	agg.Error(msg, errors.New("first"))
+	agg.Error(msg, errors.New("second"))
+	time.Sleep(30 * time.Millisecond)
+	agg.Error(msg, errors.New("third"))
+
+	summaries := logs.FilterMessage(msg + " (repeated)").All()
+	require.Len(t, summaries, 1)
+	fields := summaries[0].ContextMap()
+	assert.Equal(t, int64(2), fields["count"])
+	assert.Equal(t, int64(3), fields["total"])
+	assert.Equal(t, "third", fields["last_error"])
+	assert.GreaterOrEqual(t, fields["duration"], 20*time.Millisecond)
+
+	// The next summary only counts failures since the last one.
+	agg.Error(msg, errors.New("fourth"))
+	assert.Len(t, logs.FilterMessage(msg+" (repeated)").All(), 1)
+}
+
+func TestAggregator_RecoveryResets(t *testing.T) {
+	agg, logs := newObserved(zapcore.InfoLevel, time.Hour)
+
+	agg.Success(msg)
+	assert.Equal(t, 0, logs.Len(), "success without failures logs nothing")
+
+	agg.Error(msg, errors.New("down"))
+	agg.Error(msg, errors.New("still down"))
+	agg.Success(msg)
+
+	recovered := logs.FilterMessage(msg + " (recovered)").All()
+	require.Len(t, recovered, 1)
+	assert.Equal(t, zapcore.InfoLevel, recovered[0].Level)
+	assert.Equal(t, int64(2), recovered[0].ContextMap()["total"])
+	assert.Equal(t, "still down", recovered[0].ContextMap()["last_error"])
+
+	// A new outage is reported again.
+	agg.Error(msg, errors.New("down again"))
+	assert.Len(t, logs.FilterMessage(msg).All(), 2)
+}
+
+func TestAggregator_MessagesAreIndependent(t *testing.T) {
+	agg, logs := newObserved(zapcore.InfoLevel, time.Hour)
+
+	agg.Error(msg, errors.New("a"))
+	agg.Error("Failed to consume logs", errors.New("b"))
+	agg.Success("Failed to consume logs")
+
+	assert.Len(t, logs.FilterMessage(msg).All(), 1)
+	assert.Len(t, logs.FilterMessage("Failed to consume logs").All(), 1)
+	assert.Len(t, logs.FilterMessage("Failed to consume logs (recovered)").All(), 1)
+	assert.Empty(t, logs.FilterMessage(msg+" (recovered)").All())
+}
+
+func TestAggregator_DebugStream(t *testing.T) {
+	agg, logs := newObserved(zapcore.DebugLevel, time.Hour)
+
+	agg.Error(msg, errors.New("one"))
+	agg.Error(msg, errors.New("two"))
+	agg.Error(msg, errors.New("three"))
+
+	assert.Len(t, logs.FilterLevelExact(zapcore.ErrorLevel).All(), 1)
+	assert.Len(t, logs.FilterLevelExact(zapcore.DebugLevel).All(), 2)
+}
+
+func TestAggregator_Disabled(t *testing.T) {
+	agg, logs := newObserved(zapcore.InfoLevel, 0)
+
+	agg.Error(msg, errors.New("one"))
+	agg.Error(msg, errors.New("two"))
+	agg.Success(msg)
+
+	assert.Len(t, logs.FilterLevelExact(zapcore.ErrorLevel).All(), 2)
+	assert.Equal(t, 2, logs.Len())
+}
+
+func TestAggregator_Nil(t *testing.T) {
+	var agg *errlog.Aggregator
+	assert.NotPanics(t, func() {
+		agg.Error(msg, errors.New("x"))
+		agg.Success(msg)
+	})
+}
@@ -0,0 +1,253 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package errorbudget_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/errorbudget"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+var tfoLabels = selfmetrics.Exporter(component.MustNewID("tfo"), pipeline.SignalTraces)
+
+var errBackend = errors.New("backend unavailable")
+
+func testConfig() errorbudget.Config {
+	return errorbudget.Config{
+		Enabled:       true,
+		Budget:        50 * time.Millisecond,
+		ProbeInterval: 10 * time.Millisecond,
+		ProbeTimeout:  10 * time.Millisecond,
+	}
+}
+
+type recorder struct {
+	mu          sync.Mutex
+	transitions []errorbudget.Transition
+}
+
+func (r *recorder) hooks() errorbudget.Hooks {
+	record := func(t errorbudget.Transition) {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.transitions = append(r.transitions, t)
+	}
+	return errorbudget.Hooks{OnDisabled: record, OnEnabled: record}
+}
+
+func (r *recorder) states() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	states := make([]string, 0, len(r.transitions))
+	for _, t := range r.transitions {
+		states = append(states, t.State)
+	}
+	return states
+}
+
+func (r *recorder) last() errorbudget.Transition {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.transitions[len(r.transitions)-1]
+}
+
+func newGuard(t *testing.T, cfg errorbudget.Config, probe errorbudget.ProbeFunc, rec *recorder) *errorbudget.Guard {
+	t.Helper()
+	require.NoError(t, cfg.Validate())
+	g, err := errorbudget.New(cfg, componenttest.NewNopTelemetrySettings(), tfoLabels, probe, rec.hooks())
+	require.NoError(t, err)
+	return g
+}
+
+// exhaust fails sends until the budget has run out.
+func exhaust(g *errorbudget.Guard, budget time.Duration) {
+	g.Record(errBackend)
+	time.Sleep(budget)
+	g.Record(errBackend)
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *errorbudget.Config)
+		wantErr string
+	}{
+		{name: "defaults", mutate: func(*errorbudget.Config) {}},
+		{name: "disabled ignores values", mutate: func(cfg *errorbudget.Config) {
+			cfg.Enabled = false
+			cfg.Budget = 0
+		}},
+		{name: "zero budget", mutate: func(cfg *errorbudget.Config) { cfg.Budget = 0 }, wantErr: "error_budget.budget"},
+		{name: "zero probe interval", mutate: func(cfg *errorbudget.Config) { cfg.ProbeInterval = 0 }, wantErr: "error_budget.probe_interval"},
+		{name: "zero probe timeout", mutate: func(cfg *errorbudget.Config) { cfg.ProbeTimeout = 0 }, wantErr: "error_budget.probe_timeout"},
+		{name: "probe timeout above interval", mutate: func(cfg *errorbudget.Config) {
+			cfg.ProbeTimeout = time.Minute
+		}, wantErr: "must not be longer"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := errorbudget.NewDefaultConfig()
+			cfg.Enabled = true
+			tt.mutate(&cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestGuard_NilStaysEnabled(t *testing.T) {
+	var g *errorbudget.Guard
+	g.Record(errBackend)
+	g.Drop(context.Background(), 10)
+	g.Probe(context.Background())
+	g.Start(context.Background())
+	g.Shutdown(context.Background())
+	assert.True(t, g.Enabled())
+}
+
+func TestGuard_DisablesAfterBudget(t *testing.T) {
+	rec := &recorder{}
+	cfg := testConfig()
+	g := newGuard(t, cfg, nil, rec)
+
+	g.Record(errBackend)
+	g.Record(errBackend)
+	assert.True(t, g.Enabled(), "failures within the budget keep the exporter")
+
+	time.Sleep(cfg.Budget)
+	g.Record(errBackend)
+	assert.False(t, g.Enabled())
+	require.Equal(t, []string{errorbudget.StateDisabled}, rec.states())
+	assert.ErrorIs(t, rec.last().Err, errBackend)
+	assert.GreaterOrEqual(t, rec.last().Duration, cfg.Budget)
+
+	g.Record(errBackend)
+	assert.Len(t, rec.states(), 1, "further failures are not reported again")
+}
+
+func TestGuard_SuccessResetsFailureClock(t *testing.T) {
+	rec := &recorder{}
+	cfg := testConfig()
+	g := newGuard(t, cfg, nil, rec)
+
+	g.Record(errBackend)
+	time.Sleep(cfg.Budget)
+	g.Record(nil)
+	g.Record(errBackend)
+	assert.True(t, g.Enabled())
+	assert.Empty(t, rec.states())
+}
+
+func TestGuard_IgnoresCancellation(t *testing.T) {
+	rec := &recorder{}
+	cfg := testConfig()
+	g := newGuard(t, cfg, nil, rec)
+
+	g.Record(context.Canceled)
+	time.Sleep(cfg.Budget)
+	g.Record(context.Canceled)
+	assert.True(t, g.Enabled())
+}
+
+func TestGuard_ProbeReenables(t *testing.T) {
+	rec := &recorder{}
+	cfg := testConfig()
+	var healthy atomic.Bool
+	var probes atomic.Int32
+	g := newGuard(t, cfg, func(ctx context.Context) error {
+		probes.Add(1)
+		_, hasDeadline := ctx.Deadline()
+		assert.True(t, hasDeadline)
+		if !healthy.Load() {
+			return errBackend
+		}
+		return nil
+	}, rec)
+
+	g.Probe(context.Background())
+	assert.Zero(t, probes.Load(), "an enabled exporter is not probed")
+
+	exhaust(g, cfg.Budget)
+	require.False(t, g.Enabled())
+	g.Drop(context.Background(), 7)
+
+	g.Probe(context.Background())
+	assert.False(t, g.Enabled(), "a failed probe keeps the exporter out")
+
+	healthy.Store(true)
+	g.Probe(context.Background())
+	assert.True(t, g.Enabled())
+	require.Equal(t, []string{errorbudget.StateDisabled, errorbudget.StateEnabled}, rec.states())
+	assert.Equal(t, int64(7), rec.last().Dropped)
+}
+
+func TestGuard_ProbeLoop(t *testing.T) {
+	rec := &recorder{}
+	cfg := testConfig()
+	g := newGuard(t, cfg, func(context.Context) error { return nil }, rec)
+	g.Start(context.Background())
+	t.Cleanup(func() { g.Shutdown(context.Background()) })
+
+	exhaust(g, cfg.Budget)
+	require.Eventually(t, g.Enabled, time.Second, 5*time.Millisecond)
+	assert.Equal(t, []string{errorbudget.StateDisabled, errorbudget.StateEnabled}, rec.states())
+}
+
+func TestGuard_RecordsMetrics(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { _ = tel.Shutdown(context.Background()) })
+
+	cfg := testConfig()
+	g, err := errorbudget.New(cfg, tel.NewTelemetrySettings(), tfoLabels, nil, errorbudget.Hooks{})
+	require.NoError(t, err)
+
+	exhaust(g, cfg.Budget)
+	g.Drop(context.Background(), 3)
+	g.Record(nil)
+
+	m, err := tel.GetMetric("tfo_error_budget_transitions")
+	require.NoError(t, err)
+	sum := m.Data.(metricdata.Sum[int64])
+	require.Len(t, sum.DataPoints, 2)
+	for _, dp := range sum.DataPoints {
+		assert.Equal(t, int64(1), dp.Value)
+		_, ok := dp.Attributes.Value("state")
+		assert.True(t, ok)
+	}
+
+	m, err = tel.GetMetric("tfo_error_budget_dropped")
+	require.NoError(t, err)
+	sum = m.Data.(metricdata.Sum[int64])
+	require.Len(t, sum.DataPoints, 1)
+	assert.Equal(t, int64(3), sum.DataPoints[0].Value)
+}
@@ -0,0 +1,60 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package experiment_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/experiment"
+)
+
+func TestFromContext_Empty(t *testing.T) {
+	assert.Nil(t, experiment.FromContext(context.Background()))
+}
+
+func TestObservation_AddFromContext(t *testing.T) {
+	obs := &experiment.Observation{}
+	ctx := experiment.NewContext(context.Background(), obs)
+
+	got := experiment.FromContext(ctx)
+	require.Same(t, obs, got)
+	got.Add(3, 120)
+	got.Add(2, 80)
+
+	assert.Equal(t, int64(5), obs.Records())
+	assert.Equal(t, int64(200), obs.Bytes())
+}
+
+func TestObservation_ConcurrentAdd(t *testing.T) {
+	obs := &experiment.Observation{}
+
+	var wg sync.WaitGroup
+	for range 10 {
+		wg.Go(func() {
+			for range 100 {
+				obs.Add(1, 10)
+			}
+		})
+	}
+	wg.Wait()
+
+	assert.Equal(t, int64(1000), obs.Records())
+	assert.Equal(t, int64(10000), obs.Bytes())
+}
@@ -0,0 +1,215 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package fileconf_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/confmap/provider/yamlprovider"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/fileconf"
+)
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func retrieve(t *testing.T, path string) (any, error) {
+	t.Helper()
+	p := fileconf.NewProviderFactory().Create(confmap.ProviderSettings{})
+	ret, err := p.Retrieve(context.Background(), "file:"+path, nil)
+	if err != nil {
+		return nil, err
+	}
+	return ret.AsRaw()
+}
+
+func resolve(t *testing.T, uris ...string) map[string]any {
+	t.Helper()
+	resolver, err := confmap.NewResolver(confmap.ResolverSettings{
+		URIs: uris,
+		ProviderFactories: []confmap.ProviderFactory{
+			fileconf.NewProviderFactory(),
+			yamlprovider.NewFactory(),
+		},
+	})
+	require.NoError(t, err)
+	conf, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	return conf.ToStringMap()
+}
+
+const anchorsYAML = `
+x-defaults: &defaults
+  timeout: 5s
+  tls:
+    insecure: true
+
+exporters:
+  tfo/a:
+    <<: *defaults
+    endpoint: a
+  tfo/b:
+    <<: *defaults
+    endpoint: b
+  tfo/c: *defaults
+  tfo/d: *defaults
+`
+
+func TestProvider_YAMLAnchors(t *testing.T) {
+	raw, err := retrieve(t, writeFile(t, "config.yaml", anchorsYAML))
+	require.NoError(t, err)
+
+	defaults := map[string]any{"timeout": "5s", "tls": map[string]any{"insecure": true}}
+	assert.Equal(t, map[string]any{
+		"exporters": map[string]any{
+			"tfo/a": map[string]any{"timeout": "5s", "tls": map[string]any{"insecure": true}, "endpoint": "a"},
+			"tfo/b": map[string]any{"timeout": "5s", "tls": map[string]any{"insecure": true}, "endpoint": "b"},
+			"tfo/c": defaults,
+			"tfo/d": defaults,
+		},
+	}, raw, "x- keys are removed")
+}
+
+func TestProvider_AliasesAreIndependent(t *testing.T) {
+	path := writeFile(t, "config.yaml", anchorsYAML)
+
+	conf := resolve(t, "file:"+path,
+		"yaml:exporters::tfo/a::tls::insecure: false",
+		"yaml:exporters::tfo/c::tls::insecure: false",
+	)
+
+	exporters := conf["exporters"].(map[string]any)
+	tls := func(id string) any {
+		return exporters[id].(map[string]any)["tls"].(map[string]any)["insecure"]
+	}
+	assert.Equal(t, false, tls("tfo/a"))
+	assert.Equal(t, true, tls("tfo/b"))
+	assert.Equal(t, false, tls("tfo/c"))
+	assert.Equal(t, true, tls("tfo/d"))
+}
+
+func TestProvider_Formats(t *testing.T) {
+	yamlPath := writeFile(t, "config.yaml", `
+receivers:
+  tfootlp:
+    protocols:
+      grpc:
+        endpoint: 0.0.0.0:4317
+        max_recv_msg_size_mib: 16
+processors:
+  memory_limiter:
+    limit_percentage: 80.5
+service:
+  pipelines:
+    traces:
+      receivers: [tfootlp]
+      processors: [memory_limiter]
+`)
+	jsonPath := writeFile(t, "config.json", `{
+  "x-comment": "ignored",
+  "receivers": {"tfootlp": {"protocols": {"grpc": {"endpoint": "0.0.0.0:4317", "max_recv_msg_size_mib": 16}}}},
+  "processors": {"memory_limiter": {"limit_percentage": 80.5}},
+  "service": {"pipelines": {"traces": {"receivers": ["tfootlp"], "processors": ["memory_limiter"]}}}
+}`)
+	tomlPath := writeFile(t, "CONFIG.TOML", `
+[receivers.tfootlp.protocols.grpc]
+endpoint = "0.0.0.0:4317"
+max_recv_msg_size_mib = 16
+
+[processors.memory_limiter]
+limit_percentage = 80.5
+
+[service.pipelines.traces]
+receivers = ["tfootlp"]
+processors = ["memory_limiter"]
+`)
+
+	want := resolve(t, "file:"+yamlPath)
+	assert.Equal(t, want, resolve(t, "file:"+jsonPath))
+	assert.Equal(t, want, resolve(t, "file:"+tomlPath))
+
+	raw, err := retrieve(t, jsonPath)
+	require.NoError(t, err)
+	grpc := raw.(map[string]any)["receivers"].(map[string]any)["tfootlp"].(map[string]any)["protocols"].(map[string]any)["grpc"].(map[string]any)
+	assert.Equal(t, 16, grpc["max_recv_msg_size_mib"])
+}
+
+func TestProvider_EmbeddedInString(t *testing.T) {
+	for name, content := range map[string]string{
+		"labels.json": `{"x-comment": "ignored", "team": "core", "tier": 1}`,
+		"labels.toml": "team = \"core\"\ntier = 1\n",
+		"labels.yaml": "x-comment: ignored\nteam: core\ntier: 1\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			path := writeFile(t, name, content)
+			conf := resolve(t, "yaml:processors::attributes::labels: 'labels=${file:"+path+"}'")
+
+			labels := conf["processors"].(map[string]any)["attributes"].(map[string]any)["labels"]
+			assert.Equal(t, `labels={"team":"core","tier":1}`, labels)
+		})
+	}
+}
+
+func TestProvider_TOMLLocalDates(t *testing.T) {
+	raw, err := retrieve(t, writeFile(t, "config.toml", "[extensions.tfoclock]\nepoch = 2026-01-02\n"))
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"extensions": map[string]any{"tfoclock": map[string]any{"epoch": "2026-01-02"}},
+	}, raw)
+}
+
+func TestProvider_PlainFileIsString(t *testing.T) {
+	raw, err := retrieve(t, writeFile(t, "token.txt", "s3cr3t"))
+	require.NoError(t, err)
+	assert.Equal(t, "s3cr3t", raw)
+}
+
+func TestProvider_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		file    string
+		content string
+		wantErr string
+	}{
+		{name: "invalid JSON", file: "c.json", content: `{"receivers": `, wantErr: "unable to parse the JSON file"},
+		{name: "JSON array", file: "c.json", content: `[1, 2]`, wantErr: "unable to parse the JSON file"},
+		{name: "trailing JSON", file: "c.json", content: `{} {}`, wantErr: "unexpected data after the top-level object"},
+		{name: "invalid TOML", file: "c.toml", content: "[receivers\n", wantErr: "unable to parse the TOML file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := retrieve(t, writeFile(t, tt.file, tt.content))
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+
+	_, err := retrieve(t, filepath.Join(t.TempDir(), "missing.yaml"))
+	assert.ErrorContains(t, err, "unable to read the file")
+
+	p := fileconf.NewProviderFactory().Create(confmap.ProviderSettings{})
+	assert.Equal(t, "file", p.Scheme())
+	_, err = p.Retrieve(context.Background(), "env:HOME", nil)
+	assert.ErrorContains(t, err, `"env:HOME" uri is not supported by "file" provider`)
+}
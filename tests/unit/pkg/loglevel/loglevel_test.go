@@ -0,0 +1,54 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package loglevel_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/loglevel"
+)
+
+func TestWrapCore(t *testing.T) {
+	t.Cleanup(loglevel.Reset)
+	core, logs := observer.New(zapcore.InfoLevel)
+	logger := zap.New(core, zap.WrapCore(loglevel.WrapCore)).With(zap.String("component", "tfo"))
+
+	logger.Debug("configured level")
+	logger.Info("configured level")
+	assert.Equal(t, 1, logs.Len())
+
+	loglevel.Set(zapcore.DebugLevel)
+	logger.Debug("lowered")
+	assert.Equal(t, 2, logs.Len(), "a level below the configured one takes effect")
+	assert.Equal(t, "tfo", logs.All()[1].ContextMap()["component"])
+
+	loglevel.Set(zapcore.ErrorLevel)
+	logger.Warn("raised")
+	logger.Error("raised")
+	assert.Equal(t, 3, logs.Len())
+	assert.Equal(t, zapcore.ErrorLevel, zapcore.LevelOf(logger.Core()))
+
+	loglevel.Reset()
+	logger.Debug("restored")
+	logger.Warn("restored")
+	assert.Equal(t, 4, logs.Len())
+	_, ok := loglevel.Get()
+	assert.False(t, ok)
+}
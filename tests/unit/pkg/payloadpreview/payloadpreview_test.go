@@ -0,0 +1,127 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package payloadpreview_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/payloadpreview"
+)
+
+func TestFor_SharesPreviewsByExporter(t *testing.T) {
+	p := payloadpreview.For("tfo/shared")
+	assert.Same(t, p, payloadpreview.For("tfo/shared"))
+	assert.NotSame(t, p, payloadpreview.For("tfo/other"))
+
+	found, ok := payloadpreview.Lookup("tfo/shared")
+	require.True(t, ok)
+	assert.Same(t, p, found)
+	_, ok = payloadpreview.Lookup("tfo/unknown")
+	assert.False(t, ok)
+
+	assert.Contains(t, payloadpreview.Exporters(), "tfo/shared")
+	assert.IsNonDecreasing(t, payloadpreview.Exporters())
+}
+
+func TestPreview_RecordsUpToCount(t *testing.T) {
+	p := payloadpreview.For("tfo/count")
+	assert.False(t, p.Wants("logs"))
+	_, ok := p.Status()
+	assert.False(t, ok)
+
+	status, err := p.Start("", 2, time.Minute)
+	require.NoError(t, err)
+	assert.True(t, status.Active)
+	assert.Equal(t, "tfo/count", status.Exporter)
+	assert.Empty(t, status.Payloads)
+
+	assert.True(t, p.Wants("logs"))
+	p.Record("logs", "http://backend/v2/logs", 10, []byte(`{"resourceLogs":[]}`))
+	assert.True(t, p.Wants("traces"))
+	p.Record("traces", "http://backend/v2/traces", 20, []byte(`{"resourceSpans":[]}`))
+	assert.False(t, p.Wants("logs"))
+	p.Record("logs", "http://backend/v2/logs", 30, []byte(`{}`))
+
+	status, ok = p.Status()
+	require.True(t, ok)
+	assert.False(t, status.Active)
+	assert.Equal(t, 2, status.Captured)
+	require.Len(t, status.Payloads, 2)
+	assert.Equal(t, "logs", status.Payloads[0].Signal)
+	assert.Equal(t, 10, status.Payloads[0].Size)
+	assert.JSONEq(t, `{"resourceSpans":[]}`, string(status.Payloads[1].Body))
+}
+
+func TestPreview_SignalFilter(t *testing.T) {
+	p := payloadpreview.For("tfo/signal")
+	_, err := p.Start("metrics", 5, time.Minute)
+	require.NoError(t, err)
+
+	assert.False(t, p.Wants("traces"))
+	assert.True(t, p.Wants("metrics"))
+	p.Record("traces", "http://backend/v2/traces", 1, []byte(`{}`))
+	p.Record("metrics", "http://backend/v2/metrics", 1, []byte(`{}`))
+
+	status, _ := p.Status()
+	assert.True(t, status.Active)
+	require.Len(t, status.Payloads, 1)
+	assert.Equal(t, "metrics", status.Payloads[0].Signal)
+}
+
+func TestPreview_StartWhileActive(t *testing.T) {
+	p := payloadpreview.For("tfo/active")
+	_, err := p.Start("", 5, time.Minute)
+	require.NoError(t, err)
+	p.Record("logs", "http://backend/v2/logs", 1, []byte(`{}`))
+
+	_, err = p.Start("", 5, time.Minute)
+	require.ErrorIs(t, err, payloadpreview.ErrActive)
+
+	status, ok := p.Stop()
+	require.True(t, ok)
+	assert.False(t, status.Active)
+	assert.Len(t, status.Payloads, 1)
+	assert.False(t, p.Wants("logs"))
+
+	// A new preview discards the payloads of the last one.
+	status, err = p.Start("", 5, time.Minute)
+	require.NoError(t, err)
+	assert.Empty(t, status.Payloads)
+}
+
+func TestPreview_Expires(t *testing.T) {
+	p := payloadpreview.For("tfo/expires")
+	_, err := p.Start("", 5, time.Millisecond)
+	require.NoError(t, err)
+	time.Sleep(5 * time.Millisecond)
+
+	assert.False(t, p.Wants("logs"))
+	status, ok := p.Status()
+	require.True(t, ok)
+	assert.False(t, status.Active)
+
+	_, err = p.Start("", 5, time.Minute)
+	assert.NoError(t, err)
+}
+
+func TestPreview_NilRecordsNothing(t *testing.T) {
+	var p *payloadpreview.Preview
+	assert.False(t, p.Wants("logs"))
+	p.Record("logs", "http://backend/v2/logs", 1, []byte(`{}`))
+}
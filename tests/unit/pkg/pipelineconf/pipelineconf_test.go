@@ -0,0 +1,233 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package pipelineconf_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/pipelineconf"
+)
+
+// newConf returns a configuration with the given traces pipeline processors
+// and a processors section defining them.
+func newConf(processors ...any) *confmap.Conf {
+	defined := map[string]any{}
+	for _, p := range processors {
+		if s, ok := p.(string); ok {
+			defined[s] = nil
+		}
+	}
+	return confmap.NewFromStringMap(map[string]any{
+		"receivers":  map[string]any{"tfootlp": nil},
+		"processors": defined,
+		"exporters":  map[string]any{"tfo": nil},
+		"connectors": map[string]any{"forward": nil},
+		"service": map[string]any{
+			"pipelines": map[string]any{
+				"traces": map[string]any{
+					"receivers":  []any{"tfootlp"},
+					"processors": processors,
+					"exporters":  []any{"tfo", "forward"},
+				},
+				"traces/out": map[string]any{
+					"receivers": []any{"forward"},
+					"exporters": []any{"tfo"},
+				},
+			},
+		},
+	})
+}
+
+func TestParse(t *testing.T) {
+	pipelines, err := pipelineconf.Parse(newConf("memory_limiter", "batch/large"))
+	require.NoError(t, err)
+	require.Len(t, pipelines, 2)
+
+	p := pipelines[0]
+	assert.Equal(t, pipeline.NewID(pipeline.SignalTraces), p.ID)
+	assert.Equal(t, []component.ID{component.MustNewID("tfootlp")}, p.Receivers)
+	assert.Equal(t, []component.ID{
+		component.MustNewID("memory_limiter"),
+		component.MustNewIDWithName("batch", "large"),
+	}, p.Processors)
+	assert.Equal(t, []component.ID{component.MustNewID("tfo"), component.MustNewID("forward")}, p.Exporters)
+
+	assert.Equal(t, pipeline.NewIDWithName(pipeline.SignalTraces, "out"), pipelines[1].ID)
+	assert.Equal(t, []component.ID{component.MustNewID("forward")}, pipelines[1].Receivers)
+}
+
+func TestParse_Errors(t *testing.T) {
+	unknown := newConf("batch")
+	require.NoError(t, unknown.Merge(confmap.NewFromStringMap(map[string]any{
+		"service::pipelines::traces::processors": []any{"batch", "memory_limiter"},
+	})))
+
+	tests := []struct {
+		name    string
+		conf    *confmap.Conf
+		wantErr string
+	}{
+		{
+			name:    "unknown processor",
+			conf:    unknown,
+			wantErr: `service::pipelines::traces::processors[1]: "memory_limiter" is not configured in the processors section`,
+		},
+		{
+			name:    "malformed reference",
+			conf:    newConf("batch/"),
+			wantErr: "service::pipelines::traces::processors[0]:",
+		},
+		{
+			name:    "not a string",
+			conf:    newConf(42),
+			wantErr: "service::pipelines::traces::processors[0]: must be a component ID, got 42",
+		},
+		{
+			name: "not a list",
+			conf: confmap.NewFromStringMap(map[string]any{
+				"service::pipelines::logs::processors": "batch",
+			}),
+			wantErr: "service::pipelines::logs::processors: must be a list of component IDs",
+		},
+		{
+			name: "invalid pipeline ID",
+			conf: confmap.NewFromStringMap(map[string]any{
+				"service::pipelines::spans": map[string]any{},
+			}),
+			wantErr: "service::pipelines::spans:",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := pipelineconf.Parse(tt.conf)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestCheckOrder(t *testing.T) {
+	tests := []struct {
+		name       string
+		processors []any
+		want       []string
+	}{
+		{
+			name:       "recommended order",
+			processors: []any{"memory_limiter", "tfoexempt", "tail_sampling", "tfodedup", "batch"},
+		},
+		{
+			name:       "no processors",
+			processors: nil,
+		},
+		{
+			name:       "memory_limiter not first",
+			processors: []any{"batch", "memory_limiter/strict"},
+			want:       []string{"memory_limiter/strict should be the first processor; batch runs before it"},
+		},
+		{
+			name:       "batch before dropping processors",
+			processors: []any{"memory_limiter", "batch", "filter/noise", "probabilistic_sampler"},
+			want: []string{
+				"filter/noise drops data and should run before batch",
+				"probabilistic_sampler drops data and should run before batch",
+			},
+		},
+		{
+			name:       "sampler before tfoexempt",
+			processors: []any{"probabilistic_sampler", "tfoexempt/canary", "tail_sampling"},
+			want:       []string{"probabilistic_sampler samples before tfoexempt/canary exempts traces and should run after it"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pipelines, err := pipelineconf.Parse(newConf(tt.processors...))
+			require.NoError(t, err)
+
+			var got []string
+			for _, w := range pipelineconf.CheckOrder(pipelines[0]) {
+				assert.Equal(t, "traces", w.Pipeline)
+				got = append(got, w.Message)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestConverter(t *testing.T) {
+	core, logs := observer.New(zapcore.WarnLevel)
+	set := confmap.ConverterSettings{Logger: zap.New(core)}
+	converter := pipelineconf.NewConverterFactory().Create(set)
+
+	conf := newConf("batch", "memory_limiter")
+	before := conf.ToStringMap()
+	require.NoError(t, converter.Convert(context.Background(), conf))
+	assert.Equal(t, before, conf.ToStringMap(), "configuration is not modified")
+
+	entries := logs.FilterMessage("Questionable processor order").All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "traces", entries[0].ContextMap()["pipeline"])
+
+	unused := newConf("batch")
+	require.NoError(t, unused.Merge(confmap.NewFromStringMap(map[string]any{
+		"receivers::prometheus::config::scrape_configs": []any{map[string]any{"job_name": "node"}},
+	})))
+	require.NoError(t, converter.Convert(context.Background(), unused))
+	entries = logs.FilterMessage("Component is configured but not used by the service; its settings have no effect").All()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "receivers::prometheus", entries[0].ContextMap()["component"])
+
+	bad := newConf("batch")
+	require.NoError(t, bad.Merge(confmap.NewFromStringMap(map[string]any{
+		"service::pipelines::traces::processors": []any{"missing"},
+	})))
+	assert.ErrorContains(t, converter.Convert(context.Background(), bad), `"missing" is not configured`)
+}
+
+func TestUnused(t *testing.T) {
+	conf := newConf("batch")
+	require.NoError(t, conf.Merge(confmap.NewFromStringMap(map[string]any{
+		"extensions":          map[string]any{"health_check": nil, "pprof": nil},
+		"receivers":           map[string]any{"prometheus": nil},
+		"processors":          map[string]any{"memory_limiter": nil},
+		"exporters":           map[string]any{"debug": nil},
+		"connectors":          map[string]any{"span_metrics": nil},
+		"service::extensions": []any{"health_check"},
+	})))
+	assert.Equal(t, []string{
+		"exporters::debug",
+		"extensions::pprof",
+		"processors::memory_limiter",
+		"receivers::prometheus",
+	}, pipelineconf.Unused(conf, mustParse(t, conf)))
+	assert.Empty(t, pipelineconf.Unused(newConf("batch"), mustParse(t, newConf("batch"))))
+}
+
+func mustParse(t *testing.T, conf *confmap.Conf) []pipelineconf.Pipeline {
+	t.Helper()
+	pipelines, err := pipelineconf.Parse(conf)
+	require.NoError(t, err)
+	return pipelines
+}
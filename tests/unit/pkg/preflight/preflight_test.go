@@ -0,0 +1,321 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package preflight_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/preflight"
+)
+
+func writeConfig(t *testing.T, yaml string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(yaml), 0o600))
+	return path
+}
+
+// busyEndpoint returns an endpoint held by a listener until the test ends.
+func busyEndpoint(t *testing.T) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = lis.Close() })
+	return lis.Addr().String()
+}
+
+// freeEndpoint returns an endpoint that was free a moment ago.
+func freeEndpoint(t *testing.T) string {
+	t.Helper()
+	lis, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = lis.Close() }()
+	return lis.Addr().String()
+}
+
+func run(t *testing.T, yaml string, connections int) *preflight.Report {
+	t.Helper()
+	report, err := preflight.Run(context.Background(), preflight.Options{
+		ConfigURIs:          []string{writeConfig(t, yaml)},
+		ExpectedConnections: connections,
+	})
+	require.NoError(t, err)
+	return report
+}
+
+// find returns the check of name on target.
+func find(t *testing.T, report *preflight.Report, name, target string) preflight.Check {
+	t.Helper()
+	for _, c := range report.Checks {
+		if c.Name == name && c.Target == target {
+			return c
+		}
+	}
+	require.Failf(t, "check not found", "%s %s in %+v", name, target, report.Checks)
+	return preflight.Check{}
+}
+
+func TestRun_Ports(t *testing.T) {
+	busy, free, unused := busyEndpoint(t), freeEndpoint(t), busyEndpoint(t)
+	report := run(t, fmt.Sprintf(`
+receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: %s
+      http:
+        endpoint: %s
+  zipkin:
+    endpoint: %s
+exporters:
+  debug:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [debug]
+`, busy, free, unused), 1)
+
+	assert.False(t, report.Passed())
+	check := find(t, report, "port", busy)
+	require.Error(t, check.Err)
+	assert.Equal(t, busy+" is already in use; stop the process holding it or change receivers::otlp::protocols::grpc::endpoint", check.Err.Error())
+	assert.NoError(t, find(t, report, "port", free).Err)
+
+	for _, c := range report.Checks {
+		assert.NotEqual(t, unused, c.Target, "receivers outside the pipelines are not checked")
+	}
+
+	var out bytes.Buffer
+	report.Print(&out)
+	assert.Contains(t, out.String(), "FAIL  port")
+	assert.Contains(t, out.String(), "Result: FAIL")
+}
+
+func TestRun_PortDefaultsAndExtensions(t *testing.T) {
+	health := freeEndpoint(t)
+	report := run(t, fmt.Sprintf(`
+extensions:
+  health_check:
+    endpoint: %s
+receivers:
+  otlp:
+    protocols:
+      grpc:
+  tfootlp:
+    protocols:
+      grpc:
+        endpoint: 127.0.0.1:0
+exporters:
+  otlp:
+    endpoint: backend.example.com:4317
+service:
+  extensions: [health_check]
+  pipelines:
+    traces:
+      receivers: [otlp, tfootlp]
+      exporters: [otlp]
+`, health), 1)
+
+	assert.NoError(t, find(t, report, "port", health).Err)
+	targets := make([]string, 0, len(report.Checks))
+	for _, c := range report.Checks {
+		if c.Name == "port" {
+			targets = append(targets, c.Target)
+		}
+	}
+	assert.Contains(t, targets, "localhost:4317", "the default endpoint of a protocol left empty is checked")
+	assert.NotContains(t, targets, "backend.example.com:4317", "exporter endpoints are not listen endpoints")
+	assert.NotContains(t, targets, "localhost:55690", "endpoints of disabled features are not checked")
+}
+
+func TestRun_ClientEndpointsSkipped(t *testing.T) {
+	server := busyEndpoint(t)
+	report := run(t, fmt.Sprintf(`
+receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: 127.0.0.1:0
+  redis:
+    endpoint: %s
+exporters:
+  debug:
+service:
+  pipelines:
+    metrics:
+      receivers: [otlp, redis]
+      exporters: [debug]
+`, server), 1)
+
+	assert.True(t, report.Passed())
+	for _, c := range report.Checks {
+		assert.NotEqual(t, server, c.Target, "the server a scraper connects to is not a listen endpoint")
+	}
+}
+
+func TestRun_Disk(t *testing.T) {
+	config := func(dir, maxSize string) string {
+		return fmt.Sprintf(`
+receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: 127.0.0.1:0
+exporters:
+  tforetention:
+    directory: %s
+    max_size: %s
+    segment_size: 1KiB
+service:
+  pipelines:
+    logs:
+      receivers: [otlp]
+      exporters: [tforetention]
+`, dir, maxSize)
+	}
+
+	dir := filepath.Join(t.TempDir(), "retention")
+	report := run(t, config(dir, "1MiB"), 1)
+	check := find(t, report, "disk", dir)
+	assert.NoError(t, check.Err)
+	assert.Contains(t, check.Detail, "1MiB needed")
+
+	report = run(t, config(dir, "1000000TiB"), 1)
+	check = find(t, report, "disk", dir)
+	require.Error(t, check.Err)
+	assert.Contains(t, check.Err.Error(), "free disk space or lower exporters::tforetention::max_size")
+
+	file := filepath.Join(t.TempDir(), "file")
+	require.NoError(t, os.WriteFile(file, nil, 0o600))
+	report = run(t, config(file, "1MiB"), 1)
+	check = find(t, report, "disk", file)
+	require.Error(t, check.Err)
+	assert.Contains(t, check.Err.Error(), "is not a directory; change exporters::tforetention::directory")
+}
+
+func TestRun_FileStorage(t *testing.T) {
+	config := func(dir string, create bool) string {
+		return fmt.Sprintf(`
+extensions:
+  file_storage:
+    directory: %s
+    create_directory: %t
+receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: 127.0.0.1:0
+exporters:
+  debug:
+service:
+  extensions: [file_storage]
+  pipelines:
+    logs:
+      receivers: [otlp]
+      exporters: [debug]
+`, dir, create)
+	}
+
+	dir := t.TempDir()
+	check := find(t, run(t, config(dir, false), 1), "disk", dir)
+	require.NoError(t, check.Err)
+	assert.Contains(t, check.Detail, "free")
+
+	missing := filepath.Join(dir, "queue")
+	check = find(t, run(t, config(missing, false), 1), "disk", missing)
+	require.Error(t, check.Err)
+	assert.Contains(t, check.Err.Error(), "does not exist; create it, set create_directory or change extensions::file_storage::directory")
+	assert.NoError(t, find(t, run(t, config(missing, true), 1), "disk", missing).Err)
+}
+
+func TestRun_OpenFiles(t *testing.T) {
+	config := `
+receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: 127.0.0.1:0
+exporters:
+  otlp:
+    endpoint: backend.example.com:4317
+    sending_queue:
+      enabled: true
+      num_consumers: 7
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [otlp]
+`
+	check := find(t, run(t, config, 1), "open files", "RLIMIT_NOFILE")
+	require.NoError(t, check.Err)
+	assert.Contains(t, check.Detail, "72 expected", "1 connection, 7 export workers and 64 reserved")
+
+	check = find(t, run(t, config, 1<<40), "open files", "RLIMIT_NOFILE")
+	require.Error(t, check.Err)
+	assert.Contains(t, check.Err.Error(), "(1099511627776 connections, 0 listeners, 7 export workers, 64 reserved); raise it with")
+}
+
+func TestRun_ConfigErrors(t *testing.T) {
+	_, err := preflight.Run(context.Background(), preflight.Options{})
+	assert.ErrorContains(t, err, "at least one config file must be provided")
+
+	_, err = preflight.Run(context.Background(), preflight.Options{
+		ConfigURIs: []string{filepath.Join(t.TempDir(), "missing.yaml")},
+	})
+	assert.ErrorContains(t, err, "failed to load configuration")
+}
+
+func TestRun_PortsHandedOver(t *testing.T) {
+	busy, dir := busyEndpoint(t), t.TempDir()
+	yaml := fmt.Sprintf(`
+listener_handoff:
+  enabled: true
+  directory: %s
+receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: %s
+exporters:
+  debug:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [debug]
+`, dir, busy)
+
+	// Without a running collector serving the handoff socket the port
+	// stays busy.
+	require.Error(t, find(t, run(t, yaml, 1), "port", busy).Err)
+
+	sock, err := net.Listen("unix", filepath.Join(dir, "handoff.sock"))
+	require.NoError(t, err)
+	defer func() { _ = sock.Close() }()
+	check := find(t, run(t, yaml, 1), "port", busy)
+	require.NoError(t, check.Err)
+	assert.Equal(t, "in use by the previous process, which hands over and stops", check.Detail)
+}
@@ -0,0 +1,174 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package presetconf_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/confmap"
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/presetconf"
+)
+
+func noEnv(string) (string, bool) { return "", false }
+
+func baseConf(preset string) *confmap.Conf {
+	return confmap.NewFromStringMap(map[string]any{
+		"preset": preset,
+		"processors": map[string]any{
+			"batch":          map[string]any{},
+			"memory_limiter": map[string]any{},
+		},
+		"exporters": map[string]any{
+			"otlp":  map[string]any{"endpoint": "gateway:4317"},
+			"tfo":   map[string]any{"endpoint": "https://api.example.com"},
+			"debug": map[string]any{},
+		},
+		"service": map[string]any{
+			"pipelines": map[string]any{
+				"traces": map[string]any{
+					"receivers":  []any{"otlp"},
+					"processors": []any{"memory_limiter", "batch"},
+					"exporters":  []any{"otlp", "tfo"},
+				},
+			},
+		},
+	})
+}
+
+func TestApply_FillsDefaults(t *testing.T) {
+	conf := baseConf("edge-small")
+	name, err := presetconf.Apply(conf, noEnv)
+	require.NoError(t, err)
+
+	assert.Equal(t, "edge-small", name)
+	assert.False(t, conf.IsSet(presetconf.SectionKey), "key must not reach otelcol")
+	assert.Equal(t, "55%", conf.Get("runtime::gomemlimit"))
+	assert.Equal(t, 50, conf.Get("runtime::gc_percent"))
+	assert.Equal(t, 256, conf.Get("processors::batch::send_batch_size"))
+	assert.Equal(t, "5s", conf.Get("processors::batch::timeout"))
+	assert.Equal(t, 70, conf.Get("processors::memory_limiter::limit_percentage"))
+	assert.Equal(t, 15, conf.Get("processors::memory_limiter::spike_limit_percentage"))
+	assert.Equal(t, "1s", conf.Get("processors::memory_limiter::check_interval"))
+	assert.Equal(t, 2, conf.Get("exporters::otlp::sending_queue::num_consumers"))
+	assert.Equal(t, 200, conf.Get("exporters::otlp::sending_queue::queue_size"))
+	assert.False(t, conf.IsSet("exporters::tfo::sending_queue"), "the tfo queue stays disabled unless configured")
+	assert.False(t, conf.IsSet("exporters::debug::sending_queue"))
+	assert.Equal(t, []any{"health_check"}, conf.Get("service::extensions"))
+	assert.True(t, conf.IsSet("extensions::health_check"))
+}
+
+func TestApply_ExplicitConfigWins(t *testing.T) {
+	conf := baseConf("gateway-large")
+	require.NoError(t, conf.Merge(confmap.NewFromStringMap(map[string]any{
+		"runtime": map[string]any{"gomemlimit": "2GiB"},
+		"processors": map[string]any{
+			"batch":          map[string]any{"send_batch_max_size": 1000},
+			"memory_limiter": map[string]any{"limit_mib": 1500},
+		},
+		"exporters": map[string]any{
+			"otlp": map[string]any{"sending_queue": map[string]any{"sizer": "bytes"}},
+			"tfo":  map[string]any{"sending_queue": map[string]any{"num_consumers": 5}},
+		},
+	})))
+	_, err := presetconf.Apply(conf, noEnv)
+	require.NoError(t, err)
+
+	assert.Equal(t, "2GiB", conf.Get("runtime::gomemlimit"))
+	assert.Equal(t, 200, conf.Get("runtime::gc_percent"), "other runtime keys keep their defaults")
+	assert.False(t, conf.IsSet("processors::batch::send_batch_size"), "the batch size is defaulted with its maximum")
+	assert.Equal(t, "200ms", conf.Get("processors::batch::timeout"))
+	assert.False(t, conf.IsSet("processors::memory_limiter::limit_percentage"), "limit_mib replaces the percentages")
+	assert.Equal(t, "1s", conf.Get("processors::memory_limiter::check_interval"))
+	assert.False(t, conf.IsSet("exporters::otlp::sending_queue::queue_size"), "queue_size depends on the sizer")
+	assert.Equal(t, 32, conf.Get("exporters::otlp::sending_queue::num_consumers"))
+	assert.Equal(t, 5, conf.Get("exporters::tfo::sending_queue::num_consumers"))
+	assert.Equal(t, 20000, conf.Get("exporters::tfo::sending_queue::queue_size"), "a configured tfo queue is defaulted")
+}
+
+func TestApply_EnvironmentWins(t *testing.T) {
+	conf := baseConf("edge-small")
+	_, err := presetconf.Apply(conf, func(key string) (string, bool) {
+		return "400MiB", key == "GOMEMLIMIT"
+	})
+	require.NoError(t, err)
+
+	assert.False(t, conf.IsSet("runtime::gomemlimit"))
+	assert.Equal(t, 50, conf.Get("runtime::gc_percent"))
+}
+
+func TestApply_ExplicitExtensionsReplacePreset(t *testing.T) {
+	conf := baseConf("gateway-large")
+	require.NoError(t, conf.Merge(confmap.NewFromStringMap(map[string]any{
+		"extensions": map[string]any{"zpages": map[string]any{}},
+		"service":    map[string]any{"extensions": []any{"zpages"}},
+	})))
+	_, err := presetconf.Apply(conf, noEnv)
+	require.NoError(t, err)
+
+	assert.Equal(t, []any{"zpages"}, conf.Get("service::extensions"))
+	assert.False(t, conf.IsSet("extensions::health_check"))
+	assert.False(t, conf.IsSet("extensions::pprof"))
+}
+
+func TestApply_EnabledExtensionsGetDefaults(t *testing.T) {
+	conf := baseConf("k8s-daemonset")
+	_, err := presetconf.Apply(conf, noEnv)
+	require.NoError(t, err)
+
+	assert.Equal(t, []any{"health_check"}, conf.Get("service::extensions"))
+	assert.Equal(t, "0.0.0.0:13133", conf.Get("extensions::health_check::endpoint"))
+}
+
+func TestApply_NoPreset(t *testing.T) {
+	for _, conf := range []*confmap.Conf{baseConf(""), confmap.NewFromStringMap(map[string]any{"exporters": map[string]any{}})} {
+		before := conf.ToStringMap()
+		delete(before, presetconf.SectionKey)
+		name, err := presetconf.Apply(conf, noEnv)
+		require.NoError(t, err)
+		assert.Empty(t, name)
+		assert.Equal(t, before, conf.ToStringMap())
+	}
+}
+
+func TestApply_UnknownPreset(t *testing.T) {
+	_, err := presetconf.Apply(baseConf("tiny"), noEnv)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `preset "tiny" is not defined`)
+	assert.Contains(t, err.Error(), "edge-small, gateway-large, k8s-daemonset")
+}
+
+func TestApply_RejectsNonStringPreset(t *testing.T) {
+	conf := confmap.NewFromStringMap(map[string]any{"preset": map[string]any{"name": "edge-small"}})
+	_, err := presetconf.Apply(conf, noEnv)
+	require.Error(t, err)
+}
+
+func TestConverter_AppliesPreset(t *testing.T) {
+	conf := baseConf("edge-small")
+	c := presetconf.NewConverterFactory().Create(confmap.ConverterSettings{Logger: zap.NewNop()})
+	require.NoError(t, c.Convert(context.Background(), conf))
+
+	assert.False(t, conf.IsSet(presetconf.SectionKey))
+	assert.Equal(t, 256, conf.Get("processors::batch::send_batch_size"))
+}
+
+func TestNames(t *testing.T) {
+	assert.Equal(t, []string{"edge-small", "gateway-large", "k8s-daemonset"}, presetconf.Names())
+}
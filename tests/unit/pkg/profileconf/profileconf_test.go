@@ -0,0 +1,123 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package profileconf_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/confmap"
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/profileconf"
+)
+
+func baseConf() *confmap.Conf {
+	return confmap.NewFromStringMap(map[string]any{
+		"exporters": map[string]any{
+			"tfo": map[string]any{
+				"endpoint": "https://api.example.com",
+				"timeout":  "30s",
+			},
+		},
+		"service": map[string]any{
+			"pipelines": map[string]any{
+				"traces": map[string]any{
+					"receivers": []any{"otlp"},
+					"exporters": []any{"tfo", "debug"},
+				},
+			},
+		},
+		"profiles": map[string]any{
+			"dev": map[string]any{
+				"exporters": map[string]any{
+					"tfo": map[string]any{"endpoint": "https://dev.example.com"},
+				},
+				"service": map[string]any{
+					"pipelines": map[string]any{
+						"traces": map[string]any{"exporters": []any{"debug"}},
+					},
+				},
+			},
+			"prod": nil,
+		},
+	})
+}
+
+func convert(t *testing.T, profile string, conf *confmap.Conf) error {
+	t.Helper()
+	c := profileconf.NewConverterFactory(profile).Create(confmap.ConverterSettings{Logger: zap.NewNop()})
+	return c.Convert(context.Background(), conf)
+}
+
+func TestConverter_MergesProfileOverBase(t *testing.T) {
+	conf := baseConf()
+	require.NoError(t, convert(t, "dev", conf))
+
+	assert.False(t, conf.IsSet(profileconf.SectionKey), "section must not reach otelcol")
+	assert.Equal(t, "https://dev.example.com", conf.Get("exporters::tfo::endpoint"))
+	assert.Equal(t, "30s", conf.Get("exporters::tfo::timeout"), "base keys are kept")
+	assert.Equal(t, []any{"debug"}, conf.Get("service::pipelines::traces::exporters"), "lists are replaced")
+	assert.Equal(t, []any{"otlp"}, conf.Get("service::pipelines::traces::receivers"))
+}
+
+func TestConverter_EmptyProfileKeepsBase(t *testing.T) {
+	conf := baseConf()
+	require.NoError(t, convert(t, "prod", conf))
+
+	assert.False(t, conf.IsSet(profileconf.SectionKey))
+	assert.Equal(t, "https://api.example.com", conf.Get("exporters::tfo::endpoint"))
+}
+
+func TestConverter_NoProfileRemovesSection(t *testing.T) {
+	t.Setenv(profileconf.EnvVar, "")
+	conf := baseConf()
+	require.NoError(t, convert(t, "", conf))
+
+	assert.False(t, conf.IsSet(profileconf.SectionKey))
+	assert.Equal(t, "https://api.example.com", conf.Get("exporters::tfo::endpoint"))
+}
+
+func TestConverter_ProfileFromEnv(t *testing.T) {
+	t.Setenv(profileconf.EnvVar, "dev")
+	conf := baseConf()
+	require.NoError(t, convert(t, "", conf))
+	assert.Equal(t, "https://dev.example.com", conf.Get("exporters::tfo::endpoint"))
+
+	conf = baseConf()
+	require.NoError(t, convert(t, "prod", conf), "the flag wins over the environment")
+	assert.Equal(t, "https://api.example.com", conf.Get("exporters::tfo::endpoint"))
+}
+
+func TestConverter_UnknownProfile(t *testing.T) {
+	err := convert(t, "staging", baseConf())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `profile "staging" is not defined`)
+	assert.Contains(t, err.Error(), "dev, prod")
+
+	conf := confmap.NewFromStringMap(map[string]any{"receivers": map[string]any{"nop": nil}})
+	err = convert(t, "dev", conf)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "defines no profiles")
+}
+
+func TestConverter_ProfileNotAMap(t *testing.T) {
+	conf := confmap.NewFromStringMap(map[string]any{
+		"profiles": map[string]any{"dev": "oops"},
+	})
+	assert.ErrorContains(t, convert(t, "dev", conf), "expected a map")
+}
@@ -0,0 +1,160 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package provenance_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/provenance"
+)
+
+var received = time.Date(2026, 5, 14, 9, 11, 32, 123456789, time.UTC)
+
+func hop(id string) provenance.Hop {
+	return provenance.Hop{CollectorID: id, Tenant: "tenant-" + id, ReceivedAt: received, MaxHops: 3}
+}
+
+func path(t *testing.T, attrs pcommon.Map) []any {
+	t.Helper()
+	v, ok := attrs.Get(provenance.AttrPath)
+	require.True(t, ok)
+	return v.Slice().AsRaw()
+}
+
+func TestStamp_FirstHopStartsEnvelope(t *testing.T) {
+	attrs := pcommon.NewMap()
+	require.NoError(t, provenance.Stamp(attrs, hop("edge-1")))
+
+	assert.Equal(t, map[string]any{
+		provenance.AttrOriginCollectorID: "edge-1",
+		provenance.AttrOriginTenant:      "tenant-edge-1",
+		provenance.AttrOriginReceivedAt:  "2026-05-14T09:11:32.123456789Z",
+		provenance.AttrHopCount:          int64(1),
+		provenance.AttrPath:              []any{"edge-1"},
+	}, attrs.AsRaw())
+}
+
+func TestStamp_LaterHopsKeepOrigin(t *testing.T) {
+	attrs := pcommon.NewMap()
+	require.NoError(t, provenance.Stamp(attrs, hop("edge-1")))
+	later := hop("region-1")
+	later.ReceivedAt = received.Add(time.Minute)
+	require.NoError(t, provenance.Stamp(attrs, later))
+
+	origin, _ := attrs.Get(provenance.AttrOriginCollectorID)
+	assert.Equal(t, "edge-1", origin.Str())
+	tenant, _ := attrs.Get(provenance.AttrOriginTenant)
+	assert.Equal(t, "tenant-edge-1", tenant.Str())
+	at, _ := attrs.Get(provenance.AttrOriginReceivedAt)
+	assert.Equal(t, "2026-05-14T09:11:32.123456789Z", at.Str())
+	hops, _ := attrs.Get(provenance.AttrHopCount)
+	assert.Equal(t, int64(2), hops.Int())
+	assert.Equal(t, []any{"edge-1", "region-1"}, path(t, attrs))
+}
+
+func TestStamp_NoTenant(t *testing.T) {
+	attrs := pcommon.NewMap()
+	require.NoError(t, provenance.Stamp(attrs, provenance.Hop{CollectorID: "edge-1", ReceivedAt: received}))
+
+	_, ok := attrs.Get(provenance.AttrOriginTenant)
+	assert.False(t, ok)
+}
+
+func TestStamp_Loop(t *testing.T) {
+	attrs := pcommon.NewMap()
+	require.NoError(t, provenance.Stamp(attrs, hop("edge-1")))
+	require.NoError(t, provenance.Stamp(attrs, hop("region-1")))
+	before := attrs.AsRaw()
+
+	assert.ErrorIs(t, provenance.Stamp(attrs, hop("edge-1")), provenance.ErrLoop)
+	assert.Equal(t, before, attrs.AsRaw(), "attributes unchanged")
+}
+
+func TestStamp_HopLimit(t *testing.T) {
+	attrs := pcommon.NewMap()
+	for _, id := range []string{"edge-1", "region-1", "global-1"} {
+		require.NoError(t, provenance.Stamp(attrs, hop(id)))
+	}
+	before := attrs.AsRaw()
+
+	assert.ErrorIs(t, provenance.Stamp(attrs, hop("backend-1")), provenance.ErrHopLimit)
+	assert.Equal(t, before, attrs.AsRaw(), "attributes unchanged")
+
+	unlimited := hop("backend-1")
+	unlimited.MaxHops = 0
+	require.NoError(t, provenance.Stamp(attrs, unlimited))
+}
+
+func TestStampTraces_RemovesRejectedResources(t *testing.T) {
+	td := ptrace.NewTraces()
+	addResource := func(stamps []string, spans int) {
+		rs := td.ResourceSpans().AppendEmpty()
+		for _, id := range stamps {
+			h := hop(id)
+			h.MaxHops = 0
+			require.NoError(t, provenance.Stamp(rs.Resource().Attributes(), h))
+		}
+		ss := rs.ScopeSpans().AppendEmpty()
+		for range spans {
+			ss.Spans().AppendEmpty()
+		}
+	}
+	addResource(nil, 1)                                      // new
+	addResource([]string{"edge-1"}, 2)                       // forwarded
+	addResource([]string{"region-1", "edge-2"}, 3)           // loop
+	addResource([]string{"edge-3", "edge-4", "edge-5"}, 4)   // over the limit
+	addResource([]string{"edge-6", "edge-7", "region-1"}, 5) // loop and over the limit
+
+	rejected := provenance.StampTraces(td, hop("region-1"))
+
+	assert.Equal(t, provenance.Rejected{Loop: 8, HopLimit: 4}, rejected)
+	require.Equal(t, 2, td.ResourceSpans().Len())
+	assert.Equal(t, []any{"region-1"}, path(t, td.ResourceSpans().At(0).Resource().Attributes()))
+	assert.Equal(t, []any{"edge-1", "region-1"}, path(t, td.ResourceSpans().At(1).Resource().Attributes()))
+}
+
+func TestStampMetricsAndLogs(t *testing.T) {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	require.NoError(t, provenance.Stamp(rm.Resource().Attributes(), hop("region-1")))
+	metrics := rm.ScopeMetrics().AppendEmpty().Metrics()
+	metrics.AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty()
+	sum := metrics.AppendEmpty().SetEmptySum().DataPoints()
+	sum.AppendEmpty()
+	sum.AppendEmpty()
+	metrics.AppendEmpty().SetEmptyHistogram().DataPoints().AppendEmpty()
+	md.ResourceMetrics().AppendEmpty()
+
+	assert.Equal(t, provenance.Rejected{Loop: 4}, provenance.StampMetrics(md, hop("region-1")))
+	assert.Equal(t, 1, md.ResourceMetrics().Len())
+
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	for _, id := range []string{"a", "b", "c"} {
+		require.NoError(t, provenance.Stamp(rl.Resource().Attributes(), hop(id)))
+	}
+	rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+
+	assert.Equal(t, provenance.Rejected{HopLimit: 1}, provenance.StampLogs(ld, hop("d")))
+	assert.Zero(t, ld.ResourceLogs().Len())
+}
@@ -0,0 +1,320 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package registry_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/exporter/exportertest"
+	"go.opentelemetry.io/collector/otelcol"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/collector/service/telemetry/otelconftelemetry"
+
+	"github.com/telemetryflow/telemetryflow-collector/components/tfoprometheusexporter"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/registry"
+)
+
+func TestNewRegistrySet_Empty(t *testing.T) {
+	reg := registry.NewRegistrySet()
+
+	_, err := reg.Factories()
+	require.Error(t, err, "a set without telemetry factory cannot build a collector")
+
+	reg.SetTelemetry(otelconftelemetry.NewFactory())
+	factories, err := reg.Factories()
+	require.NoError(t, err)
+	assert.Empty(t, factories.Receivers)
+	assert.Empty(t, factories.Exporters)
+}
+
+func TestDefault_ContainsBuiltins(t *testing.T) {
+	factories, err := registry.Default().Factories()
+	require.NoError(t, err)
+
+	assert.Contains(t, factories.Receivers, component.MustNewType("tfootlp"))
+	assert.Contains(t, factories.Receivers, component.MustNewType("otlp"))
+	assert.Contains(t, factories.Exporters, component.MustNewType("tfo"))
+	assert.Contains(t, factories.Exporters, component.MustNewType("file"))
+	assert.Contains(t, factories.Extensions, component.MustNewType("tfoauth"))
+	assert.Contains(t, factories.Extensions, component.MustNewType("tfoidentity"))
+	assert.Contains(t, factories.Extensions, component.MustNewType("tfoopamp"))
+	assert.Contains(t, factories.Extensions, component.MustNewType("tfosupport"))
+	assert.Contains(t, factories.Extensions, component.MustNewType("tfoqueuequota"))
+	assert.Contains(t, factories.Processors, component.MustNewType("batch"))
+	assert.Contains(t, factories.Processors, component.MustNewType("redaction"))
+	assert.Contains(t, factories.Processors, component.MustNewType("tfocardinality"))
+	assert.Contains(t, factories.Processors, component.MustNewType("tfospanname"))
+	assert.Contains(t, factories.Processors, component.MustNewType("tfotraceid"))
+	assert.Contains(t, factories.Connectors, component.MustNewType("span_metrics"))
+	assert.Contains(t, factories.Receivers, component.MustNewType("file_log"))
+	assert.Contains(t, factories.Extensions, component.MustNewType("file_storage"))
+	assert.NotNil(t, factories.Telemetry)
+}
+
+func TestDefault_DeprecatedAliases(t *testing.T) {
+	factories, err := registry.Default().Factories()
+	require.NoError(t, err)
+
+	// Renamed contrib components still accept their former type.
+	fileLog := factories.Receivers[component.MustNewType("file_log")]
+	require.NotNil(t, fileLog)
+	assert.Same(t, fileLog, factories.Receivers[component.MustNewType("filelog")])
+	assert.Same(t, factories.Receivers[component.MustNewType("host_metrics")],
+		factories.Receivers[component.MustNewType("hostmetrics")])
+
+	reg := registry.NewRegistrySet()
+	require.NoError(t, reg.RegisterReceivers(fileLog))
+	err = reg.RegisterReceivers(fileLog)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `duplicate receiver factory "file_log"`)
+}
+
+func TestDefault_PrometheusExporter(t *testing.T) {
+	factories, err := registry.Default().Factories()
+	require.NoError(t, err)
+
+	// The TFO wrapper replaces the upstream prometheus exporter.
+	prometheus := factories.Exporters[component.MustNewType("prometheus")]
+	require.NotNil(t, prometheus)
+	assert.IsType(t, &tfoprometheusexporter.Config{}, prometheus.CreateDefaultConfig())
+}
+
+func TestDefault_Independent(t *testing.T) {
+	a := registry.Default()
+	b := registry.Default()
+
+	require.NoError(t, a.RegisterReceivers(receivertest.NewNopFactory()))
+
+	fa, err := a.Factories()
+	require.NoError(t, err)
+	fb, err := b.Factories()
+	require.NoError(t, err)
+	assert.Contains(t, fa.Receivers, component.MustNewType("nop"))
+	assert.NotContains(t, fb.Receivers, component.MustNewType("nop"))
+}
+
+func TestRegister_Duplicate(t *testing.T) {
+	reg := registry.NewRegistrySet()
+	require.NoError(t, reg.RegisterExporters(exportertest.NewNopFactory()))
+
+	err := reg.RegisterExporters(exportertest.NewNopFactory())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `duplicate exporter factory "nop"`)
+}
+
+func TestRegister_DuplicateIsAtomic(t *testing.T) {
+	reg := registry.Default()
+	reg.SetTelemetry(otelconftelemetry.NewFactory())
+	before, err := reg.Factories()
+	require.NoError(t, err)
+
+	// nop is new, otlp already exists: neither may be registered.
+	otlp := before.Receivers[component.MustNewType("otlp")]
+	require.Error(t, reg.RegisterReceivers(receivertest.NewNopFactory(), otlp))
+
+	after, err := reg.Factories()
+	require.NoError(t, err)
+	assert.Len(t, after.Receivers, len(before.Receivers))
+	assert.NotContains(t, after.Receivers, component.MustNewType("nop"))
+}
+
+func TestReplace_Overwrites(t *testing.T) {
+	reg := registry.NewRegistrySet()
+	reg.SetTelemetry(otelconftelemetry.NewFactory())
+	require.NoError(t, reg.RegisterReceivers(receivertest.NewNopFactory()))
+
+	replacement := receivertest.NewNopFactory()
+	reg.ReplaceReceivers(replacement)
+
+	factories, err := reg.Factories()
+	require.NoError(t, err)
+	assert.Len(t, factories.Receivers, 1)
+	assert.Same(t, replacement, factories.Receivers[component.MustNewType("nop")])
+}
+
+func TestClone_Independent(t *testing.T) {
+	reg := registry.NewRegistrySet()
+	reg.SetTelemetry(otelconftelemetry.NewFactory())
+	clone := reg.Clone()
+
+	require.NoError(t, clone.RegisterReceivers(receivertest.NewNopFactory()))
+
+	orig, err := reg.Factories()
+	require.NoError(t, err)
+	assert.Empty(t, orig.Receivers)
+}
+
+func TestFactories_Snapshot(t *testing.T) {
+	reg := registry.NewRegistrySet()
+	reg.SetTelemetry(otelconftelemetry.NewFactory())
+	snapshot, err := reg.Factories()
+	require.NoError(t, err)
+
+	require.NoError(t, reg.RegisterReceivers(receivertest.NewNopFactory()))
+	assert.Empty(t, snapshot.Receivers)
+}
+
+func TestBuilder_Settings(t *testing.T) {
+	set := registry.Builder{}.Settings()
+
+	assert.Equal(t, registry.DefaultBuildInfo(), set.BuildInfo)
+	assert.Len(t, set.ConfigProviderSettings.ResolverSettings.ProviderFactories, 3)
+	assert.Len(t, set.ConfigProviderSettings.ResolverSettings.ConverterFactories, 7)
+
+	factories, err := set.Factories()
+	require.NoError(t, err)
+	assert.Contains(t, factories.Receivers, component.MustNewType("tfootlp"))
+}
+
+const nopConfig = `
+receivers:
+  nop:
+exporters:
+  nop:
+service:
+  telemetry:
+    metrics:
+      level: none
+  pipelines:
+    traces:
+      receivers: [nop]
+      exporters: [nop]
+`
+
+func TestBuilder_NewWithInjectedRegistry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(nopConfig), 0o600))
+
+	reg := registry.NewRegistrySet()
+	reg.SetTelemetry(otelconftelemetry.NewFactory())
+	require.NoError(t, reg.RegisterReceivers(receivertest.NewNopFactory()))
+	require.NoError(t, reg.RegisterExporters(exportertest.NewNopFactory()))
+
+	col, err := registry.Builder{
+		Registry:   reg,
+		BuildInfo:  component.BuildInfo{Command: "embedded", Version: "test"},
+		ConfigURIs: []string{"file:" + path},
+	}.New()
+	require.NoError(t, err)
+
+	done := make(chan error, 1)
+	go func() { done <- col.Run(context.Background()) }()
+
+	require.Eventually(t, func() bool {
+		return col.GetState() == otelcol.StateRunning
+	}, 10*time.Second, 10*time.Millisecond)
+
+	col.Shutdown()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(10 * time.Second):
+		t.Fatal("collector did not shut down")
+	}
+}
+
+func TestBuilder_Validate(t *testing.T) {
+	reg := registry.NewRegistrySet()
+	reg.SetTelemetry(otelconftelemetry.NewFactory())
+	require.NoError(t, reg.RegisterReceivers(receivertest.NewNopFactory()))
+	require.NoError(t, reg.RegisterExporters(exportertest.NewNopFactory()))
+
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.yaml")
+	require.NoError(t, os.WriteFile(valid, []byte(nopConfig), 0o600))
+	unknown := filepath.Join(dir, "unknown.yaml")
+	require.NoError(t, os.WriteFile(unknown, []byte(strings.ReplaceAll(nopConfig, "nop", "missing")), 0o600))
+
+	err := registry.Builder{Registry: reg, ConfigURIs: []string{"file:" + valid}}.Validate(context.Background())
+	assert.NoError(t, err)
+
+	err = registry.Builder{Registry: reg, ConfigURIs: []string{"file:" + unknown}}.Validate(context.Background())
+	assert.ErrorContains(t, err, "missing")
+}
+
+func TestBuilder_ValidateDryRun(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "config.yaml")
+	config := `
+preset: edge-small
+runtime:
+  gomaxprocs: 1
+listener_handoff:
+  enabled: true
+  directory: `+dir+`
+receivers:
+  otlp:
+    protocols:
+      grpc:
+        endpoint: 127.0.0.1:0
+exporters:
+  debug:
+service:
+  telemetry:
+    metrics:
+      level: none
+  pipelines:
+    traces:
+      receivers: [otlp]
+      exporters: [debug]
+`
+	require.NoError(t, os.WriteFile(path, []byte(config), 0o600))
+
+	memoryLimit, procs := debug.SetMemoryLimit(-1), runtime.GOMAXPROCS(0)
+	err := registry.Builder{ConfigURIs: []string{"file:" + path}, DryRun: true}.Validate(context.Background())
+	require.NoError(t, err, "the runtime and listener_handoff sections, also those set by the preset, are removed")
+	assert.Equal(t, memoryLimit, debug.SetMemoryLimit(-1), "the Go runtime is left alone")
+	assert.Equal(t, procs, runtime.GOMAXPROCS(0))
+	assert.NoFileExists(t, filepath.Join(dir, "handoff.sock"), "no handoff server is started")
+
+	require.NoError(t, os.WriteFile(path, []byte(strings.Replace(config, "gomaxprocs: 1", "gomaxprocs: -2", 1)), 0o600))
+	err = registry.Builder{ConfigURIs: []string{"file:" + path}, DryRun: true}.Validate(context.Background())
+	assert.ErrorContains(t, err, "runtime.gomaxprocs must not be negative", "the sections are still checked")
+}
+
+func TestBuilder_AppliesProfile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	config := nopConfig + `
+profiles:
+  quiet:
+    service:
+      telemetry:
+        logs:
+          level: error
+`
+	require.NoError(t, os.WriteFile(path, []byte(config), 0o600))
+
+	set := registry.Builder{ConfigURIs: []string{"file:" + path}, Profile: "quiet"}.Settings()
+	resolver, err := confmap.NewResolver(set.ConfigProviderSettings.ResolverSettings)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = resolver.Shutdown(context.Background()) })
+
+	conf, err := resolver.Resolve(context.Background())
+	require.NoError(t, err)
+	assert.False(t, conf.IsSet("profiles"))
+	assert.Equal(t, "error", conf.Get("service::telemetry::logs::level"))
+	assert.Equal(t, "none", conf.Get("service::telemetry::metrics::level"))
+}
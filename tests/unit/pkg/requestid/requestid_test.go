@@ -0,0 +1,63 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package requestid_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/requestid"
+)
+
+func TestNew(t *testing.T) {
+	a, b := requestid.New(), requestid.New()
+	assert.Len(t, a, 32)
+	assert.NotEqual(t, a, b)
+	assert.True(t, requestid.Valid(a))
+}
+
+func TestValid(t *testing.T) {
+	assert.True(t, requestid.Valid("req-1"))
+	assert.True(t, requestid.Valid("3f2b9c1e-7a4d-4b8e-9c0f-1d2e3f4a5b6c"))
+	assert.False(t, requestid.Valid(""))
+	assert.False(t, requestid.Valid("with space"))
+	assert.False(t, requestid.Valid("line\nbreak"))
+	assert.False(t, requestid.Valid("ümlaut"))
+}
+
+func TestContext(t *testing.T) {
+	ctx := context.Background()
+	assert.Empty(t, requestid.FromContext(ctx))
+
+	ctx = requestid.NewContext(ctx, "req-1")
+	assert.Equal(t, "req-1", requestid.FromContext(ctx))
+}
+
+func TestField(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	logger := zap.New(core)
+
+	logger.Info("without", requestid.Field(context.Background()))
+	logger.Info("with", requestid.Field(requestid.NewContext(context.Background(), "req-1")))
+
+	entries := logs.All()
+	assert.Empty(t, entries[0].ContextMap())
+	assert.Equal(t, map[string]any{"request_id": "req-1"}, entries[1].ContextMap())
+}
@@ -0,0 +1,205 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package residency_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/pipeline"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/residency"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+func euConfig(mode residency.Mode) residency.Config {
+	cfg := residency.NewDefaultConfig()
+	cfg.Enabled = true
+	cfg.Regions = []string{"EU", "eu-west-1"}
+	cfg.Mode = mode
+	return cfg
+}
+
+func newPolicy(t *testing.T, cfg residency.Config) *residency.Policy {
+	t.Helper()
+	p, err := residency.NewPolicy(cfg, componenttest.NewNopTelemetrySettings(),
+		selfmetrics.Exporter(component.MustNewIDWithName("tfo", "eu"), pipeline.SignalTraces))
+	require.NoError(t, err)
+	return p
+}
+
+// testTraces builds one resource per resource region, each holding one span
+// per span region ("" leaves the attribute unset).
+func testTraces(resourceRegions []string, spanRegions []string) ptrace.Traces {
+	td := ptrace.NewTraces()
+	for _, rr := range resourceRegions {
+		rs := td.ResourceSpans().AppendEmpty()
+		if rr != "" {
+			rs.Resource().Attributes().PutStr(residency.DefaultAttribute, rr)
+		}
+		ss := rs.ScopeSpans().AppendEmpty()
+		for _, sr := range spanRegions {
+			span := ss.Spans().AppendEmpty()
+			if sr != "" {
+				span.Attributes().PutStr(residency.DefaultAttribute, sr)
+			}
+		}
+	}
+	return td
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *residency.Config)
+		wantErr string
+	}{
+		{name: "valid", mutate: func(*residency.Config) {}},
+		{
+			name:   "disabled skips checks",
+			mutate: func(cfg *residency.Config) { cfg.Enabled = false; cfg.Regions = nil },
+		},
+		{
+			name:    "no regions",
+			mutate:  func(cfg *residency.Config) { cfg.Regions = nil },
+			wantErr: "residency.regions must not be empty",
+		},
+		{
+			name:    "blank region",
+			mutate:  func(cfg *residency.Config) { cfg.Regions = []string{" "} },
+			wantErr: "empty entries",
+		},
+		{
+			name:    "empty attribute",
+			mutate:  func(cfg *residency.Config) { cfg.Attribute = "" },
+			wantErr: "residency.attribute",
+		},
+		{
+			name:    "unknown mode",
+			mutate:  func(cfg *residency.Config) { cfg.Mode = "block" },
+			wantErr: "residency.mode",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := euConfig(residency.ModeEnforce)
+			tt.mutate(&cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestConfig_Blocks(t *testing.T) {
+	cfg := residency.NewDefaultConfig()
+	assert.False(t, cfg.Blocks())
+	cfg = euConfig(residency.ModeEnforce)
+	assert.True(t, cfg.Blocks())
+	cfg = euConfig(residency.ModeAudit)
+	assert.False(t, cfg.Blocks())
+}
+
+func TestPolicy_NilAllowsEverything(t *testing.T) {
+	var p *residency.Policy
+	td := testTraces([]string{"us"}, []string{""})
+	assert.Zero(t, p.ApplyTraces(context.Background(), td))
+	assert.Equal(t, 1, td.SpanCount())
+}
+
+func TestPolicy_Traces_Enforce(t *testing.T) {
+	p := newPolicy(t, euConfig(residency.ModeEnforce))
+
+	// Resource tagged "eu" with spans: inherited, overridden to "us", overridden to "eu-west-1".
+	td := testTraces([]string{"eu", "us", ""}, []string{"", "us", "Eu-West-1"})
+
+	violations := p.ApplyTraces(context.Background(), td)
+
+	// eu resource: "us" span blocked. us resource: inherited span blocked,
+	// "us" span blocked. untagged resource: "us" span blocked.
+	assert.Equal(t, 4, violations)
+	assert.Equal(t, 5, td.SpanCount())
+}
+
+func TestPolicy_Traces_DropsEmptyResources(t *testing.T) {
+	p := newPolicy(t, euConfig(residency.ModeEnforce))
+	td := testTraces([]string{"eu", "us"}, []string{""})
+
+	assert.Equal(t, 1, p.ApplyTraces(context.Background(), td))
+	require.Equal(t, 1, td.ResourceSpans().Len())
+	region, _ := td.ResourceSpans().At(0).Resource().Attributes().Get(residency.DefaultAttribute)
+	assert.Equal(t, "eu", region.Str())
+}
+
+func TestPolicy_Traces_Audit(t *testing.T) {
+	p := newPolicy(t, euConfig(residency.ModeAudit))
+	td := testTraces([]string{"us"}, []string{"", ""})
+
+	assert.Equal(t, 2, p.ApplyTraces(context.Background(), td))
+	assert.Equal(t, 2, td.SpanCount(), "audit mode must not block")
+}
+
+func TestPolicy_Untagged(t *testing.T) {
+	cfg := euConfig(residency.ModeEnforce)
+	cfg.AllowUntagged = false
+	p := newPolicy(t, cfg)
+	td := testTraces([]string{""}, []string{"", "eu"})
+
+	assert.Equal(t, 1, p.ApplyTraces(context.Background(), td))
+	assert.Equal(t, 1, td.SpanCount())
+}
+
+func TestPolicy_Logs(t *testing.T) {
+	p := newPolicy(t, euConfig(residency.ModeEnforce))
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr(residency.DefaultAttribute, "eu")
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.LogRecords().AppendEmpty()
+	sl.LogRecords().AppendEmpty().Attributes().PutStr(residency.DefaultAttribute, "ap-southeast-3")
+
+	assert.Equal(t, 1, p.ApplyLogs(context.Background(), ld))
+	assert.Equal(t, 1, ld.LogRecordCount())
+}
+
+func TestPolicy_Metrics(t *testing.T) {
+	p := newPolicy(t, euConfig(residency.ModeEnforce))
+	md := pmetric.NewMetrics()
+	for _, region := range []string{"eu", "us"} {
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().PutStr(residency.DefaultAttribute, region)
+		sm := rm.ScopeMetrics().AppendEmpty()
+		gauge := sm.Metrics().AppendEmpty().SetEmptyGauge()
+		gauge.DataPoints().AppendEmpty().SetIntValue(1)
+		gauge.DataPoints().AppendEmpty().SetIntValue(2)
+		sm.Metrics().AppendEmpty().SetEmptySum().DataPoints().AppendEmpty().SetIntValue(3)
+	}
+
+	assert.Equal(t, 3, p.ApplyMetrics(context.Background(), md))
+	assert.Equal(t, 3, md.DataPointCount())
+	assert.Equal(t, 1, md.ResourceMetrics().Len())
+}
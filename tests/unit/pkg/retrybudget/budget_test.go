@@ -0,0 +1,138 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package retrybudget_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/retrybudget"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+var tfoLabels = selfmetrics.Exporter(component.MustNewID("tfo"), pipeline.SignalTraces)
+
+func budgetConfig(ratio, minPerSecond float64) retrybudget.Config {
+	cfg := retrybudget.NewDefaultConfig()
+	cfg.Enabled = true
+	cfg.Ratio = ratio
+	cfg.MinRetriesPerSecond = minPerSecond
+	return cfg
+}
+
+// newBudget returns a handle on a budget private to the test: budgets are
+// shared per host, so the test name is used as host.
+func newBudget(t *testing.T, cfg retrybudget.Config, name string) *retrybudget.Budget {
+	t.Helper()
+	require.NoError(t, cfg.Validate())
+	labels := selfmetrics.Exporter(component.MustNewIDWithName("tfo", name), pipeline.SignalTraces)
+	b, err := retrybudget.New(cfg, componenttest.NewNopTelemetrySettings(), t.Name(), labels)
+	require.NoError(t, err)
+	return b
+}
+
+func withdrawals(b *retrybudget.Budget, attempts int) int {
+	n := 0
+	for range attempts {
+		if b.Withdraw() {
+			n++
+		}
+	}
+	return n
+}
+
+func TestConfig_Validate(t *testing.T) {
+	cfg := retrybudget.NewDefaultConfig()
+	assert.NoError(t, cfg.Validate(), "disabled config is not validated")
+
+	cfg.Enabled = true
+	assert.NoError(t, cfg.Validate())
+
+	bad := cfg
+	bad.Ratio = -1
+	assert.ErrorContains(t, bad.Validate(), "retry_budget.ratio")
+
+	bad = cfg
+	bad.MinRetriesPerSecond = -1
+	assert.ErrorContains(t, bad.Validate(), "retry_budget.min_retries_per_second")
+
+	bad = cfg
+	bad.Window = 100 * time.Millisecond
+	assert.ErrorContains(t, bad.Validate(), "retry_budget.window")
+}
+
+func TestBudget_NilAdmitsEverything(t *testing.T) {
+	var b *retrybudget.Budget
+	b.Deposit()
+	assert.True(t, b.Withdraw())
+}
+
+func TestBudget_MinimumRetryRate(t *testing.T) {
+	// No successes: only the floor of 1/s over the 10s window is allowed.
+	b := newBudget(t, budgetConfig(0, 1), "a")
+	assert.Equal(t, 10, withdrawals(b, 50))
+}
+
+func TestBudget_RatioOfSuccesses(t *testing.T) {
+	b := newBudget(t, budgetConfig(0.2, 0), "a")
+	assert.False(t, b.Withdraw())
+
+	for range 50 {
+		b.Deposit()
+	}
+	assert.Equal(t, 10, withdrawals(b, 50))
+}
+
+func TestBudget_SharedPerHost(t *testing.T) {
+	cfg := budgetConfig(0, 1)
+	a := newBudget(t, cfg, "primary")
+	b := newBudget(t, cfg, "secondary")
+
+	assert.Equal(t, 6, withdrawals(a, 6))
+	assert.Equal(t, 4, withdrawals(b, 10), "the second exporter draws on the same budget")
+
+	other, err := retrybudget.New(cfg, componenttest.NewNopTelemetrySettings(), t.Name()+":8443", tfoLabels)
+	require.NoError(t, err)
+	assert.Equal(t, 10, withdrawals(other, 10), "another host has its own budget")
+}
+
+func TestBudget_RecordsDenied(t *testing.T) {
+	tel := componenttest.NewTelemetry()
+	t.Cleanup(func() { _ = tel.Shutdown(context.Background()) })
+
+	cfg := budgetConfig(0, 0.5)
+	b, err := retrybudget.New(cfg, tel.NewTelemetrySettings(), t.Name(), tfoLabels)
+	require.NoError(t, err)
+
+	assert.Equal(t, 5, withdrawals(b, 8))
+
+	m, err := tel.GetMetric("tfo_retry_budget_exhausted")
+	require.NoError(t, err)
+	sum := m.Data.(metricdata.Sum[int64])
+	require.Len(t, sum.DataPoints, 1)
+	assert.Equal(t, int64(3), sum.DataPoints[0].Value)
+	typ, _ := sum.DataPoints[0].Attributes.Value(selfmetrics.TypeKey)
+	assert.Equal(t, "tfo", typ.AsString())
+	host, _ := sum.DataPoints[0].Attributes.Value("server.address")
+	assert.Equal(t, t.Name(), host.AsString())
+}
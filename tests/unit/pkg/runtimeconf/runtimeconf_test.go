@@ -0,0 +1,281 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package runtimeconf_test
+
+import (
+	"context"
+	"math"
+	"os"
+	"path/filepath"
+	"runtime"
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/confmap"
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/runtimeconf"
+)
+
+func noEnv(string) (string, bool) { return "", false }
+
+func env(vars map[string]string) func(string) (string, bool) {
+	return func(k string) (string, bool) {
+		v, ok := vars[k]
+		return v, ok
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+}
+
+func TestParseBytes(t *testing.T) {
+	tests := map[string]int64{
+		"0":       0,
+		"1024":    1024,
+		"512B":    512,
+		"64KiB":   64 << 10,
+		"1536MiB": 1536 << 20,
+		"2GiB":    2 << 30,
+		" 1TiB ":  1 << 40,
+	}
+	for in, want := range tests {
+		got, err := runtimeconf.ParseBytes(in)
+		require.NoError(t, err, in)
+		assert.Equal(t, want, got, in)
+	}
+
+	for _, in := range []string{"", "1GB", "-1MiB", "1.5GiB", "9999999TiB"} {
+		_, err := runtimeconf.ParseBytes(in)
+		assert.Error(t, err, in)
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	assert.Equal(t, "1536MiB", runtimeconf.FormatBytes(1536<<20))
+	assert.Equal(t, "2GiB", runtimeconf.FormatBytes(2<<30))
+	assert.Equal(t, "1023B", runtimeconf.FormatBytes(1023))
+}
+
+func TestConfig_Validate(t *testing.T) {
+	assert.NoError(t, (&runtimeconf.Config{}).Validate())
+	assert.NoError(t, (&runtimeconf.Config{MemoryLimit: "off"}).Validate())
+	assert.NoError(t, (&runtimeconf.Config{GOMAXPROCS: 4, MemoryLimit: "1GiB", GCPercent: -1}).Validate())
+	assert.NoError(t, (&runtimeconf.Config{MemoryLimit: "1.5GB"}).Validate())
+
+	assert.ErrorContains(t, (&runtimeconf.Config{GOMAXPROCS: -1}).Validate(), "runtime.gomaxprocs")
+	assert.ErrorContains(t, (&runtimeconf.Config{MemoryLimit: "lots"}).Validate(), "runtime.gomemlimit")
+	assert.NoError(t, (&runtimeconf.Config{MemoryLimit: "80%"}).Validate())
+	assert.ErrorContains(t, (&runtimeconf.Config{MemoryLimit: "0%"}).Validate(), "invalid percentage")
+	assert.ErrorContains(t, (&runtimeconf.Config{MemoryLimit: "120%"}).Validate(), "invalid percentage")
+}
+
+func TestResolve_MemoryLimitPercentage(t *testing.T) {
+	conf := confmap.New()
+	cfg := runtimeconf.Config{MemoryLimit: "80%"}
+
+	p, err := runtimeconf.Resolve(cfg, conf, runtimeconf.Limits{Memory: 1 << 30, TotalMemory: 8 << 30}, env(nil))
+	require.NoError(t, err)
+	assert.Equal(t, runtimeconf.SourceConfig, p.MemoryLimitSource)
+	assert.Equal(t, int64(1<<30)/100*80, p.MemoryLimit, "the cgroup limit comes first")
+
+	p, err = runtimeconf.Resolve(cfg, conf, runtimeconf.Limits{TotalMemory: 8 << 30}, env(nil))
+	require.NoError(t, err)
+	assert.Equal(t, int64(8<<30)/100*80, p.MemoryLimit, "then the host memory")
+
+	p, err = runtimeconf.Resolve(cfg, conf, runtimeconf.Limits{}, env(nil))
+	require.NoError(t, err)
+	assert.Equal(t, runtimeconf.SourceDefault, p.MemoryLimitSource, "unknown memory leaves the limit to the runtime")
+	assert.Zero(t, p.MemoryLimit)
+}
+
+func TestDetectLimits_CgroupV2(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "cpu.max"), "150000 100000\n")
+	writeFile(t, filepath.Join(root, "memory.max"), "1073741824\n")
+
+	l := runtimeconf.DetectLimits(root)
+	assert.InDelta(t, 1.5, l.CPUQuota, 1e-9)
+	assert.Equal(t, int64(1<<30), l.Memory)
+	assert.Equal(t, int64(1<<30), l.AvailableMemory())
+}
+
+func TestDetectLimits_CgroupV2Unlimited(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "cpu.max"), "max 100000\n")
+	writeFile(t, filepath.Join(root, "memory.max"), "max\n")
+
+	l := runtimeconf.DetectLimits(root)
+	assert.Zero(t, l.CPUQuota)
+	assert.Zero(t, l.Memory)
+}
+
+func TestDetectLimits_CgroupV1(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "cpu,cpuacct", "cpu.cfs_quota_us"), "200000\n")
+	writeFile(t, filepath.Join(root, "cpu,cpuacct", "cpu.cfs_period_us"), "100000\n")
+	writeFile(t, filepath.Join(root, "memory", "memory.limit_in_bytes"), "9223372036854771712\n")
+
+	l := runtimeconf.DetectLimits(root)
+	assert.InDelta(t, 2.0, l.CPUQuota, 1e-9)
+	assert.Zero(t, l.Memory, "the v1 sentinel means unlimited")
+}
+
+func TestDetectLimits_Missing(t *testing.T) {
+	l := runtimeconf.DetectLimits(t.TempDir())
+	assert.Zero(t, l.CPUQuota)
+	assert.Zero(t, l.Memory)
+}
+
+func TestResolve_Defaults(t *testing.T) {
+	p, err := runtimeconf.Resolve(runtimeconf.Config{}, confmap.New(), runtimeconf.Limits{}, noEnv)
+	require.NoError(t, err)
+
+	assert.Equal(t, runtimeconf.SourceDefault, p.GOMAXPROCSSource)
+	assert.Equal(t, runtime.GOMAXPROCS(0), p.GOMAXPROCS)
+	assert.Equal(t, runtimeconf.SourceDefault, p.MemoryLimitSource)
+	assert.Zero(t, p.MemoryLimit)
+	assert.Equal(t, runtimeconf.SourceDefault, p.GCPercentSource)
+}
+
+func TestResolve_CgroupQuota(t *testing.T) {
+	p, err := runtimeconf.Resolve(runtimeconf.Config{}, confmap.New(), runtimeconf.Limits{CPUQuota: 0.5}, noEnv)
+	require.NoError(t, err)
+
+	assert.Equal(t, runtimeconf.SourceCgroup, p.GOMAXPROCSSource)
+	assert.Equal(t, 1, p.GOMAXPROCS)
+
+	p, err = runtimeconf.Resolve(runtimeconf.Config{}, confmap.New(), runtimeconf.Limits{CPUQuota: 10000}, noEnv)
+	require.NoError(t, err)
+	assert.Equal(t, runtime.NumCPU(), p.GOMAXPROCS, "capped at the host CPU count")
+}
+
+func TestResolve_MemoryLimiter(t *testing.T) {
+	conf := confmap.NewFromStringMap(map[string]any{
+		"processors": map[string]any{
+			"memory_limiter": map[string]any{
+				"limit_mib":       2048,
+				"spike_limit_mib": 512,
+			},
+			"memory_limiter/small": map[string]any{
+				"limit_percentage": 50,
+			},
+			"memory_limiter/unused": map[string]any{
+				"limit_mib": 64,
+			},
+			"batch": map[string]any{},
+		},
+		"service": map[string]any{
+			"pipelines": map[string]any{
+				"traces": map[string]any{
+					"processors": []any{"memory_limiter", "batch"},
+				},
+				"logs": map[string]any{
+					"processors": []any{"memory_limiter/small"},
+				},
+			},
+		},
+	})
+
+	// 50% of 4GiB = 2048MiB, minus the default 20% spike = 1638.4MiB, which
+	// is above the 1536MiB soft limit of the unnamed limiter.
+	limits := runtimeconf.Limits{Memory: 4 << 30}
+	p, err := runtimeconf.Resolve(runtimeconf.Config{}, conf, limits, noEnv)
+	require.NoError(t, err)
+	assert.Equal(t, runtimeconf.SourceMemoryLimiter, p.MemoryLimitSource)
+	assert.Equal(t, int64(1536<<20), p.MemoryLimit)
+
+	// Against 2GiB the percentage based limiter is the smaller one.
+	limits = runtimeconf.Limits{TotalMemory: 2 << 30}
+	p, err = runtimeconf.Resolve(runtimeconf.Config{}, conf, limits, noEnv)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2<<30)/100*50*4/5, p.MemoryLimit)
+}
+
+func TestResolve_Precedence(t *testing.T) {
+	conf := confmap.NewFromStringMap(map[string]any{
+		"processors": map[string]any{
+			"memory_limiter": map[string]any{"limit_mib": 1000},
+		},
+		"service": map[string]any{
+			"pipelines": map[string]any{
+				"traces": map[string]any{"processors": []any{"memory_limiter"}},
+			},
+		},
+	})
+	vars := env(map[string]string{"GOMAXPROCS": "3", "GOMEMLIMIT": "256MiB", "GOGC": "off"})
+	limits := runtimeconf.Limits{CPUQuota: 1}
+
+	p, err := runtimeconf.Resolve(runtimeconf.Config{}, conf, limits, vars)
+	require.NoError(t, err)
+	assert.Equal(t, runtimeconf.SourceEnv, p.GOMAXPROCSSource)
+	assert.Equal(t, runtimeconf.SourceEnv, p.MemoryLimitSource)
+	assert.Equal(t, int64(256<<20), p.MemoryLimit)
+	assert.Equal(t, runtimeconf.SourceEnv, p.GCPercentSource)
+	assert.Equal(t, -1, p.GCPercent)
+
+	cfg := runtimeconf.Config{GOMAXPROCS: 2, MemoryLimit: "off", GCPercent: 50}
+	p, err = runtimeconf.Resolve(cfg, conf, limits, vars)
+	require.NoError(t, err)
+	assert.Equal(t, runtimeconf.SourceConfig, p.GOMAXPROCSSource)
+	assert.Equal(t, 2, p.GOMAXPROCS)
+	assert.Equal(t, runtimeconf.SourceConfig, p.MemoryLimitSource)
+	assert.Equal(t, int64(math.MaxInt64), p.MemoryLimit)
+	assert.Equal(t, runtimeconf.SourceConfig, p.GCPercentSource)
+	assert.Equal(t, 50, p.GCPercent)
+}
+
+func TestConverter_AppliesAndRemovesSection(t *testing.T) {
+	prevProcs := runtime.GOMAXPROCS(0)
+	prevLimit := debug.SetMemoryLimit(-1)
+	prevGC := debug.SetGCPercent(100)
+	t.Cleanup(func() {
+		runtime.GOMAXPROCS(prevProcs)
+		debug.SetMemoryLimit(prevLimit)
+		debug.SetGCPercent(prevGC)
+	})
+
+	conf := confmap.NewFromStringMap(map[string]any{
+		"runtime": map[string]any{
+			"gomaxprocs": 1,
+			"gomemlimit": "768MiB",
+			"gc_percent": 150,
+		},
+		"receivers": map[string]any{"nop": nil},
+	})
+
+	c := runtimeconf.NewConverterFactoryWithRoot(t.TempDir()).Create(confmap.ConverterSettings{Logger: zap.NewNop()})
+	require.NoError(t, c.Convert(context.Background(), conf))
+
+	assert.False(t, conf.IsSet("runtime"), "section must not reach otelcol")
+	assert.True(t, conf.IsSet("receivers"))
+	assert.Equal(t, 1, runtime.GOMAXPROCS(0))
+	assert.Equal(t, int64(768<<20), debug.SetMemoryLimit(-1))
+	assert.Equal(t, 150, debug.SetGCPercent(150))
+}
+
+func TestConverter_RejectsUnknownKeys(t *testing.T) {
+	conf := confmap.NewFromStringMap(map[string]any{
+		"runtime": map[string]any{"gomaxproc": 2},
+	})
+
+	c := runtimeconf.NewConverterFactory().Create(confmap.ConverterSettings{Logger: zap.NewNop()})
+	assert.Error(t, c.Convert(context.Background(), conf))
+}
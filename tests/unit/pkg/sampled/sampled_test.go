@@ -0,0 +1,58 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package sampled_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/sampled"
+)
+
+func TestFor_SharedByName(t *testing.T) {
+	a := sampled.For("tfoarchive/shared")
+	assert.Same(t, a, sampled.For("tfoarchive/shared"))
+	assert.NotSame(t, a, sampled.For("tfoarchive/other"))
+}
+
+func TestDecisions_KeepAndKept(t *testing.T) {
+	d := sampled.For("tfoarchive/keep")
+	now := time.Now()
+	d.Keep([16]byte{1}, now)
+
+	assert.True(t, d.Kept([16]byte{1}))
+	assert.False(t, d.Kept([16]byte{2}))
+}
+
+func TestDecisions_ForgetsAfterRetention(t *testing.T) {
+	// Decisions are process-wide: start from a fresh name on every run.
+	d := sampled.For(fmt.Sprintf("tfoarchive/retention-%d", time.Now().UnixNano()))
+	d.SetRetention(time.Minute)
+	start := time.Now()
+	d.Keep([16]byte{1}, start)
+	d.Keep([16]byte{2}, start.Add(30*time.Second))
+
+	// Kept again: the newer decision counts.
+	d.Keep([16]byte{1}, start.Add(50*time.Second))
+	d.Keep([16]byte{3}, start.Add(95*time.Second))
+
+	assert.True(t, d.Kept([16]byte{1}))
+	assert.False(t, d.Kept([16]byte{2}))
+	assert.True(t, d.Kept([16]byte{3}))
+	assert.Equal(t, 2, d.Len())
+}
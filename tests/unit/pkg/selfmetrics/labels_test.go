@@ -0,0 +1,75 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package selfmetrics_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pipeline"
+	"go.opentelemetry.io/otel/attribute"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+)
+
+func attrMap(kvs []attribute.KeyValue) map[string]string {
+	m := make(map[string]string, len(kvs))
+	for _, kv := range kvs {
+		m[string(kv.Key)] = kv.Value.AsString()
+	}
+	return m
+}
+
+func TestLabels_Exporter(t *testing.T) {
+	labels := selfmetrics.Exporter(component.MustNewIDWithName("tfo", "eu"), pipeline.SignalLogs)
+
+	assert.Equal(t, map[string]string{
+		selfmetrics.KindKey:     "exporter",
+		selfmetrics.TypeKey:     "tfo",
+		selfmetrics.NameKey:     "eu",
+		selfmetrics.PipelineKey: selfmetrics.All,
+		selfmetrics.SignalKey:   "logs",
+	}, attrMap(labels.Attributes()))
+	assert.Equal(t, "exporter/tfo/eu", labels.String())
+}
+
+func TestLabels_SharedReceiver(t *testing.T) {
+	labels := selfmetrics.Receiver(component.MustNewID("tfootlp"))
+
+	attrs := attrMap(labels.Attributes())
+	assert.Equal(t, "receiver", attrs[selfmetrics.KindKey])
+	assert.Equal(t, "", attrs[selfmetrics.NameKey])
+	assert.Equal(t, selfmetrics.All, attrs[selfmetrics.PipelineKey])
+	assert.Equal(t, selfmetrics.All, attrs[selfmetrics.SignalKey])
+}
+
+func TestLabels_WithPipeline(t *testing.T) {
+	labels := selfmetrics.Processor(component.MustNewID("tfodedup"), pipeline.SignalTraces).
+		WithPipeline(pipeline.NewIDWithName(pipeline.SignalMetrics, "edge"))
+
+	attrs := attrMap(labels.Attributes())
+	assert.Equal(t, "metrics/edge", attrs[selfmetrics.PipelineKey])
+	assert.Equal(t, "metrics", attrs[selfmetrics.SignalKey], "the signal follows the pipeline")
+}
+
+func TestLabels_ExtraAttributes(t *testing.T) {
+	labels := selfmetrics.Extension(component.MustNewID("tfoclock"))
+
+	attrs := attrMap(labels.Attributes(attribute.String("source", "combined")))
+	assert.Len(t, attrs, 6)
+	assert.Equal(t, "combined", attrs["source"])
+	assert.Equal(t, "extension", attrs[selfmetrics.KindKey])
+}
@@ -0,0 +1,228 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package serverconf_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/configauth"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configoptional"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/extension/extensionauth"
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+)
+
+func TestAdminConfig_Validate(t *testing.T) {
+	withAuth := func(cfg *serverconf.AdminConfig) {
+		cfg.Auth = configoptional.Some(confighttp.AuthConfig{
+			Config: configauth.Config{AuthenticatorID: component.MustNewID("basicauth")},
+		})
+	}
+	withClientCA := func(clientAuth serverconf.ClientAuthType) func(cfg *serverconf.AdminConfig) {
+		return func(cfg *serverconf.AdminConfig) {
+			tlsCfg := configtls.NewDefaultServerConfig()
+			tlsCfg.ClientCAFile = "/etc/tfo-collector/client-ca.pem"
+			cfg.TLS = configoptional.Some(tlsCfg)
+			cfg.ClientAuthType = clientAuth
+		}
+	}
+	tests := []struct {
+		name     string
+		endpoint string
+		mutate   func(cfg *serverconf.AdminConfig)
+		wantErr  string
+	}{
+		{name: "localhost", endpoint: "localhost:55690"},
+		{name: "ipv4 loopback", endpoint: "127.0.0.1:55690"},
+		{name: "ipv6 loopback", endpoint: "[::1]:55690"},
+		{name: "empty endpoint disables the API", endpoint: ""},
+		{name: "wildcard", endpoint: ":55690", wantErr: `endpoint ":55690" is not a loopback address`},
+		{name: "address", endpoint: "10.0.0.1:55690", wantErr: "is not a loopback address"},
+		{name: "hostname", endpoint: "collector.internal:55690", wantErr: "is not a loopback address"},
+		{name: "wildcard with auth", endpoint: "0.0.0.0:55690", mutate: withAuth},
+		{
+			name:     "wildcard with server tls only",
+			endpoint: "0.0.0.0:55690",
+			mutate: func(cfg *serverconf.AdminConfig) {
+				cfg.TLS = configoptional.Some(configtls.NewDefaultServerConfig())
+			},
+			wantErr: "is not a loopback address",
+		},
+		{
+			name:     "wildcard with acme only",
+			endpoint: "0.0.0.0:55690",
+			mutate: func(cfg *serverconf.AdminConfig) {
+				cfg.ACME = serverconf.ACMEConfig{Enabled: true, Domains: []string{"collector.example.com"}, CacheDir: t.TempDir()}
+			},
+			wantErr: "is not a loopback address",
+		},
+		{
+			name:     "wildcard with client ca",
+			endpoint: "0.0.0.0:55690",
+			mutate:   withClientCA(""),
+		},
+		{
+			name:     "wildcard with required client certificates",
+			endpoint: "0.0.0.0:55690",
+			mutate:   withClientCA(serverconf.ClientAuthRequireAndVerify),
+		},
+		{
+			name:     "wildcard with optional client certificates",
+			endpoint: "0.0.0.0:55690",
+			mutate:   withClientCA(serverconf.ClientAuthVerifyIfGiven),
+			wantErr:  "is not a loopback address",
+		},
+		{name: "missing port", endpoint: "localhost", wantErr: "invalid endpoint"},
+		{
+			name:     "cors",
+			endpoint: "localhost:55690",
+			mutate: func(cfg *serverconf.AdminConfig) {
+				cfg.CORS = configoptional.Some(confighttp.CORSConfig{})
+			},
+			wantErr: "cors is not supported",
+		},
+		{
+			name:     "negative body size",
+			endpoint: "localhost:55690",
+			mutate:   func(cfg *serverconf.AdminConfig) { cfg.MaxRequestBodySize = -1 },
+			wantErr:  "max_request_body_size must not be negative",
+		},
+		{
+			name:     "invalid acl",
+			endpoint: "localhost:55690",
+			mutate:   func(cfg *serverconf.AdminConfig) { cfg.ACL.AllowedCIDRs = []string{"nope"} },
+			wantErr:  "acl.allowed_cidrs",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := serverconf.NewDefaultAdminConfig(tt.endpoint)
+			if tt.mutate != nil {
+				tt.mutate(&cfg)
+			}
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+// authExtension is an auth extension accepting requests that carry the
+// token header.
+type authExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+	extensionauth.ServerAuthenticateFunc
+}
+
+// authHost provides the auth extension to StartAdmin.
+type authHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h authHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+// startAdmin starts an admin server on a free loopback port that echoes
+// the request body.
+func startAdmin(t *testing.T, cfg *serverconf.AdminConfig, host component.Host) string {
+	t.Helper()
+	handler := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			serverconf.WriteJSON(w, http.StatusRequestEntityTooLarge, map[string]string{"error": err.Error()})
+			return
+		}
+		serverconf.WriteJSON(w, http.StatusOK, map[string]string{"body": string(body)})
+	})
+	server, err := cfg.StartAdmin(context.Background(), host, "Test admin API", handler, zap.NewNop())
+	require.NoError(t, err)
+	t.Cleanup(func() { assert.NoError(t, server.Shutdown(context.Background())) })
+	return "http://" + server.Addr().String()
+}
+
+func TestStartAdmin_Serves(t *testing.T) {
+	cfg := serverconf.NewDefaultAdminConfig("127.0.0.1:0")
+	cfg.MaxRequestBodySize = 8
+	cfg.ServerHeader = "tfo-collector"
+	url := startAdmin(t, &cfg, componenttest.NewNopHost())
+
+	resp, err := http.Post(url, "text/plain", strings.NewReader("ok"))
+	require.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "tfo-collector", resp.Header.Get("Server"))
+	assert.JSONEq(t, `{"body": "ok"}`, string(body))
+
+	resp, err = http.Post(url, "text/plain", strings.NewReader("more than eight bytes"))
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+}
+
+func TestStartAdmin_Auth(t *testing.T) {
+	authID := component.MustNewID("tokenauth")
+	host := authHost{
+		Host: componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{
+			authID: authExtension{ServerAuthenticateFunc: func(ctx context.Context, sources map[string][]string) (context.Context, error) {
+				if len(sources["Token"]) == 1 && sources["Token"][0] == "secret" {
+					return ctx, nil
+				}
+				return ctx, errors.New("missing token")
+			}},
+		},
+	}
+	cfg := serverconf.NewDefaultAdminConfig("127.0.0.1:0")
+	cfg.Auth = configoptional.Some(confighttp.AuthConfig{Config: configauth.Config{AuthenticatorID: authID}})
+	url := startAdmin(t, &cfg, host)
+
+	resp, err := http.Get(url)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	require.NoError(t, err)
+	req.Header.Set("Token", "secret")
+	resp, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	_ = resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	// An auth extension missing from the host fails the start.
+	cfg.Auth = configoptional.Some(confighttp.AuthConfig{Config: configauth.Config{AuthenticatorID: component.MustNewID("missing")}})
+	_, err = cfg.StartAdmin(context.Background(), host, "Test admin API", http.NotFoundHandler(), zap.NewNop())
+	assert.ErrorContains(t, err, "auth:")
+}
@@ -0,0 +1,143 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package serverconf_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config/confighttp"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+)
+
+func corsConfig(origins, headers []string, maxAge int) *confighttp.CORSConfig {
+	cfg := confighttp.NewDefaultCORSConfig()
+	cfg.AllowedOrigins = origins
+	cfg.AllowedHeaders = headers
+	cfg.MaxAge = maxAge
+	return &cfg
+}
+
+// serveCORS runs one request through a CORS handler and reports whether the
+// wrapped handler was reached.
+func serveCORS(h http.Handler, req *http.Request) (*httptest.ResponseRecorder, bool) {
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, req)
+	return w, w.Header().Get("X-Next") == "reached"
+}
+
+func newCORS(cfg *confighttp.CORSConfig, credentials bool) http.Handler {
+	return serverconf.NewCORSHandler(cfg, credentials, http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-Next", "reached")
+		w.WriteHeader(http.StatusOK)
+	}))
+}
+
+func preflight(origin, method, headers string) *http.Request {
+	req := httptest.NewRequest(http.MethodOptions, "/v1/traces", nil)
+	req.Header.Set("Origin", origin)
+	req.Header.Set("Access-Control-Request-Method", method)
+	if headers != "" {
+		req.Header.Set("Access-Control-Request-Headers", headers)
+	}
+	return req
+}
+
+func TestValidateCORS(t *testing.T) {
+	assert.NoError(t, serverconf.ValidateCORS(nil, true))
+	assert.NoError(t, serverconf.ValidateCORS(corsConfig([]string{"*"}, nil, 0), false))
+	assert.NoError(t, serverconf.ValidateCORS(corsConfig([]string{"https://*.example.com"}, nil, 0), true))
+
+	assert.ErrorContains(t, serverconf.ValidateCORS(corsConfig([]string{"*"}, nil, 0), true), "cors_allow_credentials")
+	assert.ErrorContains(t, serverconf.ValidateCORS(corsConfig([]string{"https://*.*.com"}, nil, 0), false), "at most one wildcard")
+}
+
+func TestCORS_Preflight(t *testing.T) {
+	h := newCORS(corsConfig([]string{"https://*.example.com"}, []string{"X-TelemetryFlow-Key-ID"}, 600), false)
+
+	w, reached := serveCORS(h, preflight("https://rum.example.com", "POST", "content-type, x-telemetryflow-key-id"))
+	assert.False(t, reached, "preflight must not reach the endpoint")
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "https://rum.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "POST", w.Header().Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, "content-type, x-telemetryflow-key-id", w.Header().Get("Access-Control-Allow-Headers"))
+	assert.Equal(t, "600", w.Header().Get("Access-Control-Max-Age"))
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Credentials"))
+	assert.Contains(t, w.Header().Values("Vary"), "Origin")
+}
+
+func TestCORS_PreflightRejected(t *testing.T) {
+	h := newCORS(corsConfig([]string{"https://*.example.com"}, []string{"X-TelemetryFlow-Key-ID"}, 0), false)
+
+	tests := map[string]*http.Request{
+		"origin":      preflight("https://evil.test", "POST", ""),
+		"suffix":      preflight("https://example.com.evil.test", "POST", ""),
+		"method":      preflight("https://rum.example.com", "DELETE", ""),
+		"header":      preflight("https://rum.example.com", "POST", "X-Other"),
+		"no_implicit": preflight("https://rum.example.com", "POST", "X-Requested-With"),
+	}
+	for name, req := range tests {
+		t.Run(name, func(t *testing.T) {
+			w, reached := serveCORS(h, req)
+			assert.False(t, reached)
+			assert.Equal(t, http.StatusForbidden, w.Code)
+			assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+		})
+	}
+}
+
+func TestCORS_DefaultHeaders(t *testing.T) {
+	h := newCORS(corsConfig([]string{"https://app.example.com"}, nil, 0), false)
+
+	w, _ := serveCORS(h, preflight("https://APP.example.com", "POST", "X-Requested-With, Content-Type"))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Empty(t, w.Header().Get("Access-Control-Max-Age"))
+}
+
+func TestCORS_ActualRequest(t *testing.T) {
+	h := newCORS(corsConfig([]string{"https://app.example.com"}, nil, 0), true)
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/traces", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	w, reached := serveCORS(h, req)
+	require.True(t, reached)
+	assert.Equal(t, "https://app.example.com", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "true", w.Header().Get("Access-Control-Allow-Credentials"))
+
+	req = httptest.NewRequest(http.MethodPost, "/v1/traces", nil)
+	req.Header.Set("Origin", "https://other.example.com")
+	w, reached = serveCORS(h, req)
+	require.True(t, reached, "the browser, not the server, blocks disallowed origins")
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Origin"))
+
+	w, reached = serveCORS(h, httptest.NewRequest(http.MethodPost, "/v1/traces", nil))
+	require.True(t, reached)
+	assert.Empty(t, w.Header().Values("Vary"), "same-origin requests are untouched")
+}
+
+func TestCORS_WildcardNeverSendsCredentials(t *testing.T) {
+	// Validation rejects this combination; the handler must still not
+	// reflect arbitrary origins with credentials.
+	h := newCORS(corsConfig([]string{"*"}, []string{"*"}, 0), true)
+
+	w, _ := serveCORS(h, preflight("https://evil.test", "POST", "X-Anything"))
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, "*", w.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, w.Header().Get("Access-Control-Allow-Credentials"))
+}
@@ -0,0 +1,116 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package serverconf_test
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+)
+
+func freeAddr(t *testing.T) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = l.Close() }()
+	return l.Addr().String()
+}
+
+func TestListen_HandsOverSocketOnSameAddress(t *testing.T) {
+	addr := freeAddr(t)
+
+	first, err := serverconf.Listen("tcp", addr)
+	require.NoError(t, err)
+	acceptErr := make(chan error, 1)
+	go func() {
+		_, err := first.Accept()
+		acceptErr <- err
+	}()
+	require.NoError(t, first.Close())
+
+	select {
+	case err := <-acceptErr:
+		assert.True(t, errors.Is(err, net.ErrClosed))
+	case <-time.After(time.Second):
+		t.Fatal("Accept did not return after Close")
+	}
+
+	// The socket is still open: a client connecting between the two owners
+	// waits in the backlog instead of being refused.
+	client, err := net.DialTimeout("tcp", addr, time.Second)
+	require.NoError(t, err)
+	defer func() { _ = client.Close() }()
+
+	second, err := serverconf.Listen("tcp", addr)
+	require.NoError(t, err)
+	defer func() { _ = second.Close() }()
+	assert.Equal(t, addr, second.Addr().String())
+
+	conn, err := second.Accept()
+	require.NoError(t, err)
+	_ = conn.Close()
+}
+
+func TestListen_SecondOwnerWhileInUse(t *testing.T) {
+	addr := freeAddr(t)
+
+	first, err := serverconf.Listen("tcp", addr)
+	require.NoError(t, err)
+	defer func() { _ = first.Close() }()
+
+	_, err = serverconf.Listen("tcp", addr)
+	assert.Error(t, err)
+}
+
+func TestListen_ChangedAddressFreesReleasedPort(t *testing.T) {
+	addr := freeAddr(t)
+	_, port, err := net.SplitHostPort(addr)
+	require.NoError(t, err)
+
+	old, err := serverconf.Listen("tcp", addr)
+	require.NoError(t, err)
+	require.NoError(t, old.Close())
+
+	// Binding every interface conflicts with the parked loopback socket,
+	// which is closed to make room.
+	wide, err := serverconf.Listen("tcp", fmt.Sprintf("0.0.0.0:%s", port))
+	require.NoError(t, err)
+	defer func() { _ = wide.Close() }()
+	assert.Equal(t, port, fmt.Sprint(wide.Addr().(*net.TCPAddr).Port))
+}
+
+func TestListen_EphemeralPortIsNotPooled(t *testing.T) {
+	l, err := serverconf.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	require.NoError(t, l.Close())
+
+	_, err = net.DialTimeout("tcp", addr, time.Second)
+	assert.Error(t, err)
+}
+
+func TestListen_CloseIsIdempotent(t *testing.T) {
+	l, err := serverconf.Listen("tcp", freeAddr(t))
+	require.NoError(t, err)
+	assert.NoError(t, l.Close())
+	assert.NoError(t, l.Close())
+}
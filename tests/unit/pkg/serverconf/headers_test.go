@@ -0,0 +1,114 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package serverconf_test
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/config/configopaque"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/requestid"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+)
+
+// serveHeaders runs one request through a headers handler whose wrapped
+// handler sets a Server header and records the request ID it saw.
+func serveHeaders(cfg *serverconf.HeadersConfig, responseHeaders configopaque.MapList, req *http.Request) (*httptest.ResponseRecorder, string) {
+	var seen string
+	next := http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		seen = requestid.FromContext(req.Context())
+		w.Header().Set("Server", "Go")
+		w.WriteHeader(http.StatusOK)
+	})
+	w := httptest.NewRecorder()
+	serverconf.NewHeadersHandler(cfg, responseHeaders, next).ServeHTTP(w, req)
+	return w, seen
+}
+
+func TestHeadersHandler_Defaults(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/v1/traces", nil)
+	w, seen := serveHeaders(&serverconf.HeadersConfig{}, nil, req)
+
+	assert.Empty(t, w.Header().Values("Server"), "no Server header by default")
+	assert.Empty(t, w.Header().Get("Strict-Transport-Security"))
+	id := w.Header().Get(requestid.Header)
+	assert.Len(t, id, 32, "generated request ID")
+	assert.Equal(t, id, seen)
+}
+
+func TestHeadersHandler_ConfiguredHeaders(t *testing.T) {
+	cfg := &serverconf.HeadersConfig{
+		ServerHeader: "tfo-collector",
+		HSTSMaxAge:   24 * time.Hour,
+	}
+	var extra configopaque.MapList
+	extra.Set("X-Content-Type-Options", "nosniff")
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/traces", nil)
+	w, _ := serveHeaders(cfg, extra, req)
+	assert.Equal(t, "tfo-collector", w.Header().Get("Server"))
+	assert.Equal(t, "nosniff", w.Header().Get("X-Content-Type-Options"))
+	assert.Empty(t, w.Header().Get("Strict-Transport-Security"), "HSTS only over TLS")
+
+	req = httptest.NewRequest(http.MethodPost, "/v1/traces", nil)
+	req.TLS = &tls.ConnectionState{}
+	w, _ = serveHeaders(cfg, extra, req)
+	assert.Equal(t, "max-age=86400", w.Header().Get("Strict-Transport-Security"))
+}
+
+func TestHeadersHandler_RequestID(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		sent   string
+		echoed bool
+	}{
+		{name: "client ID", header: "", sent: "batch-42", echoed: true},
+		{name: "custom header", header: "X-Correlation-ID", sent: "abc", echoed: true},
+		{name: "invalid ID", header: "", sent: "bad id\r\n", echoed: false},
+		{name: "too long", header: "", sent: strings.Repeat("a", requestid.MaxLength+1), echoed: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := tt.header
+			if header == "" {
+				header = requestid.Header
+			}
+			req := httptest.NewRequest(http.MethodPost, "/v1/traces", nil)
+			req.Header.Set(header, tt.sent)
+			w, seen := serveHeaders(&serverconf.HeadersConfig{RequestIDHeader: tt.header}, nil, req)
+
+			got := w.Header().Get(header)
+			assert.Equal(t, got, seen)
+			if tt.echoed {
+				assert.Equal(t, tt.sent, got)
+			} else {
+				assert.NotEqual(t, tt.sent, got)
+				assert.True(t, requestid.Valid(got))
+			}
+		})
+	}
+}
+
+func TestHeadersConfig_Validate(t *testing.T) {
+	assert.NoError(t, (&serverconf.HeadersConfig{}).Validate())
+	assert.EqualError(t, (&serverconf.HeadersConfig{HSTSMaxAge: -time.Second}).Validate(), "hsts_max_age must not be negative")
+}
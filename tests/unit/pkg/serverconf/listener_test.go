@@ -0,0 +1,333 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package serverconf_test
+
+import (
+	"encoding/binary"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configoptional"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+)
+
+// serve wraps a loopback listener with cfg and returns it together with a
+// channel receiving accepted connections.
+func serve(t *testing.T, cfg serverconf.Config) (net.Listener, <-chan net.Conn) {
+	t.Helper()
+	raw, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	lis, err := cfg.WrapListener(raw)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = lis.Close() })
+
+	accepted := make(chan net.Conn, 1)
+	go func() {
+		for {
+			c, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			accepted <- c
+		}
+	}()
+	return lis, accepted
+}
+
+func dial(t *testing.T, lis net.Listener, payload []byte) net.Conn {
+	t.Helper()
+	c, err := net.Dial("tcp", lis.Addr().String())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Close() })
+	if len(payload) > 0 {
+		_, err = c.Write(payload)
+		require.NoError(t, err)
+	}
+	return c
+}
+
+func waitConn(t *testing.T, accepted <-chan net.Conn) net.Conn {
+	t.Helper()
+	select {
+	case c := <-accepted:
+		t.Cleanup(func() { _ = c.Close() })
+		return c
+	case <-time.After(2 * time.Second):
+		t.Fatal("connection was not accepted")
+		return nil
+	}
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     serverconf.Config
+		wantErr string
+	}{
+		{name: "empty"},
+		{
+			name: "valid cidrs and bare ips",
+			cfg: serverconf.Config{
+				ACL:           serverconf.ACLConfig{AllowedCIDRs: []string{"10.0.0.0/8", "::1"}, DeniedCIDRs: []string{"10.1.2.3"}},
+				ProxyProtocol: serverconf.ProxyProtocolConfig{Enabled: true, TrustedCIDRs: []string{"192.168.0.0/16"}},
+			},
+		},
+		{
+			name:    "invalid allowed cidr",
+			cfg:     serverconf.Config{ACL: serverconf.ACLConfig{AllowedCIDRs: []string{"10.0.0.0/33"}}},
+			wantErr: "acl.allowed_cidrs",
+		},
+		{
+			name:    "invalid denied cidr",
+			cfg:     serverconf.Config{ACL: serverconf.ACLConfig{DeniedCIDRs: []string{"nope"}}},
+			wantErr: "acl.denied_cidrs",
+		},
+		{
+			name:    "invalid trusted cidr",
+			cfg:     serverconf.Config{ProxyProtocol: serverconf.ProxyProtocolConfig{TrustedCIDRs: []string{"x"}}},
+			wantErr: "proxy_protocol.trusted_cidrs",
+		},
+		{
+			name:    "proxy protocol without trusted cidrs",
+			cfg:     serverconf.Config{ProxyProtocol: serverconf.ProxyProtocolConfig{Enabled: true}},
+			wantErr: "proxy_protocol.trusted_cidrs must not be empty",
+		},
+		{
+			name:    "negative header timeout",
+			cfg:     serverconf.Config{ProxyProtocol: serverconf.ProxyProtocolConfig{HeaderTimeout: -time.Second}},
+			wantErr: "header_timeout",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestWrapListener_NoHardeningReturnsSameListener(t *testing.T) {
+	raw, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = raw.Close() }()
+
+	cfg := serverconf.Config{}
+	lis, err := cfg.WrapListener(raw)
+	require.NoError(t, err)
+	assert.Same(t, raw, lis)
+}
+
+func TestWrapListener_ACLDeniesConnection(t *testing.T) {
+	lis, accepted := serve(t, serverconf.Config{
+		ACL: serverconf.ACLConfig{AllowedCIDRs: []string{"10.0.0.0/8"}},
+	})
+
+	c := dial(t, lis, nil)
+	_ = c.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, err := c.Read(make([]byte, 1))
+	require.Error(t, err, "denied connection should be closed by the server")
+
+	select {
+	case <-accepted:
+		t.Fatal("denied connection must not be returned by Accept")
+	default:
+	}
+}
+
+func TestWrapListener_ACLDenyTakesPrecedence(t *testing.T) {
+	lis, accepted := serve(t, serverconf.Config{
+		ACL: serverconf.ACLConfig{
+			AllowedCIDRs: []string{"127.0.0.0/8"},
+			DeniedCIDRs:  []string{"127.0.0.1"},
+		},
+	})
+
+	c := dial(t, lis, nil)
+	_ = c.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, err := c.Read(make([]byte, 1))
+	require.Error(t, err)
+	assert.Empty(t, accepted)
+}
+
+func TestWrapListener_ACLAllowsConnection(t *testing.T) {
+	lis, accepted := serve(t, serverconf.Config{
+		ACL: serverconf.ACLConfig{AllowedCIDRs: []string{"127.0.0.0/8"}},
+	})
+
+	dial(t, lis, []byte("ok"))
+	c := waitConn(t, accepted)
+	buf := make([]byte, 2)
+	_, err := io.ReadFull(c, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "ok", string(buf))
+}
+
+func TestWrapListener_ProxyProtocolV1(t *testing.T) {
+	lis, accepted := serve(t, serverconf.Config{
+		ProxyProtocol: serverconf.ProxyProtocolConfig{Enabled: true, TrustedCIDRs: []string{"127.0.0.0/8"}},
+	})
+
+	dial(t, lis, []byte("PROXY TCP4 203.0.113.7 10.0.0.1 5555 4318\r\nhello"))
+	c := waitConn(t, accepted)
+
+	buf := make([]byte, 5)
+	_, err := io.ReadFull(c, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "hello", string(buf))
+	assert.Equal(t, "203.0.113.7:5555", c.RemoteAddr().String())
+}
+
+func TestWrapListener_ProxyProtocolV1Unknown(t *testing.T) {
+	lis, accepted := serve(t, serverconf.Config{
+		ProxyProtocol: serverconf.ProxyProtocolConfig{Enabled: true, TrustedCIDRs: []string{"127.0.0.0/8"}},
+	})
+
+	client := dial(t, lis, []byte("PROXY UNKNOWN\r\nhi"))
+	c := waitConn(t, accepted)
+
+	buf := make([]byte, 2)
+	_, err := io.ReadFull(c, buf)
+	require.NoError(t, err)
+	assert.Equal(t, client.LocalAddr().String(), c.RemoteAddr().String())
+}
+
+func TestWrapListener_ProxyProtocolV2(t *testing.T) {
+	lis, accepted := serve(t, serverconf.Config{
+		ProxyProtocol: serverconf.ProxyProtocolConfig{Enabled: true, TrustedCIDRs: []string{"127.0.0.0/8"}},
+	})
+
+	header := []byte("\r\n\r\n\x00\r\nQUIT\n")
+	header = append(header, 0x21, 0x11) // v2 PROXY, TCP over IPv4
+	header = binary.BigEndian.AppendUint16(header, 12)
+	header = append(header, 198, 51, 100, 9) // source
+	header = append(header, 10, 0, 0, 1)     // destination
+	header = binary.BigEndian.AppendUint16(header, 40000)
+	header = binary.BigEndian.AppendUint16(header, 4317)
+
+	dial(t, lis, append(header, []byte("data")...))
+	c := waitConn(t, accepted)
+
+	assert.Equal(t, "198.51.100.9:40000", c.RemoteAddr().String())
+	buf := make([]byte, 4)
+	_, err := io.ReadFull(c, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "data", string(buf))
+}
+
+func TestWrapListener_ProxyProtocolAppliesACLToClientAddress(t *testing.T) {
+	lis, accepted := serve(t, serverconf.Config{
+		ACL:           serverconf.ACLConfig{AllowedCIDRs: []string{"203.0.113.0/24"}},
+		ProxyProtocol: serverconf.ProxyProtocolConfig{Enabled: true, TrustedCIDRs: []string{"127.0.0.0/8"}},
+	})
+
+	dial(t, lis, []byte("PROXY TCP4 198.51.100.1 10.0.0.1 5555 4318\r\nhello"))
+	c := waitConn(t, accepted)
+
+	_, err := c.Read(make([]byte, 5))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "denied")
+}
+
+func TestWrapListener_ProxyProtocolInvalidHeader(t *testing.T) {
+	lis, accepted := serve(t, serverconf.Config{
+		ProxyProtocol: serverconf.ProxyProtocolConfig{Enabled: true, TrustedCIDRs: []string{"127.0.0.0/8"}},
+	})
+
+	dial(t, lis, []byte("POST /v1/traces HTTP/1.1\r\n\r\n"))
+	c := waitConn(t, accepted)
+
+	_, err := c.Read(make([]byte, 1))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "PROXY protocol")
+}
+
+func TestWrapListener_ProxyProtocolUntrustedPeerSkipsHeader(t *testing.T) {
+	lis, accepted := serve(t, serverconf.Config{
+		ProxyProtocol: serverconf.ProxyProtocolConfig{Enabled: true, TrustedCIDRs: []string{"10.0.0.0/8"}},
+	})
+
+	client := dial(t, lis, []byte("raw"))
+	c := waitConn(t, accepted)
+
+	buf := make([]byte, 3)
+	_, err := io.ReadFull(c, buf)
+	require.NoError(t, err)
+	assert.Equal(t, "raw", string(buf))
+	assert.Equal(t, client.LocalAddr().String(), c.RemoteAddr().String())
+}
+
+func TestWrapListener_ProxyProtocolTrustsNoPeerByDefault(t *testing.T) {
+	lis, accepted := serve(t, serverconf.Config{
+		ProxyProtocol: serverconf.ProxyProtocolConfig{Enabled: true},
+	})
+
+	header := "PROXY TCP4 203.0.113.7 10.0.0.1 5555 4318\r\n"
+	client := dial(t, lis, []byte(header))
+	c := waitConn(t, accepted)
+
+	buf := make([]byte, len(header))
+	_, err := io.ReadFull(c, buf)
+	require.NoError(t, err)
+	assert.Equal(t, header, string(buf))
+	assert.Equal(t, client.LocalAddr().String(), c.RemoteAddr().String())
+}
+
+func TestNewHTTPServer(t *testing.T) {
+	t.Run("defaults for unset timeouts", func(t *testing.T) {
+		srv := serverconf.NewHTTPServer(&confighttp.ServerConfig{}, nil)
+		assert.Equal(t, serverconf.DefaultHTTPReadTimeout, srv.ReadTimeout)
+		assert.Equal(t, serverconf.DefaultHTTPWriteTimeout, srv.WriteTimeout)
+		assert.Equal(t, serverconf.DefaultMaxHeaderBytes, srv.MaxHeaderBytes)
+	})
+
+	t.Run("configured timeouts", func(t *testing.T) {
+		cfg := confighttp.NewDefaultServerConfig()
+		cfg.ReadTimeout = 5 * time.Second
+		cfg.WriteTimeout = 7 * time.Second
+		srv := serverconf.NewHTTPServer(&cfg, nil)
+		assert.Equal(t, 5*time.Second, srv.ReadTimeout)
+		assert.Equal(t, 7*time.Second, srv.WriteTimeout)
+		assert.Equal(t, cfg.ReadHeaderTimeout, srv.ReadHeaderTimeout)
+		assert.Equal(t, cfg.IdleTimeout, srv.IdleTimeout)
+	})
+}
+
+func TestGRPCServerOptions(t *testing.T) {
+	assert.Empty(t, serverconf.GRPCServerOptions(&configgrpc.ServerConfig{}))
+
+	cfg := configgrpc.ServerConfig{
+		MaxConcurrentStreams: 100,
+		ReadBufferSize:       64 * 1024,
+		WriteBufferSize:      64 * 1024,
+		Keepalive: configoptional.Some(configgrpc.KeepaliveServerConfig{
+			ServerParameters:  configoptional.Some(configgrpc.NewDefaultKeepaliveServerParameters()),
+			EnforcementPolicy: configoptional.Some(configgrpc.NewDefaultKeepaliveEnforcementPolicy()),
+		}),
+	}
+	assert.Len(t, serverconf.GRPCServerOptions(&cfg), 5)
+}
@@ -0,0 +1,265 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package serverconf_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+)
+
+// writeClientCert writes a self-signed client certificate for name and
+// returns its file, which doubles as the client CA, and the certificate.
+func writeClientCert(t *testing.T, name string) (string, tls.Certificate) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(2),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	file := filepath.Join(t.TempDir(), "client.pem")
+	require.NoError(t, os.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	return file, tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key}
+}
+
+// clientHandshake connects to lis with TLS presenting the first of certs,
+// if any, and returns the outcome of the handshake on the server side.
+func clientHandshake(t *testing.T, lis net.Listener, roots *x509.CertPool, certs ...tls.Certificate) error {
+	t.Helper()
+	result := make(chan error, 1)
+	go func() {
+		conn, err := lis.Accept()
+		if err != nil {
+			result <- err
+			return
+		}
+		defer func() { _ = conn.Close() }()
+		result <- conn.(*tls.Conn).Handshake()
+	}()
+
+	dialer := &net.Dialer{Timeout: 5 * time.Second}
+	conn, err := tls.DialWithDialer(dialer, "tcp", lis.Addr().String(), &tls.Config{
+		ServerName: "collector.example.com",
+		RootCAs:    roots,
+		// Send the certificate even if the server does not list its issuer.
+		GetClientCertificate: func(*tls.CertificateRequestInfo) (*tls.Certificate, error) {
+			if len(certs) == 0 {
+				return &tls.Certificate{}, nil
+			}
+			return &certs[0], nil
+		},
+		MinVersion: tls.VersionTLS12,
+	})
+	if err == nil {
+		defer func() { _ = conn.Close() }()
+	}
+	return <-result
+}
+
+// listen returns a listener serving tlsCfg.
+func listen(t *testing.T, tlsCfg *serverconf.TLS) net.Listener {
+	t.Helper()
+	raw, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	lis := tlsCfg.WrapListener(raw)
+	t.Cleanup(func() { _ = lis.Close() })
+	return lis
+}
+
+func TestConfig_ValidateTLS(t *testing.T) {
+	withCA := &configtls.ServerConfig{
+		Config:       configtls.Config{CertFile: "server.crt", KeyFile: "server.key"},
+		ClientCAFile: "ca.crt",
+	}
+	withoutCA := &configtls.ServerConfig{Config: configtls.Config{CertFile: "server.crt", KeyFile: "server.key"}}
+
+	tests := []struct {
+		name    string
+		cfg     serverconf.Config
+		static  *configtls.ServerConfig
+		wantErr string
+	}{
+		{name: "plaintext", cfg: serverconf.Config{}},
+		{name: "verify with ca", cfg: serverconf.Config{ClientAuthType: serverconf.ClientAuthRequireAndVerify}, static: withCA},
+		{name: "request without ca", cfg: serverconf.Config{ClientAuthType: serverconf.ClientAuthRequest}, static: withoutCA},
+		{
+			name:    "client auth without tls",
+			cfg:     serverconf.Config{ClientAuthType: serverconf.ClientAuthRequest},
+			wantErr: "requires tls",
+		},
+		{
+			name:    "verify without ca",
+			cfg:     serverconf.Config{ClientAuthType: serverconf.ClientAuthVerifyIfGiven},
+			static:  withoutCA,
+			wantErr: "requires tls.client_ca_file",
+		},
+		{name: "reload", cfg: serverconf.Config{TLSReload: serverconf.TLSReloadConfig{Enabled: true}}, static: withoutCA},
+		{
+			name:    "reload without files",
+			cfg:     serverconf.Config{TLSReload: serverconf.TLSReloadConfig{Enabled: true}},
+			wantErr: "tls_reload requires",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.ValidateTLS(tt.static)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestConfig_ValidateClientAuthType(t *testing.T) {
+	cfg := serverconf.Config{ClientAuthType: "optional"}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid client_auth_type")
+
+	cfg = serverconf.Config{TLSReload: serverconf.TLSReloadConfig{Interval: -time.Second}}
+	require.Error(t, cfg.Validate())
+}
+
+func TestNewTLS_ClientAuth(t *testing.T) {
+	caFile, clientCert := writeClientCert(t, "edge-agent")
+	_, otherCert := writeClientCert(t, "intruder")
+
+	tests := []struct {
+		name     string
+		authType serverconf.ClientAuthType
+		certs    []tls.Certificate
+		wantErr  bool
+	}{
+		{name: "default requires certificate", certs: nil, wantErr: true},
+		{name: "default accepts trusted certificate", certs: []tls.Certificate{clientCert}},
+		{name: "require and verify rejects untrusted", authType: serverconf.ClientAuthRequireAndVerify, certs: []tls.Certificate{otherCert}, wantErr: true},
+		{name: "verify if given accepts none", authType: serverconf.ClientAuthVerifyIfGiven},
+		{name: "verify if given rejects untrusted", authType: serverconf.ClientAuthVerifyIfGiven, certs: []tls.Certificate{otherCert}, wantErr: true},
+		{name: "require any accepts untrusted", authType: serverconf.ClientAuthRequireAny, certs: []tls.Certificate{otherCert}},
+		{name: "require any rejects none", authType: serverconf.ClientAuthRequireAny, wantErr: true},
+		{name: "none accepts none", authType: serverconf.ClientAuthNone},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			static, roots := writeCert(t, "collector.example.com")
+			static.ClientCAFile = caFile
+			cfg := serverconf.Config{ClientAuthType: tt.authType}
+			tlsCfg, err := cfg.NewTLS(context.Background(), static, zap.NewNop())
+			require.NoError(t, err)
+
+			err = clientHandshake(t, listen(t, tlsCfg), roots, tt.certs...)
+			if tt.wantErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNewTLS_ReloadsChangedFiles(t *testing.T) {
+	static, roots := writeCert(t, "collector.example.com")
+	rotated, rotatedRoots := writeCert(t, "collector.example.com")
+	caFile, clientCert := writeClientCert(t, "edge-agent")
+	static.ClientCAFile = caFile
+
+	cfg := serverconf.Config{
+		ClientAuthType: serverconf.ClientAuthRequireAndVerify,
+		TLSReload:      serverconf.TLSReloadConfig{Enabled: true, Interval: time.Millisecond},
+	}
+	tlsCfg, err := cfg.NewTLS(context.Background(), static, zap.NewNop())
+	require.NoError(t, err)
+	lis := listen(t, tlsCfg)
+
+	err = clientHandshake(t, lis, roots, clientCert)
+	require.NoError(t, err)
+
+	// A key written before its certificate does not load; the previous
+	// files keep being served until the rotation completes.
+	copyFile(t, rotated.KeyFile, static.KeyFile)
+	time.Sleep(5 * time.Millisecond)
+	err = clientHandshake(t, lis, roots, clientCert)
+	require.NoError(t, err)
+
+	copyFile(t, rotated.CertFile, static.CertFile)
+	time.Sleep(5 * time.Millisecond)
+	err = clientHandshake(t, lis, rotatedRoots, clientCert)
+	require.NoError(t, err, "rotated certificate is served")
+
+	// A rotated client CA no longer trusts the previous client certificate.
+	newCA, newClientCert := writeClientCert(t, "edge-agent")
+	copyFile(t, newCA, caFile)
+	time.Sleep(5 * time.Millisecond)
+	err = clientHandshake(t, lis, rotatedRoots, clientCert)
+	require.Error(t, err)
+	err = clientHandshake(t, lis, rotatedRoots, newClientCert)
+	require.NoError(t, err)
+}
+
+func TestNewTLS_ReloadWithinIntervalKeepsFiles(t *testing.T) {
+	static, roots := writeCert(t, "collector.example.com")
+	rotated, _ := writeCert(t, "collector.example.com")
+
+	cfg := serverconf.Config{TLSReload: serverconf.TLSReloadConfig{Enabled: true, Interval: time.Hour}}
+	tlsCfg, err := cfg.NewTLS(context.Background(), static, zap.NewNop())
+	require.NoError(t, err)
+	lis := listen(t, tlsCfg)
+
+	copyFile(t, rotated.KeyFile, static.KeyFile)
+	copyFile(t, rotated.CertFile, static.CertFile)
+	err = clientHandshake(t, lis, roots)
+	require.NoError(t, err, "files are not checked again before the interval")
+}
+
+// copyFile replaces dst with the content of src.
+func copyFile(t *testing.T, src, dst string) {
+	t.Helper()
+	data, err := os.ReadFile(src)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(dst, data, 0o600))
+}
@@ -0,0 +1,151 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package serverconf_test
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+)
+
+// requireDualStack skips the test on hosts without IPv6 loopback.
+func requireDualStack(t *testing.T) {
+	t.Helper()
+	l, err := net.Listen("tcp6", "[::1]:0")
+	if err != nil {
+		t.Skipf("IPv6 is not available: %v", err)
+	}
+	_ = l.Close()
+}
+
+// dials reports whether a connection to host on the port of lis succeeds.
+func dials(t *testing.T, lis net.Listener, host string) bool {
+	t.Helper()
+	_, port, err := net.SplitHostPort(lis.Addr().String())
+	require.NoError(t, err)
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(host, port), time.Second)
+	if err != nil {
+		return false
+	}
+	_ = conn.Close()
+	return true
+}
+
+func TestConfig_ValidateNetwork(t *testing.T) {
+	for _, network := range []string{"", serverconf.NetworkTCP4, serverconf.NetworkTCP6, serverconf.NetworkDual} {
+		cfg := serverconf.Config{Network: network}
+		assert.NoError(t, cfg.Validate(), network)
+	}
+	cfg := serverconf.Config{Network: "udp"}
+	assert.ErrorContains(t, cfg.Validate(), `invalid network "udp"`)
+}
+
+func TestConfig_ValidateEndpoint(t *testing.T) {
+	tests := []struct {
+		name     string
+		network  string
+		endpoint string
+		wantErr  string
+	}{
+		{"bracketed wildcard", "", "[::]:4317", ""},
+		{"bracketed loopback", "", "[::1]:4317", ""},
+		{"zoned link-local", serverconf.NetworkTCP6, "[fe80::1%eth0]:4317", ""},
+		{"empty host", "", ":4317", ""},
+		{"host name", serverconf.NetworkTCP6, "collector.internal:4317", ""},
+		{"unbracketed IPv6", "", ":::4317", "IPv6 addresses must be enclosed in brackets"},
+		{"unbracketed IPv6 loopback", "", "::1:4317", "IPv6 addresses must be enclosed in brackets"},
+		{"missing port", "", "[::]", "missing port"},
+		{"empty port", "", "0.0.0.0:", "missing port"},
+		{"tcp4 with IPv6", serverconf.NetworkTCP4, "[::]:4317", "listens on IPv4 only"},
+		{"tcp4 with mapped IPv4", serverconf.NetworkTCP4, "[::ffff:10.0.0.1]:4317", ""},
+		{"tcp6 with IPv4", serverconf.NetworkTCP6, "0.0.0.0:4317", "listens on IPv6 only"},
+		{"dual with IPv4 wildcard", serverconf.NetworkDual, "0.0.0.0:4317", ""},
+		{"dual with IPv6 wildcard", serverconf.NetworkDual, "[::]:4317", ""},
+		{"dual with address", serverconf.NetworkDual, "[::1]:4317", "requires an empty or wildcard host"},
+		{"dual with host name", serverconf.NetworkDual, "localhost:4317", "requires an empty or wildcard host"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := serverconf.Config{Network: tt.network}
+			err := cfg.ValidateEndpoint(tt.endpoint)
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestConfig_Listen_Networks(t *testing.T) {
+	requireDualStack(t)
+
+	tests := []struct {
+		name     string
+		network  string
+		endpoint string
+		ipv4     bool
+		ipv6     bool
+	}{
+		{"dual from IPv4 wildcard", serverconf.NetworkDual, "0.0.0.0:0", true, true},
+		{"dual from IPv6 wildcard", serverconf.NetworkDual, "[::]:0", true, true},
+		{"tcp4", serverconf.NetworkTCP4, "0.0.0.0:0", true, false},
+		{"tcp6", serverconf.NetworkTCP6, "[::]:0", false, true},
+		{"unset with IPv4 wildcard", "", "0.0.0.0:0", true, true},
+		{"unset with IPv4 address", "", "127.0.0.1:0", true, false},
+		{"unset with IPv6 address", "", "[::1]:0", false, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := serverconf.Config{Network: tt.network}
+			require.NoError(t, cfg.ValidateEndpoint(tt.endpoint))
+			lis, err := cfg.Listen(tt.endpoint)
+			require.NoError(t, err)
+			defer func() { _ = lis.Close() }()
+
+			assert.Equal(t, tt.ipv4, dials(t, lis, "127.0.0.1"), "IPv4")
+			assert.Equal(t, tt.ipv6, dials(t, lis, "::1"), "IPv6")
+		})
+	}
+}
+
+func TestConfig_ListenURL(t *testing.T) {
+	addr := func(s string) net.Addr {
+		a, err := net.ResolveTCPAddr("tcp", s)
+		require.NoError(t, err)
+		return a
+	}
+	tests := []struct {
+		network string
+		addr    string
+		want    string
+	}{
+		{"", "0.0.0.0:4318", "http://127.0.0.1:4318"},
+		{"", "[::]:4318", "http://localhost:4318"},
+		{serverconf.NetworkDual, "[::]:4318", "http://localhost:4318"},
+		{serverconf.NetworkTCP6, "[::]:4318", "http://[::1]:4318"},
+		{"", "[fd00::2]:4318", "http://[fd00::2]:4318"},
+		{"", "10.0.0.1:4318", "http://10.0.0.1:4318"},
+	}
+	for _, tt := range tests {
+		cfg := serverconf.Config{Network: tt.network}
+		assert.Equal(t, tt.want, cfg.ListenURL("http", addr(tt.addr)), tt.addr)
+	}
+}
@@ -0,0 +1,291 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package serverconf_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+)
+
+// writeCert writes a self-signed certificate for name and 127.0.0.1 and
+// returns the static TLS settings using it and a pool trusting it.
+func writeCert(t *testing.T, name string) (*configtls.ServerConfig, *x509.CertPool) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: name},
+		DNSNames:     []string{name},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "cert.pem")
+	keyFile := filepath.Join(dir, "key.pem")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER}), 0o600))
+
+	leaf, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+	pool := x509.NewCertPool()
+	pool.AddCert(leaf)
+
+	static := configtls.NewDefaultServerConfig()
+	static.CertFile = certFile
+	static.KeyFile = keyFile
+	return &static, pool
+}
+
+// handshake connects to lis with TLS for serverName and returns the
+// common name of the certificate served.
+func handshake(t *testing.T, lis net.Listener, serverName string, roots *x509.CertPool) (string, error) {
+	t.Helper()
+	go func() {
+		conn, err := lis.Accept()
+		if err != nil {
+			return
+		}
+		defer func() { _ = conn.Close() }()
+		_ = conn.(*tls.Conn).Handshake()
+	}()
+
+	dialer := &net.Dialer{Timeout: 5 * time.Second}
+	conn, err := tls.DialWithDialer(dialer, "tcp", lis.Addr().String(), &tls.Config{
+		ServerName: serverName,
+		RootCAs:    roots,
+		MinVersion: tls.VersionTLS12,
+	})
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = conn.Close() }()
+	return conn.ConnectionState().PeerCertificates[0].Subject.CommonName, nil
+}
+
+// unreachableDirectory returns an ACME directory URL nothing listens on.
+func unreachableDirectory(t *testing.T) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	addr := l.Addr().String()
+	require.NoError(t, l.Close())
+	return "http://" + addr + "/directory"
+}
+
+func TestACMEConfig_Validate(t *testing.T) {
+	valid := func() serverconf.ACMEConfig {
+		return serverconf.ACMEConfig{
+			Enabled:  true,
+			Domains:  []string{"collector.example.com"},
+			CacheDir: "/var/lib/tfo/acme",
+		}
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(*serverconf.ACMEConfig)
+		wantErr string
+	}{
+		{name: "valid", modify: func(*serverconf.ACMEConfig) {}},
+		{name: "disabled", modify: func(cfg *serverconf.ACMEConfig) { *cfg = serverconf.ACMEConfig{} }},
+		{
+			name:    "no domains",
+			modify:  func(cfg *serverconf.ACMEConfig) { cfg.Domains = nil },
+			wantErr: "acme.domains must be set",
+		},
+		{
+			name:    "ip domain",
+			modify:  func(cfg *serverconf.ACMEConfig) { cfg.Domains = []string{"10.0.0.1"} },
+			wantErr: "is not a host name",
+		},
+		{
+			name:    "no cache dir",
+			modify:  func(cfg *serverconf.ACMEConfig) { cfg.CacheDir = "" },
+			wantErr: "acme.cache_dir must be set",
+		},
+		{
+			name:    "challenge endpoint without port",
+			modify:  func(cfg *serverconf.ACMEConfig) { cfg.HTTPChallengeEndpoint = "0.0.0.0" },
+			wantErr: "acme.http_challenge_endpoint",
+		},
+		{
+			name:    "negative renew before",
+			modify:  func(cfg *serverconf.ACMEConfig) { cfg.RenewBefore = -time.Hour },
+			wantErr: "acme.renew_before",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := serverconf.Config{ACME: valid()}
+			tt.modify(&cfg.ACME)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestNewTLS_Plaintext(t *testing.T) {
+	var cfg serverconf.Config
+	tlsCfg, err := cfg.NewTLS(context.Background(), nil, zap.NewNop())
+	require.NoError(t, err)
+	assert.Nil(t, tlsCfg)
+	assert.Nil(t, tlsCfg.Config())
+	assert.NoError(t, tlsCfg.Shutdown(context.Background()))
+
+	raw, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer func() { _ = raw.Close() }()
+	assert.Same(t, raw, tlsCfg.WrapListener(raw))
+}
+
+func TestNewTLS_Static(t *testing.T) {
+	static, roots := writeCert(t, "static.example.com")
+
+	var cfg serverconf.Config
+	tlsCfg, err := cfg.NewTLS(context.Background(), static, zap.NewNop())
+	require.NoError(t, err)
+	require.NotNil(t, tlsCfg)
+	assert.Equal(t, []string{"h2", "http/1.1"}, tlsCfg.Config().NextProtos)
+
+	raw, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	lis := tlsCfg.WrapListener(raw)
+	defer func() { _ = lis.Close() }()
+
+	cn, err := handshake(t, lis, "static.example.com", roots)
+	require.NoError(t, err)
+	assert.Equal(t, "static.example.com", cn)
+}
+
+func TestNewTLS_ACMEFallsBackToStatic(t *testing.T) {
+	static, roots := writeCert(t, "collector.example.com")
+
+	cfg := serverconf.Config{ACME: serverconf.ACMEConfig{
+		Enabled:      true,
+		Domains:      []string{"collector.example.com"},
+		DirectoryURL: unreachableDirectory(t),
+		CacheDir:     t.TempDir(),
+	}}
+	tlsCfg, err := cfg.NewTLS(context.Background(), static, zap.NewNop())
+	require.NoError(t, err)
+	assert.Contains(t, tlsCfg.Config().NextProtos, "acme-tls/1", "TLS-ALPN-01 challenges must be negotiable")
+
+	raw, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	lis := tlsCfg.WrapListener(raw)
+	defer func() { _ = lis.Close() }()
+
+	// The CA is unreachable, so the static certificate is served.
+	cn, err := handshake(t, lis, "collector.example.com", roots)
+	require.NoError(t, err)
+	assert.Equal(t, "collector.example.com", cn)
+
+	// Clients connecting by address never get an ACME certificate.
+	cn, err = handshake(t, lis, "", roots)
+	require.NoError(t, err)
+	assert.Equal(t, "collector.example.com", cn)
+}
+
+func TestNewTLS_ACMEWithoutFallbackFailsHandshake(t *testing.T) {
+	cfg := serverconf.Config{ACME: serverconf.ACMEConfig{
+		Enabled:      true,
+		Domains:      []string{"collector.example.com"},
+		DirectoryURL: unreachableDirectory(t),
+		CacheDir:     t.TempDir(),
+	}}
+	tlsCfg, err := cfg.NewTLS(context.Background(), nil, zap.NewNop())
+	require.NoError(t, err)
+
+	raw, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	lis := tlsCfg.WrapListener(raw)
+	defer func() { _ = lis.Close() }()
+
+	_, err = handshake(t, lis, "collector.example.com", nil)
+	assert.Error(t, err)
+}
+
+func TestNewTLS_HTTPChallengeServer(t *testing.T) {
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	endpoint := l.Addr().String()
+	require.NoError(t, l.Close())
+
+	cfg := serverconf.Config{ACME: serverconf.ACMEConfig{
+		Enabled:               true,
+		Domains:               []string{"collector.example.com"},
+		DirectoryURL:          unreachableDirectory(t),
+		CacheDir:              t.TempDir(),
+		HTTPChallengeEndpoint: endpoint,
+	}}
+	tlsCfg, err := cfg.NewTLS(context.Background(), nil, zap.NewNop())
+	require.NoError(t, err)
+
+	client := &http.Client{
+		Timeout:       5 * time.Second,
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+	get := func(host, path string) int {
+		req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("http://%s%s", endpoint, path), http.NoBody)
+		require.NoError(t, err)
+		req.Host = host
+		resp, err := client.Do(req)
+		require.NoError(t, err)
+		_ = resp.Body.Close()
+		return resp.StatusCode
+	}
+
+	assert.Equal(t, http.StatusNotFound, get("collector.example.com", "/.well-known/acme-challenge/unknown"))
+	assert.Equal(t, http.StatusForbidden, get("other.example.com", "/.well-known/acme-challenge/unknown"))
+	assert.Equal(t, http.StatusFound, get("collector.example.com", "/v1/traces"), "other paths redirect to HTTPS")
+
+	require.NoError(t, tlsCfg.Shutdown(context.Background()))
+}
@@ -0,0 +1,193 @@
+//go:build linux || darwin
+
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package serverconf_test
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/confmap"
+	"go.uber.org/zap"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/serverconf"
+)
+
+// handoffHelperEnv carries "address|socket path" to the helper process.
+const handoffHelperEnv = "TFO_HANDOFF_HELPER"
+
+// TestHandoffHelperProcess is the previous collector of
+// TestTakeOverListeners: it listens, serves the handoff socket and exits
+// shortly after handing over, answering "old" to clients until then.
+func TestHandoffHelperProcess(t *testing.T) {
+	arg := os.Getenv(handoffHelperEnv)
+	if arg == "" {
+		t.Skip("helper process")
+	}
+	addr, path, _ := strings.Cut(arg, "|")
+	lis, err := serverconf.Listen("tcp", addr)
+	if err != nil {
+		fmt.Println("error:", err)
+		os.Exit(1)
+	}
+	go func() {
+		for {
+			conn, err := lis.Accept()
+			if err != nil {
+				return
+			}
+			_, _ = conn.Write([]byte("old\n"))
+			_ = conn.Close()
+		}
+	}()
+	_, err = serverconf.ServeHandoff(path, func(int) {
+		go func() {
+			time.Sleep(200 * time.Millisecond)
+			os.Exit(0)
+		}()
+	})
+	if err != nil {
+		fmt.Println("error:", err)
+		os.Exit(1)
+	}
+	fmt.Println("ready")
+	select {}
+}
+
+func TestTakeOverListeners(t *testing.T) {
+	addr := freeAddr(t)
+	path := filepath.Join(t.TempDir(), "handoff.sock")
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestHandoffHelperProcess$")
+	cmd.Env = append(os.Environ(), handoffHelperEnv+"="+addr+"|"+path)
+	stdout, err := cmd.StdoutPipe()
+	require.NoError(t, err)
+	require.NoError(t, cmd.Start())
+	t.Cleanup(func() {
+		_ = cmd.Process.Kill()
+		_ = cmd.Wait()
+	})
+	line, err := bufio.NewReader(stdout).ReadString('\n')
+	require.NoError(t, err)
+	require.Equal(t, "ready\n", line)
+
+	conn, err := net.DialTimeout("tcp", addr, time.Second)
+	require.NoError(t, err)
+	reply, err := bufio.NewReader(conn).ReadString('\n')
+	require.NoError(t, err)
+	assert.Equal(t, "old\n", reply)
+	_ = conn.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	n, err := serverconf.TakeOverListeners(ctx, path)
+	require.NoError(t, err, "waits for the previous process to exit")
+	assert.Equal(t, 1, n)
+
+	// The previous process is gone but the socket is open here: a client
+	// waits in the backlog until the new server listens.
+	client, err := net.DialTimeout("tcp", addr, time.Second)
+	require.NoError(t, err)
+	defer func() { _ = client.Close() }()
+
+	lis, err := serverconf.Listen("tcp", addr)
+	require.NoError(t, err)
+	defer func() { _ = lis.Close() }()
+	accepted, err := lis.Accept()
+	require.NoError(t, err)
+	defer func() { _ = accepted.Close() }()
+	assert.Equal(t, client.LocalAddr().String(), accepted.RemoteAddr().String())
+}
+
+func TestTakeOverListeners_NoPreviousProcess(t *testing.T) {
+	dir := t.TempDir()
+	n, err := serverconf.TakeOverListeners(context.Background(), filepath.Join(dir, "handoff.sock"))
+	require.NoError(t, err)
+	assert.Zero(t, n)
+
+	// A socket file left by a process that exited.
+	server, err := serverconf.ServeHandoff(filepath.Join(dir, "stale.sock"), nil)
+	require.NoError(t, err)
+	require.NoError(t, server.Close())
+	n, err = serverconf.TakeOverListeners(context.Background(), filepath.Join(dir, "stale.sock"))
+	require.NoError(t, err)
+	assert.Zero(t, n)
+}
+
+func TestServeHandoff_ReportsHandoff(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state", "handoff.sock")
+	handed := make(chan int, 1)
+	server, err := serverconf.ServeHandoff(path, func(n int) { handed <- n })
+	require.NoError(t, err)
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	// Closing the server stands in for the previous process exiting.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	taken := make(chan error, 1)
+	go func() {
+		_, err := serverconf.TakeOverListeners(ctx, path)
+		taken <- err
+	}()
+	select {
+	case <-handed:
+	case <-time.After(5 * time.Second):
+		t.Fatal("handoff not reported")
+	}
+	require.NoError(t, server.Close())
+	require.NoError(t, <-taken)
+}
+
+func TestLoadHandoffConfig(t *testing.T) {
+	cfg, err := serverconf.LoadHandoffConfig(confmap.New())
+	require.NoError(t, err)
+	assert.Equal(t, serverconf.DefaultHandoffConfig(), cfg)
+	assert.Equal(t, filepath.Join(serverconf.DefaultHandoffDirectory, "handoff.sock"), cfg.SocketPath())
+
+	_, err = serverconf.LoadHandoffConfig(confmap.NewFromStringMap(map[string]any{
+		"listener_handoff": map[string]any{"enabled": true, "directory": ""},
+	}))
+	require.EqualError(t, err, "listener_handoff.directory must not be empty")
+
+	_, err = serverconf.LoadHandoffConfig(confmap.NewFromStringMap(map[string]any{
+		"listener_handoff": map[string]any{"enabled": true, "timeout": "0s"},
+	}))
+	require.EqualError(t, err, "listener_handoff.timeout must be positive")
+}
+
+func TestHandoffConverter_Disabled(t *testing.T) {
+	conf := confmap.NewFromStringMap(map[string]any{
+		"listener_handoff": map[string]any{"enabled": false, "directory": t.TempDir()},
+		"receivers":        map[string]any{"otlp": nil},
+	})
+	c := serverconf.NewHandoffConverterFactory().Create(confmap.ConverterSettings{Logger: zap.NewNop()})
+	require.NoError(t, c.Convert(context.Background(), conf))
+	assert.False(t, conf.IsSet("listener_handoff"))
+	assert.True(t, conf.IsSet("receivers"))
+}
@@ -0,0 +1,206 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package tlsbootstrap_test
+
+import (
+	"bytes"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/tlsbootstrap"
+)
+
+func readCert(t *testing.T, path string) *x509.Certificate {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	block, _ := pem.Decode(data)
+	require.NotNil(t, block)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+	return cert
+}
+
+func caPool(t *testing.T, path string) *x509.CertPool {
+	t.Helper()
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	pool := x509.NewCertPool()
+	require.True(t, pool.AppendCertsFromPEM(data))
+	return pool
+}
+
+func TestRun_IssuesCertificates(t *testing.T) {
+	dir := t.TempDir()
+	report, err := tlsbootstrap.Run(tlsbootstrap.Options{
+		Dir:      dir,
+		Hosts:    []string{"collector.lab.local", "10.0.0.5"},
+		Clients:  []string{"edge-01"},
+		Validity: 48 * time.Hour,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"edge-01"}, report.Clients)
+	require.Len(t, report.Files, 7)
+	for _, f := range report.Files {
+		assert.True(t, f.Created, f.Path)
+		assert.FileExists(t, f.Path)
+	}
+
+	roots := caPool(t, filepath.Join(dir, tlsbootstrap.CACertFile))
+	ca := readCert(t, filepath.Join(dir, tlsbootstrap.CACertFile))
+	assert.True(t, ca.IsCA)
+
+	server := readCert(t, filepath.Join(dir, tlsbootstrap.ServerCertFile))
+	_, err = server.Verify(x509.VerifyOptions{DNSName: "collector.lab.local", Roots: roots})
+	assert.NoError(t, err)
+	_, err = server.Verify(x509.VerifyOptions{DNSName: "10.0.0.5", Roots: roots})
+	assert.NoError(t, err)
+	assert.WithinDuration(t, time.Now().Add(48*time.Hour), server.NotAfter, time.Minute)
+
+	client := readCert(t, filepath.Join(dir, tlsbootstrap.ClientsDir, "edge-01", tlsbootstrap.ClientCertFile))
+	assert.Equal(t, "edge-01", client.Subject.CommonName)
+	_, err = client.Verify(x509.VerifyOptions{Roots: roots, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}})
+	assert.NoError(t, err)
+	_, err = client.Verify(x509.VerifyOptions{Roots: roots, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth}})
+	assert.Error(t, err, "client certificates cannot serve")
+
+	for _, key := range []string{
+		filepath.Join(dir, tlsbootstrap.CAKeyFile),
+		filepath.Join(dir, tlsbootstrap.ServerKeyFile),
+		filepath.Join(dir, tlsbootstrap.ClientsDir, "edge-01", tlsbootstrap.ClientKeyFile),
+	} {
+		info, err := os.Stat(key)
+		require.NoError(t, err)
+		assert.Equal(t, os.FileMode(0o600), info.Mode().Perm(), key)
+	}
+	bundleCA, err := os.ReadFile(filepath.Join(dir, tlsbootstrap.ClientsDir, "edge-01", tlsbootstrap.CACertFile))
+	require.NoError(t, err)
+	caPEM, err := os.ReadFile(filepath.Join(dir, tlsbootstrap.CACertFile))
+	require.NoError(t, err)
+	assert.Equal(t, caPEM, bundleCA)
+}
+
+func TestRun_MutualTLSHandshake(t *testing.T) {
+	dir := t.TempDir()
+	_, err := tlsbootstrap.Run(tlsbootstrap.Options{Dir: dir, Hosts: []string{"localhost"}, Clients: []string{"edge-01"}})
+	require.NoError(t, err)
+
+	serverCert, err := tls.LoadX509KeyPair(filepath.Join(dir, tlsbootstrap.ServerCertFile), filepath.Join(dir, tlsbootstrap.ServerKeyFile))
+	require.NoError(t, err)
+	bundle := filepath.Join(dir, tlsbootstrap.ClientsDir, "edge-01")
+	clientCert, err := tls.LoadX509KeyPair(filepath.Join(bundle, tlsbootstrap.ClientCertFile), filepath.Join(bundle, tlsbootstrap.ClientKeyFile))
+	require.NoError(t, err)
+
+	serverConn, clientConn := net.Pipe()
+	defer func() { _ = serverConn.Close() }()
+	defer func() { _ = clientConn.Close() }()
+	server := tls.Server(serverConn, &tls.Config{
+		Certificates: []tls.Certificate{serverCert},
+		ClientCAs:    caPool(t, filepath.Join(dir, tlsbootstrap.CACertFile)),
+		ClientAuth:   tls.RequireAndVerifyClientCert,
+		MinVersion:   tls.VersionTLS13,
+	})
+	client := tls.Client(clientConn, &tls.Config{
+		Certificates: []tls.Certificate{clientCert},
+		RootCAs:      caPool(t, filepath.Join(bundle, tlsbootstrap.CACertFile)),
+		ServerName:   "localhost",
+		MinVersion:   tls.VersionTLS13,
+	})
+
+	errs := make(chan error, 1)
+	go func() { errs <- server.Handshake() }()
+	require.NoError(t, client.Handshake())
+	require.NoError(t, <-errs)
+	assert.Equal(t, "edge-01", server.ConnectionState().PeerCertificates[0].Subject.CommonName)
+}
+
+func TestRun_ReusesCA(t *testing.T) {
+	dir := t.TempDir()
+	_, err := tlsbootstrap.Run(tlsbootstrap.Options{Dir: dir, Hosts: []string{"localhost"}, Clients: []string{"edge-01"}})
+	require.NoError(t, err)
+	caPEM, err := os.ReadFile(filepath.Join(dir, tlsbootstrap.CACertFile))
+	require.NoError(t, err)
+	serverPEM, err := os.ReadFile(filepath.Join(dir, tlsbootstrap.ServerCertFile))
+	require.NoError(t, err)
+
+	// A second run adds a sender and keeps everything else.
+	report, err := tlsbootstrap.Run(tlsbootstrap.Options{Dir: dir, Hosts: []string{"localhost"}, Clients: []string{"edge-01", "edge-02"}})
+	require.NoError(t, err)
+	created := map[string]bool{}
+	for _, f := range report.Files {
+		rel, err := filepath.Rel(dir, f.Path)
+		require.NoError(t, err)
+		created[rel] = f.Created
+	}
+	assert.False(t, created["ca.crt"])
+	assert.False(t, created["server.crt"])
+	assert.False(t, created["clients/edge-01/client.crt"])
+	assert.False(t, created["clients/edge-01/ca.crt"])
+	assert.True(t, created["clients/edge-02/client.crt"])
+
+	roots := caPool(t, filepath.Join(dir, tlsbootstrap.CACertFile))
+	edge02 := readCert(t, filepath.Join(dir, tlsbootstrap.ClientsDir, "edge-02", tlsbootstrap.ClientCertFile))
+	_, err = edge02.Verify(x509.VerifyOptions{Roots: roots, KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth}})
+	assert.NoError(t, err)
+
+	// Force reissues the certificates but not the CA.
+	_, err = tlsbootstrap.Run(tlsbootstrap.Options{Dir: dir, Hosts: []string{"localhost"}, Force: true})
+	require.NoError(t, err)
+	after, err := os.ReadFile(filepath.Join(dir, tlsbootstrap.CACertFile))
+	require.NoError(t, err)
+	assert.Equal(t, caPEM, after)
+	reissued, err := os.ReadFile(filepath.Join(dir, tlsbootstrap.ServerCertFile))
+	require.NoError(t, err)
+	assert.NotEqual(t, serverPEM, reissued)
+}
+
+func TestRun_Errors(t *testing.T) {
+	dir := t.TempDir()
+	_, err := tlsbootstrap.Run(tlsbootstrap.Options{Dir: dir, Clients: []string{"../escape"}})
+	assert.ErrorContains(t, err, `client name "../escape"`)
+
+	_, err = tlsbootstrap.Run(tlsbootstrap.Options{Dir: dir, Clients: []string{"edge", "edge"}})
+	assert.ErrorContains(t, err, "listed twice")
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, tlsbootstrap.CACertFile), []byte("x"), 0o644))
+	_, err = tlsbootstrap.Run(tlsbootstrap.Options{Dir: dir})
+	assert.ErrorContains(t, err, "only one of ca.crt and ca.key")
+}
+
+func TestReport_Print(t *testing.T) {
+	dir := t.TempDir()
+	report, err := tlsbootstrap.Run(tlsbootstrap.Options{Dir: dir, Hosts: []string{"localhost"}, Clients: []string{"edge-01"}})
+	require.NoError(t, err)
+
+	var out bytes.Buffer
+	report.Print(&out)
+	assert.Contains(t, out.String(), "created  clients/edge-01/client.crt")
+	assert.Contains(t, out.String(), "client_ca_file: "+filepath.Join(dir, tlsbootstrap.CACertFile))
+	assert.Contains(t, out.String(), "cert_file: /etc/tfo-collector/certs/client.crt")
+
+	out.Reset()
+	report.Clients = nil
+	report.Print(&out)
+	assert.NotContains(t, out.String(), "on each sender")
+}
@@ -0,0 +1,267 @@
+// TelemetryFlow Collector - AI-Powered Observability & Incident Response Management (IRM) Platform
+// Copyright (c) 2024-2026 Telemetri Data Indonesia. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package watchdog_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+
+	"github.com/telemetryflow/telemetryflow-collector/pkg/selfmetrics"
+	"github.com/telemetryflow/telemetryflow-collector/pkg/watchdog"
+)
+
+func testConfig() watchdog.Config {
+	return watchdog.Config{
+		Enabled:       true,
+		CheckInterval: 10 * time.Millisecond,
+		StallTimeout:  50 * time.Millisecond,
+		MaxRestarts:   2,
+	}
+}
+
+type recorder struct {
+	mu        sync.Mutex
+	wedged    []watchdog.Incident
+	recovered []string
+}
+
+func (r *recorder) hooks() watchdog.Hooks {
+	return watchdog.Hooks{
+		OnWedged: func(i watchdog.Incident) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.wedged = append(r.wedged, i)
+		},
+		OnRecovered: func(name string) {
+			r.mu.Lock()
+			defer r.mu.Unlock()
+			r.recovered = append(r.recovered, name)
+		},
+	}
+}
+
+func (r *recorder) counts() (int, int) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return len(r.wedged), len(r.recovered)
+}
+
+func newWatchdog(t *testing.T, cfg watchdog.Config, rec *recorder) *watchdog.Watchdog {
+	t.Helper()
+	wd, err := watchdog.New(cfg, componenttest.NewNopTelemetrySettings(),
+		selfmetrics.Receiver(component.MustNewID("tfootlp")), rec.hooks())
+	require.NoError(t, err)
+	return wd
+}
+
+func TestConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		mutate  func(cfg *watchdog.Config)
+		wantErr string
+	}{
+		{name: "defaults", mutate: func(*watchdog.Config) {}},
+		{
+			name:   "disabled ignores invalid values",
+			mutate: func(cfg *watchdog.Config) { cfg.Enabled = false; cfg.StallTimeout = -1 },
+		},
+		{
+			name:    "zero check interval",
+			mutate:  func(cfg *watchdog.Config) { cfg.CheckInterval = 0 },
+			wantErr: "check_interval",
+		},
+		{
+			name:    "zero stall timeout",
+			mutate:  func(cfg *watchdog.Config) { cfg.StallTimeout = 0 },
+			wantErr: "stall_timeout must be positive",
+		},
+		{
+			name:    "stall timeout shorter than check interval",
+			mutate:  func(cfg *watchdog.Config) { cfg.StallTimeout = time.Second; cfg.CheckInterval = time.Minute },
+			wantErr: "must not be shorter",
+		},
+		{
+			name:    "negative max restarts",
+			mutate:  func(cfg *watchdog.Config) { cfg.MaxRestarts = -1 },
+			wantErr: "max_restarts",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := watchdog.NewDefaultConfig()
+			cfg.Enabled = true
+			tt.mutate(&cfg)
+			err := cfg.Validate()
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestNewDefaultConfig(t *testing.T) {
+	cfg := watchdog.NewDefaultConfig()
+	assert.False(t, cfg.Enabled)
+	assert.Equal(t, watchdog.DefaultCheckInterval, cfg.CheckInterval)
+	assert.Equal(t, watchdog.DefaultStallTimeout, cfg.StallTimeout)
+	assert.Equal(t, watchdog.DefaultMaxRestarts, cfg.MaxRestarts)
+}
+
+func TestHeartbeat_NilIsNoop(t *testing.T) {
+	var h *watchdog.Heartbeat
+	h.Begin()
+	h.End()
+	assert.Zero(t, h.Pending())
+}
+
+func TestWatchdog_IdleIsNotWedged(t *testing.T) {
+	rec := &recorder{}
+	wd := newWatchdog(t, testConfig(), rec)
+	restarts := 0
+	wd.Register("idle", func(context.Context) error { restarts++; return nil })
+
+	time.Sleep(60 * time.Millisecond)
+	wd.Check(context.Background())
+
+	wedged, _ := rec.counts()
+	assert.Zero(t, wedged)
+	assert.Zero(t, restarts)
+}
+
+func TestWatchdog_ProgressIsNotWedged(t *testing.T) {
+	rec := &recorder{}
+	wd := newWatchdog(t, testConfig(), rec)
+	h := wd.Register("busy", nil)
+
+	h.Begin()
+	h.Begin()
+	time.Sleep(60 * time.Millisecond)
+	h.End()
+	wd.Check(context.Background())
+
+	wedged, _ := rec.counts()
+	assert.Zero(t, wedged)
+	assert.Equal(t, int64(1), h.Pending())
+}
+
+func TestWatchdog_RestartsWedgedAndRecovers(t *testing.T) {
+	rec := &recorder{}
+	wd := newWatchdog(t, testConfig(), rec)
+	restarts := 0
+	h := wd.Register("stuck", func(context.Context) error { restarts++; return nil })
+
+	h.Begin()
+	time.Sleep(60 * time.Millisecond)
+	wd.Check(context.Background())
+
+	require.Equal(t, 1, restarts)
+	rec.mu.Lock()
+	require.Len(t, rec.wedged, 1)
+	incident := rec.wedged[0]
+	rec.mu.Unlock()
+	assert.Equal(t, "stuck", incident.Component)
+	assert.Equal(t, int64(1), incident.Pending)
+	assert.True(t, incident.Restarted)
+	assert.NoError(t, incident.Err)
+	assert.GreaterOrEqual(t, incident.StalledFor, 50*time.Millisecond)
+
+	// The stall clock restarts after a restart.
+	wd.Check(context.Background())
+	assert.Equal(t, 1, restarts)
+
+	h.End()
+	wd.Check(context.Background())
+	_, recovered := rec.counts()
+	assert.Equal(t, 1, recovered)
+}
+
+func TestWatchdog_MaxRestarts(t *testing.T) {
+	rec := &recorder{}
+	cfg := testConfig()
+	cfg.StallTimeout = 20 * time.Millisecond
+	cfg.CheckInterval = 5 * time.Millisecond
+	wd := newWatchdog(t, cfg, rec)
+	restartErr := errors.New("restart failed")
+	restarts := 0
+	h := wd.Register("stuck", func(context.Context) error { restarts++; return restartErr })
+
+	h.Begin()
+	for range 5 {
+		time.Sleep(25 * time.Millisecond)
+		wd.Check(context.Background())
+	}
+
+	assert.Equal(t, cfg.MaxRestarts, restarts)
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	require.Len(t, rec.wedged, cfg.MaxRestarts)
+	assert.ErrorIs(t, rec.wedged[0].Err, restartErr)
+}
+
+func TestWatchdog_ReportOnly(t *testing.T) {
+	rec := &recorder{}
+	cfg := testConfig()
+	cfg.MaxRestarts = 0
+	wd := newWatchdog(t, cfg, rec)
+	restarts := 0
+	h := wd.Register("stuck", func(context.Context) error { restarts++; return nil })
+
+	h.Begin()
+	time.Sleep(60 * time.Millisecond)
+	wd.Check(context.Background())
+	wd.Check(context.Background())
+
+	assert.Zero(t, restarts)
+	rec.mu.Lock()
+	defer rec.mu.Unlock()
+	require.Len(t, rec.wedged, 1, "an exhausted loop is reported once")
+	assert.False(t, rec.wedged[0].Restarted)
+}
+
+func TestWatchdog_StartShutdown(t *testing.T) {
+	rec := &recorder{}
+	wd := newWatchdog(t, testConfig(), rec)
+	restarted := make(chan struct{}, 1)
+	h := wd.Register("stuck", func(context.Context) error {
+		select {
+		case restarted <- struct{}{}:
+		default:
+		}
+		return nil
+	})
+
+	wd.Start(context.Background())
+	h.Begin()
+
+	select {
+	case <-restarted:
+	case <-time.After(2 * time.Second):
+		t.Fatal("watchdog did not restart wedged loop")
+	}
+	wd.Shutdown(context.Background())
+	wd.Shutdown(context.Background())
+}